@@ -903,6 +903,10 @@ func (fb *filterBackend) GetMaxBlocksPerRequest() int64 {
 	return eth.DefaultSettings.MaxBlocksPerRequest
 }
 
+func (fb *filterBackend) GetMaxLogsPerRequest() int {
+	return eth.DefaultSettings.MaxLogsPerRequest
+}
+
 func (fb *filterBackend) ChainDb() ethdb.Database { return fb.db }
 
 func (fb *filterBackend) EventMux() *event.TypeMux { panic("not supported") }
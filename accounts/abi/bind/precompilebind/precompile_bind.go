@@ -31,13 +31,19 @@
 package precompilebind
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"text/template"
+	"unicode"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	precompilecontract "github.com/ava-labs/subnet-evm/precompile/contract"
 )
 
 var errNoAnonymousEvent = errors.New("event type must not be anonymous")
@@ -51,6 +57,13 @@ const (
 	ConfigTestFileName   = "config_test.go"
 )
 
+// Directories, relative to the Solidity contracts folder, that the generated
+// Solidity files belong to.
+const (
+	SolidityInterfacesDir = "interfaces"
+	SolidityTestsDir      = "test"
+)
+
 type PrecompileBindFile struct {
 	// FileName is the name of the file to be generated.
 	FileName string
@@ -125,6 +138,247 @@ func PrecompileBind(types []string, abiData string, bytecodes []string, fsigs []
 	return result, nil
 }
 
+// PrecompileScaffold contains the non-Go files generated for a precompile.
+type PrecompileScaffold struct {
+	// Interface is the Solidity interface of the precompile, which belongs
+	// in [SolidityInterfacesDir].
+	Interface PrecompileBindFile
+	// Test is the DS-Test contract skeleton of the precompile, which belongs
+	// in [SolidityTestsDir].
+	Test PrecompileBindFile
+	// Genesis is a genesis with the precompile enabled, which belongs in
+	// tests/precompile/genesis.
+	Genesis PrecompileBindFile
+}
+
+// PrecompileBindScaffold generates the Solidity interface, the DS-Test contract
+// skeleton and the genesis for a precompiled contract of type [typ].
+// The generated file names are derived from [typ] and [pkg].
+func PrecompileBindScaffold(typ string, pkg string, abiData string) (*PrecompileScaffold, error) {
+	if err := verifyABI(abiData); err != nil {
+		return nil, err
+	}
+	evmABI, err := abi.JSON(strings.NewReader(abiData))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := newSolidityData(typ, evmABI)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaceSol, err := executeTemplate(tmplSourcePrecompileInterfaceSol, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate solidity interface: %w", err)
+	}
+	testSol, err := executeTemplate(tmplSourcePrecompileTestSol, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate solidity test: %w", err)
+	}
+	genesis, err := executeTemplate(tmplSourcePrecompileGenesis, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate genesis: %w", err)
+	}
+
+	return &PrecompileScaffold{
+		Interface: NewPrecompileBindFile("I"+data.Type+".sol", interfaceSol, false),
+		Test:      NewPrecompileBindFile(data.Type+"Test.sol", testSol, true),
+		Genesis:   NewPrecompileBindFile(pkg+".json", genesis, false),
+	}, nil
+}
+
+// newSolidityData converts the parsed ABI into the data required by the
+// Solidity and genesis templates.
+func newSolidityData(typ string, evmABI abi.ABI) (*tmplSolidityData, error) {
+	typeName := abi.ToCamelCase(typ)
+	data := &tmplSolidityData{
+		Type:         typeName,
+		InstanceName: strings.ToLower(typeName[:1]) + typeName[1:],
+		AddressName:  toUpperSnakeCase(typeName) + "_ADDRESS",
+		ConfigKey:    strings.ToLower(typeName[:1]) + typeName[1:] + "Config",
+	}
+
+	methods := make(map[string]abi.Method, len(evmABI.Methods))
+	for name, method := range evmABI.Methods {
+		methods[name] = method
+	}
+	events := make(map[string]abi.Event, len(evmABI.Events))
+	for name, event := range evmABI.Events {
+		events[name] = event
+	}
+	// precompileVersion and functionGasCost are provided for every precompile,
+	// so they are declared by the template (or IAllowList) even if the ABI
+	// declares them.
+	delete(methods, precompilecontract.PrecompileVersionFuncName)
+	delete(methods, precompilecontract.FunctionGasCostFuncName)
+	funcs := make(map[string]*bind.TmplMethod, len(methods))
+	for name := range methods {
+		funcs[name] = nil
+	}
+	if allowListEnabled(funcs) {
+		data.AllowList = true
+		// these are declared by IAllowList.
+		for name := range allowlist.AllowListABI.Methods {
+			delete(methods, name)
+		}
+		for name := range allowlist.AllowListABI.Events {
+			delete(events, name)
+		}
+	}
+
+	structs := newSolidityStructs()
+	for _, name := range sortedKeys(events) {
+		event := events[name]
+		params := make([]string, 0, len(event.Inputs))
+		for _, input := range event.Inputs {
+			param := structs.typeName(input.Type)
+			if input.Indexed {
+				param += " indexed"
+			}
+			params = append(params, appendName(param, input.Name))
+		}
+		data.Events = append(data.Events, &tmplSolidityEvent{
+			Name:   event.RawName,
+			Params: strings.Join(params, ", "),
+		})
+	}
+	for _, name := range sortedKeys(methods) {
+		method := methods[name]
+		data.Funcs = append(data.Funcs, &tmplSolidityMethod{
+			Name:       method.RawName,
+			Params:     structs.declarations(method.Inputs, "calldata"),
+			Mutability: solidityMutability(method),
+			Returns:    structs.declarations(method.Outputs, "memory"),
+		})
+	}
+	data.Structs = structs.list
+	return data, nil
+}
+
+// solidityStructs collects the struct definitions of the tuple types
+// referenced by an ABI.
+type solidityStructs struct {
+	names map[string]string // tuple signature -> struct name
+	list  []*tmplSolidityStruct
+}
+
+func newSolidityStructs() *solidityStructs {
+	return &solidityStructs{names: make(map[string]string)}
+}
+
+// typeName returns the Solidity type name of [t], defining the struct types
+// it references.
+func (s *solidityStructs) typeName(t abi.Type) string {
+	switch t.T {
+	case abi.SliceTy:
+		return s.typeName(*t.Elem) + "[]"
+	case abi.ArrayTy:
+		return fmt.Sprintf("%s[%d]", s.typeName(*t.Elem), t.Size)
+	case abi.TupleTy:
+		return s.structName(t)
+	default:
+		return t.String()
+	}
+}
+
+func (s *solidityStructs) structName(t abi.Type) string {
+	if name, ok := s.names[t.String()]; ok {
+		return name
+	}
+	name := t.TupleRawName
+	if name == "" {
+		name = fmt.Sprintf("Struct%d", len(s.list))
+	}
+	s.names[t.String()] = name
+	// nested structs are appended while resolving the fields, so they are
+	// defined before the struct that references them.
+	fields := make([]string, len(t.TupleElems))
+	for i, elem := range t.TupleElems {
+		fields[i] = appendName(s.typeName(*elem), t.TupleRawNames[i])
+	}
+	s.list = append(s.list, &tmplSolidityStruct{Name: name, Fields: fields})
+	return name
+}
+
+// declarations returns the comma separated Solidity declarations of [args],
+// using [location] as the data location of reference types.
+func (s *solidityStructs) declarations(args abi.Arguments, location string) string {
+	params := make([]string, 0, len(args))
+	for _, arg := range args {
+		param := s.typeName(arg.Type)
+		if isSolidityReferenceType(arg.Type) {
+			param += " " + location
+		}
+		params = append(params, appendName(param, arg.Name))
+	}
+	return strings.Join(params, ", ")
+}
+
+func isSolidityReferenceType(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	default:
+		return false
+	}
+}
+
+func solidityMutability(method abi.Method) string {
+	switch {
+	case method.StateMutability == "view" || method.StateMutability == "pure" || method.StateMutability == "payable":
+		return method.StateMutability
+	case method.StateMutability == "" && method.Constant:
+		return "view"
+	case method.StateMutability == "" && method.Payable:
+		return "payable"
+	default:
+		return ""
+	}
+}
+
+func appendName(decl string, name string) string {
+	if name == "" {
+		return decl
+	}
+	return decl + " " + name
+}
+
+// toUpperSnakeCase converts a camel case name such as "HelloWorld" into
+// "HELLO_WORLD".
+func toUpperSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func executeTemplate(source string, data interface{}) (string, error) {
+	tmpl, err := template.New("").Parse(source)
+	if err != nil {
+		return "", err
+	}
+	buffer := new(bytes.Buffer)
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
 // createPrecompileHook creates a bind hook for precompiled contracts.
 func createPrecompileHook(abifilename string, template string) bind.BindHook {
 	return func(lang bind.Lang, pkg string, types []string, contracts map[string]*bind.TmplContract, structs map[string]*bind.TmplStruct) (interface{}, string, error) {
@@ -147,6 +401,11 @@ func createPrecompileHook(abifilename string, template string) bind.BindHook {
 		for k, v := range contract.Calls {
 			funcs[k] = v
 		}
+		// precompileVersion is provided by the template and functionGasCost by the
+		// framework for every precompile, so they are not generated even if the ABI
+		// declares them.
+		delete(funcs, precompilecontract.PrecompileVersionFuncName)
+		delete(funcs, precompilecontract.FunctionGasCostFuncName)
 		isAllowList := allowListEnabled(funcs)
 		if isAllowList {
 			// these functions are not needed for binded contract.
@@ -178,6 +437,11 @@ func createPrecompileHook(abifilename string, template string) bind.BindHook {
 
 func allowListEnabled(funcs map[string]*bind.TmplMethod) bool {
 	for key := range allowlist.AllowListABI.Methods {
+		// Stromboli functions are optional so ABIs generated before Stromboli are still
+		// detected as allow list precompiles.
+		if slices.Contains(allowlist.StromboliFunctionNames, key) {
+			continue
+		}
 		if _, ok := funcs[key]; !ok {
 			return false
 		}
@@ -27,6 +27,7 @@
 package precompilebind
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -35,6 +36,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -707,3 +709,85 @@ func TestPrecompileBind(t *testing.T) {
 		t.Fatalf("failed to run binding test: %v\n%s", err, out)
 	}
 }
+
+func TestPrecompileBindScaffold(t *testing.T) {
+	for _, tt := range bindTests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaffold, err := PrecompileBindScaffold(tt.name, strings.ToLower(tt.name), tt.abi)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			typeName := abi.ToCamelCase(tt.name)
+			require.Equal(t, "I"+typeName+".sol", scaffold.Interface.FileName)
+			require.Equal(t, typeName+"Test.sol", scaffold.Test.FileName)
+			require.Equal(t, strings.ToLower(tt.name)+".json", scaffold.Genesis.FileName)
+
+			require.Contains(t, scaffold.Interface.Content, "interface I"+typeName)
+			require.Contains(t, scaffold.Test.Content, "contract "+typeName+"Test")
+			if tt.expectAllowlist {
+				require.Contains(t, scaffold.Interface.Content, "is IAllowList")
+				require.NotContains(t, scaffold.Interface.Content, "function readAllowList(")
+				require.Contains(t, scaffold.Test.Content, "is AllowListTest")
+			} else {
+				require.NotContains(t, scaffold.Interface.Content, "IAllowList")
+				require.Contains(t, scaffold.Interface.Content, "function precompileVersion() external view returns (uint256 version);")
+				require.Contains(t, scaffold.Test.Content, "is DSTest")
+			}
+
+			var genesis struct {
+				Config map[string]json.RawMessage `json:"config"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(scaffold.Genesis.Content), &genesis))
+			var config struct {
+				BlockTimestamp *uint64  `json:"blockTimestamp"`
+				AdminAddresses []string `json:"adminAddresses"`
+			}
+			configKey := strings.ToLower(typeName[:1]) + typeName[1:] + "Config"
+			require.Contains(t, genesis.Config, configKey)
+			require.NoError(t, json.Unmarshal(genesis.Config[configKey], &config))
+			require.NotNil(t, config.BlockTimestamp)
+			require.Equal(t, tt.expectAllowlist, len(config.AdminAddresses) > 0)
+		})
+	}
+}
+
+func TestPrecompileBindScaffoldInterface(t *testing.T) {
+	abiData := `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"bytes","name":"data","type":"bytes"}],"name":"Stored","type":"event"},{"inputs":[{"components":[{"internalType":"uint256","name":"id","type":"uint256"},{"internalType":"string","name":"label","type":"string"}],"internalType":"struct IStore.Item[]","name":"items","type":"tuple[]"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"store","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"uint256","name":"id","type":"uint256"}],"name":"load","outputs":[{"components":[{"internalType":"uint256","name":"id","type":"uint256"},{"internalType":"string","name":"label","type":"string"}],"internalType":"struct IStore.Item","name":"item","type":"tuple"},{"internalType":"uint8[2]","name":"flags","type":"uint8[2]"}],"stateMutability":"view","type":"function"}]`
+
+	scaffold, err := PrecompileBindScaffold("Store", "store", abiData)
+	require.NoError(t, err)
+
+	require.Equal(t, `// Code generated
+// This file is a generated precompile interface. Review the declarations and
+// add documentation comments for each event and function.
+
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IStore {
+  struct IStoreItem {
+    uint256 id;
+    string label;
+  }
+
+  event Stored(address indexed sender, bytes data);
+
+  function load(uint256 id) external view returns (IStoreItem memory item, uint8[2] memory flags);
+
+  function store(IStoreItem[] calldata items, bytes calldata data) external payable;
+
+  // Returns the interface version of the precompile.
+  function precompileVersion() external view returns (uint256 version);
+
+  // Returns the fixed gas cost of the function with [selector].
+  function functionGasCost(bytes4 selector) external view returns (uint256 gasCost);
+}
+`, scaffold.Interface.Content)
+	require.Contains(t, scaffold.Test.Content, "address constant STORE_ADDRESS = ")
+	require.Contains(t, scaffold.Test.Content, "IStore store = IStore(STORE_ADDRESS);")
+	require.Contains(t, scaffold.Test.Content, "function step_load() public {")
+	require.Contains(t, scaffold.Test.Content, "function step_store() public {")
+}
@@ -37,6 +37,7 @@ func TestVerify(t *testing.T) {
 			ChainConfig: func() precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			}(),
 			ExpectedError: "",
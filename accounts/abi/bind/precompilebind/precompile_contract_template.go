@@ -343,8 +343,9 @@ func create{{.Contract.Type}}Precompile() contract.StatefulPrecompiledContract {
 		if !ok {
 			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
 		}
-		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
 	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
 
 	{{- if .Contract.Fallback}}
 	// Construct the contract with the fallback function.
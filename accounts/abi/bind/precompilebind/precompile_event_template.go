@@ -27,33 +27,21 @@ var (
 {{$structs := .Structs}}
 {{$contract := .Contract}}
 /* NOTE: Events can only be emitted in state-changing functions. So you cannot use events in read-only (view) functions.
-Events are generally emitted at the end of a state-changing function with AddLog method of the StateDB. The AddLog method takes 4 arguments:
-	1. Address of the contract that emitted the event.
-	2. Topic hashes of the event.
-	3. Encoded non-indexed data of the event.
-	4. Block number at which the event was emitted.
-The first argument is the address of the contract that emitted the event.
+Events are generally emitted at the end of a state-changing function with the EmitEvent helper of the contract package,
+which packs the event and adds it as a log of the contract to the current block.
 Topics can be at most 4 elements, the first topic is the hash of the event signature and the rest are the indexed event arguments. There can be at most 3 indexed arguments.
 Topics cannot be fully unpacked into their original values since they're 32-bytes hashes.
 The non-indexed arguments are encoded using the ABI encoding scheme. The non-indexed arguments can be unpacked into their original values.
-Before packing the event, you need to calculate the gas cost of the event. The gas cost of an event is the base gas cost + the gas cost of the topics + the gas cost of the non-indexed data.
+Before emitting the event, you need to charge the gas cost of the event. The gas cost of an event is the base gas cost + the gas cost of the topics + the gas cost of the non-indexed data.
 See Get{EvetName}EventGasCost functions for more details.
 You can use the following code to emit an event in your state-changing precompile functions (generated packer might be different)):
-topics, data, err := PackMyEvent(
-	topic1,
-	topic2,
-	data1,
-	data2,
-)
-if err != nil {
+if remainingGas, err = contract.DeductGas(remainingGas, GetMyEventGasCost(data)); err != nil {
+	return nil, 0, err
+}
+if err := contract.EmitEvent(accessibleState, ContractAddress, MyContractABI, "MyEvent", topic1, topic2, data.Data1, data.Data2); err != nil {
 	return nil, remainingGas, err
 }
-accessibleState.GetStateDB().AddLog(
-	ContractAddress,
-	topics,
-	data,
-	accessibleState.GetBlockContext().Number().Uint64(),
-)
+Alternatively, contract.EmitEventWithGas charges the exact gas cost of the packed event before emitting it.
 */
 {{range .Contract.Events}}
 	{{$event := .}}
@@ -107,7 +95,7 @@ accessibleState.GetStateDB().AddLog(
 	// Pack{{.Normalized.Name}}Event packs the event into the appropriate arguments for {{.Original.Name}}.
 	// It returns topic hashes and the encoded non-indexed data.
 	func Pack{{.Normalized.Name}}Event({{range .Normalized.Inputs}} {{if .Indexed}}{{decapitalise .Name}} {{bindtype .Type $structs}},{{end}}{{end}}{{if $createdDataStruct}} data {{.Normalized.Name}}EventData{{end}}) ([]common.Hash, []byte, error) {
-		return {{$contract.Type}}ABI.PackEvent("{{.Original.Name}}"{{range .Normalized.Inputs}},{{if .Indexed}}{{decapitalise .Name}}{{else}}data.{{capitalise .Name}}{{end}}{{end}})
+		return contract.PackEvent({{$contract.Type}}ABI, "{{.Original.Name}}"{{range .Normalized.Inputs}},{{if .Indexed}}{{decapitalise .Name}}{{else}}data.{{capitalise .Name}}{{end}}{{end}})
 	}
 	{{ if $createdDataStruct }}
 		// Unpack{{.Normalized.Name}}EventData attempts to unpack non-indexed [dataBytes].
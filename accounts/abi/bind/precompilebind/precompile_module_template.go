@@ -26,6 +26,10 @@ var _ contract.Configurator = &configurator{}
 // must be unique across all precompiles.
 const ConfigKey = "{{decapitalise .Contract.Type}}Config"
 
+// Version is the interface version of the precompile returned by precompileVersion.
+// Increment it whenever functions are added to the precompile's interface.
+const Version = 1
+
 // ContractAddress is the defined address of the precompile contract.
 // This should be unique across all precompile contracts.
 // See precompile/registry/registry.go for registered precompile contracts and more information.
@@ -35,8 +39,10 @@ var ContractAddress = common.HexToAddress("{ASUITABLEHEXADDRESS}") // SET A SUIT
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
+	Version:      Version,
 	Contract:     {{.Contract.Type}}Precompile,
 	Configurator: &configurator{},
+	ABI:          &{{.Contract.Type}}ABI,
 }
 
 type configurator struct{}
@@ -0,0 +1,168 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+// tmplSolidityData is the data structure required to fill the Solidity and
+// genesis templates of a precompile.
+type tmplSolidityData struct {
+	Type         string                // Capitalised name of the precompile type
+	InstanceName string                // Name of the interface instance in the test contract
+	AddressName  string                // Name of the Solidity address constant
+	ConfigKey    string                // Key of the precompile config in the genesis
+	AllowList    bool                  // Indicator whether the precompile uses an allow list
+	Structs      []*tmplSolidityStruct // Struct definitions used by the interface
+	Events       []*tmplSolidityEvent  // Events declared by the interface
+	Funcs        []*tmplSolidityMethod // Functions declared by the interface
+}
+
+// tmplSolidityStruct is a Solidity struct definition.
+type tmplSolidityStruct struct {
+	Name   string
+	Fields []string // Field declarations, e.g. "uint256 amount"
+}
+
+// tmplSolidityEvent is a Solidity event declaration.
+type tmplSolidityEvent struct {
+	Name   string
+	Params string // Comma separated parameter declarations
+}
+
+// tmplSolidityMethod is a Solidity function declaration.
+type tmplSolidityMethod struct {
+	Name       string
+	Params     string // Comma separated parameter declarations
+	Mutability string // Empty for nonpayable functions
+	Returns    string // Comma separated return declarations, empty if nothing is returned
+}
+
+// tmplSourcePrecompileInterfaceSol is the Solidity interface template of a precompile.
+const tmplSourcePrecompileInterfaceSol = `// Code generated
+// This file is a generated precompile interface. Review the declarations and
+// add documentation comments for each event and function.
+
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+{{- if .AllowList}}
+import "./IAllowList.sol";
+{{- end}}
+
+interface I{{.Type}}{{if .AllowList}} is IAllowList{{end}} {
+{{- $separate := false}}
+{{- range .Structs}}
+{{- if $separate}}
+{{end}}
+  struct {{.Name}} {
+  {{- range .Fields}}
+    {{.}};
+  {{- end}}
+  }
+{{- $separate = true}}
+{{- end}}
+{{- range .Events}}
+{{- if $separate}}
+{{end}}
+  event {{.Name}}({{.Params}});
+{{- $separate = true}}
+{{- end}}
+{{- range .Funcs}}
+{{- if $separate}}
+{{end}}
+  function {{.Name}}({{.Params}}) external{{if .Mutability}} {{.Mutability}}{{end}}{{if .Returns}} returns ({{.Returns}}){{end}};
+{{- $separate = true}}
+{{- end}}
+{{- if not .AllowList}}
+{{- if $separate}}
+{{end}}
+  // Returns the interface version of the precompile.
+  function precompileVersion() external view returns (uint256 version);
+
+  // Returns the fixed gas cost of the function with [selector].
+  function functionGasCost(bytes4 selector) external view returns (uint256 gasCost);
+{{- end}}
+}
+`
+
+// tmplSourcePrecompileTestSol is the DS-Test contract skeleton template of a precompile.
+const tmplSourcePrecompileTestSol = `// Code generated
+// This file is a generated DS-Test contract skeleton for the precompile.
+// Each step_ function is run by the TypeScript test in contracts/test.
+
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "../interfaces/I{{.Type}}.sol";
+{{- if .AllowList}}
+import "./AllowListTest.sol";
+{{- else}}
+import "ds-test/src/test.sol";
+{{- end}}
+
+// SET THE CONTRACT ADDRESS DEFINED IN THE GENERATED module.go HERE
+address constant {{.AddressName}} = 0x0000000000000000000000000000000000000000;
+
+contract {{.Type}}Test is {{if .AllowList}}AllowListTest{{else}}DSTest{{end}} {
+  I{{.Type}} {{.InstanceName}} = I{{.Type}}({{.AddressName}});
+
+  function setUp() public {
+    // noop
+  }
+{{range .Funcs}}
+  function step_{{.Name}}() public {
+    // CUSTOM CODE STARTS HERE
+    // call {{$.InstanceName}}.{{.Name}} and assert on its effects
+  }
+{{end}}}
+`
+
+// tmplSourcePrecompileGenesis is the template of a genesis with the precompile
+// enabled, suitable for the e2e tests in tests/precompile.
+const tmplSourcePrecompileGenesis = `{
+  "config": {
+    "chainId": 99999,
+    "homesteadBlock": 0,
+    "eip150Block": 0,
+    "eip155Block": 0,
+    "eip158Block": 0,
+    "byzantiumBlock": 0,
+    "constantinopleBlock": 0,
+    "petersburgBlock": 0,
+    "istanbulBlock": 0,
+    "muirGlacierBlock": 0,
+    "feeConfig": {
+      "gasLimit": 20000000,
+      "minBaseFee": 1000000000,
+      "targetGas": 100000000,
+      "baseFeeChangeDenominator": 48,
+      "minBlockGasCost": 0,
+      "maxBlockGasCost": 10000000,
+      "targetBlockRate": 2,
+      "blockGasCostStep": 500000
+    },
+    "{{.ConfigKey}}": {
+      "blockTimestamp": 0{{if .AllowList}},
+      "adminAddresses": [
+        "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+      ]{{end}}
+    }
+  },
+  "alloc": {
+    "8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC": {
+      "balance": "0x52B7D2DCC80CD2E4000000"
+    },
+    "0x0Fa8EA536Be85F32724D57A37758761B86416123": {
+      "balance": "0x52B7D2DCC80CD2E4000000"
+    }
+  },
+  "nonce": "0x0",
+  "timestamp": "0x0",
+  "extraData": "0x00",
+  "gasLimit": "0x1312D00",
+  "difficulty": "0x0",
+  "mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+  "coinbase": "0x0000000000000000000000000000000000000000",
+  "number": "0x0",
+  "gasUsed": "0x0",
+  "parentHash": "0x0000000000000000000000000000000000000000000000000000000000000000"
+}
+`
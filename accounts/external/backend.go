@@ -27,6 +27,7 @@
 package external
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -51,8 +52,8 @@ func (eb *ExternalBackend) Wallets() []accounts.Wallet {
 	return eb.signers
 }
 
-func NewExternalBackend(endpoint string) (*ExternalBackend, error) {
-	signer, err := NewExternalSigner(endpoint)
+func NewExternalBackend(endpoint string, options ...rpc.ClientOption) (*ExternalBackend, error) {
+	signer, err := NewExternalSigner(endpoint, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -79,8 +80,11 @@ type ExternalSigner struct {
 	cache    []accounts.Account
 }
 
-func NewExternalSigner(endpoint string) (*ExternalSigner, error) {
-	client, err := rpc.Dial(endpoint)
+// NewExternalSigner connects to the external signer at [endpoint], applying
+// [options] to the connection, such as the headers authenticating the node to
+// a remote signer.
+func NewExternalSigner(endpoint string, options ...rpc.ClientOption) (*ExternalSigner, error) {
+	client, err := rpc.DialOptions(context.Background(), endpoint, options...)
 	if err != nil {
 		return nil, err
 	}
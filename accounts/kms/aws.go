@@ -0,0 +1,221 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsService        = "kms"
+	awsContentType    = "application/x-amz-json-1.1"
+	awsSigningAlg     = "AWS4-HMAC-SHA256"
+	awsDateTimeFormat = "20060102T150405Z"
+	awsDateFormat     = "20060102"
+)
+
+var errNoAWSCredentials = errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+
+var _ Client = (*AWSClient)(nil)
+
+// AWSCredentials authenticate the requests to AWS KMS.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Optional, set for temporary credentials
+}
+
+// AWSCredentialsFromEnv returns the credentials set in the standard AWS
+// environment variables.
+func AWSCredentialsFromEnv() (AWSCredentials, error) {
+	creds := AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return AWSCredentials{}, errNoAWSCredentials
+	}
+	return creds, nil
+}
+
+// AWSClient signs with an ECC_SECG_P256K1 key of AWS KMS.
+type AWSClient struct {
+	keyID    string
+	region   string
+	endpoint string
+	creds    AWSCredentials
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewAWSClient returns a client of the key [keyID] (a key ID, ARN or alias) of
+// AWS KMS in [region]. [endpoint] overrides the regional endpoint of the
+// service if set.
+func NewAWSClient(keyID, region, endpoint string, creds AWSCredentials) *AWSClient {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com/", region)
+	}
+	return &AWSClient{
+		keyID:    keyID,
+		region:   region,
+		endpoint: endpoint,
+		creds:    creds,
+		client:   http.DefaultClient,
+		now:      time.Now,
+	}
+}
+
+func (c *AWSClient) PublicKey(ctx context.Context) ([]byte, error) {
+	var reply struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	err := c.call(ctx, "GetPublicKey", map[string]interface{}{
+		"KeyId": c.keyID,
+	}, &reply)
+	return reply.PublicKey, err
+}
+
+func (c *AWSClient) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	var reply struct {
+		Signature []byte `json:"Signature"`
+	}
+	err := c.call(ctx, "Sign", map[string]interface{}{
+		"KeyId":            c.keyID,
+		"Message":          digest,
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}, &reply)
+	return reply.Signature, err
+}
+
+// call sends the [action] request of the KMS JSON API with [args] and decodes
+// the response into [reply]. Byte slices are base64 encoded both ways, as
+// expected by the API.
+func (c *AWSClient) call(ctx context.Context, action string, args interface{}, reply interface{}) error {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", awsContentType)
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	signAWSRequest(req, body, c.creds, c.region, awsService, c.now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return fmt.Errorf("AWS KMS %s request failed with status %d: %s %s", action, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+	return json.Unmarshal(respBody, reply)
+}
+
+// signAWSRequest adds the AWS Signature Version 4 of [req] and [body], covering
+// the host and all the headers of the request, to its Authorization header.
+func signAWSRequest(req *http.Request, body []byte, creds AWSCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	req.Header.Set("X-Amz-Date", now.Format(awsDateTimeFormat))
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(awsDateFormat), region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		awsSigningAlg,
+		now.Format(awsDateTimeFormat),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.Format(awsDateFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigningAlg, creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns [query] sorted and URI encoded as required by the
+// AWS signature.
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, value := range values {
+			params = append(params, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,134 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const gcpEndpoint = "https://cloudkms.googleapis.com/"
+
+var (
+	errNoGCPToken = errors.New("GOOGLE_OAUTH_ACCESS_TOKEN must be set")
+	errInvalidPEM = errors.New("public key is not PEM encoded")
+)
+
+var _ Client = (*GCPClient)(nil)
+
+// GCPTokenSource returns the OAuth 2.0 access token authenticating a request to
+// Cloud KMS. It is called for every request so that tokens can be refreshed.
+type GCPTokenSource func(ctx context.Context) (string, error)
+
+// GCPTokenFromEnv returns a token source of the access token set in the
+// GOOGLE_OAUTH_ACCESS_TOKEN environment variable, as printed by
+// `gcloud auth print-access-token`.
+func GCPTokenFromEnv() (GCPTokenSource, error) {
+	token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	if token == "" {
+		return nil, errNoGCPToken
+	}
+	return func(context.Context) (string, error) { return token, nil }, nil
+}
+
+// GCPClient signs with an EC_SIGN_SECP256K1_SHA256 key version of Cloud KMS.
+type GCPClient struct {
+	name     string
+	endpoint string
+	token    GCPTokenSource
+	client   *http.Client
+}
+
+// NewGCPClient returns a client of the key version [name], of the form
+// projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+// [endpoint] overrides the endpoint of the service if set.
+func NewGCPClient(name, endpoint string, token GCPTokenSource) *GCPClient {
+	if endpoint == "" {
+		endpoint = gcpEndpoint
+	}
+	return &GCPClient{
+		name:     name,
+		endpoint: strings.TrimSuffix(endpoint, "/") + "/v1/",
+		token:    token,
+		client:   http.DefaultClient,
+	}
+}
+
+func (c *GCPClient) PublicKey(ctx context.Context) ([]byte, error) {
+	var reply struct {
+		PEM string `json:"pem"`
+	}
+	if err := c.call(ctx, http.MethodGet, c.name+"/publicKey", nil, &reply); err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(reply.PEM))
+	if block == nil {
+		return nil, errInvalidPEM
+	}
+	return block.Bytes, nil
+}
+
+func (c *GCPClient) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	var reply struct {
+		Signature []byte `json:"signature"`
+	}
+	args := map[string]interface{}{
+		"digest": map[string][]byte{"sha256": digest},
+	}
+	err := c.call(ctx, http.MethodPost, c.name+":asymmetricSign", args, &reply)
+	return reply.Signature, err
+}
+
+// call sends a [method] request to [path] of the Cloud KMS REST API with the
+// JSON encoded [args], if any, and decodes the response into [reply].
+func (c *GCPClient) call(ctx context.Context, method, path string, args interface{}, reply interface{}) error {
+	var body io.Reader
+	if args != nil {
+		b, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	token, err := c.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if args != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return fmt.Errorf("cloud KMS request failed with status %d: %s", resp.StatusCode, apiErr.Error.Message)
+	}
+	return json.Unmarshal(respBody, reply)
+}
@@ -0,0 +1,188 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package kms signs transactions with secp256k1 keys held by a key management
+// service, so that high-value keys such as the admins of the allow list and fee
+// manager precompiles never leave it.
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	BackendAWS = "aws"
+	BackendGCP = "gcp"
+)
+
+var (
+	errUnknownBackend   = errors.New("unknown KMS backend")
+	errNoKeyID          = errors.New("KMS key ID must be set")
+	errUnsupportedCurve = errors.New("key is not a secp256k1 key")
+	errNoRecoveryID     = errors.New("signature does not match the public key")
+
+	// oidSecp256k1 identifies the secp256k1 curve in a SubjectPublicKeyInfo.
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// Client is a key management service holding a single secp256k1 key.
+type Client interface {
+	// PublicKey returns the DER encoded SubjectPublicKeyInfo of the key.
+	PublicKey(ctx context.Context) ([]byte, error)
+	// Sign returns the DER encoded ECDSA signature of the 32 byte [digest].
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Config selects the key of a key management service.
+type Config struct {
+	Backend  string `json:"backend"`  // [BackendAWS] or [BackendGCP]
+	KeyID    string `json:"keyID"`    // AWS key ID, ARN or alias, or Cloud KMS key version name
+	Region   string `json:"region"`   // AWS region
+	Endpoint string `json:"endpoint"` // Overrides the endpoint of the service if set
+}
+
+// NewClient returns the Client of the key selected by [config], authenticated
+// with the credentials set in the environment.
+func NewClient(config Config) (Client, error) {
+	if config.KeyID == "" {
+		return nil, errNoKeyID
+	}
+	switch config.Backend {
+	case BackendAWS:
+		creds, err := AWSCredentialsFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return NewAWSClient(config.KeyID, config.Region, config.Endpoint, creds), nil
+	case BackendGCP:
+		token, err := GCPTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return NewGCPClient(config.KeyID, config.Endpoint, token), nil
+	default:
+		return nil, fmt.Errorf("%w %q, must be %q or %q", errUnknownBackend, config.Backend, BackendAWS, BackendGCP)
+	}
+}
+
+// Signer signs hashes and transactions with the key of a [Client].
+type Signer struct {
+	client  Client
+	pubkey  *ecdsa.PublicKey
+	address common.Address
+}
+
+// NewSigner fetches the public key of [client] and returns a Signer using it.
+func NewSigner(ctx context.Context, client Client) (*Signer, error) {
+	der, err := client.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch public key: %w", err)
+	}
+	pubkey, err := parsePublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		client:  client,
+		pubkey:  pubkey,
+		address: crypto.PubkeyToAddress(*pubkey),
+	}, nil
+}
+
+// Address returns the address of the key.
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+// SignHash returns the signature of [hash] in the [R || S || V] format used by
+// Ethereum, where V is 0 or 1.
+func (s *Signer) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	der, err := s.client.Sign(ctx, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	var sig struct{ R, S *big.Int }
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("failed to parse signature: trailing data")
+	}
+	// Services don't normalize S, but only signatures in the lower half of the
+	// curve order are valid for transactions.
+	if sig.S.Cmp(secp256k1HalfN) > 0 {
+		sig.S = new(big.Int).Sub(secp256k1N, sig.S)
+	}
+	signature := make([]byte, crypto.SignatureLength)
+	sig.R.FillBytes(signature[:32])
+	sig.S.FillBytes(signature[32:64])
+	// The service doesn't return the recovery ID, so find the one recovering
+	// the public key.
+	for v := byte(0); v < 2; v++ {
+		signature[64] = v
+		recovered, err := crypto.SigToPub(hash[:], signature)
+		if err == nil && recovered.Equal(s.pubkey) {
+			return signature, nil
+		}
+	}
+	return nil, errNoRecoveryID
+}
+
+// SignTx returns [tx] signed with [signer].
+func (s *Signer) SignTx(ctx context.Context, tx *types.Transaction, signer types.Signer) (*types.Transaction, error) {
+	signature, err := s.SignHash(ctx, signer.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, signature)
+}
+
+// NewTransactor returns the options to transact with the bindings of contracts,
+// such as those of the precompiles, from the address of the key on the chain
+// with [chainID].
+func (s *Signer) NewTransactor(ctx context.Context, chainID *big.Int) *bind.TransactOpts {
+	signer := types.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From: s.address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(ctx, tx, signer)
+		},
+		Context: ctx,
+	}
+}
+
+// parsePublicKey parses the DER encoded SubjectPublicKeyInfo of a secp256k1
+// key, which x509.ParsePKIXPublicKey doesn't support.
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("failed to parse public key: trailing data")
+	}
+	if !info.Algorithm.Parameters.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("%w: curve %s", errUnsupportedCurve, info.Algorithm.Parameters)
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+}
@@ -0,0 +1,249 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// testClient is a Client signing with a local key. If [highS] is set, it
+// returns the signatures with S in the upper half of the curve order, as a
+// service may.
+type testClient struct {
+	key   *ecdsa.PrivateKey
+	highS bool
+}
+
+func (c *testClient) PublicKey(context.Context) ([]byte, error) {
+	return marshalPublicKey(&c.key.PublicKey, oidSecp256k1)
+}
+
+func (c *testClient) Sign(_ context.Context, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, c.key)
+	if err != nil {
+		return nil, err
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if c.highS {
+		s.Sub(secp256k1N, s)
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func marshalPublicKey(pubkey *ecdsa.PublicKey, curve asn1.ObjectIdentifier) ([]byte, error) {
+	type algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	return asn1.Marshal(struct {
+		Algorithm algorithm
+		PublicKey asn1.BitString
+	}{
+		Algorithm: algorithm{oidECPublicKey, curve},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(pubkey), BitLength: 8 * 65},
+	})
+}
+
+func TestSignerSignTx(t *testing.T) {
+	for _, highS := range []bool{false, true} {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signer, err := NewSigner(context.Background(), &testClient{key: key, highS: highS})
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+		chainID := big.NewInt(99999)
+		txSigner := types.LatestSignerForChainID(chainID)
+		for nonce := uint64(0); nonce < 8; nonce++ {
+			tx := types.NewTx(&types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     nonce,
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(25_000_000_000),
+				Gas:       21_000,
+				To:        &common.Address{1},
+			})
+			signed, err := signer.SignTx(context.Background(), tx, txSigner)
+			require.NoError(t, err)
+			sender, err := types.Sender(txSigner, signed)
+			require.NoError(t, err)
+			require.Equal(t, signer.Address(), sender)
+		}
+
+		opts := signer.NewTransactor(context.Background(), chainID)
+		require.Equal(t, signer.Address(), opts.From)
+		_, err = opts.Signer(common.Address{2}, types.NewTx(&types.LegacyTx{}))
+		require.Error(t, err)
+	}
+}
+
+func TestParsePublicKeyUnsupportedCurve(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	// prime256v1, the curve of most ECC keys of the services
+	der, err := marshalPublicKey(&key.PublicKey, asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	require.NoError(t, err)
+	_, err = parsePublicKey(der)
+	require.ErrorIs(t, err, errUnsupportedCurve)
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// Example of the AWS Signature Version 4 documentation.
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	creds := AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	signAWSRequest(req, nil, creds, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	require.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"),
+	)
+}
+
+func TestAWSClient(t *testing.T) {
+	require := require.New(t)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	kms := &testClient{key: key, highS: true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"__type": "MissingAuthenticationTokenException"})
+			return
+		}
+		var args struct {
+			KeyId            string
+			Message          []byte
+			MessageType      string
+			SigningAlgorithm string
+		}
+		require.NoError(json.NewDecoder(r.Body).Decode(&args))
+		require.Equal("alias/admin", args.KeyId)
+
+		var (
+			reply = make(map[string][]byte)
+			err   error
+		)
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			reply["PublicKey"], err = kms.PublicKey(r.Context())
+		case "TrentService.Sign":
+			require.Equal("DIGEST", args.MessageType)
+			require.Equal("ECDSA_SHA_256", args.SigningAlgorithm)
+			reply["Signature"], err = kms.Sign(r.Context(), args.Message)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		require.NoError(err)
+		require.NoError(json.NewEncoder(w).Encode(reply))
+	}))
+	defer server.Close()
+
+	_, err = NewSigner(context.Background(), NewAWSClient("alias/admin", "us-east-1", server.URL, AWSCredentials{}))
+	require.ErrorContains(err, "MissingAuthenticationTokenException")
+
+	client := NewAWSClient("alias/admin", "us-east-1", server.URL, AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	signer, err := NewSigner(context.Background(), client)
+	require.NoError(err)
+	require.Equal(crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	hash := common.Hash{1, 2, 3}
+	sig, err := signer.SignHash(context.Background(), hash)
+	require.NoError(err)
+	pubkey, err := crypto.SigToPub(hash[:], sig)
+	require.NoError(err)
+	require.Equal(signer.Address(), crypto.PubkeyToAddress(*pubkey))
+}
+
+func TestGCPClient(t *testing.T) {
+	require := require.New(t)
+
+	const name = "projects/p/locations/global/keyRings/r/cryptoKeys/admin/cryptoKeyVersions/1"
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	kms := &testClient{key: key}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("Bearer token", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/"+name+"/publicKey":
+			der, err := kms.PublicKey(r.Context())
+			require.NoError(err)
+			require.NoError(json.NewEncoder(w).Encode(map[string]string{
+				"pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			}))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/"+name+":asymmetricSign":
+			var args struct {
+				Digest struct {
+					SHA256 []byte `json:"sha256"`
+				} `json:"digest"`
+			}
+			require.NoError(json.NewDecoder(r.Body).Decode(&args))
+			sig, err := kms.Sign(r.Context(), args.Digest.SHA256)
+			require.NoError(err)
+			require.NoError(json.NewEncoder(w).Encode(map[string][]byte{"signature": sig}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]map[string]string{"error": {"message": "not found"}})
+		}
+	}))
+	defer server.Close()
+
+	token := func(context.Context) (string, error) { return "token", nil }
+	_, err = NewSigner(context.Background(), NewGCPClient(name+"0", server.URL, token))
+	require.ErrorContains(err, "not found")
+
+	signer, err := NewSigner(context.Background(), NewGCPClient(name, server.URL, token))
+	require.NoError(err)
+	require.Equal(crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	hash := common.Hash{4, 5, 6}
+	sig, err := signer.SignHash(context.Background(), hash)
+	require.NoError(err)
+	pubkey, err := crypto.SigToPub(hash[:], sig)
+	require.NoError(err)
+	require.Equal(signer.Address(), crypto.PubkeyToAddress(*pubkey))
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "")
+
+	_, err := NewClient(Config{Backend: "yubihsm", KeyID: "1"})
+	require.ErrorIs(t, err, errUnknownBackend)
+	_, err = NewClient(Config{Backend: BackendAWS})
+	require.ErrorIs(t, err, errNoKeyID)
+	_, err = NewClient(Config{Backend: BackendAWS, KeyID: "alias/admin"})
+	require.ErrorIs(t, err, errNoAWSCredentials)
+	_, err = NewClient(Config{Backend: BackendGCP, KeyID: "projects/p"})
+	require.ErrorIs(t, err, errNoGCPToken)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	client, err := NewClient(Config{Backend: BackendAWS, KeyID: "alias/admin", Region: "eu-west-1"})
+	require.NoError(t, err)
+	require.Equal(t, "https://kms.eu-west-1.amazonaws.com/", client.(*AWSClient).endpoint)
+}
@@ -0,0 +1,118 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// dbmigrate copies the standalone database of a chain from one key-value
+// backend to another, ie. from leveldb to pebble, while the node is stopped.
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/cmd/utils"
+	"github.com/ava-labs/subnet-evm/internal/flags"
+	"github.com/ava-labs/subnet-evm/plugin/evm/standalonedb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	srcTypeFlag = &cli.StringFlag{
+		Name:     "src-type",
+		Usage:    fmt.Sprintf("Backend of the source database (%v)", standalonedb.Names()),
+		Required: true,
+	}
+	srcPathFlag = &cli.StringFlag{
+		Name:     "src-path",
+		Usage:    "Path of the source database",
+		Required: true,
+	}
+	srcConfigFlag = &cli.StringFlag{
+		Name:  "src-config",
+		Usage: "Path of the backend-specific config file of the source database",
+	}
+	dstTypeFlag = &cli.StringFlag{
+		Name:     "dst-type",
+		Usage:    fmt.Sprintf("Backend of the destination database (%v)", standalonedb.Names()),
+		Required: true,
+	}
+	dstPathFlag = &cli.StringFlag{
+		Name:     "dst-path",
+		Usage:    "Path of the destination database, which must be empty or partially migrated into",
+		Required: true,
+	}
+	dstConfigFlag = &cli.StringFlag{
+		Name:  "dst-config",
+		Usage: "Path of the backend-specific config file of the destination database",
+	}
+	batchSizeFlag = &cli.IntFlag{
+		Name:  "batch-size",
+		Usage: "Size in bytes of the batches written to the destination database",
+		Value: ethdb.IdealBatchSize,
+	}
+)
+
+var app = flags.NewApp("subnet-evm database migration tool")
+
+func init() {
+	app.Name = "dbmigrate"
+	app.Flags = []cli.Flag{
+		srcTypeFlag,
+		srcPathFlag,
+		srcConfigFlag,
+		dstTypeFlag,
+		dstPathFlag,
+		dstConfigFlag,
+		batchSizeFlag,
+	}
+	app.Action = dbmigrate
+}
+
+func openDatabase(name string, path string, configPath string) database.Database {
+	var config []byte
+	if configPath != "" {
+		var err error
+		config, err = os.ReadFile(configPath)
+		if err != nil {
+			utils.Fatalf("Failed to read %s database config: %v", name, err)
+		}
+	}
+	db, err := standalonedb.New(name, path, config, logging.NoLog{}, prometheus.NewRegistry())
+	if err != nil {
+		utils.Fatalf("%v", err)
+	}
+	return db
+}
+
+func dbmigrate(c *cli.Context) error {
+	src := openDatabase(c.String(srcTypeFlag.Name), c.String(srcPathFlag.Name), c.String(srcConfigFlag.Name))
+	defer src.Close()
+	dst := openDatabase(c.String(dstTypeFlag.Name), c.String(dstPathFlag.Name), c.String(dstConfigFlag.Name))
+	defer dst.Close()
+
+	start := time.Now()
+	migrated, err := standalonedb.Migrate(src, dst, c.Int(batchSizeFlag.Name))
+	if err != nil {
+		return err
+	}
+	if !migrated {
+		fmt.Println("Destination database was already migrated into")
+		return nil
+	}
+	fmt.Println("Database migrated successfully in", time.Since(start))
+	return nil
+}
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -150,7 +150,7 @@ func Transaction(ctx *cli.Context) error {
 		}
 		// Check intrinsic gas
 		rules := chainConfig.Rules(new(big.Int), 0)
-		if gas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, rules); err != nil {
+		if gas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, rules); err != nil {
 			r.Error = err
 			results = append(results, r)
 			continue
@@ -182,7 +182,7 @@ func Transaction(ctx *cli.Context) error {
 			r.Error = errors.New("gas * maxFeePerGas exceeds 256 bits")
 		}
 		// Check whether the init code size has been exceeded.
-		if chainConfig.IsDurango(0) && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
+		if chainConfig.IsDurango(0) && tx.To() == nil && len(tx.Data()) > chainConfig.GetMaxInitCodeSize(0) {
 			r.Error = errors.New("max initcode size exceeded")
 		}
 		results = append(results, r)
@@ -228,6 +228,7 @@ func init() {
 		stateTransitionCommand,
 		transactionCommand,
 		blockBuilderCommand,
+		statelessCommand,
 	}
 }
 
@@ -0,0 +1,110 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/ethclient/subnetevmclient"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	RPCFlag = &cli.StringFlag{
+		Name:  "rpc",
+		Usage: "RPC endpoint of a node serving execution witnesses (debug_executionWitness)",
+		Value: "http://127.0.0.1:9650/ext/bc/C/rpc",
+	}
+	FromBlockFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "first block to verify",
+		Value: 1,
+	}
+	ToBlockFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "last block to verify (defaults to --from)",
+	}
+	FollowFlag = &cli.BoolFlag{
+		Name:  "follow",
+		Usage: "keep verifying new blocks as they are accepted",
+	}
+)
+
+var errStatelessVerificationFailed = errors.New("stateless verification failed")
+
+var statelessCommand = &cli.Command{
+	Action: statelessCmd,
+	Name:   "stateless",
+	Usage:  "Verifies the execution of blocks without the state, using the execution witnesses served by a node",
+	Flags: []cli.Flag{
+		RPCFlag,
+		FromBlockFlag,
+		ToBlockFlag,
+		FollowFlag,
+	},
+}
+
+func statelessCmd(ctx *cli.Context) error {
+	rpcClient, err := rpc.DialContext(ctx.Context, ctx.String(RPCFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer rpcClient.Close()
+	var (
+		client    = ethclient.NewClient(rpcClient)
+		witnesses = subnetevmclient.New(rpcClient)
+		engine    = dummy.NewFaker()
+	)
+	configWithUpgrades, err := client.ChainConfig(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch chain config: %w", err)
+	}
+	config := configWithUpgrades.ChainConfig
+	config.UpgradeConfig = configWithUpgrades.UpgradeConfig
+
+	from, to := ctx.Uint64(FromBlockFlag.Name), ctx.Uint64(ToBlockFlag.Name)
+	if to < from {
+		to = from
+	}
+	for number := from; ctx.Bool(FollowFlag.Name) || number <= to; number++ {
+		// Wait for the block to be accepted when following the chain.
+		for ctx.Bool(FollowFlag.Name) {
+			head, err := client.BlockNumber(ctx.Context)
+			if err != nil {
+				return err
+			}
+			if head >= number {
+				break
+			}
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Context.Done():
+				return ctx.Context.Err()
+			}
+		}
+
+		blockNumber := new(big.Int).SetUint64(number)
+		block, err := witnesses.RawBlock(ctx.Context, blockNumber)
+		if err != nil {
+			return fmt.Errorf("failed to fetch block %d: %w", number, err)
+		}
+		witness, err := witnesses.ExecutionWitness(ctx.Context, blockNumber)
+		if err != nil {
+			return fmt.Errorf("failed to fetch witness of block %d: %w", number, err)
+		}
+		start := time.Now()
+		if err := core.ExecuteStateless(&config, engine, block, witness); err != nil {
+			return fmt.Errorf("%w: block %d (%s): %w", errStatelessVerificationFailed, number, block.Hash(), err)
+		}
+		fmt.Printf("verified block %d (%s): txs=%d nodes=%d codes=%d elapsed=%s\n", number, block.Hash(), len(block.Transactions()), len(witness.State), len(witness.Codes), time.Since(start))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+var errInvalidProofOfPossession = errors.New("invalid proof of possession")
+
+var (
+	publicKeyFlag = &cli.StringFlag{
+		Name:     "public-key",
+		Usage:    "Hex encoded BLS public key",
+		Required: true,
+	}
+	popFlag = &cli.StringFlag{
+		Name:     "pop",
+		Usage:    "Hex encoded BLS proof of possession of the public key",
+		Required: true,
+	}
+)
+
+var blsCommand = &cli.Command{
+	Name:  "bls",
+	Usage: "Manage BLS keys used by validators to sign warp messages",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "generate",
+			Usage:  "Generate a new BLS secret key",
+			Flags:  []cli.Flag{outFlag},
+			Action: generateBLS,
+		},
+		{
+			Name:      "inspect",
+			Usage:     "Validate a BLS secret key and derive its public key and proof of possession",
+			ArgsUsage: "<hex secret key>",
+			Flags:     []cli.Flag{fileFlag},
+			Action:    inspectBLS,
+		},
+		{
+			Name:   "verify-pop",
+			Usage:  "Verify the proof of possession of a BLS public key",
+			Flags:  []cli.Flag{publicKeyFlag, popFlag},
+			Action: verifyBLSProofOfPossession,
+		},
+	},
+}
+
+// blsKey holds a BLS secret key with the public key and proof of possession
+// registered for it on the P-Chain.
+type blsKey struct {
+	SecretKey         hexutil.Bytes `json:"secretKey"`
+	PublicKey         hexutil.Bytes `json:"publicKey"`
+	ProofOfPossession hexutil.Bytes `json:"proofOfPossession"`
+}
+
+func newBLSKey(sk *bls.SecretKey) *blsKey {
+	pop := signer.NewProofOfPossession(sk)
+	return &blsKey{
+		SecretKey:         bls.SecretKeyToBytes(sk),
+		PublicKey:         pop.PublicKey[:],
+		ProofOfPossession: pop.ProofOfPossession[:],
+	}
+}
+
+// parseBLSKey parses a BLS secret key given either as raw bytes, as written to the
+// staking signer key file, or hex encoded.
+func parseBLSKey(input []byte) (*bls.SecretKey, error) {
+	b := input
+	if len(input) != bls.SecretKeyLen {
+		decoded, err := hex.DecodeString(trimHex(string(input)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid BLS secret key: %w", err)
+		}
+		b = decoded
+	}
+	sk, err := bls.SecretKeyFromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BLS secret key: %w", err)
+	}
+	return sk, nil
+}
+
+// verifyProofOfPossession returns an error if [pop] is not a valid proof of possession of [publicKey].
+func verifyProofOfPossession(publicKey []byte, pop []byte) error {
+	if len(publicKey) != bls.PublicKeyLen {
+		return fmt.Errorf("invalid BLS public key length %d, expected %d", len(publicKey), bls.PublicKeyLen)
+	}
+	if len(pop) != bls.SignatureLen {
+		return fmt.Errorf("%w: length %d, expected %d", errInvalidProofOfPossession, len(pop), bls.SignatureLen)
+	}
+	p := new(signer.ProofOfPossession)
+	copy(p.PublicKey[:], publicKey)
+	copy(p.ProofOfPossession[:], pop)
+	if err := p.Verify(); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidProofOfPossession, err)
+	}
+	return nil
+}
+
+func generateBLS(c *cli.Context) error {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		return err
+	}
+	res := newBLSKey(sk)
+	if path := c.String(outFlag.Name); path != "" {
+		// Raw bytes, as read by the node from the staking signer key file.
+		if err := writeKeyFile(path, res.SecretKey); err != nil {
+			return err
+		}
+	}
+	return printJSON(res)
+}
+
+func inspectBLS(c *cli.Context) error {
+	input, err := keyInput(c)
+	if err != nil {
+		return err
+	}
+	sk, err := parseBLSKey(input)
+	if err != nil {
+		return err
+	}
+	return printJSON(newBLSKey(sk))
+}
+
+func verifyBLSProofOfPossession(c *cli.Context) error {
+	publicKey, err := hex.DecodeString(trimHex(c.String(publicKeyFlag.Name)))
+	if err != nil {
+		return fmt.Errorf("invalid BLS public key: %w", err)
+	}
+	pop, err := hex.DecodeString(trimHex(c.String(popFlag.Name)))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidProofOfPossession, err)
+	}
+	if err := verifyProofOfPossession(publicKey, pop); err != nil {
+		return err
+	}
+	fmt.Println("valid proof of possession")
+	return nil
+}
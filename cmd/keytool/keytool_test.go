@@ -0,0 +1,61 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256k1Key(t *testing.T) {
+	require := require.New(t)
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+	res, err := newSecp256k1Key(key, "custom", big.NewInt(100))
+	require.NoError(err)
+
+	// The hex key is loadable by go-ethereum and derives the same address
+	ecdsaKey, err := ethcrypto.HexToECDSA(res.PrivateKey)
+	require.NoError(err)
+	require.Equal(res.Address, ethcrypto.PubkeyToAddress(ecdsaKey.PublicKey))
+	require.Equal(big.NewInt(100), res.Alloc[res.Address].Balance)
+
+	// Both encodings parse back to the same key
+	for _, input := range []string{res.PrivateKey, "0x" + res.PrivateKey, res.AvalanchePrivateKey} {
+		parsed, err := parseSecp256k1Key(input)
+		require.NoError(err)
+		require.Equal(key.Bytes(), parsed.Bytes())
+	}
+
+	_, err = parseSecp256k1Key("0x1234")
+	require.Error(err)
+	_, err = parseSecp256k1Key("PrivateKey-invalid")
+	require.Error(err)
+}
+
+func TestBLSKey(t *testing.T) {
+	require := require.New(t)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	res := newBLSKey(sk)
+	require.NoError(verifyProofOfPossession(res.PublicKey, res.ProofOfPossession))
+
+	// Raw and hex encoded keys parse back to the same key
+	for _, input := range [][]byte{res.SecretKey, []byte(res.SecretKey.String()), []byte(hex.EncodeToString(res.SecretKey))} {
+		parsed, err := parseBLSKey(input)
+		require.NoError(err)
+		require.Equal(res, newBLSKey(parsed))
+	}
+
+	other, err := bls.NewSecretKey()
+	require.NoError(err)
+	require.ErrorIs(verifyProofOfPossession(res.PublicKey, newBLSKey(other).ProofOfPossession), errInvalidProofOfPossession)
+	require.ErrorIs(verifyProofOfPossession(res.PublicKey, res.ProofOfPossession[1:]), errInvalidProofOfPossession)
+}
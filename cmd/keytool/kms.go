@@ -0,0 +1,82 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+
+	"github.com/ava-labs/subnet-evm/accounts/kms"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	kmsBackendFlag = &cli.StringFlag{
+		Name:     "backend",
+		Usage:    "Key management service holding the key (aws or gcp)",
+		Required: true,
+	}
+	kmsKeyFlag = &cli.StringFlag{
+		Name:     "key",
+		Usage:    "AWS KMS key ID, ARN or alias, or Cloud KMS key version name",
+		Required: true,
+	}
+	kmsRegionFlag = &cli.StringFlag{
+		Name:  "region",
+		Usage: "AWS region of the key",
+	}
+	kmsEndpointFlag = &cli.StringFlag{
+		Name:  "endpoint",
+		Usage: "Endpoint of the key management service, if not the default one",
+	}
+)
+
+var kmsCommand = &cli.Command{
+	Name:  "kms",
+	Usage: "Inspect secp256k1 keys held by AWS KMS or Cloud KMS",
+	Description: `The credentials are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY
+and AWS_SESSION_TOKEN environment variables for AWS KMS, and from the
+GOOGLE_OAUTH_ACCESS_TOKEN environment variable for Cloud KMS.`,
+	Subcommands: []*cli.Command{
+		{
+			Name:   "inspect",
+			Usage:  "Derive the address of a key, to set it as a precompile admin",
+			Flags:  []cli.Flag{kmsBackendFlag, kmsKeyFlag, kmsRegionFlag, kmsEndpointFlag, balanceFlag},
+			Action: inspectKMS,
+		},
+	},
+}
+
+// kmsKey holds the address of a key of a key management service.
+type kmsKey struct {
+	Address common.Address `json:"address"`
+	// Alloc is the genesis alloc funding [Address], if a balance is given.
+	Alloc core.GenesisAlloc `json:"alloc,omitempty"`
+}
+
+func inspectKMS(c *cli.Context) error {
+	balance, err := parseBalance(c)
+	if err != nil {
+		return err
+	}
+	client, err := kms.NewClient(kms.Config{
+		Backend:  c.String(kmsBackendFlag.Name),
+		KeyID:    c.String(kmsKeyFlag.Name),
+		Region:   c.String(kmsRegionFlag.Name),
+		Endpoint: c.String(kmsEndpointFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	signer, err := kms.NewSigner(context.Background(), client)
+	if err != nil {
+		return err
+	}
+	res := &kmsKey{Address: signer.Address()}
+	if balance != nil {
+		res.Alloc = core.GenesisAlloc{res.Address: {Balance: balance}}
+	}
+	return printJSON(res)
+}
@@ -0,0 +1,86 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// keytool generates and inspects the secp256k1 and BLS keys used to launch a
+// Subnet-EVM chain, and prints them in the formats expected by the genesis,
+// the node staking configuration and the tests.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/internal/flags"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	outFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the generated key to (the file must not exist)",
+	}
+	fileFlag = &cli.StringFlag{
+		Name:  "file",
+		Usage: "File to read the key from instead of the command line argument",
+	}
+)
+
+var app = flags.NewApp("subnet-evm key management tool")
+
+func init() {
+	app.Name = "keytool"
+	app.Commands = []*cli.Command{
+		secp256k1Command,
+		blsCommand,
+		kmsCommand,
+	}
+}
+
+// keyInput returns the key given as the first argument or in the file set with [fileFlag].
+func keyInput(c *cli.Context) ([]byte, error) {
+	if path := c.String(fileFlag.Name); path != "" {
+		return os.ReadFile(path)
+	}
+	if c.NArg() != 1 {
+		return nil, fmt.Errorf("expected the key as the only argument or --%s", fileFlag.Name)
+	}
+	return []byte(c.Args().First()), nil
+}
+
+// writeKeyFile writes [content] to the new file at [path], readable only by the user.
+func writeKeyFile(path string, content []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printJSON prints [v] as indented JSON to stdout.
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// trimHex returns [s] without surrounding spaces and 0x prefix.
+func trimHex(s string) string {
+	s = strings.TrimSpace(s)
+	return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
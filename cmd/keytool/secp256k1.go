@@ -0,0 +1,162 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/cb58"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	hrpFlag = &cli.StringFlag{
+		Name:  "hrp",
+		Usage: "Human readable part of the P-Chain address",
+		Value: constants.FallbackHRP,
+	}
+	balanceFlag = &cli.StringFlag{
+		Name:  "balance",
+		Usage: "Balance of the address in the genesis alloc, in wei (decimal or 0x-prefixed hex)",
+	}
+)
+
+var secp256k1Command = &cli.Command{
+	Name:  "secp256k1",
+	Usage: "Manage secp256k1 keys used for EVM accounts and P-Chain addresses",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "generate",
+			Usage:  "Generate a new secp256k1 key",
+			Flags:  []cli.Flag{outFlag, hrpFlag, balanceFlag},
+			Action: generateSecp256k1,
+		},
+		{
+			Name:      "inspect",
+			Usage:     "Validate a secp256k1 key and derive its addresses",
+			ArgsUsage: "<hex or PrivateKey-... key>",
+			Flags:     []cli.Flag{fileFlag, hrpFlag, balanceFlag},
+			Action:    inspectSecp256k1,
+		},
+	},
+}
+
+// secp256k1Key holds a secp256k1 private key in the formats used to launch a chain.
+type secp256k1Key struct {
+	// PrivateKey is the hex encoded private key without 0x prefix, as loaded by
+	// crypto.HexToECDSA in tests and tools.
+	PrivateKey string `json:"privateKey"`
+	// AvalanchePrivateKey is the CB58 encoded private key used by Avalanche wallets.
+	AvalanchePrivateKey string `json:"avalanchePrivateKey"`
+	// Address is the EVM address, as used in the genesis alloc and allow lists.
+	Address common.Address `json:"address"`
+	// PChainAddress is the bech32 P-Chain address of the key.
+	PChainAddress string `json:"pChainAddress"`
+	// Alloc is the genesis alloc funding [Address], if a balance is given.
+	Alloc core.GenesisAlloc `json:"alloc,omitempty"`
+}
+
+// newSecp256k1Key returns the formats of [key], with a genesis alloc of [balance] if not nil.
+func newSecp256k1Key(key *secp256k1.PrivateKey, hrp string, balance *big.Int) (*secp256k1Key, error) {
+	pChainAddress, err := address.Format("P", hrp, key.Address().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	res := &secp256k1Key{
+		PrivateKey:          hex.EncodeToString(key.Bytes()),
+		AvalanchePrivateKey: key.String(),
+		Address:             ethcrypto.PubkeyToAddress(key.ToECDSA().PublicKey),
+		PChainAddress:       pChainAddress,
+	}
+	if balance != nil {
+		res.Alloc = core.GenesisAlloc{res.Address: {Balance: balance}}
+	}
+	return res, nil
+}
+
+// parseSecp256k1Key parses a hex encoded or CB58 encoded "PrivateKey-" prefixed private key.
+func parseSecp256k1Key(s string) (*secp256k1.PrivateKey, error) {
+	s = strings.TrimSpace(s)
+	var (
+		b   []byte
+		err error
+	)
+	if strings.HasPrefix(s, secp256k1.PrivateKeyPrefix) {
+		b, err = cb58.Decode(strings.TrimPrefix(s, secp256k1.PrivateKeyPrefix))
+	} else {
+		b, err = hex.DecodeString(trimHex(s))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 key: %w", err)
+	}
+	key, err := secp256k1.ToPrivateKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 key: %w", err)
+	}
+	return key, nil
+}
+
+// parseBalance parses the balance set with [balanceFlag], or returns nil if it is not set.
+func parseBalance(c *cli.Context) (*big.Int, error) {
+	if !c.IsSet(balanceFlag.Name) {
+		return nil, nil
+	}
+	balance, ok := math.ParseBig256(c.String(balanceFlag.Name))
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %q", c.String(balanceFlag.Name))
+	}
+	return balance, nil
+}
+
+func generateSecp256k1(c *cli.Context) error {
+	balance, err := parseBalance(c)
+	if err != nil {
+		return err
+	}
+	key, err := secp256k1.NewPrivateKey()
+	if err != nil {
+		return err
+	}
+	res, err := newSecp256k1Key(key, c.String(hrpFlag.Name), balance)
+	if err != nil {
+		return err
+	}
+	if path := c.String(outFlag.Name); path != "" {
+		// Same format as crypto.SaveECDSA, so the key can be loaded with crypto.LoadECDSA.
+		if err := writeKeyFile(path, []byte(res.PrivateKey)); err != nil {
+			return err
+		}
+	}
+	return printJSON(res)
+}
+
+func inspectSecp256k1(c *cli.Context) error {
+	balance, err := parseBalance(c)
+	if err != nil {
+		return err
+	}
+	input, err := keyInput(c)
+	if err != nil {
+		return err
+	}
+	key, err := parseSecp256k1Key(string(input))
+	if err != nil {
+		return err
+	}
+	res, err := newSecp256k1Key(key, c.String(hrpFlag.Name), balance)
+	if err != nil {
+		return err
+	}
+	return printJSON(res)
+}
@@ -64,6 +64,16 @@ var (
 		Name:  "out",
 		Usage: "Output folder for the generated precompile files, - for STDOUT (default = ./precompile/contracts/{pkg}). Test files won't be generated if STDOUT is used",
 	}
+	contractsOutFlag = &cli.StringFlag{
+		Name:  "contracts-out",
+		Usage: "Solidity contracts folder to generate the precompile interface and DS-Test contract into",
+		Value: "./contracts/contracts",
+	}
+	genesisOutFlag = &cli.StringFlag{
+		Name:  "genesis-out",
+		Usage: "Folder to generate the genesis with the precompile enabled into",
+		Value: "./tests/precompile/genesis",
+	}
 )
 
 var app = flags.NewApp("subnet-evm precompile generator tool")
@@ -75,6 +85,8 @@ func init() {
 		outFlag,
 		pkgFlag,
 		typeFlag,
+		contractsOutFlag,
+		genesisOutFlag,
 	}
 	app.Action = precompilegen
 }
@@ -150,6 +162,12 @@ func precompilegen(c *cli.Context) error {
 		utils.Fatalf("Failed to generate precompile: %v", err)
 	}
 
+	// Generate the Solidity interface, DS-Test contract and genesis
+	scaffold, err := precompilebind.PrecompileBindScaffold(kind, pkg, string(abi))
+	if err != nil {
+		utils.Fatalf("Failed to generate precompile scaffolding: %v", err)
+	}
+
 	// Either flush it out to a file or display on the standard output
 	// Skip displaying test codes here.
 	if isOutStdout {
@@ -159,6 +177,10 @@ func precompilegen(c *cli.Context) error {
 				fmt.Printf("%s\n", file.Content)
 			}
 		}
+		for _, file := range []precompilebind.PrecompileBindFile{scaffold.Interface, scaffold.Genesis} {
+			fmt.Printf("-----file: %s-----\n", file.FileName)
+			fmt.Printf("%s\n", file.Content)
+		}
 		return nil
 	}
 
@@ -184,6 +206,27 @@ func precompilegen(c *cli.Context) error {
 		utils.Fatalf("Failed to write README: %v", err)
 	}
 
+	contractsOut := c.String(contractsOutFlag.Name)
+	genesisOut := c.String(genesisOutFlag.Name)
+	scaffoldFiles := []struct {
+		dir  string
+		file precompilebind.PrecompileBindFile
+	}{
+		{filepath.Join(contractsOut, precompilebind.SolidityInterfacesDir), scaffold.Interface},
+		{filepath.Join(contractsOut, precompilebind.SolidityTestsDir), scaffold.Test},
+		{genesisOut, scaffold.Genesis},
+	}
+	for _, f := range scaffoldFiles {
+		if err := os.MkdirAll(f.dir, 0o700); err != nil {
+			utils.Fatalf("Failed to create folder %s: %v", f.dir, err)
+		}
+		outputPath := filepath.Join(f.dir, f.file.FileName)
+		if err := os.WriteFile(outputPath, []byte(f.file.Content), 0o600); err != nil {
+			utils.Fatalf("Failed to write generated file %s: %v", outputPath, err)
+		}
+		fmt.Println("Generated: ", outputPath)
+	}
+
 	fmt.Println("Precompile files generated successfully at: ", outFlagStr)
 	return nil
 }
@@ -0,0 +1,323 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// replay re-executes a range of accepted blocks from a copy of the standalone
+// database of a chain, reporting the time spent executing each block, hashing
+// its state and committing its tries, to compare the performance of
+// subnet-evm versions on real chain data. The database is never written to.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/cmd/utils"
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/internal/flags"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm"
+	"github.com/ava-labs/subnet-evm/plugin/evm/standalonedb"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	subnetutils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+var (
+	dbTypeFlag = &cli.StringFlag{
+		Name:     "db-type",
+		Usage:    fmt.Sprintf("Backend of the database (%v)", standalonedb.Names()),
+		Required: true,
+	}
+	dbPathFlag = &cli.StringFlag{
+		Name:     "db-path",
+		Usage:    "Path of a copy of the standalone database of the chain",
+		Required: true,
+	}
+	dbConfigFlag = &cli.StringFlag{
+		Name:  "db-config",
+		Usage: "Path of the backend-specific config file of the database",
+	}
+	fromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "First block to replay. The state of its parent must be on disk, ie. committed by an archival node or at a commit interval",
+		Required: true,
+	}
+	toFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Last block to replay (default: the last accepted block)",
+	}
+	networkIDFlag = &cli.UintFlag{
+		Name:  "network-id",
+		Usage: "Network ID of the chain, as seen by precompiles",
+	}
+	subnetIDFlag = &cli.StringFlag{
+		Name:  "subnet-id",
+		Usage: "Subnet ID of the chain, as seen by precompiles",
+	}
+	chainIDFlag = &cli.StringFlag{
+		Name:  "chain-id",
+		Usage: "Blockchain ID of the chain, as seen by precompiles such as warp",
+	}
+	precompileGasFlag = &cli.BoolFlag{
+		Name:  "precompile-gas",
+		Usage: "Report the gas used by each precompile, which traces every call frame and slows down execution",
+	}
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: fmt.Sprintf("Format of the report of each block (%s or %s)", formatCSV, formatJSON),
+		Value: formatCSV,
+	}
+)
+
+var app = flags.NewApp("subnet-evm block replay tool")
+
+func init() {
+	app.Name = "replay"
+	app.Flags = []cli.Flag{
+		dbTypeFlag,
+		dbPathFlag,
+		dbConfigFlag,
+		fromFlag,
+		toFlag,
+		networkIDFlag,
+		subnetIDFlag,
+		chainIDFlag,
+		precompileGasFlag,
+		formatFlag,
+	}
+	app.Action = replay
+}
+
+func openDatabase(name string, path string, configPath string) database.Database {
+	var config []byte
+	if configPath != "" {
+		var err error
+		config, err = os.ReadFile(configPath)
+		if err != nil {
+			utils.Fatalf("Failed to read database config: %v", err)
+		}
+	}
+	db, err := standalonedb.New(name, path, config, logging.NoLog{}, prometheus.NewRegistry())
+	if err != nil {
+		utils.Fatalf("%v", err)
+	}
+	return db
+}
+
+// chainConfig returns the config of the chain stored in [chaindb], with the
+// snow context seen by precompiles set from the flags.
+func chainConfig(c *cli.Context, chaindb ethdb.Database) (*params.ChainConfig, error) {
+	genesisHash := rawdb.ReadCanonicalHash(chaindb, 0)
+	if genesisHash == (common.Hash{}) {
+		return nil, fmt.Errorf("database has no genesis block")
+	}
+	config := rawdb.ReadChainConfig(chaindb, genesisHash)
+	if config == nil {
+		return nil, fmt.Errorf("database has no chain config for genesis %s", genesisHash)
+	}
+	snowCtx := subnetutils.TestSnowContext()
+	snowCtx.NetworkID = uint32(c.Uint(networkIDFlag.Name))
+	for _, id := range []struct {
+		flag *cli.StringFlag
+		dst  *ids.ID
+	}{{subnetIDFlag, &snowCtx.SubnetID}, {chainIDFlag, &snowCtx.ChainID}} {
+		if !c.IsSet(id.flag.Name) {
+			continue
+		}
+		parsed, err := ids.FromString(c.String(id.flag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", id.flag.Name, err)
+		}
+		*id.dst = parsed
+	}
+	config.SnowCtx = snowCtx
+	return config, nil
+}
+
+// reporter writes the stats of the replayed blocks to stdout.
+type reporter interface {
+	Report(stats *core.ReplayStats) error
+	Flush() error
+}
+
+type csvReporter struct{ w *csv.Writer }
+
+func newCSVReporter() (*csvReporter, error) {
+	r := &csvReporter{w: csv.NewWriter(os.Stdout)}
+	return r, r.w.Write([]string{"number", "hash", "txs", "gas_used", "execution_us", "validation_us", "commit_us", "precompile_gas"})
+}
+
+func (r *csvReporter) Report(stats *core.ReplayStats) error {
+	return r.w.Write([]string{
+		strconv.FormatUint(stats.Number, 10),
+		stats.Hash.Hex(),
+		strconv.Itoa(stats.Txs),
+		strconv.FormatUint(stats.GasUsed, 10),
+		strconv.FormatInt(stats.Execution.Microseconds(), 10),
+		strconv.FormatInt(stats.Validation.Microseconds(), 10),
+		strconv.FormatInt(stats.Commit.Microseconds(), 10),
+		formatPrecompileGas(stats.PrecompileGas),
+	})
+}
+
+func (r *csvReporter) Flush() error {
+	r.w.Flush()
+	return r.w.Error()
+}
+
+type jsonReporter struct{ enc *json.Encoder }
+
+func (r *jsonReporter) Report(stats *core.ReplayStats) error {
+	precompileGas := make(map[string]uint64, len(stats.PrecompileGas))
+	for addr, gas := range stats.PrecompileGas {
+		precompileGas[precompileName(addr)] = gas
+	}
+	return r.enc.Encode(struct {
+		Number        uint64            `json:"number"`
+		Hash          common.Hash       `json:"hash"`
+		Txs           int               `json:"txs"`
+		GasUsed       uint64            `json:"gasUsed"`
+		Execution     int64             `json:"executionUs"`
+		Validation    int64             `json:"validationUs"`
+		Commit        int64             `json:"commitUs"`
+		PrecompileGas map[string]uint64 `json:"precompileGas,omitempty"`
+	}{
+		Number:        stats.Number,
+		Hash:          stats.Hash,
+		Txs:           stats.Txs,
+		GasUsed:       stats.GasUsed,
+		Execution:     stats.Execution.Microseconds(),
+		Validation:    stats.Validation.Microseconds(),
+		Commit:        stats.Commit.Microseconds(),
+		PrecompileGas: precompileGas,
+	})
+}
+
+func (r *jsonReporter) Flush() error { return nil }
+
+// precompileName returns the config key of the stateful precompile at [addr],
+// or [addr] for other precompiles.
+func precompileName(addr common.Address) string {
+	if module, ok := modules.GetPrecompileModuleByAddress(addr); ok {
+		return module.ConfigKey
+	}
+	return addr.Hex()
+}
+
+// formatPrecompileGas formats [gas] as name=gas pairs sorted by name.
+func formatPrecompileGas(gas map[common.Address]uint64) string {
+	pairs := make([]string, 0, len(gas))
+	for addr, used := range gas {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", precompileName(addr), used))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+func replay(c *cli.Context) error {
+	db := openDatabase(c.String(dbTypeFlag.Name), c.String(dbPathFlag.Name), c.String(dbConfigFlag.Name))
+	defer db.Close()
+	chaindb := rawdb.NewDatabase(evm.Database{Database: prefixdb.NewNested([]byte("ethdb"), db)})
+
+	config, err := chainConfig(c, chaindb)
+	if err != nil {
+		return err
+	}
+	from, to := c.Uint64(fromFlag.Name), c.Uint64(toFlag.Name)
+	if !c.IsSet(toFlag.Name) {
+		head := rawdb.ReadHeadBlockHash(chaindb)
+		number := rawdb.ReadHeaderNumber(chaindb, head)
+		if number == nil {
+			return fmt.Errorf("database has no last accepted block")
+		}
+		to = *number
+	}
+	if from == 0 || from > to {
+		return fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+
+	var r reporter
+	switch format := c.String(formatFlag.Name); format {
+	case formatCSV:
+		if r, err = newCSVReporter(); err != nil {
+			return err
+		}
+	case formatJSON:
+		r = &jsonReporter{enc: json.NewEncoder(os.Stdout)}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	replayer := core.NewReplayer(config, dummy.NewFaker(), chaindb, c.Bool(precompileGasFlag.Name))
+	defer replayer.Close()
+
+	var (
+		start                         = time.Now()
+		logged                        = start
+		gasUsed                       uint64
+		execution, validation, commit time.Duration
+	)
+	for number := from; number <= to; number++ {
+		hash := rawdb.ReadCanonicalHash(chaindb, number)
+		block := rawdb.ReadBlock(chaindb, hash, number)
+		if block == nil {
+			return fmt.Errorf("missing accepted block %d", number)
+		}
+		stats, err := replayer.Replay(block)
+		if err != nil {
+			return fmt.Errorf("failed to replay block %d (%s): %w", number, hash, err)
+		}
+		if err := r.Report(stats); err != nil {
+			return err
+		}
+		gasUsed += stats.GasUsed
+		execution += stats.Execution
+		validation += stats.Validation
+		commit += stats.Commit
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Replaying blocks", "number", number, "remaining", to-number, "elapsed", time.Since(start))
+			logged = time.Now()
+		}
+	}
+	if err := r.Flush(); err != nil {
+		return err
+	}
+
+	var mgasps float64
+	if total := execution + validation + commit; total > 0 {
+		mgasps = float64(gasUsed) / 1e6 / total.Seconds()
+	}
+	log.Info("Replayed blocks", "from", from, "to", to, "gasUsed", gasUsed, "execution", execution, "validation", validation, "commit", commit, "mgasps", mgasps, "elapsed", time.Since(start))
+	return nil
+}
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
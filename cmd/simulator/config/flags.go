@@ -29,12 +29,22 @@ const (
 	BatchSizeKey      = "batch-size"
 	MetricsPortKey    = "metrics-port"
 	MetricsOutputKey  = "metrics-output"
+
+	ScenarioFileKey = "scenario-file"
+
+	WarpEndpointsKey   = "warp-endpoints"
+	WarpSourceURIKey   = "warp-source-uri"
+	WarpSourceChainKey = "warp-source-chain"
+	WarpSubnetIDKey    = "warp-subnet-id"
+	WarpTPSKey         = "warp-tps"
 )
 
 var (
 	ErrNoEndpoints = errors.New("must specify at least one endpoint")
 	ErrNoWorkers   = errors.New("must specify non-zero number of workers")
 	ErrNoTxs       = errors.New("must specify non-zero number of txs-per-worker")
+
+	ErrNoWarpSource = errors.New("must specify warp-source-uri and warp-source-chain with warp-endpoints")
 )
 
 type Config struct {
@@ -48,6 +58,15 @@ type Config struct {
 	BatchSize     uint64        `json:"batch-size"`
 	MetricsPort   uint64        `json:"metrics-port"`
 	MetricsOutput string        `json:"metrics-output"`
+	ScenarioFile  string        `json:"scenario-file"`
+
+	// Warp workload, enabled by setting [WarpEndpoints] to the endpoints of the
+	// chain receiving the warp messages sent on [Endpoints].
+	WarpEndpoints   []string `json:"warp-endpoints"`
+	WarpSourceURI   string   `json:"warp-source-uri"`
+	WarpSourceChain string   `json:"warp-source-chain"`
+	WarpSubnetID    string   `json:"warp-subnet-id"`
+	WarpTPS         float64  `json:"warp-tps"`
 }
 
 func BuildConfig(v *viper.Viper) (Config, error) {
@@ -62,6 +81,13 @@ func BuildConfig(v *viper.Viper) (Config, error) {
 		BatchSize:     v.GetUint64(BatchSizeKey),
 		MetricsPort:   v.GetUint64(MetricsPortKey),
 		MetricsOutput: v.GetString(MetricsOutputKey),
+		ScenarioFile:  v.GetString(ScenarioFileKey),
+
+		WarpEndpoints:   v.GetStringSlice(WarpEndpointsKey),
+		WarpSourceURI:   v.GetString(WarpSourceURIKey),
+		WarpSourceChain: v.GetString(WarpSourceChainKey),
+		WarpSubnetID:    v.GetString(WarpSubnetIDKey),
+		WarpTPS:         v.GetFloat64(WarpTPSKey),
 	}
 	if len(c.Endpoints) == 0 {
 		return c, ErrNoEndpoints
@@ -80,6 +106,12 @@ func BuildConfig(v *viper.Viper) (Config, error) {
 	if c.MaxTipCap < 0 {
 		return c, fmt.Errorf("invalid max tip cap %d <= 0", c.MaxTipCap)
 	}
+	if len(c.WarpEndpoints) > 0 && (c.WarpSourceURI == "" || c.WarpSourceChain == "") {
+		return c, ErrNoWarpSource
+	}
+	if c.WarpTPS < 0 {
+		return c, fmt.Errorf("invalid warp tps %f < 0", c.WarpTPS)
+	}
 	return c, nil
 }
 
@@ -126,4 +158,10 @@ func addSimulatorFlags(fs *pflag.FlagSet) {
 	fs.Uint64(BatchSizeKey, 100, "Specify the batchsize for the worker to issue and confirm txs")
 	fs.Uint64(MetricsPortKey, 8082, "Specify the port to use for the metrics server")
 	fs.String(MetricsOutputKey, "", "Specify the file to write metrics in json format, or empy to write to stdout (defaults to stdout)")
+	fs.String(ScenarioFileKey, "", "Specify the YAML file of the scenarios of the transactions to issue, instead of value transfers (the key pools of the scenarios set the number of workers)")
+	fs.StringSlice(WarpEndpointsKey, nil, "Specify a comma separated list of RPC Websocket Endpoints of the chain to deliver warp messages sent on the endpoints to (enables the warp workload)")
+	fs.String(WarpSourceURIKey, "", "Specify the URI of a node validating the chain of the endpoints, used to aggregate the signatures of warp messages (e.g. http://127.0.0.1:9650)")
+	fs.String(WarpSourceChainKey, "", "Specify the blockchain ID of the chain of the endpoints, sending the warp messages")
+	fs.String(WarpSubnetIDKey, "", "Specify the subnet ID whose validators sign the warp messages, if not the one of the sending chain (e.g. the receiving subnet for messages sent from the C-Chain)")
+	fs.Float64(WarpTPSKey, 0, "Specify the total rate of warp messages sent per second across all workers (0 indicates no limit)")
 }
@@ -123,12 +123,12 @@ func (l *Loader[T]) ConfirmReachedTip(ctx context.Context) error {
 	return eg.Wait()
 }
 
-// ExecuteLoader creates txSequences from [config] and has txAgents execute the specified simulation.
-func ExecuteLoader(ctx context.Context, config config.Config) error {
+// simulationContext returns a context cancelled after [config.Timeout], if set,
+// or when the simulator is interrupted.
+func simulationContext(ctx context.Context, config config.Config) (context.Context, context.CancelFunc) {
+	var timeoutCancel context.CancelFunc = func() {}
 	if config.Timeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
-		defer cancel()
+		ctx, timeoutCancel = context.WithTimeout(ctx, config.Timeout)
 	}
 
 	// Create buffered sigChan to receive SIGINT notifications
@@ -148,39 +148,65 @@ func ExecuteLoader(ctx context.Context, config config.Config) error {
 		// Cancel the child context and end all processes
 		cancel()
 	}()
+	return ctx, func() {
+		cancel()
+		timeoutCancel()
+	}
+}
 
-	m := metrics.NewDefaultMetrics()
-	metricsCtx := context.Background()
-	ms := m.Serve(metricsCtx, strconv.Itoa(int(config.MetricsPort)), MetricsEndpoint)
-	defer ms.Shutdown()
+// loadKeys loads the keys in [config.KeyDir], generating and saving new ones
+// until there are at least [config.Workers] keys.
+func loadKeys(ctx context.Context, config config.Config) ([]*key.Key, error) {
+	keys, err := key.LoadAll(ctx, config.KeyDir)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; len(keys) < config.Workers; i++ {
+		newKey, err := key.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %d new key: %w", i, err)
+		}
+		if err := newKey.Save(config.KeyDir); err != nil {
+			return nil, fmt.Errorf("failed to save %d new key: %w", i, err)
+		}
+		keys = append(keys, newKey)
+	}
+	return keys, nil
+}
 
-	// Construct the arguments for the load simulator
-	clients := make([]ethclient.Client, 0, len(config.Endpoints))
-	for i := 0; i < config.Workers; i++ {
-		clientURI := config.Endpoints[i%len(config.Endpoints)]
+// dialClients returns [numClients] clients, dialing [endpoints] round-robin.
+func dialClients(endpoints []string, numClients int) ([]ethclient.Client, error) {
+	clients := make([]ethclient.Client, 0, numClients)
+	for i := 0; i < numClients; i++ {
+		clientURI := endpoints[i%len(endpoints)]
 		client, err := ethclient.Dial(clientURI)
 		if err != nil {
-			return fmt.Errorf("failed to dial client at %s: %w", clientURI, err)
+			return nil, fmt.Errorf("failed to dial client at %s: %w", clientURI, err)
 		}
 		clients = append(clients, client)
 	}
+	return clients, nil
+}
 
-	keys, err := key.LoadAll(ctx, config.KeyDir)
+// ExecuteLoader creates txSequences from [config] and has txAgents execute the specified simulation.
+func ExecuteLoader(ctx context.Context, config config.Config) error {
+	ctx, cancel := simulationContext(ctx, config)
+	defer cancel()
+
+	m := metrics.NewDefaultMetrics()
+	metricsCtx := context.Background()
+	ms := m.Serve(metricsCtx, strconv.Itoa(int(config.MetricsPort)), MetricsEndpoint)
+	defer ms.Shutdown()
+
+	// Construct the arguments for the load simulator
+	clients, err := dialClients(config.Endpoints, config.Workers)
 	if err != nil {
 		return err
 	}
-	// Ensure there are at least [config.Workers] keys and save any newly generated ones.
-	if len(keys) < config.Workers {
-		for i := 0; len(keys) < config.Workers; i++ {
-			newKey, err := key.Generate()
-			if err != nil {
-				return fmt.Errorf("failed to generate %d new key: %w", i, err)
-			}
-			if err := newKey.Save(config.KeyDir); err != nil {
-				return fmt.Errorf("failed to save %d new key: %w", i, err)
-			}
-			keys = append(keys, newKey)
-		}
+
+	keys, err := loadKeys(ctx, config)
+	if err != nil {
+		return err
 	}
 
 	// Each address needs: params.GWei * MaxFeeCap * params.TxGas * TxsPerWorker total wei
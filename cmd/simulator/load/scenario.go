@@ -0,0 +1,187 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/scenario"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ExecuteScenarioLoader has the keys of the key pools of the scenario file
+// [config.ScenarioFile] issue [config.TxsPerWorker] transactions each, picking
+// the scenario of each transaction in proportion to the scenario weights.
+func ExecuteScenarioLoader(ctx context.Context, config config.Config) error {
+	scenarios, err := scenario.Load(config.ScenarioFile)
+	if err != nil {
+		return err
+	}
+	// The key pools set the number of workers.
+	config.Workers = scenarios.NumKeys()
+
+	ctx, cancel := simulationContext(ctx, config)
+	defer cancel()
+
+	m := metrics.NewDefaultMetrics()
+	metricsCtx := context.Background()
+	ms := m.Serve(metricsCtx, strconv.Itoa(int(config.MetricsPort)), MetricsEndpoint)
+	defer ms.Shutdown()
+
+	clients, err := dialClients(config.Endpoints, config.Workers)
+	if err != nil {
+		return err
+	}
+	client := clients[0]
+
+	keys, err := loadKeys(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	bigGwei := big.NewInt(params.GWei)
+	gasTipCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxTipCap))
+	gasFeeCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxFeeCap))
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch chainID: %w", err)
+	}
+
+	// Each address needs enough funds for the gas of all of its transactions at
+	// the highest gas limit of the scenarios, in addition to the gas of the
+	// deployments and mints preparing them.
+	setupGas := uint64(2*scenario.DeployGas + 2*scenario.MintGas)
+	minFundsPerAddr := new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(config.TxsPerWorker*scenarios.MaxGas()+setupGas))
+	fundStart := time.Now()
+	log.Info("Distributing funds", "numTxsPerWorker", config.TxsPerWorker, "minFunds", minFundsPerAddr)
+	keys, err = DistributeFunds(ctx, client, keys, config.Workers, minFundsPerAddr, m)
+	if err != nil {
+		return err
+	}
+	log.Info("Distributed funds successfully", "time", time.Since(fundStart))
+
+	pks := make([]*ecdsa.PrivateKey, 0, len(keys))
+	addresses := make([]common.Address, 0, len(keys))
+	for _, key := range keys {
+		pks = append(pks, key.PrivKey)
+		addresses = append(addresses, key.Address)
+	}
+
+	contracts, err := deployScenarioContracts(ctx, client, keys[0], scenarios, chainID, gasFeeCap, gasTipCap)
+	if err != nil {
+		return err
+	}
+	generator := scenario.NewGenerator(scenarios, contracts, chainID, gasFeeCap, gasTipCap, addresses)
+
+	workers := make([]txs.Worker[*types.Transaction], 0, len(clients))
+	for i, client := range clients {
+		workers = append(workers, NewSingleAddressTxWorker(ctx, client, addresses[i]))
+	}
+
+	log.Info("Minting tokens of the scenarios...")
+	mintStart := time.Now()
+	mintSequences := make([]txs.TxSequence[*types.Transaction], 0, len(pks))
+	for i, pk := range pks {
+		targets := generator.MintTargets(i)
+		mintSequence, err := txs.GenerateTxSequence(ctx, func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+			tx, err := generator.MintTx(key, nonce, targets[0])
+			targets = targets[1:]
+			return tx, err
+		}, client, pk, uint64(len(targets)), false)
+		if err != nil {
+			return err
+		}
+		mintSequences = append(mintSequences, mintSequence)
+	}
+	if err := New(workers, mintSequences, config.BatchSize, m).Execute(ctx); err != nil {
+		return err
+	}
+	log.Info("Minted tokens successfully", "time", time.Since(mintStart))
+
+	log.Info("Creating transaction sequences...")
+	txSequenceStart := time.Now()
+	txSequences := make([]txs.TxSequence[*types.Transaction], 0, len(pks))
+	for i, pk := range pks {
+		txSequence, err := txs.GenerateTxSequence(ctx, generator.ForKey(i), client, pk, config.TxsPerWorker, false)
+		if err != nil {
+			return fmt.Errorf("failed to generate tx sequence at index %d: %w", i, err)
+		}
+		txSequences = append(txSequences, txSequence)
+	}
+	for name, count := range generator.Counts {
+		log.Info("Created scenario transactions", "scenario", name, "numTxs", count)
+	}
+	log.Info("Created transaction sequences successfully", "time", time.Since(txSequenceStart))
+
+	loader := New(workers, txSequences, config.BatchSize, m)
+	err = loader.Execute(ctx)
+	prerr := m.Print(config.MetricsOutput) // Print regardless of execution error
+	if prerr != nil {
+		log.Warn("Failed to print metrics", "error", prerr)
+	}
+	return err
+}
+
+// deployScenarioContracts deploys the contracts shared by [scenarios] from
+// [deployer] and waits for their acceptance.
+func deployScenarioContracts(ctx context.Context, client ethclient.Client, deployer *key.Key, scenarios *scenario.Config, chainID, gasFeeCap, gasTipCap *big.Int) (scenario.Contracts, error) {
+	var contracts scenario.Contracts
+	nonce, err := client.NonceAt(ctx, deployer.Address, nil)
+	if err != nil {
+		return contracts, err
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	deploy := func(code []byte) (common.Address, error) {
+		tx, err := types.SignNewTx(deployer.PrivKey, signer, &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       scenario.DeployGas,
+			Data:      code,
+			Value:     common.Big0,
+		})
+		if err != nil {
+			return common.Address{}, err
+		}
+		if err := client.SendTransaction(ctx, tx); err != nil {
+			return common.Address{}, fmt.Errorf("failed to issue deployment %s: %w", tx.Hash(), err)
+		}
+		receipt, err := client.WaitForTxAcceptance(ctx, tx.Hash())
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to confirm deployment %s: %w", tx.Hash(), err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return common.Address{}, fmt.Errorf("deployment %s failed", tx.Hash())
+		}
+		nonce++
+		return receipt.ContractAddress, nil
+	}
+	if scenarios.Uses(scenario.TypeERC20) {
+		if contracts.Token, err = deploy(scenario.TokenCode()); err != nil {
+			return contracts, err
+		}
+		log.Info("Deployed token", "address", contracts.Token)
+	}
+	if scenarios.Uses(scenario.TypeSwap) {
+		if contracts.Pair, err = deploy(scenario.PairCode()); err != nil {
+			return contracts, err
+		}
+		log.Info("Deployed pair", "address", contracts.Pair)
+	}
+	return contracts, nil
+}
@@ -0,0 +1,384 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/predicate"
+	warpBackend "github.com/ava-labs/subnet-evm/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// warpSendGas is the gas limit of the transactions sending warp messages.
+	warpSendGas = 200_000
+	// warpDeliveryGas is the gas budgeted to fund each delivery of a warp
+	// message, whose gas limit is estimated since the predicate gas depends on
+	// the configuration of the receiving chain.
+	warpDeliveryGas = 1_000_000
+)
+
+var (
+	errNoWarpMessage     = errors.New("no warp message sent")
+	errNoPredicateResult = errors.New("block does not contain predicate results")
+)
+
+// warpWorker sends warp messages from the address of its key on the sending
+// chain and delivers them from the same address on the receiving chain.
+type warpWorker struct {
+	key         *key.Key
+	source      ethclient.Client
+	destination ethclient.Client
+	warpClient  warpBackend.Client
+	subnetID    string
+
+	sourceSigner      types.Signer
+	destinationSigner types.Signer
+	gasFeeCap         *big.Int
+	gasTipCap         *big.Int
+
+	sourceNonce uint64
+
+	// Deliveries are issued concurrently, so their nonces are assigned under
+	// [destinationNonceLock].
+	destinationNonceLock sync.Mutex
+	destinationNonce     uint64
+
+	limiter *rate.Limiter
+	metrics *metrics.WarpMetrics
+}
+
+// Execute sends [numMessages] warp messages at the rate allowed by the
+// limiter shared by all the workers, and delivers each of them as soon as it is
+// accepted on the sending chain.
+func (w *warpWorker) Execute(ctx context.Context, numMessages uint64) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for i := uint64(0); i < numMessages; i++ {
+		if err := w.limiter.Wait(ctx); err != nil {
+			eg.Go(func() error { return err })
+			break
+		}
+		payload := []byte(fmt.Sprintf("simulator %s-%d", w.key.Address, i))
+		start := time.Now()
+		tx, err := w.send(ctx, payload)
+		if err != nil {
+			eg.Go(func() error { return err })
+			break
+		}
+		eg.Go(func() error {
+			return w.deliver(ctx, tx, start)
+		})
+	}
+	return eg.Wait()
+}
+
+// send issues the transaction sending a warp message with [payload] on the
+// sending chain.
+func (w *warpWorker) send(ctx context.Context, payload []byte) (*types.Transaction, error) {
+	data, err := warp.PackSendWarpMessage(payload)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := types.SignNewTx(w.key.PrivKey, w.sourceSigner, &types.DynamicFeeTx{
+		ChainID:   w.sourceSigner.ChainID(),
+		Nonce:     w.sourceNonce,
+		GasTipCap: w.gasTipCap,
+		GasFeeCap: w.gasFeeCap,
+		Gas:       warpSendGas,
+		To:        &warp.Module.Address,
+		Data:      data,
+		Value:     common.Big0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.source.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to issue warp message tx %s: %w", tx.Hash(), err)
+	}
+	w.sourceNonce++
+	return tx, nil
+}
+
+// deliver waits for the acceptance of [sendTx], issued at [start], aggregates
+// the signatures of its warp message and delivers it on the receiving chain.
+func (w *warpWorker) deliver(ctx context.Context, sendTx *types.Transaction, start time.Time) error {
+	receipt, err := w.source.WaitForTxAcceptance(ctx, sendTx.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to confirm warp message tx %s: %w", sendTx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("warp message tx %s failed", sendTx.Hash())
+	}
+	w.metrics.SendTimes.Observe(time.Since(start).Seconds())
+
+	var unsignedMessageLog *types.Log
+	for _, l := range receipt.Logs {
+		if l.Address == warp.Module.Address {
+			unsignedMessageLog = l
+			break
+		}
+	}
+	if unsignedMessageLog == nil {
+		return fmt.Errorf("%w by tx %s", errNoWarpMessage, sendTx.Hash())
+	}
+	unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(unsignedMessageLog.Data)
+	if err != nil {
+		return err
+	}
+
+	aggregationStart := time.Now()
+	signedMessageBytes, err := w.warpClient.GetMessageAggregateSignature(ctx, unsignedMessage.ID(), warp.WarpDefaultQuorumNumerator, w.subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate signatures of warp message %s: %w", unsignedMessage.ID(), err)
+	}
+	w.metrics.AggregationTimes.Observe(time.Since(aggregationStart).Seconds())
+
+	deliveryStart := time.Now()
+	tx, err := w.deliveryTx(ctx, signedMessageBytes)
+	if err != nil {
+		return err
+	}
+	if err := w.destination.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("failed to issue delivery tx %s: %w", tx.Hash(), err)
+	}
+	receipt, err = w.destination.WaitForTxAcceptance(ctx, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to confirm delivery tx %s: %w", tx.Hash(), err)
+	}
+	w.metrics.DeliveryTimes.Observe(time.Since(deliveryStart).Seconds())
+	w.metrics.EndToEndTimes.Observe(time.Since(start).Seconds())
+
+	verified, err := w.isPredicateVerified(ctx, receipt)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		log.Warn("Delivered warp message failed verification", "messageID", unsignedMessage.ID(), "txHash", tx.Hash())
+		w.metrics.Failed.Inc()
+		return nil
+	}
+	w.metrics.Delivered.Inc()
+	return nil
+}
+
+// deliveryTx returns the signed transaction delivering [signedMessageBytes]
+// on the receiving chain, with the gas limit estimated by the receiving chain.
+func (w *warpWorker) deliveryTx(ctx context.Context, signedMessageBytes []byte) (*types.Transaction, error) {
+	data, err := warp.PackGetVerifiedWarpMessage(0)
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx := predicate.NewPredicateTx(
+		w.destinationSigner.ChainID(),
+		0,
+		&warp.Module.Address,
+		0,
+		w.gasFeeCap,
+		w.gasTipCap,
+		common.Big0,
+		data,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessageBytes,
+	)
+	gas, err := w.destination.EstimateGas(ctx, interfaces.CallMsg{
+		From:       w.key.Address,
+		To:         unsignedTx.To(),
+		GasFeeCap:  w.gasFeeCap,
+		GasTipCap:  w.gasTipCap,
+		Data:       data,
+		AccessList: unsignedTx.AccessList(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate delivery gas: %w", err)
+	}
+
+	w.destinationNonceLock.Lock()
+	defer w.destinationNonceLock.Unlock()
+
+	tx, err := types.SignNewTx(w.key.PrivKey, w.destinationSigner, &types.DynamicFeeTx{
+		ChainID:    w.destinationSigner.ChainID(),
+		Nonce:      w.destinationNonce,
+		GasTipCap:  w.gasTipCap,
+		GasFeeCap:  w.gasFeeCap,
+		Gas:        gas,
+		To:         unsignedTx.To(),
+		Data:       data,
+		Value:      common.Big0,
+		AccessList: unsignedTx.AccessList(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	w.destinationNonce++
+	return tx, nil
+}
+
+// isPredicateVerified returns true if the warp predicate of the transaction in
+// [receipt] passed verification when it was accepted.
+func (w *warpWorker) isPredicateVerified(ctx context.Context, receipt *types.Receipt) (bool, error) {
+	header, err := w.destination.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch header %d: %w", receipt.BlockNumber, err)
+	}
+	resultBytes, ok := predicate.GetPredicateResultBytes(header.Extra)
+	if !ok {
+		return false, fmt.Errorf("%w: %d", errNoPredicateResult, receipt.BlockNumber)
+	}
+	results, err := predicate.ParseResults(resultBytes)
+	if err != nil {
+		return false, err
+	}
+	// Failed predicates are marked by setting their bit in the results bitset.
+	return !set.BitsFromBytes(results.GetResults(receipt.TxHash, warp.ContractAddress)).Contains(0), nil
+}
+
+// warpSettings are the settings of the warp workload derived from the config of
+// the simulator.
+type warpSettings struct {
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+	// The key of each worker needs funds on both chains for the gas of all of
+	// its messages.
+	sourceFunds      *big.Int
+	destinationFunds *big.Int
+	// limiter is shared by all the workers to send at most [config.WarpTPS]
+	// messages per second in total.
+	limiter *rate.Limiter
+}
+
+func newWarpSettings(config config.Config) warpSettings {
+	bigGwei := big.NewInt(params.GWei)
+	gasFeeCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxFeeCap))
+	limit := rate.Limit(config.WarpTPS)
+	if config.WarpTPS == 0 {
+		limit = rate.Inf
+	}
+	return warpSettings{
+		gasFeeCap:        gasFeeCap,
+		gasTipCap:        new(big.Int).Mul(bigGwei, big.NewInt(config.MaxTipCap)),
+		sourceFunds:      new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(config.TxsPerWorker*warpSendGas)),
+		destinationFunds: new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(config.TxsPerWorker*warpDeliveryGas)),
+		limiter:          rate.NewLimiter(limit, int(math.Max(1, config.WarpTPS))),
+	}
+}
+
+// ExecuteWarpLoader has [config.Workers] workers send [config.TxsPerWorker]
+// warp messages each on the chain of [config.Endpoints], at a total rate of
+// [config.WarpTPS], and deliver them on the chain of [config.WarpEndpoints].
+func ExecuteWarpLoader(ctx context.Context, config config.Config) error {
+	ctx, cancel := simulationContext(ctx, config)
+	defer cancel()
+
+	m := metrics.NewDefaultMetrics()
+	wm := m.NewWarpMetrics()
+	metricsCtx := context.Background()
+	ms := m.Serve(metricsCtx, strconv.Itoa(int(config.MetricsPort)), MetricsEndpoint)
+	defer ms.Shutdown()
+
+	sources, err := dialClients(config.Endpoints, config.Workers)
+	if err != nil {
+		return err
+	}
+	destinations, err := dialClients(config.WarpEndpoints, config.Workers)
+	if err != nil {
+		return err
+	}
+	warpClient, err := warpBackend.NewClient(config.WarpSourceURI, config.WarpSourceChain)
+	if err != nil {
+		return err
+	}
+
+	keys, err := loadKeys(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	settings := newWarpSettings(config)
+	fundStart := time.Now()
+	log.Info("Distributing funds on sending chain", "numTxsPerWorker", config.TxsPerWorker, "minFunds", settings.sourceFunds)
+	keys, err = DistributeFunds(ctx, sources[0], keys, config.Workers, settings.sourceFunds, m)
+	if err != nil {
+		return err
+	}
+	log.Info("Distributing funds on receiving chain", "numTxsPerWorker", config.TxsPerWorker, "minFunds", settings.destinationFunds)
+	if _, err := DistributeFunds(ctx, destinations[0], keys, config.Workers, settings.destinationFunds, m); err != nil {
+		return err
+	}
+	log.Info("Distributed funds successfully", "time", time.Since(fundStart))
+
+	sourceChainID, err := sources[0].ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sending chainID: %w", err)
+	}
+	destinationChainID, err := destinations[0].ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch receiving chainID: %w", err)
+	}
+
+	workers := make([]*warpWorker, 0, config.Workers)
+	for i, key := range keys {
+		sourceNonce, err := sources[i].NonceAt(ctx, key.Address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch nonce of %s on sending chain: %w", key.Address, err)
+		}
+		destinationNonce, err := destinations[i].NonceAt(ctx, key.Address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch nonce of %s on receiving chain: %w", key.Address, err)
+		}
+		workers = append(workers, &warpWorker{
+			key:               key,
+			source:            sources[i],
+			destination:       destinations[i],
+			warpClient:        warpClient,
+			subnetID:          config.WarpSubnetID,
+			sourceSigner:      types.LatestSignerForChainID(sourceChainID),
+			destinationSigner: types.LatestSignerForChainID(destinationChainID),
+			gasFeeCap:         settings.gasFeeCap,
+			gasTipCap:         settings.gasTipCap,
+			sourceNonce:       sourceNonce,
+			destinationNonce:  destinationNonce,
+			limiter:           settings.limiter,
+			metrics:           wm,
+		})
+	}
+
+	log.Info("Starting warp workers...", "numWorkers", len(workers), "tps", config.WarpTPS)
+	eg := errgroup.Group{}
+	for _, worker := range workers {
+		worker := worker
+		eg.Go(func() error {
+			return worker.Execute(ctx, config.TxsPerWorker)
+		})
+	}
+	err = eg.Wait()
+	if err == nil {
+		log.Info("Warp workers completed successfully.")
+	}
+	prerr := m.Print(config.MetricsOutput) // Print regardless of execution error
+	if prerr != nil {
+		log.Warn("Failed to print metrics", "error", prerr)
+	}
+	return err
+}
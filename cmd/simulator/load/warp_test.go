@@ -0,0 +1,82 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func buildConfig(t *testing.T, args ...string) (config.Config, error) {
+	v, err := config.BuildViper(config.BuildFlagSet(), args)
+	require.NoError(t, err)
+	return config.BuildConfig(v)
+}
+
+func TestWarpConfig(t *testing.T) {
+	require := require.New(t)
+
+	c, err := buildConfig(t,
+		"--endpoints=ws://127.0.0.1:9650/ext/bc/A/ws",
+		"--warp-endpoints=ws://127.0.0.1:9650/ext/bc/B/ws,ws://127.0.0.1:9652/ext/bc/B/ws",
+		"--warp-source-uri=http://127.0.0.1:9650",
+		"--warp-source-chain=A",
+		"--warp-tps=2.5",
+		"--workers=2",
+		"--txs-per-worker=10",
+		"--max-fee-cap=30",
+		"--max-tip-cap=2",
+	)
+	require.NoError(err)
+	require.Equal([]string{"ws://127.0.0.1:9650/ext/bc/B/ws", "ws://127.0.0.1:9652/ext/bc/B/ws"}, c.WarpEndpoints)
+	require.Equal("http://127.0.0.1:9650", c.WarpSourceURI)
+	require.Equal("A", c.WarpSourceChain)
+	require.Empty(c.WarpSubnetID)
+	require.Equal(2.5, c.WarpTPS)
+
+	settings := newWarpSettings(c)
+	gwei := big.NewInt(params.GWei)
+	require.Equal(new(big.Int).Mul(gwei, big.NewInt(30)), settings.gasFeeCap)
+	require.Equal(new(big.Int).Mul(gwei, big.NewInt(2)), settings.gasTipCap)
+	require.Equal(new(big.Int).Mul(settings.gasFeeCap, big.NewInt(10*warpSendGas)), settings.sourceFunds)
+	require.Equal(new(big.Int).Mul(settings.gasFeeCap, big.NewInt(10*warpDeliveryGas)), settings.destinationFunds)
+	require.Equal(rate.Limit(2.5), settings.limiter.Limit())
+	require.Equal(2, settings.limiter.Burst())
+
+	// The warp workload can be configured in the config file, and sends
+	// without a rate limit by default.
+	path := filepath.Join(t.TempDir(), "simulator.yaml")
+	require.NoError(os.WriteFile(path, []byte(`
+endpoints: ["ws://127.0.0.1:9650/ext/bc/A/ws"]
+warp-endpoints: ["ws://127.0.0.1:9650/ext/bc/B/ws"]
+warp-source-uri: http://127.0.0.1:9650
+warp-source-chain: A
+warp-subnet-id: S
+`), 0o600))
+	c, err = buildConfig(t, "--config-file="+path)
+	require.NoError(err)
+	require.Equal([]string{"ws://127.0.0.1:9650/ext/bc/B/ws"}, c.WarpEndpoints)
+	require.Equal("S", c.WarpSubnetID)
+	settings = newWarpSettings(c)
+	require.Equal(rate.Inf, settings.limiter.Limit())
+	require.Equal(1, settings.limiter.Burst())
+
+	// Rates below one message per second still allow sending a message at once.
+	c.WarpTPS = 0.5
+	require.Equal(1, newWarpSettings(c).limiter.Burst())
+
+	_, err = buildConfig(t, "--warp-endpoints=ws://127.0.0.1:9650/ext/bc/B/ws", "--warp-source-uri=http://127.0.0.1:9650")
+	require.ErrorIs(err, config.ErrNoWarpSource)
+	_, err = buildConfig(t, "--warp-endpoints=ws://127.0.0.1:9650/ext/bc/B/ws", "--warp-source-chain=A")
+	require.ErrorIs(err, config.ErrNoWarpSource)
+	_, err = buildConfig(t, "--warp-tps=-1")
+	require.ErrorContains(err, "invalid warp tps")
+}
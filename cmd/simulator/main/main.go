@@ -49,7 +49,14 @@ func main() {
 		fmt.Printf("%s\n", err)
 		os.Exit(1)
 	}
-	if err := load.ExecuteLoader(context.Background(), config); err != nil {
+	execute := load.ExecuteLoader
+	switch {
+	case len(config.WarpEndpoints) > 0:
+		execute = load.ExecuteWarpLoader
+	case config.ScenarioFile != "":
+		execute = load.ExecuteScenarioLoader
+	}
+	if err := execute(context.Background(), config); err != nil {
 		fmt.Printf("load execution failed: %s\n", err)
 		os.Exit(1)
 	}
@@ -57,6 +57,69 @@ func NewMetrics(reg *prometheus.Registry) *Metrics {
 	return m
 }
 
+// WarpMetrics measures the delivery of warp messages from a sending chain to
+// a receiving chain.
+type WarpMetrics struct {
+	// Summary of the quantiles of the times from issuing a warp message on the
+	// sending chain to its acceptance
+	SendTimes prometheus.Summary
+	// Summary of the quantiles of the times to aggregate the signatures of
+	// accepted warp messages
+	AggregationTimes prometheus.Summary
+	// Summary of the quantiles of the times from issuing the delivery of a signed
+	// warp message on the receiving chain to its acceptance
+	DeliveryTimes prometheus.Summary
+	// Summary of the quantiles of the times from issuing a warp message on the
+	// sending chain to the acceptance of its delivery on the receiving chain
+	EndToEndTimes prometheus.Summary
+	// Number of warp messages delivered with a verified predicate
+	Delivered prometheus.Counter
+	// Number of warp messages whose delivery failed predicate verification
+	Failed prometheus.Counter
+}
+
+// NewWarpMetrics creates and returns a WarpMetrics registered with the same
+// Collector as [m].
+func (m *Metrics) NewWarpMetrics() *WarpMetrics {
+	wm := &WarpMetrics{
+		SendTimes: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "warp_send_time",
+			Help:       "Individual Warp Message Issuance To Acceptance Times on the Sending Chain",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		AggregationTimes: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "warp_aggregation_time",
+			Help:       "Individual Warp Message Signature Aggregation Times",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		DeliveryTimes: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "warp_delivery_time",
+			Help:       "Individual Warp Message Delivery Issuance To Acceptance Times on the Receiving Chain",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		EndToEndTimes: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "warp_end_to_end_time",
+			Help:       "Individual Warp Message Send Issuance To Delivery Acceptance Times",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		Delivered: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "warp_delivered",
+			Help: "Number of Warp Messages Delivered with a Verified Predicate",
+		}),
+		Failed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "warp_failed",
+			Help: "Number of Warp Messages Delivered with a Failed Predicate",
+		}),
+	}
+	m.reg.MustRegister(wm.SendTimes)
+	m.reg.MustRegister(wm.AggregationTimes)
+	m.reg.MustRegister(wm.DeliveryTimes)
+	m.reg.MustRegister(wm.EndToEndTimes)
+	m.reg.MustRegister(wm.Delivered)
+	m.reg.MustRegister(wm.Failed)
+	return wm
+}
+
 type MetricsServer struct {
 	metricsPort     string
 	metricsEndpoint string
@@ -130,6 +193,15 @@ func (m *Metrics) Print(outputFile string) error {
 		}
 		defer jsonFile.Close()
 
+		// The quantiles of the summaries without observations, such as those of
+		// the workload that is not run, are NaN, which JSON cannot encode.
+		for _, mf := range metrics {
+			for _, m := range mf.GetMetric() {
+				if summary := m.GetSummary(); summary != nil && summary.GetSampleCount() == 0 {
+					summary.Quantile = nil
+				}
+			}
+		}
 		if err := json.NewEncoder(jsonFile).Encode(metrics); err != nil {
 			return err
 		}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpMetrics(t *testing.T) {
+	require := require.New(t)
+
+	m := NewDefaultMetrics()
+	wm := m.NewWarpMetrics()
+
+	// Two messages are delivered, and one of them fails verification.
+	for _, latencies := range []struct{ send, aggregation, delivery float64 }{
+		{send: 1, aggregation: 0.25, delivery: 2},
+		{send: 3, aggregation: 0.75, delivery: 4},
+	} {
+		wm.SendTimes.Observe(latencies.send)
+		wm.AggregationTimes.Observe(latencies.aggregation)
+		wm.DeliveryTimes.Observe(latencies.delivery)
+		wm.EndToEndTimes.Observe(latencies.send + latencies.aggregation + latencies.delivery)
+	}
+	wm.Delivered.Inc()
+	wm.Failed.Inc()
+
+	summaries := make(map[string]*dto.Summary)
+	families, err := m.reg.Gather()
+	require.NoError(err)
+	for _, mf := range families {
+		if summary := mf.GetMetric()[0].GetSummary(); summary != nil {
+			summaries[mf.GetName()] = summary
+		}
+	}
+	for name, sum := range map[string]float64{
+		"warp_send_time":        4,
+		"warp_aggregation_time": 1,
+		"warp_delivery_time":    6,
+		"warp_end_to_end_time":  11,
+	} {
+		require.Contains(summaries, name)
+		require.Equal(uint64(2), summaries[name].GetSampleCount(), name)
+		require.Equal(sum, summaries[name].GetSampleSum(), name)
+	}
+	require.Equal(0.75, summaries["warp_aggregation_time"].GetQuantile()[2].GetValue())
+	require.Equal(1.0, testutil.ToFloat64(wm.Delivered))
+	require.Equal(1.0, testutil.ToFloat64(wm.Failed))
+
+	// The tx summaries without observations are printed along with the warp
+	// metrics.
+	require.Equal(uint64(0), summaries["tx_issuance_time"].GetSampleCount())
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	require.NoError(m.Print(path))
+	b, err := os.ReadFile(path)
+	require.NoError(err)
+	var printed []struct {
+		Name string `json:"name"`
+	}
+	require.NoError(json.Unmarshal(b, &printed))
+	require.Len(printed, len(families))
+
+	// The warp metrics can only be registered once.
+	require.Panics(func() { m.NewWarpMetrics() })
+}
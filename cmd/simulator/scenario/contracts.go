@@ -0,0 +1,110 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/asm"
+)
+
+var (
+	//go:embed contracts/token.evm
+	tokenSource []byte
+	//go:embed contracts/pair.evm
+	pairSource []byte
+
+	// TokenRuntime is the runtime code of the ERC20 token of the erc20 scenario.
+	TokenRuntime = mustCompile(tokenSource)
+	// PairRuntime is the runtime code of the pool of the swap scenario.
+	PairRuntime = mustCompile(pairSource)
+
+	// PairReserve is the initial reserve of both tokens of the pool.
+	PairReserve = new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+
+	transferSelector = common.FromHex("0xa9059cbb") // transfer(address,uint256)
+	mintSelector     = common.FromHex("0xa0712d68") // mint(uint256)
+	swapSelector     = common.FromHex("0xc037646a") // swap(bool,uint256)
+)
+
+// mustCompile returns the bytecode of the EVM assembly [source].
+func mustCompile(source []byte) []byte {
+	compiler := asm.NewCompiler(false)
+	compiler.Feed(asm.Lex(source, false))
+	code, errs := compiler.Compile()
+	if len(errs) != 0 {
+		panic(fmt.Errorf("failed to compile contract: %w", errors.Join(errs...)))
+	}
+	return common.FromHex(code)
+}
+
+// deployCode returns the creation code of a contract with [runtime] code,
+// setting the storage [slots] in order to [values] first.
+func deployCode(runtime []byte, slots []common.Hash, values []common.Hash) []byte {
+	var code []byte
+	for i, slot := range slots {
+		code = append(code, byte(vm.PUSH32))
+		code = append(code, values[i][:]...)
+		code = append(code, byte(vm.PUSH32))
+		code = append(code, slot[:]...)
+		code = append(code, byte(vm.SSTORE))
+	}
+	// Copy the runtime code appended to the creation code to memory and return it.
+	offset := len(code) + 15
+	code = append(code,
+		byte(vm.PUSH4), byte(len(runtime)>>24), byte(len(runtime)>>16), byte(len(runtime)>>8), byte(len(runtime)),
+		byte(vm.DUP1),
+		byte(vm.PUSH2), byte(offset>>8), byte(offset),
+		byte(vm.PUSH1), 0,
+		byte(vm.CODECOPY),
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN),
+	)
+	return append(code, runtime...)
+}
+
+// TokenCode returns the creation code of the ERC20 token.
+func TokenCode() []byte {
+	return deployCode(TokenRuntime, nil, nil)
+}
+
+// PairCode returns the creation code of the pool, with reserves of
+// [PairReserve] of both tokens.
+func PairCode() []byte {
+	reserve := common.BigToHash(PairReserve)
+	return deployCode(PairRuntime, []common.Hash{{}, common.BigToHash(common.Big1)}, []common.Hash{reserve, reserve})
+}
+
+// PackTransfer returns the input of transfer(address,uint256).
+func PackTransfer(to common.Address, amount *big.Int) []byte {
+	return pack(transferSelector, common.BytesToHash(to[:]), common.BigToHash(amount))
+}
+
+// PackMint returns the input of mint(uint256) of both the token and the pool.
+func PackMint(amount *big.Int) []byte {
+	return pack(mintSelector, common.BigToHash(amount))
+}
+
+// PackSwap returns the input of swap(bool,uint256) of the pool.
+func PackSwap(zeroForOne bool, amountIn *big.Int) []byte {
+	var zero common.Hash
+	if zeroForOne {
+		zero = common.BigToHash(common.Big1)
+	}
+	return pack(swapSelector, zero, common.BigToHash(amountIn))
+}
+
+func pack(selector []byte, args ...common.Hash) []byte {
+	data := make([]byte, 0, len(selector)+len(args)*common.HashLength)
+	data = append(data, selector...)
+	for _, arg := range args {
+		data = append(data, arg[:]...)
+	}
+	return data
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gas limits of the transactions of each type of scenario, covering the
+// first write of the storage slots they touch.
+const (
+	ERC20Gas  = 70_000
+	SwapGas   = 150_000
+	DeployGas = 300_000
+	MintGas   = 100_000
+)
+
+var (
+	// MintAmount is the amount of tokens minted to each key before the load.
+	MintAmount = new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+
+	transferAmount = big.NewInt(1)
+	swapAmount     = new(big.Int).Exp(big.NewInt(10), big.NewInt(15), nil)
+)
+
+// Contracts are the addresses of the contracts shared by the scenarios.
+type Contracts struct {
+	Token common.Address // Token of the erc20 scenarios
+	Pair  common.Address // Pool of the swap scenarios
+}
+
+// Generator creates the transactions of the scenarios of a Config.
+type Generator struct {
+	config    *Config
+	contracts Contracts
+	signer    types.Signer
+	chainID   *big.Int
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+
+	// pools are the pools of the keys, in the order of their addresses.
+	pools    []string
+	poolKeys map[string][]common.Address
+
+	// Counts is the number of transactions generated for each scenario.
+	Counts map[string]uint64
+}
+
+// NewGenerator returns a Generator assigning [addresses], in order, to the key
+// pools of [config]. There must be at least [config.NumKeys] addresses.
+func NewGenerator(config *Config, contracts Contracts, chainID, gasFeeCap, gasTipCap *big.Int, addresses []common.Address) *Generator {
+	g := &Generator{
+		config:    config,
+		contracts: contracts,
+		signer:    types.LatestSignerForChainID(chainID),
+		chainID:   chainID,
+		gasFeeCap: gasFeeCap,
+		gasTipCap: gasTipCap,
+		poolKeys:  make(map[string][]common.Address, len(config.KeyPools)),
+		Counts:    make(map[string]uint64, len(config.Scenarios)),
+	}
+	i := 0
+	for _, pool := range config.KeyPools {
+		for j := 0; j < pool.Size; j++ {
+			g.pools = append(g.pools, pool.Name)
+			g.poolKeys[pool.Name] = append(g.poolKeys[pool.Name], addresses[i])
+			i++
+		}
+	}
+	return g
+}
+
+// ForKey returns the generator of the transactions of the key at [index],
+// picking the scenario of each transaction at random in proportion to the
+// weights of the scenarios of its pool. The choices are seeded by [index] so
+// that runs are reproducible.
+//
+// The returned generators must not be called concurrently.
+func (g *Generator) ForKey(index int) txs.CreateTx {
+	var (
+		pool        = g.pools[index]
+		scenarios   []Scenario
+		totalWeight uint64
+	)
+	for _, s := range g.config.Scenarios {
+		if s.KeyPool == pool {
+			scenarios = append(scenarios, s)
+			totalWeight += s.Weight
+		}
+	}
+	rng := rand.New(rand.NewSource(int64(index)))
+	return func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+		pick := uint64(rng.Int63n(int64(totalWeight)))
+		s := scenarios[len(scenarios)-1]
+		for _, candidate := range scenarios {
+			if pick < candidate.Weight {
+				s = candidate
+				break
+			}
+			pick -= candidate.Weight
+		}
+		g.Counts[s.Name]++
+		return g.newTx(s, key, nonce, rng)
+	}
+}
+
+// MintTargets returns the contracts the key at [index] must mint tokens of
+// before executing its scenarios.
+func (g *Generator) MintTargets(index int) []common.Address {
+	var token, pair bool
+	for _, s := range g.config.Scenarios {
+		if s.KeyPool != g.pools[index] {
+			continue
+		}
+		token = token || s.Type == TypeERC20
+		pair = pair || s.Type == TypeSwap
+	}
+	var targets []common.Address
+	if token {
+		targets = append(targets, g.contracts.Token)
+	}
+	if pair {
+		targets = append(targets, g.contracts.Pair)
+	}
+	return targets
+}
+
+// MintTx returns the transaction minting [MintAmount] tokens of [target].
+func (g *Generator) MintTx(key *ecdsa.PrivateKey, nonce uint64, target common.Address) (*types.Transaction, error) {
+	return g.sign(key, nonce, &target, MintGas, common.Big0, PackMint(MintAmount))
+}
+
+func (g *Generator) newTx(s Scenario, key *ecdsa.PrivateKey, nonce uint64, rng *rand.Rand) (*types.Transaction, error) {
+	gas := scenarioGas(s)
+	switch s.Type {
+	case TypeTransfer:
+		to := g.randomPeer(s.KeyPool, rng)
+		return g.sign(key, nonce, &to, gas, transferAmount, nil)
+	case TypeERC20:
+		return g.sign(key, nonce, &g.contracts.Token, gas, common.Big0, PackTransfer(g.randomPeer(s.KeyPool, rng), transferAmount))
+	case TypeSwap:
+		return g.sign(key, nonce, &g.contracts.Pair, gas, common.Big0, PackSwap(rng.Intn(2) == 0, swapAmount))
+	case TypeDeploy:
+		return g.sign(key, nonce, nil, gas, common.Big0, TokenCode())
+	default: // TypeCall
+		to := common.HexToAddress(s.To)
+		return g.sign(key, nonce, &to, gas, common.Big0, common.FromHex(s.Data))
+	}
+}
+
+// randomPeer returns the address of a random key of [pool].
+func (g *Generator) randomPeer(pool string, rng *rand.Rand) common.Address {
+	keys := g.poolKeys[pool]
+	return keys[rng.Intn(len(keys))]
+}
+
+func (g *Generator) sign(key *ecdsa.PrivateKey, nonce uint64, to *common.Address, gas uint64, value *big.Int, data []byte) (*types.Transaction, error) {
+	return types.SignNewTx(key, g.signer, &types.DynamicFeeTx{
+		ChainID:   g.chainID,
+		Nonce:     nonce,
+		GasTipCap: g.gasTipCap,
+		GasFeeCap: g.gasFeeCap,
+		Gas:       gas,
+		To:        to,
+		Data:      data,
+		Value:     value,
+	})
+}
+
+// scenarioGas returns the gas limit of the transactions of [s].
+func scenarioGas(s Scenario) uint64 {
+	switch s.Type {
+	case TypeTransfer:
+		return params.TxGas
+	case TypeERC20:
+		return ERC20Gas
+	case TypeSwap:
+		return SwapGas
+	case TypeDeploy:
+		return DeployGas
+	default: // TypeCall
+		if s.Gas != 0 {
+			return s.Gas
+		}
+		return DefaultCallGas
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package scenario generates the transactions of weighted mixes of workloads,
+// such as ERC20 transfers, swaps, contract deployments and precompile calls,
+// described by a YAML scenario file.
+package scenario
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/viper"
+)
+
+const (
+	TypeTransfer = "transfer" // Native value transfers
+	TypeERC20    = "erc20"    // Transfers of an ERC20 token
+	TypeSwap     = "swap"     // Swaps on a constant product pool
+	TypeDeploy   = "deploy"   // Deployments of an ERC20 token
+	TypeCall     = "call"     // Calls of [Scenario.To] with [Scenario.Data], e.g. of a precompile
+
+	// DefaultCallGas is the gas limit of the calls of a scenario not setting one.
+	DefaultCallGas = 100_000
+)
+
+var (
+	ErrNoScenarios      = errors.New("must specify at least one scenario")
+	ErrUnknownType      = errors.New("unknown scenario type")
+	ErrUnknownKeyPool   = errors.New("unknown key pool")
+	ErrEmptyKeyPool     = errors.New("key pool must have at least one key")
+	ErrDuplicateKeyPool = errors.New("duplicate key pool")
+	ErrNoWeight         = errors.New("scenario weight must be non-zero")
+	ErrNoCallTarget     = errors.New("call scenario must specify to")
+	ErrUnusedKeyPool    = errors.New("key pool is not used by any scenario")
+)
+
+// KeyPool is a set of [Size] keys issuing the transactions of the scenarios
+// using it.
+type KeyPool struct {
+	Name string `mapstructure:"name"`
+	Size int    `mapstructure:"size"`
+}
+
+// Scenario is a kind of transaction issued by the keys of [KeyPool]. Each key
+// picks the scenario of each of its transactions at random, in proportion to
+// the [Weight] of the scenarios using its pool.
+type Scenario struct {
+	Name    string `mapstructure:"name"`
+	Type    string `mapstructure:"type"`
+	Weight  uint64 `mapstructure:"weight"`
+	KeyPool string `mapstructure:"key-pool"`
+
+	// Call scenarios only
+	To   string `mapstructure:"to"`
+	Data string `mapstructure:"data"`
+	Gas  uint64 `mapstructure:"gas"`
+}
+
+// Config is the content of a scenario file.
+type Config struct {
+	KeyPools  []KeyPool  `mapstructure:"key-pools"`
+	Scenarios []Scenario `mapstructure:"scenarios"`
+}
+
+// Load reads and verifies the scenario file at [path], in any format
+// supported by viper such as YAML.
+func Load(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := v.Unmarshal(c); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario file %s: %w", path, err)
+	}
+	for i := range c.Scenarios {
+		if c.Scenarios[i].Name == "" {
+			c.Scenarios[i].Name = c.Scenarios[i].Type
+		}
+	}
+	return c, c.Verify()
+}
+
+// Verify returns an error if the scenarios are invalid.
+func (c *Config) Verify() error {
+	if len(c.Scenarios) == 0 {
+		return ErrNoScenarios
+	}
+	used := make(map[string]bool, len(c.KeyPools))
+	for _, pool := range c.KeyPools {
+		if _, ok := used[pool.Name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateKeyPool, pool.Name)
+		}
+		if pool.Size <= 0 {
+			return fmt.Errorf("%w: %s", ErrEmptyKeyPool, pool.Name)
+		}
+		used[pool.Name] = false
+	}
+	for _, s := range c.Scenarios {
+		switch s.Type {
+		case TypeTransfer, TypeERC20, TypeSwap, TypeDeploy:
+		case TypeCall:
+			if !common.IsHexAddress(s.To) {
+				return fmt.Errorf("%w: %s", ErrNoCallTarget, s.Name)
+			}
+			if _, err := hexutil.Decode(s.Data); s.Data != "" && err != nil {
+				return fmt.Errorf("invalid data of scenario %s: %w", s.Name, err)
+			}
+		default:
+			return fmt.Errorf("%w %q of scenario %s", ErrUnknownType, s.Type, s.Name)
+		}
+		if s.Weight == 0 {
+			return fmt.Errorf("%w: %s", ErrNoWeight, s.Name)
+		}
+		if _, ok := used[s.KeyPool]; !ok {
+			return fmt.Errorf("%w %q of scenario %s", ErrUnknownKeyPool, s.KeyPool, s.Name)
+		}
+		used[s.KeyPool] = true
+	}
+	for name, ok := range used {
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnusedKeyPool, name)
+		}
+	}
+	return nil
+}
+
+// NumKeys returns the total number of keys of the key pools.
+func (c *Config) NumKeys() int {
+	numKeys := 0
+	for _, pool := range c.KeyPools {
+		numKeys += pool.Size
+	}
+	return numKeys
+}
+
+// MaxGas returns the highest gas limit of the transactions of the scenarios.
+func (c *Config) MaxGas() uint64 {
+	maxGas := uint64(0)
+	for _, s := range c.Scenarios {
+		if gas := scenarioGas(s); gas > maxGas {
+			maxGas = gas
+		}
+	}
+	return maxGas
+}
+
+// Uses returns true if any scenario is of type [typ].
+func (c *Config) Uses(typ string) bool {
+	for _, s := range c.Scenarios {
+		if s.Type == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// intrinsicGasMargin covers the intrinsic gas of the transactions, which the
+// runtime doesn't charge.
+const intrinsicGasMargin = 25_000
+
+func newRuntimeConfig(t *testing.T) *runtime.Config {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	return &runtime.Config{State: statedb, GasLimit: 10_000_000}
+}
+
+// call calls [address] with [input] from [from] and returns the output and
+// the gas used.
+func call(t *testing.T, cfg *runtime.Config, from, address common.Address, input []byte) ([]byte, uint64) {
+	cfg.Origin = from
+	ret, leftOverGas, err := runtime.Call(address, input, cfg)
+	require.NoError(t, err)
+	return ret, cfg.GasLimit - leftOverGas
+}
+
+func TestToken(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		cfg   = newRuntimeConfig(t)
+		alice = common.Address{1}
+		bob   = common.Address{2}
+	)
+	_, token, leftOverGas, err := runtime.Create(TokenCode(), cfg)
+	require.NoError(err)
+	require.Equal(TokenRuntime, cfg.State.GetCode(token))
+	require.Less(cfg.GasLimit-leftOverGas+intrinsicGasMargin+32_000, uint64(DeployGas))
+
+	balanceOf := func(addr common.Address) *big.Int {
+		ret, _ := call(t, cfg, alice, token, pack(common.FromHex("0x70a08231"), common.BytesToHash(addr[:])))
+		return new(big.Int).SetBytes(ret)
+	}
+
+	_, gas := call(t, cfg, alice, token, PackMint(MintAmount))
+	require.Less(gas+intrinsicGasMargin, uint64(MintGas))
+	require.Equal(MintAmount, balanceOf(alice))
+	// The balances are stored like in a Solidity mapping at slot 0.
+	slot := crypto.Keccak256Hash(common.BytesToHash(alice[:]).Bytes(), common.Hash{}.Bytes())
+	require.Equal(common.BigToHash(MintAmount), cfg.State.GetState(token, slot))
+
+	ret, gas := call(t, cfg, alice, token, PackTransfer(bob, big.NewInt(7)))
+	require.Less(gas+intrinsicGasMargin, uint64(ERC20Gas))
+	require.Equal(common.BigToHash(common.Big1).Bytes(), ret)
+	require.Equal(new(big.Int).Sub(MintAmount, big.NewInt(7)), balanceOf(alice))
+	require.Equal(big.NewInt(7), balanceOf(bob))
+
+	logs := cfg.State.Logs()
+	require.Len(logs, 2)
+	require.Equal([]common.Hash{
+		common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		common.BytesToHash(alice[:]),
+		common.BytesToHash(bob[:]),
+	}, logs[1].Topics)
+	require.Equal(common.BigToHash(big.NewInt(7)).Bytes(), logs[1].Data)
+
+	// Transfers above the balance revert.
+	cfg.Origin = bob
+	_, _, err = runtime.Call(token, PackTransfer(alice, big.NewInt(8)), cfg)
+	require.Error(err)
+	require.Equal(big.NewInt(7), balanceOf(bob))
+}
+
+func TestPair(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		cfg   = newRuntimeConfig(t)
+		alice = common.Address{1}
+	)
+	_, pair, leftOverGas, err := runtime.Create(PairCode(), cfg)
+	require.NoError(err)
+	require.Less(cfg.GasLimit-leftOverGas+intrinsicGasMargin+32_000, uint64(DeployGas))
+
+	reserve := func(slot int64) *big.Int {
+		return cfg.State.GetState(pair, common.BigToHash(big.NewInt(slot))).Big()
+	}
+	balance := func(mapping int64) *big.Int {
+		slot := crypto.Keccak256Hash(common.BytesToHash(alice[:]).Bytes(), common.BigToHash(big.NewInt(mapping)).Bytes())
+		return cfg.State.GetState(pair, slot).Big()
+	}
+	require.Equal(PairReserve, reserve(0))
+	require.Equal(PairReserve, reserve(1))
+
+	_, gas := call(t, cfg, alice, pair, PackMint(MintAmount))
+	require.Less(gas+intrinsicGasMargin, uint64(MintGas))
+	require.Equal(MintAmount, balance(2))
+	require.Equal(MintAmount, balance(3))
+
+	// amountOut = amountIn * 997 * reserveOut / (reserveIn * 1000 + amountIn * 997)
+	expectedOut := func(amountIn, reserveIn, reserveOut *big.Int) *big.Int {
+		inWithFee := new(big.Int).Mul(amountIn, big.NewInt(997))
+		num := new(big.Int).Mul(inWithFee, reserveOut)
+		den := new(big.Int).Add(new(big.Int).Mul(reserveIn, big.NewInt(1000)), inWithFee)
+		return num.Div(num, den)
+	}
+	for _, zeroForOne := range []bool{true, false, true} {
+		in, out := int64(0), int64(1)
+		if !zeroForOne {
+			in, out = 1, 0
+		}
+		var (
+			reserveIn, reserveOut = reserve(in), reserve(out)
+			balanceIn, balanceOut = balance(2 + in), balance(2 + out)
+			amountOut             = expectedOut(swapAmount, reserveIn, reserveOut)
+		)
+		ret, gas := call(t, cfg, alice, pair, PackSwap(zeroForOne, swapAmount))
+		require.Less(gas+intrinsicGasMargin, uint64(SwapGas))
+		require.Equal(amountOut, new(big.Int).SetBytes(ret))
+		require.Equal(new(big.Int).Add(reserveIn, swapAmount), reserve(in))
+		require.Equal(new(big.Int).Sub(reserveOut, amountOut), reserve(out))
+		require.Equal(new(big.Int).Sub(balanceIn, swapAmount), balance(2+in))
+		require.Equal(new(big.Int).Add(balanceOut, amountOut), balance(2+out))
+	}
+
+	// Swaps above the balance revert.
+	cfg.Origin = common.Address{2}
+	_, _, err = runtime.Call(pair, PackSwap(true, swapAmount), cfg)
+	require.Error(err)
+}
+
+func TestLoad(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "scenarios.yaml")
+	require.NoError(os.WriteFile(path, []byte(`
+key-pools:
+  - name: traders
+    size: 3
+  - name: deployers
+    size: 1
+scenarios:
+  - type: erc20
+    weight: 3
+    key-pool: traders
+  - name: swaps
+    type: swap
+    weight: 1
+    key-pool: traders
+  - type: deploy
+    weight: 1
+    key-pool: deployers
+  - name: blockchain-id
+    type: call
+    weight: 1
+    key-pool: deployers
+    to: "0x0200000000000000000000000000000000000005"
+    data: "0x4213cf78"
+    gas: 30000
+`), 0o600))
+	c, err := Load(path)
+	require.NoError(err)
+	require.Equal(4, c.NumKeys())
+	require.Equal(Scenario{Name: "erc20", Type: TypeERC20, Weight: 3, KeyPool: "traders"}, c.Scenarios[0])
+	require.Equal(uint64(30_000), c.Scenarios[3].Gas)
+
+	addresses := []common.Address{{1}, {2}, {3}, {4}}
+	contracts := Contracts{Token: common.Address{5}, Pair: common.Address{6}}
+	g := NewGenerator(c, contracts, big.NewInt(1), big.NewInt(100), big.NewInt(1), addresses)
+	require.Equal(uint64(DeployGas), c.MaxGas())
+	require.Equal([]common.Address{contracts.Token, contracts.Pair}, g.MintTargets(0))
+	require.Empty(g.MintTargets(3))
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	create := g.ForKey(0)
+	for nonce := uint64(0); nonce < 100; nonce++ {
+		tx, err := create(key, nonce)
+		require.NoError(err)
+		require.Equal(nonce, tx.Nonce())
+		require.Contains([]common.Address{contracts.Token, contracts.Pair}, *tx.To())
+	}
+	require.Equal(uint64(100), g.Counts["erc20"]+g.Counts["swaps"])
+	require.Greater(g.Counts["erc20"], g.Counts["swaps"])
+
+	create = g.ForKey(3)
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		tx, err := create(key, nonce)
+		require.NoError(err)
+		if tx.To() == nil {
+			require.Equal(TokenCode(), tx.Data())
+		} else {
+			require.Equal(uint64(30_000), tx.Gas())
+		}
+	}
+	require.Equal(uint64(20), g.Counts["deploy"]+g.Counts["blockchain-id"])
+
+	for _, c := range []*Config{
+		{},
+		{Scenarios: []Scenario{{Type: "mint", Weight: 1}}},
+		{Scenarios: []Scenario{{Type: TypeTransfer, Weight: 1, KeyPool: "missing"}}},
+		{KeyPools: []KeyPool{{Name: "p", Size: 1}}, Scenarios: []Scenario{{Type: TypeTransfer, KeyPool: "p"}}},
+		{KeyPools: []KeyPool{{Name: "p", Size: 1}}, Scenarios: []Scenario{{Type: TypeCall, Weight: 1, KeyPool: "p"}}},
+		{KeyPools: []KeyPool{{Name: "p", Size: 1}, {Name: "q", Size: 1}}, Scenarios: []Scenario{{Type: TypeTransfer, Weight: 1, KeyPool: "p"}}},
+	} {
+		require.Error(c.Verify())
+	}
+}
@@ -12,10 +12,15 @@ import (
 
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/consensus/headerextra"
 	"github.com/ava-labs/subnet-evm/consensus/misc/eip4844"
+	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warpincentives"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
@@ -198,19 +203,9 @@ func (self *DummyEngine) verifyHeader(chain consensus.ChainHeaderReader, header
 	if uncle {
 		return errUnclesUnsupported
 	}
-	switch {
-	case config.IsDurango(header.Time):
-		if len(header.Extra) < params.DynamicFeeExtraDataSize {
-			return fmt.Errorf("expected extra-data field length >= %d, found %d", params.DynamicFeeExtraDataSize, len(header.Extra))
-		}
-	case config.IsSubnetEVM(header.Time):
-		if len(header.Extra) != params.DynamicFeeExtraDataSize {
-			return fmt.Errorf("expected extra-data field to be: %d, but found %d", params.DynamicFeeExtraDataSize, len(header.Extra))
-		}
-	default:
-		if uint64(len(header.Extra)) > params.MaximumExtraDataSize {
-			return fmt.Errorf("extra-data too long: %d > %d", len(header.Extra), params.MaximumExtraDataSize)
-		}
+	// Ensure that the extra data is valid for the rules of the block
+	if err := params.VerifyExtraData(config.Rules(header.Number, header.Time), header.Extra); err != nil {
+		return err
 	}
 	// Ensure gas-related header fields are correct
 	if err := self.verifyHeaderGasFields(config, header, parent, chain); err != nil {
@@ -220,6 +215,10 @@ func (self *DummyEngine) verifyHeader(chain consensus.ChainHeaderReader, header
 	if err := self.verifyCoinbase(config, header, parent, chain); err != nil {
 		return err
 	}
+	// Ensure that the chain's custom header fields are valid
+	if err := headerextra.VerifyHeader(config, header, parent); err != nil {
+		return err
+	}
 
 	// Verify the header's timestamp
 	if header.Time > uint64(self.clock.Time().Add(allowedFutureBlockTime).Unix()) {
@@ -343,6 +342,84 @@ func (self *DummyEngine) verifyBlockFee(
 	return nil
 }
 
+// blockFees returns the fees credited to the coinbase by the transactions of [receipts],
+// after any fee discount.
+func blockFees(receipts []*types.Receipt) *big.Int {
+	fees := new(big.Int)
+	for _, receipt := range receipts {
+		if receipt.Fee != nil {
+			fees.Add(fees, receipt.Fee)
+		}
+	}
+	return fees
+}
+
+// distributeRewardSplits distributes [fees], the fees collected by the reward manager contract
+// in the block of [header], to the reward split recipients proportionally to their weights. The
+// reward manager contract is the coinbase of [header] only if reward splits were set through the
+// reward manager precompile. Only the fees are split, so any other funds held by the contract,
+// such as value sent to it, are left in place. Any rounding remainder is credited to the last
+// recipient.
+func distributeRewardSplits(config *params.ChainConfig, header *types.Header, state *state.StateDB, fees *big.Int) {
+	if header.Coinbase != rewardmanager.ContractAddress || !config.IsStromboli(header.Time) {
+		return
+	}
+	recipients, weights := rewardmanager.GetRewardSplits(state)
+	if len(recipients) == 0 {
+		return
+	}
+	if balance := state.GetBalance(rewardmanager.ContractAddress); balance.Cmp(fees) < 0 {
+		fees = balance
+	}
+	if fees.Sign() <= 0 {
+		return
+	}
+	totalWeight := new(big.Int)
+	for _, weight := range weights {
+		totalWeight.Add(totalWeight, weight)
+	}
+	remaining := new(big.Int).Set(fees)
+	for i, recipient := range recipients {
+		amount := remaining
+		if i < len(recipients)-1 {
+			amount = new(big.Int).Mul(fees, weights[i])
+			amount.Div(amount, totalWeight)
+		}
+		state.SubBalance(rewardmanager.ContractAddress, amount)
+		state.AddBalance(recipient, amount)
+		remaining = new(big.Int).Sub(remaining, amount)
+	}
+}
+
+// accrueWarpIncentives moves the configured share of [fees], the fees credited to the coinbase
+// of [header] in the block, to the warp incentives reward pool while the precompile is enabled,
+// and returns the amount moved. Fees burned to the blackhole address do not accrue, since moving
+// them to the pool would mint funds that were taken out of circulation. The share is capped by
+// the balance of the coinbase, which may have spent fees it received earlier in the block.
+func accrueWarpIncentives(config *params.ChainConfig, header *types.Header, state *state.StateDB, fees *big.Int) *big.Int {
+	if header.Coinbase == constants.BlackholeAddr || !config.IsPrecompileEnabled(warpincentives.ContractAddress, header.Time) {
+		return new(big.Int)
+	}
+	amount := warpincentives.FeeShareOf(state, fees)
+	if balance := state.GetBalance(header.Coinbase); balance.Cmp(amount) < 0 {
+		amount = new(big.Int).Set(balance)
+	}
+	if amount.Sign() == 0 {
+		return amount
+	}
+	state.SubBalance(header.Coinbase, amount)
+	state.AddBalance(warpincentives.ContractAddress, amount)
+	return amount
+}
+
+// distributeFees accrues the warp incentives and distributes the reward splits out of the fees
+// credited to the coinbase of [header] by the transactions of [receipts].
+func distributeFees(config *params.ChainConfig, header *types.Header, state *state.StateDB, receipts []*types.Receipt) {
+	fees := blockFees(receipts)
+	accrued := accrueWarpIncentives(config, header, state, fees)
+	distributeRewardSplits(config, header, state, fees.Sub(fees, accrued))
+}
+
 func (self *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types.Block, parent *types.Header, state *state.StateDB, receipts []*types.Receipt) error {
 	if chain.Config().IsSubnetEVM(block.Time()) {
 		// we use the parent to determine the fee config
@@ -375,6 +452,13 @@ func (self *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *type
 		); err != nil {
 			return err
 		}
+		// The precompiles enabled at the block account for the upgrades scheduled by the
+		// governance precompile.
+		config, err := governance.ChainConfigAt(chain.Config(), state, block.Time())
+		if err != nil {
+			return err
+		}
+		distributeFees(config, block.Header(), state, receipts)
 	}
 
 	return nil
@@ -408,6 +492,11 @@ func (self *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader,
 		); err != nil {
 			return nil, err
 		}
+		config, err := governance.ChainConfigAt(chain.Config(), state, header.Time)
+		if err != nil {
+			return nil, err
+		}
+		distributeFees(config, header, state, receipts)
 	}
 	// commit the final state root
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
@@ -8,9 +8,16 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warpincentives"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 var testBlockGasCostStep = big.NewInt(50_000)
@@ -140,3 +147,164 @@ func TestVerifyBlockFee(t *testing.T) {
 		})
 	}
 }
+
+func TestDistributeRewardSplits(t *testing.T) {
+	var (
+		treasury = common.HexToAddress("0x0124")
+		pool     = common.HexToAddress("0x0125")
+	)
+	stromboliConfig := *params.TestChainConfig
+	stromboliConfig.OptionalNetworkUpgrades = params.OptionalNetworkUpgrades{StromboliTimestamp: utils.NewUint64(10)}
+
+	tests := map[string]struct {
+		coinbase common.Address
+		time     uint64
+		balance  int64 // balance of the reward manager contract other than the fees of the block
+		fees     int64 // fees collected by the reward manager contract in the block
+		expected map[common.Address]int64
+	}{
+		"splits fees by weight": {
+			coinbase: rewardmanager.ContractAddress,
+			time:     10,
+			fees:     1000,
+			expected: map[common.Address]int64{treasury: 500, pool: 300, constants.BlackholeAddr: 200, rewardmanager.ContractAddress: 0},
+		},
+		"remainder goes to last recipient": {
+			coinbase: rewardmanager.ContractAddress,
+			time:     10,
+			fees:     999,
+			expected: map[common.Address]int64{treasury: 499, pool: 299, constants.BlackholeAddr: 201, rewardmanager.ContractAddress: 0},
+		},
+		"other funds are not split": {
+			coinbase: rewardmanager.ContractAddress,
+			time:     10,
+			balance:  5000,
+			fees:     1000,
+			expected: map[common.Address]int64{treasury: 500, pool: 300, constants.BlackholeAddr: 200, rewardmanager.ContractAddress: 5000},
+		},
+		"other coinbase is not split": {
+			coinbase: treasury,
+			time:     10,
+			fees:     1000,
+			expected: map[common.Address]int64{treasury: 0, pool: 0, rewardmanager.ContractAddress: 1000},
+		},
+		"not split before Stromboli": {
+			coinbase: rewardmanager.ContractAddress,
+			time:     9,
+			fees:     1000,
+			expected: map[common.Address]int64{treasury: 0, pool: 0, rewardmanager.ContractAddress: 1000},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			rewardmanager.StoreRewardSplits(
+				statedb,
+				[]common.Address{treasury, pool, constants.BlackholeAddr},
+				[]*big.Int{big.NewInt(50), big.NewInt(30), big.NewInt(20)},
+			)
+			// Value sent to the contract in the block is part of its other funds.
+			statedb.AddBalance(rewardmanager.ContractAddress, big.NewInt(test.balance+test.fees))
+
+			header := &types.Header{Coinbase: test.coinbase, Time: test.time}
+			distributeRewardSplits(&stromboliConfig, header, statedb, big.NewInt(test.fees))
+
+			for addr, expected := range test.expected {
+				require.Zero(t, big.NewInt(expected).Cmp(statedb.GetBalance(addr)), "balance of %s: %d", addr, statedb.GetBalance(addr))
+			}
+		})
+	}
+}
+
+func TestDistributeFees(t *testing.T) {
+	treasury := common.HexToAddress("0x0124")
+	config := *params.TestChainConfig
+	config.OptionalNetworkUpgrades = params.OptionalNetworkUpgrades{StromboliTimestamp: utils.NewUint64(0)}
+	config.GenesisPrecompiles = params.Precompiles{
+		warpincentives.ConfigKey: warpincentives.NewConfig(utils.NewUint64(0), nil, nil, nil, 0),
+	}
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	rewardmanager.StoreRewardSplits(statedb, []common.Address{treasury}, []*big.Int{big.NewInt(1)})
+	warpincentives.StoreFeeShare(statedb, 1_000)
+	// The reward manager contract received 2000 in fees and 500 in value transfers.
+	statedb.AddBalance(rewardmanager.ContractAddress, big.NewInt(2500))
+
+	header := &types.Header{Coinbase: rewardmanager.ContractAddress, Time: 1}
+	receipts := []*types.Receipt{{Fee: big.NewInt(1500)}, {Fee: big.NewInt(500)}}
+	distributeFees(&config, header, statedb, receipts)
+
+	// The warp incentives share is taken out of the fees, and only the rest of the fees is split.
+	require.Zero(t, big.NewInt(200).Cmp(statedb.GetBalance(warpincentives.ContractAddress)))
+	require.Zero(t, big.NewInt(1800).Cmp(statedb.GetBalance(treasury)))
+	require.Zero(t, big.NewInt(500).Cmp(statedb.GetBalance(rewardmanager.ContractAddress)))
+}
+
+func TestAccrueWarpIncentives(t *testing.T) {
+	coinbase := common.HexToAddress("0x0124")
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		warpincentives.ConfigKey: warpincentives.NewConfig(utils.NewUint64(10), nil, nil, nil, 0),
+	}
+
+	tests := map[string]struct {
+		coinbase        common.Address
+		time            uint64
+		feeShare        uint64
+		coinbaseBalance int64
+		poolBalance     int64
+		expectedAccrued int64
+	}{
+		"accrues share of fees": {
+			coinbase:        coinbase,
+			time:            10,
+			feeShare:        1_000,
+			coinbaseBalance: 10_000,
+			poolBalance:     5,
+			expectedAccrued: 200, // 10% of 2 receipts with a fee of 1000
+		},
+		"capped by coinbase balance": {
+			coinbase:        coinbase,
+			time:            10,
+			feeShare:        1_000,
+			coinbaseBalance: 150,
+			expectedAccrued: 150,
+		},
+		"burned fees do not accrue": {
+			coinbase:        constants.BlackholeAddr,
+			time:            10,
+			feeShare:        1_000,
+			coinbaseBalance: 10_000,
+		},
+		"zero fee share": {
+			coinbase:        coinbase,
+			time:            10,
+			coinbaseBalance: 10_000,
+		},
+		"precompile not enabled": {
+			coinbase:        coinbase,
+			time:            9,
+			feeShare:        1_000,
+			coinbaseBalance: 10_000,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			warpincentives.StoreFeeShare(statedb, test.feeShare)
+			statedb.AddBalance(test.coinbase, big.NewInt(test.coinbaseBalance))
+			statedb.AddBalance(warpincentives.ContractAddress, big.NewInt(test.poolBalance))
+
+			header := &types.Header{Coinbase: test.coinbase, Time: test.time, BaseFee: big.NewInt(10)}
+			receipts := []*types.Receipt{{GasUsed: 100, Fee: big.NewInt(1000)}, {GasUsed: 100, Fee: big.NewInt(1000)}}
+			accrued := accrueWarpIncentives(&config, header, statedb, blockFees(receipts))
+
+			require.Zero(t, big.NewInt(test.expectedAccrued).Cmp(accrued))
+			require.Zero(t, big.NewInt(test.poolBalance+test.expectedAccrued).Cmp(statedb.GetBalance(warpincentives.ContractAddress)))
+			require.Zero(t, big.NewInt(test.coinbaseBalance-test.expectedAccrued).Cmp(statedb.GetBalance(test.coinbase)))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package headerextra allows chains to define additional header fields that
+// are validated as part of consensus.
+//
+// Subnet-EVM reserves the first [params.DynamicFeeExtraDataSize] bytes of the
+// header extra data for the fee window and, after Durango, appends the operator data
+// allowed by the chain config (see [params.ParseOperatorData]) and the predicate
+// results. A chain defining its own fields is responsible for encoding them
+// without conflicting with this layout. Fields should be registered from an init
+// function so that every node verifies the same set of fields.
+package headerextra
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+// ErrInvalidField is returned when a registered field fails verification.
+var ErrInvalidField = errors.New("invalid header field")
+
+var (
+	errEmptyName      = errors.New("header field name must not be empty")
+	errNoVerifier     = errors.New("header field must define a verification callback")
+	errDuplicateField = errors.New("header field already registered")
+)
+
+// Field is a custom header field validated as part of consensus.
+type Field struct {
+	// Name uniquely identifies the field.
+	Name string
+	// VerifyGenesis verifies the field in the genesis header. The state root of
+	// [header] is not set. Optional.
+	VerifyGenesis func(config *params.ChainConfig, header *types.Header) error
+	// VerifyHeader verifies the field in [header], which extends [parent]. Optional.
+	VerifyHeader func(config *params.ChainConfig, header *types.Header, parent *types.Header) error
+}
+
+// Registry holds the registered header fields in registration order.
+type Registry struct {
+	fields []Field
+}
+
+// Register adds [field] to the registry.
+func (r *Registry) Register(field Field) error {
+	if field.Name == "" {
+		return errEmptyName
+	}
+	if field.VerifyGenesis == nil && field.VerifyHeader == nil {
+		return fmt.Errorf("%w: %s", errNoVerifier, field.Name)
+	}
+	for _, registered := range r.fields {
+		if registered.Name == field.Name {
+			return fmt.Errorf("%w: %s", errDuplicateField, field.Name)
+		}
+	}
+	r.fields = append(r.fields, field)
+	return nil
+}
+
+// Fields returns the registered fields in registration order.
+func (r *Registry) Fields() []Field {
+	return r.fields
+}
+
+// VerifyGenesis verifies every registered field in the genesis [header].
+func (r *Registry) VerifyGenesis(config *params.ChainConfig, header *types.Header) error {
+	for _, field := range r.fields {
+		if field.VerifyGenesis == nil {
+			continue
+		}
+		if err := field.VerifyGenesis(config, header); err != nil {
+			return fmt.Errorf("%w %s in genesis: %w", ErrInvalidField, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// VerifyHeader verifies every registered field in [header], which extends [parent].
+func (r *Registry) VerifyHeader(config *params.ChainConfig, header *types.Header, parent *types.Header) error {
+	for _, field := range r.fields {
+		if field.VerifyHeader == nil {
+			continue
+		}
+		if err := field.VerifyHeader(config, header, parent); err != nil {
+			return fmt.Errorf("%w %s in block %d: %w", ErrInvalidField, field.Name, header.Number, err)
+		}
+	}
+	return nil
+}
+
+// registry holds the fields verified by the consensus engine and genesis.
+var registry = &Registry{}
+
+// RegisterField registers [field] to be verified for every header and the genesis.
+func RegisterField(field Field) error {
+	return registry.Register(field)
+}
+
+// RegisteredFields returns the registered fields in registration order.
+func RegisteredFields() []Field {
+	return registry.Fields()
+}
+
+// VerifyGenesis verifies every registered field in the genesis [header].
+func VerifyGenesis(config *params.ChainConfig, header *types.Header) error {
+	return registry.VerifyGenesis(config, header)
+}
+
+// VerifyHeader verifies every registered field in [header], which extends [parent].
+func VerifyHeader(config *params.ChainConfig, header *types.Header, parent *types.Header) error {
+	return registry.VerifyHeader(config, header, parent)
+}
@@ -0,0 +1,125 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package headerextra
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	verifyHeader := func(*params.ChainConfig, *types.Header, *types.Header) error { return nil }
+	verifyGenesis := func(*params.ChainConfig, *types.Header) error { return nil }
+
+	tests := []struct {
+		name      string
+		fields    []Field
+		expectErr error
+	}{
+		{
+			name: "header and genesis verifiers",
+			fields: []Field{
+				{Name: "a", VerifyHeader: verifyHeader},
+				{Name: "b", VerifyGenesis: verifyGenesis},
+				{Name: "c", VerifyHeader: verifyHeader, VerifyGenesis: verifyGenesis},
+			},
+		},
+		{
+			name:      "empty name",
+			fields:    []Field{{VerifyHeader: verifyHeader}},
+			expectErr: errEmptyName,
+		},
+		{
+			name:      "no verifier",
+			fields:    []Field{{Name: "a"}},
+			expectErr: errNoVerifier,
+		},
+		{
+			name: "duplicate name",
+			fields: []Field{
+				{Name: "a", VerifyHeader: verifyHeader},
+				{Name: "a", VerifyGenesis: verifyGenesis},
+			},
+			expectErr: errDuplicateField,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Registry{}
+			var err error
+			for _, field := range test.fields {
+				if err = r.Register(field); err != nil {
+					break
+				}
+			}
+			require.ErrorIs(t, err, test.expectErr)
+			if test.expectErr == nil {
+				require.Len(t, r.Fields(), len(test.fields))
+				for i, field := range r.Fields() {
+					require.Equal(t, test.fields[i].Name, field.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	errInvalidMarker := errors.New("invalid marker")
+	// marker requires the last byte of the extra data to be 0xff.
+	verifyMarker := func(header *types.Header) error {
+		if len(header.Extra) == 0 || header.Extra[len(header.Extra)-1] != 0xff {
+			return errInvalidMarker
+		}
+		return nil
+	}
+
+	var calls []string
+	r := &Registry{}
+	require.NoError(t, r.Register(Field{
+		Name: "genesisOnly",
+		VerifyGenesis: func(_ *params.ChainConfig, header *types.Header) error {
+			calls = append(calls, "genesisOnly")
+			return verifyMarker(header)
+		},
+	}))
+	require.NoError(t, r.Register(Field{
+		Name: "marker",
+		VerifyGenesis: func(_ *params.ChainConfig, header *types.Header) error {
+			calls = append(calls, "marker")
+			return verifyMarker(header)
+		},
+		VerifyHeader: func(_ *params.ChainConfig, header *types.Header, parent *types.Header) error {
+			calls = append(calls, "marker")
+			if header.Number.Uint64() != parent.Number.Uint64()+1 {
+				return errors.New("unexpected parent")
+			}
+			return verifyMarker(header)
+		},
+	}))
+
+	genesis := &types.Header{Number: big.NewInt(0), Extra: []byte{0xff}}
+	require.NoError(t, r.VerifyGenesis(params.TestChainConfig, genesis))
+	require.Equal(t, []string{"genesisOnly", "marker"}, calls)
+
+	calls = nil
+	header := &types.Header{Number: big.NewInt(1), Extra: []byte{0xff}}
+	require.NoError(t, r.VerifyHeader(params.TestChainConfig, header, genesis))
+	require.Equal(t, []string{"marker"}, calls)
+
+	header.Extra = []byte{0x00}
+	err := r.VerifyHeader(params.TestChainConfig, header, genesis)
+	require.ErrorIs(t, err, ErrInvalidField)
+	require.ErrorIs(t, err, errInvalidMarker)
+	require.ErrorContains(t, err, "marker")
+
+	genesis.Extra = nil
+	err = r.VerifyGenesis(params.TestChainConfig, genesis)
+	require.ErrorIs(t, err, ErrInvalidField)
+	require.ErrorContains(t, err, "genesisOnly")
+}
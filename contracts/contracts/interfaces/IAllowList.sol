@@ -16,6 +16,24 @@ interface IAllowList {
   // Set [addr] to have no role for the precompile contract.
   function setNone(address addr) external;
 
+  // Set each address in [addrs] to [role]. (only after Stromboli)
+  function setMany(address[] calldata addrs, uint256 role) external;
+
   // Read the status of [addr].
   function readAllowList(address addr) external view returns (uint256 role);
+
+  // Read up to [limit] addresses with a non-None role, starting at [offset], along with their roles. (only after Stromboli)
+  // Reverts if the precompile was enabled before Stromboli, since roles assigned before Stromboli are not tracked.
+  function readAll(uint256 offset, uint256 limit) external view returns (address[] memory addrs, uint256[] memory roles);
+
+  // Read the number of addresses with a non-None role. (only after Stromboli)
+  // Reverts if the precompile was enabled before Stromboli, since roles assigned before Stromboli are not tracked.
+  function readAllCount() external view returns (uint256 count);
+
+  // Returns the interface version of the precompile. (only after Stromboli)
+  function precompileVersion() external view returns (uint256 version);
+
+  // Returns the fixed gas cost of the function with [selector]. Reverts if the function
+  // does not declare a fixed gas cost. (only after Stromboli)
+  function functionGasCost(bytes4 selector) external view returns (uint256 gasCost);
 }
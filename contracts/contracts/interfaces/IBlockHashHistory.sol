@@ -0,0 +1,13 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+interface IBlockHashHistory {
+  // getBlockHash returns the hash of the block at [blockNumber] if it is one of the 8192
+  // blocks preceding the current block, similar to EIP-2935. It returns zero for the current
+  // or future blocks, older blocks, and blocks produced before the precompile was activated.
+  function getBlockHash(uint256 blockNumber) external view returns (bytes32 blockHash);
+}
@@ -14,6 +14,7 @@ interface IFeeManager is IAllowList {
     uint256 blockGasCostStep;
   }
   event FeeConfigChanged(address indexed sender, FeeConfig oldFeeConfig, FeeConfig newFeeConfig);
+  event FeeDiscountChanged(address indexed sender, address indexed account, uint256 oldDiscount, uint256 newDiscount);
 
   // Set fee config fields to contract storage
   function setFeeConfig(
@@ -44,4 +45,12 @@ interface IFeeManager is IAllowList {
 
   // Get the last block number changed the fee config from the contract storage
   function getFeeConfigLastChangedAt() external view returns (uint256 blockNumber);
+
+  // Set the base fee discount percentage (0-100) for the given account (only after Stromboli)
+  // A transaction gets the larger discount of its sender and of the account it calls directly.
+  // The discount does not apply to the contracts called in turn, nor to contract creation.
+  function setFeeDiscount(address account, uint256 discount) external;
+
+  // Get the base fee discount percentage for the given account (only after Stromboli)
+  function getFeeDiscount(address account) external view returns (uint256 discount);
 }
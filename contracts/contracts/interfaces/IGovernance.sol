@@ -0,0 +1,63 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+// Enabled addresses propose changes that are executed once approved by [threshold] of them
+// within [votingPeriod] seconds. A proposal either calls a stateful precompile from the
+// governance precompile address, which must hold the roles required by the call, or schedules
+// a precompile upgrade that every node applies at its timestamp, as if it was in upgrade.json.
+interface IGovernance is IAllowList {
+  // ProposalCreated is the event logged whenever a proposal is created
+  event ProposalCreated(uint256 indexed proposalId, address indexed proposer, address indexed target, bytes data);
+
+  // ProposalApproved is the event logged whenever a proposal is approved
+  event ProposalApproved(uint256 indexed proposalId, address indexed voter);
+
+  // ProposalExecuted is the event logged whenever a proposal is executed
+  event ProposalExecuted(uint256 indexed proposalId, address indexed executor);
+
+  // propose creates a proposal calling the stateful precompile [target] with [data], approved by the caller.
+  // Can only be called by enabled, manager and admin addresses.
+  function propose(address target, bytes calldata data) external returns (uint256 proposalId);
+
+  // proposeUpgrade creates a proposal scheduling [upgrade], a JSON encoded entry of the precompileUpgrades
+  // of upgrade.json, approved by the caller. Can only be called by enabled, manager and admin addresses.
+  function proposeUpgrade(bytes calldata upgrade) external returns (uint256 proposalId);
+
+  // approve approves the open proposal [proposalId].
+  // Can only be called by enabled, manager and admin addresses.
+  function approve(uint256 proposalId) external;
+
+  // execute executes the open proposal [proposalId] once it has enough approvals and returns the result of its call.
+  // Executing an upgrade proposal fails while 64 upgrades are scheduled.
+  // Can only be called by enabled, manager and admin addresses.
+  function execute(uint256 proposalId) external returns (bytes memory result);
+
+  // getProposal returns the proposal [proposalId]
+  function getProposal(
+    uint256 proposalId
+  )
+    external
+    view
+    returns (address proposer, address target, bytes memory data, uint256 deadline, uint256 approvals, bool executed);
+
+  // hasApproved returns true if [voter] approved the proposal [proposalId]
+  function hasApproved(uint256 proposalId, address voter) external view returns (bool approved);
+
+  // proposalCount returns the number of proposals created, which are numbered from 1
+  function proposalCount() external view returns (uint256 count);
+
+  // scheduledUpgrades returns the IDs of the executed upgrade proposals still in effect. Activated upgrades
+  // followed by a later activated upgrade of the same precompile, or overridden by upgrade.json, are pruned.
+  function scheduledUpgrades() external view returns (uint256[] memory proposalIds);
+
+  // threshold returns the number of approvals required to execute a proposal
+  function threshold() external view returns (uint256 threshold);
+
+  // votingPeriod returns the number of seconds a proposal is open after its creation
+  function votingPeriod() external view returns (uint256 period);
+}
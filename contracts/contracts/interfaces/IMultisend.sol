@@ -0,0 +1,15 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+interface IMultisend {
+  event Multisend(address indexed sender, uint256 numRecipients, uint256 totalAmount);
+
+  // multisend transfers amounts[i] of the native token from the caller to recipients[i].
+  // The transfers are funded from the caller's balance, so no value should be sent with the call.
+  // Reverts without transferring anything if the caller's balance is insufficient.
+  function multisend(address[] calldata recipients, uint256[] calldata amounts) external;
+}
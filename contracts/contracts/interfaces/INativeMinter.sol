@@ -4,6 +4,31 @@ import "./IAllowList.sol";
 
 interface INativeMinter is IAllowList {
   event NativeCoinMinted(address indexed sender, address indexed recipient, uint256 amount);
+  // SupplyCapChanged is the event logged whenever the supply cap is modified
+  event SupplyCapChanged(address indexed sender, uint256 oldCap, uint256 newCap);
+  // MinterBudgetChanged is the event logged whenever the budget of a minter is modified
+  event MinterBudgetChanged(address indexed sender, address indexed minter, uint256 oldBudget, uint256 newBudget);
+  // MintPeriodChanged is the event logged whenever the mint period is modified
+  event MintPeriodChanged(address indexed sender, uint256 oldPeriod, uint256 newPeriod);
+
   // Mint [amount] number of native coins and send to [addr]
   function mintNativeCoin(address addr, uint256 amount) external;
+
+  // Set the maximum total amount that can be minted to [cap]. A zero cap disables the cap. (only after Stromboli)
+  function setSupplyCap(uint256 cap) external;
+
+  // Set the amount [minter] can mint per mint period to [budget]. A zero budget prevents [minter] from minting,
+  // and a budget of type(uint256).max removes the budget. (only after Stromboli)
+  function setMinterBudget(address minter, uint256 budget) external;
+
+  // Set the length in seconds of the period after which minter budgets reset to [period]. A zero period means
+  // budgets never reset. (only after Stromboli)
+  function setMintPeriod(uint256 period) external;
+
+  // Returns the supply cap, the total amount minted since Stromboli and the mint period in seconds. (only after Stromboli)
+  function mintLimits() external view returns (uint256 supplyCap, uint256 totalMinted, uint256 mintPeriod);
+
+  // Returns the budget of [minter] and the amount it can still mint in its current mint period, both
+  // type(uint256).max if [minter] has no budget. (only after Stromboli)
+  function minterBudget(address minter) external view returns (uint256 budget, uint256 remaining);
 }
@@ -0,0 +1,18 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IPrecompileRegistry {
+  // Returns whether the precompile at [precompileAddress] is enabled and its interface version.
+  // Addresses that are not precompiles are reported as disabled with version 0.
+  function getPrecompileVersion(address precompileAddress) external view returns (bool enabled, uint256 version);
+
+  // Returns the addresses and interface versions of all enabled precompiles, sorted by address.
+  function getEnabledPrecompiles() external view returns (address[] memory precompileAddresses, uint256[] memory versions);
+
+  // Returns the interface version of the precompile registry.
+  function precompileVersion() external view returns (uint256 version);
+
+  // Returns the fixed gas cost of the function with [selector]. Reverts if the function
+  // does not declare a fixed gas cost. (only after Stromboli)
+  function functionGasCost(bytes4 selector) external view returns (uint256 gasCost);
+}
@@ -0,0 +1,19 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+interface IRandomness {
+  event RandomnessRevealed(uint256 indexed round, uint256 randomValue);
+
+  // getRandom returns the random value of [round], a block number of this chain.
+  // The value is derived from the aggregate BLS signature of the chain validators over
+  // the hash of that block, signed by any validators holding the Warp quorum of the stake.
+  // The first call for a round must be made by a transaction carrying the Warp signed
+  // block hash in its access list for this precompile, within 256 blocks of the round,
+  // so a round that no quorum signs in time can never be revealed. The value is then
+  // stored and returned by every later call.
+  function getRandom(uint256 round) external returns (uint256 randomValue);
+}
@@ -12,6 +12,9 @@ interface IRewardManager is IAllowList {
   // RewardsDisabled is the event logged whenever rewards are disabled
   event RewardsDisabled(address indexed sender);
 
+  // RewardSplitsChanged is the event logged whenever reward splits are modified
+  event RewardSplitsChanged(address indexed sender, address[] recipients, uint256[] weights);
+
   // setRewardAddress sets the reward address to the given address
   function setRewardAddress(address addr) external;
 
@@ -26,4 +29,11 @@ interface IRewardManager is IAllowList {
 
   // areFeeRecipientsAllowed returns true if fee recipients are allowed
   function areFeeRecipientsAllowed() external view returns (bool isAllowed);
+
+  // setRewardSplits distributes block rewards among [recipients] proportionally to [weights] (only after Stromboli)
+  function setRewardSplits(address[] calldata recipients, uint256[] calldata weights) external;
+
+  // currentRewardSplits returns the current reward split recipients and weights (only after Stromboli).
+  // The splits are cleared by setRewardAddress, allowFeeRecipients and disableRewards.
+  function currentRewardSplits() external view returns (address[] memory recipients, uint256[] memory weights);
 }
@@ -0,0 +1,25 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+interface ITxAllowList is IAllowList {
+  // TargetRestrictionSet is the event logged whenever the target restriction is enabled or disabled
+  event TargetRestrictionSet(address indexed sender, bool enabled);
+
+  // TargetAllowedSet is the event logged whenever a target is added to or removed from the allowed targets
+  event TargetAllowedSet(address indexed target, address indexed sender, bool allowed);
+
+  // setTargetRestriction restricts the contracts non-admin accounts can send transactions to. (only after Stromboli)
+  // Only the recipient of a transaction is checked, if it has code: transfers to accounts without code
+  // are not restricted, and contract creation is restricted by the contract deployer allow list instead.
+  function setTargetRestriction(bool enabled) external;
+
+  // setTargetAllowed adds [target] to or removes it from the allowed targets. (only after Stromboli)
+  function setTargetAllowed(address target, bool allowed) external;
+
+  // isTargetRestricted returns true if the target restriction is enabled. (only after Stromboli)
+  function isTargetRestricted() external view returns (bool enabled);
+
+  // isTargetAllowed returns true if [target] is an allowed target. (only after Stromboli)
+  function isTargetAllowed(address target) external view returns (bool allowed);
+}
@@ -0,0 +1,40 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+// The balance of the precompile is the reward pool. A portion of the fees paid to the fee
+// recipient of each block accrues to the pool (burned fees do not), and validators claim it
+// proportionally to the warp signatures they served. Served signatures are tracked off-chain
+// by the nodes aggregating signatures (warp_getServedSignatures) and reported on-chain by
+// enabled addresses.
+interface IWarpIncentives is IAllowList {
+  // ServedSignaturesReported is the event logged whenever served signatures are credited to a validator
+  event ServedSignaturesReported(address indexed reporter, address indexed validator, uint256 count);
+
+  // RewardsClaimed is the event logged whenever a validator claims its rewards
+  event RewardsClaimed(address indexed validator, uint256 amount);
+
+  // reportServedSignatures credits counts[i] served signatures to validators[i].
+  // Can only be called by enabled, manager and admin addresses.
+  function reportServedSignatures(address[] calldata validators, uint256[] calldata counts) external;
+
+  // claimRewards transfers the share of the reward pool proportional to the served signatures
+  // of the caller to the caller and resets them. Reverts if there is nothing to claim.
+  function claimRewards() external returns (uint256 amount);
+
+  // servedSignatures returns the served signatures credited to [validator] and not claimed yet
+  function servedSignatures(address validator) external view returns (uint256 count);
+
+  // totalServedSignatures returns the served signatures credited to all validators and not claimed yet
+  function totalServedSignatures() external view returns (uint256 count);
+
+  // pendingRewards returns the amount [validator] would receive by claiming now
+  function pendingRewards(address validator) external view returns (uint256 amount);
+
+  // feeShare returns the portion of the block fees accruing to the reward pool, in basis points
+  function feeShare() external view returns (uint256 basisPoints);
+}
@@ -48,4 +48,11 @@ interface IWarpMessenger {
   // This blockchainID is the hash of the transaction that created this blockchain on the P-Chain
   // and is not related to the Ethereum ChainID.
   function getBlockchainID() external view returns (bytes32 blockchainID);
+
+  // precompileVersion returns the interface version of the precompile. (only after Stromboli)
+  function precompileVersion() external view returns (uint256 version);
+
+  // functionGasCost returns the fixed gas cost of the function with [selector]. Reverts if the
+  // function does not declare a fixed gas cost. (only after Stromboli)
+  function functionGasCost(bytes4 selector) external view returns (uint256 gasCost);
 }
@@ -107,6 +107,15 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 // ValidateState validates the various changes that happen after a state transition,
 // such as amount of used gas, the receipt roots and the state root itself.
 func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateDB, receipts types.Receipts, usedGas uint64) error {
+	if err := v.ValidateReceipts(block, receipts, usedGas); err != nil {
+		return err
+	}
+	return validateStateRoot(v.config, block.Header(), statedb)
+}
+
+// ValidateReceipts validates the amount of used gas and the receipts of a state
+// transition, leaving the state root to be validated separately.
+func (v *BlockValidator) ValidateReceipts(block *types.Block, receipts types.Receipts, usedGas uint64) error {
 	header := block.Header()
 	if block.GasUsed() != usedGas {
 		return fmt.Errorf("invalid gas used (remote: %d local: %d)", block.GasUsed(), usedGas)
@@ -122,9 +131,13 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 	if receiptSha != header.ReceiptHash {
 		return fmt.Errorf("invalid receipt root hash (remote: %x local: %x)", header.ReceiptHash, receiptSha)
 	}
-	// Validate the state root against the received state root and throw
-	// an error if they don't match.
-	if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
+	return nil
+}
+
+// validateStateRoot validates the state root of [statedb] against the received
+// state root and throws an error if they don't match.
+func validateStateRoot(config *params.ChainConfig, header *types.Header, statedb *state.StateDB) error {
+	if root := statedb.IntermediateRoot(config.IsEIP158(header.Number)); header.Root != root {
 		return fmt.Errorf("invalid merkle root (remote: %x local: %x) dberr: %w", header.Root, root, statedb.Error())
 	}
 	return nil
@@ -70,6 +70,8 @@ var (
 	snapshotAccountReadTimer = metrics.NewRegisteredCounter("chain/snapshot/account/reads", nil)
 	snapshotStorageReadTimer = metrics.NewRegisteredCounter("chain/snapshot/storage/reads", nil)
 	snapshotCommitTimer      = metrics.NewRegisteredCounter("chain/snapshot/commits", nil)
+	snapshotAccountMisses    = metrics.NewRegisteredCounter("chain/snapshot/account/misses", nil)
+	snapshotStorageMisses    = metrics.NewRegisteredCounter("chain/snapshot/storage/misses", nil)
 
 	triedbCommitTimer = metrics.NewRegisteredCounter("chain/triedb/commits", nil)
 
@@ -104,6 +106,8 @@ var (
 	errCacheConfigNotSpecified = errors.New("must specify cache config")
 	errInvalidOldChain         = errors.New("invalid old chain")
 	errInvalidNewChain         = errors.New("invalid new chain")
+	errTxIndexingSkipped       = errors.New("transaction indexing is skipped")
+	errTxIndexRebuildRunning   = errors.New("transaction index rebuild already running")
 )
 
 const (
@@ -166,23 +170,31 @@ type cacheableCoinbaseConfig struct {
 // CacheConfig contains the configuration values for the trie database
 // that's resident in a blockchain.
 type CacheConfig struct {
-	TrieCleanLimit                  int     // Memory allowance (MB) to use for caching trie nodes in memory
-	TrieDirtyLimit                  int     // Memory limit (MB) at which to block on insert and force a flush of dirty trie nodes to disk
-	TrieDirtyCommitTarget           int     // Memory limit (MB) to target for the dirties cache before invoking commit
-	TriePrefetcherParallelism       int     // Max concurrent disk reads trie prefetcher should perform at once
-	CommitInterval                  uint64  // Commit the trie every [CommitInterval] blocks.
-	Pruning                         bool    // Whether to disable trie write caching and GC altogether (archive node)
-	AcceptorQueueLimit              int     // Blocks to queue before blocking during acceptance
-	PopulateMissingTries            *uint64 // If non-nil, sets the starting height for re-generating historical tries.
-	PopulateMissingTriesParallelism int     // Number of readers to use when trying to populate missing tries.
-	AllowMissingTries               bool    // Whether to allow an archive node to run with pruning enabled
-	SnapshotDelayInit               bool    // Whether to initialize snapshots on startup or wait for external call
-	SnapshotLimit                   int     // Memory allowance (MB) to use for caching snapshot entries in memory
-	SnapshotVerify                  bool    // Verify generated snapshots
-	Preimages                       bool    // Whether to store preimage of trie key to the disk
-	AcceptedCacheSize               int     // Depth of accepted headers cache and accepted logs cache at the accepted tip
-	TxLookupLimit                   uint64  // Number of recent blocks for which to maintain transaction lookup indices
-	SkipTxIndexing                  bool    // Whether to skip transaction indexing
+	TrieCleanLimit                  int           // Memory allowance (MB) to use for caching trie nodes in memory
+	TrieDirtyLimit                  int           // Memory limit (MB) at which to block on insert and force a flush of dirty trie nodes to disk
+	TrieDirtyCommitTarget           int           // Memory limit (MB) to target for the dirties cache before invoking commit
+	TriePrefetcherParallelism       int           // Max concurrent disk reads trie prefetcher should perform at once
+	CommitInterval                  uint64        // Commit the trie every [CommitInterval] blocks.
+	Pruning                         bool          // Whether to disable trie write caching and GC altogether (archive node)
+	AcceptorQueueLimit              int           // Blocks to queue before blocking during acceptance
+	PopulateMissingTries            *uint64       // If non-nil, sets the starting height for re-generating historical tries.
+	PopulateMissingTriesParallelism int           // Number of readers to use when trying to populate missing tries.
+	AllowMissingTries               bool          // Whether to allow an archive node to run with pruning enabled
+	SnapshotDelayInit               bool          // Whether to initialize snapshots on startup or wait for external call
+	SnapshotLimit                   int           // Memory allowance (MB) to use for caching snapshot entries in memory
+	SnapshotVerify                  bool          // Verify generated snapshots
+	Preimages                       bool          // Whether to store preimage of trie key to the disk
+	StateDiffs                      bool          // Whether to store the state diff of each block to the disk
+	StateDiffsRetention             uint64        // Number of recent accepted blocks whose state diffs are retained (0 = unlimited)
+	GasUsageWindow                  uint64        // Number of blocks per window of the contract gas usage index (0 = disabled)
+	GasUsageRetention               uint64        // Number of recent windows retained in the contract gas usage index (0 = unlimited)
+	AcceptedCacheSize               int           // Depth of accepted headers cache and accepted logs cache at the accepted tip
+	TxLookupLimit                   uint64        // Number of recent blocks for which to maintain transaction lookup indices
+	SkipTxIndexing                  bool          // Whether to skip transaction indexing
+	ParallelTxExecution             int           // Number of goroutines speculatively executing the transactions of a block (0 = sequential execution)
+	AsyncStateCommit                bool          // Whether to hash and commit the state of verified blocks in the background
+	StateHistory                    uint64        // Number of recent accepted state roots retained in pruning mode (0 = 32)
+	StateHistoryDuration            time.Duration // Minimum age of the accepted state roots retained in pruning mode (0 = disabled)
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
@@ -224,6 +236,10 @@ type BlockChain struct {
 	stateCache   state.Database // State database to reuse between imports (contains state cache)
 	stateManager TrieWriter
 
+	// stateCommitter hashes and commits the state of verified blocks in the
+	// background, if [CacheConfig.AsyncStateCommit] is set.
+	stateCommitter *stateCommitter
+
 	hc                *HeaderChain
 	rmLogsFeed        event.Feed
 	chainFeed         event.Feed
@@ -301,6 +317,11 @@ type BlockChain struct {
 
 	// [acceptedLogsCache] stores recently accepted logs to improve the performance of eth_getLogs.
 	acceptedLogsCache FIFOCache[common.Hash, [][]*types.Log]
+
+	// [txIndexRebuild] is the progress of the last transaction index rebuild
+	// started by RebuildTxIndex, if any.
+	txIndexRebuild     *TxIndexRebuild
+	txIndexRebuildLock sync.Mutex
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -416,6 +437,11 @@ func NewBlockChain(
 	// Start processing accepted blocks effects in the background
 	go bc.startAcceptor()
 
+	// Start committing the state of verified blocks in the background if required.
+	if bc.cacheConfig.AsyncStateCommit {
+		bc.stateCommitter = newStateCommitter(bc.commitStateInBackground)
+	}
+
 	// Start tx indexer/unindexer if required.
 	if bc.cacheConfig.TxLookupLimit != 0 {
 		bc.wg.Add(1)
@@ -491,15 +517,101 @@ func (bc *BlockChain) dispatchTxUnindexer() {
 	}
 }
 
+// TxIndexRebuild is the progress of a transaction index rebuild.
+type TxIndexRebuild struct {
+	Running bool   `json:"running"`
+	From    uint64 `json:"from"` // First block of the rebuilt range
+	To      uint64 `json:"to"`   // Last block of the rebuilt range
+	// Tail is the lowest block indexed so far. Blocks are indexed from [To]
+	// down to [From], so Tail is To+1 until the first block is indexed.
+	Tail     uint64     `json:"tail"`
+	Started  time.Time  `json:"started"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// RebuildTxIndex rewrites in the background the transaction lookup entries of
+// the accepted blocks retained by [CacheConfig.TxLookupLimit], to recover from
+// missing or corrupt entries. Blocks accepted while the rebuild runs are indexed
+// by the acceptor as usual.
+func (bc *BlockChain) RebuildTxIndex() (TxIndexRebuild, error) {
+	if bc.cacheConfig.SkipTxIndexing {
+		return TxIndexRebuild{}, errTxIndexingSkipped
+	}
+	bc.txIndexRebuildLock.Lock()
+	defer bc.txIndexRebuildLock.Unlock()
+
+	if bc.txIndexRebuild != nil && bc.txIndexRebuild.Running {
+		return *bc.txIndexRebuild, errTxIndexRebuildRunning
+	}
+	var (
+		head = bc.LastAcceptedBlock().NumberU64()
+		from uint64
+	)
+	if limit := bc.cacheConfig.TxLookupLimit; limit != 0 && head >= limit {
+		from = head - limit + 1
+	}
+	rebuild := &TxIndexRebuild{
+		Running: true,
+		From:    from,
+		To:      head,
+		Tail:    head + 1,
+		Started: time.Now(),
+	}
+	bc.txIndexRebuild = rebuild
+	log.Info("Rebuilding transaction index", "from", from, "to", head)
+
+	bc.wg.Add(1)
+	go func() {
+		defer bc.wg.Done()
+
+		rawdb.IndexTransactionsWithProgress(bc.db, from, head+1, bc.quit, func(number uint64) {
+			bc.txIndexRebuildLock.Lock()
+			rebuild.Tail = number
+			bc.txIndexRebuildLock.Unlock()
+		})
+		// Drop the lookups cached before the rebuild, which may be the corrupt ones.
+		bc.txLookupCache.Purge()
+
+		bc.txIndexRebuildLock.Lock()
+		defer bc.txIndexRebuildLock.Unlock()
+		finished := time.Now()
+		rebuild.Running = false
+		rebuild.Finished = &finished
+		log.Info("Rebuilt transaction index", "from", from, "to", head, "tail", rebuild.Tail, "elapsed", common.PrettyDuration(finished.Sub(rebuild.Started)))
+	}()
+	return *rebuild, nil
+}
+
+// TxIndexRebuildStatus returns the progress of the last transaction index
+// rebuild started by RebuildTxIndex, or false if none was started.
+func (bc *BlockChain) TxIndexRebuildStatus() (TxIndexRebuild, bool) {
+	bc.txIndexRebuildLock.Lock()
+	defer bc.txIndexRebuildLock.Unlock()
+
+	if bc.txIndexRebuild == nil {
+		return TxIndexRebuild{}, false
+	}
+	return *bc.txIndexRebuild, true
+}
+
 // writeBlockAcceptedIndices writes any indices that must be persisted for accepted block.
 // This includes the following:
 // - transaction lookup indices
+// - contract gas usage indices
+// - removing the state diff falling out of the retention window
 // - updating the acceptor tip index
 func (bc *BlockChain) writeBlockAcceptedIndices(b *types.Block) error {
 	batch := bc.db.NewBatch()
 	if !bc.cacheConfig.SkipTxIndexing {
 		rawdb.WriteTxLookupEntriesByBlock(batch, b)
 	}
+	if bc.cacheConfig.GasUsageWindow > 0 {
+		bc.writeContractGasUsage(batch, b)
+	}
+	if retention := bc.cacheConfig.StateDiffsRetention; retention > 0 && b.NumberU64() > retention {
+		// Remove the state diff of the accepted block falling out of the retention window.
+		rawdb.DeleteStateDiff(batch, rawdb.ReadCanonicalHash(bc.db, b.NumberU64()-retention))
+	}
 	if err := rawdb.WriteAcceptorTip(batch, b.Hash()); err != nil {
 		return fmt.Errorf("%w: failed to write acceptor tip key", err)
 	}
@@ -509,6 +621,43 @@ func (bc *BlockChain) writeBlockAcceptedIndices(b *types.Block) error {
 	return nil
 }
 
+// writeContractGasUsage adds the gas used by the transactions of [b] to the
+// contract gas usage index, attributing it to the called or created contract.
+// Once [b] starts a new window, the windows older than [GasUsageRetention] are
+// removed from the index, unless it is zero.
+func (bc *BlockChain) writeContractGasUsage(batch ethdb.Batch, b *types.Block) {
+	window := b.NumberU64() / bc.cacheConfig.GasUsageWindow
+	retention := bc.cacheConfig.GasUsageRetention
+	if retention > 0 && b.NumberU64()%bc.cacheConfig.GasUsageWindow == 0 && window >= retention {
+		rawdb.DeleteContractGasUsages(bc.db, batch, window-retention)
+	}
+	if len(b.Transactions()) == 0 {
+		return
+	}
+	receipts := bc.GetReceiptsByHash(b.Hash())
+	if len(receipts) != len(b.Transactions()) {
+		log.Warn("Missing receipts for contract gas usage index", "block", b.NumberU64(), "hash", b.Hash())
+		return
+	}
+	usages := make(map[common.Address]rawdb.ContractGasUsage)
+	for i, tx := range b.Transactions() {
+		contract := receipts[i].ContractAddress
+		if to := tx.To(); to != nil {
+			contract = *to
+		}
+		usage := usages[contract]
+		usage.GasUsed += receipts[i].GasUsed
+		usage.TxCount++
+		usages[contract] = usage
+	}
+	for contract, usage := range usages {
+		stored := rawdb.ReadContractGasUsage(bc.db, window, contract)
+		stored.GasUsed += usage.GasUsed
+		stored.TxCount += usage.TxCount
+		rawdb.WriteContractGasUsage(batch, window, contract, stored)
+	}
+}
+
 // flattenSnapshot attempts to flatten a block of [hash] to disk.
 func (bc *BlockChain) flattenSnapshot(postAbortWork func() error, hash common.Hash) error {
 	// If snapshots are not initialized, perform [postAbortWork] immediately.
@@ -944,6 +1093,11 @@ func (bc *BlockChain) stopWithoutSaving() {
 
 	log.Info("Closing quit channel")
 	close(bc.quit)
+	// Commit the state of all verified blocks, so it can be accepted or rejected
+	if bc.stateCommitter != nil {
+		log.Info("Stopping state committer")
+		bc.stateCommitter.stop()
+	}
 	// Wait for accepted feed to process all remaining items
 	log.Info("Stopping Acceptor")
 	start := time.Now()
@@ -1065,6 +1219,12 @@ func (bc *BlockChain) Accept(block *types.Block) error {
 		)
 	}
 
+	// The state of [block] must reach its required root before it is accepted.
+	if err := bc.waitForState(block.Hash()); err != nil {
+		return err
+	}
+	defer bc.releaseState(block.Hash())
+
 	// If the canonical hash at the block height does not match the block we are
 	// accepting, we need to trigger a reorg.
 	canonical := bc.GetCanonicalHash(block.NumberU64())
@@ -1125,20 +1285,28 @@ func (bc *BlockChain) Reject(block *types.Block) error {
 	bc.chainmu.Lock()
 	defer bc.chainmu.Unlock()
 
-	// Reject Trie
-	if err := bc.stateManager.RejectTrie(block); err != nil {
-		return fmt.Errorf("unable to reject trie: %w", err)
-	}
+	// If the state of [block] never reached its required root, its trie was
+	// never inserted and there is nothing to reject.
+	committed := bc.waitForState(block.Hash()) == nil
+	defer bc.releaseState(block.Hash())
+
+	if committed {
+		// Reject Trie
+		if err := bc.stateManager.RejectTrie(block); err != nil {
+			return fmt.Errorf("unable to reject trie: %w", err)
+		}
 
-	if bc.snaps != nil {
-		if err := bc.snaps.Discard(block.Hash()); err != nil {
-			log.Error("unable to discard snap from rejected block", "block", block.Hash(), "number", block.NumberU64(), "root", block.Root())
+		if bc.snaps != nil {
+			if err := bc.snaps.Discard(block.Hash()); err != nil {
+				log.Error("unable to discard snap from rejected block", "block", block.Hash(), "number", block.NumberU64(), "root", block.Root())
+			}
 		}
 	}
 
 	// Remove the block since its data is no longer needed
 	batch := bc.db.NewBatch()
 	rawdb.DeleteBlock(batch, block.Hash(), block.NumberU64())
+	rawdb.DeleteStateDiff(batch, block.Hash())
 	if err := batch.Write(); err != nil {
 		return fmt.Errorf("failed to write delete block batch: %w", err)
 	}
@@ -1208,10 +1376,32 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
 	rawdb.WritePreimages(blockBatch, state.Preimages())
+	if bc.cacheConfig.StateDiffs {
+		rawdb.WriteStateDiff(blockBatch, block.Hash(), state.StateDiff())
+	}
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
 
+	// If the state is committed in the background, its root is only checked
+	// against the header once the checkpoint of [block] is reached.
+	if bc.stateCommitter != nil {
+		// The prefetcher is not safe to use concurrently, and is not needed
+		// to hash the state.
+		state.StopPrefetcher()
+		if bc.stateCommitter.enqueue(block, state) {
+			return nil
+		}
+		if err := validateStateRoot(bc.chainConfig, block.Header(), state); err != nil {
+			return err
+		}
+	}
+	return bc.commitState(block, state)
+}
+
+// commitState commits [state] to the trie database as the state of [block] and
+// inserts its trie into [stateManager].
+func (bc *BlockChain) commitState(block *types.Block, state *state.StateDB) error {
 	// Commit all cached state changes into underlying memory database.
 	// If snapshots are enabled, call CommitWithSnaps to explicitly create a snapshot
 	// diff layer for the block.
@@ -1280,6 +1470,11 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 			return n, err
 		}
 	}
+	// Imports of whole chains are only complete once the state of the last
+	// block reaches its required root.
+	if err := bc.waitForState(chain[len(chain)-1].Hash()); err != nil {
+		return len(chain) - 1, err
+	}
 
 	return len(chain), nil
 }
@@ -1303,6 +1498,11 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 	start := time.Now()
 	bc.senderCacher.Recover(types.MakeSigner(bc.chainConfig, block.Number(), block.Time()), block.Transactions())
 
+	// The state of the parent must reach its required root before it is built on.
+	if err := bc.waitForState(block.ParentHash()); err != nil {
+		return err
+	}
+
 	substart := time.Now()
 	err := bc.engine.VerifyHeader(bc, block.Header())
 	if err == nil {
@@ -1381,9 +1581,16 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 	}
 	ptime := time.Since(pstart)
 
-	// Validate the state using the default validator
+	// Validate the state using the default validator. If the state is committed
+	// in the background, its root is validated there.
 	vstart := time.Now()
-	if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
+	deferred := writes && bc.stateCommitter != nil
+	if deferred {
+		err = bc.validator.ValidateReceipts(block, receipts, usedGas)
+	} else {
+		err = bc.validator.ValidateState(block, statedb, receipts, usedGas)
+	}
+	if err != nil {
 		bc.reportBlock(block, receipts, err)
 		return err
 	}
@@ -1394,6 +1601,8 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 	storageReadTimer.Inc(statedb.StorageReads.Milliseconds())                  // Storage reads are complete(in processing)
 	snapshotAccountReadTimer.Inc(statedb.SnapshotAccountReads.Milliseconds())  // Account reads are complete(in processing)
 	snapshotStorageReadTimer.Inc(statedb.SnapshotStorageReads.Milliseconds())  // Storage reads are complete(in processing)
+	snapshotAccountMisses.Inc(int64(statedb.SnapshotAccountMisses))            // Account reads served by the trie (in processing)
+	snapshotStorageMisses.Inc(int64(statedb.SnapshotStorageMisses))            // Storage reads served by the trie (in processing)
 	accountUpdateTimer.Inc(statedb.AccountUpdates.Milliseconds())              // Account updates are complete(in validation)
 	storageUpdateTimer.Inc(statedb.StorageUpdates.Milliseconds())              // Storage updates are complete(in validation)
 	accountHashTimer.Inc(statedb.AccountHashes.Milliseconds())                 // Account hashes are complete(in validation)
@@ -1421,12 +1630,17 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 	if err := bc.writeBlockAndSetHead(block, receipts, logs, statedb); err != nil {
 		return err
 	}
-	// Update the metrics touched during block commit
-	accountCommitTimer.Inc(statedb.AccountCommits.Milliseconds())   // Account commits are complete, we can mark them
-	storageCommitTimer.Inc(statedb.StorageCommits.Milliseconds())   // Storage commits are complete, we can mark them
-	snapshotCommitTimer.Inc(statedb.SnapshotCommits.Milliseconds()) // Snapshot commits are complete, we can mark them
-	triedbCommitTimer.Inc(statedb.TrieDBCommits.Milliseconds())     // Trie database commits are complete, we can mark them
-	blockWriteTimer.Inc((time.Since(wstart) - statedb.AccountCommits - statedb.StorageCommits - statedb.SnapshotCommits - statedb.TrieDBCommits).Milliseconds())
+	// Update the metrics touched during block commit, unless [statedb] is being
+	// committed in the background.
+	if !deferred {
+		accountCommitTimer.Inc(statedb.AccountCommits.Milliseconds())   // Account commits are complete, we can mark them
+		storageCommitTimer.Inc(statedb.StorageCommits.Milliseconds())   // Storage commits are complete, we can mark them
+		snapshotCommitTimer.Inc(statedb.SnapshotCommits.Milliseconds()) // Snapshot commits are complete, we can mark them
+		triedbCommitTimer.Inc(statedb.TrieDBCommits.Milliseconds())     // Trie database commits are complete, we can mark them
+		blockWriteTimer.Inc((time.Since(wstart) - statedb.AccountCommits - statedb.StorageCommits - statedb.SnapshotCommits - statedb.TrieDBCommits).Milliseconds())
+	} else {
+		blockWriteTimer.Inc(time.Since(wstart).Milliseconds())
+	}
 	blockInsertTimer.Inc(time.Since(start).Milliseconds())
 
 	log.Debug("Inserted new block", "number", block.Number(), "hash", block.Hash(),
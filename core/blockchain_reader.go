@@ -39,9 +39,11 @@ import (
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/event"
 )
 
@@ -221,6 +223,7 @@ func (bc *BlockChain) GetTransactionLookup(hash common.Hash) *rawdb.LegacyTxLook
 
 // HasState checks if state trie is fully present in the database or not.
 func (bc *BlockChain) HasState(hash common.Hash) bool {
+	bc.waitForStateRoot(hash)
 	_, err := bc.stateCache.OpenTrie(hash)
 	return err == nil
 }
@@ -249,6 +252,7 @@ func (bc *BlockChain) State() (*state.StateDB, error) {
 
 // StateAt returns a new mutable state based on a particular point in time.
 func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	bc.waitForStateRoot(root)
 	return state.New(root, bc.stateCache, bc.snaps)
 }
 
@@ -344,22 +348,51 @@ func (bc *BlockChain) SubscribeAcceptedTransactionEvent(ch chan<- NewTxsEvent) e
 	return bc.scope.Track(bc.txAcceptedFeed.Subscribe(ch))
 }
 
+// ConfigAt returns the chain config at [parent], including the precompile upgrades
+// scheduled by the governance precompile in the state of [parent].
+func (bc *BlockChain) ConfigAt(parent *types.Header) (*params.ChainConfig, error) {
+	return configAt(bc.Config(), parent, bc.StateAt)
+}
+
+// configAt implements ConfigAt, opening the state of [parent] with [stateAt]
+// only if the governance precompile is enabled at [parent].
+func configAt(config *params.ChainConfig, parent *types.Header, stateAt func(common.Hash) (*state.StateDB, error)) (*params.ChainConfig, error) {
+	if !config.IsPrecompileEnabled(governance.ContractAddress, parent.Time) {
+		return config, nil
+	}
+	stateDB, err := stateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	return governance.ChainConfigAt(config, stateDB, parent.Time)
+}
+
 // GetFeeConfigAt returns the fee configuration and the last changed block number at [parent].
 // If FeeManager is activated at [parent], returns the fee config in the precompile contract state.
-// Otherwise returns the fee config in the chain config.
+// Otherwise returns the fee config in the chain config, as changed by the fee config upgrades
+// activated at [parent].
 // Assumes that a valid configuration is stored when the precompile is activated.
 func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
-	config := bc.Config()
+	return getFeeConfigAt(bc.Config(), parent, bc.feeConfigCache, bc.StateAt)
+}
+
+// getFeeConfigAt implements GetFeeConfigAt, opening the state with [stateAt]
+// if the fee config is not in [cache].
+func getFeeConfigAt(config *params.ChainConfig, parent *types.Header, cache *lru.Cache[common.Hash, *cacheableFeeConfig], stateAt func(common.Hash) (*state.StateDB, error)) (commontype.FeeConfig, *big.Int, error) {
+	config, err := configAt(config, parent, stateAt)
+	if err != nil {
+		return commontype.EmptyFeeConfig, nil, err
+	}
 	if !config.IsPrecompileEnabled(feemanager.ContractAddress, parent.Time) {
-		return config.FeeConfig, common.Big0, nil
+		return config.GetFeeConfigAtTimestamp(parent.Time), common.Big0, nil
 	}
 
 	// try to return it from the cache
-	if cached, hit := bc.feeConfigCache.Get(parent.Root); hit {
+	if cached, hit := cache.Get(parent.Root); hit {
 		return cached.feeConfig, cached.lastChangedAt, nil
 	}
 
-	stateDB, err := bc.StateAt(parent.Root)
+	stateDB, err := stateAt(parent.Root)
 	if err != nil {
 		return commontype.EmptyFeeConfig, nil, err
 	}
@@ -375,7 +408,7 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	lastChangedAt := feemanager.GetFeeConfigLastChangedAt(stateDB)
 	cacheable := &cacheableFeeConfig{feeConfig: storedFeeConfig, lastChangedAt: lastChangedAt}
 	// add it to the cache
-	bc.feeConfigCache.Add(parent.Root, cacheable)
+	cache.Add(parent.Root, cacheable)
 	return storedFeeConfig, lastChangedAt, nil
 }
 
@@ -383,13 +416,22 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 // If RewardManager is activated at [parent], returns the reward manager config in the precompile contract state.
 // If fee recipients are allowed, returns true in the second return value.
 func (bc *BlockChain) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
-	config := bc.Config()
+	return getCoinbaseAt(bc.Config(), parent, bc.coinbaseConfigCache, bc.StateAt)
+}
+
+// getCoinbaseAt implements GetCoinbaseAt, opening the state with [stateAt] if
+// the coinbase config is not in [cache].
+func getCoinbaseAt(config *params.ChainConfig, parent *types.Header, cache *lru.Cache[common.Hash, *cacheableCoinbaseConfig], stateAt func(common.Hash) (*state.StateDB, error)) (common.Address, bool, error) {
 	if !config.IsSubnetEVM(parent.Time) {
 		return constants.BlackholeAddr, false, nil
 	}
 
+	config, err := configAt(config, parent, stateAt)
+	if err != nil {
+		return common.Address{}, false, err
+	}
 	if !config.IsPrecompileEnabled(rewardmanager.ContractAddress, parent.Time) {
-		if bc.chainConfig.AllowFeeRecipients {
+		if config.AllowFeeRecipients {
 			return common.Address{}, true, nil
 		} else {
 			return constants.BlackholeAddr, false, nil
@@ -397,18 +439,18 @@ func (bc *BlockChain) GetCoinbaseAt(parent *types.Header) (common.Address, bool,
 	}
 
 	// try to return it from the cache
-	if cached, hit := bc.coinbaseConfigCache.Get(parent.Root); hit {
+	if cached, hit := cache.Get(parent.Root); hit {
 		return cached.coinbaseAddress, cached.allowFeeRecipients, nil
 	}
 
-	stateDB, err := bc.StateAt(parent.Root)
+	stateDB, err := stateAt(parent.Root)
 	if err != nil {
 		return common.Address{}, false, err
 	}
 	rewardAddress, feeRecipients := rewardmanager.GetStoredRewardAddress(stateDB)
 
 	cacheable := &cacheableCoinbaseConfig{coinbaseAddress: rewardAddress, allowFeeRecipients: feeRecipients}
-	bc.coinbaseConfigCache.Add(parent.Root, cacheable)
+	cache.Add(parent.Root, cacheable)
 	return rewardAddress, feeRecipients, nil
 }
 
@@ -116,6 +116,19 @@ func TestPruningBlockChain(t *testing.T) {
 	}
 }
 
+func TestAsyncStateCommitBlockChain(t *testing.T) {
+	create := func(db ethdb.Database, gspec *Genesis, lastAcceptedHash common.Hash) (*BlockChain, error) {
+		cacheConfig := *pruningConfig
+		cacheConfig.AsyncStateCommit = true
+		return createBlockChain(db, &cacheConfig, gspec, lastAcceptedHash)
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.testFunc(t, create)
+		})
+	}
+}
+
 func TestPruningBlockChainSnapsDisabled(t *testing.T) {
 	create := func(db ethdb.Database, gspec *Genesis, lastAcceptedHash common.Hash) (*BlockChain, error) {
 		return createBlockChain(
@@ -800,6 +813,108 @@ func TestTransactionSkipIndexing(t *testing.T) {
 
 // TestCanonicalHashMarker tests all the canonical hash markers are updated/deleted
 // correctly in case reorg is called.
+func TestRebuildTxIndex(t *testing.T) {
+	require := require.New(t)
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		funds   = big.NewInt(10000000000000)
+		gspec   = &Genesis{
+			Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+			Alloc:  GenesisAlloc{addr1: {Balance: funds}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 10, 10, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(addr1), addr2, big.NewInt(10000), params.TxGas, nil, nil), signer, key1)
+		require.NoError(err)
+		block.AddTx(tx)
+	})
+	require.NoError(err)
+
+	for _, limit := range []uint64{0, 4} {
+		t.Run(fmt.Sprintf("limit: %d", limit), func(t *testing.T) {
+			conf := &CacheConfig{
+				TrieCleanLimit:            256,
+				TrieDirtyLimit:            256,
+				TrieDirtyCommitTarget:     20,
+				TriePrefetcherParallelism: 4,
+				Pruning:                   true,
+				CommitInterval:            4096,
+				SnapshotLimit:             256,
+				SnapshotNoBuild:           true,
+				AcceptorQueueLimit:        64,
+				TxLookupLimit:             limit,
+			}
+			chainDB := rawdb.NewMemoryDatabase()
+			chain, err := createBlockChain(chainDB, conf, gspec, common.Hash{})
+			require.NoError(err)
+			defer chain.Stop()
+
+			_, err = chain.InsertChain(blocks)
+			require.NoError(err)
+			for _, block := range blocks {
+				require.NoError(chain.Accept(block))
+			}
+			chain.DrainAcceptorQueue()
+
+			// Corrupt the index by dropping the entries of every accepted block.
+			for _, block := range blocks {
+				for _, tx := range block.Transactions() {
+					rawdb.DeleteTxLookupEntry(chainDB, tx.Hash())
+				}
+			}
+			_, ok := chain.TxIndexRebuildStatus()
+			require.False(ok)
+
+			rebuild, err := chain.RebuildTxIndex()
+			require.NoError(err)
+			require.EqualValues(10, rebuild.To)
+			require.Eventually(func() bool {
+				rebuild, ok = chain.TxIndexRebuildStatus()
+				return ok && !rebuild.Running
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NotNil(rebuild.Finished)
+
+			var from uint64
+			if limit != 0 {
+				from = 10 - limit + 1
+			}
+			require.Equal(from, rebuild.From)
+			require.Equal(from, rebuild.Tail)
+			require.Equal(from, *rawdb.ReadTxIndexTail(chainDB))
+			for _, block := range blocks {
+				for _, tx := range block.Transactions() {
+					entry := rawdb.ReadTxLookupEntry(chainDB, tx.Hash())
+					if block.NumberU64() < from {
+						require.Nil(entry, "block %d", block.NumberU64())
+					} else {
+						require.NotNil(entry, "block %d", block.NumberU64())
+						require.Equal(block.NumberU64(), *entry)
+					}
+				}
+			}
+		})
+	}
+
+	// The index cannot be rebuilt while indexing is skipped.
+	chain, err := createBlockChain(rawdb.NewMemoryDatabase(), &CacheConfig{
+		TrieCleanLimit:     256,
+		TrieDirtyLimit:     256,
+		Pruning:            true,
+		CommitInterval:     4096,
+		SnapshotLimit:      256,
+		AcceptorQueueLimit: 64,
+		SkipTxIndexing:     true,
+	}, gspec, common.Hash{})
+	require.NoError(err)
+	defer chain.Stop()
+	_, err = chain.RebuildTxIndex()
+	require.ErrorIs(err, errTxIndexingSkipped)
+}
+
 func TestCanonicalHashMarker(t *testing.T) {
 	var cases = []struct {
 		forkA int
@@ -957,6 +1072,77 @@ func TestTxLookupSkipIndexingBlockChain(t *testing.T) {
 	}
 }
 
+func TestStateDiffsRetention(t *testing.T) {
+	require := require.New(t)
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		funds   = big.NewInt(10000000000000)
+		gspec   = &Genesis{
+			Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+			Alloc:  GenesisAlloc{addr1: {Balance: funds}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewFaker(), 8, 10, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(addr1), addr2, big.NewInt(10000), params.TxGas, nil, nil), signer, key1)
+		require.NoError(err)
+		block.AddTx(tx)
+	})
+	require.NoError(err)
+	// Generate a sibling of the first block to be rejected.
+	_, forkBlocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewFaker(), 1, 10, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(addr1), addr1, big.NewInt(10000), params.TxGas, nil, nil), signer, key1)
+		require.NoError(err)
+		block.AddTx(tx)
+	})
+	require.NoError(err)
+
+	conf := &CacheConfig{
+		TrieCleanLimit:            256,
+		TrieDirtyLimit:            256,
+		TrieDirtyCommitTarget:     20,
+		TriePrefetcherParallelism: 4,
+		Pruning:                   true,
+		CommitInterval:            4096,
+		SnapshotLimit:             256,
+		SnapshotNoBuild:           true, // Ensure the test errors if snapshot initialization fails
+		AcceptorQueueLimit:        64,
+		StateDiffs:                true,
+		StateDiffsRetention:       3,
+	}
+	chain, err := createBlockChain(rawdb.NewMemoryDatabase(), conf, gspec, common.Hash{})
+	require.NoError(err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(err)
+	_, err = chain.InsertChain(forkBlocks)
+	require.NoError(err)
+	require.NotNil(rawdb.ReadStateDiff(chain.db, forkBlocks[0].Hash()))
+
+	require.NoError(chain.Accept(blocks[0]))
+	require.NoError(chain.Reject(forkBlocks[0]))
+	require.Nil(rawdb.ReadStateDiff(chain.db, forkBlocks[0].Hash()), "state diff of rejected block should be deleted")
+
+	for _, block := range blocks[1:] {
+		require.NoError(chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+
+	// Only the state diffs of the last [StateDiffsRetention] accepted blocks are retained.
+	for _, block := range blocks {
+		diff := rawdb.ReadStateDiff(chain.db, block.Hash())
+		if block.NumberU64() > uint64(len(blocks))-conf.StateDiffsRetention {
+			require.NotNilf(diff, "missing state diff of block %d", block.NumberU64())
+		} else {
+			require.Nilf(diff, "state diff of block %d should be deleted", block.NumberU64())
+		}
+	}
+}
+
 func TestCreateThenDeletePreByzantium(t *testing.T) {
 	// We want to use pre-byzantium rules where we have intermediate state roots
 	// between transactions.
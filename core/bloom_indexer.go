@@ -23,9 +23,11 @@ import (
 	"github.com/ava-labs/subnet-evm/core/bloombits"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
 	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/bitutil"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 const (
@@ -34,6 +36,11 @@ const (
 	bloomThrottling = 100 * time.Millisecond
 )
 
+var (
+	bloomSectionsGauge = metrics.NewRegisteredGauge("chain/bloombits/sections", nil)
+	bloomSectionTimer  = metrics.NewRegisteredTimer("chain/bloombits/section", nil)
+)
+
 // BloomIndexer implements a core.ChainIndexer, building up a rotated bloom bits index
 // for the Ethereum header bloom filters, permitting blazing fast filtering.
 type BloomIndexer struct {
@@ -42,6 +49,7 @@ type BloomIndexer struct {
 	gen     *bloombits.Generator // generator to rotate the bloom bits crating the bloom index
 	section uint64               // Section is the section number being processed currently
 	head    common.Hash          // Head is the hash of the last header processed
+	start   time.Time            // Time the current section started being processed
 }
 
 // NewBloomIndexer returns a chain indexer that generates bloom bits data for the
@@ -61,6 +69,7 @@ func NewBloomIndexer(db ethdb.Database, size, confirms uint64) *ChainIndexer {
 func (b *BloomIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
 	gen, err := bloombits.NewGenerator(uint(b.size))
 	b.gen, b.section, b.head = gen, section, common.Hash{}
+	b.start = time.Now()
 	return err
 }
 
@@ -83,10 +92,54 @@ func (b *BloomIndexer) Commit() error {
 		}
 		rawdb.WriteBloomBits(batch, uint(i), b.section, b.head, bitutil.CompressBytes(bits))
 	}
-	return batch.Write()
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	bloomSectionsGauge.Update(int64(b.section + 1))
+	bloomSectionTimer.UpdateSince(b.start)
+	return nil
 }
 
 // Prune returns an empty error since we don't support pruning here.
 func (b *BloomIndexer) Prune(threshold uint64) error {
 	return nil
 }
+
+// acceptedIndexerChain feeds a ChainIndexer with the accepted blocks of a
+// BlockChain rather than its preferred head. Accepted blocks are final, so
+// sections can be indexed as soon as their last block is accepted.
+type acceptedIndexerChain struct {
+	bc *BlockChain
+}
+
+// AcceptedIndexerChain returns a ChainIndexerChain following the accepted
+// blocks of [bc], to start a ChainIndexer requiring no confirmations on.
+func (bc *BlockChain) AcceptedIndexerChain() ChainIndexerChain {
+	return acceptedIndexerChain{bc: bc}
+}
+
+func (c acceptedIndexerChain) CurrentHeader() *types.Header {
+	return c.bc.LastAcceptedBlock().Header()
+}
+
+func (c acceptedIndexerChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Subscription {
+	accepted := make(chan ChainEvent, cap(ch))
+	sub := c.bc.SubscribeChainAcceptedEvent(accepted)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-accepted:
+				select {
+				case ch <- ChainHeadEvent{Block: ev.Block}:
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomIndexerFollowsAcceptedBlocks(t *testing.T) {
+	require := require.New(t)
+
+	const sectionSize = 8
+	gspec := &Genesis{Config: params.TestChainConfig}
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 20, 10, func(int, *BlockGen) {})
+	require.NoError(err)
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := createBlockChain(db, DefaultCacheConfig, gspec, common.Hash{})
+	require.NoError(err)
+	defer chain.Stop()
+
+	indexer := NewBloomIndexer(db, sectionSize, 0)
+	indexer.Start(chain.AcceptedIndexerChain())
+	defer indexer.Close()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(err)
+
+	sections := func() uint64 {
+		sections, _, _ := indexer.Sections()
+		return sections
+	}
+	// Sections are indexed as soon as their last block is accepted, without
+	// waiting for confirmations, and not before, even if the blocks are
+	// already part of the preferred chain.
+	for _, block := range blocks[:14] { // Accept up to block 14
+		require.NoError(chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+	require.Eventually(func() bool { return sections() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Never(func() bool { return sections() > 1 }, 300*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(chain.Accept(blocks[14])) // Block 15 completes the second section
+	chain.DrainAcceptorQueue()
+	require.Eventually(func() bool { return sections() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	_, head, headHash := indexer.Sections()
+	require.EqualValues(2*sectionSize-1, head)
+	require.Equal(blocks[14].Hash(), headHash)
+}
@@ -33,12 +33,14 @@ import (
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/consensus/misc/eip4844"
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -117,6 +119,9 @@ func (b *BlockGen) addTx(bc *BlockChain, vmConfig vm.Config, tx *types.Transacti
 	}
 	b.txs = append(b.txs, tx)
 	b.receipts = append(b.receipts, receipt)
+	if b.header.BlobGasUsed != nil {
+		*b.header.BlobGasUsed += receipt.BlobGasUsed
+	}
 }
 
 // AddTx adds a transaction to the generated block. If no coinbase has
@@ -174,6 +179,11 @@ func (b *BlockGen) Timestamp() uint64 {
 	return b.header.Time
 }
 
+// ParentHash returns the hash of the parent of the block being generated.
+func (b *BlockGen) ParentHash() common.Hash {
+	return b.header.ParentHash
+}
+
 // BaseFee returns the EIP-1559 base fee of the block being generated.
 func (b *BlockGen) BaseFee() *big.Int {
 	return new(big.Int).Set(b.header.BaseFee)
@@ -254,7 +264,14 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		b := &BlockGen{i: i, chain: blocks, parent: parent, statedb: statedb, config: config, engine: engine}
 		b.header = makeHeader(chainreader, config, parent, gap, statedb, b.engine)
 
-		err := ApplyUpgrades(config, &parent.Header().Time, b, statedb)
+		// The transactions of the block observe the precompile upgrades scheduled
+		// by the governance precompile.
+		blockConfig, err := governance.ChainConfigAt(config, statedb, b.header.Time)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read scheduled upgrades %v", err)
+		}
+		b.config = blockConfig
+		err = ApplyUpgrades(b.config, &parent.Header().Time, b, statedb)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to configure precompiles %v", err)
 		}
@@ -349,6 +366,16 @@ func makeHeader(chain consensus.ChainReader, config *params.ChainConfig, parent
 	} else {
 		header.GasLimit = CalcGasLimit(parent.GasUsed(), parent.GasLimit(), parent.GasLimit(), parent.GasLimit())
 	}
+	if chain.Config().IsCancun(header.Number, header.Time) {
+		var parentExcessBlobGas, parentBlobGasUsed uint64
+		if parent.ExcessBlobGas() != nil {
+			parentExcessBlobGas = *parent.ExcessBlobGas()
+			parentBlobGasUsed = *parent.BlobGasUsed()
+		}
+		excessBlobGas := eip4844.CalcExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+	}
 	return header
 }
 
@@ -367,7 +394,7 @@ func (cr *fakeChainReader) GetHeaderByHash(hash common.Hash) *types.Header
 func (cr *fakeChainReader) GetHeader(hash common.Hash, number uint64) *types.Header { return nil }
 func (cr *fakeChainReader) GetBlock(hash common.Hash, number uint64) *types.Block   { return nil }
 func (cr *fakeChainReader) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
-	return cr.config.FeeConfig, nil, nil
+	return cr.config.GetFeeConfigAtTimestamp(parent.Time), nil, nil
 }
 
 func (cr *fakeChainReader) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
@@ -109,4 +109,20 @@ var (
 	// ErrBlobFeeCapTooLow is returned if the transaction fee cap is less than the
 	// blob gas fee of the block.
 	ErrBlobFeeCapTooLow = errors.New("max fee per blob gas less than block blob gas fee")
+
+	// ErrEmptyAuthList is returned if a set code transaction has no authorizations.
+	ErrEmptyAuthList = errors.New("set code transaction with empty auth list")
+
+	// ErrSetCodeTxCreate is returned if a set code transaction is a contract creation.
+	ErrSetCodeTxCreate = errors.New("set code transaction cannot be used to create contract")
+)
+
+// EIP-7702 authorization errors. An authorization failing any of these checks
+// is skipped without invalidating the transaction that carries it.
+var (
+	ErrAuthorizationWrongChainID       = errors.New("EIP-7702 authorization chain ID mismatch")
+	ErrAuthorizationNonceOverflow      = errors.New("EIP-7702 authorization nonce > 64 bit")
+	ErrAuthorizationInvalidSignature   = errors.New("EIP-7702 authorization has invalid signature")
+	ErrAuthorizationDestinationHasCode = errors.New("EIP-7702 authorization destination is a contract")
+	ErrAuthorizationNonceMismatch      = errors.New("EIP-7702 authorization nonce does not match current account nonce")
 )
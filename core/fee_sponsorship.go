@@ -0,0 +1,94 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// isSponsoredSelector is the selector of the sponsorship check of the paymaster:
+// isSponsored(address sender, address to, uint256 value, uint256 gasLimit, bytes data) returns (bool)
+var isSponsoredSelector = crypto.Keccak256([]byte("isSponsored(address,address,uint256,uint256,bytes)"))[:4]
+
+// packIsSponsored returns the ABI encoded call of the sponsorship check of [msg].
+func packIsSponsored(msg *Message) []byte {
+	var to common.Address
+	if msg.To != nil {
+		to = *msg.To
+	}
+	input := make([]byte, 0, 4+6*common.HashLength+len(msg.Data)+common.HashLength)
+	input = append(input, isSponsoredSelector...)
+	input = append(input, common.LeftPadBytes(msg.From.Bytes(), common.HashLength)...)
+	input = append(input, common.LeftPadBytes(to.Bytes(), common.HashLength)...)
+	input = append(input, common.BigToHash(msg.Value).Bytes()...)
+	input = append(input, common.BigToHash(new(big.Int).SetUint64(msg.GasLimit)).Bytes()...)
+	input = append(input, common.BigToHash(big.NewInt(5*common.HashLength)).Bytes()...) // offset of data
+	input = append(input, common.BigToHash(big.NewInt(int64(len(msg.Data)))).Bytes()...)
+	input = append(input, common.RightPadBytes(msg.Data, (len(msg.Data)+common.HashLength-1)/common.HashLength*common.HashLength)...)
+	return input
+}
+
+// checkFeeSponsorship returns whether the paymaster of [sponsorship] sponsors the
+// gas of [msg], and the gas used by the check. The paymaster is called statically
+// from [params.FeeSponsorshipCheckCaller] with [gas], and sponsors the message
+// only if it returns true.
+func checkFeeSponsorship(evm *vm.EVM, sponsorship *params.FeeSponsorship, msg *Message, gas uint64) (bool, uint64) {
+	if gas == 0 {
+		return false, 0
+	}
+	// The check is not part of the execution of the message, so it is not traced.
+	tracer := evm.Config.Tracer
+	evm.Config.Tracer = nil
+	defer func() { evm.Config.Tracer = tracer }()
+
+	ret, leftOverGas, err := evm.StaticCall(vm.AccountRef(params.FeeSponsorshipCheckCaller), sponsorship.Paymaster, packIsSponsored(msg), gas)
+	sponsored := err == nil && len(ret) == common.HashLength && common.BytesToHash(ret) == common.BigToHash(common.Big1)
+	return sponsored, gas - leftOverGas
+}
+
+// feeSponsorshipCheckGas returns the gas available to the sponsorship check of
+// [msg]: the check gas of [sponsorship], limited to the gas left by [msg] after
+// its intrinsic gas under [rules], since the check is charged to the message.
+func feeSponsorshipCheckGas(sponsorship *params.FeeSponsorship, msg *Message, rules params.Rules) uint64 {
+	intrinsicGas, err := IntrinsicGas(msg.Data, msg.AccessList, msg.SetCodeAuthorizations, msg.To == nil, rules)
+	if err != nil || msg.GasLimit < intrinsicGas {
+		return 0
+	}
+	return min(sponsorship.GetCheckGas(), msg.GasLimit-intrinsicGas)
+}
+
+// IsFeeSponsored returns whether the paymaster of [config] sponsors the gas of
+// [tx], when executed on top of [statedb] in a block following [head] at [time].
+// Block hashes are not available to the paymaster, so the result is only an
+// estimate of the sponsorship of the transaction once included in a block.
+func IsFeeSponsored(config *params.ChainConfig, head *types.Header, time uint64, statedb *state.StateDB, tx *types.Transaction) bool {
+	if !config.IsFeeSponsorship(time) {
+		return false
+	}
+	msg, err := TransactionToMessage(tx, types.LatestSigner(config), head.BaseFee)
+	if err != nil || msg.From == config.FeeSponsorship.Paymaster {
+		return false
+	}
+	blockContext := vm.BlockContext{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: new(big.Int).Add(head.Number, common.Big1),
+		Time:        time,
+		Difficulty:  new(big.Int),
+		BaseFee:     head.BaseFee,
+		GasLimit:    head.GasLimit,
+	}
+	evm := vm.NewEVM(blockContext, NewEVMTxContext(msg), statedb, config, vm.Config{})
+	gas := feeSponsorshipCheckGas(config.FeeSponsorship, msg, config.Rules(blockContext.BlockNumber, time))
+	sponsored, _ := checkFeeSponsorship(evm, config.FeeSponsorship, msg, gas)
+	return sponsored
+}
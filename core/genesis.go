@@ -36,10 +36,12 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ava-labs/subnet-evm/consensus/headerextra"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -413,7 +415,31 @@ func (g *Genesis) Verify() error {
 	if err := g.Config.Verify(); err != nil {
 		return err
 	}
-	return nil
+	// Make sure no precompile is shadowed by code allocated in the genesis
+	for addr, account := range g.Alloc {
+		if len(account.Code) == 0 {
+			continue
+		}
+		if module, ok := modules.GetPrecompileModuleByAddress(addr); ok {
+			return fmt.Errorf("genesis allocates code at the address %s of precompile %s", addr, module.ConfigKey)
+		}
+	}
+	// Verify the chain's custom header fields. The state root is not computed
+	// here, since it requires applying the whole allocation.
+	header := &types.Header{
+		Number:     new(big.Int).SetUint64(g.Number),
+		Nonce:      types.EncodeNonce(g.Nonce),
+		Time:       g.Timestamp,
+		ParentHash: g.ParentHash,
+		Extra:      g.ExtraData,
+		GasLimit:   g.GasLimit,
+		GasUsed:    g.GasUsed,
+		BaseFee:    g.BaseFee,
+		Difficulty: g.Difficulty,
+		MixDigest:  g.Mixhash,
+		Coinbase:   g.Coinbase,
+	}
+	return headerextra.VerifyGenesis(g.Config, header)
 }
 
 // GenesisBlockForTesting creates and writes a block in which addr has the given wei balance.
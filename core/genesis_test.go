@@ -328,3 +328,21 @@ func TestGenesisWriteUpgradesRegression(t *testing.T) {
 	_, _, err = SetupGenesisBlock(db, trieDB, genesis, lastAcceptedBlock.Hash(), false)
 	require.NoError(err)
 }
+
+func TestGenesisVerifyPrecompileCollision(t *testing.T) {
+	require := require.New(t)
+	config := *params.TestChainConfig
+	genesis := &Genesis{
+		Config: &config,
+		Alloc: GenesisAlloc{
+			deployerallowlist.ContractAddress: {Balance: big.NewInt(1)},
+		},
+		GasLimit: config.FeeConfig.GasLimit.Uint64(),
+	}
+	// Funding a precompile address is allowed
+	require.NoError(genesis.Verify())
+
+	// Allocating code at a precompile address is not
+	genesis.Alloc[deployerallowlist.ContractAddress] = GenesisAccount{Balance: big.NewInt(1), Code: []byte{0x00}}
+	require.ErrorContains(genesis.Verify(), "genesis allocates code at the address")
+}
@@ -0,0 +1,183 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/metrics"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	parallelMergedMeter     = metrics.NewRegisteredMeter("chain/parallel/merged", nil)     // Txs whose speculative execution was merged
+	parallelReexecutedMeter = metrics.NewRegisteredMeter("chain/parallel/reexecuted", nil) // Txs re-executed due to a conflict
+)
+
+// speculativeResult is the result of executing a transaction on the state
+// before the transactions of its block.
+type speculativeResult struct {
+	receipt *types.Receipt
+	access  *state.AccessRecord
+	err     error
+	done    chan struct{}
+}
+
+// useParallelExecution reports whether the transactions of [block] are
+// executed in parallel under [cfg].
+func (p *StateProcessor) useParallelExecution(block *types.Block, cfg vm.Config) bool {
+	if p.bc == nil || p.bc.cacheConfig.ParallelTxExecution <= 0 || len(block.Transactions()) < 2 {
+		return false
+	}
+	// Tracers and preimage recording observe the execution of every transaction
+	// in order, and intermediate roots are only computed sequentially.
+	return cfg.Tracer == nil && !cfg.EnablePreimageRecording && p.config.IsByzantium(block.Number())
+}
+
+// applyTransactionsParallel applies the transactions of [block] to [statedb] under [config]
+// with the same results as applying them in order, using [workers] goroutines.
+//
+// Each transaction is first executed speculatively on a copy of [statedb],
+// recording the state it reads and writes. The results are then merged into
+// [statedb] in order. A transaction reading state written by a previous
+// transaction of the block, or whose changes cannot be merged, is executed
+// again on [statedb] instead. Blocks of independent transactions, such as
+// transfers between distinct accounts, are therefore mostly executed in
+// parallel, while blocks of dependent transactions are executed sequentially
+// after a wasted speculative execution.
+func (p *StateProcessor) applyTransactionsParallel(config *params.ChainConfig, block *types.Block, statedb *state.StateDB, cfg vm.Config, gp *GasPool, usedGas *uint64, workers int) (types.Receipts, []*types.Log, error) {
+	var (
+		header      = block.Header()
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		txs         = block.Transactions()
+		signer      = types.MakeSigner(config, header.Number, header.Time)
+		results     = make([]*speculativeResult, len(txs))
+		indices     = make(chan int, len(txs))
+		base        = statedb.Copy()
+		wg          sync.WaitGroup
+	)
+	base.StopPrefetcher()
+	for i := range txs {
+		results[i] = &speculativeResult{done: make(chan struct{})}
+		indices <- i
+	}
+	close(indices)
+
+	// Stop the workers before returning, so they do not outlive the block.
+	quit := make(chan struct{})
+	defer func() {
+		close(quit)
+		wg.Wait()
+	}()
+	for w := 0; w < workers && w < len(txs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The block context caches block hashes, so it is not shared.
+			vmenv := vm.NewEVM(NewEVMBlockContext(header, p.bc, nil), vm.TxContext{}, base, config, cfg)
+			for i := range indices {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+				p.speculate(txs[i], i, results[i], base, signer, header, blockHash, vmenv)
+			}
+		}()
+	}
+
+	var (
+		receipts types.Receipts
+		allLogs  []*types.Log
+		written  = make(state.AccessSet)
+		vmenv    = vm.NewEVM(NewEVMBlockContext(header, p.bc, nil), vm.TxContext{}, statedb, config, cfg)
+	)
+	for i, tx := range txs {
+		result := results[i]
+		<-result.done
+
+		statedb.SetTxContext(tx.Hash(), i)
+		receipt, access, merged := p.mergeSpeculative(tx, result, statedb, gp, usedGas, written)
+		if merged {
+			parallelMergedMeter.Mark(1)
+		} else {
+			parallelReexecutedMeter.Mark(1)
+
+			msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+			statedb.StartAccessRecording()
+			receipt, err = applyTransaction(msg, config, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
+			access = statedb.StopAccessRecording()
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+		}
+		written.Add(access.Writes())
+		receipts = append(receipts, receipt)
+		allLogs = append(allLogs, receipt.Logs...)
+	}
+	log.Trace("Executed block transactions in parallel", "number", blockNumber, "hash", blockHash, "txs", len(txs), "workers", workers)
+	return receipts, allLogs, nil
+}
+
+// speculate executes [tx] at index [i] of its block on a copy of [base], the
+// state before the transactions of the block, and stores the outcome in [result].
+func (p *StateProcessor) speculate(tx *types.Transaction, i int, result *speculativeResult, base *state.StateDB, signer types.Signer, header *types.Header, blockHash common.Hash, vmenv *vm.EVM) {
+	defer close(result.done)
+
+	msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+	if err != nil {
+		result.err = err
+		return
+	}
+	var (
+		statedb = base.Copy()
+		gp      = new(GasPool).AddGas(header.GasLimit)
+		usedGas = new(uint64)
+	)
+	statedb.SetTxContext(tx.Hash(), i)
+	statedb.StartAccessRecording()
+	result.receipt, result.err = applyTransaction(msg, vmenv.ChainConfig(), gp, statedb, header.Number, blockHash, tx, usedGas, vmenv)
+	result.access = statedb.StopAccessRecording()
+}
+
+// mergeSpeculative merges the speculative execution of [tx] in [result] into
+// [statedb], if it did not read any of the state [written] by the previous
+// transactions of the block. It returns the receipt of the transaction and
+// the state it accessed, and whether the execution was merged.
+func (p *StateProcessor) mergeSpeculative(tx *types.Transaction, result *speculativeResult, statedb *state.StateDB, gp *GasPool, usedGas *uint64, written state.AccessSet) (*types.Receipt, *state.AccessRecord, bool) {
+	if result.err != nil || !result.access.Mergeable() || result.access.Reads().Intersects(written) {
+		return nil, nil, false
+	}
+	// Buying the gas of the transaction must not exceed the block gas limit,
+	// otherwise the execution fails with the same error.
+	if gp.Gas() < tx.Gas() {
+		return nil, nil, false
+	}
+	if err := statedb.MergeAccess(result.access); err != nil {
+		return nil, nil, false
+	}
+	receipt := result.receipt
+	for _, l := range receipt.Logs {
+		statedb.AddLog(l.Address, l.Topics, l.Data, l.BlockNumber)
+	}
+	statedb.Finalise(true)
+	// The gas used never exceeds the gas limit of the transaction.
+	gp.SetGas(gp.Gas() - receipt.GasUsed)
+	*usedGas += receipt.GasUsed
+
+	receipt.CumulativeGasUsed = *usedGas
+	receipt.Logs = statedb.GetLogs(tx.Hash(), receipt.BlockNumber.Uint64(), receipt.BlockHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	return receipt, result.access, true
+}
@@ -0,0 +1,109 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelTxExecution(t *testing.T) {
+	// counterBin deploys a contract incrementing the value of slot 0 and
+	// emitting an empty log on every call.
+	const counterBin = "600f600c600039600f6000f3" + "60005460010160005560006000a000"
+
+	require := require.New(t)
+	keys := make([]*ecdsa.PrivateKey, 8)
+	addrs := make([]common.Address, len(keys))
+	alloc := make(GenesisAlloc)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		alloc[addrs[i]] = GenesisAccount{Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}
+	}
+	var (
+		engine   = dummy.NewCoinbaseFaker()
+		gspec    = &Genesis{Config: params.TestChainConfig, Alloc: alloc}
+		signer   = types.LatestSigner(gspec.Config)
+		counter  = crypto.CreateAddress(addrs[0], 0)
+		fresh    = common.Address{0xfe}
+		transfer = big.NewInt(params.Ether)
+	)
+	sign := func(b *BlockGen, key *ecdsa.PrivateKey, to *common.Address, value *big.Int, gas uint64, data []byte) {
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(crypto.PubkeyToAddress(key.PublicKey)),
+			To:       to,
+			Value:    value,
+			Gas:      gas,
+			GasPrice: new(big.Int).Mul(b.BaseFee(), common.Big2),
+			Data:     data,
+		})
+		require.NoError(err)
+		b.AddTx(tx)
+	}
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, engine, 3, 10, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			// Independent transfers, some to the same recipient, and
+			// dependent transfers from the same sender.
+			sign(b, keys[0], nil, common.Big0, 200_000, common.FromHex(counterBin))
+			for j := 1; j < len(keys); j++ {
+				sign(b, keys[j], &addrs[(j+1)%len(keys)], transfer, params.TxGas, nil)
+				sign(b, keys[j], &addrs[0], transfer, params.TxGas, nil)
+			}
+		case 1:
+			// Conflicting calls to the same contract, interleaved with
+			// transfers creating the same account.
+			for j := range keys {
+				sign(b, keys[j], &counter, common.Big0, 100_000, nil)
+				sign(b, keys[j], &fresh, transfer, params.TxGas, nil)
+			}
+		case 2:
+			// Transfers spending the funds received from the previous ones.
+			for j := range keys {
+				sign(b, keys[j], &addrs[(j+3)%len(keys)], new(big.Int).Sub(b.GetBalance(addrs[j]), big.NewInt(params.Ether)), params.TxGas, nil)
+			}
+			sign(b, keys[1], &counter, common.Big0, 100_000, nil)
+		}
+	})
+	require.NoError(err)
+
+	cacheConfig := *DefaultCacheConfig
+	cacheConfig.ParallelTxExecution = 4
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), &cacheConfig, gspec, engine, vm.Config{}, common.Hash{}, false)
+	require.NoError(err)
+	defer chain.Stop()
+
+	// The blocks are only inserted if the state root, receipts and logs match
+	// the ones computed sequentially when generating them.
+	_, err = chain.InsertChain(blocks)
+	require.NoError(err)
+
+	statedb, err := chain.State()
+	require.NoError(err)
+	require.Equal(common.BigToHash(big.NewInt(int64(len(keys)+1))), statedb.GetState(counter, common.Hash{}))
+	require.Equal(new(big.Int).Mul(big.NewInt(int64(len(keys))), transfer), statedb.GetBalance(fresh))
+
+	receipts := chain.GetReceiptsByHash(blocks[1].Hash())
+	var logIndex uint
+	for i, receipt := range receipts {
+		require.Equal(uint(i), receipt.TransactionIndex)
+		for _, l := range receipt.Logs {
+			require.Equal(logIndex, l.Index)
+			require.Equal(uint(i), l.TxIndex)
+			logIndex++
+		}
+	}
+	require.Equal(uint(len(keys)), logIndex)
+}
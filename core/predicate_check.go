@@ -16,13 +16,16 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var ErrMissingPredicateContext = errors.New("missing predicate context")
+var (
+	ErrMissingPredicateContext = errors.New("missing predicate context")
+	ErrPredicateFailed         = errors.New("predicate failed verification")
+)
 
 // CheckPredicates verifies the predicates of [tx] and returns the result. Returning an error invalidates the block.
 func CheckPredicates(rules params.Rules, predicateContext *precompileconfig.PredicateContext, tx *types.Transaction) (map[common.Address][]byte, error) {
 	// Check that the transaction can cover its IntrinsicGas (including the gas required by the predicate) before
 	// verifying the predicate.
-	intrinsicGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, rules)
+	intrinsicGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, rules)
 	if err != nil {
 		return nil, err
 	}
@@ -65,3 +68,20 @@ func CheckPredicates(rules params.Rules, predicateContext *precompileconfig.Pred
 	}
 	return predicateResults, nil
 }
+
+// VerifyPredicates returns an error if [tx] cannot cover the gas of its predicates
+// or if any of its predicates fails verification. Failed predicates do not
+// invalidate a block, but the transaction burns its gas without effect, so this
+// is used to keep such transactions out of the mempool.
+func VerifyPredicates(rules params.Rules, predicateContext *precompileconfig.PredicateContext, tx *types.Transaction) error {
+	results, err := CheckPredicates(rules, predicateContext, tx)
+	if err != nil {
+		return err
+	}
+	for address, result := range results {
+		if failed := set.BitsFromBytes(result).Len(); failed != 0 {
+			return fmt.Errorf("%w: %d predicates of %s", ErrPredicateFailed, failed, address)
+		}
+	}
+	return nil
+}
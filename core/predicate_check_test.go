@@ -315,7 +315,7 @@ func TestCheckPredicate(t *testing.T) {
 				return
 			}
 			require.Equal(test.expectedRes, predicateRes)
-			intrinsicGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), true, rules)
+			intrinsicGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), nil, true, rules)
 			require.NoError(err)
 			require.Equal(tx.Gas(), intrinsicGas) // Require test specifies exact amount of gas consumed
 		})
@@ -459,3 +459,34 @@ func TestCheckPredicatesOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyPredicates(t *testing.T) {
+	require := require.New(t)
+
+	addr := common.HexToAddress("0xaa")
+	validHash := common.Hash{1}
+	invalidHash := common.Hash{2}
+	predicateContext := &precompileconfig.PredicateContext{
+		ProposerVMBlockCtx: &block.Context{
+			PChainHeight: 10,
+		},
+	}
+	rules := params.TestChainConfig.Rules(common.Big0, 0)
+	predicater := precompileconfig.NewMockPredicater(gomock.NewController(t))
+	predicater.EXPECT().PredicateGas(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	predicater.EXPECT().VerifyPredicate(gomock.Any(), validHash[:]).Return(nil).AnyTimes()
+	predicater.EXPECT().VerifyPredicate(gomock.Any(), invalidHash[:]).Return(errors.New("test error")).AnyTimes()
+	rules.Predicaters[addr] = predicater
+
+	newTx := func(hashes ...common.Hash) *types.Transaction {
+		var accessList types.AccessList
+		for _, hash := range hashes {
+			accessList = append(accessList, types.AccessTuple{Address: addr, StorageKeys: []common.Hash{hash}})
+		}
+		return types.NewTx(&types.DynamicFeeTx{AccessList: accessList, Gas: 53000})
+	}
+	require.NoError(VerifyPredicates(rules, predicateContext, newTx()))
+	require.NoError(VerifyPredicates(rules, predicateContext, newTx(validHash, validHash)))
+	require.ErrorIs(VerifyPredicates(rules, predicateContext, newTx(validHash, invalidHash)), ErrPredicateFailed)
+	require.ErrorIs(VerifyPredicates(rules, nil, newTx(validHash)), ErrMissingPredicateContext)
+}
@@ -0,0 +1,77 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ContractGasUsage is the gas consumed by the transactions calling a contract
+// within a window of blocks.
+type ContractGasUsage struct {
+	GasUsed uint64
+	TxCount uint64
+}
+
+// ReadContractGasUsage retrieves the gas usage of [address] in [window].
+func ReadContractGasUsage(db ethdb.KeyValueReader, window uint64, address common.Address) ContractGasUsage {
+	data, _ := db.Get(gasUsageKey(window, address))
+	return decodeContractGasUsage(data)
+}
+
+// ReadContractGasUsages retrieves the gas usage of all the contracts in [window].
+func ReadContractGasUsages(db ethdb.Iteratee, window uint64) map[common.Address]ContractGasUsage {
+	prefix := gasUsageWindowKey(window)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	usages := make(map[common.Address]ContractGasUsage)
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+common.AddressLength {
+			continue
+		}
+		usages[common.BytesToAddress(it.Key()[len(prefix):])] = decodeContractGasUsage(it.Value())
+	}
+	return usages
+}
+
+// WriteContractGasUsage stores the gas usage of [address] in [window].
+func WriteContractGasUsage(db ethdb.KeyValueWriter, window uint64, address common.Address, usage ContractGasUsage) {
+	data := make([]byte, 2*wrappers.LongLen)
+	binary.BigEndian.PutUint64(data, usage.GasUsed)
+	binary.BigEndian.PutUint64(data[wrappers.LongLen:], usage.TxCount)
+	if err := db.Put(gasUsageKey(window, address), data); err != nil {
+		log.Crit("Failed to store contract gas usage", "err", err)
+	}
+}
+
+// DeleteContractGasUsages removes the gas usage of all the contracts in [window],
+// iterating the entries of [db] and deleting them through [batch].
+func DeleteContractGasUsages(db ethdb.Iteratee, batch ethdb.KeyValueWriter, window uint64) {
+	it := db.NewIterator(gasUsageWindowKey(window), nil)
+	defer it.Release()
+
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete contract gas usage", "err", err)
+		}
+	}
+}
+
+// decodeContractGasUsage decodes a stored gas usage, returning an empty gas
+// usage if [data] is malformed.
+func decodeContractGasUsage(data []byte) ContractGasUsage {
+	if len(data) != 2*wrappers.LongLen {
+		return ContractGasUsage{}
+	}
+	return ContractGasUsage{
+		GasUsed: binary.BigEndian.Uint64(data),
+		TxCount: binary.BigEndian.Uint64(data[wrappers.LongLen:]),
+	}
+}
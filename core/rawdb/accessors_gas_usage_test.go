@@ -0,0 +1,41 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractGasUsage(t *testing.T) {
+	require := require.New(t)
+	db := NewMemoryDatabase()
+
+	var (
+		addr1 = common.Address{1}
+		addr2 = common.Address{2}
+	)
+	require.Equal(ContractGasUsage{}, ReadContractGasUsage(db, 1, addr1))
+
+	WriteContractGasUsage(db, 1, addr1, ContractGasUsage{GasUsed: 100, TxCount: 2})
+	WriteContractGasUsage(db, 1, addr2, ContractGasUsage{GasUsed: 50, TxCount: 1})
+	WriteContractGasUsage(db, 2, addr1, ContractGasUsage{GasUsed: 30, TxCount: 1})
+	require.Equal(ContractGasUsage{GasUsed: 100, TxCount: 2}, ReadContractGasUsage(db, 1, addr1))
+	require.Equal(map[common.Address]ContractGasUsage{
+		addr1: {GasUsed: 100, TxCount: 2},
+		addr2: {GasUsed: 50, TxCount: 1},
+	}, ReadContractGasUsages(db, 1))
+
+	// Deleting a window must not affect the other windows.
+	batch := db.NewBatch()
+	DeleteContractGasUsages(db, batch, 1)
+	require.Len(ReadContractGasUsages(db, 1), 2) // not deleted until the batch is written
+	require.NoError(batch.Write())
+	require.Empty(ReadContractGasUsages(db, 1))
+	require.Equal(map[common.Address]ContractGasUsage{
+		addr1: {GasUsed: 30, TxCount: 1},
+	}, ReadContractGasUsages(db, 2))
+}
@@ -240,6 +240,42 @@ func DeleteOfflinePruning(db ethdb.KeyValueStore) error {
 	return DeleteTimeMarker(db, offlinePruningKey)
 }
 
+// WriteOfflinePruningRequest writes a marker requesting offline pruning to run
+// on the next start, regardless of whether it is enabled in the config. The
+// marker is deleted once offline pruning completes.
+func WriteOfflinePruningRequest(db ethdb.KeyValueStore) error {
+	return WriteTimeMarker(db, offlinePruningRequestKey)
+}
+
+// ReadOfflinePruningRequest reads the timestamp of the pending request to run
+// offline pruning if present.
+func ReadOfflinePruningRequest(db ethdb.KeyValueStore) (time.Time, error) {
+	return ReadTimeMarker(db, offlinePruningRequestKey)
+}
+
+// DeleteOfflinePruningRequest deletes any pending request to run offline pruning.
+func DeleteOfflinePruningRequest(db ethdb.KeyValueStore) error {
+	return DeleteTimeMarker(db, offlinePruningRequestKey)
+}
+
+// WriteOfflinePruningProgress writes the last key deleted by the ongoing run of
+// offline pruning, from which it is resumed if interrupted.
+func WriteOfflinePruningProgress(db ethdb.KeyValueWriter, key []byte) error {
+	return db.Put(offlinePruningProgressKey, key)
+}
+
+// ReadOfflinePruningProgress reads the last key deleted by the ongoing run of
+// offline pruning, or nil if there is none.
+func ReadOfflinePruningProgress(db ethdb.KeyValueReader) []byte {
+	key, _ := db.Get(offlinePruningProgressKey)
+	return key
+}
+
+// DeleteOfflinePruningProgress deletes the progress of the last run of offline pruning.
+func DeleteOfflinePruningProgress(db ethdb.KeyValueWriter) error {
+	return db.Delete(offlinePruningProgressKey)
+}
+
 // WritePopulateMissingTries writes a marker for the current attempt to populate
 // missing tries.
 func WritePopulateMissingTries(db ethdb.KeyValueStore) error {
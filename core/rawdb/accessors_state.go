@@ -29,9 +29,11 @@ package rawdb
 import (
 	"encoding/binary"
 
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // ReadPreimage retrieves a single preimage of the provided hash.
@@ -51,6 +53,39 @@ func WritePreimages(db ethdb.KeyValueWriter, preimages map[common.Hash][]byte) {
 	preimageHitCounter.Inc(int64(len(preimages)))
 }
 
+// ReadStateDiff retrieves the state diff persisted for the block with the
+// provided hash, or nil if none was stored.
+func ReadStateDiff(db ethdb.KeyValueReader, hash common.Hash) *types.StateDiff {
+	data, _ := db.Get(stateDiffKey(hash))
+	if len(data) == 0 {
+		return nil
+	}
+	diff := new(types.StateDiff)
+	if err := rlp.DecodeBytes(data, diff); err != nil {
+		log.Error("Invalid state diff RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return diff
+}
+
+// WriteStateDiff stores the state diff of the block with the provided hash.
+func WriteStateDiff(db ethdb.KeyValueWriter, hash common.Hash, diff *types.StateDiff) {
+	data, err := rlp.EncodeToBytes(diff)
+	if err != nil {
+		log.Crit("Failed to RLP encode state diff", "err", err)
+	}
+	if err := db.Put(stateDiffKey(hash), data); err != nil {
+		log.Crit("Failed to store state diff", "err", err)
+	}
+}
+
+// DeleteStateDiff removes the state diff of the block with the provided hash.
+func DeleteStateDiff(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(stateDiffKey(hash)); err != nil {
+		log.Crit("Failed to delete state diff", "err", err)
+	}
+}
+
 // ReadCode retrieves the contract code of the provided code hash.
 func ReadCode(db ethdb.KeyValueReader, hash common.Hash) []byte {
 	// Try with the prefixed code scheme first and only. The legacy scheme was never used in subnet-evm.
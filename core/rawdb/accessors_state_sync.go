@@ -31,6 +31,31 @@ func WriteSyncRoot(db ethdb.KeyValueWriter, root common.Hash) error {
 	return db.Put(syncRootKey, root[:])
 }
 
+// ReadSyncBaseRoot reads the root of the local state an in-progress sync is
+// incremental from and returns common.Hash{} if the sync is not incremental.
+func ReadSyncBaseRoot(db ethdb.KeyValueReader) (common.Hash, error) {
+	has, err := db.Has(syncBaseRootKey)
+	if err != nil || !has {
+		return common.Hash{}, err
+	}
+	root, err := db.Get(syncBaseRootKey)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(root), nil
+}
+
+// WriteSyncBaseRoot writes root as the root of the local state the in-progress
+// sync is incremental from.
+func WriteSyncBaseRoot(db ethdb.KeyValueWriter, root common.Hash) error {
+	return db.Put(syncBaseRootKey, root[:])
+}
+
+// DeleteSyncBaseRoot removes the marker of an incremental sync.
+func DeleteSyncBaseRoot(db ethdb.KeyValueWriter) error {
+	return db.Delete(syncBaseRootKey)
+}
+
 // AddCodeToFetch adds a marker that we need to fetch the code for [hash].
 func AddCodeToFetch(db ethdb.KeyValueWriter, hash common.Hash) {
 	if err := db.Put(codeToFetchKey(hash), nil); err != nil {
@@ -218,6 +218,16 @@ func indexTransactions(db ethdb.Database, from uint64, to uint64, interrupt chan
 // 	indexTransactions(db, from, to, interrupt, nil)
 // }
 
+// IndexTransactionsWithProgress creates txlookup indices of the specified block
+// range like IndexTransactions, calling [progress] with the number of each block
+// before it is indexed. Blocks are indexed from [to-1] down to [from].
+func IndexTransactionsWithProgress(db ethdb.Database, from uint64, to uint64, interrupt chan struct{}, progress func(uint64)) {
+	indexTransactions(db, from, to, interrupt, func(number uint64) bool {
+		progress(number)
+		return true
+	})
+}
+
 // indexTransactionsForTesting is the internal debug version with an additional hook.
 func indexTransactionsForTesting(db ethdb.Database, from uint64, to uint64, interrupt chan struct{}, hook func(uint64) bool) {
 	indexTransactions(db, from, to, interrupt, hook)
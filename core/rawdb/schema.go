@@ -72,6 +72,12 @@ var (
 	// offlinePruningKey tracks runs of offline pruning
 	offlinePruningKey = []byte("OfflinePruning")
 
+	// offlinePruningRequestKey tracks requests to run offline pruning on the next start
+	offlinePruningRequestKey = []byte("OfflinePruningRequest")
+
+	// offlinePruningProgressKey tracks the last key deleted by an ongoing run of offline pruning
+	offlinePruningProgressKey = []byte("OfflinePruningProgress")
+
 	// populateMissingTriesKey tracks runs of trie backfills
 	populateMissingTriesKey = []byte("PopulateMissingTries")
 
@@ -108,11 +114,18 @@ var (
 	// BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 	BloomBitsIndexPrefix = []byte("iB")
 
+	// stateDiffPrefix + block hash -> state diff of the block
+	stateDiffPrefix = []byte("sd")
+
+	// gasUsagePrefix + window (uint64 big endian) + address -> gas used and tx count of the contract in the window
+	gasUsagePrefix = []byte("gu")
+
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 
 	// State sync progress keys and prefixes
 	syncRootKey            = []byte("sync_root")     // indicates the root of the main account trie currently being synced
+	syncBaseRootKey        = []byte("sync_base")     // indicates the root of the local state the in-progress sync is incremental from
 	syncStorageTriesPrefix = []byte("sync_storage")  // syncStorageTriesPrefix + trie root + account hash: indicates a storage trie must be fetched for the account
 	syncSegmentsPrefix     = []byte("sync_segments") // syncSegmentsPrefix + trie root + 32-byte start key: indicates the trie at root has a segment starting at the specified key
 	CodeToFetchPrefix      = []byte("CP")            // CodeToFetchPrefix + code hash -> empty value tracks the outstanding code hashes we need to fetch.
@@ -177,6 +190,21 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// stateDiffKey = stateDiffPrefix + hash
+func stateDiffKey(hash common.Hash) []byte {
+	return append(stateDiffPrefix, hash.Bytes()...)
+}
+
+// gasUsageKey = gasUsagePrefix + window (uint64 big endian) + address
+func gasUsageKey(window uint64, address common.Address) []byte {
+	return append(gasUsageWindowKey(window), address.Bytes()...)
+}
+
+// gasUsageWindowKey = gasUsagePrefix + window (uint64 big endian)
+func gasUsageWindowKey(window uint64) []byte {
+	return append(append([]byte{}, gasUsagePrefix...), encodeBlockNumber(window)...)
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
@@ -0,0 +1,212 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// ReplayStats are the measurements of the replay of a block.
+type ReplayStats struct {
+	Number  uint64
+	Hash    common.Hash
+	Txs     int
+	GasUsed uint64
+
+	Execution  time.Duration // Processing the transactions
+	Validation time.Duration // Hashing the state and validating the receipts
+	Commit     time.Duration // Committing the tries to the in-memory trie database
+
+	// PrecompileGas is the gas used by the calls of each precompile, excluding
+	// the gas of the precompiles they call. It is only set if the replayer
+	// traces precompile calls.
+	PrecompileGas map[common.Address]uint64
+}
+
+// Replayer re-executes accepted blocks on top of the state of their parent,
+// read from [db], to measure the time spent processing them. The state of the
+// replayed blocks is only committed to an in-memory trie database, so [db] is
+// never written to.
+type Replayer struct {
+	config  *params.ChainConfig
+	engine  consensus.Engine
+	db      ethdb.Database
+	statedb state.Database
+	chain   *witnessChain
+	tracer  *precompileGasTracer
+
+	// root is the last state root committed to the trie database, and
+	// referenced until the next block is committed.
+	root common.Hash
+}
+
+// NewReplayer returns a Replayer of the blocks of [db]. If [tracePrecompiles]
+// is true, the gas used by precompile calls is recorded at the cost of
+// tracing every call frame.
+func NewReplayer(config *params.ChainConfig, engine consensus.Engine, db ethdb.Database, tracePrecompiles bool) *Replayer {
+	r := &Replayer{
+		config:  config,
+		engine:  engine,
+		db:      db,
+		statedb: state.NewDatabaseWithConfig(db, &trie.Config{}),
+	}
+	r.chain = newWitnessChain(config, engine, func(hash common.Hash, number uint64) *types.Header {
+		return rawdb.ReadHeader(db, hash, number)
+	}, func(root common.Hash) (*state.StateDB, error) {
+		return state.New(root, r.statedb, nil)
+	})
+	if tracePrecompiles {
+		r.tracer = &precompileGasTracer{}
+	}
+	return r
+}
+
+// Replay re-executes [block] and verifies it results in the state root and
+// receipts of the block. The state of the parent of [block] must either be on
+// disk or be the state of the last replayed block.
+func (r *Replayer) Replay(block *types.Block) (*ReplayStats, error) {
+	parent := rawdb.ReadHeader(r.db, block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	statedb, err := state.New(parent.Root, r.statedb, nil)
+	if err != nil {
+		return nil, fmt.Errorf("missing state of parent %s at height %d: %w", parent.Hash(), parent.Number, err)
+	}
+
+	var cfg vm.Config
+	if r.tracer != nil {
+		r.tracer.gas = make(map[common.Address]uint64)
+		cfg.Tracer = r.tracer
+	}
+	processor := &StateProcessor{config: r.config, chain: r.chain, engine: r.engine}
+	stats := &ReplayStats{
+		Number: block.NumberU64(),
+		Hash:   block.Hash(),
+		Txs:    len(block.Transactions()),
+	}
+
+	start := time.Now()
+	receipts, _, usedGas, err := processor.Process(block, parent, statedb, cfg)
+	if err != nil {
+		return nil, err
+	}
+	stats.Execution = time.Since(start)
+	stats.GasUsed = usedGas
+
+	start = time.Now()
+	if err := NewBlockValidator(r.config, nil, r.engine).ValidateState(block, statedb, receipts, usedGas); err != nil {
+		return nil, err
+	}
+	stats.Validation = time.Since(start)
+
+	start = time.Now()
+	root, err := statedb.Commit(block.NumberU64(), r.config.IsEIP158(block.Number()), true)
+	if err != nil {
+		return nil, err
+	}
+	stats.Commit = time.Since(start)
+
+	// Only keep the state of the last block in memory.
+	triedb := r.statedb.TrieDB()
+	if r.root != (common.Hash{}) && r.root != root {
+		triedb.Dereference(r.root)
+	}
+	r.root = root
+
+	if r.tracer != nil {
+		stats.PrecompileGas = r.tracer.gas
+	}
+	return stats, nil
+}
+
+// Close releases the state of the last replayed block.
+func (r *Replayer) Close() {
+	if r.root != (common.Hash{}) {
+		r.statedb.TrieDB().Dereference(r.root)
+		r.root = common.Hash{}
+	}
+}
+
+var _ vm.EVMLogger = (*precompileGasTracer)(nil)
+
+// precompileGasTracer sums the gas used by the calls of each precompile.
+type precompileGasTracer struct {
+	gas    map[common.Address]uint64
+	rules  params.Rules
+	frames []precompileFrame
+}
+
+type precompileFrame struct {
+	addr       common.Address
+	precompile bool
+	// nested is the gas used by the precompiles called by the frame.
+	nested uint64
+}
+
+// isPrecompile returns true if [addr] is a precompile enabled by the rules
+// of the transaction.
+func (t *precompileGasTracer) isPrecompile(addr common.Address) bool {
+	for _, precompile := range vm.ActivePrecompiles(t.rules) {
+		if addr == precompile {
+			return true
+		}
+	}
+	return t.rules.IsPrecompileEnabled(addr)
+}
+
+func (t *precompileGasTracer) enter(to common.Address) {
+	t.frames = append(t.frames, precompileFrame{addr: to, precompile: t.isPrecompile(to)})
+}
+
+func (t *precompileGasTracer) exit(gasUsed uint64) {
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if !frame.precompile {
+		return
+	}
+	t.gas[frame.addr] += gasUsed - frame.nested
+	if len(t.frames) != 0 {
+		t.frames[len(t.frames)-1].nested += gasUsed
+	}
+}
+
+func (t *precompileGasTracer) CaptureTxStart(uint64) {}
+func (t *precompileGasTracer) CaptureTxEnd(uint64)   {}
+
+func (t *precompileGasTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.rules = env.ChainRules()
+	t.frames = t.frames[:0]
+	t.enter(to)
+}
+
+func (t *precompileGasTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exit(gasUsed)
+}
+
+func (t *precompileGasTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.enter(to)
+}
+
+func (t *precompileGasTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(gasUsed)
+}
+
+func (t *precompileGasTracer) CaptureState(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, []byte, int, error) {
+}
+
+func (t *precompileGasTracer) CaptureFault(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error) {
+}
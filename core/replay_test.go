@@ -0,0 +1,80 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayer(t *testing.T) {
+	require := require.New(t)
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		engine   = dummy.NewCoinbaseFaker()
+		gspec    = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}}}
+		signer   = types.LatestSigner(gspec.Config)
+		sha256   = common.BytesToAddress([]byte{2})
+		identity = common.BytesToAddress([]byte{4})
+		input    = make([]byte, 64)
+	)
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, engine, 3, 10, func(i int, b *BlockGen) {
+		for _, to := range []common.Address{sha256, identity, {byte(i + 1)}} {
+			b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    b.TxNonce(addr),
+				To:       &to,
+				Value:    common.Big1,
+				Gas:      100_000,
+				GasPrice: new(big.Int).Mul(b.BaseFee(), common.Big2),
+				Data:     input,
+			}))
+		}
+	})
+	require.NoError(err)
+	chain, err := NewBlockChain(db, DefaultCacheConfig, gspec, engine, vm.Config{}, common.Hash{}, false)
+	require.NoError(err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(err)
+	chain.Stop()
+
+	replayer := NewReplayer(gspec.Config, engine, db, true)
+	defer replayer.Close()
+	for _, block := range blocks {
+		stats, err := replayer.Replay(block)
+		require.NoError(err)
+		require.Equal(block.NumberU64(), stats.Number)
+		require.Equal(block.Hash(), stats.Hash)
+		require.Equal(3, stats.Txs)
+		require.Equal(block.GasUsed(), stats.GasUsed)
+		// sha256 costs 60 plus 12 per word, and identity 15 plus 3 per word.
+		require.Equal(map[common.Address]uint64{sha256: 84, identity: 21}, stats.PrecompileGas)
+	}
+
+	// Only the state of the last replayed block is kept in memory.
+	_, err = replayer.Replay(blocks[1])
+	require.ErrorContains(err, "missing state of parent")
+
+	// Precompile gas is only recorded when tracing precompile calls.
+	replayer = NewReplayer(gspec.Config, engine, db, false)
+	stats, err := replayer.Replay(blocks[0])
+	require.NoError(err)
+	require.Nil(stats.PrecompileGas)
+
+	// Replaying a block with a different state root fails.
+	header := blocks[1].Header()
+	header.Root = common.Hash{1}
+	_, err = replayer.Replay(types.NewBlockWithHeader(header).WithBody(blocks[1].Transactions(), nil))
+	require.ErrorContains(err, "invalid merkle root")
+}
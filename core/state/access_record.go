@@ -0,0 +1,253 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errMergeUnderflow = errors.New("merged balance change underflows the account balance")
+
+// accessKind is the part of an account a state key refers to.
+type accessKind uint8
+
+const (
+	accessExist accessKind = iota
+	accessBalance
+	accessNonce
+	accessCode
+	accessSlot
+)
+
+// accessKey identifies a piece of state read or written by a transaction.
+type accessKey struct {
+	address common.Address
+	kind    accessKind
+	slot    common.Hash // Only set for accessSlot
+}
+
+// AccessSet is a set of state keys read or written by transactions.
+type AccessSet map[accessKey]struct{}
+
+// Intersects reports whether [a] and [b] have a key in common.
+func (a AccessSet) Intersects(b AccessSet) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for key := range a {
+		if _, ok := b[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds the keys of [b] to [a].
+func (a AccessSet) Add(b AccessSet) {
+	for key := range b {
+		a[key] = struct{}{}
+	}
+}
+
+// accountAccess is the state of an account when it was first accessed in the
+// recorded execution, and the changes made to it once recording is stopped.
+type accountAccess struct {
+	existed bool
+	balance *big.Int
+
+	balanceDelta *big.Int
+	nonce        *uint64
+	code         []byte
+	codeSet      bool
+	storage      map[common.Hash]common.Hash
+}
+
+// AccessRecord records the state read and written by the execution of a
+// transaction, so the transaction can be executed speculatively on a copy of
+// the state and its effects merged into another StateDB in which the state it
+// read is unchanged.
+//
+// Balance changes made with AddBalance and SubBalance are not reads, and are
+// merged as deltas. This way transactions paying fees to the same coinbase or
+// transferring value to the same account do not conflict with each other.
+// Every other change is also a read, so a change is only merged into state
+// holding the value observed by the recorded execution.
+type AccessRecord struct {
+	reads     AccessSet
+	writes    AccessSet
+	accounts  map[common.Address]*accountAccess
+	mergeable bool
+}
+
+func newAccessRecord() *AccessRecord {
+	return &AccessRecord{
+		reads:     make(AccessSet),
+		writes:    make(AccessSet),
+		accounts:  make(map[common.Address]*accountAccess),
+		mergeable: true,
+	}
+}
+
+// Reads returns the state keys read by the recorded execution.
+func (r *AccessRecord) Reads() AccessSet { return r.reads }
+
+// Writes returns the state keys written by the recorded execution.
+func (r *AccessRecord) Writes() AccessSet { return r.writes }
+
+// Mergeable reports whether the changes of the recorded execution can be
+// merged with MergeAccess. Executions destructing or resetting accounts, and
+// executions that could not be recorded entirely, cannot be merged.
+func (r *AccessRecord) Mergeable() bool { return r.mergeable }
+
+func (r *AccessRecord) read(addr common.Address, kind accessKind, slot common.Hash) {
+	r.reads[accessKey{address: addr, kind: kind, slot: slot}] = struct{}{}
+}
+
+func (r *AccessRecord) write(addr common.Address, kind accessKind, slot common.Hash) {
+	r.writes[accessKey{address: addr, kind: kind, slot: slot}] = struct{}{}
+}
+
+// StartAccessRecording starts recording the state accessed through s in a new
+// AccessRecord, returned by StopAccessRecording.
+func (s *StateDB) StartAccessRecording() {
+	s.access = newAccessRecord()
+}
+
+// StopAccessRecording stops recording the accessed state and returns the
+// record, or nil if recording was not started. It must be called after the
+// recorded execution is finalised.
+func (s *StateDB) StopAccessRecording() *AccessRecord {
+	r := s.access
+	if r == nil {
+		return nil
+	}
+	s.access = nil
+	if s.dbErr != nil {
+		r.mergeable = false
+	}
+	for key := range r.writes {
+		account := r.accounts[key.address]
+		obj := s.getStateObject(key.address)
+		switch key.kind {
+		case accessBalance:
+			balance := new(big.Int)
+			if obj != nil {
+				balance.Set(obj.Balance())
+			}
+			account.balanceDelta = balance.Sub(balance, account.balance)
+		case accessNonce:
+			var nonce uint64
+			if obj != nil {
+				nonce = obj.Nonce()
+			}
+			account.nonce = &nonce
+		case accessCode:
+			if obj != nil {
+				account.code = obj.Code()
+			}
+			account.codeSet = true
+		case accessSlot:
+			if account.storage == nil {
+				account.storage = make(map[common.Hash]common.Hash)
+			}
+			if obj != nil {
+				account.storage[key.slot] = obj.GetState(key.slot)
+			}
+		}
+	}
+	// Account creations conflict with reads of the existence of the account,
+	// while deletions are only applied by executing the transaction.
+	for addr, account := range r.accounts {
+		if exists := s.getStateObject(addr) != nil; exists != account.existed {
+			r.write(addr, accessExist, common.Hash{})
+			if !exists {
+				r.mergeable = false
+			}
+		}
+	}
+	return r
+}
+
+// touchAccess records the state of [addr] when it is first accessed.
+func (s *StateDB) touchAccess(addr common.Address) {
+	if _, ok := s.access.accounts[addr]; ok {
+		return
+	}
+	account := &accountAccess{balance: new(big.Int)}
+	if obj := s.getStateObject(addr); obj != nil {
+		account.existed = true
+		account.balance.Set(obj.Balance())
+	}
+	s.access.accounts[addr] = account
+}
+
+// recordRead records a read of [kind] of [addr], if recording.
+func (s *StateDB) recordRead(addr common.Address, kind accessKind, slot common.Hash) {
+	if s.access == nil {
+		return
+	}
+	s.touchAccess(addr)
+	s.access.read(addr, kind, slot)
+}
+
+// recordWrite records a change of [kind] of [addr], if recording. Changes
+// other than balance deltas depend on the previous value, so they are reads too.
+func (s *StateDB) recordWrite(addr common.Address, kind accessKind, slot common.Hash) {
+	if s.access == nil {
+		return
+	}
+	s.touchAccess(addr)
+	s.access.write(addr, kind, slot)
+	if kind != accessBalance {
+		s.access.read(addr, kind, slot)
+	}
+}
+
+// recordUnmergeable records a change of [addr] that cannot be merged, if
+// recording.
+func (s *StateDB) recordUnmergeable(addr common.Address) {
+	if s.access == nil {
+		return
+	}
+	s.recordWrite(addr, accessExist, common.Hash{})
+	s.access.mergeable = false
+}
+
+// MergeAccess applies the changes recorded in [r] to s. The caller must ensure
+// that the state read by the recorded execution was not changed in s, that is
+// that [r] reads none of the keys written to s since the state it was recorded
+// on was copied. The changes are not applied if a balance would underflow, in
+// which case an error is returned.
+func (s *StateDB) MergeAccess(r *AccessRecord) error {
+	if !r.mergeable {
+		return errors.New("access record is not mergeable")
+	}
+	for addr, account := range r.accounts {
+		if account.balanceDelta != nil && account.balanceDelta.Sign() < 0 && s.GetBalance(addr).CmpAbs(account.balanceDelta) < 0 {
+			return errMergeUnderflow
+		}
+	}
+	for addr, account := range r.accounts {
+		if delta := account.balanceDelta; delta != nil && delta.Sign() != 0 {
+			if delta.Sign() > 0 {
+				s.AddBalance(addr, delta)
+			} else {
+				s.SubBalance(addr, new(big.Int).Neg(delta))
+			}
+		}
+		if account.nonce != nil {
+			s.SetNonce(addr, *account.nonce)
+		}
+		if account.codeSet {
+			s.SetCode(addr, account.code)
+		}
+		for key, value := range account.storage {
+			s.SetState(addr, key, value)
+		}
+	}
+	return nil
+}
@@ -132,6 +132,11 @@ type Trie interface {
 	// if fails to create node iterator.
 	NodeIterator(startKey []byte) (trie.NodeIterator, error)
 
+	// Witness returns the encoded trie nodes resolved from the database since
+	// the trie was opened or last committed, proving every value read from or
+	// written to it against its original root.
+	Witness() map[string]struct{}
+
 	// Prove constructs a Merkle proof for key. The result contains all encoded nodes
 	// on the path to the value at key. The value itself is also included in the last
 	// node and can be retrieved by verifying the proof.
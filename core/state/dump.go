@@ -27,6 +27,7 @@
 package state
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -47,6 +48,18 @@ type DumpConfig struct {
 	OnlyWithAddresses bool
 	Start             []byte
 	Max               uint64
+
+	// AddressPrefix restricts the dump to the accounts whose address starts
+	// with it. Accounts with a missing preimage are skipped if it is set.
+	AddressPrefix []byte
+	// StorageStart is the storage trie key the storage of the account at
+	// [Start] is dumped from.
+	StorageStart []byte
+	// MaxStorage is the maximum number of storage slots dumped, if non-zero.
+	// If it is reached in the storage of an account, the account is dumped
+	// with the slots collected so far and the returned next key is the
+	// account trie key followed by the storage trie key to resume from.
+	MaxStorage uint64
 }
 
 // DumpCollector interface which the state trie calls during iteration
@@ -144,6 +157,7 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 	var (
 		missingPreimages int
 		accounts         uint64
+		slots            uint64
 		start            = time.Now()
 		logged           = time.Now()
 	)
@@ -181,6 +195,13 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 		} else {
 			address = &addr
 		}
+		if len(conf.AddressPrefix) > 0 && (address == nil || !bytes.HasPrefix(addr[:], conf.AddressPrefix)) {
+			continue
+		}
+		if !conf.SkipStorage && conf.MaxStorage > 0 && slots >= conf.MaxStorage {
+			nextKey = it.Key
+			break
+		}
 		obj := newObject(s, addr, &data)
 		if !conf.SkipCode {
 			account.Code = obj.Code()
@@ -192,22 +213,34 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 				log.Error("Failed to load storage trie", "err", err)
 				continue
 			}
-			trieIt, err := tr.NodeIterator(nil)
+			var storageStart []byte
+			if bytes.Equal(it.Key, conf.Start) {
+				storageStart = conf.StorageStart
+			}
+			trieIt, err := tr.NodeIterator(storageStart)
 			if err != nil {
 				log.Error("Failed to create trie iterator", "err", err)
 				continue
 			}
 			storageIt := trie.NewIterator(trieIt)
 			for storageIt.Next() {
+				if conf.MaxStorage > 0 && slots >= conf.MaxStorage {
+					nextKey = append(common.CopyBytes(it.Key), storageIt.Key...)
+					break
+				}
 				_, content, _, err := rlp.Split(storageIt.Value)
 				if err != nil {
 					log.Error("Failed to decode the value returned by iterator", "error", err)
 					continue
 				}
 				account.Storage[common.BytesToHash(s.trie.GetKey(storageIt.Key))] = common.Bytes2Hex(content)
+				slots++
 			}
 		}
 		c.OnAccount(address, account)
+		if nextKey != nil {
+			break
+		}
 		accounts++
 		if time.Since(logged) > 8*time.Second {
 			log.Info("Trie dumping in progress", "at", it.Key, "accounts", accounts,
@@ -134,14 +134,22 @@ func prune(maindb ethdb.Database, stateBloom *stateBloom, bloomPath string, star
 	// that the false-positive is low enough(~0.05%). The probablity of the
 	// dangling node is the state root is super low. So the dangling nodes in
 	// theory will never ever be visited again.
+	//
+	// The last deleted key is persisted along with every batch of deletions, so
+	// an interrupted run resumes from it instead of iterating the database from
+	// the start.
 	var (
 		count  int
 		size   common.StorageSize
 		pstart = time.Now()
 		logged = time.Now()
 		batch  = maindb.NewBatch()
-		iter   = maindb.NewIterator(nil, nil)
+		resume = rawdb.ReadOfflinePruningProgress(maindb)
+		iter   = maindb.NewIterator(nil, resume)
 	)
+	if len(resume) > 0 {
+		log.Info("Resuming state pruning", "progress", pruningProgress(resume))
+	}
 	// We wrap iter.Release() in an anonymous function so that the [iter]
 	// value captured is the value of [iter] at the end of the function as opposed
 	// to incorrectly capturing the first iterator immediately.
@@ -180,13 +188,16 @@ func prune(maindb ethdb.Database, stateBloom *stateBloom, bloomPath string, star
 				eta = time.Duration(left/speed) * time.Millisecond
 			}
 			if time.Since(logged) > 8*time.Second {
-				log.Info("Pruning state data", "nodes", count, "size", size,
+				log.Info("Pruning state data", "nodes", count, "size", size, "progress", pruningProgress(key),
 					"elapsed", common.PrettyDuration(time.Since(pstart)), "eta", common.PrettyDuration(eta))
 				logged = time.Now()
 			}
 			// Recreate the iterator after every batch commit in order
 			// to allow the underlying compactor to delete the entries.
 			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				if err := rawdb.WriteOfflinePruningProgress(batch, key); err != nil {
+					return err
+				}
 				if err := batch.Write(); err != nil {
 					return err
 				}
@@ -214,6 +225,9 @@ func prune(maindb ethdb.Database, stateBloom *stateBloom, bloomPath string, star
 	if err := rawdb.WriteOfflinePruning(maindb); err != nil {
 		return fmt.Errorf("failed to write offline pruning success marker: %w", err)
 	}
+	if err := rawdb.DeleteOfflinePruningProgress(maindb); err != nil {
+		return fmt.Errorf("failed to delete offline pruning progress: %w", err)
+	}
 
 	// Delete the state bloom, it marks the entire pruning procedure is
 	// finished. If any crashes or manual exit happens before this,
@@ -289,6 +303,10 @@ func (p *Pruner) Prune(root common.Hash) error {
 	}
 	filterName := bloomFilterName(p.config.Datadir, root)
 
+	// Progress left by a previous run refers to another state bloom.
+	if err := rawdb.DeleteOfflinePruningProgress(p.db); err != nil {
+		return err
+	}
 	log.Info("Writing state bloom to disk", "name", filterName)
 	if err := p.stateBloom.Commit(filterName, filterName+stateBloomFileTempSuffix); err != nil {
 		return err
@@ -331,6 +349,27 @@ func RecoverPruning(datadir string, db ethdb.Database) error {
 	return prune(db, stateBloom, stateBloomPath, time.Now())
 }
 
+// InterruptedPruning returns the state root targeted by a run of offline pruning
+// that was interrupted, and the fraction of the database it already went over,
+// or an empty root if no run is waiting to be resumed by RecoverPruning.
+func InterruptedPruning(datadir string, db ethdb.KeyValueReader) (common.Hash, float64, error) {
+	_, root, err := findBloomFilter(datadir)
+	if err != nil || root == (common.Hash{}) {
+		return common.Hash{}, 0, err
+	}
+	return root, pruningProgress(rawdb.ReadOfflinePruningProgress(db)), nil
+}
+
+// pruningProgress returns the fraction of the database iterated over once
+// [key] is reached, assuming the keys of the pruned entries are uniformly
+// distributed hashes.
+func pruningProgress(key []byte) float64 {
+	if len(key) < 8 {
+		return 0
+	}
+	return float64(binary.BigEndian.Uint64(key[:8])) / math.MaxUint64
+}
+
 // extractGenesis loads the genesis state and commits all the state entries
 // into the given bloomfilter.
 func extractGenesis(db ethdb.Database, stateBloom *stateBloom) error {
@@ -136,6 +136,24 @@ func loadSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int,
 	return snapshot, generator.Done, nil
 }
 
+// CompletedRoot returns the root of the snapshot persisted in [db] if it is
+// fully generated, or common.Hash{} otherwise.
+func CompletedRoot(db ethdb.KeyValueReader) common.Hash {
+	root := rawdb.ReadSnapshotRoot(db)
+	if root == (common.Hash{}) {
+		return common.Hash{}
+	}
+	generatorBlob := rawdb.ReadSnapshotGenerator(db)
+	if len(generatorBlob) == 0 {
+		return common.Hash{}
+	}
+	var generator journalGenerator
+	if err := rlp.DecodeBytes(generatorBlob, &generator); err != nil || !generator.Done {
+		return common.Hash{}
+	}
+	return root
+}
+
 // ResetSnapshotGeneration writes a clean snapshot generator marker to [db]
 // so no re-generation is performed after.
 func ResetSnapshotGeneration(db ethdb.KeyValueWriter) {
@@ -58,6 +58,24 @@ func WipeSnapshot(db ethdb.KeyValueStore, full bool) chan struct{} {
 	return wiper
 }
 
+// WipeAccountSnapshot is like [WipeSnapshot], but only deletes the account
+// entries and keeps the storage entries of the snapshot, so they can be reused
+// by an incremental state sync.
+func WipeAccountSnapshot(db ethdb.KeyValueStore) chan struct{} {
+	rawdb.DeleteSnapshotBlockHash(db)
+	rawdb.DeleteSnapshotRoot(db)
+
+	wiper := make(chan struct{}, 1)
+	go func() {
+		if err := wipeKeyRange(db, "accounts", rawdb.SnapshotAccountPrefix, nil, nil, len(rawdb.SnapshotAccountPrefix)+common.HashLength, true); err != nil {
+			log.Error("Failed to wipe account snapshot", "err", err) // Database close will trigger this
+			return
+		}
+		close(wiper)
+	}()
+	return wiper
+}
+
 // wipeContent iterates over the entire key-value database and deletes all the
 // data associated with the snapshot (accounts, storage), but not the root hash
 // as the wiper is meant to run on a background thread but the root needs to be
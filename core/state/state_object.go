@@ -216,6 +216,9 @@ func (s *stateObject) GetCommittedState(key common.Hash) common.Hash {
 	}
 	// If the snapshot is unavailable or reading from it fails, load from the database.
 	if s.db.snap == nil || err != nil {
+		if s.db.snap != nil {
+			s.db.SnapshotStorageMisses++
+		}
 		start := time.Now()
 		tr, err := s.getTrie()
 		if err != nil {
@@ -287,19 +290,20 @@ func (s *stateObject) updateTrie() (Trie, error) {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { s.db.StorageUpdates += time.Since(start) }(time.Now())
 	}
+	return s.updateStorageTrie(s.updateStorage())
+}
+
+// updateStorage moves the pending storage modifications into the original
+// storage and caches them for the snapshot, returning the slots to write into
+// the storage trie with updateStorageTrie. It does not access the storage trie.
+func (s *stateObject) updateStorage() Storage {
 	// The snapshot storage map for the object
 	var (
 		storage map[common.Hash][]byte
 		origin  map[common.Hash][]byte
 		hasher  = s.db.hasher
+		updates = make(Storage, len(s.pendingStorage))
 	)
-	tr, err := s.getTrie()
-	if err != nil {
-		s.db.setError(err)
-		return nil, err
-	}
-	// Insert all the pending updates into the trie
-	usedStorage := make([][]byte, 0, len(s.pendingStorage))
 	for key, value := range s.pendingStorage {
 		// Skip noop changes, persist actual changes
 		if value == s.originStorage[key] {
@@ -307,23 +311,15 @@ func (s *stateObject) updateTrie() (Trie, error) {
 		}
 		prev := s.originStorage[key]
 		s.originStorage[key] = value
+		updates[key] = value
 
 		// rlp-encoded value to be used by the snapshot
 		var snapshotVal []byte
 		if (value == common.Hash{}) {
-			if err := tr.DeleteStorage(s.address, key[:]); err != nil {
-				s.db.setError(err)
-				return nil, err
-			}
 			s.db.StorageDeleted += 1
 		} else {
-			trimmedVal := common.TrimLeftZeroes(value[:])
 			// Encoding []byte cannot fail, ok to ignore the error.
-			snapshotVal, _ = rlp.EncodeToBytes(trimmedVal)
-			if err := tr.UpdateStorage(s.address, key[:], trimmedVal); err != nil {
-				s.db.setError(err)
-				return nil, err
-			}
+			snapshotVal, _ = rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
 			s.db.StorageUpdated += 1
 		}
 		// Cache the mutated storage slots until commit
@@ -353,15 +349,42 @@ func (s *stateObject) updateTrie() (Trie, error) {
 				origin[khash] = b
 			}
 		}
+	}
+	if len(s.pendingStorage) > 0 {
+		s.pendingStorage = make(Storage)
+	}
+	return updates
+}
+
+// updateStorageTrie writes [updates] into the object's storage trie. It only
+// modifies the object itself, so the storage tries of distinct objects can be
+// updated concurrently.
+func (s *stateObject) updateStorageTrie(updates Storage) (Trie, error) {
+	tr, err := s.getTrie()
+	if err != nil {
+		s.db.setError(err)
+		return nil, err
+	}
+	// Insert all the pending updates into the trie
+	usedStorage := make([][]byte, 0, len(updates))
+	for key, value := range updates {
+		if (value == common.Hash{}) {
+			if err := tr.DeleteStorage(s.address, key[:]); err != nil {
+				s.db.setError(err)
+				return nil, err
+			}
+		} else {
+			if err := tr.UpdateStorage(s.address, key[:], common.TrimLeftZeroes(value[:])); err != nil {
+				s.db.setError(err)
+				return nil, err
+			}
+		}
 		// Cache the items for preloading
 		usedStorage = append(usedStorage, common.CopyBytes(key[:])) // Copy needed for closure
 	}
 	if s.db.prefetcher != nil {
 		s.db.prefetcher.used(s.addrHash, s.data.Root, usedStorage)
 	}
-	if len(s.pendingStorage) > 0 {
-		s.pendingStorage = make(Storage)
-	}
 	return tr, nil
 }
 
@@ -87,3 +87,51 @@ func TestIterativeDump(t *testing.T) {
 		t.Errorf("DumpToCollector mismatch:\ngot: %s\nwant: %s\n", got, want)
 	}
 }
+
+func TestDumpPagination(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	tdb := NewDatabaseWithConfig(db, &trie.Config{Preimages: true})
+	sdb, _ := New(types.EmptyRootHash, tdb, nil)
+
+	prefixed := common.HexToAddress("0xabcd000000000000000000000000000000000001")
+	sdb.SetBalance(prefixed, big.NewInt(1))
+	for i := 0; i < 5; i++ {
+		sdb.SetState(prefixed, common.BigToHash(big.NewInt(int64(i))), common.BigToHash(big.NewInt(int64(i+1))))
+	}
+	sdb.SetBalance(common.HexToAddress("0xabce000000000000000000000000000000000002"), big.NewInt(2))
+	sdb.SetBalance(common.HexToAddress("0x0000000000000000000000000000000000000003"), big.NewInt(3))
+	root, _ := sdb.Commit(0, false, false)
+	sdb, _ = New(root, tdb, nil)
+
+	// Only the accounts with the address prefix are dumped.
+	dump := sdb.RawDump(&DumpConfig{SkipStorage: true, OnlyWithAddresses: true, AddressPrefix: []byte{0xab}})
+	if len(dump.Accounts) != 2 {
+		t.Fatalf("expected 2 accounts with prefix, got %d", len(dump.Accounts))
+	}
+
+	// The storage of an account is split across pages of [MaxStorage] slots.
+	conf := &DumpConfig{OnlyWithAddresses: true, AddressPrefix: prefixed[:2], MaxStorage: 2}
+	storage := make(map[common.Hash]string)
+	pages := 0
+	for {
+		dump := &IteratorDump{Accounts: make(map[common.Address]DumpAccount)}
+		next := sdb.DumpToCollector(dump, conf)
+		pages++
+		if len(dump.Accounts[prefixed].Storage) > 2 {
+			t.Fatalf("expected at most 2 slots per page, got %d", len(dump.Accounts[prefixed].Storage))
+		}
+		for k, v := range dump.Accounts[prefixed].Storage {
+			storage[k] = v
+		}
+		if next == nil {
+			break
+		}
+		conf.Start, conf.StorageStart = next[:common.HashLength], next[common.HashLength:]
+	}
+	if pages != 3 {
+		t.Fatalf("expected 3 pages, got %d", pages)
+	}
+	if len(storage) != 5 {
+		t.Fatalf("expected 5 slots, got %d", len(storage))
+	}
+}
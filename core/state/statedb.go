@@ -32,6 +32,7 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/subnet-evm/core/rawdb"
@@ -83,6 +84,11 @@ type StateDB struct {
 	hasher     crypto.KeccakState
 	snap       snapshot.Snapshot // Nil if snapshot is not available
 
+	// trieParallelism is the number of storage tries updated concurrently when
+	// computing the state root. It is only set when reading from the snapshot,
+	// since the state is then accessed through the tries only after execution.
+	trieParallelism int
+
 	// originalRoot is the pre-state root, before any changes were made.
 	// It will be updated when the Commit is called.
 	originalRoot common.Hash
@@ -108,7 +114,8 @@ type StateDB struct {
 	// returned by StateDB.Commit. Notably, this error is also shared
 	// by all cached state objects in case the database failure occurs
 	// when accessing state of accounts.
-	dbErr error
+	dbErr   error
+	errLock sync.Mutex // Protects dbErr while storage tries are updated concurrently
 
 	// The refund counter, also used by state transitioning.
 	refund uint64
@@ -131,6 +138,10 @@ type StateDB struct {
 	// Transient storage
 	transientStorage transientStorage
 
+	// State accessed through the StateDB, only recorded between
+	// StartAccessRecording and StopAccessRecording.
+	access *AccessRecord
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -155,6 +166,10 @@ type StateDB struct {
 	StorageUpdated int
 	AccountDeleted int
 	StorageDeleted int
+
+	// Reads falling back to the tries although a snapshot is available
+	SnapshotAccountMisses int
+	SnapshotStorageMisses int
 }
 
 // New creates a new state from a given trie.
@@ -214,6 +229,7 @@ func (s *StateDB) StartPrefetcher(namespace string, maxConcurrency int) {
 	}
 	if s.snap != nil {
 		s.prefetcher = newTriePrefetcher(s.db, s.originalRoot, namespace, maxConcurrency)
+		s.trieParallelism = maxConcurrency
 	}
 }
 
@@ -228,6 +244,9 @@ func (s *StateDB) StopPrefetcher() {
 
 // setError remembers the first non-nil error it is called with.
 func (s *StateDB) setError(err error) {
+	s.errLock.Lock()
+	defer s.errLock.Unlock()
+
 	if s.dbErr == nil {
 		s.dbErr = err
 	}
@@ -235,6 +254,9 @@ func (s *StateDB) setError(err error) {
 
 // Error returns the memorized database failure occurred earlier.
 func (s *StateDB) Error() error {
+	s.errLock.Lock()
+	defer s.errLock.Unlock()
+
 	return s.dbErr
 }
 
@@ -305,6 +327,58 @@ func (s *StateDB) Preimages() map[common.Hash][]byte {
 	return s.preimages
 }
 
+// StateDiff returns the account and storage mutations accumulated in the scope
+// of the current block. It must be called after IntermediateRoot and before
+// Commit, since committing resets the tracked mutations.
+func (s *StateDB) StateDiff() *types.StateDiff {
+	addrs := make(map[common.Address]struct{}, len(s.accountsOrigin)+len(s.stateObjectsDestruct))
+	for addr := range s.accountsOrigin {
+		addrs[addr] = struct{}{}
+	}
+	for addr := range s.stateObjectsDestruct {
+		addrs[addr] = struct{}{}
+	}
+	diff := &types.StateDiff{Accounts: make([]types.AccountDiff, 0, len(addrs))}
+	for addr := range addrs {
+		addrHash := crypto.Keccak256Hash(addr[:])
+		account := types.AccountDiff{
+			Address: addr,
+			Prev:    s.accountsOrigin[addr],
+			Post:    s.accounts[addrHash],
+		}
+		// The original value of a destructed account is tracked separately
+		// until commit, where it overwrites the value in accountsOrigin.
+		if prev, destructed := s.stateObjectsDestruct[addr]; destructed {
+			account.Destructed = true
+			if prev != nil {
+				account.Prev = types.SlimAccountRLP(*prev)
+			} else {
+				account.Prev = nil
+			}
+		}
+		// Skip accounts that were created and destructed within the block.
+		if len(account.Prev) == 0 && len(account.Post) == 0 {
+			continue
+		}
+		post := s.storages[addrHash]
+		for key, prev := range s.storagesOrigin[addr] {
+			account.Storage = append(account.Storage, types.StorageDiff{
+				Key:  key,
+				Prev: prev,
+				Post: post[key],
+			})
+		}
+		sort.Slice(account.Storage, func(i, j int) bool {
+			return account.Storage[i].Key.Cmp(account.Storage[j].Key) < 0
+		})
+		diff.Accounts = append(diff.Accounts, account)
+	}
+	sort.Slice(diff.Accounts, func(i, j int) bool {
+		return diff.Accounts[i].Address.Cmp(diff.Accounts[j].Address) < 0
+	})
+	return diff
+}
+
 // AddRefund adds gas to the refund counter
 func (s *StateDB) AddRefund(gas uint64) {
 	s.journal.append(refundChange{prev: s.refund})
@@ -326,18 +400,26 @@ func (s *StateDB) SubRefund(gas uint64) {
 // Exist reports whether the given account address exists in the state.
 // Notably this also returns true for self-destructed accounts.
 func (s *StateDB) Exist(addr common.Address) bool {
+	s.recordRead(addr, accessExist, common.Hash{})
 	return s.getStateObject(addr) != nil
 }
 
 // Empty returns whether the state object is either non-existent
 // or empty according to the EIP161 specification (balance = nonce = code = 0)
 func (s *StateDB) Empty(addr common.Address) bool {
+	if s.access != nil {
+		s.recordRead(addr, accessExist, common.Hash{})
+		s.recordRead(addr, accessBalance, common.Hash{})
+		s.recordRead(addr, accessNonce, common.Hash{})
+		s.recordRead(addr, accessCode, common.Hash{})
+	}
 	so := s.getStateObject(addr)
 	return so == nil || so.empty()
 }
 
 // GetBalance retrieves the balance from the given address or 0 if object not found
 func (s *StateDB) GetBalance(addr common.Address) *big.Int {
+	s.recordRead(addr, accessBalance, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.Balance()
@@ -345,7 +427,19 @@ func (s *StateDB) GetBalance(addr common.Address) *big.Int {
 	return new(big.Int).Set(common.Big0)
 }
 
+// GetCommittedBalance retrieves the balance of [addr] as of the last commit of
+// the state, ie. before the changes of the block being processed.
+func (s *StateDB) GetCommittedBalance(addr common.Address) *big.Int {
+	s.recordRead(addr, accessBalance, common.Hash{})
+	stateObject := s.getStateObject(addr)
+	if stateObject != nil && stateObject.origin != nil {
+		return new(big.Int).Set(stateObject.origin.Balance)
+	}
+	return new(big.Int).Set(common.Big0)
+}
+
 func (s *StateDB) GetNonce(addr common.Address) uint64 {
+	s.recordRead(addr, accessNonce, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.Nonce()
@@ -360,6 +454,7 @@ func (s *StateDB) TxIndex() int {
 }
 
 func (s *StateDB) GetCode(addr common.Address) []byte {
+	s.recordRead(addr, accessCode, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.Code()
@@ -368,6 +463,7 @@ func (s *StateDB) GetCode(addr common.Address) []byte {
 }
 
 func (s *StateDB) GetCodeSize(addr common.Address) int {
+	s.recordRead(addr, accessCode, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.CodeSize()
@@ -376,6 +472,7 @@ func (s *StateDB) GetCodeSize(addr common.Address) int {
 }
 
 func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
+	s.recordRead(addr, accessCode, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject == nil {
 		return common.Hash{}
@@ -385,6 +482,7 @@ func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
 
 // GetState retrieves a value from the given account's storage trie.
 func (s *StateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
+	s.recordRead(addr, accessSlot, hash)
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.GetState(hash)
@@ -421,8 +519,39 @@ func (s *StateDB) GetStorageProof(a common.Address, key common.Hash) ([][]byte,
 	return proof, nil
 }
 
+// GetStorageRoot retrieves the storage root of the given account, with the
+// storage changes of the finalised transactions applied.
+func (s *StateDB) GetStorageRoot(addr common.Address) common.Hash {
+	stateObject := s.getStateObject(addr)
+	if stateObject == nil {
+		return types.EmptyRootHash
+	}
+	stateObject.updateRoot()
+	return stateObject.data.Root
+}
+
+// CheckKnownAccounts checks the storage of the given accounts matches the
+// expected one, as required by conditional transactions.
+func (s *StateDB) CheckKnownAccounts(accounts types.KnownAccounts) error {
+	for addr, account := range accounts {
+		if account.StorageRoot != nil {
+			if root := s.GetStorageRoot(addr); root != *account.StorageRoot {
+				return fmt.Errorf("%w: storage root of %s is %s, expected %s", types.ErrKnownAccountMismatch, addr, root, *account.StorageRoot)
+			}
+			continue
+		}
+		for slot, expected := range account.StorageSlots {
+			if value := s.GetState(addr, slot); value != expected {
+				return fmt.Errorf("%w: slot %s of %s is %s, expected %s", types.ErrKnownAccountMismatch, slot, addr, value, expected)
+			}
+		}
+	}
+	return nil
+}
+
 // GetCommittedState retrieves a value from the given account's committed storage trie.
 func (s *StateDB) GetCommittedState(addr common.Address, hash common.Hash) common.Hash {
+	s.recordRead(addr, accessSlot, hash)
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.GetCommittedState(hash)
@@ -451,6 +580,7 @@ func (s *StateDB) StorageTrie(addr common.Address) (Trie, error) {
 }
 
 func (s *StateDB) HasSelfDestructed(addr common.Address) bool {
+	s.recordRead(addr, accessExist, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject != nil {
 		return stateObject.selfDestructed
@@ -464,6 +594,7 @@ func (s *StateDB) HasSelfDestructed(addr common.Address) bool {
 
 // AddBalance adds amount to the account associated with addr.
 func (s *StateDB) AddBalance(addr common.Address, amount *big.Int) {
+	s.recordWrite(addr, accessBalance, common.Hash{})
 	stateObject := s.GetOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.AddBalance(amount)
@@ -472,6 +603,7 @@ func (s *StateDB) AddBalance(addr common.Address, amount *big.Int) {
 
 // SubBalance subtracts amount from the account associated with addr.
 func (s *StateDB) SubBalance(addr common.Address, amount *big.Int) {
+	s.recordWrite(addr, accessBalance, common.Hash{})
 	stateObject := s.GetOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.SubBalance(amount)
@@ -479,6 +611,8 @@ func (s *StateDB) SubBalance(addr common.Address, amount *big.Int) {
 }
 
 func (s *StateDB) SetBalance(addr common.Address, amount *big.Int) {
+	s.recordRead(addr, accessBalance, common.Hash{})
+	s.recordWrite(addr, accessBalance, common.Hash{})
 	stateObject := s.GetOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.SetBalance(amount)
@@ -486,6 +620,7 @@ func (s *StateDB) SetBalance(addr common.Address, amount *big.Int) {
 }
 
 func (s *StateDB) SetNonce(addr common.Address, nonce uint64) {
+	s.recordWrite(addr, accessNonce, common.Hash{})
 	stateObject := s.GetOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.SetNonce(nonce)
@@ -493,6 +628,7 @@ func (s *StateDB) SetNonce(addr common.Address, nonce uint64) {
 }
 
 func (s *StateDB) SetCode(addr common.Address, code []byte) {
+	s.recordWrite(addr, accessCode, common.Hash{})
 	stateObject := s.GetOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.SetCode(crypto.Keccak256Hash(code), code)
@@ -500,6 +636,7 @@ func (s *StateDB) SetCode(addr common.Address, code []byte) {
 }
 
 func (s *StateDB) SetState(addr common.Address, key, value common.Hash) {
+	s.recordWrite(addr, accessSlot, key)
 	stateObject := s.GetOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.SetState(key, value)
@@ -518,6 +655,7 @@ func (s *StateDB) SetStorage(addr common.Address, storage map[common.Hash]common
 	//
 	// TODO(rjl493456442) this function should only be supported by 'unwritable'
 	// state and all mutations made should all be discarded afterwards.
+	s.recordUnmergeable(addr)
 	if _, ok := s.stateObjectsDestruct[addr]; !ok {
 		s.stateObjectsDestruct[addr] = nil
 	}
@@ -533,6 +671,7 @@ func (s *StateDB) SetStorage(addr common.Address, storage map[common.Hash]common
 // The account's state object is still available until the state is committed,
 // getStateObject will return a non-nil account after SelfDestruct.
 func (s *StateDB) SelfDestruct(addr common.Address) {
+	s.recordUnmergeable(addr)
 	stateObject := s.getStateObject(addr)
 	if stateObject == nil {
 		return
@@ -547,6 +686,7 @@ func (s *StateDB) SelfDestruct(addr common.Address) {
 }
 
 func (s *StateDB) Selfdestruct6780(addr common.Address) {
+	s.recordRead(addr, accessExist, common.Hash{})
 	stateObject := s.getStateObject(addr)
 	if stateObject == nil {
 		return
@@ -680,6 +820,9 @@ func (s *StateDB) getDeletedStateObject(addr common.Address) *stateObject {
 	}
 	// If snapshot unavailable or reading from it failed, load from the database
 	if data == nil {
+		if s.snap != nil {
+			s.SnapshotAccountMisses++
+		}
 		start := time.Now()
 		var err error
 		data, err = s.trie.GetAccount(addr)
@@ -769,6 +912,13 @@ func (s *StateDB) createObject(addr common.Address) (newobj, prev *stateObject)
 //
 // Carrying over the balance ensures that Ether doesn't disappear.
 func (s *StateDB) CreateAccount(addr common.Address) {
+	if s.access != nil {
+		s.recordWrite(addr, accessExist, common.Hash{})
+		// Resetting an existing account wipes its storage, which cannot be merged.
+		if s.getStateObject(addr) != nil {
+			s.access.mergeable = false
+		}
+	}
 	newObj, prev := s.createObject(addr)
 	if prev != nil {
 		newObj.setBalance(prev.data.Balance)
@@ -1009,6 +1159,75 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 	s.clearJournalAndRefund()
 }
 
+// updateStorageRoots writes the pending storage changes into the storage tries
+// of the pending objects and updates their storage roots.
+//
+// When the state is read from the snapshot, the storage tries are only accessed
+// at this point, so the random trie lookups and the hashing they require are
+// spread over [trieParallelism] goroutines. The changes themselves are still
+// collected sequentially, as they update state shared by all the objects.
+func (s *StateDB) updateStorageRoots() {
+	if s.trieParallelism <= 1 {
+		for addr := range s.stateObjectsPending {
+			if obj := s.stateObjects[addr]; !obj.deleted {
+				obj.updateRoot()
+			}
+		}
+		return
+	}
+	// Track the amount of time wasted on updating and hashing the storage tries
+	if metrics.EnabledExpensive {
+		defer func(start time.Time) { s.StorageUpdates += time.Since(start) }(time.Now())
+	}
+	type storageRootUpdate struct {
+		obj     *stateObject
+		updates Storage // Nil if the object has no pending storage changes
+	}
+	var tasks []storageRootUpdate
+	for addr := range s.stateObjectsPending {
+		obj := s.stateObjects[addr]
+		if obj.deleted {
+			continue
+		}
+		obj.finalise(false)
+		switch {
+		case len(obj.pendingStorage) > 0:
+			tasks = append(tasks, storageRootUpdate{obj: obj, updates: obj.updateStorage()})
+		case obj.trie != nil:
+			tasks = append(tasks, storageRootUpdate{obj: obj})
+		}
+	}
+	var (
+		workers = s.trieParallelism
+		queue   = make(chan storageRootUpdate, len(tasks))
+		wg      sync.WaitGroup
+	)
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				tr := task.obj.trie
+				if task.updates != nil {
+					var err error
+					if tr, err = task.obj.updateStorageTrie(task.updates); err != nil {
+						continue
+					}
+				}
+				task.obj.data.Root = tr.Hash()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // IntermediateRoot computes the current root hash of the state trie.
 // It is called in between transactions to get the root hash that
 // goes into transaction receipts.
@@ -1035,11 +1254,7 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 	// the account prefetcher. Instead, let's process all the storage updates
 	// first, giving the account prefetches just a few more milliseconds of time
 	// to pull useful data from disk.
-	for addr := range s.stateObjectsPending {
-		if obj := s.stateObjects[addr]; !obj.deleted {
-			obj.updateRoot()
-		}
-	}
+	s.updateStorageRoots()
 	// Now we're about to start to write changes to the trie. The trie is so far
 	// _untouched_. We can check with the prefetcher, if it can give us a trie
 	// which has the same root, but also has some content loaded into it.
@@ -799,6 +799,29 @@ func TestMissingTrieNodes(t *testing.T) {
 	}
 }
 
+// TestGetCommittedBalance tests that the committed balance of an account is its
+// balance as of the state the StateDB was opened at.
+func TestGetCommittedBalance(t *testing.T) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	state, _ := New(types.EmptyRootHash, db, nil)
+	addr := common.HexToAddress("0xaffeaffeaffeaffeaffeaffeaffeaffeaffeaffe")
+
+	state.SetBalance(addr, big.NewInt(42))
+	if balance := state.GetCommittedBalance(addr); balance.Sign() != 0 {
+		t.Fatalf("committed balance of new account mismatch: have %v, want 0", balance)
+	}
+	root, _ := state.Commit(0, true, false)
+
+	state, _ = New(root, db, nil)
+	state.AddBalance(addr, big.NewInt(8))
+	if balance := state.GetBalance(addr); balance.Cmp(big.NewInt(50)) != 0 {
+		t.Fatalf("balance mismatch: have %v, want %v", balance, 50)
+	}
+	if balance := state.GetCommittedBalance(addr); balance.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("committed balance mismatch: have %v, want %v", balance, 42)
+	}
+}
+
 func TestStateDBAccessList(t *testing.T) {
 	// Some helpers
 	addr := func(a string) common.Address {
@@ -1080,3 +1103,108 @@ func TestResetObject(t *testing.T) {
 		t.Fatalf("Unexpected storage slot value %v", slot)
 	}
 }
+
+func TestStateDiff(t *testing.T) {
+	var (
+		memdb    = rawdb.NewMemoryDatabase()
+		db       = NewDatabase(memdb)
+		state, _ = New(types.EmptyRootHash, db, nil)
+		addr     = common.Address{0x01}
+		removed  = common.Address{0x02}
+		slot     = common.Hash{0x01}
+	)
+	state.SetBalance(addr, big.NewInt(1))
+	state.SetState(addr, slot, common.Hash{0x01})
+	state.SetBalance(removed, big.NewInt(1))
+	root, err := state.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+
+	state, _ = New(root, db, nil)
+	state.SetBalance(addr, big.NewInt(2))
+	state.SetNonce(addr, 1)
+	state.SetState(addr, slot, common.Hash{0x02})
+	state.SelfDestruct(removed)
+	state.IntermediateRoot(false)
+
+	diff := state.StateDiff()
+	if len(diff.Accounts) != 2 {
+		t.Fatalf("expected 2 modified accounts, got %d", len(diff.Accounts))
+	}
+	updated := diff.Accounts[0]
+	if updated.Address != addr || updated.Destructed {
+		t.Fatalf("unexpected account diff %+v", updated)
+	}
+	prev, err := types.FullAccount(updated.Prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post, err := types.FullAccount(updated.Post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev.Balance.Uint64() != 1 || post.Balance.Uint64() != 2 || prev.Nonce != 0 || post.Nonce != 1 {
+		t.Fatalf("unexpected account transition %+v -> %+v", prev, post)
+	}
+	if len(updated.Storage) != 1 || updated.Storage[0].Key != crypto.Keccak256Hash(slot[:]) {
+		t.Fatalf("unexpected storage diff %+v", updated.Storage)
+	}
+	deleted := diff.Accounts[1]
+	if deleted.Address != removed || !deleted.Destructed || len(deleted.Prev) == 0 || len(deleted.Post) != 0 {
+		t.Fatalf("unexpected account diff %+v", deleted)
+	}
+}
+
+func TestParallelStorageRoots(t *testing.T) {
+	var (
+		disk     = rawdb.NewMemoryDatabase()
+		tdb      = trie.NewDatabase(disk)
+		db       = NewDatabaseWithNodeDB(disk, tdb)
+		snaps, _ = snapshot.New(snapshot.Config{CacheSize: 10}, disk, tdb, common.Hash{}, types.EmptyRootHash)
+		state, _ = New(types.EmptyRootHash, db, snaps)
+	)
+	for i := byte(0); i < 32; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		state.SetBalance(addr, big.NewInt(int64(i)+1))
+		for j := byte(0); j < 8; j++ {
+			state.SetState(addr, common.BytesToHash([]byte{j}), common.BytesToHash([]byte{i, j}))
+		}
+	}
+	root, err := state.CommitWithSnap(0, false, snaps, common.Hash{0x01}, common.Hash{}, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+
+	// Update, delete and add slots of most accounts, and read the storage of
+	// some accounts without changing it.
+	update := func(parallelism int) (common.Hash, map[common.Hash]map[common.Hash][]byte) {
+		state, err := New(root, db, snaps)
+		if err != nil {
+			t.Fatalf("failed to open state: %v", err)
+		}
+		state.StartPrefetcher("test", parallelism)
+		defer state.StopPrefetcher()
+
+		for i := byte(0); i < 32; i++ {
+			addr := common.BytesToAddress([]byte{i})
+			if i%4 == 0 {
+				state.GetState(addr, common.Hash{})
+				continue
+			}
+			state.SetState(addr, common.BytesToHash([]byte{1}), common.Hash{})
+			state.SetState(addr, common.BytesToHash([]byte{2}), common.BytesToHash([]byte{i}))
+			state.SetState(addr, common.BytesToHash([]byte{i + 8}), common.BytesToHash([]byte{i}))
+			state.Finalise(false)
+		}
+		return state.IntermediateRoot(false), state.storages
+	}
+	seqRoot, seqStorages := update(1)
+	parRoot, parStorages := update(4)
+	if seqRoot != parRoot {
+		t.Fatalf("root mismatch: sequential %x, parallel %x", seqRoot, parRoot)
+	}
+	if !reflect.DeepEqual(seqStorages, parStorages) {
+		t.Fatalf("snapshot storage mismatch: sequential %v, parallel %v", seqStorages, parStorages)
+	}
+}
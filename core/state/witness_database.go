@@ -0,0 +1,96 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/core/stateless"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WitnessDatabase wraps a Database to record the state accessed through it
+// into an execution witness. It must be used without snapshots, so every
+// state read goes through the tries.
+type WitnessDatabase struct {
+	Database
+	witness *stateless.Witness
+
+	lock  sync.Mutex
+	tries []Trie // Tries opened through the database, to collect their witness
+}
+
+// NewWitnessDatabase returns a database recording the state accessed through
+// [db] into [witness].
+func NewWitnessDatabase(db Database, witness *stateless.Witness) *WitnessDatabase {
+	return &WitnessDatabase{
+		Database: db,
+		witness:  witness,
+	}
+}
+
+// OpenTrie opens the main account trie and tracks it.
+func (db *WitnessDatabase) OpenTrie(root common.Hash) (Trie, error) {
+	tr, err := db.Database.OpenTrie(root)
+	if err != nil {
+		return nil, err
+	}
+	db.track(tr)
+	return tr, nil
+}
+
+// OpenStorageTrie opens the storage trie of an account and tracks it.
+func (db *WitnessDatabase) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash) (Trie, error) {
+	tr, err := db.Database.OpenStorageTrie(stateRoot, address, root)
+	if err != nil {
+		return nil, err
+	}
+	db.track(tr)
+	return tr, nil
+}
+
+// CopyTrie returns an independent copy of the given trie and tracks it.
+func (db *WitnessDatabase) CopyTrie(t Trie) Trie {
+	tr := db.Database.CopyTrie(t)
+	db.track(tr)
+	return tr
+}
+
+// ContractCode retrieves a particular contract's code and records it.
+func (db *WitnessDatabase) ContractCode(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	code, err := db.Database.ContractCode(addr, codeHash)
+	if err != nil {
+		return nil, err
+	}
+	db.witness.AddCode(code)
+	return code, nil
+}
+
+// ContractCodeSize retrieves a particular contracts code's size and records
+// the code, since it is required to know its size.
+func (db *WitnessDatabase) ContractCodeSize(addr common.Address, codeHash common.Hash) (int, error) {
+	code, err := db.ContractCode(addr, codeHash)
+	if err != nil {
+		return 0, err
+	}
+	return len(code), nil
+}
+
+// Collect adds the trie nodes resolved by the tracked tries to the witness.
+// It must be called before the tries are committed.
+func (db *WitnessDatabase) Collect() {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for _, tr := range db.tries {
+		db.witness.AddState(tr.Witness())
+	}
+}
+
+func (db *WitnessDatabase) track(tr Trie) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.tries = append(db.tries, tr)
+}
@@ -0,0 +1,222 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// stateCommitQueueLimit is the number of verified blocks whose state may be
+// waiting to be committed before insertion blocks.
+const stateCommitQueueLimit = 16
+
+var (
+	stateCommitQueueGauge = metrics.NewRegisteredGauge("chain/statecommit/queue/size", nil)
+	stateCommitWorkTimer  = metrics.NewRegisteredCounter("chain/statecommit/work", nil)
+	stateCommitWaitTimer  = metrics.NewRegisteredCounter("chain/statecommit/wait", nil)
+)
+
+// stateCheckpoint is the pending commitment of the state of a verified block,
+// which must hash to the state root required by the header of the block.
+type stateCheckpoint struct {
+	block *types.Block
+	state *state.StateDB // Released once the checkpoint is reached
+
+	done chan struct{}
+	err  error // Set before [done] is closed
+}
+
+// stateCommitter hashes and commits the state of verified blocks in the
+// background, in insertion order.
+//
+// Verifying a block only executes it and checks its receipts. Hashing its state,
+// checking the root against the one required by its header, committing the state
+// to the trie database and inserting its trie into [TrieWriter] are all deferred
+// to a checkpoint. Anything using the state of a block must first wait for its
+// checkpoint: building on the block, opening its state, and accepting or
+// rejecting it. A block whose checkpoint fails is never committed, so it must
+// be rejected, and accepting it fails.
+type stateCommitter struct {
+	commit func(block *types.Block, statedb *state.StateDB) error
+	tasks  chan *stateCheckpoint
+	done   chan struct{}
+
+	lock        sync.Mutex
+	checkpoints map[common.Hash]*stateCheckpoint // Checkpoints of the blocks that are not accepted or rejected yet
+
+	closingLock sync.RWMutex
+	closed      bool
+}
+
+func newStateCommitter(commit func(block *types.Block, statedb *state.StateDB) error) *stateCommitter {
+	c := &stateCommitter{
+		commit:      commit,
+		tasks:       make(chan *stateCheckpoint, stateCommitQueueLimit),
+		done:        make(chan struct{}),
+		checkpoints: make(map[common.Hash]*stateCheckpoint),
+	}
+	go c.loop()
+	return c
+}
+
+func (c *stateCommitter) loop() {
+	defer close(c.done)
+
+	for cp := range c.tasks {
+		start := time.Now()
+		stateCommitQueueGauge.Dec(1)
+
+		if err := c.commit(cp.block, cp.state); err != nil {
+			log.Error("Failed to commit block state", "number", cp.block.Number(), "hash", cp.block.Hash(), "root", cp.block.Root(), "err", err)
+			cp.err = fmt.Errorf("failed to commit state of block %d:%s: %w", cp.block.NumberU64(), cp.block.Hash(), err)
+		}
+		cp.state = nil
+		close(cp.done)
+		stateCommitWorkTimer.Inc(time.Since(start).Milliseconds())
+	}
+}
+
+// enqueue schedules the commitment of [statedb] as the state of [block] once
+// the state of the previously enqueued blocks is committed. It returns false if
+// the committer is stopped, in which case the caller must commit the state itself.
+//
+// The prefetcher of [statedb] must be stopped, and [statedb] must not be used
+// by the caller afterwards.
+func (c *stateCommitter) enqueue(block *types.Block, statedb *state.StateDB) bool {
+	c.closingLock.RLock()
+	defer c.closingLock.RUnlock()
+
+	if c.closed {
+		return false
+	}
+	cp := &stateCheckpoint{
+		block: block,
+		state: statedb,
+		done:  make(chan struct{}),
+	}
+	c.lock.Lock()
+	c.checkpoints[block.Hash()] = cp
+	c.lock.Unlock()
+
+	stateCommitQueueGauge.Inc(1)
+	c.tasks <- cp
+	return true
+}
+
+// wait blocks until the checkpoint of the block [hash] is reached, and returns
+// its error if the state of the block could not be committed. It returns nil if
+// the block has no pending checkpoint.
+func (c *stateCommitter) wait(hash common.Hash) error {
+	c.lock.Lock()
+	cp, ok := c.checkpoints[hash]
+	c.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	return waitForCheckpoint(cp)
+}
+
+// waitForRoot blocks until the checkpoints of every block requiring [root] are
+// reached.
+func (c *stateCommitter) waitForRoot(root common.Hash) {
+	c.lock.Lock()
+	var pending []*stateCheckpoint
+	for _, cp := range c.checkpoints {
+		if cp.block.Root() == root {
+			pending = append(pending, cp)
+		}
+	}
+	c.lock.Unlock()
+
+	for _, cp := range pending {
+		_ = waitForCheckpoint(cp)
+	}
+}
+
+// release discards the checkpoint of the block [hash] once the block is
+// accepted or rejected.
+func (c *stateCommitter) release(hash common.Hash) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.checkpoints, hash)
+}
+
+// stop commits the state of every enqueued block and stops the committer.
+func (c *stateCommitter) stop() {
+	c.closingLock.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.tasks)
+	}
+	c.closingLock.Unlock()
+
+	<-c.done
+}
+
+func waitForCheckpoint(cp *stateCheckpoint) error {
+	start := time.Now()
+	<-cp.done
+	stateCommitWaitTimer.Inc(time.Since(start).Milliseconds())
+	return cp.err
+}
+
+// waitForState blocks until the state of the block [hash] is committed, if it is
+// committed in the background, and returns an error if its checkpoint failed.
+func (bc *BlockChain) waitForState(hash common.Hash) error {
+	if bc.stateCommitter == nil {
+		return nil
+	}
+	return bc.stateCommitter.wait(hash)
+}
+
+// waitForStateRoot blocks until [root] is committed, if it is the required state
+// root of blocks committed in the background. The state may still be missing if
+// their checkpoints failed.
+func (bc *BlockChain) waitForStateRoot(root common.Hash) {
+	if bc.stateCommitter == nil {
+		return
+	}
+	bc.stateCommitter.waitForRoot(root)
+}
+
+// releaseState discards the checkpoint of the block [hash] once it is accepted
+// or rejected.
+func (bc *BlockChain) releaseState(hash common.Hash) {
+	if bc.stateCommitter == nil {
+		return
+	}
+	bc.stateCommitter.release(hash)
+}
+
+// commitStateInBackground checks that [statedb] hashes to the state root of
+// [block] and commits it on behalf of [stateCommitter].
+func (bc *BlockChain) commitStateInBackground(block *types.Block, statedb *state.StateDB) error {
+	if err := validateStateRoot(bc.chainConfig, block.Header(), statedb); err != nil {
+		return err
+	}
+	accountHashTimer.Inc(statedb.AccountHashes.Milliseconds())
+	storageHashTimer.Inc(statedb.StorageHashes.Milliseconds())
+
+	// Avoid flattening the snapshot while the diff layer of [block] is created.
+	bc.flattenLock.Lock()
+	defer bc.flattenLock.Unlock()
+
+	if err := bc.commitState(block, statedb); err != nil {
+		return err
+	}
+	accountCommitTimer.Inc(statedb.AccountCommits.Milliseconds())
+	storageCommitTimer.Inc(statedb.StorageCommits.Milliseconds())
+	snapshotCommitTimer.Inc(statedb.SnapshotCommits.Milliseconds())
+	triedbCommitTimer.Inc(statedb.TrieDBCommits.Milliseconds())
+	return nil
+}
@@ -0,0 +1,128 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newAsyncStateCommitTest(t *testing.T, numBlocks int) (*Genesis, []*types.Block, common.Address, *CacheConfig) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		gspec   = &Genesis{
+			Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+			Alloc:  GenesisAlloc{addr1: {Balance: big.NewInt(1000000)}},
+		}
+		signer = types.HomesteadSigner{}
+	)
+	_, chain, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), numBlocks, 10, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), addr2, big.NewInt(10000), params.TxGas, nil, nil), signer, key1)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+	require.NoError(t, err)
+
+	cacheConfig := *pruningConfig
+	cacheConfig.AsyncStateCommit = true
+	return gspec, chain, addr2, &cacheConfig
+}
+
+func TestAsyncStateCommitInvalidRoot(t *testing.T) {
+	require := require.New(t)
+	gspec, chain, _, cacheConfig := newAsyncStateCommitTest(t, 1)
+
+	blockchain, err := createBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, common.Hash{})
+	require.NoError(err)
+	defer blockchain.Stop()
+
+	// The state root is only checked at the checkpoint of the block, so a block
+	// requiring the wrong root passes verification.
+	header := chain[0].Header()
+	header.Root = common.Hash{1}
+	block := types.NewBlockWithHeader(header).WithBody(chain[0].Transactions(), nil)
+	require.NoError(blockchain.InsertBlock(block))
+
+	require.ErrorContains(blockchain.Accept(block), "invalid merkle root")
+	require.False(blockchain.HasState(header.Root))
+
+	// The valid block can be accepted in its place, and the block rejected.
+	require.NoError(blockchain.InsertBlock(chain[0]))
+	require.NoError(blockchain.Accept(chain[0]))
+	require.NoError(blockchain.Reject(block))
+	require.True(blockchain.HasState(chain[0].Root()))
+}
+
+func TestAsyncStateCommitCrash(t *testing.T) {
+	require := require.New(t)
+	gspec, chain, addr2, cacheConfig := newAsyncStateCommitTest(t, 10)
+	chainDB := rawdb.NewMemoryDatabase()
+
+	blockchain, err := createBlockChain(chainDB, cacheConfig, gspec, common.Hash{})
+	require.NoError(err)
+
+	// Stop the pipeline in the middle of committing the state of the sixth block.
+	var (
+		errCrash = errors.New("crash")
+		reached  = make(chan struct{})
+		crash    = make(chan struct{})
+	)
+	blockchain.stateCommitter.stop()
+	blockchain.stateCommitter = newStateCommitter(func(block *types.Block, statedb *state.StateDB) error {
+		if block.NumberU64() < 6 {
+			return blockchain.commitStateInBackground(block, statedb)
+		}
+		close(reached)
+		<-crash
+		return errCrash
+	})
+
+	for _, block := range chain[:6] {
+		require.NoError(blockchain.InsertBlock(block))
+	}
+	for _, block := range chain[:5] {
+		require.NoError(blockchain.Accept(block))
+	}
+	blockchain.DrainAcceptorQueue()
+	<-reached
+
+	// Crash without shutting down the state manager, so the accepted state is
+	// never flushed to disk.
+	close(crash)
+	blockchain.stopWithoutSaving()
+
+	// Restart from the last accepted block, whose state must be recovered, while
+	// the state of the sixth block was never committed.
+	restarted, err := createBlockChain(chainDB, cacheConfig, gspec, chain[4].Hash())
+	require.NoError(err)
+	defer restarted.Stop()
+
+	require.Equal(chain[4].Hash(), restarted.CurrentBlock().Hash())
+	require.True(restarted.HasState(chain[4].Root()))
+	require.False(restarted.HasState(chain[5].Root()))
+
+	_, err = restarted.InsertChain(chain[5:])
+	require.NoError(err)
+	for _, block := range chain[5:] {
+		require.NoError(restarted.Accept(block))
+	}
+	restarted.DrainAcceptorQueue()
+
+	statedb, err := restarted.State()
+	require.NoError(err)
+	require.Equal(big.NewInt(100000), statedb.GetBalance(addr2))
+}
@@ -45,7 +45,7 @@ const (
 	// dirties cache at tip (only applicable in [pruning] mode).
 	//
 	// Keeping extra tries around at tip enables clients to query data from
-	// recent trie roots.
+	// recent trie roots. It can be raised with [CacheConfig.StateHistory].
 	tipBufferSize = 32
 
 	// flushWindow is the distance to the [commitInterval] when we start
@@ -79,7 +79,7 @@ func NewTrieWriter(db TrieDB, config *CacheConfig) TrieWriter {
 			targetCommitSize: common.StorageSize(config.TrieDirtyCommitTarget) * 1024 * 1024,
 			imageCap:         4 * 1024 * 1024,
 			commitInterval:   config.CommitInterval,
-			tipBuffer:        NewBoundedBuffer(tipBufferSize, db.Dereference),
+			tipBuffer:        newStateHistory(config.StateHistory, config.StateHistoryDuration, db.Dereference),
 		}
 		cm.flushStepSize = (cm.memoryCap - cm.targetCommitSize) / common.StorageSize(flushWindow)
 		return cm
@@ -120,7 +120,72 @@ type cappedMemoryTrieWriter struct {
 	imageCap         common.StorageSize
 	commitInterval   uint64
 
-	tipBuffer *BoundedBuffer[common.Hash]
+	tipBuffer *stateHistory
+}
+
+// stateHistoryEntry is an accepted root retained by [stateHistory].
+type stateHistoryEntry struct {
+	root common.Hash
+	time uint64
+}
+
+// stateHistory retains the roots of recently accepted blocks and calls
+// [callback] on the roots falling out of the retention window. A root is
+// retained until at least [blocks] newer roots have been accepted and, if
+// [duration] is non-zero, the newest accepted block is at least [duration]
+// younger than its block.
+//
+// stateHistory is not thread-safe and requires the caller synchronize usage.
+type stateHistory struct {
+	blocks   uint64
+	duration uint64 // seconds
+	callback func(common.Hash) error
+
+	entries []stateHistoryEntry
+}
+
+// newStateHistory creates a new [stateHistory]. If [blocks] is 0,
+// [tipBufferSize] roots are retained.
+func newStateHistory(blocks uint64, duration time.Duration, callback func(common.Hash) error) *stateHistory {
+	if blocks == 0 {
+		blocks = tipBufferSize
+	}
+	return &stateHistory{
+		blocks:   blocks,
+		duration: uint64(duration / time.Second),
+		callback: callback,
+	}
+}
+
+// Insert retains [root] of a block with [timestamp] and culls the roots that
+// are no longer in the retention window.
+func (h *stateHistory) Insert(root common.Hash, timestamp uint64) error {
+	h.entries = append(h.entries, stateHistoryEntry{root: root, time: timestamp})
+	for uint64(len(h.entries)) > h.blocks {
+		oldest := h.entries[0]
+		if h.duration != 0 && oldest.time+h.duration > timestamp {
+			break
+		}
+		if err := h.callback(oldest.root); err != nil {
+			return err
+		}
+		h.entries[0] = stateHistoryEntry{}
+		h.entries = h.entries[1:]
+	}
+	return nil
+}
+
+// Last retrieves the last root added to the history.
+func (h *stateHistory) Last() (common.Hash, bool) {
+	if len(h.entries) == 0 {
+		return common.Hash{}, false
+	}
+	return h.entries[len(h.entries)-1].root, true
+}
+
+// Len returns the number of retained roots.
+func (h *stateHistory) Len() int {
+	return len(h.entries)
 }
 
 func (cm *cappedMemoryTrieWriter) InsertTrie(block *types.Block) error {
@@ -140,12 +205,12 @@ func (cm *cappedMemoryTrieWriter) InsertTrie(block *types.Block) error {
 func (cm *cappedMemoryTrieWriter) AcceptTrie(block *types.Block) error {
 	root := block.Root()
 
-	// Attempt to dereference roots at least [tipBufferSize] old (so queries at tip
-	// can still be completed).
+	// Attempt to dereference roots outside of the retention window (at least
+	// [tipBufferSize] old by default, so queries at tip can still be completed).
 	//
 	// Note: It is safe to dereference roots that have been committed to disk
 	// (they are no-ops).
-	if err := cm.tipBuffer.Insert(root); err != nil {
+	if err := cm.tipBuffer.Insert(root, block.Time()); err != nil {
 		return err
 	}
 
@@ -6,6 +6,7 @@ package core
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/subnet-evm/core/types"
 
@@ -102,3 +103,48 @@ func TestNoPruningTrieWriter(t *testing.T) {
 		m.LastDereference = common.Hash{}
 	}
 }
+
+func TestCappedMemoryTrieWriterStateHistory(t *testing.T) {
+	const (
+		history  = 64
+		interval = 10 // seconds between blocks
+	)
+	tests := map[string]struct {
+		duration time.Duration
+		retained int // number of roots retained at tip
+	}{
+		"blocks":           {retained: history},
+		"shorter duration": {duration: 100 * time.Second, retained: history},
+		"longer duration":  {duration: 1000 * time.Second, retained: 1000 / interval},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &MockTrieDB{}
+			cacheConfig := &CacheConfig{Pruning: true, CommitInterval: 4096, StateHistory: history, StateHistoryDuration: test.duration}
+			w := NewTrieWriter(m, cacheConfig).(*cappedMemoryTrieWriter)
+			assert := assert.New(t)
+			for i := 1; i <= 200; i++ {
+				bigI := big.NewInt(int64(i))
+				block := types.NewBlock(
+					&types.Header{
+						Root:   common.BigToHash(bigI),
+						Number: bigI,
+						Time:   uint64(i * interval),
+					},
+					nil, nil, nil, nil,
+				)
+				assert.NoError(w.AcceptTrie(block))
+				if i <= test.retained {
+					assert.Equal(common.Hash{}, m.LastDereference, "should not have dereferenced root within retention window")
+					assert.Equal(i, w.tipBuffer.Len())
+				} else {
+					assert.Equal(common.BigToHash(big.NewInt(int64(i-test.retained))), m.LastDereference, "should have dereferenced root outside of retention window")
+					assert.Equal(test.retained, w.tipBuffer.Len())
+				}
+			}
+			last, ok := w.tipBuffer.Last()
+			assert.True(ok)
+			assert.Equal(common.BigToHash(big.NewInt(200)), last)
+		})
+	}
+}
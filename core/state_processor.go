@@ -36,7 +36,10 @@ import (
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/blockhashhistory"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/stateupgrade"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -50,14 +53,22 @@ import (
 type StateProcessor struct {
 	config *params.ChainConfig // Chain configuration options
 	bc     *BlockChain         // Canonical block chain
+	chain  processorChain      // Chain data used to process blocks, the canonical block chain unless processing statelessly
 	engine consensus.Engine    // Consensus engine used for block rewards
 }
 
+// processorChain is the chain data used to process blocks.
+type processorChain interface {
+	ChainContext
+	consensus.ChainHeaderReader
+}
+
 // NewStateProcessor initialises a new StateProcessor.
 func NewStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consensus.Engine) *StateProcessor {
 	return &StateProcessor{
 		config: config,
 		bc:     bc,
+		chain:  bc,
 		engine: engine,
 	}
 }
@@ -80,17 +91,35 @@ func (p *StateProcessor) Process(block *types.Block, parent *types.Header, state
 		gp          = new(GasPool).AddGas(block.GasLimit())
 	)
 
+	// Merge the precompile upgrades scheduled by the governance precompile into the
+	// chain config, so that they are applied and observed as its own upgrades.
+	config, err := governance.ChainConfigAt(p.config, statedb, block.Time())
+	if err != nil {
+		log.Error("failed to read scheduled upgrades processing block", "hash", block.Hash(), "number", block.NumberU64(), "timestamp", block.Time(), "err", err)
+		return nil, nil, 0, err
+	}
 	// Configure any upgrades that should go into effect during this block.
-	err := ApplyUpgrades(p.config, &parent.Time, block, statedb)
+	err = ApplyUpgrades(config, &parent.Time, block, statedb)
 	if err != nil {
 		log.Error("failed to configure precompiles processing block", "hash", block.Hash(), "number", block.NumberU64(), "timestamp", block.Time(), "err", err)
 		return nil, nil, 0, err
 	}
 
+	if p.useParallelExecution(block, cfg) {
+		receipts, allLogs, err = p.applyTransactionsParallel(config, block, statedb, cfg, gp, usedGas, p.bc.cacheConfig.ParallelTxExecution)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if err := p.engine.Finalize(p.chain, block, parent, statedb, receipts); err != nil {
+			return nil, nil, 0, fmt.Errorf("engine finalization check failed: %w", err)
+		}
+		return receipts, allLogs, *usedGas, nil
+	}
+
 	var (
-		context = NewEVMBlockContext(header, p.bc, nil)
-		vmenv   = vm.NewEVM(context, vm.TxContext{}, statedb, p.config, cfg)
-		signer  = types.MakeSigner(p.config, header.Number, header.Time)
+		context = NewEVMBlockContext(header, p.chain, nil)
+		vmenv   = vm.NewEVM(context, vm.TxContext{}, statedb, config, cfg)
+		signer  = types.MakeSigner(config, header.Number, header.Time)
 	)
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
@@ -99,7 +128,7 @@ func (p *StateProcessor) Process(block *types.Block, parent *types.Header, state
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		statedb.SetTxContext(tx.Hash(), i)
-		receipt, err := applyTransaction(msg, p.config, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
+		receipt, err := applyTransaction(msg, config, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
 		if err != nil {
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
@@ -107,7 +136,7 @@ func (p *StateProcessor) Process(block *types.Block, parent *types.Header, state
 		allLogs = append(allLogs, receipt.Logs...)
 	}
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
-	if err := p.engine.Finalize(p.bc, block, parent, statedb, receipts); err != nil {
+	if err := p.engine.Finalize(p.chain, block, parent, statedb, receipts); err != nil {
 		return nil, nil, 0, fmt.Errorf("engine finalization check failed: %w", err)
 	}
 
@@ -144,6 +173,10 @@ func applyTransaction(msg *Message, config *params.ChainConfig, gp *GasPool, sta
 	}
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
+	receipt.Fee = result.Fee
+	if tx.Type() == types.BlobTxType {
+		receipt.BlobGasUsed = tx.BlobGas()
+	}
 
 	// If the transaction created a contract, store the creation address in the receipt.
 	if msg.To == nil {
@@ -186,34 +219,41 @@ func ApplyPrecompileActivations(c *params.ChainConfig, parentTimestamp *uint64,
 	// an identical global state in a deterministic order when they are configured.
 	for _, module := range modules.RegisteredModules() {
 		for _, activatingConfig := range c.GetActivatingPrecompileConfigs(module.Address, parentTimestamp, blockTimestamp, c.PrecompileUpgrades) {
-			// If this transition activates the upgrade, configure the stateful precompile.
-			// (or deconfigure it if it is being disabled.)
-			if activatingConfig.IsDisabled() {
-				log.Info("Disabling precompile", "name", module.ConfigKey)
-				statedb.SelfDestruct(module.Address)
-				// Calling Finalise here effectively commits Suicide call and wipes the contract state.
-				// This enables re-configuration of the same contract state in the same block.
-				// Without an immediate Finalise call after the Suicide, a reconfigured precompiled state can be wiped out
-				// since Suicide will be committed after the reconfiguration.
-				statedb.Finalise(true)
-			} else {
-				log.Info("Activating new precompile", "name", module.ConfigKey, "config", activatingConfig)
-				// Set the nonce of the precompile's address (as is done when a contract is created) to ensure
-				// that it is marked as non-empty and will not be cleaned up when the statedb is finalized.
-				statedb.SetNonce(module.Address, 1)
-				// Set the code of the precompile's address to a non-zero length byte slice to ensure that the precompile
-				// can be called from within Solidity contracts. Solidity adds a check before invoking a contract to ensure
-				// that it does not attempt to invoke a non-existent contract.
-				statedb.SetCode(module.Address, []byte{0x1})
-				if err := module.Configure(c, activatingConfig, statedb, blockContext); err != nil {
-					return fmt.Errorf("could not configure precompile, name: %s, reason: %w", module.ConfigKey, err)
-				}
+			if err := ApplyPrecompileConfig(c, module, activatingConfig, blockContext, statedb); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// ApplyPrecompileConfig configures the stateful precompile of [module] with [config]
+// in [statedb], or deconfigures it if [config] disables the precompile.
+func ApplyPrecompileConfig(c *params.ChainConfig, module modules.Module, config precompileconfig.Config, blockContext contract.ConfigurationBlockContext, statedb *state.StateDB) error {
+	if config.IsDisabled() {
+		log.Info("Disabling precompile", "name", module.ConfigKey)
+		statedb.SelfDestruct(module.Address)
+		// Calling Finalise here effectively commits Suicide call and wipes the contract state.
+		// This enables re-configuration of the same contract state in the same block.
+		// Without an immediate Finalise call after the Suicide, a reconfigured precompiled state can be wiped out
+		// since Suicide will be committed after the reconfiguration.
+		statedb.Finalise(true)
+		return nil
+	}
+	log.Info("Activating new precompile", "name", module.ConfigKey, "config", config)
+	// Set the nonce of the precompile's address (as is done when a contract is created) to ensure
+	// that it is marked as non-empty and will not be cleaned up when the statedb is finalized.
+	statedb.SetNonce(module.Address, 1)
+	// Set the code of the precompile's address to a non-zero length byte slice to ensure that the precompile
+	// can be called from within Solidity contracts. Solidity adds a check before invoking a contract to ensure
+	// that it does not attempt to invoke a non-existent contract.
+	statedb.SetCode(module.Address, []byte{0x1})
+	if err := module.Configure(c, config, statedb, blockContext); err != nil {
+		return fmt.Errorf("could not configure precompile, name: %s, reason: %w", module.ConfigKey, err)
+	}
+	return nil
+}
+
 // applyStateUpgrades checks if any of the state upgrades specified by the chain config are activated by the block
 // transition from [parentTimestamp] to the timestamp set in [header]. If this is the case, it calls [Configure]
 // to apply the necessary state transitions for the upgrade.
@@ -228,9 +268,28 @@ func applyStateUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockCon
 	return nil
 }
 
+// blockHashContext is implemented by the block contexts of a block transition, which
+// know the hash of the parent block.
+type blockHashContext interface {
+	contract.ConfigurationBlockContext
+	ParentHash() common.Hash
+}
+
+// applyBlockHashHistory stores the parent hash of the block in [blockContext] in the
+// block hash history while the precompile is enabled, before any transaction of the
+// block is executed.
+func applyBlockHashHistory(c *params.ChainConfig, blockContext blockHashContext, statedb *state.StateDB) {
+	if !c.IsPrecompileEnabled(blockhashhistory.ContractAddress, blockContext.Timestamp()) {
+		return
+	}
+	blockhashhistory.StoreParentHash(statedb, blockContext.Number().Uint64(), blockContext.ParentHash())
+}
+
 // ApplyUpgrades checks if any of the precompile or state upgrades specified by the chain config are activated by the block
 // transition from [parentTimestamp] to the timestamp set in [header]. If this is the case, it calls [Configure]
-// to apply the necessary state transitions for the upgrade.
+// to apply the necessary state transitions for the upgrade. The precompile upgrades scheduled by the governance
+// precompile are only applied if [c] was returned by governance.ChainConfigAt, and are then pruned once superseded.
+// If [blockContext] is a block, the hash of its parent is then stored in the block hash history.
 // This function is called:
 // - in block processing to update the state when processing a block.
 // - in the miner to apply the state upgrades when producing a block.
@@ -238,5 +297,16 @@ func ApplyUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockContext
 	if err := ApplyPrecompileActivations(c, parentTimestamp, blockContext, statedb); err != nil {
 		return err
 	}
-	return applyStateUpgrades(c, parentTimestamp, blockContext, statedb)
+	if err := applyStateUpgrades(c, parentTimestamp, blockContext, statedb); err != nil {
+		return err
+	}
+	if c.IsPrecompileEnabled(governance.ContractAddress, blockContext.Timestamp()) {
+		if err := governance.PruneScheduledUpgrades(c, statedb, blockContext.Timestamp()); err != nil {
+			return fmt.Errorf("could not prune scheduled upgrades: %w", err)
+		}
+	}
+	if block, ok := blockContext.(blockHashContext); ok {
+		applyBlockHashHistory(c, block, statedb)
+	}
+	return nil
 }
@@ -27,23 +27,34 @@
 package core
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/consensus/misc/eip4844"
+	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/blockhashhistory"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warpincentives"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -338,6 +349,40 @@ func TestStateProcessorErrors(t *testing.T) {
 			}
 		}
 	}
+
+	// ErrSenderNoEOA, for a sender with EIP-7702 delegation code before Vesuvius
+	{
+		var (
+			sender = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+			code   = types.AddressToDelegation(common.Address{0xaa})
+			db     = rawdb.NewMemoryDatabase()
+			gspec  = &Genesis{
+				Config: config,
+				Alloc: GenesisAlloc{
+					sender: GenesisAccount{
+						Balance: big.NewInt(1000000000000000000), // 1 ether
+						Nonce:   0,
+						Code:    code,
+					},
+				},
+				GasLimit: params.TestChainConfig.FeeConfig.GasLimit.Uint64(),
+			}
+			blockchain, _ = NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+		)
+		defer blockchain.Stop()
+		txs := []*types.Transaction{
+			mkDynamicTx(0, common.Address{}, params.TxGas, big.NewInt(0), big.NewInt(params.TestMaxBaseFee)),
+		}
+		block := GenerateBadBlock(gspec.ToBlock(), dummy.NewCoinbaseFaker(), txs, gspec.Config)
+		_, err := blockchain.InsertChain(types.Blocks{block})
+		if err == nil {
+			t.Fatal("block imported without errors")
+		}
+		want := fmt.Sprintf("could not apply tx 0 [%v]: sender not an eoa: address %v, codehash: %v", txs[0].Hash().Hex(), sender.Hex(), crypto.Keccak256Hash(code).Hex())
+		if have := err.Error(); have != want {
+			t.Errorf("have \"%v\"\nwant \"%v\"\n", have, want)
+		}
+	}
 }
 
 // TestBadTxAllowListBlock tests the output generated when the
@@ -419,6 +464,544 @@ func TestBadTxAllowListBlock(t *testing.T) {
 	}
 }
 
+// TestFeeManagerDiscount tests that a base fee discount stored in the fee manager
+// reduces the gas price charged to the sender and paid to the coinbase.
+func TestFeeManagerDiscount(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+		target   = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		coinbase = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		baseFee  = big.NewInt(100)
+		balance  = big.NewInt(1000000000000000000)
+	)
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		feemanager.ConfigKey: feemanager.NewConfig(utils.NewUint64(0), nil, nil, nil, nil),
+	}
+
+	for _, tt := range []struct {
+		name             string
+		senderDiscount   uint64
+		targetDiscount   uint64
+		expectedGasPrice int64
+	}{
+		{name: "no discount", expectedGasPrice: 110},
+		{name: "sender discount", senderDiscount: 50, expectedGasPrice: 60},
+		{name: "target discount", targetDiscount: 100, expectedGasPrice: 10},
+		{name: "larger discount wins", senderDiscount: 20, targetDiscount: 30, expectedGasPrice: 80},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			statedb.SetBalance(sender, balance)
+			feemanager.SetFeeDiscount(statedb, sender, tt.senderDiscount)
+			feemanager.SetFeeDiscount(statedb, target, tt.targetDiscount)
+
+			blockCtx := vm.BlockContext{
+				CanTransfer: CanTransfer,
+				Transfer:    Transfer,
+				Coinbase:    coinbase,
+				BlockNumber: big.NewInt(1),
+				Time:        1,
+				GasLimit:    params.TxGas,
+				BaseFee:     baseFee,
+			}
+			msg := &Message{
+				To:        &target,
+				From:      sender,
+				Value:     big.NewInt(0),
+				GasLimit:  params.TxGas,
+				GasPrice:  big.NewInt(110),
+				GasFeeCap: big.NewInt(110),
+				GasTipCap: big.NewInt(10),
+			}
+			evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, &config, vm.Config{})
+			if _, err := ApplyMessage(evm, msg, new(GasPool).AddGas(params.TxGas)); err != nil {
+				t.Fatal(err)
+			}
+
+			fee := new(big.Int).Mul(big.NewInt(tt.expectedGasPrice), new(big.Int).SetUint64(params.TxGas))
+			if have, want := statedb.GetBalance(sender), new(big.Int).Sub(balance, fee); have.Cmp(want) != 0 {
+				t.Fatalf("sender balance mismatch: have %v, want %v", have, want)
+			}
+			if have := statedb.GetBalance(coinbase); have.Cmp(fee) != 0 {
+				t.Fatalf("coinbase balance mismatch: have %v, want %v", have, fee)
+			}
+		})
+	}
+}
+
+func TestFeeSponsorship(t *testing.T) {
+	var (
+		sender    = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+		target    = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		other     = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+		coinbase  = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		paymaster = common.HexToAddress("0x00000000000000000000000000000000000000dd")
+		baseFee   = big.NewInt(100)
+		balance   = big.NewInt(1000000000000000000)
+		value     = big.NewInt(1000)
+		checkGas  = uint64(10_000)
+		gasLimit  = params.TxGas + checkGas
+	)
+	// sponsorTarget returns true if the recipient of the transaction is [target].
+	sponsorTarget := append(append([]byte{byte(vm.PUSH1), 0x24, byte(vm.CALLDATALOAD), byte(vm.PUSH20)}, target.Bytes()...),
+		byte(vm.EQ), byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0, byte(vm.RETURN))
+	// sponsorChecks returns true if called by the sponsorship check caller.
+	sponsorChecks := append(append([]byte{byte(vm.CALLER), byte(vm.PUSH20)}, params.FeeSponsorshipCheckCaller.Bytes()...),
+		byte(vm.EQ), byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0, byte(vm.RETURN))
+	// loop runs out of gas.
+	loop := []byte{byte(vm.JUMPDEST), byte(vm.PUSH1), 0, byte(vm.JUMP)}
+
+	config := *params.TestChainConfig
+	config.FeeSponsorship = &params.FeeSponsorship{
+		BlockTimestamp: utils.NewUint64(0),
+		Paymaster:      paymaster,
+		CheckGas:       checkGas,
+	}
+
+	for _, tt := range []struct {
+		name             string
+		paymasterCode    []byte
+		paymasterBalance *big.Int
+		senderBalance    *big.Int
+		to               common.Address
+		value            *big.Int
+		gasLimit         uint64
+		expectedErr      error
+		sponsored        bool
+		checkGasUsed     uint64
+	}{
+		{name: "sponsored", paymasterCode: sponsorTarget, paymasterBalance: balance, senderBalance: common.Big0, to: target, value: common.Big0, gasLimit: gasLimit, sponsored: true, checkGasUsed: 27},
+		{name: "sponsored with value", paymasterCode: sponsorTarget, paymasterBalance: balance, senderBalance: value, to: target, value: value, gasLimit: gasLimit, sponsored: true, checkGasUsed: 27},
+		{name: "sponsored without value", paymasterCode: sponsorTarget, paymasterBalance: balance, senderBalance: common.Big0, to: target, value: value, gasLimit: gasLimit, expectedErr: ErrInsufficientFunds},
+		{name: "sponsored check caller", paymasterCode: sponsorChecks, paymasterBalance: balance, senderBalance: common.Big0, to: target, value: common.Big0, gasLimit: gasLimit, sponsored: true, checkGasUsed: 23},
+		{name: "not sponsored", paymasterCode: sponsorTarget, paymasterBalance: balance, senderBalance: balance, to: other, value: common.Big0, gasLimit: gasLimit, checkGasUsed: 27},
+		{name: "check out of gas", paymasterCode: loop, paymasterBalance: balance, senderBalance: balance, to: target, value: common.Big0, gasLimit: gasLimit, checkGasUsed: checkGas},
+		{name: "check limited by gas limit", paymasterCode: loop, paymasterBalance: balance, senderBalance: balance, to: target, value: common.Big0, gasLimit: params.TxGas + 100, checkGasUsed: 100},
+		{name: "no gas left for check", paymasterCode: sponsorTarget, paymasterBalance: balance, senderBalance: balance, to: target, value: common.Big0, gasLimit: params.TxGas},
+		{name: "paymaster out of funds", paymasterCode: sponsorTarget, paymasterBalance: common.Big0, senderBalance: balance, to: target, value: common.Big0, gasLimit: gasLimit, expectedErr: ErrInsufficientFunds},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(err)
+			statedb.SetBalance(sender, tt.senderBalance)
+			statedb.SetBalance(paymaster, tt.paymasterBalance)
+			statedb.SetCode(paymaster, tt.paymasterCode)
+
+			blockCtx := vm.BlockContext{
+				CanTransfer: CanTransfer,
+				Transfer:    Transfer,
+				Coinbase:    coinbase,
+				BlockNumber: big.NewInt(1),
+				Time:        1,
+				GasLimit:    tt.gasLimit,
+				BaseFee:     baseFee,
+			}
+			msg := &Message{
+				To:        &tt.to,
+				From:      sender,
+				Value:     tt.value,
+				GasLimit:  tt.gasLimit,
+				GasPrice:  big.NewInt(110),
+				GasFeeCap: big.NewInt(110),
+				GasTipCap: big.NewInt(10),
+			}
+			evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, &config, vm.Config{})
+			result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(tt.gasLimit))
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			// The gas used by the sponsorship check is charged to the account buying the gas.
+			require.Equal(params.TxGas+tt.checkGasUsed, result.UsedGas)
+			fee := new(big.Int).Mul(big.NewInt(110), new(big.Int).SetUint64(result.UsedGas))
+			senderBalance, paymasterBalance := new(big.Int).Sub(tt.senderBalance, tt.value), tt.paymasterBalance
+			if tt.sponsored {
+				paymasterBalance = new(big.Int).Sub(paymasterBalance, fee)
+			} else {
+				senderBalance.Sub(senderBalance, fee)
+			}
+			require.Equal(senderBalance, statedb.GetBalance(sender))
+			require.Equal(paymasterBalance, statedb.GetBalance(paymaster))
+			require.Equal(fee, statedb.GetBalance(coinbase))
+		})
+	}
+}
+
+// TestWarpIncentivesFeeShare tests that the share of the block fees accruing to the warp
+// incentives reward pool is taken out of the fees actually credited to the coinbase.
+func TestWarpIncentivesFeeShare(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		coinbase  = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		paymaster = common.HexToAddress("0x00000000000000000000000000000000000000dd")
+		balance   = new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100))
+		// sponsorAll returns true.
+		sponsorAll = []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0, byte(vm.RETURN)}
+	)
+	for _, tt := range []struct {
+		name      string
+		coinbase  common.Address
+		discount  uint64
+		sponsored bool
+	}{
+		{name: "full fee", coinbase: coinbase},
+		{name: "discounted fee", coinbase: coinbase, discount: 50},
+		{name: "sponsored fee", coinbase: coinbase, sponsored: true},
+		{name: "burned fee", coinbase: constants.BlackholeAddr},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			config := *params.TestChainConfig
+			config.GenesisPrecompiles = params.Precompiles{
+				feemanager.ConfigKey:     feemanager.NewConfig(utils.NewUint64(0), nil, nil, nil, nil),
+				warpincentives.ConfigKey: warpincentives.NewConfig(utils.NewUint64(0), nil, nil, nil, 1_000),
+			}
+			alloc := GenesisAlloc{
+				feemanager.ContractAddress: {
+					Balance: new(big.Int),
+					Storage: map[common.Hash]common.Hash{
+						feemanager.FeeDiscountStorageKey(sender): common.BigToHash(new(big.Int).SetUint64(tt.discount)),
+					},
+				},
+			}
+			if tt.sponsored {
+				config.FeeSponsorship = &params.FeeSponsorship{BlockTimestamp: utils.NewUint64(0), Paymaster: paymaster}
+				alloc[paymaster] = GenesisAccount{Balance: balance, Code: sponsorAll}
+			} else {
+				alloc[sender] = GenesisAccount{Balance: balance}
+			}
+			gspec := &Genesis{Config: &config, Alloc: alloc}
+			signer := types.LatestSigner(&config)
+
+			var baseFee *big.Int
+			db, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(i int, b *BlockGen) {
+				b.SetCoinbase(tt.coinbase)
+				baseFee = b.BaseFee()
+				tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+					ChainID:   config.ChainID,
+					Nonce:     0,
+					GasTipCap: big.NewInt(0),
+					GasFeeCap: baseFee,
+					Gas:       100_000,
+					To:        &common.Address{0xaa},
+					Value:     new(big.Int),
+				}), signer, key)
+				require.NoError(err)
+				b.AddTx(tx)
+			})
+			require.NoError(err)
+			statedb, err := state.New(blocks[0].Root(), state.NewDatabase(db), nil)
+			require.NoError(err)
+
+			receipt := receipts[0][0]
+			price := new(big.Int).Sub(baseFee, new(big.Int).Div(new(big.Int).Mul(baseFee, new(big.Int).SetUint64(tt.discount)), big.NewInt(100)))
+			fee := new(big.Int).Mul(price, new(big.Int).SetUint64(receipt.GasUsed))
+			require.Equal(fee, receipt.Fee)
+			if tt.sponsored {
+				require.Equal(new(big.Int).Sub(balance, fee), statedb.GetBalance(paymaster))
+			}
+
+			accrued := new(big.Int).Div(fee, big.NewInt(10))
+			if tt.coinbase == constants.BlackholeAddr {
+				accrued = new(big.Int)
+			}
+			require.Equal(accrued, statedb.GetBalance(warpincentives.ContractAddress))
+			require.Equal(new(big.Int).Sub(fee, accrued), statedb.GetBalance(tt.coinbase))
+
+			// The state processor accrues the same share as the block builder.
+			blockchain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+			require.NoError(err)
+			defer blockchain.Stop()
+			_, err = blockchain.InsertChain(blocks)
+			require.NoError(err)
+		})
+	}
+}
+
+// TestBlockHashHistory tests that the parent hash of each block is stored in the block hash
+// history consistently by the chain maker and the state processor.
+func TestBlockHashHistory(t *testing.T) {
+	config := *params.TestChainConfig
+	config.OptionalNetworkUpgrades = params.OptionalNetworkUpgrades{
+		StromboliTimestamp: utils.NewUint64(0),
+	}
+	config.GenesisPrecompiles = params.Precompiles{
+		blockhashhistory.ConfigKey: blockhashhistory.NewConfig(utils.NewUint64(0)),
+	}
+	gspec := &Genesis{
+		Config:   &config,
+		GasLimit: config.FeeConfig.GasLimit.Uint64(),
+	}
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 3, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockchain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+
+	statedb, err := blockchain.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The hashes of the ancestors of the head are stored in its state
+	head := blockchain.CurrentBlock().Number.Uint64()
+	for number := uint64(0); number < head; number++ {
+		if have, want := blockhashhistory.GetBlockHash(statedb, head, number), blockchain.GetHeaderByNumber(number).Hash(); have != want {
+			t.Fatalf("block %d hash mismatch: have %x, want %x", number, have, want)
+		}
+	}
+}
+
+func TestBlobTransactions(t *testing.T) {
+	config := *params.TestChainConfig
+	config.CancunTime = u64(0)
+	var (
+		signer  = types.LatestSigner(&config)
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		numBlob = 2
+		gspec   = &Genesis{
+			Config: &config,
+			Alloc: GenesisAlloc{
+				addr: {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100))},
+			},
+		}
+	)
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		hashes := make([]common.Hash, numBlob)
+		for j := range hashes {
+			hashes[j] = common.Hash{params.BlobTxHashVersion, byte(i), byte(j)}
+		}
+		tx, err := types.SignTx(types.NewTx(&types.BlobTx{
+			ChainID:    uint256.MustFromBig(config.ChainID),
+			Nonce:      uint64(i),
+			GasTipCap:  uint256.NewInt(0),
+			GasFeeCap:  uint256.MustFromBig(b.BaseFee()),
+			Gas:        params.TxGas,
+			To:         common.Address{1},
+			Value:      new(uint256.Int),
+			BlobFeeCap: uint256.NewInt(params.BlobTxMinBlobGasprice),
+			BlobHashes: hashes,
+		}), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockchain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+
+	wantBlobGas := uint64(numBlob * params.BlobTxBlobGasPerBlob)
+	for _, block := range blocks {
+		if have := *block.BlobGasUsed(); have != wantBlobGas {
+			t.Fatalf("block %d blob gas used mismatch: have %d, want %d", block.NumberU64(), have, wantBlobGas)
+		}
+		// The blob gas of the block stays below the target, so the excess stays zero.
+		if have := *block.ExcessBlobGas(); have != 0 {
+			t.Fatalf("block %d excess blob gas mismatch: have %d, want 0", block.NumberU64(), have)
+		}
+		receipts := blockchain.GetReceiptsByHash(block.Hash())
+		if len(receipts) != 1 {
+			t.Fatalf("block %d receipts mismatch: have %d, want 1", block.NumberU64(), len(receipts))
+		}
+		if receipts[0].BlobGasUsed != wantBlobGas {
+			t.Fatalf("block %d receipt blob gas used mismatch: have %d, want %d", block.NumberU64(), receipts[0].BlobGasUsed, wantBlobGas)
+		}
+	}
+}
+
+func TestSetCodeTransactions(t *testing.T) {
+	config := *params.TestChainConfig
+	config.StromboliTimestamp = u64(0)
+	config.VesuviusTimestamp = u64(0)
+	var (
+		signer     = types.LatestSigner(&config)
+		key, _     = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr       = crypto.PubkeyToAddress(key.PublicKey)
+		authKey, _ = crypto.HexToECDSA("0202020202020202020202020202020202020202020202020202002020202020")
+		authority  = crypto.PubkeyToAddress(authKey.PublicKey)
+		target     = common.Address{0xaa}
+		gspec      = &Genesis{
+			Config: &config,
+			Alloc: GenesisAlloc{
+				addr: {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100))},
+				// PUSH1 0x42 PUSH1 0x00 SSTORE STOP
+				target: {Code: []byte{byte(vm.PUSH1), 0x42, byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP)}},
+			},
+		}
+	)
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(i int, b *BlockGen) {
+		auth, err := types.SignSetCode(authKey, types.SetCodeAuthorization{
+			ChainID: *uint256.MustFromBig(config.ChainID),
+			Address: target,
+			Nonce:   0,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := types.SignTx(types.NewTx(&types.SetCodeTx{
+			ChainID:   uint256.MustFromBig(config.ChainID),
+			Nonce:     0,
+			GasTipCap: uint256.NewInt(0),
+			GasFeeCap: uint256.MustFromBig(b.BaseFee()),
+			Gas:       100_000,
+			To:        authority,
+			Value:     new(uint256.Int),
+			AuthList:  []types.SetCodeAuthorization{auth},
+		}), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockchain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+
+	receipts := blockchain.GetReceiptsByHash(blocks[0].Hash())
+	if len(receipts) != 1 || receipts[0].Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("expected a single successful receipt, got %v", receipts)
+	}
+	statedb, err := blockchain.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := statedb.GetCode(authority), types.AddressToDelegation(target); !bytes.Equal(have, want) {
+		t.Fatalf("authority code mismatch: have %x, want %x", have, want)
+	}
+	if have := statedb.GetNonce(authority); have != 1 {
+		t.Fatalf("authority nonce mismatch: have %d, want 1", have)
+	}
+	// The delegated code runs in the context of the authority.
+	if have := statedb.GetState(authority, common.Hash{}); have != common.BigToHash(big.NewInt(0x42)) {
+		t.Fatalf("authority storage mismatch: have %x, want 0x42", have)
+	}
+}
+
+// TestGovernanceUpgrade tests that a precompile upgrade scheduled by the governance precompile
+// configures the precompile when it activates and enables it in the rules of the EVM.
+func TestGovernanceUpgrade(t *testing.T) {
+	var (
+		admin   = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+		balance = big.NewInt(1000000000000000000)
+	)
+	config := *params.TestChainConfig
+	config.OptionalNetworkUpgrades = params.OptionalNetworkUpgrades{
+		StromboliTimestamp: utils.NewUint64(0),
+	}
+	config.GenesisPrecompiles = params.Precompiles{
+		governance.ConfigKey: governance.NewConfig(utils.NewUint64(0), []common.Address{admin}, nil, nil, 1, 0),
+	}
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetBalance(admin, balance)
+	genesisCtx := vm.BlockContext{BlockNumber: big.NewInt(0), Time: 0}
+	if err := ApplyPrecompileActivations(&config, nil, &genesisCtx, statedb); err != nil {
+		t.Fatal(err)
+	}
+
+	upgrade, err := json.Marshal(&params.PrecompileUpgrade{
+		Config: txallowlist.NewConfig(utils.NewUint64(20), []common.Address{governance.ContractAddress}, nil, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposeUpgrade, err := governance.PackProposeUpgrade(upgrade)
+	if err != nil {
+		t.Fatal(err)
+	}
+	execute, err := governance.PackExecute(common.Big1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockCtx := vm.BlockContext{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		BlockNumber: big.NewInt(1),
+		Time:        10,
+		GasLimit:    params.TxGas * 100,
+		BaseFee:     big.NewInt(0),
+	}
+	for _, data := range [][]byte{proposeUpgrade, execute} {
+		msg := &Message{
+			To:        &governance.ContractAddress,
+			From:      admin,
+			Nonce:     statedb.GetNonce(admin),
+			Value:     big.NewInt(0),
+			GasLimit:  blockCtx.GasLimit,
+			GasPrice:  big.NewInt(0),
+			GasFeeCap: big.NewInt(0),
+			GasTipCap: big.NewInt(0),
+			Data:      data,
+		}
+		evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, &config, vm.Config{})
+		result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(blockCtx.GasLimit))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+	}
+
+	// The upgrade activates in the block at timestamp 20, once merged into the chain config.
+	blockCtx.BlockNumber, blockCtx.Time = big.NewInt(2), 20
+	blockConfig, err := governance.ChainConfigAt(&config, statedb, blockCtx.Time)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyUpgrades(blockConfig, utils.NewUint64(10), &blockCtx, statedb); err != nil {
+		t.Fatal(err)
+	}
+	if role := txallowlist.GetTxAllowListStatus(statedb, governance.ContractAddress); role != allowlist.AdminRole {
+		t.Fatalf("governance role mismatch: have %v, want %v", role, allowlist.AdminRole)
+	}
+	readAllowList, err := allowlist.PackReadAllowList(governance.ContractAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evm := vm.NewEVM(blockCtx, vm.TxContext{}, statedb, &config, vm.Config{})
+	ret, _, err := evm.StaticCall(vm.AccountRef(admin), txallowlist.ContractAddress, readAllowList, params.TxGas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.BigToHash(allowlist.AdminRole.Big()).Bytes(); !bytes.Equal(ret, want) {
+		t.Fatalf("readAllowList mismatch: have %x, want %x", ret, want)
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
@@ -36,6 +36,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/vmerrs"
@@ -46,9 +47,10 @@ import (
 // ExecutionResult includes all output after executing given evm
 // message no matter the execution itself is successful or not.
 type ExecutionResult struct {
-	UsedGas    uint64 // Total used gas but include the refunded gas
-	Err        error  // Any error encountered during the execution(listed in core/vm/errors.go)
-	ReturnData []byte // Returned data from evm(function result or data supplied with revert opcode)
+	UsedGas    uint64   // Total used gas but include the refunded gas
+	Fee        *big.Int // Fee credited to the coinbase, after any fee discount
+	Err        error    // Any error encountered during the execution(listed in core/vm/errors.go)
+	ReturnData []byte   // Returned data from evm(function result or data supplied with revert opcode)
 }
 
 // Unwrap returns the internal evm error which allows us for further
@@ -79,7 +81,7 @@ func (result *ExecutionResult) Revert() []byte {
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, accessList types.AccessList, isContractCreation bool, rules params.Rules) (uint64, error) {
+func IntrinsicGas(data []byte, accessList types.AccessList, authList []types.SetCodeAuthorization, isContractCreation bool, rules params.Rules) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if isContractCreation && rules.IsHomestead {
@@ -132,6 +134,12 @@ func IntrinsicGas(data []byte, accessList types.AccessList, isContractCreation b
 		}
 		gas = totalGas
 	}
+	if authList != nil {
+		if (math.MaxUint64-gas)/params.CallNewAccountGas < uint64(len(authList)) {
+			return 0, ErrGasUintOverflow
+		}
+		gas += uint64(len(authList)) * params.CallNewAccountGas
+	}
 
 	return gas, nil
 }
@@ -199,6 +207,10 @@ type Message struct {
 	BlobGasFeeCap *big.Int
 	BlobHashes    []common.Hash
 
+	// SetCodeAuthorizations are the EIP-7702 authorizations of the message,
+	// only set for set code transactions.
+	SetCodeAuthorizations []types.SetCodeAuthorization
+
 	// When SkipAccountChecks is true, the message nonce is not checked against the
 	// account nonce in state. It also disables checking that the sender is an EOA.
 	// This field will be set to true for operations like RPC eth_call.
@@ -220,6 +232,8 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		SkipAccountChecks: false,
 		BlobHashes:        tx.BlobHashes(),
 		BlobGasFeeCap:     tx.BlobGasFeeCap(),
+
+		SetCodeAuthorizations: tx.SetCodeAuthorizations(),
 	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
@@ -268,6 +282,9 @@ type StateTransition struct {
 	msg          *Message
 	gasRemaining uint64
 	initialGas   uint64
+	gasPrice     *big.Int       // price per unit of gas charged to the sender, after any fee discount
+	payer        common.Address // account buying the gas, the sender unless sponsored by the paymaster
+	checkGasUsed uint64         // gas used by the sponsorship check of the paymaster, charged as intrinsic gas
 	state        vm.StateDB
 	evm          *vm.EVM
 }
@@ -292,11 +309,13 @@ func (st *StateTransition) to() common.Address {
 
 func (st *StateTransition) buyGas() error {
 	mgval := new(big.Int).SetUint64(st.msg.GasLimit)
-	mgval = mgval.Mul(mgval, st.msg.GasPrice)
+	mgval = mgval.Mul(mgval, st.gasPrice)
 	balanceCheck := new(big.Int).Set(mgval)
 	if st.msg.GasFeeCap != nil {
+		// The fee discount is deducted from the fee cap as well.
+		feeCap := new(big.Int).Sub(st.msg.GasFeeCap, new(big.Int).Sub(st.msg.GasPrice, st.gasPrice))
 		balanceCheck.SetUint64(st.msg.GasLimit)
-		balanceCheck = balanceCheck.Mul(balanceCheck, st.msg.GasFeeCap)
+		balanceCheck = balanceCheck.Mul(balanceCheck, feeCap)
 		balanceCheck.Add(balanceCheck, st.msg.Value)
 	}
 	if st.evm.ChainConfig().IsCancun(st.evm.Context.BlockNumber, st.evm.Context.Time) {
@@ -311,8 +330,17 @@ func (st *StateTransition) buyGas() error {
 			mgval.Add(mgval, blobFee)
 		}
 	}
-	if have, want := st.state.GetBalance(st.msg.From), balanceCheck; have.Cmp(want) < 0 {
-		return fmt.Errorf("%w: address %v have %v want %v", ErrInsufficientFunds, st.msg.From.Hex(), have, want)
+	if st.payer != st.msg.From {
+		// The gas is bought by the paymaster, while the sender still pays the value.
+		if st.msg.GasFeeCap != nil {
+			balanceCheck.Sub(balanceCheck, st.msg.Value)
+		}
+		if have, want := st.state.GetBalance(st.msg.From), st.msg.Value; have.Cmp(want) < 0 {
+			return fmt.Errorf("%w: address %v have %v want %v", ErrInsufficientFunds, st.msg.From.Hex(), have, want)
+		}
+	}
+	if have, want := st.state.GetBalance(st.payer), balanceCheck; have.Cmp(want) < 0 {
+		return fmt.Errorf("%w: address %v have %v want %v", ErrInsufficientFunds, st.payer.Hex(), have, want)
 	}
 	if err := st.gp.SubGas(st.msg.GasLimit); err != nil {
 		return err
@@ -320,7 +348,7 @@ func (st *StateTransition) buyGas() error {
 	st.gasRemaining += st.msg.GasLimit
 
 	st.initialGas = st.msg.GasLimit
-	st.state.SubBalance(st.msg.From, mgval)
+	st.state.SubBalance(st.payer, mgval)
 	return nil
 }
 
@@ -340,9 +368,14 @@ func (st *StateTransition) preCheck() error {
 			return fmt.Errorf("%w: address %v, nonce: %d", ErrNonceMax,
 				msg.From.Hex(), stNonce)
 		}
-		// Make sure the sender is an EOA
+		// Make sure the sender is an EOA. Once Vesuvius is active, accounts
+		// delegating to code with EIP-7702 are still EOAs.
 		codeHash := st.state.GetCodeHash(msg.From)
-		if codeHash != (common.Hash{}) && codeHash != types.EmptyCodeHash {
+		delegated := false
+		if st.evm.ChainConfig().IsVesuvius(st.evm.Context.Time) {
+			_, delegated = types.ParseDelegation(st.state.GetCode(msg.From))
+		}
+		if codeHash != (common.Hash{}) && codeHash != types.EmptyCodeHash && !delegated {
 			return fmt.Errorf("%w: address %v, codehash: %s", ErrSenderNoEOA,
 				msg.From.Hex(), codeHash)
 		}
@@ -357,6 +390,10 @@ func (st *StateTransition) preCheck() error {
 			if !txAllowListRole.IsEnabled() {
 				return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, msg.From)
 			}
+			// Check that the target contract is allowed if targets are restricted
+			if msg.To != nil && st.state.GetCodeSize(*msg.To) > 0 && !txallowlist.CanCallTarget(st.state, txAllowListRole, *msg.To) {
+				return fmt.Errorf("%w: %s", vmerrs.ErrTargetAddressNotAllowListed, *msg.To)
+			}
 		}
 	}
 
@@ -397,6 +434,19 @@ func (st *StateTransition) preCheck() error {
 		}
 	}
 
+	// Check that EIP-7702 authorization list signatures are well formed.
+	if msg.SetCodeAuthorizations != nil {
+		if !st.evm.ChainConfig().IsVesuvius(st.evm.Context.Time) {
+			return fmt.Errorf("%w: set code transactions require Vesuvius", ErrTxTypeNotSupported)
+		}
+		if msg.To == nil {
+			return fmt.Errorf("%w (sender %v)", ErrSetCodeTxCreate, msg.From)
+		}
+		if len(msg.SetCodeAuthorizations) == 0 {
+			return fmt.Errorf("%w (sender %v)", ErrEmptyAuthList, msg.From)
+		}
+	}
+
 	if st.evm.ChainConfig().IsCancun(st.evm.Context.BlockNumber, st.evm.Context.Time) {
 		if st.blobGasUsed() > 0 {
 			// Check that the user is paying at least the current blob fee
@@ -407,9 +457,56 @@ func (st *StateTransition) preCheck() error {
 		}
 	}
 
+	st.gasPrice = new(big.Int).Sub(msg.GasPrice, st.baseFeeDiscount())
+	st.payer = st.feePayer()
 	return st.buyGas()
 }
 
+// baseFeeDiscount returns the discount per unit of gas granted on the base fee
+// of the block to the message. The discount never exceeds the gas price.
+func (st *StateTransition) baseFeeDiscount() *big.Int {
+	discount := FeeDiscount(st.evm.ChainConfig(), st.evm.Context.Time, st.evm.Context.BaseFee, st.state, st.msg.From, st.msg.To)
+	return cmath.BigMin(discount, st.msg.GasPrice)
+}
+
+// FeeDiscount returns the discount per unit of gas granted on [baseFee] by the
+// FeeManager precompile to a transaction from [from] to [to] at [time]: the
+// discount of the sender or the recipient, whichever is larger.
+// The discount of the recipient only applies to the account the transaction
+// calls, so that contracts can discount the transactions calling them. It does
+// not apply to the contracts called in turn, nor to contract creation.
+func FeeDiscount(config *params.ChainConfig, time uint64, baseFee *big.Int, statedb vm.StateDB, from common.Address, to *common.Address) *big.Int {
+	if baseFee == nil || !config.IsPrecompileEnabled(feemanager.ContractAddress, time) {
+		return new(big.Int)
+	}
+	percent := feemanager.GetFeeDiscount(statedb, from)
+	if to != nil {
+		percent = max(percent, feemanager.GetFeeDiscount(statedb, *to))
+	}
+	if percent == 0 {
+		return new(big.Int)
+	}
+	discount := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(percent))
+	return discount.Div(discount, big.NewInt(feemanager.MaxFeeDiscount))
+}
+
+// feePayer returns the account buying the gas of the message: the paymaster of
+// the chain if it sponsors the message, or the sender otherwise. The gas used by
+// the sponsorship check is recorded, to be charged to the account buying the gas.
+func (st *StateTransition) feePayer() common.Address {
+	config := st.evm.ChainConfig()
+	if !config.IsFeeSponsorship(st.evm.Context.Time) || st.msg.From == config.FeeSponsorship.Paymaster {
+		return st.msg.From
+	}
+	rules := config.Rules(st.evm.Context.BlockNumber, st.evm.Context.Time)
+	sponsored, gasUsed := checkFeeSponsorship(st.evm, config.FeeSponsorship, st.msg, feeSponsorshipCheckGas(config.FeeSponsorship, st.msg, rules))
+	st.checkGasUsed = gasUsed
+	if !sponsored {
+		return st.msg.From
+	}
+	return config.FeeSponsorship.Paymaster
+}
+
 // TransitionDb will transition the state by applying the current message and
 // returning the evm execution result with following fields.
 //
@@ -452,10 +549,12 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	)
 
 	// Check clauses 4-5, subtract intrinsic gas if everything is correct
-	gas, err := IntrinsicGas(msg.Data, msg.AccessList, contractCreation, rules)
+	gas, err := IntrinsicGas(msg.Data, msg.AccessList, msg.SetCodeAuthorizations, contractCreation, rules)
 	if err != nil {
 		return nil, err
 	}
+	// The sponsorship check is bounded by the gas left after the intrinsic gas.
+	gas += st.checkGasUsed
 	if st.gasRemaining < gas {
 		return nil, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gasRemaining, gas)
 	}
@@ -467,8 +566,8 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}
 
 	// Check whether the init code size has been exceeded.
-	if rules.IsDurango && contractCreation && len(msg.Data) > params.MaxInitCodeSize {
-		return nil, fmt.Errorf("%w: code size %v limit %v", vmerrs.ErrMaxInitCodeSizeExceeded, len(msg.Data), params.MaxInitCodeSize)
+	if rules.IsDurango && contractCreation && len(msg.Data) > rules.MaxInitCodeSize {
+		return nil, fmt.Errorf("%w: code size %v limit %v", vmerrs.ErrMaxInitCodeSizeExceeded, len(msg.Data), rules.MaxInitCodeSize)
 	}
 
 	// Execute the preparatory steps for state transition which includes:
@@ -485,18 +584,94 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	} else {
 		// Increment the nonce for the next transaction
 		st.state.SetNonce(msg.From, st.state.GetNonce(sender.Address())+1)
+
+		// Apply EIP-7702 authorizations.
+		if msg.SetCodeAuthorizations != nil {
+			for _, auth := range msg.SetCodeAuthorizations {
+				// Note errors are ignored, we simply skip invalid authorizations here.
+				st.applyAuthorization(&auth)
+			}
+		}
+
+		// Perform convenience warming of the delegation target if the recipient
+		// is delegated, mirroring the access of the sender and recipient.
+		if addr, ok := types.ParseDelegation(st.state.GetCode(st.to())); ok && rules.IsVesuvius {
+			st.state.AddAddressToAccessList(addr)
+		}
+
 		ret, st.gasRemaining, vmerr = st.evm.Call(sender, st.to(), msg.Data, st.gasRemaining, msg.Value)
 	}
 	st.refundGas(rules.IsSubnetEVM)
-	st.state.AddBalance(st.evm.Context.Coinbase, new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), msg.GasPrice))
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
+	st.state.AddBalance(st.evm.Context.Coinbase, fee)
 
 	return &ExecutionResult{
 		UsedGas:    st.gasUsed(),
+		Fee:        fee,
 		Err:        vmerr,
 		ReturnData: ret,
 	}, nil
 }
 
+// validateAuthorization validates an EIP-7702 authorization against the state.
+func (st *StateTransition) validateAuthorization(auth *types.SetCodeAuthorization) (authority common.Address, err error) {
+	// Verify chain ID is null or equal to current chain ID.
+	if !auth.ChainID.IsZero() && auth.ChainID.CmpBig(st.evm.ChainConfig().ChainID) != 0 {
+		return authority, ErrAuthorizationWrongChainID
+	}
+	// Limit nonce to 2^64-1 per EIP-2681.
+	if auth.Nonce+1 < auth.Nonce {
+		return authority, ErrAuthorizationNonceOverflow
+	}
+	// Validate signature values and recover authority.
+	authority, err = auth.Authority()
+	if err != nil {
+		return authority, fmt.Errorf("%w: %v", ErrAuthorizationInvalidSignature, err)
+	}
+	// Check the authority account
+	//  1) doesn't have code or has existing delegation
+	//  2) matches the auth's nonce
+	//
+	// Note it is added to the access list even if the authorization is invalid.
+	st.state.AddAddressToAccessList(authority)
+	code := st.state.GetCode(authority)
+	if _, ok := types.ParseDelegation(code); len(code) != 0 && !ok {
+		return authority, ErrAuthorizationDestinationHasCode
+	}
+	if have := st.state.GetNonce(authority); have != auth.Nonce {
+		return authority, ErrAuthorizationNonceMismatch
+	}
+	return authority, nil
+}
+
+// applyAuthorization applies an EIP-7702 code delegation to the state.
+func (st *StateTransition) applyAuthorization(auth *types.SetCodeAuthorization) error {
+	authority, err := st.validateAuthorization(auth)
+	if err != nil {
+		return err
+	}
+
+	// If the account already exists in state, refund the new account cost
+	// charged in the intrinsic calculation. The refund is credited directly
+	// since Subnet-EVM does not apply the refund counter.
+	if st.state.Exist(authority) {
+		st.gasRemaining += params.CallNewAccountGas - params.TxAuthTupleGas
+	}
+
+	// Update nonce and account code.
+	st.state.SetNonce(authority, auth.Nonce+1)
+	if auth.Address == (common.Address{}) {
+		// Delegation to zero address means clear.
+		st.state.SetCode(authority, nil)
+		return nil
+	}
+
+	// Otherwise install delegation to auth.Address.
+	st.state.SetCode(authority, types.AddressToDelegation(auth.Address))
+
+	return nil
+}
+
 func (st *StateTransition) refundGas(subnetEVM bool) {
 	// Inspired by: https://gist.github.com/holiman/460f952716a74eeb9ab358bb1836d821#gistcomment-3642048
 	if !subnetEVM {
@@ -509,8 +684,8 @@ func (st *StateTransition) refundGas(subnetEVM bool) {
 	}
 
 	// Return ETH for remaining gas, exchanged at the original rate.
-	remaining := new(big.Int).Mul(new(big.Int).SetUint64(st.gasRemaining), st.msg.GasPrice)
-	st.state.AddBalance(st.msg.From, remaining)
+	remaining := new(big.Int).Mul(new(big.Int).SetUint64(st.gasRemaining), st.gasPrice)
+	st.state.AddBalance(st.payer, remaining)
 
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
@@ -0,0 +1,147 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/stateless"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+var (
+	errWitnessParentMismatch = errors.New("witness parent does not match block parent")
+	errInvalidTxRoot         = errors.New("invalid transaction root")
+)
+
+var _ processorChain = (*witnessChain)(nil)
+
+// witnessChain provides the chain data needed to process a block, with the
+// past headers returned by [header] and the state opened by [stateAt].
+type witnessChain struct {
+	config  *params.ChainConfig
+	engine  consensus.Engine
+	header  func(hash common.Hash, number uint64) *types.Header
+	stateAt func(root common.Hash) (*state.StateDB, error)
+
+	feeConfigCache      *lru.Cache[common.Hash, *cacheableFeeConfig]
+	coinbaseConfigCache *lru.Cache[common.Hash, *cacheableCoinbaseConfig]
+}
+
+func newWitnessChain(config *params.ChainConfig, engine consensus.Engine, header func(common.Hash, uint64) *types.Header, stateAt func(common.Hash) (*state.StateDB, error)) *witnessChain {
+	return &witnessChain{
+		config:              config,
+		engine:              engine,
+		header:              header,
+		stateAt:             stateAt,
+		feeConfigCache:      lru.NewCache[common.Hash, *cacheableFeeConfig](1),
+		coinbaseConfigCache: lru.NewCache[common.Hash, *cacheableCoinbaseConfig](1),
+	}
+}
+
+func (c *witnessChain) Config() *params.ChainConfig { return c.config }
+
+func (c *witnessChain) Engine() consensus.Engine { return c.engine }
+
+func (c *witnessChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return c.header(hash, number)
+}
+
+// CurrentHeader, GetHeaderByNumber and GetHeaderByHash are not used to
+// process blocks, so they return nil.
+func (c *witnessChain) CurrentHeader() *types.Header              { return nil }
+func (c *witnessChain) GetHeaderByNumber(uint64) *types.Header    { return nil }
+func (c *witnessChain) GetHeaderByHash(common.Hash) *types.Header { return nil }
+
+func (c *witnessChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+	return getFeeConfigAt(c.config, parent, c.feeConfigCache, c.stateAt)
+}
+
+func (c *witnessChain) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
+	return getCoinbaseAt(c.config, parent, c.coinbaseConfigCache, c.stateAt)
+}
+
+// ExecutionWitness re-executes [block] on top of the state of its parent and
+// returns the witness of the state it accesses, to verify it statelessly with
+// [ExecuteStateless].
+func (bc *BlockChain) ExecutionWitness(block *types.Block) (*stateless.Witness, error) {
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	if err := bc.waitForState(block.ParentHash()); err != nil {
+		return nil, err
+	}
+
+	// Open the state without snapshots, so every state access goes through the
+	// tries and is recorded.
+	witness := stateless.NewWitness(parent)
+	db := state.NewWitnessDatabase(state.NewDatabaseWithNodeDB(bc.db, bc.triedb), witness)
+	chain := newWitnessChain(
+		bc.chainConfig,
+		bc.engine,
+		func(hash common.Hash, number uint64) *types.Header {
+			header := bc.GetHeader(hash, number)
+			if header != nil {
+				witness.AddHeader(header)
+			}
+			return header
+		},
+		func(root common.Hash) (*state.StateDB, error) {
+			return state.New(root, db, nil)
+		},
+	)
+	statedb, err := state.New(parent.Root, db, nil)
+	if err != nil {
+		return nil, err
+	}
+	processor := &StateProcessor{config: bc.chainConfig, chain: chain, engine: bc.engine}
+	if _, _, _, err := processor.Process(block, parent, statedb, vm.Config{}); err != nil {
+		return nil, err
+	}
+	// Hash the state to resolve the trie nodes required to update the tries.
+	if root := statedb.IntermediateRoot(bc.chainConfig.IsEIP158(block.Number())); root != block.Root() {
+		return nil, fmt.Errorf("invalid merkle root (remote: %x local: %x) dberr: %w", block.Root(), root, statedb.Error())
+	}
+	db.Collect()
+	return witness, nil
+}
+
+// ExecuteStateless verifies [block] by executing it on top of the state in
+// [witness], without access to the rest of the chain. The header of the block
+// must have been verified beforehand, as only its execution is verified.
+func ExecuteStateless(config *params.ChainConfig, engine consensus.Engine, block *types.Block, witness *stateless.Witness) error {
+	parent := witness.Headers[0]
+	if parent.Hash() != block.ParentHash() || parent.Number.Uint64()+1 != block.NumberU64() {
+		return fmt.Errorf("%w: expected %s, got %s", errWitnessParentMismatch, block.ParentHash(), parent.Hash())
+	}
+	if txHash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); txHash != block.TxHash() {
+		return fmt.Errorf("%w (remote: %x local: %x)", errInvalidTxRoot, block.TxHash(), txHash)
+	}
+
+	db := state.NewDatabase(witness.MakeHashDB())
+	chain := newWitnessChain(config, engine, witness.Header, func(root common.Hash) (*state.StateDB, error) {
+		return state.New(root, db, nil)
+	})
+	statedb, err := state.New(witness.Root(), db, nil)
+	if err != nil {
+		return err
+	}
+	processor := &StateProcessor{config: config, chain: chain, engine: engine}
+	receipts, _, usedGas, err := processor.Process(block, parent, statedb, vm.Config{})
+	if err != nil {
+		return err
+	}
+	return NewBlockValidator(config, nil, engine).ValidateState(block, statedb, receipts, usedGas)
+}
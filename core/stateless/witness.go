@@ -0,0 +1,164 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package stateless implements execution witnesses: the state a block reads
+// and writes during its execution, with the proofs of this state against the
+// state root of its parent, so the block can be verified without the full state.
+package stateless
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var errNoParent = errors.New("witness has no parent header")
+
+// Witness encompasses the state required to execute a block on top of its
+// parent: the trie nodes and contract code it accesses, and the headers of
+// the past blocks it accesses (ie. through the BLOCKHASH opcode).
+type Witness struct {
+	Headers []*types.Header     // Past headers accessed during execution, starting with the parent
+	Codes   map[string]struct{} // Contract code accessed during execution
+	State   map[string]struct{} // Trie nodes proving the accessed state against the parent state root
+
+	lock sync.Mutex
+}
+
+// NewWitness returns an empty witness for executing a block on top of [parent].
+func NewWitness(parent *types.Header) *Witness {
+	return &Witness{
+		Headers: []*types.Header{parent},
+		Codes:   make(map[string]struct{}),
+		State:   make(map[string]struct{}),
+	}
+}
+
+// Root returns the state root the state of the witness is proven against.
+func (w *Witness) Root() common.Hash {
+	return w.Headers[0].Root
+}
+
+// AddHeader adds [header] to the witness if it is not already in it.
+func (w *Witness) AddHeader(header *types.Header) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	hash := header.Hash()
+	for _, h := range w.Headers {
+		if h.Hash() == hash {
+			return
+		}
+	}
+	w.Headers = append(w.Headers, header)
+}
+
+// AddCode adds contract [code] to the witness.
+func (w *Witness) AddCode(code []byte) {
+	if len(code) == 0 {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.Codes[string(code)] = struct{}{}
+}
+
+// AddState adds the encoded trie [nodes] to the witness.
+func (w *Witness) AddState(nodes map[string]struct{}) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for node := range nodes {
+		w.State[node] = struct{}{}
+	}
+}
+
+// Header returns the header of the block [number] with [hash] if it is in the
+// witness, or nil otherwise.
+func (w *Witness) Header(hash common.Hash, number uint64) *types.Header {
+	for _, header := range w.Headers {
+		if header.Number.Uint64() == number && header.Hash() == hash {
+			return header
+		}
+	}
+	return nil
+}
+
+// MakeHashDB returns an in-memory database with the trie nodes and contract
+// code of the witness, to open the state at [Root] with the hash scheme.
+func (w *Witness) MakeHashDB() ethdb.Database {
+	db := rawdb.NewMemoryDatabase()
+	for code := range w.Codes {
+		blob := []byte(code)
+		rawdb.WriteCode(db, crypto.Keccak256Hash(blob), blob)
+	}
+	for node := range w.State {
+		blob := []byte(node)
+		rawdb.WriteLegacyTrieNode(db, crypto.Keccak256Hash(blob), blob)
+	}
+	return db
+}
+
+// extWitness is the RLP encoding of a witness, with its codes and trie nodes
+// sorted so the encoding is deterministic.
+type extWitness struct {
+	Headers []*types.Header
+	Codes   [][]byte
+	State   [][]byte
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (w *Witness) EncodeRLP(out io.Writer) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	ext := extWitness{
+		Headers: w.Headers,
+		Codes:   sortedBytes(w.Codes),
+		State:   sortedBytes(w.State),
+	}
+	return rlp.Encode(out, &ext)
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (w *Witness) DecodeRLP(s *rlp.Stream) error {
+	var ext extWitness
+	if err := s.Decode(&ext); err != nil {
+		return err
+	}
+	if len(ext.Headers) == 0 {
+		return errNoParent
+	}
+	w.Headers = ext.Headers
+	w.Codes = make(map[string]struct{}, len(ext.Codes))
+	for _, code := range ext.Codes {
+		w.Codes[string(code)] = struct{}{}
+	}
+	w.State = make(map[string]struct{}, len(ext.State))
+	for _, node := range ext.State {
+		w.State[string(node)] = struct{}{}
+	}
+	return nil
+}
+
+func sortedBytes(set map[string]struct{}) [][]byte {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	blobs := make([][]byte, len(keys))
+	for i, key := range keys {
+		blobs[i] = []byte(key)
+	}
+	return blobs
+}
@@ -0,0 +1,113 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/stateless"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionWitness(t *testing.T) {
+	// blockhashBin deploys a contract storing the hash of the third ancestor of
+	// the block in slot 0, and incrementing the value of slot 1 on every call.
+	const blockhashBin = "6012600c60003960126000f3" + "600343034060005560015460010160015500"
+
+	require := require.New(t)
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	var (
+		engine   = dummy.NewCoinbaseFaker()
+		gspec    = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}}}
+		signer   = types.LatestSigner(gspec.Config)
+		contract = crypto.CreateAddress(addr, 0)
+	)
+	genDb, _, _, err := GenerateChainWithGenesis(gspec, engine, 0, 10, nil)
+	require.NoError(err)
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, engine, vm.Config{}, common.Hash{}, false)
+	require.NoError(err)
+	defer chain.Stop()
+
+	// Generate the blocks one at a time on top of the chain, so BLOCKHASH can
+	// be executed when generating them.
+	blocks := make([]*types.Block, 4)
+	for i := range blocks {
+		var (
+			to   *common.Address
+			data []byte
+		)
+		if i == 0 {
+			data = common.FromHex(blockhashBin)
+		} else {
+			to = &contract
+		}
+		generated, _, err := GenerateChain(gspec.Config, chain.GetBlockByHash(chain.CurrentBlock().Hash()), engine, genDb, 1, 10, func(_ int, b *BlockGen) {
+			b.AddTxWithChain(chain, types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    b.TxNonce(addr),
+				To:       to,
+				Gas:      200_000,
+				GasPrice: new(big.Int).Mul(b.BaseFee(), common.Big2),
+				Data:     data,
+			}))
+			b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    b.TxNonce(addr),
+				To:       &common.Address{byte(i + 1)},
+				Value:    big.NewInt(params.Ether),
+				Gas:      params.TxGas,
+				GasPrice: new(big.Int).Mul(b.BaseFee(), common.Big2),
+			}))
+		})
+		require.NoError(err)
+		_, err = chain.InsertChain(generated)
+		require.NoError(err)
+		blocks[i] = generated[0]
+	}
+
+	for _, block := range blocks {
+		witness, err := chain.ExecutionWitness(block)
+		require.NoError(err)
+		require.NotEmpty(witness.State)
+
+		// The witness must be sufficient to verify the block after a round
+		// trip through its encoding.
+		encoded, err := rlp.EncodeToBytes(witness)
+		require.NoError(err)
+		decoded := new(stateless.Witness)
+		require.NoError(rlp.DecodeBytes(encoded, decoded))
+		require.NoError(ExecuteStateless(gspec.Config, engine, block, decoded))
+	}
+
+	// The witness of the last block includes the header read with BLOCKHASH.
+	last := blocks[len(blocks)-1]
+	witness, err := chain.ExecutionWitness(last)
+	require.NoError(err)
+	require.Len(witness.Headers, 2)
+	require.Len(witness.Codes, 1)
+
+	// Executing the block with a witness missing the ancestor header results
+	// in a different state.
+	witness.Headers = witness.Headers[:1]
+	require.ErrorContains(ExecuteStateless(gspec.Config, engine, last, witness), "invalid merkle root")
+
+	// Executing the block on a witness missing the state fails.
+	witness, err = chain.ExecutionWitness(last)
+	require.NoError(err)
+	witness.State = make(map[string]struct{})
+	require.Error(ExecuteStateless(gspec.Config, engine, last, witness))
+
+	// Executing the block on top of the witness of another block fails.
+	witness, err = chain.ExecutionWitness(blocks[1])
+	require.NoError(err)
+	require.ErrorIs(ExecuteStateless(gspec.Config, engine, last, witness), errWitnessParentMismatch)
+}
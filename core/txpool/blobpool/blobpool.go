@@ -795,9 +795,10 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 			p.recheck(addr, inclusions)
 		}
 	}
-	// Flush out any blobs from limbo that are older than the latest finality
+	// Flush out any blobs from limbo that are older than the retention window
+	// behind the latest finality
 	if p.chain.Config().IsCancun(p.head.Number, p.head.Time) {
-		p.limbo.finalize(p.chain.CurrentFinalBlock())
+		p.limbo.finalize(p.chain.CurrentFinalBlock(), p.config.Retention)
 	}
 	feeConfig, _, err := p.chain.GetFeeConfigAt(p.head)
 	if err != nil {
@@ -1201,6 +1202,30 @@ func (p *BlobPool) Get(hash common.Hash) *txpool.Transaction {
 	}
 }
 
+// GetBlobSidecar returns the blobs of a transaction if it is contained in the
+// pool or was included in a block within the retention window, or nil otherwise.
+func (p *BlobPool) GetBlobSidecar(hash common.Hash) *types.BlobTxSidecar {
+	if tx := p.Get(hash); tx != nil {
+		return &types.BlobTxSidecar{
+			Blobs:       tx.BlobTxBlobs,
+			Commitments: tx.BlobTxCommits,
+			Proofs:      tx.BlobTxProofs,
+		}
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	item, err := p.limbo.get(hash)
+	if err != nil {
+		return nil
+	}
+	return &types.BlobTxSidecar{
+		Blobs:       item.Blobs,
+		Commitments: item.Commits,
+		Proofs:      item.Proofs,
+	}
+}
+
 // Add inserts a set of blob transactions into the pool if they pass validation (both
 // consensus validity and pool restictions).
 func (p *BlobPool) Add(txs []*txpool.Transaction, local bool, sync bool) []error {
@@ -1617,19 +1642,50 @@ func (p *BlobPool) Stats() (int, int) {
 // Content retrieves the data content of the transaction pool, returning all the
 // pending as well as queued transactions, grouped by account and sorted by nonce.
 //
-// For the blob pool, this method will return nothing for now.
-// TODO(karalabe): Abstract out the returned metadata.
+// The transactions are loaded from the persistent store without their blobs, so
+// that the content matches the pending count reported by Stats.
 func (p *BlobPool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
-	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pending := make(map[common.Address][]*types.Transaction, len(p.index))
+	for addr, txs := range p.index {
+		pending[addr] = p.loadTxs(txs)
+	}
+	return pending, make(map[common.Address][]*types.Transaction) // No non-executable txs in the blob pool
 }
 
 // ContentFrom retrieves the data content of the transaction pool, returning the
 // pending as well as queued transactions of this address, grouped by nonce.
 //
-// For the blob pool, this method will return nothing for now.
-// TODO(karalabe): Abstract out the returned metadata.
+// The transactions are loaded from the persistent store without their blobs.
 func (p *BlobPool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
-	return []*types.Transaction{}, []*types.Transaction{}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.loadTxs(p.index[addr]), []*types.Transaction{}
+}
+
+// loadTxs retrieves the transactions of [metas] from the persistent store,
+// skipping the ones that cannot be loaded.
+//
+// The caller must hold the pool lock.
+func (p *BlobPool) loadTxs(metas []*blobTxMeta) []*types.Transaction {
+	txs := make([]*types.Transaction, 0, len(metas))
+	for _, meta := range metas {
+		data, err := p.store.Get(meta.id)
+		if err != nil {
+			log.Error("Tracked blob transaction missing from store", "hash", meta.hash, "id", meta.id, "err", err)
+			continue
+		}
+		item := new(blobTx)
+		if err := rlp.DecodeBytes(data, item); err != nil {
+			log.Error("Blobs corrupted for tracked transaction", "hash", meta.hash, "id", meta.id, "err", err)
+			continue
+		}
+		txs = append(txs, item.Tx)
+	}
+	return txs
 }
 
 // Locals retrieves the accounts currently considered local by the pool.
@@ -1639,6 +1695,21 @@ func (p *BlobPool) Locals() []common.Address {
 	return []common.Address{}
 }
 
+// PrioritySenders retrieves the senders whose transactions are included first
+// in blocks.
+//
+// There are no priority senders in the blob pool.
+func (p *BlobPool) PrioritySenders() []common.Address {
+	return []common.Address{}
+}
+
+// Cancel removes a local transaction from the pool.
+//
+// There is no notion of local transactions in the blob pool.
+func (p *BlobPool) Cancel(hash common.Hash) error {
+	return txpool.ErrNotLocal
+}
+
 // Status returns the known status (unknown/pending/queued) of a transaction
 // identified by their hashes.
 func (p *BlobPool) Status(hash common.Hash) txpool.TxStatus {
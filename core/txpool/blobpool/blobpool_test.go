@@ -327,6 +327,23 @@ func verifyPoolInternals(t *testing.T, pool *BlobPool) {
 	if pool.stored != stored {
 		t.Errorf("pool storage mismatch: have %d, want %d", pool.stored, stored)
 	}
+	// Verify that the pool content matches the index
+	pending, queued := pool.Content()
+	if len(pending) != len(pool.index) || len(queued) != 0 {
+		t.Errorf("pool content accounts mismatch: have %d pending, %d queued, want %d pending", len(pending), len(queued), len(pool.index))
+	}
+	for addr, txs := range pool.index {
+		content, _ := pool.ContentFrom(addr)
+		if len(pending[addr]) != len(txs) || len(content) != len(txs) {
+			t.Errorf("addr %v content mismatch: have %d txs, %d from address, want %d", addr, len(pending[addr]), len(content), len(txs))
+			continue
+		}
+		for i, tx := range txs {
+			if pending[addr][i].Hash() != tx.hash || content[i].Hash() != tx.hash {
+				t.Errorf("addr %v, tx %d content hash mismatch: have #%x, want #%x", addr, i, pending[addr][i].Hash(), tx.hash)
+			}
+		}
+	}
 	// Verify the price heap internals
 	verifyHeapInternals(t, pool.evict)
 }
@@ -35,6 +35,7 @@ type Config struct {
 	Datadir   string // Data directory containing the currently executable blobs
 	Datacap   uint64 // Soft-cap of database storage (hard cap is larger due to overhead)
 	PriceBump uint64 // Minimum price bump percentage to replace an already existing nonce
+	Retention uint64 // Number of accepted blocks to keep the blobs of included transactions for
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -42,6 +43,7 @@ var DefaultConfig = Config{
 	Datadir:   "blobpool",
 	Datacap:   10 * 1024 * 1024 * 1024,
 	PriceBump: 100, // either have patience or be aggressive, no mushy ground
+	Retention: 1024,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
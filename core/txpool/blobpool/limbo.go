@@ -52,6 +52,8 @@ type limboBlob struct {
 // limbo is a light, indexed database to temporarily store recently included
 // blobs until they are finalized. The purpose is to support small reorgs, which
 // would require pulling back up old blobs (which aren't part of the chain).
+// Blobs are additionally retained for a configured number of blocks after
+// finality, so they can be served after the transaction is accepted.
 //
 // TODO(karalabe): Currently updating the inclusion block of a blob needs a full db rewrite. Can we do without?
 type limbo struct {
@@ -125,16 +127,21 @@ func (l *limbo) parseBlob(id uint64, data []byte) error {
 	return nil
 }
 
-// finalize evicts all blobs belonging to a recently finalized block or older.
-func (l *limbo) finalize(final *types.Header) {
+// finalize evicts all blobs belonging to blocks finalized at least [retention]
+// blocks before [final].
+func (l *limbo) finalize(final *types.Header, retention uint64) {
 	// Just in case there's no final block yet (network not yet merged, weird
 	// restart, sethead, etc), fail gracefully.
 	if final == nil {
 		log.Error("Nil finalized block cannot evict old blobs")
 		return
 	}
+	if final.Number.Uint64() < retention {
+		return
+	}
+	evictBefore := final.Number.Uint64() - retention
 	for block, ids := range l.groups {
-		if block > final.Number.Uint64() {
+		if block > evictBefore {
 			continue
 		}
 		for id, owner := range ids {
@@ -183,6 +190,24 @@ func (l *limbo) pull(tx common.Hash) ([]kzg4844.Blob, []kzg4844.Commitment, []kz
 	return item.Blobs, item.Commits, item.Proofs, nil
 }
 
+// get retrieves a previously pushed set of blobs from the limbo without removing
+// it. This method should be used to serve the blobs of included transactions.
+func (l *limbo) get(tx common.Hash) (*limboBlob, error) {
+	id, ok := l.index[tx]
+	if !ok {
+		return nil, errors.New("unseen blob transaction")
+	}
+	data, err := l.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	item := new(limboBlob)
+	if err := rlp.DecodeBytes(data, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
 // update changes the block number under which a blob transaction is tracked. This
 // method should be used when a reorg changes a transaction's inclusion block.
 //
@@ -0,0 +1,53 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blobpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the limbo keeps the blobs of included transactions for the
+// configured number of blocks after finality.
+func TestLimboRetention(t *testing.T) {
+	require := require.New(t)
+
+	l, err := newLimbo("")
+	require.NoError(err)
+	defer l.Close()
+
+	var (
+		first  = common.Hash{1}
+		second = common.Hash{2}
+		blobs  = []kzg4844.Blob{{0x01}}
+	)
+	require.NoError(l.push(first, 5, blobs, []kzg4844.Commitment{{0x02}}, []kzg4844.Proof{{0x03}}))
+	require.NoError(l.push(second, 10, blobs, []kzg4844.Commitment{{0x04}}, []kzg4844.Proof{{0x05}}))
+
+	// Blocks within the retention window of the final block are kept
+	l.finalize(&types.Header{Number: big.NewInt(6)}, 2)
+	item, err := l.get(first)
+	require.NoError(err)
+	require.Equal(uint64(5), item.Block)
+	require.Equal(blobs, item.Blobs)
+
+	l.finalize(&types.Header{Number: big.NewInt(7)}, 2)
+	_, err = l.get(first)
+	require.Error(err)
+	item, err = l.get(second)
+	require.NoError(err)
+	require.Equal([]kzg4844.Commitment{{0x04}}, item.Commits)
+
+	// Without retention, blobs are evicted as soon as they are finalized
+	l.finalize(&types.Header{Number: big.NewInt(10)}, 0)
+	_, err = l.get(second)
+	require.Error(err)
+	require.Empty(l.index)
+	require.Empty(l.groups)
+}
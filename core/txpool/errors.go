@@ -64,4 +64,11 @@ var (
 	// ErrFutureReplacePending is returned if a future transaction replaces a pending
 	// transaction. Future transactions should only be able to replace other future transactions.
 	ErrFutureReplacePending = errors.New("future transaction tries to replace pending")
+
+	// ErrTxNotFound is returned if a transaction is attempted to be cancelled,
+	// but it is not contained within the pool.
+	ErrTxNotFound = errors.New("transaction not found")
+
+	// ErrNotLocal is returned if a remote transaction is attempted to be cancelled.
+	ErrNotLocal = errors.New("transaction is not local")
 )
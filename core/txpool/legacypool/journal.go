@@ -156,12 +156,16 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 	journaled := 0
 	for _, txs := range all {
 		for _, tx := range txs {
+			// Conditional transactions would be loaded without their conditions
+			if tx.Conditional() != nil {
+				continue
+			}
 			if err = rlp.Encode(replacement, tx); err != nil {
 				replacement.Close()
 				return err
 			}
+			journaled++
 		}
-		journaled += len(txs)
 	}
 	replacement.Close()
 
@@ -45,6 +45,7 @@ import (
 	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/prque"
@@ -76,6 +77,14 @@ var (
 	// ErrTxPoolOverflow is returned if the transaction pool is full and can't accept
 	// another remote transaction.
 	ErrTxPoolOverflow = errors.New("txpool is full")
+
+	// ErrSystemLaneOverflow is returned if the system lane of the transaction pool
+	// is full and can't accept another transaction.
+	ErrSystemLaneOverflow = errors.New("txpool system lane is full")
+
+	// ErrReplacementLimit is returned if a transaction is attempted to replace a
+	// transaction whose nonce reached the replacement limit of the chain.
+	ErrReplacementLimit = errors.New("transaction replacement limit reached")
 )
 
 var (
@@ -148,8 +157,10 @@ type Config struct {
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
 
+	PrioritySenders []common.Address // Senders exempt from eviction, whose transactions are included first in blocks
+
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
-	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
+	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce), unless set by the chain
 
 	AccountSlots uint64 // Number of executable transaction slots guaranteed per account
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
@@ -161,8 +172,8 @@ type Config struct {
 
 // DefaultConfig contains the default configurations for the transaction pool.
 var DefaultConfig = Config{
-	// If we re-enable txpool journaling, we should also add the saved local
-	// transactions to the p2p gossip on startup.
+	// Journaling is disabled by default. The VM pushes the journaled local
+	// transactions to its peers on startup when it is enabled.
 	Journal:   "",
 	Rejournal: time.Hour,
 
@@ -243,10 +254,13 @@ type LegacyPool struct {
 
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
+	currentConfig *params.ChainConfig          // Chain config at the current head, including the scheduled precompile upgrades
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 
-	locals  *accountSet // Set of local transaction to exempt from eviction rules
-	journal *journal    // Journal of local transaction to back up to disk
+	locals   *accountSet // Set of local transaction to exempt from eviction rules
+	system   *accountSet // Set of system lane senders, whose transactions have their own limits
+	priority *accountSet // Set of priority senders, whose transactions are included first in blocks
+	journal  *journal    // Journal of local transaction to back up to disk
 
 	reserve txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
 	pending map[common.Address]*list     // All currently processable transactions
@@ -255,6 +269,8 @@ type LegacyPool struct {
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	replacements map[common.Hash]uint64 // Number of times the transaction at the nonce of each transaction was replaced
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -286,6 +302,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		queue:               make(map[common.Address]*list),
 		beats:               make(map[common.Address]time.Time),
 		all:                 newLookup(),
+		replacements:        make(map[common.Hash]uint64),
 		reqResetCh:          make(chan *txpoolResetRequest),
 		reqPromoteCh:        make(chan *accountSet),
 		queueTxEventCh:      make(chan *types.Transaction),
@@ -299,6 +316,21 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		log.Info("Setting new local account", "address", addr)
 		pool.locals.add(addr)
 	}
+	// Priority senders are also tracked as locals, which exempts their
+	// transactions from the eviction rules and the limits of the pool.
+	pool.priority = newAccountSet(pool.signer)
+	for _, addr := range config.PrioritySenders {
+		log.Info("Setting new priority sender", "address", addr)
+		pool.priority.add(addr)
+		pool.locals.add(addr)
+	}
+	pool.system = newAccountSet(pool.signer)
+	if lane := pool.chainconfig.SystemTxLane; lane != nil {
+		for _, addr := range lane.Senders {
+			log.Info("Setting new system lane account", "address", addr)
+			pool.system.add(addr)
+		}
+	}
 	pool.priced = newPricedList(pool.all)
 
 	if !config.NoLocals && config.Journal != "" {
@@ -311,7 +343,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 // pool, specifically, whether it is a Legacy, AccessList or Dynamic transaction.
 func (pool *LegacyPool) Filter(tx *types.Transaction) bool {
 	switch tx.Type() {
-	case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType:
+	case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType, types.SetCodeTxType:
 		return true
 	default:
 		return false
@@ -394,8 +426,8 @@ func (pool *LegacyPool) loop() {
 		case <-evict.C:
 			pool.mu.Lock()
 			for addr := range pool.queue {
-				// Skip local transactions from the eviction mechanism
-				if pool.locals.contains(addr) {
+				// Skip local and system lane transactions from the eviction mechanism
+				if pool.locals.contains(addr) || pool.system.contains(addr) {
 					continue
 				}
 				// Any non-locals old enough should be removed
@@ -663,6 +695,40 @@ func (pool *LegacyPool) Locals() []common.Address {
 	return pool.locals.flatten()
 }
 
+// Cancel removes the local transaction [hash] from the pool, moving the later
+// transactions of its sender back to the queue. Remote transactions cannot be
+// cancelled, as the operator of the node has no authority over them.
+func (pool *LegacyPool) Cancel(hash common.Hash) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.all.Get(hash) == nil {
+		return txpool.ErrTxNotFound
+	}
+	if pool.all.GetLocal(hash) == nil {
+		return txpool.ErrNotLocal
+	}
+	pool.removeTx(hash, true, true)
+	log.Info("Cancelled local transaction", "hash", hash)
+
+	// Rewrite the journal, so the transaction is not re-injected on restart
+	if pool.journal != nil {
+		if err := pool.journal.rotate(pool.local()); err != nil {
+			log.Warn("Failed to rotate local tx journal", "err", err)
+		}
+	}
+	return nil
+}
+
+// PrioritySenders retrieves the senders whose transactions are included first
+// in blocks.
+func (pool *LegacyPool) PrioritySenders() []common.Address {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	return pool.priority.flatten()
+}
+
 // local retrieves all currently known local transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -689,8 +755,9 @@ func (pool *LegacyPool) validateTxBasics(tx *types.Transaction, local bool) erro
 		Accept: 0 |
 			1<<types.LegacyTxType |
 			1<<types.AccessListTxType |
-			1<<types.DynamicFeeTxType,
-		MaxSize: txMaxSize,
+			1<<types.DynamicFeeTxType |
+			1<<types.SetCodeTxType,
+		MaxSize: pool.chainconfig.GetMaxTxSize(txMaxSize),
 		MinTip:  pool.gasTip.Load(),
 	}
 	if local {
@@ -710,7 +777,7 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 
 	opts := &txpool.ValidationOptionsWithState{
 		State: pool.currentState,
-		Rules: pool.chainconfig.Rules(
+		Rules: pool.currentConfig.Rules(
 			pool.currentHead.Load().Number,
 			pool.currentHead.Load().Time,
 		),
@@ -736,12 +803,21 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
 			if list := pool.pending[addr]; list != nil {
 				if tx := list.txs.Get(nonce); tx != nil {
-					return tx.Cost()
+					return txpool.SenderCost(tx)
 				}
 			}
 			return nil
 		},
 	}
+	// Only the value of the transaction is charged to the sender if the paymaster
+	// of the chain sponsors its gas in the pending block.
+	head := pool.currentHead.Load()
+	pendingTime := max(head.Time, uint64(time.Now().Unix()))
+	tx.SetFeeSponsored(core.IsFeeSponsored(pool.currentConfig, head, pendingTime, pool.currentState, tx))
+	// The fee discount is granted on the base fee, so it is at least the discount
+	// on the minimum base fee whatever the base fee of the block including [tx].
+	from, _ := types.Sender(pool.signer, tx)
+	tx.SetFeeDiscount(core.FeeDiscount(pool.currentConfig, pool.currentHead.Load().Time, pool.minimumFee, pool.currentState, from, tx.To()))
 	if err := txpool.ValidateTransactionWithState(tx, pool.signer, opts); err != nil {
 		return err
 	}
@@ -773,6 +849,9 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		invalidTxMeter.Mark(1)
 		return false, err
 	}
+	// Transactions of the system lane are tracked like local transactions, such
+	// that they are never discarded to make room for the public lane.
+	isSystem := pool.system.containsTx(tx)
 	// already validated by this point
 	from, _ := types.Sender(pool.signer, tx)
 
@@ -798,8 +877,23 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			}
 		}()
 	}
-	// If the transaction pool is full, discard underpriced transactions
-	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
+	if err := pool.checkReplacementLimit(from, tx); err != nil {
+		log.Trace("Discarding transaction over the replacement limit", "hash", hash, "nonce", tx.Nonce())
+		pendingDiscardMeter.Mark(1)
+		return false, err
+	}
+	// The system lane has its own limit, independent of the limits of the public lane.
+	systemSlots := pool.systemSlots()
+	if isSystem {
+		if list := pool.pending[from]; list == nil || !list.Contains(tx.Nonce()) {
+			if uint64(systemSlots+numSlots(tx)) > pool.chainconfig.SystemTxLane.GetPoolSlots() {
+				log.Trace("Discarding overflown system lane transaction", "hash", hash)
+				overflowedTxMeter.Mark(1)
+				return false, ErrSystemLaneOverflow
+			}
+		}
+	} else if uint64(pool.all.Slots()-systemSlots+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
+		// If the transaction pool is full, discard underpriced transactions
 		// If the new transaction is underpriced, don't accept it
 		if !isLocal && pool.priced.Underpriced(tx) {
 			log.Trace("Discarding underpriced transaction", "hash", hash, "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
@@ -819,7 +913,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		// New transaction is better than our worse ones, make room for it.
 		// If it's a local transaction, forcibly discard all available transactions.
 		// Otherwise if we can't make enough room for new one, abort the operation.
-		drop, success := pool.priced.Discard(pool.all.Slots()-int(pool.config.GlobalSlots+pool.config.GlobalQueue)+numSlots(tx), isLocal)
+		drop, success := pool.priced.Discard(pool.all.Slots()-systemSlots-int(pool.config.GlobalSlots+pool.config.GlobalQueue)+numSlots(tx), isLocal)
 
 		// Special case, we still can't make the room for the new remote one.
 		if !isLocal && !success {
@@ -863,7 +957,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 	// Try to replace an existing transaction in the pending pool
 	if list := pool.pending[from]; list != nil && list.Contains(tx.Nonce()) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump)
+		inserted, old := list.Add(tx, pool.priceBump())
 		if !inserted {
 			pendingDiscardMeter.Mark(1)
 			return false, txpool.ErrReplaceUnderpriced
@@ -872,10 +966,11 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		if old != nil {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
+			pool.trackReplacement(old, tx)
 			pendingReplaceMeter.Mark(1)
 		}
-		pool.all.Add(tx, isLocal)
-		pool.priced.Put(tx, isLocal)
+		pool.all.Add(tx, isLocal || isSystem)
+		pool.priced.Put(tx, isLocal || isSystem)
 		pool.journalTx(from, tx)
 		pool.queueTxEvent(tx)
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
@@ -885,7 +980,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		return old != nil, nil
 	}
 	// New transaction isn't replacing a pending one, push into queue
-	replaced, err = pool.enqueueTx(hash, tx, isLocal, true)
+	replaced, err = pool.enqueueTx(hash, tx, isLocal || isSystem, true)
 	if err != nil {
 		return false, err
 	}
@@ -904,6 +999,21 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 	return replaced, nil
 }
 
+// systemSlots returns the number of slots used by the transactions of the system lane.
+func (pool *LegacyPool) systemSlots() int {
+	slots := 0
+	for addr := range pool.system.accounts {
+		for _, lists := range []map[common.Address]*list{pool.pending, pool.queue} {
+			if list := lists[addr]; list != nil {
+				for _, tx := range list.txs.items {
+					slots += numSlots(tx)
+				}
+			}
+		}
+	}
+	return slots
+}
+
 // isGapped reports whether the given transaction is immediately executable.
 func (pool *LegacyPool) isGapped(from common.Address, tx *types.Transaction) bool {
 	// Short circuit if transaction falls within the scope of the pending list
@@ -937,7 +1047,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	if pool.queue[from] == nil {
 		pool.queue[from] = newList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump)
+	inserted, old := pool.queue[from].Add(tx, pool.priceBump())
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardMeter.Mark(1)
@@ -947,6 +1057,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
+		pool.trackReplacement(old, tx)
 		queuedReplaceMeter.Mark(1)
 	} else {
 		// Nothing was replaced, bump the queued counter
@@ -968,11 +1079,50 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	return old != nil, nil
 }
 
+// priceBump returns the minimum price bump percentage to replace a transaction.
+func (pool *LegacyPool) priceBump() uint64 {
+	if bump := pool.chainconfig.GetTxReplacementPolicy().PriceBump; bump != 0 {
+		return bump
+	}
+	return pool.config.PriceBump
+}
+
+// checkReplacementLimit returns ErrReplacementLimit if [tx] would replace a
+// transaction whose nonce was already replaced as many times as the chain allows.
+func (pool *LegacyPool) checkReplacementLimit(from common.Address, tx *types.Transaction) error {
+	limit := pool.chainconfig.GetTxReplacementPolicy().MaxReplacements
+	if limit == 0 {
+		return nil
+	}
+	var old *types.Transaction
+	if list := pool.pending[from]; list != nil {
+		old = list.txs.Get(tx.Nonce())
+	}
+	if list := pool.queue[from]; old == nil && list != nil {
+		old = list.txs.Get(tx.Nonce())
+	}
+	if old != nil && pool.replacements[old.Hash()] >= limit {
+		return ErrReplacementLimit
+	}
+	return nil
+}
+
+// trackReplacement carries the number of replacements of [old] over to [tx],
+// which replaced it, if the chain limits the number of replacements.
+func (pool *LegacyPool) trackReplacement(old, tx *types.Transaction) {
+	if pool.chainconfig.GetTxReplacementPolicy().MaxReplacements == 0 {
+		return
+	}
+	pool.replacements[tx.Hash()] = pool.replacements[old.Hash()] + 1
+	delete(pool.replacements, old.Hash())
+}
+
 // journalTx adds the specified transaction to the local disk journal if it is
 // deemed to have been sent from a local account.
 func (pool *LegacyPool) journalTx(from common.Address, tx *types.Transaction) {
-	// Only journal if it's enabled and the transaction is local
-	if pool.journal == nil || !pool.locals.contains(from) {
+	// Only journal if it's enabled and the transaction is local. Conditional
+	// transactions are not journaled, as their conditions would be lost on restart.
+	if pool.journal == nil || !pool.locals.contains(from) || tx.Conditional() != nil {
 		return
 	}
 	if err := pool.journal.insert(tx); err != nil {
@@ -991,7 +1141,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump)
+	inserted, old := list.Add(tx, pool.priceBump())
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
@@ -1416,6 +1566,13 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	pool.truncatePending()
 	pool.truncateQueue()
 
+	// Forget the replacements of the transactions that left the pool
+	for hash := range pool.replacements {
+		if pool.all.Get(hash) == nil {
+			delete(pool.replacements, hash)
+		}
+	}
+
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
 	pool.mu.Unlock()
@@ -1526,15 +1683,21 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 		log.Error("Failed to reset txpool state", "err", err, "root", newHead.Root)
 		return
 	}
+	config, err := governance.ChainConfigAt(pool.chainconfig, statedb, newHead.Time)
+	if err != nil {
+		log.Error("Failed to read scheduled upgrades", "err", err, "root", newHead.Root)
+		return
+	}
 	pool.currentHead.Store(newHead)
 	pool.currentStateLock.Lock()
 	pool.currentState = statedb
+	pool.currentConfig = config
 	pool.currentStateLock.Unlock()
 	pool.pendingNonces = newNoncer(statedb)
 
 	// when we reset txPool we should explicitly check if fee struct for min base fee has changed
 	// so that we can correctly drop txs with < minBaseFee from tx pool.
-	if pool.chainconfig.IsPrecompileEnabled(feemanager.ContractAddress, newHead.Time) {
+	if config.IsPrecompileEnabled(feemanager.ContractAddress, newHead.Time) {
 		feeConfig, _, err := pool.chain.GetFeeConfigAt(newHead)
 		if err != nil {
 			log.Error("Failed to get fee config state", "err", err, "root", newHead.Root)
@@ -1595,7 +1758,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 
 		// Drop all transactions over the allowed limit
 		var caps types.Transactions
-		if !pool.locals.contains(addr) {
+		if !pool.locals.contains(addr) && !pool.system.contains(addr) {
 			caps = list.Cap(int(pool.config.AccountQueue))
 			for _, tx := range caps {
 				hash := tx.Hash()
@@ -1627,7 +1790,11 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 // equal number for all for accounts with many pending transactions.
 func (pool *LegacyPool) truncatePending() {
 	pending := uint64(0)
-	for _, list := range pool.pending {
+	for addr, list := range pool.pending {
+		// The system lane is not subject to the limits of the public lane
+		if pool.system.contains(addr) {
+			continue
+		}
 		pending += uint64(list.Len())
 	}
 	if pending <= pool.config.GlobalSlots {
@@ -1639,7 +1806,7 @@ func (pool *LegacyPool) truncatePending() {
 	spammers := prque.New[int64, common.Address](nil)
 	for addr, list := range pool.pending {
 		// Only evict transactions from high rollers
-		if !pool.locals.contains(addr) && uint64(list.Len()) > pool.config.AccountSlots {
+		if !pool.locals.contains(addr) && !pool.system.contains(addr) && uint64(list.Len()) > pool.config.AccountSlots {
 			spammers.Push(addr, int64(list.Len()))
 		}
 	}
@@ -1712,7 +1879,11 @@ func (pool *LegacyPool) truncatePending() {
 // truncateQueue drops the oldest transactions in the queue if the pool is above the global queue limit.
 func (pool *LegacyPool) truncateQueue() {
 	queued := uint64(0)
-	for _, list := range pool.queue {
+	for addr, list := range pool.queue {
+		// The system lane is not subject to the limits of the public lane
+		if pool.system.contains(addr) {
+			continue
+		}
 		queued += uint64(list.Len())
 	}
 	if queued <= pool.config.GlobalQueue {
@@ -1722,7 +1893,7 @@ func (pool *LegacyPool) truncateQueue() {
 	// Sort all accounts with queued transactions by heartbeat
 	addresses := make(addressesByHeartbeat, 0, len(pool.queue))
 	for addr := range pool.queue {
-		if !pool.locals.contains(addr) { // don't drop locals
+		if !pool.locals.contains(addr) && !pool.system.contains(addr) { // don't drop locals nor the system lane
 			addresses = append(addresses, addressByHeartbeat{addr, pool.beats[addr]})
 		}
 	}
@@ -46,6 +46,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
@@ -2670,3 +2671,313 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// Tests that the transactions of the system lane are accepted while the public
+// lane is full, and are limited independently of it.
+func TestSystemLaneLimiting(t *testing.T) {
+	t.Parallel()
+
+	systemKey, _ := crypto.GenerateKey()
+	systemAddr := crypto.PubkeyToAddress(systemKey.PublicKey)
+
+	chainConfig := *params.TestChainConfig
+	chainConfig.SystemTxLane = &params.SystemTxLane{
+		Senders:     []common.Address{systemAddr},
+		ReservedGas: 1_000_000,
+		PoolSlots:   2,
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(&chainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.NoLocals = true
+	config.GlobalSlots = 4
+	config.GlobalQueue = 1
+
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	publicKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(publicKey.PublicKey), big.NewInt(1000000000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(otherKey.PublicKey), big.NewInt(1000000000000))
+	testAddBalance(pool, systemAddr, big.NewInt(1000000000000))
+
+	// Fill the public lane
+	for i := uint64(0); i < config.GlobalSlots+config.GlobalQueue; i++ {
+		if err := pool.addRemoteSync(transaction(i, 100000, publicKey)); err != nil {
+			t.Fatalf("tx %d: failed to add public transaction: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(transaction(0, 100000, otherKey)); !errors.Is(err, txpool.ErrUnderpriced) {
+		t.Fatalf("adding public transaction to full public lane: have %v, want %v", err, txpool.ErrUnderpriced)
+	}
+	// The system lane is still available
+	for i := uint64(0); i < chainConfig.SystemTxLane.PoolSlots; i++ {
+		if err := pool.addRemoteSync(transaction(i, 100000, systemKey)); err != nil {
+			t.Fatalf("tx %d: failed to add system transaction: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(transaction(chainConfig.SystemTxLane.PoolSlots, 100000, systemKey)); !errors.Is(err, ErrSystemLaneOverflow) {
+		t.Fatalf("adding system transaction to full system lane: have %v, want %v", err, ErrSystemLaneOverflow)
+	}
+	// Replacing a pending transaction of the full system lane is allowed
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(2), systemKey)); err != nil {
+		t.Fatalf("failed to replace system transaction: %v", err)
+	}
+	// Neither lane evicted the other
+	pending, queued := pool.Stats()
+	if want := int(config.GlobalSlots+config.GlobalQueue) + int(chainConfig.SystemTxLane.PoolSlots); pending != want || queued != 0 {
+		t.Fatalf("pool size mismatch: have %d pending and %d queued, want %d pending", pending, queued, want)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the transactions of priority senders are accepted while the pool
+// is full, and are not subject to the per-account limits.
+func TestPrioritySendersLimiting(t *testing.T) {
+	t.Parallel()
+
+	priorityKey, _ := crypto.GenerateKey()
+	priorityAddr := crypto.PubkeyToAddress(priorityKey.PublicKey)
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.NoLocals = true
+	config.PrioritySenders = []common.Address{priorityAddr}
+	config.AccountSlots = 2
+	config.GlobalSlots = 4
+	config.GlobalQueue = 1
+
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	if senders := pool.PrioritySenders(); len(senders) != 1 || senders[0] != priorityAddr {
+		t.Fatalf("priority senders mismatch: have %v, want %v", senders, []common.Address{priorityAddr})
+	}
+	publicKey, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(publicKey.PublicKey), big.NewInt(1000000000000))
+	testAddBalance(pool, priorityAddr, big.NewInt(1000000000000))
+
+	// Fill the pool
+	for i := uint64(0); i < config.GlobalSlots+config.GlobalQueue; i++ {
+		if err := pool.addRemoteSync(transaction(i, 100000, publicKey)); err != nil {
+			t.Fatalf("tx %d: failed to add public transaction: %v", i, err)
+		}
+	}
+	// The priority sender evicts the public transactions, beyond its account slots
+	count := config.GlobalSlots + config.GlobalQueue
+	for i := uint64(0); i < count; i++ {
+		if err := pool.addRemoteSync(transaction(i, 100000, priorityKey)); err != nil {
+			t.Fatalf("tx %d: failed to add priority transaction: %v", i, err)
+		}
+	}
+	pending, queued := pool.Stats()
+	if pending != int(count) || queued != 0 {
+		t.Fatalf("pool size mismatch: have %d pending and %d queued, want %d pending", pending, queued, count)
+	}
+	if list := pool.pending[priorityAddr]; list == nil || list.Len() != int(count) {
+		t.Fatalf("priority sender transactions were evicted")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the replacement policy of the chain overrides the price bump of the
+// pool and limits the number of replacements of a nonce.
+func TestTxReplacementPolicy(t *testing.T) {
+	t.Parallel()
+
+	chainConfig := *params.TestChainConfig
+	chainConfig.TxReplacementPolicy = &params.TxReplacementPolicy{PriceBump: 50, MaxReplacements: 2}
+	pool, key := setupPoolWithConfig(&chainConfig)
+	defer pool.Close()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000000))
+
+	for _, nonce := range []uint64{0, 2} { // pending and queued
+		if err := pool.addRemoteSync(pricedTransaction(nonce, 100000, big.NewInt(100), key)); err != nil {
+			t.Fatalf("nonce %d: failed to add original transaction: %v", nonce, err)
+		}
+		if err := pool.addRemoteSync(pricedTransaction(nonce, 100000, big.NewInt(149), key)); !errors.Is(err, txpool.ErrReplaceUnderpriced) {
+			t.Fatalf("nonce %d: replacing below the price bump: have %v, want %v", nonce, err, txpool.ErrReplaceUnderpriced)
+		}
+		for _, price := range []int64{150, 225} {
+			if err := pool.addRemoteSync(pricedTransaction(nonce, 100000, big.NewInt(price), key)); err != nil {
+				t.Fatalf("nonce %d: failed to replace transaction with price %d: %v", nonce, price, err)
+			}
+		}
+		if err := pool.addRemoteSync(pricedTransaction(nonce, 100000, big.NewInt(1000), key)); !errors.Is(err, ErrReplacementLimit) {
+			t.Fatalf("nonce %d: replacing over the limit: have %v, want %v", nonce, err, ErrReplacementLimit)
+		}
+	}
+	pending, queued := pool.Stats()
+	if pending != 1 || queued != 1 {
+		t.Fatalf("pool size mismatch: have %d pending and %d queued, want 1 pending and 1 queued", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that local transactions can be cancelled, demoting the later
+// transactions of their sender, while remote transactions cannot.
+func TestCancel(t *testing.T) {
+	t.Parallel()
+
+	pool, local := setupPool()
+	defer pool.Close()
+	remote, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(local.PublicKey), big.NewInt(1000000000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(remote.PublicKey), big.NewInt(1000000000000))
+
+	txs := make([]*types.Transaction, 3)
+	for i := range txs {
+		txs[i] = transaction(uint64(i), 100000, local)
+		if err := pool.addLocal(txs[i]); err != nil {
+			t.Fatalf("tx %d: failed to add local transaction: %v", i, err)
+		}
+	}
+	remoteTx := transaction(0, 100000, remote)
+	if err := pool.addRemoteSync(remoteTx); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+
+	if err := pool.Cancel(remoteTx.Hash()); !errors.Is(err, txpool.ErrNotLocal) {
+		t.Fatalf("cancelling remote transaction: have %v, want %v", err, txpool.ErrNotLocal)
+	}
+	if err := pool.Cancel(common.Hash{1}); !errors.Is(err, txpool.ErrTxNotFound) {
+		t.Fatalf("cancelling unknown transaction: have %v, want %v", err, txpool.ErrTxNotFound)
+	}
+	if err := pool.Cancel(txs[0].Hash()); err != nil {
+		t.Fatalf("failed to cancel local transaction: %v", err)
+	}
+	if pool.Has(txs[0].Hash()) {
+		t.Fatalf("cancelled transaction still in the pool")
+	}
+	pending, queued := pool.Stats()
+	if pending != 1 || queued != 2 {
+		t.Fatalf("pool size mismatch: have %d pending and %d queued, want 1 pending and 2 queued", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that only the value of transactions sponsored by the paymaster of the
+// chain is charged to their sender.
+func TestFeeSponsoredTransactions(t *testing.T) {
+	t.Parallel()
+
+	paymaster := common.HexToAddress("0x00000000000000000000000000000000000000dd")
+	chainConfig := *params.TestChainConfig
+	chainConfig.FeeSponsorship = &params.FeeSponsorship{BlockTimestamp: utils.NewUint64(0), Paymaster: paymaster}
+	pool, key := setupPoolWithConfig(&chainConfig)
+	defer pool.Close()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(100))
+
+	// The paymaster does not sponsor transactions without code.
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), key)); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("unsponsored transaction: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+
+	// The paymaster returning true sponsors every transaction.
+	pool.mu.Lock()
+	pool.currentState.SetCode(paymaster, common.FromHex("600160005260206000f3"))
+	pool.mu.Unlock()
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx := pricedTransaction(nonce, 100000, big.NewInt(1), key)
+		err := pool.addRemoteSync(tx)
+		if nonce == 0 && err != nil {
+			t.Fatalf("failed to add sponsored transaction: %v", err)
+		}
+		// The sender cannot afford the value of a second transaction.
+		if nonce == 1 && !errors.Is(err, core.ErrInsufficientFunds) {
+			t.Fatalf("second sponsored transaction: have %v, want %v", err, core.ErrInsufficientFunds)
+		}
+		if !tx.FeeSponsored() {
+			t.Fatalf("transaction %d not marked as sponsored", nonce)
+		}
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Fatalf("pending transactions mismatch: have %d, want 1", pending)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the sponsorship of transactions is checked at the time of the
+// pending block, so that it applies in the block activating it.
+func TestFeeSponsoredTransactionsActivation(t *testing.T) {
+	t.Parallel()
+
+	paymaster := common.HexToAddress("0x00000000000000000000000000000000000000dd")
+	chainConfig := *params.TestChainConfig
+	// The sponsorship activates after the head of the chain, at time 0.
+	chainConfig.FeeSponsorship = &params.FeeSponsorship{BlockTimestamp: utils.NewUint64(1), Paymaster: paymaster}
+	pool, key := setupPoolWithConfig(&chainConfig)
+	defer pool.Close()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(100))
+
+	pool.mu.Lock()
+	pool.currentState.SetCode(paymaster, common.FromHex("600160005260206000f3"))
+	pool.mu.Unlock()
+	tx := pricedTransaction(0, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add sponsored transaction: %v", err)
+	}
+	if !tx.FeeSponsored() {
+		t.Fatal("transaction not marked as sponsored")
+	}
+}
+
+// Tests that the fee discount of the sender of a transaction, at the minimum
+// base fee, is deducted from the cost charged to it.
+func TestFeeDiscountedTransactions(t *testing.T) {
+	t.Parallel()
+
+	chainConfig := *params.TestChainConfig
+	chainConfig.GenesisPrecompiles = params.Precompiles{
+		feemanager.ConfigKey: feemanager.NewConfig(utils.NewUint64(0), nil, nil, nil, nil),
+	}
+	pool, key := setupPoolWithConfig(&chainConfig)
+	defer pool.Close()
+	pool.SetMinFee(big.NewInt(10))
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	// Enough for the value and half the gas of a transaction.
+	testAddBalance(pool, from, big.NewInt(100+100000*5))
+
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(10), key)); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("undiscounted transaction: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+
+	pool.mu.Lock()
+	feemanager.SetFeeDiscount(pool.currentState, from, 50)
+	pool.mu.Unlock()
+	tx := pricedTransaction(0, 100000, big.NewInt(10), key)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add discounted transaction: %v", err)
+	}
+	if discount := tx.FeeDiscount(); discount.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("fee discount mismatch: have %v, want %v", discount, 5)
+	}
+	// The sender cannot afford a second transaction.
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(10), key)); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("second discounted transaction: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Fatalf("pending transactions mismatch: have %d, want 1", pending)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
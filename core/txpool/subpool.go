@@ -147,6 +147,13 @@ type SubPool interface {
 	// Locals retrieves the accounts currently considered local by the pool.
 	Locals() []common.Address
 
+	// PrioritySenders retrieves the senders whose transactions are included
+	// first in blocks.
+	PrioritySenders() []common.Address
+
+	// Cancel removes a local transaction from the pool.
+	Cancel(hash common.Hash) error
+
 	// Status returns the known status (unknown/pending/queued) of a transaction
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
@@ -75,6 +75,14 @@ type BlockChain interface {
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
 }
 
+// SenderFilter returns a non-nil error if the transactions of [sender] must not be
+// accepted into the pool nor retrieved as pending transactions.
+type SenderFilter func(sender common.Address) error
+
+// TxFilter returns a non-nil error if [tx] must not be accepted into the pool.
+// It is called without holding any lock of the pool, so it may be expensive.
+type TxFilter func(tx *types.Transaction) error
+
 // TxPool is an aggregator for various transaction specific pools, collectively
 // tracking all the transactions deemed interesting by the node. Transactions
 // enter the pool when they are received from the network or submitted locally.
@@ -89,8 +97,10 @@ type TxPool struct {
 	subs event.SubscriptionScope // Subscription scope to unscubscribe all on shutdown
 	quit chan chan error         // Quit channel to tear down the head updater
 
-	gasTip    atomic.Pointer[big.Int] // Remember last value set so it can be retrieved
-	reorgFeed event.Feed
+	gasTip       atomic.Pointer[big.Int]      // Remember last value set so it can be retrieved
+	senderFilter atomic.Pointer[SenderFilter] // Filter applied to the senders of added and pending transactions
+	txFilter     atomic.Pointer[TxFilter]     // Filter applied to the added transactions
+	reorgFeed    event.Feed
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -321,10 +331,22 @@ func (p *TxPool) Add(txs []*Transaction, local bool, sync bool) []error {
 	txsets := make([][]*Transaction, len(p.subpools))
 	splits := make([]int, len(txs))
 
+	filtered := make([]error, len(txs))
+
 	for i, tx := range txs {
 		// Mark this transaction belonging to no-subpool
 		splits[i] = -1
 
+		// Reject the transactions of the senders rejected by the sender filter,
+		// and the transactions rejected by the tx filter
+		if err := p.filterTx(tx.Tx); err != nil {
+			filtered[i] = err
+			continue
+		}
+		if err := p.applyTxFilter(tx.Tx); err != nil {
+			filtered[i] = err
+			continue
+		}
 		// Try to find a subpool that accepts the transaction
 		for j, subpool := range p.subpools {
 			if subpool.Filter(tx.Tx) {
@@ -342,6 +364,11 @@ func (p *TxPool) Add(txs []*Transaction, local bool, sync bool) []error {
 	}
 	errs := make([]error, len(txs))
 	for i, split := range splits {
+		// If the transaction was rejected by the filter, report why
+		if filtered[i] != nil {
+			errs[i] = filtered[i]
+			continue
+		}
 		// If the transaction was rejected by all subpools, mark it unsupported
 		if split == -1 {
 			errs[i] = core.ErrTxTypeNotSupported
@@ -354,6 +381,74 @@ func (p *TxPool) Add(txs []*Transaction, local bool, sync bool) []error {
 	return errs
 }
 
+// SetSenderFilter sets the filter applied to the senders of the transactions added
+// to the pool and of the pending transactions. Transactions already in the pool are
+// not evicted, but they are not retrieved as pending while their sender is rejected.
+// A nil [filter] removes the filter.
+func (p *TxPool) SetSenderFilter(filter SenderFilter) {
+	if filter == nil {
+		p.senderFilter.Store(nil)
+		return
+	}
+	p.senderFilter.Store(&filter)
+}
+
+// SetTxFilter sets the filter applied to the transactions added to the pool.
+// Transactions already in the pool are not affected. A nil [filter] removes the
+// filter.
+func (p *TxPool) SetTxFilter(filter TxFilter) {
+	if filter == nil {
+		p.txFilter.Store(nil)
+		return
+	}
+	p.txFilter.Store(&filter)
+}
+
+// filterSender returns the error of the sender filter for [sender], if any.
+func (p *TxPool) filterSender(sender common.Address) error {
+	filter := p.senderFilter.Load()
+	if filter == nil {
+		return nil
+	}
+	return (*filter)(sender)
+}
+
+// filterTx returns the error of the sender filter for the sender of [tx], if any.
+func (p *TxPool) filterTx(tx *types.Transaction) error {
+	if p.senderFilter.Load() == nil {
+		return nil
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSender, err)
+	}
+	return p.filterSender(sender)
+}
+
+// applyTxFilter returns the error of the tx filter for [tx], if any. Known
+// transactions are not filtered again, since the subpools reject them anyway.
+func (p *TxPool) applyTxFilter(tx *types.Transaction) error {
+	filter := p.txFilter.Load()
+	if filter == nil || p.Has(tx.Hash()) {
+		return nil
+	}
+	return (*filter)(tx)
+}
+
+// filterPending removes the transactions of the senders rejected by the sender
+// filter from [pending].
+func (p *TxPool) filterPending(pending map[common.Address][]*LazyTransaction) map[common.Address][]*LazyTransaction {
+	if p.senderFilter.Load() == nil {
+		return pending
+	}
+	for addr := range pending {
+		if p.filterSender(addr) != nil {
+			delete(pending, addr)
+		}
+	}
+	return pending
+}
+
 func (p *TxPool) AddRemotesSync(txs []*types.Transaction) []error {
 	wrapped := make([]*Transaction, len(txs))
 	for i, tx := range txs {
@@ -382,7 +477,7 @@ func (p *TxPool) PendingWithBaseFee(enforceTips bool, baseFee *big.Int) map[comm
 			txs[addr] = set
 		}
 	}
-	return txs
+	return p.filterPending(txs)
 }
 
 // PendingSize returns the number of pending txs in the tx pool.
@@ -393,7 +488,7 @@ func (p *TxPool) PendingWithBaseFee(enforceTips bool, baseFee *big.Int) map[comm
 func (p *TxPool) PendingSize(enforceTips bool) int {
 	count := 0
 	for _, subpool := range p.subpools {
-		for _, txs := range subpool.Pending(enforceTips) {
+		for _, txs := range p.filterPending(subpool.Pending(enforceTips)) {
 			count += len(txs)
 		}
 	}
@@ -409,7 +504,7 @@ func (p *TxPool) PendingFrom(addrs []common.Address, enforceTips bool) map[commo
 			txs[addr] = set
 		}
 	}
-	return txs
+	return p.filterPending(txs)
 }
 
 // IteratePending iterates over [pool.pending] until [f] returns false.
@@ -519,6 +614,33 @@ func (p *TxPool) Locals() []common.Address {
 	return flat
 }
 
+// PrioritySenders retrieves the senders whose transactions are included first
+// in blocks.
+func (p *TxPool) PrioritySenders() []common.Address {
+	// Retrieve the priority senders from each subpool and deduplicate them
+	senders := make(map[common.Address]struct{})
+	for _, subpool := range p.subpools {
+		for _, sender := range subpool.PrioritySenders() {
+			senders[sender] = struct{}{}
+		}
+	}
+	flat := make([]common.Address, 0, len(senders))
+	for sender := range senders {
+		flat = append(flat, sender)
+	}
+	return flat
+}
+
+// Cancel removes the local transaction [hash] from the subpool tracking it.
+func (p *TxPool) Cancel(hash common.Hash) error {
+	for _, subpool := range p.subpools {
+		if subpool.Has(hash) {
+			return subpool.Cancel(hash)
+		}
+	}
+	return ErrTxNotFound
+}
+
 // Status returns the known status (unknown/pending/queued) of a transaction
 // identified by their hashes.
 func (p *TxPool) Status(hash common.Hash) TxStatus {
@@ -38,6 +38,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -78,9 +79,12 @@ func ValidateTransaction(tx *types.Transaction, blobs []kzg4844.Blob, commits []
 	if !opts.Config.IsCancun(head.Number, head.Time) && tx.Type() == types.BlobTxType {
 		return fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type())
 	}
+	if !opts.Config.IsVesuvius(head.Time) && tx.Type() == types.SetCodeTxType {
+		return fmt.Errorf("%w: type %d rejected, pool not yet in Vesuvius", core.ErrTxTypeNotSupported, tx.Type())
+	}
 	// Check whether the init code size has been exceeded
-	if opts.Config.IsDurango(head.Time) && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
-		return fmt.Errorf("%w: code size %v, limit %v", vmerrs.ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
+	if maxInitCodeSize := opts.Config.GetMaxInitCodeSize(head.Time); opts.Config.IsDurango(head.Time) && tx.To() == nil && len(tx.Data()) > maxInitCodeSize {
+		return fmt.Errorf("%w: code size %v, limit %v", vmerrs.ErrMaxInitCodeSizeExceeded, len(tx.Data()), maxInitCodeSize)
 	}
 	// Transactions can't be negative. This may never happen using RLP decoded
 	// transactions but may occur for transactions created using the RPC.
@@ -107,6 +111,10 @@ func ValidateTransaction(tx *types.Transaction, blobs []kzg4844.Blob, commits []
 	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
 		return core.ErrTipAboveFeeCap
 	}
+	// Ensure set code transactions carry at least one authorization
+	if tx.Type() == types.SetCodeTxType && len(tx.SetCodeAuthorizations()) == 0 {
+		return core.ErrEmptyAuthList
+	}
 	// Make sure the transaction is signed properly
 	from, err := types.Sender(signer, tx)
 	if err != nil {
@@ -114,7 +122,7 @@ func ValidateTransaction(tx *types.Transaction, blobs []kzg4844.Blob, commits []
 	}
 	// Ensure the transaction has more gas than the bare minimum needed to cover
 	// the transaction metadata
-	intrGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, opts.Config.Rules(head.Number, head.Time))
+	intrGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, opts.Config.Rules(head.Number, head.Time))
 	if err != nil {
 		return err
 	}
@@ -173,6 +181,21 @@ func ValidateTransaction(tx *types.Transaction, blobs []kzg4844.Blob, commits []
 	return nil
 }
 
+// SenderCost returns the cost of [tx] paid by its sender: only its value if its
+// gas is sponsored by the paymaster of the chain, or its full cost minus its fee
+// discount otherwise.
+func SenderCost(tx *types.Transaction) *big.Int {
+	if tx.FeeSponsored() {
+		return new(big.Int).Set(tx.Value())
+	}
+	cost := tx.Cost()
+	if discount := tx.FeeDiscount(); discount.Sign() > 0 {
+		discount = math.BigMin(discount, tx.GasFeeCap())
+		cost.Sub(cost, discount.Mul(discount, new(big.Int).SetUint64(tx.Gas())))
+	}
+	return cost
+}
+
 // ValidationOptionsWithState define certain differences between stateful transaction
 // validation across the different pools without having to duplicate those checks.
 type ValidationOptionsWithState struct {
@@ -233,7 +256,7 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	// Ensure the transactor has enough funds to cover the transaction costs
 	var (
 		balance = opts.State.GetBalance(from)
-		cost    = tx.Cost()
+		cost    = SenderCost(tx)
 	)
 	if balance.Cmp(cost) < 0 {
 		return fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
@@ -266,6 +289,9 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 		if !txAllowListRole.IsEnabled() {
 			return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, from)
 		}
+		if to := tx.To(); to != nil && opts.State.GetCodeSize(*to) > 0 && !txallowlist.CanCallTarget(opts.State, txAllowListRole, *to) {
+			return fmt.Errorf("%w: %s", vmerrs.ErrTargetAddressNotAllowListed, *to)
+		}
 	}
 
 	return nil
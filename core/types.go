@@ -42,6 +42,10 @@ type Validator interface {
 	// ValidateState validates the given statedb and optionally the receipts and
 	// gas used.
 	ValidateState(block *types.Block, state *state.StateDB, receipts types.Receipts, usedGas uint64) error
+
+	// ValidateReceipts validates the receipts and gas used of the given block,
+	// without validating its state root.
+	ValidateReceipts(block *types.Block, receipts types.Receipts, usedGas uint64) error
 }
 
 // Processor is an interface for processing blocks using a given initial state.
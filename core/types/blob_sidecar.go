@@ -0,0 +1,107 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errNotBlobTx          = errors.New("not a blob transaction")
+	errBlobSidecarMissing = errors.New("blob transaction sidecar missing")
+)
+
+// BlobTxSidecar contains the blobs of a blob transaction along with their
+// commitments and proofs. Sidecars are never part of the block body: blocks
+// only commit to the versioned hashes of the blobs in their transactions.
+type BlobTxSidecar struct {
+	Blobs       []kzg4844.Blob       // The opaque blobs of the transaction
+	Commitments []kzg4844.Commitment // The commitments for the blobs
+	Proofs      []kzg4844.Proof      // The proofs verifying the commitments
+}
+
+// BlobHashes computes the versioned hashes of the commitments in the sidecar.
+func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
+	hasher := sha256.New()
+	hashes := make([]common.Hash, len(sc.Commitments))
+	for i := range sc.Commitments {
+		hasher.Write(sc.Commitments[i][:])
+		hash := hasher.Sum(nil)
+		hasher.Reset()
+
+		hashes[i][0] = params.BlobTxHashVersion
+		copy(hashes[i][1:], hash[1:])
+	}
+	return hashes
+}
+
+// blobTxWithSidecar is the payload of the network encoding of a blob transaction,
+// as specified by EIP-4844: rlp([tx_payload_body, blobs, commitments, proofs]).
+type blobTxWithSidecar struct {
+	BlobTx      *BlobTx
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+// EncodeBlobTxWithSidecar returns the network encoding of the blob transaction
+// [tx] along with its [sidecar].
+func EncodeBlobTxWithSidecar(tx *Transaction, sidecar *BlobTxSidecar) ([]byte, error) {
+	inner, ok := tx.inner.(*BlobTx)
+	if !ok {
+		return nil, errNotBlobTx
+	}
+	if sidecar == nil {
+		return nil, errBlobSidecarMissing
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(BlobTxType)
+	err := rlp.Encode(&buf, &blobTxWithSidecar{
+		BlobTx:      inner,
+		Blobs:       sidecar.Blobs,
+		Commitments: sidecar.Commitments,
+		Proofs:      sidecar.Proofs,
+	})
+	return buf.Bytes(), err
+}
+
+// DecodeBlobTxWithSidecar decodes the network encoding of a blob transaction,
+// returning the transaction and its sidecar. The sidecar is not validated
+// against the blob hashes of the transaction.
+func DecodeBlobTxWithSidecar(b []byte) (*Transaction, *BlobTxSidecar, error) {
+	if len(b) <= 1 {
+		return nil, nil, errShortTypedTx
+	}
+	if b[0] != BlobTxType {
+		return nil, nil, errNotBlobTx
+	}
+	var payload blobTxWithSidecar
+	if err := rlp.DecodeBytes(b[1:], &payload); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode blob transaction with sidecar: %w", err)
+	}
+	if payload.BlobTx == nil {
+		return nil, nil, errNotBlobTx
+	}
+	// The size of the transaction excludes the sidecar, which is never part of
+	// the block body.
+	enc, err := rlp.EncodeToBytes(payload.BlobTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx := new(Transaction)
+	tx.setDecoded(payload.BlobTx, uint64(1+len(enc)))
+	return tx, &BlobTxSidecar{
+		Blobs:       payload.Blobs,
+		Commitments: payload.Commitments,
+		Proofs:      payload.Proofs,
+	}, nil
+}
@@ -0,0 +1,72 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobTxWithSidecarEncoding(t *testing.T) {
+	require := require.New(t)
+
+	blob := kzg4844.Blob{0x01}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	require.NoError(err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	require.NoError(err)
+	sidecar := &BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{blob},
+		Commitments: []kzg4844.Commitment{commitment},
+		Proofs:      []kzg4844.Proof{proof},
+	}
+	hashes := sidecar.BlobHashes()
+	require.Len(hashes, 1)
+	require.Equal(byte(params.BlobTxHashVersion), hashes[0][0])
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	signer := NewCancunSigner(common.Big1)
+	tx, err := SignNewTx(key, signer, &BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Nonce:      1,
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(2),
+		Gas:        params.TxGas,
+		To:         common.Address{1},
+		Value:      uint256.NewInt(3),
+		BlobFeeCap: uint256.NewInt(4),
+		BlobHashes: hashes,
+	})
+	require.NoError(err)
+
+	enc, err := EncodeBlobTxWithSidecar(tx, sidecar)
+	require.NoError(err)
+	decodedTx, decodedSidecar, err := DecodeBlobTxWithSidecar(enc)
+	require.NoError(err)
+	require.Equal(tx.Hash(), decodedTx.Hash())
+	require.Equal(tx.Size(), decodedTx.Size())
+	require.Equal(sidecar, decodedSidecar)
+
+	sender, err := Sender(signer, decodedTx)
+	require.NoError(err)
+	require.Equal(crypto.PubkeyToAddress(key.PublicKey), sender)
+
+	// The canonical encoding does not carry the sidecar
+	canonical, err := tx.MarshalBinary()
+	require.NoError(err)
+	_, _, err = DecodeBlobTxWithSidecar(canonical)
+	require.Error(err)
+
+	_, err = EncodeBlobTxWithSidecar(NewTx(&LegacyTx{}), sidecar)
+	require.ErrorIs(err, errNotBlobTx)
+	_, err = EncodeBlobTxWithSidecar(tx, nil)
+	require.ErrorIs(err, errBlobSidecarMissing)
+}
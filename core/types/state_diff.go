@@ -0,0 +1,38 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateDiff is the set of state mutations performed by a single block.
+//
+// Account values are stored in 'slim RLP' encoding and storage values in
+// prefix-zero trimmed RLP encoding, matching the representation used by the
+// snapshot layer. An empty value means the account or slot did not exist.
+type StateDiff struct {
+	Accounts []AccountDiff
+}
+
+// AccountDiff describes how a single account was mutated by a block.
+type AccountDiff struct {
+	Address common.Address
+	Prev    []byte // Slim RLP of the account before the block, empty if it did not exist
+	Post    []byte // Slim RLP of the account after the block, empty if it was deleted
+
+	// Destructed is set if the account was self-destructed or cleared during
+	// the block. In this case the original storage was wiped and only the slots
+	// written afterwards are included in Storage.
+	Destructed bool
+	Storage    []StorageDiff
+}
+
+// StorageDiff describes how a single storage slot was mutated by a block.
+// Key is the hash of the storage slot, as used in the storage trie.
+type StorageDiff struct {
+	Key  common.Hash
+	Prev []byte
+	Post []byte
+}
@@ -55,6 +55,7 @@ const (
 	AccessListTxType = 0x01
 	DynamicFeeTxType = 0x02
 	BlobTxType       = 0x03
+	SetCodeTxType    = 0x04
 )
 
 // Transaction is an Ethereum transaction.
@@ -62,6 +63,10 @@ type Transaction struct {
 	inner TxData    // Consensus contents of a transaction
 	time  time.Time // Time first seen locally (spam avoidance)
 
+	conditional  *TransactionConditional // Conditions on the block including the transaction, not part of the transaction
+	feeSponsored atomic.Bool             // Whether the paymaster of the chain pays the gas, as checked by the transaction pool
+	feeDiscount  atomic.Pointer[big.Int] // Discount per unit of gas on the fee cap, as checked by the transaction pool
+
 	// caches
 	hash atomic.Value
 	size atomic.Value
@@ -209,6 +214,10 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 		var inner BlobTx
 		err := rlp.DecodeBytes(b[1:], &inner)
 		return &inner, err
+	case SetCodeTxType:
+		var inner SetCodeTx
+		err := rlp.DecodeBytes(b[1:], &inner)
+		return &inner, err
 	default:
 		return nil, ErrTxTypeNotSupported
 	}
@@ -307,6 +316,16 @@ func (tx *Transaction) BlobGasFeeCap() *big.Int { return tx.inner.blobGasFeeCap(
 // BlobHashes returns the hases of the blob commitments for blob transactions, nil otherwise.
 func (tx *Transaction) BlobHashes() []common.Hash { return tx.inner.blobHashes() }
 
+// SetCodeAuthorizations returns the authorizations list of the transaction for
+// set code transactions, nil otherwise.
+func (tx *Transaction) SetCodeAuthorizations() []SetCodeAuthorization {
+	setcodetx, ok := tx.inner.(*SetCodeTx)
+	if !ok {
+		return nil
+	}
+	return setcodetx.AuthList
+}
+
 // Value returns the ether amount of the transaction.
 func (tx *Transaction) Value() *big.Int { return new(big.Int).Set(tx.inner.value()) }
 
@@ -416,6 +435,45 @@ func (tx *Transaction) Time() time.Time {
 	return tx.time
 }
 
+// SetConditional sets the conditions on the block including the transaction.
+// It must be set before the transaction is added to the transaction pool.
+func (tx *Transaction) SetConditional(conditional *TransactionConditional) {
+	tx.conditional = conditional
+}
+
+// Conditional returns the conditions on the block including the transaction,
+// or nil if it has none.
+func (tx *Transaction) Conditional() *TransactionConditional {
+	return tx.conditional
+}
+
+// SetFeeSponsored sets whether the paymaster of the chain pays the gas of the
+// transaction, as checked by the transaction pool when adding it.
+func (tx *Transaction) SetFeeSponsored(sponsored bool) {
+	tx.feeSponsored.Store(sponsored)
+}
+
+// FeeSponsored returns whether the paymaster of the chain pays the gas of the
+// transaction, as checked by the transaction pool when adding it.
+func (tx *Transaction) FeeSponsored() bool {
+	return tx.feeSponsored.Load()
+}
+
+// SetFeeDiscount sets the discount per unit of gas granted on the fee cap of the
+// transaction, as checked by the transaction pool when adding it.
+func (tx *Transaction) SetFeeDiscount(discount *big.Int) {
+	tx.feeDiscount.Store(discount)
+}
+
+// FeeDiscount returns the discount per unit of gas granted on the fee cap of the
+// transaction, as checked by the transaction pool when adding it.
+func (tx *Transaction) FeeDiscount() *big.Int {
+	if discount := tx.feeDiscount.Load(); discount != nil {
+		return new(big.Int).Set(discount)
+	}
+	return new(big.Int)
+}
+
 // Hash returns the transaction hash.
 func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
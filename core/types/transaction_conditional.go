@@ -0,0 +1,124 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// ErrConditionalNotReached is returned if the block is before the range of
+	// blocks a conditional transaction may be included in.
+	ErrConditionalNotReached = errors.New("transaction conditional not reached")
+
+	// ErrConditionalExpired is returned if the block is after the range of
+	// blocks a conditional transaction may be included in.
+	ErrConditionalExpired = errors.New("transaction conditional expired")
+
+	// ErrKnownAccountMismatch is returned if the state of a known account of a
+	// conditional transaction differs from the expected one.
+	ErrKnownAccountMismatch = errors.New("known account state mismatch")
+
+	errInvalidBlockNumberRange = errors.New("blockNumberMin is greater than blockNumberMax")
+	errInvalidTimestampRange   = errors.New("timestampMin is greater than timestampMax")
+)
+
+// KnownAccount is the expected storage of an account, either its storage root
+// or the values of some of its storage slots.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// MarshalJSON marshals the known account as its storage root if set, or as
+// the object of its storage slots otherwise.
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+// UnmarshalJSON unmarshals the known account from either a storage root or an
+// object of storage slots.
+func (a *KnownAccount) UnmarshalJSON(input []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(input, &root); err == nil {
+		a.StorageRoot = &root
+		a.StorageSlots = nil
+		return nil
+	}
+	var slots map[common.Hash]common.Hash
+	if err := json.Unmarshal(input, &slots); err != nil {
+		return fmt.Errorf("known account must be a storage root or storage slots: %w", err)
+	}
+	a.StorageRoot = nil
+	a.StorageSlots = slots
+	return nil
+}
+
+// KnownAccounts are the accounts whose storage must match the expected one for
+// a conditional transaction to be included.
+type KnownAccounts map[common.Address]KnownAccount
+
+// TransactionConditional are the conditions on the block and state a
+// transaction may be included in, as used by eth_sendRawTransactionConditional.
+// They are not part of the transaction, and are only enforced by the node the
+// transaction was submitted to.
+type TransactionConditional struct {
+	KnownAccounts  KnownAccounts   `json:"knownAccounts"`
+	BlockNumberMin *hexutil.Big    `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *hexutil.Big    `json:"blockNumberMax,omitempty"`
+	TimestampMin   *hexutil.Uint64 `json:"timestampMin,omitempty"`
+	TimestampMax   *hexutil.Uint64 `json:"timestampMax,omitempty"`
+}
+
+// Validate checks the ranges of the conditional are not empty.
+func (c *TransactionConditional) Validate() error {
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && c.BlockNumberMin.ToInt().Cmp(c.BlockNumberMax.ToInt()) > 0 {
+		return errInvalidBlockNumberRange
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return errInvalidTimestampRange
+	}
+	return nil
+}
+
+// Cost returns the number of storage lookups required to check the known
+// accounts of the conditional.
+func (c *TransactionConditional) Cost() int {
+	cost := 0
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost++
+		} else {
+			cost += len(account.StorageSlots)
+		}
+	}
+	return cost
+}
+
+// CheckBlock checks the block [number] with timestamp [time] is in the ranges
+// of the conditional.
+func (c *TransactionConditional) CheckBlock(number *big.Int, time uint64) error {
+	if c.BlockNumberMin != nil && number.Cmp(c.BlockNumberMin.ToInt()) < 0 {
+		return fmt.Errorf("%w: block number %d before %d", ErrConditionalNotReached, number, c.BlockNumberMin.ToInt())
+	}
+	if c.BlockNumberMax != nil && number.Cmp(c.BlockNumberMax.ToInt()) > 0 {
+		return fmt.Errorf("%w: block number %d after %d", ErrConditionalExpired, number, c.BlockNumberMax.ToInt())
+	}
+	if c.TimestampMin != nil && time < uint64(*c.TimestampMin) {
+		return fmt.Errorf("%w: timestamp %d before %d", ErrConditionalNotReached, time, uint64(*c.TimestampMin))
+	}
+	if c.TimestampMax != nil && time > uint64(*c.TimestampMax) {
+		return fmt.Errorf("%w: timestamp %d after %d", ErrConditionalExpired, time, uint64(*c.TimestampMax))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionConditionalJSON(t *testing.T) {
+	require := require.New(t)
+
+	input := `{
+		"knownAccounts": {
+			"0x0000000000000000000000000000000000000001": "0x0100000000000000000000000000000000000000000000000000000000000000",
+			"0x0000000000000000000000000000000000000002": {
+				"0x0000000000000000000000000000000000000000000000000000000000000000": "0x0000000000000000000000000000000000000000000000000000000000000002",
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000003"
+			}
+		},
+		"blockNumberMin": "0x2",
+		"timestampMax": "0x64"
+	}`
+	var conditional TransactionConditional
+	require.NoError(json.Unmarshal([]byte(input), &conditional))
+	require.NoError(conditional.Validate())
+	require.Equal(3, conditional.Cost())
+
+	root := conditional.KnownAccounts[common.HexToAddress("0x01")].StorageRoot
+	require.NotNil(root)
+	require.Equal(common.Hash{0x01}, *root)
+	require.Len(conditional.KnownAccounts[common.HexToAddress("0x02")].StorageSlots, 2)
+
+	encoded, err := json.Marshal(&conditional)
+	require.NoError(err)
+	var decoded TransactionConditional
+	require.NoError(json.Unmarshal(encoded, &decoded))
+	require.Equal(conditional, decoded)
+
+	require.Error(json.Unmarshal([]byte(`{"knownAccounts":{"0x0000000000000000000000000000000000000001":1}}`), &conditional))
+}
+
+func TestTransactionConditionalCheckBlock(t *testing.T) {
+	timeMin, timeMax := uint64(10), uint64(20)
+	conditional := TransactionConditional{
+		BlockNumberMin: (*hexutil.Big)(big.NewInt(2)),
+		BlockNumberMax: (*hexutil.Big)(big.NewInt(4)),
+		TimestampMin:   (*hexutil.Uint64)(&timeMin),
+		TimestampMax:   (*hexutil.Uint64)(&timeMax),
+	}
+	require.NoError(t, conditional.Validate())
+
+	tests := []struct {
+		number uint64
+		time   uint64
+		err    error
+	}{
+		{number: 2, time: 10},
+		{number: 4, time: 20},
+		{number: 1, time: 15, err: ErrConditionalNotReached},
+		{number: 3, time: 9, err: ErrConditionalNotReached},
+		{number: 5, time: 15, err: ErrConditionalExpired},
+		{number: 3, time: 21, err: ErrConditionalExpired},
+	}
+	for _, test := range tests {
+		require.ErrorIs(t, conditional.CheckBlock(new(big.Int).SetUint64(test.number), test.time), test.err, "block %d at %d", test.number, test.time)
+	}
+
+	conditional.BlockNumberMin = (*hexutil.Big)(big.NewInt(5))
+	require.ErrorIs(t, conditional.Validate(), errInvalidBlockNumberRange)
+}
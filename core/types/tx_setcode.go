@@ -0,0 +1,236 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// DelegationPrefix is used by code to denote the account is delegating to
+// another account, as specified by EIP-7702.
+var DelegationPrefix = []byte{0xef, 0x01, 0x00}
+
+// ParseDelegation tries to parse the address from a delegation slice.
+func ParseDelegation(b []byte) (common.Address, bool) {
+	if len(b) != len(DelegationPrefix)+common.AddressLength || !bytes.HasPrefix(b, DelegationPrefix) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(b[len(DelegationPrefix):]), true
+}
+
+// AddressToDelegation adds the delegation prefix to the specified address.
+func AddressToDelegation(addr common.Address) []byte {
+	return append(common.CopyBytes(DelegationPrefix), addr.Bytes()...)
+}
+
+// SetCodeTx implements the EIP-7702 transaction type which temporarily installs
+// the code at the signer's address.
+type SetCodeTx struct {
+	ChainID    *uint256.Int
+	Nonce      uint64
+	GasTipCap  *uint256.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *uint256.Int // a.k.a. maxFeePerGas
+	Gas        uint64
+	To         common.Address
+	Value      *uint256.Int
+	Data       []byte
+	AccessList AccessList
+	AuthList   []SetCodeAuthorization
+
+	// Signature values
+	V *uint256.Int `json:"v" gencodec:"required"`
+	R *uint256.Int `json:"r" gencodec:"required"`
+	S *uint256.Int `json:"s" gencodec:"required"`
+}
+
+// SetCodeAuthorization is an authorization from an account to deploy code at
+// its address.
+type SetCodeAuthorization struct {
+	ChainID uint256.Int
+	Address common.Address
+	Nonce   uint64
+	V       uint8
+	R       uint256.Int
+	S       uint256.Int
+}
+
+// setCodeAuthorizationJSON is the JSON representation of a SetCodeAuthorization.
+type setCodeAuthorizationJSON struct {
+	ChainID *hexutil.Big   `json:"chainId"`
+	Address common.Address `json:"address"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+	V       hexutil.Uint64 `json:"yParity"`
+	R       *hexutil.Big   `json:"r"`
+	S       *hexutil.Big   `json:"s"`
+}
+
+// MarshalJSON marshals the authorization as JSON.
+func (a SetCodeAuthorization) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&setCodeAuthorizationJSON{
+		ChainID: (*hexutil.Big)(a.ChainID.ToBig()),
+		Address: a.Address,
+		Nonce:   hexutil.Uint64(a.Nonce),
+		V:       hexutil.Uint64(a.V),
+		R:       (*hexutil.Big)(a.R.ToBig()),
+		S:       (*hexutil.Big)(a.S.ToBig()),
+	})
+}
+
+// UnmarshalJSON unmarshals the authorization from JSON.
+func (a *SetCodeAuthorization) UnmarshalJSON(input []byte) error {
+	var dec setCodeAuthorizationJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' in authorization")
+	}
+	if dec.R == nil {
+		return errors.New("missing required field 'r' in authorization")
+	}
+	if dec.S == nil {
+		return errors.New("missing required field 's' in authorization")
+	}
+	if dec.V > 1 {
+		return errors.New("'yParity' field must be 0 or 1")
+	}
+	if a.ChainID.SetFromBig((*big.Int)(dec.ChainID)) {
+		return errors.New("'chainId' value overflows uint256")
+	}
+	if a.R.SetFromBig((*big.Int)(dec.R)) {
+		return errors.New("'r' value overflows uint256")
+	}
+	if a.S.SetFromBig((*big.Int)(dec.S)) {
+		return errors.New("'s' value overflows uint256")
+	}
+	a.Address = dec.Address
+	a.Nonce = uint64(dec.Nonce)
+	a.V = uint8(dec.V)
+	return nil
+}
+
+// SignSetCode creates a signed SetCode authorization.
+func SignSetCode(prv *ecdsa.PrivateKey, auth SetCodeAuthorization) (SetCodeAuthorization, error) {
+	sighash := auth.sigHash()
+	sig, err := crypto.Sign(sighash[:], prv)
+	if err != nil {
+		return SetCodeAuthorization{}, err
+	}
+	r, s, _ := decodeSignature(sig)
+	return SetCodeAuthorization{
+		ChainID: auth.ChainID,
+		Address: auth.Address,
+		Nonce:   auth.Nonce,
+		V:       sig[64],
+		R:       *uint256.MustFromBig(r),
+		S:       *uint256.MustFromBig(s),
+	}, nil
+}
+
+// sigHash returns the hash of the authorization signed by the authority.
+func (a *SetCodeAuthorization) sigHash() common.Hash {
+	return prefixedRlpHash(0x05, []any{
+		a.ChainID,
+		a.Address,
+		a.Nonce,
+	})
+}
+
+// Authority recovers the account that signed the authorization.
+func (a *SetCodeAuthorization) Authority() (common.Address, error) {
+	v := new(big.Int).SetUint64(uint64(a.V) + 27)
+	return recoverPlain(a.sigHash(), a.R.ToBig(), a.S.ToBig(), v, true)
+}
+
+// copy creates a deep copy of the transaction data and initializes all fields.
+func (tx *SetCodeTx) copy() TxData {
+	cpy := &SetCodeTx{
+		Nonce: tx.Nonce,
+		To:    tx.To,
+		Data:  common.CopyBytes(tx.Data),
+		Gas:   tx.Gas,
+		// These are copied below.
+		AccessList: make(AccessList, len(tx.AccessList)),
+		AuthList:   make([]SetCodeAuthorization, len(tx.AuthList)),
+		Value:      new(uint256.Int),
+		ChainID:    new(uint256.Int),
+		GasTipCap:  new(uint256.Int),
+		GasFeeCap:  new(uint256.Int),
+		V:          new(uint256.Int),
+		R:          new(uint256.Int),
+		S:          new(uint256.Int),
+	}
+	copy(cpy.AccessList, tx.AccessList)
+	copy(cpy.AuthList, tx.AuthList)
+
+	if tx.Value != nil {
+		cpy.Value.Set(tx.Value)
+	}
+	if tx.ChainID != nil {
+		cpy.ChainID.Set(tx.ChainID)
+	}
+	if tx.GasTipCap != nil {
+		cpy.GasTipCap.Set(tx.GasTipCap)
+	}
+	if tx.GasFeeCap != nil {
+		cpy.GasFeeCap.Set(tx.GasFeeCap)
+	}
+	if tx.V != nil {
+		cpy.V.Set(tx.V)
+	}
+	if tx.R != nil {
+		cpy.R.Set(tx.R)
+	}
+	if tx.S != nil {
+		cpy.S.Set(tx.S)
+	}
+	return cpy
+}
+
+// accessors for innerTx.
+func (tx *SetCodeTx) txType() byte              { return SetCodeTxType }
+func (tx *SetCodeTx) chainID() *big.Int         { return tx.ChainID.ToBig() }
+func (tx *SetCodeTx) accessList() AccessList    { return tx.AccessList }
+func (tx *SetCodeTx) data() []byte              { return tx.Data }
+func (tx *SetCodeTx) gas() uint64               { return tx.Gas }
+func (tx *SetCodeTx) gasFeeCap() *big.Int       { return tx.GasFeeCap.ToBig() }
+func (tx *SetCodeTx) gasTipCap() *big.Int       { return tx.GasTipCap.ToBig() }
+func (tx *SetCodeTx) gasPrice() *big.Int        { return tx.GasFeeCap.ToBig() }
+func (tx *SetCodeTx) value() *big.Int           { return tx.Value.ToBig() }
+func (tx *SetCodeTx) nonce() uint64             { return tx.Nonce }
+func (tx *SetCodeTx) to() *common.Address       { tmp := tx.To; return &tmp }
+func (tx *SetCodeTx) blobGas() uint64           { return 0 }
+func (tx *SetCodeTx) blobGasFeeCap() *big.Int   { return nil }
+func (tx *SetCodeTx) blobHashes() []common.Hash { return nil }
+
+func (tx *SetCodeTx) effectiveGasPrice(dst *big.Int, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return dst.Set(tx.GasFeeCap.ToBig())
+	}
+	tip := dst.Sub(tx.GasFeeCap.ToBig(), baseFee)
+	if tip.Cmp(tx.GasTipCap.ToBig()) > 0 {
+		tip.Set(tx.GasTipCap.ToBig())
+	}
+	return tip.Add(tip, baseFee)
+}
+
+func (tx *SetCodeTx) rawSignatureValues() (v, r, s *big.Int) {
+	return tx.V.ToBig(), tx.R.ToBig(), tx.S.ToBig()
+}
+
+func (tx *SetCodeTx) setSignatureValues(chainID, v, r, s *big.Int) {
+	tx.ChainID.SetFromBig(chainID)
+	tx.V.SetFromBig(v)
+	tx.R.SetFromBig(r)
+	tx.S.SetFromBig(s)
+}
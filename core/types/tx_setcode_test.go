@@ -0,0 +1,84 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDelegation(t *testing.T) {
+	addr := common.Address{0x42}
+	got, ok := ParseDelegation(AddressToDelegation(addr))
+	require.True(t, ok)
+	require.Equal(t, addr, got)
+
+	_, ok = ParseDelegation(append(AddressToDelegation(addr), 0x00))
+	require.False(t, ok)
+	_, ok = ParseDelegation(append([]byte{0xef, 0x01, 0x01}, addr.Bytes()...))
+	require.False(t, ok)
+	_, ok = ParseDelegation(nil)
+	require.False(t, ok)
+}
+
+func TestSetCodeTxEncoding(t *testing.T) {
+	require := require.New(t)
+
+	authKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	auth, err := SignSetCode(authKey, SetCodeAuthorization{
+		ChainID: *uint256.NewInt(1),
+		Address: common.Address{0x42},
+		Nonce:   7,
+	})
+	require.NoError(err)
+	authority, err := auth.Authority()
+	require.NoError(err)
+	require.Equal(crypto.PubkeyToAddress(authKey.PublicKey), authority)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	signer := NewVesuviusSigner(common.Big1)
+	tx, err := SignNewTx(key, signer, &SetCodeTx{
+		ChainID:   uint256.NewInt(1),
+		Nonce:     1,
+		GasTipCap: uint256.NewInt(1),
+		GasFeeCap: uint256.NewInt(2),
+		Gas:       params.TxGas,
+		To:        common.Address{1},
+		Value:     uint256.NewInt(3),
+		AuthList:  []SetCodeAuthorization{auth},
+	})
+	require.NoError(err)
+
+	// RLP round trip
+	enc, err := rlp.EncodeToBytes(tx)
+	require.NoError(err)
+	var decoded Transaction
+	require.NoError(rlp.DecodeBytes(enc, &decoded))
+	require.Equal(tx.Hash(), decoded.Hash())
+	require.Equal([]SetCodeAuthorization{auth}, decoded.SetCodeAuthorizations())
+
+	// JSON round trip
+	js, err := json.Marshal(tx)
+	require.NoError(err)
+	var fromJSON Transaction
+	require.NoError(json.Unmarshal(js, &fromJSON))
+	require.Equal(tx.Hash(), fromJSON.Hash())
+
+	sender, err := Sender(signer, &decoded)
+	require.NoError(err)
+	require.Equal(crypto.PubkeyToAddress(key.PublicKey), sender)
+
+	// Set code transactions are not accepted by signers before Vesuvius.
+	_, err = Sender(NewCancunSigner(common.Big1), &decoded)
+	require.ErrorIs(err, ErrTxTypeNotSupported)
+}
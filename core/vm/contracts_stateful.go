@@ -29,3 +29,15 @@ func (w *wrappedPrecompiledContract) Run(accessibleState contract.AccessibleStat
 func RunStatefulPrecompiledContract(precompile contract.StatefulPrecompiledContract, accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 	return precompile.Run(accessibleState, caller, addr, input, suppliedGas, readOnly)
 }
+
+// tracingStateDB wraps the StateDB exposed to stateful precompiles, notifying
+// [logger] of the logs they emit.
+type tracingStateDB struct {
+	contract.StateDB
+	logger PrecompileLogger
+}
+
+func (s *tracingStateDB) AddLog(addr common.Address, topics []common.Hash, data []byte, blockNumber uint64) {
+	s.logger.CapturePrecompileLog(addr, topics, data)
+	s.StateDB.AddLog(addr, topics, data, blockNumber)
+}
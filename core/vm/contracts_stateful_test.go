@@ -0,0 +1,212 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const forwardingPrecompileGas = 100
+
+var (
+	forwardingPrecompileAddrs = []common.Address{
+		common.HexToAddress("0x03000000000000000000000000000000000000fd"),
+		common.HexToAddress("0x03000000000000000000000000000000000000fe"),
+	}
+	errForwardingPrecompile = errors.New("forwarding precompile failure")
+	callCountKey            = common.Hash{'c', 'a', 'l', 'l', 's'}
+)
+
+// forwardingPrecompile counts its calls in its storage. If the input starts with an
+// address, it forwards the rest of the input to the precompile at this address.
+// An input of [0x01] makes it fail after counting the call.
+type forwardingPrecompile struct{}
+
+func (forwardingPrecompile) Run(accessibleState contract.AccessibleState, _ common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	remainingGas, err := contract.DeductGas(suppliedGas, forwardingPrecompileGas)
+	if err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	stateDB := accessibleState.GetStateDB()
+	calls := stateDB.GetState(addr, callCountKey).Big()
+	stateDB.SetState(addr, callCountKey, common.BigToHash(calls.Add(calls, common.Big1)))
+
+	switch {
+	case len(input) == 1 && input[0] == 0x01:
+		return nil, 0, errForwardingPrecompile
+	case len(input) >= common.AddressLength:
+		return accessibleState.CallPrecompile(addr, common.BytesToAddress(input[:common.AddressLength]), input[common.AddressLength:], remainingGas, readOnly)
+	default:
+		return nil, remainingGas, nil
+	}
+}
+
+func init() {
+	for i, addr := range forwardingPrecompileAddrs {
+		if err := modules.RegisterModule(modules.Module{
+			ConfigKey: "forwardingPrecompile" + string(rune('A'+i)),
+			Address:   addr,
+			Contract:  forwardingPrecompile{},
+		}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestCallPrecompile(t *testing.T) {
+	var (
+		a            = forwardingPrecompileAddrs[0]
+		b            = forwardingPrecompileAddrs[1]
+		notEnabled   = common.HexToAddress("0x03000000000000000000000000000000000000fc")
+		callerOfA    = common.Address{1}
+		suppliedGas  = uint64(1000)
+		concatenated = func(parts ...[]byte) []byte {
+			var res []byte
+			for _, part := range parts {
+				res = append(res, part...)
+			}
+			return res
+		}
+	)
+	tests := []struct {
+		name          string
+		input         []byte
+		readOnly      bool // interpreter read only mode
+		expectedErr   error
+		expectedGas   uint64
+		expectedCalls map[common.Address]int64
+	}{
+		{
+			name:          "call enabled precompile",
+			input:         b.Bytes(),
+			expectedGas:   suppliedGas - 2*forwardingPrecompileGas,
+			expectedCalls: map[common.Address]int64{a: 1, b: 1},
+		},
+		{
+			name:          "call precompile not enabled",
+			input:         notEnabled.Bytes(),
+			expectedErr:   vmerrs.ErrNotStatefulPrecompile,
+			expectedGas:   suppliedGas - forwardingPrecompileGas,
+			expectedCalls: map[common.Address]int64{a: 1},
+		},
+		{
+			name:          "call itself",
+			input:         a.Bytes(),
+			expectedErr:   vmerrs.ErrPrecompileReentrancy,
+			expectedGas:   suppliedGas - forwardingPrecompileGas,
+			expectedCalls: map[common.Address]int64{a: 1},
+		},
+		{
+			// The failed call to b consumes all of its gas and reverts its changes.
+			name:          "re-enter caller",
+			input:         concatenated(b.Bytes(), a.Bytes()),
+			expectedErr:   vmerrs.ErrPrecompileReentrancy,
+			expectedCalls: map[common.Address]int64{a: 1},
+		},
+		{
+			name:          "failed call is reverted",
+			input:         concatenated(b.Bytes(), []byte{0x01}),
+			expectedErr:   errForwardingPrecompile,
+			expectedCalls: map[common.Address]int64{a: 1},
+		},
+		{
+			name:          "read only interpreter",
+			input:         b.Bytes(),
+			readOnly:      true,
+			expectedErr:   vmerrs.ErrWriteProtection,
+			expectedCalls: map[common.Address]int64{a: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(err)
+			evm := NewEVM(BlockContext{BlockNumber: big.NewInt(0)}, TxContext{}, statedb, params.TestChainConfig, Config{})
+			evm.chainRules.ActivePrecompiles = map[common.Address]precompileconfig.Config{a: nil, b: nil}
+
+			// The call from a to b must be read only if the interpreter is, even if a passes false.
+			evm.interpreter.readOnly = test.readOnly
+
+			// a is run as if called through an EVM CALL, and calls b with the rest of the input.
+			_, remainingGas, err := RunStatefulPrecompiledContract(forwardingPrecompile{}, evm, callerOfA, a, test.input, suppliedGas, false)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedGas, remainingGas)
+			for _, addr := range forwardingPrecompileAddrs {
+				require.Equal(test.expectedCalls[addr], statedb.GetState(addr, callCountKey).Big().Int64(), addr)
+			}
+			require.Empty(evm.precompileCalls)
+		})
+	}
+}
+
+var valuePrecompileAddr = common.HexToAddress("0x03000000000000000000000000000000000000fb")
+
+// valuePrecompile returns the value transferred to it by the call.
+type valuePrecompile struct{}
+
+func (valuePrecompile) Run(accessibleState contract.AccessibleState, _ common.Address, _ common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+	return common.BigToHash(accessibleState.GetCallValue()).Bytes(), suppliedGas, nil
+}
+
+func init() {
+	if err := modules.RegisterModule(modules.Module{
+		ConfigKey: "valuePrecompile",
+		Address:   valuePrecompileAddr,
+		Contract:  valuePrecompile{},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func TestPrecompileCallValue(t *testing.T) {
+	require := require.New(t)
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	caller := common.Address{1}
+	statedb.AddBalance(caller, big.NewInt(100))
+	evm := NewEVM(BlockContext{
+		BlockNumber: big.NewInt(0),
+		CanTransfer: func(db StateDB, addr common.Address, amount *big.Int) bool {
+			return db.GetBalance(addr).Cmp(amount) >= 0
+		},
+		Transfer: func(db StateDB, from, to common.Address, amount *big.Int) {
+			db.SubBalance(from, amount)
+			db.AddBalance(to, amount)
+		},
+	}, TxContext{}, statedb, params.TestChainConfig, Config{})
+	evm.chainRules.ActivePrecompiles = map[common.Address]precompileconfig.Config{valuePrecompileAddr: nil}
+
+	// The value of a CALL is transferred to the precompile.
+	ret, _, err := evm.Call(AccountRef(caller), valuePrecompileAddr, nil, 1000, big.NewInt(7))
+	require.NoError(err)
+	require.Equal(common.BigToHash(big.NewInt(7)).Bytes(), ret)
+
+	// The value of a CALLCODE stays with its caller.
+	ret, _, err = evm.CallCode(AccountRef(caller), valuePrecompileAddr, nil, 1000, big.NewInt(7))
+	require.NoError(err)
+	require.Equal(common.Hash{}.Bytes(), ret)
+
+	// A precompile called by another precompile receives no value.
+	ret, _, err = evm.CallPrecompile(forwardingPrecompileAddrs[0], valuePrecompileAddr, nil, 1000, false)
+	require.NoError(err)
+	require.Equal(common.Hash{}.Bytes(), ret)
+	require.Equal(common.Big0, evm.GetCallValue())
+}
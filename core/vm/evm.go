@@ -29,6 +29,7 @@ package vm
 import (
 	"fmt"
 	"math/big"
+	"slices"
 	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/snow"
@@ -37,12 +38,14 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/holiman/uint256"
 )
 
@@ -140,6 +143,13 @@ func (b *BlockContext) GetPredicateResults(txHash common.Hash, address common.Ad
 	return b.PredicateResults.GetResults(txHash, address)
 }
 
+func (b *BlockContext) GetBlockHash(number uint64) common.Hash {
+	if b.GetHash == nil {
+		return common.Hash{}
+	}
+	return b.GetHash(number)
+}
+
 // TxContext provides the EVM with information about a transaction.
 // All fields can change between transactions.
 type TxContext struct {
@@ -183,11 +193,17 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// precompileCalls holds the addresses of the stateful precompiles taking part in the
+	// ongoing precompile to precompile calls, to prevent re-entering them.
+	precompileCalls []common.Address
+	// callValue is the value transferred to the running stateful precompile.
+	callValue *big.Int
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
 // only ever be used *once*.
 func NewEVM(blockCtx BlockContext, txCtx TxContext, statedb StateDB, chainConfig *params.ChainConfig, config Config) *EVM {
+	chainConfig = withScheduledUpgrades(chainConfig, blockCtx, statedb)
 	evm := &EVM{
 		Context:     blockCtx,
 		TxContext:   txCtx,
@@ -200,6 +216,22 @@ func NewEVM(blockCtx BlockContext, txCtx TxContext, statedb StateDB, chainConfig
 	return evm
 }
 
+// withScheduledUpgrades returns [chainConfig] with the precompile upgrades scheduled by the
+// governance precompile in [statedb] merged in, unless they already are. Block processing and
+// building merge them before creating the EVM and fail if they cannot be read, so this only
+// covers the other callers, for which the unmerged config is used if they cannot be read.
+func withScheduledUpgrades(chainConfig *params.ChainConfig, blockCtx BlockContext, statedb StateDB) *params.ChainConfig {
+	if statedb == nil || chainConfig.HasScheduledPrecompileUpgrades() {
+		return chainConfig
+	}
+	merged, err := governance.ChainConfigAt(chainConfig, statedb, blockCtx.Time)
+	if err != nil {
+		log.Error("Failed to read scheduled upgrades", "number", blockCtx.BlockNumber, "err", err)
+		return chainConfig
+	}
+	return merged
+}
+
 // Reset resets the EVM with a new transaction context.Reset
 // This is not threadsafe and should only be done very cautiously.
 func (evm *EVM) Reset(txCtx TxContext, statedb StateDB) {
@@ -225,14 +257,75 @@ func (evm *EVM) GetSnowContext() *snow.Context {
 
 // GetStateDB returns the evm's StateDB
 func (evm *EVM) GetStateDB() contract.StateDB {
+	if logger, ok := evm.Config.Tracer.(PrecompileLogger); ok {
+		return &tracingStateDB{StateDB: evm.StateDB, logger: logger}
+	}
 	return evm.StateDB
 }
 
+// GetCallValue returns the value transferred to the running stateful precompile. Only a CALL
+// transfers value to the precompile, while the value of a CALLCODE stays with its caller.
+func (evm *EVM) GetCallValue() *big.Int {
+	if evm.callValue == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(evm.callValue)
+}
+
+// runPrecompile runs the stateful precompile [p] with [value], or no value if nil, as the value
+// transferred to it.
+func (evm *EVM) runPrecompile(p contract.StatefulPrecompiledContract, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, value *big.Int, readOnly bool) ([]byte, uint64, error) {
+	parentValue := evm.callValue
+	evm.callValue = value
+	defer func() { evm.callValue = parentValue }()
+	return RunStatefulPrecompiledContract(p, evm, caller, addr, input, suppliedGas, readOnly)
+}
+
 // GetBlockContext returns the evm's BlockContext
 func (evm *EVM) GetBlockContext() contract.BlockContext {
 	return &evm.Context
 }
 
+// CallPrecompile runs the stateful precompile at [addr] on behalf of the stateful precompile
+// [caller], without going through an EVM CALL. Only the precompiles enabled by the chain rules
+// can be called, and a precompile cannot be re-entered while it takes part in such a call.
+// The state changes of the call are reverted if it fails, consuming all of [suppliedGas] unless
+// the call reverted. The caller is responsible for charging the gas used by the call.
+func (evm *EVM) CallPrecompile(caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if evm.depth > int(params.CallCreateDepth) {
+		return nil, suppliedGas, vmerrs.ErrDepth
+	}
+	if _, ok := evm.chainRules.ActivePrecompiles[addr]; !ok {
+		return nil, suppliedGas, fmt.Errorf("%w: %s", vmerrs.ErrNotStatefulPrecompile, addr)
+	}
+	module, ok := modules.GetPrecompileModuleByAddress(addr)
+	if !ok {
+		return nil, suppliedGas, fmt.Errorf("%w: %s", vmerrs.ErrNotStatefulPrecompile, addr)
+	}
+	if addr == caller || slices.Contains(evm.precompileCalls, addr) {
+		return nil, suppliedGas, fmt.Errorf("%w: %s", vmerrs.ErrPrecompileReentrancy, addr)
+	}
+	evm.precompileCalls = append(evm.precompileCalls, caller, addr)
+	defer func() { evm.precompileCalls = evm.precompileCalls[:len(evm.precompileCalls)-2] }()
+
+	if evm.Config.Tracer != nil {
+		evm.Config.Tracer.CaptureEnter(CALL, caller, addr, input, suppliedGas, new(big.Int))
+		defer func() {
+			evm.Config.Tracer.CaptureExit(ret, suppliedGas-remainingGas, err)
+		}()
+	}
+
+	snapshot := evm.StateDB.Snapshot()
+	ret, remainingGas, err = evm.runPrecompile(module.Contract, caller, addr, input, suppliedGas, nil, readOnly || evm.interpreter.readOnly)
+	if err != nil {
+		evm.StateDB.RevertToSnapshot(snapshot)
+		if err != vmerrs.ErrExecutionReverted {
+			remainingGas = 0
+		}
+	}
+	return ret, remainingGas, err
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() *EVMInterpreter {
 	return evm.interpreter
@@ -246,6 +339,13 @@ func (evm *EVM) SetBlockContext(blockCtx BlockContext) {
 	evm.chainRules = evm.chainConfig.Rules(num, timestamp)
 }
 
+// SetChainConfig updates the chain config of the EVM, e.g. to execute a call
+// with overridden precompile configs.
+func (evm *EVM) SetChainConfig(chainConfig *params.ChainConfig) {
+	evm.chainConfig = withScheduledUpgrades(chainConfig, evm.Context, evm.StateDB)
+	evm.chainRules = evm.chainConfig.Rules(evm.Context.BlockNumber, evm.Context.Time)
+}
+
 // Call executes the contract associated with the addr with the given input as
 // parameters. It also handles any necessary value transfer required and takes
 // the necessary steps to create accounts and reverses the state in case of an
@@ -301,11 +401,11 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	}
 
 	if isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, evm.interpreter.readOnly)
+		ret, gas, err = evm.runPrecompile(p, caller.Address(), addr, input, gas, value, evm.interpreter.readOnly)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
-		code := evm.StateDB.GetCode(addr)
+		code := evm.resolveCode(addr)
 		if len(code) == 0 {
 			ret, err = nil, nil // gas is unchanged
 		} else {
@@ -313,7 +413,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 			// If the account has no code, we can abort here
 			// The depth-check is already done, and precompiles handled above
 			contract := NewContract(caller, AccountRef(addrCopy), value, gas)
-			contract.SetCallCode(&addrCopy, evm.StateDB.GetCodeHash(addrCopy), code)
+			contract.SetCallCode(&addrCopy, evm.resolveCodeHash(addrCopy), code)
 			ret, err = evm.interpreter.Run(contract, input, false)
 			gas = contract.Gas
 		}
@@ -367,13 +467,13 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, evm.interpreter.readOnly)
+		ret, gas, err = evm.runPrecompile(p, caller.Address(), addr, input, gas, nil, evm.interpreter.readOnly)
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
 		contract := NewContract(caller, AccountRef(caller.Address()), value, gas)
-		contract.SetCallCode(&addrCopy, evm.StateDB.GetCodeHash(addrCopy), evm.StateDB.GetCode(addrCopy))
+		contract.SetCallCode(&addrCopy, evm.resolveCodeHash(addrCopy), evm.resolveCode(addrCopy))
 		ret, err = evm.interpreter.Run(contract, input, false)
 		gas = contract.Gas
 	}
@@ -412,12 +512,12 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, evm.interpreter.readOnly)
+		ret, gas, err = evm.runPrecompile(p, caller.Address(), addr, input, gas, nil, evm.interpreter.readOnly)
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and make initialise the delegate values
 		contract := NewContract(caller, AccountRef(caller.Address()), nil, gas).AsDelegate()
-		contract.SetCallCode(&addrCopy, evm.StateDB.GetCodeHash(addrCopy), evm.StateDB.GetCode(addrCopy))
+		contract.SetCallCode(&addrCopy, evm.resolveCodeHash(addrCopy), evm.resolveCode(addrCopy))
 		ret, err = evm.interpreter.Run(contract, input, false)
 		gas = contract.Gas
 	}
@@ -461,7 +561,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	}
 
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunStatefulPrecompiledContract(p, evm, caller.Address(), addr, input, gas, true)
+		ret, gas, err = evm.runPrecompile(p, caller.Address(), addr, input, gas, nil, true)
 	} else {
 		// At this point, we use a copy of address. If we don't, the go compiler will
 		// leak the 'contract' to the outer scope, and make allocation for 'contract'
@@ -470,7 +570,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
 		contract := NewContract(caller, AccountRef(addrCopy), new(big.Int), gas)
-		contract.SetCallCode(&addrCopy, evm.StateDB.GetCodeHash(addrCopy), evm.StateDB.GetCode(addrCopy))
+		contract.SetCallCode(&addrCopy, evm.resolveCodeHash(addrCopy), evm.resolveCode(addrCopy))
 		// When an error was returned by the EVM or when setting the creation code
 		// above we revert to the snapshot and consume any gas remaining. Additionally
 		// when we're in Homestead this also counts for code storage gas errors.
@@ -486,6 +586,35 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	return ret, gas, err
 }
 
+// resolveCode returns the code associated with the provided account. After
+// Vesuvius, it also follows an EIP-7702 delegation designator to the code of
+// the delegation target.
+func (evm *EVM) resolveCode(addr common.Address) []byte {
+	code := evm.StateDB.GetCode(addr)
+	if !evm.chainRules.IsVesuvius {
+		return code
+	}
+	if target, ok := types.ParseDelegation(code); ok {
+		// Note we only follow one level of delegation.
+		return evm.StateDB.GetCode(target)
+	}
+	return code
+}
+
+// resolveCodeHash returns the code hash associated with the provided address.
+// After Vesuvius, it also follows an EIP-7702 delegation designator to the code
+// hash of the delegation target.
+func (evm *EVM) resolveCodeHash(addr common.Address) common.Hash {
+	if evm.chainRules.IsVesuvius {
+		code := evm.StateDB.GetCode(addr)
+		if target, ok := types.ParseDelegation(code); ok {
+			// Note we only follow one level of delegation.
+			return evm.StateDB.GetCodeHash(target)
+		}
+	}
+	return evm.StateDB.GetCodeHash(addr)
+}
+
 type codeAndHash struct {
 	code []byte
 	hash common.Hash
@@ -621,8 +750,13 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 	return evm.create(caller, codeAndHash, gas, endowment, contractAddr, CREATE2)
 }
 
-// ChainConfig returns the environment's chain configuration
+// ChainConfig returns the environment's chain configuration, including the
+// precompile upgrades scheduled by the governance precompile.
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
 
+// ChainRules returns the rules of the environment, including the precompile
+// upgrades scheduled by the governance precompile.
+func (evm *EVM) ChainRules() params.Rules { return evm.chainRules }
+
 // GetChainConfig implements AccessibleState
 func (evm *EVM) GetChainConfig() precompileconfig.ChainConfig { return evm.chainConfig }
@@ -191,3 +191,59 @@ func TestCreateGas(t *testing.T) {
 		}
 	}
 }
+
+func TestCallGasEIP7702(t *testing.T) {
+	var (
+		caller    = common.BytesToAddress([]byte("caller"))
+		delegated = common.BytesToAddress([]byte("delegated"))
+		target    = common.BytesToAddress([]byte("target"))
+		// call(0xffff, delegated, 0, 0, 0, 0, 0)
+		code = append(append([]byte{
+			byte(PUSH1), 0, byte(PUSH1), 0, byte(PUSH1), 0, byte(PUSH1), 0, byte(PUSH1), 0,
+			byte(PUSH20)}, delegated.Bytes()...),
+			byte(PUSH2), 0xff, 0xff, byte(CALL), byte(STOP),
+		)
+		config = *params.TestChainConfig
+	)
+	config.VesuviusTimestamp = new(uint64)
+
+	for _, tt := range []struct {
+		name       string
+		warmTarget bool
+		gasUsed    uint64
+	}{
+		// pushes (21) + warm call (100) + cold callee (2500) + cold target (2600)
+		{name: "cold target", gasUsed: 5221},
+		// pushes (21) + warm call (100) + cold callee (2500) + warm target (100)
+		{name: "warm target", warmTarget: true, gasUsed: 2721},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			statedb.SetCode(caller, code)
+			statedb.SetCode(delegated, types.AddressToDelegation(target))
+			statedb.SetCode(target, []byte{byte(STOP)})
+			statedb.Finalise(true)
+			statedb.AddAddressToAccessList(caller)
+			if tt.warmTarget {
+				statedb.AddAddressToAccessList(target)
+			}
+			vmctx := BlockContext{
+				CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+				Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+				BlockNumber: big.NewInt(0),
+			}
+			vmenv := NewEVM(vmctx, TxContext{}, statedb, &config, Config{})
+			startGas := uint64(100_000)
+			_, gas, err := vmenv.Call(AccountRef(common.Address{}), caller, nil, startGas, new(big.Int))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gasUsed := startGas - gas; gasUsed != tt.gasUsed {
+				t.Fatalf("gas used mismatch: have %d, want %d", gasUsed, tt.gasUsed)
+			}
+			if !statedb.AddressInAccessList(target) {
+				t.Fatal("delegation target not added to the access list")
+			}
+		})
+	}
+}
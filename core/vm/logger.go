@@ -51,3 +51,10 @@ type EVMLogger interface {
 	CaptureState(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, rData []byte, depth int, err error)
 	CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error)
 }
+
+// PrecompileLogger is an optional interface of EVMLogger. Stateful precompiles
+// emit logs without executing LOG opcodes, so loggers implementing it are
+// notified of these logs instead.
+type PrecompileLogger interface {
+	CapturePrecompileLog(addr common.Address, topics []common.Hash, data []byte)
+}
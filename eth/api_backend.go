@@ -89,8 +89,9 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	// Treat requests for the pending, latest, or accepted block
-	// identically.
+	// The finalized and safe tags always resolve to the last accepted block.
+	// The pending and latest tags resolve to the preferred block if unfinalized
+	// queries are allowed, and to the last accepted block otherwise.
 	acceptedBlock := b.eth.LastAcceptedBlock()
 	if number.IsAccepted() {
 		if b.isLatestAndAllowed(number) {
@@ -156,8 +157,9 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	// Treat requests for the pending, latest, or accepted block
-	// identically.
+	// The finalized and safe tags always resolve to the last accepted block.
+	// The pending and latest tags resolve to the preferred block if unfinalized
+	// queries are allowed, and to the last accepted block otherwise.
 	acceptedBlock := b.eth.LastAcceptedBlock()
 	if number.IsAccepted() {
 		if b.isLatestAndAllowed(number) {
@@ -342,11 +344,29 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	}
 
 	// We only enqueue transactions for push gossip if they were submitted over the RPC and
-	// added to the mempool.
-	b.eth.gossiper.Add(signedTx)
+	// added to the mempool. Conditional transactions are not gossiped, as peers would
+	// include them without enforcing their conditions.
+	if signedTx.Conditional() == nil {
+		b.eth.gossiper.Add(signedTx)
+	}
 	return nil
 }
 
+// SendBlobTx adds a blob transaction along with its sidecar to the transaction
+// pool. Blobs are not gossiped, so the transaction is only included in blocks
+// built by this node.
+func (b *EthAPIBackend) SendBlobTx(ctx context.Context, signedTx *types.Transaction, sidecar *types.BlobTxSidecar) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.eth.txPool.Add([]*txpool.Transaction{{
+		Tx:            signedTx,
+		BlobTxBlobs:   sidecar.Blobs,
+		BlobTxCommits: sidecar.Commitments,
+		BlobTxProofs:  sidecar.Proofs,
+	}}, true, false)[0]
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(false)
 	var txs types.Transactions
@@ -402,6 +422,10 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transac
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+func (b *EthAPIBackend) CancelPoolTransaction(txHash common.Hash) error {
+	return b.eth.txPool.Cancel(txHash)
+}
+
 func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
 	return b.eth.txPool.SubscribeNewTxsEvent(ch)
 }
@@ -418,6 +442,10 @@ func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
+func (b *EthAPIBackend) EstimatePriorityFee(ctx context.Context, gas uint64) (*gasprice.PriorityFeeEstimate, error) {
+	return b.gpo.EstimatePriorityFee(ctx, gas)
+}
+
 func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
@@ -466,6 +494,14 @@ func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
 	return b.eth.config.RPCEVMTimeout
 }
 
+func (b *EthAPIBackend) RPCTraceTimeout() time.Duration {
+	return b.eth.config.RPCTraceTimeout
+}
+
+func (b *EthAPIBackend) RPCMaxTraceTimeout() time.Duration {
+	return b.eth.config.RPCMaxTraceTimeout
+}
+
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
@@ -493,6 +529,10 @@ func (b *EthAPIBackend) GetMaxBlocksPerRequest() int64 {
 	return b.eth.settings.MaxBlocksPerRequest
 }
 
+func (b *EthAPIBackend) GetMaxLogsPerRequest() int {
+	return b.eth.settings.MaxLogsPerRequest
+}
+
 func (b *EthAPIBackend) StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, tracers.StateReleaseFunc, error) {
 	return b.eth.StateAtBlock(ctx, block, reexec, base, readOnly, preferDisk)
 }
@@ -509,6 +549,8 @@ func (b *EthAPIBackend) MinRequiredTip(ctx context.Context, header *types.Header
 	return dummy.MinRequiredTip(b.ChainConfig(), header)
 }
 
+// isLatestAndAllowed returns true if [number] should resolve to the preferred
+// block rather than to the last accepted block.
 func (b *EthAPIBackend) isLatestAndAllowed(number rpc.BlockNumber) bool {
 	return number.IsLatest() && b.IsAllowUnfinalizedQueries()
 }
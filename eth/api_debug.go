@@ -87,12 +87,75 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 
 // Preimage is a debug API function that returns the preimage for a sha3 hash, if known.
 func (api *DebugAPI) Preimage(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
-	if preimage := rawdb.ReadPreimage(api.eth.ChainDb(), hash); preimage != nil {
+	if preimage := api.preimage(hash); preimage != nil {
 		return preimage, nil
 	}
 	return nil, errors.New("unknown preimage")
 }
 
+// ExecutionWitness returns the RLP encoded execution witness of the block
+// [blockNrOrHash]: the state it accesses, proven against the state root of its
+// parent, and the past headers it accesses. It allows the execution of the
+// block to be verified without the full state.
+func (api *DebugAPI) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	witness, err := api.eth.blockchain.ExecutionWitness(block)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(witness)
+}
+
+// StorageKeyPreimage is the result of resolving a hashed storage trie key.
+type StorageKeyPreimage struct {
+	// Slot is the storage slot whose hash is the trie key.
+	Slot common.Hash `json:"slot"`
+	// SlotPreimage is the keccak256 input the slot was derived from, if the
+	// slot was computed by the SHA3 opcode (e.g. mapping or dynamic array
+	// slots) and keccak preimage recording was enabled when it was executed.
+	SlotPreimage hexutil.Bytes `json:"slotPreimage,omitempty"`
+	// MappingKey and MappingSlot are set if [SlotPreimage] has the layout of
+	// a Solidity mapping slot, keccak256(key ++ slot).
+	MappingKey  *common.Hash `json:"mappingKey,omitempty"`
+	MappingSlot *common.Hash `json:"mappingSlot,omitempty"`
+}
+
+// ResolveStorageKey resolves a hashed storage trie key back to its original
+// slot and, when known, to the inputs the slot was derived from.
+// Requires trie key preimages to be recorded (preimages-enabled) and, to
+// resolve derived slots, keccak preimage recording.
+func (api *DebugAPI) ResolveStorageKey(ctx context.Context, hash common.Hash) (*StorageKeyPreimage, error) {
+	slot := api.preimage(hash)
+	if len(slot) != common.HashLength {
+		return nil, fmt.Errorf("unknown storage key preimage for %#x", hash)
+	}
+	res := &StorageKeyPreimage{Slot: common.BytesToHash(slot)}
+	if preimage := api.preimage(res.Slot); preimage != nil {
+		res.SlotPreimage = preimage
+		if len(preimage) == 2*common.HashLength {
+			key, mappingSlot := common.BytesToHash(preimage[:common.HashLength]), common.BytesToHash(preimage[common.HashLength:])
+			res.MappingKey, res.MappingSlot = &key, &mappingSlot
+		}
+	}
+	return res, nil
+}
+
+// preimage returns the preimage of [hash] from the trie database preimage
+// store, which also holds preimages that have not yet been flushed to disk,
+// falling back to the chain database.
+func (api *DebugAPI) preimage(hash common.Hash) []byte {
+	if preimage := api.eth.BlockChain().TrieDB().Preimage(hash); preimage != nil {
+		return preimage
+	}
+	return rawdb.ReadPreimage(api.eth.ChainDb(), hash)
+}
+
 // GetBadBlocks returns a list of the last 'bad blocks' that the client has seen on the network
 // and returns them as a JSON list of block hashes.
 func (api *DebugAPI) GetBadBlocks(ctx context.Context) ([]*ethapi.BadBlockArgs, error) {
@@ -105,9 +168,26 @@ const AccountRangeMaxResults = 256
 
 // AccountRange enumerates all accounts in the given block and start point in paging request
 func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hexutil.Bytes, maxResults int, nocode, nostorage, incompletes bool) (state.IteratorDump, error) {
-	var stateDb *state.StateDB
-	var err error
+	stateDb, err := api.stateAt(blockNrOrHash)
+	if err != nil {
+		return state.IteratorDump{}, err
+	}
+
+	opts := &state.DumpConfig{
+		SkipCode:          nocode,
+		SkipStorage:       nostorage,
+		OnlyWithAddresses: !incompletes,
+		Start:             start,
+		Max:               uint64(maxResults),
+	}
+	if maxResults > AccountRangeMaxResults || maxResults <= 0 {
+		opts.Max = AccountRangeMaxResults
+	}
+	return stateDb.IteratorDump(opts), nil
+}
 
+// stateAt returns the state of the block specified by [blockNrOrHash].
+func (api *DebugAPI) stateAt(blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, error) {
 	if number, ok := blockNrOrHash.Number(); ok {
 		var header *types.Header
 		if number.IsAccepted() {
@@ -119,41 +199,170 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 		} else {
 			block := api.eth.blockchain.GetBlockByNumber(uint64(number))
 			if block == nil {
-				return state.IteratorDump{}, fmt.Errorf("block #%d not found", number)
+				return nil, fmt.Errorf("block #%d not found", number)
 			}
 			header = block.Header()
 		}
 		if header == nil {
-			return state.IteratorDump{}, fmt.Errorf("block #%d not found", number)
-		}
-		stateDb, err = api.eth.BlockChain().StateAt(header.Root)
-		if err != nil {
-			return state.IteratorDump{}, err
+			return nil, fmt.Errorf("block #%d not found", number)
 		}
+		return api.eth.BlockChain().StateAt(header.Root)
 	} else if hash, ok := blockNrOrHash.Hash(); ok {
 		block := api.eth.blockchain.GetBlockByHash(hash)
 		if block == nil {
-			return state.IteratorDump{}, fmt.Errorf("block %s not found", hash.Hex())
-		}
-		stateDb, err = api.eth.BlockChain().StateAt(block.Root())
-		if err != nil {
-			return state.IteratorDump{}, err
+			return nil, fmt.Errorf("block %s not found", hash.Hex())
 		}
-	} else {
-		return state.IteratorDump{}, errors.New("either block number or block hash must be specified")
+		return api.eth.BlockChain().StateAt(block.Root())
+	}
+	return nil, errors.New("either block number or block hash must be specified")
+}
+
+const (
+	// DumpStateMaxAccounts is the maximum number of accounts returned per
+	// debug_dumpState page.
+	DumpStateMaxAccounts = AccountRangeMaxResults
+	// DumpStateMaxStorage is the maximum number of storage slots returned per
+	// debug_dumpState page.
+	DumpStateMaxStorage = 4096
+)
+
+// DumpStateArgs are the options of a state dump.
+type DumpStateArgs struct {
+	// AddressPrefix restricts the dump to the accounts whose address starts with it.
+	AddressPrefix hexutil.Bytes `json:"addressPrefix"`
+	// Next is the pagination token returned by the previous page, if any.
+	Next hexutil.Bytes `json:"next"`
+	// IncludeCode and IncludeStorage add the code and storage of the accounts to the dump.
+	IncludeCode    bool `json:"includeCode"`
+	IncludeStorage bool `json:"includeStorage"`
+	// MaxAccounts and MaxStorage limit the size of a page, up to [DumpStateMaxAccounts]
+	// and [DumpStateMaxStorage].
+	MaxAccounts hexutil.Uint64 `json:"maxAccounts"`
+	MaxStorage  hexutil.Uint64 `json:"maxStorage"`
+}
+
+// StateDumpPage is a page of a state dump. Accounts are ordered by the hash of
+// their address, so that dumping the same state always yields the same pages.
+// An account whose storage does not fit in a page is repeated in the next page
+// with the rest of its storage.
+type StateDumpPage struct {
+	Root     common.Hash         `json:"root"`
+	Accounts []state.DumpAccount `json:"accounts"`
+	Next     hexutil.Bytes       `json:"next,omitempty"` // nil if no more accounts
+}
+
+// OnRoot implements state.DumpCollector interface
+func (p *StateDumpPage) OnRoot(root common.Hash) {
+	p.Root = root
+}
+
+// OnAccount implements state.DumpCollector interface
+func (p *StateDumpPage) OnAccount(addr *common.Address, account state.DumpAccount) {
+	account.Address = addr
+	p.Accounts = append(p.Accounts, account)
+}
+
+// DumpState returns a page of the state of the given block. The dump can be
+// resumed from the next page by passing its [StateDumpPage.Next] token in [args].
+func (api *DebugAPI) DumpState(blockNrOrHash rpc.BlockNumberOrHash, args *DumpStateArgs) (*StateDumpPage, error) {
+	if args == nil {
+		args = new(DumpStateArgs)
+	}
+	opts, err := args.dumpConfig()
+	if err != nil {
+		return nil, err
+	}
+	stateDb, err := api.stateAt(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return dumpStatePage(stateDb, opts), nil
+}
+
+// DumpStateStream streams the pages of the state of the given block, starting
+// from the page of [args], as notifications. The last page has no next token.
+func (api *DebugAPI) DumpStateStream(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, args *DumpStateArgs) (*rpc.Subscription, error) {
+	if args == nil {
+		args = new(DumpStateArgs)
+	}
+	opts, err := args.dumpConfig()
+	if err != nil {
+		return nil, err
+	}
+	stateDb, err := api.stateAt(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	// Dumping the whole state is a **long** operation, only do with subscriptions
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	sub := notifier.CreateSubscription()
 
+	go func() {
+		for {
+			page := dumpStatePage(stateDb, opts)
+			if err := notifier.Notify(sub.ID, page); err != nil {
+				log.Debug("Failed to send state dump page", "err", err)
+				return
+			}
+			if page.Next == nil {
+				return
+			}
+			select {
+			case <-notifier.Closed():
+				return
+			default:
+			}
+			opts.Start, opts.StorageStart = splitDumpStateNext(page.Next)
+		}
+	}()
+	return sub, nil
+}
+
+// dumpConfig returns the state dump config of [args].
+func (args *DumpStateArgs) dumpConfig() (*state.DumpConfig, error) {
 	opts := &state.DumpConfig{
-		SkipCode:          nocode,
-		SkipStorage:       nostorage,
-		OnlyWithAddresses: !incompletes,
-		Start:             start,
-		Max:               uint64(maxResults),
+		SkipCode:          !args.IncludeCode,
+		SkipStorage:       !args.IncludeStorage,
+		OnlyWithAddresses: true,
+		AddressPrefix:     args.AddressPrefix,
+		Max:               uint64(args.MaxAccounts),
+		MaxStorage:        uint64(args.MaxStorage),
 	}
-	if maxResults > AccountRangeMaxResults || maxResults <= 0 {
-		opts.Max = AccountRangeMaxResults
+	if len(args.AddressPrefix) > common.AddressLength {
+		return nil, fmt.Errorf("address prefix too long: %d bytes", len(args.AddressPrefix))
 	}
-	return stateDb.IteratorDump(opts), nil
+	switch len(args.Next) {
+	case 0, common.HashLength, 2 * common.HashLength:
+		opts.Start, opts.StorageStart = splitDumpStateNext(args.Next)
+	default:
+		return nil, fmt.Errorf("invalid state dump next token length: %d", len(args.Next))
+	}
+	if opts.Max == 0 || opts.Max > DumpStateMaxAccounts {
+		opts.Max = DumpStateMaxAccounts
+	}
+	if opts.MaxStorage == 0 || opts.MaxStorage > DumpStateMaxStorage {
+		opts.MaxStorage = DumpStateMaxStorage
+	}
+	return opts, nil
+}
+
+// splitDumpStateNext splits a next token into the account trie key and the
+// storage trie key the dump resumes from.
+func splitDumpStateNext(next []byte) ([]byte, []byte) {
+	if len(next) <= common.HashLength {
+		return next, nil
+	}
+	return next[:common.HashLength], next[common.HashLength:]
+}
+
+// dumpStatePage dumps a page of [stateDb] according to [opts].
+func dumpStatePage(stateDb *state.StateDB, opts *state.DumpConfig) *StateDumpPage {
+	page := &StateDumpPage{Accounts: make([]state.DumpAccount, 0)}
+	page.Next = stateDb.DumpToCollector(page, opts)
+	return page
 }
 
 // StorageRangeResult is the result of a debug_storageRangeAt API call.
@@ -0,0 +1,349 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SubnetEVMAPI provides subnet-evm specific APIs that have no counterpart in
+// upstream go-ethereum.
+type SubnetEVMAPI struct {
+	eth *Ethereum
+}
+
+// NewSubnetEVMAPI creates a new SubnetEVMAPI instance.
+func NewSubnetEVMAPI(eth *Ethereum) *SubnetEVMAPI {
+	return &SubnetEVMAPI{eth: eth}
+}
+
+// StateDiffResult is the result of a subnetevm_getStateDiff API call.
+type StateDiffResult struct {
+	BlockHash   common.Hash          `json:"blockHash"`
+	BlockNumber hexutil.Uint64       `json:"blockNumber"`
+	Accounts    []*AccountDiffResult `json:"accounts"`
+}
+
+// AccountDiffResult describes the changes applied to a single account. Fields
+// that were left unchanged by the block are omitted.
+type AccountDiffResult struct {
+	Address    common.Address           `json:"address"`
+	Created    bool                     `json:"created,omitempty"`
+	Deleted    bool                     `json:"deleted,omitempty"`
+	Destructed bool                     `json:"destructed,omitempty"`
+	Balance    *BalanceDiff             `json:"balance,omitempty"`
+	Nonce      *NonceDiff               `json:"nonce,omitempty"`
+	CodeHash   *HashDiff                `json:"codeHash,omitempty"`
+	Storage    map[common.Hash]HashDiff `json:"storage,omitempty"`
+}
+
+// BalanceDiff is the balance of an account before and after a block.
+type BalanceDiff struct {
+	From *hexutil.Big `json:"from"`
+	To   *hexutil.Big `json:"to"`
+}
+
+// NonceDiff is the nonce of an account before and after a block.
+type NonceDiff struct {
+	From hexutil.Uint64 `json:"from"`
+	To   hexutil.Uint64 `json:"to"`
+}
+
+// HashDiff is a hash value (code hash or storage slot) before and after a block.
+type HashDiff struct {
+	From common.Hash `json:"from"`
+	To   common.Hash `json:"to"`
+}
+
+// GetStateDiff returns the accounts changed by the block with the given hash,
+// along with their balance, nonce, code and storage deltas.
+//
+// If state diffs are persisted by the node the stored diff is returned,
+// otherwise the block is re-executed on top of its parent state, for at most
+// the maximum duration of the API calls.
+func (api *SubnetEVMAPI) GetStateDiff(ctx context.Context, blockHash common.Hash) (*StateDiffResult, error) {
+	block := api.eth.blockchain.GetBlockByHash(blockHash)
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", blockHash)
+	}
+	diff := rawdb.ReadStateDiff(api.eth.ChainDb(), blockHash)
+	if diff == nil {
+		var err error
+		if diff, err = api.computeStateDiff(ctx, block); err != nil {
+			return nil, err
+		}
+	}
+	return newStateDiffResult(block, diff)
+}
+
+// computeStateDiff re-executes [block] on top of its parent state and returns
+// the resulting state diff. The re-execution is aborted once it exceeds the
+// maximum duration of the API calls, if any.
+func (api *SubnetEVMAPI) computeStateDiff(ctx context.Context, block *types.Block) (*types.StateDiff, error) {
+	if block.NumberU64() == 0 {
+		return nil, fmt.Errorf("state diff is not available for the genesis block")
+	}
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, release, err := api.eth.StateAtBlock(ctx, parent, 0, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if timeout := api.eth.config.RPCEVMTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	abort := newAbortLogger(ctx)
+	_, _, _, err = api.eth.blockchain.Processor().Process(block, parent.Header(), statedb, vm.Config{Tracer: abort})
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("processing block %d aborted: %w", block.NumberU64(), ctx.Err())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("processing block %d failed: %w", block.NumberU64(), err)
+	}
+	statedb.IntermediateRoot(api.eth.blockchain.Config().IsEIP158(block.Number()))
+	return statedb.StateDiff(), nil
+}
+
+// abortLogger cancels the EVM executing the transactions of a block once [done]
+// is closed, since the processing of a block cannot be interrupted otherwise.
+type abortLogger struct {
+	done <-chan struct{}
+	env  *vm.EVM
+}
+
+func newAbortLogger(ctx context.Context) *abortLogger {
+	return &abortLogger{done: ctx.Done()}
+}
+
+func (l *abortLogger) abortIfDone() {
+	select {
+	case <-l.done:
+		l.env.Cancel()
+	default:
+	}
+}
+
+func (l *abortLogger) CaptureTxStart(uint64) {}
+func (l *abortLogger) CaptureTxEnd(uint64)   {}
+func (l *abortLogger) CaptureStart(env *vm.EVM, _ common.Address, _ common.Address, _ bool, _ []byte, _ uint64, _ *big.Int) {
+	l.env = env
+	l.abortIfDone()
+}
+func (l *abortLogger) CaptureEnd([]byte, uint64, error) {}
+func (l *abortLogger) CaptureEnter(vm.OpCode, common.Address, common.Address, []byte, uint64, *big.Int) {
+}
+func (l *abortLogger) CaptureExit([]byte, uint64, error) {}
+func (l *abortLogger) CaptureState(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, []byte, int, error) {
+	l.abortIfDone()
+}
+func (l *abortLogger) CaptureFault(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error) {}
+
+func newStateDiffResult(block *types.Block, diff *types.StateDiff) (*StateDiffResult, error) {
+	result := &StateDiffResult{
+		BlockHash:   block.Hash(),
+		BlockNumber: hexutil.Uint64(block.NumberU64()),
+		Accounts:    make([]*AccountDiffResult, 0, len(diff.Accounts)),
+	}
+	for _, account := range diff.Accounts {
+		prev, err := decodeDiffAccount(account.Prev)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous account %s: %w", account.Address, err)
+		}
+		post, err := decodeDiffAccount(account.Post)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account %s: %w", account.Address, err)
+		}
+		res := &AccountDiffResult{
+			Address:    account.Address,
+			Created:    len(account.Prev) == 0,
+			Deleted:    len(account.Post) == 0,
+			Destructed: account.Destructed,
+		}
+		if prev.Balance.Cmp(post.Balance) != 0 {
+			res.Balance = &BalanceDiff{From: (*hexutil.Big)(prev.Balance), To: (*hexutil.Big)(post.Balance)}
+		}
+		if prev.Nonce != post.Nonce {
+			res.Nonce = &NonceDiff{From: hexutil.Uint64(prev.Nonce), To: hexutil.Uint64(post.Nonce)}
+		}
+		if prevCode, postCode := common.BytesToHash(prev.CodeHash), common.BytesToHash(post.CodeHash); prevCode != postCode {
+			res.CodeHash = &HashDiff{From: prevCode, To: postCode}
+		}
+		for _, slot := range account.Storage {
+			from, err := decodeDiffSlot(slot.Prev)
+			if err != nil {
+				return nil, fmt.Errorf("invalid previous storage slot %s of %s: %w", slot.Key, account.Address, err)
+			}
+			to, err := decodeDiffSlot(slot.Post)
+			if err != nil {
+				return nil, fmt.Errorf("invalid storage slot %s of %s: %w", slot.Key, account.Address, err)
+			}
+			if from == to {
+				continue
+			}
+			if res.Storage == nil {
+				res.Storage = make(map[common.Hash]HashDiff)
+			}
+			res.Storage[slot.Key] = HashDiff{From: from, To: to}
+		}
+		result.Accounts = append(result.Accounts, res)
+	}
+	return result, nil
+}
+
+// BlobSidecarResult is the result of a subnetevm_getBlobSidecar API call.
+type BlobSidecarResult struct {
+	TxHash      common.Hash     `json:"txHash"`
+	BlobHashes  []common.Hash   `json:"blobVersionedHashes"`
+	Blobs       []hexutil.Bytes `json:"blobs"`
+	Commitments []hexutil.Bytes `json:"commitments"`
+	Proofs      []hexutil.Bytes `json:"proofs"`
+}
+
+// GetBlobSidecar returns the blobs of the blob transaction with the given hash.
+//
+// Blobs are not part of the block body and are not gossiped, so they are only
+// available on nodes that received the transaction over RPC, for as long as the
+// transaction is pending or was accepted within the configured blob retention.
+func (api *SubnetEVMAPI) GetBlobSidecar(txHash common.Hash) (*BlobSidecarResult, error) {
+	sidecar := api.eth.blobPool.GetBlobSidecar(txHash)
+	if sidecar == nil {
+		return nil, fmt.Errorf("blobs of transaction %#x not found", txHash)
+	}
+	result := &BlobSidecarResult{
+		TxHash:      txHash,
+		BlobHashes:  sidecar.BlobHashes(),
+		Blobs:       make([]hexutil.Bytes, len(sidecar.Blobs)),
+		Commitments: make([]hexutil.Bytes, len(sidecar.Commitments)),
+		Proofs:      make([]hexutil.Bytes, len(sidecar.Proofs)),
+	}
+	for i := range sidecar.Blobs {
+		result.Blobs[i] = sidecar.Blobs[i][:]
+	}
+	for i := range sidecar.Commitments {
+		result.Commitments[i] = sidecar.Commitments[i][:]
+	}
+	for i := range sidecar.Proofs {
+		result.Proofs[i] = sidecar.Proofs[i][:]
+	}
+	return result, nil
+}
+
+// ContractGasUsageResult is the result of a subnetevm_getContractGasUsage API
+// call.
+type ContractGasUsageResult struct {
+	FromBlock    hexutil.Uint64          `json:"fromBlock"`
+	ToBlock      hexutil.Uint64          `json:"toBlock"`
+	WindowSize   hexutil.Uint64          `json:"windowSize"`
+	TotalGasUsed hexutil.Uint64          `json:"totalGasUsed"`
+	Contracts    []*ContractGasUsageItem `json:"contracts"`
+}
+
+// ContractGasUsageItem is the gas consumed by the transactions sent to a
+// single contract.
+type ContractGasUsageItem struct {
+	Address common.Address `json:"address"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	TxCount hexutil.Uint64 `json:"txCount"`
+}
+
+// GetContractGasUsage returns the contracts that consumed the most gas in the
+// last [windows] windows of the contract gas usage index, up to and including
+// the window of the last accepted block, sorted by gas used. At most [limit]
+// contracts are returned if [limit] is non-zero.
+//
+// The index is only maintained if gas-usage-index-window is configured.
+func (api *SubnetEVMAPI) GetContractGasUsage(windows *hexutil.Uint64, limit *hexutil.Uint64) (*ContractGasUsageResult, error) {
+	cacheConfig := api.eth.blockchain.CacheConfig()
+	if cacheConfig.GasUsageWindow == 0 {
+		return nil, errors.New("contract gas usage index is not enabled")
+	}
+	count := uint64(1)
+	if windows != nil {
+		count = uint64(*windows)
+	}
+	if count == 0 || (cacheConfig.GasUsageRetention > 0 && count > cacheConfig.GasUsageRetention) {
+		return nil, fmt.Errorf("number of windows must be between 1 and %d", cacheConfig.GasUsageRetention)
+	}
+	var (
+		size   = cacheConfig.GasUsageWindow
+		last   = api.eth.blockchain.LastAcceptedBlock().NumberU64() / size
+		first  = uint64(0)
+		usages = make(map[common.Address]*ContractGasUsageItem)
+		db     = api.eth.ChainDb()
+		result = &ContractGasUsageResult{WindowSize: hexutil.Uint64(size)}
+	)
+	if last >= count {
+		first = last - count + 1
+	}
+	for window := first; window <= last; window++ {
+		for address, usage := range rawdb.ReadContractGasUsages(db, window) {
+			item, ok := usages[address]
+			if !ok {
+				item = &ContractGasUsageItem{Address: address}
+				usages[address] = item
+			}
+			item.GasUsed += hexutil.Uint64(usage.GasUsed)
+			item.TxCount += hexutil.Uint64(usage.TxCount)
+			result.TotalGasUsed += hexutil.Uint64(usage.GasUsed)
+		}
+	}
+	result.FromBlock = hexutil.Uint64(first * size)
+	result.ToBlock = hexutil.Uint64((last+1)*size - 1)
+	result.Contracts = make([]*ContractGasUsageItem, 0, len(usages))
+	for _, item := range usages {
+		result.Contracts = append(result.Contracts, item)
+	}
+	sort.Slice(result.Contracts, func(i, j int) bool {
+		if result.Contracts[i].GasUsed != result.Contracts[j].GasUsed {
+			return result.Contracts[i].GasUsed > result.Contracts[j].GasUsed
+		}
+		return result.Contracts[i].Address.Cmp(result.Contracts[j].Address) < 0
+	})
+	if limit != nil && *limit > 0 && uint64(len(result.Contracts)) > uint64(*limit) {
+		result.Contracts = result.Contracts[:*limit]
+	}
+	return result, nil
+}
+
+// decodeDiffAccount decodes a slim RLP encoded account, returning an empty
+// account if [data] is empty.
+func decodeDiffAccount(data []byte) (*types.StateAccount, error) {
+	if len(data) == 0 {
+		return &types.StateAccount{
+			Balance:  new(big.Int),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash.Bytes(),
+		}, nil
+	}
+	return types.FullAccount(data)
+}
+
+// decodeDiffSlot decodes a prefix-zero trimmed RLP encoded storage value.
+func decodeDiffSlot(data []byte) (common.Hash, error) {
+	if len(data) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(content), nil
+}
@@ -71,6 +71,7 @@ var DefaultSettings Settings = Settings{MaxBlocksPerRequest: 2000}
 
 type Settings struct {
 	MaxBlocksPerRequest int64 // Maximum number of blocks to serve per getLogs request
+	MaxLogsPerRequest   int   // Maximum number of logs to serve per getLogs request (0 = unlimited)
 }
 
 // PushGossiper sends pushes pending transactions to peers until they are
@@ -84,7 +85,8 @@ type Ethereum struct {
 	config *Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool   *txpool.TxPool
+	blobPool *blobpool.BlobPool
 
 	blockchain *core.BlockChain
 	gossiper   PushGossiper
@@ -171,7 +173,7 @@ func New(
 		networkID:         config.NetworkId,
 		etherbase:         config.Miner.Etherbase,
 		bloomRequests:     make(chan chan *bloombits.Retrieval),
-		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
+		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, 0),
 		settings:          settings,
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
 	}
@@ -212,9 +214,17 @@ func New(
 			SnapshotVerify:                  config.SnapshotVerify,
 			SnapshotNoBuild:                 config.SkipSnapshotRebuild,
 			Preimages:                       config.Preimages,
+			StateDiffs:                      config.StateDiffs,
+			StateDiffsRetention:             config.StateDiffsRetention,
+			GasUsageWindow:                  config.GasUsageWindow,
+			GasUsageRetention:               config.GasUsageRetention,
 			AcceptedCacheSize:               config.AcceptedCacheSize,
 			TxLookupLimit:                   config.TxLookupLimit,
 			SkipTxIndexing:                  config.SkipTxIndexing,
+			ParallelTxExecution:             config.ParallelTxExecution,
+			AsyncStateCommit:                config.AsyncStateCommit,
+			StateHistory:                    config.StateHistory,
+			StateHistoryDuration:            config.StateHistoryDuration,
 		}
 	)
 
@@ -236,14 +246,17 @@ func New(
 		return nil, err
 	}
 
-	eth.bloomIndexer.Start(eth.blockchain)
+	// Index the accepted blocks, which are final, so that sections are indexed
+	// as soon as they are accepted and eth_getLogs scans at most one section of
+	// headers past the index.
+	eth.bloomIndexer.Start(eth.blockchain.AcceptedIndexerChain())
 
 	config.BlobPool.Datadir = ""
-	blobPool := blobpool.New(config.BlobPool, &chainWithFinalBlock{eth.blockchain})
+	eth.blobPool = blobpool.New(config.BlobPool, &chainWithFinalBlock{eth.blockchain})
 
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
 
-	eth.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), eth.blockchain, []txpool.SubPool{legacyPool, blobPool})
+	eth.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), eth.blockchain, []txpool.SubPool{legacyPool, eth.blobPool})
 	if err != nil {
 		return nil, err
 	}
@@ -320,6 +333,10 @@ func (s *Ethereum) APIs() []rpc.API {
 			Namespace: "net",
 			Service:   s.netRPCService,
 			Name:      "net",
+		}, {
+			Namespace: "subnetevm",
+			Service:   NewSubnetEVMAPI(s),
+			Name:      "subnetevm",
 		},
 	}...)
 }
@@ -423,11 +440,15 @@ func (s *Ethereum) precheckPopulateMissingTries() error {
 }
 
 func (s *Ethereum) handleOfflinePruning(cacheConfig *core.CacheConfig, gspec *core.Genesis, vmConfig vm.Config, lastAcceptedHash common.Hash) error {
-	if s.config.OfflinePruning && !s.config.Pruning {
+	// Offline pruning may also be requested through the admin API, in which
+	// case it runs once on the next start.
+	requestedAt, err := rawdb.ReadOfflinePruningRequest(s.chainDb)
+	requested := err == nil
+	if (s.config.OfflinePruning || requested) && !s.config.Pruning {
 		return core.ErrRefuseToCorruptArchiver
 	}
 
-	if !s.config.OfflinePruning {
+	if !s.config.OfflinePruning && !requested {
 		// Delete the offline pruning marker to indicate that the node started with offline pruning disabled.
 		if err := rawdb.DeleteOfflinePruning(s.chainDb); err != nil {
 			return fmt.Errorf("failed to write offline pruning disabled marker: %w", err)
@@ -439,9 +460,18 @@ func (s *Ethereum) handleOfflinePruning(cacheConfig *core.CacheConfig, gspec *co
 	// to the last accepted block before pruning begins.
 	// If offline pruning marker is on disk, then we force the node to be started with offline pruning disabled
 	// before allowing another run of offline pruning.
-	if lastRun, err := rawdb.ReadOfflinePruning(s.chainDb); err == nil {
-		log.Error("Offline pruning is not meant to be left enabled permanently. Please disable offline pruning and allow your node to start successfully before running offline pruning again.")
-		return fmt.Errorf("cannot start chain with offline pruning enabled on consecutive starts (last=%v)", lastRun)
+	if s.config.OfflinePruning {
+		if lastRun, err := rawdb.ReadOfflinePruning(s.chainDb); err == nil {
+			log.Error("Offline pruning is not meant to be left enabled permanently. Please disable offline pruning and allow your node to start successfully before running offline pruning again.")
+			return fmt.Errorf("cannot start chain with offline pruning enabled on consecutive starts (last=%v)", lastRun)
+		}
+	} else {
+		// The admin API refuses requests without a data directory, but it may
+		// have been unset since.
+		if len(s.config.OfflinePruningDataDirectory) == 0 {
+			return errors.New("cannot run requested offline pruning without a data directory")
+		}
+		log.Info("Running requested offline pruning", "requestedAt", requestedAt)
 	}
 
 	// Clean up middle roots
@@ -466,6 +496,9 @@ func (s *Ethereum) handleOfflinePruning(cacheConfig *core.CacheConfig, gspec *co
 	if err := pruner.Prune(targetRoot); err != nil {
 		return fmt.Errorf("failed to prune blockchain with target root: %s due to: %w", targetRoot, err)
 	}
+	if err := rawdb.DeleteOfflinePruningRequest(s.chainDb); err != nil {
+		return fmt.Errorf("failed to delete offline pruning request: %w", err)
+	}
 	// Note: Time Marker is written inside of [Prune] before compaction begins
 	// (considered an optional optimization)
 	s.blockchain, err = core.NewBlockChain(s.chainDb, cacheConfig, gspec, s.engine, vmConfig, lastAcceptedHash, s.config.SkipUpgradeCheck)
@@ -103,6 +103,17 @@ type Config struct {
 	TriePrefetcherParallelism int
 	SnapshotCache             int
 	Preimages                 bool
+	StateDiffs                bool
+
+	// StateDiffsRetention is the number of recent accepted blocks whose state
+	// diffs are retained, or all of them if zero.
+	StateDiffsRetention uint64
+
+	// GasUsageWindow is the number of blocks per window of the contract gas
+	// usage index, which is disabled if zero. GasUsageRetention is the number
+	// of recent windows retained in the index.
+	GasUsageWindow    uint64
+	GasUsageRetention uint64
 
 	// AcceptedCacheSize is the depth of accepted headers cache and accepted
 	// logs cache at the accepted tip.
@@ -127,6 +138,14 @@ type Config struct {
 	// RPCEVMTimeout is the global timeout for eth-call.
 	RPCEVMTimeout time.Duration
 
+	// RPCTraceTimeout is the timeout of a transaction trace that does not
+	// specify a timeout (0 = 5 seconds).
+	RPCTraceTimeout time.Duration
+
+	// RPCMaxTraceTimeout is the maximum timeout a transaction trace can
+	// specify (0 = unlimited).
+	RPCMaxTraceTimeout time.Duration
+
 	// RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64 `toml:",omitempty"`
@@ -164,4 +183,19 @@ type Config struct {
 	// This is useful for validators that don't need to index transactions.
 	// TxLookupLimit can be still used to control unindexing old transactions.
 	SkipTxIndexing bool
+
+	// ParallelTxExecution is the number of goroutines speculatively executing
+	// the transactions of a block during verification (0 = sequential).
+	ParallelTxExecution int
+
+	// AsyncStateCommit hashes and commits the state of verified blocks in the background.
+	AsyncStateCommit bool
+
+	// StateHistory is the number of recent accepted state roots retained
+	// when pruning is enabled (0 = the default tip buffer).
+	StateHistory uint64
+
+	// StateHistoryDuration additionally retains the accepted state roots of
+	// blocks younger than this duration when pruning is enabled.
+	StateHistoryDuration time.Duration
 }
@@ -32,6 +32,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -151,10 +152,49 @@ func (api *FilterAPI) NewPendingTransactionFilter(fullTx *bool) rpc.ID {
 	return pendingTxSub.ID
 }
 
+// PendingTxCriteria selects the transactions notified by a pending transactions
+// subscription. A transaction is selected if it matches every non-empty field.
+type PendingTxCriteria struct {
+	From []common.Address `json:"from"` // Senders of the transaction
+	To   []common.Address `json:"to"`   // Recipients of the transaction
+	// Precompiles selects the transactions calling one of the precompiles or
+	// including it in their access list, e.g. to carry warp message predicates.
+	Precompiles []common.Address `json:"precompiles"`
+}
+
+// matches returns whether [tx] is selected by the criteria.
+func (c *PendingTxCriteria) matches(tx *types.Transaction, signer types.Signer) bool {
+	if c == nil {
+		return true
+	}
+	if len(c.From) > 0 {
+		from, err := types.Sender(signer, tx)
+		if err != nil || !slices.Contains(c.From, from) {
+			return false
+		}
+	}
+	if len(c.To) > 0 && (tx.To() == nil || !slices.Contains(c.To, *tx.To())) {
+		return false
+	}
+	if len(c.Precompiles) > 0 {
+		if tx.To() != nil && slices.Contains(c.Precompiles, *tx.To()) {
+			return true
+		}
+		for _, tuple := range tx.AccessList() {
+			if slices.Contains(c.Precompiles, tuple.Address) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
 // NewPendingTransactions creates a subscription that is triggered each time a
 // transaction enters the transaction pool. If fullTx is true the full tx is
-// sent to the client, otherwise the hash is sent.
-func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+// sent to the client, otherwise the hash is sent. If criteria is given, only
+// the transactions it selects are sent.
+func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool, criteria *PendingTxCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
@@ -166,6 +206,7 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 		txs := make(chan []*types.Transaction, 128)
 		pendingTxSub := api.events.SubscribePendingTxs(txs)
 		chainConfig := api.sys.backend.ChainConfig()
+		signer := types.LatestSigner(chainConfig)
 
 		for {
 			select {
@@ -174,6 +215,9 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 				// TODO(rjl493456442) Send a batch of tx hashes in one notification
 				latest := api.sys.backend.CurrentHeader()
 				for _, tx := range txs {
+					if !criteria.matches(tx, signer) {
+						continue
+					}
 					if fullTx != nil && *fullTx {
 						rpcTx := ethapi.NewRPCTransaction(tx, latest, latest.BaseFee, chainConfig)
 						notifier.Notify(rpcSub.ID, rpcTx)
@@ -240,19 +284,31 @@ func (api *FilterAPI) NewAcceptedTransactions(ctx context.Context, fullTx *bool)
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 func (api *FilterAPI) NewBlockFilter() rpc.ID {
+	return api.newBlockFilter(!api.sys.backend.IsAllowUnfinalizedQueries())
+}
+
+// NewAcceptedBlockFilter creates a filter that fetches the blocks accepted by
+// consensus, even if the node allows unfinalized queries.
+func (api *FilterAPI) NewAcceptedBlockFilter() rpc.ID {
+	return api.newBlockFilter(true)
+}
+
+func (api *FilterAPI) newBlockFilter(acceptedOnly bool) rpc.ID {
 	var (
 		headers   = make(chan *types.Header)
 		headerSub *Subscription
+		typ       = BlocksSubscription
 	)
 
-	if api.sys.backend.IsAllowUnfinalizedQueries() {
-		headerSub = api.events.SubscribeNewHeads(headers)
-	} else {
+	if acceptedOnly {
 		headerSub = api.events.SubscribeAcceptedHeads(headers)
+		typ = AcceptedBlocksSubscription
+	} else {
+		headerSub = api.events.SubscribeNewHeads(headers)
 	}
 
 	api.filtersMu.Lock()
-	api.filters[headerSub.ID] = &filter{typ: BlocksSubscription, deadline: time.NewTimer(api.timeout), hashes: make([]common.Hash, 0), s: headerSub}
+	api.filters[headerSub.ID] = &filter{typ: typ, deadline: time.NewTimer(api.timeout), hashes: make([]common.Hash, 0), s: headerSub}
 	api.filtersMu.Unlock()
 
 	go func() {
@@ -277,7 +333,21 @@ func (api *FilterAPI) NewBlockFilter() rpc.ID {
 }
 
 // NewHeads send a notification each time a new (header) block is appended to the chain.
+// Each notification also reports whether the block is accepted and the last accepted
+// height of the node, so subscribers can detect lag without polling.
 func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribeHeads(ctx, !api.sys.backend.IsAllowUnfinalizedQueries())
+}
+
+// AcceptedHeads send a notification each time a block is accepted by consensus,
+// even if the node allows unfinalized queries, so subscribers never act on a
+// block that may not be accepted. The notifications have the same payload as
+// NewHeads.
+func (api *FilterAPI) AcceptedHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribeHeads(ctx, true)
+}
+
+func (api *FilterAPI) subscribeHeads(ctx context.Context, acceptedOnly bool) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
@@ -291,16 +361,16 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 			headersSub event.Subscription
 		)
 
-		if api.sys.backend.IsAllowUnfinalizedQueries() {
-			headersSub = api.events.SubscribeNewHeads(headers)
-		} else {
+		if acceptedOnly {
 			headersSub = api.events.SubscribeAcceptedHeads(headers)
+		} else {
+			headersSub = api.events.SubscribeNewHeads(headers)
 		}
 
 		for {
 			select {
 			case h := <-headers:
-				notifier.Notify(rpcSub.ID, h)
+				notifier.Notify(rpcSub.ID, api.headNotification(h, acceptedOnly))
 			case <-rpcSub.Err():
 				headersSub.Unsubscribe()
 				return
@@ -314,8 +384,36 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 	return rpcSub, nil
 }
 
+// headNotification returns the newHeads payload of [header] with its acceptance status and
+// the last accepted height of the node.
+// If [accepted] is true, the header is known to come from an accepted block.
+func (api *FilterAPI) headNotification(header *types.Header, accepted bool) interfaces.HeadNotification {
+	lastAcceptedHeight := api.sys.backend.LastAcceptedBlock().NumberU64()
+	if !accepted && header.Number.Uint64() <= lastAcceptedHeight {
+		// Below the last accepted height, the canonical chain only contains accepted blocks.
+		canonical, err := api.sys.backend.HeaderByNumber(context.Background(), rpc.BlockNumber(header.Number.Int64()))
+		accepted = err == nil && canonical != nil && canonical.Hash() == header.Hash()
+	}
+	return interfaces.HeadNotification{
+		Header:             header,
+		Accepted:           accepted,
+		LastAcceptedHeight: lastAcceptedHeight,
+	}
+}
+
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
 func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	return api.subscribeLogs(ctx, crit, !api.sys.backend.IsAllowUnfinalizedQueries())
+}
+
+// AcceptedLogs creates a subscription that fires for the logs of the blocks
+// accepted by consensus that match the given filter criteria, even if the node
+// allows unfinalized queries.
+func (api *FilterAPI) AcceptedLogs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	return api.subscribeLogs(ctx, crit, true)
+}
+
+func (api *FilterAPI) subscribeLogs(ctx context.Context, crit FilterCriteria, acceptedOnly bool) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
@@ -328,13 +426,13 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 		err         error
 	)
 
-	if api.sys.backend.IsAllowUnfinalizedQueries() {
-		logsSub, err = api.events.SubscribeLogs(interfaces.FilterQuery(crit), matchedLogs)
+	if acceptedOnly {
+		logsSub, err = api.events.SubscribeAcceptedLogs(interfaces.FilterQuery(crit), matchedLogs)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		logsSub, err = api.events.SubscribeAcceptedLogs(interfaces.FilterQuery(crit), matchedLogs)
+		logsSub, err = api.events.SubscribeLogs(interfaces.FilterQuery(crit), matchedLogs)
 		if err != nil {
 			return nil, err
 		}
@@ -377,26 +475,39 @@ type FilterCriteria interfaces.FilterQuery
 //
 // In case "fromBlock" > "toBlock" an error is returned.
 func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
+	return api.newFilter(crit, !api.sys.backend.IsAllowUnfinalizedQueries())
+}
+
+// NewAcceptedFilter creates a filter like NewFilter, which only fetches the
+// logs of the blocks accepted by consensus, even if the node allows unfinalized
+// queries.
+func (api *FilterAPI) NewAcceptedFilter(crit FilterCriteria) (rpc.ID, error) {
+	return api.newFilter(crit, true)
+}
+
+func (api *FilterAPI) newFilter(crit FilterCriteria, acceptedOnly bool) (rpc.ID, error) {
 	var (
 		logs    = make(chan []*types.Log)
 		logsSub *Subscription
 		err     error
+		typ     = LogsSubscription
 	)
 
-	if api.sys.backend.IsAllowUnfinalizedQueries() {
-		logsSub, err = api.events.SubscribeLogs(interfaces.FilterQuery(crit), logs)
+	if acceptedOnly {
+		logsSub, err = api.events.SubscribeAcceptedLogs(interfaces.FilterQuery(crit), logs)
 		if err != nil {
 			return "", err
 		}
+		typ = AcceptedLogsSubscription
 	} else {
-		logsSub, err = api.events.SubscribeAcceptedLogs(interfaces.FilterQuery(crit), logs)
+		logsSub, err = api.events.SubscribeLogs(interfaces.FilterQuery(crit), logs)
 		if err != nil {
 			return "", err
 		}
 	}
 
 	api.filtersMu.Lock()
-	api.filters[logsSub.ID] = &filter{typ: LogsSubscription, crit: crit, deadline: time.NewTimer(api.timeout), logs: make([]*types.Log, 0), s: logsSub}
+	api.filters[logsSub.ID] = &filter{typ: typ, crit: crit, deadline: time.NewTimer(api.timeout), logs: make([]*types.Log, 0), s: logsSub}
 	api.filtersMu.Unlock()
 
 	go func() {
@@ -471,7 +582,7 @@ func (api *FilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Lo
 	f, found := api.filters[id]
 	api.filtersMu.Unlock()
 
-	if !found || f.typ != LogsSubscription {
+	if !found || (f.typ != LogsSubscription && f.typ != AcceptedLogsSubscription) {
 		return nil, errFilterNotFound
 	}
 
@@ -492,6 +603,17 @@ func (api *FilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Lo
 		if f.crit.ToBlock != nil {
 			end = f.crit.ToBlock.Int64()
 		}
+		if f.typ == AcceptedLogsSubscription {
+			// Accepted filters never return the logs of blocks past the last
+			// accepted block.
+			lastAccepted := api.sys.backend.LastAcceptedBlock().Number().Int64()
+			if begin < 0 {
+				begin = lastAccepted
+			}
+			if end < 0 || end > lastAccepted {
+				end = lastAccepted
+			}
+		}
 		// Construct the range filter
 		filter = api.sys.NewRangeFilter(begin, end, f.crit.Addresses, f.crit.Topics)
 	}
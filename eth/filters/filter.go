@@ -34,10 +34,19 @@ import (
 
 	"github.com/ava-labs/subnet-evm/core/bloombits"
 	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var (
+	// indexedBlocksCounter counts the blocks of range queries searched through
+	// the bloombits index, and unindexedBlocksCounter those whose headers were
+	// scanned because their section is not indexed yet.
+	indexedBlocksCounter   = metrics.NewRegisteredCounter("eth/filters/logs/indexed", nil)
+	unindexedBlocksCounter = metrics.NewRegisteredCounter("eth/filters/logs/unindexed", nil)
+)
+
 // Filter can be used to retrieve and filter logs.
 type Filter struct {
 	sys *FilterSystem
@@ -115,7 +124,14 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		if header == nil {
 			return nil, errors.New("unknown block")
 		}
-		return f.blockLogs(ctx, header)
+		logs, err := f.blockLogs(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+		if maxLogs := f.sys.backend.GetMaxLogsPerRequest(); maxLogs > 0 && len(logs) > maxLogs {
+			return nil, errTooManyLogs(maxLogs)
+		}
+		return logs, nil
 	}
 
 	// Disallow blocks past the last accepted block if the backend does not
@@ -196,15 +212,36 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	// If the requested range of blocks exceeds the maximum number of blocks allowed by the backend
 	// return an error instead of searching for the logs.
 	if maxBlocks := f.sys.backend.GetMaxBlocksPerRequest(); f.end-f.begin >= maxBlocks && maxBlocks > 0 {
-		return nil, fmt.Errorf("requested too many blocks from %d to %d, maximum is set to %d", f.begin, f.end, maxBlocks)
+		return nil, &rpc.LimitExceededError{
+			Message: fmt.Sprintf("requested too many blocks from %d to %d, maximum is set to %d", f.begin, f.end, maxBlocks),
+			Limit:   "maxBlocksPerRequest",
+			Max:     maxBlocks,
+		}
 	}
 	// Gather all indexed logs, and finish with non indexed ones
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	logChan, errChan := f.rangeLogsAsync(ctx)
+	maxLogs := f.sys.backend.GetMaxLogsPerRequest()
 	var logs []*types.Log
 	for {
 		select {
 		case log := <-logChan:
 			logs = append(logs, log)
+			if maxLogs > 0 && len(logs) > maxLogs {
+				// Stop the search, draining the logs found until it returns
+				cancel()
+				go func() {
+					for {
+						select {
+						case <-logChan:
+						case <-errChan:
+							return
+						}
+					}
+				}()
+				return nil, errTooManyLogs(maxLogs)
+			}
 		case err := <-errChan:
 			if err != nil {
 				// if an error occurs during extraction, we do return the extracted data
@@ -215,6 +252,15 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	}
 }
 
+// errTooManyLogs returns the error of a request matching more than [maxLogs] logs.
+func errTooManyLogs(maxLogs int) error {
+	return &rpc.LimitExceededError{
+		Message: fmt.Sprintf("query returned more than %d logs", maxLogs),
+		Limit:   "maxLogsPerRequest",
+		Max:     maxLogs,
+	}
+}
+
 // rangeLogsAsync retrieves block-range logs that match the filter criteria asynchronously,
 // it creates and returns two channels: one for delivering log data, and one for reporting errors.
 func (f *Filter) rangeLogsAsync(ctx context.Context) (chan *types.Log, chan error) {
@@ -259,6 +305,8 @@ func (f *Filter) rangeLogsAsync(ctx context.Context) (chan *types.Log, chan erro
 // indexedLogs returns the logs matching the filter criteria based on the bloom
 // bits indexed available locally or via the network.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64, logChan chan *types.Log) error {
+	indexedBlocksCounter.Inc(int64(end + 1 - uint64(f.begin)))
+
 	// Create a matcher session and request servicing from the backend
 	matches := make(chan uint64, 64)
 
@@ -310,6 +358,7 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64, logChan chan *ty
 		if header == nil || err != nil {
 			return err
 		}
+		unindexedBlocksCounter.Inc(1)
 		found, err := f.blockLogs(ctx, header)
 		if err != nil {
 			return err
@@ -87,6 +87,7 @@ type Backend interface {
 	IsAllowUnfinalizedQueries() bool
 	LastAcceptedBlock() *types.Block
 	GetMaxBlocksPerRequest() int64
+	GetMaxLogsPerRequest() int
 }
 
 // FilterSystem holds resources shared by all filters.
@@ -28,6 +28,7 @@ package filters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -47,6 +48,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/stretchr/testify/require"
@@ -54,10 +56,12 @@ import (
 
 type testBackend struct {
 	db                ethdb.Database
+	maxLogs           int
 	sections          uint64
 	txFeed            event.Feed
 	acceptedTxFeed    event.Feed
 	logsFeed          event.Feed
+	acceptedLogsFeed  event.Feed
 	rmLogsFeed        event.Feed
 	pendingLogsFeed   event.Feed
 	chainFeed         event.Feed
@@ -85,6 +89,10 @@ func (b *testBackend) GetMaxBlocksPerRequest() int64 {
 	return 0
 }
 
+func (b *testBackend) GetMaxLogsPerRequest() int {
+	return b.maxLogs
+}
+
 func (b *testBackend) LastAcceptedBlock() *types.Block {
 	return rawdb.ReadHeadBlock(b.db)
 }
@@ -168,7 +176,7 @@ func (b *testBackend) SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Su
 }
 
 func (b *testBackend) SubscribeAcceptedLogsEvent(ch chan<- []*types.Log) event.Subscription {
-	return b.logsFeed.Subscribe(ch)
+	return b.acceptedLogsFeed.Subscribe(ch)
 }
 
 func (b *testBackend) SubscribeAcceptedTransactionEvent(ch chan<- core.NewTxsEvent) event.Subscription {
@@ -280,6 +288,119 @@ func TestBlockSubscription(t *testing.T) {
 	<-sub1.Err()
 }
 
+// TestHeadNotification tests that head notifications report the acceptance status of the
+// header and the last accepted height, and survive a JSON round trip.
+func TestHeadNotification(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		_, sys  = newTestFilterSystem(t, db, Config{})
+		api     = NewFilterAPI(sys)
+		genesis = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(1),
+		}
+		_, chain, _, _ = core.GenerateChainWithGenesis(genesis, dummy.NewFaker(), 10, 10, func(i int, b *core.BlockGen) {})
+		// A different block gap gives the fork different timestamps and hashes.
+		_, fork, _, _ = core.GenerateChainWithGenesis(genesis, dummy.NewFaker(), 10, 11, func(i int, b *core.BlockGen) {})
+	)
+	// Accept the first 5 blocks of [chain].
+	for _, block := range chain[:5] {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+	}
+	lastAcceptedHeight := chain[4].NumberU64()
+
+	tests := []struct {
+		name         string
+		header       *types.Header
+		acceptedOnly bool
+		accepted     bool
+	}{
+		{"accepted header", chain[2].Header(), false, true},
+		{"processing header", chain[7].Header(), false, false},
+		{"rejected sibling", fork[2].Header(), false, false},
+		{"accepted heads subscription", chain[4].Header(), true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			notification := api.headNotification(test.header, test.acceptedOnly)
+			require.Equal(test.accepted, notification.Accepted)
+			require.Equal(lastAcceptedHeight, notification.LastAcceptedHeight)
+
+			encoded, err := json.Marshal(notification)
+			require.NoError(err)
+			var decoded interfaces.HeadNotification
+			require.NoError(json.Unmarshal(encoded, &decoded))
+			require.Equal(notification.Accepted, decoded.Accepted)
+			require.Equal(notification.LastAcceptedHeight, decoded.LastAcceptedHeight)
+			require.Equal(test.header.Hash(), decoded.Hash())
+
+			// Subscribers decoding the payload as a plain header keep working.
+			var header types.Header
+			require.NoError(json.Unmarshal(encoded, &header))
+			require.Equal(test.header.Hash(), header.Hash())
+		})
+	}
+}
+
+// TestAcceptedFilters tests that the accepted variants of the block and log
+// filters only fetch accepted blocks, although the backend allows unfinalized
+// queries.
+func TestAcceptedFilters(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		api          = NewFilterAPI(sys)
+		genesis      = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(1),
+		}
+		_, chain, _, _ = core.GenerateChainWithGenesis(genesis, dummy.NewFaker(), 4, 10, func(i int, b *core.BlockGen) {})
+		addr           = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		logs           = []*types.Log{{Address: addr, BlockNumber: 1}, {Address: addr, BlockNumber: 2}}
+	)
+	require := require.New(t)
+
+	blockFilter := api.NewBlockFilter()
+	acceptedBlockFilter := api.NewAcceptedBlockFilter()
+	logFilter, err := api.NewFilter(FilterCriteria{Addresses: []common.Address{addr}})
+	require.NoError(err)
+	acceptedLogFilter, err := api.NewAcceptedFilter(FilterCriteria{Addresses: []common.Address{addr}})
+	require.NoError(err)
+
+	time.Sleep(1 * time.Second)
+	// All the blocks are processed, but only the first two are accepted.
+	for _, block := range chain {
+		backend.chainFeed.Send(core.ChainEvent{Hash: block.Hash(), Block: block})
+	}
+	backend.logsFeed.Send(logs)
+	for _, block := range chain[:2] {
+		backend.chainAcceptedFeed.Send(core.ChainEvent{Hash: block.Hash(), Block: block})
+	}
+	backend.acceptedLogsFeed.Send(logs[:1])
+	time.Sleep(1 * time.Second)
+
+	for _, tt := range []struct {
+		id       rpc.ID
+		expected interface{}
+	}{
+		{blockFilter, []common.Hash{chain[0].Hash(), chain[1].Hash(), chain[2].Hash(), chain[3].Hash()}},
+		{acceptedBlockFilter, []common.Hash{chain[0].Hash(), chain[1].Hash()}},
+		{logFilter, logs},
+		{acceptedLogFilter, logs[:1]},
+	} {
+		changes, err := api.GetFilterChanges(tt.id)
+		require.NoError(err)
+		require.Equal(tt.expected, changes)
+	}
+}
+
 // TestPendingTxFilter tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilter(t *testing.T) {
 	t.Parallel()
@@ -393,6 +514,83 @@ func TestPendingTxFilterFullTx(t *testing.T) {
 	}
 }
 
+// TestPendingTxSubscriptionCriteria tests whether pending tx subscriptions only
+// notify the transactions selected by their criteria.
+func TestPendingTxSubscriptionCriteria(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		server       = rpc.NewServer(0)
+		client       = rpc.DialInProc(server)
+
+		key, _     = crypto.GenerateKey()
+		sender     = crypto.PubkeyToAddress(key.PublicKey)
+		signer     = types.LatestSigner(params.TestChainConfig)
+		recipient  = common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268")
+		precompile = common.HexToAddress("0x0200000000000000000000000000000000000005")
+	)
+	defer server.Stop()
+	defer client.Close()
+	if err := server.RegisterName("eth", NewFilterAPI(sys)); err != nil {
+		t.Fatal(err)
+	}
+	newTx := func(nonce uint64, to common.Address, accessList types.AccessList) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.AccessListTx{ChainID: params.TestChainConfig.ChainID, Nonce: nonce, To: &to, Gas: 21000, GasPrice: new(big.Int), AccessList: accessList})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	var (
+		toRecipient  = newTx(0, recipient, nil)
+		toPrecompile = newTx(1, precompile, nil)
+		withTuple    = newTx(2, recipient, types.AccessList{{Address: precompile}})
+		unsigned     = types.NewTransaction(3, recipient, new(big.Int), 0, new(big.Int), nil)
+	)
+
+	tests := []struct {
+		criteria PendingTxCriteria
+		want     []*types.Transaction
+	}{
+		{PendingTxCriteria{From: []common.Address{sender}}, []*types.Transaction{toRecipient, toPrecompile, withTuple}},
+		{PendingTxCriteria{To: []common.Address{recipient}}, []*types.Transaction{toRecipient, withTuple, unsigned}},
+		{PendingTxCriteria{Precompiles: []common.Address{precompile}}, []*types.Transaction{toPrecompile, withTuple}},
+		{PendingTxCriteria{From: []common.Address{sender}, To: []common.Address{recipient}, Precompiles: []common.Address{precompile}}, []*types.Transaction{withTuple}},
+	}
+	subs := make([]chan common.Hash, len(tests))
+	for i, test := range tests {
+		subs[i] = make(chan common.Hash, 8)
+		sub, err := client.EthSubscribe(context.Background(), subs[i], "newPendingTransactions", false, test.criteria)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sub.Unsubscribe()
+	}
+
+	time.Sleep(1 * time.Second)
+	backend.txFeed.Send(core.NewTxsEvent{Txs: []*types.Transaction{toRecipient, toPrecompile, withTuple, unsigned}})
+
+	for i, test := range tests {
+		for _, want := range test.want {
+			select {
+			case hash := <-subs[i]:
+				if hash != want.Hash() {
+					t.Errorf("test %d: invalid hash, want %x, got %x", i, want.Hash(), hash)
+				}
+			case <-time.After(1 * time.Second):
+				t.Fatalf("test %d: timeout waiting for tx %x", i, want.Hash())
+			}
+		}
+		select {
+		case hash := <-subs[i]:
+			t.Errorf("test %d: unexpected tx %x", i, hash)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
 // TestLogFilterCreation test whether a given filter criteria makes sense.
 // If not it must return an error.
 func TestLogFilterCreation(t *testing.T) {
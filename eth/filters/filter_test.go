@@ -385,3 +385,46 @@ func patchWant(t *testing.T, want string, blocks []*types.Block) string {
 	}
 	return string(result)
 }
+
+func TestFiltersMaxLogs(t *testing.T) {
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		addr         = common.BytesToAddress([]byte("jeff"))
+
+		gspec = &core.Genesis{
+			BaseFee: big.NewInt(1),
+			Config:  params.TestChainConfig,
+		}
+	)
+	_, chain, receipts, err := core.GenerateChainWithGenesis(gspec, dummy.NewFaker(), 10, 10, func(i int, gen *core.BlockGen) {
+		gen.AddUncheckedReceipt(makeReceipt(addr))
+		gen.AddUncheckedTx(types.NewTransaction(999, common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+	})
+	require.NoError(t, err)
+	gspec.MustCommit(db)
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+
+	backend.maxLogs = 10
+	logs, err := sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), []common.Address{addr}, nil).Logs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, logs, 10)
+
+	backend.maxLogs = 5
+	_, err = sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), []common.Address{addr}, nil).Logs(context.Background())
+	var limitErr *rpc.LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "maxLogsPerRequest", limitErr.Limit)
+	require.Equal(t, 5, limitErr.Max)
+
+	// Single block queries are limited as well
+	backend.maxLogs = 1
+	logs, err = sys.NewBlockFilter(chain[0].Hash(), []common.Address{addr}, nil).Logs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+}
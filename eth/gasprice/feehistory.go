@@ -33,6 +33,7 @@ import (
 	"math/big"
 	"slices"
 
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
@@ -164,7 +165,8 @@ func (oracle *Oracle) resolveBlockRange(ctx context.Context, lastBlock rpc.Block
 // Three arrays are returned based on the processed blocks:
 //   - reward: the requested percentiles of effective priority fees per gas of transactions in each
 //     block, sorted in ascending order and weighted by gas used.
-//   - baseFee: base fee per gas in the given block
+//   - baseFee: base fee per gas in the given block, estimated at the current time for the
+//     block following the last accepted block
 //   - gasUsedRatio: gasUsed/gasLimit in the given block
 //
 // Note: baseFee includes the next block after the newest of the returned range, because this
@@ -237,5 +239,43 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks uint64, unresolvedL
 		reward = nil
 	}
 	baseFee, gasUsedRatio = baseFee[:firstMissing], gasUsedRatio[:firstMissing]
+	// Include the base fee of the block following the newest of the returned range,
+	// as long as the returned range ends with the requested last block.
+	if firstMissing == blocks {
+		nextBaseFee, err := oracle.nextBaseFee(ctx, lastBlock)
+		if err != nil {
+			return common.Big0, nil, nil, nil, err
+		}
+		baseFee = append(baseFee, nextBaseFee)
+	}
 	return new(big.Int).SetUint64(oldestBlock), reward, baseFee, gasUsedRatio, nil
 }
+
+// nextBaseFee returns the base fee of the block following the block with [number].
+// If that block has not been accepted yet, its base fee is estimated as if it was
+// produced at the current time.
+func (oracle *Oracle) nextBaseFee(ctx context.Context, number uint64) (*big.Int, error) {
+	if number < oracle.backend.LastAcceptedBlock().NumberU64() {
+		header, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(number+1))
+		if err != nil {
+			return nil, err
+		}
+		if header.BaseFee == nil {
+			return new(big.Int), nil
+		}
+		return new(big.Int).Set(header.BaseFee), nil
+	}
+	header, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	if header.BaseFee == nil {
+		return new(big.Int), nil
+	}
+	feeConfig, _, err := oracle.backend.GetFeeConfigAt(header)
+	if err != nil {
+		return nil, err
+	}
+	_, nextBaseFee, err := dummy.EstimateNextBaseFee(oracle.backend.ChainConfig(), feeConfig, header, oracle.clock.Unix())
+	return nextBaseFee, err
+}
@@ -38,7 +38,6 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
-	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
@@ -307,6 +306,90 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	return tip, err
 }
 
+// PriorityFeeEstimate is an estimate of the tip a transaction needs to pay to be
+// included in the next block, accounting for the block gas cost of the dynamic fee
+// algorithm.
+type PriorityFeeEstimate struct {
+	// BaseFee is the estimated base fee of the next block.
+	BaseFee *big.Int
+	// BlockGasCost is the estimated block gas cost of the next block.
+	BlockGasCost *big.Int
+	// RequiredBlockFee is the sum of the tips the next block must pay,
+	// that is BlockGasCost * BaseFee.
+	RequiredBlockFee *big.Int
+	// TargetGas and TargetBlockRate are the parameters of the fee config
+	// used to build the next block.
+	TargetGas       *big.Int
+	TargetBlockRate uint64
+	// SampledTip is the tip suggested from the content of recent blocks.
+	SampledTip *big.Int
+	// RequiredTip is the tip per gas a transaction using the estimated gas
+	// must pay to cover RequiredBlockFee on its own.
+	RequiredTip *big.Int
+	// Tip is the greater of SampledTip and RequiredTip.
+	Tip *big.Int
+}
+
+// EstimatePriorityFee returns an estimate of the tip a transaction using [gas] needs
+// to pay to be included in a block produced at the current time. Unlike SuggestTipCap,
+// the estimate accounts for the block gas cost the next block must cover, which
+// only depends on the time elapsed since the last block.
+// If [gas] is zero, the transaction is assumed to share the block with others using
+// [Config.MinGasUsed] gas in total.
+// If base fees have not been enabled, only SampledTip and Tip are set.
+func (oracle *Oracle) EstimatePriorityFee(ctx context.Context, gas uint64) (*PriorityFeeEstimate, error) {
+	sampledTip, _, err := oracle.suggestDynamicFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &PriorityFeeEstimate{
+		SampledTip: sampledTip,
+		Tip:        new(big.Int).Set(sampledTip),
+	}
+
+	header, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	// If the fetched block does not have a base fee, there is no block gas cost to cover
+	if header.BaseFee == nil {
+		return estimate, nil
+	}
+	feeConfig, _, err := oracle.backend.GetFeeConfigAt(header)
+	if err != nil {
+		return nil, err
+	}
+	config := oracle.backend.ChainConfig()
+	timestamp := oracle.clock.Unix()
+	_, baseFee, err := dummy.EstimateNextBaseFee(config, feeConfig, header, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	blockGasCost := dummy.EstimateNextBlockGasCost(config, feeConfig, header, timestamp)
+	if blockGasCost == nil {
+		blockGasCost = new(big.Int)
+	}
+	if gas == 0 {
+		gas = oracle.feeInfoProvider.minGasUsed
+	}
+
+	estimate.BaseFee = baseFee
+	estimate.BlockGasCost = blockGasCost
+	estimate.RequiredBlockFee = new(big.Int).Mul(blockGasCost, baseFee)
+	estimate.TargetGas = new(big.Int).Set(feeConfig.TargetGas)
+	estimate.TargetBlockRate = feeConfig.TargetBlockRate
+	estimate.RequiredTip = new(big.Int)
+	if gas > 0 {
+		// requiredTip = ceil(requiredBlockFee/gas)
+		gasBig := new(big.Int).SetUint64(gas)
+		estimate.RequiredTip.Add(estimate.RequiredBlockFee, gasBig)
+		estimate.RequiredTip.Sub(estimate.RequiredTip, common.Big1)
+		estimate.RequiredTip.Div(estimate.RequiredTip, gasBig)
+	}
+	estimate.Tip = new(big.Int).Set(math.BigMax(sampledTip, estimate.RequiredTip))
+	return estimate, nil
+}
+
 // suggestDynamicFees estimates the gas tip and base fee based on a simple sampling method
 func (oracle *Oracle) suggestDynamicFees(ctx context.Context) (*big.Int, *big.Int, error) {
 	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
@@ -314,15 +397,12 @@ func (oracle *Oracle) suggestDynamicFees(ctx context.Context) (*big.Int, *big.In
 		return nil, nil, err
 	}
 
-	var (
-		feeLastChangedAt *big.Int
-		feeConfig        commontype.FeeConfig
-	)
-	if oracle.backend.ChainConfig().IsPrecompileEnabled(feemanager.ContractAddress, head.Time) {
-		feeConfig, feeLastChangedAt, err = oracle.backend.GetFeeConfigAt(head)
-		if err != nil {
-			return nil, nil, err
-		}
+	// The fee config is read from the fee manager if it is enabled at [head], including
+	// by an upgrade scheduled by the governance precompile, and was last changed at
+	// block 0 otherwise.
+	feeConfig, feeLastChangedAt, err := oracle.backend.GetFeeConfigAt(head)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	headHash := head.Hash()
@@ -43,6 +43,7 @@ import (
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/stretchr/testify/require"
@@ -443,3 +444,46 @@ func TestSuggestGasPriceAfterFeeConfigUpdate(t *testing.T) {
 	require.NoError(err)
 	require.Equal(highFeeConfig.MinBaseFee, got)
 }
+
+func TestEstimatePriorityFee(t *testing.T) {
+	require := require.New(t)
+
+	backend := newTestBackend(t, params.TestChainConfig, 3, testGenBlock(t, 55, 370))
+	defer backend.teardown()
+
+	oracle, err := NewOracle(backend, defaultOracleConfig())
+	require.NoError(err)
+
+	head := backend.chain.CurrentHeader()
+	feeConfig := params.TestChainConfig.FeeConfig
+
+	// A block produced right after the head must cover an increased block gas cost.
+	oracle.clock.Set(time.Unix(int64(head.Time), 0))
+	estimate, err := oracle.EstimatePriorityFee(context.Background(), params.TxGas)
+	require.NoError(err)
+
+	expectedBlockGasCost := new(big.Int).Add(head.BlockGasCost, new(big.Int).Mul(feeConfig.BlockGasCostStep, new(big.Int).SetUint64(feeConfig.TargetBlockRate)))
+	expectedBlockGasCost = math.BigMin(expectedBlockGasCost, feeConfig.MaxBlockGasCost)
+	require.Equal(expectedBlockGasCost, estimate.BlockGasCost)
+	require.Equal(new(big.Int).Mul(estimate.BlockGasCost, estimate.BaseFee), estimate.RequiredBlockFee)
+	require.Equal(feeConfig.TargetGas, estimate.TargetGas)
+	require.Equal(feeConfig.TargetBlockRate, estimate.TargetBlockRate)
+
+	// The required tip covers the required block fee on its own.
+	require.GreaterOrEqual(new(big.Int).Mul(estimate.RequiredTip, new(big.Int).SetUint64(params.TxGas)).Cmp(estimate.RequiredBlockFee), 0)
+	require.Less(new(big.Int).Mul(new(big.Int).Sub(estimate.RequiredTip, common.Big1), new(big.Int).SetUint64(params.TxGas)).Cmp(estimate.RequiredBlockFee), 0)
+	require.Equal(math.BigMax(estimate.SampledTip, estimate.RequiredTip), estimate.Tip)
+
+	// The required tip is shared with the other transactions of the block if no gas is given.
+	shared, err := oracle.EstimatePriorityFee(context.Background(), 0)
+	require.NoError(err)
+	require.Less(shared.RequiredTip.Cmp(estimate.RequiredTip), 0)
+
+	// Once enough time has elapsed, the block gas cost drops to its minimum and
+	// the sampled tip is recommended.
+	oracle.clock.Set(time.Unix(int64(head.Time)+60, 0))
+	estimate, err = oracle.EstimatePriorityFee(context.Background(), params.TxGas)
+	require.NoError(err)
+	require.Equal(feeConfig.MinBlockGasCost, estimate.BlockGasCost)
+	require.Equal(estimate.SampledTip, estimate.Tip)
+}
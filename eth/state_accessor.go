@@ -37,6 +37,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/eth/tracers"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -254,8 +255,14 @@ func (eth *Ethereum) StateAtNextBlock(ctx context.Context, parent *types.Block,
 		return nil, nil, err
 	}
 
-	// Apply upgrades here for the [nextBlock]
-	err = core.ApplyUpgrades(eth.blockchain.Config(), &parent.Header().Time, nextBlock, statedb)
+	// Apply upgrades here for the [nextBlock], including the precompile
+	// upgrades scheduled by the governance precompile.
+	config, err := governance.ChainConfigAt(eth.blockchain.Config(), statedb, nextBlock.Time())
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	err = core.ApplyUpgrades(config, &parent.Header().Time, nextBlock, statedb)
 	if err != nil {
 		release()
 		return nil, nil, err
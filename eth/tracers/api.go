@@ -46,6 +46,7 @@ import (
 	"github.com/ava-labs/subnet-evm/eth/tracers/logger"
 	"github.com/ava-labs/subnet-evm/internal/ethapi"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -93,6 +94,8 @@ type Backend interface {
 	BadBlocks() ([]*types.Block, []*core.BadBlockReason)
 	GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error)
 	RPCGasCap() uint64
+	RPCTraceTimeout() time.Duration
+	RPCMaxTraceTimeout() time.Duration
 	ChainConfig() *params.ChainConfig
 	Engine() consensus.Engine
 	ChainDb() ethdb.Database
@@ -954,7 +957,11 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 		config.BlockOverrides.Apply(&vmctx)
 		// Apply all relevant upgrades from [originalTime] to the block time set in the override.
 		// Should be applied before the state overrides.
-		err = core.ApplyUpgrades(api.backend.ChainConfig(), &originalTime, &vmctx, statedb)
+		chainConfig, err := governance.ChainConfigAt(api.backend.ChainConfig(), statedb, vmctx.Time)
+		if err != nil {
+			return nil, err
+		}
+		err = core.ApplyUpgrades(chainConfig, &originalTime, &vmctx, statedb)
 		if err != nil {
 			return nil, err
 		}
@@ -1000,10 +1007,20 @@ func (api *baseAPI) traceTx(ctx context.Context, message *core.Message, txctx *C
 	vmenv := vm.NewEVM(vmctx, txContext, statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer, NoBaseFee: true})
 
 	// Define a meaningful timeout of a single transaction trace
+	if defaultTimeout := api.backend.RPCTraceTimeout(); defaultTimeout > 0 {
+		timeout = defaultTimeout
+	}
 	if config.Timeout != nil {
 		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
 			return nil, err
 		}
+		if maxTimeout := api.backend.RPCMaxTraceTimeout(); maxTimeout > 0 && timeout > maxTimeout {
+			return nil, &rpc.LimitExceededError{
+				Message: fmt.Sprintf("trace timeout %s exceeds maximum %s", timeout, maxTimeout),
+				Limit:   "maxTraceTimeout",
+				Max:     maxTimeout.String(),
+			}
+		}
 	}
 	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 	go func() {
@@ -36,6 +36,7 @@ import (
 	"reflect"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
@@ -66,6 +67,8 @@ type testBackend struct {
 	chaindb     ethdb.Database
 	chain       *core.BlockChain
 
+	maxTraceTimeout time.Duration
+
 	refHook func() // Hook is invoked when the requested state is referenced
 	relHook func() // Hook is invoked when the requested state is released
 }
@@ -142,6 +145,14 @@ func (b *testBackend) RPCGasCap() uint64 {
 	return 25000000
 }
 
+func (b *testBackend) RPCTraceTimeout() time.Duration {
+	return 0
+}
+
+func (b *testBackend) RPCMaxTraceTimeout() time.Duration {
+	return b.maxTraceTimeout
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return b.chainConfig
 }
@@ -404,6 +415,42 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceTransactionMaxTimeout(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	target := common.Hash{}
+	signer := types.HomesteadSigner{}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, new(big.Int).Add(b.BaseFee(), big.NewInt(int64(500*params.GWei))), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+	backend.maxTraceTimeout = time.Second
+	api := NewAPI(backend)
+
+	timeout := "1s"
+	if _, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Timeout: &timeout}); err != nil {
+		t.Fatalf("failed to trace transaction within the max timeout: %v", err)
+	}
+	timeout = "1m"
+	_, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Timeout: &timeout})
+	var limitErr *rpc.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("want %T, have %v", limitErr, err)
+	}
+	if limitErr.Limit != "maxTraceTimeout" {
+		t.Fatalf("want limit maxTraceTimeout, have %s", limitErr.Limit)
+	}
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
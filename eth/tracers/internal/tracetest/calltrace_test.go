@@ -31,6 +31,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -40,7 +41,10 @@ import (
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/eth/tracers"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
 	"github.com/ava-labs/subnet-evm/tests"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -408,3 +412,90 @@ func TestInternals(t *testing.T) {
 		})
 	}
 }
+
+func TestPrecompileCallTrace(t *testing.T) {
+	var (
+		origin    = common.HexToAddress("0x00000000000000000000000000000000feed")
+		recipient = common.HexToAddress("0x00000000000000000000000000000000beef")
+		context   = vm.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			BlockNumber: big.NewInt(1),
+			Time:        5,
+			Difficulty:  big.NewInt(0),
+			GasLimit:    uint64(6000000),
+			BaseFee:     big.NewInt(0),
+		}
+		chainConfig = *params.TestChainConfig
+	)
+	chainConfig.GenesisPrecompiles = params.Precompiles{
+		nativeminter.ConfigKey: nativeminter.NewConfig(utils.NewUint64(0), []common.Address{origin}, nil, nil, nil),
+	}
+	_, statedb := tests.MakePreState(rawdb.NewMemoryDatabase(),
+		core.GenesisAlloc{
+			origin:                       core.GenesisAccount{Balance: big.NewInt(500000000000000)},
+			nativeminter.ContractAddress: core.GenesisAccount{Code: []byte{0x1}, Nonce: 1},
+		}, false)
+	nativeminter.SetContractNativeMinterStatus(statedb, origin, allowlist.AdminRole)
+
+	input, err := nativeminter.PackMintNativeCoin(recipient, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer, err := tracers.DefaultDirectory.New("callTracer", nil, json.RawMessage(`{"withLog": true}`))
+	if err != nil {
+		t.Fatalf("failed to create call tracer: %v", err)
+	}
+	evm := vm.NewEVM(context, vm.TxContext{Origin: origin, GasPrice: big.NewInt(0)}, statedb, &chainConfig, vm.Config{Tracer: tracer})
+	msg := &core.Message{
+		To:        &nativeminter.ContractAddress,
+		From:      origin,
+		Data:      input,
+		Value:     big.NewInt(0),
+		GasLimit:  100000,
+		GasPrice:  big.NewInt(0),
+		GasFeeCap: big.NewInt(0),
+		GasTipCap: big.NewInt(0),
+	}
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if _, err := st.TransitionDb(); err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	var trace struct {
+		Logs       []callLog `json:"logs"`
+		Precompile struct {
+			Name    string                 `json:"name"`
+			Method  string                 `json:"method"`
+			Args    map[string]interface{} `json:"args"`
+			Effects []struct {
+				Address common.Address         `json:"address"`
+				Event   string                 `json:"event"`
+				Args    map[string]interface{} `json:"args"`
+			} `json:"effects"`
+		} `json:"precompile"`
+	}
+	if err := json.Unmarshal(res, &trace); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if trace.Precompile.Name != nativeminter.ConfigKey || trace.Precompile.Method != "mintNativeCoin" {
+		t.Fatalf("unexpected precompile call: %s", res)
+	}
+	wantArgs := map[string]interface{}{"addr": strings.ToLower(recipient.Hex()), "amount": "0x3e8"}
+	if !reflect.DeepEqual(trace.Precompile.Args, wantArgs) {
+		t.Errorf("precompile args mismatch\n have: %v\n want: %v", trace.Precompile.Args, wantArgs)
+	}
+	if len(trace.Precompile.Effects) != 1 || trace.Precompile.Effects[0].Event != "NativeCoinMinted" {
+		t.Fatalf("unexpected precompile effects: %s", res)
+	}
+	wantEffect := map[string]interface{}{"sender": strings.ToLower(origin.Hex()), "recipient": strings.ToLower(recipient.Hex()), "amount": "0x3e8"}
+	if !reflect.DeepEqual(trace.Precompile.Effects[0].Args, wantEffect) {
+		t.Errorf("precompile effect mismatch\n have: %v\n want: %v", trace.Precompile.Effects[0].Args, wantEffect)
+	}
+	if len(trace.Logs) != 1 || trace.Logs[0].Address != nativeminter.ContractAddress {
+		t.Errorf("unexpected logs: %s", res)
+	}
+}
@@ -470,6 +470,39 @@ func (t *jsTracer) setBuiltinFunctions() {
 		}
 		return false
 	})
+	vm.Set("decodePrecompileInput", func(addr goja.Value, input goja.Value) goja.Value {
+		a, err := t.fromBuf(vm, addr, true)
+		if err != nil {
+			vm.Interrupt(err)
+			return nil
+		}
+		in, err := t.fromBuf(vm, input, true)
+		if err != nil {
+			vm.Interrupt(err)
+			return nil
+		}
+		decoder, ok := tracers.GetPrecompileDecoder(common.BytesToAddress(a))
+		if !ok {
+			return goja.Null()
+		}
+		method, args, err := decoder.DecodeInput(in)
+		if err != nil {
+			return goja.Null()
+		}
+		// Round trip the decoded call through JSON to expose plain JS values.
+		encoded, err := json.Marshal(map[string]interface{}{"method": method, "args": args})
+		if err != nil {
+			vm.Interrupt(err)
+			return nil
+		}
+		parse, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("parse"))
+		res, err := parse(goja.Undefined(), vm.ToValue(string(encoded)))
+		if err != nil {
+			vm.Interrupt(err)
+			return nil
+		}
+		return res
+	})
 	vm.Set("slice", func(slice goja.Value, start, end int) goja.Value {
 		b, err := t.fromBuf(vm, slice, false)
 		if err != nil {
@@ -29,6 +29,7 @@ package js
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 	"testing"
@@ -38,6 +39,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/eth/tracers"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -268,6 +270,27 @@ func TestIsPrecompile(t *testing.T) {
 	}
 }
 
+func TestDecodePrecompileInput(t *testing.T) {
+	input, err := nativeminter.PackMintNativeCoin(common.Address{1}, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := fmt.Sprintf("{res: null, step: function() {}, fault: function() {}, result: function() { return [decodePrecompileInput(toAddress('%x'), '%x'), decodePrecompileInput(toAddress('%x'), '%x')]; }}",
+		nativeminter.ContractAddress, input, common.Address{1}, input)
+	tracer, err := newJsTracer(code, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := runTrace(tracer, testCtx(), params.TestChainConfig, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"args":{"addr":"0x0100000000000000000000000000000000000000","amount":"0x3e8"},"method":"mintNativeCoin"},null]`
+	if string(res) != want {
+		t.Errorf("decoded precompile input mismatch\n have: %s\n want: %s", res, want)
+	}
+}
+
 func TestEnterExit(t *testing.T) {
 	// test that either both or none of enter() and exit() are defined
 	if _, err := newJsTracer("{step: function() {}, fault: function() {}, result: function() { return null; }, enter: function() {}}", new(tracers.Context), nil); err == nil {
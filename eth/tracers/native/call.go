@@ -65,6 +65,7 @@ type callFrame struct {
 	RevertReason string          `json:"revertReason,omitempty"`
 	Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
 	Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
+	Precompile   *precompileCall `json:"precompile,omitempty" rlp:"-"`
 	// Placed at end on purpose. The RLP will be decoded to 0 instead of
 	// nil if there are non-empty elements after in the struct.
 	Value *big.Int `json:"value,omitempty" rlp:"optional"`
@@ -80,6 +81,9 @@ func (f callFrame) failed() bool {
 
 func (f *callFrame) processOutput(output []byte, err error) {
 	output = common.CopyBytes(output)
+	if f.Precompile != nil {
+		f.Precompile.processOutput(output, err)
+	}
 	if err == nil {
 		f.Output = output
 		return
@@ -111,6 +115,7 @@ type callFrameMarshaling struct {
 
 type callTracer struct {
 	noopTracer
+	env       *vm.EVM
 	callstack []callFrame
 	config    callTracerConfig
 	gasLimit  uint64
@@ -139,6 +144,7 @@ func newCallTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, e
 
 // CaptureStart implements the EVMLogger interface to initialize the tracing operation.
 func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.env = env
 	toCopy := to
 	t.callstack[0] = callFrame{
 		Type:  vm.CALL,
@@ -150,6 +156,8 @@ func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Ad
 	}
 	if create {
 		t.callstack[0].Type = vm.CREATE
+	} else {
+		t.callstack[0].Precompile = newPrecompileCall(env, to, input)
 	}
 }
 
@@ -223,9 +231,33 @@ func (t *callTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.
 		Gas:   gas,
 		Value: value,
 	}
+	if typ != vm.CREATE && typ != vm.CREATE2 && typ != vm.SELFDESTRUCT {
+		call.Precompile = newPrecompileCall(t.env, to, input)
+	}
 	t.callstack = append(t.callstack, call)
 }
 
+// CapturePrecompileLog implements the vm.PrecompileLogger interface to trace
+// the logs emitted by stateful precompiles, which do not execute LOG opcodes.
+func (t *callTracer) CapturePrecompileLog(addr common.Address, topics []common.Hash, data []byte) {
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+	call := &t.callstack[len(t.callstack)-1]
+	// Avoid processing nested calls when only caring about top call
+	if t.config.OnlyTopCall && (call.To == nil || *call.To != addr) {
+		return
+	}
+	if call.Precompile != nil {
+		call.Precompile.addLog(addr, topics, data)
+	}
+	if t.config.WithLog {
+		log := callLog{Address: addr, Topics: append([]common.Hash(nil), topics...), Data: common.CopyBytes(data)}
+		call.Logs = append(call.Logs, log)
+	}
+}
+
 // CaptureExit is called when EVM exits a scope, even if the scope didn't
 // execute any code.
 func (t *callTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
@@ -252,10 +284,9 @@ func (t *callTracer) CaptureTxStart(gasLimit uint64) {
 
 func (t *callTracer) CaptureTxEnd(restGas uint64) {
 	t.callstack[0].GasUsed = t.gasLimit - restGas
-	if t.config.WithLog {
-		// Logs are not emitted when the call fails
-		clearFailedLogs(&t.callstack[0], false)
-	}
+	// Logs, including the effects of precompile calls, are not emitted when
+	// the call fails
+	clearFailedLogs(&t.callstack[0], false)
 }
 
 // GetResult returns the json-encoded nested list of call traces, and any
@@ -285,6 +316,9 @@ func clearFailedLogs(cf *callFrame, parentFailed bool) {
 	// Clear own logs
 	if failed {
 		cf.Logs = nil
+		if cf.Precompile != nil {
+			cf.Precompile.Effects = nil
+		}
 	}
 	for i := range cf.Calls {
 		clearFailedLogs(&cf.Calls[i], failed)
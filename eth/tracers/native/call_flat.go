@@ -212,6 +212,12 @@ func (t *flatCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	}
 }
 
+// CapturePrecompileLog implements the vm.PrecompileLogger interface to trace
+// the logs emitted by stateful precompiles.
+func (t *flatCallTracer) CapturePrecompileLog(addr common.Address, topics []common.Hash, data []byte) {
+	t.tracer.CapturePrecompileLog(addr, topics, data)
+}
+
 func (t *flatCallTracer) CaptureTxStart(gasLimit uint64) {
 	t.tracer.CaptureTxStart(gasLimit)
 }
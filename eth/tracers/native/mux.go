@@ -111,6 +111,16 @@ func (t *muxTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	}
 }
 
+// CapturePrecompileLog implements the vm.PrecompileLogger interface to trace
+// the logs emitted by stateful precompiles.
+func (t *muxTracer) CapturePrecompileLog(addr common.Address, topics []common.Hash, data []byte) {
+	for _, t := range t.tracers {
+		if logger, ok := t.(vm.PrecompileLogger); ok {
+			logger.CapturePrecompileLog(addr, topics, data)
+		}
+	}
+}
+
 func (t *muxTracer) CaptureTxStart(gasLimit uint64) {
 	for _, t := range t.tracers {
 		t.CaptureTxStart(gasLimit)
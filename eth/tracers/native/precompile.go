@@ -0,0 +1,78 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package native
+
+import (
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/eth/tracers"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// precompileCall is the decoded call to a stateful precompile. Stateful
+// precompiles do not execute any code, so it is the only insight into what
+// a call to a precompile did.
+type precompileCall struct {
+	Name    string                 `json:"name"`
+	Method  string                 `json:"method,omitempty"`
+	Args    map[string]interface{} `json:"args,omitempty"`
+	Results map[string]interface{} `json:"results,omitempty"`
+	Effects []precompileEffect     `json:"effects,omitempty"`
+
+	decoder tracers.PrecompileDecoder
+}
+
+// precompileEffect is a decoded event emitted by a stateful precompile, e.g.
+// NativeCoinMinted or FeeConfigChanged.
+type precompileEffect struct {
+	Address common.Address         `json:"address"`
+	Event   string                 `json:"event"`
+	Args    map[string]interface{} `json:"args,omitempty"`
+}
+
+// newPrecompileCall returns the decoded call of [input] to the stateful
+// precompile at [addr], or nil if there is no stateful precompile enabled at
+// [addr] in [env].
+func newPrecompileCall(env *vm.EVM, addr common.Address, input []byte) *precompileCall {
+	if env == nil || !env.ChainConfig().IsPrecompileEnabled(addr, env.Context.Time) {
+		return nil
+	}
+	module, ok := modules.GetPrecompileModuleByAddress(addr)
+	if !ok {
+		return nil
+	}
+	call := &precompileCall{Name: module.ConfigKey}
+	if call.decoder, ok = tracers.GetPrecompileDecoder(addr); !ok {
+		return call
+	}
+	method, args, err := call.decoder.DecodeInput(input)
+	if err != nil {
+		return call
+	}
+	call.Method = method
+	call.Args = args
+	return call
+}
+
+// processOutput decodes the results of a successful call.
+func (c *precompileCall) processOutput(output []byte, err error) {
+	if err != nil || c.Method == "" {
+		return
+	}
+	c.Results, _ = c.decoder.DecodeOutput(c.Method, output)
+}
+
+// addLog decodes a log emitted by the precompile at [addr] as an effect of
+// the call. Logs that cannot be decoded are ignored.
+func (c *precompileCall) addLog(addr common.Address, topics []common.Hash, data []byte) {
+	decoder, ok := tracers.GetPrecompileDecoder(addr)
+	if !ok {
+		return
+	}
+	event, args, err := decoder.DecodeLog(topics, data)
+	if err != nil {
+		return
+	}
+	c.Effects = append(c.Effects, precompileEffect{Address: addr, Event: event, Args: args})
+}
@@ -0,0 +1,172 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var errNoEventTopic = errors.New("log has no event topic")
+
+// PrecompileDecoder decodes the calls to a stateful precompile and the logs it
+// emits, so tracers can show what the precompile did instead of an opaque call.
+// Decoded values must be encodable by encoding/json.
+type PrecompileDecoder interface {
+	// DecodeInput returns the name and the arguments of the method called by [input].
+	DecodeInput(input []byte) (method string, args map[string]interface{}, err error)
+	// DecodeOutput returns the results returned by [method] in [output].
+	DecodeOutput(method string, output []byte) (map[string]interface{}, error)
+	// DecodeLog returns the name and the arguments of the event of a log.
+	DecodeLog(topics []common.Hash, data []byte) (event string, args map[string]interface{}, err error)
+}
+
+var (
+	precompileDecodersLock sync.RWMutex
+	precompileDecoders     = make(map[common.Address]PrecompileDecoder)
+)
+
+// RegisterPrecompileDecoder registers [decoder] for the stateful precompile at
+// [address]. Precompiles compiled into a subnet register their decoder in the
+// init function of their package, alongside their module. Precompiles without a
+// registered decoder are decoded with the ABI of their module, if any.
+func RegisterPrecompileDecoder(address common.Address, decoder PrecompileDecoder) error {
+	precompileDecodersLock.Lock()
+	defer precompileDecodersLock.Unlock()
+
+	if _, ok := precompileDecoders[address]; ok {
+		return fmt.Errorf("precompile decoder already registered for address %s", address)
+	}
+	precompileDecoders[address] = decoder
+	return nil
+}
+
+// GetPrecompileDecoder returns the decoder of the stateful precompile at
+// [address], falling back to the ABI of the module registered at [address].
+func GetPrecompileDecoder(address common.Address) (PrecompileDecoder, bool) {
+	precompileDecodersLock.RLock()
+	decoder, ok := precompileDecoders[address]
+	precompileDecodersLock.RUnlock()
+	if ok {
+		return decoder, true
+	}
+	module, ok := modules.GetPrecompileModuleByAddress(address)
+	if !ok || module.ABI == nil {
+		return nil, false
+	}
+	return NewABIPrecompileDecoder(module.ABI), true
+}
+
+// ABIPrecompileDecoder is a PrecompileDecoder for precompiles following the
+// Solidity ABI, like the built-in precompiles.
+type ABIPrecompileDecoder struct {
+	abi *abi.ABI
+}
+
+// NewABIPrecompileDecoder returns a decoder of the calls and logs described by
+// [abi].
+func NewABIPrecompileDecoder(abi *abi.ABI) *ABIPrecompileDecoder {
+	return &ABIPrecompileDecoder{abi: abi}
+}
+
+func (d *ABIPrecompileDecoder) DecodeInput(input []byte) (string, map[string]interface{}, error) {
+	if len(input) < 4 {
+		return "", nil, fmt.Errorf("input too short to contain a method selector: %d bytes", len(input))
+	}
+	method, err := d.abi.MethodById(input[:4])
+	if err != nil {
+		return "", nil, err
+	}
+	args, err := unpackIntoMap(method.Inputs, input[4:])
+	return method.Name, args, err
+}
+
+func (d *ABIPrecompileDecoder) DecodeOutput(method string, output []byte) (map[string]interface{}, error) {
+	m, ok := d.abi.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %q not found", method)
+	}
+	return unpackIntoMap(m.Outputs, output)
+}
+
+func (d *ABIPrecompileDecoder) DecodeLog(topics []common.Hash, data []byte) (string, map[string]interface{}, error) {
+	if len(topics) == 0 {
+		return "", nil, errNoEventTopic
+	}
+	event, err := d.abi.EventByID(topics[0])
+	if err != nil {
+		return "", nil, err
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	args := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(args, indexed, topics[1:]); err != nil {
+		return "", nil, err
+	}
+	for name, value := range args {
+		args[name] = formatABIValue(value)
+	}
+	values, err := unpackIntoMap(event.Inputs, data)
+	if err != nil {
+		return "", nil, err
+	}
+	for name, value := range values {
+		args[name] = value
+	}
+	return event.Name, args, nil
+}
+
+// unpackIntoMap unpacks [data] according to the non-indexed [args].
+func unpackIntoMap(args abi.Arguments, data []byte) (map[string]interface{}, error) {
+	if len(args.NonIndexed()) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]interface{})
+	if err := args.UnpackIntoMap(values, data); err != nil {
+		return nil, err
+	}
+	for name, value := range values {
+		values[name] = formatABIValue(value)
+	}
+	return values, nil
+}
+
+// formatABIValue converts the unpacked ABI values that encoding/json does not
+// encode as hex strings, to match the rest of the traces.
+func formatABIValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case common.Address, common.Hash:
+		return value
+	case *big.Int:
+		return (*hexutil.Big)(value)
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(bytes), v)
+			return hexutil.Bytes(bytes)
+		}
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = formatABIValue(v.Index(i).Interface())
+		}
+		return values
+	default:
+		return value
+	}
+}
@@ -0,0 +1,85 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type testPrecompileDecoder struct{}
+
+func (testPrecompileDecoder) DecodeInput(input []byte) (string, map[string]interface{}, error) {
+	return "test", map[string]interface{}{"input": hexutil.Bytes(input)}, nil
+}
+
+func (testPrecompileDecoder) DecodeOutput(string, []byte) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (testPrecompileDecoder) DecodeLog([]common.Hash, []byte) (string, map[string]interface{}, error) {
+	return "Test", nil, nil
+}
+
+func TestPrecompileDecoder(t *testing.T) {
+	// Custom decoders take precedence and cannot be registered twice
+	addr := common.HexToAddress("0x0300000000000000000000000000000000000042")
+	if _, ok := GetPrecompileDecoder(addr); ok {
+		t.Fatal("unexpected decoder for unregistered address")
+	}
+	if err := RegisterPrecompileDecoder(addr, testPrecompileDecoder{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		precompileDecodersLock.Lock()
+		delete(precompileDecoders, addr)
+		precompileDecodersLock.Unlock()
+	})
+	if err := RegisterPrecompileDecoder(addr, testPrecompileDecoder{}); err == nil {
+		t.Fatal("expected error registering a second decoder for the same address")
+	}
+	decoder, ok := GetPrecompileDecoder(addr)
+	if !ok {
+		t.Fatal("missing registered decoder")
+	}
+	if method, _, err := decoder.DecodeInput([]byte{1}); err != nil || method != "test" {
+		t.Fatalf("unexpected decoded method %q: %v", method, err)
+	}
+
+	// Built-in precompiles fall back to the ABI of their module
+	decoder, ok = GetPrecompileDecoder(nativeminter.ContractAddress)
+	if !ok {
+		t.Fatal("missing decoder for native minter")
+	}
+	recipient := common.Address{1}
+	input, err := nativeminter.PackMintNativeCoin(recipient, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, args, err := decoder.DecodeInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "mintNativeCoin" || args["addr"] != recipient || args["amount"].(*hexutil.Big).ToInt().Int64() != 1000 {
+		t.Fatalf("unexpected decoded call %s(%v)", method, args)
+	}
+	topics, data, err := nativeminter.PackNativeCoinMintedEvent(common.Address{2}, recipient, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, args, err := decoder.DecodeLog(topics, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event != "NativeCoinMinted" || args["sender"] != (common.Address{2}) || args["recipient"] != recipient {
+		t.Fatalf("unexpected decoded event %s(%v)", event, args)
+	}
+	if _, _, err := decoder.DecodeInput([]byte{1, 2, 3, 4}); err == nil {
+		t.Fatal("expected error decoding unknown method")
+	}
+}
@@ -0,0 +1,123 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultBatchConfig is the batch config used by clients created with NewClient.
+var DefaultBatchConfig = BatchConfig{
+	MaxBatchSize:         100,
+	MaxConcurrentBatches: 4,
+}
+
+// BatchConfig bounds the JSON-RPC batch requests sent by a client.
+type BatchConfig struct {
+	// MaxBatchSize is the maximum number of calls sent in a single batch
+	// request. Larger batches are split, since nodes limit the size of the
+	// batches they serve. Zero means no limit.
+	MaxBatchSize int
+	// MaxConcurrentBatches is the maximum number of batch requests in flight
+	// at once. Zero means no limit.
+	MaxConcurrentBatches int
+}
+
+// BatchCallContext sends all the calls in [b] as JSON-RPC batch requests and
+// waits for the responses. Like rpc.Client.BatchCallContext, an error is only
+// returned if a batch request fails as a whole or [ctx] is done, while the
+// errors of individual calls are set on their elements.
+func (ec *client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	size := ec.batch.MaxBatchSize
+	if size <= 0 || size > len(b) {
+		size = len(b)
+	}
+	if size == 0 {
+		return nil
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if ec.batch.MaxConcurrentBatches > 0 {
+		g.SetLimit(ec.batch.MaxConcurrentBatches)
+	}
+	for start := 0; start < len(b); start += size {
+		batch := b[start:min(start+size, len(b))]
+		g.Go(func() error {
+			// Do not send the remaining batches once a batch failed.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return ec.c.BatchCallContext(ctx, batch)
+		})
+	}
+	return g.Wait()
+}
+
+// BlocksByNumbers returns the blocks at [numbers], fetched in batch requests.
+// An error is returned if any of the blocks cannot be fetched, wrapping
+// interfaces.NotFound if it does not exist.
+func (ec *client) BlocksByNumbers(ctx context.Context, numbers []*big.Int) ([]*types.Block, error) {
+	raws := make([]json.RawMessage, len(numbers))
+	batch := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{ToBlockNumArg(number), true},
+			Result: &raws[i],
+		}
+	}
+	if err := ec.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*types.Block, len(numbers))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to fetch block %s: %w", ToBlockNumArg(numbers[i]), elem.Error)
+		}
+		block, err := ec.decodeBlock(ctx, raws[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %s: %w", ToBlockNumArg(numbers[i]), err)
+		}
+		blocks[i] = block
+	}
+	return blocks, nil
+}
+
+// ReceiptsByBlock returns the receipts of each of [blocks], fetched in batch
+// requests. An error is returned if the receipts of any of the blocks cannot be
+// fetched, wrapping interfaces.NotFound if the block does not exist.
+func (ec *client) ReceiptsByBlock(ctx context.Context, blocks []rpc.BlockNumberOrHash) ([][]*types.Receipt, error) {
+	receipts := make([][]*types.Receipt, len(blocks))
+	batch := make([]rpc.BatchElem, len(blocks))
+	for i, blockNrOrHash := range blocks {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBlockReceipts",
+			Args:   []interface{}{blockNrOrHash.String()},
+			Result: &receipts[i],
+		}
+	}
+	if err := ec.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	for i, elem := range batch {
+		err := elem.Error
+		if err == nil && receipts[i] == nil {
+			err = interfaces.NotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch receipts of block %s: %w", blocks[i].String(), err)
+		}
+	}
+	return receipts, nil
+}
@@ -0,0 +1,121 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// batchTestService is a minimal eth namespace serving empty blocks up to height.
+type batchTestService struct {
+	height uint64
+}
+
+func (s *batchTestService) header(number rpc.BlockNumber) *types.Header {
+	if number < 0 || uint64(number) > s.height {
+		return nil
+	}
+	return &types.Header{
+		Number:      big.NewInt(number.Int64()),
+		Difficulty:  common.Big1,
+		UncleHash:   types.EmptyUncleHash,
+		TxHash:      types.EmptyTxsHash,
+		ReceiptHash: types.EmptyReceiptsHash,
+	}
+}
+
+func (s *batchTestService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	header := s.header(number)
+	if header == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	fields["transactions"] = []interface{}{}
+	fields["uncles"] = []common.Hash{}
+	return fields, nil
+}
+
+func (s *batchTestService) GetBlockReceipts(blockNrOrHash rpc.BlockNumberOrHash) []*types.Receipt {
+	number, ok := blockNrOrHash.Number()
+	if !ok || s.header(number) == nil {
+		return nil
+	}
+	return []*types.Receipt{}
+}
+
+func newBatchTestClient(t *testing.T, service *batchTestService, config BatchConfig) Client {
+	server := rpc.NewServer(0)
+	require.NoError(t, server.RegisterName("eth", service))
+	rpcClient := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		rpcClient.Close()
+		server.Stop()
+	})
+	return NewClientWithBatchConfig(rpcClient, config)
+}
+
+func TestBlocksByNumbers(t *testing.T) {
+	client := newBatchTestClient(t, &batchTestService{height: 10}, BatchConfig{MaxBatchSize: 3, MaxConcurrentBatches: 2})
+
+	numbers := make([]*big.Int, 10)
+	for i := range numbers {
+		numbers[i] = big.NewInt(int64(i + 1))
+	}
+	blocks, err := client.BlocksByNumbers(context.Background(), numbers)
+	require.NoError(t, err)
+	require.Len(t, blocks, len(numbers))
+	for i, block := range blocks {
+		require.Equal(t, numbers[i], block.Number())
+	}
+
+	_, err = client.BlocksByNumbers(context.Background(), []*big.Int{big.NewInt(1), big.NewInt(11)})
+	require.ErrorIs(t, err, interfaces.NotFound)
+
+	blocks, err = client.BlocksByNumbers(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+}
+
+func TestReceiptsByBlock(t *testing.T) {
+	client := newBatchTestClient(t, &batchTestService{height: 10}, BatchConfig{MaxBatchSize: 2})
+
+	blocks := make([]rpc.BlockNumberOrHash, 5)
+	for i := range blocks {
+		blocks[i] = rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(i + 1))
+	}
+	receipts, err := client.ReceiptsByBlock(context.Background(), blocks)
+	require.NoError(t, err)
+	require.Len(t, receipts, len(blocks))
+	for _, blockReceipts := range receipts {
+		require.NotNil(t, blockReceipts)
+	}
+
+	_, err = client.ReceiptsByBlock(context.Background(), []rpc.BlockNumberOrHash{rpc.BlockNumberOrHashWithNumber(11)})
+	require.ErrorIs(t, err, interfaces.NotFound)
+}
+
+func TestBatchCallContextCanceled(t *testing.T) {
+	client := newBatchTestClient(t, &batchTestService{height: 10}, DefaultBatchConfig)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.BlocksByNumbers(ctx, []*big.Int{big.NewInt(1)})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,30 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToBlockNumArg(t *testing.T) {
+	tests := []struct {
+		number *big.Int
+		want   string
+	}{
+		{nil, "latest"},
+		{big.NewInt(0), "0x0"},
+		{big.NewInt(16), "0x10"},
+		{big.NewInt(int64(rpc.LatestBlockNumber)), "latest"},
+		{big.NewInt(int64(rpc.PendingBlockNumber)), "pending"},
+		{big.NewInt(int64(rpc.FinalizedBlockNumber)), "finalized"},
+		{big.NewInt(int64(rpc.SafeBlockNumber)), "safe"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, ToBlockNumArg(tt.number))
+	}
+}
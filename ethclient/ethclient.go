@@ -75,6 +75,7 @@ type Client interface {
 	Close()
 	ChainConfig(context.Context) (*params.ChainConfigWithUpgradesJSON, error)
 	ChainID(context.Context) (*big.Int, error)
+	NativeCurrency(context.Context) (*params.NativeCurrency, error)
 	BlockByHash(context.Context, common.Hash) (*types.Block, error)
 	BlockByNumber(context.Context, *big.Int) (*types.Block, error)
 	BlockNumber(context.Context) (uint64, error)
@@ -90,6 +91,8 @@ type Client interface {
 	SubscribeNewAcceptedTransactions(context.Context, chan<- *common.Hash) (interfaces.Subscription, error)
 	SubscribeNewPendingTransactions(context.Context, chan<- *common.Hash) (interfaces.Subscription, error)
 	SubscribeNewHead(context.Context, chan<- *types.Header) (interfaces.Subscription, error)
+	SubscribeNewHeadNotification(context.Context, chan<- *interfaces.HeadNotification) (interfaces.Subscription, error)
+	SubscribeAcceptedHead(context.Context, chan<- *types.Header) (interfaces.Subscription, error)
 	NetworkID(context.Context) (*big.Int, error)
 	BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error)
 	AssetBalanceAt(context.Context, common.Address, ids.ID, *big.Int) (*big.Int, error)
@@ -98,6 +101,7 @@ type Client interface {
 	NonceAt(context.Context, common.Address, *big.Int) (uint64, error)
 	FilterLogs(context.Context, interfaces.FilterQuery) ([]types.Log, error)
 	SubscribeFilterLogs(context.Context, interfaces.FilterQuery, chan<- types.Log) (interfaces.Subscription, error)
+	SubscribeAcceptedFilterLogs(context.Context, interfaces.FilterQuery, chan<- types.Log) (interfaces.Subscription, error)
 	AcceptedCodeAt(context.Context, common.Address) ([]byte, error)
 	AcceptedNonceAt(context.Context, common.Address) (uint64, error)
 	AcceptedCallContract(context.Context, interfaces.CallMsg) ([]byte, error)
@@ -109,11 +113,18 @@ type Client interface {
 	EstimateGas(context.Context, interfaces.CallMsg) (uint64, error)
 	EstimateBaseFee(context.Context) (*big.Int, error)
 	SendTransaction(context.Context, *types.Transaction) error
+	SendTransactionConditional(context.Context, *types.Transaction, types.TransactionConditional) error
+	WaitForHeight(context.Context, uint64) error
+	WaitForTxAcceptance(context.Context, common.Hash) (*types.Receipt, error)
+	BatchCallContext(context.Context, []rpc.BatchElem) error
+	BlocksByNumbers(context.Context, []*big.Int) ([]*types.Block, error)
+	ReceiptsByBlock(context.Context, []rpc.BlockNumberOrHash) ([][]*types.Receipt, error)
 }
 
 // client defines implementation for typed wrappers for the Ethereum RPC API.
 type client struct {
-	c *rpc.Client
+	c     *rpc.Client
+	batch BatchConfig
 }
 
 // Dial connects a client to the given URL.
@@ -132,7 +143,13 @@ func DialContext(ctx context.Context, rawurl string) (Client, error) {
 
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) Client {
-	return &client{c}
+	return NewClientWithBatchConfig(c, DefaultBatchConfig)
+}
+
+// NewClientWithBatchConfig creates a client that uses the given RPC client and
+// splits batch requests according to [config].
+func NewClientWithBatchConfig(c *rpc.Client, config BatchConfig) Client {
+	return &client{c: c, batch: config}
 }
 
 // Close closes the underlying RPC connection.
@@ -167,6 +184,17 @@ func (ec *client) ChainID(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&result), err
 }
 
+// NativeCurrency retrieves the display name, symbol and decimals of the native token
+// of the chain.
+func (ec *client) NativeCurrency(ctx context.Context) (*params.NativeCurrency, error) {
+	var result *params.NativeCurrency
+	err := ec.c.CallContext(ctx, &result, "eth_nativeCurrency")
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
 // BlockByHash returns the given full block.
 //
 // Note that loading full blocks requires two requests. Use HeaderByHash
@@ -215,7 +243,12 @@ func (ec *client) getBlock(ctx context.Context, method string, args ...interface
 	if err != nil {
 		return nil, err
 	}
+	return ec.decodeBlock(ctx, raw)
+}
 
+// decodeBlock decodes the full block [raw] returned by the API, fetching its
+// uncles if it has any.
+func (ec *client) decodeBlock(ctx context.Context, raw json.RawMessage) (*types.Block, error) {
 	// Decode header and transactions.
 	var head *types.Header
 	if err := json.Unmarshal(raw, &head); err != nil {
@@ -290,6 +323,7 @@ func (ec *client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.He
 
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
+// Use rpc.FinalizedBlockNumber or rpc.SafeBlockNumber to get the last accepted header.
 func (ec *client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	var head *types.Header
 	err := ec.c.CallContext(ctx, &head, "eth_getBlockByNumber", ToBlockNumArg(number), false)
@@ -454,6 +488,27 @@ func (ec *client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header)
 	return sub, nil
 }
 
+// SubscribeNewHeadNotification subscribes to notifications about the current blockchain head
+// on the given channel, including whether the head is accepted and the last accepted height
+// of the node.
+func (ec *client) SubscribeNewHeadNotification(ctx context.Context, ch chan<- *interfaces.HeadNotification) (interfaces.Subscription, error) {
+	sub, err := ec.c.EthSubscribe(ctx, ch, "newHeads")
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// SubscribeAcceptedHead subscribes to notifications about the blocks accepted by
+// consensus on the given channel, even if the node allows unfinalized queries.
+func (ec *client) SubscribeAcceptedHead(ctx context.Context, ch chan<- *types.Header) (interfaces.Subscription, error) {
+	sub, err := ec.c.EthSubscribe(ctx, ch, "acceptedHeads")
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
 // State Access
 
 // NetworkID returns the network ID for this client.
@@ -538,6 +593,21 @@ func (ec *client) SubscribeFilterLogs(ctx context.Context, q interfaces.FilterQu
 	return sub, nil
 }
 
+// SubscribeAcceptedFilterLogs subscribes to the results of a streaming filter
+// query over the blocks accepted by consensus, even if the node allows
+// unfinalized queries.
+func (ec *client) SubscribeAcceptedFilterLogs(ctx context.Context, q interfaces.FilterQuery, ch chan<- types.Log) (interfaces.Subscription, error) {
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := ec.c.EthSubscribe(ctx, ch, "acceptedLogs", arg)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
 func toFilterArg(q interfaces.FilterQuery) (interface{}, error) {
 	arg := map[string]interface{}{
 		"address": q.Addresses,
@@ -693,6 +763,20 @@ func (ec *client) SendTransaction(ctx context.Context, tx *types.Transaction) er
 	return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(data))
 }
 
+// SendTransactionConditional injects a signed transaction into the pending pool of
+// the node, to be included only in a block meeting [conditional]. The transaction is
+// not gossiped by the node, so it is only included in blocks built by the node.
+func (ec *client) SendTransactionConditional(ctx context.Context, tx *types.Transaction, conditional types.TransactionConditional) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return ec.c.CallContext(ctx, nil, "eth_sendRawTransactionConditional", hexutil.Encode(data), conditional)
+}
+
+// ToBlockNumArg encodes [number] as a block number argument.
+// A nil number is encoded as "latest" and negative numbers are encoded as their
+// block tags, such that rpc.FinalizedBlockNumber is encoded as "finalized".
 func ToBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
@@ -0,0 +1,39 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type nativeCurrencyTestService struct {
+	config *params.ChainConfig
+}
+
+func (s *nativeCurrencyTestService) NativeCurrency() params.NativeCurrency {
+	return s.config.GetNativeCurrency()
+}
+
+func TestNativeCurrency(t *testing.T) {
+	require := require.New(t)
+	config := &params.ChainConfig{
+		NativeCurrency: &params.NativeCurrency{Name: "Gas Token", Symbol: "GAS", Decimals: 6},
+	}
+	server := rpc.NewServer(0)
+	require.NoError(server.RegisterName("eth", &nativeCurrencyTestService{config: config}))
+	rpcClient := rpc.DialInProc(server)
+	defer func() {
+		rpcClient.Close()
+		server.Stop()
+	}()
+
+	nativeCurrency, err := NewClient(rpcClient).NativeCurrency(context.Background())
+	require.NoError(err)
+	require.Equal(config.NativeCurrency, nativeCurrency)
+}
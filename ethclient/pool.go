@@ -0,0 +1,674 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ Client = (*pool)(nil)
+	_ Pool   = (*pool)(nil)
+
+	errNoEndpoints       = errors.New("no endpoints")
+	errChainIDMismatch   = errors.New("endpoints serve different chains")
+	errEndpointTooBehind = errors.New("endpoint is behind the other endpoints")
+)
+
+// DefaultPoolConfig is the pool config used when none is given.
+var DefaultPoolConfig = PoolConfig{
+	HealthCheckInterval: 10 * time.Second,
+	HealthCheckTimeout:  5 * time.Second,
+	MaxBlocksBehind:     5,
+	StickyPeriod:        time.Minute,
+}
+
+// PoolConfig configures the health checks and the failover of a Pool.
+type PoolConfig struct {
+	// HealthCheckInterval is how often the endpoints are checked in the
+	// background. Zero disables the background checks, in which case the
+	// endpoints are only checked by CheckHealth.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout bounds the duration of the check of an endpoint.
+	// Zero means no bound other than the context of CheckHealth.
+	HealthCheckTimeout time.Duration
+	// MaxBlocksBehind is the number of blocks an endpoint may lag behind the
+	// most advanced endpoint of the pool and still be considered healthy.
+	MaxBlocksBehind uint64
+	// StickyPeriod is how long the nonce, transaction and receipt queries are
+	// sent to the endpoint which accepted the last transaction sent through
+	// the pool, so that they observe its effects even if the other endpoints
+	// have not received it yet. Zero disables sticky queries.
+	StickyPeriod time.Duration
+}
+
+// EndpointStatus is the health of an endpoint of a Pool.
+type EndpointStatus struct {
+	// Healthy is false if the last check or request of the endpoint failed,
+	// or if it lagged behind the other endpoints at the last check.
+	Healthy bool
+	// Height is the last accepted height of the endpoint at the last check.
+	Height uint64
+	// LastCheck is the time of the last check of the endpoint.
+	LastCheck time.Time
+	// LastError is the reason the endpoint is unhealthy, if it is.
+	LastError error
+}
+
+// Pool is a Client spreading its requests over several endpoints serving the
+// same blockchain. Requests are sent to the first healthy endpoint, in the
+// order the endpoints were given, and are retried on the next ones when an
+// endpoint fails to serve them. Errors returned by the node for the request
+// itself, such as a reverted call or a missing block, are not retried.
+//
+// Subscriptions are made on a single endpoint and are not moved to another
+// endpoint if it fails: callers should subscribe again once the subscription
+// reports an error.
+type Pool interface {
+	Client
+	// Endpoints returns the status of the endpoints of the pool, in the
+	// order they were given.
+	Endpoints() []EndpointStatus
+	// CheckHealth checks all the endpoints of the pool and updates their status.
+	CheckHealth(ctx context.Context)
+}
+
+// poolEndpoint is an endpoint of a pool and its status, guarded by the pool lock.
+type poolEndpoint struct {
+	client Client
+	status EndpointStatus
+}
+
+// pool is the implementation of Pool.
+type pool struct {
+	config    PoolConfig
+	endpoints []*poolEndpoint
+
+	lock        sync.RWMutex
+	sticky      *poolEndpoint
+	stickyUntil time.Time
+
+	closeOnce sync.Once
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// DialPool connects to all the URLs in [rawurls] and returns a pool of the
+// resulting clients, in the same priority order. All the endpoints must
+// report the same chain ID.
+func DialPool(ctx context.Context, rawurls []string, config PoolConfig) (Pool, error) {
+	clients := make([]Client, 0, len(rawurls))
+	closeAll := func() {
+		for _, c := range clients {
+			c.Close()
+		}
+	}
+	var chainID *big.Int
+	for _, rawurl := range rawurls {
+		c, err := DialContext(ctx, rawurl)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to dial %s: %w", rawurl, err)
+		}
+		clients = append(clients, c)
+
+		id, err := c.ChainID(ctx)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to fetch chain ID of %s: %w", rawurl, err)
+		}
+		if chainID == nil {
+			chainID = id
+		} else if chainID.Cmp(id) != 0 {
+			closeAll()
+			return nil, fmt.Errorf("%w: %s has chain ID %d, expected %d", errChainIDMismatch, rawurl, id, chainID)
+		}
+	}
+	return NewPool(clients, config)
+}
+
+// NewPool returns a pool of [clients], which must serve the same blockchain,
+// in priority order. All the endpoints are considered healthy until they are
+// checked or fail a request. The pool takes ownership of [clients] and closes
+// them when it is closed.
+func NewPool(clients []Client, config PoolConfig) (Pool, error) {
+	if len(clients) == 0 {
+		return nil, errNoEndpoints
+	}
+	p := &pool{
+		config:    config,
+		endpoints: make([]*poolEndpoint, len(clients)),
+		quit:      make(chan struct{}),
+	}
+	for i, c := range clients {
+		p.endpoints[i] = &poolEndpoint{
+			client: c,
+			status: EndpointStatus{Healthy: true},
+		}
+	}
+	if config.HealthCheckInterval > 0 {
+		p.wg.Add(1)
+		go p.healthCheckLoop()
+	}
+	return p, nil
+}
+
+func (p *pool) healthCheckLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.quit
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.CheckHealth(ctx)
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// CheckHealth fetches the last accepted height of every endpoint concurrently.
+// An endpoint is healthy if it answers and is at most MaxBlocksBehind blocks
+// behind the most advanced endpoint.
+func (p *pool) CheckHealth(ctx context.Context) {
+	var (
+		heights = make([]uint64, len(p.endpoints))
+		errs    = make([]error, len(p.endpoints))
+		wg      sync.WaitGroup
+	)
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *poolEndpoint) {
+			defer wg.Done()
+
+			checkCtx := ctx
+			if p.config.HealthCheckTimeout > 0 {
+				var cancel context.CancelFunc
+				checkCtx, cancel = context.WithTimeout(ctx, p.config.HealthCheckTimeout)
+				defer cancel()
+			}
+			heights[i], errs[i] = e.client.BlockNumber(checkCtx)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var maxHeight uint64
+	for i, err := range errs {
+		if err == nil && heights[i] > maxHeight {
+			maxHeight = heights[i]
+		}
+	}
+
+	now := time.Now()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for i, e := range p.endpoints {
+		e.status.LastCheck = now
+		switch {
+		case errs[i] != nil:
+			e.status.Healthy = false
+			e.status.LastError = errs[i]
+		case heights[i]+p.config.MaxBlocksBehind < maxHeight:
+			e.status.Healthy = false
+			e.status.Height = heights[i]
+			e.status.LastError = fmt.Errorf("%w: height %d, highest %d", errEndpointTooBehind, heights[i], maxHeight)
+		default:
+			e.status.Healthy = true
+			e.status.Height = heights[i]
+			e.status.LastError = nil
+		}
+	}
+}
+
+// Endpoints returns the status of the endpoints of the pool.
+func (p *pool) Endpoints() []EndpointStatus {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	statuses := make([]EndpointStatus, len(p.endpoints))
+	for i, e := range p.endpoints {
+		statuses[i] = e.status
+	}
+	return statuses
+}
+
+// candidates returns the endpoints to try a request on, in order: the sticky
+// endpoint first if [sticky] is set and it is still healthy, then the healthy
+// endpoints and finally the unhealthy ones, which may have recovered since
+// they were last checked.
+func (p *pool) candidates(sticky bool) []*poolEndpoint {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	candidates := make([]*poolEndpoint, 0, len(p.endpoints))
+	var first *poolEndpoint
+	if sticky && p.sticky != nil && p.sticky.status.Healthy && time.Now().Before(p.stickyUntil) {
+		first = p.sticky
+		candidates = append(candidates, first)
+	}
+	for _, e := range p.endpoints {
+		if e.status.Healthy && e != first {
+			candidates = append(candidates, e)
+		}
+	}
+	for _, e := range p.endpoints {
+		if !e.status.Healthy {
+			candidates = append(candidates, e)
+		}
+	}
+	return candidates
+}
+
+// markFailed marks [e] unhealthy after it failed to serve a request with [err].
+func (p *pool) markFailed(e *poolEndpoint, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e.status.Healthy = false
+	e.status.LastError = err
+}
+
+// stick makes [e] the endpoint of the sticky queries for the sticky period.
+func (p *pool) stick(e *poolEndpoint) {
+	if p.config.StickyPeriod <= 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.sticky = e
+	p.stickyUntil = time.Now().Add(p.config.StickyPeriod)
+}
+
+// do calls [f] with the client of each candidate endpoint until one serves
+// the request, and returns that endpoint and the result of [f].
+func (p *pool) do(ctx context.Context, sticky bool, f func(Client) error) (*poolEndpoint, error) {
+	var err error
+	for _, e := range p.candidates(sticky) {
+		err = f(e.client)
+		if !isEndpointError(err) {
+			return e, err
+		}
+		p.markFailed(e, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, err
+}
+
+// poolCall is a typed wrapper around pool.do for requests returning a value.
+func poolCall[T any](ctx context.Context, p *pool, sticky bool, f func(Client) (T, error)) (T, error) {
+	var result T
+	_, err := p.do(ctx, sticky, func(c Client) error {
+		var err error
+		result, err = f(c)
+		return err
+	})
+	return result, err
+}
+
+// isEndpointError returns true if [err] is caused by the endpoint failing to
+// serve a request rather than by the request itself, in which case the
+// request is retried on the next endpoint.
+func isEndpointError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, interfaces.NotFound) {
+		return false
+	}
+	// Errors of the JSON-RPC response, e.g. execution reverted or nonce too low
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// Close stops the health checks and closes the clients of all the endpoints.
+func (p *pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.quit)
+		p.wg.Wait()
+		for _, e := range p.endpoints {
+			e.client.Close()
+		}
+	})
+}
+
+// Client returns the underlying RPC client of the first healthy endpoint.
+func (p *pool) Client() *rpc.Client {
+	return p.candidates(false)[0].client.Client()
+}
+
+func (p *pool) ChainConfig(ctx context.Context) (*params.ChainConfigWithUpgradesJSON, error) {
+	return poolCall(ctx, p, false, func(c Client) (*params.ChainConfigWithUpgradesJSON, error) {
+		return c.ChainConfig(ctx)
+	})
+}
+
+func (p *pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.ChainID(ctx)
+	})
+}
+
+func (p *pool) NativeCurrency(ctx context.Context) (*params.NativeCurrency, error) {
+	return poolCall(ctx, p, false, func(c Client) (*params.NativeCurrency, error) {
+		return c.NativeCurrency(ctx)
+	})
+}
+
+func (p *pool) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return poolCall(ctx, p, false, func(c Client) (*types.Block, error) {
+		return c.BlockByHash(ctx, hash)
+	})
+}
+
+func (p *pool) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return poolCall(ctx, p, false, func(c Client) (*types.Block, error) {
+		return c.BlockByNumber(ctx, number)
+	})
+}
+
+func (p *pool) BlockNumber(ctx context.Context) (uint64, error) {
+	return poolCall(ctx, p, false, func(c Client) (uint64, error) {
+		return c.BlockNumber(ctx)
+	})
+}
+
+func (p *pool) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]*types.Receipt, error) {
+		return c.BlockReceipts(ctx, blockNrOrHash)
+	})
+}
+
+func (p *pool) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return poolCall(ctx, p, false, func(c Client) (*types.Header, error) {
+		return c.HeaderByHash(ctx, hash)
+	})
+}
+
+func (p *pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return poolCall(ctx, p, false, func(c Client) (*types.Header, error) {
+		return c.HeaderByNumber(ctx, number)
+	})
+}
+
+// TransactionByHash is sent to the sticky endpoint, if any.
+func (p *pool) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var isPending bool
+	tx, err := poolCall(ctx, p, true, func(c Client) (*types.Transaction, error) {
+		tx, pending, err := c.TransactionByHash(ctx, hash)
+		isPending = pending
+		return tx, err
+	})
+	return tx, isPending, err
+}
+
+func (p *pool) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	return poolCall(ctx, p, false, func(c Client) (common.Address, error) {
+		return c.TransactionSender(ctx, tx, block, index)
+	})
+}
+
+func (p *pool) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return poolCall(ctx, p, false, func(c Client) (uint, error) {
+		return c.TransactionCount(ctx, blockHash)
+	})
+}
+
+func (p *pool) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return poolCall(ctx, p, false, func(c Client) (*types.Transaction, error) {
+		return c.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// TransactionReceipt is sent to the sticky endpoint, if any.
+func (p *pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return poolCall(ctx, p, true, func(c Client) (*types.Receipt, error) {
+		return c.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (p *pool) SyncProgress(ctx context.Context) error {
+	_, err := p.do(ctx, false, func(c Client) error {
+		return c.SyncProgress(ctx)
+	})
+	return err
+}
+
+func (p *pool) SubscribeNewAcceptedTransactions(ctx context.Context, ch chan<- *common.Hash) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeNewAcceptedTransactions(ctx, ch)
+	})
+}
+
+func (p *pool) SubscribeNewPendingTransactions(ctx context.Context, ch chan<- *common.Hash) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeNewPendingTransactions(ctx, ch)
+	})
+}
+
+func (p *pool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeNewHead(ctx, ch)
+	})
+}
+
+func (p *pool) SubscribeNewHeadNotification(ctx context.Context, ch chan<- *interfaces.HeadNotification) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeNewHeadNotification(ctx, ch)
+	})
+}
+
+func (p *pool) SubscribeAcceptedHead(ctx context.Context, ch chan<- *types.Header) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeAcceptedHead(ctx, ch)
+	})
+}
+
+func (p *pool) NetworkID(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.NetworkID(ctx)
+	})
+}
+
+func (p *pool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (p *pool) AssetBalanceAt(ctx context.Context, account common.Address, assetID ids.ID, blockNumber *big.Int) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.AssetBalanceAt(ctx, account, assetID, blockNumber)
+	})
+}
+
+func (p *pool) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]byte, error) {
+		return c.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (p *pool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]byte, error) {
+		return c.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// NonceAt is sent to the sticky endpoint, if any.
+func (p *pool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return poolCall(ctx, p, true, func(c Client) (uint64, error) {
+		return c.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (p *pool) FilterLogs(ctx context.Context, q interfaces.FilterQuery) ([]types.Log, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]types.Log, error) {
+		return c.FilterLogs(ctx, q)
+	})
+}
+
+func (p *pool) SubscribeFilterLogs(ctx context.Context, q interfaces.FilterQuery, ch chan<- types.Log) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+func (p *pool) SubscribeAcceptedFilterLogs(ctx context.Context, q interfaces.FilterQuery, ch chan<- types.Log) (interfaces.Subscription, error) {
+	return poolCall(ctx, p, false, func(c Client) (interfaces.Subscription, error) {
+		return c.SubscribeAcceptedFilterLogs(ctx, q, ch)
+	})
+}
+
+func (p *pool) AcceptedCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]byte, error) {
+		return c.AcceptedCodeAt(ctx, account)
+	})
+}
+
+// AcceptedNonceAt is sent to the sticky endpoint, if any.
+func (p *pool) AcceptedNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return poolCall(ctx, p, true, func(c Client) (uint64, error) {
+		return c.AcceptedNonceAt(ctx, account)
+	})
+}
+
+func (p *pool) AcceptedCallContract(ctx context.Context, msg interfaces.CallMsg) ([]byte, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]byte, error) {
+		return c.AcceptedCallContract(ctx, msg)
+	})
+}
+
+func (p *pool) CallContract(ctx context.Context, msg interfaces.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]byte, error) {
+		return c.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (p *pool) CallContractAtHash(ctx context.Context, msg interfaces.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]byte, error) {
+		return c.CallContractAtHash(ctx, msg, blockHash)
+	})
+}
+
+func (p *pool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.SuggestGasPrice(ctx)
+	})
+}
+
+func (p *pool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.SuggestGasTipCap(ctx)
+	})
+}
+
+func (p *pool) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*interfaces.FeeHistory, error) {
+	return poolCall(ctx, p, false, func(c Client) (*interfaces.FeeHistory, error) {
+		return c.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// EstimateGas is sent to the sticky endpoint, if any, since the estimate
+// depends on the state of the sender.
+func (p *pool) EstimateGas(ctx context.Context, msg interfaces.CallMsg) (uint64, error) {
+	return poolCall(ctx, p, true, func(c Client) (uint64, error) {
+		return c.EstimateGas(ctx, msg)
+	})
+}
+
+func (p *pool) EstimateBaseFee(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, false, func(c Client) (*big.Int, error) {
+		return c.EstimateBaseFee(ctx)
+	})
+}
+
+// SendTransaction sends [tx] to the sticky endpoint, if any, and makes the
+// endpoint which accepted it the sticky endpoint. Sending a transaction again
+// to another endpoint after a failure is safe, since it is signed: at most one
+// copy of it can be included.
+func (p *pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	e, err := p.do(ctx, true, func(c Client) error {
+		return c.SendTransaction(ctx, tx)
+	})
+	if err == nil {
+		p.stick(e)
+	}
+	return err
+}
+
+// SendTransactionConditional behaves as SendTransaction.
+func (p *pool) SendTransactionConditional(ctx context.Context, tx *types.Transaction, conditional types.TransactionConditional) error {
+	e, err := p.do(ctx, true, func(c Client) error {
+		return c.SendTransactionConditional(ctx, tx, conditional)
+	})
+	if err == nil {
+		p.stick(e)
+	}
+	return err
+}
+
+func (p *pool) WaitForHeight(ctx context.Context, height uint64) error {
+	_, err := p.do(ctx, false, func(c Client) error {
+		return c.WaitForHeight(ctx, height)
+	})
+	return err
+}
+
+// WaitForTxAcceptance is sent to the sticky endpoint, if any.
+func (p *pool) WaitForTxAcceptance(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return poolCall(ctx, p, true, func(c Client) (*types.Receipt, error) {
+		return c.WaitForTxAcceptance(ctx, txHash)
+	})
+}
+
+func (p *pool) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	_, err := p.do(ctx, false, func(c Client) error {
+		return c.BatchCallContext(ctx, b)
+	})
+	return err
+}
+
+func (p *pool) BlocksByNumbers(ctx context.Context, numbers []*big.Int) ([]*types.Block, error) {
+	return poolCall(ctx, p, false, func(c Client) ([]*types.Block, error) {
+		return c.BlocksByNumbers(ctx, numbers)
+	})
+}
+
+func (p *pool) ReceiptsByBlock(ctx context.Context, blocks []rpc.BlockNumberOrHash) ([][]*types.Receipt, error) {
+	return poolCall(ctx, p, false, func(c Client) ([][]*types.Receipt, error) {
+		return c.ReceiptsByBlock(ctx, blocks)
+	})
+}
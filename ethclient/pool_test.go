@@ -0,0 +1,187 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// poolTestService is a minimal eth namespace whose nonce is incremented by
+// every transaction it receives.
+type poolTestService struct {
+	height atomic.Uint64
+	nonce  atomic.Uint64
+}
+
+func (s *poolTestService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.height.Load())
+}
+
+func (s *poolTestService) GetTransactionCount(common.Address, rpc.BlockNumberOrHash) hexutil.Uint64 {
+	return hexutil.Uint64(s.nonce.Load())
+}
+
+func (s *poolTestService) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	s.nonce.Add(1)
+	return tx.Hash(), nil
+}
+
+func (s *poolTestService) GetTransactionReceipt(common.Hash) *types.Receipt {
+	return nil
+}
+
+func newPoolTestClient(t *testing.T, service *poolTestService) Client {
+	server := rpc.NewServer(0)
+	require.NoError(t, server.RegisterName("eth", service))
+	rpcClient := rpc.DialInProc(server)
+	t.Cleanup(server.Stop)
+	return NewClient(rpcClient)
+}
+
+func newTestPool(t *testing.T, clients ...Client) Pool {
+	p, err := NewPool(clients, PoolConfig{
+		MaxBlocksBehind: 5,
+		StickyPeriod:    DefaultPoolConfig.StickyPeriod,
+	})
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestNewPoolNoEndpoints(t *testing.T) {
+	_, err := NewPool(nil, DefaultPoolConfig)
+	require.ErrorIs(t, err, errNoEndpoints)
+}
+
+func TestPoolFailover(t *testing.T) {
+	down := newPoolTestClient(t, &poolTestService{})
+	down.Close()
+	up := &poolTestService{}
+	up.height.Store(10)
+	p := newTestPool(t, down, newPoolTestClient(t, up))
+
+	height, err := p.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), height)
+
+	endpoints := p.Endpoints()
+	require.False(t, endpoints[0].Healthy)
+	require.ErrorIs(t, endpoints[0].LastError, rpc.ErrClientQuit)
+	require.True(t, endpoints[1].Healthy)
+}
+
+func TestPoolRequestErrorNotRetried(t *testing.T) {
+	p := newTestPool(t, newPoolTestClient(t, &poolTestService{}), newPoolTestClient(t, &poolTestService{}))
+
+	_, err := p.TransactionReceipt(context.Background(), common.Hash{1})
+	require.ErrorIs(t, err, interfaces.NotFound)
+
+	err = p.SendTransaction(context.Background(), types.NewTx(&types.LegacyTx{}))
+	require.NoError(t, err)
+	for _, endpoint := range p.Endpoints() {
+		require.True(t, endpoint.Healthy)
+	}
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	behind, ahead := &poolTestService{}, &poolTestService{}
+	behind.height.Store(10)
+	ahead.height.Store(100)
+	down := newPoolTestClient(t, &poolTestService{})
+	down.Close()
+	p := newTestPool(t, newPoolTestClient(t, behind), newPoolTestClient(t, ahead), down)
+
+	p.CheckHealth(context.Background())
+	endpoints := p.Endpoints()
+	require.False(t, endpoints[0].Healthy)
+	require.ErrorIs(t, endpoints[0].LastError, errEndpointTooBehind)
+	require.True(t, endpoints[1].Healthy)
+	require.Equal(t, uint64(100), endpoints[1].Height)
+	require.False(t, endpoints[2].Healthy)
+
+	height, err := p.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), height)
+
+	// Once caught up, the first endpoint is preferred again
+	behind.height.Store(98)
+	p.CheckHealth(context.Background())
+	require.True(t, p.Endpoints()[0].Healthy)
+	height, err = p.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(98), height)
+}
+
+func TestPoolSticky(t *testing.T) {
+	first, second := &poolTestService{}, &poolTestService{}
+	second.height.Store(100)
+	p := newTestPool(t, newPoolTestClient(t, first), newPoolTestClient(t, second))
+
+	// The first endpoint is behind, so the transaction is sent to the second one
+	p.CheckHealth(context.Background())
+	require.NoError(t, p.SendTransaction(context.Background(), types.NewTx(&types.LegacyTx{})))
+	require.Equal(t, uint64(0), first.nonce.Load())
+	require.Equal(t, uint64(1), second.nonce.Load())
+
+	// Nonce queries stick to the second endpoint once the first one caught up,
+	// while other queries are sent to the first endpoint again.
+	first.height.Store(99)
+	p.CheckHealth(context.Background())
+	height, err := p.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(99), height)
+	nonce, err := p.NonceAt(context.Background(), common.Address{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), nonce)
+	nonce, err = p.AcceptedNonceAt(context.Background(), common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), nonce)
+
+	require.NoError(t, p.SendTransaction(context.Background(), types.NewTx(&types.LegacyTx{})))
+	require.Equal(t, uint64(2), second.nonce.Load())
+}
+
+func TestIsEndpointError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil":               {err: nil, expected: false},
+		"not found":         {err: interfaces.NotFound, expected: false},
+		"context canceled":  {err: context.Canceled, expected: false},
+		"client closed":     {err: rpc.ErrClientQuit, expected: true},
+		"connection error":  {err: errors.New("connection refused"), expected: true},
+		"server error":      {err: rpc.HTTPError{StatusCode: 503}, expected: true},
+		"too many requests": {err: rpc.HTTPError{StatusCode: 429}, expected: true},
+		"bad request":       {err: rpc.HTTPError{StatusCode: 400}, expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, isEndpointError(test.err))
+		})
+	}
+}
+
+func TestPoolMethodNotFound(t *testing.T) {
+	p := newTestPool(t, newPoolTestClient(t, &poolTestService{}))
+	// The method is not served by the test service, which is an error of the
+	// request rather than of the endpoint, so it is not retried.
+	_, err := p.ChainID(context.Background())
+	require.Error(t, err)
+	require.True(t, p.Endpoints()[0].Healthy)
+}
@@ -33,12 +33,14 @@ import (
 	"runtime"
 	"runtime/debug"
 
+	"github.com/ava-labs/subnet-evm/core/stateless"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/interfaces"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // Client is a wrapper around rpc.Client that implements geth-specific functionality.
@@ -161,6 +163,34 @@ func (ec *Client) CallContract(ctx context.Context, msg interfaces.CallMsg, bloc
 	return hex, err
 }
 
+// ExecutionWitness retrieves the execution witness of the block [blockNumber],
+// to verify its execution without the full state with core.ExecuteStateless.
+func (ec *Client) ExecutionWitness(ctx context.Context, blockNumber *big.Int) (*stateless.Witness, error) {
+	var result hexutil.Bytes
+	if err := ec.c.CallContext(ctx, &result, "debug_executionWitness", ethclient.ToBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	witness := new(stateless.Witness)
+	if err := rlp.DecodeBytes(result, witness); err != nil {
+		return nil, err
+	}
+	return witness, nil
+}
+
+// RawBlock retrieves the RLP encoded block [blockNumber], as created by the
+// block producer.
+func (ec *Client) RawBlock(ctx context.Context, blockNumber *big.Int) (*types.Block, error) {
+	var result hexutil.Bytes
+	if err := ec.c.CallContext(ctx, &result, "debug_getRawBlock", ethclient.ToBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(result, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
 // GCStats retrieves the current garbage collection stats from a geth node.
 func (ec *Client) GCStats(ctx context.Context) (*debug.GCStats, error) {
 	var result debug.GCStats
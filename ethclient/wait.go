@@ -0,0 +1,113 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// waitPollInterval is how often the wait helpers query the node when no head
+// notification arrives, e.g. because the connection does not support subscriptions.
+const waitPollInterval = 100 * time.Millisecond
+
+// WaitForHeight blocks until the node has accepted a block at [height] or higher,
+// or [ctx] is done.
+func (ec *client) WaitForHeight(ctx context.Context, height uint64) error {
+	var lastAccepted uint64
+	return ec.waitFor(ctx, func() string {
+		return fmt.Sprintf("height %d (last accepted %d)", height, lastAccepted)
+	}, func(ctx context.Context) (bool, error) {
+		accepted, err := ec.BlockNumber(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch last accepted height: %w", err)
+		}
+		lastAccepted = accepted
+		return lastAccepted >= height, nil
+	})
+}
+
+// WaitForTxAcceptance blocks until the transaction [txHash] is included in an
+// accepted block and returns its receipt, or returns an error once [ctx] is done.
+func (ec *client) WaitForTxAcceptance(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := ec.waitFor(ctx, func() string {
+		return fmt.Sprintf("tx %s", txHash)
+	}, func(ctx context.Context) (bool, error) {
+		var err error
+		// Receipts are only served for transactions in accepted blocks.
+		receipt, err = ec.TransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, interfaces.NotFound):
+			return false, nil
+		default:
+			return false, fmt.Errorf("failed to fetch receipt of tx %s: %w", txHash, err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// waitFor calls [check] until it reports done, returns an error, or [ctx] is done,
+// in which case the returned error describes the awaited condition with [desc].
+// [check] is called again whenever the node notifies a new head, and at least every
+// [waitPollInterval] in case notifications are unavailable or the subscription fails.
+func (ec *client) waitFor(ctx context.Context, desc func() string, check func(context.Context) (bool, error)) error {
+	var (
+		heads  = make(chan *types.Header, 1)
+		subErr <-chan error
+	)
+	// Subscriptions are not supported over HTTP, in which case only polling is used.
+	if sub, err := ec.SubscribeNewHead(ctx, heads); err == nil {
+		defer sub.Unsubscribe()
+		subErr = sub.Err()
+	}
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			// The request may have failed because [ctx] expired while it was in flight.
+			if ctxErr := contextErr(ctx); ctxErr != nil {
+				return fmt.Errorf("timed out waiting for %s: %w", desc(), ctxErr)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-heads:
+		case <-subErr:
+			// Fall back to polling.
+			subErr = nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s: %w", desc(), ctx.Err())
+		}
+	}
+}
+
+// contextErr returns the error of [ctx] if it is done or its deadline has passed.
+// Transports may fail a request on the deadline before [ctx] reports it.
+func contextErr(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
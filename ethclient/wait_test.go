@@ -0,0 +1,88 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethclient
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// waitTestService is a minimal eth namespace that accepts a new block every time
+// the last accepted height is queried. It does not support subscriptions, so the
+// wait helpers have to fall back to polling.
+type waitTestService struct {
+	height   atomic.Uint64
+	txHash   common.Hash
+	txHeight uint64
+}
+
+func (s *waitTestService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.height.Add(1))
+}
+
+func (s *waitTestService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	height := s.height.Add(1)
+	if hash != s.txHash || height < s.txHeight {
+		return nil
+	}
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		Logs:        []*types.Log{},
+		TxHash:      hash,
+		BlockNumber: new(big.Int).SetUint64(s.txHeight),
+	}
+}
+
+func newWaitTestClient(t *testing.T, service *waitTestService) Client {
+	server := rpc.NewServer(0)
+	require.NoError(t, server.RegisterName("eth", service))
+	rpcClient := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		rpcClient.Close()
+		server.Stop()
+	})
+	return NewClient(rpcClient)
+}
+
+func TestWaitForHeight(t *testing.T) {
+	client := newWaitTestClient(t, &waitTestService{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, client.WaitForHeight(ctx, 3))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*waitPollInterval)
+	defer cancel()
+	err := client.WaitForHeight(ctx, 1_000)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForTxAcceptance(t *testing.T) {
+	service := &waitTestService{
+		txHash:   common.Hash{1},
+		txHeight: 3,
+	}
+	client := newWaitTestClient(t, service)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := client.WaitForTxAcceptance(ctx, service.txHash)
+	require.NoError(t, err)
+	require.Equal(t, service.txHash, receipt.TxHash)
+	require.Equal(t, service.txHeight, receipt.BlockNumber.Uint64())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*waitPollInterval)
+	defer cancel()
+	_, err = client.WaitForTxAcceptance(ctx, common.Hash{2})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
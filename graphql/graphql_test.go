@@ -0,0 +1,180 @@
+// (c) 2024, Ava Labs, Inc.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/eth"
+	"github.com/ava-labs/subnet-evm/eth/ethconfig"
+	"github.com/ava-labs/subnet-evm/eth/filters"
+	"github.com/ava-labs/subnet-evm/node"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	dest        = common.HexToAddress("0x0000000000000000000000000000000000000dad")
+)
+
+func TestBuildSchema(t *testing.T) {
+	// Make sure the schema can be parsed and matched up to the object model.
+	_, err := New(nil, nil)
+	require.NoError(t, err)
+}
+
+func TestGraphQLBlockSerialization(t *testing.T) {
+	genesis := &core.Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: params.TestChainConfig.FeeConfig.GasLimit.Uint64(),
+		Alloc:    core.GenesisAlloc{testAddr: {Balance: testBalance}},
+	}
+	url := newGQLService(t, genesis, 2, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(constants.BlackholeAddr)
+		signer := types.LatestSigner(genesis.Config)
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			Nonce:     gen.TxNonce(testAddr),
+			To:        &dest,
+			Value:     big.NewInt(1),
+			Gas:       params.TxGas,
+			GasFeeCap: gen.BaseFee(),
+			GasTipCap: big.NewInt(0),
+		}), signer, testKey)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+
+	feeConfig := genesis.Config.FeeConfig
+	wantFeeConfig := fmt.Sprintf(
+		`{"gasLimit":"%#x","targetBlockRate":"%#x","minBaseFee":"%#x","lastChangedAt":"0x0"}`,
+		feeConfig.GasLimit, feeConfig.TargetBlockRate, feeConfig.MinBaseFee,
+	)
+	for i, tt := range []struct {
+		body string
+		want string
+		code int
+	}{
+		{ // Should return latest block
+			body: `{"query": "{block{number}}","variables": null}`,
+			want: `{"data":{"block":{"number":"0x2"}}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{block(number:1){number,gasUsed,transactionCount}}","variables": null}`,
+			want: `{"data":{"block":{"number":"0x1","gasUsed":"0x5208","transactionCount":"0x1"}}}`,
+			code: 200,
+		},
+		{ // Blocks above the last accepted one cannot be queried
+			body: `{"query": "{block(number:1337){number}}","variables": null}`,
+			want: `{"errors":[{"message":"cannot query unfinalized data","path":["block"]}],"data":{"block":null}}`,
+			code: 400,
+		},
+		{
+			body: `{"query": "{block(number:\"a\"){number}}","variables": null}`,
+			want: `{"errors":[{"message":"strconv.ParseInt: parsing \"a\": invalid syntax"}],"data":{}}`,
+			code: 400,
+		},
+		{
+			body: `{"query": "{bleh{number}}","variables": null}"`,
+			want: `{"errors":[{"message":"Cannot query field \"bleh\" on type \"Query\".","locations":[{"line":1,"column":2}]}]}`,
+			code: 400,
+		},
+		{ // Should return the subnet fee fields
+			body: `{"query": "{block(number:0){blockGasCost,feeConfig{gasLimit,targetBlockRate,minBaseFee,lastChangedAt}}}","variables": null}`,
+			want: `{"data":{"block":{"blockGasCost":null,"feeConfig":` + wantFeeConfig + `}}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{block(number:2){blockGasCost,feeConfig{gasLimit,targetBlockRate,minBaseFee,lastChangedAt}}}","variables": null}`,
+			want: `{"data":{"block":{"blockGasCost":"0x0","feeConfig":` + wantFeeConfig + `}}}`,
+			code: 200,
+		},
+		{
+			body: fmt.Sprintf(`{"query": "{block{account(address:\"%s\"){balance}}}","variables": null}`, dest),
+			want: `{"data":{"block":{"account":{"balance":"0x2"}}}}`,
+			code: 200,
+		},
+	} {
+		resp, err := http.Post(url, "application/json", strings.NewReader(tt.body))
+		require.NoError(t, err)
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, tt.want, string(bodyBytes), "testcase %d %s", i, tt.body)
+		require.Equal(t, tt.code, resp.StatusCode, "testcase %d %s", i, tt.body)
+	}
+}
+
+// newGQLService creates a chain from [gspec] with [genBlocks] accepted blocks
+// and returns the URL of a GraphQL handler serving it.
+func newGQLService(t *testing.T, gspec *core.Genesis, genBlocks int, genfunc func(i int, gen *core.BlockGen)) string {
+	stack, err := node.New(&node.Config{})
+	require.NoError(t, err)
+
+	ethConf := ethconfig.NewDefaultConfig()
+	ethConf.Genesis = gspec
+	ethBackend, err := eth.New(stack, &ethConf, nil, rawdb.NewMemoryDatabase(), eth.DefaultSettings, common.Hash{}, &mockable.Clock{})
+	require.NoError(t, err)
+	ethBackend.Start()
+	t.Cleanup(func() { ethBackend.Stop() })
+
+	// Create some blocks and import them
+	chain := ethBackend.BlockChain()
+	_, blocks, _, err := core.GenerateChainWithGenesis(gspec, dummy.NewFaker(), genBlocks, 10, genfunc)
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+
+	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{})
+	handler, err := New(ethBackend.APIBackend, filterSystem)
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
@@ -0,0 +1,65 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package interfaces
+
+import (
+	"encoding/json"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// HeadNotification is the payload of newHeads subscriptions.
+// It encodes the header with two additional fields, so subscribers decoding the
+// payload as a plain header keep working.
+type HeadNotification struct {
+	*types.Header
+	// Accepted is true if the block of the header has been accepted by consensus.
+	Accepted bool
+	// LastAcceptedHeight is the height of the last block accepted by the node when the
+	// notification was sent. Subscribers can compare it across nodes to detect lag.
+	LastAcceptedHeight uint64
+}
+
+type headNotificationExtra struct {
+	Accepted           bool           `json:"accepted"`
+	LastAcceptedHeight hexutil.Uint64 `json:"lastAcceptedHeight"`
+}
+
+// MarshalJSON encodes the header fields and the acceptance fields as a single object.
+func (n HeadNotification) MarshalJSON() ([]byte, error) {
+	headerJSON, err := json.Marshal(n.Header)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(headerJSON, &fields); err != nil {
+		return nil, err
+	}
+	fields["accepted"], err = json.Marshal(n.Accepted)
+	if err != nil {
+		return nil, err
+	}
+	fields["lastAcceptedHeight"], err = json.Marshal(hexutil.Uint64(n.LastAcceptedHeight))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes a notification encoded by MarshalJSON.
+func (n *HeadNotification) UnmarshalJSON(input []byte) error {
+	var header types.Header
+	if err := json.Unmarshal(input, &header); err != nil {
+		return err
+	}
+	var extra headNotificationExtra
+	if err := json.Unmarshal(input, &extra); err != nil {
+		return err
+	}
+	n.Header = &header
+	n.Accepted = extra.Accepted
+	n.LastAcceptedHeight = uint64(extra.LastAcceptedHeight)
+	return nil
+}
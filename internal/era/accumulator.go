@@ -0,0 +1,97 @@
+// (c) 2024, Ava Labs, Inc.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+package era
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// accumulatorDepth is the depth of the merkle tree of the header records of an
+// era1 file, which holds up to MaxSize = 2^accumulatorDepth records.
+const accumulatorDepth = 13
+
+// ComputeAccumulator returns the SSZ hash tree root of the list of header
+// records (block hash, total difficulty) of an era1 file, as defined by the
+// historical accumulator of the Portal network.
+func ComputeAccumulator(hashes []common.Hash, tds []*big.Int) (common.Hash, error) {
+	if len(hashes) != len(tds) {
+		return common.Hash{}, fmt.Errorf("cannot compute accumulator of %d hashes and %d total difficulties", len(hashes), len(tds))
+	}
+	if len(hashes) > MaxSize {
+		return common.Hash{}, fmt.Errorf("cannot compute accumulator of more than %d records: %d", MaxSize, len(hashes))
+	}
+	chunks := make([][32]byte, len(hashes))
+	for i, hash := range hashes {
+		td := littleEndianUint256(tds[i])
+		chunks[i] = sha256.Sum256(append(hash.Bytes(), td[:]...))
+	}
+	root := merkleize(chunks, accumulatorDepth)
+
+	// Mix in the length of the list
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:8], uint64(len(hashes)))
+	return sha256.Sum256(append(root[:], length[:]...)), nil
+}
+
+// merkleize returns the root of the merkle tree of [depth] whose leaves are
+// [chunks], padded with zero chunks.
+func merkleize(chunks [][32]byte, depth int) [32]byte {
+	var zero [32]byte
+	for level := 0; level < depth; level++ {
+		if len(chunks) == 0 {
+			zero = sha256.Sum256(append(zero[:], zero[:]...))
+			continue
+		}
+		if len(chunks)%2 == 1 {
+			chunks = append(chunks, zero)
+		}
+		next := make([][32]byte, len(chunks)/2)
+		for i := range next {
+			next[i] = sha256.Sum256(append(chunks[2*i][:], chunks[2*i+1][:]...))
+		}
+		chunks = next
+		zero = sha256.Sum256(append(zero[:], zero[:]...))
+	}
+	if len(chunks) == 0 {
+		return zero
+	}
+	return chunks[0]
+}
+
+// littleEndianUint256 returns [n] as a 32 bytes little-endian integer.
+func littleEndianUint256(n *big.Int) [32]byte {
+	var b [32]byte
+	n.FillBytes(b[:])
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
@@ -0,0 +1,112 @@
+// (c) 2024, Ava Labs, Inc.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// headerSize is the size of the header of an e2store entry: its type (2
+// bytes), the length of its value (4 bytes) and 2 reserved bytes.
+const headerSize = 8
+
+var errReservedNotZero = errors.New("reserved bytes of entry header are not zero")
+
+// entry is a type-length-value record of an e2store file.
+type entry struct {
+	typ   uint16
+	value []byte
+}
+
+// e2storeWriter writes the entries of an e2store file.
+type e2storeWriter struct {
+	w io.Writer
+}
+
+func newE2storeWriter(w io.Writer) *e2storeWriter {
+	return &e2storeWriter{w: w}
+}
+
+// write writes the entry of type [typ] holding [value] and returns the number
+// of bytes written.
+func (w *e2storeWriter) write(typ uint16, value []byte) (int, error) {
+	var header [headerSize]byte
+	binary.LittleEndian.PutUint16(header[0:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(value)))
+	n, err := w.w.Write(header[:])
+	if err != nil {
+		return n, err
+	}
+	m, err := w.w.Write(value)
+	return n + m, err
+}
+
+// e2storeReader reads the entries of an e2store file at arbitrary offsets.
+type e2storeReader struct {
+	r io.ReaderAt
+}
+
+func newE2storeReader(r io.ReaderAt) *e2storeReader {
+	return &e2storeReader{r: r}
+}
+
+// readAt reads the entry at [off] and returns it along with its total size.
+func (r *e2storeReader) readAt(off int64) (*entry, int64, error) {
+	var header [headerSize]byte
+	if _, err := r.r.ReadAt(header[:], off); err != nil {
+		return nil, 0, err
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return nil, 0, errReservedNotZero
+	}
+	e := &entry{
+		typ:   binary.LittleEndian.Uint16(header[0:2]),
+		value: make([]byte, binary.LittleEndian.Uint32(header[2:6])),
+	}
+	if _, err := r.r.ReadAt(e.value, off+headerSize); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, fmt.Errorf("failed to read entry value at %d: %w", off, err)
+	}
+	return e, headerSize + int64(len(e.value)), nil
+}
+
+// readTypeAt reads the entry at [off], which must be of type [typ].
+func (r *e2storeReader) readTypeAt(off int64, typ uint16) ([]byte, int64, error) {
+	e, n, err := r.readAt(off)
+	if err != nil {
+		return nil, 0, err
+	}
+	if e.typ != typ {
+		return nil, 0, fmt.Errorf("expected entry of type %#x at %d, got %#x", typ, off, e.typ)
+	}
+	return e.value, n, nil
+}
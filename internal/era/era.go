@@ -0,0 +1,343 @@
+// (c) 2024, Ava Labs, Inc.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+// Package era implements the era1 archive format of the block history: e2store
+// files holding the headers, bodies, receipts and total difficulties of up to
+// MaxSize consecutive blocks, followed by their accumulator and an index of the
+// blocks.
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+)
+
+// MaxSize is the maximum number of blocks of an era1 file.
+const MaxSize = 1 << accumulatorDepth
+
+// Types of the e2store entries of era1 files
+const (
+	TypeVersion            uint16 = 0x3265
+	TypeCompressedHeader   uint16 = 0x03
+	TypeCompressedBody     uint16 = 0x04
+	TypeCompressedReceipts uint16 = 0x05
+	TypeTotalDifficulty    uint16 = 0x06
+	TypeAccumulator        uint16 = 0x07
+	TypeBlockIndex         uint16 = 0x3266
+)
+
+var (
+	errEmpty      = errors.New("cannot finalize empty era1 file")
+	errFull       = errors.New("era1 file is full")
+	errNotInOrder = errors.New("blocks must be added in order")
+)
+
+// Filename returns the name of the era1 file of [network] holding the blocks
+// of [epoch], whose accumulator is [root].
+func Filename(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s.era1", network, epoch, root.Hex()[2:10])
+}
+
+// ReadDir returns the paths of the era1 files of [network] in [dir], sorted
+// by epoch.
+func ReadDir(dir string, network string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, network+"-") || filepath.Ext(name) != ".era1" {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Builder writes the blocks added to it into an era1 file.
+type Builder struct {
+	w       *e2storeWriter
+	written int64
+
+	start   *uint64
+	offsets []int64
+	hashes  []common.Hash
+	tds     []*big.Int
+}
+
+// NewBuilder returns a Builder writing an era1 file into [w].
+func NewBuilder(w io.Writer) *Builder {
+	return &Builder{w: newE2storeWriter(w)}
+}
+
+// Add adds [block], its [receipts] and the total difficulty [td] of the chain
+// up to it to the era1 file. Blocks must be added in order.
+func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int) error {
+	if len(b.offsets) == MaxSize {
+		return errFull
+	}
+	number := block.NumberU64()
+	if b.start == nil {
+		if err := b.write(TypeVersion, nil); err != nil {
+			return err
+		}
+		b.start = &number
+	} else if number != *b.start+uint64(len(b.offsets)) {
+		return fmt.Errorf("%w: expected block %d, got %d", errNotInOrder, *b.start+uint64(len(b.offsets)), number)
+	}
+	b.offsets = append(b.offsets, b.written)
+	b.hashes = append(b.hashes, block.Hash())
+	b.tds = append(b.tds, new(big.Int).Set(td))
+
+	header, err := rlp.EncodeToBytes(block.Header())
+	if err != nil {
+		return err
+	}
+	body, err := rlp.EncodeToBytes(block.Body())
+	if err != nil {
+		return err
+	}
+	rawReceipts, err := rlp.EncodeToBytes(receipts)
+	if err != nil {
+		return err
+	}
+	for _, compressed := range []struct {
+		typ  uint16
+		data []byte
+	}{
+		{TypeCompressedHeader, header},
+		{TypeCompressedBody, body},
+		{TypeCompressedReceipts, rawReceipts},
+	} {
+		if err := b.writeCompressed(compressed.typ, compressed.data); err != nil {
+			return err
+		}
+	}
+	tdBytes := littleEndianUint256(td)
+	return b.write(TypeTotalDifficulty, tdBytes[:])
+}
+
+// Finalize writes the accumulator and the block index of the era1 file, and
+// returns the accumulator.
+func (b *Builder) Finalize() (common.Hash, error) {
+	if b.start == nil {
+		return common.Hash{}, errEmpty
+	}
+	root, err := ComputeAccumulator(b.hashes, b.tds)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := b.write(TypeAccumulator, root.Bytes()); err != nil {
+		return common.Hash{}, err
+	}
+
+	// The offsets of the blocks are relative to the block index entry.
+	index := make([]byte, 16+8*len(b.offsets))
+	binary.LittleEndian.PutUint64(index, *b.start)
+	for i, offset := range b.offsets {
+		binary.LittleEndian.PutUint64(index[8+8*i:], uint64(offset-b.written))
+	}
+	binary.LittleEndian.PutUint64(index[8+8*len(b.offsets):], uint64(len(b.offsets)))
+	if err := b.write(TypeBlockIndex, index); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+func (b *Builder) write(typ uint16, value []byte) error {
+	n, err := b.w.write(typ, value)
+	b.written += int64(n)
+	return err
+}
+
+func (b *Builder) writeCompressed(typ uint16, value []byte) error {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(value); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return b.write(typ, buf.Bytes())
+}
+
+// ReadAtSeekCloser is the file an Era is read from.
+type ReadAtSeekCloser interface {
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// Era reads the blocks of an era1 file.
+type Era struct {
+	f     ReadAtSeekCloser
+	r     *e2storeReader
+	start uint64
+	count uint64
+	// indexOffset is the offset of the block index entry
+	indexOffset int64
+}
+
+// Open opens the era1 file at [path].
+func Open(path string) (*Era, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	e, err := From(f)
+	if err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	return e, nil
+}
+
+// From returns the Era read from [f], which it closes once closed.
+func From(f ReadAtSeekCloser) (*Era, error) {
+	length, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if length < headerSize+24 {
+		return nil, fmt.Errorf("era1 file too short: %d bytes", length)
+	}
+	var buf [8]byte
+	if _, err := f.ReadAt(buf[:], length-8); err != nil {
+		return nil, err
+	}
+	count := binary.LittleEndian.Uint64(buf[:])
+	if count == 0 || count > MaxSize {
+		return nil, fmt.Errorf("invalid era1 block count: %d", count)
+	}
+	e := &Era{
+		f:           f,
+		r:           newE2storeReader(f),
+		count:       count,
+		indexOffset: length - headerSize - 16 - 8*int64(count),
+	}
+	index, _, err := e.r.readTypeAt(e.indexOffset, TypeBlockIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block index: %w", err)
+	}
+	e.start = binary.LittleEndian.Uint64(index)
+	if _, _, err := e.r.readTypeAt(0, TypeVersion); err != nil {
+		return nil, fmt.Errorf("failed to read version: %w", err)
+	}
+	return e, nil
+}
+
+// Start returns the number of the first block of the era1 file.
+func (e *Era) Start() uint64 { return e.start }
+
+// Count returns the number of blocks of the era1 file.
+func (e *Era) Count() uint64 { return e.count }
+
+// Close closes the era1 file.
+func (e *Era) Close() error { return e.f.Close() }
+
+// GetBlockWithReceiptsByNumber returns the block [number] of the era1 file,
+// along with its receipts and the total difficulty of the chain up to it.
+func (e *Era) GetBlockWithReceiptsByNumber(number uint64) (*types.Block, types.Receipts, *big.Int, error) {
+	if number < e.start || number >= e.start+e.count {
+		return nil, nil, nil, fmt.Errorf("block %d out of range [%d, %d)", number, e.start, e.start+e.count)
+	}
+	var buf [8]byte
+	if _, err := e.f.ReadAt(buf[:], e.indexOffset+headerSize+8+8*int64(number-e.start)); err != nil {
+		return nil, nil, nil, err
+	}
+	off := e.indexOffset + int64(binary.LittleEndian.Uint64(buf[:]))
+
+	var header types.Header
+	n, err := e.readCompressed(off, TypeCompressedHeader, &header)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read header of block %d: %w", number, err)
+	}
+	off += n
+	var body types.Body
+	n, err = e.readCompressed(off, TypeCompressedBody, &body)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read body of block %d: %w", number, err)
+	}
+	off += n
+	var receipts types.Receipts
+	n, err = e.readCompressed(off, TypeCompressedReceipts, &receipts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read receipts of block %d: %w", number, err)
+	}
+	off += n
+	tdBytes, _, err := e.r.readTypeAt(off, TypeTotalDifficulty)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read total difficulty of block %d: %w", number, err)
+	}
+	block := types.NewBlockWithHeader(&header).WithBody(body.Transactions, body.Uncles)
+	return block, receipts, littleEndianToBig(tdBytes), nil
+}
+
+// Accumulator returns the accumulator of the era1 file.
+func (e *Era) Accumulator() (common.Hash, error) {
+	// The accumulator entry precedes the block index entry.
+	off := e.indexOffset - headerSize - common.HashLength
+	value, _, err := e.r.readTypeAt(off, TypeAccumulator)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(value), nil
+}
+
+func (e *Era) readCompressed(off int64, typ uint16, val interface{}) (int64, error) {
+	value, n, err := e.r.readTypeAt(off, typ)
+	if err != nil {
+		return 0, err
+	}
+	data, err := io.ReadAll(snappy.NewReader(bytes.NewReader(value)))
+	if err != nil {
+		return 0, err
+	}
+	return n, rlp.DecodeBytes(data, val)
+}
+
+func littleEndianToBig(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
@@ -0,0 +1,134 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package era
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		start = 100
+		count = 32
+	)
+	var (
+		blocks   []*types.Block
+		receipts []types.Receipts
+		tds      []*big.Int
+		hashes   []common.Hash
+		td       = big.NewInt(0)
+	)
+	for i := uint64(0); i < count; i++ {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(start + i),
+			Difficulty: big.NewInt(1),
+			GasLimit:   8_000_000,
+			Time:       i,
+		}
+		tx := types.NewTransaction(i, common.Address{byte(i)}, big.NewInt(int64(i)), 21_000, big.NewInt(1), nil)
+		receipt := &types.Receipt{
+			Type:              types.LegacyTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21_000,
+			Logs:              []*types.Log{{Address: common.Address{byte(i)}, Topics: []common.Hash{{byte(i)}}, Data: []byte{byte(i)}}},
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		block := types.NewBlockWithHeader(header).WithBody([]*types.Transaction{tx}, nil)
+		td = new(big.Int).Add(td, header.Difficulty)
+
+		blocks = append(blocks, block)
+		receipts = append(receipts, types.Receipts{receipt})
+		tds = append(tds, td)
+		hashes = append(hashes, block.Hash())
+	}
+
+	path := filepath.Join(t.TempDir(), "test.era1")
+	f, err := os.Create(path)
+	require.NoError(err)
+	builder := NewBuilder(f)
+	for i, block := range blocks {
+		require.NoError(builder.Add(block, receipts[i], tds[i]))
+	}
+	// Blocks must be added in order
+	require.ErrorIs(builder.Add(blocks[0], receipts[0], tds[0]), errNotInOrder)
+	root, err := builder.Finalize()
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	expectedRoot, err := ComputeAccumulator(hashes, tds)
+	require.NoError(err)
+	require.Equal(expectedRoot, root)
+
+	e, err := Open(path)
+	require.NoError(err)
+	defer e.Close()
+	require.Equal(uint64(start), e.Start())
+	require.Equal(uint64(count), e.Count())
+	accumulator, err := e.Accumulator()
+	require.NoError(err)
+	require.Equal(root, accumulator)
+
+	for i, want := range blocks {
+		block, blockReceipts, blockTd, err := e.GetBlockWithReceiptsByNumber(want.NumberU64())
+		require.NoError(err)
+		require.Equal(want.Hash(), block.Hash())
+		require.Equal(want.Transactions()[0].Hash(), block.Transactions()[0].Hash())
+		require.Equal(types.DeriveSha(receipts[i], trie.NewStackTrie(nil)), types.DeriveSha(blockReceipts, trie.NewStackTrie(nil)))
+		require.Zero(tds[i].Cmp(blockTd))
+	}
+	_, _, _, err = e.GetBlockWithReceiptsByNumber(start + count)
+	require.Error(err)
+}
+
+func TestBuilderEmpty(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "empty.era1"))
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = NewBuilder(f).Finalize()
+	require.ErrorIs(t, err, errEmpty)
+}
+
+func TestComputeAccumulator(t *testing.T) {
+	require := require.New(t)
+
+	_, err := ComputeAccumulator(make([]common.Hash, MaxSize+1), make([]*big.Int, MaxSize+1))
+	require.Error(err)
+
+	hashes := []common.Hash{{1}, {2}}
+	tds := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	root, err := ComputeAccumulator(hashes, tds)
+	require.NoError(err)
+	// The accumulator commits to the total difficulties of the blocks
+	other, err := ComputeAccumulator(hashes, []*big.Int{big.NewInt(1), big.NewInt(3)})
+	require.NoError(err)
+	require.NotEqual(root, other)
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		Filename("test", 1, common.Hash{1}),
+		Filename("test", 0, common.Hash{2}),
+		Filename("other", 0, common.Hash{3}),
+		"test-readme.txt",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+	paths, err := ReadDir(dir, "test")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, Filename("test", 0, common.Hash{2})),
+		filepath.Join(dir, Filename("test", 1, common.Hash{1})),
+	}, paths)
+}
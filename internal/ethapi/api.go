@@ -29,9 +29,12 @@ package ethapi
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -47,6 +50,9 @@ import (
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/eth/tracers/logger"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/davecgh/go-spew/spew"
@@ -97,6 +103,41 @@ func (s *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, e
 	return (*hexutil.Big)(tipcap), err
 }
 
+type priorityFeeEstimateResult struct {
+	BaseFee          *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	BlockGasCost     *hexutil.Big   `json:"blockGasCost,omitempty"`
+	RequiredBlockFee *hexutil.Big   `json:"requiredBlockFee,omitempty"`
+	TargetGas        *hexutil.Big   `json:"targetGas,omitempty"`
+	TargetBlockRate  hexutil.Uint64 `json:"targetBlockRate"`
+	SampledTip       *hexutil.Big   `json:"sampledPriorityFeePerGas"`
+	RequiredTip      *hexutil.Big   `json:"requiredPriorityFeePerGas,omitempty"`
+	Tip              *hexutil.Big   `json:"maxPriorityFeePerGas"`
+}
+
+// EstimatePriorityFee returns a suggestion for a gas tip cap for a dynamic fee transaction
+// using [gas] gas, accounting for the block gas cost the next block must cover.
+// If [gas] is not provided, the transaction is assumed to share the block with others.
+func (s *EthereumAPI) EstimatePriorityFee(ctx context.Context, gas *hexutil.Uint64) (*priorityFeeEstimateResult, error) {
+	var gasUsed uint64
+	if gas != nil {
+		gasUsed = uint64(*gas)
+	}
+	estimate, err := s.b.EstimatePriorityFee(ctx, gasUsed)
+	if err != nil {
+		return nil, err
+	}
+	return &priorityFeeEstimateResult{
+		BaseFee:          (*hexutil.Big)(estimate.BaseFee),
+		BlockGasCost:     (*hexutil.Big)(estimate.BlockGasCost),
+		RequiredBlockFee: (*hexutil.Big)(estimate.RequiredBlockFee),
+		TargetGas:        (*hexutil.Big)(estimate.TargetGas),
+		TargetBlockRate:  hexutil.Uint64(estimate.TargetBlockRate),
+		SampledTip:       (*hexutil.Big)(estimate.SampledTip),
+		RequiredTip:      (*hexutil.Big)(estimate.RequiredTip),
+		Tip:              (*hexutil.Big)(estimate.Tip),
+	}, nil
+}
+
 type feeHistoryResult struct {
 	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
@@ -225,32 +266,48 @@ func (s *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	}
 	pending, queue := s.b.TxPoolContent()
 
-	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
-		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
-	}
 	// Flatten the pending transactions
 	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
-		}
-		content["pending"][account.Hex()] = dump
+		content["pending"][account.Hex()] = inspectTxs(txs)
 	}
 	// Flatten the queued transactions
 	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
-		}
-		content["queued"][account.Hex()] = dump
+		content["queued"][account.Hex()] = inspectTxs(txs)
 	}
 	return content
 }
 
+// InspectFrom retrieves the transactions of [addr] in the transaction pool and
+// flattens them into an easily inspectable list.
+func (s *TxPoolAPI) InspectFrom(addr common.Address) map[string]map[string]string {
+	pending, queue := s.b.TxPoolContentFrom(addr)
+	return map[string]map[string]string{
+		"pending": inspectTxs(pending),
+		"queued":  inspectTxs(queue),
+	}
+}
+
+// Cancel drops the local transaction [hash] from the transaction pool, so that
+// its nonce can be reused by a transaction that does not meet the price bump
+// required to replace it. Peers that received the transaction may still
+// include it in a block.
+func (s *TxPoolAPI) Cancel(hash common.Hash) error {
+	return s.b.CancelPoolTransaction(hash)
+}
+
+// inspectTxs flattens [txs] into strings keyed by nonce.
+func inspectTxs(txs []*types.Transaction) map[string]string {
+	dump := make(map[string]string, len(txs))
+	for _, tx := range txs {
+		if to := tx.To(); to != nil {
+			dump[fmt.Sprintf("%d", tx.Nonce())] = fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		} else {
+			dump[fmt.Sprintf("%d", tx.Nonce())] = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+	}
+	return dump
+}
+
 // EthereumAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type EthereumAccountAPI struct {
@@ -619,16 +676,42 @@ func (api *BlockChainAPI) ChainId() *hexutil.Big {
 	return (*hexutil.Big)(api.b.ChainConfig().ChainID)
 }
 
-// GetActivePrecompilesAt returns the active precompile configs at the given block timestamp.
-func (s *BlockChainAPI) GetActivePrecompilesAt(ctx context.Context, blockTimestamp *uint64) params.Precompiles {
+// NativeCurrency returns the display name, symbol and decimals of the native token
+// of the chain, so wallets and explorers do not have to assume them.
+func (api *BlockChainAPI) NativeCurrency() params.NativeCurrency {
+	return api.b.ChainConfig().GetNativeCurrency()
+}
+
+// GetActivePrecompilesAt returns the active precompile configs at the given block timestamp,
+// including the precompile upgrades scheduled by the governance precompile as of the current block.
+func (s *BlockChainAPI) GetActivePrecompilesAt(ctx context.Context, blockTimestamp *uint64) (params.Precompiles, error) {
+	head := s.b.CurrentHeader()
 	var timestamp uint64
 	if blockTimestamp == nil {
-		timestamp = s.b.CurrentHeader().Time
+		timestamp = head.Time
 	} else {
 		timestamp = *blockTimestamp
 	}
 
-	return s.b.ChainConfig().EnabledStatefulPrecompiles(timestamp)
+	config, err := chainConfigAt(ctx, s.b, head)
+	if err != nil {
+		return nil, err
+	}
+	return config.EnabledStatefulPrecompiles(timestamp), nil
+}
+
+// chainConfigAt returns the chain config at [header], including the precompile upgrades
+// scheduled by the governance precompile in the state of [header].
+func chainConfigAt(ctx context.Context, b Backend, header *types.Header) (*params.ChainConfig, error) {
+	config := b.ChainConfig()
+	if !config.IsPrecompileEnabled(governance.ContractAddress, header.Time) {
+		return config, nil
+	}
+	statedb, _, err := b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(header.Hash(), false))
+	if err != nil {
+		return nil, err
+	}
+	return governance.ChainConfigAt(config, statedb, header.Time)
 }
 
 type FeeConfigResult struct {
@@ -955,26 +1038,102 @@ func (s *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.
 	// Derive the sender.
 	signer := types.MakeSigner(s.b.ChainConfig(), block.Number(), block.Time())
 
+	config, err := receiptPredicatesConfig(ctx, s.b, block.Header())
+	if err != nil {
+		return nil, err
+	}
 	result := make([]map[string]interface{}, len(receipts))
 	for i, receipt := range receipts {
 		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i)
+		marshalReceiptPredicates(result[i], config, block.Header(), txs[i])
 	}
 
 	return result, nil
 }
 
+// selectableReceiptFields are the receipt fields that can be requested from
+// GetBlockWithReceiptFields, along with how they are marshalled.
+var selectableReceiptFields = map[string]func(*types.Receipt) interface{}{
+	"status":            func(r *types.Receipt) interface{} { return hexutil.Uint(r.Status) },
+	"gasUsed":           func(r *types.Receipt) interface{} { return hexutil.Uint64(r.GasUsed) },
+	"cumulativeGasUsed": func(r *types.Receipt) interface{} { return hexutil.Uint64(r.CumulativeGasUsed) },
+	"effectiveGasPrice": func(r *types.Receipt) interface{} { return (*hexutil.Big)(r.EffectiveGasPrice) },
+	"logsCount":         func(r *types.Receipt) interface{} { return hexutil.Uint(len(r.Logs)) },
+	"contractAddress": func(r *types.Receipt) interface{} {
+		// If the ContractAddress is 20 0x0 bytes, assume it is not a contract creation
+		if r.ContractAddress == (common.Address{}) {
+			return nil
+		}
+		return r.ContractAddress
+	},
+}
+
+// GetBlockWithReceiptFields returns the requested block with only the hashes of its
+// transactions, each along with the receipt fields listed in [fields].
+// This lets explorers get the outcome of the transactions of a block in a single call,
+// instead of fetching the full block and then its receipts.
+// Receipts are only read if at least one field is requested.
+func (s *BlockChainAPI) GetBlockWithReceiptFields(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, fields []string) (map[string]interface{}, error) {
+	for _, field := range fields {
+		if _, ok := selectableReceiptFields[field]; !ok {
+			return nil, fmt.Errorf("unsupported receipt field %q", field)
+		}
+	}
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		// When the block doesn't exist, the RPC method should return JSON null
+		// as per specification.
+		return nil, nil
+	}
+	txs := block.Transactions()
+	var receipts types.Receipts
+	if len(fields) > 0 && len(txs) > 0 {
+		receipts, err = s.b.GetReceipts(ctx, block.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if len(txs) != len(receipts) {
+			return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+		}
+	}
+
+	response, err := s.rpcMarshalBlock(ctx, block, false, false)
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]map[string]interface{}, len(txs))
+	for i, tx := range txs {
+		transaction := map[string]interface{}{
+			"hash": tx.Hash(),
+		}
+		for _, field := range fields {
+			transaction[field] = selectableReceiptFields[field](receipts[i])
+		}
+		transactions[i] = transaction
+	}
+	response["transactions"] = transactions
+	// Note: Subnet-EVM enforces that the difficulty of a block is always 1, such that the total difficulty of a block
+	// will be equivalent to its height.
+	response["totalDifficulty"] = (*hexutil.Big)(block.Number())
+	return response, nil
+}
+
 // OverrideAccount indicates the overriding fields of account during the execution
 // of a message call.
 // Note, state and stateDiff can't be specified at the same time. If state is
 // set, message execution will only use the data in the given state. Otherwise
 // if statDiff is set, all diff will be applied first and then execute the call
 // message.
+// If the account is a stateful precompile, precompileConfig overrides its config
+// as if it was activated in the block of the call (e.g. {"disable": true} to
+// deactivate it), before the other fields are applied.
 type OverrideAccount struct {
-	Nonce     *hexutil.Uint64              `json:"nonce"`
-	Code      *hexutil.Bytes               `json:"code"`
-	Balance   **hexutil.Big                `json:"balance"`
-	State     *map[common.Hash]common.Hash `json:"state"`
-	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+	Nonce            *hexutil.Uint64              `json:"nonce"`
+	Code             *hexutil.Bytes               `json:"code"`
+	Balance          **hexutil.Big                `json:"balance"`
+	State            *map[common.Hash]common.Hash `json:"state"`
+	StateDiff        *map[common.Hash]common.Hash `json:"stateDiff"`
+	PrecompileConfig *json.RawMessage             `json:"precompileConfig"`
 }
 
 // StateOverride is the collection of overridden accounts.
@@ -1019,6 +1178,74 @@ func (diff *StateOverride) Apply(state *state.StateDB) error {
 	return nil
 }
 
+// ApplyPrecompiles configures the stateful precompiles with an overridden config
+// into the given state, as if the configs were activated in the block of
+// [blockCtx]. It returns a copy of [chainConfig] including the overridden
+// configs, or [chainConfig] itself if no precompile config is overridden.
+func (diff *StateOverride) ApplyPrecompiles(chainConfig *params.ChainConfig, state *state.StateDB, blockCtx *vm.BlockContext) (*params.ChainConfig, error) {
+	if diff == nil {
+		return chainConfig, nil
+	}
+	var overrides []params.PrecompileUpgrade
+	for addr, account := range *diff {
+		if account.PrecompileConfig == nil {
+			continue
+		}
+		module, ok := modules.GetPrecompileModuleByAddress(addr)
+		if !ok {
+			return nil, fmt.Errorf("account %s is not a stateful precompile", addr.Hex())
+		}
+		config, err := decodePrecompileOverride(module, *account.PrecompileConfig, blockCtx.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid precompile config of account %s: %w", addr.Hex(), err)
+		}
+		overrides = append(overrides, params.PrecompileUpgrade{Config: config})
+	}
+	if len(overrides) == 0 {
+		return chainConfig, nil
+	}
+	config := *chainConfig
+	config.PrecompileUpgrades = append(slices.Clone(chainConfig.PrecompileUpgrades), overrides...)
+	// Configure the precompiles in the same order as the registered modules, as
+	// is done when precompiles are activated by the chain.
+	for _, module := range modules.RegisteredModules() {
+		for _, override := range overrides {
+			if override.Key() != module.ConfigKey {
+				continue
+			}
+			if !override.IsDisabled() {
+				if err := override.Verify(&config); err != nil {
+					return nil, fmt.Errorf("invalid precompile config of account %s: %w", module.Address.Hex(), err)
+				}
+			}
+			if err := core.ApplyPrecompileConfig(&config, module, override.Config, blockCtx, state); err != nil {
+				return nil, err
+			}
+		}
+	}
+	state.Finalise(false)
+	return &config, nil
+}
+
+// decodePrecompileOverride decodes the overridden config of the precompile of
+// [module], activating it at [timestamp].
+func decodePrecompileOverride(module modules.Module, data json.RawMessage, timestamp uint64) (precompileconfig.Config, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["blockTimestamp"] = json.RawMessage(strconv.FormatUint(timestamp, 10))
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	config := module.MakeConfig()
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 // BlockOverrides is a set of header fields to override.
 type BlockOverrides struct {
 	Number     *hexutil.Big
@@ -1087,6 +1314,14 @@ func (context *ChainContext) GetHeader(hash common.Hash, number uint64) *types.H
 }
 
 func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
+	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	if blockOverrides != nil {
+		blockOverrides.Apply(&blockCtx)
+	}
+	chainConfig, err := overrides.ApplyPrecompiles(b.ChainConfig(), state, &blockCtx)
+	if err != nil {
+		return nil, err
+	}
 	if err := overrides.Apply(state); err != nil {
 		return nil, err
 	}
@@ -1108,11 +1343,10 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 	if err != nil {
 		return nil, err
 	}
-	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
-	if blockOverrides != nil {
-		blockOverrides.Apply(&blockCtx)
-	}
 	evm, vmError := b.GetEVM(ctx, msg, state, header, &vm.Config{NoBaseFee: true}, &blockCtx)
+	if chainConfig != b.ChainConfig() {
+		evm.SetChainConfig(chainConfig)
+	}
 
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -1326,7 +1560,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	executable := func(gas uint64, state *state.StateDB, header *types.Header) (bool, *core.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		result, err := doCall(ctx, b, args, state, header, nil, nil, 0, gasCap)
+		result, err := doCall(ctx, b, args, state, header, overrides, nil, 0, gasCap)
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -1335,14 +1569,12 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 		}
 		return result.Failed(), result, nil
 	}
+	// The overrides are applied by each call, so that precompile config overrides
+	// are also taken into account.
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return 0, err
 	}
-	err = overrides.Apply(state)
-	if err != nil {
-		return 0, err
-	}
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
 		s := state.Copy()
@@ -1478,26 +1710,27 @@ func (s *BlockChainAPI) rpcMarshalBlock(ctx context.Context, b *types.Block, inc
 
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
-	BlockHash        *common.Hash      `json:"blockHash"`
-	BlockNumber      *hexutil.Big      `json:"blockNumber"`
-	From             common.Address    `json:"from"`
-	Gas              hexutil.Uint64    `json:"gas"`
-	GasPrice         *hexutil.Big      `json:"gasPrice"`
-	GasFeeCap        *hexutil.Big      `json:"maxFeePerGas,omitempty"`
-	GasTipCap        *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
-	Hash             common.Hash       `json:"hash"`
-	Input            hexutil.Bytes     `json:"input"`
-	Nonce            hexutil.Uint64    `json:"nonce"`
-	To               *common.Address   `json:"to"`
-	TransactionIndex *hexutil.Uint64   `json:"transactionIndex"`
-	Value            *hexutil.Big      `json:"value"`
-	Type             hexutil.Uint64    `json:"type"`
-	Accesses         *types.AccessList `json:"accessList,omitempty"`
-	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
-	V                *hexutil.Big      `json:"v"`
-	R                *hexutil.Big      `json:"r"`
-	S                *hexutil.Big      `json:"s"`
-	YParity          *hexutil.Uint64   `json:"yParity,omitempty"`
+	BlockHash         *common.Hash                 `json:"blockHash"`
+	BlockNumber       *hexutil.Big                 `json:"blockNumber"`
+	From              common.Address               `json:"from"`
+	Gas               hexutil.Uint64               `json:"gas"`
+	GasPrice          *hexutil.Big                 `json:"gasPrice"`
+	GasFeeCap         *hexutil.Big                 `json:"maxFeePerGas,omitempty"`
+	GasTipCap         *hexutil.Big                 `json:"maxPriorityFeePerGas,omitempty"`
+	Hash              common.Hash                  `json:"hash"`
+	Input             hexutil.Bytes                `json:"input"`
+	Nonce             hexutil.Uint64               `json:"nonce"`
+	To                *common.Address              `json:"to"`
+	TransactionIndex  *hexutil.Uint64              `json:"transactionIndex"`
+	Value             *hexutil.Big                 `json:"value"`
+	Type              hexutil.Uint64               `json:"type"`
+	Accesses          *types.AccessList            `json:"accessList,omitempty"`
+	AuthorizationList []types.SetCodeAuthorization `json:"authorizationList,omitempty"`
+	ChainID           *hexutil.Big                 `json:"chainId,omitempty"`
+	V                 *hexutil.Big                 `json:"v"`
+	R                 *hexutil.Big                 `json:"r"`
+	S                 *hexutil.Big                 `json:"s"`
+	YParity           *hexutil.Uint64              `json:"yParity,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1540,12 +1773,13 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
 		result.YParity = &yparity
 
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.SetCodeTxType:
 		al := tx.AccessList()
 		yparity := hexutil.Uint64(v.Sign())
 		result.Accesses = &al
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
 		result.YParity = &yparity
+		result.AuthorizationList = tx.SetCodeAuthorizations()
 		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
 		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
 		// if the transaction has been mined, compute the effective gas price
@@ -1647,7 +1881,11 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		to = crypto.CreateAddress(args.from(), uint64(*args.Nonce))
 	}
 	// Retrieve the precompiles since they don't need to be added to the access list
-	precompiles := vm.ActivePrecompiles(b.ChainConfig().Rules(header.Number, header.Time))
+	config, err := governance.ChainConfigAt(b.ChainConfig(), db, header.Time)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	precompiles := vm.ActivePrecompiles(config.Rules(header.Number, header.Time))
 
 	// Create an initial tracer
 	prevTracer := logger.NewAccessListTracer(nil, args.from(), to, precompiles)
@@ -1871,7 +2109,13 @@ func (s *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.
 
 	// Derive the sender.
 	signer := types.MakeSigner(s.b.ChainConfig(), header.Number, header.Time)
-	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
+	fields := marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index))
+	config, err := receiptPredicatesConfig(ctx, s.b, header)
+	if err != nil {
+		return nil, err
+	}
+	marshalReceiptPredicates(fields, config, header, tx)
+	return fields, nil
 }
 
 // marshalReceipt marshals a transaction receipt into a JSON object.
@@ -1926,6 +2170,16 @@ func (s *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*type
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, nil)
+}
+
+// SubmitBlobTransaction is a helper function that submits a blob tx along with
+// its sidecar to txPool and logs a message.
+func SubmitBlobTransaction(ctx context.Context, b Backend, tx *types.Transaction, sidecar *types.BlobTxSidecar) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, sidecar)
+}
+
+func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction, sidecar *types.BlobTxSidecar) (common.Hash, error) {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
@@ -1935,7 +2189,11 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
-	if err := b.SendTx(ctx, tx); err != nil {
+	if sidecar != nil {
+		if err := b.SendBlobTx(ctx, tx, sidecar); err != nil {
+			return common.Hash{}, err
+		}
+	} else if err := b.SendTx(ctx, tx); err != nil {
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
@@ -2007,10 +2265,70 @@ func (s *TransactionAPI) FillTransaction(ctx context.Context, args TransactionAr
 // SendRawTransaction will add the signed transaction to the transaction pool.
 // The sender is responsible for signing the transaction and using the correct nonce.
 func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	// Blob transactions are submitted in their network encoding, which carries
+	// the blobs along with the transaction.
+	if len(input) > 0 && input[0] == types.BlobTxType {
+		if tx, sidecar, err := types.DecodeBlobTxWithSidecar(input); err == nil {
+			return SubmitBlobTransaction(ctx, s.b, tx, sidecar)
+		}
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	return SubmitTransaction(ctx, s.b, tx)
+}
+
+// maxConditionalCost is the maximum number of storage lookups required to check
+// the known accounts of a conditional transaction.
+const maxConditionalCost = 1000
+
+// conditionalError is an API error returned when the conditions of a transaction
+// submitted with eth_sendRawTransactionConditional are rejected.
+type conditionalError struct {
+	error
+	code int
+}
+
+// ErrorCode returns the JSON error code for a rejected conditional transaction.
+func (e *conditionalError) ErrorCode() int {
+	return e.code
+}
+
+// Unwrap returns the reason the conditional transaction was rejected.
+func (e *conditionalError) Unwrap() error {
+	return e.error
+}
+
+// SendRawTransactionConditional will add the signed transaction to the transaction
+// pool, to be included only in a block meeting the given conditions: the storage of
+// the known accounts when the transaction is executed, and the range of block numbers
+// and timestamps. The conditions are checked against the latest block on submission,
+// and again when building blocks. Conditional transactions are not gossiped, so they
+// are only included in blocks built by this node.
+func (s *TransactionAPI) SendRawTransactionConditional(ctx context.Context, input hexutil.Bytes, options types.TransactionConditional) (common.Hash, error) {
 	tx := new(types.Transaction)
 	if err := tx.UnmarshalBinary(input); err != nil {
 		return common.Hash{}, err
 	}
+	if err := options.Validate(); err != nil {
+		return common.Hash{}, &conditionalError{err, -32602}
+	}
+	if cost := options.Cost(); cost > maxConditionalCost {
+		return common.Hash{}, &conditionalError{fmt.Errorf("conditional cost %d exceeds the maximum of %d", cost, maxConditionalCost), -32005}
+	}
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if state == nil || err != nil {
+		return common.Hash{}, err
+	}
+	// Conditions which may still be met by a later block are accepted.
+	if err := options.CheckBlock(header.Number, header.Time); err != nil && !errors.Is(err, types.ErrConditionalNotReached) {
+		return common.Hash{}, &conditionalError{err, -32003}
+	}
+	if err := state.CheckKnownAccounts(options.KnownAccounts); err != nil {
+		return common.Hash{}, &conditionalError{err, -32003}
+	}
+	tx.SetConditional(&options)
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
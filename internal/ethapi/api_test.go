@@ -47,8 +47,11 @@ import (
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/eth/gasprice"
 	"github.com/ava-labs/subnet-evm/internal/blocktest"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -386,6 +389,9 @@ func newTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i i
 func (b testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(0), nil
 }
+func (b testBackend) EstimatePriorityFee(ctx context.Context, gas uint64) (*gasprice.PriorityFeeEstimate, error) {
+	panic("implement me")
+}
 func (b testBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
 	return nil, nil, nil, nil, nil
 }
@@ -495,6 +501,9 @@ func (b testBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig,
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
+func (b testBackend) SendBlobTx(ctx context.Context, signedTx *types.Transaction, sidecar *types.BlobTxSidecar) error {
+	panic("implement me")
+}
 func (b testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.db, txHash)
 	return tx, blockHash, blockNumber, index, nil
@@ -511,6 +520,7 @@ func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, m
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	panic("implement me")
 }
+func (b testBackend) CancelPoolTransaction(txHash common.Hash) error { panic("implement me") }
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
@@ -787,6 +797,48 @@ func TestCall(t *testing.T) {
 			blockOverrides: BlockOverrides{Number: (*hexutil.Big)(big.NewInt(11))},
 			want:           "0x000000000000000000000000000000000000000000000000000000000000000b",
 		},
+		// Precompile configs can be overridden, e.g. to enable the tx allow list
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From: &accounts[0].addr,
+				To:   &txallowlist.ContractAddress,
+				Data: readAllowListInput(t, accounts[1].addr),
+			},
+			overrides: StateOverride{
+				txallowlist.ContractAddress: OverrideAccount{PrecompileConfig: newRPCRawMessage(`{"enabledAddresses": ["` + accounts[1].addr.Hex() + `"]}`)},
+			},
+			want: "0x0000000000000000000000000000000000000000000000000000000000000001",
+		},
+		// State overrides are applied on top of the overridden precompile config
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From: &accounts[0].addr,
+				To:   &txallowlist.ContractAddress,
+				Data: readAllowListInput(t, accounts[1].addr),
+			},
+			overrides: StateOverride{
+				txallowlist.ContractAddress: OverrideAccount{
+					PrecompileConfig: newRPCRawMessage(`{"enabledAddresses": ["` + accounts[1].addr.Hex() + `"]}`),
+					StateDiff:        &map[common.Hash]common.Hash{accounts[1].addr.Hash(): common.BigToHash(big.NewInt(2))},
+				},
+			},
+			want: "0x0000000000000000000000000000000000000000000000000000000000000002",
+		},
+		// Precompile configs can only be overridden for precompile addresses
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From:  &accounts[0].addr,
+				To:    &accounts[1].addr,
+				Value: (*hexutil.Big)(big.NewInt(1000)),
+			},
+			overrides: StateOverride{
+				accounts[2].addr: OverrideAccount{PrecompileConfig: newRPCRawMessage(`{}`)},
+			},
+			expectErr: fmt.Errorf("account %s is not a stateful precompile", accounts[2].addr.Hex()),
+		},
 	}
 	for i, tc := range testSuite {
 		result, err := api.Call(context.Background(), tc.call, rpc.BlockNumberOrHash{BlockNumber: &tc.blockNumber}, &tc.overrides, &tc.blockOverrides)
@@ -813,6 +865,72 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestSimulateV1(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		randomAccounts = newAccounts(2)
+		logger         = common.HexToAddress("0x1000")
+		reverter       = common.HexToAddress("0x2000")
+		loggerCode     = hexutil.Bytes(common.FromHex("0x60006000a000")) // LOG0 with empty data
+		reverterCode   = hexutil.Bytes(common.FromHex("0x60006000fd"))   // REVERT with empty data
+		latest         = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {}))
+
+	results, err := api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: []simBlock{
+			{
+				StateOverrides: &StateOverride{
+					logger:   OverrideAccount{Code: &loggerCode},
+					reverter: OverrideAccount{Code: &reverterCode},
+				},
+				Calls: []TransactionArgs{
+					{From: &accounts[0].addr, To: &randomAccounts[0].addr, Value: (*hexutil.Big)(big.NewInt(1000))},
+					{From: &accounts[0].addr, To: &logger},
+				},
+			},
+			{
+				// The state changes of the previous block are visible.
+				Calls: []TransactionArgs{
+					{From: &randomAccounts[0].addr, To: &randomAccounts[1].addr, Value: (*hexutil.Big)(big.NewInt(1000))},
+					{From: &accounts[0].addr, To: &reverter},
+				},
+			},
+		},
+	}, &latest)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, uint64(2), results[0].Number.ToInt().Uint64())
+	require.Equal(t, uint64(3), results[1].Number.ToInt().Uint64())
+	require.Len(t, results[0].Calls, 2)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), results[0].Calls[0].Status)
+	require.Equal(t, hexutil.Uint64(params.TxGas), results[0].Calls[0].GasUsed)
+	require.Len(t, results[0].Calls[1].Logs, 1)
+	require.Equal(t, logger, results[0].Calls[1].Logs[0].Address)
+	require.Equal(t, results[0].Calls[0].GasUsed+results[0].Calls[1].GasUsed, results[0].GasUsed)
+
+	require.Len(t, results[1].Calls, 2)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), results[1].Calls[0].Status)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusFailed), results[1].Calls[1].Status)
+	require.NotNil(t, results[1].Calls[1].Error)
+	require.Equal(t, (&revertError{}).ErrorCode(), results[1].Calls[1].Error.Code)
+
+	// Simulated blocks must follow each other.
+	number := hexutil.Big(*big.NewInt(1))
+	_, err = api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: []simBlock{{BlockOverrides: &BlockOverrides{Number: &number}}},
+	}, &latest)
+	require.ErrorIs(t, err, errSimulateBlockNumber)
+}
+
 type Account struct {
 	key  *ecdsa.PrivateKey
 	addr common.Address
@@ -833,6 +951,17 @@ func newRPCBalance(balance *big.Int) **hexutil.Big {
 	return &rpcBalance
 }
 
+func readAllowListInput(t *testing.T, address common.Address) *hexutil.Bytes {
+	input, err := allowlist.PackReadAllowList(address)
+	require.NoError(t, err)
+	return (*hexutil.Bytes)(&input)
+}
+
+func newRPCRawMessage(str string) *json.RawMessage {
+	msg := json.RawMessage(str)
+	return &msg
+}
+
 func hex2Bytes(str string) *hexutil.Bytes {
 	rpcBytes := hexutil.Bytes(common.Hex2Bytes(str))
 	return &rpcBytes
@@ -2083,3 +2212,62 @@ func TestRPCGetBlockReceipts(t *testing.T) {
 		require.JSONEqf(t, want, have, "test %d: json not match, want: %s, have: %s", i, want, have)
 	}
 }
+
+func TestRPCGetBlockWithReceiptFields(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genBlocks  = 5
+		backend, _ = setupReceiptBackend(t, genBlocks)
+		api        = NewBlockChainAPI(backend)
+		ctx        = context.Background()
+	)
+
+	// Unknown fields are rejected.
+	_, err := api.GetBlockWithReceiptFields(ctx, rpc.BlockNumberOrHashWithNumber(1), []string{"logs"})
+	require.ErrorContains(t, err, `unsupported receipt field "logs"`)
+
+	// Missing blocks return null.
+	result, err := api.GetBlockWithReceiptFields(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(genBlocks+1)), nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	for i := 0; i <= genBlocks; i++ {
+		number := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(i))
+		block, err := api.GetBlockByNumber(ctx, rpc.BlockNumber(i), false)
+		require.NoError(t, err)
+		receipts, err := api.GetBlockReceipts(ctx, number)
+		require.NoError(t, err)
+
+		// Without fields, only the transaction hashes are returned.
+		result, err := api.GetBlockWithReceiptFields(ctx, number, nil)
+		require.NoError(t, err)
+		require.Equal(t, block["hash"], result["hash"])
+		txs := result["transactions"].([]map[string]interface{})
+		require.Len(t, txs, len(receipts))
+		for j, tx := range txs {
+			require.Equal(t, map[string]interface{}{"hash": receipts[j]["transactionHash"]}, tx)
+		}
+
+		// Requested fields match the full receipts.
+		fields := []string{"status", "gasUsed", "cumulativeGasUsed", "effectiveGasPrice", "contractAddress"}
+		result, err = api.GetBlockWithReceiptFields(ctx, number, fields)
+		require.NoError(t, err)
+		txs = result["transactions"].([]map[string]interface{})
+		require.Len(t, txs, len(receipts))
+		for j, tx := range txs {
+			want, err := json.Marshal(receipts[j])
+			require.NoError(t, err)
+			have, err := json.Marshal(tx)
+			require.NoError(t, err)
+			var wantFields, haveFields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(want, &wantFields))
+			require.NoError(t, json.Unmarshal(have, &haveFields))
+			require.Len(t, haveFields, len(fields)+1)
+			require.Equal(t, wantFields["transactionHash"], haveFields["hash"])
+			for _, field := range fields {
+				require.Equal(t, wantFields[field], haveFields[field], "block %d tx %d field %s", i, j, field)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// marshalReceiptPredicates adds to the receipt [fields] of [tx], accepted in the block
+// of [header], the predicate results of the transaction and the IDs of the warp
+// messages it delivered, if the chain config enables them. [config] is the chain config
+// returned by receiptPredicatesConfig for [header].
+func marshalReceiptPredicates(fields map[string]interface{}, config *params.ChainConfig, header *types.Header, tx *types.Transaction) {
+	if !config.ReceiptPredicateResults {
+		return
+	}
+	var results *predicate.Results
+	if resultBytes, ok := predicate.GetPredicateResultBytes(header.Extra); ok {
+		var err error
+		results, err = predicate.ParseResults(resultBytes)
+		if err != nil {
+			log.Debug("failed to parse predicate results", "block", header.Number, "err", err)
+		}
+	}
+	predicateResults, warpMessageIDs := receiptPredicates(config.Rules(header.Number, header.Time), results, tx)
+	fields["predicateResults"] = predicateResults
+	fields["warpMessageIDs"] = warpMessageIDs
+}
+
+// receiptPredicatesConfig returns the chain config to pass to marshalReceiptPredicates for the
+// receipts of the block of [header], which only reads the precompile upgrades scheduled by the
+// governance precompile if the chain config adds the predicate results to the receipts.
+func receiptPredicatesConfig(ctx context.Context, b Backend, header *types.Header) (*params.ChainConfig, error) {
+	if !b.ChainConfig().ReceiptPredicateResults {
+		return b.ChainConfig(), nil
+	}
+	return chainConfigAt(ctx, b, header)
+}
+
+// receiptPredicates returns the predicate results bitset of [tx] in [results] for
+// every predicater address of its access list under [rules], and the IDs of the
+// warp messages of its predicates that passed verification, in access list order.
+// A nil [results] is treated as the results of a block without predicates.
+func receiptPredicates(rules params.Rules, results *predicate.Results, tx *types.Transaction) (map[common.Address]hexutil.Bytes, []ids.ID) {
+	if results == nil {
+		results = predicate.NewResults()
+	}
+	var warpAddress common.Address
+	if module, ok := modules.GetPrecompileModule(warp.ConfigKey); ok {
+		warpAddress = module.Address
+	}
+
+	var (
+		predicateResults = make(map[common.Address]hexutil.Bytes)
+		warpMessageIDs   = []ids.ID{}
+		indices          = make(map[common.Address]int)
+		failed           = make(map[common.Address]set.Bits)
+	)
+	for _, accessTuple := range tx.AccessList() {
+		address := accessTuple.Address
+		if !rules.PredicaterExists(address) {
+			continue
+		}
+		if _, ok := predicateResults[address]; !ok {
+			bitset := results.GetResults(tx.Hash(), address)
+			predicateResults[address] = bitset
+			failed[address] = set.BitsFromBytes(bitset)
+		}
+		// Failed predicates are marked by setting their bit in the results bitset.
+		index := indices[address]
+		indices[address]++
+		if address != warpAddress || failed[address].Contains(index) {
+			continue
+		}
+		predicateBytes, err := predicate.UnpackPredicate(utils.HashSliceToBytes(accessTuple.StorageKeys))
+		if err != nil {
+			continue
+		}
+		message, err := avalancheWarp.ParseMessage(predicateBytes)
+		if err != nil {
+			continue
+		}
+		warpMessageIDs = append(warpMessageIDs, message.ID())
+	}
+	return predicateResults, warpMessageIDs
+}
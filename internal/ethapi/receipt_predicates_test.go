@@ -0,0 +1,64 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptPredicates(t *testing.T) {
+	require := require.New(t)
+
+	messages := make([]*avalancheWarp.Message, 3)
+	builder := predicate.NewTxBuilder(big.NewInt(1)).
+		AccessList(types.AccessList{{Address: common.Address{1}, StorageKeys: []common.Hash{{1}}}})
+	for i := range messages {
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte{byte(i)})
+		require.NoError(err)
+		messages[i], err = avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
+		require.NoError(err)
+		builder.AddPredicate(warp.ContractAddress, messages[i].Bytes())
+	}
+	tx, err := builder.Build()
+	require.NoError(err)
+
+	rules := params.Rules{
+		Predicaters: map[common.Address]precompileconfig.Predicater{
+			warp.ContractAddress: warp.NewDefaultConfig(utils.NewUint64(0)),
+		},
+	}
+
+	// Without results, all the predicates are considered verified
+	predicateResults, warpMessageIDs := receiptPredicates(rules, nil, tx)
+	require.Equal(map[common.Address]hexutil.Bytes{warp.ContractAddress: nil}, predicateResults)
+	require.Equal([]ids.ID{messages[0].ID(), messages[1].ID(), messages[2].ID()}, warpMessageIDs)
+
+	// The second message failed verification
+	failed := set.NewBits(1).Bytes()
+	results := predicate.NewResultsFromMap(map[common.Hash]predicate.TxResults{
+		tx.Hash(): {warp.ContractAddress: failed},
+	})
+	predicateResults, warpMessageIDs = receiptPredicates(rules, results, tx)
+	require.Equal(map[common.Address]hexutil.Bytes{warp.ContractAddress: failed}, predicateResults)
+	require.Equal([]ids.ID{messages[0].ID(), messages[2].ID()}, warpMessageIDs)
+
+	// Transactions without predicates have empty results
+	predicateResults, warpMessageIDs = receiptPredicates(params.Rules{}, results, tx)
+	require.Empty(predicateResults)
+	require.Empty(warpMessageIDs)
+}
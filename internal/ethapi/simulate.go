@@ -0,0 +1,252 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks that can be simulated
+	// in a single eth_simulateV1 request.
+	maxSimulateBlocks = 256
+
+	// errCodeVMError is the error code of a simulated call that failed with an
+	// EVM error other than a revert.
+	errCodeVMError = -32015
+)
+
+var (
+	errSimulateNoBlocks       = errors.New("no blocks to simulate")
+	errSimulateTooManyBlocks  = fmt.Errorf("too many blocks to simulate, maximum is %d", maxSimulateBlocks)
+	errSimulateBlockNumber    = errors.New("simulated block numbers must be increasing")
+	errSimulateBlockTimestamp = errors.New("simulated block timestamps must not decrease")
+)
+
+// simOpts are the inputs of eth_simulateV1.
+type simOpts struct {
+	BlockStateCalls []simBlock `json:"blockStateCalls"`
+}
+
+// simBlock is a block to simulate. Its overrides are applied before its
+// calls are executed, and its state changes are visible to the following
+// blocks.
+type simBlock struct {
+	BlockOverrides *BlockOverrides   `json:"blockOverrides"`
+	StateOverrides *StateOverride    `json:"stateOverrides"`
+	Calls          []TransactionArgs `json:"calls"`
+}
+
+// simCallResult is the result of a simulated call.
+type simCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *simCallError  `json:"error,omitempty"`
+}
+
+// simCallError is the error of a simulated call that failed in the EVM.
+type simCallError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+	Data    string `json:"data,omitempty"`
+}
+
+// simBlockResult is the result of a simulated block.
+type simBlockResult struct {
+	Number        *hexutil.Big    `json:"number"`
+	Timestamp     hexutil.Uint64  `json:"timestamp"`
+	GasLimit      hexutil.Uint64  `json:"gasLimit"`
+	GasUsed       hexutil.Uint64  `json:"gasUsed"`
+	BaseFeePerGas *hexutil.Big    `json:"baseFeePerGas,omitempty"`
+	Calls         []simCallResult `json:"calls"`
+}
+
+// SimulateV1 executes the calls of a series of blocks on top of the state of
+// the given block and returns the logs, gas used, and return data of each call.
+// The blocks can override the state and the header fields of the block context.
+//
+// Note, predicates attached to the access list of a call (e.g. warp messages)
+// are not verified and are treated as valid, so that the calls consuming them
+// can be simulated before the messages are signed.
+func (s *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]*simBlockResult, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, errSimulateNoBlocks
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, errSimulateTooManyBlocks
+	}
+	if blockNrOrHash == nil {
+		n := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &n
+	}
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+
+	// Setup context so it may be cancelled when the simulation has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var cancel context.CancelFunc
+	if timeout := s.b.RPCEVMTimeout(); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	sim := &simulator{
+		b:      s.b,
+		state:  state,
+		gasCap: s.b.RPCGasCap(),
+	}
+	return sim.execute(ctx, header, opts.BlockStateCalls)
+}
+
+// simulator executes simulated blocks on top of a state.
+type simulator struct {
+	b      Backend
+	state  *state.StateDB
+	gasCap uint64
+}
+
+// execute simulates [blocks] on top of [parent].
+func (sim *simulator) execute(ctx context.Context, parent *types.Header, blocks []simBlock) ([]*simBlockResult, error) {
+	defer func(start time.Time) { log.Debug("Executing simulated blocks finished", "runtime", time.Since(start)) }(time.Now())
+
+	results := make([]*simBlockResult, len(blocks))
+	for i, block := range blocks {
+		header := types.CopyHeader(parent)
+		header.ParentHash = parent.Hash()
+		header.Number = new(big.Int).Add(parent.Number, common.Big1)
+		header.Time = parent.Time + 1
+		header.GasUsed = 0
+		// Drop the predicate results of the parent, so that the predicates of
+		// the simulated calls are treated as valid.
+		header.Extra = nil
+
+		blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, sim.b), nil)
+		block.BlockOverrides.Apply(&blockCtx)
+		if blockCtx.BlockNumber.Cmp(parent.Number) <= 0 {
+			return nil, fmt.Errorf("%w: block %d follows block %d", errSimulateBlockNumber, blockCtx.BlockNumber, parent.Number)
+		}
+		if blockCtx.Time < parent.Time {
+			return nil, fmt.Errorf("%w: block %d has timestamp %d before %d", errSimulateBlockTimestamp, blockCtx.BlockNumber, blockCtx.Time, parent.Time)
+		}
+		header.Number = new(big.Int).Set(blockCtx.BlockNumber)
+		header.Time = blockCtx.Time
+		header.GasLimit = blockCtx.GasLimit
+		header.Coinbase = blockCtx.Coinbase
+		header.BaseFee = blockCtx.BaseFee
+
+		if err := block.StateOverrides.Apply(sim.state); err != nil {
+			return nil, err
+		}
+		result, err := sim.executeBlock(ctx, header, &blockCtx, block.Calls)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", header.Number, err)
+		}
+		results[i] = result
+		parent = header
+	}
+	return results, nil
+}
+
+// executeBlock executes [calls] in the block with [header] and [blockCtx].
+func (sim *simulator) executeBlock(ctx context.Context, header *types.Header, blockCtx *vm.BlockContext, calls []TransactionArgs) (*simBlockResult, error) {
+	var (
+		gp     = new(core.GasPool).AddGas(blockCtx.GasLimit)
+		result = &simBlockResult{
+			Number:        (*hexutil.Big)(header.Number),
+			Timestamp:     hexutil.Uint64(header.Time),
+			GasLimit:      hexutil.Uint64(header.GasLimit),
+			BaseFeePerGas: (*hexutil.Big)(header.BaseFee),
+			Calls:         make([]simCallResult, len(calls)),
+		}
+	)
+	for i, args := range calls {
+		if args.Gas == nil {
+			remaining := hexutil.Uint64(gp.Gas())
+			args.Gas = &remaining
+		}
+		msg, err := args.ToMessage(sim.gasCap, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		// Simulated calls are not signed transactions, so their logs are
+		// attributed to a hash derived from their position.
+		txHash := simulatedTxHash(header.Number.Uint64(), i)
+		sim.state.SetTxContext(txHash, i)
+
+		evm, vmError := sim.b.GetEVM(ctx, msg, sim.state, header, &vm.Config{NoBaseFee: true}, blockCtx)
+
+		// Wait for the context to be done and cancel the evm. Even if the
+		// EVM has finished, cancelling may be done (repeatedly)
+		go func() {
+			<-ctx.Done()
+			evm.Cancel()
+		}()
+
+		res, err := core.ApplyMessage(evm, msg, gp)
+		if err := vmError(); err != nil {
+			return nil, err
+		}
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", sim.b.RPCEVMTimeout())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w (supplied gas %d)", i, err, msg.GasLimit)
+		}
+		sim.state.Finalise(true)
+
+		callResult := simCallResult{
+			ReturnValue: res.Return(),
+			Logs:        sim.state.GetLogs(txHash, header.Number.Uint64(), common.Hash{}),
+			GasUsed:     hexutil.Uint64(res.UsedGas),
+			Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+		if callResult.Logs == nil {
+			callResult.Logs = []*types.Log{}
+		}
+		if res.Failed() {
+			callResult.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			if errors.Is(res.Err, vmerrs.ErrExecutionReverted) {
+				revertErr := newRevertError(res)
+				callResult.ReturnValue = res.Revert()
+				callResult.Error = &simCallError{Message: revertErr.Error(), Code: revertErr.ErrorCode(), Data: revertErr.reason}
+			} else {
+				callResult.Error = &simCallError{Message: res.Err.Error(), Code: errCodeVMError}
+			}
+		}
+		result.Calls[i] = callResult
+		result.GasUsed += callResult.GasUsed
+	}
+	return result, nil
+}
+
+// simulatedTxHash returns the hash the logs of the [index]th call of the
+// simulated block [number] are attributed to.
+func simulatedTxHash(number uint64, index int) common.Hash {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], number)
+	binary.BigEndian.PutUint64(b[8:], uint64(index))
+	return crypto.Keccak256Hash(b[:])
+}
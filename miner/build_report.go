@@ -0,0 +1,136 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reasons pending transactions were left out of a built block.
+const (
+	ExclusionGasLimit        = "block gas limit reached"
+	ExclusionTimeBudget      = "build time budget exceeded"
+	ExclusionBlobGas         = "blob gas limit reached"
+	ExclusionConditional     = "conditions not met"
+	ExclusionSize            = "block size limit reached"
+	ExclusionReplayProtected = "replay protected before EIP-155"
+	ExclusionNonceTooLow     = "nonce too low"
+	ExclusionFailed          = "execution failed"
+	ExclusionSenderSkipped   = "earlier transaction of the sender excluded"
+	ExclusionBuildFailed     = "block building failed"
+)
+
+// TxExclusion explains why a pending transaction was left out of a built block.
+type TxExclusion struct {
+	Reason string
+	// Err is the error excluding the transaction, if any.
+	Err error
+}
+
+// BuildReport summarizes a block building attempt of the miner, such that the
+// transactions it left out can be diagnosed.
+type BuildReport struct {
+	ParentHash   common.Hash
+	Number       uint64
+	Time         uint64
+	BaseFee      *big.Int
+	BlockGasCost *big.Int // nil until the block is assembled
+	GasLimit     uint64
+	GasUsed      uint64
+	// Pending is the number of pending transactions paying the base fee when
+	// the attempt started.
+	Pending    int
+	Included   []common.Hash
+	Excluded   map[common.Hash]TxExclusion
+	OverBudget bool
+	// Err is the error failing the attempt, if any.
+	Err error
+}
+
+// exclude records that the transaction [hash] was left out of the block for
+// [reason].
+func (env *environment) exclude(hash common.Hash, reason string, err error) {
+	env.excluded[hash] = TxExclusion{Reason: reason, Err: err}
+}
+
+// skipSender records that the transaction [hash] of [sender] was left out of
+// the block for [reason], along with the later transactions of [sender].
+func (env *environment) skipSender(sender common.Address, hash common.Hash, reason string, err error) {
+	env.exclude(hash, reason, err)
+	if _, ok := env.skippedSenders[sender]; !ok {
+		env.skippedSenders[sender] = hash
+	}
+}
+
+// newBuildReport returns the report of the attempt that built [env] from the
+// [pending] transactions, failed with [err] if not nil.
+func newBuildReport(env *environment, pending map[common.Address][]*txpool.LazyTransaction, err error) *BuildReport {
+	report := &BuildReport{
+		ParentHash: env.parent.Hash(),
+		Number:     env.header.Number.Uint64(),
+		Time:       env.header.Time,
+		BaseFee:    env.header.BaseFee,
+		GasLimit:   env.header.GasLimit,
+		GasUsed:    env.header.GasUsed,
+		Excluded:   env.excluded,
+		OverBudget: env.overBudget,
+		Err:        err,
+	}
+	if env.header.BlockGasCost != nil {
+		report.BlockGasCost = new(big.Int).Set(env.header.BlockGasCost)
+	}
+	included := make(map[common.Hash]struct{}, len(env.txs))
+	for _, tx := range env.txs {
+		if err != nil {
+			report.Excluded[tx.Hash()] = TxExclusion{Reason: ExclusionBuildFailed, Err: err}
+			continue
+		}
+		report.Included = append(report.Included, tx.Hash())
+		included[tx.Hash()] = struct{}{}
+	}
+	for sender, txs := range pending {
+		report.Pending += len(txs)
+		for _, tx := range txs {
+			if _, ok := included[tx.Hash]; ok {
+				continue
+			}
+			if _, ok := report.Excluded[tx.Hash]; ok {
+				continue
+			}
+			// The transactions the miner did not reach either follow an
+			// excluded transaction of their sender, or did not fit in the block.
+			switch skipped, ok := env.skippedSenders[sender]; {
+			case ok:
+				report.Excluded[tx.Hash] = TxExclusion{Reason: ExclusionSenderSkipped, Err: fmt.Errorf("follows excluded transaction %s", skipped)}
+			case env.overBudget:
+				report.Excluded[tx.Hash] = TxExclusion{Reason: ExclusionTimeBudget}
+			default:
+				report.Excluded[tx.Hash] = TxExclusion{Reason: ExclusionGasLimit}
+			}
+		}
+	}
+	return report
+}
+
+// LastBuildReport returns the report of the last block building attempt of the
+// miner, or nil if none reached the packing of transactions.
+func (miner *Miner) LastBuildReport() *BuildReport {
+	return miner.worker.lastBuildReport()
+}
+
+func (w *worker) lastBuildReport() *BuildReport {
+	w.reportLock.Lock()
+	defer w.reportLock.Unlock()
+	return w.report
+}
+
+func (w *worker) setBuildReport(report *BuildReport) {
+	w.reportLock.Lock()
+	defer w.reportLock.Unlock()
+	w.report = report
+}
@@ -0,0 +1,60 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReport(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		included   = types.NewTransaction(0, common.Address{}, nil, 21000, big.NewInt(1), nil)
+		failed     = types.NewTransaction(1, common.Address{}, nil, 21000, big.NewInt(1), nil)
+		skipped    = types.NewTransaction(2, common.Address{}, nil, 21000, big.NewInt(1), nil)
+		unreached  = types.NewTransaction(3, common.Address{}, nil, 21000, big.NewInt(1), nil)
+		alice, bob = common.Address{1}, common.Address{2}
+	)
+	env := &environment{
+		parent:         &types.Header{Number: big.NewInt(1)},
+		header:         &types.Header{Number: big.NewInt(2), GasLimit: 21000, GasUsed: 21000, BaseFee: big.NewInt(1)},
+		txs:            []*types.Transaction{included},
+		excluded:       make(map[common.Hash]TxExclusion),
+		skippedSenders: make(map[common.Address]common.Hash),
+	}
+	env.skipSender(alice, failed.Hash(), ExclusionFailed, core.ErrInsufficientFunds)
+	pending := map[common.Address][]*txpool.LazyTransaction{
+		alice: {{Hash: included.Hash()}, {Hash: failed.Hash()}, {Hash: skipped.Hash()}},
+		bob:   {{Hash: unreached.Hash()}},
+	}
+
+	report := newBuildReport(env, pending, nil)
+	require.Equal(env.parent.Hash(), report.ParentHash)
+	require.EqualValues(2, report.Number)
+	require.Equal(4, report.Pending)
+	require.Equal([]common.Hash{included.Hash()}, report.Included)
+	require.Len(report.Excluded, 3)
+	require.Equal(TxExclusion{Reason: ExclusionFailed, Err: core.ErrInsufficientFunds}, report.Excluded[failed.Hash()])
+	require.Equal(ExclusionSenderSkipped, report.Excluded[skipped.Hash()].Reason)
+	require.ErrorContains(report.Excluded[skipped.Hash()].Err, failed.Hash().Hex())
+	require.Equal(TxExclusion{Reason: ExclusionGasLimit}, report.Excluded[unreached.Hash()])
+
+	// The transactions of a failed attempt are all excluded
+	errBlockFee := errors.New("insufficient block fee")
+	env.excluded = make(map[common.Hash]TxExclusion)
+	env.overBudget = true
+	report = newBuildReport(env, pending, errBlockFee)
+	require.Empty(report.Included)
+	require.Equal(TxExclusion{Reason: ExclusionBuildFailed, Err: errBlockFee}, report.Excluded[included.Hash()])
+	require.Equal(TxExclusion{Reason: ExclusionTimeBudget}, report.Excluded[unreached.Hash()])
+}
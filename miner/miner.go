@@ -28,6 +28,9 @@
 package miner
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
@@ -36,6 +39,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/event"
 )
 
@@ -48,6 +52,12 @@ type Backend interface {
 // Config is the configuration parameters of mining.
 type Config struct {
 	Etherbase common.Address `toml:",omitempty"` // Public address for block mining rewards
+	ExtraData hexutil.Bytes  `toml:",omitempty"` // Operator data included in the block extra data, if allowed by the chain
+	Ordering  string         `toml:",omitempty"` // Name of the registered OrderingPolicy of the transactions of built blocks ("" = PriceOrderingName)
+
+	// BuildTimeBudget is the wall-clock time after which the block builder stops
+	// packing transactions and emits the partially built block (0 = unlimited).
+	BuildTimeBudget time.Duration `toml:",omitempty"`
 }
 
 type Miner struct {
@@ -64,6 +74,16 @@ func (miner *Miner) SetEtherbase(addr common.Address) {
 	miner.worker.setEtherbase(addr)
 }
 
+// SetExtra sets the operator data included in the extra data of the blocks built from
+// now on. It is omitted from blocks whose rules do not allow it.
+func (miner *Miner) SetExtra(extra []byte) error {
+	if len(extra) > params.MaxConfigurableOperatorDataSize {
+		return fmt.Errorf("extra exceeds max length. %d > %v", len(extra), params.MaxConfigurableOperatorDataSize)
+	}
+	miner.worker.setExtra(extra)
+	return nil
+}
+
 func (miner *Miner) GenerateBlock(predicateContext *precompileconfig.PredicateContext) (*types.Block, error) {
 	return miner.worker.commitNewWork(predicateContext)
 }
@@ -36,17 +36,20 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 )
 
-// txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
-type txWithMinerFee struct {
-	tx   *txpool.LazyTransaction
-	from common.Address
-	fees *big.Int
+// OrderedTx is the next transaction of an account, ordered against the next
+// transactions of the other accounts by an OrderingPolicy.
+type OrderedTx struct {
+	Tx   *txpool.LazyTransaction
+	From common.Address
+	Tip  *big.Int // Effective miner tip at the base fee of the block
+
+	score *big.Int // Score of the transaction, cached by the score ordering policies
 }
 
-// newTxWithMinerFee creates a wrapped transaction, calculating the effective
+// newOrderedTx creates a wrapped transaction, calculating the effective
 // miner gasTipCap if a base fee is provided.
 // Returns error in case of a negative effective miner gasTipCap.
-func newTxWithMinerFee(tx *txpool.LazyTransaction, from common.Address, baseFee *big.Int) (*txWithMinerFee, error) {
+func newOrderedTx(tx *txpool.LazyTransaction, from common.Address, baseFee *big.Int) (*OrderedTx, error) {
 	tip := new(big.Int).Set(tx.GasTipCap)
 	if baseFee != nil {
 		if tx.GasFeeCap.Cmp(baseFee) < 0 {
@@ -54,48 +57,44 @@ func newTxWithMinerFee(tx *txpool.LazyTransaction, from common.Address, baseFee
 		}
 		tip = math.BigMin(tx.GasTipCap, new(big.Int).Sub(tx.GasFeeCap, baseFee))
 	}
-	return &txWithMinerFee{
-		tx:   tx,
-		from: from,
-		fees: tip,
+	return &OrderedTx{
+		Tx:   tx,
+		From: from,
+		Tip:  tip,
 	}, nil
 }
 
-// txByPriceAndTime implements both the sort and the heap interface, making it useful
-// for all at once sorting as well as individually adding and removing elements.
-type txByPriceAndTime []*txWithMinerFee
-
-func (s txByPriceAndTime) Len() int { return len(s) }
-func (s txByPriceAndTime) Less(i, j int) bool {
-	// If the prices are equal, use the time the transaction was first seen for
-	// deterministic sorting
-	cmp := s[i].fees.Cmp(s[j].fees)
-	if cmp == 0 {
-		return s[i].tx.Time.Before(s[j].tx.Time)
-	}
-	return cmp > 0
+// txHeap implements both the sort and the heap interface over the next
+// transaction of each account, in the order of an OrderingPolicy.
+type txHeap struct {
+	txs    []*OrderedTx
+	policy OrderingPolicy
 }
-func (s txByPriceAndTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
-func (s *txByPriceAndTime) Push(x interface{}) {
-	*s = append(*s, x.(*txWithMinerFee))
+func (h *txHeap) Len() int           { return len(h.txs) }
+func (h *txHeap) Less(i, j int) bool { return h.policy.Less(h.txs[i], h.txs[j]) }
+func (h *txHeap) Swap(i, j int)      { h.txs[i], h.txs[j] = h.txs[j], h.txs[i] }
+
+func (h *txHeap) Push(x interface{}) {
+	h.txs = append(h.txs, x.(*OrderedTx))
 }
 
-func (s *txByPriceAndTime) Pop() interface{} {
-	old := *s
+func (h *txHeap) Pop() interface{} {
+	old := h.txs
 	n := len(old)
 	x := old[n-1]
 	old[n-1] = nil
-	*s = old[0 : n-1]
+	h.txs = old[0 : n-1]
 	return x
 }
 
 // transactionsByPriceAndNonce represents a set of transactions that can return
-// transactions in a profit-maximizing sorted order, while supporting removing
-// entire batches of transactions for non-executable accounts.
+// transactions in the order of an OrderingPolicy, profit-maximizing by default,
+// while supporting removing entire batches of transactions for non-executable
+// accounts.
 type transactionsByPriceAndNonce struct {
 	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
-	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
+	heads   *txHeap                                      // Next transaction for each unique account (policy heap)
 	signer  types.Signer                                 // Signer for the set of transactions
 	baseFee *big.Int                                     // Current base fee
 }
@@ -106,18 +105,27 @@ type transactionsByPriceAndNonce struct {
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
 func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
-	// Initialize a price and received time based heap with the head transactions
-	heads := make(txByPriceAndTime, 0, len(txs))
+	return newTransactionsByPolicyAndNonce(signer, txs, baseFee, PriceOrdering)
+}
+
+// newTransactionsByPolicyAndNonce creates a transaction set that can retrieve
+// transactions in the order of [policy] in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func newTransactionsByPolicyAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, policy OrderingPolicy) *transactionsByPriceAndNonce {
+	// Initialize a policy ordered heap with the head transactions
+	heads := &txHeap{txs: make([]*OrderedTx, 0, len(txs)), policy: policy}
 	for from, accTxs := range txs {
-		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFee)
+		wrapped, err := newOrderedTx(accTxs[0], from, baseFee)
 		if err != nil {
 			delete(txs, from)
 			continue
 		}
-		heads = append(heads, wrapped)
+		heads.txs = append(heads.txs, wrapped)
 		txs[from] = accTxs[1:]
 	}
-	heap.Init(&heads)
+	heap.Init(heads)
 
 	// Assemble and return the transaction set
 	return &transactionsByPriceAndNonce{
@@ -128,30 +136,30 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 	}
 }
 
-// Peek returns the next transaction by price.
+// Peek returns the next transaction in the order of the policy.
 func (t *transactionsByPriceAndNonce) Peek() *txpool.LazyTransaction {
-	if len(t.heads) == 0 {
+	if t.heads.Len() == 0 {
 		return nil
 	}
-	return t.heads[0].tx
+	return t.heads.txs[0].Tx
 }
 
 // Shift replaces the current best head with the next one from the same account.
 func (t *transactionsByPriceAndNonce) Shift() {
-	acc := t.heads[0].from
+	acc := t.heads.txs[0].From
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
-			t.heads[0], t.txs[acc] = wrapped, txs[1:]
-			heap.Fix(&t.heads, 0)
+		if wrapped, err := newOrderedTx(txs[0], acc, t.baseFee); err == nil {
+			t.heads.txs[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(t.heads, 0)
 			return
 		}
 	}
-	heap.Pop(&t.heads)
+	heap.Pop(t.heads)
 }
 
 // Pop removes the best transaction, *not* replacing it with the next one from
 // the same account. This should be used when a transaction cannot be executed
 // and hence all subsequent ones should be discarded from the same account.
 func (t *transactionsByPriceAndNonce) Pop() {
-	heap.Pop(&t.heads)
+	heap.Pop(t.heads)
 }
@@ -13,3 +13,7 @@ type TransactionsByPriceAndNonce = transactionsByPriceAndNonce
 func NewTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *TransactionsByPriceAndNonce {
 	return newTransactionsByPriceAndNonce(signer, txs, baseFee)
 }
+
+func NewTransactionsByPolicyAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, policy OrderingPolicy) *TransactionsByPriceAndNonce {
+	return newTransactionsByPolicyAndNonce(signer, txs, baseFee, policy)
+}
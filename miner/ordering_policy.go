@@ -0,0 +1,132 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+const (
+	// PriceOrderingName is the name of PriceOrdering, the default ordering policy.
+	PriceOrderingName = "price"
+	// FIFOOrderingName is the name of FIFOOrdering.
+	FIFOOrderingName = "fifo"
+)
+
+// OrderingPolicy decides the order in which the block builder includes the
+// pending transactions of different accounts. The transactions of an account
+// are always included in nonce order, so a policy only orders the next
+// transactions of the accounts against each other.
+type OrderingPolicy interface {
+	// Less reports whether [a] must be included before [b].
+	Less(a, b *OrderedTx) bool
+}
+
+// OrderingPolicyFunc adapts an ordinary function to an OrderingPolicy.
+type OrderingPolicyFunc func(a, b *OrderedTx) bool
+
+func (f OrderingPolicyFunc) Less(a, b *OrderedTx) bool { return f(a, b) }
+
+var (
+	// PriceOrdering includes the transactions paying the highest tip first, to
+	// maximize the fees of the block builder. Among transactions paying the same
+	// tip, the ones seen first are included first, to avoid network spam attacks
+	// aiming for a specific ordering.
+	PriceOrdering OrderingPolicy = OrderingPolicyFunc(func(a, b *OrderedTx) bool {
+		if cmp := a.Tip.Cmp(b.Tip); cmp != 0 {
+			return cmp > 0
+		}
+		return a.Tx.Time.Before(b.Tx.Time)
+	})
+
+	// FIFOOrdering includes the transactions seen first first, regardless of
+	// their tip, for subnets requiring fair ordering. Among transactions seen at
+	// the same time, the ones paying the highest tip are included first.
+	FIFOOrdering OrderingPolicy = OrderingPolicyFunc(func(a, b *OrderedTx) bool {
+		if !a.Tx.Time.Equal(b.Tx.Time) {
+			return a.Tx.Time.Before(b.Tx.Time)
+		}
+		return a.Tip.Cmp(b.Tip) > 0
+	})
+)
+
+// Scorer returns the score of a transaction for ScoreOrdering.
+type Scorer func(tx *OrderedTx) *big.Int
+
+type scoreOrdering struct {
+	scorer Scorer
+}
+
+// ScoreOrdering returns an ordering policy including the transactions with the
+// highest score given by [scorer] first. Among transactions with the same score,
+// the ones seen first are included first. The score of each transaction is only
+// computed once.
+func ScoreOrdering(scorer Scorer) OrderingPolicy {
+	return &scoreOrdering{scorer: scorer}
+}
+
+func (s *scoreOrdering) Less(a, b *OrderedTx) bool {
+	if cmp := s.score(a).Cmp(s.score(b)); cmp != 0 {
+		return cmp > 0
+	}
+	return a.Tx.Time.Before(b.Tx.Time)
+}
+
+func (s *scoreOrdering) score(tx *OrderedTx) *big.Int {
+	if tx.score == nil {
+		tx.score = s.scorer(tx)
+	}
+	return tx.score
+}
+
+var (
+	orderingPoliciesLock sync.RWMutex
+	orderingPolicies     = map[string]OrderingPolicy{
+		PriceOrderingName: PriceOrdering,
+		FIFOOrderingName:  FIFOOrdering,
+	}
+)
+
+// RegisterOrderingPolicy registers [policy] under [name], so it can be selected
+// by the Ordering of the miner config. Ordering policies compiled into a subnet
+// are registered in the init function of their package.
+func RegisterOrderingPolicy(name string, policy OrderingPolicy) error {
+	orderingPoliciesLock.Lock()
+	defer orderingPoliciesLock.Unlock()
+
+	if _, ok := orderingPolicies[name]; ok {
+		return fmt.Errorf("ordering policy %q already registered", name)
+	}
+	orderingPolicies[name] = policy
+	return nil
+}
+
+// GetOrderingPolicy returns the ordering policy registered under [name]. The
+// empty name refers to PriceOrdering.
+func GetOrderingPolicy(name string) (OrderingPolicy, bool) {
+	if name == "" {
+		return PriceOrdering, true
+	}
+	orderingPoliciesLock.RLock()
+	defer orderingPoliciesLock.RUnlock()
+
+	policy, ok := orderingPolicies[name]
+	return policy, ok
+}
+
+// OrderingPolicyNames returns the sorted names of the registered ordering policies.
+func OrderingPolicyNames() []string {
+	orderingPoliciesLock.RLock()
+	defer orderingPoliciesLock.RUnlock()
+
+	names := make([]string, 0, len(orderingPolicies))
+	for name := range orderingPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
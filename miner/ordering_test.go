@@ -30,6 +30,7 @@ import (
 	"crypto/ecdsa"
 	"math/big"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -196,3 +197,74 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the transactions of different accounts are retrieved in the order
+// of the ordering policy, and in nonce order for the same account.
+func TestTransactionPolicySort(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	// The later an account sent its transactions, the more they pay
+	newGroups := func() map[common.Address][]*txpool.LazyTransaction {
+		groups := map[common.Address][]*txpool.LazyTransaction{}
+		for i, key := range keys {
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			for nonce := uint64(0); nonce < 2; nonce++ {
+				tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(100), 100, big.NewInt(int64(i+1)), nil), signer, key)
+				tx.SetTime(time.Unix(int64(i), int64(nonce)))
+				groups[addr] = append(groups[addr], &txpool.LazyTransaction{
+					Hash:      tx.Hash(),
+					Tx:        &txpool.Transaction{Tx: tx},
+					Time:      tx.Time(),
+					GasFeeCap: tx.GasFeeCap(),
+					GasTipCap: tx.GasTipCap(),
+				})
+			}
+		}
+		return groups
+	}
+	sorted := func(policy OrderingPolicy) []int64 {
+		txset := newTransactionsByPolicyAndNonce(signer, newGroups(), nil, policy)
+		var prices []int64
+		for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
+			prices = append(prices, tx.GasFeeCap.Int64())
+			txset.Shift()
+		}
+		return prices
+	}
+
+	if have, want := sorted(PriceOrdering), []int64{3, 3, 2, 2, 1, 1}; !slices.Equal(have, want) {
+		t.Errorf("price ordering mismatch: have %v, want %v", have, want)
+	}
+	// The first transaction of the first account is seen before the second one
+	// of the others
+	if have, want := sorted(FIFOOrdering), []int64{1, 1, 2, 2, 3, 3}; !slices.Equal(have, want) {
+		t.Errorf("fifo ordering mismatch: have %v, want %v", have, want)
+	}
+	// Favor the second account, then fall back to the time first seen
+	favored := crypto.PubkeyToAddress(keys[1].PublicKey)
+	scorer := func(tx *OrderedTx) *big.Int {
+		if tx.From == favored {
+			return common.Big1
+		}
+		return common.Big0
+	}
+	if have, want := sorted(ScoreOrdering(scorer)), []int64{2, 2, 1, 1, 3, 3}; !slices.Equal(have, want) {
+		t.Errorf("score ordering mismatch: have %v, want %v", have, want)
+	}
+}
+
+func TestRegisterOrderingPolicy(t *testing.T) {
+	if policy, ok := GetOrderingPolicy(""); !ok || policy == nil {
+		t.Fatalf("default ordering policy not found")
+	}
+	if err := RegisterOrderingPolicy(FIFOOrderingName, PriceOrdering); err == nil {
+		t.Fatalf("registered ordering policy %q twice", FIFOOrderingName)
+	}
+	if _, ok := GetOrderingPolicy("unknown"); ok {
+		t.Fatalf("found unregistered ordering policy")
+	}
+}
@@ -32,6 +32,7 @@ package miner
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"sync"
 	"time"
@@ -40,12 +41,15 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/consensus/misc/eip4844"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ethereum/go-ethereum/common"
@@ -57,8 +61,14 @@ const (
 	targetTxsSize = 1800 * units.KiB
 )
 
+var (
+	buildBlockMeter      = metrics.NewRegisteredMeter("miner/build/blocks", nil)
+	buildOverBudgetMeter = metrics.NewRegisteredMeter("miner/build/overbudget", nil) // Blocks emitted partially built due to the time budget
+)
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
+	config  *params.ChainConfig // chain config including the precompile upgrades scheduled by the governance precompile
 	signer  types.Signer
 	state   *state.StateDB // apply state changes here
 	tcount  int            // tx count in cycle
@@ -78,7 +88,13 @@ type environment struct {
 	// way that the gas pool and state is reset.
 	predicateResults *predicate.Results
 
-	start time.Time // Time that block building began
+	start    time.Time // Time that block building began
+	deadline time.Time // Wall-clock time after which no more transactions are packed, zero if unlimited
+
+	overBudget bool // Whether packing stopped because the deadline passed
+
+	excluded       map[common.Hash]TxExclusion    // Pending transactions left out of the block
+	skippedSenders map[common.Address]common.Hash // Senders whose later transactions are left out, by first excluded transaction
 }
 
 // worker is the main object which takes care of submitting new work to consensus engine
@@ -98,7 +114,13 @@ type worker struct {
 	mux      *event.TypeMux // TODO replace
 	mu       sync.RWMutex   // The lock used to protect the coinbase and extra fields
 	coinbase common.Address
+	extra    []byte
 	clock    *mockable.Clock // Allows us mock the clock for testing
+
+	ordering OrderingPolicy // Order of the transactions of different accounts in built blocks
+
+	reportLock sync.Mutex
+	report     *BuildReport // Report of the last block building attempt
 }
 
 func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus.Engine, eth Backend, mux *event.TypeMux, clock *mockable.Clock) *worker {
@@ -110,8 +132,15 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		chain:       eth.BlockChain(),
 		mux:         mux,
 		coinbase:    config.Etherbase,
+		extra:       config.ExtraData,
 		clock:       clock,
 	}
+	ordering, ok := GetOrderingPolicy(config.Ordering)
+	if !ok {
+		log.Error("Unknown ordering policy, using the default", "ordering", config.Ordering, "default", PriceOrderingName)
+		ordering = PriceOrdering
+	}
+	worker.ordering = ordering
 
 	return worker
 }
@@ -123,12 +152,20 @@ func (w *worker) setEtherbase(addr common.Address) {
 	w.coinbase = addr
 }
 
+// setExtra sets the operator data included in the extra data of built blocks.
+func (w *worker) setExtra(extra []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.extra = extra
+}
+
 // commitNewWork generates several new sealing tasks based on the parent block.
 func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateContext) (*types.Block, error) {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 
 	tstart := w.clock.Time()
+	wallStart := time.Now()
 	timestamp := uint64(tstart.Unix())
 	parent := w.chain.CurrentBlock()
 	// Note: in order to support asynchronous block production, blocks are allowed to have
@@ -168,6 +205,19 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 			return nil, fmt.Errorf("failed to calculate new base fee: %w", err)
 		}
 	}
+	// Apply EIP-4844 blob gas accounting, the blob gas used is accumulated as
+	// blob transactions are added to the block.
+	if w.chainConfig.IsCancun(header.Number, header.Time) {
+		var excessBlobGas uint64
+		if w.chainConfig.IsCancun(parent.Number, parent.Time) {
+			excessBlobGas = eip4844.CalcExcessBlobGas(*parent.ExcessBlobGas, *parent.BlobGasUsed)
+		} else {
+			// For the first post-fork block, both parent.data_gas_used and parent.excess_data_gas are evaluated as 0
+			excessBlobGas = eip4844.CalcExcessBlobGas(0, 0)
+		}
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = &excessBlobGas
+	}
 
 	if w.coinbase == (common.Address{}) {
 		return nil, errors.New("cannot mine without etherbase")
@@ -195,6 +245,9 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new current environment: %w", err)
 	}
+	if w.config.BuildTimeBudget > 0 {
+		env.deadline = wallStart.Add(w.config.BuildTimeBudget)
+	}
 	// Ensure we always stop prefetcher after block building is complete.
 	defer func() {
 		if env.state == nil {
@@ -203,7 +256,7 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		env.state.StopPrefetcher()
 	}()
 	// Configure any upgrades that should go into effect during this block.
-	err = core.ApplyUpgrades(w.chainConfig, &parent.Time, types.NewBlockWithHeader(header), env.state)
+	err = core.ApplyUpgrades(env.config, &parent.Time, types.NewBlockWithHeader(header), env.state)
 	if err != nil {
 		log.Error("failed to configure precompiles mining new block", "parent", parent.Hash(), "number", header.Number, "timestamp", header.Time, "err", err)
 		return nil, err
@@ -211,10 +264,40 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 
 	// Fill the block with all available pending transactions.
 	pending := w.eth.TxPool().PendingWithBaseFee(true, header.BaseFee)
+	considered := maps.Clone(pending)
 
-	// Split the pending transactions into locals and remotes
+	// Split the pending transactions into system lane, priority senders, locals and remotes
+	systemTxs := make(map[common.Address][]*txpool.LazyTransaction)
+	priorityTxs := make(map[common.Address][]*txpool.LazyTransaction)
 	localTxs := make(map[common.Address][]*txpool.LazyTransaction)
 	remoteTxs := pending
+	if lane := w.chainConfig.SystemTxLane; lane != nil {
+		for _, account := range lane.Senders {
+			if txs := remoteTxs[account]; len(txs) > 0 {
+				delete(remoteTxs, account)
+				systemTxs[account] = txs
+			}
+		}
+	}
+	if len(systemTxs) > 0 {
+		txs := newTransactionsByPolicyAndNonce(env.signer, systemTxs, header.BaseFee, w.ordering)
+		w.commitSystemTransactions(env, txs, header.Coinbase)
+		// Offer the system transactions left out of the reserved gas along with the
+		// public lane, such that those needing more gas than reserved are still included.
+		for account, txs := range env.uncommitted(systemTxs) {
+			remoteTxs[account] = txs
+		}
+	}
+	for _, account := range w.eth.TxPool().PrioritySenders() {
+		if txs := remoteTxs[account]; len(txs) > 0 {
+			delete(remoteTxs, account)
+			priorityTxs[account] = txs
+		}
+	}
+	if len(priorityTxs) > 0 {
+		txs := newTransactionsByPolicyAndNonce(env.signer, priorityTxs, header.BaseFee, w.ordering)
+		w.commitTransactions(env, txs, header.Coinbase)
+	}
 	for _, account := range w.eth.TxPool().Locals() {
 		if txs := remoteTxs[account]; len(txs) > 0 {
 			delete(remoteTxs, account)
@@ -222,15 +305,32 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		}
 	}
 	if len(localTxs) > 0 {
-		txs := newTransactionsByPriceAndNonce(env.signer, localTxs, header.BaseFee)
+		txs := newTransactionsByPolicyAndNonce(env.signer, localTxs, header.BaseFee, w.ordering)
 		w.commitTransactions(env, txs, header.Coinbase)
 	}
 	if len(remoteTxs) > 0 {
-		txs := newTransactionsByPriceAndNonce(env.signer, remoteTxs, header.BaseFee)
+		txs := newTransactionsByPolicyAndNonce(env.signer, remoteTxs, header.BaseFee, w.ordering)
 		w.commitTransactions(env, txs, header.Coinbase)
 	}
 
-	return w.commit(env)
+	buildBlockMeter.Mark(1)
+	if env.overBudget {
+		buildOverBudgetMeter.Mark(1)
+		log.Debug("Block building exceeded the time budget, emitting partial block", "number", header.Number, "txs", env.tcount, "elapsed", time.Since(wallStart), "budget", w.config.BuildTimeBudget)
+	}
+	block, err := w.commit(env)
+	w.setBuildReport(newBuildReport(env, considered, err))
+	return block, err
+}
+
+// operatorData returns the encoded operator data to include in the extra data of a block
+// built under [rules], or nil if the configured data is not allowed by the chain.
+func (w *worker) operatorData(rules params.Rules) []byte {
+	if err := rules.VerifyOperatorData(w.extra); err != nil {
+		log.Warn("Omitting operator data from block extra data", "err", err)
+		return nil
+	}
+	return params.PackOperatorData(w.extra)
 }
 
 func (w *worker) createCurrentEnvironment(predicateContext *precompileconfig.PredicateContext, parent *types.Header, header *types.Header, tstart time.Time) (*environment, error) {
@@ -238,18 +338,25 @@ func (w *worker) createCurrentEnvironment(predicateContext *precompileconfig.Pre
 	if err != nil {
 		return nil, err
 	}
+	config, err := governance.ChainConfigAt(w.chainConfig, state, header.Time)
+	if err != nil {
+		return nil, err
+	}
 	state.StartPrefetcher("miner", w.eth.BlockChain().CacheConfig().TriePrefetcherParallelism)
 	return &environment{
-		signer:           types.MakeSigner(w.chainConfig, header.Number, header.Time),
+		config:           config,
+		signer:           types.MakeSigner(config, header.Number, header.Time),
 		state:            state,
 		parent:           parent,
 		header:           header,
 		tcount:           0,
 		gasPool:          new(core.GasPool).AddGas(header.GasLimit),
-		rules:            w.chainConfig.Rules(header.Number, header.Time),
+		rules:            config.Rules(header.Number, header.Time),
 		predicateContext: predicateContext,
 		predicateResults: predicate.NewResults(),
 		start:            tstart,
+		excluded:         make(map[common.Hash]TxExclusion),
+		skippedSenders:   make(map[common.Address]common.Hash),
 	}, nil
 }
 
@@ -273,7 +380,7 @@ func (w *worker) commitTransaction(env *environment, tx *txpool.Transaction, coi
 		blockContext = core.NewEVMBlockContext(env.header, w.chain, &coinbase)
 	}
 
-	receipt, err := core.ApplyTransaction(w.chainConfig, w.chain, blockContext, env.gasPool, env.state, env.header, tx.Tx, &env.header.GasUsed, *w.chain.GetVMConfig())
+	receipt, err := core.ApplyTransaction(env.config, w.chain, blockContext, env.gasPool, env.state, env.header, tx.Tx, &env.header.GasUsed, *w.chain.GetVMConfig())
 	if err != nil {
 		env.state.RevertToSnapshot(snap)
 		env.gasPool.SetGas(gp)
@@ -283,6 +390,9 @@ func (w *worker) commitTransaction(env *environment, tx *txpool.Transaction, coi
 	env.txs = append(env.txs, tx.Tx)
 	env.receipts = append(env.receipts, receipt)
 	env.size += tx.Tx.Size()
+	if env.header.BlobGasUsed != nil {
+		*env.header.BlobGasUsed += receipt.BlobGasUsed
+	}
 
 	return receipt.Logs, nil
 }
@@ -294,6 +404,13 @@ func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAn
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
 			break
 		}
+		// Stop packing once the build time budget is spent. At least one transaction
+		// is always included, so blocks are still produced under heavy load.
+		if env.tcount > 0 && !env.deadline.IsZero() && time.Now().After(env.deadline) {
+			log.Trace("Build time budget exceeded", "txs", env.tcount)
+			env.overBudget = true
+			break
+		}
 		// Retrieve the next transaction and abort if all done.
 		ltx := txs.Peek()
 		if ltx == nil {
@@ -306,23 +423,50 @@ func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAn
 			txs.Pop()
 			continue
 		}
+		// Error may be ignored here. The error has already been checked
+		// during transaction acceptance is the transaction pool.
+		from, _ := types.Sender(env.signer, tx.Tx)
+
+		// Skip blob transactions whose blobs would exceed the blob gas of the block.
+		if blobGas := tx.Tx.BlobGas(); blobGas > 0 {
+			if env.header.BlobGasUsed == nil || *env.header.BlobGasUsed+blobGas > params.BlobTxMaxBlobGasPerBlock {
+				log.Trace("Skipping blob transaction that would exceed the block blob gas", "hash", tx.Tx.Hash(), "blobGas", blobGas)
+
+				env.skipSender(from, tx.Tx.Hash(), ExclusionBlobGas, nil)
+				txs.Pop()
+				continue
+			}
+		}
+		// Skip conditional transactions whose conditions are not met by the block, and
+		// drop them from the pool unless a later block may still meet them.
+		if conditional := tx.Tx.Conditional(); conditional != nil {
+			if err := checkConditional(env, conditional); err != nil {
+				log.Debug("Skipping conditional transaction", "hash", tx.Tx.Hash(), "err", err)
+				env.skipSender(from, tx.Tx.Hash(), ExclusionConditional, err)
+				if !errors.Is(err, types.ErrConditionalNotReached) {
+					if err := w.eth.TxPool().Cancel(tx.Tx.Hash()); err != nil {
+						log.Debug("Failed to drop conditional transaction", "hash", tx.Tx.Hash(), "err", err)
+					}
+				}
+				txs.Pop()
+				continue
+			}
+		}
 		// Abort transaction if it won't fit in the block and continue to search for a smaller
 		// transction that will fit.
 		if totalTxsSize := env.size + tx.Tx.Size(); totalTxsSize > targetTxsSize {
 			log.Trace("Skipping transaction that would exceed target size", "hash", tx.Tx.Hash(), "totalTxsSize", totalTxsSize, "txSize", tx.Tx.Size())
 
+			env.skipSender(from, tx.Tx.Hash(), ExclusionSize, nil)
 			txs.Pop()
 			continue
 		}
-		// Error may be ignored here. The error has already been checked
-		// during transaction acceptance is the transaction pool.
-		from, _ := types.Sender(env.signer, tx.Tx)
-
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
 		if tx.Tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Tx.Hash(), "eip155", w.chainConfig.EIP155Block)
 
+			env.skipSender(from, tx.Tx.Hash(), ExclusionReplayProtected, nil)
 			txs.Pop()
 			continue
 		}
@@ -334,6 +478,7 @@ func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAn
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Tx.Nonce())
+			env.exclude(tx.Tx.Hash(), ExclusionNonceTooLow, err)
 			txs.Shift()
 
 		case errors.Is(err, nil):
@@ -344,15 +489,68 @@ func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAn
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", tx.Tx.Hash(), "err", err)
+			reason := ExclusionFailed
+			if errors.Is(err, core.ErrGasLimitReached) {
+				reason = ExclusionGasLimit
+			}
+			env.skipSender(from, tx.Tx.Hash(), reason, err)
 			txs.Pop()
 		}
 	}
 }
 
+// checkConditional checks [conditional] is met by the block being built on top
+// of the state of [env].
+func checkConditional(env *environment, conditional *types.TransactionConditional) error {
+	if err := conditional.CheckBlock(env.header.Number, env.header.Time); err != nil {
+		return err
+	}
+	return env.state.CheckKnownAccounts(conditional.KnownAccounts)
+}
+
+// commitSystemTransactions commits the transactions of the system lane before those of
+// the public lane, within the gas reserved to the system lane, such that they are
+// included even while the public lane is saturated.
+func (w *worker) commitSystemTransactions(env *environment, txs *transactionsByPriceAndNonce, coinbase common.Address) {
+	available := env.gasPool.Gas()
+	reserved := w.chainConfig.SystemTxLane.ReservedGas
+	if reserved > available {
+		reserved = available
+	}
+	env.gasPool.SetGas(reserved)
+	w.commitTransactions(env, txs, coinbase)
+	env.gasPool.AddGas(available - reserved)
+}
+
+// uncommitted returns the transactions of [pending] that were not committed to
+// [env], and clears their exclusions so they can be offered again.
+func (env *environment) uncommitted(pending map[common.Address][]*txpool.LazyTransaction) map[common.Address][]*txpool.LazyTransaction {
+	remaining := make(map[common.Address][]*txpool.LazyTransaction)
+	for account, txs := range pending {
+		nonce := env.state.GetNonce(account)
+		for len(txs) > 0 {
+			if tx := txs[0].Resolve(); tx != nil && tx.Tx.Nonce() >= nonce {
+				break
+			}
+			txs = txs[1:]
+		}
+		if len(txs) == 0 {
+			continue
+		}
+		for _, tx := range txs {
+			delete(env.excluded, tx.Hash)
+		}
+		delete(env.skippedSenders, account)
+		remaining[account] = txs
+	}
+	return remaining
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 func (w *worker) commit(env *environment) (*types.Block, error) {
 	if env.rules.IsDurango {
+		env.header.Extra = append(env.header.Extra, w.operatorData(env.rules)...)
 		predicateResultsBytes, err := env.predicateResults.Bytes()
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal predicate results: %w", err)
@@ -0,0 +1,53 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUncommitted(t *testing.T) {
+	require := require.New(t)
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	var (
+		alice, bob = common.Address{1}, common.Address{2}
+		lazy       = func(nonce uint64, gas uint64) *txpool.LazyTransaction {
+			tx := types.NewTransaction(nonce, common.Address{}, nil, gas, big.NewInt(1), nil)
+			return &txpool.LazyTransaction{Hash: tx.Hash(), Tx: &txpool.Transaction{Tx: tx}}
+		}
+		committed = lazy(0, 21000)
+		tooLarge  = lazy(1, 1_000_000)
+		following = lazy(2, 21000)
+		done      = lazy(0, 21000)
+	)
+	env := &environment{
+		state:          statedb,
+		excluded:       make(map[common.Hash]TxExclusion),
+		skippedSenders: make(map[common.Address]common.Hash),
+	}
+	// alice has a transaction committed and the next one left out of the gas
+	// pool, and bob has all of its transactions committed.
+	statedb.SetNonce(alice, 1)
+	statedb.SetNonce(bob, 1)
+	env.skipSender(alice, tooLarge.Hash, ExclusionGasLimit, core.ErrGasLimitReached)
+
+	remaining := env.uncommitted(map[common.Address][]*txpool.LazyTransaction{
+		alice: {committed, tooLarge, following},
+		bob:   {done},
+	})
+	require.Equal(map[common.Address][]*txpool.LazyTransaction{alice: {tooLarge, following}}, remaining)
+	require.Empty(env.excluded)
+	require.Empty(env.skippedSenders)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/ava-labs/subnet-evm/accounts"
 	"github.com/ava-labs/subnet-evm/accounts/external"
 	"github.com/ava-labs/subnet-evm/accounts/keystore"
+	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -53,6 +54,10 @@ type Config struct {
 	// ExternalSigner specifies an external URI for a clef-type signer.
 	ExternalSigner string `toml:",omitempty"`
 
+	// ExternalSignerHeaders are the HTTP headers sent to the external signer,
+	// such as the credentials of a remote signer.
+	ExternalSignerHeaders map[string]string `toml:",omitempty"`
+
 	// UseLightweightKDF lowers the memory and CPU requirements of the key store
 	// scrypt KDF at the expense of security.
 	UseLightweightKDF bool `toml:",omitempty"`
@@ -131,7 +136,11 @@ func makeAccountManager(conf *Config) (*accounts.Manager, error) {
 	var backends []accounts.Backend
 	if len(conf.ExternalSigner) > 0 {
 		log.Info("Using external signer", "url", conf.ExternalSigner)
-		if extapi, err := external.NewExternalBackend(conf.ExternalSigner); err == nil {
+		options := make([]rpc.ClientOption, 0, len(conf.ExternalSignerHeaders))
+		for key, value := range conf.ExternalSignerHeaders {
+			options = append(options, rpc.WithHeader(key, value))
+		}
+		if extapi, err := external.NewExternalBackend(conf.ExternalSigner, options...); err == nil {
 			backends = append(backends, extapi)
 		} else {
 			return nil, fmt.Errorf("error connecting to external signer: %v", err)
@@ -39,6 +39,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const maxJSONLen = 64 * 1024 * 1024 // 64MB
@@ -194,8 +195,26 @@ type ChainConfig struct {
 	FeeConfig          commontype.FeeConfig `json:"feeConfig"`                    // Set the configuration for the dynamic fee algorithm
 	AllowFeeRecipients bool                 `json:"allowFeeRecipients,omitempty"` // Allows fees to be collected by block builders.
 
+	MaxInitCodeSize uint64 `json:"maxInitCodeSize,omitempty"` // Overrides the maximum init code size once Stromboli is activated (0 = default).
+	MaxTxSize       uint64 `json:"maxTxSize,omitempty"`       // Overrides the maximum size of a transaction accepted by the tx pool (0 = default).
+
+	MaxOperatorDataSize  uint64          `json:"maxOperatorDataSize,omitempty"`  // Maximum size of the operator data in the header extra data once Stromboli is activated (0 = not allowed).
+	OperatorDataPrefixes []hexutil.Bytes `json:"operatorDataPrefixes,omitempty"` // If set, operator data must start with one of these prefixes.
+
+	PrecompileAddresses PrecompileAddresses `json:"precompileAddresses,omitempty"` // Config for moving custom precompiles to other addresses than their compile-time ones.
+
+	NativeCurrency *NativeCurrency `json:"nativeCurrency,omitempty"` // Display name, symbol and decimals of the native token (nil = unnamed with 18 decimals).
+	SystemTxLane   *SystemTxLane   `json:"systemTxLane,omitempty"`   // Lane of the tx pool and of block building reserved to allow-listed senders (nil = disabled).
+
+	TxReplacementPolicy *TxReplacementPolicy `json:"txReplacementPolicy,omitempty"` // Rules to replace transactions of the tx pool (nil = tx pool config).
+	FeeSponsorship      *FeeSponsorship      `json:"feeSponsorship,omitempty"`      // Paymaster contract paying the gas of qualifying transactions (nil = disabled).
+
+	ReceiptPredicateResults bool `json:"receiptPredicateResults,omitempty"` // Adds the predicate results and delivered warp message IDs of transactions to the receipts served over RPC.
+
 	GenesisPrecompiles Precompiles `json:"-"` // Config for enabling precompiles from genesis. JSON encode/decode will be handled by the custom marshaler/unmarshaler.
 	UpgradeConfig      `json:"-"`  // Config specified in upgradeBytes (avalanche network upgrades or enable/disabling precompiles). Skip encoding/decoding directly into ChainConfig.
+
+	unscheduled *ChainConfig // Config the scheduled precompile upgrades were merged into by WithScheduledPrecompileUpgrades (nil = none merged).
 }
 
 // Description returns a human-readable description of ChainConfig.
@@ -260,6 +279,38 @@ func (c *ChainConfig) Description() string {
 
 	banner += fmt.Sprintf("Allow Fee Recipients: %v", c.AllowFeeRecipients)
 	banner += "\n"
+
+	if c.MaxInitCodeSize != 0 {
+		banner += fmt.Sprintf("Max Init Code Size: %d (after Stromboli)\n", c.MaxInitCodeSize)
+	}
+	if c.MaxTxSize != 0 {
+		banner += fmt.Sprintf("Max Tx Size: %d\n", c.MaxTxSize)
+	}
+	if c.MaxOperatorDataSize != 0 {
+		banner += fmt.Sprintf("Max Operator Data Size: %d (after Stromboli)\n", c.MaxOperatorDataSize)
+	}
+	if len(c.PrecompileAddresses) != 0 {
+		precompileAddressesBytes, err := json.Marshal(c.PrecompileAddresses)
+		if err != nil {
+			precompileAddressesBytes = []byte("cannot marshal PrecompileAddresses")
+		}
+		banner += fmt.Sprintf("Precompile Addresses: %s\n", string(precompileAddressesBytes))
+	}
+	if c.NativeCurrency != nil {
+		banner += fmt.Sprintf("Native Currency: %s (%s), %d decimals\n", c.NativeCurrency.Name, c.NativeCurrency.Symbol, c.NativeCurrency.Decimals)
+	}
+	if c.SystemTxLane != nil {
+		banner += fmt.Sprintf("System Tx Lane: %d senders, %d reserved gas\n", len(c.SystemTxLane.Senders), c.SystemTxLane.ReservedGas)
+	}
+	if c.TxReplacementPolicy != nil {
+		banner += fmt.Sprintf("Tx Replacement Policy: %d%% price bump, %d max replacements\n", c.TxReplacementPolicy.PriceBump, c.TxReplacementPolicy.MaxReplacements)
+	}
+	if c.FeeSponsorship != nil {
+		banner += fmt.Sprintf("Fee Sponsorship: paymaster %s, %d check gas, @%v\n", c.FeeSponsorship.Paymaster, c.FeeSponsorship.GetCheckGas(), ptrToString(c.FeeSponsorship.BlockTimestamp))
+	}
+	if c.ReceiptPredicateResults {
+		banner += "Receipt Predicate Results: enabled\n"
+	}
 	return banner
 }
 
@@ -322,6 +373,18 @@ func (c *ChainConfig) IsDurango(time uint64) bool {
 	return utils.IsTimestampForked(c.DurangoTimestamp, time)
 }
 
+// IsStromboli returns whether [time] represents a block
+// with a timestamp after the Stromboli upgrade time.
+func (c *ChainConfig) IsStromboli(time uint64) bool {
+	return utils.IsTimestampForked(c.getOptionalNetworkUpgrades().StromboliTimestamp, time)
+}
+
+// IsVesuvius returns whether [time] represents a block
+// with a timestamp after the Vesuvius upgrade time.
+func (c *ChainConfig) IsVesuvius(time uint64) bool {
+	return utils.IsTimestampForked(c.getOptionalNetworkUpgrades().VesuviusTimestamp, time)
+}
+
 // IsCancun returns whether [time] represents a block
 // with a timestamp after the Cancun upgrade time.
 func (c *ChainConfig) IsCancun(num *big.Int, time uint64) bool {
@@ -374,6 +437,36 @@ func (c *ChainConfig) Verify() error {
 		return err
 	}
 
+	if c.MaxInitCodeSize > MaxConfigurableTxSize {
+		return fmt.Errorf("maxInitCodeSize %d exceeds limit %d", c.MaxInitCodeSize, MaxConfigurableTxSize)
+	}
+	if c.MaxTxSize > MaxConfigurableTxSize {
+		return fmt.Errorf("maxTxSize %d exceeds limit %d", c.MaxTxSize, MaxConfigurableTxSize)
+	}
+	if err := c.verifyOperatorDataPolicy(); err != nil {
+		return err
+	}
+	if c.NativeCurrency != nil {
+		if err := c.NativeCurrency.Verify(); err != nil {
+			return err
+		}
+	}
+	if c.SystemTxLane != nil {
+		if err := c.SystemTxLane.Verify(); err != nil {
+			return err
+		}
+	}
+	if c.TxReplacementPolicy != nil {
+		if err := c.TxReplacementPolicy.Verify(); err != nil {
+			return err
+		}
+	}
+	if c.FeeSponsorship != nil {
+		if err := c.FeeSponsorship.Verify(); err != nil {
+			return err
+		}
+	}
+
 	// Verify the precompile upgrades are internally consistent given the existing chainConfig.
 	if err := c.verifyPrecompileUpgrades(); err != nil {
 		return fmt.Errorf("invalid precompile upgrades: %w", err)
@@ -384,6 +477,11 @@ func (c *ChainConfig) Verify() error {
 		return fmt.Errorf("invalid state upgrades: %w", err)
 	}
 
+	// Verify the fee config upgrades are internally consistent given the existing chainConfig.
+	if err := c.verifyFeeConfigUpgrades(); err != nil {
+		return fmt.Errorf("invalid fee config upgrades: %w", err)
+	}
+
 	return nil
 }
 
@@ -519,6 +617,25 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, height *big.Int, time
 		return err
 	}
 
+	// The init code size limit is consensus relevant once Stromboli is activated.
+	if (c.IsStromboli(time) || newcfg.IsStromboli(time)) && c.MaxInitCodeSize != newcfg.MaxInitCodeSize {
+		return newTimestampCompatError("MaxInitCodeSize", c.getOptionalNetworkUpgrades().StromboliTimestamp, newOptionalNetworkUpgrades.StromboliTimestamp)
+	}
+	// The precompile addresses are consensus relevant from genesis.
+	if !c.PrecompileAddresses.Equal(newcfg.PrecompileAddresses) {
+		return newTimestampCompatError("PrecompileAddresses", utils.NewUint64(0), utils.NewUint64(0))
+	}
+
+	// The operator data policy is consensus relevant once Stromboli is activated.
+	if (c.IsStromboli(time) || newcfg.IsStromboli(time)) && !c.sameOperatorDataPolicy(newcfg) {
+		return newTimestampCompatError("OperatorDataPolicy", c.getOptionalNetworkUpgrades().StromboliTimestamp, newOptionalNetworkUpgrades.StromboliTimestamp)
+	}
+
+	// The fee sponsorship is consensus relevant once activated.
+	if err := c.checkFeeSponsorshipCompatible(newcfg, time); err != nil {
+		return err
+	}
+
 	// Check that the precompiles on the new config are compatible with the existing precompile config.
 	if err := c.CheckPrecompilesCompatible(newcfg.PrecompileUpgrades, time); err != nil {
 		return err
@@ -529,6 +646,11 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, height *big.Int, time
 		return err
 	}
 
+	// Check that the fee config upgrades on the new config are compatible with the existing fee config upgrades.
+	if err := c.CheckFeeConfigUpgradesCompatible(newcfg.FeeConfigUpgrades, time); err != nil {
+		return err
+	}
+
 	// TODO verify that the fee config is fully compatible between [c] and [newcfg].
 	return nil
 }
@@ -656,6 +778,21 @@ type Rules struct {
 	IsSubnetEVM bool
 	IsDurango   bool
 
+	// Rules for optional Subnet-EVM network upgrades
+	IsStromboli bool
+	IsVesuvius  bool
+
+	// MaxInitCodeSize is the maximum init code size permitted in a creation
+	// transaction and the create instructions.
+	MaxInitCodeSize int
+
+	// MaxOperatorDataSize is the maximum size of the operator data in the header
+	// extra data, or 0 if operator data is not allowed.
+	MaxOperatorDataSize int
+	// OperatorDataPrefixes restricts the operator data to start with one of these
+	// prefixes if non-empty.
+	OperatorDataPrefixes []hexutil.Bytes
+
 	// ActivePrecompiles maps addresses to stateful precompiled contracts that are enabled
 	// for this rule set.
 	// Note: none of these addresses should conflict with the address space used by
@@ -702,6 +839,11 @@ func (c *ChainConfig) Rules(blockNum *big.Int, timestamp uint64) Rules {
 
 	rules.IsSubnetEVM = c.IsSubnetEVM(timestamp)
 	rules.IsDurango = c.IsDurango(timestamp)
+	rules.IsStromboli = c.IsStromboli(timestamp)
+	rules.IsVesuvius = c.IsVesuvius(timestamp)
+	rules.MaxInitCodeSize = c.GetMaxInitCodeSize(timestamp)
+	rules.MaxOperatorDataSize = c.GetMaxOperatorDataSize(timestamp)
+	rules.OperatorDataPrefixes = c.OperatorDataPrefixes
 
 	// Initialize the stateful precompiles that should be enabled at [blockTimestamp].
 	rules.ActivePrecompiles = make(map[common.Address]precompileconfig.Config)
@@ -734,6 +876,24 @@ func (c *ChainConfig) AllowedFeeRecipients() bool {
 	return c.AllowFeeRecipients
 }
 
+// GetMaxInitCodeSize returns the maximum init code size at [time].
+// The chain configured limit only applies once Stromboli is activated.
+func (c *ChainConfig) GetMaxInitCodeSize(time uint64) int {
+	if c.MaxInitCodeSize != 0 && c.IsStromboli(time) {
+		return int(c.MaxInitCodeSize)
+	}
+	return MaxInitCodeSize
+}
+
+// GetMaxTxSize returns the maximum size of a transaction accepted by the tx pool,
+// or [defaultSize] if the chain does not configure one.
+func (c *ChainConfig) GetMaxTxSize(defaultSize uint64) uint64 {
+	if c.MaxTxSize != 0 {
+		return c.MaxTxSize
+	}
+	return defaultSize
+}
+
 // getOptionalNetworkUpgrades returns OptionalNetworkUpgrades from upgrade config if set there,
 // otherwise it falls back to the genesis chain config.
 func (c *ChainConfig) getOptionalNetworkUpgrades() *OptionalNetworkUpgrades {
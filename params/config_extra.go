@@ -24,6 +24,9 @@ type UpgradeConfig struct {
 
 	// Config for enabling and disabling precompiles as network upgrades.
 	PrecompileUpgrades []PrecompileUpgrade `json:"precompileUpgrades,omitempty"`
+
+	// Config for changing the fee config as a network upgrade.
+	FeeConfigUpgrades []FeeConfigUpgrade `json:"feeConfigUpgrades,omitempty"`
 }
 
 // AvalancheContext provides Avalanche specific context directly into the EVM.
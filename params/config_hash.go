@@ -0,0 +1,109 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Hash returns the keccak256 hash of the canonical JSON encoding of the fully
+// resolved chain config, including the genesis precompiles and the upgrades.
+// Nodes running the same chain with the same upgrade bytes have the same hash,
+// whatever the formatting of the genesis and upgrade bytes they were given.
+func (c *ChainConfig) Hash() (common.Hash, error) {
+	return hashJSON(c.ToWithUpgradesJSON())
+}
+
+// ActiveHash returns the hash of the chain config as in effect at [time],
+// that is without the network upgrades, precompile upgrades, state upgrades and
+// fee config upgrades scheduled after [time].
+// Unlike Hash, it does not change when an upgrade is scheduled, only once it
+// is activated.
+func (c *ChainConfig) ActiveHash(time uint64) (common.Hash, error) {
+	active := *c
+	active.MandatoryNetworkUpgrades = MandatoryNetworkUpgrades{
+		SubnetEVMTimestamp: activeTimestamp(c.SubnetEVMTimestamp, time),
+		DurangoTimestamp:   activeTimestamp(c.DurangoTimestamp, time),
+		CancunTime:         activeTimestamp(c.CancunTime, time),
+	}
+	active.OptionalNetworkUpgrades = activeOptionalNetworkUpgrades(c.OptionalNetworkUpgrades, time)
+	if c.UpgradeConfig.OptionalNetworkUpgrades != nil {
+		upgrades := activeOptionalNetworkUpgrades(*c.UpgradeConfig.OptionalNetworkUpgrades, time)
+		active.UpgradeConfig.OptionalNetworkUpgrades = &upgrades
+	}
+
+	active.GenesisPrecompiles = make(Precompiles)
+	for key, config := range c.GenesisPrecompiles {
+		if utils.IsTimestampForked(config.Timestamp(), time) {
+			active.GenesisPrecompiles[key] = config
+		}
+	}
+	active.UpgradeConfig.PrecompileUpgrades = nil
+	for _, upgrade := range c.PrecompileUpgrades {
+		if utils.IsTimestampForked(upgrade.Timestamp(), time) {
+			active.UpgradeConfig.PrecompileUpgrades = append(active.UpgradeConfig.PrecompileUpgrades, upgrade)
+		}
+	}
+	active.UpgradeConfig.StateUpgrades = nil
+	for _, upgrade := range c.StateUpgrades {
+		if utils.IsTimestampForked(upgrade.BlockTimestamp, time) {
+			active.UpgradeConfig.StateUpgrades = append(active.UpgradeConfig.StateUpgrades, upgrade)
+		}
+	}
+	active.UpgradeConfig.FeeConfigUpgrades = nil
+	for _, upgrade := range c.FeeConfigUpgrades {
+		if utils.IsTimestampForked(upgrade.BlockTimestamp, time) {
+			active.UpgradeConfig.FeeConfigUpgrades = append(active.UpgradeConfig.FeeConfigUpgrades, upgrade)
+		}
+	}
+	if !c.IsFeeSponsorship(time) {
+		active.FeeSponsorship = nil
+	}
+	return active.Hash()
+}
+
+func activeOptionalNetworkUpgrades(upgrades OptionalNetworkUpgrades, time uint64) OptionalNetworkUpgrades {
+	return OptionalNetworkUpgrades{
+		StromboliTimestamp: activeTimestamp(upgrades.StromboliTimestamp, time),
+		VesuviusTimestamp:  activeTimestamp(upgrades.VesuviusTimestamp, time),
+	}
+}
+
+// activeTimestamp returns [timestamp] if it is activated at [time], and nil
+// otherwise.
+func activeTimestamp(timestamp *uint64, time uint64) *uint64 {
+	if utils.IsTimestampForked(timestamp, time) {
+		return timestamp
+	}
+	return nil
+}
+
+// hashJSON returns the keccak256 hash of the canonical JSON encoding of [v],
+// with the keys of all the objects sorted and no insignificant whitespace.
+func hashJSON(v interface{}) (common.Hash, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to marshal chain config: %w", err)
+	}
+	// Decoding into generic values and encoding them again sorts the keys of
+	// the objects, whatever the order of the fields of the custom marshalers.
+	// Numbers are kept as is, so large integers are not rounded.
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to decode chain config: %w", err)
+	}
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to marshal canonical chain config: %w", err)
+	}
+	return crypto.Keccak256Hash(canonical), nil
+}
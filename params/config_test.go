@@ -31,12 +31,14 @@ import (
 	"math"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -170,6 +172,39 @@ func TestConfigRules(t *testing.T) {
 	}
 }
 
+func TestConfigSizeLimits(t *testing.T) {
+	require := require.New(t)
+
+	c := &ChainConfig{
+		FeeConfig: DefaultFeeConfig,
+		OptionalNetworkUpgrades: OptionalNetworkUpgrades{
+			StromboliTimestamp: utils.NewUint64(500),
+		},
+		MaxInitCodeSize: 4 * MaxInitCodeSize,
+	}
+	require.NoError(c.Verify())
+
+	// The init code size limit only applies after Stromboli
+	require.Equal(MaxInitCodeSize, c.GetMaxInitCodeSize(0))
+	require.Equal(MaxInitCodeSize, c.Rules(big.NewInt(0), 0).MaxInitCodeSize)
+	require.Equal(4*MaxInitCodeSize, c.GetMaxInitCodeSize(500))
+	require.Equal(4*MaxInitCodeSize, c.Rules(big.NewInt(0), 500).MaxInitCodeSize)
+
+	// The tx size limit does not depend on activation
+	require.EqualValues(128, c.GetMaxTxSize(128))
+	c.MaxTxSize = 256 * 1024
+	require.EqualValues(256*1024, c.GetMaxTxSize(128))
+
+	// Changing the init code size after Stromboli is incompatible
+	newcfg := *c
+	newcfg.MaxInitCodeSize = 0
+	require.Nil(c.CheckCompatible(&newcfg, 0, 499))
+	require.NotNil(c.CheckCompatible(&newcfg, 0, 500))
+
+	c.MaxTxSize = MaxConfigurableTxSize + 1
+	require.ErrorContains(c.Verify(), "maxTxSize")
+}
+
 func TestConfigUnmarshalJSON(t *testing.T) {
 	require := require.New(t)
 
@@ -328,3 +363,150 @@ func TestChainConfigMarshalWithUpgrades(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, config, unmarshalled)
 }
+
+func TestPrecompileAddresses(t *testing.T) {
+	require := require.New(t)
+
+	c := &ChainConfig{}
+	require.NoError(json.Unmarshal([]byte(`{"precompileAddresses": {"txAllowListConfig": "0x0300000000000000000000000000000000000001"}}`), c))
+	require.Equal(PrecompileAddresses{txallowlist.ConfigKey: common.HexToAddress("0x0300000000000000000000000000000000000001")}, c.PrecompileAddresses)
+
+	// Built-in precompiles cannot be moved
+	require.ErrorIs(c.ApplyPrecompileAddresses(), modules.ErrAddressNotSettable)
+	require.Equal(txallowlist.ContractAddress, modules.ModuleAddress(txallowlist.ConfigKey))
+	c.PrecompileAddresses = PrecompileAddresses{"unknown": common.HexToAddress("0x0300000000000000000000000000000000000001")}
+	require.ErrorIs(c.ApplyPrecompileAddresses(), modules.ErrUnknownModule)
+
+	// Configuring a precompile at its compile-time address is a no-op
+	c.PrecompileAddresses = PrecompileAddresses{txallowlist.ConfigKey: txallowlist.ContractAddress}
+	require.NoError(c.ApplyPrecompileAddresses())
+
+	// Changing the addresses is incompatible
+	newcfg := *c
+	newcfg.PrecompileAddresses = nil
+	require.NotNil(c.CheckCompatible(&newcfg, 0, 0))
+	newcfg.PrecompileAddresses = PrecompileAddresses{txallowlist.ConfigKey: txallowlist.ContractAddress}
+	require.Nil(c.CheckCompatible(&newcfg, 0, 0))
+}
+
+func TestNativeCurrency(t *testing.T) {
+	require := require.New(t)
+
+	c := &ChainConfig{}
+	require.Equal(NativeCurrency{Decimals: DefaultNativeCurrencyDecimals}, c.GetNativeCurrency())
+
+	require.NoError(json.Unmarshal([]byte(`{"nativeCurrency": {"name": "Gas Token", "symbol": "GAS", "decimals": 6}}`), c))
+	require.Equal(NativeCurrency{Name: "Gas Token", Symbol: "GAS", Decimals: 6}, c.GetNativeCurrency())
+
+	config := *TestChainConfig
+	config.NativeCurrency = &NativeCurrency{Symbol: "GAS", Decimals: 6}
+	require.NoError(config.Verify())
+	config.NativeCurrency = &NativeCurrency{Symbol: "GAS", Decimals: MaxNativeCurrencyDecimals + 1}
+	require.ErrorContains(config.Verify(), "native currency decimals")
+	config.NativeCurrency = &NativeCurrency{Symbol: strings.Repeat("G", maxNativeCurrencyNameLen+1)}
+	require.ErrorContains(config.Verify(), "native currency symbol length")
+}
+
+func TestSystemTxLane(t *testing.T) {
+	require := require.New(t)
+
+	sender := common.HexToAddress("0x0100000000000000000000000000000000000001")
+	c := &ChainConfig{}
+	require.False(c.IsSystemSender(sender))
+
+	require.NoError(json.Unmarshal([]byte(`{"systemTxLane": {"senders": ["0x0100000000000000000000000000000000000001"], "reservedGas": 100000}}`), c))
+	require.Equal(&SystemTxLane{Senders: []common.Address{sender}, ReservedGas: 100_000}, c.SystemTxLane)
+	require.True(c.IsSystemSender(sender))
+	require.False(c.IsSystemSender(common.Address{}))
+	require.Equal(uint64(DefaultSystemTxLanePoolSlots), c.SystemTxLane.GetPoolSlots())
+
+	config := *TestChainConfig
+	config.SystemTxLane = &SystemTxLane{Senders: []common.Address{sender}, ReservedGas: 100_000}
+	require.NoError(config.Verify())
+	config.SystemTxLane = &SystemTxLane{ReservedGas: 100_000}
+	require.ErrorContains(config.Verify(), "at least one sender")
+	config.SystemTxLane = &SystemTxLane{Senders: []common.Address{sender}, ReservedGas: TxGas - 1}
+	require.ErrorContains(config.Verify(), "reserved gas")
+	config.SystemTxLane = &SystemTxLane{Senders: []common.Address{sender, sender}, ReservedGas: 100_000}
+	require.ErrorContains(config.Verify(), "duplicate system tx lane sender")
+}
+
+func TestTxReplacementPolicy(t *testing.T) {
+	require := require.New(t)
+
+	c := &ChainConfig{}
+	require.Equal(TxReplacementPolicy{}, c.GetTxReplacementPolicy())
+
+	require.NoError(json.Unmarshal([]byte(`{"txReplacementPolicy": {"priceBump": 25, "maxReplacements": 3}}`), c))
+	require.Equal(TxReplacementPolicy{PriceBump: 25, MaxReplacements: 3}, c.GetTxReplacementPolicy())
+
+	config := *TestChainConfig
+	config.TxReplacementPolicy = &TxReplacementPolicy{PriceBump: MaxTxReplacementPriceBump}
+	require.NoError(config.Verify())
+	config.TxReplacementPolicy = &TxReplacementPolicy{PriceBump: MaxTxReplacementPriceBump + 1}
+	require.ErrorContains(config.Verify(), "tx replacement price bump")
+}
+
+func TestFeeSponsorship(t *testing.T) {
+	require := require.New(t)
+
+	c := &ChainConfig{}
+	require.NoError(json.Unmarshal([]byte(`{"feeSponsorship": {"blockTimestamp": 10, "paymaster": "0x00000000000000000000000000000000000000dd"}}`), c))
+	require.False(c.IsFeeSponsorship(9))
+	require.True(c.IsFeeSponsorship(10))
+	require.Equal(uint64(DefaultFeeSponsorshipCheckGas), c.FeeSponsorship.GetCheckGas())
+
+	config := *TestChainConfig
+	config.FeeSponsorship = &FeeSponsorship{BlockTimestamp: utils.NewUint64(10), Paymaster: common.Address{0xdd}, CheckGas: MaxFeeSponsorshipCheckGas}
+	require.NoError(config.Verify())
+	config.FeeSponsorship.CheckGas = MaxFeeSponsorshipCheckGas + 1
+	require.ErrorContains(config.Verify(), "fee sponsorship check gas")
+	config.FeeSponsorship = &FeeSponsorship{BlockTimestamp: utils.NewUint64(10)}
+	require.ErrorContains(config.Verify(), "fee sponsorship must have a paymaster")
+
+	// The sponsorship can only be changed before it is activated.
+	stored := *TestChainConfig
+	stored.FeeSponsorship = &FeeSponsorship{BlockTimestamp: utils.NewUint64(10), Paymaster: common.Address{0xdd}}
+	updated := stored
+	updated.FeeSponsorship = &FeeSponsorship{BlockTimestamp: utils.NewUint64(20), Paymaster: common.Address{0xee}}
+	require.Nil(stored.checkFeeSponsorshipCompatible(&updated, 9))
+	require.NotNil(stored.checkFeeSponsorshipCompatible(&updated, 10))
+}
+
+func TestChainConfigHash(t *testing.T) {
+	require := require.New(t)
+
+	// The hash does not depend on the formatting of the config.
+	var a, b ChainConfigWithUpgradesJSON
+	require.NoError(json.Unmarshal([]byte(`{"chainId": 1, "feeConfig": {"gasLimit": 8000000}, "upgrades": {}}`), &a))
+	require.NoError(json.Unmarshal([]byte(`{"feeConfig":{"gasLimit":8000000},"chainId":1}`), &b))
+	aHash, err := a.ChainConfig.Hash()
+	require.NoError(err)
+	bHash, err := b.ChainConfig.Hash()
+	require.NoError(err)
+	require.Equal(aHash, bHash)
+
+	config := *TestChainConfig
+	hash, err := config.Hash()
+	require.NoError(err)
+	activeHash, err := config.ActiveHash(50)
+	require.NoError(err)
+	require.Equal(hash, activeHash)
+
+	// Scheduling an upgrade changes the hash, but not the active hash until the
+	// upgrade is activated.
+	config.UpgradeConfig = UpgradeConfig{
+		PrecompileUpgrades: []PrecompileUpgrade{
+			{Config: txallowlist.NewConfig(utils.NewUint64(100), nil, nil, nil)},
+		},
+	}
+	scheduledHash, err := config.Hash()
+	require.NoError(err)
+	require.NotEqual(hash, scheduledHash)
+	scheduledActiveHash, err := config.ActiveHash(50)
+	require.NoError(err)
+	require.Equal(activeHash, scheduledActiveHash)
+	scheduledActiveHash, err = config.ActiveHash(100)
+	require.NoError(err)
+	require.Equal(scheduledHash, scheduledActiveHash)
+}
@@ -0,0 +1,162 @@
+// (c) 2024 Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// OperatorDataMarker marks the start of the operator data in the header extra data,
+	// right after the fee window. The predicate results that may follow the fee window
+	// otherwise always start with their codec version (0x0000), so the marker cannot be
+	// mistaken for them.
+	OperatorDataMarker byte = 0xff
+	// OperatorDataHeaderSize is the size of the marker and the big endian uint16 length
+	// preceding the operator data.
+	OperatorDataHeaderSize = 3
+	// MaxConfigurableOperatorDataSize is the upper bound of the chain configurable
+	// operator data size, as limited by the uint16 length prefix.
+	MaxConfigurableOperatorDataSize = math.MaxUint16
+)
+
+var (
+	ErrInvalidExtraData        = errors.New("invalid header extra data")
+	ErrOperatorDataNotAllowed  = errors.New("operator data not allowed")
+	ErrOperatorDataTooLarge    = errors.New("operator data too large")
+	ErrOperatorDataPrefix      = errors.New("operator data does not start with an allowed prefix")
+	errEmptyOperatorData       = errors.New("empty operator data")
+	errTruncatedOperatorData   = errors.New("truncated operator data")
+	errEmptyOperatorDataPrefix = errors.New("empty operator data prefix")
+)
+
+// PackOperatorData returns the encoding of [data] to be appended to the fee window
+// of the header extra data. Empty operator data is omitted from the extra data.
+func PackOperatorData(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	packed := make([]byte, OperatorDataHeaderSize, OperatorDataHeaderSize+len(data))
+	packed[0] = OperatorDataMarker
+	binary.BigEndian.PutUint16(packed[1:], uint16(len(data)))
+	return append(packed, data...)
+}
+
+// ParseOperatorData parses the operator data at the start of [extraData], the bytes of
+// the header extra data following the fee window. It returns the operator data, nil if
+// there is none, and the remaining bytes.
+func ParseOperatorData(extraData []byte) ([]byte, []byte, error) {
+	if len(extraData) == 0 || extraData[0] != OperatorDataMarker {
+		return nil, extraData, nil
+	}
+	if len(extraData) < OperatorDataHeaderSize {
+		return nil, nil, errTruncatedOperatorData
+	}
+	size := int(binary.BigEndian.Uint16(extraData[1:OperatorDataHeaderSize]))
+	if size == 0 {
+		return nil, nil, errEmptyOperatorData
+	}
+	end := OperatorDataHeaderSize + size
+	if len(extraData) < end {
+		return nil, nil, fmt.Errorf("%w: expected %d bytes, found %d", errTruncatedOperatorData, size, len(extraData)-OperatorDataHeaderSize)
+	}
+	return extraData[OperatorDataHeaderSize:end], extraData[end:], nil
+}
+
+// VerifyOperatorData verifies [data] against the operator data policy of [rules].
+// Empty operator data is always valid.
+func (r *Rules) VerifyOperatorData(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if r.MaxOperatorDataSize == 0 {
+		return ErrOperatorDataNotAllowed
+	}
+	if len(data) > r.MaxOperatorDataSize {
+		return fmt.Errorf("%w: %d > %d", ErrOperatorDataTooLarge, len(data), r.MaxOperatorDataSize)
+	}
+	if len(r.OperatorDataPrefixes) == 0 {
+		return nil
+	}
+	for _, prefix := range r.OperatorDataPrefixes {
+		if bytes.HasPrefix(data, prefix) {
+			return nil
+		}
+	}
+	return ErrOperatorDataPrefix
+}
+
+// VerifyExtraData verifies the size and content of the header [extraData] under [rules].
+// This is shared by the block builder and the verifiers so they apply the same policy.
+func VerifyExtraData(rules Rules, extraData []byte) error {
+	switch {
+	case rules.IsDurango:
+		if len(extraData) < DynamicFeeExtraDataSize {
+			return fmt.Errorf("%w: expected length >= %d, found %d", ErrInvalidExtraData, DynamicFeeExtraDataSize, len(extraData))
+		}
+		operatorData, _, err := ParseOperatorData(extraData[DynamicFeeExtraDataSize:])
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidExtraData, err)
+		}
+		if err := rules.VerifyOperatorData(operatorData); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidExtraData, err)
+		}
+	case rules.IsSubnetEVM:
+		if len(extraData) != DynamicFeeExtraDataSize {
+			return fmt.Errorf("%w: expected length %d, found %d", ErrInvalidExtraData, DynamicFeeExtraDataSize, len(extraData))
+		}
+	default:
+		if uint64(len(extraData)) > MaximumExtraDataSize {
+			return fmt.Errorf("%w: too long: %d > %d", ErrInvalidExtraData, len(extraData), MaximumExtraDataSize)
+		}
+	}
+	return nil
+}
+
+// verifyOperatorDataPolicy verifies the operator data limits configured in the chain config.
+func (c *ChainConfig) verifyOperatorDataPolicy() error {
+	if c.MaxOperatorDataSize > MaxConfigurableOperatorDataSize {
+		return fmt.Errorf("maxOperatorDataSize %d exceeds limit %d", c.MaxOperatorDataSize, MaxConfigurableOperatorDataSize)
+	}
+	if len(c.OperatorDataPrefixes) != 0 && c.MaxOperatorDataSize == 0 {
+		return errors.New("operatorDataPrefixes requires a non-zero maxOperatorDataSize")
+	}
+	for i, prefix := range c.OperatorDataPrefixes {
+		if len(prefix) == 0 {
+			return fmt.Errorf("operatorDataPrefixes[%d]: %w", i, errEmptyOperatorDataPrefix)
+		}
+		if uint64(len(prefix)) > c.MaxOperatorDataSize {
+			return fmt.Errorf("operatorDataPrefixes[%d] of length %d exceeds maxOperatorDataSize %d", i, len(prefix), c.MaxOperatorDataSize)
+		}
+	}
+	return nil
+}
+
+// GetMaxOperatorDataSize returns the maximum size of the operator data in the header
+// extra data at [time], or 0 if operator data is not allowed.
+// Operator data can only be included once Stromboli is activated.
+func (c *ChainConfig) GetMaxOperatorDataSize(time uint64) int {
+	if !c.IsStromboli(time) {
+		return 0
+	}
+	return int(c.MaxOperatorDataSize)
+}
+
+// sameOperatorDataPolicy returns true if [newcfg] configures the same operator data
+// policy as [c].
+func (c *ChainConfig) sameOperatorDataPolicy(newcfg *ChainConfig) bool {
+	if c.MaxOperatorDataSize != newcfg.MaxOperatorDataSize || len(c.OperatorDataPrefixes) != len(newcfg.OperatorDataPrefixes) {
+		return false
+	}
+	for i, prefix := range c.OperatorDataPrefixes {
+		if !bytes.Equal(prefix, newcfg.OperatorDataPrefixes[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+// (c) 2024 Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOperatorData(t *testing.T) {
+	results := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	tests := []struct {
+		name         string
+		extraData    []byte
+		expectedData []byte
+		expectedRest []byte
+		expectedErr  error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:         "no operator data",
+			extraData:    results,
+			expectedRest: results,
+		},
+		{
+			name:         "operator data",
+			extraData:    append(PackOperatorData([]byte{1, 2, 3}), results...),
+			expectedData: []byte{1, 2, 3},
+			expectedRest: results,
+		},
+		{
+			name:         "operator data only",
+			extraData:    PackOperatorData([]byte{1, 2, 3}),
+			expectedData: []byte{1, 2, 3},
+			expectedRest: []byte{},
+		},
+		{
+			name:        "truncated header",
+			extraData:   []byte{OperatorDataMarker, 0x00},
+			expectedErr: errTruncatedOperatorData,
+		},
+		{
+			name:        "truncated data",
+			extraData:   PackOperatorData([]byte{1, 2, 3})[:5],
+			expectedErr: errTruncatedOperatorData,
+		},
+		{
+			name:        "empty operator data",
+			extraData:   []byte{OperatorDataMarker, 0x00, 0x00},
+			expectedErr: errEmptyOperatorData,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			data, rest, err := ParseOperatorData(test.extraData)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedData, data)
+			require.Equal(test.expectedRest, rest)
+		})
+	}
+}
+
+func TestVerifyExtraData(t *testing.T) {
+	feeWindow := make([]byte, DynamicFeeExtraDataSize)
+	withOperatorData := func(data []byte) []byte {
+		return append(append(append([]byte{}, feeWindow...), PackOperatorData(data)...), 0x00, 0x00)
+	}
+	durango := Rules{IsSubnetEVM: true, IsDurango: true}
+	policy := Rules{IsSubnetEVM: true, IsDurango: true, IsStromboli: true, MaxOperatorDataSize: 4, OperatorDataPrefixes: []hexutil.Bytes{{0x01}, {0x02, 0x03}}}
+	tests := []struct {
+		name        string
+		rules       Rules
+		extraData   []byte
+		expectedErr error
+	}{
+		{
+			name:      "pre subnet evm",
+			extraData: make([]byte, MaximumExtraDataSize),
+		},
+		{
+			name:        "pre subnet evm too long",
+			extraData:   make([]byte, MaximumExtraDataSize+1),
+			expectedErr: ErrInvalidExtraData,
+		},
+		{
+			name:      "subnet evm",
+			rules:     Rules{IsSubnetEVM: true},
+			extraData: feeWindow,
+		},
+		{
+			name:        "subnet evm too long",
+			rules:       Rules{IsSubnetEVM: true},
+			extraData:   append(feeWindow, 0x00),
+			expectedErr: ErrInvalidExtraData,
+		},
+		{
+			name:      "durango predicate results",
+			rules:     durango,
+			extraData: append(feeWindow, 0x00, 0x00),
+		},
+		{
+			name:        "durango too short",
+			rules:       durango,
+			extraData:   feeWindow[1:],
+			expectedErr: ErrInvalidExtraData,
+		},
+		{
+			name:        "operator data not allowed",
+			rules:       durango,
+			extraData:   withOperatorData([]byte{0x01}),
+			expectedErr: ErrOperatorDataNotAllowed,
+		},
+		{
+			name:      "operator data",
+			rules:     policy,
+			extraData: withOperatorData([]byte{0x02, 0x03, 0x04, 0x05}),
+		},
+		{
+			name:        "operator data too large",
+			rules:       policy,
+			extraData:   withOperatorData([]byte{0x01, 0x02, 0x03, 0x04, 0x05}),
+			expectedErr: ErrOperatorDataTooLarge,
+		},
+		{
+			name:        "operator data without allowed prefix",
+			rules:       policy,
+			extraData:   withOperatorData([]byte{0x02, 0x04}),
+			expectedErr: ErrOperatorDataPrefix,
+		},
+		{
+			name:        "malformed operator data",
+			rules:       policy,
+			extraData:   append(feeWindow, OperatorDataMarker, 0x00, 0x05, 0x01),
+			expectedErr: errTruncatedOperatorData,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyExtraData(test.rules, test.extraData)
+			require.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, ErrInvalidExtraData)
+			}
+		})
+	}
+}
+
+func TestOperatorDataPolicyConfig(t *testing.T) {
+	require := require.New(t)
+	c := ChainConfig{
+		FeeConfig: DefaultFeeConfig,
+		OptionalNetworkUpgrades: OptionalNetworkUpgrades{
+			StromboliTimestamp: utils.NewUint64(500),
+		},
+		MaxOperatorDataSize:  32,
+		OperatorDataPrefixes: []hexutil.Bytes{{0x01}},
+	}
+	require.NoError(c.Verify())
+
+	// Operator data is only allowed once Stromboli is activated
+	require.Zero(c.Rules(big.NewInt(0), 499).MaxOperatorDataSize)
+	rules := c.Rules(big.NewInt(0), 500)
+	require.Equal(32, rules.MaxOperatorDataSize)
+	require.NoError(rules.VerifyOperatorData([]byte{0x01, 0x02}))
+
+	// Changing the policy after Stromboli is incompatible
+	newcfg := c
+	newcfg.OperatorDataPrefixes = []hexutil.Bytes{{0x02}}
+	require.Nil(c.CheckCompatible(&newcfg, 0, 499))
+	require.NotNil(c.CheckCompatible(&newcfg, 0, 500))
+
+	invalid := c
+	invalid.OperatorDataPrefixes = []hexutil.Bytes{{}}
+	require.ErrorIs(invalid.Verify(), errEmptyOperatorDataPrefix)
+	invalid.OperatorDataPrefixes = []hexutil.Bytes{bytes.Repeat([]byte{0x01}, 33)}
+	require.ErrorContains(invalid.Verify(), "operatorDataPrefixes")
+	invalid.MaxOperatorDataSize = MaxConfigurableOperatorDataSize + 1
+	require.ErrorContains(invalid.Verify(), "maxOperatorDataSize")
+	invalid.MaxOperatorDataSize = 0
+	require.ErrorContains(invalid.Verify(), "maxOperatorDataSize")
+}
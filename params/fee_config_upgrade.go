@@ -0,0 +1,114 @@
+// (c) 2024 Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+// FeeConfigUpgrade replaces the fee config of the chain from [BlockTimestamp] onwards,
+// without requiring the FeeManager precompile.
+// While the FeeManager precompile is enabled, the fee config stored in its state
+// takes precedence over the fee config upgrades.
+type FeeConfigUpgrade struct {
+	BlockTimestamp *uint64              `json:"blockTimestamp,omitempty"`
+	FeeConfig      commontype.FeeConfig `json:"feeConfig"`
+}
+
+func (f *FeeConfigUpgrade) Equal(other *FeeConfigUpgrade) bool {
+	return reflect.DeepEqual(f, other)
+}
+
+// verifyFeeConfigUpgrades checks [c.FeeConfigUpgrades] is well formed:
+// - the specified blockTimestamps must monotonically increase
+// - each fee config must be valid
+func (c *ChainConfig) verifyFeeConfigUpgrades() error {
+	var previousUpgradeTimestamp *uint64
+	for i, upgrade := range c.FeeConfigUpgrades {
+		upgradeTimestamp := upgrade.BlockTimestamp
+		if upgradeTimestamp == nil {
+			return fmt.Errorf("FeeConfigUpgrade[%d]: config block timestamp cannot be nil ", i)
+		}
+		// Verify the upgrade's timestamp is equal 0 (to avoid confusion with genesis).
+		if *upgradeTimestamp == 0 {
+			return fmt.Errorf("FeeConfigUpgrade[%d]: config block timestamp (%v) must be greater than 0", i, *upgradeTimestamp)
+		}
+
+		// Verify specified timestamps are strictly monotonically increasing.
+		if previousUpgradeTimestamp != nil && *upgradeTimestamp <= *previousUpgradeTimestamp {
+			return fmt.Errorf("FeeConfigUpgrade[%d]: config block timestamp (%v) <= previous timestamp (%v)", i, *upgradeTimestamp, *previousUpgradeTimestamp)
+		}
+		previousUpgradeTimestamp = upgradeTimestamp
+
+		if err := upgrade.FeeConfig.Verify(); err != nil {
+			return fmt.Errorf("FeeConfigUpgrade[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetFeeConfigAtTimestamp returns the fee config of the latest fee config upgrade
+// activated at [time], or the genesis fee config if none is activated.
+// It does not take the FeeManager precompile into account.
+func (c *ChainConfig) GetFeeConfigAtTimestamp(time uint64) commontype.FeeConfig {
+	feeConfig := c.FeeConfig
+	for _, upgrade := range c.FeeConfigUpgrades {
+		if !utils.IsTimestampForked(upgrade.BlockTimestamp, time) {
+			break
+		}
+		feeConfig = upgrade.FeeConfig
+	}
+	return feeConfig
+}
+
+// getActivatedFeeConfigUpgrades returns the fee config upgrades of [upgrades] activated at [time].
+func getActivatedFeeConfigUpgrades(upgrades []FeeConfigUpgrade, time uint64) []FeeConfigUpgrade {
+	activated := make([]FeeConfigUpgrade, 0)
+	for _, upgrade := range upgrades {
+		if utils.IsTimestampForked(upgrade.BlockTimestamp, time) {
+			activated = append(activated, upgrade)
+		}
+	}
+	return activated
+}
+
+// CheckFeeConfigUpgradesCompatible checks if [feeConfigUpgrades] are compatible with [c] at [lastTimestamp].
+func (c *ChainConfig) CheckFeeConfigUpgradesCompatible(feeConfigUpgrades []FeeConfigUpgrade, lastTimestamp uint64) *ConfigCompatError {
+	activeUpgrades := getActivatedFeeConfigUpgrades(c.FeeConfigUpgrades, lastTimestamp)
+	newUpgrades := getActivatedFeeConfigUpgrades(feeConfigUpgrades, lastTimestamp)
+
+	// Check activated upgrades are still present.
+	for i, upgrade := range activeUpgrades {
+		if len(newUpgrades) <= i {
+			// missing upgrade
+			return newTimestampCompatError(
+				fmt.Sprintf("missing FeeConfigUpgrade[%d]", i),
+				upgrade.BlockTimestamp,
+				nil,
+			)
+		}
+		// All upgrades that have activated must be identical.
+		if !upgrade.Equal(&newUpgrades[i]) {
+			return newTimestampCompatError(
+				fmt.Sprintf("FeeConfigUpgrade[%d]", i),
+				upgrade.BlockTimestamp,
+				newUpgrades[i].BlockTimestamp,
+			)
+		}
+	}
+	// then, make sure newUpgrades does not have additional upgrades
+	// that are already activated. (cannot perform retroactive upgrade)
+	if len(newUpgrades) > len(activeUpgrades) {
+		return newTimestampCompatError(
+			fmt.Sprintf("cannot retroactively enable FeeConfigUpgrade[%d]", len(activeUpgrades)),
+			nil,
+			newUpgrades[len(activeUpgrades)].BlockTimestamp,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyFeeConfigUpgrades(t *testing.T) {
+	feeConfig := commontype.ValidTestFeeConfig
+	invalidFeeConfig := commontype.ValidTestFeeConfig
+	invalidFeeConfig.MinBaseFee = big.NewInt(-1)
+	tests := []struct {
+		name          string
+		upgrades      []FeeConfigUpgrade
+		expectedError string
+	}{
+		{
+			name: "valid upgrade",
+			upgrades: []FeeConfigUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), FeeConfig: feeConfig},
+				{BlockTimestamp: utils.NewUint64(2), FeeConfig: feeConfig},
+			},
+		},
+		{
+			name: "upgrade block timestamp is not strictly increasing",
+			upgrades: []FeeConfigUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), FeeConfig: feeConfig},
+				{BlockTimestamp: utils.NewUint64(1), FeeConfig: feeConfig},
+			},
+			expectedError: "config block timestamp (1) <= previous timestamp (1)",
+		},
+		{
+			name: "upgrade block timestamp is zero",
+			upgrades: []FeeConfigUpgrade{
+				{BlockTimestamp: utils.NewUint64(0), FeeConfig: feeConfig},
+			},
+			expectedError: "config block timestamp (0) must be greater than 0",
+		},
+		{
+			name: "invalid fee config",
+			upgrades: []FeeConfigUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), FeeConfig: invalidFeeConfig},
+			},
+			expectedError: "minBaseFee = -1 cannot be less than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			baseConfig := *TestChainConfig
+			config := &baseConfig
+			config.FeeConfigUpgrades = tt.upgrades
+
+			err := config.Verify()
+			if tt.expectedError == "" {
+				require.NoError(err)
+			} else {
+				require.ErrorContains(err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestGetFeeConfigAtTimestamp(t *testing.T) {
+	require := require.New(t)
+	upgraded := commontype.ValidTestFeeConfig
+	upgraded.MinBaseFee = big.NewInt(1)
+
+	baseConfig := *TestChainConfig
+	config := &baseConfig
+	config.FeeConfigUpgrades = []FeeConfigUpgrade{
+		{BlockTimestamp: utils.NewUint64(10), FeeConfig: upgraded},
+	}
+	require.Equal(config.FeeConfig, config.GetFeeConfigAtTimestamp(9))
+	require.Equal(upgraded, config.GetFeeConfigAtTimestamp(10))
+	require.Equal(upgraded, config.GetFeeConfigAtTimestamp(11))
+}
+
+func TestCheckCompatibleFeeConfigUpgrades(t *testing.T) {
+	chainConfig := *TestChainConfig
+	upgraded := commontype.ValidTestFeeConfig
+	upgraded.MinBaseFee = big.NewInt(1)
+	upgradeJSON := `{"feeConfigUpgrades":[{"blockTimestamp":5,"feeConfig":{"gasLimit":8000000,"targetBlockRate":2,"minBaseFee":1,"targetGas":15000000,"baseFeeChangeDenominator":36,"minBlockGasCost":0,"maxBlockGasCost":1000000,"blockGasCostStep":200000}}]}`
+
+	var upgradeConfig UpgradeConfig
+	require.NoError(t, json.Unmarshal([]byte(upgradeJSON), &upgradeConfig))
+	require.Equal(t, []FeeConfigUpgrade{{BlockTimestamp: utils.NewUint64(5), FeeConfig: upgraded}}, upgradeConfig.FeeConfigUpgrades)
+
+	chainConfig.UpgradeConfig = upgradeConfig
+	require.NoError(t, chainConfig.Verify())
+
+	// Rescheduling an upgrade that is not yet activated is compatible.
+	rescheduled := []FeeConfigUpgrade{{BlockTimestamp: utils.NewUint64(6), FeeConfig: upgraded}}
+	require.Nil(t, chainConfig.CheckFeeConfigUpgradesCompatible(rescheduled, 4))
+
+	// Changing an activated upgrade is not compatible.
+	require.NotNil(t, chainConfig.CheckFeeConfigUpgradesCompatible(rescheduled, 5))
+	// Removing an activated upgrade is not compatible.
+	require.NotNil(t, chainConfig.CheckFeeConfigUpgradesCompatible(nil, 5))
+	// Retroactively adding an upgrade is not compatible.
+	require.NotNil(t, (&ChainConfig{}).CheckFeeConfigUpgradesCompatible(upgradeConfig.FeeConfigUpgrades, 5))
+}
@@ -0,0 +1,90 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultFeeSponsorshipCheckGas is the gas available to the sponsorship check
+	// of the paymaster when the chain does not configure it.
+	DefaultFeeSponsorshipCheckGas = 50_000
+	// MaxFeeSponsorshipCheckGas is the largest gas a chain can make available to
+	// the sponsorship check. The check is executed for every transaction, before
+	// knowing whether the sender can pay for it, so it must remain cheap.
+	MaxFeeSponsorshipCheckGas = 200_000
+)
+
+// FeeSponsorshipCheckCaller is the caller of the sponsorship checks of the
+// paymaster, so that it can tell them apart from the calls of transactions.
+var FeeSponsorshipCheckCaller = common.HexToAddress("0x0000000000000000000000000000000000000fee")
+
+// FeeSponsorship configures a paymaster contract paying the gas of qualifying
+// transactions on behalf of their senders.
+//
+// Before buying the gas of a transaction, the paymaster is called by
+// [FeeSponsorshipCheckCaller] with
+// isSponsored(address sender, address to, uint256 value, uint256 gasLimit, bytes data)
+// as a static call limited to [CheckGas], and to the gas left by the transaction
+// after its intrinsic gas. If it returns true, the gas of the transaction is
+// bought from and refunded to the paymaster, while the sender still pays the
+// value of the transaction. Either way, the gas used by the check is charged as
+// part of the gas used by the transaction, to the account buying its gas.
+type FeeSponsorship struct {
+	BlockTimestamp *uint64        `json:"blockTimestamp"`     // Activation timestamp of the sponsorship.
+	Paymaster      common.Address `json:"paymaster"`          // Contract consulted to sponsor transactions, which pays their gas.
+	CheckGas       uint64         `json:"checkGas,omitempty"` // Gas available to the sponsorship check (0 = DefaultFeeSponsorshipCheckGas).
+}
+
+// GetCheckGas returns the gas available to the sponsorship check.
+func (s *FeeSponsorship) GetCheckGas() uint64 {
+	if s.CheckGas == 0 {
+		return DefaultFeeSponsorshipCheckGas
+	}
+	return s.CheckGas
+}
+
+// Verify returns an error if [s] is not a valid fee sponsorship config.
+func (s *FeeSponsorship) Verify() error {
+	if s.BlockTimestamp == nil {
+		return errors.New("fee sponsorship must have an activation timestamp")
+	}
+	if s.Paymaster == (common.Address{}) {
+		return errors.New("fee sponsorship must have a paymaster")
+	}
+	if s.CheckGas > MaxFeeSponsorshipCheckGas {
+		return fmt.Errorf("fee sponsorship check gas %d exceeds limit %d", s.CheckGas, MaxFeeSponsorshipCheckGas)
+	}
+	return nil
+}
+
+// IsFeeSponsorship returns whether the fee sponsorship of [c] is active at [time].
+func (c *ChainConfig) IsFeeSponsorship(time uint64) bool {
+	return c.FeeSponsorship != nil && utils.IsTimestampForked(c.FeeSponsorship.BlockTimestamp, time)
+}
+
+// checkFeeSponsorshipCompatible returns an error if the fee sponsorship of
+// [newcfg] differs from the one of [c] while either is active at [time].
+func (c *ChainConfig) checkFeeSponsorshipCompatible(newcfg *ChainConfig, time uint64) *ConfigCompatError {
+	if !c.IsFeeSponsorship(time) && !newcfg.IsFeeSponsorship(time) {
+		return nil
+	}
+	if reflect.DeepEqual(c.FeeSponsorship, newcfg.FeeSponsorship) {
+		return nil
+	}
+	var storedTime, newTime *uint64
+	if c.FeeSponsorship != nil {
+		storedTime = c.FeeSponsorship.BlockTimestamp
+	}
+	if newcfg.FeeSponsorship != nil {
+		newTime = newcfg.FeeSponsorship.BlockTimestamp
+	}
+	return newTimestampCompatError("FeeSponsorship", storedTime, newTime)
+}
@@ -0,0 +1,49 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import "fmt"
+
+const (
+	// DefaultNativeCurrencyDecimals is the number of decimals of the native
+	// currency when the chain does not configure one.
+	DefaultNativeCurrencyDecimals = 18
+	// MaxNativeCurrencyDecimals is the largest number of decimals that keeps one
+	// unit of the native currency within a uint256.
+	MaxNativeCurrencyDecimals = 77
+	// maxNativeCurrencyNameLen is the maximum length of the name and symbol of the native currency.
+	maxNativeCurrencyNameLen = 64
+)
+
+// NativeCurrency describes how the native token of the chain is displayed to users.
+// It is informational only: balances and fees are always accounted for in the
+// smallest unit of the native token.
+type NativeCurrency struct {
+	Name     string `json:"name,omitempty"`
+	Symbol   string `json:"symbol,omitempty"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// Verify returns an error if [n] is not a valid native currency description.
+func (n *NativeCurrency) Verify() error {
+	if n.Decimals > MaxNativeCurrencyDecimals {
+		return fmt.Errorf("native currency decimals %d exceeds limit %d", n.Decimals, MaxNativeCurrencyDecimals)
+	}
+	if len(n.Name) > maxNativeCurrencyNameLen {
+		return fmt.Errorf("native currency name length %d exceeds limit %d", len(n.Name), maxNativeCurrencyNameLen)
+	}
+	if len(n.Symbol) > maxNativeCurrencyNameLen {
+		return fmt.Errorf("native currency symbol length %d exceeds limit %d", len(n.Symbol), maxNativeCurrencyNameLen)
+	}
+	return nil
+}
+
+// GetNativeCurrency returns the native currency configured by the chain, or an
+// unnamed currency with [DefaultNativeCurrencyDecimals] decimals if there is none.
+func (c *ChainConfig) GetNativeCurrency() NativeCurrency {
+	if c.NativeCurrency == nil {
+		return NativeCurrency{Decimals: DefaultNativeCurrencyDecimals}
+	}
+	return *c.NativeCurrency
+}
@@ -54,13 +54,29 @@ func GetMandatoryNetworkUpgrades(networkID uint32) MandatoryNetworkUpgrades {
 // OptionalNetworkUpgrades includes overridable and optional Subnet-EVM network upgrades.
 // These can be specified in genesis and upgrade configs.
 // Timestamps can be different for each subnet network.
-// TODO: once we add the first optional upgrade here, we should uncomment TestVMUpgradeBytesOptionalNetworkUpgrades
-type OptionalNetworkUpgrades struct{}
+type OptionalNetworkUpgrades struct {
+	// Stromboli activates the stateful precompile functions and consensus rules added
+	// after Durango, such as the allow list batch functions and the reward splits
+	// of the reward manager. (nil = no fork, 0 = already activated)
+	StromboliTimestamp *uint64 `json:"stromboliTimestamp,omitempty"`
+	// Vesuvius activates EIP-7702 set code transactions, allowing externally
+	// owned accounts to delegate to contract code. (nil = no fork, 0 = already activated)
+	VesuviusTimestamp *uint64 `json:"vesuviusTimestamp,omitempty"`
+}
 
 func (n *OptionalNetworkUpgrades) CheckOptionalCompatible(newcfg *OptionalNetworkUpgrades, time uint64) *ConfigCompatError {
+	if isForkTimestampIncompatible(n.StromboliTimestamp, newcfg.StromboliTimestamp, time) {
+		return newTimestampCompatError("Stromboli fork block timestamp", n.StromboliTimestamp, newcfg.StromboliTimestamp)
+	}
+	if isForkTimestampIncompatible(n.VesuviusTimestamp, newcfg.VesuviusTimestamp, time) {
+		return newTimestampCompatError("Vesuvius fork block timestamp", n.VesuviusTimestamp, newcfg.VesuviusTimestamp)
+	}
 	return nil
 }
 
 func (n *OptionalNetworkUpgrades) optionalForkOrder() []fork {
-	return []fork{}
+	return []fork{
+		{name: "stromboliTimestamp", timestamp: n.StromboliTimestamp},
+		{name: "vesuviusTimestamp", timestamp: n.VesuviusTimestamp},
+	}
 }
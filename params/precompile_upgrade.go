@@ -186,6 +186,40 @@ func (c *ChainConfig) GetActivatingPrecompileConfigs(address common.Address, fro
 	return configs
 }
 
+// WithScheduledPrecompileUpgrades returns a copy of [c] with [upgrades], sorted by timestamp, merged into
+// its precompile upgrades. Upgrades of [c] activating at the same timestamp as one of [upgrades] are
+// ordered after it so that they take precedence. If [c] was itself returned by WithScheduledPrecompileUpgrades,
+// [upgrades] replace the upgrades merged into it.
+// The returned config only determines the precompiles enabled at a block, it is neither verified nor stored.
+func (c *ChainConfig) WithScheduledPrecompileUpgrades(upgrades []PrecompileUpgrade) *ChainConfig {
+	unscheduled := c.WithoutScheduledPrecompileUpgrades()
+	merged := *unscheduled
+	merged.unscheduled = unscheduled
+	merged.PrecompileUpgrades = make([]PrecompileUpgrade, 0, len(unscheduled.PrecompileUpgrades)+len(upgrades))
+	for _, upgrade := range unscheduled.PrecompileUpgrades {
+		for ; len(upgrades) > 0 && *upgrades[0].Timestamp() <= *upgrade.Timestamp(); upgrades = upgrades[1:] {
+			merged.PrecompileUpgrades = append(merged.PrecompileUpgrades, upgrades[0])
+		}
+		merged.PrecompileUpgrades = append(merged.PrecompileUpgrades, upgrade)
+	}
+	merged.PrecompileUpgrades = append(merged.PrecompileUpgrades, upgrades...)
+	return &merged
+}
+
+// WithoutScheduledPrecompileUpgrades returns the config the scheduled precompile upgrades were
+// merged into by WithScheduledPrecompileUpgrades, or [c] if none were.
+func (c *ChainConfig) WithoutScheduledPrecompileUpgrades() *ChainConfig {
+	if c.unscheduled != nil {
+		return c.unscheduled
+	}
+	return c
+}
+
+// HasScheduledPrecompileUpgrades returns true if [c] was returned by WithScheduledPrecompileUpgrades.
+func (c *ChainConfig) HasScheduledPrecompileUpgrades() bool {
+	return c.unscheduled != nil
+}
+
 // CheckPrecompilesCompatible checks if [precompileUpgrades] are compatible with [c] at [headTimestamp].
 // Returns a ConfigCompatError if upgrades already activated at [headTimestamp] are missing from
 // [precompileUpgrades]. Upgrades not already activated may be modified or absent from [precompileUpgrades].
@@ -298,3 +298,31 @@ func (tt *upgradeCompatibilityTest) run(t *testing.T, chainConfig ChainConfig) {
 		}
 	}
 }
+
+func TestWithScheduledPrecompileUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	chainConfig := *TestChainConfig
+	var (
+		enableTxAllowList  = PrecompileUpgrade{Config: txallowlist.NewConfig(utils.NewUint64(10), nil, nil, nil)}
+		disableTxAllowList = PrecompileUpgrade{Config: txallowlist.NewDisableConfig(utils.NewUint64(20))}
+		enableDeployer     = PrecompileUpgrade{Config: deployerallowlist.NewConfig(utils.NewUint64(10), nil, nil, nil)}
+		disableDeployer    = PrecompileUpgrade{Config: deployerallowlist.NewDisableConfig(utils.NewUint64(30))}
+	)
+	chainConfig.PrecompileUpgrades = []PrecompileUpgrade{enableTxAllowList, disableTxAllowList}
+
+	// Scheduled upgrades are ordered by timestamp, before the upgrades of the chain config
+	// activating at the same timestamp.
+	merged := chainConfig.WithScheduledPrecompileUpgrades([]PrecompileUpgrade{enableDeployer, disableDeployer})
+	require.Equal([]PrecompileUpgrade{enableDeployer, enableTxAllowList, disableTxAllowList, disableDeployer}, merged.PrecompileUpgrades)
+	require.True(merged.HasScheduledPrecompileUpgrades())
+	require.Same(&chainConfig, merged.WithoutScheduledPrecompileUpgrades())
+	require.Len(chainConfig.PrecompileUpgrades, 2)
+	require.True(merged.IsPrecompileEnabled(deployerallowlist.ContractAddress, 20))
+	require.False(merged.IsPrecompileEnabled(deployerallowlist.ContractAddress, 30))
+
+	// Merging into a merged config replaces the scheduled upgrades.
+	remerged := merged.WithScheduledPrecompileUpgrades([]PrecompileUpgrade{enableDeployer})
+	require.Equal([]PrecompileUpgrade{enableDeployer, enableTxAllowList, disableTxAllowList}, remerged.PrecompileUpgrades)
+	require.Same(&chainConfig, remerged.WithoutScheduledPrecompileUpgrades())
+}
@@ -5,9 +5,13 @@ package params
 
 import (
 	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
 
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type Precompiles map[string]precompileconfig.Config
@@ -34,3 +38,29 @@ func (ccp *Precompiles) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// PrecompileAddresses maps precompile module keys to the address the chain
+// configures for them instead of their compile-time address.
+type PrecompileAddresses map[string]common.Address
+
+// ApplyPrecompileAddresses moves the precompile modules listed in [c.PrecompileAddresses]
+// to their configured addresses. The modules must allow their address to be configured.
+// This must be called before [c] is verified or used.
+func (c *ChainConfig) ApplyPrecompileAddresses() error {
+	keys := make([]string, 0, len(c.PrecompileAddresses))
+	for key := range c.PrecompileAddresses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := modules.SetModuleAddress(key, c.PrecompileAddresses[key]); err != nil {
+			return fmt.Errorf("failed to configure the address of precompile %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Equal returns true if [p] and [other] configure the same addresses.
+func (p PrecompileAddresses) Equal(other PrecompileAddresses) bool {
+	return maps.Equal(p, other)
+}
@@ -0,0 +1,69 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultSystemTxLanePoolSlots is the number of tx pool slots of the system lane
+// when the chain does not configure one.
+const DefaultSystemTxLanePoolSlots = 1024
+
+// SystemTxLane configures a lane of the tx pool and of block building reserved to
+// allow-listed senders, such as oracles and bridges.
+// Transactions of the system lane are not evicted by the transactions of the public
+// lane, and are included in blocks before them within [ReservedGas], so they are
+// included even while the public lane is saturated. Those left out of [ReservedGas],
+// such as transactions needing more gas, compete with the public lane for the rest
+// of the block.
+// It is a block building policy only: blocks built by other nodes are not verified
+// against it.
+type SystemTxLane struct {
+	Senders     []common.Address `json:"senders"`
+	ReservedGas uint64           `json:"reservedGas"`         // Gas of each block available to the system lane before the public lane.
+	PoolSlots   uint64           `json:"poolSlots,omitempty"` // Maximum number of tx pool slots of the system lane (0 = DefaultSystemTxLanePoolSlots).
+}
+
+// GetPoolSlots returns the maximum number of tx pool slots of the system lane.
+func (s *SystemTxLane) GetPoolSlots() uint64 {
+	if s.PoolSlots == 0 {
+		return DefaultSystemTxLanePoolSlots
+	}
+	return s.PoolSlots
+}
+
+// Verify returns an error if [s] is not a valid system lane config.
+func (s *SystemTxLane) Verify() error {
+	if len(s.Senders) == 0 {
+		return errors.New("system tx lane must have at least one sender")
+	}
+	if s.ReservedGas < TxGas {
+		return fmt.Errorf("system tx lane reserved gas %d is below the minimum transaction gas %d", s.ReservedGas, TxGas)
+	}
+	seen := make(map[common.Address]struct{}, len(s.Senders))
+	for _, sender := range s.Senders {
+		if _, ok := seen[sender]; ok {
+			return fmt.Errorf("duplicate system tx lane sender %s", sender)
+		}
+		seen[sender] = struct{}{}
+	}
+	return nil
+}
+
+// IsSystemSender returns whether [addr] is a sender of the system lane of [c].
+func (c *ChainConfig) IsSystemSender(addr common.Address) bool {
+	if c.SystemTxLane == nil {
+		return false
+	}
+	for _, sender := range c.SystemTxLane.Senders {
+		if sender == addr {
+			return true
+		}
+	}
+	return false
+}
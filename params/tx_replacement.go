@@ -0,0 +1,35 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import "fmt"
+
+// MaxTxReplacementPriceBump is the largest price bump percentage a chain can
+// require to replace a transaction of the tx pool.
+const MaxTxReplacementPriceBump = 1000
+
+// TxReplacementPolicy configures how the transactions of the tx pool can be
+// replaced by transactions with the same sender and nonce.
+// It is a tx pool policy only: it does not affect the validity of blocks.
+type TxReplacementPolicy struct {
+	PriceBump       uint64 `json:"priceBump,omitempty"`       // Minimum price bump percentage to replace a transaction (0 = tx pool config).
+	MaxReplacements uint64 `json:"maxReplacements,omitempty"` // Maximum number of times the transaction of a nonce can be replaced (0 = unlimited).
+}
+
+// Verify returns an error if [p] is not a valid replacement policy.
+func (p *TxReplacementPolicy) Verify() error {
+	if p.PriceBump > MaxTxReplacementPriceBump {
+		return fmt.Errorf("tx replacement price bump %d exceeds limit %d", p.PriceBump, MaxTxReplacementPriceBump)
+	}
+	return nil
+}
+
+// GetTxReplacementPolicy returns the tx replacement policy of [c], which is
+// empty if the chain does not configure one.
+func (c *ChainConfig) GetTxReplacementPolicy() TxReplacementPolicy {
+	if c.TxReplacementPolicy == nil {
+		return TxReplacementPolicy{}
+	}
+	return *c.TxReplacementPolicy
+}
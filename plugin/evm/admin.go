@@ -4,14 +4,27 @@
 package evm
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state/pruner"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// errAPIKeysRequired is returned by the admin calls that must be authenticated,
+// when no API keys are configured to authenticate them.
+var errAPIKeysRequired = errors.New("this call requires API keys to be configured")
+
 // Admin is the API service for admin API calls
 type Admin struct {
 	vm       *VM
@@ -81,6 +94,29 @@ func (p *Admin) SetLogLevel(_ *http.Request, args *SetLogLevelArgs, reply *api.E
 	return nil
 }
 
+type SetModuleLogLevelsArgs struct {
+	Levels map[string]string `json:"levels"`
+}
+
+// SetModuleLogLevels raises the log level of the packages matching the patterns
+// of [args.Levels], e.g. {"core/txpool/*": "debug", "plugin/evm/*": "trace"}.
+// Each call replaces the levels set by the previous one.
+func (p *Admin) SetModuleLogLevels(r *http.Request, args *SetModuleLogLevelsArgs, _ *api.EmptyReply) error {
+	log.Info("EVM: SetModuleLogLevels called", "levels", args.Levels)
+
+	if err := p.authorize(r, "setModuleLogLevels"); err != nil {
+		return err
+	}
+
+	p.vm.ctx.Lock.Lock()
+	defer p.vm.ctx.Lock.Unlock()
+
+	if err := p.vm.logger.SetModuleLogLevels(args.Levels); err != nil {
+		return fmt.Errorf("failed to set module log levels: %w", err)
+	}
+	return nil
+}
+
 type ConfigReply struct {
 	Config *Config `json:"config"`
 }
@@ -89,3 +125,272 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *ConfigReply) er
 	reply.Config = &p.vm.config
 	return nil
 }
+
+type ChainConfigReply struct {
+	Config *params.ChainConfigWithUpgradesJSON `json:"config"`
+	// Timestamp is the timestamp of the last accepted block, at which
+	// [ActivePrecompiles] are resolved.
+	Timestamp         uint64             `json:"timestamp"`
+	ActivePrecompiles params.Precompiles `json:"activePrecompiles"`
+}
+
+// GetChainConfig returns the chain config along with its upgrades, and the
+// precompile configs active as of the last accepted block, including those
+// enabled by the upgrades scheduled by the governance precompile.
+func (p *Admin) GetChainConfig(r *http.Request, _ *struct{}, reply *ChainConfigReply) error {
+	if err := p.authorize(r, "getChainConfig"); err != nil {
+		return err
+	}
+	lastAccepted := p.vm.blockChain.LastAcceptedBlock().Header()
+	config, err := p.vm.blockChain.ConfigAt(lastAccepted)
+	if err != nil {
+		return err
+	}
+	reply.Config = p.vm.chainConfig.ToWithUpgradesJSON()
+	reply.Timestamp = lastAccepted.Time
+	reply.ActivePrecompiles = config.EnabledStatefulPrecompiles(lastAccepted.Time)
+	return nil
+}
+
+type ChainConfigHashReply struct {
+	// Hash is the hash of the chain config including all its upgrades, which
+	// must be the same on all the nodes of the chain.
+	Hash common.Hash `json:"hash"`
+	// ActiveHash is the hash of the chain config without the upgrades
+	// scheduled after [Timestamp], the timestamp of the last accepted block.
+	ActiveHash common.Hash `json:"activeHash"`
+	Timestamp  uint64      `json:"timestamp"`
+	// Version is the version of the VM running the chain.
+	Version string `json:"version"`
+}
+
+// GetChainConfigHash returns the hashes of the canonical encoding of the chain
+// config, to compare the upgrades loaded by different nodes.
+func (p *Admin) GetChainConfigHash(_ *http.Request, _ *struct{}, reply *ChainConfigHashReply) error {
+	hashes, err := p.vm.chainConfigHasher.hashes()
+	if err != nil {
+		return fmt.Errorf("failed to hash chain config: %w", err)
+	}
+	reply.Hash = hashes.Hash
+	reply.ActiveHash = hashes.ActiveHash
+	reply.Timestamp = hashes.Timestamp
+	reply.Version = Version
+	return nil
+}
+
+type ValidateUpgradesArgs struct {
+	// Upgrades are the upgrade bytes to validate, as in the upgrade.json file
+	// of the chain.
+	Upgrades json.RawMessage `json:"upgrades"`
+}
+
+// ValidateUpgrades verifies the upgrade bytes of [args] against the chain
+// config of the node, and checks they can be applied on top of the last
+// accepted block, without applying them. The reply lists the changes made at
+// each activation.
+func (p *Admin) ValidateUpgrades(r *http.Request, args *ValidateUpgradesArgs, reply *UpgradesReport) error {
+	log.Info("Admin: ValidateUpgrades called")
+
+	if err := p.authorize(r, "validateUpgrades"); err != nil {
+		return err
+	}
+
+	upgraded, err := applyUpgradeBytes(p.vm.chainConfig, args.Upgrades)
+	if err != nil {
+		return fmt.Errorf("invalid upgrades: %w", err)
+	}
+	head := p.vm.blockChain.LastAcceptedBlock()
+	if compatErr := p.vm.chainConfig.CheckCompatible(upgraded, head.NumberU64(), head.Time()); compatErr != nil {
+		return fmt.Errorf("upgrades are incompatible with the last accepted block: %w", compatErr)
+	}
+	*reply = *reportUpgrades(upgraded, head.Time())
+	return nil
+}
+
+// authorize rejects the call of the admin [method] unless [r] carries an API key
+// allowed to call "admin_[method]". The call is always rejected if no API keys
+// are configured.
+func (p *Admin) authorize(r *http.Request, method string) error {
+	if p.vm.authenticator == nil {
+		return errAPIKeysRequired
+	}
+	var apiKey string
+	if r != nil {
+		apiKey = rpc.APIKeyFromHeader(r.Header)
+	}
+	if err := p.vm.authenticator.authorizeKey(apiKey, "admin_"+method); err != nil {
+		return err
+	}
+	return nil
+}
+
+type HaltBlockProductionArgs struct {
+	Reason string `json:"reason"`
+}
+
+// HaltBlockProduction stops this node from building blocks. The node keeps verifying
+// and accepting the blocks built by other nodes.
+// The halt persists across restarts until ResumeBlockProduction is called.
+func (p *Admin) HaltBlockProduction(r *http.Request, args *HaltBlockProductionArgs, _ *api.EmptyReply) error {
+	if err := p.authorize(r, "haltBlockProduction"); err != nil {
+		return err
+	}
+	log.Warn("Admin: HaltBlockProduction called", "reason", args.Reason)
+
+	return p.vm.circuitBreaker.halt(args.Reason)
+}
+
+// ResumeBlockProduction lets this node build blocks again.
+func (p *Admin) ResumeBlockProduction(r *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	if err := p.authorize(r, "resumeBlockProduction"); err != nil {
+		return err
+	}
+	log.Warn("Admin: ResumeBlockProduction called")
+
+	if err := p.vm.circuitBreaker.resume(); err != nil {
+		return err
+	}
+	if p.vm.builder != nil {
+		p.vm.builder.signalTxsReady()
+	}
+	return nil
+}
+
+type TripCircuitBreakerArgs struct {
+	Allowlist []common.Address `json:"allowlist"`
+	Reason    string           `json:"reason"`
+}
+
+// TripCircuitBreaker makes this node reject the transactions of all the senders but
+// [args.Allowlist], both when they are submitted and when building blocks. The
+// circuit breaker stays tripped across restarts until ResetCircuitBreaker is called.
+func (p *Admin) TripCircuitBreaker(r *http.Request, args *TripCircuitBreakerArgs, _ *api.EmptyReply) error {
+	if err := p.authorize(r, "tripCircuitBreaker"); err != nil {
+		return err
+	}
+	log.Warn("Admin: TripCircuitBreaker called", "reason", args.Reason, "allowlist", args.Allowlist)
+
+	return p.vm.circuitBreaker.trip(args.Allowlist, args.Reason)
+}
+
+// ResetCircuitBreaker makes this node accept the transactions of all the senders again.
+func (p *Admin) ResetCircuitBreaker(r *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	if err := p.authorize(r, "resetCircuitBreaker"); err != nil {
+		return err
+	}
+	log.Warn("Admin: ResetCircuitBreaker called")
+
+	return p.vm.circuitBreaker.reset()
+}
+
+type CircuitBreakerStatusReply struct {
+	Status CircuitBreakerStatus `json:"status"`
+}
+
+func (p *Admin) GetCircuitBreakerStatus(_ *http.Request, _ *struct{}, reply *CircuitBreakerStatusReply) error {
+	reply.Status = p.vm.circuitBreaker.status()
+	return nil
+}
+
+// ScheduleStatePruning requests the node to prune the state not reachable from
+// the last accepted block on its next start, as if offline pruning was enabled
+// for a single start.
+func (p *Admin) ScheduleStatePruning(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	log.Info("Admin: ScheduleStatePruning called")
+
+	if !p.vm.config.Pruning {
+		return errors.New("cannot prune state while pruning is disabled")
+	}
+	if len(p.vm.config.OfflinePruningDataDirectory) == 0 {
+		return errors.New("cannot prune state without an offline pruning data directory")
+	}
+	return rawdb.WriteOfflinePruningRequest(p.vm.chaindb)
+}
+
+// CancelStatePruning cancels the request made by ScheduleStatePruning. It does
+// not affect offline pruning enabled in the config, nor an interrupted run of
+// offline pruning, which is always resumed.
+func (p *Admin) CancelStatePruning(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	log.Info("Admin: CancelStatePruning called")
+
+	return rawdb.DeleteOfflinePruningRequest(p.vm.chaindb)
+}
+
+type StatePruningStatusReply struct {
+	// ScheduledAt is the time of the pending ScheduleStatePruning request, if any.
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+	// LastRun is the time the last run of offline pruning completed, if the node
+	// has not been restarted with offline pruning disabled since.
+	LastRun *time.Time `json:"lastRun,omitempty"`
+	// InterruptedRoot is the state root targeted by a run of offline pruning
+	// that was interrupted and will be resumed on the next start, if any.
+	InterruptedRoot *common.Hash `json:"interruptedRoot,omitempty"`
+	// Progress is the fraction of the database the interrupted run went over.
+	Progress float64 `json:"progress"`
+}
+
+// GetStatePruningStatus reports the pending, last and interrupted runs of
+// offline pruning.
+func (p *Admin) GetStatePruningStatus(_ *http.Request, _ *struct{}, reply *StatePruningStatusReply) error {
+	if scheduledAt, err := rawdb.ReadOfflinePruningRequest(p.vm.chaindb); err == nil {
+		reply.ScheduledAt = &scheduledAt
+	}
+	if lastRun, err := rawdb.ReadOfflinePruning(p.vm.chaindb); err == nil {
+		reply.LastRun = &lastRun
+	}
+	if len(p.vm.config.OfflinePruningDataDirectory) == 0 {
+		return nil
+	}
+	root, progress, err := pruner.InterruptedPruning(p.vm.config.OfflinePruningDataDirectory, p.vm.chaindb)
+	if err != nil {
+		return fmt.Errorf("failed to look up interrupted pruning: %w", err)
+	}
+	if root != (common.Hash{}) {
+		reply.InterruptedRoot = &root
+		reply.Progress = progress
+	}
+	return nil
+}
+
+type TxIndexStatusReply struct {
+	// Retention is "full" if the transactions of all accepted blocks are
+	// indexed, "recent" if only those of the last [TxLookupLimit] blocks are,
+	// and "off" if transaction indexing is skipped.
+	Retention     string `json:"retention"`
+	TxLookupLimit uint64 `json:"txLookupLimit"`
+	// Tail is the lowest block whose transactions are indexed, if recorded.
+	Tail *uint64 `json:"tail,omitempty"`
+	// Rebuild is the progress of the last rebuild started by RebuildTxIndex.
+	Rebuild *core.TxIndexRebuild `json:"rebuild,omitempty"`
+}
+
+// RebuildTxIndex rewrites in the background the transaction index of the
+// accepted blocks within the configured retention, to recover from missing or
+// corrupt entries. Its progress is reported by GetTxIndexStatus.
+func (p *Admin) RebuildTxIndex(_ *http.Request, _ *struct{}, reply *TxIndexStatusReply) error {
+	log.Info("Admin: RebuildTxIndex called")
+
+	if _, err := p.vm.blockChain.RebuildTxIndex(); err != nil {
+		return fmt.Errorf("cannot rebuild transaction index: %w", err)
+	}
+	return p.GetTxIndexStatus(nil, nil, reply)
+}
+
+// GetTxIndexStatus reports the retention of the transaction index and the
+// progress of the last rebuild.
+func (p *Admin) GetTxIndexStatus(_ *http.Request, _ *struct{}, reply *TxIndexStatusReply) error {
+	switch {
+	case p.vm.config.SkipTxIndexing:
+		reply.Retention = "off"
+	case p.vm.config.TxLookupLimit == 0:
+		reply.Retention = "full"
+	default:
+		reply.Retention = "recent"
+	}
+	reply.TxLookupLimit = p.vm.config.TxLookupLimit
+	reply.Tail = rawdb.ReadTxIndexTail(p.vm.chaindb)
+	if rebuild, ok := p.vm.blockChain.TxIndexRebuildStatus(); ok {
+		reply.Rebuild = &rebuild
+	}
+	return nil
+}
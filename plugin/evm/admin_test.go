@@ -0,0 +1,55 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAuthentication(t *testing.T) {
+	require := require.New(t)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, circuitBreakerTestConfig, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	admin := NewAdminService(vm, t.TempDir())
+
+	upgradeBytesJSON, err := json.Marshal(&params.UpgradeConfig{})
+	require.NoError(err)
+	calls := map[string]func(apiKey string) error{
+		"setModuleLogLevels": func(apiKey string) error {
+			return admin.SetModuleLogLevels(newAdminRequest(apiKey), &SetModuleLogLevelsArgs{}, &api.EmptyReply{})
+		},
+		"getChainConfig": func(apiKey string) error {
+			return admin.GetChainConfig(newAdminRequest(apiKey), nil, &ChainConfigReply{})
+		},
+		"validateUpgrades": func(apiKey string) error {
+			return admin.ValidateUpgrades(newAdminRequest(apiKey), &ValidateUpgradesArgs{Upgrades: upgradeBytesJSON}, &UpgradesReport{})
+		},
+	}
+	// The calls are served while the VM lock is not held, as over the API.
+	vm.ctx.Lock.Unlock()
+	for method, call := range calls {
+		require.ErrorContains(call(""), "missing or unknown API key", method)
+		require.ErrorContains(call("unknown"), "missing or unknown API key", method)
+		require.ErrorContains(call("eth-key"), "method admin_"+method+" is not allowed", method)
+		require.NoError(call("admin-key"), method)
+	}
+	vm.ctx.Lock.Lock()
+
+	// Without API keys, the calls cannot be used at all.
+	_, noKeysVM, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, `{"admin-api-enabled": true}`, "")
+	defer func() {
+		require.NoError(noKeysVM.Shutdown(context.Background()))
+	}()
+	noKeysAdmin := NewAdminService(noKeysVM, t.TempDir())
+	require.ErrorIs(noKeysAdmin.GetChainConfig(newAdminRequest("admin-key"), nil, &ChainConfigReply{}), errAPIKeysRequired)
+	require.ErrorIs(noKeysAdmin.ValidateUpgrades(newAdminRequest("admin-key"), &ValidateUpgradesArgs{Upgrades: upgradeBytesJSON}, &UpgradesReport{}), errAPIKeysRequired)
+}
@@ -0,0 +1,162 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"golang.org/x/time/rate"
+)
+
+const (
+	errcodeUnauthorized     = -32001
+	errcodeMethodNotAllowed = -32004
+
+	// graphqlMethod is the method API keys must be allowed to call to query the
+	// GraphQL endpoint.
+	graphqlMethod = "graphql_query"
+)
+
+var (
+	_ rpc.Error = (*unauthorizedError)(nil)
+	_ rpc.Error = (*methodNotAllowedError)(nil)
+)
+
+// APIKeyConfig is an API key allowed to call the chain RPC, with the methods it
+// can call and its rate quota.
+type APIKeyConfig struct {
+	Key string `json:"key"`
+	// Methods the key can call, e.g. "eth_call", or "eth_*" for all the methods
+	// of a namespace (empty = all methods). The restricted calls of the admin
+	// API are named "admin_<method>", e.g. "admin_haltBlockProduction".
+	Methods []string `json:"methods"`
+	// Calls per second allowed to the key, in bursts of up to Burst calls
+	// (0 = unlimited)
+	RateLimit float64 `json:"rate-limit"`
+	Burst     int     `json:"burst"`
+}
+
+type unauthorizedError struct{}
+
+func (*unauthorizedError) ErrorCode() int { return errcodeUnauthorized }
+
+func (*unauthorizedError) Error() string { return "missing or unknown API key" }
+
+type methodNotAllowedError struct{ method string }
+
+func (e *methodNotAllowedError) ErrorCode() int { return errcodeMethodNotAllowed }
+
+func (e *methodNotAllowedError) Error() string {
+	return fmt.Sprintf("method %s is not allowed for this API key", e.method)
+}
+
+type apiKey struct {
+	methods    set.Set[string]
+	namespaces set.Set[string]
+	rateLimit  float64
+	limiter    *rate.Limiter // nil when unlimited
+}
+
+func (k *apiKey) allows(method string) bool {
+	if k.methods.Len() == 0 && k.namespaces.Len() == 0 {
+		return true
+	}
+	if k.methods.Contains(method) {
+		return true
+	}
+	namespace, _, _ := strings.Cut(method, "_")
+	return k.namespaces.Contains(namespace)
+}
+
+// apiKeyAuthenticator restricts the chain RPC to the clients sending a configured
+// API key, enforcing the methods allowed to each key and its rate quota.
+// Requests without a known key are rejected by the handlers it wraps, and the
+// calls are authorized by the rpc.CallAuthorizer it provides, which also covers
+// the calls made over websocket connections.
+type apiKeyAuthenticator struct {
+	keys map[string]*apiKey
+}
+
+func newAPIKeyAuthenticator(configs []APIKeyConfig) *apiKeyAuthenticator {
+	a := &apiKeyAuthenticator{
+		keys: make(map[string]*apiKey, len(configs)),
+	}
+	for _, config := range configs {
+		key := &apiKey{rateLimit: config.RateLimit}
+		for _, method := range config.Methods {
+			if namespace, ok := strings.CutSuffix(method, "_*"); ok {
+				key.namespaces.Add(namespace)
+			} else {
+				key.methods.Add(method)
+			}
+		}
+		if config.RateLimit > 0 {
+			key.limiter = rate.NewLimiter(rate.Limit(config.RateLimit), config.Burst)
+		}
+		a.keys[config.Key] = key
+	}
+	return a
+}
+
+// wrap returns a handler rejecting the requests without a known API key before
+// serving them with [handler].
+func (a *apiKeyAuthenticator) wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := a.keys[rpc.APIKeyFromHeader(r.Header)]; !ok {
+			writeRPCError(w, http.StatusUnauthorized, &unauthorizedError{})
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// wrapMethod returns a handler serving the requests with [handler] as calls to
+// [method], for the endpoints not served by the rpc server.
+func (a *apiKeyAuthenticator) wrapMethod(handler http.Handler, method string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := a.authorizeKey(rpc.APIKeyFromHeader(r.Header), method); err != nil {
+			status := http.StatusForbidden
+			switch err.(type) {
+			case *unauthorizedError:
+				status = http.StatusUnauthorized
+			case *rpc.LimitExceededError:
+				status = http.StatusTooManyRequests
+			}
+			writeRPCError(w, status, err)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// authorize implements rpc.CallAuthorizer.
+func (a *apiKeyAuthenticator) authorize(ctx context.Context, method string) error {
+	if err := a.authorizeKey(rpc.PeerInfoFromContext(ctx).HTTP.APIKey, method); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *apiKeyAuthenticator) authorizeKey(apiKey string, method string) rpc.Error {
+	key, ok := a.keys[apiKey]
+	if !ok {
+		return &unauthorizedError{}
+	}
+	if !key.allows(method) {
+		return &methodNotAllowedError{method: method}
+	}
+	if key.limiter != nil && !key.limiter.Allow() {
+		return &rpc.LimitExceededError{
+			Message: "API key rate limit exceeded",
+			Limit:   "apiKeyRateLimit",
+			Max:     key.rateLimit,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeysTestService struct{}
+
+func (apiKeysTestService) Ping() string { return "pong" }
+
+func (apiKeysTestService) Echo(s string) string { return s }
+
+func newAPIKeysTestServer(t *testing.T, configs []APIKeyConfig) (string, string) {
+	server := rpc.NewServer(0)
+	require.NoError(t, server.RegisterName("test", apiKeysTestService{}))
+	require.NoError(t, server.RegisterName("other", apiKeysTestService{}))
+	authenticator := newAPIKeyAuthenticator(configs)
+	server.SetCallAuthorizer(authenticator.authorize)
+
+	mux := http.NewServeMux()
+	mux.Handle("/rpc", authenticator.wrap(server))
+	mux.Handle("/ws", authenticator.wrap(server.WebsocketHandler([]string{"*"})))
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL + "/rpc", "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+}
+
+func requireRPCErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, code, rpcErr.ErrorCode())
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	httpURL, wsURL := newAPIKeysTestServer(t, []APIKeyConfig{
+		{Key: "all"},
+		{Key: "restricted", Methods: []string{"test_ping", "other_*"}, RateLimit: 0.001, Burst: 2},
+	})
+	ctx := context.Background()
+
+	// Requests without a known key are rejected before being served
+	client, err := rpc.DialOptions(ctx, httpURL, rpc.WithHeader("X-API-Key", "unknown"))
+	require.NoError(t, err)
+	var res string
+	err = client.Call(&res, "test_ping")
+	var httpErr rpc.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	client.Close()
+	_, err = rpc.DialOptions(ctx, wsURL)
+	require.Error(t, err)
+
+	client, err = rpc.DialOptions(ctx, httpURL, rpc.WithHeader("Authorization", "Bearer all"))
+	require.NoError(t, err)
+	require.NoError(t, client.Call(&res, "test_echo", "hello"))
+	require.Equal(t, "hello", res)
+	client.Close()
+
+	for _, url := range []string{httpURL, wsURL} {
+		client, err = rpc.DialOptions(ctx, url, rpc.WithHeader("X-API-Key", "restricted"))
+		require.NoError(t, err)
+		requireRPCErrorCode(t, client.Call(&res, "test_echo", "hello"), errcodeMethodNotAllowed)
+		client.Close()
+	}
+
+	// The quota of the key is shared by its connections
+	client, err = rpc.DialOptions(ctx, wsURL, rpc.WithHeader("X-API-Key", "restricted"))
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Call(&res, "test_ping"))
+	require.Equal(t, "pong", res)
+	require.NoError(t, client.Call(&res, "other_echo", "hello"))
+	requireRPCErrorCode(t, client.Call(&res, "test_ping"), -32005)
+}
+
+func TestAPIKeyAuthenticatorWrapMethod(t *testing.T) {
+	authenticator := newAPIKeyAuthenticator([]APIKeyConfig{
+		{Key: "graphql", Methods: []string{graphqlMethod}, RateLimit: 0.001, Burst: 1},
+		{Key: "rpc", Methods: []string{"eth_*"}},
+	})
+	handler := authenticator.wrapMethod(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), graphqlMethod)
+
+	for _, tt := range []struct {
+		key  string
+		want int
+	}{
+		{"", http.StatusUnauthorized},
+		{"rpc", http.StatusForbidden},
+		{"graphql", http.StatusOK},
+		{"graphql", http.StatusTooManyRequests},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		req.Header.Set("X-API-Key", tt.key)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, tt.want, w.Code, tt.key)
+	}
+}
@@ -12,11 +12,15 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/governance"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/predicate"
 
@@ -24,6 +28,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 )
 
 var (
@@ -52,8 +58,13 @@ func (vm *VM) newBlock(ethBlock *types.Block) *Block {
 func (b *Block) ID() ids.ID { return b.id }
 
 // Accept implements the snowman.Block interface
-func (b *Block) Accept(context.Context) error {
+func (b *Block) Accept(ctx context.Context) (err error) {
 	vm := b.vm
+	ctx, span := vm.tracer.Start(ctx, "subnetevm.Block.accept", oteltrace.WithAttributes(
+		attribute.Stringer("blkID", b.ID()),
+		attribute.Int64("height", int64(b.Height())),
+	))
+	defer func() { endSpan(span, err) }()
 
 	// Although returning an error from Accept is considered fatal, it is good
 	// practice to cleanup the batch we were modifying in the case of an error.
@@ -67,12 +78,23 @@ func (b *Block) Accept(context.Context) error {
 	// take place before the accepted log is emitted to subscribers. Use of the
 	// sharedMemoryWriter ensures shared memory requests generated by
 	// precompiles are committed atomically with the vm's lastAcceptedKey.
-	rules := b.vm.chainConfig.Rules(b.ethBlock.Number(), b.ethBlock.Timestamp())
+	rules, err := b.rules()
+	if err != nil {
+		return err
+	}
 	sharedMemoryWriter := NewSharedMemoryWriter()
 	if err := b.handlePrecompileAccept(rules, sharedMemoryWriter); err != nil {
 		return err
 	}
-	if err := vm.blockChain.Accept(b.ethBlock); err != nil {
+	// Predicates are only verified once the chain is bootstrapped, so deliveries are
+	// only observed from then on.
+	if vm.bootstrapped {
+		b.handleWarpDeliveries(rules)
+	}
+	_, chainSpan := vm.tracer.Start(ctx, "subnetevm.blockChain.accept")
+	err = vm.blockChain.Accept(b.ethBlock)
+	endSpan(chainSpan, err)
+	if err != nil {
 		return fmt.Errorf("chain could not accept %s: %w", b.ID(), err)
 	}
 
@@ -130,6 +152,45 @@ func (b *Block) handlePrecompileAccept(rules params.Rules, sharedMemoryWriter *s
 	return nil
 }
 
+// handleWarpDeliveries reports the Warp messages delivered by the block with a verified
+// predicate to the warp backend, which measures the latency of the messages sent by this chain.
+func (b *Block) handleWarpDeliveries(rules params.Rules) {
+	if _, ok := rules.Predicaters[warp.ContractAddress]; !ok {
+		return
+	}
+	predicateResultsBytes, ok := predicate.GetPredicateResultBytes(b.ethBlock.Extra())
+	if !ok {
+		return
+	}
+	predicateResults, err := predicate.ParseResults(predicateResultsBytes)
+	if err != nil {
+		log.Debug("failed to parse predicate results of accepted block", "block", b.ID(), "err", err)
+		return
+	}
+	for _, tx := range b.ethBlock.Transactions() {
+		predicates := predicate.PreparePredicateStorageSlots(rules, tx.AccessList())[warp.ContractAddress]
+		if len(predicates) == 0 {
+			continue
+		}
+		// Bits set in the results are the indices of the predicates that failed verification.
+		failed := set.BitsFromBytes(predicateResults.GetResults(tx.Hash(), warp.ContractAddress))
+		for i, predicateBytes := range predicates {
+			if failed.Contains(i) {
+				continue
+			}
+			unpackedPredicateBytes, err := predicate.UnpackPredicate(predicateBytes)
+			if err != nil {
+				continue
+			}
+			warpMessage, err := avalancheWarp.ParseMessage(unpackedPredicateBytes)
+			if err != nil {
+				continue
+			}
+			b.vm.warpBackend.MessageDelivered(warpMessage.UnsignedMessage.ID())
+		}
+	}
+}
+
 // Reject implements the snowman.Block interface
 func (b *Block) Reject(context.Context) error {
 	b.status = choices.Rejected
@@ -161,6 +222,23 @@ func (b *Block) Timestamp() time.Time {
 	return time.Unix(int64(b.ethBlock.Time()), 0)
 }
 
+// rules returns the rules of the block, including the precompile upgrades scheduled
+// by the governance precompile in the state of its parent.
+func (b *Block) rules() (params.Rules, error) {
+	config := b.vm.chainConfig
+	if config.IsPrecompileEnabled(governance.ContractAddress, b.ethBlock.Time()) {
+		parent := b.vm.blockChain.GetHeader(b.ethBlock.ParentHash(), b.ethBlock.NumberU64()-1)
+		if parent == nil {
+			return params.Rules{}, fmt.Errorf("parent %s of block %s not found", b.ethBlock.ParentHash(), b.ID())
+		}
+		var err error
+		if config, err = b.vm.blockChain.ConfigAt(parent); err != nil {
+			return params.Rules{}, err
+		}
+	}
+	return config.Rules(b.ethBlock.Number(), b.ethBlock.Timestamp()), nil
+}
+
 // syntacticVerify verifies that a *Block is well-formed.
 func (b *Block) syntacticVerify() error {
 	if b == nil || b.ethBlock == nil {
@@ -173,8 +251,8 @@ func (b *Block) syntacticVerify() error {
 }
 
 // Verify implements the snowman.Block interface
-func (b *Block) Verify(context.Context) error {
-	return b.verify(&precompileconfig.PredicateContext{
+func (b *Block) Verify(ctx context.Context) error {
+	return b.verify(ctx, &precompileconfig.PredicateContext{
 		SnowCtx:            b.vm.ctx,
 		ProposerVMBlockCtx: nil,
 	}, true)
@@ -182,7 +260,11 @@ func (b *Block) Verify(context.Context) error {
 
 // ShouldVerifyWithContext implements the block.WithVerifyContext interface
 func (b *Block) ShouldVerifyWithContext(context.Context) (bool, error) {
-	predicates := b.vm.chainConfig.Rules(b.ethBlock.Number(), b.ethBlock.Timestamp()).Predicaters
+	rules, err := b.rules()
+	if err != nil {
+		return false, err
+	}
+	predicates := rules.Predicaters
 	// Short circuit early if there are no predicates to verify
 	if len(predicates) == 0 {
 		return false, nil
@@ -205,7 +287,7 @@ func (b *Block) ShouldVerifyWithContext(context.Context) (bool, error) {
 
 // VerifyWithContext implements the block.WithVerifyContext interface
 func (b *Block) VerifyWithContext(ctx context.Context, proposerVMBlockCtx *block.Context) error {
-	return b.verify(&precompileconfig.PredicateContext{
+	return b.verify(ctx, &precompileconfig.PredicateContext{
 		SnowCtx:            b.vm.ctx,
 		ProposerVMBlockCtx: proposerVMBlockCtx,
 	}, true)
@@ -214,7 +296,15 @@ func (b *Block) VerifyWithContext(ctx context.Context, proposerVMBlockCtx *block
 // Verify the block is valid.
 // Enforces that the predicates are valid within [predicateContext].
 // Writes the block details to disk and the state to the trie manager iff writes=true.
-func (b *Block) verify(predicateContext *precompileconfig.PredicateContext, writes bool) error {
+func (b *Block) verify(ctx context.Context, predicateContext *precompileconfig.PredicateContext, writes bool) (err error) {
+	ctx, span := b.vm.tracer.Start(ctx, "subnetevm.Block.verify", oteltrace.WithAttributes(
+		attribute.Stringer("blkID", b.ID()),
+		attribute.Int64("height", int64(b.Height())),
+		attribute.Int("numTxs", len(b.ethBlock.Transactions())),
+		attribute.Bool("writes", writes),
+	))
+	defer func() { endSpan(span, err) }()
+
 	if predicateContext.ProposerVMBlockCtx != nil {
 		log.Debug("Verifying block with context", "block", b.ID(), "height", b.Height())
 	} else {
@@ -229,7 +319,10 @@ func (b *Block) verify(predicateContext *precompileconfig.PredicateContext, writ
 	// been accepted by the network (so the predicate was validated by the network when the
 	// block was originally verified).
 	if b.vm.bootstrapped {
-		if err := b.verifyPredicates(predicateContext); err != nil {
+		_, predicatesSpan := b.vm.tracer.Start(ctx, "subnetevm.Block.verifyPredicates")
+		err := b.verifyPredicates(predicateContext)
+		endSpan(predicatesSpan, err)
+		if err != nil {
 			return fmt.Errorf("failed to verify predicates: %w", err)
 		}
 	}
@@ -243,12 +336,18 @@ func (b *Block) verify(predicateContext *precompileconfig.PredicateContext, writ
 		return nil
 	}
 
-	return b.vm.blockChain.InsertBlockManual(b.ethBlock, writes)
+	_, insertSpan := b.vm.tracer.Start(ctx, "subnetevm.blockChain.insertBlock")
+	err = b.vm.blockChain.InsertBlockManual(b.ethBlock, writes)
+	endSpan(insertSpan, err)
+	return err
 }
 
 // verifyPredicates verifies the predicates in the block are valid according to predicateContext.
 func (b *Block) verifyPredicates(predicateContext *precompileconfig.PredicateContext) error {
-	rules := b.vm.chainConfig.Rules(b.ethBlock.Number(), b.ethBlock.Timestamp())
+	rules, err := b.rules()
+	if err != nil {
+		return err
+	}
 
 	switch {
 	case !rules.IsDurango && rules.PredicatersExist():
@@ -27,7 +27,8 @@ type blockBuilder struct {
 	ctx         *snow.Context
 	chainConfig *params.ChainConfig
 
-	txPool *txpool.TxPool
+	txPool         *txpool.TxPool
+	circuitBreaker *circuitBreaker
 
 	shutdownChan <-chan struct{}
 	shutdownWg   *sync.WaitGroup
@@ -48,6 +49,12 @@ type blockBuilder struct {
 	// If the mempool receives a new transaction, the block builder will send a new notification to
 	// the engine and cancel the timer.
 	buildBlockTimer *timer.Timer
+
+	// lastBuildTime and lastBuildErr are the time and the error of the last
+	// block building attempt, reported by the build diagnostics API.
+	// [buildBlockLock] must be held when accessing them.
+	lastBuildTime time.Time
+	lastBuildErr  error
 }
 
 func (vm *VM) NewBlockBuilder(notifyBuildBlockChan chan<- commonEng.Message) *blockBuilder {
@@ -55,6 +62,7 @@ func (vm *VM) NewBlockBuilder(notifyBuildBlockChan chan<- commonEng.Message) *bl
 		ctx:                  vm.ctx,
 		chainConfig:          vm.chainConfig,
 		txPool:               vm.txPool,
+		circuitBreaker:       &vm.circuitBreaker,
 		shutdownChan:         vm.shutdownChan,
 		shutdownWg:           &vm.shutdownWg,
 		notifyBuildBlockChan: notifyBuildBlockChan,
@@ -95,9 +103,30 @@ func (b *blockBuilder) handleGenerateBlock() {
 	b.buildBlockTimer.SetTimeoutIn(minBlockBuildingRetryDelay)
 }
 
+// recordBuildResult records the outcome of a block building attempt.
+func (b *blockBuilder) recordBuildResult(err error) {
+	b.buildBlockLock.Lock()
+	defer b.buildBlockLock.Unlock()
+
+	b.lastBuildTime = time.Now()
+	b.lastBuildErr = err
+}
+
+// buildStatus returns whether a PendingTxs notification awaits BuildBlock, and
+// the time and error of the last block building attempt.
+func (b *blockBuilder) buildStatus() (bool, time.Time, error) {
+	b.buildBlockLock.Lock()
+	defer b.buildBlockLock.Unlock()
+
+	return b.buildSent, b.lastBuildTime, b.lastBuildErr
+}
+
 // needToBuild returns true if there are outstanding transactions to be issued
-// into a block.
+// into a block and block production is not halted.
 func (b *blockBuilder) needToBuild() bool {
+	if b.circuitBreaker.checkBlockProduction() != nil {
+		return false
+	}
 	size := b.txPool.PendingSize(true)
 	return size > 0
 }
@@ -132,6 +161,9 @@ func (b *blockBuilder) signalTxsReady() {
 	// In the future, we may wish to add optimization here to only signal the
 	// engine if the sum of the projected tips in the mempool satisfies the
 	// required block fee.
+	if b.circuitBreaker.checkBlockProduction() != nil {
+		return
+	}
 	b.markBuilding()
 }
 
@@ -0,0 +1,303 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/miner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxBuildDiagnosticsTxs is the maximum number of transactions diagnosed when
+// no transaction is requested.
+const maxBuildDiagnosticsTxs = 1024
+
+// Statuses of the diagnosed transactions
+const (
+	txStatusPending  = "pending"
+	txStatusQueued   = "queued"
+	txStatusIncluded = "included"
+	txStatusUnknown  = "unknown"
+)
+
+// BuildDiagnosticsAPI explains the block production of the node, ie. why block
+// building is idle and why pending transactions are not included in blocks.
+type BuildDiagnosticsAPI struct{ vm *VM }
+
+// BuildDiagnosticsReply is the reply of BuildDiagnostics.
+type BuildDiagnosticsReply struct {
+	// IdleReasons are the reasons no block is being built, if any.
+	IdleReasons []string `json:"idleReasons"`
+	// NextBaseFee, NextBlockGasCost and GasLimit are the estimated fee
+	// parameters of the next block.
+	NextBaseFee      *hexutil.Big   `json:"nextBaseFee,omitempty"`
+	NextBlockGasCost *hexutil.Big   `json:"nextBlockGasCost,omitempty"`
+	GasLimit         hexutil.Uint64 `json:"gasLimit"`
+	LastAttempt      *BuildAttempt  `json:"lastAttempt,omitempty"`
+	Transactions     []TxDiagnosis  `json:"transactions"`
+}
+
+// BuildAttempt summarizes the last block building attempt of the node.
+type BuildAttempt struct {
+	Time         time.Time      `json:"time"`
+	ParentHash   common.Hash    `json:"parentHash"`
+	Number       hexutil.Uint64 `json:"number"`
+	BaseFee      *hexutil.Big   `json:"baseFee,omitempty"`
+	BlockGasCost *hexutil.Big   `json:"blockGasCost,omitempty"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Pending      int            `json:"pending"`
+	Included     int            `json:"included"`
+	Excluded     int            `json:"excluded"`
+	OverBudget   bool           `json:"overBudget"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// TxDiagnosis explains why a transaction is not included in a block.
+type TxDiagnosis struct {
+	Hash   common.Hash `json:"hash"`
+	Status string      `json:"status"`
+	// Position is the rank of the transaction among the pending transactions
+	// paying the next base fee, by decreasing tip, and GasAhead the gas of the
+	// transactions ranked before it.
+	Position int            `json:"position,omitempty"`
+	GasAhead hexutil.Uint64 `json:"gasAhead,omitempty"`
+	Reasons  []string       `json:"reasons"`
+}
+
+// buildDiagnosis holds the state the transactions are diagnosed against.
+type buildDiagnosis struct {
+	signer           types.Signer
+	baseFee          *big.Int
+	requiredBlockFee *big.Int // tips the next block must pay to cover its block gas cost
+	gasLimit         uint64
+	report           *miner.BuildReport // nil unless built on top of [head]
+	ranks            map[common.Hash]txRank
+}
+
+type txRank struct {
+	position int
+	gasAhead uint64
+}
+
+// BuildDiagnostics explains why block building is idle and why the
+// transactions [hashes] (by default, those of the txpool) are not included in
+// the next block.
+func (api *BuildDiagnosticsAPI) BuildDiagnostics(ctx context.Context, hashes *[]common.Hash) (*BuildDiagnosticsReply, error) {
+	vm := api.vm
+	head := vm.blockChain.CurrentBlock()
+	feeConfig, _, err := vm.blockChain.GetFeeConfigAt(head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee config: %w", err)
+	}
+	d := &buildDiagnosis{
+		signer:   types.LatestSigner(vm.chainConfig),
+		gasLimit: feeConfig.GasLimit.Uint64(),
+	}
+	reply := &BuildDiagnosticsReply{
+		GasLimit: hexutil.Uint64(d.gasLimit),
+	}
+	timestamp := uint64(vm.clock.Time().Unix())
+	if vm.chainConfig.IsSubnetEVM(timestamp) {
+		_, d.baseFee, err = dummy.EstimateNextBaseFee(vm.chainConfig, feeConfig, head, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate next base fee: %w", err)
+		}
+		blockGasCost := dummy.EstimateNextBlockGasCost(vm.chainConfig, feeConfig, head, timestamp)
+		d.requiredBlockFee = new(big.Int).Mul(blockGasCost, d.baseFee)
+		reply.NextBaseFee = (*hexutil.Big)(d.baseFee)
+		reply.NextBlockGasCost = (*hexutil.Big)(blockGasCost)
+	}
+
+	reply.IdleReasons = api.idleReasons(d)
+	if report := vm.miner.LastBuildReport(); report != nil {
+		reply.LastAttempt = newBuildAttempt(report)
+		if vm.builder != nil {
+			_, reply.LastAttempt.Time, _ = vm.builder.buildStatus()
+		}
+		if report.ParentHash == head.Hash() {
+			d.report = report
+		}
+	}
+
+	d.ranks = rankPending(vm.txPool.PendingWithBaseFee(true, d.baseFee), d.baseFee)
+	var txHashes []common.Hash
+	if hashes != nil {
+		txHashes = *hashes
+	} else {
+		txHashes = poolHashes(vm.txPool)
+	}
+	reply.Transactions = make([]TxDiagnosis, 0, len(txHashes))
+	for _, hash := range txHashes {
+		reply.Transactions = append(reply.Transactions, api.diagnoseTx(d, hash))
+	}
+	return reply, nil
+}
+
+// idleReasons returns the reasons no block is being built.
+func (api *BuildDiagnosticsAPI) idleReasons(d *buildDiagnosis) []string {
+	vm := api.vm
+	reasons := []string{}
+	if vm.builder == nil {
+		reasons = append(reasons, "the chain is not bootstrapped")
+	}
+	if err := vm.circuitBreaker.checkBlockProduction(); err != nil {
+		reasons = append(reasons, err.Error())
+	}
+	pending, queued := vm.txPool.Stats()
+	switch {
+	case pending == 0 && queued == 0:
+		reasons = append(reasons, "the txpool is empty")
+	case pending == 0:
+		reasons = append(reasons, fmt.Sprintf("the %d transactions of the txpool have nonce gaps", queued))
+	case len(d.ranks) == 0 && d.baseFee != nil:
+		reasons = append(reasons, fmt.Sprintf("none of the %d pending transactions pays the next base fee %d", pending, d.baseFee))
+	}
+	if vm.builder != nil {
+		buildSent, _, lastErr := vm.builder.buildStatus()
+		if buildSent {
+			reasons = append(reasons, "waiting for the consensus engine to build a block, which only happens in the proposer window of the node")
+		}
+		if lastErr != nil {
+			reasons = append(reasons, fmt.Sprintf("the last block building attempt failed: %s", lastErr))
+		}
+	}
+	return reasons
+}
+
+// diagnoseTx explains why the transaction [hash] is not included in the next
+// block.
+func (api *BuildDiagnosticsAPI) diagnoseTx(d *buildDiagnosis, hash common.Hash) TxDiagnosis {
+	vm := api.vm
+	diagnosis := TxDiagnosis{
+		Hash:    hash,
+		Reasons: []string{},
+	}
+	status := vm.txPool.Status(hash)
+	tx := vm.txPool.Get(hash)
+	if status == txpool.TxStatusUnknown || tx == nil {
+		if number := rawdb.ReadTxLookupEntry(vm.chaindb, hash); number != nil {
+			diagnosis.Status = txStatusIncluded
+			diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("included in block %d", *number))
+			return diagnosis
+		}
+		diagnosis.Status = txStatusUnknown
+		diagnosis.Reasons = append(diagnosis.Reasons, "not in the txpool, it was never received or was dropped")
+		return diagnosis
+	}
+
+	diagnosis.Status = txStatusPending
+	if status == txpool.TxStatusQueued {
+		diagnosis.Status = txStatusQueued
+		// The sender was checked when the transaction entered the txpool.
+		sender, _ := types.Sender(d.signer, tx.Tx)
+		diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("nonce gap: nonce %d but the next nonce of the sender is %d", tx.Tx.Nonce(), vm.txPool.Nonce(sender)))
+	}
+	if d.baseFee != nil {
+		if tx.Tx.GasFeeCapIntCmp(d.baseFee) < 0 {
+			diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("fee cap %d below the next base fee %d", tx.Tx.GasFeeCap(), d.baseFee))
+		} else if d.requiredBlockFee.Sign() > 0 {
+			tip, _ := tx.Tx.EffectiveGasTip(d.baseFee)
+			if contribution := new(big.Int).Mul(tip, new(big.Int).SetUint64(tx.Tx.Gas())); contribution.Cmp(d.requiredBlockFee) < 0 {
+				diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("tips of at most %d do not cover the block fee %d of the next block gas cost, which higher paying transactions must subsidize", contribution, d.requiredBlockFee))
+			}
+		}
+	}
+	if err := vm.verifyTxPredicates(tx.Tx); err != nil {
+		diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("predicate fails verification: %s", err))
+	}
+	if rank, ok := d.ranks[hash]; ok {
+		diagnosis.Position = rank.position
+		diagnosis.GasAhead = hexutil.Uint64(rank.gasAhead)
+		if rank.gasAhead+tx.Tx.Gas() > d.gasLimit {
+			diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("pool position %d: %d gas of higher paying transactions ahead exceeds the block gas limit %d", rank.position, rank.gasAhead, d.gasLimit))
+		}
+	}
+	if d.report != nil {
+		if exclusion, ok := d.report.Excluded[hash]; ok {
+			reason := "excluded from the last built block: " + exclusion.Reason
+			if exclusion.Err != nil {
+				reason += fmt.Sprintf(" (%s)", exclusion.Err)
+			}
+			diagnosis.Reasons = append(diagnosis.Reasons, reason)
+		}
+	}
+	return diagnosis
+}
+
+func newBuildAttempt(report *miner.BuildReport) *BuildAttempt {
+	attempt := &BuildAttempt{
+		ParentHash:   report.ParentHash,
+		Number:       hexutil.Uint64(report.Number),
+		BaseFee:      (*hexutil.Big)(report.BaseFee),
+		BlockGasCost: (*hexutil.Big)(report.BlockGasCost),
+		GasUsed:      hexutil.Uint64(report.GasUsed),
+		Pending:      report.Pending,
+		Included:     len(report.Included),
+		Excluded:     len(report.Excluded),
+		OverBudget:   report.OverBudget,
+	}
+	if report.Err != nil {
+		attempt.Error = report.Err.Error()
+	}
+	return attempt
+}
+
+// rankPending ranks the [pending] transactions by decreasing tip at [baseFee],
+// approximating the order the miner packs them in.
+func rankPending(pending map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) map[common.Hash]txRank {
+	var txs []*txpool.LazyTransaction
+	for _, senderTxs := range pending {
+		txs = append(txs, senderTxs...)
+	}
+	tip := func(tx *txpool.LazyTransaction) *big.Int {
+		if baseFee == nil {
+			return tx.GasTipCap
+		}
+		tip := new(big.Int).Sub(tx.GasFeeCap, baseFee)
+		if tip.Cmp(tx.GasTipCap) > 0 {
+			tip = tx.GasTipCap
+		}
+		return tip
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return tip(txs[i]).Cmp(tip(txs[j])) > 0
+	})
+	ranks := make(map[common.Hash]txRank, len(txs))
+	var gasAhead uint64
+	for i, tx := range txs {
+		ranks[tx.Hash] = txRank{position: i + 1, gasAhead: gasAhead}
+		if resolved := tx.Resolve(); resolved != nil {
+			gasAhead += resolved.Tx.Gas()
+		}
+	}
+	return ranks
+}
+
+// poolHashes returns the hashes of up to [maxBuildDiagnosticsTxs] transactions
+// of [pool], pending ones first.
+func poolHashes(pool *txpool.TxPool) []common.Hash {
+	pending, queued := pool.Content()
+	var hashes []common.Hash
+	for _, content := range []map[common.Address][]*types.Transaction{pending, queued} {
+		for _, txs := range content {
+			for _, tx := range txs {
+				if len(hashes) == maxBuildDiagnosticsTxs {
+					return hashes
+				}
+				hashes = append(hashes, tx.Hash())
+			}
+		}
+	}
+	return hashes
+}
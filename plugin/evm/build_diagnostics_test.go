@@ -0,0 +1,97 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const buildDiagnosticsTestConfig = `{"build-diagnostics-api-enabled": true, "api-keys": [{"key": "debug-key", "methods": ["debug_*"]}, {"key": "eth-key", "methods": ["eth_*"]}]}`
+
+func TestBuildDiagnostics(t *testing.T) {
+	require := require.New(t)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, buildDiagnosticsTestConfig, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	api := &BuildDiagnosticsAPI{vm}
+
+	reply, err := api.BuildDiagnostics(context.Background(), nil)
+	require.NoError(err)
+	require.Contains(reply.IdleReasons, "the txpool is empty")
+	require.Nil(reply.LastAttempt)
+	require.Empty(reply.Transactions)
+
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	newTx := func(nonce uint64) *types.Transaction {
+		tx := types.NewTransaction(nonce, common.Address{1}, big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[0])
+		require.NoError(err)
+		return signedTx
+	}
+	pendingTx, queuedTx := newTx(0), newTx(2)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{pendingTx, queuedTx}) {
+		require.NoError(err)
+	}
+
+	_, err = vm.BuildBlock(context.Background())
+	require.NoError(err)
+
+	unknown := common.Hash{1}
+	reply, err = api.BuildDiagnostics(context.Background(), &[]common.Hash{pendingTx.Hash(), queuedTx.Hash(), unknown})
+	require.NoError(err)
+	require.NotNil(reply.LastAttempt)
+	require.EqualValues(1, reply.LastAttempt.Number)
+	require.Equal(1, reply.LastAttempt.Pending)
+	require.Equal(1, reply.LastAttempt.Included)
+	require.Empty(reply.LastAttempt.Error)
+
+	require.Len(reply.Transactions, 3)
+	require.Equal(TxDiagnosis{Hash: pendingTx.Hash(), Status: txStatusPending, Position: 1, Reasons: []string{}}, reply.Transactions[0])
+	require.Equal(txStatusQueued, reply.Transactions[1].Status)
+	require.Equal([]string{"nonce gap: nonce 2 but the next nonce of the sender is 1"}, reply.Transactions[1].Reasons)
+	require.Equal(txStatusUnknown, reply.Transactions[2].Status)
+}
+
+func TestBuildDiagnosticsAuthentication(t *testing.T) {
+	require := require.New(t)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, buildDiagnosticsTestConfig, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	handlers, err := vm.CreateHandlers(context.Background())
+	require.NoError(err)
+
+	call := func(apiKey string) *httptest.ResponseRecorder {
+		body := `{"jsonrpc":"2.0","id":1,"method":"debug_buildDiagnostics","params":[]}`
+		r := httptest.NewRequest(http.MethodPost, ethRPCEndpoint, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			r.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		handlers[ethRPCEndpoint].ServeHTTP(w, r)
+		return w
+	}
+	require.Equal(http.StatusUnauthorized, call("").Code)
+	require.Equal(http.StatusUnauthorized, call("unknown").Code)
+	require.Contains(call("eth-key").Body.String(), "method debug_buildDiagnostics is not allowed")
+	w := call("debug-key")
+	require.Equal(http.StatusOK, w.Code)
+	require.Contains(w.Body.String(), "the txpool is empty")
+
+	// The diagnostics cannot be enabled without API keys.
+	config := Config{BuildDiagnosticsAPIEnabled: true}
+	config.SetDefaults()
+	require.ErrorContains(config.Validate(), "without api keys")
+}
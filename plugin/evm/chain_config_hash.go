@@ -0,0 +1,180 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/peer"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// chainConfigCheckTimeout is the time a peer has to respond with the hash of
+// its chain config.
+const chainConfigCheckTimeout = 30 * time.Second
+
+// chainConfigHasher computes the hashes of the chain config of this node, and
+// serves them to peers.
+type chainConfigHasher struct {
+	config *params.ChainConfig
+	chain  *core.BlockChain
+	codec  codec.Manager
+
+	// hash does not change while the VM is running, as upgrades are only
+	// loaded on start.
+	hash common.Hash
+}
+
+func newChainConfigHasher(config *params.ChainConfig, chain *core.BlockChain, codec codec.Manager) (*chainConfigHasher, error) {
+	hash, err := config.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash chain config: %w", err)
+	}
+	return &chainConfigHasher{
+		config: config,
+		chain:  chain,
+		codec:  codec,
+		hash:   hash,
+	}, nil
+}
+
+// hashes returns the hash of the chain config and the hash of the chain config
+// in effect as of the last accepted block.
+func (h *chainConfigHasher) hashes() (message.ChainConfigHashResponse, error) {
+	timestamp := h.chain.LastAcceptedBlock().Time()
+	activeHash, err := h.config.ActiveHash(timestamp)
+	if err != nil {
+		return message.ChainConfigHashResponse{}, err
+	}
+	return message.ChainConfigHashResponse{
+		Hash:       h.hash,
+		ActiveHash: activeHash,
+		Timestamp:  timestamp,
+	}, nil
+}
+
+// OnChainConfigHashRequest responds to a ChainConfigHashRequest with the hashes
+// of the chain config of this node.
+func (h *chainConfigHasher) OnChainConfigHashRequest(_ context.Context, nodeID ids.NodeID, requestID uint32, _ message.ChainConfigHashRequest) ([]byte, error) {
+	response, err := h.hashes()
+	if err != nil {
+		log.Error("failed to hash chain config", "nodeID", nodeID, "requestID", requestID, "err", err)
+		return nil, nil
+	}
+	responseBytes, err := h.codec.Marshal(message.Version, response)
+	if err != nil {
+		log.Error("failed to marshal ChainConfigHashResponse, dropping request", "nodeID", nodeID, "requestID", requestID, "err", err)
+		return nil, nil
+	}
+	return responseBytes, nil
+}
+
+// chainConfigChecker periodically requests the hash of the chain config of a
+// peer, and warns when it differs from the one of this node.
+type chainConfigChecker struct {
+	hasher *chainConfigHasher
+	client peer.NetworkClient
+	codec  codec.Manager
+
+	lock sync.Mutex
+	// mismatches are the last hashes reported by peers whose chain config
+	// differs, so each mismatch is only logged once.
+	mismatches map[ids.NodeID]common.Hash
+}
+
+func newChainConfigChecker(hasher *chainConfigHasher, client peer.NetworkClient, codec codec.Manager) *chainConfigChecker {
+	return &chainConfigChecker{
+		hasher:     hasher,
+		client:     client,
+		codec:      codec,
+		mismatches: make(map[ids.NodeID]common.Hash),
+	}
+}
+
+// run checks the chain config of a peer every [frequency] until [shutdownChan]
+// is closed.
+func (c *chainConfigChecker) run(frequency time.Duration, shutdownChan <-chan struct{}) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), chainConfigCheckTimeout)
+			if err := c.check(ctx); err != nil {
+				log.Debug("failed to check chain config of peer", "err", err)
+			}
+			cancel()
+		case <-shutdownChan:
+			return
+		}
+	}
+}
+
+// check requests the hash of the chain config of any peer, and logs a warning
+// if it differs from the one of this node.
+func (c *chainConfigChecker) check(ctx context.Context) error {
+	requestBytes, err := message.RequestToBytes(c.codec, message.ChainConfigHashRequest{})
+	if err != nil {
+		return err
+	}
+	responseBytes, nodeID, err := c.client.SendAppRequestAny(ctx, nil, requestBytes)
+	if err != nil {
+		return err
+	}
+	if len(responseBytes) == 0 {
+		// Peers running a version without the chain config hash do not respond.
+		return nil
+	}
+	var response message.ChainConfigHashResponse
+	if _, err := c.codec.Unmarshal(responseBytes, &response); err != nil {
+		return fmt.Errorf("failed to unmarshal ChainConfigHashResponse from %s: %w", nodeID, err)
+	}
+	c.compare(nodeID, response)
+	return nil
+}
+
+// compare logs a warning if the hash of the chain config of [nodeID] differs
+// from the one of this node, unless the same mismatch was already reported. It
+// returns whether the chain configs differ.
+func (c *chainConfigChecker) compare(nodeID ids.NodeID, response message.ChainConfigHashResponse) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if response.Hash == c.hasher.hash {
+		delete(c.mismatches, nodeID)
+		return false
+	}
+	if last, ok := c.mismatches[nodeID]; ok && last == response.Hash {
+		return true
+	}
+	c.mismatches[nodeID] = response.Hash
+
+	local, err := c.hasher.hashes()
+	if err != nil {
+		log.Warn("peer has a different chain config", "nodeID", nodeID, "hash", c.hasher.hash, "peerHash", response.Hash, "err", err)
+		return true
+	}
+	// The active hashes are logged with their timestamps, as they are only
+	// comparable as of the same timestamp.
+	log.Warn("peer has a different chain config, check the upgrades of the nodes",
+		"nodeID", nodeID,
+		"hash", local.Hash,
+		"peerHash", response.Hash,
+		"activeHash", local.ActiveHash,
+		"peerActiveHash", response.ActiveHash,
+		"timestamp", local.Timestamp,
+		"peerTimestamp", response.Timestamp,
+	)
+	return true
+}
@@ -0,0 +1,375 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/internal/era"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Formats of the chain data exported and imported through the admin API
+const (
+	// chainFormatRLP is the concatenation of the RLP encoded blocks, as
+	// exported by geth. It is gzipped if the file name ends with ".gz".
+	chainFormatRLP = "rlp"
+	// chainFormatEra1 is a directory of era1 archives, each holding the
+	// blocks, receipts and total difficulties of an epoch of era.MaxSize blocks.
+	chainFormatEra1 = "era1"
+)
+
+var (
+	errUnknownChainFormat = errors.New("unknown chain data format")
+	errImportBootstrapped = errors.New("cannot import blocks once the chain is bootstrapped")
+)
+
+type ExportChainArgs struct {
+	// File is the file the blocks are exported to, or the directory of the
+	// era1 files.
+	File string `json:"file"`
+	// Format is either "rlp" (default) or "era1".
+	Format string `json:"format"`
+	// First and Last are the range of the exported blocks. They default to
+	// the genesis and to the last accepted block.
+	First *uint64 `json:"first,omitempty"`
+	Last  *uint64 `json:"last,omitempty"`
+}
+
+type ExportChainReply struct {
+	First uint64   `json:"first"`
+	Last  uint64   `json:"last"`
+	Files []string `json:"files"`
+}
+
+// ExportChain exports the accepted blocks from [args.First] to [args.Last] in
+// [args.Format]. The era1 archives also hold the receipts of the blocks, and
+// are split on epoch boundaries, such that archives exported by different
+// nodes are identical. Existing files are never overwritten.
+func (p *Admin) ExportChain(_ *http.Request, args *ExportChainArgs, reply *ExportChainReply) error {
+	log.Info("Admin: ExportChain called", "file", args.File, "format", args.Format)
+
+	if len(args.File) == 0 {
+		return errors.New("cannot export chain without a file")
+	}
+	lastAccepted := p.vm.blockChain.LastAcceptedBlock().NumberU64()
+	first, last := uint64(0), lastAccepted
+	if args.First != nil {
+		first = *args.First
+	}
+	if args.Last != nil {
+		if *args.Last > lastAccepted {
+			return fmt.Errorf("cannot export block %d after the last accepted block %d", *args.Last, lastAccepted)
+		}
+		last = *args.Last
+	}
+	if first > last {
+		return fmt.Errorf("cannot export blocks from %d to %d", first, last)
+	}
+
+	var (
+		files []string
+		err   error
+	)
+	switch args.Format {
+	case "", chainFormatRLP:
+		files, err = p.exportRLP(args.File, first, last)
+	case chainFormatEra1:
+		files, err = p.exportEra1(args.File, first, last)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownChainFormat, args.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export chain: %w", err)
+	}
+	reply.First = first
+	reply.Last = last
+	reply.Files = files
+	return nil
+}
+
+func (p *Admin) exportRLP(file string, first, last uint64) (_ []string, err error) {
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, out.Close()) }()
+
+	var w io.Writer = out
+	if strings.HasSuffix(file, ".gz") {
+		gz := gzip.NewWriter(out)
+		defer func() { err = errors.Join(err, gz.Close()) }()
+		w = gz
+	}
+	return []string{file}, p.vm.blockChain.ExportN(w, first, last)
+}
+
+func (p *Admin) exportEra1(dir string, first, last uint64) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	// The era1 archives hold the total difficulty of the chain up to each of
+	// their blocks, which is not stored.
+	td := new(big.Int)
+	for number := uint64(0); number < first; number++ {
+		header := p.vm.blockChain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("header %d not found", number)
+		}
+		td.Add(td, header.Difficulty)
+	}
+
+	var files []string
+	for start := first; start <= last; {
+		epoch := start / era.MaxSize
+		end := min(last, (epoch+1)*era.MaxSize-1)
+		file, err := p.exportEra1Epoch(dir, int(epoch), start, end, td)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, file)
+		start = end + 1
+	}
+	return files, nil
+}
+
+// exportEra1Epoch writes the blocks from [first] to [last] of [epoch] into an
+// era1 file of [dir], adding their difficulties to [td].
+func (p *Admin) exportEra1Epoch(dir string, epoch int, first, last uint64, td *big.Int) (_ string, err error) {
+	// The name of the archive depends on its accumulator, so it is written to
+	// a temporary file first.
+	tmp, err := os.CreateTemp(dir, ".era1-export-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tmp.Close(), os.Remove(tmp.Name()))
+		}
+	}()
+
+	builder := era.NewBuilder(tmp)
+	err = p.vm.blockChain.ExportCallback(func(block *types.Block) error {
+		receipts := p.vm.blockChain.GetReceiptsByHash(block.Hash())
+		if receipts == nil && block.ReceiptHash() != types.EmptyRootHash {
+			return fmt.Errorf("receipts of block %d not found", block.NumberU64())
+		}
+		td.Add(td, block.Difficulty())
+		return builder.Add(block, receipts, td)
+	}, first, last)
+	if err != nil {
+		return "", err
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, era.Filename(p.vm.ctx.ChainID.String(), epoch, root))
+	if _, err := os.Stat(file); err == nil {
+		return "", fmt.Errorf("%s would overwrite an existing file", file)
+	}
+	return file, os.Rename(tmp.Name(), file)
+}
+
+type ImportChainArgs struct {
+	// File is the file the blocks are imported from. In the era1 format, it is
+	// either an era1 file or a directory of era1 files of this chain.
+	File string `json:"file"`
+	// Format is either "rlp" (default) or "era1".
+	Format string `json:"format"`
+}
+
+type ImportChainReply struct {
+	// Imported is the number of blocks accepted by the import, and Skipped the
+	// number of blocks already accepted.
+	Imported     uint64 `json:"imported"`
+	Skipped      uint64 `json:"skipped"`
+	LastAccepted uint64 `json:"lastAccepted"`
+}
+
+// ImportChain verifies and accepts the blocks of [args.File] extending the last
+// accepted block, in order to seed a node from an archive of the chain. The
+// receipts of era1 archives are checked against the blocks before they are
+// executed. Blocks can only be imported until the chain is bootstrapped, after
+// which consensus decides the accepted blocks.
+func (p *Admin) ImportChain(_ *http.Request, args *ImportChainArgs, reply *ImportChainReply) error {
+	log.Info("Admin: ImportChain called", "file", args.File, "format", args.Format)
+
+	p.vm.ctx.Lock.Lock()
+	defer p.vm.ctx.Lock.Unlock()
+
+	if p.vm.bootstrapped {
+		return errImportBootstrapped
+	}
+	importer := &chainImporter{vm: p.vm}
+	var err error
+	switch args.Format {
+	case "", chainFormatRLP:
+		err = importer.importRLP(args.File)
+	case chainFormatEra1:
+		err = importer.importEra1(args.File)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownChainFormat, args.Format)
+	}
+	// The blocks accepted before a failure remain accepted.
+	if importer.imported > 0 {
+		if setErr := p.vm.State.SetLastAcceptedBlock(importer.lastAccepted); setErr != nil {
+			err = errors.Join(err, setErr)
+		}
+	}
+	reply.Imported = importer.imported
+	reply.Skipped = importer.skipped
+	reply.LastAccepted = p.vm.blockChain.LastConsensusAcceptedBlock().NumberU64()
+	if err != nil {
+		return fmt.Errorf("failed to import chain: %w", err)
+	}
+	return nil
+}
+
+// chainImporter accepts the blocks extending the last accepted block.
+type chainImporter struct {
+	vm           *VM
+	imported     uint64
+	skipped      uint64
+	lastAccepted *Block
+}
+
+func (i *chainImporter) importRLP(file string) error {
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var r io.Reader = in
+	if strings.HasSuffix(file, ".gz") {
+		if r, err = gzip.NewReader(r); err != nil {
+			return err
+		}
+	}
+	stream := rlp.NewStream(r, 0)
+	for index := 0; ; index++ {
+		block := new(types.Block)
+		if err := stream.Decode(block); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("block %d: failed to parse: %w", index, err)
+		}
+		if err := i.accept(block); err != nil {
+			return err
+		}
+	}
+}
+
+func (i *chainImporter) importEra1(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	paths := []string{file}
+	if info.IsDir() {
+		if paths, err = era.ReadDir(file, i.vm.ctx.ChainID.String()); err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no era1 files of chain %s in %s", i.vm.ctx.ChainID, file)
+		}
+	}
+	for _, path := range paths {
+		if err := i.importEra1File(path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (i *chainImporter) importEra1File(path string) error {
+	e, err := era.Open(path)
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+
+	// Check the archive is consistent before executing its blocks.
+	var (
+		blocks = make([]*types.Block, 0, e.Count())
+		hashes = make([]common.Hash, 0, e.Count())
+		tds    = make([]*big.Int, 0, e.Count())
+	)
+	for number := e.Start(); number < e.Start()+e.Count(); number++ {
+		block, receipts, td, err := e.GetBlockWithReceiptsByNumber(number)
+		if err != nil {
+			return err
+		}
+		if hash := types.DeriveSha(receipts, trie.NewStackTrie(nil)); hash != block.ReceiptHash() {
+			return fmt.Errorf("receipts of block %d do not match its receipt root: %s != %s", number, hash, block.ReceiptHash())
+		}
+		blocks = append(blocks, block)
+		hashes = append(hashes, block.Hash())
+		tds = append(tds, td)
+	}
+	expected, err := e.Accumulator()
+	if err != nil {
+		return err
+	}
+	root, err := era.ComputeAccumulator(hashes, tds)
+	if err != nil {
+		return err
+	}
+	if root != expected {
+		return fmt.Errorf("accumulator does not match the blocks: %s != %s", root, expected)
+	}
+
+	for _, block := range blocks {
+		if err := i.accept(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accept verifies and accepts [ethBlock] if it extends the last accepted
+// block, and skips it if it is already accepted.
+func (i *chainImporter) accept(ethBlock *types.Block) error {
+	lastAccepted := i.vm.blockChain.LastConsensusAcceptedBlock()
+	number := ethBlock.NumberU64()
+	if number <= lastAccepted.NumberU64() {
+		if canonical := i.vm.blockChain.GetCanonicalHash(number); canonical != ethBlock.Hash() {
+			return fmt.Errorf("block %d (%s) conflicts with accepted block %s", number, ethBlock.Hash(), canonical)
+		}
+		i.skipped++
+		return nil
+	}
+	if number != lastAccepted.NumberU64()+1 || ethBlock.ParentHash() != lastAccepted.Hash() {
+		return fmt.Errorf("block %d (%s) does not extend the last accepted block %d (%s)", number, ethBlock.Hash(), lastAccepted.NumberU64(), lastAccepted.Hash())
+	}
+
+	ctx := context.Background()
+	block := i.vm.newBlock(ethBlock)
+	if err := block.Verify(ctx); err != nil {
+		return fmt.Errorf("failed to verify block %d: %w", number, err)
+	}
+	if err := block.Accept(ctx); err != nil {
+		return fmt.Errorf("failed to accept block %d: %w", number, err)
+	}
+	i.imported++
+	i.lastAccepted = block
+	return nil
+}
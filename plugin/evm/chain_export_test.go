@@ -0,0 +1,99 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/internal/era"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportChain(t *testing.T) {
+	require := require.New(t)
+
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	for i := 0; i < 3; i++ {
+		tx := types.NewTransaction(uint64(i), testEthAddrs[1], big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+		require.NoError(err)
+		for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+			require.NoError(err)
+		}
+		vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+		issueAndAccept(t, issuer, vm)
+	}
+	vm.blockChain.DrainAcceptorQueue()
+	head := vm.blockChain.LastAcceptedBlock()
+	require.EqualValues(3, head.NumberU64())
+
+	dir := t.TempDir()
+	admin := NewAdminService(vm, dir)
+	var (
+		rlpFile  = filepath.Join(dir, "chain.rlp.gz")
+		era1Dir  = filepath.Join(dir, "era1")
+		exported ExportChainReply
+	)
+	require.NoError(admin.ExportChain(nil, &ExportChainArgs{File: rlpFile}, &exported))
+	require.Equal(ExportChainReply{First: 0, Last: 3, Files: []string{rlpFile}}, exported)
+	// Existing files are not overwritten
+	require.Error(admin.ExportChain(nil, &ExportChainArgs{File: rlpFile}, &exported))
+	// Blocks which are not accepted cannot be exported
+	last := uint64(4)
+	require.Error(admin.ExportChain(nil, &ExportChainArgs{File: filepath.Join(dir, "other.rlp"), Last: &last}, &exported))
+	require.ErrorIs(admin.ExportChain(nil, &ExportChainArgs{File: filepath.Join(dir, "other"), Format: "csv"}, &exported), errUnknownChainFormat)
+
+	require.NoError(admin.ExportChain(nil, &ExportChainArgs{File: era1Dir, Format: chainFormatEra1}, &exported))
+	require.Len(exported.Files, 1)
+	e, err := era.Open(exported.Files[0])
+	require.NoError(err)
+	require.EqualValues(0, e.Start())
+	require.EqualValues(4, e.Count())
+	block, receipts, _, err := e.GetBlockWithReceiptsByNumber(2)
+	require.NoError(err)
+	require.Equal(vm.blockChain.GetBlockByNumber(2).Hash(), block.Hash())
+	require.Len(receipts, 1)
+	require.Equal(types.ReceiptStatusSuccessful, receipts[0].Status)
+	require.NoError(e.Close())
+
+	for _, args := range []*ImportChainArgs{
+		{File: rlpFile},
+		{File: era1Dir, Format: chainFormatEra1},
+	} {
+		_, importVM, _, _ := GenesisVM(t, false, genesisJSONSubnetEVM, "", "")
+		// The admin API takes the lock of the chain
+		importVM.ctx.Lock.Unlock()
+
+		var imported ImportChainReply
+		importAdmin := NewAdminService(importVM, dir)
+		require.NoError(importAdmin.ImportChain(nil, args, &imported))
+		// The genesis is already accepted
+		require.Equal(ImportChainReply{Imported: 3, Skipped: 1, LastAccepted: 3}, imported)
+		lastAcceptedID, err := importVM.LastAccepted(context.Background())
+		require.NoError(err)
+		require.Equal(head.Hash(), common.Hash(lastAcceptedID))
+
+		// Importing the blocks again skips them
+		require.NoError(importAdmin.ImportChain(nil, args, &imported))
+		require.Equal(ImportChainReply{Skipped: 4, LastAccepted: 3}, imported)
+
+		importVM.ctx.Lock.Lock()
+		require.NoError(importVM.SetState(context.Background(), snow.Bootstrapping))
+		require.NoError(importVM.SetState(context.Background(), snow.NormalOp))
+		importVM.ctx.Lock.Unlock()
+		require.ErrorIs(importAdmin.ImportChain(nil, args, &imported), errImportBootstrapped)
+		require.NoError(importVM.Shutdown(context.Background()))
+	}
+}
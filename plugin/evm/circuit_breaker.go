@@ -0,0 +1,211 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// circuitBreakerKey is the key of the persisted [CircuitBreakerStatus] in the
+// metadata database.
+var circuitBreakerKey = []byte("circuit_breaker")
+
+var (
+	errBlockProductionHalted = errors.New("block production is halted")
+	errCircuitBreakerTripped = errors.New("circuit breaker is tripped")
+
+	blockProductionHaltedGauge = metrics.GetOrRegisterGauge("circuit_breaker_block_production_halted", nil)
+	circuitBreakerTrippedGauge = metrics.GetOrRegisterGauge("circuit_breaker_tripped", nil)
+)
+
+// CircuitBreakerStatus describes the incident response controls of the node.
+type CircuitBreakerStatus struct {
+	BlockProductionHalted bool             `json:"blockProductionHalted"`
+	HaltReason            string           `json:"haltReason,omitempty"`
+	Tripped               bool             `json:"tripped"`
+	TripReason            string           `json:"tripReason,omitempty"`
+	Allowlist             []common.Address `json:"allowlist,omitempty"`
+}
+
+// circuitBreaker holds the incident response controls set through the admin API.
+// While block production is halted, the node does not build blocks but keeps
+// verifying and accepting the blocks built by other nodes.
+// While the circuit breaker is tripped, the node only accepts and includes in its
+// blocks the transactions of the allow-listed senders.
+// The controls are local to the node, and persisted so that they survive restarts.
+type circuitBreaker struct {
+	lock       sync.RWMutex
+	halted     bool
+	haltReason string
+	tripped    bool
+	tripReason string
+	allowlist  set.Set[common.Address]
+
+	db     database.KeyValueWriterDeleter
+	commit func() error // Commits the writes to [db]
+}
+
+// initialize restores the controls persisted in [db], and persists the
+// following changes to [db], committing them with [commit].
+func (c *circuitBreaker) initialize(db database.Database, commit func() error) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.db = db
+	c.commit = commit
+	statusBytes, err := db.Get(circuitBreakerKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		var status CircuitBreakerStatus
+		if err := json.Unmarshal(statusBytes, &status); err != nil {
+			return fmt.Errorf("failed to parse circuit breaker status: %w", err)
+		}
+		c.halted = status.BlockProductionHalted
+		c.haltReason = status.HaltReason
+		c.tripped = status.Tripped
+		c.tripReason = status.TripReason
+		c.allowlist = set.Of(status.Allowlist...)
+		log.Warn("Restored circuit breaker", "blockProductionHalted", c.halted, "haltReason", c.haltReason, "tripped", c.tripped, "tripReason", c.tripReason, "allowlist", status.Allowlist)
+	}
+	c.updateMetrics()
+	return nil
+}
+
+// save persists the controls and updates their metrics. It assumes [c.lock] is held.
+func (c *circuitBreaker) save() error {
+	c.updateMetrics()
+	if c.db == nil {
+		return nil
+	}
+	if !c.halted && !c.tripped {
+		if err := c.db.Delete(circuitBreakerKey); err != nil {
+			return err
+		}
+		return c.commit()
+	}
+	statusBytes, err := json.Marshal(c.statusLocked())
+	if err != nil {
+		return err
+	}
+	if err := c.db.Put(circuitBreakerKey, statusBytes); err != nil {
+		return err
+	}
+	return c.commit()
+}
+
+func (c *circuitBreaker) updateMetrics() {
+	var halted, tripped int64
+	if c.halted {
+		halted = 1
+	}
+	if c.tripped {
+		tripped = 1
+	}
+	blockProductionHaltedGauge.Update(halted)
+	circuitBreakerTrippedGauge.Update(tripped)
+}
+
+// halt stops block production for [reason].
+func (c *circuitBreaker) halt(reason string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.halted = true
+	c.haltReason = reason
+	return c.save()
+}
+
+// resume restarts block production.
+func (c *circuitBreaker) resume() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.halted = false
+	c.haltReason = ""
+	return c.save()
+}
+
+// checkBlockProduction returns an error if block production is halted.
+func (c *circuitBreaker) checkBlockProduction() error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.halted {
+		return fmt.Errorf("%w: %s", errBlockProductionHalted, c.haltReason)
+	}
+	return nil
+}
+
+// trip rejects the transactions of all the senders but [allowlist] for [reason].
+func (c *circuitBreaker) trip(allowlist []common.Address, reason string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.tripped = true
+	c.tripReason = reason
+	c.allowlist = set.Of(allowlist...)
+	return c.save()
+}
+
+// reset accepts the transactions of all the senders again.
+func (c *circuitBreaker) reset() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.tripped = false
+	c.tripReason = ""
+	c.allowlist = nil
+	return c.save()
+}
+
+// filterSender returns an error if the transactions of [sender] are rejected.
+// It is used as the sender filter of the tx pool.
+func (c *circuitBreaker) filterSender(sender common.Address) error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.tripped && !c.allowlist.Contains(sender) {
+		return fmt.Errorf("%w: sender %s is not allow-listed", errCircuitBreakerTripped, sender)
+	}
+	return nil
+}
+
+func (c *circuitBreaker) status() CircuitBreakerStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.statusLocked()
+}
+
+func (c *circuitBreaker) statusLocked() CircuitBreakerStatus {
+	return CircuitBreakerStatus{
+		BlockProductionHalted: c.halted,
+		HaltReason:            c.haltReason,
+		Tripped:               c.tripped,
+		TripReason:            c.tripReason,
+		Allowlist:             c.allowlist.List(),
+	}
+}
+
+// CircuitBreakerAPI exposes the incident response controls of the node in the
+// eth namespace, so that clients can tell why their transactions are rejected
+// or no blocks are built.
+type CircuitBreakerAPI struct{ vm *VM }
+
+// CircuitBreakerStatus returns the incident response controls of the node.
+func (api *CircuitBreakerAPI) CircuitBreakerStatus() CircuitBreakerStatus {
+	return api.vm.circuitBreaker.status()
+}
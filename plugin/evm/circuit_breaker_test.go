@@ -0,0 +1,196 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const circuitBreakerTestConfig = `{"admin-api-enabled": true, "api-keys": [{"key": "admin-key", "methods": ["admin_*"]}, {"key": "eth-key", "methods": ["eth_*"]}]}`
+
+func newAdminRequest(apiKey string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, adminEndpoint, nil)
+	if apiKey != "" {
+		r.Header.Set("X-API-Key", apiKey)
+	}
+	return r
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	require := require.New(t)
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, circuitBreakerTestConfig, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	newTxPoolHeadChan := make(chan core.NewTxPoolReorgEvent, 1)
+	vm.txPool.SubscribeNewReorgEvent(newTxPoolHeadChan)
+
+	admin := NewAdminService(vm, t.TempDir())
+	r := newAdminRequest("admin-key")
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	newTx := func(nonce uint64, keyIndex int) *types.Transaction {
+		tx := types.NewTransaction(nonce, common.Address{1}, big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[keyIndex])
+		require.NoError(err)
+		return signedTx
+	}
+
+	// Only the allow-listed senders are accepted while the circuit breaker is tripped.
+	require.NoError(admin.TripCircuitBreaker(r, &TripCircuitBreakerArgs{
+		Allowlist: []common.Address{testEthAddrs[0]},
+		Reason:    "incident",
+	}, &api.EmptyReply{}))
+	errs := vm.txPool.AddRemotesSync([]*types.Transaction{newTx(0, 0), newTx(0, 1)})
+	require.NoError(errs[0])
+	require.ErrorIs(errs[1], errCircuitBreakerTripped)
+
+	health, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.Equal("true", health.(map[string]interface{})["circuitBreaker"].(map[string]string)["circuitBreakerTripped"])
+
+	// No blocks are built while block production is halted.
+	require.NoError(admin.HaltBlockProduction(r, &HaltBlockProductionArgs{Reason: "incident"}, &api.EmptyReply{}))
+	_, err = vm.BuildBlock(context.Background())
+	require.ErrorIs(err, errBlockProductionHalted)
+
+	reply := &CircuitBreakerStatusReply{}
+	require.NoError(admin.GetCircuitBreakerStatus(nil, nil, reply))
+	require.Equal(CircuitBreakerStatus{
+		BlockProductionHalted: true,
+		HaltReason:            "incident",
+		Tripped:               true,
+		TripReason:            "incident",
+		Allowlist:             []common.Address{testEthAddrs[0]},
+	}, reply.Status)
+
+	// Blocks built once block production resumes only include allow-listed senders.
+	require.NoError(admin.ResumeBlockProduction(r, nil, &api.EmptyReply{}))
+	blk := issueAndAccept(t, issuer, vm)
+	<-newTxPoolHeadChan
+	ethBlk := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	require.Len(ethBlk.Transactions(), 1)
+
+	// All senders are accepted again once the circuit breaker is reset.
+	require.NoError(admin.ResetCircuitBreaker(r, nil, &api.EmptyReply{}))
+	errs = vm.txPool.AddRemotesSync([]*types.Transaction{newTx(0, 1)})
+	require.NoError(errs[0])
+	vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+	blk = issueAndAccept(t, issuer, vm)
+	<-newTxPoolHeadChan
+	ethBlk = blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	require.Len(ethBlk.Transactions(), 1)
+
+	health, err = vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.NotContains(health, "circuitBreaker")
+}
+
+func TestCircuitBreakerAuthentication(t *testing.T) {
+	require := require.New(t)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, circuitBreakerTestConfig, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	admin := NewAdminService(vm, t.TempDir())
+	args := &HaltBlockProductionArgs{Reason: "incident"}
+
+	require.ErrorContains(admin.HaltBlockProduction(newAdminRequest(""), args, &api.EmptyReply{}), "missing or unknown API key")
+	require.ErrorContains(admin.HaltBlockProduction(newAdminRequest("unknown"), args, &api.EmptyReply{}), "missing or unknown API key")
+	require.ErrorContains(admin.HaltBlockProduction(newAdminRequest("eth-key"), args, &api.EmptyReply{}), "method admin_haltBlockProduction is not allowed")
+	require.NoError(vm.circuitBreaker.checkBlockProduction())
+
+	// The admin endpoint rejects the requests without a known API key.
+	handlers, err := vm.CreateHandlers(context.Background())
+	require.NoError(err)
+	w := httptest.NewRecorder()
+	body := `{"jsonrpc":"2.0","id":1,"method":"admin.getCircuitBreakerStatus","params":{}}`
+	handlers[adminEndpoint].ServeHTTP(w, httptest.NewRequest(http.MethodPost, adminEndpoint, strings.NewReader(body)))
+	require.Equal(http.StatusUnauthorized, w.Code)
+
+	// Without API keys, the controls cannot be used at all.
+	_, noKeysVM, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, `{"admin-api-enabled": true}`, "")
+	defer func() {
+		require.NoError(noKeysVM.Shutdown(context.Background()))
+	}()
+	noKeysAdmin := NewAdminService(noKeysVM, t.TempDir())
+	require.ErrorIs(noKeysAdmin.HaltBlockProduction(newAdminRequest("admin-key"), args, &api.EmptyReply{}), errAPIKeysRequired)
+	require.ErrorIs(noKeysAdmin.TripCircuitBreaker(newAdminRequest("admin-key"), &TripCircuitBreakerArgs{}, &api.EmptyReply{}), errAPIKeysRequired)
+}
+
+func TestCircuitBreakerRestart(t *testing.T) {
+	require := require.New(t)
+	_, vm, dbManager, _ := GenesisVM(t, true, genesisJSONSubnetEVM, circuitBreakerTestConfig, "")
+	admin := NewAdminService(vm, t.TempDir())
+	r := newAdminRequest("admin-key")
+
+	require.NoError(admin.HaltBlockProduction(r, &HaltBlockProductionArgs{Reason: "halt"}, &api.EmptyReply{}))
+	require.NoError(admin.TripCircuitBreaker(r, &TripCircuitBreakerArgs{
+		Allowlist: []common.Address{testEthAddrs[0]},
+		Reason:    "trip",
+	}, &api.EmptyReply{}))
+	require.EqualValues(1, blockProductionHaltedGauge.Snapshot().Value())
+	require.EqualValues(1, circuitBreakerTrippedGauge.Snapshot().Value())
+	require.NoError(vm.Shutdown(context.Background()))
+
+	restart := func() *VM {
+		restartedVM := &VM{}
+		require.NoError(restartedVM.Initialize(
+			context.Background(),
+			NewContext(),
+			dbManager,
+			buildGenesisTest(t, genesisJSONSubnetEVM),
+			[]byte(""),
+			[]byte(circuitBreakerTestConfig),
+			make(chan commonEng.Message, 1),
+			[]*commonEng.Fx{},
+			nil,
+		))
+		return restartedVM
+	}
+
+	// The controls survive the restart, and are reported in the eth namespace.
+	restartedVM := restart()
+	want := CircuitBreakerStatus{
+		BlockProductionHalted: true,
+		HaltReason:            "halt",
+		Tripped:               true,
+		TripReason:            "trip",
+		Allowlist:             []common.Address{testEthAddrs[0]},
+	}
+	require.Equal(want, (&CircuitBreakerAPI{restartedVM}).CircuitBreakerStatus())
+	require.ErrorIs(restartedVM.circuitBreaker.checkBlockProduction(), errBlockProductionHalted)
+	require.ErrorIs(restartedVM.circuitBreaker.filterSender(testEthAddrs[1]), errCircuitBreakerTripped)
+	require.EqualValues(1, blockProductionHaltedGauge.Snapshot().Value())
+
+	admin = NewAdminService(restartedVM, t.TempDir())
+	require.NoError(admin.ResumeBlockProduction(r, nil, &api.EmptyReply{}))
+	require.NoError(admin.ResetCircuitBreaker(r, nil, &api.EmptyReply{}))
+	require.NoError(restartedVM.Shutdown(context.Background()))
+
+	// The controls stay cleared after another restart.
+	restartedVM = restart()
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	status := (&CircuitBreakerAPI{restartedVM}).CircuitBreakerStatus()
+	require.False(status.BlockProductionHalted)
+	require.False(status.Tripped)
+	require.EqualValues(0, blockProductionHaltedGauge.Snapshot().Value())
+	require.EqualValues(0, circuitBreakerTrippedGauge.Snapshot().Value())
+}
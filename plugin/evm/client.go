@@ -22,7 +22,11 @@ type Client interface {
 	MemoryProfile(ctx context.Context, options ...rpc.Option) error
 	LockProfile(ctx context.Context, options ...rpc.Option) error
 	SetLogLevel(ctx context.Context, level log.Lvl, options ...rpc.Option) error
+	SetModuleLogLevels(ctx context.Context, levels map[string]log.Lvl, options ...rpc.Option) error
 	GetVMConfig(ctx context.Context, options ...rpc.Option) (*Config, error)
+	GetChainConfig(ctx context.Context, options ...rpc.Option) (*ChainConfigReply, error)
+	GetChainConfigHash(ctx context.Context, options ...rpc.Option) (*ChainConfigHashReply, error)
+	ValidateUpgrades(ctx context.Context, upgrades []byte, options ...rpc.Option) (*UpgradesReport, error)
 }
 
 // Client implementation for interacting with EVM [chain]
@@ -66,9 +70,45 @@ func (c *client) SetLogLevel(ctx context.Context, level log.Lvl, options ...rpc.
 	}, &api.EmptyReply{}, options...)
 }
 
+// SetModuleLogLevels dynamically sets the log level of the packages matching
+// the patterns of [levels]
+func (c *client) SetModuleLogLevels(ctx context.Context, levels map[string]log.Lvl, options ...rpc.Option) error {
+	args := &SetModuleLogLevelsArgs{Levels: make(map[string]string, len(levels))}
+	for pattern, level := range levels {
+		args.Levels[pattern] = level.String()
+	}
+	return c.adminRequester.SendRequest(ctx, "admin.setModuleLogLevels", args, &api.EmptyReply{}, options...)
+}
+
 // GetVMConfig returns the current config of the VM
 func (c *client) GetVMConfig(ctx context.Context, options ...rpc.Option) (*Config, error) {
 	res := &ConfigReply{}
 	err := c.adminRequester.SendRequest(ctx, "admin.getVMConfig", struct{}{}, res, options...)
 	return res.Config, err
 }
+
+// GetChainConfig returns the chain config and the precompiles active as of the
+// last accepted block
+func (c *client) GetChainConfig(ctx context.Context, options ...rpc.Option) (*ChainConfigReply, error) {
+	res := &ChainConfigReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.getChainConfig", struct{}{}, res, options...)
+	return res, err
+}
+
+// GetChainConfigHash returns the hashes of the chain config and the version of
+// the VM
+func (c *client) GetChainConfigHash(ctx context.Context, options ...rpc.Option) (*ChainConfigHashReply, error) {
+	res := &ChainConfigHashReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.getChainConfigHash", struct{}{}, res, options...)
+	return res, err
+}
+
+// ValidateUpgrades validates [upgrades] against the chain config of the node
+// without applying them, and returns the changes made at each activation
+func (c *client) ValidateUpgrades(ctx context.Context, upgrades []byte, options ...rpc.Option) (*UpgradesReport, error) {
+	res := &UpgradesReport{}
+	err := c.adminRequester.SendRequest(ctx, "admin.validateUpgrades", &ValidateUpgradesArgs{
+		Upgrades: upgrades,
+	}, res, options...)
+	return res, err
+}
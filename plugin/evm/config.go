@@ -8,48 +8,72 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+	"github.com/ava-labs/subnet-evm/core/txpool/blobpool"
 	"github.com/ava-labs/subnet-evm/core/txpool/legacypool"
 	"github.com/ava-labs/subnet-evm/eth"
+	"github.com/ava-labs/subnet-evm/miner"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/plugin/evm/standalonedb"
+	"github.com/ava-labs/subnet-evm/sync/statesync"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/spf13/cast"
 )
 
 const (
-	defaultAcceptorQueueLimit                         = 64 // Provides 2 minutes of buffer (2s block target) for a commit delay
-	defaultPruningEnabled                             = true
-	defaultCommitInterval                             = 4096
-	defaultTrieCleanCache                             = 512
-	defaultTrieDirtyCache                             = 512
-	defaultTrieDirtyCommitTarget                      = 20
-	defaultTriePrefetcherParallelism                  = 16
-	defaultSnapshotCache                              = 256
-	defaultSyncableCommitInterval                     = defaultCommitInterval * 4
-	defaultSnapshotWait                               = false
-	defaultRpcGasCap                                  = 50_000_000 // Default to 50M Gas Limit
-	defaultRpcTxFeeCap                                = 100        // 100 AVAX
-	defaultMetricsExpensiveEnabled                    = true
-	defaultApiMaxDuration                             = 0 // Default to no maximum API call duration
-	defaultWsCpuRefillRate                            = 0 // Default to no maximum WS CPU usage
-	defaultWsCpuMaxStored                             = 0 // Default to no maximum WS CPU usage
-	defaultMaxBlocksPerRequest                        = 0 // Default to no maximum on the number of blocks per getLogs request
-	defaultContinuousProfilerFrequency                = 15 * time.Minute
-	defaultContinuousProfilerMaxFiles                 = 5
-	defaultPushGossipNumValidators                    = 100
-	defaultPushGossipNumPeers                         = 0
-	defaultPushRegossipNumValidators                  = 10
-	defaultPushRegossipNumPeers                       = 0
-	defaultPushGossipFrequency                        = 100 * time.Millisecond
-	defaultPullGossipFrequency                        = 1 * time.Second
-	defaultRegossipFrequency                          = 30 * time.Second
-	defaultOfflinePruningBloomFilterSize       uint64 = 512 // Default size (MB) for the offline pruner to use
-	defaultLogLevel                                   = "info"
-	defaultLogJSONFormat                              = false
-	defaultMaxOutboundActiveRequests                  = 16
-	defaultMaxOutboundActiveCrossChainRequests        = 64
-	defaultPopulateMissingTriesParallelism            = 1024
-	defaultStateSyncServerTrieCache                   = 64 // MB
-	defaultAcceptedCacheSize                          = 32 // blocks
+	defaultAcceptorQueueLimit                          = 64 // Provides 2 minutes of buffer (2s block target) for a commit delay
+	defaultPruningEnabled                              = true
+	defaultCommitInterval                              = 4096
+	defaultTrieCleanCache                              = 512
+	defaultTrieDirtyCache                              = 512
+	defaultTrieDirtyCommitTarget                       = 20
+	defaultTriePrefetcherParallelism                   = 16
+	defaultSnapshotCache                               = 256
+	defaultSyncableCommitInterval                      = defaultCommitInterval * 4
+	defaultSnapshotWait                                = false
+	defaultRpcGasCap                                   = 50_000_000 // Default to 50M Gas Limit
+	defaultRpcTxFeeCap                                 = 100        // 100 AVAX
+	defaultMetricsExpensiveEnabled                     = true
+	defaultApiMaxDuration                              = 0 // Default to no maximum API call duration
+	defaultWsCpuRefillRate                             = 0 // Default to no maximum WS CPU usage
+	defaultWsCpuMaxStored                              = 0 // Default to no maximum WS CPU usage
+	defaultMaxBlocksPerRequest                         = 0 // Default to no maximum on the number of blocks per getLogs request
+	defaultContinuousProfilerFrequency                 = 15 * time.Minute
+	defaultContinuousProfilerMaxFiles                  = 5
+	defaultPushGossipNumValidators                     = 100
+	defaultPushGossipNumPeers                          = 0
+	defaultPushRegossipNumValidators                   = 10
+	defaultPushRegossipNumPeers                        = 0
+	defaultPushGossipFrequency                         = 100 * time.Millisecond
+	defaultPullGossipFrequency                         = 1 * time.Second
+	defaultRegossipFrequency                           = 30 * time.Second
+	defaultTxGossipBloomMinTargetElements              = 8 * 1024
+	defaultTxGossipBloomTargetFalsePositiveRate        = 0.01
+	defaultTxGossipBloomResetFalsePositiveRate         = 0.05
+	defaultTxGossipBloomChurnMultiplier                = 3
+	defaultOfflinePruningBloomFilterSize        uint64 = 512 // Default size (MB) for the offline pruner to use
+	defaultLogLevel                                    = "info"
+	defaultLogJSONFormat                               = false
+	defaultLogFileMaxSize                              = 100 // MB
+	defaultLogFileMaxBackups                           = 10
+	defaultTracingExporterType                         = "grpc"
+	defaultTracingEndpoint                             = "localhost:4317"
+	defaultTracingSampleRate                           = 0.1
+	defaultHealthMaxDBLevel0Tables                     = 12 // leveldb stops writes at 12 level 0 tables
+	defaultMaxOutboundActiveRequests                   = 16
+	defaultMaxOutboundActiveCrossChainRequests         = 64
+	defaultPopulateMissingTriesParallelism             = 1024
+	defaultStateSyncServerTrieCache                    = 64 // MB
+	defaultAcceptedCacheSize                           = 32 // blocks
+	defaultWarpPrimaryNetworkSampleSize                = 100
+	defaultGasUsageIndexRetention                      = 128    // windows
+	defaultStateDiffsRetention                         = 86_400 // blocks
+	defaultChainConfigCheckFrequency                   = 5 * time.Minute
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -91,6 +115,11 @@ type Config struct {
 	AdminAPIEnabled   bool   `json:"admin-api-enabled"`
 	AdminAPIDir       string `json:"admin-api-dir"`
 	WarpAPIEnabled    bool   `json:"warp-api-enabled"`
+	GraphQLEnabled    bool   `json:"graphql-enabled"`
+	// BuildDiagnosticsAPIEnabled adds debug_buildDiagnostics, explaining why
+	// block building is idle and why pending transactions are excluded. It
+	// requires [APIKeys], as the diagnostics expose the pending transactions.
+	BuildDiagnosticsAPIEnabled bool `json:"build-diagnostics-api-enabled"`
 
 	// EnabledEthAPIs is a list of Ethereum services that should be enabled
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
@@ -113,9 +142,17 @@ type Config struct {
 	SnapshotCache             int `json:"snapshot-cache"`              // Size of the snapshot disk layer clean cache (MB)
 
 	// Eth Settings
-	Preimages      bool `json:"preimages-enabled"`
-	SnapshotWait   bool `json:"snapshot-wait"`
-	SnapshotVerify bool `json:"snapshot-verification-enabled"`
+	Preimages       bool `json:"preimages-enabled"`
+	KeccakPreimages bool `json:"keccak-preimages-enabled"` // Records the inputs of the SHA3 opcode, used to resolve derived storage slots
+	StateDiffs      bool `json:"state-diffs-enabled"`
+
+	StateDiffsRetention uint64 `json:"state-diffs-retention"` // Number of recent accepted blocks whose state diffs are retained (0 = unlimited)
+
+	// Contract Gas Usage Index Settings
+	GasUsageIndexWindow    uint64 `json:"gas-usage-index-window"`    // Number of blocks per window of the contract gas usage index (0 = disabled)
+	GasUsageIndexRetention uint64 `json:"gas-usage-index-retention"` // Number of recent windows retained in the contract gas usage index
+	SnapshotWait           bool   `json:"snapshot-wait"`
+	SnapshotVerify         bool   `json:"snapshot-verification-enabled"`
 
 	// Pruning Settings
 	Pruning                         bool    `json:"pruning-enabled"`                    // If enabled, trie roots are only persisted every 4096 blocks
@@ -140,6 +177,22 @@ type Config struct {
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 	TxPoolLifetime     Duration `json:"tx-pool-lifetime"`
 
+	// TxPoolJournal is the file the local transactions are persisted to, so
+	// they are re-injected into the mempool and gossiped again on restart.
+	// Relative paths are resolved against the chain data directory. An empty
+	// path disables the journal. Requires [LocalTxsEnabled].
+	TxPoolJournal   string   `json:"tx-pool-journal"`
+	TxPoolRejournal Duration `json:"tx-pool-rejournal"`
+
+	// TxPoolPrioritySenders are the senders, e.g. relayers or oracles, whose
+	// transactions are never evicted from the mempool and are included first
+	// in the blocks built by this node.
+	TxPoolPrioritySenders []common.Address `json:"tx-pool-priority-senders"`
+
+	// BlobPoolRetention is the number of accepted blocks for which the blobs of
+	// included blob transactions are kept and served over the API.
+	BlobPoolRetention uint64 `json:"blob-pool-retention"`
+
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
 	WSCPUMaxStored           Duration      `json:"ws-cpu-max-stored"`
@@ -148,10 +201,26 @@ type Config struct {
 	AllowUnprotectedTxs      bool          `json:"allow-unprotected-txs"`
 	AllowUnprotectedTxHashes []common.Hash `json:"allow-unprotected-tx-hashes"`
 
+	// Per-method RPC limits protecting public RPC nodes from heavy queries.
+	// Requests exceeding a limit fail with a JSON-RPC error of code -32005
+	// carrying the name and the value of the limit (0 = unlimited).
+	MaxLogsPerRequest             int      `json:"api-max-logs-per-request"`
+	TraceTimeout                  Duration `json:"api-trace-timeout"`     // Timeout of traces not specifying one (0 = 5s)
+	MaxTraceTimeout               Duration `json:"api-max-trace-timeout"` // Maximum timeout a trace can specify
+	APIMaxConcurrentRequestsPerIP int      `json:"api-max-concurrent-requests-per-ip"`
+
+	// APIKeys restricts the chain RPC endpoints to the clients sending one of
+	// the keys in the X-API-Key header, or as a bearer token in the
+	// Authorization header (empty = no authentication).
+	APIKeys []APIKeyConfig `json:"api-keys"`
+
 	// Keystore Settings
 	KeystoreDirectory             string `json:"keystore-directory"` // both absolute and relative supported
 	KeystoreExternalSigner        string `json:"keystore-external-signer"`
 	KeystoreInsecureUnlockAllowed bool   `json:"keystore-insecure-unlock-allowed"`
+	// KeystoreExternalSignerHeaders are the HTTP headers sent to the external
+	// signer, such as the credentials of a remote signer.
+	KeystoreExternalSignerHeaders map[string]string `json:"keystore-external-signer-headers"`
 
 	// Gossip Settings
 	PushGossipNumValidators   int              `json:"push-gossip-num-validators"`
@@ -163,13 +232,86 @@ type Config struct {
 	RegossipFrequency         Duration         `json:"regossip-frequency"`
 	PriorityRegossipAddresses []common.Address `json:"priority-regossip-addresses"`
 
+	// Restricts the transaction gossip to the validators and the nodes of
+	// TxGossipAllowedNodeIDs, e.g. RPC nodes of a private subnet: transactions are
+	// only pushed to validators, and only the restricted set of nodes can pull them.
+	TxGossipValidatorsOnly bool         `json:"tx-gossip-validators-only"`
+	TxGossipAllowedNodeIDs []ids.NodeID `json:"tx-gossip-allowed-node-ids"`
+
+	// Sizing of the bloom filter of known transactions sent with pull gossip
+	// requests. A lower false positive rate suppresses more duplicate
+	// transactions in the responses at the cost of larger requests.
+	TxGossipBloomMinTargetElements       int     `json:"tx-gossip-bloom-min-target-elements"`
+	TxGossipBloomTargetFalsePositiveRate float64 `json:"tx-gossip-bloom-target-false-positive-rate"`
+	TxGossipBloomResetFalsePositiveRate  float64 `json:"tx-gossip-bloom-reset-false-positive-rate"`
+	TxGossipBloomChurnMultiplier         int     `json:"tx-gossip-bloom-churn-multiplier"`
+
+	// Bytes per second of tx gossip accepted from each peer, gossip messages
+	// over the budget are dropped (0 = unlimited)
+	TxGossipPeerBandwidth int `json:"tx-gossip-peer-bandwidth"`
+
+	// Push gossip is delayed until PushGossipBatchSize transactions are added or
+	// PushGossipMaxDelay elapsed since the last push, to send fewer but fuller
+	// messages (0 = push at every PushGossipFrequency tick)
+	PushGossipBatchSize int      `json:"push-gossip-batch-size"`
+	PushGossipMaxDelay  Duration `json:"push-gossip-max-delay"`
+
+	// Forwards the transactions issued over the RPC to the validators expected
+	// to propose a block in the next TxForwardingNumProposers proposer windows,
+	// in addition to the push gossip (0 = disabled)
+	TxForwardingNumProposers int `json:"tx-forwarding-num-proposers"`
+
+	// Frequency to compare the chain config hash with the one of a peer, logging
+	// a warning when they differ (0 = disabled)
+	ChainConfigCheckFrequency Duration `json:"chain-config-check-frequency"`
+
 	// Log
 	LogLevel      string `json:"log-level"`
 	LogJSONFormat bool   `json:"log-json-format"`
+	// LogSubsystemLevels sets the log level of subsystems (core, txpool, warp,
+	// sync) independently of LogLevel, e.g. {"txpool": "debug"}
+	LogSubsystemLevels map[string]string `json:"log-subsystem-levels"`
+	// LogFile is a file the logs are also written to in JSON, rotated once it
+	// exceeds LogFileMaxSize megabytes (empty = disabled)
+	LogFile        string `json:"log-file"`
+	LogFileMaxSize int    `json:"log-file-max-size"`
+	// Number of rotated log files kept and days they are kept for (0 = unlimited)
+	LogFileMaxBackups int  `json:"log-file-max-backups"`
+	LogFileMaxAge     int  `json:"log-file-max-age"`
+	LogFileCompress   bool `json:"log-file-compress"`
+
+	// Tracing exports spans of block building, verification, acceptance, state
+	// sync and warp signatures to an OTLP collector
+	TracingEnabled      bool              `json:"tracing-enabled"`
+	TracingExporterType string            `json:"tracing-exporter-type"` // "grpc" or "http"
+	TracingEndpoint     string            `json:"tracing-endpoint"`
+	TracingInsecure     bool              `json:"tracing-insecure"`
+	TracingHeaders      map[string]string `json:"tracing-headers"`
+	TracingSampleRate   float64           `json:"tracing-sample-rate"`
+
+	// Health check thresholds, crossing any of them reports the chain unhealthy
+	// (0 = check disabled)
+	HealthMaxLastAcceptedAge Duration `json:"health-max-last-accepted-age"`
+	HealthMaxTxPoolPending   int      `json:"health-max-txpool-pending"`
+	HealthMinPeers           int      `json:"health-min-peers"`
+	// Level 0 tables of the standalone leveldb database above which writes are
+	// stalled by compactions
+	HealthMaxDBLevel0Tables int `json:"health-max-db-level0-tables"`
 
 	// Address for Tx Fees (must be empty if not supported by blockchain)
 	FeeRecipient string `json:"feeRecipient"`
 
+	// Operator data included in the extra data of built blocks (omitted unless allowed by the blockchain)
+	BlockExtraData hexutil.Bytes `json:"block-extra-data"`
+
+	// Name of the ordering policy of the transactions of built blocks, among the
+	// policies registered with miner.RegisterOrderingPolicy (empty = by tip)
+	BlockBuilderOrdering string `json:"block-builder-ordering"`
+
+	// Wall-clock budget for packing transactions into a block, after which the
+	// partially built block is emitted (0 = unlimited)
+	BlockBuildTimeBudget Duration `json:"block-build-time-budget"`
+
 	// Offline Pruning Settings
 	OfflinePruning                bool   `json:"offline-pruning-enabled"`
 	OfflinePruningBloomFilterSize uint64 `json:"offline-pruning-bloom-filter-size"`
@@ -187,10 +329,51 @@ type Config struct {
 	StateSyncCommitInterval  uint64 `json:"state-sync-commit-interval"`
 	StateSyncMinBlocks       uint64 `json:"state-sync-min-blocks"`
 	StateSyncRequestSize     uint16 `json:"state-sync-request-size"`
+	// StateSyncIncremental re-syncs the state of a node with a complete local
+	// state (ie. that state synced before and fell behind) incrementally from
+	// it, only fetching the storage tries that changed since.
+	StateSyncIncremental bool `json:"state-sync-incremental"`
+	// StateSyncParallelism is the number of concurrent leaf requests. If
+	// StateSyncAdaptiveParallelism is set, it is the maximum number of
+	// concurrent leaf requests, which is adapted to the latency and failures
+	// of the requests to peers.
+	StateSyncParallelism         int  `json:"state-sync-parallelism"`
+	StateSyncAdaptiveParallelism bool `json:"state-sync-adaptive-parallelism"`
+	// StateSyncCodeBatchSize is the number of code hashes to request from a
+	// peer at a time. Values above 5 require peers to serve code in batches.
+	StateSyncCodeBatchSize int `json:"state-sync-code-batch-size"`
+	StateSyncCodeWorkers   int `json:"state-sync-code-workers"`
+	// StateSyncCheckpoint is a warp message attesting the hash of the block at
+	// StateSyncCheckpointHeight, signed by a quorum of the current validators
+	// of the chain. If the local state is behind it, the state is synced to
+	// this block instead of the latest summary accepted by the validators.
+	StateSyncCheckpoint       hexutil.Bytes `json:"state-sync-checkpoint"`
+	StateSyncCheckpointHeight uint64        `json:"state-sync-checkpoint-height"`
+
+	// StreamGRPCAddress is the address the gRPC service streaming the accepted
+	// blocks, receipts and precompile events to indexers listens on. The
+	// service is disabled if empty.
+	StreamGRPCAddress string `json:"stream-grpc-address"`
 
 	// Database Settings
 	InspectDatabase bool `json:"inspect-database"` // Inspects the database on startup if enabled.
 
+	// DatabaseType selects the key-value backend (ie. leveldb or pebble, or an
+	// externally registered backend) storing the chain data in a database of
+	// its own at DatabasePath (default: {chain-data-dir}/db), configured by the
+	// backend-specific DatabaseConfigFile. If empty, the chain data is stored
+	// in the database provided by the node.
+	DatabaseType       string `json:"database-type"`
+	DatabasePath       string `json:"database-path"`
+	DatabaseConfigFile string `json:"database-config-file"`
+	// DatabaseMigrate copies the chain data from the database provided by the
+	// node into the database selected by DatabaseType on its first startup.
+	DatabaseMigrate bool `json:"database-migrate"`
+	// DatabaseRestorePath is the directory of a backup made by the
+	// admin.backupDatabase API, restored into the empty database of the chain on
+	// its first startup.
+	DatabaseRestorePath string `json:"database-restore-path"`
+
 	// SkipUpgradeCheck disables checking that upgrades must take place before the last
 	// accepted block. Skipping this check is useful when a node operator does not update
 	// their node before the network upgrade and their node accepts blocks that have
@@ -215,11 +398,40 @@ type Config struct {
 	// TxLookupLimit can be still used to control unindexing old transactions.
 	SkipTxIndexing bool `json:"skip-tx-indexing"`
 
+	// ParallelTxExecution is the number of goroutines speculatively executing
+	// the transactions of a block in parallel when verifying it. Transactions
+	// conflicting with previous ones are re-executed in order.
+	//  * 0:   means transactions are executed sequentially
+	ParallelTxExecution int `json:"parallel-tx-execution"`
+
+	// AsyncStateCommit hashes and commits the state of verified blocks in the
+	// background. Verifying a block only executes it, while its state root is
+	// checked against its header at a checkpoint that building on, accepting or
+	// rejecting the block waits for. Accepting a block whose state root does not
+	// match fails.
+	AsyncStateCommit bool `json:"async-state-commit"`
+
+	// StateHistory is the number of recent accepted blocks whose state is
+	// retained in memory when pruning is enabled, so it can be queried (ie.
+	// with eth_call) without running an archive node. StateHistoryDuration
+	// additionally retains the state of the blocks accepted within that
+	// duration of the last accepted block. Older state is culled, unless it
+	// was already flushed to disk. Retained state is not preserved across
+	// restarts, and its memory is bounded by trie-dirty-cache.
+	//  * 0: means the default of 32 blocks
+	StateHistory         uint64   `json:"state-history"`
+	StateHistoryDuration Duration `json:"state-history-duration"`
+
 	// WarpOffChainMessages encodes off-chain messages (unrelated to any on-chain event ie. block or AddressedCall)
 	// that the node should be willing to sign.
 	// Note: only supports AddressedCall payloads as defined here:
 	// https://github.com/ava-labs/avalanchego/tree/7623ffd4be915a5185c9ed5e11fa9be15a6e1f00/vms/platformvm/warp/payload#addressedcall
 	WarpOffChainMessages []hexutil.Bytes `json:"warp-off-chain-messages"`
+
+	// WarpPrimaryNetworkSampleSize is the number of Primary Network validators, sampled by stake,
+	// the warp API requests signatures from when aggregating a message from the Primary Network.
+	// Each retry doubles the sample. If 0, signatures are requested from every validator.
+	WarpPrimaryNetworkSampleSize int `json:"warp-primary-network-sample-size"`
 }
 
 // EthAPIs returns an array of strings representing the Eth APIs that should be enabled
@@ -228,7 +440,7 @@ func (c Config) EthAPIs() []string {
 }
 
 func (c Config) EthBackendSettings() eth.Settings {
-	return eth.Settings{MaxBlocksPerRequest: c.MaxBlocksPerRequest}
+	return eth.Settings{MaxBlocksPerRequest: c.MaxBlocksPerRequest, MaxLogsPerRequest: c.MaxLogsPerRequest}
 }
 
 func (c *Config) SetDefaults() {
@@ -244,6 +456,8 @@ func (c *Config) SetDefaults() {
 	c.TxPoolAccountQueue = legacypool.DefaultConfig.AccountQueue
 	c.TxPoolGlobalQueue = legacypool.DefaultConfig.GlobalQueue
 	c.TxPoolLifetime.Duration = legacypool.DefaultConfig.Lifetime
+	c.TxPoolRejournal.Duration = legacypool.DefaultConfig.Rejournal
+	c.BlobPoolRetention = blobpool.DefaultConfig.Retention
 
 	c.APIMaxDuration.Duration = defaultApiMaxDuration
 	c.WSCPURefillRate.Duration = defaultWsCpuRefillRate
@@ -267,9 +481,19 @@ func (c *Config) SetDefaults() {
 	c.PushGossipFrequency.Duration = defaultPushGossipFrequency
 	c.PullGossipFrequency.Duration = defaultPullGossipFrequency
 	c.RegossipFrequency.Duration = defaultRegossipFrequency
+	c.TxGossipBloomMinTargetElements = defaultTxGossipBloomMinTargetElements
+	c.TxGossipBloomTargetFalsePositiveRate = defaultTxGossipBloomTargetFalsePositiveRate
+	c.TxGossipBloomResetFalsePositiveRate = defaultTxGossipBloomResetFalsePositiveRate
+	c.TxGossipBloomChurnMultiplier = defaultTxGossipBloomChurnMultiplier
 	c.OfflinePruningBloomFilterSize = defaultOfflinePruningBloomFilterSize
 	c.LogLevel = defaultLogLevel
 	c.LogJSONFormat = defaultLogJSONFormat
+	c.LogFileMaxSize = defaultLogFileMaxSize
+	c.LogFileMaxBackups = defaultLogFileMaxBackups
+	c.TracingExporterType = defaultTracingExporterType
+	c.TracingEndpoint = defaultTracingEndpoint
+	c.TracingSampleRate = defaultTracingSampleRate
+	c.HealthMaxDBLevel0Tables = defaultHealthMaxDBLevel0Tables
 	c.MaxOutboundActiveRequests = defaultMaxOutboundActiveRequests
 	c.MaxOutboundActiveCrossChainRequests = defaultMaxOutboundActiveCrossChainRequests
 	c.PopulateMissingTriesParallelism = defaultPopulateMissingTriesParallelism
@@ -277,8 +501,15 @@ func (c *Config) SetDefaults() {
 	c.StateSyncCommitInterval = defaultSyncableCommitInterval
 	c.StateSyncMinBlocks = defaultStateSyncMinBlocks
 	c.StateSyncRequestSize = defaultStateSyncRequestSize
+	c.StateSyncParallelism = statesync.DefaultParallelism
+	c.StateSyncCodeBatchSize = message.MaxCodeHashesPerRequest
+	c.StateSyncCodeWorkers = statesync.DefaultNumCodeFetchingWorkers
 	c.AllowUnprotectedTxHashes = defaultAllowUnprotectedTxHashes
 	c.AcceptedCacheSize = defaultAcceptedCacheSize
+	c.WarpPrimaryNetworkSampleSize = defaultWarpPrimaryNetworkSampleSize
+	c.GasUsageIndexRetention = defaultGasUsageIndexRetention
+	c.StateDiffsRetention = defaultStateDiffsRetention
+	c.ChainConfigCheckFrequency.Duration = defaultChainConfigCheckFrequency
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) (err error) {
@@ -316,6 +547,135 @@ func (c *Config) Validate() error {
 	if c.Pruning && c.CommitInterval == 0 {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
+	if !c.Pruning && (c.StateHistory != 0 || c.StateHistoryDuration.Duration != 0) {
+		return fmt.Errorf("cannot configure state history while pruning is disabled")
+	}
+	if c.DatabaseType != "" && !standalonedb.Exists(c.DatabaseType) {
+		return fmt.Errorf("unknown database type %q, must be one of %v", c.DatabaseType, standalonedb.Names())
+	}
+	if c.DatabaseType == "" && (c.DatabasePath != "" || c.DatabaseConfigFile != "" || c.DatabaseMigrate) {
+		return fmt.Errorf("cannot configure the database without a database type")
+	}
+	if c.DatabaseRestorePath != "" && c.DatabaseMigrate {
+		return fmt.Errorf("cannot both restore and migrate the database")
+	}
+	if len(c.KeystoreExternalSignerHeaders) > 0 && c.KeystoreExternalSigner == "" {
+		return fmt.Errorf("cannot set external signer headers without an external signer")
+	}
+	if c.StateHistoryDuration.Duration < 0 {
+		return fmt.Errorf("cannot use negative state history duration (%s)", c.StateHistoryDuration)
+	}
+	if c.ParallelTxExecution < 0 {
+		return fmt.Errorf("cannot use negative parallel tx execution (%d)", c.ParallelTxExecution)
+	}
+	if c.WarpPrimaryNetworkSampleSize < 0 {
+		return fmt.Errorf("cannot use negative warp primary network sample size (%d)", c.WarpPrimaryNetworkSampleSize)
+	}
+	if len(c.BlockExtraData) > params.MaxConfigurableOperatorDataSize {
+		return fmt.Errorf("block extra data length %d exceeds limit %d", len(c.BlockExtraData), params.MaxConfigurableOperatorDataSize)
+	}
+	if _, ok := miner.GetOrderingPolicy(c.BlockBuilderOrdering); !ok {
+		return fmt.Errorf("unknown block builder ordering %q, must be one of %v", c.BlockBuilderOrdering, miner.OrderingPolicyNames())
+	}
+	if c.BlockBuildTimeBudget.Duration < 0 {
+		return fmt.Errorf("cannot use negative block build time budget (%s)", c.BlockBuildTimeBudget)
+	}
+	if c.TxPoolJournal != "" && !c.LocalTxsEnabled {
+		return fmt.Errorf("cannot enable the tx pool journal (%s) while local txs are disabled", c.TxPoolJournal)
+	}
+	if len(c.StateSyncCheckpoint) > 0 && !c.StateSyncEnabled {
+		return fmt.Errorf("cannot configure a state sync checkpoint while state sync is disabled")
+	}
+	if len(c.StateSyncCheckpoint) > 0 && c.StateSyncCheckpointHeight == 0 {
+		return fmt.Errorf("cannot configure a state sync checkpoint without its block height")
+	}
+	if len(c.TxGossipAllowedNodeIDs) > 0 && !c.TxGossipValidatorsOnly {
+		return fmt.Errorf("cannot configure tx gossip allowed node IDs unless tx gossip is restricted to validators")
+	}
+	if c.TxGossipValidatorsOnly && (c.PushGossipNumValidators == 0 || c.PushRegossipNumValidators == 0) {
+		return fmt.Errorf("cannot restrict tx gossip to validators without gossiping to validators (push: %d, regossip: %d)", c.PushGossipNumValidators, c.PushRegossipNumValidators)
+	}
+	if c.TxGossipBloomMinTargetElements < 1 {
+		return fmt.Errorf("cannot use tx gossip bloom min target elements below 1 (%d)", c.TxGossipBloomMinTargetElements)
+	}
+	if c.TxGossipBloomTargetFalsePositiveRate <= 0 || c.TxGossipBloomTargetFalsePositiveRate > c.TxGossipBloomResetFalsePositiveRate || c.TxGossipBloomResetFalsePositiveRate >= 1 {
+		return fmt.Errorf("tx gossip bloom false positive rates must satisfy 0 < target (%v) <= reset (%v) < 1", c.TxGossipBloomTargetFalsePositiveRate, c.TxGossipBloomResetFalsePositiveRate)
+	}
+	if c.TxGossipBloomChurnMultiplier < 1 {
+		return fmt.Errorf("cannot use tx gossip bloom churn multiplier below 1 (%d)", c.TxGossipBloomChurnMultiplier)
+	}
+	if c.TxGossipPeerBandwidth < 0 {
+		return fmt.Errorf("cannot use negative tx gossip peer bandwidth (%d)", c.TxGossipPeerBandwidth)
+	}
+	if c.PushGossipBatchSize < 0 {
+		return fmt.Errorf("cannot use negative push gossip batch size (%d)", c.PushGossipBatchSize)
+	}
+	if c.PushGossipMaxDelay.Duration < 0 {
+		return fmt.Errorf("cannot use negative push gossip max delay (%s)", c.PushGossipMaxDelay)
+	}
+	if c.MaxLogsPerRequest < 0 {
+		return fmt.Errorf("cannot use negative api max logs per request (%d)", c.MaxLogsPerRequest)
+	}
+	if c.TraceTimeout.Duration < 0 || c.MaxTraceTimeout.Duration < 0 {
+		return fmt.Errorf("cannot use negative api trace timeouts (default: %s, max: %s)", c.TraceTimeout, c.MaxTraceTimeout)
+	}
+	if c.MaxTraceTimeout.Duration > 0 && c.TraceTimeout.Duration > c.MaxTraceTimeout.Duration {
+		return fmt.Errorf("cannot use an api trace timeout (%s) above the max trace timeout (%s)", c.TraceTimeout, c.MaxTraceTimeout)
+	}
+	if c.APIMaxConcurrentRequestsPerIP < 0 {
+		return fmt.Errorf("cannot use negative api max concurrent requests per IP (%d)", c.APIMaxConcurrentRequestsPerIP)
+	}
+	apiKeys := set.NewSet[string](len(c.APIKeys))
+	for i, apiKey := range c.APIKeys {
+		if apiKey.Key == "" {
+			return fmt.Errorf("cannot use an empty API key (api-keys[%d])", i)
+		}
+		if apiKeys.Contains(apiKey.Key) {
+			return fmt.Errorf("cannot configure the same API key twice (api-keys[%d])", i)
+		}
+		apiKeys.Add(apiKey.Key)
+		if apiKey.RateLimit < 0 {
+			return fmt.Errorf("cannot use negative API key rate limit (api-keys[%d]: %v)", i, apiKey.RateLimit)
+		}
+		if apiKey.RateLimit > 0 && apiKey.Burst < 1 {
+			return fmt.Errorf("cannot use API key burst below 1 with a rate limit (api-keys[%d]: %d)", i, apiKey.Burst)
+		}
+	}
+	if c.BuildDiagnosticsAPIEnabled && len(c.APIKeys) == 0 {
+		return fmt.Errorf("cannot enable the build diagnostics api without api keys")
+	}
+	if _, err := parseSubsystemLogLevels(c.LogSubsystemLevels); err != nil {
+		return fmt.Errorf("cannot use log subsystem levels: %w", err)
+	}
+	if c.LogFileMaxSize < 0 || c.LogFileMaxBackups < 0 || c.LogFileMaxAge < 0 {
+		return fmt.Errorf("cannot use negative log file max size (%d), max backups (%d) or max age (%d)", c.LogFileMaxSize, c.LogFileMaxBackups, c.LogFileMaxAge)
+	}
+	if c.TracingEnabled {
+		if _, err := trace.ExporterTypeFromString(c.TracingExporterType); err != nil {
+			return fmt.Errorf("cannot enable tracing: %w", err)
+		}
+		if c.TracingEndpoint == "" {
+			return fmt.Errorf("cannot enable tracing without a tracing endpoint")
+		}
+	}
+	if c.TracingSampleRate < 0 || c.TracingSampleRate > 1 {
+		return fmt.Errorf("tracing sample rate %v must be between 0 and 1", c.TracingSampleRate)
+	}
+	if c.HealthMaxLastAcceptedAge.Duration < 0 || c.HealthMaxTxPoolPending < 0 || c.HealthMinPeers < 0 || c.HealthMaxDBLevel0Tables < 0 {
+		return fmt.Errorf("cannot use negative health check thresholds")
+	}
+	if c.TxForwardingNumProposers < 0 || c.TxForwardingNumProposers > proposer.MaxBuildWindows {
+		return fmt.Errorf("tx forwarding num proposers %d must be between 0 and %d", c.TxForwardingNumProposers, proposer.MaxBuildWindows)
+	}
+	if c.StateSyncParallelism < 1 {
+		return fmt.Errorf("cannot use state sync parallelism below 1 (%d)", c.StateSyncParallelism)
+	}
+	if c.StateSyncCodeWorkers < 1 {
+		return fmt.Errorf("cannot use fewer than 1 state sync code worker (%d)", c.StateSyncCodeWorkers)
+	}
+	if c.StateSyncCodeBatchSize < 1 || c.StateSyncCodeBatchSize > message.MaxCodeHashesPerBatchRequest {
+		return fmt.Errorf("state sync code batch size %d must be between 1 and %d", c.StateSyncCodeBatchSize, message.MaxCodeHashesPerBatchRequest)
+	}
 
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -105,6 +106,81 @@ func TestUnmarshalConfig(t *testing.T) {
 			Config{AllowUnprotectedTxHashes: []common.Hash{common.HexToHash("0x803351deb6d745e91545a6a3e1c0ea3e9a6a02a1a4193b70edfcd2f40f71a01c")}},
 			false,
 		},
+		{
+			"tx gossip restricted to validators",
+			[]byte(fmt.Sprintf(`{"tx-gossip-validators-only": true, "tx-gossip-allowed-node-ids": ["%s"]}`, ids.EmptyNodeID)),
+			Config{TxGossipValidatorsOnly: true, TxGossipAllowedNodeIDs: []ids.NodeID{ids.EmptyNodeID}},
+			false,
+		},
+		{
+			"log subsystems and file",
+			[]byte(`{"log-subsystem-levels": {"txpool": "debug"}, "log-file": "/var/log/subnet-evm.log", "log-file-max-size": 10, "log-file-max-backups": 3, "log-file-max-age": 7, "log-file-compress": true}`),
+			Config{LogSubsystemLevels: map[string]string{"txpool": "debug"}, LogFile: "/var/log/subnet-evm.log", LogFileMaxSize: 10, LogFileMaxBackups: 3, LogFileMaxAge: 7, LogFileCompress: true},
+			false,
+		},
+		{
+			"tracing",
+			[]byte(`{"tracing-enabled": true, "tracing-exporter-type": "http", "tracing-endpoint": "collector:4318", "tracing-insecure": true, "tracing-headers": {"x-token": "secret"}, "tracing-sample-rate": 0.5}`),
+			Config{TracingEnabled: true, TracingExporterType: "http", TracingEndpoint: "collector:4318", TracingInsecure: true, TracingHeaders: map[string]string{"x-token": "secret"}, TracingSampleRate: 0.5},
+			false,
+		},
+		{
+			"health thresholds",
+			[]byte(`{"health-max-last-accepted-age": "1m", "health-max-txpool-pending": 5000, "health-min-peers": 3, "health-max-db-level0-tables": 8}`),
+			Config{HealthMaxLastAcceptedAge: Duration{time.Minute}, HealthMaxTxPoolPending: 5000, HealthMinPeers: 3, HealthMaxDBLevel0Tables: 8},
+			false,
+		},
+		{
+			"build diagnostics api enabled",
+			[]byte(`{"build-diagnostics-api-enabled": true}`),
+			Config{BuildDiagnosticsAPIEnabled: true},
+			false,
+		},
+		{
+			"graphql enabled",
+			[]byte(`{"graphql-enabled": true}`),
+			Config{GraphQLEnabled: true},
+			false,
+		},
+		{
+			"api keys",
+			[]byte(`{"api-keys": [{"key": "secret", "methods": ["eth_call", "net_*"], "rate-limit": 10, "burst": 20}]}`),
+			Config{APIKeys: []APIKeyConfig{{Key: "secret", Methods: []string{"eth_call", "net_*"}, RateLimit: 10, Burst: 20}}},
+			false,
+		},
+		{
+			"api limits",
+			[]byte(`{"api-max-logs-per-request": 10000, "api-trace-timeout": "10s", "api-max-trace-timeout": "1m", "api-max-concurrent-requests-per-ip": 8}`),
+			Config{MaxLogsPerRequest: 10000, TraceTimeout: Duration{10 * time.Second}, MaxTraceTimeout: Duration{time.Minute}, APIMaxConcurrentRequestsPerIP: 8},
+			false,
+		},
+		{
+			"database restore path",
+			[]byte(`{"database-restore-path": "/backups/chain"}`),
+			Config{DatabaseRestorePath: "/backups/chain"},
+			false,
+		},
+		{
+			"stream grpc address",
+			[]byte(`{"stream-grpc-address": "127.0.0.1:9660"}`),
+			Config{StreamGRPCAddress: "127.0.0.1:9660"},
+			false,
+		},
+		{
+			"keystore external signer headers",
+			[]byte(`{"keystore-external-signer": "https://signer:8550", "keystore-external-signer-headers": {"Authorization": "Bearer token"}}`),
+			Config{
+				KeystoreExternalSigner:        "https://signer:8550",
+				KeystoreExternalSignerHeaders: map[string]string{"Authorization": "Bearer token"},
+			},
+			false,
+		},
+		{
+			"tx gossip shaping",
+			[]byte(`{"tx-gossip-bloom-target-false-positive-rate": 0.001, "tx-gossip-peer-bandwidth": 1048576, "push-gossip-batch-size": 64, "push-gossip-max-delay": "500ms"}`),
+			Config{TxGossipBloomTargetFalsePositiveRate: 0.001, TxGossipPeerBandwidth: 1048576, PushGossipBatchSize: 64, PushGossipMaxDelay: Duration{500 * time.Millisecond}},
+			false,
+		},
 	}
 
 	for _, tt := range tests {
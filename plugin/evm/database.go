@@ -5,11 +5,22 @@ package evm
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/subnet-evm/plugin/evm/standalonedb"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// standaloneDBDir is the directory of the standalone database in the chain
+// data directory, unless configured otherwise.
+const standaloneDBDir = "db"
+
 var (
 	_ ethdb.KeyValueStore = &Database{}
 
@@ -62,3 +73,38 @@ func (batch Batch) ValueSize() int { return batch.Batch.Size() }
 
 // Replay implements ethdb.Batch
 func (batch Batch) Replay(w ethdb.KeyValueWriter) error { return batch.Batch.Replay(w) }
+
+// openStandaloneDatabase opens the database of the backend selected by
+// [DatabaseType], migrating the contents of the node provided [db] into it
+// first if configured to. The metrics of the backend are registered in [reg].
+func (vm *VM) openStandaloneDatabase(db database.Database, reg prometheus.Registerer) (database.Database, error) {
+	path := vm.config.DatabasePath
+	if path == "" {
+		path = filepath.Join(vm.ctx.ChainDataDir, standaloneDBDir)
+	}
+	var config []byte
+	if vm.config.DatabaseConfigFile != "" {
+		var err error
+		config, err = os.ReadFile(vm.config.DatabaseConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read database config file: %w", err)
+		}
+	}
+	standaloneDB, err := standalonedb.New(vm.config.DatabaseType, path, config, vm.ctx.Log, reg)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Opened standalone database", "type", vm.config.DatabaseType, "path", path)
+
+	if vm.config.DatabaseMigrate {
+		start := time.Now()
+		migrated, err := standalonedb.Migrate(db, standaloneDB, ethdb.IdealBatchSize)
+		if err != nil {
+			return nil, errors.Join(err, standaloneDB.Close())
+		}
+		if migrated {
+			log.Info("Migrated node database into standalone database", "type", vm.config.DatabaseType, "elapsed", time.Since(start))
+		}
+	}
+	return standaloneDB, nil
+}
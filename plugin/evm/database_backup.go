@@ -0,0 +1,201 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/plugin/evm/standalonedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// backupDBDir and backupManifestFile are the database and the manifest of
+	// a backup in its directory.
+	backupDBDir        = "db"
+	backupManifestFile = "manifest.json"
+)
+
+var (
+	errBackupExists         = errors.New("backup directory already exists")
+	errBackupChainMismatch  = errors.New("backup is of another chain")
+	errBackupNoLastAccepted = errors.New("database has no last accepted block")
+)
+
+// DatabaseBackupManifest describes the contents of a backup of the database of
+// the chain.
+type DatabaseBackupManifest struct {
+	ChainID ids.ID `json:"chainID"`
+	// Type is the backend of the database of the backup.
+	Type               string      `json:"type"`
+	LastAcceptedHash   common.Hash `json:"lastAcceptedHash"`
+	LastAcceptedHeight uint64      `json:"lastAcceptedHeight"`
+	Keys               int         `json:"keys"`
+	Time               time.Time   `json:"time"`
+	Version            string      `json:"version"`
+}
+
+type BackupDatabaseArgs struct {
+	// Path is the directory the backup is written to, which must not exist.
+	Path string `json:"path"`
+	// Type is the backend of the database of the backup. It defaults to the
+	// configured DatabaseType, or to leveldb if the chain is stored in the
+	// database provided by the node.
+	Type string `json:"type"`
+}
+
+type BackupDatabaseReply struct {
+	Manifest DatabaseBackupManifest `json:"manifest"`
+}
+
+// BackupDatabase copies the database of the chain into a new database at
+// [args.Path], while the node keeps running. The copy is read from a single
+// iterator, which the leveldb and pebble backends serve from a snapshot, so
+// the backup is as consistent as the database after a crash of the node. The
+// backup is restored with the database-restore-path config of a new node.
+func (p *Admin) BackupDatabase(_ *http.Request, args *BackupDatabaseArgs, reply *BackupDatabaseReply) error {
+	log.Info("Admin: BackupDatabase called", "path", args.Path, "type", args.Type)
+
+	if len(args.Path) == 0 {
+		return errors.New("cannot back up database without a path")
+	}
+	dbType := args.Type
+	if dbType == "" {
+		dbType = p.vm.config.DatabaseType
+	}
+	if dbType == "" || dbType == memdb.Name {
+		dbType = leveldb.Name
+	}
+	if _, err := os.Stat(args.Path); err == nil {
+		return fmt.Errorf("%w: %s", errBackupExists, args.Path)
+	}
+	if err := os.MkdirAll(args.Path, 0o755); err != nil {
+		return err
+	}
+	manifest, err := p.vm.backupDatabase(args.Path, dbType)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to back up database: %w", err), os.RemoveAll(args.Path))
+	}
+	reply.Manifest = *manifest
+	return nil
+}
+
+func (vm *VM) backupDatabase(path string, dbType string) (*DatabaseBackupManifest, error) {
+	start := time.Now()
+	backupDB, err := standalonedb.New(dbType, filepath.Join(path, backupDBDir), nil, vm.ctx.Log, prometheus.NewRegistry())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := standalonedb.Copy(vm.baseDB, backupDB, ethdb.IdealBatchSize)
+	if err != nil {
+		return nil, errors.Join(err, backupDB.Close())
+	}
+	// The last accepted block is read from the backup, which may be ahead of
+	// the block last accepted when the copy started.
+	lastAccepted, err := readLastAcceptedBlock(backupDB)
+	if err := errors.Join(err, backupDB.Close()); err != nil {
+		return nil, err
+	}
+
+	manifest := &DatabaseBackupManifest{
+		ChainID:            vm.ctx.ChainID,
+		Type:               dbType,
+		LastAcceptedHash:   lastAccepted.Hash(),
+		LastAcceptedHeight: lastAccepted.NumberU64(),
+		Keys:               keys,
+		Time:               vm.clock.Time().UTC(),
+		Version:            Version,
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(path, backupManifestFile), manifestBytes, 0o644); err != nil {
+		return nil, err
+	}
+	log.Info("Backed up database", "path", path, "type", dbType, "keys", keys, "height", manifest.LastAcceptedHeight, "elapsed", time.Since(start))
+	return manifest, nil
+}
+
+// restoreDatabase copies the backup at [DatabaseRestorePath] into [db] once, and
+// checks the restored database holds the last accepted block of the backup.
+// Restoring into a database the chain was already started with fails, and an
+// interrupted restore is restarted from scratch.
+func (vm *VM) restoreDatabase(db database.Database) error {
+	path := vm.config.DatabaseRestorePath
+	manifestBytes, err := os.ReadFile(filepath.Join(path, backupManifestFile))
+	if err != nil {
+		return fmt.Errorf("failed to read backup manifest: %w", err)
+	}
+	var manifest DatabaseBackupManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return fmt.Errorf("failed to parse backup manifest: %w", err)
+	}
+	if manifest.ChainID != vm.ctx.ChainID {
+		return fmt.Errorf("%w: %s != %s", errBackupChainMismatch, manifest.ChainID, vm.ctx.ChainID)
+	}
+
+	start := time.Now()
+	backupDB, err := standalonedb.New(manifest.Type, filepath.Join(path, backupDBDir), nil, vm.ctx.Log, prometheus.NewRegistry())
+	if err != nil {
+		return err
+	}
+	restored, err := standalonedb.Migrate(backupDB, db, ethdb.IdealBatchSize)
+	if err := errors.Join(err, backupDB.Close()); err != nil {
+		return err
+	}
+	if !restored {
+		return nil
+	}
+
+	lastAccepted, err := readLastAcceptedBlock(db)
+	if err != nil {
+		return err
+	}
+	if lastAccepted.Hash() != manifest.LastAcceptedHash || lastAccepted.NumberU64() != manifest.LastAcceptedHeight {
+		return fmt.Errorf("restored last accepted block %d (%s) does not match the backup manifest %d (%s)",
+			lastAccepted.NumberU64(), lastAccepted.Hash(), manifest.LastAcceptedHeight, manifest.LastAcceptedHash)
+	}
+	log.Info("Restored database", "path", path, "height", lastAccepted.NumberU64(), "hash", lastAccepted.Hash(), "elapsed", time.Since(start))
+	return nil
+}
+
+// readLastAcceptedBlock returns the last accepted block stored in [db], laid
+// out as the database of the VM.
+func readLastAcceptedBlock(db database.Database) (*types.Block, error) {
+	lastAcceptedBytes, err := prefixdb.New(acceptedPrefix, db).Get(lastAcceptedKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, errBackupNoLastAccepted
+	}
+	if err != nil {
+		return nil, err
+	}
+	hash := common.BytesToHash(lastAcceptedBytes)
+	chaindb := rawdb.NewDatabase(Database{prefixdb.NewNested(ethDBPrefix, db)})
+	height := rawdb.ReadHeaderNumber(chaindb, hash)
+	if height == nil {
+		return nil, fmt.Errorf("header number of last accepted block %s not found", hash)
+	}
+	block := rawdb.ReadBlock(chaindb, hash, *height)
+	if block == nil {
+		return nil, fmt.Errorf("last accepted block %d (%s) not found", *height, hash)
+	}
+	return block, nil
+}
@@ -0,0 +1,84 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseBackupRestore(t *testing.T) {
+	require := require.New(t)
+
+	issuer, vm, dbManager, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(err)
+	}
+	blk := issueAndAccept(t, issuer, vm)
+
+	// The database is backed up while the VM is running.
+	path := filepath.Join(t.TempDir(), "backup")
+	admin := NewAdminService(vm, t.TempDir())
+	reply := &BackupDatabaseReply{}
+	require.NoError(admin.BackupDatabase(nil, &BackupDatabaseArgs{Path: path}, reply))
+	require.Equal(vm.ctx.ChainID, reply.Manifest.ChainID)
+	require.Equal("leveldb", reply.Manifest.Type)
+	require.Equal(common.Hash(blk.ID()), reply.Manifest.LastAcceptedHash)
+	require.Equal(uint64(1), reply.Manifest.LastAcceptedHeight)
+	require.Positive(reply.Manifest.Keys)
+	require.FileExists(filepath.Join(path, backupManifestFile))
+	require.ErrorIs(admin.BackupDatabase(nil, &BackupDatabaseArgs{Path: path}, reply), errBackupExists)
+	require.NoError(vm.Shutdown(context.Background()))
+
+	initialize := func(db database.Database) (*VM, error) {
+		vm := &VM{}
+		return vm, vm.Initialize(
+			context.Background(),
+			NewContext(),
+			db,
+			buildGenesisTest(t, genesisJSONSubnetEVM),
+			[]byte(""),
+			[]byte(fmt.Sprintf(`{"database-restore-path":%q}`, path)),
+			make(chan commonEng.Message, 1),
+			[]*commonEng.Fx{},
+			nil,
+		)
+	}
+
+	// A new node continues from the last accepted block of the backup, and
+	// later restarts skip the restore.
+	restoredDB := memdb.New()
+	for i := 0; i < 2; i++ {
+		restoredVM, err := initialize(restoredDB)
+		require.NoError(err)
+		lastAccepted, err := restoredVM.LastAccepted(context.Background())
+		require.NoError(err)
+		require.Equal(blk.ID(), lastAccepted)
+		require.True(restoredVM.blockChain.HasState(restoredVM.blockChain.LastAcceptedBlock().Root()))
+		require.NoError(restoredVM.Shutdown(context.Background()))
+	}
+
+	// The backup is not restored over the database of a chain.
+	_, err = initialize(dbManager)
+	require.Error(err)
+
+	// The backup must match its manifest.
+	require.NoError(os.WriteFile(filepath.Join(path, backupManifestFile), []byte(`{"chainID":"11111111111111111111111111111111LpoYY","type":"leveldb"}`), 0o644))
+	_, err = initialize(memdb.New())
+	require.ErrorIs(err, errBackupChainMismatch)
+}
@@ -0,0 +1,119 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/internal/ethapi"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ava-labs/subnet-evm/signer/core/apitypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner serves the account API of clef, signing with a single key.
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSigner) Version() string {
+	return "6.0.0"
+}
+
+func (s *testSigner) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+func (s *testSigner) SignTransaction(args apitypes.SendTxArgs) (map[string]interface{}, error) {
+	if args.From.Address() != crypto.PubkeyToAddress(s.key.PublicKey) {
+		return nil, fmt.Errorf("unknown account %s", args.From.Address())
+	}
+	tx, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": tx}, nil
+}
+
+func TestExternalSignerSendTransaction(t *testing.T) {
+	require := require.New(t)
+
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("account", &testSigner{key: testKeys[0]}))
+	signer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer signer.Close()
+
+	// The node cannot start without the credentials of the signer.
+	unauthorizedVM := &VM{}
+	ctx, dbManager, genesisBytes, unauthorizedIssuer, _ := setupGenesis(t, genesisJSONSubnetEVM)
+	err := unauthorizedVM.Initialize(
+		context.Background(),
+		ctx,
+		dbManager,
+		genesisBytes,
+		[]byte(""),
+		[]byte(fmt.Sprintf(`{"keystore-external-signer": %q}`, signer.URL)),
+		unauthorizedIssuer,
+		[]*commonEng.Fx{},
+		&commonEng.SenderTest{T: t},
+	)
+	require.ErrorContains(err, "external signer")
+
+	configJSON := fmt.Sprintf(`{"keystore-external-signer": %q, "keystore-external-signer-headers": {"Authorization": "Bearer secret"}}`, signer.URL)
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, configJSON, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	var (
+		from     = testEthAddrs[0]
+		to       = testEthAddrs[1]
+		gas      = hexutil.Uint64(21000)
+		gasPrice = (*hexutil.Big)(big.NewInt(testMinGasPrice))
+		value    = (*hexutil.Big)(big.NewInt(1))
+	)
+	api := ethapi.NewTransactionAPI(vm.eth.APIBackend, new(ethapi.AddrLocker))
+	txHash, err := api.SendTransaction(context.Background(), ethapi.TransactionArgs{
+		From:     &from,
+		To:       &to,
+		Gas:      &gas,
+		GasPrice: gasPrice,
+		Value:    value,
+	})
+	require.NoError(err)
+
+	// Only the accounts of the signer can send transactions.
+	_, err = api.SendTransaction(context.Background(), ethapi.TransactionArgs{From: &to, To: &from, Gas: &gas, GasPrice: gasPrice, Value: value})
+	require.Error(err)
+
+	vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+	blk := issueAndAccept(t, issuer, vm)
+	ethBlock := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	require.Len(ethBlock.Transactions(), 1)
+	require.Equal(txHash, ethBlock.Transactions()[0].Hash())
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,7 +28,8 @@ import (
 const pendingTxsBuffer = 10
 
 var (
-	_ p2p.Handler = (*txGossipHandler)(nil)
+	_ p2p.Handler      = (*txGossipHandler)(nil)
+	_ p2p.ValidatorSet = (*txGossipSet)(nil)
 
 	_ gossip.Gossipable               = (*GossipEthTx)(nil)
 	_ gossip.Marshaller[*GossipEthTx] = (*GossipEthTxMarshaller)(nil)
@@ -44,7 +46,7 @@ func newTxGossipHandler[T gossip.Gossipable](
 	maxMessageSize int,
 	throttlingPeriod time.Duration,
 	throttlingLimit int,
-	validators *p2p.Validators,
+	validators p2p.ValidatorSet,
 ) txGossipHandler {
 	// push gossip messages can be handled from any peer
 	handler := gossip.NewHandler(
@@ -73,6 +75,24 @@ func newTxGossipHandler[T gossip.Gossipable](
 	}
 }
 
+// txGossipSet is the set of nodes allowed to pull transactions when the tx gossip is
+// restricted to validators: the validators and the allowed nodes.
+type txGossipSet struct {
+	validators p2p.ValidatorSet
+	allowed    set.Set[ids.NodeID]
+}
+
+func newTxGossipSet(validators p2p.ValidatorSet, allowed []ids.NodeID) txGossipSet {
+	return txGossipSet{
+		validators: validators,
+		allowed:    set.Of(allowed...),
+	}
+}
+
+func (s txGossipSet) Has(ctx context.Context, nodeID ids.NodeID) bool {
+	return s.allowed.Contains(nodeID) || s.validators.Has(ctx, nodeID)
+}
+
 type txGossipHandler struct {
 	appGossipHandler  p2p.Handler
 	appRequestHandler p2p.Handler
@@ -90,15 +110,22 @@ func (t txGossipHandler) CrossChainAppRequest(context.Context, ids.ID, time.Time
 }
 
 func NewGossipEthTxPool(mempool *txpool.TxPool, registerer prometheus.Registerer) (*GossipEthTxPool, error) {
-	bloom, err := gossip.NewBloomFilter(registerer, "eth_tx_bloom_filter", txGossipBloomMinTargetElements, txGossipBloomTargetFalsePositiveRate, txGossipBloomResetFalsePositiveRate)
+	return NewGossipEthTxPoolWithBloomConfig(mempool, registerer, DefaultTxGossipBloomConfig)
+}
+
+// NewGossipEthTxPoolWithBloomConfig returns a GossipEthTxPool whose bloom filter
+// is sized according to [config].
+func NewGossipEthTxPoolWithBloomConfig(mempool *txpool.TxPool, registerer prometheus.Registerer, config TxGossipBloomConfig) (*GossipEthTxPool, error) {
+	bloom, err := gossip.NewBloomFilter(registerer, "eth_tx_bloom_filter", config.MinTargetElements, config.TargetFalsePositiveRate, config.ResetFalsePositiveRate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize bloom filter: %w", err)
 	}
 
 	return &GossipEthTxPool{
-		mempool:    mempool,
-		pendingTxs: make(chan core.NewTxsEvent, pendingTxsBuffer),
-		bloom:      bloom,
+		mempool:         mempool,
+		pendingTxs:      make(chan core.NewTxsEvent, pendingTxsBuffer),
+		bloom:           bloom,
+		churnMultiplier: config.ChurnMultiplier,
 	}, nil
 }
 
@@ -106,8 +133,9 @@ type GossipEthTxPool struct {
 	mempool    *txpool.TxPool
 	pendingTxs chan core.NewTxsEvent
 
-	bloom *gossip.BloomFilter
-	lock  sync.RWMutex
+	bloom           *gossip.BloomFilter
+	churnMultiplier int
+	lock            sync.RWMutex
 }
 
 func (g *GossipEthTxPool) Subscribe(ctx context.Context) {
@@ -120,7 +148,7 @@ func (g *GossipEthTxPool) Subscribe(ctx context.Context) {
 			return
 		case pendingTxs := <-g.pendingTxs:
 			g.lock.Lock()
-			optimalElements := (g.mempool.PendingSize(false) + len(pendingTxs.Txs)) * txGossipBloomChurnMultiplier
+			optimalElements := (g.mempool.PendingSize(false) + len(pendingTxs.Txs)) * g.churnMultiplier
 			for _, pendingTx := range pendingTxs.Txs {
 				tx := &GossipEthTx{Tx: pendingTx}
 				g.bloom.Add(tx)
@@ -158,6 +186,16 @@ func (g *GossipEthTxPool) Has(txID ids.ID) bool {
 
 func (g *GossipEthTxPool) Iterate(f func(tx *GossipEthTx) bool) {
 	g.mempool.IteratePending(func(tx *txpool.Transaction) bool {
+		// Blobs are not part of the gossiped transaction, so peers could not
+		// add blob transactions to their mempool.
+		if tx.Tx.Type() == types.BlobTxType {
+			return true
+		}
+		// Peers would include conditional transactions without enforcing
+		// their conditions.
+		if tx.Tx.Conditional() != nil {
+			return true
+		}
 		return f(&GossipEthTx{Tx: tx.Tx})
 	})
 }
@@ -205,4 +243,10 @@ func (e *EthPushGossiper) Add(tx *types.Transaction) {
 		return
 	}
 	ethTxPushGossiper.Add(&GossipEthTx{tx})
+	if batcher := e.vm.ethTxPushBatcher.Get(); batcher != nil {
+		batcher.Add(1)
+	}
+	if forwarder := e.vm.ethTxForwarder.Get(); forwarder != nil {
+		forwarder.Add(tx)
+	}
 }
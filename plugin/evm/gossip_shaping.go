@@ -0,0 +1,164 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// peerBandwidthLimitersSize is the number of peers whose gossip bandwidth is tracked.
+// The budget of a peer evicted from the cache starts full again.
+const peerBandwidthLimitersSize = 4096
+
+var (
+	_ p2p.Handler     = (*bandwidthLimitedHandler)(nil)
+	_ gossip.Gossiper = (*batchingGossiper)(nil)
+)
+
+// DefaultTxGossipBloomConfig is the bloom filter config used by NewGossipEthTxPool.
+var DefaultTxGossipBloomConfig = TxGossipBloomConfig{
+	MinTargetElements:       txGossipBloomMinTargetElements,
+	TargetFalsePositiveRate: txGossipBloomTargetFalsePositiveRate,
+	ResetFalsePositiveRate:  txGossipBloomResetFalsePositiveRate,
+	ChurnMultiplier:         txGossipBloomChurnMultiplier,
+}
+
+// TxGossipBloomConfig tunes the bloom filter of the transactions known by the node,
+// which is sent with pull gossip requests so that peers only respond with the
+// transactions the node does not have. A lower false positive rate suppresses
+// more duplicates at the cost of a larger filter in every request.
+type TxGossipBloomConfig struct {
+	// MinTargetElements is the minimum number of transactions the filter is sized for.
+	MinTargetElements int
+	// TargetFalsePositiveRate is the false positive rate the filter is sized for.
+	TargetFalsePositiveRate float64
+	// ResetFalsePositiveRate is the false positive rate at which the filter is reset.
+	ResetFalsePositiveRate float64
+	// ChurnMultiplier is the ratio of the number of transactions the filter is sized
+	// for to the number of pending transactions, accounting for the transactions
+	// leaving the mempool but still in the filter.
+	ChurnMultiplier int
+}
+
+// bandwidthLimitedHandler is a p2p.Handler dropping the gossip messages of a peer once
+// the peer exceeds its bandwidth budget, so that a few peers cannot flood the node
+// during throughput spikes. Requests are not limited.
+type bandwidthLimitedHandler struct {
+	p2p.Handler
+
+	bytesPerSecond int
+	burst          int
+	limiters       cache.LRU[ids.NodeID, *rate.Limiter]
+
+	droppedMessages prometheus.Counter
+	droppedBytes    prometheus.Counter
+}
+
+// newBandwidthLimitedHandler returns a handler accepting [bytesPerSecond] of gossip
+// per peer, with bursts of up to one second of budget or one message of
+// [maxMessageSize], whichever is larger.
+func newBandwidthLimitedHandler(handler p2p.Handler, bytesPerSecond int, maxMessageSize int, registerer prometheus.Registerer) (*bandwidthLimitedHandler, error) {
+	h := &bandwidthLimitedHandler{
+		Handler:        handler,
+		bytesPerSecond: bytesPerSecond,
+		burst:          max(bytesPerSecond, maxMessageSize),
+		limiters:       cache.LRU[ids.NodeID, *rate.Limiter]{Size: peerBandwidthLimitersSize},
+		droppedMessages: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "eth_tx_gossip_throttled_messages",
+			Help: "number of tx gossip messages dropped because their peer exceeded its bandwidth budget",
+		}),
+		droppedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "eth_tx_gossip_throttled_bytes",
+			Help: "bytes of tx gossip messages dropped because their peer exceeded its bandwidth budget",
+		}),
+	}
+	for _, counter := range []prometheus.Counter{h.droppedMessages, h.droppedBytes} {
+		if err := registerer.Register(counter); err != nil {
+			return nil, fmt.Errorf("failed to register tx gossip bandwidth metrics: %w", err)
+		}
+	}
+	return h, nil
+}
+
+func (h *bandwidthLimitedHandler) limiter(nodeID ids.NodeID) *rate.Limiter {
+	if limiter, ok := h.limiters.Get(nodeID); ok {
+		return limiter
+	}
+	limiter := rate.NewLimiter(rate.Limit(h.bytesPerSecond), h.burst)
+	h.limiters.Put(nodeID, limiter)
+	return limiter
+}
+
+func (h *bandwidthLimitedHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	size := len(gossipBytes)
+	limiter := h.limiter(nodeID)
+	// Messages larger than the burst are accepted once the budget is full,
+	// consuming all of it, rather than being rejected forever.
+	if !limiter.AllowN(time.Now(), min(size, h.burst)) {
+		h.droppedMessages.Inc()
+		h.droppedBytes.Add(float64(size))
+		return
+	}
+	h.Handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+// batchingGossiper delays push gossip until [batchSize] transactions were added since
+// the last push or [maxDelay] elapsed, so that moderate bursts of transactions are
+// sent in a few full messages rather than in many small ones, while transactions
+// are still pushed at every tick once a batch fills up during throughput spikes.
+// Regossip is delayed along with the push gossip.
+type batchingGossiper struct {
+	gossiper  gossip.Gossiper
+	batchSize int64
+	maxDelay  time.Duration
+
+	added      atomic.Int64
+	lastGossip time.Time
+
+	delayed prometheus.Counter
+}
+
+func newBatchingGossiper(gossiper gossip.Gossiper, batchSize int, maxDelay time.Duration, registerer prometheus.Registerer) (*batchingGossiper, error) {
+	g := &batchingGossiper{
+		gossiper:  gossiper,
+		batchSize: int64(batchSize),
+		maxDelay:  maxDelay,
+		delayed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "eth_tx_push_gossip_delayed",
+			Help: "number of push gossip rounds delayed to batch more transactions",
+		}),
+	}
+	if err := registerer.Register(g.delayed); err != nil {
+		return nil, fmt.Errorf("failed to register push gossip batching metrics: %w", err)
+	}
+	return g, nil
+}
+
+// Add records that [n] transactions were added to the push gossiper.
+func (g *batchingGossiper) Add(n int) {
+	g.added.Add(int64(n))
+}
+
+// Gossip pushes the transactions added since the last push once a batch is full
+// or the oldest of them waited for the max delay.
+func (g *batchingGossiper) Gossip(ctx context.Context) error {
+	now := time.Now()
+	if g.added.Load() < g.batchSize && now.Sub(g.lastGossip) < g.maxDelay {
+		g.delayed.Inc()
+		return nil
+	}
+	g.added.Store(0)
+	g.lastGossip = now
+	return g.gossiper.Gossip(ctx)
+}
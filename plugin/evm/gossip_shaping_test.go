@@ -0,0 +1,107 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthLimitedHandler(t *testing.T) {
+	received := make(map[ids.NodeID]int)
+	handler, err := newBandwidthLimitedHandler(
+		p2p.TestHandler{
+			AppGossipF: func(_ context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+				received[nodeID] += len(gossipBytes)
+			},
+		},
+		100,
+		10,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	nodeID0, nodeID1 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	for i := 0; i < 20; i++ {
+		handler.AppGossip(context.Background(), nodeID0, make([]byte, 10))
+	}
+	handler.AppGossip(context.Background(), nodeID1, make([]byte, 10))
+
+	// The budget of a peer is not shared with the other peers
+	require.LessOrEqual(t, received[nodeID0], 110)
+	require.GreaterOrEqual(t, received[nodeID0], 100)
+	require.Equal(t, 10, received[nodeID1])
+	dropped := testutil.ToFloat64(handler.droppedMessages)
+	require.GreaterOrEqual(t, dropped, float64(9))
+	require.Equal(t, dropped*10, testutil.ToFloat64(handler.droppedBytes))
+}
+
+func TestBandwidthLimitedHandlerLargeMessage(t *testing.T) {
+	var received int
+	handler, err := newBandwidthLimitedHandler(
+		p2p.TestHandler{
+			AppGossipF: func(_ context.Context, _ ids.NodeID, gossipBytes []byte) {
+				received += len(gossipBytes)
+			},
+		},
+		10,
+		100,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	// Messages up to the max message size are accepted even if the per second
+	// budget is smaller
+	nodeID := ids.GenerateTestNodeID()
+	handler.AppGossip(context.Background(), nodeID, make([]byte, 100))
+	require.Equal(t, 100, received)
+	handler.AppGossip(context.Background(), nodeID, make([]byte, 100))
+	require.Equal(t, 100, received)
+}
+
+func TestBatchingGossiper(t *testing.T) {
+	var gossiped int
+	batcher, err := newBatchingGossiper(
+		&gossip.TestGossiper{
+			GossipF: func(context.Context) error {
+				gossiped++
+				return nil
+			},
+		},
+		3,
+		time.Hour,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	// The first round is not delayed since no push happened for longer than the max delay
+	require.NoError(t, batcher.Gossip(context.Background()))
+	require.Equal(t, 1, gossiped)
+
+	batcher.Add(2)
+	require.NoError(t, batcher.Gossip(context.Background()))
+	require.Equal(t, 1, gossiped)
+	require.Equal(t, float64(1), testutil.ToFloat64(batcher.delayed))
+
+	batcher.Add(1)
+	require.NoError(t, batcher.Gossip(context.Background()))
+	require.Equal(t, 2, gossiped)
+
+	// The batch is emptied by the push
+	require.NoError(t, batcher.Gossip(context.Background()))
+	require.Equal(t, 2, gossiped)
+
+	// The push is no longer delayed once the max delay elapsed
+	batcher.lastGossip = time.Now().Add(-time.Hour)
+	require.NoError(t, batcher.Gossip(context.Background()))
+	require.Equal(t, 3, gossiped)
+}
@@ -9,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
@@ -106,3 +108,24 @@ func setupPoolWithConfig(t *testing.T, config *params.ChainConfig, fundedAddress
 
 	return txPool
 }
+
+type testValidatorSet struct {
+	validators set.Set[ids.NodeID]
+}
+
+func (v testValidatorSet) Has(_ context.Context, nodeID ids.NodeID) bool {
+	return v.validators.Contains(nodeID)
+}
+
+func TestTxGossipSet(t *testing.T) {
+	var (
+		validator = ids.GenerateTestNodeID()
+		allowed   = ids.GenerateTestNodeID()
+		other     = ids.GenerateTestNodeID()
+	)
+	gossipSet := newTxGossipSet(testValidatorSet{validators: set.Of(validator)}, []ids.NodeID{allowed})
+
+	require.True(t, gossipSet.Has(context.Background(), validator))
+	require.True(t, gossipSet.Has(context.Background(), allowed))
+	require.False(t, gossipSet.Has(context.Background(), other))
+}
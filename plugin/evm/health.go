@@ -3,12 +3,192 @@
 
 package evm
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// Names of the metrics of the standalone leveldb database reporting its
+	// compaction pressure
+	dbTableCountMetric   = "table_count"
+	dbWriteDelayedMetric = "write_delayed"
+)
+
+var (
+	errLastAcceptedTooOld = errors.New("last accepted block is too old")
+	errTxPoolBacklog      = errors.New("too many pending transactions in the txpool")
+	errStateSyncFailed    = errors.New("state sync failed")
+	errCompactionPressure = errors.New("too many level 0 tables in the database")
+	errDatabaseUnhealthy  = errors.New("database is unhealthy")
+	errNotEnoughPeers     = errors.New("not enough connected peers")
+)
+
+type lastAcceptedHealth struct {
+	Height uint64 `json:"height"`
+	Age    string `json:"age"`
+}
+
+type txPoolHealth struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+}
+
+type stateSyncHealth struct {
+	Enabled      bool   `json:"enabled"`
+	Bootstrapped bool   `json:"bootstrapped"`
+	Error        string `json:"error,omitempty"`
+}
+
+type databaseHealth struct {
+	Level0Tables int  `json:"level0Tables"`
+	WriteDelayed bool `json:"writeDelayed"`
+}
+
+type peersHealth struct {
+	Connected uint32 `json:"connected"`
+}
 
 // Health returns nil if this chain is healthy.
-// Also returns details, which should be one of:
-// string, []byte, map[string]string
+// Also returns details, which report the last accepted block, the txpool
+// backlog, the state sync status, the compaction pressure of the standalone
+// database and the connected peers, and the incident response controls of the
+// node while any is active. The chain is unhealthy once any of the thresholds
+// configured for these checks is crossed, failing the health of the node.
 func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
-	// TODO perform actual health check
-	return nil, nil
+	details := make(map[string]interface{})
+	var errs []error
+	for _, check := range []struct {
+		name string
+		fn   func() (interface{}, error)
+	}{
+		{"lastAccepted", vm.lastAcceptedHealth},
+		{"txPool", vm.txPoolHealth},
+		{"stateSync", vm.stateSyncHealth},
+		{"database", vm.databaseHealth},
+		{"peers", vm.peersHealth},
+	} {
+		result, err := check.fn()
+		if result != nil {
+			details[check.name] = result
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", check.name, err))
+		}
+	}
+
+	status := vm.circuitBreaker.status()
+	if status.BlockProductionHalted || status.Tripped {
+		details["circuitBreaker"] = map[string]string{
+			"blockProductionHalted": fmt.Sprint(status.BlockProductionHalted),
+			"haltReason":            status.HaltReason,
+			"circuitBreakerTripped": fmt.Sprint(status.Tripped),
+			"tripReason":            status.TripReason,
+		}
+	}
+	return details, errors.Join(errs...)
+}
+
+func (vm *VM) lastAcceptedHealth() (interface{}, error) {
+	header := vm.blockChain.LastAcceptedBlock().Header()
+	age := vm.clock.Time().Sub(time.Unix(int64(header.Time), 0))
+	result := lastAcceptedHealth{
+		Height: header.Number.Uint64(),
+		Age:    age.String(),
+	}
+	// The age of the last accepted block is only meaningful once the chain is
+	// following the network.
+	if maxAge := vm.config.HealthMaxLastAcceptedAge.Duration; vm.bootstrapped && maxAge > 0 && age > maxAge {
+		return result, fmt.Errorf("%w: %s > %s", errLastAcceptedTooOld, age, maxAge)
+	}
+	return result, nil
+}
+
+func (vm *VM) txPoolHealth() (interface{}, error) {
+	pending, queued := vm.txPool.Stats()
+	result := txPoolHealth{
+		Pending: pending,
+		Queued:  queued,
+	}
+	if maxPending := vm.config.HealthMaxTxPoolPending; maxPending > 0 && pending > maxPending {
+		return result, fmt.Errorf("%w: %d > %d", errTxPoolBacklog, pending, maxPending)
+	}
+	return result, nil
+}
+
+func (vm *VM) stateSyncHealth() (interface{}, error) {
+	result := stateSyncHealth{
+		Enabled:      vm.config.StateSyncEnabled,
+		Bootstrapped: vm.bootstrapped,
+	}
+	if err := vm.StateSyncClient.Error(); err != nil {
+		result.Error = err.Error()
+		return result, fmt.Errorf("%w: %w", errStateSyncFailed, err)
+	}
+	return result, nil
+}
+
+// databaseHealth reports the compaction pressure of the standalone database,
+// read from its metrics. It returns no details when the chain is stored in the
+// database of the node, which reports its own health.
+func (vm *VM) databaseHealth() (interface{}, error) {
+	if vm.standaloneDB == nil {
+		return nil, nil
+	}
+	if _, err := vm.standaloneDB.HealthCheck(context.Background()); err != nil {
+		return nil, fmt.Errorf("%w: %w", errDatabaseUnhealthy, err)
+	}
+	families, err := vm.dbMetrics.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather database metrics: %w", err)
+	}
+	result, ok := parseDatabaseHealth(families)
+	if !ok {
+		// Only the leveldb backend reports its compaction pressure.
+		return nil, nil
+	}
+	if maxTables := vm.config.HealthMaxDBLevel0Tables; maxTables > 0 && result.Level0Tables > maxTables {
+		return result, fmt.Errorf("%w: %d > %d", errCompactionPressure, result.Level0Tables, maxTables)
+	}
+	return result, nil
+}
+
+// parseDatabaseHealth returns the compaction pressure reported by the leveldb
+// metrics in [families], and false if there are none.
+func parseDatabaseHealth(families []*dto.MetricFamily) (databaseHealth, bool) {
+	var (
+		result databaseHealth
+		found  bool
+	)
+	for _, family := range families {
+		switch family.GetName() {
+		case dbTableCountMetric:
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "level" && label.GetValue() == "0" {
+						result.Level0Tables = int(metric.GetGauge().GetValue())
+						found = true
+					}
+				}
+			}
+		case dbWriteDelayedMetric:
+			for _, metric := range family.GetMetric() {
+				result.WriteDelayed = metric.GetGauge().GetValue() > 0
+			}
+		}
+	}
+	return result, found
+}
+
+func (vm *VM) peersHealth() (interface{}, error) {
+	connected := vm.Network.Size()
+	result := peersHealth{Connected: connected}
+	if minPeers := vm.config.HealthMinPeers; minPeers > 0 && int(connected) < minPeers {
+		return result, fmt.Errorf("%w: %d < %d", errNotEnoughPeers, connected, minPeers)
+	}
+	return result, nil
 }
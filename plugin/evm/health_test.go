@@ -0,0 +1,66 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	require := require.New(t)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, `{"health-max-txpool-pending": 1, "health-min-peers": 1}`, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	health, err := vm.HealthCheck(context.Background())
+	require.ErrorIs(err, errNotEnoughPeers)
+	require.NotErrorIs(err, errTxPoolBacklog)
+	details := health.(map[string]interface{})
+	require.Zero(details["lastAccepted"].(lastAcceptedHealth).Height)
+	require.Equal(txPoolHealth{}, details["txPool"])
+	require.Equal(stateSyncHealth{Bootstrapped: true}, details["stateSync"])
+	require.Equal(peersHealth{}, details["peers"])
+	require.NotContains(details, "database")
+
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx := types.NewTransaction(nonce, common.Address{1}, big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[0])
+		require.NoError(err)
+		require.NoError(vm.txPool.AddRemotesSync([]*types.Transaction{signedTx})[0])
+	}
+	health, err = vm.HealthCheck(context.Background())
+	require.ErrorIs(err, errTxPoolBacklog)
+	require.Equal(txPoolHealth{Pending: 2}, health.(map[string]interface{})["txPool"])
+}
+
+func TestParseDatabaseHealth(t *testing.T) {
+	require := require.New(t)
+
+	reg := prometheus.NewRegistry()
+	_, ok := parseDatabaseHealth(nil)
+	require.False(ok)
+
+	tableCount := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: dbTableCountMetric}, []string{"level"})
+	writeDelayed := prometheus.NewGauge(prometheus.GaugeOpts{Name: dbWriteDelayedMetric})
+	require.NoError(reg.Register(tableCount))
+	require.NoError(reg.Register(writeDelayed))
+	tableCount.WithLabelValues("0").Set(13)
+	tableCount.WithLabelValues("1").Set(100)
+	writeDelayed.Set(1)
+
+	families, err := reg.Gather()
+	require.NoError(err)
+	result, ok := parseDatabaseHealth(families)
+	require.True(ok)
+	require.Equal(databaseHealth{Level0Tables: 13, WriteDelayed: true}, result)
+}
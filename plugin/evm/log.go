@@ -8,6 +8,10 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -18,13 +22,30 @@ const (
 	timeFormat = "2006-01-02T15:04:05-0700"
 )
 
+// logSubsystems are the subsystems whose log level can be set independently of
+// the global one, with the patterns of their source files in the syntax of
+// log.GlogHandler.Vmodule. The first subsystem matching a file takes precedence.
+var logSubsystems = []logSubsystem{
+	{name: "txpool", patterns: []string{"core/txpool/*"}},
+	{name: "core", patterns: []string{"core/*", "consensus/*"}},
+	{name: "warp", patterns: []string{"warp/*"}},
+	{name: "sync", patterns: []string{"sync/*", "plugin/evm/syncervm_client.go", "plugin/evm/syncervm_server.go"}},
+}
+
 type SubnetEVMLogger struct {
 	log.Handler
+
+	// glogger filters the records by level, globally and per package.
+	glogger *log.GlogHandler
+	// subsystems filters the records of the subsystems with their own level
+	// before [glogger].
+	subsystems *subsystemHandler
 }
 
 // InitLogger initializes logger with alias and sets the log level and format with the original [os.StdErr] interface
-// along with the context logger.
-func InitLogger(alias string, level string, jsonFormat bool, writer io.Writer) (SubnetEVMLogger, error) {
+// along with the context logger. If [file] is not nil, the records are also
+// written to it in JSON.
+func InitLogger(alias string, level string, jsonFormat bool, writer io.Writer, file io.Writer) (SubnetEVMLogger, error) {
 	logFormat := SubnetEVMTermFormat(alias)
 	if jsonFormat {
 		logFormat = SubnetEVMJSONFormat(alias)
@@ -32,11 +53,20 @@ func InitLogger(alias string, level string, jsonFormat bool, writer io.Writer) (
 
 	// Create handler
 	logHandler := log.StreamHandler(writer, logFormat)
-	c := SubnetEVMLogger{Handler: logHandler}
+	if file != nil {
+		logHandler = log.MultiHandler(logHandler, log.StreamHandler(file, SubnetEVMJSONFormat(alias)))
+	}
+	glogger := log.NewGlogHandler(logHandler)
+	c := SubnetEVMLogger{
+		Handler:    logHandler,
+		glogger:    glogger,
+		subsystems: newSubsystemHandler(logHandler, glogger, logSubsystems),
+	}
 
 	if err := c.SetLogLevel(level); err != nil {
 		return SubnetEVMLogger{}, err
 	}
+	log.Root().SetHandler(c.subsystems)
 	return c, nil
 }
 
@@ -47,7 +77,37 @@ func (c *SubnetEVMLogger) SetLogLevel(level string) error {
 	if err != nil {
 		return err
 	}
-	log.Root().SetHandler(log.LvlFilterHandler(logLevel, c))
+	c.glogger.Verbosity(logLevel)
+	return nil
+}
+
+// SetModuleLogLevels raises the log level of the packages matching the patterns
+// of [levels] above the level set by SetLogLevel, e.g. {"core/txpool/*": "debug"}.
+// The patterns follow the syntax of log.GlogHandler.Vmodule. Each call replaces
+// the levels set by the previous one, so an empty [levels] resets them.
+func (c *SubnetEVMLogger) SetModuleLogLevels(levels map[string]string) error {
+	patterns := make([]string, 0, len(levels))
+	for pattern, level := range levels {
+		logLevel, err := log.LvlFromString(level)
+		if err != nil {
+			return fmt.Errorf("invalid log level of %q: %w", pattern, err)
+		}
+		patterns = append(patterns, fmt.Sprintf("%s=%d", pattern, logLevel))
+	}
+	sort.Strings(patterns)
+	return c.glogger.Vmodule(strings.Join(patterns, ","))
+}
+
+// SetSubsystemLogLevels sets the log level of the subsystems of [levels], e.g.
+// {"txpool": "debug", "sync": "warn"}, which replaces the global and package
+// levels for their records. Each call replaces the levels set by the previous
+// one, so an empty [levels] resets them.
+func (c *SubnetEVMLogger) SetSubsystemLogLevels(levels map[string]string) error {
+	logLevels, err := parseSubsystemLogLevels(levels)
+	if err != nil {
+		return err
+	}
+	c.subsystems.setLevels(logLevels)
 	return nil
 }
 
@@ -55,9 +115,10 @@ func SubnetEVMTermFormat(alias string) log.Format {
 	prefix := fmt.Sprintf("<%s Chain>", alias)
 	return log.FormatFunc(func(r *log.Record) []byte {
 		location := fmt.Sprintf("%+v", r.Call)
-		newMsg := fmt.Sprintf("%s %s: %s", prefix, location, r.Msg)
-		r.Msg = newMsg
-		return log.TerminalFormat(false).Format(r)
+		// Format a copy so the other handlers of the record get its message
+		record := *r
+		record.Msg = fmt.Sprintf("%s %s: %s", prefix, location, r.Msg)
+		return log.TerminalFormat(false).Format(&record)
 	})
 }
 
@@ -119,3 +180,121 @@ func formatJSONValue(value interface{}) (result interface{}) {
 		return v
 	}
 }
+
+type logSubsystem struct {
+	name     string
+	patterns []string
+}
+
+// parseSubsystemLogLevels parses the levels of the subsystems of [levels].
+func parseSubsystemLogLevels(levels map[string]string) (map[string]log.Lvl, error) {
+	logLevels := make(map[string]log.Lvl, len(levels))
+	for name, level := range levels {
+		known := false
+		for _, subsystem := range logSubsystems {
+			known = known || subsystem.name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown log subsystem %q", name)
+		}
+		logLevel, err := log.LvlFromString(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level of subsystem %q: %w", name, err)
+		}
+		logLevels[name] = logLevel
+	}
+	return logLevels, nil
+}
+
+// subsystemHandler writes the records logged by the subsystems with a level
+// to [origin] if they are within it, and leaves the other records to [next].
+// Unlike log.GlogHandler, it can lower the level of a subsystem below the
+// global one.
+type subsystemHandler struct {
+	origin log.Handler
+	next   log.Handler
+
+	subsystems []logSubsystem
+	matchers   [][]*regexp.Regexp
+
+	lock      sync.RWMutex
+	levels    map[string]log.Lvl
+	siteCache map[uintptr]string // subsystem of each call site
+}
+
+func newSubsystemHandler(origin, next log.Handler, subsystems []logSubsystem) *subsystemHandler {
+	h := &subsystemHandler{
+		origin:     origin,
+		next:       next,
+		subsystems: subsystems,
+		matchers:   make([][]*regexp.Regexp, len(subsystems)),
+		siteCache:  make(map[uintptr]string),
+	}
+	for i, subsystem := range subsystems {
+		for _, pattern := range subsystem.patterns {
+			h.matchers[i] = append(h.matchers[i], compileLogPattern(pattern))
+		}
+	}
+	return h
+}
+
+// compileLogPattern compiles [pattern] as log.GlogHandler.Vmodule does.
+func compileLogPattern(pattern string) *regexp.Regexp {
+	matcher := ".*"
+	for _, comp := range strings.Split(pattern, "/") {
+		if comp == "*" {
+			matcher += "(/.*)?"
+		} else if comp != "" {
+			matcher += "/" + regexp.QuoteMeta(comp)
+		}
+	}
+	if !strings.HasSuffix(pattern, ".go") {
+		matcher += "/[^/]+\\.go"
+	}
+	return regexp.MustCompile(matcher + "$")
+}
+
+func (h *subsystemHandler) setLevels(levels map[string]log.Lvl) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.levels = levels
+}
+
+// subsystem returns the subsystem of the source file at [path], or the empty
+// string if it is not part of any.
+func (h *subsystemHandler) subsystem(path string) string {
+	for i, matchers := range h.matchers {
+		for _, matcher := range matchers {
+			if matcher.MatchString(path) {
+				return h.subsystems[i].name
+			}
+		}
+	}
+	return ""
+}
+
+func (h *subsystemHandler) Log(r *log.Record) error {
+	h.lock.RLock()
+	levels := h.levels
+	subsystem, ok := h.siteCache[r.Call.Frame().PC]
+	h.lock.RUnlock()
+	if len(levels) == 0 {
+		return h.next.Log(r)
+	}
+
+	if !ok {
+		subsystem = h.subsystem(fmt.Sprintf("%+s", r.Call))
+		h.lock.Lock()
+		h.siteCache[r.Call.Frame().PC] = subsystem
+		h.lock.Unlock()
+	}
+	level, ok := levels[subsystem]
+	if !ok {
+		return h.next.Log(r)
+	}
+	if level >= r.Lvl {
+		return h.origin.Log(r)
+	}
+	return nil
+}
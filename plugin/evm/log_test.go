@@ -0,0 +1,100 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetModuleLogLevels(t *testing.T) {
+	require := require.New(t)
+
+	root := log.Root().GetHandler()
+	t.Cleanup(func() { log.Root().SetHandler(root) })
+
+	var buf bytes.Buffer
+	logger, err := InitLogger("test", "info", false, &buf, nil)
+	require.NoError(err)
+
+	log.Debug("filtered")
+	require.Empty(buf.String())
+
+	require.NoError(logger.SetModuleLogLevels(map[string]string{"plugin/evm/*": "debug"}))
+	log.Debug("raised")
+	log.Trace("filtered")
+	require.Contains(buf.String(), "raised")
+	require.NotContains(buf.String(), "filtered")
+
+	buf.Reset()
+	require.NoError(logger.SetModuleLogLevels(map[string]string{"core/*": "trace"}))
+	log.Debug("filtered")
+	require.Empty(buf.String())
+
+	require.Error(logger.SetModuleLogLevels(map[string]string{"core/*": "loud"}))
+}
+
+func TestLogSubsystems(t *testing.T) {
+	h := newSubsystemHandler(nil, nil, logSubsystems)
+	for path, want := range map[string]string{
+		"github.com/ava-labs/subnet-evm/core/txpool/legacypool/legacypool.go": "txpool",
+		"github.com/ava-labs/subnet-evm/core/blockchain.go":                   "core",
+		"github.com/ava-labs/subnet-evm/consensus/dummy/consensus.go":         "core",
+		"github.com/ava-labs/subnet-evm/warp/backend.go":                      "warp",
+		"github.com/ava-labs/subnet-evm/sync/statesync/sync_helpers.go":       "sync",
+		"github.com/ava-labs/subnet-evm/plugin/evm/syncervm_client.go":        "sync",
+		"github.com/ava-labs/subnet-evm/plugin/evm/vm.go":                     "",
+		"github.com/ava-labs/subnet-evm/eth/backend.go":                       "",
+	} {
+		require.Equal(t, want, h.subsystem(path), path)
+	}
+}
+
+func TestSetSubsystemLogLevels(t *testing.T) {
+	require := require.New(t)
+
+	root := log.Root().GetHandler()
+	t.Cleanup(func() { log.Root().SetHandler(root) })
+
+	var buf, file bytes.Buffer
+	logger, err := InitLogger("test", "info", false, &buf, &file)
+	require.NoError(err)
+	// Make the records of this file part of a subsystem
+	logger.subsystems = newSubsystemHandler(logger.Handler, logger.glogger, []logSubsystem{
+		{name: "sync", patterns: []string{"plugin/evm/log_test.go"}},
+	})
+	log.Root().SetHandler(logger.subsystems)
+
+	// The level of a subsystem can be raised and lowered
+	require.NoError(logger.SetSubsystemLogLevels(map[string]string{"sync": "debug"}))
+	log.Debug("raised")
+	log.Trace("filtered")
+	require.Contains(buf.String(), "raised")
+	require.NotContains(buf.String(), "filtered")
+
+	buf.Reset()
+	require.NoError(logger.SetSubsystemLogLevels(map[string]string{"sync": "error"}))
+	log.Warn("filtered")
+	require.Empty(buf.String())
+
+	require.NoError(logger.SetSubsystemLogLevels(nil))
+	log.Warn("global")
+	require.Contains(buf.String(), "global")
+
+	// The file gets the records in JSON, with the original message
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	require.Len(lines, 2)
+	var record map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(lines[1]), &record))
+	require.Equal("global", record["msg"])
+	require.Equal("test Chain", record["logger"])
+
+	require.ErrorContains(logger.SetSubsystemLogLevels(map[string]string{"p2p": "debug"}), "unknown log subsystem")
+	require.Error(logger.SetSubsystemLogLevels(map[string]string{"sync": "loud"}))
+}
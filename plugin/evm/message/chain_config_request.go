@@ -0,0 +1,35 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ Request = ChainConfigHashRequest{}
+
+// ChainConfigHashRequest is used to request the hash of the chain config of a
+// peer, to detect peers running with different upgrades.
+type ChainConfigHashRequest struct{}
+
+func (ChainConfigHashRequest) String() string {
+	return "ChainConfigHashRequest"
+}
+
+func (r ChainConfigHashRequest) Handle(ctx context.Context, nodeID ids.NodeID, requestID uint32, handler RequestHandler) ([]byte, error) {
+	return handler.HandleChainConfigHashRequest(ctx, nodeID, requestID, r)
+}
+
+// ChainConfigHashResponse is the response to a ChainConfigHashRequest.
+// Hash is the hash of the chain config including all its upgrades, and
+// ActiveHash the hash of the chain config as in effect at Timestamp, the
+// timestamp of the last accepted block of the responding node.
+type ChainConfigHashResponse struct {
+	Hash       common.Hash `serialize:"true"`
+	ActiveHash common.Hash `serialize:"true"`
+	Timestamp  uint64      `serialize:"true"`
+}
@@ -0,0 +1,45 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarshalChainConfigHashRequest asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalChainConfigHashRequest(t *testing.T) {
+	base64ChainConfigHashRequest := "AAAAAAAL"
+	requestBytes, err := RequestToBytes(Codec, ChainConfigHashRequest{})
+	require.NoError(t, err)
+	require.Equal(t, base64ChainConfigHashRequest, base64.StdEncoding.EncodeToString(requestBytes))
+
+	request, err := BytesToRequest(Codec, requestBytes)
+	require.NoError(t, err)
+	require.Equal(t, ChainConfigHashRequest{}, request)
+}
+
+// TestMarshalChainConfigHashResponse asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalChainConfigHashResponse(t *testing.T) {
+	response := ChainConfigHashResponse{
+		Hash:       common.Hash{68, 79, 70, 65, 72, 73, 64, 107},
+		ActiveHash: common.Hash{1, 2, 3},
+		Timestamp:  1_700_000_000,
+	}
+
+	base64ChainConfigHashResponse := "AABET0ZBSElAawAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAECAwAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAGVT8QA="
+	responseBytes, err := Codec.Marshal(Version, response)
+	require.NoError(t, err)
+	require.Equal(t, base64ChainConfigHashResponse, base64.StdEncoding.EncodeToString(responseBytes))
+
+	var r ChainConfigHashResponse
+	_, err = Codec.Unmarshal(responseBytes, &r)
+	require.NoError(t, err)
+	require.Equal(t, response, r)
+}
@@ -9,9 +9,16 @@ import (
 	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// MaxCodeResponseBytes is the size of the code after which servers stop
+// adding code to a response, so large batches fit in a message. The response
+// then only contains the code of a prefix of the requested hashes.
+// Requests of at most [MaxCodeHashesPerRequest] hashes always fit in it.
+const MaxCodeResponseBytes = units.MiB
+
 var _ Request = CodeRequest{}
 
 // CodeRequest is a request to retrieve a contract code with specified Hash
@@ -41,6 +48,8 @@ func NewCodeRequest(hashes []common.Hash) CodeRequest {
 // CodeResponse is a response to a CodeRequest
 // crypto.Keccak256Hash of each element in Data is expected to equal
 // the corresponding element in CodeRequest.Hashes
+// Data may only contain the code of a non-empty prefix of CodeRequest.Hashes
+// if it would exceed MaxCodeResponseBytes
 // handler: handlers.CodeRequestHandler
 type CodeResponse struct {
 	Data [][]byte `serialize:"true"`
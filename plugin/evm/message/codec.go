@@ -47,6 +47,10 @@ func init() {
 		c.RegisterType(BlockSignatureRequest{}),
 		c.RegisterType(SignatureResponse{}),
 
+		// Chain config request types
+		c.RegisterType(ChainConfigHashRequest{}),
+		c.RegisterType(ChainConfigHashResponse{}),
+
 		Codec.RegisterCodec(Version, c),
 	)
 
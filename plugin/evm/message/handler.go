@@ -40,6 +40,7 @@ type RequestHandler interface {
 	HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest CodeRequest) ([]byte, error)
 	HandleMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest MessageSignatureRequest) ([]byte, error)
 	HandleBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest BlockSignatureRequest) ([]byte, error)
+	HandleChainConfigHashRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, chainConfigHashRequest ChainConfigHashRequest) ([]byte, error)
 }
 
 // ResponseHandler handles response for a sent request
@@ -73,6 +74,10 @@ func (NoopRequestHandler) HandleBlockSignatureRequest(ctx context.Context, nodeI
 	return nil, nil
 }
 
+func (NoopRequestHandler) HandleChainConfigHashRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, chainConfigHashRequest ChainConfigHashRequest) ([]byte, error) {
+	return nil, nil
+}
+
 // CrossChainRequestHandler interface handles incoming requests from another chain
 type CrossChainRequestHandler interface {
 	HandleEthCallRequest(ctx context.Context, requestingchainID ids.ID, requestID uint32, ethCallRequest EthCallRequest) ([]byte, error)
@@ -13,6 +13,11 @@ import (
 
 const MaxCodeHashesPerRequest = 5
 
+// MaxCodeHashesPerBatchRequest is the maximum number of code hashes served in
+// a single request. Clients should only request more than
+// [MaxCodeHashesPerRequest] hashes from servers that serve batches.
+const MaxCodeHashesPerBatchRequest = 64
+
 var _ Request = LeafsRequest{}
 
 // LeafsRequest is a request to receive trie leaves at specified Root within Start and End byte range
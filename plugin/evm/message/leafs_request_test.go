@@ -143,7 +143,8 @@ type mockHandler struct {
 	handleBlockRequestCalled,
 	handleCodeRequestCalled,
 	handleMessageSignatureCalled,
-	handleBlockSignatureCalled bool
+	handleBlockSignatureCalled,
+	handleChainConfigHashCalled bool
 }
 
 func (m *mockHandler) HandleStateTrieLeafsRequest(context.Context, ids.NodeID, uint32, LeafsRequest) ([]byte, error) {
@@ -170,6 +171,11 @@ func (m *mockHandler) HandleBlockSignatureRequest(ctx context.Context, nodeID id
 	return nil, nil
 }
 
+func (m *mockHandler) HandleChainConfigHashRequest(context.Context, ids.NodeID, uint32, ChainConfigHashRequest) ([]byte, error) {
+	m.handleChainConfigHashCalled = true
+	return nil, nil
+}
+
 func (m *mockHandler) reset() {
 	m.handleStateTrieCalled = false
 	m.handleBlockRequestCalled = false
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	syncHandlers "github.com/ava-labs/subnet-evm/sync/handlers"
@@ -25,6 +26,7 @@ type networkHandler struct {
 	blockRequestHandler          *syncHandlers.BlockRequestHandler
 	codeRequestHandler           *syncHandlers.CodeRequestHandler
 	signatureRequestHandler      *warpHandlers.SignatureRequestHandler
+	chainConfigHasher            *chainConfigHasher
 }
 
 // newNetworkHandler constructs the handler for serving network requests.
@@ -33,14 +35,17 @@ func newNetworkHandler(
 	diskDB ethdb.KeyValueReader,
 	evmTrieDB *trie.Database,
 	warpBackend warp.Backend,
+	chainConfigHasher *chainConfigHasher,
 	networkCodec codec.Manager,
+	tracer trace.Tracer,
 ) message.RequestHandler {
 	syncStats := syncStats.NewHandlerStats(metrics.Enabled)
 	return &networkHandler{
 		stateTrieLeafsRequestHandler: syncHandlers.NewLeafsRequestHandler(evmTrieDB, provider, networkCodec, syncStats),
 		blockRequestHandler:          syncHandlers.NewBlockRequestHandler(provider, networkCodec, syncStats),
 		codeRequestHandler:           syncHandlers.NewCodeRequestHandler(diskDB, networkCodec, syncStats),
-		signatureRequestHandler:      warpHandlers.NewSignatureRequestHandler(warpBackend, networkCodec),
+		signatureRequestHandler:      warpHandlers.NewSignatureRequestHandler(warpBackend, networkCodec, tracer),
+		chainConfigHasher:            chainConfigHasher,
 	}
 }
 
@@ -63,3 +68,7 @@ func (n networkHandler) HandleMessageSignatureRequest(ctx context.Context, nodeI
 func (n networkHandler) HandleBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockSignatureRequest message.BlockSignatureRequest) ([]byte, error) {
 	return n.signatureRequestHandler.OnBlockSignatureRequest(ctx, nodeID, requestID, blockSignatureRequest)
 }
+
+func (n networkHandler) HandleChainConfigHashRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, chainConfigHashRequest message.ChainConfigHashRequest) ([]byte, error) {
+	return n.chainConfigHasher.OnChainConfigHashRequest(ctx, nodeID, requestID, chainConfigHashRequest)
+}
@@ -0,0 +1,103 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/rpc"
+)
+
+// perIPConcurrencyLimiter limits the number of requests served concurrently to
+// each remote IP, so that a single client cannot use all of the resources of the
+// node. Websocket connections count as one request for as long as they are open.
+type perIPConcurrencyLimiter struct {
+	max int
+
+	lock   sync.Mutex
+	active map[string]int
+}
+
+func newPerIPConcurrencyLimiter(max int) *perIPConcurrencyLimiter {
+	return &perIPConcurrencyLimiter{
+		max:    max,
+		active: make(map[string]int),
+	}
+}
+
+// wrap returns a handler serving the requests with [handler] within the limits
+// shared by all the handlers wrapped by [l].
+func (l *perIPConcurrencyLimiter) wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		if !l.acquire(ip) {
+			writeRPCError(w, http.StatusTooManyRequests, &rpc.LimitExceededError{
+				Message: fmt.Sprintf("too many concurrent requests from %s", ip),
+				Limit:   "maxConcurrentRequestsPerIP",
+				Max:     l.max,
+			})
+			return
+		}
+		defer l.release(ip)
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (l *perIPConcurrencyLimiter) acquire(ip string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.active[ip] >= l.max {
+		return false
+	}
+	l.active[ip]++
+	return true
+}
+
+func (l *perIPConcurrencyLimiter) release(ip string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.active[ip]--
+	if l.active[ip] == 0 {
+		delete(l.active, ip)
+	}
+}
+
+// writeRPCError responds to a request rejected before being parsed with the
+// JSON-RPC error [err] and the HTTP [status], so that clients not parsing the
+// body can still handle the error.
+func writeRPCError(w http.ResponseWriter, status int, err rpc.Error) {
+	type jsonError struct {
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    interface{} `json:"data,omitempty"`
+	}
+	type jsonrpcMessage struct {
+		Version string     `json:"jsonrpc"`
+		ID      *int       `json:"id"`
+		Error   *jsonError `json:"error"`
+	}
+	msg := jsonrpcMessage{
+		Version: "2.0",
+		Error: &jsonError{
+			Code:    err.ErrorCode(),
+			Message: err.Error(),
+		},
+	}
+	if dataErr, ok := err.(rpc.DataError); ok {
+		msg.Error.Data = dataErr.ErrorData()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(msg)
+}
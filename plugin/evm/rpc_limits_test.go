@@ -0,0 +1,65 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerIPConcurrencyLimiter(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	limiter := newPerIPConcurrencyLimiter(1)
+	blocking := limiter.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))
+	handler := limiter.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	newRequest := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.RemoteAddr = remoteAddr
+		return r
+	}
+
+	done := make(chan struct{})
+	go func() {
+		blocking.ServeHTTP(httptest.NewRecorder(), newRequest("10.0.0.1:1000"))
+		close(done)
+	}()
+	<-started
+
+	// The limit is per IP and shared by the wrapped handlers
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("10.0.0.1:2000"))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	var res struct {
+		Error struct {
+			Code int `json:"code"`
+			Data struct {
+				Limit string `json:"limit"`
+				Max   int    `json:"max"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, -32005, res.Error.Code)
+	require.Equal(t, "maxConcurrentRequestsPerIP", res.Error.Data.Limit)
+	require.Equal(t, 1, res.Error.Data.Max)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("10.0.0.2:1000"))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	close(release)
+	<-done
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("10.0.0.1:3000"))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, limiter.active)
+}
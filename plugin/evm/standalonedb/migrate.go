@@ -0,0 +1,106 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package standalonedb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	migrationInProgress byte = iota
+	migrationDone
+)
+
+var (
+	// migrationKey marks the progress of a migration into a database. It is
+	// shorter than the keys written by prefix databases, so it cannot collide
+	// with the keys of the VM.
+	migrationKey = []byte("standaloneDBMigration")
+
+	errNotEmpty = errors.New("cannot migrate into a non-empty database")
+)
+
+// Copy copies every key-value pair of [src] into [dst], except for the marker
+// of a previous migration into [src], writing batches of roughly [batchSize]
+// bytes. It returns the number of copied keys.
+func Copy(src database.Iteratee, dst database.Batcher, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = ethdb.IdealBatchSize
+	}
+	it := src.NewIterator()
+	defer it.Release()
+
+	var (
+		batch  = dst.NewBatch()
+		keys   int
+		logged = time.Now()
+	)
+	for it.Next() {
+		if bytes.Equal(it.Key(), migrationKey) {
+			continue
+		}
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return keys, err
+		}
+		keys++
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return keys, err
+		}
+		batch.Reset()
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Copying database", "keys", keys, "key", fmt.Sprintf("%x", it.Key()))
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return keys, err
+	}
+	return keys, batch.Write()
+}
+
+// Migrate copies the contents of [src] into [dst] once. Migrating into a
+// database previously migrated into is a no-op, and an interrupted migration
+// is restarted from scratch. It returns true if the contents were copied.
+func Migrate(src database.Database, dst database.Database, batchSize int) (bool, error) {
+	status, err := dst.Get(migrationKey)
+	switch {
+	case err == nil && len(status) == 1 && status[0] == migrationDone:
+		return false, nil
+	case err == nil:
+		log.Warn("Restarting interrupted database migration")
+	case errors.Is(err, database.ErrNotFound):
+		empty, err := database.IsEmpty(dst)
+		if err != nil {
+			return false, err
+		}
+		if !empty {
+			return false, errNotEmpty
+		}
+	default:
+		return false, err
+	}
+
+	if err := dst.Put(migrationKey, []byte{migrationInProgress}); err != nil {
+		return false, err
+	}
+	keys, err := Copy(src, dst, batchSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to migrate database after %d keys: %w", keys, err)
+	}
+	if err := dst.Put(migrationKey, []byte{migrationDone}); err != nil {
+		return false, err
+	}
+	log.Info("Migrated database", "keys", keys)
+	return true, nil
+}
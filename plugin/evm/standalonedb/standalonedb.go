@@ -0,0 +1,100 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package standalonedb opens the key-value backends the VM can store its data
+// in instead of the database provided by the node.
+package standalonedb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	errEmptyName         = errors.New("database backend name cannot be empty")
+	errAlreadyRegistered = errors.New("database backend already registered")
+	errUnknownBackend    = errors.New("unknown database backend")
+)
+
+// Factory opens the database of a backend at [path], parsing its
+// backend-specific [config]. Metrics of the backend are registered in [reg].
+type Factory func(path string, config []byte, log logging.Logger, reg prometheus.Registerer) (database.Database, error)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[string]Factory{
+		leveldb.Name: func(path string, config []byte, log logging.Logger, reg prometheus.Registerer) (database.Database, error) {
+			return leveldb.New(path, config, log, "", reg)
+		},
+		pebble.Name: func(path string, config []byte, log logging.Logger, reg prometheus.Registerer) (database.Database, error) {
+			return pebble.New(path, config, log, "", reg)
+		},
+		memdb.Name: func(string, []byte, logging.Logger, prometheus.Registerer) (database.Database, error) {
+			return memdb.New(), nil
+		},
+	}
+)
+
+// Register makes the backend opened by [factory] selectable as [name]. It is
+// intended to be called from the init function of the package implementing an
+// external backend (ie. Firewood or RocksDB), linked into the VM binary.
+func Register(name string, factory Factory) error {
+	if name == "" {
+		return errEmptyName
+	}
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, ok := factories[name]; ok {
+		return fmt.Errorf("%w: %s", errAlreadyRegistered, name)
+	}
+	factories[name] = factory
+	return nil
+}
+
+// Exists returns true if a backend is registered as [name].
+func Exists(name string) bool {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	_, ok := factories[name]
+	return ok
+}
+
+// Names returns the sorted names of the registered backends.
+func Names() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New opens the database of the backend registered as [name] at [path].
+func New(name string, path string, config []byte, log logging.Logger, reg prometheus.Registerer) (database.Database, error) {
+	factoriesLock.RLock()
+	factory, ok := factories[name]
+	factoriesLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q, must be one of %v", errUnknownBackend, name, Names())
+	}
+	db, err := factory(path, config, log, reg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database at %q: %w", name, path, err)
+	}
+	return db, nil
+}
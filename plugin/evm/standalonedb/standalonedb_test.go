@@ -0,0 +1,115 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package standalonedb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackends(t *testing.T) {
+	for _, name := range Names() {
+		for testName, test := range database.Tests {
+			t.Run(fmt.Sprintf("%s/%s", name, testName), func(t *testing.T) {
+				db, err := New(name, t.TempDir(), nil, logging.NoLog{}, prometheus.NewRegistry())
+				require.NoError(t, err)
+				test(t, db)
+				_ = db.Close()
+			})
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	require := require.New(t)
+
+	require.ErrorIs(Register("", nil), errEmptyName)
+	require.ErrorIs(Register(memdb.Name, nil), errAlreadyRegistered)
+
+	_, err := New("unknown", t.TempDir(), nil, logging.NoLog{}, prometheus.NewRegistry())
+	require.ErrorIs(err, errUnknownBackend)
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	for i := 0; i < 1000; i++ {
+		require.NoError(src.Put([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i))))
+	}
+	dst := memdb.New()
+
+	// Use a small batch size to write multiple batches.
+	migrated, err := Migrate(src, dst, 128)
+	require.NoError(err)
+	require.True(migrated)
+	for i := 0; i < 1000; i++ {
+		value, err := dst.Get([]byte(fmt.Sprintf("key-%d", i)))
+		require.NoError(err)
+		require.Equal([]byte(fmt.Sprintf("value-%d", i)), value)
+	}
+
+	// Migrating again is a no-op, even though the source changed.
+	require.NoError(src.Put([]byte("new-key"), []byte("new-value")))
+	migrated, err = Migrate(src, dst, 128)
+	require.NoError(err)
+	require.False(migrated)
+	has, err := dst.Has([]byte("new-key"))
+	require.NoError(err)
+	require.False(has)
+
+	// Migrating from the migrated database does not copy its marker.
+	next := memdb.New()
+	migrated, err = Migrate(dst, next, 0)
+	require.NoError(err)
+	require.True(migrated)
+	count, err := database.Count(next)
+	require.NoError(err)
+	require.Equal(1001, count) // 1000 keys and the migration marker
+}
+
+func TestMigrateInterrupted(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	require.NoError(src.Put([]byte("key"), []byte("value")))
+
+	// A non-empty database that was never migrated into is rejected.
+	dst := memdb.New()
+	require.NoError(dst.Put([]byte("other"), []byte("value")))
+	_, err := Migrate(src, dst, 0)
+	require.ErrorIs(err, errNotEmpty)
+
+	// A partially migrated database is migrated again.
+	dst = memdb.New()
+	require.NoError(dst.Put(migrationKey, []byte{migrationInProgress}))
+	migrated, err := Migrate(src, dst, 0)
+	require.NoError(err)
+	require.True(migrated)
+	value, err := dst.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func BenchmarkBackends(b *testing.B) {
+	for _, name := range Names() {
+		for _, size := range database.BenchmarkSizes {
+			keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+			for benchName, bench := range database.Benchmarks {
+				b.Run(fmt.Sprintf("%s_%d_pairs_%d_keys_%d_values_%s", name, size[0], size[1], size[2], benchName), func(b *testing.B) {
+					db, err := New(name, b.TempDir(), nil, logging.NoLog{}, prometheus.NewRegistry())
+					require.NoError(b, err)
+					bench(b, db, keys, values)
+					_ = db.Close()
+				})
+			}
+		}
+	}
+}
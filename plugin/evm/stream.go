@@ -0,0 +1,195 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/eth/tracers"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/proto/pb/stream"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// acceptedEventsBuffer is the size of the channel of the accepted chain events
+// notifying a stream of new blocks.
+const acceptedEventsBuffer = 16
+
+var _ stream.StreamServer = (*streamServer)(nil)
+
+// streamServer serves the accepted blocks of the chain to indexers over gRPC.
+// Blocks are read from the database rather than from the accepted events, so
+// that a stream never skips a block, however slow its client is.
+type streamServer struct {
+	stream.UnsafeStreamServer
+
+	chain    *core.BlockChain
+	listener net.Listener
+	server   *grpc.Server
+}
+
+// newStreamServer starts serving the accepted blocks of [chain] on [address].
+func newStreamServer(chain *core.BlockChain, address string) (*streamServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %w", address, err)
+	}
+	s := &streamServer{
+		chain:    chain,
+		listener: listener,
+		server:   grpc.NewServer(),
+	}
+	stream.RegisterStreamServer(s.server, s)
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			log.Error("Accepted block stream server stopped", "err", err)
+		}
+	}()
+	log.Info("Serving accepted block stream", "address", listener.Addr())
+	return s, nil
+}
+
+// stop closes the streams and the listener of the server.
+func (s *streamServer) stop() {
+	s.server.Stop()
+}
+
+// AcceptedBlocks implements the Stream gRPC service.
+func (s *streamServer) AcceptedBlocks(req *stream.AcceptedBlocksRequest, srv stream.Stream_AcceptedBlocksServer) error {
+	next := req.StartHeight
+	if after := req.After; after != nil {
+		lastAccepted := s.chain.LastAcceptedBlock().NumberU64()
+		if after.Height > lastAccepted {
+			return status.Errorf(codes.OutOfRange, "cursor %d is after the last accepted block %d", after.Height, lastAccepted)
+		}
+		if hash := s.chain.GetCanonicalHash(after.Height); hash != common.BytesToHash(after.Hash) {
+			return status.Errorf(codes.FailedPrecondition, "cursor %d (%x) is not accepted, block %s is", after.Height, after.Hash, hash)
+		}
+		next = after.Height + 1
+	}
+
+	// Subscribe before catching up, so that the blocks accepted meanwhile are
+	// not missed. The events only signal that blocks were accepted, and are
+	// drained without waiting for the client so it cannot hold up the acceptor.
+	accepted := make(chan core.ChainEvent, acceptedEventsBuffer)
+	sub := s.chain.SubscribeChainAcceptedEvent(accepted)
+	defer sub.Unsubscribe()
+	notify := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case <-accepted:
+				select {
+				case notify <- struct{}{}:
+				default:
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	for {
+		for lastAccepted := s.chain.LastAcceptedBlock().NumberU64(); next <= lastAccepted; next++ {
+			block, err := s.acceptedBlock(next, req)
+			if err != nil {
+				return err
+			}
+			if err := srv.Send(block); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-notify:
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+		}
+	}
+}
+
+// acceptedBlock returns the accepted block [number] along with the receipts
+// and precompile events requested by [req].
+func (s *streamServer) acceptedBlock(number uint64, req *stream.AcceptedBlocksRequest) (*stream.AcceptedBlock, error) {
+	block := s.chain.GetBlockByNumber(number)
+	if block == nil {
+		return nil, status.Errorf(codes.NotFound, "accepted block %d not found", number)
+	}
+	blockBytes, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode block %d: %s", number, err)
+	}
+	reply := &stream.AcceptedBlock{
+		Cursor: &stream.Cursor{Height: number, Hash: block.Hash().Bytes()},
+		Block:  blockBytes,
+	}
+	if !req.Receipts && !req.Events {
+		return reply, nil
+	}
+
+	receipts := s.chain.GetReceiptsByHash(block.Hash())
+	if receipts == nil && block.ReceiptHash() != types.EmptyRootHash {
+		return nil, status.Errorf(codes.NotFound, "receipts of accepted block %d not found", number)
+	}
+	if req.Receipts {
+		for _, receipt := range receipts {
+			receiptBytes, err := receipt.MarshalBinary()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to encode receipt of tx %s: %s", receipt.TxHash, err)
+			}
+			reply.Receipts = append(reply.Receipts, receiptBytes)
+		}
+	}
+	if req.Events {
+		reply.Events = precompileEvents(receipts)
+	}
+	return reply, nil
+}
+
+// precompileEvents returns the logs of [receipts] emitted by stateful
+// precompiles, decoded with their ABI. Logs which cannot be decoded are left
+// out.
+func precompileEvents(receipts types.Receipts) []*stream.PrecompileEvent {
+	var events []*stream.PrecompileEvent
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			module, ok := modules.GetPrecompileModuleByAddress(l.Address)
+			if !ok {
+				continue
+			}
+			decoder, ok := tracers.GetPrecompileDecoder(l.Address)
+			if !ok {
+				continue
+			}
+			name, args, err := decoder.DecodeLog(l.Topics, l.Data)
+			if err != nil {
+				log.Debug("Failed to decode precompile log", "address", l.Address, "tx", l.TxHash, "err", err)
+				continue
+			}
+			argsJSON, err := json.Marshal(args)
+			if err != nil {
+				log.Debug("Failed to encode precompile log arguments", "address", l.Address, "tx", l.TxHash, "err", err)
+				continue
+			}
+			events = append(events, &stream.PrecompileEvent{
+				TxHash:        l.TxHash.Bytes(),
+				TxIndex:       uint32(l.TxIndex),
+				LogIndex:      uint32(l.Index),
+				Address:       l.Address.Bytes(),
+				Precompile:    module.ConfigKey,
+				Name:          name,
+				ArgumentsJson: argsJSON,
+			})
+		}
+	}
+	return events
+}
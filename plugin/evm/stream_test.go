@@ -0,0 +1,123 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/proto/pb/stream"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamAcceptedBlocks(t *testing.T) {
+	require := require.New(t)
+
+	genesis := &core.Genesis{}
+	require.NoError(genesis.UnmarshalJSON([]byte(genesisJSONDurango)))
+	genesis.Config.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(utils.NewUint64(0)),
+	}
+	genesisJSON, err := genesis.MarshalJSON()
+	require.NoError(err)
+	issuer, vm, _, _ := GenesisVM(t, true, string(genesisJSON), `{"stream-grpc-address":"127.0.0.1:0"}`, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	conn, err := grpc.Dial(vm.streamServer.listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err)
+	defer conn.Close()
+	client := stream.NewStreamClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	issueTx := func(nonce uint64, to common.Address, data []byte) {
+		tx := types.NewTransaction(nonce, to, big.NewInt(1), 100_000, big.NewInt(testMinGasPrice), data)
+		signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(vm.chainConfig.ChainID), testKeys[0])
+		require.NoError(err)
+		for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+			require.NoError(err)
+		}
+		vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+		issueAndAccept(t, issuer, vm)
+	}
+	sendWarpMessageInput, err := warp.PackSendWarpMessage([]byte("payload"))
+	require.NoError(err)
+	issueTx(0, warp.ContractAddress, sendWarpMessageInput)
+
+	// The stream catches up from the start height, including the receipts and
+	// the decoded precompile events.
+	blocks, err := client.AcceptedBlocks(ctx, &stream.AcceptedBlocksRequest{Receipts: true, Events: true})
+	require.NoError(err)
+	genesisBlock, err := blocks.Recv()
+	require.NoError(err)
+	require.Equal(uint64(0), genesisBlock.Cursor.Height)
+	require.Equal(vm.genesisHash.Bytes(), genesisBlock.Cursor.Hash)
+	require.Empty(genesisBlock.Receipts)
+
+	first, err := blocks.Recv()
+	require.NoError(err)
+	require.Equal(uint64(1), first.Cursor.Height)
+	var block types.Block
+	require.NoError(rlp.DecodeBytes(first.Block, &block))
+	require.Equal(common.BytesToHash(first.Cursor.Hash), block.Hash())
+	require.Len(first.Receipts, 1)
+	var receipt types.Receipt
+	require.NoError(receipt.UnmarshalBinary(first.Receipts[0]))
+	require.Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	require.Len(first.Events, 1)
+	event := first.Events[0]
+	require.Equal(warp.ConfigKey, event.Precompile)
+	require.Equal("SendWarpMessage", event.Name)
+	require.Equal(block.Transactions()[0].Hash().Bytes(), event.TxHash)
+	require.Equal(warp.ContractAddress.Bytes(), event.Address)
+	var args map[string]interface{}
+	require.NoError(json.Unmarshal(event.ArgumentsJson, &args))
+	require.Contains(args, "message")
+
+	// Blocks accepted afterwards are pushed to the open stream.
+	issueTx(1, testEthAddrs[1], nil)
+	second, err := blocks.Recv()
+	require.NoError(err)
+	require.Equal(uint64(2), second.Cursor.Height)
+
+	// A stream resumed from a cursor starts from the next block, and only
+	// includes what was requested.
+	resumed, err := client.AcceptedBlocks(ctx, &stream.AcceptedBlocksRequest{After: first.Cursor})
+	require.NoError(err)
+	block2, err := resumed.Recv()
+	require.NoError(err)
+	require.Equal(second.Cursor.Height, block2.Cursor.Height)
+	require.Equal(second.Cursor.Hash, block2.Cursor.Hash)
+	require.Empty(block2.Receipts)
+	require.Empty(block2.Events)
+
+	for _, tt := range []struct {
+		cursor *stream.Cursor
+		code   codes.Code
+	}{
+		{&stream.Cursor{Height: 1, Hash: common.Hash{1}.Bytes()}, codes.FailedPrecondition},
+		{&stream.Cursor{Height: 3}, codes.OutOfRange},
+	} {
+		invalid, err := client.AcceptedBlocks(ctx, &stream.AcceptedBlocksRequest{After: tt.cursor})
+		require.NoError(err)
+		_, err = invalid.Recv()
+		require.Equal(tt.code, status.Code(err))
+	}
+}
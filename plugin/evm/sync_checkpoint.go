@@ -0,0 +1,84 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	warpValidators "github.com/ava-labs/subnet-evm/warp/validators"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// checkpointFetchTimeout is the time allowed to fetch the checkpoint block
+// from peers.
+const checkpointFetchTimeout = time.Minute
+
+var errCheckpointWrongChain = errors.New("checkpoint is not signed for this chain")
+
+// syncCheckpoint is a trusted block to state sync to, attested by a warp
+// message with its hash signed by the validators of the chain. Only the blocks
+// after it are then executed.
+type syncCheckpoint struct {
+	hash   common.Hash
+	height uint64
+}
+
+// parseSyncCheckpoint parses the warp message [msgBytes] attesting the hash of
+// the block at [height], and verifies it is signed by a quorum of the current
+// validators of the chain.
+func parseSyncCheckpoint(ctx context.Context, snowCtx *snow.Context, msgBytes []byte, height uint64) (*syncCheckpoint, error) {
+	msg, err := avalancheWarp.ParseMessage(msgBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if msg.SourceChainID != snowCtx.ChainID {
+		return nil, fmt.Errorf("%w: source chain %s", errCheckpointWrongChain, msg.SourceChainID)
+	}
+	hashPayload, err := payload.ParseHash(msg.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint block hash: %w", err)
+	}
+	pChainHeight, err := snowCtx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current P-Chain height: %w", err)
+	}
+	if err := msg.Signature.Verify(
+		ctx,
+		&msg.UnsignedMessage,
+		snowCtx.NetworkID,
+		warpValidators.NewState(snowCtx),
+		pChainHeight,
+		warp.WarpDefaultQuorumNumerator,
+		warp.WarpQuorumDenominator,
+	); err != nil {
+		return nil, fmt.Errorf("failed to verify checkpoint signature: %w", err)
+	}
+	return &syncCheckpoint{
+		hash:   common.Hash(hashPayload.Hash),
+		height: height,
+	}, nil
+}
+
+// checkpointSummary fetches the checkpoint block from peers, and returns the
+// summary to sync to it. Peers only serve the block if it is accepted at the
+// checkpoint height, and the client verifies its hash.
+func (client *stateSyncerClient) checkpointSummary() (message.SyncSummary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointFetchTimeout)
+	defer cancel()
+
+	blocks, err := client.client.GetBlocks(ctx, client.checkpoint.hash, client.checkpoint.height, 1)
+	if err != nil {
+		return message.SyncSummary{}, fmt.Errorf("failed to fetch checkpoint block %s: %w", client.checkpoint.hash, err)
+	}
+	block := blocks[0]
+	return message.NewSyncSummary(block.Hash(), block.NumberU64(), block.Root())
+}
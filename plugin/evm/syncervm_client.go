@@ -14,9 +14,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
 	"github.com/ava-labs/subnet-evm/core/state/snapshot"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/eth"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
@@ -25,6 +27,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -44,6 +48,17 @@ type stateSyncClientConfig struct {
 	// algorithm.
 	stateSyncMinBlocks   uint64
 	stateSyncRequestSize uint16 // number of key/value pairs to ask peers for per request
+	// Re-sync incrementally from the local state if it is complete.
+	incremental bool
+
+	parallelism         int  // number of concurrent leaf requests
+	adaptiveParallelism bool // adapt the number of concurrent leaf requests to peer performance
+	codeBatchSize       int  // number of code hashes to ask peers for per request
+	codeWorkers         int  // number of code fetching threads
+
+	// Trusted block to sync to instead of the summaries accepted by the
+	// engine, if the local state is behind it.
+	checkpoint *syncCheckpoint
 
 	lastAcceptedHeight uint64
 
@@ -55,6 +70,7 @@ type stateSyncClientConfig struct {
 	db              *versiondb.Database
 
 	client syncclient.Client
+	tracer trace.Tracer
 
 	toEngine chan<- commonEng.Message
 }
@@ -133,6 +149,9 @@ func (client *stateSyncerClient) StateSyncClearOngoingSummary() error {
 	if err := client.db.Commit(); err != nil {
 		return fmt.Errorf("failed to commit db while clearing ongoing summary: %w", err)
 	}
+	if err := rawdb.DeleteSyncBaseRoot(client.chaindb); err != nil {
+		return fmt.Errorf("failed to clear incremental sync marker: %w", err)
+	}
 
 	return nil
 }
@@ -144,24 +163,52 @@ func (client *stateSyncerClient) ParseStateSummary(_ context.Context, summaryByt
 
 // stateSync blockingly performs the state sync for the EVM state and the atomic state
 // to [client.syncSummary]. returns an error if one occurred.
-func (client *stateSyncerClient) stateSync(ctx context.Context) error {
-	if err := client.syncBlocks(ctx, client.syncSummary.BlockHash, client.syncSummary.BlockNumber, parentsToGet); err != nil {
+func (client *stateSyncerClient) stateSync(ctx context.Context) (err error) {
+	ctx, span := client.tracer.Start(ctx, "subnetevm.stateSync", oteltrace.WithAttributes(
+		attribute.Stringer("blockHash", client.syncSummary.BlockHash),
+		attribute.Int64("height", int64(client.syncSummary.BlockNumber)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	blocksCtx, blocksSpan := client.tracer.Start(ctx, "subnetevm.stateSync.syncBlocks")
+	err = client.syncBlocks(blocksCtx, client.syncSummary.BlockHash, client.syncSummary.BlockNumber, parentsToGet)
+	endSpan(blocksSpan, err)
+	if err != nil {
 		return err
 	}
 
 	// Sync the EVM trie.
-	return client.syncStateTrie(ctx)
+	trieCtx, trieSpan := client.tracer.Start(ctx, "subnetevm.stateSync.syncStateTrie")
+	err = client.syncStateTrie(trieCtx)
+	endSpan(trieSpan, err)
+	return err
 }
 
 // acceptSyncSummary returns true if sync will be performed and launches the state sync process
 // in a goroutine.
 func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncSummary) (block.StateSyncMode, error) {
+	// If a trusted checkpoint is configured, sync to it regardless of the
+	// proposed summary, so only the blocks after it are executed.
+	if client.checkpoint != nil {
+		if client.checkpoint.hash == client.resumableSummary.BlockHash {
+			proposedSummary = client.resumableSummary
+		} else {
+			checkpointSummary, err := client.checkpointSummary()
+			if err != nil {
+				return block.StateSyncSkipped, err
+			}
+			log.Info("Syncing to trusted checkpoint instead of proposed summary", "checkpoint", checkpointSummary, "proposed", proposedSummary)
+			proposedSummary = checkpointSummary
+		}
+	}
+
 	isResume := proposedSummary.BlockHash == client.resumableSummary.BlockHash
 	if !isResume {
 		// Skip syncing if the blockchain is not significantly ahead of local state,
 		// since bootstrapping would be faster.
 		// (Also ensures we don't sync to a height prior to local state.)
-		if client.lastAcceptedHeight+client.stateSyncMinBlocks > proposedSummary.Height() {
+		// A checkpoint is only configured if it is ahead of local state.
+		if client.checkpoint == nil && client.lastAcceptedHeight+client.stateSyncMinBlocks > proposedSummary.Height() {
 			log.Info(
 				"last accepted too close to most recent syncable block, skipping state sync",
 				"lastAccepted", client.lastAcceptedHeight,
@@ -182,7 +229,23 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 		// sync marker will be wiped, so we do not accidentally resume progress from an incorrect version
 		// of the snapshot. (if switching between versions that come before this change and back this could
 		// lead to the snapshot not being cleaned up correctly)
-		<-snapshot.WipeSnapshot(client.chaindb, true)
+		//
+		// If re-syncing incrementally from a complete local state, its storage snapshot is kept instead.
+		// Note: the base root must be read before wiping, which removes the snapshot root, and persisted
+		// after, so an interrupted wipe is never used as a base.
+		baseRoot := client.incrementalBaseRoot()
+		if baseRoot != (common.Hash{}) {
+			log.Info("Re-syncing state incrementally", "baseRoot", baseRoot)
+			<-snapshot.WipeAccountSnapshot(client.chaindb)
+			if err := rawdb.WriteSyncBaseRoot(client.chaindb, baseRoot); err != nil {
+				return block.StateSyncSkipped, fmt.Errorf("failed to write incremental sync marker: %w", err)
+			}
+		} else {
+			<-snapshot.WipeSnapshot(client.chaindb, true)
+			if err := rawdb.DeleteSyncBaseRoot(client.chaindb); err != nil {
+				return block.StateSyncSkipped, fmt.Errorf("failed to clear incremental sync marker: %w", err)
+			}
+		}
 		// Reset the snapshot generator here so that when state sync completes, snapshots will not attempt to read an
 		// invalid generator.
 		// Note: this must be called after WipeSnapshot is called so that we do not invalidate a partially generated snapshot.
@@ -224,6 +287,27 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 	return block.StateSyncStatic, nil
 }
 
+// incrementalBaseRoot returns the root of the local state the state can be
+// re-synced incrementally from, or common.Hash{} if the whole state must be
+// synced. The local state must have a complete snapshot and its account trie
+// root on disk. Missing nodes deeper in its tries only cause the affected
+// storage tries to be synced.
+func (client *stateSyncerClient) incrementalBaseRoot() common.Hash {
+	if !client.incremental {
+		return common.Hash{}
+	}
+	root := snapshot.CompletedRoot(client.chaindb)
+	if root == (common.Hash{}) || root == types.EmptyRootHash {
+		log.Info("No complete local state snapshot, state will be fully synced")
+		return common.Hash{}
+	}
+	if !rawdb.HasLegacyTrieNode(client.chaindb, root) {
+		log.Info("Local state snapshot root is not on disk, state will be fully synced", "root", root)
+		return common.Hash{}
+	}
+	return root
+}
+
 // syncBlocks fetches (up to) [parentsToGet] blocks from peers
 // using [client] and writes them to disk.
 // the process begins with [fromHash] and it fetches parents recursively.
@@ -277,14 +361,22 @@ func (client *stateSyncerClient) syncBlocks(ctx context.Context, fromHash common
 
 func (client *stateSyncerClient) syncStateTrie(ctx context.Context) error {
 	log.Info("state sync: sync starting", "root", client.syncSummary.BlockRoot)
+	baseRoot, err := rawdb.ReadSyncBaseRoot(client.chaindb)
+	if err != nil {
+		return err
+	}
 	evmSyncer, err := statesync.NewStateSyncer(&statesync.StateSyncerConfig{
 		Client:                   client.client,
 		Root:                     client.syncSummary.BlockRoot,
 		BatchSize:                ethdb.IdealBatchSize,
 		DB:                       client.chaindb,
 		MaxOutstandingCodeHashes: statesync.DefaultMaxOutstandingCodeHashes,
-		NumCodeFetchingWorkers:   statesync.DefaultNumCodeFetchingWorkers,
+		NumCodeFetchingWorkers:   client.codeWorkers,
 		RequestSize:              client.stateSyncRequestSize,
+		CodeBatchSize:            client.codeBatchSize,
+		Parallelism:              client.parallelism,
+		AdaptiveParallelism:      client.adaptiveParallelism,
+		BaseRoot:                 baseRoot,
 	})
 	if err != nil {
 		return err
@@ -368,7 +460,10 @@ func (client *stateSyncerClient) updateVMMarkers() error {
 	if err := client.metadataDB.Delete(stateSyncSummaryKey); err != nil {
 		return err
 	}
-	return client.db.Commit()
+	if err := client.db.Commit(); err != nil {
+		return err
+	}
+	return rawdb.DeleteSyncBaseRoot(client.chaindb)
 }
 
 // Error returns a non-nil error if one occurred during the sync.
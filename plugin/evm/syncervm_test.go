@@ -22,7 +22,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 
 	"github.com/ava-labs/subnet-evm/accounts/keystore"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
@@ -32,6 +36,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/predicate"
 	statesyncclient "github.com/ava-labs/subnet-evm/sync/client"
 	"github.com/ava-labs/subnet-evm/sync/statesync"
@@ -227,6 +232,85 @@ func TestStateSyncToggleEnabledToDisabled(t *testing.T) {
 	testSyncerVM(t, vmSetup, test)
 }
 
+// signCheckpoint returns a warp message attesting [blockHash] on [chainID],
+// signed by [secretKey].
+func signCheckpoint(t *testing.T, networkID uint32, chainID ids.ID, blockHash common.Hash, secretKey *bls.SecretKey) []byte {
+	hashPayload, err := payload.NewHash(ids.ID(blockHash))
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, chainID, hashPayload.Bytes())
+	require.NoError(t, err)
+	signature := &avalancheWarp.BitSetSignature{Signers: set.NewBits(0).Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(bls.Sign(secretKey, unsignedMessage.Bytes())))
+	msg, err := avalancheWarp.NewMessage(unsignedMessage, signature)
+	require.NoError(t, err)
+	return msg.Bytes()
+}
+
+func TestStateSyncToCheckpoint(t *testing.T) {
+	require := require.New(t)
+	vmSetup := createSyncServerAndClientVMs(t, syncTest{
+		syncableInterval:   256,
+		stateSyncMinBlocks: 50,
+	})
+	serverVM, syncerVM := vmSetup.serverVM, vmSetup.syncerVM
+
+	// The chain is validated by a single validator signing the checkpoint.
+	nodeID := ids.GenerateTestNodeID()
+	secretKey, err := bls.NewSecretKey()
+	require.NoError(err)
+	syncerVM.ctx.ValidatorState = &validators.TestState{
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return syncerVM.ctx.SubnetID, nil
+		},
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(secretKey),
+					Weight:    100,
+				},
+			}, nil
+		},
+	}
+
+	checkpointBlock := serverVM.LastAcceptedBlock()
+	checkpointHash := common.Hash(checkpointBlock.ID())
+
+	// Checkpoints signed for another chain or by non-validators are rejected.
+	_, err = parseSyncCheckpoint(context.Background(), syncerVM.ctx, signCheckpoint(t, syncerVM.ctx.NetworkID, ids.GenerateTestID(), checkpointHash, secretKey), checkpointBlock.Height())
+	require.ErrorIs(err, errCheckpointWrongChain)
+	otherKey, err := bls.NewSecretKey()
+	require.NoError(err)
+	_, err = parseSyncCheckpoint(context.Background(), syncerVM.ctx, signCheckpoint(t, syncerVM.ctx.NetworkID, syncerVM.ctx.ChainID, checkpointHash, otherKey), checkpointBlock.Height())
+	require.ErrorIs(err, avalancheWarp.ErrInvalidSignature)
+
+	checkpoint, err := parseSyncCheckpoint(context.Background(), syncerVM.ctx, signCheckpoint(t, syncerVM.ctx.NetworkID, syncerVM.ctx.ChainID, checkpointHash, secretKey), checkpointBlock.Height())
+	require.NoError(err)
+	require.Equal(checkpointHash, checkpoint.hash)
+	syncerVM.StateSyncClient.(*stateSyncerClient).checkpoint = checkpoint
+
+	// The proposed summary is replaced by the checkpoint.
+	proposedSummary, err := message.NewSyncSummary(common.Hash{1}, checkpointBlock.Height()+1000, common.Hash{2})
+	require.NoError(err)
+	parsedSummary, err := syncerVM.ParseStateSummary(context.Background(), proposedSummary.Bytes())
+	require.NoError(err)
+	syncMode, err := parsedSummary.Accept(context.Background())
+	require.NoError(err)
+	require.Equal(block.StateSyncStatic, syncMode)
+
+	msg := <-vmSetup.syncerEngineChan
+	require.Equal(commonEng.StateSyncDone, msg)
+	require.NoError(syncerVM.StateSyncClient.Error())
+
+	require.NoError(syncerVM.SetState(context.Background(), snow.Bootstrapping))
+	require.Equal(checkpointBlock.ID(), syncerVM.LastAcceptedBlock().ID())
+	require.True(syncerVM.blockChain.HasState(syncerVM.blockChain.LastAcceptedBlock().Root()))
+	assertSyncPerformedHeights(t, syncerVM.chaindb, map[uint64]struct{}{checkpointBlock.Height(): {}})
+}
+
 func TestVMShutdownWhileSyncing(t *testing.T) {
 	t.Skip("FLAKY")
 	var (
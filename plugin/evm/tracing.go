@@ -0,0 +1,45 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"github.com/ava-labs/avalanchego/trace"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const tracingAppName = "subnet-evm"
+
+// newTracer creates the tracer exporting the spans of the VM to the OTLP
+// endpoint of [config], or a no-op tracer if tracing is disabled.
+func newTracer(config *Config) (trace.Tracer, error) {
+	if !config.TracingEnabled {
+		return trace.Noop, nil
+	}
+	exporterType, err := trace.ExporterTypeFromString(config.TracingExporterType)
+	if err != nil {
+		return nil, err
+	}
+	return trace.New(trace.Config{
+		ExporterConfig: trace.ExporterConfig{
+			Type:     exporterType,
+			Endpoint: config.TracingEndpoint,
+			Headers:  config.TracingHeaders,
+			Insecure: config.TracingInsecure,
+		},
+		Enabled:         true,
+		TraceSampleRate: config.TracingSampleRate,
+		AppName:         tracingAppName,
+		Version:         Version,
+	})
+}
+
+// endSpan ends [span], marking it as failed with [err] if it is not nil.
+func endSpan(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,65 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// recordingTracer is a trace.Tracer recording its spans with [provider].
+type recordingTracer struct {
+	oteltrace.Tracer
+	provider *sdktrace.TracerProvider
+}
+
+func (t *recordingTracer) Close() error {
+	return t.provider.Shutdown(context.Background())
+}
+
+func TestTracingBlockLifecycle(t *testing.T) {
+	require := require.New(t)
+
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	vm.tracer = &recordingTracer{Tracer: provider.Tracer("test"), provider: provider}
+
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(err)
+	}
+	issueAndAccept(t, issuer, vm)
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	for _, name := range []string{
+		"subnetevm.buildBlock",
+		"subnetevm.generateBlock",
+		"subnetevm.Block.verify",
+		"subnetevm.blockChain.insertBlock",
+		"subnetevm.Block.accept",
+		"subnetevm.blockChain.accept",
+	} {
+		require.Contains(spans, name)
+	}
+	// The phases of a block are recorded within its spans
+	require.Equal(spans["subnetevm.buildBlock"].SpanContext.SpanID(), spans["subnetevm.generateBlock"].Parent.SpanID())
+	require.Equal(spans["subnetevm.Block.accept"].SpanContext.SpanID(), spans["subnetevm.blockChain.accept"].Parent.SpanID())
+}
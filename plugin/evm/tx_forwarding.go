@@ -0,0 +1,194 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// txForwardingBuffer is the number of transactions waiting to be forwarded,
+// transactions issued while the buffer is full are only gossiped.
+const txForwardingBuffer = 1024
+
+// txForwarder forwards the transactions issued over the RPC to the validators
+// expected to propose the next block, rather than waiting for them to be
+// reached by push gossip, which cuts the inclusion latency of RPC-only nodes.
+//
+// Forwarded transactions are sent as push gossip messages of the tx gossip
+// protocol, so the proposers handle them like any gossiped transaction.
+type txForwarder struct {
+	client       *p2p.Client
+	windower     proposer.Windower
+	state        validators.State
+	chainConfig  *params.ChainConfig
+	head         func() *types.Header
+	clock        *mockable.Clock
+	nodeID       ids.NodeID
+	numProposers int
+
+	txs chan *types.Transaction
+
+	forwarded prometheus.Counter
+	dropped   prometheus.Counter
+}
+
+func newTxForwarder(
+	client *p2p.Client,
+	state validators.State,
+	subnetID ids.ID,
+	chainID ids.ID,
+	chainConfig *params.ChainConfig,
+	head func() *types.Header,
+	clock *mockable.Clock,
+	nodeID ids.NodeID,
+	numProposers int,
+	registerer prometheus.Registerer,
+) (*txForwarder, error) {
+	f := &txForwarder{
+		client:       client,
+		windower:     proposer.New(state, subnetID, chainID),
+		state:        state,
+		chainConfig:  chainConfig,
+		head:         head,
+		clock:        clock,
+		nodeID:       nodeID,
+		numProposers: numProposers,
+		txs:          make(chan *types.Transaction, txForwardingBuffer),
+		forwarded: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "eth_tx_forwarded",
+			Help: "number of transactions forwarded to the expected block proposers",
+		}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "eth_tx_forwarding_dropped",
+			Help: "number of transactions not forwarded because the forwarding buffer was full",
+		}),
+	}
+	for _, counter := range []prometheus.Counter{f.forwarded, f.dropped} {
+		if err := registerer.Register(counter); err != nil {
+			return nil, fmt.Errorf("failed to register tx forwarding metrics: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// Add enqueues [tx] to be forwarded without blocking.
+func (f *txForwarder) Add(tx *types.Transaction) {
+	// Blob and conditional transactions are not gossiped, see GossipEthTxPool.Iterate
+	if tx.Type() == types.BlobTxType || tx.Conditional() != nil {
+		return
+	}
+	select {
+	case f.txs <- tx:
+	default:
+		f.dropped.Inc()
+	}
+}
+
+// Run forwards the enqueued transactions until [ctx] is done, batching the
+// transactions enqueued while the previous batch was forwarded.
+func (f *txForwarder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case tx := <-f.txs:
+			txs := []*types.Transaction{tx}
+			size := int(tx.Size())
+		batch:
+			for size < txGossipTargetMessageSize {
+				select {
+				case tx := <-f.txs:
+					txs = append(txs, tx)
+					size += int(tx.Size())
+				default:
+					break batch
+				}
+			}
+			if err := f.forward(ctx, txs); err != nil {
+				log.Debug("failed to forward transactions", "numTxs", len(txs), "err", err)
+			}
+		}
+	}
+}
+
+func (f *txForwarder) forward(ctx context.Context, txs []*types.Transaction) error {
+	proposers, err := f.proposers(ctx)
+	if err != nil {
+		return err
+	}
+	if proposers.Len() == 0 {
+		return nil
+	}
+	gossipBytes := make([][]byte, 0, len(txs))
+	for _, tx := range txs {
+		txBytes, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		gossipBytes = append(gossipBytes, txBytes)
+	}
+	msgBytes, err := gossip.MarshalAppGossip(gossipBytes)
+	if err != nil {
+		return err
+	}
+	if err := f.client.AppGossipSpecific(ctx, proposers, msgBytes); err != nil {
+		return err
+	}
+	f.forwarded.Add(float64(len(txs)))
+	return nil
+}
+
+// proposers returns the validators expected to propose the block following the
+// current head in the next [numProposers] proposer windows, excluding this node.
+// The validator set is taken at the current P-chain height, which may differ from
+// the P-chain height of the next block when the validator set changes.
+// No proposers are returned when anyone can propose.
+func (f *txForwarder) proposers(ctx context.Context) (set.Set[ids.NodeID], error) {
+	head := f.head()
+	blockHeight := head.Number.Uint64() + 1
+	pChainHeight, err := f.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current P-chain height: %w", err)
+	}
+
+	var proposers set.Set[ids.NodeID]
+	now := f.clock.Time()
+	if !f.chainConfig.IsDurango(uint64(now.Unix())) {
+		nodeIDs, err := f.windower.Proposers(ctx, blockHeight, pChainHeight, f.numProposers)
+		if err != nil {
+			return nil, err
+		}
+		proposers.Add(nodeIDs...)
+	} else {
+		slot := proposer.TimeToSlot(time.Unix(int64(head.Time), 0), now)
+		for i := 0; i < f.numProposers; i++ {
+			nodeID, err := f.windower.ExpectedProposer(ctx, blockHeight, pChainHeight, slot+uint64(i))
+			if errors.Is(err, proposer.ErrAnyoneCanPropose) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			proposers.Add(nodeID)
+		}
+	}
+	// The transaction is already in the mempool of this node
+	proposers.Remove(f.nodeID)
+	return proposers, nil
+}
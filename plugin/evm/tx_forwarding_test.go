@@ -0,0 +1,99 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTxForwarder(t *testing.T, nodeID ids.NodeID, validatorSet map[ids.NodeID]*validators.GetValidatorOutput, numProposers int) *txForwarder {
+	state := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return validatorSet, nil
+		},
+	}
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(100, 0))
+	head := &types.Header{Number: big.NewInt(10), Time: 90}
+	f, err := newTxForwarder(
+		nil,
+		state,
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		params.TestChainConfig,
+		func() *types.Header { return head },
+		clock,
+		nodeID,
+		numProposers,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	return f
+}
+
+func TestTxForwarderProposers(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	validatorSet := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID: {NodeID: nodeID, Weight: 1},
+	}
+	for i := 0; i < 4; i++ {
+		validatorID := ids.GenerateTestNodeID()
+		validatorSet[validatorID] = &validators.GetValidatorOutput{NodeID: validatorID, Weight: 1}
+	}
+
+	f := newTestTxForwarder(t, nodeID, validatorSet, 3)
+	proposers, err := f.proposers(context.Background())
+	require.NoError(t, err)
+	require.NotZero(t, proposers.Len())
+	require.LessOrEqual(t, proposers.Len(), 3)
+	require.False(t, proposers.Contains(nodeID))
+	for proposerID := range proposers {
+		require.Contains(t, validatorSet, proposerID)
+	}
+
+	// The proposers are the same until the next proposer window
+	sameProposers, err := f.proposers(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, proposers, sameProposers)
+}
+
+func TestTxForwarderAnyoneCanPropose(t *testing.T) {
+	f := newTestTxForwarder(t, ids.GenerateTestNodeID(), nil, 3)
+	proposers, err := f.proposers(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, proposers.Len())
+
+	// Nothing is sent when there are no proposers to forward to
+	require.NoError(t, f.forward(context.Background(), []*types.Transaction{types.NewTx(&types.LegacyTx{})}))
+	require.Zero(t, testutil.ToFloat64(f.forwarded))
+}
+
+func TestTxForwarderAddFull(t *testing.T) {
+	f := newTestTxForwarder(t, ids.GenerateTestNodeID(), nil, 1)
+	for i := 0; i < txForwardingBuffer+2; i++ {
+		f.Add(types.NewTx(&types.LegacyTx{Nonce: uint64(i)}))
+	}
+	require.Len(t, f.txs, txForwardingBuffer)
+	require.Equal(t, float64(2), testutil.ToFloat64(f.dropped))
+
+	// Blob transactions are not forwarded
+	f = newTestTxForwarder(t, ids.GenerateTestNodeID(), nil, 1)
+	f.Add(types.NewTx(&types.BlobTx{}))
+	require.Empty(t, f.txs)
+}
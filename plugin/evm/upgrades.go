@@ -0,0 +1,198 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	avalanchegoConstants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UpgradeActivation lists the changes made to the chain when the upgrades
+// scheduled at [Timestamp] are activated.
+type UpgradeActivation struct {
+	Timestamp uint64    `json:"timestamp"`
+	Time      time.Time `json:"time"`
+	// Activated is true if the timestamp is already reached, in which case the
+	// changes must already be applied by all the nodes of the chain.
+	Activated bool     `json:"activated"`
+	Changes   []string `json:"changes"`
+}
+
+// UpgradesReport is the result of the validation of upgrade bytes.
+type UpgradesReport struct {
+	Activations []UpgradeActivation `json:"activations"`
+	// Warnings are the suspicious settings of valid upgrades, such as allow
+	// lists including the zero address.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ValidateUpgrades verifies [upgradeBytes] against the chain config of
+// [genesisBytes], as the VM would when starting on the network [networkID],
+// and reports the changes made at each activation relative to [now].
+func ValidateUpgrades(networkID uint32, genesisBytes []byte, upgradeBytes []byte, now time.Time) (*UpgradesReport, error) {
+	g := new(core.Genesis)
+	if err := json.Unmarshal(genesisBytes, g); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis: %w", err)
+	}
+	if g.Config == nil {
+		g.Config = params.SubnetEVMDefaultChainConfig
+	}
+	setMandatoryNetworkUpgrades(g.Config, networkID)
+	if g.Config.FeeConfig == commontype.EmptyFeeConfig {
+		g.Config.FeeConfig = params.DefaultFeeConfig
+	}
+
+	var upgradeConfig params.UpgradeConfig
+	if err := json.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade bytes: %w", err)
+	}
+	g.Config.UpgradeConfig = upgradeConfig
+	if err := g.Config.ApplyPrecompileAddresses(); err != nil {
+		return nil, err
+	}
+	if err := g.Verify(); err != nil {
+		return nil, fmt.Errorf("failed to verify genesis: %w", err)
+	}
+	return reportUpgrades(g.Config, uint64(now.Unix())), nil
+}
+
+// setMandatoryNetworkUpgrades sets the mandatory network upgrades of
+// [networkID] on [config]. They are enforced on the production networks, and
+// only used as defaults on the other networks.
+func setMandatoryNetworkUpgrades(config *params.ChainConfig, networkID uint32) {
+	mandatoryNetworkUpgrades := params.GetMandatoryNetworkUpgrades(networkID)
+	if avalanchegoConstants.ProductionNetworkIDs.Contains(networkID) {
+		// We enforce network upgrades here, regardless of the chain config
+		// provided in the genesis file
+		config.MandatoryNetworkUpgrades = mandatoryNetworkUpgrades
+	} else if config.MandatoryNetworkUpgrades == (params.MandatoryNetworkUpgrades{}) {
+		// If we are not enforcing, then apply those only if they are not
+		// already set in the genesis file
+		config.MandatoryNetworkUpgrades = mandatoryNetworkUpgrades
+	}
+}
+
+// applyUpgradeBytes returns a copy of [config] with the upgrades of
+// [upgradeBytes] instead of its own, after verifying them.
+func applyUpgradeBytes(config *params.ChainConfig, upgradeBytes []byte) (*params.ChainConfig, error) {
+	var upgradeConfig params.UpgradeConfig
+	if err := json.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade bytes: %w", err)
+	}
+	upgraded := *config
+	upgraded.UpgradeConfig = upgradeConfig
+	if err := upgraded.Verify(); err != nil {
+		return nil, err
+	}
+	return &upgraded, nil
+}
+
+// reportUpgrades lists the changes made by the upgrades of [config] at each
+// activation, and whether it is already reached at [now].
+func reportUpgrades(config *params.ChainConfig, now uint64) *UpgradesReport {
+	var (
+		report  = &UpgradesReport{}
+		changes = make(map[uint64][]string)
+	)
+	add := func(timestamp *uint64, change string) {
+		if timestamp != nil {
+			changes[*timestamp] = append(changes[*timestamp], change)
+		}
+	}
+
+	upgrades := config.UpgradeConfig
+	if networkUpgrades := upgrades.OptionalNetworkUpgrades; networkUpgrades != nil {
+		add(networkUpgrades.StromboliTimestamp, "activate network upgrade Stromboli")
+		add(networkUpgrades.VesuviusTimestamp, "activate network upgrade Vesuvius")
+	}
+	for _, upgrade := range upgrades.PrecompileUpgrades {
+		key := upgrade.Key()
+		if upgrade.IsDisabled() {
+			add(upgrade.Timestamp(), fmt.Sprintf("disable precompile %s (%s)", key, modules.ModuleAddress(key)))
+			continue
+		}
+		add(upgrade.Timestamp(), fmt.Sprintf("enable precompile %s (%s)", key, modules.ModuleAddress(key)))
+		report.Warnings = append(report.Warnings, allowListWarnings(key, upgrade.Config)...)
+	}
+	for _, upgrade := range upgrades.StateUpgrades {
+		add(upgrade.BlockTimestamp, fmt.Sprintf("upgrade the state of %d account(s)", len(upgrade.StateUpgradeAccounts)))
+	}
+	for _, upgrade := range upgrades.FeeConfigUpgrades {
+		feeConfig := upgrade.FeeConfig
+		add(upgrade.BlockTimestamp, fmt.Sprintf("update the fee config (gasLimit=%v, targetGas=%v, minBaseFee=%v)", feeConfig.GasLimit, feeConfig.TargetGas, feeConfig.MinBaseFee))
+	}
+
+	timestamps := make([]uint64, 0, len(changes))
+	for timestamp := range changes {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	for _, timestamp := range timestamps {
+		report.Activations = append(report.Activations, UpgradeActivation{
+			Timestamp: timestamp,
+			Time:      time.Unix(int64(timestamp), 0).UTC(),
+			Activated: timestamp <= now,
+			Changes:   changes[timestamp],
+		})
+	}
+	return report
+}
+
+// allowListWarnings returns the warnings about the allow list of [config],
+// enabling the precompile [key], if it has one.
+func allowListWarnings(key string, config precompileconfig.Config) []string {
+	allowList := allowListOf(config)
+	if allowList == nil {
+		return nil
+	}
+	var warnings []string
+	if len(allowList.AdminAddresses) == 0 && len(allowList.ManagerAddresses) == 0 {
+		warnings = append(warnings, fmt.Sprintf("%s has no admin or manager addresses, so its allow list cannot be changed once enabled", key))
+	}
+	roles := []struct {
+		name      string
+		addresses []common.Address
+	}{
+		{"admin", allowList.AdminAddresses},
+		{"manager", allowList.ManagerAddresses},
+		{"enabled", allowList.EnabledAddresses},
+	}
+	for _, role := range roles {
+		for _, address := range role.addresses {
+			if address == (common.Address{}) {
+				warnings = append(warnings, fmt.Sprintf("%s sets the zero address as %s", key, role.name))
+			} else if module, ok := modules.GetPrecompileModuleByAddress(address); ok {
+				warnings = append(warnings, fmt.Sprintf("%s sets the address of precompile %s as %s", key, module.ConfigKey, role.name))
+			}
+		}
+	}
+	return warnings
+}
+
+// allowListOf returns the allow list embedded in [config], or nil if the
+// precompile has no allow list.
+func allowListOf(config precompileconfig.Config) *allowlist.AllowListConfig {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.Elem().FieldByName("AllowListConfig")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(allowlist.AllowListConfig{}) {
+		return nil
+	}
+	allowList := field.Interface().(allowlist.AllowListConfig)
+	return &allowList
+}
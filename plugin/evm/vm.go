@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net/http"
 	"os"
@@ -19,7 +20,6 @@ import (
 	avalanchegoMetrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
-	avalanchegoConstants "github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/subnet-evm/commontype"
@@ -30,6 +30,8 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/eth"
 	"github.com/ava-labs/subnet-evm/eth/ethconfig"
+	"github.com/ava-labs/subnet-evm/eth/filters"
+	"github.com/ava-labs/subnet-evm/graphql"
 	"github.com/ava-labs/subnet-evm/metrics"
 	subnetEVMPrometheus "github.com/ava-labs/subnet-evm/metrics/prometheus"
 	"github.com/ava-labs/subnet-evm/miner"
@@ -37,12 +39,14 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/peer"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/predicate"
 
 	"github.com/ava-labs/subnet-evm/rpc"
 	statesyncclient "github.com/ava-labs/subnet-evm/sync/client"
 	"github.com/ava-labs/subnet-evm/sync/client/stats"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/warp"
+	warpHandlers "github.com/ava-labs/subnet-evm/warp/handlers"
 	warpValidators "github.com/ava-labs/subnet-evm/warp/validators"
 
 	// Force-load tracer engine to trigger registration
@@ -63,6 +67,8 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 
 	avalancheRPC "github.com/gorilla/rpc/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
@@ -73,6 +79,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -105,6 +112,7 @@ const (
 	// Prefixes for metrics gatherers
 	ethMetricsPrefix        = "eth"
 	chainStateMetricsPrefix = "chain_state"
+	warpMetricsPrefix       = "warp"
 
 	// p2p app protocols
 	ethTxGossipProtocol = 0x0
@@ -127,6 +135,7 @@ const (
 	adminEndpoint        = "/admin"
 	ethRPCEndpoint       = "/rpc"
 	ethWSEndpoint        = "/ws"
+	graphqlEndpoint      = "/graphql"
 	ethTxGossipNamespace = "eth_tx_gossip"
 )
 
@@ -204,6 +213,19 @@ type VM struct {
 	// block.
 	acceptedBlockDB database.Database
 
+	// [baseDB] is the database all the data of the VM is stored in, which is
+	// either the database provided by the node or [standaloneDB].
+	baseDB database.Database
+
+	// [standaloneDB] stores the chain data instead of the database provided by
+	// the node, if a [DatabaseType] is configured.
+	standaloneDB database.Database
+	dbMetrics    *prometheus.Registry
+
+	// [streamServer] serves the accepted blocks to indexers over gRPC, if a
+	// [StreamGRPCAddress] is configured.
+	streamServer *streamServer
+
 	// [warpDB] is used to store warp message signatures
 	// set to a prefixDB with the prefix [warpPrefix]
 	warpDB database.Database
@@ -214,6 +236,17 @@ type VM struct {
 
 	builder *blockBuilder
 
+	// circuitBreaker holds the incident response controls set through the admin API
+	circuitBreaker circuitBreaker
+
+	// authenticator authenticates the calls to the chain RPC and the admin API,
+	// if API keys are configured
+	authenticator *apiKeyAuthenticator
+
+	// chainConfigHasher computes the hashes of the chain config served to peers
+	// and through the admin API
+	chainConfigHasher *chainConfigHasher
+
 	clock mockable.Clock
 
 	shutdownChan chan struct{}
@@ -235,6 +268,11 @@ type VM struct {
 	bootstrapped bool
 
 	logger SubnetEVMLogger
+	// logFile is the rotated file the logs are also written to, if configured
+	logFile *lumberjack.Logger
+	// tracer records the spans of block building, verification, acceptance,
+	// state sync and warp signatures
+	tracer trace.Tracer
 	// State sync server and client
 	StateSyncServer
 	StateSyncClient
@@ -247,6 +285,8 @@ type VM struct {
 	p2pSender          commonEng.AppSender
 	ethTxGossipHandler p2p.Handler
 	ethTxPushGossiper  avalancheUtils.Atomic[*gossip.PushGossiper[*GossipEthTx]]
+	ethTxPushBatcher   avalancheUtils.Atomic[*batchingGossiper]
+	ethTxForwarder     avalancheUtils.Atomic[*txForwarder]
 	ethTxPullGossiper  gossip.Gossiper
 }
 
@@ -280,14 +320,33 @@ func (vm *VM) Initialize(
 		alias = vm.ctx.ChainID.String()
 	}
 
-	subnetEVMLogger, err := InitLogger(alias, vm.config.LogLevel, vm.config.LogJSONFormat, vm.ctx.Log)
+	var logFile io.Writer
+	if vm.config.LogFile != "" {
+		vm.logFile = &lumberjack.Logger{
+			Filename:   vm.config.LogFile,
+			MaxSize:    vm.config.LogFileMaxSize,
+			MaxBackups: vm.config.LogFileMaxBackups,
+			MaxAge:     vm.config.LogFileMaxAge,
+			Compress:   vm.config.LogFileCompress,
+		}
+		logFile = vm.logFile
+	}
+	subnetEVMLogger, err := InitLogger(alias, vm.config.LogLevel, vm.config.LogJSONFormat, vm.ctx.Log, logFile)
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger due to: %w ", err)
 	}
+	if err := subnetEVMLogger.SetSubsystemLogLevels(vm.config.LogSubsystemLevels); err != nil {
+		return fmt.Errorf("failed to set log subsystem levels due to: %w ", err)
+	}
 	vm.logger = subnetEVMLogger
 
 	log.Info("Initializing Subnet EVM VM", "Version", Version, "Config", vm.config)
 
+	vm.tracer, err = newTracer(&vm.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracer: %w", err)
+	}
+
 	if len(fxs) > 0 {
 		return errUnsupportedFXs
 	}
@@ -297,6 +356,20 @@ func (vm *VM) Initialize(
 
 	vm.toEngine = toEngine
 	vm.shutdownChan = make(chan struct{}, 1)
+	if vm.config.DatabaseType != "" {
+		vm.dbMetrics = prometheus.NewRegistry()
+		vm.standaloneDB, err = vm.openStandaloneDatabase(db, vm.dbMetrics)
+		if err != nil {
+			return err
+		}
+		db = vm.standaloneDB
+	}
+	if vm.config.DatabaseRestorePath != "" {
+		if err := vm.restoreDatabase(db); err != nil {
+			return fmt.Errorf("failed to restore database from %q: %w", vm.config.DatabaseRestorePath, err)
+		}
+	}
+	vm.baseDB = db
 	// Use NewNested rather than New so that the structure of the database
 	// remains the same regardless of the provided baseDB type.
 	vm.chaindb = rawdb.NewDatabase(Database{prefixdb.NewNested(ethDBPrefix, db)})
@@ -326,18 +399,7 @@ func (vm *VM) Initialize(
 		g.Config = params.SubnetEVMDefaultChainConfig
 	}
 
-	mandatoryNetworkUpgrades := params.GetMandatoryNetworkUpgrades(chainCtx.NetworkID)
-	if avalanchegoConstants.ProductionNetworkIDs.Contains(chainCtx.NetworkID) {
-		// We enforce network upgrades here, regardless of the chain config
-		// provided in the genesis file
-		g.Config.MandatoryNetworkUpgrades = mandatoryNetworkUpgrades
-	} else {
-		// If we are not enforcing, then apply those only if they are not
-		// already set in the genesis file
-		if g.Config.MandatoryNetworkUpgrades == (params.MandatoryNetworkUpgrades{}) {
-			g.Config.MandatoryNetworkUpgrades = mandatoryNetworkUpgrades
-		}
-	}
+	setMandatoryNetworkUpgrades(g.Config, chainCtx.NetworkID)
 
 	// Load airdrop file if provided
 	if vm.config.AirdropFile != "" {
@@ -368,6 +430,12 @@ func (vm *VM) Initialize(
 		g.Config.UpgradeConfig = upgradeConfig
 	}
 
+	// Move the custom precompiles to the addresses configured by the chain before
+	// any precompile address is used.
+	if err := g.Config.ApplyPrecompileAddresses(); err != nil {
+		return err
+	}
+
 	if err := g.Verify(); err != nil {
 		return fmt.Errorf("failed to verify genesis: %w", err)
 	}
@@ -385,6 +453,8 @@ func (vm *VM) Initialize(
 	// gas price to prevent so transactions and blocks all use the correct fees
 	vm.ethConfig.RPCGasCap = vm.config.RPCGasCap
 	vm.ethConfig.RPCEVMTimeout = vm.config.APIMaxDuration.Duration
+	vm.ethConfig.RPCTraceTimeout = vm.config.TraceTimeout.Duration
+	vm.ethConfig.RPCMaxTraceTimeout = vm.config.MaxTraceTimeout.Duration
 	vm.ethConfig.RPCTxFeeCap = vm.config.RPCTxFeeCap
 
 	vm.ethConfig.TxPool.Locals = vm.config.PriorityRegossipAddresses
@@ -396,11 +466,23 @@ func (vm *VM) Initialize(
 	vm.ethConfig.TxPool.AccountQueue = vm.config.TxPoolAccountQueue
 	vm.ethConfig.TxPool.GlobalQueue = vm.config.TxPoolGlobalQueue
 	vm.ethConfig.TxPool.Lifetime = vm.config.TxPoolLifetime.Duration
+	vm.ethConfig.TxPool.Journal = vm.config.TxPoolJournal
+	if vm.ethConfig.TxPool.Journal != "" && !filepath.IsAbs(vm.ethConfig.TxPool.Journal) {
+		vm.ethConfig.TxPool.Journal = filepath.Join(vm.ctx.ChainDataDir, vm.ethConfig.TxPool.Journal)
+	}
+	vm.ethConfig.TxPool.Rejournal = vm.config.TxPoolRejournal.Duration
+	vm.ethConfig.TxPool.PrioritySenders = vm.config.TxPoolPrioritySenders
+	vm.ethConfig.BlobPool.Retention = vm.config.BlobPoolRetention
 
 	vm.ethConfig.AllowUnfinalizedQueries = vm.config.AllowUnfinalizedQueries
 	vm.ethConfig.AllowUnprotectedTxs = vm.config.AllowUnprotectedTxs
 	vm.ethConfig.AllowUnprotectedTxHashes = vm.config.AllowUnprotectedTxHashes
 	vm.ethConfig.Preimages = vm.config.Preimages
+	vm.ethConfig.EnablePreimageRecording = vm.config.KeccakPreimages
+	vm.ethConfig.StateDiffs = vm.config.StateDiffs
+	vm.ethConfig.StateDiffsRetention = vm.config.StateDiffsRetention
+	vm.ethConfig.GasUsageWindow = vm.config.GasUsageIndexWindow
+	vm.ethConfig.GasUsageRetention = vm.config.GasUsageIndexRetention
 	vm.ethConfig.Pruning = vm.config.Pruning
 	vm.ethConfig.TrieCleanCache = vm.config.TrieCleanCache
 	vm.ethConfig.TrieDirtyCache = vm.config.TrieDirtyCache
@@ -422,6 +504,10 @@ func (vm *VM) Initialize(
 	vm.ethConfig.AcceptedCacheSize = vm.config.AcceptedCacheSize
 	vm.ethConfig.TxLookupLimit = vm.config.TxLookupLimit
 	vm.ethConfig.SkipTxIndexing = vm.config.SkipTxIndexing
+	vm.ethConfig.ParallelTxExecution = vm.config.ParallelTxExecution
+	vm.ethConfig.AsyncStateCommit = vm.config.AsyncStateCommit
+	vm.ethConfig.StateHistory = vm.config.StateHistory
+	vm.ethConfig.StateHistoryDuration = vm.config.StateHistoryDuration.Duration
 
 	// Create directory for offline pruning
 	if len(vm.ethConfig.OfflinePruningDataDirectory) != 0 {
@@ -440,6 +526,9 @@ func (vm *VM) Initialize(
 		log.Info("Config has not specified any coinbase address. Defaulting to the blackhole address.")
 		vm.ethConfig.Miner.Etherbase = constants.BlackholeAddr
 	}
+	vm.ethConfig.Miner.ExtraData = vm.config.BlockExtraData
+	vm.ethConfig.Miner.Ordering = vm.config.BlockBuilderOrdering
+	vm.ethConfig.Miner.BuildTimeBudget = vm.config.BlockBuildTimeBudget.Duration
 
 	vm.chainConfig = g.Config
 	vm.networkID = vm.ethConfig.NetworkId
@@ -488,9 +577,29 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	if len(vm.config.APIKeys) > 0 {
+		vm.authenticator = newAPIKeyAuthenticator(vm.config.APIKeys)
+	}
+	// Restore the incident response controls set before the node restarted.
+	if err := vm.circuitBreaker.initialize(vm.metadataDB, vm.db.Commit); err != nil {
+		return fmt.Errorf("failed to restore circuit breaker: %w", err)
+	}
+
 	if err := vm.initializeChain(lastAcceptedHash, vm.ethConfig); err != nil {
 		return err
 	}
+	vm.chainConfigHasher, err = newChainConfigHasher(vm.chainConfig, vm.blockChain, vm.networkCodec)
+	if err != nil {
+		return err
+	}
+	log.Info("chain config hash", "hash", vm.chainConfigHasher.hash)
+
+	if vm.config.StreamGRPCAddress != "" {
+		vm.streamServer, err = newStreamServer(vm.blockChain, vm.config.StreamGRPCAddress)
+		if err != nil {
+			return fmt.Errorf("failed to start accepted block stream: %w", err)
+		}
+	}
 
 	go vm.ctx.Log.RecoverAndPanic(vm.startContinuousProfiler)
 
@@ -510,6 +619,18 @@ func (vm *VM) initializeMetrics() error {
 		if err := vm.multiGatherer.Register("sdk", vm.sdkMetrics); err != nil {
 			return err
 		}
+		warpMetrics := prometheus.NewRegistry()
+		if err := warpMetrics.Register(warpHandlers.SignaturesServed); err != nil {
+			return err
+		}
+		if err := vm.multiGatherer.Register(warpMetricsPrefix, warpMetrics); err != nil {
+			return err
+		}
+		if vm.dbMetrics != nil {
+			if err := vm.multiGatherer.Register("db", vm.dbMetrics); err != nil {
+				return err
+			}
+		}
 		// Register [multiGatherer] after registerers have been registered to it
 		if err := vm.ctx.Metrics.Register(vm.multiGatherer); err != nil {
 			return err
@@ -523,6 +644,7 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash, ethConfig ethconfig.
 		SubnetEVMVersion:      Version,
 		KeyStoreDir:           vm.config.KeystoreDirectory,
 		ExternalSigner:        vm.config.KeystoreExternalSigner,
+		ExternalSignerHeaders: vm.config.KeystoreExternalSignerHeaders,
 		InsecureUnlockAllowed: vm.config.KeystoreInsecureUnlockAllowed,
 	}
 	node, err := node.New(nodecfg)
@@ -545,7 +667,9 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash, ethConfig ethconfig.
 	vm.txPool = vm.eth.TxPool()
 	vm.txPool.SetMinFee(vm.chainConfig.FeeConfig.MinBaseFee)
 	vm.txPool.SetGasTip(big.NewInt(0))
+	vm.txPool.SetSenderFilter(vm.circuitBreaker.filterSender)
 	vm.blockChain = vm.eth.BlockChain()
+	vm.txPool.SetTxFilter(vm.verifyTxPredicates)
 	vm.miner = vm.eth.Miner()
 
 	vm.eth.Start()
@@ -570,6 +694,18 @@ func (vm *VM) initializeStateSyncClient(lastAcceptedHeight uint64) error {
 		}
 	}
 
+	// Only verify the checkpoint while the local state is behind it, since it
+	// is signed by the validators at the time it is verified.
+	var checkpoint *syncCheckpoint
+	if vm.config.StateSyncEnabled && len(vm.config.StateSyncCheckpoint) > 0 && lastAcceptedHeight < vm.config.StateSyncCheckpointHeight {
+		var err error
+		checkpoint, err = parseSyncCheckpoint(context.TODO(), vm.ctx, vm.config.StateSyncCheckpoint, vm.config.StateSyncCheckpointHeight)
+		if err != nil {
+			return err
+		}
+		log.Info("Using trusted state sync checkpoint", "hash", checkpoint.hash, "height", checkpoint.height)
+	}
+
 	vm.StateSyncClient = NewStateSyncClient(&stateSyncClientConfig{
 		chain: vm.eth,
 		state: vm.State,
@@ -582,10 +718,17 @@ func (vm *VM) initializeStateSyncClient(lastAcceptedHeight uint64) error {
 				BlockParser:      vm,
 			},
 		),
+		tracer:               vm.tracer,
 		enabled:              vm.config.StateSyncEnabled,
 		skipResume:           vm.config.StateSyncSkipResume,
 		stateSyncMinBlocks:   vm.config.StateSyncMinBlocks,
 		stateSyncRequestSize: vm.config.StateSyncRequestSize,
+		incremental:          vm.config.StateSyncIncremental,
+		parallelism:          vm.config.StateSyncParallelism,
+		adaptiveParallelism:  vm.config.StateSyncAdaptiveParallelism,
+		codeBatchSize:        vm.config.StateSyncCodeBatchSize,
+		codeWorkers:          vm.config.StateSyncCodeWorkers,
+		checkpoint:           checkpoint,
 		lastAcceptedHeight:   lastAcceptedHeight, // TODO clean up how this is passed around
 		chaindb:              vm.chaindb,
 		metadataDB:           vm.metadataDB,
@@ -658,6 +801,7 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 		if err := vm.initBlockBuilding(); err != nil {
 			return fmt.Errorf("failed to initialize block building: %w", err)
 		}
+		vm.startChainConfigCheck()
 		vm.bootstrapped = true
 		return nil
 	default:
@@ -676,7 +820,12 @@ func (vm *VM) initBlockBuilding() error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize eth tx gossip metrics: %w", err)
 	}
-	ethTxPool, err := NewGossipEthTxPool(vm.txPool, vm.sdkMetrics)
+	ethTxPool, err := NewGossipEthTxPoolWithBloomConfig(vm.txPool, vm.sdkMetrics, TxGossipBloomConfig{
+		MinTargetElements:       vm.config.TxGossipBloomMinTargetElements,
+		TargetFalsePositiveRate: vm.config.TxGossipBloomTargetFalsePositiveRate,
+		ResetFalsePositiveRate:  vm.config.TxGossipBloomResetFalsePositiveRate,
+		ChurnMultiplier:         vm.config.TxGossipBloomChurnMultiplier,
+	})
 	if err != nil {
 		return err
 	}
@@ -694,6 +843,15 @@ func (vm *VM) initBlockBuilding() error {
 		Validators: vm.config.PushRegossipNumValidators,
 		Peers:      vm.config.PushRegossipNumPeers,
 	}
+	// The validators serve pull requests and pull transactions by default. When the
+	// tx gossip is restricted to validators, transactions are not pushed to peers
+	// that may not be validators, and the allowed nodes are treated as validators.
+	var txGossipValidators p2p.ValidatorSet = vm.validators
+	if vm.config.TxGossipValidatorsOnly {
+		pushGossipParams.Peers = 0
+		pushRegossipParams.Peers = 0
+		txGossipValidators = newTxGossipSet(vm.validators, vm.config.TxGossipAllowedNodeIDs)
+	}
 
 	ethTxPushGossiper := vm.ethTxPushGossiper.Get()
 	if ethTxPushGossiper == nil {
@@ -714,6 +872,52 @@ func (vm *VM) initBlockBuilding() error {
 		vm.ethTxPushGossiper.Set(ethTxPushGossiper)
 	}
 
+	var pushGossiper gossip.Gossiper = ethTxPushGossiper
+	if vm.config.PushGossipMaxDelay.Duration > 0 {
+		batcher, err := newBatchingGossiper(ethTxPushGossiper, vm.config.PushGossipBatchSize, vm.config.PushGossipMaxDelay.Duration, vm.sdkMetrics)
+		if err != nil {
+			return err
+		}
+		vm.ethTxPushBatcher.Set(batcher)
+		pushGossiper = batcher
+	}
+
+	if vm.config.TxForwardingNumProposers > 0 {
+		forwarder, err := newTxForwarder(
+			ethTxGossipClient,
+			vm.ctx.ValidatorState,
+			vm.ctx.SubnetID,
+			vm.ctx.ChainID,
+			vm.chainConfig,
+			vm.blockChain.CurrentBlock,
+			&vm.clock,
+			vm.ctx.NodeID,
+			vm.config.TxForwardingNumProposers,
+			vm.sdkMetrics,
+		)
+		if err != nil {
+			return err
+		}
+		vm.ethTxForwarder.Set(forwarder)
+		vm.shutdownWg.Add(1)
+		go func() {
+			forwarder.Run(ctx)
+			vm.shutdownWg.Done()
+		}()
+	}
+
+	// Peers may have dropped the local transactions loaded from the journal
+	// while the node was down, so push them again now that the gossiper exists.
+	for _, addr := range vm.txPool.Locals() {
+		pending, _ := vm.txPool.ContentFrom(addr)
+		for _, tx := range pending {
+			ethTxPushGossiper.Add(&GossipEthTx{Tx: tx})
+		}
+		if batcher := vm.ethTxPushBatcher.Get(); batcher != nil {
+			batcher.Add(len(pending))
+		}
+	}
+
 	// NOTE: gossip network must be initialized first otherwise ETH tx gossip will not work.
 	gossipStats := NewGossipStats()
 	vm.builder = vm.NewBlockBuilder(vm.toEngine)
@@ -729,8 +933,14 @@ func (vm *VM) initBlockBuilding() error {
 			txGossipTargetMessageSize,
 			txGossipThrottlingPeriod,
 			txGossipThrottlingLimit,
-			vm.validators,
+			txGossipValidators,
 		)
+		if vm.config.TxGossipPeerBandwidth > 0 {
+			vm.ethTxGossipHandler, err = newBandwidthLimitedHandler(vm.ethTxGossipHandler, vm.config.TxGossipPeerBandwidth, txGossipTargetMessageSize, vm.sdkMetrics)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if err := vm.Network.AddHandler(ethTxGossipProtocol, vm.ethTxGossipHandler); err != nil {
@@ -750,13 +960,13 @@ func (vm *VM) initBlockBuilding() error {
 		vm.ethTxPullGossiper = gossip.ValidatorGossiper{
 			Gossiper:   ethTxPullGossiper,
 			NodeID:     vm.ctx.NodeID,
-			Validators: vm.validators,
+			Validators: txGossipValidators,
 		}
 	}
 
 	vm.shutdownWg.Add(2)
 	go func() {
-		gossip.Every(ctx, vm.ctx.Log, ethTxPushGossiper, vm.config.PushGossipFrequency.Duration)
+		gossip.Every(ctx, vm.ctx.Log, pushGossiper, vm.config.PushGossipFrequency.Duration)
 		vm.shutdownWg.Done()
 	}()
 	go func() {
@@ -767,6 +977,21 @@ func (vm *VM) initBlockBuilding() error {
 	return nil
 }
 
+// startChainConfigCheck starts comparing the chain config of this node with
+// the one of its peers, unless disabled in the config.
+func (vm *VM) startChainConfigCheck() {
+	frequency := vm.config.ChainConfigCheckFrequency.Duration
+	if frequency <= 0 {
+		return
+	}
+	checker := newChainConfigChecker(vm.chainConfigHasher, vm.client, vm.networkCodec)
+	vm.shutdownWg.Add(1)
+	go func() {
+		checker.run(frequency, vm.shutdownChan)
+		vm.shutdownWg.Done()
+	}()
+}
+
 // setAppRequestHandlers sets the request handlers for the VM to serve state sync
 // requests.
 func (vm *VM) setAppRequestHandlers() {
@@ -780,7 +1005,7 @@ func (vm *VM) setAppRequestHandlers() {
 		},
 	)
 
-	networkHandler := newNetworkHandler(vm.blockChain, vm.chaindb, evmTrieDB, vm.warpBackend, vm.networkCodec)
+	networkHandler := newNetworkHandler(vm.blockChain, vm.chaindb, evmTrieDB, vm.warpBackend, vm.chainConfigHasher, vm.networkCodec, vm.tracer)
 	vm.Network.SetRequestHandler(networkHandler)
 }
 
@@ -799,6 +1024,9 @@ func (vm *VM) Shutdown(context.Context) error {
 	if vm.cancel != nil {
 		vm.cancel()
 	}
+	if vm.streamServer != nil {
+		vm.streamServer.stop()
+	}
 	vm.Network.Shutdown()
 	if err := vm.StateSyncClient.Shutdown(); err != nil {
 		log.Error("error stopping state syncer", "err", err)
@@ -807,7 +1035,20 @@ func (vm *VM) Shutdown(context.Context) error {
 	vm.eth.Stop()
 	log.Info("Ethereum backend stop completed")
 	vm.shutdownWg.Wait()
+	if vm.standaloneDB != nil {
+		if err := vm.standaloneDB.Close(); err != nil {
+			log.Error("error closing standalone database", "err", err)
+		}
+	}
+	if err := vm.tracer.Close(); err != nil {
+		log.Error("error closing tracer", "err", err)
+	}
 	log.Info("Subnet-EVM Shutdown completed")
+	if vm.logFile != nil {
+		if err := vm.logFile.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -816,22 +1057,39 @@ func (vm *VM) buildBlock(ctx context.Context) (snowman.Block, error) {
 	return vm.buildBlockWithContext(ctx, nil)
 }
 
-func (vm *VM) buildBlockWithContext(ctx context.Context, proposerVMBlockCtx *block.Context) (snowman.Block, error) {
+func (vm *VM) buildBlockWithContext(ctx context.Context, proposerVMBlockCtx *block.Context) (_ snowman.Block, err error) {
+	ctx, span := vm.tracer.Start(ctx, "subnetevm.buildBlock")
+	defer func() {
+		vm.builder.recordBuildResult(err)
+		endSpan(span, err)
+	}()
+
 	if proposerVMBlockCtx != nil {
 		log.Debug("Building block with context", "pChainBlockHeight", proposerVMBlockCtx.PChainHeight)
 	} else {
 		log.Debug("Building block without context")
 	}
+	if err := vm.circuitBreaker.checkBlockProduction(); err != nil {
+		vm.builder.handleGenerateBlock()
+		return nil, err
+	}
 	predicateCtx := &precompileconfig.PredicateContext{
 		SnowCtx:            vm.ctx,
 		ProposerVMBlockCtx: proposerVMBlockCtx,
 	}
 
+	_, generateSpan := vm.tracer.Start(ctx, "subnetevm.generateBlock")
 	block, err := vm.miner.GenerateBlock(predicateCtx)
+	endSpan(generateSpan, err)
 	vm.builder.handleGenerateBlock()
 	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(
+		attribute.Int64("height", int64(block.NumberU64())),
+		attribute.Int("numTxs", len(block.Transactions())),
+		attribute.Int64("gasUsed", int64(block.GasUsed())),
+	)
 
 	// Note: the status of block is set by ChainState
 	blk := vm.newBlock(block)
@@ -848,7 +1106,7 @@ func (vm *VM) buildBlockWithContext(ctx context.Context, proposerVMBlockCtx *blo
 	// We call verify without writes here to avoid generating a reference
 	// to the blk state root in the triedb when we are going to call verify
 	// again from the consensus engine with writes enabled.
-	if err := blk.verify(predicateCtx, false /*=writes*/); err != nil {
+	if err := blk.verify(ctx, predicateCtx, false /*=writes*/); err != nil {
 		return nil, fmt.Errorf("block failed verification due to: %w", err)
 	}
 
@@ -858,6 +1116,31 @@ func (vm *VM) buildBlockWithContext(ctx context.Context, proposerVMBlockCtx *blo
 	return blk, nil
 }
 
+// verifyTxPredicates rejects the transactions whose predicates fail verification
+// against the current P-chain height before they enter the mempool, instead of
+// letting them burn their gas once included. Blocks are built at the P-chain
+// height of their proposer, so the predicates are verified again then.
+func (vm *VM) verifyTxPredicates(tx *types.Transaction) error {
+	head := vm.blockChain.CurrentBlock()
+	config, err := vm.blockChain.ConfigAt(head)
+	if err != nil {
+		return err
+	}
+	rules := config.Rules(head.Number, head.Time)
+	if len(predicate.PreparePredicateStorageSlots(rules, tx.AccessList())) == 0 {
+		return nil
+	}
+	pChainHeight, err := vm.ctx.ValidatorState.GetCurrentHeight(context.TODO())
+	if err != nil {
+		log.Debug("Skipping predicate verification of transaction", "tx", tx.Hash(), "err", err)
+		return nil
+	}
+	return core.VerifyPredicates(rules, &precompileconfig.PredicateContext{
+		SnowCtx:            vm.ctx,
+		ProposerVMBlockCtx: &block.Context{PChainHeight: pChainHeight},
+	}, tx)
+}
+
 // parseBlock parses [b] into a block to be wrapped by ChainState.
 func (vm *VM) parseBlock(_ context.Context, b []byte) (snowman.Block, error) {
 	ethBlock := new(types.Block)
@@ -950,10 +1233,18 @@ func newHandler(name string, service interface{}) (http.Handler, error) {
 // CreateHandlers makes new http handlers that can handle API calls
 func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	handler := rpc.NewServer(vm.config.APIMaxDuration.Duration)
+	authenticator := vm.authenticator
+	if authenticator != nil {
+		handler.SetCallAuthorizer(authenticator.authorize)
+	}
 	enabledAPIs := vm.config.EthAPIs()
 	if err := attachEthService(handler, vm.eth.APIs(), enabledAPIs); err != nil {
 		return nil, err
 	}
+	if err := handler.RegisterName("eth", &CircuitBreakerAPI{vm}); err != nil {
+		return nil, err
+	}
+	enabledAPIs = append(enabledAPIs, "circuit-breaker")
 
 	primaryAlias, err := vm.ctx.BCLookup.PrimaryAlias(vm.ctx.ChainID)
 	if err != nil {
@@ -965,6 +1256,9 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to register service for admin API due to %w", err)
 		}
+		if authenticator != nil {
+			adminAPI = authenticator.wrap(adminAPI)
+		}
 		apis[adminEndpoint] = adminAPI
 		enabledAPIs = append(enabledAPIs, "subnet-evm-admin")
 	}
@@ -976,14 +1270,35 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		enabledAPIs = append(enabledAPIs, "snowman")
 	}
 
+	if vm.config.BuildDiagnosticsAPIEnabled {
+		if err := handler.RegisterName("debug", &BuildDiagnosticsAPI{vm}); err != nil {
+			return nil, err
+		}
+		enabledAPIs = append(enabledAPIs, "build-diagnostics")
+	}
+
 	if vm.config.WarpAPIEnabled {
 		validatorsState := warpValidators.NewState(vm.ctx)
-		if err := handler.RegisterName("warp", warp.NewAPI(vm.ctx.NetworkID, vm.ctx.SubnetID, vm.ctx.ChainID, validatorsState, vm.warpBackend, vm.client)); err != nil {
+		if err := handler.RegisterName("warp", warp.NewAPI(vm.ctx.NetworkID, vm.ctx.SubnetID, vm.ctx.ChainID, validatorsState, vm.warpBackend, vm.client, vm.config.WarpPrimaryNetworkSampleSize, vm.tracer)); err != nil {
 			return nil, err
 		}
 		enabledAPIs = append(enabledAPIs, "warp")
 	}
 
+	endpoints := []string{ethRPCEndpoint, ethWSEndpoint}
+	if vm.config.GraphQLEnabled {
+		filterSystem := filters.NewFilterSystem(vm.eth.APIBackend, filters.Config{
+			Timeout: 5 * time.Minute,
+		})
+		graphqlAPI, err := graphql.New(vm.eth.APIBackend, filterSystem)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GraphQL handler due to %w", err)
+		}
+		apis[graphqlEndpoint] = graphqlAPI
+		endpoints = append(endpoints, graphqlEndpoint)
+		enabledAPIs = append(enabledAPIs, "graphql")
+	}
+
 	log.Info(fmt.Sprintf("Enabled APIs: %s", strings.Join(enabledAPIs, ", ")))
 	apis[ethRPCEndpoint] = handler
 	apis[ethWSEndpoint] = handler.WebsocketHandlerWithDuration(
@@ -992,6 +1307,21 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		vm.config.WSCPURefillRate.Duration,
 		vm.config.WSCPUMaxStored.Duration,
 	)
+	if max := vm.config.APIMaxConcurrentRequestsPerIP; max > 0 {
+		// The HTTP, websocket and GraphQL endpoints share the concurrency of each IP
+		limiter := newPerIPConcurrencyLimiter(max)
+		for _, endpoint := range endpoints {
+			apis[endpoint] = limiter.wrap(apis[endpoint])
+		}
+	}
+	if authenticator != nil {
+		for _, endpoint := range []string{ethRPCEndpoint, ethWSEndpoint} {
+			apis[endpoint] = authenticator.wrap(apis[endpoint])
+		}
+		if graphqlAPI, ok := apis[graphqlEndpoint]; ok {
+			apis[graphqlEndpoint] = authenticator.wrapMethod(graphqlAPI, graphqlMethod)
+		}
+	}
 
 	return apis, nil
 }
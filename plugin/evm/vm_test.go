@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -59,6 +60,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/utils"
@@ -3270,3 +3272,268 @@ func TestCrossChainMessagestoVM(t *testing.T) {
 	require.NoError(err)
 	require.True(calledSendCrossChainAppResponseFn, "sendCrossChainAppResponseFn was not called")
 }
+
+func TestBuildBlockWithOperatorData(t *testing.T) {
+	genesis := &core.Genesis{}
+	require.NoError(t, genesis.UnmarshalJSON([]byte(genesisJSONDurango)))
+	genesis.Config.StromboliTimestamp = utils.NewUint64(0)
+	genesis.Config.MaxOperatorDataSize = 32
+	genesis.Config.OperatorDataPrefixes = []hexutil.Bytes{{0x01}}
+	genesisJSON, err := genesis.MarshalJSON()
+	require.NoError(t, err)
+
+	operatorData := []byte{0x01, 0xab, 0xcd}
+	configJSON := fmt.Sprintf(`{"block-extra-data": "%s"}`, hexutil.Encode(operatorData))
+	issuer, vm, _, _ := GenesisVM(t, true, string(genesisJSON), configJSON, "")
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+	}()
+
+	tx := types.NewTransaction(uint64(0), testEthAddrs[1], firstTxAmount, 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(t, err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(t, err)
+	}
+
+	blk := issueAndAccept(t, issuer, vm)
+	extra := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Extra()
+	parsed, resultBytes, err := params.ParseOperatorData(extra[params.DynamicFeeExtraDataSize:])
+	require.NoError(t, err)
+	require.Equal(t, operatorData, parsed)
+	predicateBytes, ok := predicate.GetPredicateResultBytes(extra)
+	require.True(t, ok)
+	require.Equal(t, resultBytes, predicateBytes)
+
+	// Data not allowed by the chain is omitted from built blocks.
+	require.NoError(t, vm.eth.Miner().SetExtra([]byte{0x02}))
+	vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+	tx = types.NewTransaction(uint64(1), testEthAddrs[1], firstTxAmount, 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err = types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(t, err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(t, err)
+	}
+	blk = issueAndAccept(t, issuer, vm)
+	extra = blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Extra()
+	parsed, _, err = params.ParseOperatorData(extra[params.DynamicFeeExtraDataSize:])
+	require.NoError(t, err)
+	require.Empty(t, parsed)
+}
+
+func TestTxPoolJournal(t *testing.T) {
+	require := require.New(t)
+
+	journal := filepath.Join(t.TempDir(), "transactions.rlp")
+	configJSON := fmt.Sprintf(`{"local-txs-enabled":true,"tx-pool-journal":%q}`, journal)
+	_, vm, dbManager, _ := GenesisVM(t, true, genesisJSONSubnetEVM, configJSON, "")
+
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	require.NoError(vm.txPool.Add([]*txpool.Transaction{{Tx: signedTx}}, true, true)[0])
+	require.NoError(vm.Shutdown(context.Background()))
+
+	restartedVM := &VM{}
+	appSender := &commonEng.SenderTest{T: t}
+	appSender.CantSendAppGossip = true
+	appSender.SendAppGossipF = func(context.Context, []byte, int, int, int) error { return nil }
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		NewContext(),
+		dbManager,
+		buildGenesisTest(t, genesisJSONSubnetEVM),
+		[]byte(""),
+		[]byte(configJSON),
+		make(chan commonEng.Message, 1),
+		[]*commonEng.Fx{},
+		appSender,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+
+	// The local transaction is re-injected from the journal
+	require.True(restartedVM.txPool.HasLocal(signedTx.Hash()))
+	require.NoError(restartedVM.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(restartedVM.SetState(context.Background(), snow.NormalOp))
+}
+
+func TestScheduleStatePruning(t *testing.T) {
+	require := require.New(t)
+
+	configJSON := fmt.Sprintf(`{"offline-pruning-data-directory":%q}`, t.TempDir())
+	issuer, vm, dbManager, _ := GenesisVM(t, true, genesisJSONSubnetEVM, configJSON, "")
+	admin := NewAdminService(vm, t.TempDir())
+
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(err)
+	}
+	blk := issueAndAccept(t, issuer, vm)
+
+	// Requests can be cancelled until the node restarts.
+	status := &StatePruningStatusReply{}
+	require.NoError(admin.ScheduleStatePruning(nil, nil, &api.EmptyReply{}))
+	require.NoError(admin.GetStatePruningStatus(nil, nil, status))
+	require.NotNil(status.ScheduledAt)
+	require.NoError(admin.CancelStatePruning(nil, nil, &api.EmptyReply{}))
+	status = &StatePruningStatusReply{}
+	require.NoError(admin.GetStatePruningStatus(nil, nil, status))
+	require.Nil(status.ScheduledAt)
+
+	require.NoError(admin.ScheduleStatePruning(nil, nil, &api.EmptyReply{}))
+	require.NoError(vm.Shutdown(context.Background()))
+
+	// The requested pruning runs once on restart, without enabling offline pruning.
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		NewContext(),
+		dbManager,
+		buildGenesisTest(t, genesisJSONSubnetEVM),
+		[]byte(""),
+		[]byte(configJSON),
+		make(chan commonEng.Message, 1),
+		[]*commonEng.Fx{},
+		nil,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	require.True(restartedVM.blockChain.HasState(blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Root()))
+
+	status = &StatePruningStatusReply{}
+	require.NoError(NewAdminService(restartedVM, t.TempDir()).GetStatePruningStatus(nil, nil, status))
+	require.Nil(status.ScheduledAt)
+	require.NotNil(status.LastRun)
+	require.Nil(status.InterruptedRoot)
+}
+
+func TestStandaloneDatabaseMigration(t *testing.T) {
+	require := require.New(t)
+
+	issuer, vm, dbManager, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(1), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+	require.NoError(err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(err)
+	}
+	blk := issueAndAccept(t, issuer, vm)
+	require.NoError(vm.Shutdown(context.Background()))
+
+	// The chain data of the node database is migrated into a pebble database
+	// the VM continues from.
+	configJSON := fmt.Sprintf(`{"database-type":"pebble","database-path":%q,"database-migrate":true}`, t.TempDir())
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		NewContext(),
+		dbManager,
+		buildGenesisTest(t, genesisJSONSubnetEVM),
+		[]byte(""),
+		[]byte(configJSON),
+		make(chan commonEng.Message, 1),
+		[]*commonEng.Fx{},
+		nil,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	require.NotNil(restartedVM.standaloneDB)
+	lastAccepted, err := restartedVM.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAccepted)
+	require.Equal(uint64(1), restartedVM.blockChain.LastAcceptedBlock().NumberU64())
+	require.True(restartedVM.blockChain.HasState(restartedVM.blockChain.LastAcceptedBlock().Root()))
+}
+
+func TestBuildBlockTimeBudget(t *testing.T) {
+	require := require.New(t)
+
+	// A budget spent before packing begins only lets the first transaction in.
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, `{"block-build-time-budget":"1ns"}`, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	txs := make([]*types.Transaction, 3)
+	for i := range txs {
+		tx := types.NewTransaction(uint64(i), testEthAddrs[1], firstTxAmount, 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[0])
+		require.NoError(err)
+		txs[i] = signedTx
+	}
+	for _, err := range vm.txPool.AddRemotesSync(txs) {
+		require.NoError(err)
+	}
+
+	for i, tx := range txs {
+		blk := issueAndAccept(t, issuer, vm)
+		ethBlock := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+		require.Len(ethBlock.Transactions(), 1, "block %d", i)
+		require.Equal(tx.Hash(), ethBlock.Transactions()[0].Hash())
+		vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+	}
+}
+
+func TestSendRawTransactionConditional(t *testing.T) {
+	require := require.New(t)
+
+	issuer, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	api := ethapi.NewTransactionAPI(vm.eth.APIBackend, new(ethapi.AddrLocker))
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64) hexutil.Bytes {
+		tx := types.NewTransaction(nonce, testEthAddrs[1], firstTxAmount, 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(err)
+		input, err := signedTx.MarshalBinary()
+		require.NoError(err)
+		return input
+	}
+
+	// Conditions not met by the latest block are rejected on submission.
+	slot := common.Hash{0x01}
+	_, err := api.SendRawTransactionConditional(context.Background(), newTx(testKeys[0], 0), types.TransactionConditional{
+		KnownAccounts: types.KnownAccounts{testEthAddrs[1]: {StorageSlots: map[common.Hash]common.Hash{slot: slot}}},
+	})
+	require.ErrorIs(err, types.ErrKnownAccountMismatch)
+
+	// A transaction waiting for a later block stays in the pool, while an
+	// expired one is dropped.
+	emptyRoot := types.EmptyRootHash
+	waiting, err := api.SendRawTransactionConditional(context.Background(), newTx(testKeys[0], 0), types.TransactionConditional{
+		KnownAccounts:  types.KnownAccounts{testEthAddrs[1]: {StorageRoot: &emptyRoot}},
+		BlockNumberMin: (*hexutil.Big)(big.NewInt(2)),
+	})
+	require.NoError(err)
+	plain, err := api.SendRawTransaction(context.Background(), newTx(testKeys[1], 0))
+	require.NoError(err)
+	expired, err := api.SendRawTransactionConditional(context.Background(), newTx(testKeys[1], 1), types.TransactionConditional{
+		BlockNumberMax: (*hexutil.Big)(big.NewInt(0)),
+	})
+	require.NoError(err)
+	require.Eventually(func() bool {
+		pending, _ := vm.txPool.Stats()
+		return pending == 3
+	}, time.Second, 10*time.Millisecond)
+
+	blk := issueAndAccept(t, issuer, vm)
+	txs := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Transactions()
+	require.Len(txs, 1)
+	require.Equal(plain, txs[0].Hash())
+	require.True(vm.txPool.Has(waiting))
+	require.False(vm.txPool.Has(expired))
+
+	vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+	blk = issueAndAccept(t, issuer, vm)
+	txs = blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Transactions()
+	require.Len(txs, 1)
+	require.Equal(waiting, txs[0].Hash())
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/vmerrs"
@@ -413,3 +414,116 @@ func TestVMStateUpgrade(t *testing.T) {
 	require.Equal(t, state.GetNonce(newAccount), uint64(1)) // Nonce should be set to 1 when code is set if nonce was 0
 	require.Equal(t, state.GetState(newAccount, storageKey), newAccountUpgrade.Storage[storageKey])
 }
+
+func TestChainConfigHash(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	reply := &ChainConfigHashReply{}
+	require.NoError(NewAdminService(vm, t.TempDir()).GetChainConfigHash(nil, nil, reply))
+	require.Equal(reply.Hash, reply.ActiveHash)
+	require.Equal(Version, reply.Version)
+
+	// Schedule an upgrade on a second node.
+	upgradeConfig := &params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{
+			{
+				Config: txallowlist.NewConfig(utils.NewUint64(uint64(time.Now().Add(time.Hour).Unix())), testEthAddrs[0:1], nil, nil),
+			},
+		},
+	}
+	upgradeBytesJSON, err := json.Marshal(upgradeConfig)
+	require.NoError(err)
+	_, upgradedVM, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, "", string(upgradeBytesJSON))
+	defer func() {
+		require.NoError(upgradedVM.Shutdown(context.Background()))
+	}()
+
+	// The nodes agree on the config in effect, but not on the config.
+	responseBytes, err := upgradedVM.chainConfigHasher.OnChainConfigHashRequest(context.Background(), vm.ctx.NodeID, 1, message.ChainConfigHashRequest{})
+	require.NoError(err)
+	var response message.ChainConfigHashResponse
+	_, err = message.Codec.Unmarshal(responseBytes, &response)
+	require.NoError(err)
+	require.NotEqual(reply.Hash, response.Hash)
+	require.Equal(reply.ActiveHash, response.ActiveHash)
+
+	checker := newChainConfigChecker(vm.chainConfigHasher, vm.client, vm.networkCodec)
+	require.True(checker.compare(upgradedVM.ctx.NodeID, response))
+	require.Contains(checker.mismatches, upgradedVM.ctx.NodeID)
+	response.Hash = reply.Hash
+	require.False(checker.compare(upgradedVM.ctx.NodeID, response))
+	require.Empty(checker.mismatches)
+}
+
+func TestValidateUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000, 0)
+	upgradeBytes := []byte(`{
+		"precompileUpgrades": [
+			{"txAllowListConfig": {"blockTimestamp": 2000, "adminAddresses": ["0x0000000000000000000000000000000000000000"]}},
+			{"txAllowListConfig": {"blockTimestamp": 3000, "disable": true}}
+		],
+		"feeConfigUpgrades": [
+			{"blockTimestamp": 500, "feeConfig": {"gasLimit": 8000000, "minBaseFee": 1, "targetGas": 15000000, "baseFeeChangeDenominator": 36, "minBlockGasCost": 0, "maxBlockGasCost": 1000000, "targetBlockRate": 2, "blockGasCostStep": 200000}}
+		]
+	}`)
+	report, err := ValidateUpgrades(constants.UnitTestID, []byte(genesisJSONSubnetEVM), upgradeBytes, now)
+	require.NoError(err)
+	require.Len(report.Activations, 3)
+	require.Equal(uint64(500), report.Activations[0].Timestamp)
+	require.True(report.Activations[0].Activated)
+	require.Equal(uint64(2000), report.Activations[1].Timestamp)
+	require.False(report.Activations[1].Activated)
+	require.Equal([]string{"enable precompile txAllowListConfig (0x0200000000000000000000000000000000000002)"}, report.Activations[1].Changes)
+	require.Equal([]string{"txAllowListConfig sets the zero address as admin"}, report.Warnings)
+
+	// Upgrades out of order are rejected.
+	_, err = ValidateUpgrades(constants.UnitTestID, []byte(genesisJSONSubnetEVM), []byte(`{
+		"precompileUpgrades": [
+			{"txAllowListConfig": {"blockTimestamp": 2000, "adminAddresses": ["0x0000000000000000000000000000000000000001"]}},
+			{"txAllowListConfig": {"blockTimestamp": 1000, "disable": true}}
+		]
+	}`), now)
+	require.ErrorContains(err, "< previous timestamp")
+}
+
+func TestAdminValidateUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	upgradeConfig := &params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{
+			{
+				Config: txallowlist.NewConfig(utils.NewUint64(0), testEthAddrs[0:1], nil, nil),
+			},
+		},
+	}
+	upgradeBytesJSON, err := json.Marshal(upgradeConfig)
+	require.NoError(err)
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONSubnetEVM, circuitBreakerTestConfig, string(upgradeBytesJSON))
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	admin := NewAdminService(vm, t.TempDir())
+
+	// Scheduling the disabling of the precompile is valid.
+	upgradeConfig.PrecompileUpgrades = append(upgradeConfig.PrecompileUpgrades, params.PrecompileUpgrade{
+		Config: txallowlist.NewDisableConfig(utils.NewUint64(uint64(time.Now().Add(time.Hour).Unix()))),
+	})
+	upgradeBytesJSON, err = json.Marshal(upgradeConfig)
+	require.NoError(err)
+	reply := &UpgradesReport{}
+	require.NoError(admin.ValidateUpgrades(newAdminRequest("admin-key"), &ValidateUpgradesArgs{Upgrades: upgradeBytesJSON}, reply))
+	require.Len(reply.Activations, 2)
+	require.True(reply.Activations[0].Activated)
+	require.False(reply.Activations[1].Activated)
+
+	// Removing the activated upgrade is not.
+	upgradeBytesJSON, err = json.Marshal(&params.UpgradeConfig{})
+	require.NoError(err)
+	require.ErrorContains(admin.ValidateUpgrades(newAdminRequest("admin-key"), &ValidateUpgradesArgs{Upgrades: upgradeBytesJSON}, &UpgradesReport{}), "incompatible")
+}
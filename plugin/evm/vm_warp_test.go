@@ -492,6 +492,9 @@ func TestReceiveWarpMessage(t *testing.T) {
 	)
 	require.NoError(err)
 
+	// Track the message as sent by this chain to measure its delivery latency
+	require.NoError(vm.warpBackend.AddMessage(unsignedMessage))
+
 	getWarpMsgInput, err := warp.PackGetVerifiedWarpMessage(0)
 	require.NoError(err)
 	getVerifiedWarpMessageTx, err := types.SignTx(
@@ -552,6 +555,12 @@ func TestReceiveWarpMessage(t *testing.T) {
 	require.NoError(block2.Accept(context.Background()))
 	vm.blockChain.DrainAcceptorQueue()
 
+	// The verified predicate delivered the message
+	latency, err := vm.warpBackend.GetMessageLatency(unsignedMessage.ID())
+	require.NoError(err)
+	require.NotNil(latency.DeliveredTime)
+	require.Equal(latency.DeliveredTime.Sub(latency.SentTime), *latency.Latency)
+
 	ethBlock := block2.(*chain.BlockWrapper).Block.(*Block).ethBlock
 	verifiedMessageReceipts := vm.blockChain.GetReceiptsByHash(ethBlock.Hash())
 	require.Len(verifiedMessageReceipts, 1)
@@ -700,3 +709,86 @@ func TestBlockSignatureRequestsToVM(t *testing.T) {
 		})
 	}
 }
+
+func TestWarpPredicateMempoolVerification(t *testing.T) {
+	require := require.New(t)
+	genesis := &core.Genesis{}
+	require.NoError(genesis.UnmarshalJSON([]byte(genesisJSONDurango)))
+	genesis.Config.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(utils.NewUint64(0)),
+	}
+	genesisJSON, err := genesis.MarshalJSON()
+	require.NoError(err)
+	_, vm, _, _ := GenesisVM(t, true, string(genesisJSON), "", "")
+
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	addressedPayload, err := payload.NewAddressedCall(testEthAddrs[0].Bytes(), avagoUtils.RandomBytes(100))
+	require.NoError(err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(vm.ctx.NetworkID, vm.ctx.ChainID, addressedPayload.Bytes())
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	blsSecretKey, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSignature := &avalancheWarp.BitSetSignature{Signers: set.NewBits(0).Bytes()}
+	copy(warpSignature.Signature[:], bls.SignatureToBytes(bls.Sign(blsSecretKey, unsignedMessage.Bytes())))
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, warpSignature)
+	require.NoError(err)
+
+	// The message is only signed by the validators of the P-chain heights from
+	// [validPChainHeight]
+	validPChainHeight := uint64(10)
+	pChainHeight := validPChainHeight - 1
+	vm.ctx.ValidatorState = &validators.TestState{
+		GetSubnetIDF: func(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+			return ids.Empty, nil
+		},
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return pChainHeight, nil
+		},
+		GetValidatorSetF: func(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if height < validPChainHeight {
+				return map[ids.NodeID]*validators.GetValidatorOutput{}, nil
+			}
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(blsSecretKey),
+					Weight:    100,
+				},
+			}, nil
+		},
+	}
+
+	getWarpMsgInput, err := warp.PackGetVerifiedWarpMessage(0)
+	require.NoError(err)
+	tx, err := types.SignTx(
+		predicate.NewPredicateTx(
+			vm.chainConfig.ChainID,
+			0,
+			&warp.Module.Address,
+			1_000_000,
+			big.NewInt(225*params.GWei),
+			big.NewInt(params.GWei),
+			common.Big0,
+			getWarpMsgInput,
+			types.AccessList{},
+			warp.ContractAddress,
+			signedMessage.Bytes(),
+		),
+		types.LatestSignerForChainID(vm.chainConfig.ChainID),
+		testKeys[0],
+	)
+	require.NoError(err)
+
+	// The predicate cannot pass against the current validator set
+	require.ErrorIs(vm.txPool.AddRemotesSync([]*types.Transaction{tx})[0], core.ErrPredicateFailed)
+	require.False(vm.txPool.Has(tx.Hash()))
+
+	pChainHeight = validPChainHeight
+	require.NoError(vm.txPool.AddRemotesSync([]*types.Transaction{tx})[0])
+	require.True(vm.txPool.Has(tx.Hash()))
+}
@@ -4,5 +4,8 @@
 package runner
 
 const (
-	versionKey = "version"
+	versionKey          = "version"
+	validateUpgradesKey = "validate-upgrades"
+	genesisKey          = "genesis"
+	networkIDKey        = "network-id"
 )
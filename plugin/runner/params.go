@@ -6,6 +6,7 @@ package runner
 import (
 	"flag"
 
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -14,6 +15,9 @@ func subnetEVMFlagSet() *flag.FlagSet {
 	fs := flag.NewFlagSet("subnet-evm", flag.ContinueOnError)
 
 	fs.Bool(versionKey, false, "If true, print version and quit")
+	fs.String(validateUpgradesKey, "", "If set, validate the upgrade file at this path against the genesis, print the changes made at each activation and quit")
+	fs.String(genesisKey, "", "Path of the genesis file of the chain, used with --"+validateUpgradesKey)
+	fs.Uint(networkIDKey, uint(constants.MainnetID), "ID of the network running the chain, used with --"+validateUpgradesKey)
 
 	return fs
 }
@@ -31,15 +35,3 @@ func getViper() (*viper.Viper, error) {
 
 	return v, nil
 }
-
-func PrintVersion() (bool, error) {
-	v, err := getViper()
-	if err != nil {
-		return false, err
-	}
-
-	if v.GetBool(versionKey) {
-		return true, nil
-	}
-	return false, nil
-}
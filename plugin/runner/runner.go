@@ -5,29 +5,68 @@ package runner
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/spf13/viper"
 
 	"github.com/ava-labs/subnet-evm/plugin/evm"
 )
 
 func Run(versionStr string) {
-	printVersion, err := PrintVersion()
+	v, err := getViper()
 	if err != nil {
 		fmt.Printf("couldn't get config: %s", err)
 		os.Exit(1)
 	}
-	if printVersion && versionStr != "" {
+	if v.GetBool(versionKey) && versionStr != "" {
 		fmt.Printf(versionStr)
 		os.Exit(0)
 	}
+	if v.GetString(validateUpgradesKey) != "" {
+		if err := validateUpgrades(v); err != nil {
+			fmt.Printf("invalid upgrades: %s\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	if err := ulimit.Set(ulimit.DefaultFDLimit, logging.NoLog{}); err != nil {
 		fmt.Printf("failed to set fd limit correctly due to: %s", err)
 		os.Exit(1)
 	}
 	rpcchainvm.Serve(context.Background(), &evm.VM{})
 }
+
+// validateUpgrades validates the upgrade file against the genesis file given
+// by the flags, and prints the changes made at each activation, without
+// starting the VM.
+func validateUpgrades(v *viper.Viper) error {
+	genesisFile := v.GetString(genesisKey)
+	if genesisFile == "" {
+		return errors.New("--" + genesisKey + " is required to validate upgrades")
+	}
+	genesisBytes, err := os.ReadFile(genesisFile)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	upgradeBytes, err := os.ReadFile(v.GetString(validateUpgradesKey))
+	if err != nil {
+		return fmt.Errorf("failed to read upgrade file: %w", err)
+	}
+	report, err := evm.ValidateUpgrades(v.GetUint32(networkIDKey), genesisBytes, upgradeBytes, time.Now())
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
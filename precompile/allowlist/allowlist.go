@@ -22,26 +22,44 @@ const (
 	ModifyAllowListGasCost = contract.WriteGasCostPerSlot
 	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
 
+	// ReadAllMaxResults is the maximum number of addresses returned by a
+	// single call to readAll.
+	ReadAllMaxResults = 256
+
 	allowListInputLen = common.HashLength
+
+	setManyFuncName      = "setMany"
+	readAllFuncName      = "readAll"
+	readAllCountFuncName = "readAllCount"
 )
 
 var (
 	// Error returned when an invalid write is attempted
 	ErrCannotModifyAllowList = errors.New("cannot modify allow list")
+	// Error returned when enumerating an allow list configured before Stromboli
+	ErrAllowListNotEnumerable = errors.New("allow list configured before Stromboli is not enumerable")
 
 	// AllowListRawABI contains the raw ABI of AllowList library interface.
 	//go:embed allowlist.abi
 	AllowListRawABI string
 
 	AllowListABI = contract.ParseABI(AllowListRawABI)
+
+	// StromboliFunctionNames are the names of the allow list functions that are
+	// only available after Stromboli.
+	StromboliFunctionNames = []string{setManyFuncName, readAllFuncName, readAllCountFuncName}
 )
 
 // GetAllowListStatus returns the allow list role of [address] for the precompile
 // at [precompileAddr]
 func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address) Role {
-	// Generate the state key for [address]
-	addressKey := address.Hash()
-	return Role(state.GetState(precompileAddr, addressKey))
+	return Role(state.GetState(precompileAddr, RoleStorageKey(address)))
+}
+
+// RoleStorageKey returns the storage key of the role of [address] in the
+// storage of an allow list precompile.
+func RoleStorageKey(address common.Address) common.Hash {
+	return address.Hash()
 }
 
 // SetAllowListRole sets the permissions of [address] to [role] for the precompile
@@ -49,7 +67,7 @@ func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, a
 // assumes [role] has already been verified as valid.
 func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) {
 	// Generate the state key for [address]
-	addressKey := address.Hash()
+	addressKey := RoleStorageKey(address)
 	// Assign [role] to the address
 	// This stores the [role] in the contract storage with address [precompileAddr]
 	// and [addressKey] hash. It means that any reusage of the [addressKey] for different value
@@ -100,33 +118,105 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 			return nil, remainingGas, vmerrs.ErrWriteProtection
 		}
 
-		stateDB := evm.GetStateDB()
+		if remainingGas, err = modifyAllowListRole(evm, precompileAddr, callerAddr, modifyAddress, role, remainingGas); err != nil {
+			return nil, remainingGas, err
+		}
+		return []byte{}, remainingGas, nil
+	}
+}
 
-		// Verify that the caller is an admin with permission to modify the allow list
-		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr)
-		// Verify that the address we are trying to modify has a status that allows it to be modified
-		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
-		if !callerStatus.CanModify(modifyStatus, role) {
-			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
+// modifyAllowListRole sets the role of [modifyAddress] to [role] on behalf of [callerAddr],
+// verifying the caller is allowed to do so and charging for the event and the
+// iterable set update from [suppliedGas].
+func modifyAllowListRole(evm contract.AccessibleState, precompileAddr, callerAddr, modifyAddress common.Address, role Role, suppliedGas uint64) (remainingGas uint64, err error) {
+	remainingGas = suppliedGas
+	stateDB := evm.GetStateDB()
+
+	// Verify that the caller is an admin with permission to modify the allow list
+	callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr)
+	// Verify that the address we are trying to modify has a status that allows it to be modified
+	modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
+	if !callerStatus.CanModify(modifyStatus, role) {
+		return remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
+	}
+	if contract.IsDurangoActivated(evm) {
+		if remainingGas, err = contract.DeductGas(remainingGas, AllowListEventGasCost); err != nil {
+			return 0, err
+		}
+		topics, data, err := PackRoleSetEvent(role, modifyAddress, callerAddr, modifyStatus)
+		if err != nil {
+			return remainingGas, err
+		}
+		stateDB.AddLog(
+			precompileAddr,
+			topics,
+			data,
+			evm.GetBlockContext().Number().Uint64(),
+		)
+	}
+
+	if contract.IsStromboliActivated(evm) {
+		if remainingGas, err = contract.DeductGas(remainingGas, allowListSetUpdateGasCost(stateDB, precompileAddr, modifyAddress, role)); err != nil {
+			return 0, err
 		}
-		if contract.IsDurangoActivated(evm) {
-			if remainingGas, err = contract.DeductGas(remainingGas, AllowListEventGasCost); err != nil {
-				return nil, 0, err
+		updateAllowListSet(stateDB, precompileAddr, modifyAddress, role)
+	}
+
+	SetAllowListRole(stateDB, precompileAddr, modifyAddress, role)
+	return remainingGas, nil
+}
+
+// PackSetMany packs [addresses] and [role] into the input data to the setMany function
+func PackSetMany(addresses []common.Address, role Role) ([]byte, error) {
+	return AllowListABI.Pack(setManyFuncName, addresses, role.Big())
+}
+
+// UnpackSetManyInput attempts to unpack [input] into the addresses and role arguments of setMany.
+func UnpackSetManyInput(input []byte) ([]common.Address, Role, error) {
+	inputStruct := struct {
+		Addrs []common.Address
+		Role  *big.Int
+	}{}
+	if err := AllowListABI.UnpackInputIntoInterface(&inputStruct, setManyFuncName, input, false); err != nil {
+		return nil, Role{}, err
+	}
+	role, err := FromBig(inputStruct.Role)
+	if err != nil {
+		return nil, Role{}, err
+	}
+	return inputStruct.Addrs, role, nil
+}
+
+// createSetMany returns an execution function for setting the allow list status of every address
+// in the input to the given role. This execution function is specific to [precompileAddr].
+// Each address is charged as an individual role setter call, and the whole call reverts if any
+// of the modifications is not permitted.
+func createSetMany(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ModifyAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		addresses, role, err := UnpackSetManyInput(input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		for i, modifyAddress := range addresses {
+			// The first modification is covered by the base cost charged above.
+			if i > 0 {
+				if remainingGas, err = contract.DeductGas(remainingGas, ModifyAllowListGasCost); err != nil {
+					return nil, 0, err
+				}
 			}
-			topics, data, err := PackRoleSetEvent(role, modifyAddress, callerAddr, modifyStatus)
-			if err != nil {
+			if remainingGas, err = modifyAllowListRole(evm, precompileAddr, callerAddr, modifyAddress, role, remainingGas); err != nil {
 				return nil, remainingGas, err
 			}
-			stateDB.AddLog(
-				precompileAddr,
-				topics,
-				data,
-				evm.GetBlockContext().Number().Uint64(),
-			)
 		}
-
-		SetAllowListRole(stateDB, precompileAddr, modifyAddress, role)
-
 		return []byte{}, remainingGas, nil
 	}
 }
@@ -175,6 +265,108 @@ func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrec
 	}
 }
 
+// PackReadAll packs [offset] and [limit] into the input data to the readAll function
+func PackReadAll(offset, limit *big.Int) ([]byte, error) {
+	return AllowListABI.Pack(readAllFuncName, offset, limit)
+}
+
+// UnpackReadAllInput attempts to unpack [input] into the offset and limit arguments of readAll.
+func UnpackReadAllInput(input []byte) (*big.Int, *big.Int, error) {
+	inputStruct := struct {
+		Offset *big.Int
+		Limit  *big.Int
+	}{}
+	if err := AllowListABI.UnpackInputIntoInterface(&inputStruct, readAllFuncName, input, false); err != nil {
+		return nil, nil, err
+	}
+	return inputStruct.Offset, inputStruct.Limit, nil
+}
+
+// PackReadAllOutput packs [addresses] and their [roles] into the output of the readAll function
+func PackReadAllOutput(addresses []common.Address, roles []*big.Int) ([]byte, error) {
+	return AllowListABI.PackOutput(readAllFuncName, addresses, roles)
+}
+
+// createReadAll returns an execution function that enumerates the addresses with a non-None role
+// of the allow list for the given [precompileAddr], along with their roles.
+// At most [ReadAllMaxResults] addresses are returned, and each returned address is charged two
+// storage reads. It fails if the allow list was configured before Stromboli.
+func createReadAll(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListSetGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		offset, limit, err := UnpackReadAllInput(input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		stateDB := evm.GetStateDB()
+		if !IsAllowListSetTracked(stateDB, precompileAddr) {
+			return nil, remainingGas, ErrAllowListNotEnumerable
+		}
+		length := GetAllowListSetLength(stateDB, precompileAddr)
+		var (
+			start = length
+			end   = length
+		)
+		if offset.IsUint64() && offset.Uint64() < length {
+			start = offset.Uint64()
+			end = start + ReadAllMaxResults
+			if limit.IsUint64() && limit.Uint64() < ReadAllMaxResults {
+				end = start + limit.Uint64()
+			}
+			if end > length {
+				end = length
+			}
+		}
+		if remainingGas, err = contract.DeductGas(remainingGas, (end-start)*2*ReadAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		addresses := make([]common.Address, 0, end-start)
+		roles := make([]*big.Int, 0, end-start)
+		for i := start; i < end; i++ {
+			address := GetAllowListSetElement(stateDB, precompileAddr, i)
+			addresses = append(addresses, address)
+			roles = append(roles, GetAllowListStatus(stateDB, precompileAddr, address).Big())
+		}
+		packedOutput, err := PackReadAllOutput(addresses, roles)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// PackReadAllCountOutput packs [count] into the output of the readAllCount function
+func PackReadAllCountOutput(count *big.Int) ([]byte, error) {
+	return AllowListABI.PackOutput(readAllCountFuncName, count)
+}
+
+// createReadAllCount returns an execution function that reads the number of addresses with a
+// non-None role of the allow list for the given [precompileAddr].
+// It fails if the allow list was configured before Stromboli.
+func createReadAllCount(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListSetGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		stateDB := evm.GetStateDB()
+		if !IsAllowListSetTracked(stateDB, precompileAddr) {
+			return nil, remainingGas, ErrAllowListNotEnumerable
+		}
+		length := GetAllowListSetLength(stateDB, precompileAddr)
+		packedOutput, err := PackReadAllCountOutput(new(big.Int).SetUint64(length))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
 // CreateAllowListPrecompile returns a StatefulPrecompiledContract with R/W control of an allow list at [precompileAddr]
 func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulPrecompiledContract {
 	// Construct the contract with no fallback function.
@@ -191,16 +383,23 @@ func CreateAllowListFunctions(precompileAddr common.Address) []*contract.Statefu
 
 	for name, method := range AllowListABI.Methods {
 		var fn *contract.StatefulPrecompileFunction
+		stateMutability := contract.WithStateMutability(contract.StateMutability(method.StateMutability))
 		if name == "readAllowList" {
-			fn = contract.NewStatefulPrecompileFunction(method.ID, createReadAllowList(precompileAddr))
+			fn = contract.NewStatefulPrecompileFunction(method.ID, createReadAllowList(precompileAddr), stateMutability, contract.WithGasCost(ReadAllowListGasCost))
 		} else if adminFnName, _ := AdminRole.GetSetterFunctionName(); name == adminFnName {
-			fn = contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, AdminRole))
+			fn = contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, AdminRole), stateMutability)
 		} else if enabledFnName, _ := EnabledRole.GetSetterFunctionName(); name == enabledFnName {
-			fn = contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, EnabledRole))
+			fn = contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, EnabledRole), stateMutability)
 		} else if noRoleFnName, _ := NoRole.GetSetterFunctionName(); name == noRoleFnName {
-			fn = contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, NoRole))
+			fn = contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, NoRole), stateMutability)
 		} else if managerFnName, _ := ManagerRole.GetSetterFunctionName(); name == managerFnName {
-			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetter(precompileAddr, ManagerRole), contract.IsDurangoActivated)
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetter(precompileAddr, ManagerRole), contract.IsDurangoActivated, stateMutability)
+		} else if name == setManyFuncName {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createSetMany(precompileAddr), contract.IsStromboliActivated, stateMutability)
+		} else if name == readAllFuncName {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createReadAll(precompileAddr), contract.IsStromboliActivated, stateMutability)
+		} else if name == readAllCountFuncName {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createReadAllCount(precompileAddr), contract.IsStromboliActivated, stateMutability, contract.WithGasCost(ReadAllowListSetGasCost))
 		} else {
 			panic(fmt.Sprintf("unexpected method name: %s", name))
 		}
@@ -22,17 +22,29 @@ type AllowListConfig struct {
 
 // Configure initializes the address space of [precompileAddr] by initializing the role of each of
 // the addresses in [AllowListAdmins].
+// After Stromboli, the addresses are also tracked in the iterable set of the allow list,
+// which is then marked as complete.
 func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	isStromboli := chainConfig.IsStromboli(blockContext.Timestamp())
+	if isStromboli {
+		markAllowListSetTracked(state, precompileAddr)
+	}
+	setRole := func(address common.Address, role Role) {
+		if isStromboli {
+			updateAllowListSet(state, precompileAddr, address, role)
+		}
+		SetAllowListRole(state, precompileAddr, address, role)
+	}
 	for _, enabledAddr := range c.EnabledAddresses {
-		SetAllowListRole(state, precompileAddr, enabledAddr, EnabledRole)
+		setRole(enabledAddr, EnabledRole)
 	}
 	for _, adminAddr := range c.AdminAddresses {
-		SetAllowListRole(state, precompileAddr, adminAddr, AdminRole)
+		setRole(adminAddr, AdminRole)
 	}
 	// Verify() should have been called before Configure()
 	// so we know manager role is activated
 	for _, managerAddr := range c.ManagerAddresses {
-		SetAllowListRole(state, precompileAddr, managerAddr, ManagerRole)
+		setRole(managerAddr, ManagerRole)
 	}
 	return nil
 }
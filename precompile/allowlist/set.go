@@ -0,0 +1,131 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// After Stromboli, every address with a non-None role is additionally tracked in an
+// iterable set stored in the precompile's storage, so the allow list can be
+// enumerated with eth_call. The set is laid out like a Solidity dynamic array
+// with an index mapping:
+//   - [setLengthKey] holds the number of tracked addresses.
+//   - [setElementsKey] + i holds the i-th tracked address.
+//   - keccak256([setIndexPrefix] ++ address) holds the 1-based position of the
+//     address in the array, or zero if it is not tracked.
+//
+// Roles assigned before Stromboli are not tracked, and the storage of the
+// precompile cannot be iterated to seed the set at activation. Therefore the set
+// is only marked as complete in [setTrackedKey] when the allow list is configured
+// after Stromboli, and enumerating an allow list configured before Stromboli
+// fails. Such an allow list becomes enumerable once the precompile is disabled
+// and enabled again after Stromboli.
+//
+// All keys are derived from keccak256 hashes, so they cannot collide with the
+// role slots, which are the left padded addresses themselves.
+var (
+	setTrackedKey  = crypto.Keccak256Hash([]byte("allowListSetTracked"))
+	setLengthKey   = crypto.Keccak256Hash([]byte("allowListSetLength"))
+	setElementsKey = crypto.Keccak256Hash([]byte("allowListSetElements"))
+	setIndexPrefix = []byte("allowListSetIndex")
+)
+
+const (
+	// AllowListSetInsertGasCost is the cost of tracking a new address in the
+	// iterable set: the element, its index and the length are written.
+	AllowListSetInsertGasCost = 3 * contract.WriteGasCostPerSlot
+	// AllowListSetRemoveGasCost is the cost of removing an address from the
+	// iterable set: the last element is read and moved into the freed
+	// position, then both indices and the length are updated.
+	AllowListSetRemoveGasCost = contract.ReadGasCostPerSlot + 4*contract.WriteGasCostPerSlot
+	// ReadAllowListSetGasCost is the cost of checking that the iterable set is
+	// complete and reading its length.
+	ReadAllowListSetGasCost = 2 * contract.ReadGasCostPerSlot
+)
+
+func setElementKey(i uint64) common.Hash {
+	return common.BigToHash(new(big.Int).Add(setElementsKey.Big(), new(big.Int).SetUint64(i)))
+}
+
+func setIndexKey(address common.Address) common.Hash {
+	return crypto.Keccak256Hash(setIndexPrefix, address.Bytes())
+}
+
+// IsAllowListSetTracked returns true if the iterable set of the precompile at
+// [precompileAddr] tracks every address with a non-None role, which is the case
+// if the allow list was configured after Stromboli.
+func IsAllowListSetTracked(stateDB contract.StateDB, precompileAddr common.Address) bool {
+	return stateDB.GetState(precompileAddr, setTrackedKey) != (common.Hash{})
+}
+
+// markAllowListSetTracked records that the iterable set of the precompile at
+// [precompileAddr] tracks every address with a non-None role.
+func markAllowListSetTracked(stateDB contract.StateDB, precompileAddr common.Address) {
+	stateDB.SetState(precompileAddr, setTrackedKey, common.BigToHash(common.Big1))
+}
+
+// GetAllowListSetLength returns the number of addresses tracked in the iterable
+// set of the precompile at [precompileAddr].
+func GetAllowListSetLength(stateDB contract.StateDB, precompileAddr common.Address) uint64 {
+	return stateDB.GetState(precompileAddr, setLengthKey).Big().Uint64()
+}
+
+// GetAllowListSetElement returns the [i]-th address tracked in the iterable set
+// of the precompile at [precompileAddr].
+// assumes [i] is less than the length of the set.
+func GetAllowListSetElement(stateDB contract.StateDB, precompileAddr common.Address, i uint64) common.Address {
+	return common.BytesToAddress(stateDB.GetState(precompileAddr, setElementKey(i)).Bytes())
+}
+
+// isTrackedInAllowListSet returns true if [address] is tracked in the iterable
+// set of the precompile at [precompileAddr].
+func isTrackedInAllowListSet(stateDB contract.StateDB, precompileAddr, address common.Address) bool {
+	return stateDB.GetState(precompileAddr, setIndexKey(address)) != (common.Hash{})
+}
+
+// allowListSetUpdateGasCost returns the gas required to update the iterable set
+// after assigning [role] to [address].
+func allowListSetUpdateGasCost(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) uint64 {
+	tracked := isTrackedInAllowListSet(stateDB, precompileAddr, address)
+	switch {
+	case !tracked && !role.IsNoRole():
+		return AllowListSetInsertGasCost
+	case tracked && role.IsNoRole():
+		return AllowListSetRemoveGasCost
+	default:
+		return 0
+	}
+}
+
+// updateAllowListSet adds [address] to or removes it from the iterable set of
+// the precompile at [precompileAddr] depending on whether [role] is NoRole.
+// It is a no-op if the address is already in the expected state.
+func updateAllowListSet(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) {
+	indexKey := setIndexKey(address)
+	index := stateDB.GetState(precompileAddr, indexKey).Big().Uint64()
+	length := GetAllowListSetLength(stateDB, precompileAddr)
+
+	switch {
+	case index == 0 && !role.IsNoRole():
+		stateDB.SetState(precompileAddr, setElementKey(length), common.BytesToHash(address.Bytes()))
+		stateDB.SetState(precompileAddr, indexKey, common.BigToHash(new(big.Int).SetUint64(length+1)))
+		stateDB.SetState(precompileAddr, setLengthKey, common.BigToHash(new(big.Int).SetUint64(length+1)))
+	case index != 0 && role.IsNoRole():
+		// Move the last element into the position of the removed one.
+		last := length - 1
+		if removed := index - 1; removed != last {
+			moved := GetAllowListSetElement(stateDB, precompileAddr, last)
+			stateDB.SetState(precompileAddr, setElementKey(removed), common.BytesToHash(moved.Bytes()))
+			stateDB.SetState(precompileAddr, setIndexKey(moved), common.BigToHash(new(big.Int).SetUint64(index)))
+		}
+		stateDB.SetState(precompileAddr, setElementKey(last), common.Hash{})
+		stateDB.SetState(precompileAddr, indexKey, common.Hash{})
+		stateDB.SetState(precompileAddr, setLengthKey, common.BigToHash(new(big.Int).SetUint64(last)))
+	}
+}
@@ -4,8 +4,10 @@
 package allowlist
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -21,6 +23,8 @@ var (
 	TestEnabledAddr = common.HexToAddress("0x0000000000000000000000000000000000000022")
 	TestNoRoleAddr  = common.HexToAddress("0x0000000000000000000000000000000000000033")
 	TestManagerAddr = common.HexToAddress("0x0000000000000000000000000000000000000044")
+
+	testOtherAddr = common.HexToAddress("0x0000000000000000000000000000000000000055")
 )
 
 func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
@@ -170,6 +174,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -188,6 +193,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -206,6 +212,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -224,6 +231,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -248,6 +256,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			SuppliedGas: 0,
@@ -267,6 +276,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			SuppliedGas: ModifyAllowListGasCost + AllowListEventGasCost,
@@ -328,6 +338,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -372,6 +383,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -437,6 +449,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -585,6 +598,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -608,6 +622,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -631,6 +646,7 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -648,6 +664,221 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 				require.Len(t, data, 0)
 			},
 		},
+		"setMany pre-Stromboli": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestNoRoleAddr}, EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"admin setMany enabled": {
+			Caller:        TestAdminAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestNoRoleAddr, testOtherAddr}, EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 2 * (ModifyAllowListGasCost + AllowListEventGasCost + AllowListSetInsertGasCost),
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, testOtherAddr))
+				require.EqualValues(t, 2, GetAllowListSetLength(state, contractAddress))
+				require.Equal(t, TestNoRoleAddr, GetAllowListSetElement(state, contractAddress, 0))
+				require.Equal(t, testOtherAddr, GetAllowListSetElement(state, contractAddress, 1))
+
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, 2)
+				require.Len(t, logsData, 2)
+			},
+		},
+		"admin setMany no role": {
+			Caller:        TestAdminAddr,
+			BeforeHook:    setDefaultRolesStromboli(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestManagerAddr}, NoRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + AllowListEventGasCost + AllowListSetRemoveGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestManagerAddr))
+				require.EqualValues(t, 2, GetAllowListSetLength(state, contractAddress))
+				// The last element is moved into the freed position.
+				require.Equal(t, TestAdminAddr, GetAllowListSetElement(state, contractAddress, 0))
+				require.Equal(t, TestEnabledAddr, GetAllowListSetElement(state, contractAddress, 1))
+			},
+		},
+		"admin setMany insufficient gas": {
+			Caller:        TestAdminAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestNoRoleAddr, testOtherAddr}, EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + AllowListEventGasCost + AllowListSetInsertGasCost,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"admin setMany readOnly": {
+			Caller:        TestAdminAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestNoRoleAddr}, EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"enabled setMany": {
+			Caller:        TestEnabledAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestNoRoleAddr}, EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotModifyAllowList.Error(),
+		},
+		"manager setMany admin": {
+			Caller:        TestManagerAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMany([]common.Address{TestNoRoleAddr}, AdminRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotModifyAllowList.Error(),
+		},
+		"readAllCount": {
+			Caller:        TestNoRoleAddr,
+			BeforeHook:    setDefaultRolesStromboli(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				return AllowListABI.Methods[readAllCountFuncName].ID
+			},
+			SuppliedGas: ReadAllowListSetGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackReadAllCountOutput(big.NewInt(3))
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"readAll": {
+			Caller:        TestNoRoleAddr,
+			BeforeHook:    setDefaultRolesStromboli(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackReadAll(big.NewInt(1), big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ReadAllowListSetGasCost + 2*2*ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackReadAllOutput(
+					[]common.Address{TestManagerAddr, TestEnabledAddr},
+					[]*big.Int{ManagerRole.Big(), EnabledRole.Big()},
+				)
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"readAll out of range": {
+			Caller:        TestNoRoleAddr,
+			BeforeHook:    setDefaultRolesStromboli(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackReadAll(big.NewInt(5), big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ReadAllowListSetGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackReadAllOutput([]common.Address{}, []*big.Int{})
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"readAll insufficient gas": {
+			Caller:        TestNoRoleAddr,
+			BeforeHook:    setDefaultRolesStromboli(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackReadAll(big.NewInt(0), big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ReadAllowListSetGasCost + 2*2*ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"readAllCount configured pre-Stromboli": {
+			Caller:        TestNoRoleAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				return AllowListABI.Methods[readAllCountFuncName].ID
+			},
+			SuppliedGas: ReadAllowListSetGasCost,
+			ReadOnly:    true,
+			ExpectedErr: ErrAllowListNotEnumerable.Error(),
+		},
+		"readAll configured pre-Stromboli": {
+			Caller:        TestNoRoleAddr,
+			BeforeHook:    SetDefaultRoles(contractAddress),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackReadAll(big.NewInt(0), big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ReadAllowListSetGasCost,
+			ReadOnly:    true,
+			ExpectedErr: ErrAllowListNotEnumerable.Error(),
+		},
+		"initial config tracks addresses post-Stromboli": {
+			ChainConfigFn: stromboliChainConfig,
+			Config: mkConfigWithAllowList(
+				module,
+				&AllowListConfig{
+					AdminAddresses:   []common.Address{TestAdminAddr},
+					EnabledAddresses: []common.Address{TestEnabledAddr},
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsAllowListSetTracked(state, contractAddress))
+				require.EqualValues(t, 2, GetAllowListSetLength(state, contractAddress))
+				require.Equal(t, TestEnabledAddr, GetAllowListSetElement(state, contractAddress, 0))
+				require.Equal(t, TestAdminAddr, GetAllowListSetElement(state, contractAddress, 1))
+			},
+		},
 	}
 }
 
@@ -665,6 +896,27 @@ func SetDefaultRoles(contractAddress common.Address) func(t testing.TB, state co
 	}
 }
 
+// setDefaultRolesStromboli returns a BeforeHook that sets the same roles as SetDefaultRoles
+// and tracks the addresses in the iterable set as done when configured after Stromboli.
+func setDefaultRolesStromboli(contractAddress common.Address) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		SetDefaultRoles(contractAddress)(t, state)
+		markAllowListSetTracked(state, contractAddress)
+		updateAllowListSet(state, contractAddress, TestAdminAddr, AdminRole)
+		updateAllowListSet(state, contractAddress, TestManagerAddr, ManagerRole)
+		updateAllowListSet(state, contractAddress, TestEnabledAddr, EnabledRole)
+	}
+}
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
 func RunPrecompileWithAllowListTests(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, contractTests map[string]testutils.PrecompileTest) {
 	t.Helper()
 	tests := AllowListTests(t, module)
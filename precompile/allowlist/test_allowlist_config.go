@@ -107,6 +107,7 @@ func AllowListConfigVerifyTests(t testing.TB, module modules.Module) map[string]
 			ChainConfig: func() precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
 				config.EXPECT().IsDurango(gomock.Any()).Return(false)
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			}(),
 			ExpectedError: ErrCannotAddManagersBeforeDurango.Error(),
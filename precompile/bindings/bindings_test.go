@@ -0,0 +1,63 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bindings
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBindingsUpToDate checks that the bindings expose the methods and events of the
+// precompiles. It fails when a precompile ABI changes until `go generate` is run.
+func TestBindingsUpToDate(t *testing.T) {
+	tests := map[string]struct {
+		metaData *bind.MetaData
+		expected abi.ABI
+	}{
+		"allow list":     {metaData: IAllowListMetaData, expected: allowlist.AllowListABI},
+		"fee manager":    {metaData: IFeeManagerMetaData, expected: feemanager.FeeManagerABI},
+		"native minter":  {metaData: INativeMinterMetaData, expected: nativeminter.NativeMinterABI},
+		"reward manager": {metaData: IRewardManagerMetaData, expected: rewardmanager.RewardManagerABI},
+		"tx allow list":  {metaData: ITxAllowListMetaData, expected: txallowlist.TxAllowListABI},
+		"warp":           {metaData: IWarpMessengerMetaData, expected: warp.WarpABI},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := test.metaData.GetAbi()
+			require.NoError(t, err)
+
+			require.Len(t, parsed.Methods, len(test.expected.Methods))
+			for name, method := range test.expected.Methods {
+				require.Contains(t, parsed.Methods, name)
+				require.Equal(t, method.ID, parsed.Methods[name].ID)
+			}
+			require.Len(t, parsed.Events, len(test.expected.Events))
+			for name, event := range test.expected.Events {
+				require.Contains(t, parsed.Events, name)
+				require.Equal(t, event.ID, parsed.Events[name].ID)
+			}
+		})
+	}
+}
+
+func TestPackSendWarpMessage(t *testing.T) {
+	payload := []byte("payload")
+	expected, err := warp.PackSendWarpMessage(payload)
+	require.NoError(t, err)
+
+	parsed, err := IWarpMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	packed, err := parsed.Pack("sendWarpMessage", payload)
+	require.NoError(t, err)
+	require.Equal(t, expected, packed)
+}
@@ -0,0 +1,16 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bindings provides Go bindings of the built-in stateful precompiles,
+// generated by abigen from their ABIs. Bind them at the address of the
+// precompile, e.g. NewIWarpMessenger(warp.ContractAddress, backend), to call
+// the precompiles, send transactions to them and filter their events without
+// packing their inputs by hand.
+package bindings
+
+//go:generate go run github.com/ava-labs/subnet-evm/cmd/abigen --abi ../allowlist/allowlist.abi --pkg bindings --type IAllowList --out gen_allowlist.go
+//go:generate go run github.com/ava-labs/subnet-evm/cmd/abigen --abi ../contracts/feemanager/contract.abi --pkg bindings --type IFeeManager --out gen_feemanager.go
+//go:generate go run github.com/ava-labs/subnet-evm/cmd/abigen --abi ../contracts/nativeminter/contract.abi --pkg bindings --type INativeMinter --out gen_nativeminter.go
+//go:generate go run github.com/ava-labs/subnet-evm/cmd/abigen --abi ../contracts/rewardmanager/contract.abi --pkg bindings --type IRewardManager --out gen_rewardmanager.go
+//go:generate go run github.com/ava-labs/subnet-evm/cmd/abigen --abi ../contracts/txallowlist/contract.abi --pkg bindings --type ITxAllowList --out gen_txallowlist.go
+//go:generate go run github.com/ava-labs/subnet-evm/cmd/abigen --abi ../contracts/warp/contract.abi --pkg bindings --type IWarpMessenger --out gen_warp.go
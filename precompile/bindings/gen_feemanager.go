@@ -0,0 +1,885 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = interfaces.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IFeeManagerFeeConfig is an auto generated low-level Go binding around an user-defined struct.
+type IFeeManagerFeeConfig struct {
+	GasLimit                 *big.Int
+	TargetBlockRate          *big.Int
+	MinBaseFee               *big.Int
+	TargetGas                *big.Int
+	BaseFeeChangeDenominator *big.Int
+	MinBlockGasCost          *big.Int
+	MaxBlockGasCost          *big.Int
+	BlockGasCostStep         *big.Int
+}
+
+// IFeeManagerMetaData contains all meta data concerning the IFeeManager contract.
+var IFeeManagerMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"uint256\",\"name\":\"gasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetBlockRate\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBaseFee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"baseFeeChangeDenominator\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockGasCostStep\",\"type\":\"uint256\"}],\"indexed\":false,\"internalType\":\"structIFeeManager.FeeConfig\",\"name\":\"oldFeeConfig\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"uint256\",\"name\":\"gasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetBlockRate\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBaseFee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"baseFeeChangeDenominator\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockGasCostStep\",\"type\":\"uint256\"}],\"indexed\":false,\"internalType\":\"structIFeeManager.FeeConfig\",\"name\":\"newFeeConfig\",\"type\":\"tuple\"}],\"name\":\"FeeConfigChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldDiscount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newDiscount\",\"type\":\"uint256\"}],\"name\":\"FeeDiscountChanged\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"getFeeConfig\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"gasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetBlockRate\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBaseFee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"baseFeeChangeDenominator\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockGasCostStep\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getFeeConfigLastChangedAt\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"blockNumber\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getFeeDiscount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"discount\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"offset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"limit\",\"type\":\"uint256\"}],\"name\":\"readAll\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"addrs\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"roles\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"readAllCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"readAllowList\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setEnabled\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"gasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetBlockRate\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBaseFee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"targetGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"baseFeeChangeDenominator\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxBlockGasCost\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"blockGasCostStep\",\"type\":\"uint256\"}],\"name\":\"setFeeConfig\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"discount\",\"type\":\"uint256\"}],\"name\":\"setFeeDiscount\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setManager\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"addrs\",\"type\":\"address[]\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"setMany\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setNone\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// IFeeManagerABI is the input ABI used to generate the binding from.
+// Deprecated: Use IFeeManagerMetaData.ABI instead.
+var IFeeManagerABI = IFeeManagerMetaData.ABI
+
+// IFeeManager is an auto generated Go binding around an Ethereum contract.
+type IFeeManager struct {
+	IFeeManagerCaller     // Read-only binding to the contract
+	IFeeManagerTransactor // Write-only binding to the contract
+	IFeeManagerFilterer   // Log filterer for contract events
+}
+
+// IFeeManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IFeeManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IFeeManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IFeeManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IFeeManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IFeeManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IFeeManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IFeeManagerSession struct {
+	Contract     *IFeeManager      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IFeeManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IFeeManagerCallerSession struct {
+	Contract *IFeeManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// IFeeManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IFeeManagerTransactorSession struct {
+	Contract     *IFeeManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// IFeeManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IFeeManagerRaw struct {
+	Contract *IFeeManager // Generic contract binding to access the raw methods on
+}
+
+// IFeeManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IFeeManagerCallerRaw struct {
+	Contract *IFeeManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IFeeManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IFeeManagerTransactorRaw struct {
+	Contract *IFeeManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIFeeManager creates a new instance of IFeeManager, bound to a specific deployed contract.
+func NewIFeeManager(address common.Address, backend bind.ContractBackend) (*IFeeManager, error) {
+	contract, err := bindIFeeManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IFeeManager{IFeeManagerCaller: IFeeManagerCaller{contract: contract}, IFeeManagerTransactor: IFeeManagerTransactor{contract: contract}, IFeeManagerFilterer: IFeeManagerFilterer{contract: contract}}, nil
+}
+
+// NewIFeeManagerCaller creates a new read-only instance of IFeeManager, bound to a specific deployed contract.
+func NewIFeeManagerCaller(address common.Address, caller bind.ContractCaller) (*IFeeManagerCaller, error) {
+	contract, err := bindIFeeManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IFeeManagerCaller{contract: contract}, nil
+}
+
+// NewIFeeManagerTransactor creates a new write-only instance of IFeeManager, bound to a specific deployed contract.
+func NewIFeeManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*IFeeManagerTransactor, error) {
+	contract, err := bindIFeeManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IFeeManagerTransactor{contract: contract}, nil
+}
+
+// NewIFeeManagerFilterer creates a new log filterer instance of IFeeManager, bound to a specific deployed contract.
+func NewIFeeManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*IFeeManagerFilterer, error) {
+	contract, err := bindIFeeManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IFeeManagerFilterer{contract: contract}, nil
+}
+
+// bindIFeeManager binds a generic wrapper to an already deployed contract.
+func bindIFeeManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IFeeManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IFeeManager *IFeeManagerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IFeeManager.Contract.IFeeManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IFeeManager *IFeeManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IFeeManager.Contract.IFeeManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IFeeManager *IFeeManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IFeeManager.Contract.IFeeManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IFeeManager *IFeeManagerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IFeeManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IFeeManager *IFeeManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IFeeManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IFeeManager *IFeeManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IFeeManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetFeeConfig is a free data retrieval call binding the contract method 0x5fbbc0d2.
+//
+// Solidity: function getFeeConfig() view returns(uint256 gasLimit, uint256 targetBlockRate, uint256 minBaseFee, uint256 targetGas, uint256 baseFeeChangeDenominator, uint256 minBlockGasCost, uint256 maxBlockGasCost, uint256 blockGasCostStep)
+func (_IFeeManager *IFeeManagerCaller) GetFeeConfig(opts *bind.CallOpts) (struct {
+	GasLimit                 *big.Int
+	TargetBlockRate          *big.Int
+	MinBaseFee               *big.Int
+	TargetGas                *big.Int
+	BaseFeeChangeDenominator *big.Int
+	MinBlockGasCost          *big.Int
+	MaxBlockGasCost          *big.Int
+	BlockGasCostStep         *big.Int
+}, error) {
+	var out []interface{}
+	err := _IFeeManager.contract.Call(opts, &out, "getFeeConfig")
+
+	outstruct := new(struct {
+		GasLimit                 *big.Int
+		TargetBlockRate          *big.Int
+		MinBaseFee               *big.Int
+		TargetGas                *big.Int
+		BaseFeeChangeDenominator *big.Int
+		MinBlockGasCost          *big.Int
+		MaxBlockGasCost          *big.Int
+		BlockGasCostStep         *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.GasLimit = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.TargetBlockRate = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.MinBaseFee = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.TargetGas = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.BaseFeeChangeDenominator = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.MinBlockGasCost = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.MaxBlockGasCost = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.BlockGasCostStep = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetFeeConfig is a free data retrieval call binding the contract method 0x5fbbc0d2.
+//
+// Solidity: function getFeeConfig() view returns(uint256 gasLimit, uint256 targetBlockRate, uint256 minBaseFee, uint256 targetGas, uint256 baseFeeChangeDenominator, uint256 minBlockGasCost, uint256 maxBlockGasCost, uint256 blockGasCostStep)
+func (_IFeeManager *IFeeManagerSession) GetFeeConfig() (struct {
+	GasLimit                 *big.Int
+	TargetBlockRate          *big.Int
+	MinBaseFee               *big.Int
+	TargetGas                *big.Int
+	BaseFeeChangeDenominator *big.Int
+	MinBlockGasCost          *big.Int
+	MaxBlockGasCost          *big.Int
+	BlockGasCostStep         *big.Int
+}, error) {
+	return _IFeeManager.Contract.GetFeeConfig(&_IFeeManager.CallOpts)
+}
+
+// GetFeeConfig is a free data retrieval call binding the contract method 0x5fbbc0d2.
+//
+// Solidity: function getFeeConfig() view returns(uint256 gasLimit, uint256 targetBlockRate, uint256 minBaseFee, uint256 targetGas, uint256 baseFeeChangeDenominator, uint256 minBlockGasCost, uint256 maxBlockGasCost, uint256 blockGasCostStep)
+func (_IFeeManager *IFeeManagerCallerSession) GetFeeConfig() (struct {
+	GasLimit                 *big.Int
+	TargetBlockRate          *big.Int
+	MinBaseFee               *big.Int
+	TargetGas                *big.Int
+	BaseFeeChangeDenominator *big.Int
+	MinBlockGasCost          *big.Int
+	MaxBlockGasCost          *big.Int
+	BlockGasCostStep         *big.Int
+}, error) {
+	return _IFeeManager.Contract.GetFeeConfig(&_IFeeManager.CallOpts)
+}
+
+// GetFeeConfigLastChangedAt is a free data retrieval call binding the contract method 0x9e05549a.
+//
+// Solidity: function getFeeConfigLastChangedAt() view returns(uint256 blockNumber)
+func (_IFeeManager *IFeeManagerCaller) GetFeeConfigLastChangedAt(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IFeeManager.contract.Call(opts, &out, "getFeeConfigLastChangedAt")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetFeeConfigLastChangedAt is a free data retrieval call binding the contract method 0x9e05549a.
+//
+// Solidity: function getFeeConfigLastChangedAt() view returns(uint256 blockNumber)
+func (_IFeeManager *IFeeManagerSession) GetFeeConfigLastChangedAt() (*big.Int, error) {
+	return _IFeeManager.Contract.GetFeeConfigLastChangedAt(&_IFeeManager.CallOpts)
+}
+
+// GetFeeConfigLastChangedAt is a free data retrieval call binding the contract method 0x9e05549a.
+//
+// Solidity: function getFeeConfigLastChangedAt() view returns(uint256 blockNumber)
+func (_IFeeManager *IFeeManagerCallerSession) GetFeeConfigLastChangedAt() (*big.Int, error) {
+	return _IFeeManager.Contract.GetFeeConfigLastChangedAt(&_IFeeManager.CallOpts)
+}
+
+// GetFeeDiscount is a free data retrieval call binding the contract method 0xb7fa7cfc.
+//
+// Solidity: function getFeeDiscount(address account) view returns(uint256 discount)
+func (_IFeeManager *IFeeManagerCaller) GetFeeDiscount(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IFeeManager.contract.Call(opts, &out, "getFeeDiscount", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetFeeDiscount is a free data retrieval call binding the contract method 0xb7fa7cfc.
+//
+// Solidity: function getFeeDiscount(address account) view returns(uint256 discount)
+func (_IFeeManager *IFeeManagerSession) GetFeeDiscount(account common.Address) (*big.Int, error) {
+	return _IFeeManager.Contract.GetFeeDiscount(&_IFeeManager.CallOpts, account)
+}
+
+// GetFeeDiscount is a free data retrieval call binding the contract method 0xb7fa7cfc.
+//
+// Solidity: function getFeeDiscount(address account) view returns(uint256 discount)
+func (_IFeeManager *IFeeManagerCallerSession) GetFeeDiscount(account common.Address) (*big.Int, error) {
+	return _IFeeManager.Contract.GetFeeDiscount(&_IFeeManager.CallOpts, account)
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_IFeeManager *IFeeManagerCaller) ReadAll(opts *bind.CallOpts, offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	var out []interface{}
+	err := _IFeeManager.contract.Call(opts, &out, "readAll", offset, limit)
+
+	outstruct := new(struct {
+		Addrs []common.Address
+		Roles []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Addrs = *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+	outstruct.Roles = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_IFeeManager *IFeeManagerSession) ReadAll(offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	return _IFeeManager.Contract.ReadAll(&_IFeeManager.CallOpts, offset, limit)
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_IFeeManager *IFeeManagerCallerSession) ReadAll(offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	return _IFeeManager.Contract.ReadAll(&_IFeeManager.CallOpts, offset, limit)
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_IFeeManager *IFeeManagerCaller) ReadAllCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IFeeManager.contract.Call(opts, &out, "readAllCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_IFeeManager *IFeeManagerSession) ReadAllCount() (*big.Int, error) {
+	return _IFeeManager.Contract.ReadAllCount(&_IFeeManager.CallOpts)
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_IFeeManager *IFeeManagerCallerSession) ReadAllCount() (*big.Int, error) {
+	return _IFeeManager.Contract.ReadAllCount(&_IFeeManager.CallOpts)
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_IFeeManager *IFeeManagerCaller) ReadAllowList(opts *bind.CallOpts, addr common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IFeeManager.contract.Call(opts, &out, "readAllowList", addr)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_IFeeManager *IFeeManagerSession) ReadAllowList(addr common.Address) (*big.Int, error) {
+	return _IFeeManager.Contract.ReadAllowList(&_IFeeManager.CallOpts, addr)
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_IFeeManager *IFeeManagerCallerSession) ReadAllowList(addr common.Address) (*big.Int, error) {
+	return _IFeeManager.Contract.ReadAllowList(&_IFeeManager.CallOpts, addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetAdmin(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setAdmin", addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_IFeeManager *IFeeManagerSession) SetAdmin(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetAdmin(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetAdmin(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetAdmin(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetEnabled(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setEnabled", addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_IFeeManager *IFeeManagerSession) SetEnabled(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetEnabled(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetEnabled(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetEnabled(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetFeeConfig is a paid mutator transaction binding the contract method 0x8f10b586.
+//
+// Solidity: function setFeeConfig(uint256 gasLimit, uint256 targetBlockRate, uint256 minBaseFee, uint256 targetGas, uint256 baseFeeChangeDenominator, uint256 minBlockGasCost, uint256 maxBlockGasCost, uint256 blockGasCostStep) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetFeeConfig(opts *bind.TransactOpts, gasLimit *big.Int, targetBlockRate *big.Int, minBaseFee *big.Int, targetGas *big.Int, baseFeeChangeDenominator *big.Int, minBlockGasCost *big.Int, maxBlockGasCost *big.Int, blockGasCostStep *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setFeeConfig", gasLimit, targetBlockRate, minBaseFee, targetGas, baseFeeChangeDenominator, minBlockGasCost, maxBlockGasCost, blockGasCostStep)
+}
+
+// SetFeeConfig is a paid mutator transaction binding the contract method 0x8f10b586.
+//
+// Solidity: function setFeeConfig(uint256 gasLimit, uint256 targetBlockRate, uint256 minBaseFee, uint256 targetGas, uint256 baseFeeChangeDenominator, uint256 minBlockGasCost, uint256 maxBlockGasCost, uint256 blockGasCostStep) returns()
+func (_IFeeManager *IFeeManagerSession) SetFeeConfig(gasLimit *big.Int, targetBlockRate *big.Int, minBaseFee *big.Int, targetGas *big.Int, baseFeeChangeDenominator *big.Int, minBlockGasCost *big.Int, maxBlockGasCost *big.Int, blockGasCostStep *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetFeeConfig(&_IFeeManager.TransactOpts, gasLimit, targetBlockRate, minBaseFee, targetGas, baseFeeChangeDenominator, minBlockGasCost, maxBlockGasCost, blockGasCostStep)
+}
+
+// SetFeeConfig is a paid mutator transaction binding the contract method 0x8f10b586.
+//
+// Solidity: function setFeeConfig(uint256 gasLimit, uint256 targetBlockRate, uint256 minBaseFee, uint256 targetGas, uint256 baseFeeChangeDenominator, uint256 minBlockGasCost, uint256 maxBlockGasCost, uint256 blockGasCostStep) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetFeeConfig(gasLimit *big.Int, targetBlockRate *big.Int, minBaseFee *big.Int, targetGas *big.Int, baseFeeChangeDenominator *big.Int, minBlockGasCost *big.Int, maxBlockGasCost *big.Int, blockGasCostStep *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetFeeConfig(&_IFeeManager.TransactOpts, gasLimit, targetBlockRate, minBaseFee, targetGas, baseFeeChangeDenominator, minBlockGasCost, maxBlockGasCost, blockGasCostStep)
+}
+
+// SetFeeDiscount is a paid mutator transaction binding the contract method 0x11cdf7be.
+//
+// Solidity: function setFeeDiscount(address account, uint256 discount) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetFeeDiscount(opts *bind.TransactOpts, account common.Address, discount *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setFeeDiscount", account, discount)
+}
+
+// SetFeeDiscount is a paid mutator transaction binding the contract method 0x11cdf7be.
+//
+// Solidity: function setFeeDiscount(address account, uint256 discount) returns()
+func (_IFeeManager *IFeeManagerSession) SetFeeDiscount(account common.Address, discount *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetFeeDiscount(&_IFeeManager.TransactOpts, account, discount)
+}
+
+// SetFeeDiscount is a paid mutator transaction binding the contract method 0x11cdf7be.
+//
+// Solidity: function setFeeDiscount(address account, uint256 discount) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetFeeDiscount(account common.Address, discount *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetFeeDiscount(&_IFeeManager.TransactOpts, account, discount)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetManager(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setManager", addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_IFeeManager *IFeeManagerSession) SetManager(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetManager(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetManager(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetManager(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetMany(opts *bind.TransactOpts, addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setMany", addrs, role)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_IFeeManager *IFeeManagerSession) SetMany(addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetMany(&_IFeeManager.TransactOpts, addrs, role)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetMany(addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetMany(&_IFeeManager.TransactOpts, addrs, role)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactor) SetNone(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.contract.Transact(opts, "setNone", addr)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_IFeeManager *IFeeManagerSession) SetNone(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetNone(&_IFeeManager.TransactOpts, addr)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_IFeeManager *IFeeManagerTransactorSession) SetNone(addr common.Address) (*types.Transaction, error) {
+	return _IFeeManager.Contract.SetNone(&_IFeeManager.TransactOpts, addr)
+}
+
+// IFeeManagerFeeConfigChangedIterator is returned from FilterFeeConfigChanged and is used to iterate over the raw logs and unpacked data for FeeConfigChanged events raised by the IFeeManager contract.
+type IFeeManagerFeeConfigChangedIterator struct {
+	Event *IFeeManagerFeeConfigChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IFeeManagerFeeConfigChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IFeeManagerFeeConfigChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IFeeManagerFeeConfigChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IFeeManagerFeeConfigChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IFeeManagerFeeConfigChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IFeeManagerFeeConfigChanged represents a FeeConfigChanged event raised by the IFeeManager contract.
+type IFeeManagerFeeConfigChanged struct {
+	Sender       common.Address
+	OldFeeConfig IFeeManagerFeeConfig
+	NewFeeConfig IFeeManagerFeeConfig
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeeConfigChanged is a free log retrieval operation binding the contract event 0x4c98e43adb5962c18f3f0e6dd066e2a2de258d3b4f695b317b77c8f27cd044fc.
+//
+// Solidity: event FeeConfigChanged(address indexed sender, (uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256) oldFeeConfig, (uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256) newFeeConfig)
+func (_IFeeManager *IFeeManagerFilterer) FilterFeeConfigChanged(opts *bind.FilterOpts, sender []common.Address) (*IFeeManagerFeeConfigChangedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _IFeeManager.contract.FilterLogs(opts, "FeeConfigChanged", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IFeeManagerFeeConfigChangedIterator{contract: _IFeeManager.contract, event: "FeeConfigChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchFeeConfigChanged is a free log subscription operation binding the contract event 0x4c98e43adb5962c18f3f0e6dd066e2a2de258d3b4f695b317b77c8f27cd044fc.
+//
+// Solidity: event FeeConfigChanged(address indexed sender, (uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256) oldFeeConfig, (uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256) newFeeConfig)
+func (_IFeeManager *IFeeManagerFilterer) WatchFeeConfigChanged(opts *bind.WatchOpts, sink chan<- *IFeeManagerFeeConfigChanged, sender []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _IFeeManager.contract.WatchLogs(opts, "FeeConfigChanged", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IFeeManagerFeeConfigChanged)
+				if err := _IFeeManager.contract.UnpackLog(event, "FeeConfigChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeeConfigChanged is a log parse operation binding the contract event 0x4c98e43adb5962c18f3f0e6dd066e2a2de258d3b4f695b317b77c8f27cd044fc.
+//
+// Solidity: event FeeConfigChanged(address indexed sender, (uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256) oldFeeConfig, (uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256) newFeeConfig)
+func (_IFeeManager *IFeeManagerFilterer) ParseFeeConfigChanged(log types.Log) (*IFeeManagerFeeConfigChanged, error) {
+	event := new(IFeeManagerFeeConfigChanged)
+	if err := _IFeeManager.contract.UnpackLog(event, "FeeConfigChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IFeeManagerFeeDiscountChangedIterator is returned from FilterFeeDiscountChanged and is used to iterate over the raw logs and unpacked data for FeeDiscountChanged events raised by the IFeeManager contract.
+type IFeeManagerFeeDiscountChangedIterator struct {
+	Event *IFeeManagerFeeDiscountChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IFeeManagerFeeDiscountChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IFeeManagerFeeDiscountChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IFeeManagerFeeDiscountChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IFeeManagerFeeDiscountChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IFeeManagerFeeDiscountChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IFeeManagerFeeDiscountChanged represents a FeeDiscountChanged event raised by the IFeeManager contract.
+type IFeeManagerFeeDiscountChanged struct {
+	Sender      common.Address
+	Account     common.Address
+	OldDiscount *big.Int
+	NewDiscount *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeeDiscountChanged is a free log retrieval operation binding the contract event 0x2a1f3a0ee817cba400d5452997315c530c4fe5856f4f68c36661a5f233b1a5a4.
+//
+// Solidity: event FeeDiscountChanged(address indexed sender, address indexed account, uint256 oldDiscount, uint256 newDiscount)
+func (_IFeeManager *IFeeManagerFilterer) FilterFeeDiscountChanged(opts *bind.FilterOpts, sender []common.Address, account []common.Address) (*IFeeManagerFeeDiscountChangedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _IFeeManager.contract.FilterLogs(opts, "FeeDiscountChanged", senderRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IFeeManagerFeeDiscountChangedIterator{contract: _IFeeManager.contract, event: "FeeDiscountChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchFeeDiscountChanged is a free log subscription operation binding the contract event 0x2a1f3a0ee817cba400d5452997315c530c4fe5856f4f68c36661a5f233b1a5a4.
+//
+// Solidity: event FeeDiscountChanged(address indexed sender, address indexed account, uint256 oldDiscount, uint256 newDiscount)
+func (_IFeeManager *IFeeManagerFilterer) WatchFeeDiscountChanged(opts *bind.WatchOpts, sink chan<- *IFeeManagerFeeDiscountChanged, sender []common.Address, account []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _IFeeManager.contract.WatchLogs(opts, "FeeDiscountChanged", senderRule, accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IFeeManagerFeeDiscountChanged)
+				if err := _IFeeManager.contract.UnpackLog(event, "FeeDiscountChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeeDiscountChanged is a log parse operation binding the contract event 0x2a1f3a0ee817cba400d5452997315c530c4fe5856f4f68c36661a5f233b1a5a4.
+//
+// Solidity: event FeeDiscountChanged(address indexed sender, address indexed account, uint256 oldDiscount, uint256 newDiscount)
+func (_IFeeManager *IFeeManagerFilterer) ParseFeeDiscountChanged(log types.Log) (*IFeeManagerFeeDiscountChanged, error) {
+	event := new(IFeeManagerFeeDiscountChanged)
+	if err := _IFeeManager.contract.UnpackLog(event, "FeeDiscountChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,1173 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = interfaces.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// INativeMinterMetaData contains all meta data concerning the INativeMinter contract.
+var INativeMinterMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldPeriod\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newPeriod\",\"type\":\"uint256\"}],\"name\":\"MintPeriodChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"minter\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldBudget\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newBudget\",\"type\":\"uint256\"}],\"name\":\"MinterBudgetChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"NativeCoinMinted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldCap\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newCap\",\"type\":\"uint256\"}],\"name\":\"SupplyCapChanged\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"mintLimits\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"supplyCap\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"totalMinted\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"mintPeriod\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mintNativeCoin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"minter\",\"type\":\"address\"}],\"name\":\"minterBudget\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"budget\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"remaining\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"offset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"limit\",\"type\":\"uint256\"}],\"name\":\"readAll\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"addrs\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"roles\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"readAllCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"readAllowList\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setEnabled\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setManager\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"addrs\",\"type\":\"address[]\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"setMany\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"period\",\"type\":\"uint256\"}],\"name\":\"setMintPeriod\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"minter\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"budget\",\"type\":\"uint256\"}],\"name\":\"setMinterBudget\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setNone\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"cap\",\"type\":\"uint256\"}],\"name\":\"setSupplyCap\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// INativeMinterABI is the input ABI used to generate the binding from.
+// Deprecated: Use INativeMinterMetaData.ABI instead.
+var INativeMinterABI = INativeMinterMetaData.ABI
+
+// INativeMinter is an auto generated Go binding around an Ethereum contract.
+type INativeMinter struct {
+	INativeMinterCaller     // Read-only binding to the contract
+	INativeMinterTransactor // Write-only binding to the contract
+	INativeMinterFilterer   // Log filterer for contract events
+}
+
+// INativeMinterCaller is an auto generated read-only Go binding around an Ethereum contract.
+type INativeMinterCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// INativeMinterTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type INativeMinterTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// INativeMinterFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type INativeMinterFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// INativeMinterSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type INativeMinterSession struct {
+	Contract     *INativeMinter    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// INativeMinterCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type INativeMinterCallerSession struct {
+	Contract *INativeMinterCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// INativeMinterTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type INativeMinterTransactorSession struct {
+	Contract     *INativeMinterTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// INativeMinterRaw is an auto generated low-level Go binding around an Ethereum contract.
+type INativeMinterRaw struct {
+	Contract *INativeMinter // Generic contract binding to access the raw methods on
+}
+
+// INativeMinterCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type INativeMinterCallerRaw struct {
+	Contract *INativeMinterCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// INativeMinterTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type INativeMinterTransactorRaw struct {
+	Contract *INativeMinterTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewINativeMinter creates a new instance of INativeMinter, bound to a specific deployed contract.
+func NewINativeMinter(address common.Address, backend bind.ContractBackend) (*INativeMinter, error) {
+	contract, err := bindINativeMinter(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinter{INativeMinterCaller: INativeMinterCaller{contract: contract}, INativeMinterTransactor: INativeMinterTransactor{contract: contract}, INativeMinterFilterer: INativeMinterFilterer{contract: contract}}, nil
+}
+
+// NewINativeMinterCaller creates a new read-only instance of INativeMinter, bound to a specific deployed contract.
+func NewINativeMinterCaller(address common.Address, caller bind.ContractCaller) (*INativeMinterCaller, error) {
+	contract, err := bindINativeMinter(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterCaller{contract: contract}, nil
+}
+
+// NewINativeMinterTransactor creates a new write-only instance of INativeMinter, bound to a specific deployed contract.
+func NewINativeMinterTransactor(address common.Address, transactor bind.ContractTransactor) (*INativeMinterTransactor, error) {
+	contract, err := bindINativeMinter(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterTransactor{contract: contract}, nil
+}
+
+// NewINativeMinterFilterer creates a new log filterer instance of INativeMinter, bound to a specific deployed contract.
+func NewINativeMinterFilterer(address common.Address, filterer bind.ContractFilterer) (*INativeMinterFilterer, error) {
+	contract, err := bindINativeMinter(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterFilterer{contract: contract}, nil
+}
+
+// bindINativeMinter binds a generic wrapper to an already deployed contract.
+func bindINativeMinter(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := INativeMinterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_INativeMinter *INativeMinterRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _INativeMinter.Contract.INativeMinterCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_INativeMinter *INativeMinterRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _INativeMinter.Contract.INativeMinterTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_INativeMinter *INativeMinterRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _INativeMinter.Contract.INativeMinterTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_INativeMinter *INativeMinterCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _INativeMinter.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_INativeMinter *INativeMinterTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _INativeMinter.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_INativeMinter *INativeMinterTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _INativeMinter.Contract.contract.Transact(opts, method, params...)
+}
+
+// MintLimits is a free data retrieval call binding the contract method 0x1e59caeb.
+//
+// Solidity: function mintLimits() view returns(uint256 supplyCap, uint256 totalMinted, uint256 mintPeriod)
+func (_INativeMinter *INativeMinterCaller) MintLimits(opts *bind.CallOpts) (struct {
+	SupplyCap   *big.Int
+	TotalMinted *big.Int
+	MintPeriod  *big.Int
+}, error) {
+	var out []interface{}
+	err := _INativeMinter.contract.Call(opts, &out, "mintLimits")
+
+	outstruct := new(struct {
+		SupplyCap   *big.Int
+		TotalMinted *big.Int
+		MintPeriod  *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.SupplyCap = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.TotalMinted = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.MintPeriod = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// MintLimits is a free data retrieval call binding the contract method 0x1e59caeb.
+//
+// Solidity: function mintLimits() view returns(uint256 supplyCap, uint256 totalMinted, uint256 mintPeriod)
+func (_INativeMinter *INativeMinterSession) MintLimits() (struct {
+	SupplyCap   *big.Int
+	TotalMinted *big.Int
+	MintPeriod  *big.Int
+}, error) {
+	return _INativeMinter.Contract.MintLimits(&_INativeMinter.CallOpts)
+}
+
+// MintLimits is a free data retrieval call binding the contract method 0x1e59caeb.
+//
+// Solidity: function mintLimits() view returns(uint256 supplyCap, uint256 totalMinted, uint256 mintPeriod)
+func (_INativeMinter *INativeMinterCallerSession) MintLimits() (struct {
+	SupplyCap   *big.Int
+	TotalMinted *big.Int
+	MintPeriod  *big.Int
+}, error) {
+	return _INativeMinter.Contract.MintLimits(&_INativeMinter.CallOpts)
+}
+
+// MinterBudget is a free data retrieval call binding the contract method 0x083d09f3.
+//
+// Solidity: function minterBudget(address minter) view returns(uint256 budget, uint256 remaining)
+func (_INativeMinter *INativeMinterCaller) MinterBudget(opts *bind.CallOpts, minter common.Address) (struct {
+	Budget    *big.Int
+	Remaining *big.Int
+}, error) {
+	var out []interface{}
+	err := _INativeMinter.contract.Call(opts, &out, "minterBudget", minter)
+
+	outstruct := new(struct {
+		Budget    *big.Int
+		Remaining *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Budget = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Remaining = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// MinterBudget is a free data retrieval call binding the contract method 0x083d09f3.
+//
+// Solidity: function minterBudget(address minter) view returns(uint256 budget, uint256 remaining)
+func (_INativeMinter *INativeMinterSession) MinterBudget(minter common.Address) (struct {
+	Budget    *big.Int
+	Remaining *big.Int
+}, error) {
+	return _INativeMinter.Contract.MinterBudget(&_INativeMinter.CallOpts, minter)
+}
+
+// MinterBudget is a free data retrieval call binding the contract method 0x083d09f3.
+//
+// Solidity: function minterBudget(address minter) view returns(uint256 budget, uint256 remaining)
+func (_INativeMinter *INativeMinterCallerSession) MinterBudget(minter common.Address) (struct {
+	Budget    *big.Int
+	Remaining *big.Int
+}, error) {
+	return _INativeMinter.Contract.MinterBudget(&_INativeMinter.CallOpts, minter)
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_INativeMinter *INativeMinterCaller) ReadAll(opts *bind.CallOpts, offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	var out []interface{}
+	err := _INativeMinter.contract.Call(opts, &out, "readAll", offset, limit)
+
+	outstruct := new(struct {
+		Addrs []common.Address
+		Roles []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Addrs = *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+	outstruct.Roles = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_INativeMinter *INativeMinterSession) ReadAll(offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	return _INativeMinter.Contract.ReadAll(&_INativeMinter.CallOpts, offset, limit)
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_INativeMinter *INativeMinterCallerSession) ReadAll(offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	return _INativeMinter.Contract.ReadAll(&_INativeMinter.CallOpts, offset, limit)
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_INativeMinter *INativeMinterCaller) ReadAllCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _INativeMinter.contract.Call(opts, &out, "readAllCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_INativeMinter *INativeMinterSession) ReadAllCount() (*big.Int, error) {
+	return _INativeMinter.Contract.ReadAllCount(&_INativeMinter.CallOpts)
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_INativeMinter *INativeMinterCallerSession) ReadAllCount() (*big.Int, error) {
+	return _INativeMinter.Contract.ReadAllCount(&_INativeMinter.CallOpts)
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_INativeMinter *INativeMinterCaller) ReadAllowList(opts *bind.CallOpts, addr common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _INativeMinter.contract.Call(opts, &out, "readAllowList", addr)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_INativeMinter *INativeMinterSession) ReadAllowList(addr common.Address) (*big.Int, error) {
+	return _INativeMinter.Contract.ReadAllowList(&_INativeMinter.CallOpts, addr)
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_INativeMinter *INativeMinterCallerSession) ReadAllowList(addr common.Address) (*big.Int, error) {
+	return _INativeMinter.Contract.ReadAllowList(&_INativeMinter.CallOpts, addr)
+}
+
+// MintNativeCoin is a paid mutator transaction binding the contract method 0x4f5aaaba.
+//
+// Solidity: function mintNativeCoin(address addr, uint256 amount) returns()
+func (_INativeMinter *INativeMinterTransactor) MintNativeCoin(opts *bind.TransactOpts, addr common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "mintNativeCoin", addr, amount)
+}
+
+// MintNativeCoin is a paid mutator transaction binding the contract method 0x4f5aaaba.
+//
+// Solidity: function mintNativeCoin(address addr, uint256 amount) returns()
+func (_INativeMinter *INativeMinterSession) MintNativeCoin(addr common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.MintNativeCoin(&_INativeMinter.TransactOpts, addr, amount)
+}
+
+// MintNativeCoin is a paid mutator transaction binding the contract method 0x4f5aaaba.
+//
+// Solidity: function mintNativeCoin(address addr, uint256 amount) returns()
+func (_INativeMinter *INativeMinterTransactorSession) MintNativeCoin(addr common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.MintNativeCoin(&_INativeMinter.TransactOpts, addr, amount)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_INativeMinter *INativeMinterTransactor) SetAdmin(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setAdmin", addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_INativeMinter *INativeMinterSession) SetAdmin(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetAdmin(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetAdmin(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetAdmin(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_INativeMinter *INativeMinterTransactor) SetEnabled(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setEnabled", addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_INativeMinter *INativeMinterSession) SetEnabled(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetEnabled(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetEnabled(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetEnabled(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_INativeMinter *INativeMinterTransactor) SetManager(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setManager", addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_INativeMinter *INativeMinterSession) SetManager(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetManager(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetManager(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetManager(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_INativeMinter *INativeMinterTransactor) SetMany(opts *bind.TransactOpts, addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setMany", addrs, role)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_INativeMinter *INativeMinterSession) SetMany(addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetMany(&_INativeMinter.TransactOpts, addrs, role)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetMany(addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetMany(&_INativeMinter.TransactOpts, addrs, role)
+}
+
+// SetMintPeriod is a paid mutator transaction binding the contract method 0xa631fd49.
+//
+// Solidity: function setMintPeriod(uint256 period) returns()
+func (_INativeMinter *INativeMinterTransactor) SetMintPeriod(opts *bind.TransactOpts, period *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setMintPeriod", period)
+}
+
+// SetMintPeriod is a paid mutator transaction binding the contract method 0xa631fd49.
+//
+// Solidity: function setMintPeriod(uint256 period) returns()
+func (_INativeMinter *INativeMinterSession) SetMintPeriod(period *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetMintPeriod(&_INativeMinter.TransactOpts, period)
+}
+
+// SetMintPeriod is a paid mutator transaction binding the contract method 0xa631fd49.
+//
+// Solidity: function setMintPeriod(uint256 period) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetMintPeriod(period *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetMintPeriod(&_INativeMinter.TransactOpts, period)
+}
+
+// SetMinterBudget is a paid mutator transaction binding the contract method 0x2a9a0520.
+//
+// Solidity: function setMinterBudget(address minter, uint256 budget) returns()
+func (_INativeMinter *INativeMinterTransactor) SetMinterBudget(opts *bind.TransactOpts, minter common.Address, budget *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setMinterBudget", minter, budget)
+}
+
+// SetMinterBudget is a paid mutator transaction binding the contract method 0x2a9a0520.
+//
+// Solidity: function setMinterBudget(address minter, uint256 budget) returns()
+func (_INativeMinter *INativeMinterSession) SetMinterBudget(minter common.Address, budget *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetMinterBudget(&_INativeMinter.TransactOpts, minter, budget)
+}
+
+// SetMinterBudget is a paid mutator transaction binding the contract method 0x2a9a0520.
+//
+// Solidity: function setMinterBudget(address minter, uint256 budget) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetMinterBudget(minter common.Address, budget *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetMinterBudget(&_INativeMinter.TransactOpts, minter, budget)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_INativeMinter *INativeMinterTransactor) SetNone(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setNone", addr)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_INativeMinter *INativeMinterSession) SetNone(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetNone(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetNone(addr common.Address) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetNone(&_INativeMinter.TransactOpts, addr)
+}
+
+// SetSupplyCap is a paid mutator transaction binding the contract method 0xb6a3f59a.
+//
+// Solidity: function setSupplyCap(uint256 cap) returns()
+func (_INativeMinter *INativeMinterTransactor) SetSupplyCap(opts *bind.TransactOpts, cap *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.contract.Transact(opts, "setSupplyCap", cap)
+}
+
+// SetSupplyCap is a paid mutator transaction binding the contract method 0xb6a3f59a.
+//
+// Solidity: function setSupplyCap(uint256 cap) returns()
+func (_INativeMinter *INativeMinterSession) SetSupplyCap(cap *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetSupplyCap(&_INativeMinter.TransactOpts, cap)
+}
+
+// SetSupplyCap is a paid mutator transaction binding the contract method 0xb6a3f59a.
+//
+// Solidity: function setSupplyCap(uint256 cap) returns()
+func (_INativeMinter *INativeMinterTransactorSession) SetSupplyCap(cap *big.Int) (*types.Transaction, error) {
+	return _INativeMinter.Contract.SetSupplyCap(&_INativeMinter.TransactOpts, cap)
+}
+
+// INativeMinterMintPeriodChangedIterator is returned from FilterMintPeriodChanged and is used to iterate over the raw logs and unpacked data for MintPeriodChanged events raised by the INativeMinter contract.
+type INativeMinterMintPeriodChangedIterator struct {
+	Event *INativeMinterMintPeriodChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *INativeMinterMintPeriodChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(INativeMinterMintPeriodChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(INativeMinterMintPeriodChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *INativeMinterMintPeriodChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *INativeMinterMintPeriodChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// INativeMinterMintPeriodChanged represents a MintPeriodChanged event raised by the INativeMinter contract.
+type INativeMinterMintPeriodChanged struct {
+	Sender    common.Address
+	OldPeriod *big.Int
+	NewPeriod *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterMintPeriodChanged is a free log retrieval operation binding the contract event 0xe88a2c62769b38c22dfae5f70739a21eccb7cf4acdfd7f80be521c68b22055fa.
+//
+// Solidity: event MintPeriodChanged(address indexed sender, uint256 oldPeriod, uint256 newPeriod)
+func (_INativeMinter *INativeMinterFilterer) FilterMintPeriodChanged(opts *bind.FilterOpts, sender []common.Address) (*INativeMinterMintPeriodChangedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.FilterLogs(opts, "MintPeriodChanged", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterMintPeriodChangedIterator{contract: _INativeMinter.contract, event: "MintPeriodChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchMintPeriodChanged is a free log subscription operation binding the contract event 0xe88a2c62769b38c22dfae5f70739a21eccb7cf4acdfd7f80be521c68b22055fa.
+//
+// Solidity: event MintPeriodChanged(address indexed sender, uint256 oldPeriod, uint256 newPeriod)
+func (_INativeMinter *INativeMinterFilterer) WatchMintPeriodChanged(opts *bind.WatchOpts, sink chan<- *INativeMinterMintPeriodChanged, sender []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.WatchLogs(opts, "MintPeriodChanged", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(INativeMinterMintPeriodChanged)
+				if err := _INativeMinter.contract.UnpackLog(event, "MintPeriodChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMintPeriodChanged is a log parse operation binding the contract event 0xe88a2c62769b38c22dfae5f70739a21eccb7cf4acdfd7f80be521c68b22055fa.
+//
+// Solidity: event MintPeriodChanged(address indexed sender, uint256 oldPeriod, uint256 newPeriod)
+func (_INativeMinter *INativeMinterFilterer) ParseMintPeriodChanged(log types.Log) (*INativeMinterMintPeriodChanged, error) {
+	event := new(INativeMinterMintPeriodChanged)
+	if err := _INativeMinter.contract.UnpackLog(event, "MintPeriodChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// INativeMinterMinterBudgetChangedIterator is returned from FilterMinterBudgetChanged and is used to iterate over the raw logs and unpacked data for MinterBudgetChanged events raised by the INativeMinter contract.
+type INativeMinterMinterBudgetChangedIterator struct {
+	Event *INativeMinterMinterBudgetChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *INativeMinterMinterBudgetChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(INativeMinterMinterBudgetChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(INativeMinterMinterBudgetChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *INativeMinterMinterBudgetChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *INativeMinterMinterBudgetChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// INativeMinterMinterBudgetChanged represents a MinterBudgetChanged event raised by the INativeMinter contract.
+type INativeMinterMinterBudgetChanged struct {
+	Sender    common.Address
+	Minter    common.Address
+	OldBudget *big.Int
+	NewBudget *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterMinterBudgetChanged is a free log retrieval operation binding the contract event 0xfbec57b501478509316d3ede5ffd6588d67190e7f8881d6d63bdae837def1554.
+//
+// Solidity: event MinterBudgetChanged(address indexed sender, address indexed minter, uint256 oldBudget, uint256 newBudget)
+func (_INativeMinter *INativeMinterFilterer) FilterMinterBudgetChanged(opts *bind.FilterOpts, sender []common.Address, minter []common.Address) (*INativeMinterMinterBudgetChangedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var minterRule []interface{}
+	for _, minterItem := range minter {
+		minterRule = append(minterRule, minterItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.FilterLogs(opts, "MinterBudgetChanged", senderRule, minterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterMinterBudgetChangedIterator{contract: _INativeMinter.contract, event: "MinterBudgetChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchMinterBudgetChanged is a free log subscription operation binding the contract event 0xfbec57b501478509316d3ede5ffd6588d67190e7f8881d6d63bdae837def1554.
+//
+// Solidity: event MinterBudgetChanged(address indexed sender, address indexed minter, uint256 oldBudget, uint256 newBudget)
+func (_INativeMinter *INativeMinterFilterer) WatchMinterBudgetChanged(opts *bind.WatchOpts, sink chan<- *INativeMinterMinterBudgetChanged, sender []common.Address, minter []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var minterRule []interface{}
+	for _, minterItem := range minter {
+		minterRule = append(minterRule, minterItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.WatchLogs(opts, "MinterBudgetChanged", senderRule, minterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(INativeMinterMinterBudgetChanged)
+				if err := _INativeMinter.contract.UnpackLog(event, "MinterBudgetChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMinterBudgetChanged is a log parse operation binding the contract event 0xfbec57b501478509316d3ede5ffd6588d67190e7f8881d6d63bdae837def1554.
+//
+// Solidity: event MinterBudgetChanged(address indexed sender, address indexed minter, uint256 oldBudget, uint256 newBudget)
+func (_INativeMinter *INativeMinterFilterer) ParseMinterBudgetChanged(log types.Log) (*INativeMinterMinterBudgetChanged, error) {
+	event := new(INativeMinterMinterBudgetChanged)
+	if err := _INativeMinter.contract.UnpackLog(event, "MinterBudgetChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// INativeMinterNativeCoinMintedIterator is returned from FilterNativeCoinMinted and is used to iterate over the raw logs and unpacked data for NativeCoinMinted events raised by the INativeMinter contract.
+type INativeMinterNativeCoinMintedIterator struct {
+	Event *INativeMinterNativeCoinMinted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *INativeMinterNativeCoinMintedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(INativeMinterNativeCoinMinted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(INativeMinterNativeCoinMinted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *INativeMinterNativeCoinMintedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *INativeMinterNativeCoinMintedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// INativeMinterNativeCoinMinted represents a NativeCoinMinted event raised by the INativeMinter contract.
+type INativeMinterNativeCoinMinted struct {
+	Sender    common.Address
+	Recipient common.Address
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterNativeCoinMinted is a free log retrieval operation binding the contract event 0x400cd392f3d56fd10bb1dbd5839fdda8298208ddaa97b368faa053e1850930ee.
+//
+// Solidity: event NativeCoinMinted(address indexed sender, address indexed recipient, uint256 amount)
+func (_INativeMinter *INativeMinterFilterer) FilterNativeCoinMinted(opts *bind.FilterOpts, sender []common.Address, recipient []common.Address) (*INativeMinterNativeCoinMintedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.FilterLogs(opts, "NativeCoinMinted", senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterNativeCoinMintedIterator{contract: _INativeMinter.contract, event: "NativeCoinMinted", logs: logs, sub: sub}, nil
+}
+
+// WatchNativeCoinMinted is a free log subscription operation binding the contract event 0x400cd392f3d56fd10bb1dbd5839fdda8298208ddaa97b368faa053e1850930ee.
+//
+// Solidity: event NativeCoinMinted(address indexed sender, address indexed recipient, uint256 amount)
+func (_INativeMinter *INativeMinterFilterer) WatchNativeCoinMinted(opts *bind.WatchOpts, sink chan<- *INativeMinterNativeCoinMinted, sender []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.WatchLogs(opts, "NativeCoinMinted", senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(INativeMinterNativeCoinMinted)
+				if err := _INativeMinter.contract.UnpackLog(event, "NativeCoinMinted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNativeCoinMinted is a log parse operation binding the contract event 0x400cd392f3d56fd10bb1dbd5839fdda8298208ddaa97b368faa053e1850930ee.
+//
+// Solidity: event NativeCoinMinted(address indexed sender, address indexed recipient, uint256 amount)
+func (_INativeMinter *INativeMinterFilterer) ParseNativeCoinMinted(log types.Log) (*INativeMinterNativeCoinMinted, error) {
+	event := new(INativeMinterNativeCoinMinted)
+	if err := _INativeMinter.contract.UnpackLog(event, "NativeCoinMinted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// INativeMinterSupplyCapChangedIterator is returned from FilterSupplyCapChanged and is used to iterate over the raw logs and unpacked data for SupplyCapChanged events raised by the INativeMinter contract.
+type INativeMinterSupplyCapChangedIterator struct {
+	Event *INativeMinterSupplyCapChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *INativeMinterSupplyCapChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(INativeMinterSupplyCapChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(INativeMinterSupplyCapChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *INativeMinterSupplyCapChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *INativeMinterSupplyCapChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// INativeMinterSupplyCapChanged represents a SupplyCapChanged event raised by the INativeMinter contract.
+type INativeMinterSupplyCapChanged struct {
+	Sender common.Address
+	OldCap *big.Int
+	NewCap *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterSupplyCapChanged is a free log retrieval operation binding the contract event 0x0263602682188540a2d633561c0b4453b7d8566285e99f9f6018b8ef2facef49.
+//
+// Solidity: event SupplyCapChanged(address indexed sender, uint256 oldCap, uint256 newCap)
+func (_INativeMinter *INativeMinterFilterer) FilterSupplyCapChanged(opts *bind.FilterOpts, sender []common.Address) (*INativeMinterSupplyCapChangedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.FilterLogs(opts, "SupplyCapChanged", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &INativeMinterSupplyCapChangedIterator{contract: _INativeMinter.contract, event: "SupplyCapChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchSupplyCapChanged is a free log subscription operation binding the contract event 0x0263602682188540a2d633561c0b4453b7d8566285e99f9f6018b8ef2facef49.
+//
+// Solidity: event SupplyCapChanged(address indexed sender, uint256 oldCap, uint256 newCap)
+func (_INativeMinter *INativeMinterFilterer) WatchSupplyCapChanged(opts *bind.WatchOpts, sink chan<- *INativeMinterSupplyCapChanged, sender []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _INativeMinter.contract.WatchLogs(opts, "SupplyCapChanged", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(INativeMinterSupplyCapChanged)
+				if err := _INativeMinter.contract.UnpackLog(event, "SupplyCapChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSupplyCapChanged is a log parse operation binding the contract event 0x0263602682188540a2d633561c0b4453b7d8566285e99f9f6018b8ef2facef49.
+//
+// Solidity: event SupplyCapChanged(address indexed sender, uint256 oldCap, uint256 newCap)
+func (_INativeMinter *INativeMinterFilterer) ParseSupplyCapChanged(log types.Log) (*INativeMinterSupplyCapChanged, error) {
+	event := new(INativeMinterSupplyCapChanged)
+	if err := _INativeMinter.contract.UnpackLog(event, "SupplyCapChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,959 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = interfaces.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ITxAllowListMetaData contains all meta data concerning the ITxAllowList contract.
+var ITxAllowListMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldRole\",\"type\":\"uint256\"}],\"name\":\"RoleSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"name\":\"TargetAllowedSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"enabled\",\"type\":\"bool\"}],\"name\":\"TargetRestrictionSet\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"}],\"name\":\"isTargetAllowed\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isTargetRestricted\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"enabled\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"offset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"limit\",\"type\":\"uint256\"}],\"name\":\"readAll\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"addrs\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"roles\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"readAllCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"readAllowList\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setEnabled\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setManager\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"addrs\",\"type\":\"address[]\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"setMany\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"setNone\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"name\":\"setTargetAllowed\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bool\",\"name\":\"enabled\",\"type\":\"bool\"}],\"name\":\"setTargetRestriction\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ITxAllowListABI is the input ABI used to generate the binding from.
+// Deprecated: Use ITxAllowListMetaData.ABI instead.
+var ITxAllowListABI = ITxAllowListMetaData.ABI
+
+// ITxAllowList is an auto generated Go binding around an Ethereum contract.
+type ITxAllowList struct {
+	ITxAllowListCaller     // Read-only binding to the contract
+	ITxAllowListTransactor // Write-only binding to the contract
+	ITxAllowListFilterer   // Log filterer for contract events
+}
+
+// ITxAllowListCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ITxAllowListCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ITxAllowListTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ITxAllowListTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ITxAllowListFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ITxAllowListFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ITxAllowListSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ITxAllowListSession struct {
+	Contract     *ITxAllowList     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ITxAllowListCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ITxAllowListCallerSession struct {
+	Contract *ITxAllowListCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// ITxAllowListTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ITxAllowListTransactorSession struct {
+	Contract     *ITxAllowListTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// ITxAllowListRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ITxAllowListRaw struct {
+	Contract *ITxAllowList // Generic contract binding to access the raw methods on
+}
+
+// ITxAllowListCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ITxAllowListCallerRaw struct {
+	Contract *ITxAllowListCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ITxAllowListTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ITxAllowListTransactorRaw struct {
+	Contract *ITxAllowListTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewITxAllowList creates a new instance of ITxAllowList, bound to a specific deployed contract.
+func NewITxAllowList(address common.Address, backend bind.ContractBackend) (*ITxAllowList, error) {
+	contract, err := bindITxAllowList(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowList{ITxAllowListCaller: ITxAllowListCaller{contract: contract}, ITxAllowListTransactor: ITxAllowListTransactor{contract: contract}, ITxAllowListFilterer: ITxAllowListFilterer{contract: contract}}, nil
+}
+
+// NewITxAllowListCaller creates a new read-only instance of ITxAllowList, bound to a specific deployed contract.
+func NewITxAllowListCaller(address common.Address, caller bind.ContractCaller) (*ITxAllowListCaller, error) {
+	contract, err := bindITxAllowList(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowListCaller{contract: contract}, nil
+}
+
+// NewITxAllowListTransactor creates a new write-only instance of ITxAllowList, bound to a specific deployed contract.
+func NewITxAllowListTransactor(address common.Address, transactor bind.ContractTransactor) (*ITxAllowListTransactor, error) {
+	contract, err := bindITxAllowList(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowListTransactor{contract: contract}, nil
+}
+
+// NewITxAllowListFilterer creates a new log filterer instance of ITxAllowList, bound to a specific deployed contract.
+func NewITxAllowListFilterer(address common.Address, filterer bind.ContractFilterer) (*ITxAllowListFilterer, error) {
+	contract, err := bindITxAllowList(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowListFilterer{contract: contract}, nil
+}
+
+// bindITxAllowList binds a generic wrapper to an already deployed contract.
+func bindITxAllowList(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ITxAllowListMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ITxAllowList *ITxAllowListRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ITxAllowList.Contract.ITxAllowListCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ITxAllowList *ITxAllowListRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.ITxAllowListTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ITxAllowList *ITxAllowListRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.ITxAllowListTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ITxAllowList *ITxAllowListCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ITxAllowList.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ITxAllowList *ITxAllowListTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ITxAllowList *ITxAllowListTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.contract.Transact(opts, method, params...)
+}
+
+// IsTargetAllowed is a free data retrieval call binding the contract method 0x5309fa47.
+//
+// Solidity: function isTargetAllowed(address target) view returns(bool allowed)
+func (_ITxAllowList *ITxAllowListCaller) IsTargetAllowed(opts *bind.CallOpts, target common.Address) (bool, error) {
+	var out []interface{}
+	err := _ITxAllowList.contract.Call(opts, &out, "isTargetAllowed", target)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsTargetAllowed is a free data retrieval call binding the contract method 0x5309fa47.
+//
+// Solidity: function isTargetAllowed(address target) view returns(bool allowed)
+func (_ITxAllowList *ITxAllowListSession) IsTargetAllowed(target common.Address) (bool, error) {
+	return _ITxAllowList.Contract.IsTargetAllowed(&_ITxAllowList.CallOpts, target)
+}
+
+// IsTargetAllowed is a free data retrieval call binding the contract method 0x5309fa47.
+//
+// Solidity: function isTargetAllowed(address target) view returns(bool allowed)
+func (_ITxAllowList *ITxAllowListCallerSession) IsTargetAllowed(target common.Address) (bool, error) {
+	return _ITxAllowList.Contract.IsTargetAllowed(&_ITxAllowList.CallOpts, target)
+}
+
+// IsTargetRestricted is a free data retrieval call binding the contract method 0x8e94ef74.
+//
+// Solidity: function isTargetRestricted() view returns(bool enabled)
+func (_ITxAllowList *ITxAllowListCaller) IsTargetRestricted(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _ITxAllowList.contract.Call(opts, &out, "isTargetRestricted")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsTargetRestricted is a free data retrieval call binding the contract method 0x8e94ef74.
+//
+// Solidity: function isTargetRestricted() view returns(bool enabled)
+func (_ITxAllowList *ITxAllowListSession) IsTargetRestricted() (bool, error) {
+	return _ITxAllowList.Contract.IsTargetRestricted(&_ITxAllowList.CallOpts)
+}
+
+// IsTargetRestricted is a free data retrieval call binding the contract method 0x8e94ef74.
+//
+// Solidity: function isTargetRestricted() view returns(bool enabled)
+func (_ITxAllowList *ITxAllowListCallerSession) IsTargetRestricted() (bool, error) {
+	return _ITxAllowList.Contract.IsTargetRestricted(&_ITxAllowList.CallOpts)
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_ITxAllowList *ITxAllowListCaller) ReadAll(opts *bind.CallOpts, offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	var out []interface{}
+	err := _ITxAllowList.contract.Call(opts, &out, "readAll", offset, limit)
+
+	outstruct := new(struct {
+		Addrs []common.Address
+		Roles []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Addrs = *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+	outstruct.Roles = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_ITxAllowList *ITxAllowListSession) ReadAll(offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	return _ITxAllowList.Contract.ReadAll(&_ITxAllowList.CallOpts, offset, limit)
+}
+
+// ReadAll is a free data retrieval call binding the contract method 0x4c6e68da.
+//
+// Solidity: function readAll(uint256 offset, uint256 limit) view returns(address[] addrs, uint256[] roles)
+func (_ITxAllowList *ITxAllowListCallerSession) ReadAll(offset *big.Int, limit *big.Int) (struct {
+	Addrs []common.Address
+	Roles []*big.Int
+}, error) {
+	return _ITxAllowList.Contract.ReadAll(&_ITxAllowList.CallOpts, offset, limit)
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_ITxAllowList *ITxAllowListCaller) ReadAllCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ITxAllowList.contract.Call(opts, &out, "readAllCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_ITxAllowList *ITxAllowListSession) ReadAllCount() (*big.Int, error) {
+	return _ITxAllowList.Contract.ReadAllCount(&_ITxAllowList.CallOpts)
+}
+
+// ReadAllCount is a free data retrieval call binding the contract method 0x86f7f8ca.
+//
+// Solidity: function readAllCount() view returns(uint256 count)
+func (_ITxAllowList *ITxAllowListCallerSession) ReadAllCount() (*big.Int, error) {
+	return _ITxAllowList.Contract.ReadAllCount(&_ITxAllowList.CallOpts)
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_ITxAllowList *ITxAllowListCaller) ReadAllowList(opts *bind.CallOpts, addr common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ITxAllowList.contract.Call(opts, &out, "readAllowList", addr)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_ITxAllowList *ITxAllowListSession) ReadAllowList(addr common.Address) (*big.Int, error) {
+	return _ITxAllowList.Contract.ReadAllowList(&_ITxAllowList.CallOpts, addr)
+}
+
+// ReadAllowList is a free data retrieval call binding the contract method 0xeb54dae1.
+//
+// Solidity: function readAllowList(address addr) view returns(uint256 role)
+func (_ITxAllowList *ITxAllowListCallerSession) ReadAllowList(addr common.Address) (*big.Int, error) {
+	return _ITxAllowList.Contract.ReadAllowList(&_ITxAllowList.CallOpts, addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetAdmin(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setAdmin", addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_ITxAllowList *ITxAllowListSession) SetAdmin(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetAdmin(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetAdmin is a paid mutator transaction binding the contract method 0x704b6c02.
+//
+// Solidity: function setAdmin(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetAdmin(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetAdmin(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetEnabled(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setEnabled", addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_ITxAllowList *ITxAllowListSession) SetEnabled(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetEnabled(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetEnabled is a paid mutator transaction binding the contract method 0x0aaf7043.
+//
+// Solidity: function setEnabled(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetEnabled(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetEnabled(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetManager(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setManager", addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_ITxAllowList *ITxAllowListSession) SetManager(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetManager(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetManager is a paid mutator transaction binding the contract method 0xd0ebdbe7.
+//
+// Solidity: function setManager(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetManager(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetManager(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetMany(opts *bind.TransactOpts, addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setMany", addrs, role)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_ITxAllowList *ITxAllowListSession) SetMany(addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetMany(&_ITxAllowList.TransactOpts, addrs, role)
+}
+
+// SetMany is a paid mutator transaction binding the contract method 0xe4194404.
+//
+// Solidity: function setMany(address[] addrs, uint256 role) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetMany(addrs []common.Address, role *big.Int) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetMany(&_ITxAllowList.TransactOpts, addrs, role)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetNone(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setNone", addr)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_ITxAllowList *ITxAllowListSession) SetNone(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetNone(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetNone is a paid mutator transaction binding the contract method 0x8c6bfb3b.
+//
+// Solidity: function setNone(address addr) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetNone(addr common.Address) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetNone(&_ITxAllowList.TransactOpts, addr)
+}
+
+// SetTargetAllowed is a paid mutator transaction binding the contract method 0x3a3d56fa.
+//
+// Solidity: function setTargetAllowed(address target, bool allowed) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetTargetAllowed(opts *bind.TransactOpts, target common.Address, allowed bool) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setTargetAllowed", target, allowed)
+}
+
+// SetTargetAllowed is a paid mutator transaction binding the contract method 0x3a3d56fa.
+//
+// Solidity: function setTargetAllowed(address target, bool allowed) returns()
+func (_ITxAllowList *ITxAllowListSession) SetTargetAllowed(target common.Address, allowed bool) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetTargetAllowed(&_ITxAllowList.TransactOpts, target, allowed)
+}
+
+// SetTargetAllowed is a paid mutator transaction binding the contract method 0x3a3d56fa.
+//
+// Solidity: function setTargetAllowed(address target, bool allowed) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetTargetAllowed(target common.Address, allowed bool) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetTargetAllowed(&_ITxAllowList.TransactOpts, target, allowed)
+}
+
+// SetTargetRestriction is a paid mutator transaction binding the contract method 0xb1b30c90.
+//
+// Solidity: function setTargetRestriction(bool enabled) returns()
+func (_ITxAllowList *ITxAllowListTransactor) SetTargetRestriction(opts *bind.TransactOpts, enabled bool) (*types.Transaction, error) {
+	return _ITxAllowList.contract.Transact(opts, "setTargetRestriction", enabled)
+}
+
+// SetTargetRestriction is a paid mutator transaction binding the contract method 0xb1b30c90.
+//
+// Solidity: function setTargetRestriction(bool enabled) returns()
+func (_ITxAllowList *ITxAllowListSession) SetTargetRestriction(enabled bool) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetTargetRestriction(&_ITxAllowList.TransactOpts, enabled)
+}
+
+// SetTargetRestriction is a paid mutator transaction binding the contract method 0xb1b30c90.
+//
+// Solidity: function setTargetRestriction(bool enabled) returns()
+func (_ITxAllowList *ITxAllowListTransactorSession) SetTargetRestriction(enabled bool) (*types.Transaction, error) {
+	return _ITxAllowList.Contract.SetTargetRestriction(&_ITxAllowList.TransactOpts, enabled)
+}
+
+// ITxAllowListRoleSetIterator is returned from FilterRoleSet and is used to iterate over the raw logs and unpacked data for RoleSet events raised by the ITxAllowList contract.
+type ITxAllowListRoleSetIterator struct {
+	Event *ITxAllowListRoleSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ITxAllowListRoleSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ITxAllowListRoleSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ITxAllowListRoleSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ITxAllowListRoleSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ITxAllowListRoleSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ITxAllowListRoleSet represents a RoleSet event raised by the ITxAllowList contract.
+type ITxAllowListRoleSet struct {
+	Role    *big.Int
+	Account common.Address
+	Sender  common.Address
+	OldRole *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleSet is a free log retrieval operation binding the contract event 0xcdb7ea01f00a414d78757bdb0f6391664ba3fedf987eed280927c1e7d695be3e.
+//
+// Solidity: event RoleSet(uint256 indexed role, address indexed account, address indexed sender, uint256 oldRole)
+func (_ITxAllowList *ITxAllowListFilterer) FilterRoleSet(opts *bind.FilterOpts, role []*big.Int, account []common.Address, sender []common.Address) (*ITxAllowListRoleSetIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _ITxAllowList.contract.FilterLogs(opts, "RoleSet", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowListRoleSetIterator{contract: _ITxAllowList.contract, event: "RoleSet", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleSet is a free log subscription operation binding the contract event 0xcdb7ea01f00a414d78757bdb0f6391664ba3fedf987eed280927c1e7d695be3e.
+//
+// Solidity: event RoleSet(uint256 indexed role, address indexed account, address indexed sender, uint256 oldRole)
+func (_ITxAllowList *ITxAllowListFilterer) WatchRoleSet(opts *bind.WatchOpts, sink chan<- *ITxAllowListRoleSet, role []*big.Int, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _ITxAllowList.contract.WatchLogs(opts, "RoleSet", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ITxAllowListRoleSet)
+				if err := _ITxAllowList.contract.UnpackLog(event, "RoleSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleSet is a log parse operation binding the contract event 0xcdb7ea01f00a414d78757bdb0f6391664ba3fedf987eed280927c1e7d695be3e.
+//
+// Solidity: event RoleSet(uint256 indexed role, address indexed account, address indexed sender, uint256 oldRole)
+func (_ITxAllowList *ITxAllowListFilterer) ParseRoleSet(log types.Log) (*ITxAllowListRoleSet, error) {
+	event := new(ITxAllowListRoleSet)
+	if err := _ITxAllowList.contract.UnpackLog(event, "RoleSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ITxAllowListTargetAllowedSetIterator is returned from FilterTargetAllowedSet and is used to iterate over the raw logs and unpacked data for TargetAllowedSet events raised by the ITxAllowList contract.
+type ITxAllowListTargetAllowedSetIterator struct {
+	Event *ITxAllowListTargetAllowedSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ITxAllowListTargetAllowedSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ITxAllowListTargetAllowedSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ITxAllowListTargetAllowedSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ITxAllowListTargetAllowedSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ITxAllowListTargetAllowedSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ITxAllowListTargetAllowedSet represents a TargetAllowedSet event raised by the ITxAllowList contract.
+type ITxAllowListTargetAllowedSet struct {
+	Target  common.Address
+	Sender  common.Address
+	Allowed bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTargetAllowedSet is a free log retrieval operation binding the contract event 0xf63a0d3ad9ce64628a60625be5cfc837dfceffb5f66441f250f75fd49bbb18fd.
+//
+// Solidity: event TargetAllowedSet(address indexed target, address indexed sender, bool allowed)
+func (_ITxAllowList *ITxAllowListFilterer) FilterTargetAllowedSet(opts *bind.FilterOpts, target []common.Address, sender []common.Address) (*ITxAllowListTargetAllowedSetIterator, error) {
+
+	var targetRule []interface{}
+	for _, targetItem := range target {
+		targetRule = append(targetRule, targetItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _ITxAllowList.contract.FilterLogs(opts, "TargetAllowedSet", targetRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowListTargetAllowedSetIterator{contract: _ITxAllowList.contract, event: "TargetAllowedSet", logs: logs, sub: sub}, nil
+}
+
+// WatchTargetAllowedSet is a free log subscription operation binding the contract event 0xf63a0d3ad9ce64628a60625be5cfc837dfceffb5f66441f250f75fd49bbb18fd.
+//
+// Solidity: event TargetAllowedSet(address indexed target, address indexed sender, bool allowed)
+func (_ITxAllowList *ITxAllowListFilterer) WatchTargetAllowedSet(opts *bind.WatchOpts, sink chan<- *ITxAllowListTargetAllowedSet, target []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var targetRule []interface{}
+	for _, targetItem := range target {
+		targetRule = append(targetRule, targetItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _ITxAllowList.contract.WatchLogs(opts, "TargetAllowedSet", targetRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ITxAllowListTargetAllowedSet)
+				if err := _ITxAllowList.contract.UnpackLog(event, "TargetAllowedSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTargetAllowedSet is a log parse operation binding the contract event 0xf63a0d3ad9ce64628a60625be5cfc837dfceffb5f66441f250f75fd49bbb18fd.
+//
+// Solidity: event TargetAllowedSet(address indexed target, address indexed sender, bool allowed)
+func (_ITxAllowList *ITxAllowListFilterer) ParseTargetAllowedSet(log types.Log) (*ITxAllowListTargetAllowedSet, error) {
+	event := new(ITxAllowListTargetAllowedSet)
+	if err := _ITxAllowList.contract.UnpackLog(event, "TargetAllowedSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ITxAllowListTargetRestrictionSetIterator is returned from FilterTargetRestrictionSet and is used to iterate over the raw logs and unpacked data for TargetRestrictionSet events raised by the ITxAllowList contract.
+type ITxAllowListTargetRestrictionSetIterator struct {
+	Event *ITxAllowListTargetRestrictionSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ITxAllowListTargetRestrictionSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ITxAllowListTargetRestrictionSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ITxAllowListTargetRestrictionSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ITxAllowListTargetRestrictionSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ITxAllowListTargetRestrictionSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ITxAllowListTargetRestrictionSet represents a TargetRestrictionSet event raised by the ITxAllowList contract.
+type ITxAllowListTargetRestrictionSet struct {
+	Sender  common.Address
+	Enabled bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTargetRestrictionSet is a free log retrieval operation binding the contract event 0x5f39c05cfba30f77f61c6a86c4b8876bc69458abad6d1b7c4af5dcd3fe06e966.
+//
+// Solidity: event TargetRestrictionSet(address indexed sender, bool enabled)
+func (_ITxAllowList *ITxAllowListFilterer) FilterTargetRestrictionSet(opts *bind.FilterOpts, sender []common.Address) (*ITxAllowListTargetRestrictionSetIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _ITxAllowList.contract.FilterLogs(opts, "TargetRestrictionSet", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ITxAllowListTargetRestrictionSetIterator{contract: _ITxAllowList.contract, event: "TargetRestrictionSet", logs: logs, sub: sub}, nil
+}
+
+// WatchTargetRestrictionSet is a free log subscription operation binding the contract event 0x5f39c05cfba30f77f61c6a86c4b8876bc69458abad6d1b7c4af5dcd3fe06e966.
+//
+// Solidity: event TargetRestrictionSet(address indexed sender, bool enabled)
+func (_ITxAllowList *ITxAllowListFilterer) WatchTargetRestrictionSet(opts *bind.WatchOpts, sink chan<- *ITxAllowListTargetRestrictionSet, sender []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _ITxAllowList.contract.WatchLogs(opts, "TargetRestrictionSet", senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ITxAllowListTargetRestrictionSet)
+				if err := _ITxAllowList.contract.UnpackLog(event, "TargetRestrictionSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTargetRestrictionSet is a log parse operation binding the contract event 0x5f39c05cfba30f77f61c6a86c4b8876bc69458abad6d1b7c4af5dcd3fe06e966.
+//
+// Solidity: event TargetRestrictionSet(address indexed sender, bool enabled)
+func (_ITxAllowList *ITxAllowListFilterer) ParseTargetRestrictionSet(log types.Log) (*ITxAllowListTargetRestrictionSet, error) {
+	event := new(ITxAllowListTargetRestrictionSet)
+	if err := _ITxAllowList.contract.UnpackLog(event, "TargetRestrictionSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,490 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = interfaces.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// WarpBlockHash is an auto generated low-level Go binding around an user-defined struct.
+type WarpBlockHash struct {
+	SourceChainID [32]byte
+	BlockHash     [32]byte
+}
+
+// WarpMessage is an auto generated low-level Go binding around an user-defined struct.
+type WarpMessage struct {
+	SourceChainID       [32]byte
+	OriginSenderAddress common.Address
+	Payload             []byte
+}
+
+// IWarpMessengerMetaData contains all meta data concerning the IWarpMessenger contract.
+var IWarpMessengerMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"messageID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"message\",\"type\":\"bytes\"}],\"name\":\"SendWarpMessage\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"getBlockchainID\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"blockchainID\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"index\",\"type\":\"uint32\"}],\"name\":\"getVerifiedWarpBlockHash\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"sourceChainID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"blockHash\",\"type\":\"bytes32\"}],\"internalType\":\"structWarpBlockHash\",\"name\":\"warpBlockHash\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"valid\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"index\",\"type\":\"uint32\"}],\"name\":\"getVerifiedWarpMessage\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"sourceChainID\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"originSenderAddress\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"}],\"internalType\":\"structWarpMessage\",\"name\":\"message\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"valid\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"}],\"name\":\"sendWarpMessage\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"messageID\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// IWarpMessengerABI is the input ABI used to generate the binding from.
+// Deprecated: Use IWarpMessengerMetaData.ABI instead.
+var IWarpMessengerABI = IWarpMessengerMetaData.ABI
+
+// IWarpMessenger is an auto generated Go binding around an Ethereum contract.
+type IWarpMessenger struct {
+	IWarpMessengerCaller     // Read-only binding to the contract
+	IWarpMessengerTransactor // Write-only binding to the contract
+	IWarpMessengerFilterer   // Log filterer for contract events
+}
+
+// IWarpMessengerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IWarpMessengerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IWarpMessengerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IWarpMessengerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IWarpMessengerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IWarpMessengerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IWarpMessengerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IWarpMessengerSession struct {
+	Contract     *IWarpMessenger   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IWarpMessengerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IWarpMessengerCallerSession struct {
+	Contract *IWarpMessengerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// IWarpMessengerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IWarpMessengerTransactorSession struct {
+	Contract     *IWarpMessengerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// IWarpMessengerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IWarpMessengerRaw struct {
+	Contract *IWarpMessenger // Generic contract binding to access the raw methods on
+}
+
+// IWarpMessengerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IWarpMessengerCallerRaw struct {
+	Contract *IWarpMessengerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IWarpMessengerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IWarpMessengerTransactorRaw struct {
+	Contract *IWarpMessengerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIWarpMessenger creates a new instance of IWarpMessenger, bound to a specific deployed contract.
+func NewIWarpMessenger(address common.Address, backend bind.ContractBackend) (*IWarpMessenger, error) {
+	contract, err := bindIWarpMessenger(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IWarpMessenger{IWarpMessengerCaller: IWarpMessengerCaller{contract: contract}, IWarpMessengerTransactor: IWarpMessengerTransactor{contract: contract}, IWarpMessengerFilterer: IWarpMessengerFilterer{contract: contract}}, nil
+}
+
+// NewIWarpMessengerCaller creates a new read-only instance of IWarpMessenger, bound to a specific deployed contract.
+func NewIWarpMessengerCaller(address common.Address, caller bind.ContractCaller) (*IWarpMessengerCaller, error) {
+	contract, err := bindIWarpMessenger(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IWarpMessengerCaller{contract: contract}, nil
+}
+
+// NewIWarpMessengerTransactor creates a new write-only instance of IWarpMessenger, bound to a specific deployed contract.
+func NewIWarpMessengerTransactor(address common.Address, transactor bind.ContractTransactor) (*IWarpMessengerTransactor, error) {
+	contract, err := bindIWarpMessenger(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IWarpMessengerTransactor{contract: contract}, nil
+}
+
+// NewIWarpMessengerFilterer creates a new log filterer instance of IWarpMessenger, bound to a specific deployed contract.
+func NewIWarpMessengerFilterer(address common.Address, filterer bind.ContractFilterer) (*IWarpMessengerFilterer, error) {
+	contract, err := bindIWarpMessenger(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IWarpMessengerFilterer{contract: contract}, nil
+}
+
+// bindIWarpMessenger binds a generic wrapper to an already deployed contract.
+func bindIWarpMessenger(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IWarpMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IWarpMessenger *IWarpMessengerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IWarpMessenger.Contract.IWarpMessengerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IWarpMessenger *IWarpMessengerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IWarpMessenger.Contract.IWarpMessengerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IWarpMessenger *IWarpMessengerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IWarpMessenger.Contract.IWarpMessengerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IWarpMessenger *IWarpMessengerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IWarpMessenger.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IWarpMessenger *IWarpMessengerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IWarpMessenger.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IWarpMessenger *IWarpMessengerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IWarpMessenger.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetBlockchainID is a free data retrieval call binding the contract method 0x4213cf78.
+//
+// Solidity: function getBlockchainID() view returns(bytes32 blockchainID)
+func (_IWarpMessenger *IWarpMessengerCaller) GetBlockchainID(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _IWarpMessenger.contract.Call(opts, &out, "getBlockchainID")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetBlockchainID is a free data retrieval call binding the contract method 0x4213cf78.
+//
+// Solidity: function getBlockchainID() view returns(bytes32 blockchainID)
+func (_IWarpMessenger *IWarpMessengerSession) GetBlockchainID() ([32]byte, error) {
+	return _IWarpMessenger.Contract.GetBlockchainID(&_IWarpMessenger.CallOpts)
+}
+
+// GetBlockchainID is a free data retrieval call binding the contract method 0x4213cf78.
+//
+// Solidity: function getBlockchainID() view returns(bytes32 blockchainID)
+func (_IWarpMessenger *IWarpMessengerCallerSession) GetBlockchainID() ([32]byte, error) {
+	return _IWarpMessenger.Contract.GetBlockchainID(&_IWarpMessenger.CallOpts)
+}
+
+// GetVerifiedWarpBlockHash is a free data retrieval call binding the contract method 0xce7f5929.
+//
+// Solidity: function getVerifiedWarpBlockHash(uint32 index) view returns((bytes32,bytes32) warpBlockHash, bool valid)
+func (_IWarpMessenger *IWarpMessengerCaller) GetVerifiedWarpBlockHash(opts *bind.CallOpts, index uint32) (struct {
+	WarpBlockHash WarpBlockHash
+	Valid         bool
+}, error) {
+	var out []interface{}
+	err := _IWarpMessenger.contract.Call(opts, &out, "getVerifiedWarpBlockHash", index)
+
+	outstruct := new(struct {
+		WarpBlockHash WarpBlockHash
+		Valid         bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.WarpBlockHash = *abi.ConvertType(out[0], new(WarpBlockHash)).(*WarpBlockHash)
+	outstruct.Valid = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetVerifiedWarpBlockHash is a free data retrieval call binding the contract method 0xce7f5929.
+//
+// Solidity: function getVerifiedWarpBlockHash(uint32 index) view returns((bytes32,bytes32) warpBlockHash, bool valid)
+func (_IWarpMessenger *IWarpMessengerSession) GetVerifiedWarpBlockHash(index uint32) (struct {
+	WarpBlockHash WarpBlockHash
+	Valid         bool
+}, error) {
+	return _IWarpMessenger.Contract.GetVerifiedWarpBlockHash(&_IWarpMessenger.CallOpts, index)
+}
+
+// GetVerifiedWarpBlockHash is a free data retrieval call binding the contract method 0xce7f5929.
+//
+// Solidity: function getVerifiedWarpBlockHash(uint32 index) view returns((bytes32,bytes32) warpBlockHash, bool valid)
+func (_IWarpMessenger *IWarpMessengerCallerSession) GetVerifiedWarpBlockHash(index uint32) (struct {
+	WarpBlockHash WarpBlockHash
+	Valid         bool
+}, error) {
+	return _IWarpMessenger.Contract.GetVerifiedWarpBlockHash(&_IWarpMessenger.CallOpts, index)
+}
+
+// GetVerifiedWarpMessage is a free data retrieval call binding the contract method 0x6f825350.
+//
+// Solidity: function getVerifiedWarpMessage(uint32 index) view returns((bytes32,address,bytes) message, bool valid)
+func (_IWarpMessenger *IWarpMessengerCaller) GetVerifiedWarpMessage(opts *bind.CallOpts, index uint32) (struct {
+	Message WarpMessage
+	Valid   bool
+}, error) {
+	var out []interface{}
+	err := _IWarpMessenger.contract.Call(opts, &out, "getVerifiedWarpMessage", index)
+
+	outstruct := new(struct {
+		Message WarpMessage
+		Valid   bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Message = *abi.ConvertType(out[0], new(WarpMessage)).(*WarpMessage)
+	outstruct.Valid = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetVerifiedWarpMessage is a free data retrieval call binding the contract method 0x6f825350.
+//
+// Solidity: function getVerifiedWarpMessage(uint32 index) view returns((bytes32,address,bytes) message, bool valid)
+func (_IWarpMessenger *IWarpMessengerSession) GetVerifiedWarpMessage(index uint32) (struct {
+	Message WarpMessage
+	Valid   bool
+}, error) {
+	return _IWarpMessenger.Contract.GetVerifiedWarpMessage(&_IWarpMessenger.CallOpts, index)
+}
+
+// GetVerifiedWarpMessage is a free data retrieval call binding the contract method 0x6f825350.
+//
+// Solidity: function getVerifiedWarpMessage(uint32 index) view returns((bytes32,address,bytes) message, bool valid)
+func (_IWarpMessenger *IWarpMessengerCallerSession) GetVerifiedWarpMessage(index uint32) (struct {
+	Message WarpMessage
+	Valid   bool
+}, error) {
+	return _IWarpMessenger.Contract.GetVerifiedWarpMessage(&_IWarpMessenger.CallOpts, index)
+}
+
+// SendWarpMessage is a paid mutator transaction binding the contract method 0xee5b48eb.
+//
+// Solidity: function sendWarpMessage(bytes payload) returns(bytes32 messageID)
+func (_IWarpMessenger *IWarpMessengerTransactor) SendWarpMessage(opts *bind.TransactOpts, payload []byte) (*types.Transaction, error) {
+	return _IWarpMessenger.contract.Transact(opts, "sendWarpMessage", payload)
+}
+
+// SendWarpMessage is a paid mutator transaction binding the contract method 0xee5b48eb.
+//
+// Solidity: function sendWarpMessage(bytes payload) returns(bytes32 messageID)
+func (_IWarpMessenger *IWarpMessengerSession) SendWarpMessage(payload []byte) (*types.Transaction, error) {
+	return _IWarpMessenger.Contract.SendWarpMessage(&_IWarpMessenger.TransactOpts, payload)
+}
+
+// SendWarpMessage is a paid mutator transaction binding the contract method 0xee5b48eb.
+//
+// Solidity: function sendWarpMessage(bytes payload) returns(bytes32 messageID)
+func (_IWarpMessenger *IWarpMessengerTransactorSession) SendWarpMessage(payload []byte) (*types.Transaction, error) {
+	return _IWarpMessenger.Contract.SendWarpMessage(&_IWarpMessenger.TransactOpts, payload)
+}
+
+// IWarpMessengerSendWarpMessageIterator is returned from FilterSendWarpMessage and is used to iterate over the raw logs and unpacked data for SendWarpMessage events raised by the IWarpMessenger contract.
+type IWarpMessengerSendWarpMessageIterator struct {
+	Event *IWarpMessengerSendWarpMessage // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IWarpMessengerSendWarpMessageIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IWarpMessengerSendWarpMessage)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IWarpMessengerSendWarpMessage)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IWarpMessengerSendWarpMessageIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IWarpMessengerSendWarpMessageIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IWarpMessengerSendWarpMessage represents a SendWarpMessage event raised by the IWarpMessenger contract.
+type IWarpMessengerSendWarpMessage struct {
+	Sender    common.Address
+	MessageID [32]byte
+	Message   []byte
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterSendWarpMessage is a free log retrieval operation binding the contract event 0x56600c567728a800c0aa927500f831cb451df66a7af570eb4df4dfbf4674887d.
+//
+// Solidity: event SendWarpMessage(address indexed sender, bytes32 indexed messageID, bytes message)
+func (_IWarpMessenger *IWarpMessengerFilterer) FilterSendWarpMessage(opts *bind.FilterOpts, sender []common.Address, messageID [][32]byte) (*IWarpMessengerSendWarpMessageIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var messageIDRule []interface{}
+	for _, messageIDItem := range messageID {
+		messageIDRule = append(messageIDRule, messageIDItem)
+	}
+
+	logs, sub, err := _IWarpMessenger.contract.FilterLogs(opts, "SendWarpMessage", senderRule, messageIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IWarpMessengerSendWarpMessageIterator{contract: _IWarpMessenger.contract, event: "SendWarpMessage", logs: logs, sub: sub}, nil
+}
+
+// WatchSendWarpMessage is a free log subscription operation binding the contract event 0x56600c567728a800c0aa927500f831cb451df66a7af570eb4df4dfbf4674887d.
+//
+// Solidity: event SendWarpMessage(address indexed sender, bytes32 indexed messageID, bytes message)
+func (_IWarpMessenger *IWarpMessengerFilterer) WatchSendWarpMessage(opts *bind.WatchOpts, sink chan<- *IWarpMessengerSendWarpMessage, sender []common.Address, messageID [][32]byte) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var messageIDRule []interface{}
+	for _, messageIDItem := range messageID {
+		messageIDRule = append(messageIDRule, messageIDItem)
+	}
+
+	logs, sub, err := _IWarpMessenger.contract.WatchLogs(opts, "SendWarpMessage", senderRule, messageIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IWarpMessengerSendWarpMessage)
+				if err := _IWarpMessenger.contract.UnpackLog(event, "SendWarpMessage", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSendWarpMessage is a log parse operation binding the contract event 0x56600c567728a800c0aa927500f831cb451df66a7af570eb4df4dfbf4674887d.
+//
+// Solidity: event SendWarpMessage(address indexed sender, bytes32 indexed messageID, bytes message)
+func (_IWarpMessenger *IWarpMessengerFilterer) ParseSendWarpMessage(log types.Log) (*IWarpMessengerSendWarpMessage, error) {
+	event := new(IWarpMessengerSendWarpMessage)
+	if err := _IWarpMessenger.contract.UnpackLog(event, "SendWarpMessage", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -6,6 +6,7 @@ package contract
 import (
 	"fmt"
 
+	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -27,6 +28,47 @@ type StatefulPrecompileFunction struct {
 	execute RunStatefulPrecompileFunc
 	// activation is checked before this function is executed
 	activation ActivationFunc
+	// stateMutability is the declared state mutability of this function.
+	// If empty, the function is responsible for handling read-only calls itself.
+	stateMutability StateMutability
+	// gasCost is the declared fixed gas cost of this function, if [hasGasCost] is set.
+	gasCost    uint64
+	hasGasCost bool
+}
+
+// FunctionOption declares an optional property of a StatefulPrecompileFunction.
+type FunctionOption func(*StatefulPrecompileFunction)
+
+// WithStateMutability declares the state mutability of the function, as found in its ABI.
+// View and pure functions cannot modify state during a read-only call, and other
+// functions are rejected during a read-only call before they are executed.
+// An empty [stateMutability], as in ABIs without the field, leaves it undeclared.
+func WithStateMutability(stateMutability StateMutability) FunctionOption {
+	if !stateMutability.valid() {
+		panic(fmt.Errorf("invalid state mutability: %q", stateMutability))
+	}
+	return func(f *StatefulPrecompileFunction) {
+		f.stateMutability = stateMutability
+	}
+}
+
+// WithGasCost declares the fixed gas cost of the function, which is reported by
+// the functionGasCost function of the precompile.
+func WithGasCost(gasCost uint64) FunctionOption {
+	return func(f *StatefulPrecompileFunction) {
+		f.gasCost = gasCost
+		f.hasGasCost = true
+	}
+}
+
+// StateMutability returns the declared state mutability of the function.
+func (f *StatefulPrecompileFunction) StateMutability() StateMutability {
+	return f.stateMutability
+}
+
+// GasCost returns the declared fixed gas cost of the function and whether it is declared.
+func (f *StatefulPrecompileFunction) GasCost() (uint64, bool) {
+	return f.gasCost, f.hasGasCost
 }
 
 func (f *StatefulPrecompileFunction) IsActivated(accessibleState AccessibleState) bool {
@@ -37,19 +79,20 @@ func (f *StatefulPrecompileFunction) IsActivated(accessibleState AccessibleState
 }
 
 // NewStatefulPrecompileFunction creates a stateful precompile function with the given arguments
-func NewStatefulPrecompileFunction(selector []byte, execute RunStatefulPrecompileFunc) *StatefulPrecompileFunction {
-	return &StatefulPrecompileFunction{
-		selector: selector,
-		execute:  execute,
-	}
+func NewStatefulPrecompileFunction(selector []byte, execute RunStatefulPrecompileFunc, opts ...FunctionOption) *StatefulPrecompileFunction {
+	return NewStatefulPrecompileFunctionWithActivator(selector, execute, nil, opts...)
 }
 
-func NewStatefulPrecompileFunctionWithActivator(selector []byte, execute RunStatefulPrecompileFunc, activation ActivationFunc) *StatefulPrecompileFunction {
-	return &StatefulPrecompileFunction{
+func NewStatefulPrecompileFunctionWithActivator(selector []byte, execute RunStatefulPrecompileFunc, activation ActivationFunc, opts ...FunctionOption) *StatefulPrecompileFunction {
+	f := &StatefulPrecompileFunction{
 		selector:   selector,
 		execute:    execute,
 		activation: activation,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 // statefulPrecompileWithFunctionSelectors implements StatefulPrecompiledContract by using 4 byte function selectors to pass
@@ -62,6 +105,7 @@ type statefulPrecompileWithFunctionSelectors struct {
 
 // NewStatefulPrecompileContract generates new StatefulPrecompile using [functions] as the available functions and [fallback]
 // as an optional fallback if there is no input data. Note: the selector of [fallback] will be ignored, so it is required to be left empty.
+// Unless [functions] already defines it, the contract also provides the functionGasCost function after Stromboli.
 func NewStatefulPrecompileContract(fallback RunStatefulPrecompileFunc, functions []*StatefulPrecompileFunction) (StatefulPrecompiledContract, error) {
 	// Construct the contract and populate [functions].
 	contract := &statefulPrecompileWithFunctionSelectors{
@@ -75,6 +119,9 @@ func NewStatefulPrecompileContract(fallback RunStatefulPrecompileFunc, functions
 		}
 		contract.functions[string(function.selector)] = function
 	}
+	if _, exists := contract.functions[string(FunctionGasCostSelector)]; !exists {
+		contract.functions[string(FunctionGasCostSelector)] = newFunctionGasCostFunction(contract.functions)
+	}
 
 	return contract, nil
 }
@@ -105,5 +152,20 @@ func (s *statefulPrecompileWithFunctionSelectors) Run(accessibleState Accessible
 		return nil, suppliedGas, fmt.Errorf("invalid non-activated function selector %#x", selector)
 	}
 
+	if readOnly {
+		switch {
+		case function.stateMutability.IsReadOnly():
+			// Drop any state modification and fail the call if one is attempted.
+			readOnlyState := newReadOnlyAccessibleState(accessibleState)
+			ret, remainingGas, err = function.execute(readOnlyState, caller, addr, functionInput, suppliedGas, readOnly)
+			if readOnlyState.modified() {
+				return nil, 0, vmerrs.ErrWriteProtection
+			}
+			return ret, remainingGas, err
+		case function.stateMutability != "":
+			return nil, 0, vmerrs.ErrWriteProtection
+		}
+	}
+
 	return function.execute(accessibleState, caller, addr, functionInput, suppliedGas, readOnly)
 }
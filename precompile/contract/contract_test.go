@@ -0,0 +1,213 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testReadSelector  = CalculateFunctionSelector("read()")
+	testWriteSelector = CalculateFunctionSelector("write()")
+)
+
+func newTestAccessibleState(ctrl *gomock.Controller, isStromboli bool, stateDB StateDB) *MockAccessibleState {
+	chainConfig := precompileconfig.NewMockChainConfig(ctrl)
+	chainConfig.EXPECT().IsStromboli(gomock.Any()).Return(isStromboli).AnyTimes()
+	blockContext := NewMockBlockContext(ctrl)
+	blockContext.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+	accessibleState := NewMockAccessibleState(ctrl)
+	accessibleState.EXPECT().GetChainConfig().Return(chainConfig).AnyTimes()
+	accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
+	accessibleState.EXPECT().GetStateDB().Return(stateDB).AnyTimes()
+	return accessibleState
+}
+
+// writeState sets a storage slot of [addr] and returns [suppliedGas] - 1.
+func writeState(accessibleState AccessibleState, _ common.Address, addr common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+	accessibleState.GetStateDB().SetState(addr, common.Hash{1}, common.Hash{2})
+	return nil, suppliedGas - 1, nil
+}
+
+// readState returns a storage slot of [addr] and [suppliedGas] - 1.
+func readState(accessibleState AccessibleState, _ common.Address, addr common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+	return accessibleState.GetStateDB().GetState(addr, common.Hash{1}).Bytes(), suppliedGas - 1, nil
+}
+
+func TestStateMutability(t *testing.T) {
+	addr := common.Address{1}
+	for name, test := range map[string]struct {
+		stateMutability StateMutability
+		execute         RunStatefulPrecompileFunc
+		readOnly        bool
+		expectWrite     bool
+		expectRead      bool
+		expectedGas     uint64
+		expectedErr     error
+	}{
+		"undeclared function writes in read-only call": {
+			execute:     writeState,
+			readOnly:    true,
+			expectWrite: true,
+			expectedGas: 9,
+		},
+		"nonpayable function rejected in read-only call": {
+			stateMutability: StateMutabilityNonPayable,
+			execute:         writeState,
+			readOnly:        true,
+			expectedErr:     vmerrs.ErrWriteProtection,
+		},
+		"nonpayable function writes": {
+			stateMutability: StateMutabilityNonPayable,
+			execute:         writeState,
+			expectWrite:     true,
+			expectedGas:     9,
+		},
+		"view function reads in read-only call": {
+			stateMutability: StateMutabilityView,
+			execute:         readState,
+			readOnly:        true,
+			expectRead:      true,
+			expectedGas:     9,
+		},
+		"view function cannot write in read-only call": {
+			stateMutability: StateMutabilityView,
+			execute:         writeState,
+			readOnly:        true,
+			expectedErr:     vmerrs.ErrWriteProtection,
+		},
+		"pure function cannot write in read-only call": {
+			stateMutability: StateMutabilityPure,
+			execute:         writeState,
+			readOnly:        true,
+			expectedErr:     vmerrs.ErrWriteProtection,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			stateDB := NewMockStateDB(ctrl)
+			if test.expectWrite {
+				stateDB.EXPECT().SetState(addr, common.Hash{1}, common.Hash{2})
+			}
+			if test.expectRead {
+				stateDB.EXPECT().GetState(addr, common.Hash{1}).Return(common.Hash{2})
+			}
+			accessibleState := newTestAccessibleState(ctrl, true, stateDB)
+
+			precompile, err := NewStatefulPrecompileContract(nil, []*StatefulPrecompileFunction{
+				NewStatefulPrecompileFunction(testWriteSelector, test.execute, WithStateMutability(test.stateMutability)),
+			})
+			require.NoError(t, err)
+
+			_, remainingGas, err := precompile.Run(accessibleState, common.Address{}, addr, testWriteSelector, 10, test.readOnly)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedGas, remainingGas)
+		})
+	}
+}
+
+func TestWithStateMutabilityInvalid(t *testing.T) {
+	require.Panics(t, func() {
+		WithStateMutability("constant")
+	})
+}
+
+func TestFunctionGasCost(t *testing.T) {
+	const readGasCost = 1234
+	functions := []*StatefulPrecompileFunction{
+		NewStatefulPrecompileFunction(testReadSelector, readState, WithStateMutability(StateMutabilityView), WithGasCost(readGasCost)),
+		NewStatefulPrecompileFunction(testWriteSelector, writeState, WithStateMutability(StateMutabilityNonPayable)),
+		NewPrecompileVersionFunction(1),
+	}
+
+	for name, test := range map[string]struct {
+		isStromboli bool
+		input       []byte
+		suppliedGas uint64
+		expectedRes []byte
+		expectedGas uint64
+		expectedErr string
+	}{
+		"declared gas cost": {
+			isStromboli: true,
+			input:       PackFunctionGasCostInput(testReadSelector),
+			suppliedGas: FunctionGasCostGasCost + 1,
+			expectedRes: PackFunctionGasCostOutput(readGasCost),
+			expectedGas: 1,
+		},
+		"precompileVersion gas cost": {
+			isStromboli: true,
+			input:       PackFunctionGasCostInput(PrecompileVersionSelector),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedRes: PackFunctionGasCostOutput(PrecompileVersionGasCost),
+		},
+		"functionGasCost gas cost": {
+			isStromboli: true,
+			input:       PackFunctionGasCostInput(FunctionGasCostSelector),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedRes: PackFunctionGasCostOutput(FunctionGasCostGasCost),
+		},
+		"undeclared gas cost": {
+			isStromboli: true,
+			input:       PackFunctionGasCostInput(testWriteSelector),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedErr: ErrGasCostNotDeclared.Error(),
+		},
+		"unknown function": {
+			isStromboli: true,
+			input:       PackFunctionGasCostInput([]byte{1, 2, 3, 4}),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedErr: ErrGasCostNotDeclared.Error(),
+		},
+		"invalid input": {
+			isStromboli: true,
+			input:       append(PackFunctionGasCostInput(testReadSelector), 1),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedErr: errInvalidFunctionGasCostInput.Error(),
+		},
+		"non-zero padding": {
+			isStromboli: true,
+			input:       append(common.CopyBytes(testReadSelector), common.Hash{1}.Bytes()[:28]...),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedErr: errInvalidFunctionGasCostInput.Error(),
+		},
+		"out of gas": {
+			isStromboli: true,
+			input:       PackFunctionGasCostInput(testReadSelector),
+			suppliedGas: FunctionGasCostGasCost - 1,
+			expectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"not activated before Stromboli": {
+			isStromboli: false,
+			input:       PackFunctionGasCostInput(testReadSelector),
+			suppliedGas: FunctionGasCostGasCost,
+			expectedGas: FunctionGasCostGasCost,
+			expectedErr: "invalid non-activated function selector",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			accessibleState := newTestAccessibleState(ctrl, test.isStromboli, NewMockStateDB(ctrl))
+
+			precompile, err := NewStatefulPrecompileContract(nil, functions)
+			require.NoError(t, err)
+
+			input := append(common.CopyBytes(FunctionGasCostSelector), test.input...)
+			res, remainingGas, err := precompile.Run(accessibleState, common.Address{}, common.Address{}, input, test.suppliedGas, true)
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expectedRes, res)
+			require.Equal(t, test.expectedGas, remainingGas)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MaxLogTopics is the maximum number of topics of a log, as emitted by LOG4.
+// Non-anonymous events use the first topic for the event signature.
+const MaxLogTopics = 4
+
+var (
+	ErrTooManyTopics     = errors.New("too many event topics")
+	errEventNotFound     = errors.New("event not found")
+	errUnexpectedArgs    = errors.New("unexpected number of event arguments")
+	errMissingTupleField = errors.New("missing tuple field")
+)
+
+// LogGasCost returns the gas charged by the EVM for a LOG with [numTopics] topics
+// and [dataLen] bytes of data.
+func LogGasCost(numTopics int, dataLen int) (uint64, error) {
+	topicGas, overflow := math.SafeMul(LogTopicGas, uint64(numTopics))
+	if overflow {
+		return 0, vmerrs.ErrGasUintOverflow
+	}
+	dataGas, overflow := math.SafeMul(LogDataGas, uint64(dataLen))
+	if overflow {
+		return 0, vmerrs.ErrGasUintOverflow
+	}
+	gas, overflow := math.SafeAdd(LogGas, topicGas)
+	if overflow {
+		return 0, vmerrs.ErrGasUintOverflow
+	}
+	gas, overflow = math.SafeAdd(gas, dataGas)
+	if overflow {
+		return 0, vmerrs.ErrGasUintOverflow
+	}
+	return gas, nil
+}
+
+// PackEvent packs [args] as the event [name] of [contractABI] and returns the topics
+// and data of the log. [args] are given in the order of the event inputs, indexed or not.
+// The first topic is the event signature unless the event is anonymous. Indexed arguments
+// of value types are stored as topics directly, strings and bytes as their keccak256 hash,
+// and arrays and tuples as the keccak256 hash of their in-place encoding, as done by Solidity.
+func PackEvent(contractABI abi.ABI, name string, args ...interface{}) ([]common.Hash, []byte, error) {
+	event, ok := contractABI.Events[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", errEventNotFound, name)
+	}
+	if len(args) != len(event.Inputs) {
+		return nil, nil, fmt.Errorf("%w: event %s expects %d, got %d", errUnexpectedArgs, name, len(event.Inputs), len(args))
+	}
+
+	var (
+		topics         = make([]common.Hash, 0, MaxLogTopics)
+		nonIndexedArgs abi.Arguments
+		nonIndexed     []interface{}
+	)
+	if !event.Anonymous {
+		topics = append(topics, event.ID)
+	}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			nonIndexedArgs = append(nonIndexedArgs, input)
+			nonIndexed = append(nonIndexed, args[i])
+			continue
+		}
+		topic, err := packTopic(input.Type, args[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to pack indexed argument %q of event %s: %w", input.Name, name, err)
+		}
+		topics = append(topics, topic)
+	}
+	if len(topics) > MaxLogTopics {
+		return nil, nil, fmt.Errorf("%w: event %s has %d topics, at most %d are allowed", ErrTooManyTopics, name, len(topics), MaxLogTopics)
+	}
+
+	data, err := nonIndexedArgs.Pack(nonIndexed...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack data of event %s: %w", name, err)
+	}
+	return topics, data, nil
+}
+
+// EmitEvent packs [args] as the event [name] of [contractABI] and adds it to the logs
+// emitted by [addr] in the current block. The caller is responsible for charging the
+// gas of the log, typically as part of the fixed gas cost of the function.
+func EmitEvent(accessibleState AccessibleState, addr common.Address, contractABI abi.ABI, name string, args ...interface{}) error {
+	topics, data, err := PackEvent(contractABI, name, args...)
+	if err != nil {
+		return err
+	}
+	accessibleState.GetStateDB().AddLog(addr, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return nil
+}
+
+// EmitEventWithGas is like EmitEvent but first deducts the gas charged by the EVM for the
+// log from [suppliedGas], so the log is only added if it can be paid for.
+// It returns the remaining gas.
+func EmitEventWithGas(accessibleState AccessibleState, addr common.Address, suppliedGas uint64, contractABI abi.ABI, name string, args ...interface{}) (uint64, error) {
+	topics, data, err := PackEvent(contractABI, name, args...)
+	if err != nil {
+		return suppliedGas, err
+	}
+	gas, err := LogGasCost(len(topics), len(data))
+	if err != nil {
+		return 0, err
+	}
+	remainingGas, err := DeductGas(suppliedGas, gas)
+	if err != nil {
+		return 0, err
+	}
+	accessibleState.GetStateDB().AddLog(addr, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return remainingGas, nil
+}
+
+// packTopic returns the topic of an indexed argument of type [typ] with [value].
+func packTopic(typ abi.Type, value interface{}) (common.Hash, error) {
+	switch typ.T {
+	case abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		encoded, err := encodeInPlace(typ, reflect.ValueOf(value))
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return crypto.Keccak256Hash(encoded), nil
+	default:
+		topics, err := abi.PackTopics([]interface{}{value})
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return topics[0], nil
+	}
+}
+
+// encodeInPlace returns the encoding of [value] used to hash indexed arrays and tuples:
+// the concatenation of the encodings of its elements, each padded to a multiple of 32 bytes,
+// without offsets or length prefixes.
+func encodeInPlace(typ abi.Type, value reflect.Value) ([]byte, error) {
+	switch typ.T {
+	case abi.StringTy:
+		return padRight([]byte(value.String())), nil
+	case abi.BytesTy:
+		return padRight(value.Bytes()), nil
+	case abi.SliceTy, abi.ArrayTy:
+		var encoded []byte
+		for i := 0; i < value.Len(); i++ {
+			elem, err := encodeInPlace(*typ.Elem, value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, elem...)
+		}
+		return encoded, nil
+	case abi.TupleTy:
+		value = reflect.Indirect(value)
+		var encoded []byte
+		for i, elemType := range typ.TupleElems {
+			field := value.FieldByName(abi.ToCamelCase(typ.TupleRawNames[i]))
+			if !field.IsValid() {
+				return nil, fmt.Errorf("%w: %s", errMissingTupleField, typ.TupleRawNames[i])
+			}
+			elem, err := encodeInPlace(*elemType, field)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, elem...)
+		}
+		return encoded, nil
+	default:
+		return abi.Arguments{{Type: typ}}.Pack(value.Interface())
+	}
+}
+
+// padRight pads [b] with zeros to a multiple of 32 bytes.
+func padRight(b []byte) []byte {
+	return common.RightPadBytes(b, (len(b)+common.HashLength-1)/common.HashLength*common.HashLength)
+}
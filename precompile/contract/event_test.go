@@ -0,0 +1,143 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testEventsABI = `[
+	{"type":"event","name":"Values","anonymous":false,"inputs":[
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":true},
+		{"name":"memo","type":"string","indexed":true},
+		{"name":"data","type":"bytes","indexed":false}
+	]},
+	{"type":"event","name":"Anonymous","anonymous":true,"inputs":[
+		{"name":"a","type":"uint256","indexed":true},
+		{"name":"b","type":"uint256","indexed":true},
+		{"name":"c","type":"uint256","indexed":true},
+		{"name":"d","type":"uint256","indexed":true}
+	]},
+	{"type":"event","name":"TooManyTopics","anonymous":true,"inputs":[
+		{"name":"a","type":"uint256","indexed":true},
+		{"name":"b","type":"uint256","indexed":true},
+		{"name":"c","type":"uint256","indexed":true},
+		{"name":"d","type":"uint256","indexed":true},
+		{"name":"e","type":"uint256","indexed":true}
+	]},
+	{"type":"event","name":"References","anonymous":false,"inputs":[
+		{"name":"amounts","type":"uint256[]","indexed":true},
+		{"name":"names","type":"string[2]","indexed":true},
+		{"name":"point","type":"tuple","indexed":true,"components":[
+			{"name":"x","type":"uint256"},
+			{"name":"owner","type":"address"}
+		]}
+	]}
+]`
+
+type testPoint struct {
+	X     *big.Int
+	Owner common.Address
+}
+
+func TestPackEvent(t *testing.T) {
+	contractABI := ParseABI(testEventsABI)
+	sender := common.HexToAddress("0x0123")
+
+	topics, data, err := PackEvent(contractABI, "Values", sender, big.NewInt(7), "memo", []byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{
+		contractABI.Events["Values"].ID,
+		common.BytesToHash(sender.Bytes()),
+		common.BigToHash(big.NewInt(7)),
+		crypto.Keccak256Hash([]byte("memo")),
+	}, topics)
+	unpacked, err := contractABI.Unpack("Values", data)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{[]byte{1, 2}}, unpacked)
+
+	// Anonymous events do not include the event signature.
+	topics, data, err = PackEvent(contractABI, "Anonymous", big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4))
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{
+		common.BigToHash(big.NewInt(1)),
+		common.BigToHash(big.NewInt(2)),
+		common.BigToHash(big.NewInt(3)),
+		common.BigToHash(big.NewInt(4)),
+	}, topics)
+	require.Empty(t, data)
+
+	// Reference types are hashed from their in-place encoding.
+	owner := common.HexToAddress("0x0456")
+	topics, _, err = PackEvent(contractABI, "References",
+		[]*big.Int{big.NewInt(1), big.NewInt(2)},
+		[2]string{"a", "bc"},
+		testPoint{X: big.NewInt(3), Owner: owner},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{
+		contractABI.Events["References"].ID,
+		crypto.Keccak256Hash(common.BigToHash(big.NewInt(1)).Bytes(), common.BigToHash(big.NewInt(2)).Bytes()),
+		crypto.Keccak256Hash(common.RightPadBytes([]byte("a"), 32), common.RightPadBytes([]byte("bc"), 32)),
+		crypto.Keccak256Hash(common.BigToHash(big.NewInt(3)).Bytes(), common.BytesToHash(owner.Bytes()).Bytes()),
+	}, topics)
+
+	_, _, err = PackEvent(contractABI, "TooManyTopics", big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5))
+	require.ErrorIs(t, err, ErrTooManyTopics)
+
+	_, _, err = PackEvent(contractABI, "Values", sender)
+	require.ErrorIs(t, err, errUnexpectedArgs)
+
+	_, _, err = PackEvent(contractABI, "Missing")
+	require.ErrorIs(t, err, errEventNotFound)
+
+	_, _, err = PackEvent(contractABI, "References", []*big.Int{}, [2]string{}, struct{ X *big.Int }{X: common.Big1})
+	require.ErrorIs(t, err, errMissingTupleField)
+}
+
+func TestLogGasCost(t *testing.T) {
+	gas, err := LogGasCost(3, 64)
+	require.NoError(t, err)
+	require.Equal(t, LogGas+3*LogTopicGas+64*LogDataGas, gas)
+
+	_, err = LogGasCost(1, int(^uint(0)>>1))
+	require.ErrorIs(t, err, vmerrs.ErrGasUintOverflow)
+}
+
+func TestEmitEventWithGas(t *testing.T) {
+	contractABI := ParseABI(testEventsABI)
+	addr := common.HexToAddress("0x0200000000000000000000000000000000000099")
+	sender := common.HexToAddress("0x0123")
+	topics, data, err := PackEvent(contractABI, "Values", sender, big.NewInt(7), "memo", []byte{1, 2})
+	require.NoError(t, err)
+	gas, err := LogGasCost(len(topics), len(data))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	blockContext := NewMockBlockContext(ctrl)
+	blockContext.EXPECT().Number().Return(big.NewInt(5)).AnyTimes()
+	stateDB := NewMockStateDB(ctrl)
+	stateDB.EXPECT().AddLog(addr, topics, data, uint64(5)).Times(2)
+	accessibleState := NewMockAccessibleState(ctrl)
+	accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
+	accessibleState.EXPECT().GetStateDB().Return(stateDB).AnyTimes()
+
+	remainingGas, err := EmitEventWithGas(accessibleState, addr, gas+1, contractABI, "Values", sender, big.NewInt(7), "memo", []byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), remainingGas)
+
+	// The log is not added if its gas cannot be paid for.
+	_, err = EmitEventWithGas(accessibleState, addr, gas-1, contractABI, "Values", sender, big.NewInt(7), "memo", []byte{1, 2})
+	require.ErrorIs(t, err, vmerrs.ErrOutOfGas)
+
+	require.NoError(t, EmitEvent(accessibleState, addr, contractABI, "Values", sender, big.NewInt(7), "memo", []byte{1, 2}))
+}
@@ -0,0 +1,86 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// FunctionGasCostFuncName is the name of the function that returns the declared gas cost
+	// of another function of a precompile.
+	FunctionGasCostFuncName = "functionGasCost"
+	// FunctionGasCostGasCost is the gas cost of the functionGasCost function.
+	// The gas costs are constants of the precompile, so no state is read.
+	FunctionGasCostGasCost uint64 = 100
+)
+
+// FunctionGasCostSelector is the 4 byte function selector of "functionGasCost(bytes4)".
+// Every stateful precompile created with NewStatefulPrecompileContract exposes this function
+// once Stromboli is activated, so that tooling can estimate gas without hardcoding constants.
+var FunctionGasCostSelector = CalculateFunctionSelector(FunctionGasCostFuncName + "(bytes4)")
+
+var (
+	// ErrGasCostNotDeclared is returned by functionGasCost if the queried function is not
+	// available or does not declare a fixed gas cost.
+	ErrGasCostNotDeclared = errors.New("gas cost not declared")
+
+	errInvalidFunctionGasCostInput = errors.New("invalid input for functionGasCost")
+)
+
+// newFunctionGasCostFunction returns the functionGasCost function, which returns the
+// declared gas cost of the activated function in [functions] with the given selector.
+func newFunctionGasCostFunction(functions map[string]*StatefulPrecompileFunction) *StatefulPrecompileFunction {
+	execute := func(accessibleState AccessibleState, _ common.Address, _ common.Address, input []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+		remainingGas, err := DeductGas(suppliedGas, FunctionGasCostGasCost)
+		if err != nil {
+			return nil, 0, err
+		}
+		selector, err := UnpackFunctionGasCostInput(input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		function, ok := functions[string(selector)]
+		if !ok || !function.IsActivated(accessibleState) || !function.hasGasCost {
+			return nil, remainingGas, fmt.Errorf("%w: %#x", ErrGasCostNotDeclared, selector)
+		}
+		return PackFunctionGasCostOutput(function.gasCost), remainingGas, nil
+	}
+	return NewStatefulPrecompileFunctionWithActivator(
+		FunctionGasCostSelector,
+		execute,
+		IsStromboliActivated,
+		WithStateMutability(StateMutabilityView),
+		WithGasCost(FunctionGasCostGasCost),
+	)
+}
+
+// PackFunctionGasCostInput packs [selector] as the ABI encoded bytes4 input of functionGasCost,
+// without the function selector.
+func PackFunctionGasCostInput(selector []byte) []byte {
+	input := make([]byte, common.HashLength)
+	copy(input, selector[:SelectorLen])
+	return input
+}
+
+// UnpackFunctionGasCostInput unpacks the ABI encoded bytes4 input of functionGasCost.
+func UnpackFunctionGasCostInput(input []byte) ([]byte, error) {
+	if len(input) != common.HashLength {
+		return nil, fmt.Errorf("%w: length %d", errInvalidFunctionGasCostInput, len(input))
+	}
+	// bytes4 is right padded with zeros.
+	if common.BytesToHash(input[SelectorLen:]) != (common.Hash{}) {
+		return nil, fmt.Errorf("%w: non-zero padding", errInvalidFunctionGasCostInput)
+	}
+	return input[:SelectorLen], nil
+}
+
+// PackFunctionGasCostOutput packs [gasCost] as the ABI encoded uint256 output of functionGasCost.
+func PackFunctionGasCostOutput(gasCost uint64) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(gasCost)).Bytes()
+}
@@ -28,6 +28,7 @@ type StateDB interface {
 
 	GetBalance(common.Address) *big.Int
 	AddBalance(common.Address, *big.Int)
+	SubBalance(common.Address, *big.Int)
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
@@ -49,6 +50,13 @@ type AccessibleState interface {
 	GetBlockContext() BlockContext
 	GetSnowContext() *snow.Context
 	GetChainConfig() precompileconfig.ChainConfig
+	// GetCallValue returns the value transferred to the running precompile by the call.
+	GetCallValue() *big.Int
+	// CallPrecompile runs the stateful precompile at [addr] with [caller] as the calling
+	// precompile, instead of routing the call through an EVM CALL. Re-entering a precompile
+	// taking part in the call returns an error. The calling precompile is responsible for
+	// deducting the gas used, [suppliedGas] - [remainingGas], from its own supplied gas.
+	CallPrecompile(caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error)
 }
 
 // ConfigurationBlockContext defines the interface required to configure a precompile.
@@ -62,6 +70,9 @@ type BlockContext interface {
 	// GetResults returns an arbitrary byte array result of verifying the predicates
 	// of the given transaction, precompile address pair.
 	GetPredicateResults(txHash common.Hash, precompileAddress common.Address) []byte
+	// GetBlockHash returns the hash of the ancestor block at [number], or the
+	// empty hash if it is not available.
+	GetBlockHash(number uint64) common.Hash
 }
 
 type Configurator interface {
@@ -42,6 +42,20 @@ func (m *MockBlockContext) EXPECT() *MockBlockContextMockRecorder {
 	return m.recorder
 }
 
+// GetBlockHash mocks base method.
+func (m *MockBlockContext) GetBlockHash(arg0 uint64) common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHash", arg0)
+	ret0, _ := ret[0].(common.Hash)
+	return ret0
+}
+
+// GetBlockHash indicates an expected call of GetBlockHash.
+func (mr *MockBlockContextMockRecorder) GetBlockHash(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHash", reflect.TypeOf((*MockBlockContext)(nil).GetBlockHash), arg0)
+}
+
 // GetPredicateResults mocks base method.
 func (m *MockBlockContext) GetPredicateResults(arg0 common.Hash, arg1 common.Address) []byte {
 	m.ctrl.T.Helper()
@@ -107,6 +121,22 @@ func (m *MockAccessibleState) EXPECT() *MockAccessibleStateMockRecorder {
 	return m.recorder
 }
 
+// CallPrecompile mocks base method.
+func (m *MockAccessibleState) CallPrecompile(arg0, arg1 common.Address, arg2 []byte, arg3 uint64, arg4 bool) ([]byte, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallPrecompile", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CallPrecompile indicates an expected call of CallPrecompile.
+func (mr *MockAccessibleStateMockRecorder) CallPrecompile(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallPrecompile", reflect.TypeOf((*MockAccessibleState)(nil).CallPrecompile), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetBlockContext mocks base method.
 func (m *MockAccessibleState) GetBlockContext() BlockContext {
 	m.ctrl.T.Helper()
@@ -135,6 +165,20 @@ func (mr *MockAccessibleStateMockRecorder) GetChainConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainConfig", reflect.TypeOf((*MockAccessibleState)(nil).GetChainConfig))
 }
 
+// GetCallValue mocks base method.
+func (m *MockAccessibleState) GetCallValue() *big.Int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCallValue")
+	ret0, _ := ret[0].(*big.Int)
+	return ret0
+}
+
+// GetCallValue indicates an expected call of GetCallValue.
+func (mr *MockAccessibleStateMockRecorder) GetCallValue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallValue", reflect.TypeOf((*MockAccessibleState)(nil).GetCallValue))
+}
+
 // GetSnowContext mocks base method.
 func (m *MockAccessibleState) GetSnowContext() *snow.Context {
 	m.ctrl.T.Helper()
@@ -383,3 +427,15 @@ func (mr *MockStateDBMockRecorder) Snapshot() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockStateDB)(nil).Snapshot))
 }
+
+// SubBalance mocks base method.
+func (m *MockStateDB) SubBalance(arg0 common.Address, arg1 *big.Int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubBalance", arg0, arg1)
+}
+
+// SubBalance indicates an expected call of SubBalance.
+func (mr *MockStateDBMockRecorder) SubBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubBalance", reflect.TypeOf((*MockStateDB)(nil).SubBalance), arg0, arg1)
+}
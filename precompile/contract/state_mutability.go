@@ -0,0 +1,79 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateMutability is the state mutability of a precompile function, as declared
+// by the stateMutability field of its ABI.
+type StateMutability string
+
+const (
+	StateMutabilityPure       StateMutability = "pure"
+	StateMutabilityView       StateMutability = "view"
+	StateMutabilityNonPayable StateMutability = "nonpayable"
+	StateMutabilityPayable    StateMutability = "payable"
+)
+
+// valid returns true if [m] is a known state mutability, or empty if the
+// state mutability is not declared.
+func (m StateMutability) valid() bool {
+	switch m {
+	case "", StateMutabilityPure, StateMutabilityView, StateMutabilityNonPayable, StateMutabilityPayable:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReadOnly returns true if a function with this state mutability does not modify state.
+func (m StateMutability) IsReadOnly() bool {
+	return m == StateMutabilityPure || m == StateMutabilityView
+}
+
+// readOnlyAccessibleState exposes a StateDB that drops every modification.
+type readOnlyAccessibleState struct {
+	AccessibleState
+	stateDB *readOnlyStateDB
+}
+
+func newReadOnlyAccessibleState(accessibleState AccessibleState) *readOnlyAccessibleState {
+	return &readOnlyAccessibleState{AccessibleState: accessibleState}
+}
+
+func (s *readOnlyAccessibleState) GetStateDB() StateDB {
+	if s.stateDB == nil {
+		s.stateDB = &readOnlyStateDB{StateDB: s.AccessibleState.GetStateDB()}
+	}
+	return s.stateDB
+}
+
+// modified returns true if a state modification was attempted.
+func (s *readOnlyAccessibleState) modified() bool {
+	return s.stateDB != nil && s.stateDB.modified
+}
+
+// readOnlyStateDB wraps a StateDB, dropping every modification and recording
+// that one was attempted.
+type readOnlyStateDB struct {
+	StateDB
+	modified bool
+}
+
+func (s *readOnlyStateDB) SetState(common.Address, common.Hash, common.Hash) { s.modified = true }
+func (s *readOnlyStateDB) SetNonce(common.Address, uint64)                   { s.modified = true }
+func (s *readOnlyStateDB) AddBalance(common.Address, *big.Int)               { s.modified = true }
+func (s *readOnlyStateDB) SubBalance(common.Address, *big.Int)               { s.modified = true }
+func (s *readOnlyStateDB) CreateAccount(common.Address)                      { s.modified = true }
+func (s *readOnlyStateDB) AddLog(common.Address, []common.Hash, []byte, uint64) {
+	s.modified = true
+}
+
+func (s *readOnlyStateDB) SetPredicateStorageSlots(common.Address, [][]byte) {
+	s.modified = true
+}
@@ -62,3 +62,7 @@ func ParseABI(rawABI string) abi.ABI {
 func IsDurangoActivated(evm AccessibleState) bool {
 	return evm.GetChainConfig().IsDurango(evm.GetBlockContext().Timestamp())
 }
+
+func IsStromboliActivated(evm AccessibleState) bool {
+	return evm.GetChainConfig().IsStromboli(evm.GetBlockContext().Timestamp())
+}
@@ -0,0 +1,48 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// PrecompileVersionFuncName is the name of the function that returns the interface version of a precompile.
+	PrecompileVersionFuncName = "precompileVersion"
+	// PrecompileVersionGasCost is the gas cost of the precompileVersion function.
+	// The version is a constant of the precompile, so no state is read.
+	PrecompileVersionGasCost uint64 = 100
+)
+
+// PrecompileVersionSelector is the 4 byte function selector of "precompileVersion()".
+// Every stateful precompile registered in subnet-evm exposes this function once
+// Stromboli is activated, so that contracts can feature-detect the installed interface.
+var PrecompileVersionSelector = CalculateFunctionSelector(PrecompileVersionFuncName + "()")
+
+// NewPrecompileVersionFunction returns the precompileVersion function that returns [version]
+// ABI encoded as a uint256. The function is only activated after Stromboli.
+func NewPrecompileVersionFunction(version uint64) *StatefulPrecompileFunction {
+	packedVersion := PackPrecompileVersionOutput(version)
+	execute := func(_ AccessibleState, _ common.Address, _ common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+		remainingGas, err := DeductGas(suppliedGas, PrecompileVersionGasCost)
+		if err != nil {
+			return nil, 0, err
+		}
+		return common.CopyBytes(packedVersion), remainingGas, nil
+	}
+	return NewStatefulPrecompileFunctionWithActivator(
+		PrecompileVersionSelector,
+		execute,
+		IsStromboliActivated,
+		WithStateMutability(StateMutabilityView),
+		WithGasCost(PrecompileVersionGasCost),
+	)
+}
+
+// PackPrecompileVersionOutput packs [version] as the ABI encoded uint256 output of precompileVersion.
+func PackPrecompileVersionOutput(version uint64) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(version)).Bytes()
+}
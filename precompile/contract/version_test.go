@@ -0,0 +1,66 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPrecompileVersionFunction(t *testing.T) {
+	for name, test := range map[string]struct {
+		isStromboli bool
+		suppliedGas uint64
+		expectedRes []byte
+		expectedGas uint64
+		expectedErr string
+	}{
+		"returns version": {
+			isStromboli: true,
+			suppliedGas: PrecompileVersionGasCost + 1,
+			expectedRes: common.BigToHash(big.NewInt(3)).Bytes(),
+			expectedGas: 1,
+		},
+		"out of gas": {
+			isStromboli: true,
+			suppliedGas: PrecompileVersionGasCost - 1,
+			expectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"not activated before Stromboli": {
+			isStromboli: false,
+			suppliedGas: PrecompileVersionGasCost,
+			expectedGas: PrecompileVersionGasCost,
+			expectedErr: "invalid non-activated function selector",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			chainConfig := precompileconfig.NewMockChainConfig(ctrl)
+			chainConfig.EXPECT().IsStromboli(gomock.Any()).Return(test.isStromboli).AnyTimes()
+			blockContext := NewMockBlockContext(ctrl)
+			blockContext.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			accessibleState := NewMockAccessibleState(ctrl)
+			accessibleState.EXPECT().GetChainConfig().Return(chainConfig).AnyTimes()
+			accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
+
+			precompile, err := NewStatefulPrecompileContract(nil, []*StatefulPrecompileFunction{NewPrecompileVersionFunction(3)})
+			require.NoError(t, err)
+
+			res, remainingGas, err := precompile.Run(accessibleState, common.Address{}, common.Address{}, PrecompileVersionSelector, test.suppliedGas, true)
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expectedRes, res)
+			require.Equal(t, test.expectedGas, remainingGas)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blockhashhistory
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// HistoryWindow is the number of most recent block hashes served by the precompile,
+	// as in EIP-2935. The hashes are stored in a ring buffer of this size.
+	HistoryWindow uint64 = 8192
+
+	GetBlockHashGasCost uint64 = contract.ReadGasCostPerSlot
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// BlockHashHistoryRawABI contains the raw ABI of BlockHashHistory contract.
+	//go:embed contract.abi
+	BlockHashHistoryRawABI string
+
+	BlockHashHistoryABI = contract.ParseABI(BlockHashHistoryRawABI)
+
+	BlockHashHistoryPrecompile = createBlockHashHistoryPrecompile()
+)
+
+// HistoryStorageKey returns the storage key of the ring buffer slot of [number].
+func HistoryStorageKey(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(number % HistoryWindow))
+}
+
+// StoreParentHash stores [parentHash] as the hash of the block preceding [number].
+// It is called before the transactions of each block are executed while the
+// precompile is enabled.
+func StoreParentHash(stateDB contract.StateDB, number uint64, parentHash common.Hash) {
+	if number == 0 {
+		return
+	}
+	stateDB.SetState(ContractAddress, HistoryStorageKey(number-1), parentHash)
+}
+
+// GetBlockHash returns the hash of the block at [number] as seen from the block at
+// [currentNumber], or the empty hash if [number] is not within the [HistoryWindow]
+// blocks preceding [currentNumber] or was produced before the precompile was activated.
+func GetBlockHash(stateDB contract.StateDB, currentNumber uint64, number uint64) common.Hash {
+	if number >= currentNumber || currentNumber-number > HistoryWindow {
+		return common.Hash{}
+	}
+	return stateDB.GetState(ContractAddress, HistoryStorageKey(number))
+}
+
+// PackGetBlockHash packs [blockNumber] into the appropriate arguments for getBlockHash.
+func PackGetBlockHash(blockNumber *big.Int) ([]byte, error) {
+	return BlockHashHistoryABI.Pack("getBlockHash", blockNumber)
+}
+
+// UnpackGetBlockHashInput attempts to unpack [input] into the blockNumber argument of getBlockHash.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetBlockHashInput(input []byte) (*big.Int, error) {
+	res, err := BlockHashHistoryABI.UnpackInput("getBlockHash", input, false)
+	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+	return unpacked, nil
+}
+
+// PackGetBlockHashOutput attempts to pack given [blockHash] of type common.Hash
+// to conform the ABI outputs.
+func PackGetBlockHashOutput(blockHash common.Hash) ([]byte, error) {
+	return BlockHashHistoryABI.PackOutput("getBlockHash", blockHash)
+}
+
+// UnpackGetBlockHashOutput attempts to unpack [output] as the block hash returned by getBlockHash.
+func UnpackGetBlockHashOutput(output []byte) (common.Hash, error) {
+	res, err := BlockHashHistoryABI.Unpack("getBlockHash", output)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new([32]byte)).(*[32]byte)
+	return unpacked, nil
+}
+
+// getBlockHash returns the hash of the requested block, or the empty hash if it is
+// not available, like the BLOCKHASH opcode over the last [HistoryWindow] blocks.
+func getBlockHash(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetBlockHashGasCost); err != nil {
+		return nil, 0, err
+	}
+	blockNumber, err := UnpackGetBlockHashInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	var blockHash common.Hash
+	if blockNumber.IsUint64() {
+		currentNumber := accessibleState.GetBlockContext().Number().Uint64()
+		blockHash = GetBlockHash(accessibleState.GetStateDB(), currentNumber, blockNumber.Uint64())
+	}
+	packedOutput, err := PackGetBlockHashOutput(blockHash)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createBlockHashHistoryPrecompile returns a StatefulPrecompiledContract with getBlockHash
+// enabled from Stromboli onward.
+func createBlockHashHistoryPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	stromboliFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getBlockHash": getBlockHash,
+	}
+	for name, function := range stromboliFunctionMap {
+		method, ok := BlockHashHistoryABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated, contract.WithStateMutability(contract.StateMutability(method.StateMutability)), contract.WithGasCost(GetBlockHashGasCost)))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
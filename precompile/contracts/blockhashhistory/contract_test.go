@@ -0,0 +1,181 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blockhashhistory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	callerAddr   = common.HexToAddress("0x0123")
+	currentBlock = HistoryWindow + 100
+)
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
+// testHash returns the hash stored in the history for block [number] in the tests.
+func testHash(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(number + 1))
+}
+
+// storeHistory stores the hashes of the [HistoryWindow] blocks preceding [currentBlock].
+func storeHistory(t testing.TB, stateDB contract.StateDB) {
+	for number := currentBlock - HistoryWindow + 1; number <= currentBlock; number++ {
+		StoreParentHash(stateDB, number, testHash(number-1))
+	}
+}
+
+func setupBlock(mbc *contract.MockBlockContext) {
+	mbc.EXPECT().Number().Return(new(big.Int).SetUint64(currentBlock)).AnyTimes()
+	mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+}
+
+func getBlockHashInput(number *big.Int) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := PackGetBlockHash(number)
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func getBlockHashOutput(t testing.TB, hash common.Hash) []byte {
+	output, err := PackGetBlockHashOutput(hash)
+	require.NoError(t, err)
+	return output
+}
+
+func TestPackUnpackGetBlockHash(t *testing.T) {
+	require := require.New(t)
+	input, err := PackGetBlockHash(big.NewInt(42))
+	require.NoError(err)
+	number, err := UnpackGetBlockHashInput(input[4:])
+	require.NoError(err)
+	require.Equal(big.NewInt(42), number)
+
+	output, err := PackGetBlockHashOutput(testHash(42))
+	require.NoError(err)
+	hash, err := UnpackGetBlockHashOutput(output)
+	require.NoError(err)
+	require.Equal(testHash(42), hash)
+}
+
+func TestGetBlockHash(t *testing.T) {
+	tests := map[string]testutils.PrecompileTest{
+		"getBlockHash before Stromboli": {
+			Caller:      callerAddr,
+			InputFn:     getBlockHashInput(big.NewInt(1)),
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"parent block": {
+			Caller:            callerAddr,
+			BeforeHook:        storeHistory,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock - 1)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ExpectedRes:       getBlockHashOutput(t, testHash(currentBlock-1)),
+		},
+		"oldest block in window": {
+			Caller:            callerAddr,
+			BeforeHook:        storeHistory,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock - HistoryWindow)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ExpectedRes:       getBlockHashOutput(t, testHash(currentBlock-HistoryWindow)),
+		},
+		"block older than window": {
+			Caller:            callerAddr,
+			BeforeHook:        storeHistory,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock - HistoryWindow - 1)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ExpectedRes:       getBlockHashOutput(t, common.Hash{}),
+		},
+		"current block": {
+			Caller:            callerAddr,
+			BeforeHook:        storeHistory,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ExpectedRes:       getBlockHashOutput(t, common.Hash{}),
+		},
+		"block number overflows uint64": {
+			Caller:            callerAddr,
+			BeforeHook:        storeHistory,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).Lsh(common.Big1, 64)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ExpectedRes:       getBlockHashOutput(t, common.Hash{}),
+		},
+		"block before activation": {
+			Caller:            callerAddr,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock - 1)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ExpectedRes:       getBlockHashOutput(t, common.Hash{}),
+		},
+		"readOnly getBlockHash": {
+			Caller:            callerAddr,
+			BeforeHook:        storeHistory,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock - 1)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost,
+			ReadOnly:          true,
+			ExpectedRes:       getBlockHashOutput(t, testHash(currentBlock-1)),
+		},
+		"insufficient gas": {
+			Caller:            callerAddr,
+			SetupBlockContext: setupBlock,
+			InputFn:           getBlockHashInput(new(big.Int).SetUint64(currentBlock - 1)),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetBlockHashGasCost - 1,
+			ExpectedErr:       "out of gas",
+		},
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestStoreParentHashRingBuffer(t *testing.T) {
+	require := require.New(t)
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+
+	// The parent hash of the genesis block is not stored
+	StoreParentHash(statedb, 0, testHash(0))
+	require.False(statedb.Exist(ContractAddress))
+
+	StoreParentHash(statedb, 1, testHash(0))
+	StoreParentHash(statedb, HistoryWindow+1, testHash(HistoryWindow))
+	// Block HistoryWindow overwrote the slot of block 0, which is out of the window
+	require.Equal(testHash(HistoryWindow), GetBlockHash(statedb, HistoryWindow+1, HistoryWindow))
+	require.Equal(common.Hash{}, GetBlockHash(statedb, HistoryWindow+1, 0))
+	require.Equal(testHash(HistoryWindow), GetBlockHash(statedb, 2*HistoryWindow, HistoryWindow))
+	require.Equal(common.Hash{}, GetBlockHash(statedb, 2*HistoryWindow+1, HistoryWindow))
+}
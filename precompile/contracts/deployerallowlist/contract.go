@@ -10,7 +10,7 @@ import (
 )
 
 // Singleton StatefulPrecompiledContract for W/R access to the contract deployer allow list.
-var ContractDeployerAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
+var ContractDeployerAllowListPrecompile contract.StatefulPrecompiledContract = createContractDeployerAllowListPrecompile()
 
 // GetContractDeployerAllowListStatus returns the role of [address] for the contract deployer
 // allow list.
@@ -24,3 +24,17 @@ func GetContractDeployerAllowListStatus(stateDB contract.StateDB, address common
 func SetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
 	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
 }
+
+// createContractDeployerAllowListPrecompile returns a StatefulPrecompiledContract with the allow list functions
+// and the precompileVersion function.
+func createContractDeployerAllowListPrecompile() contract.StatefulPrecompiledContract {
+	functions := allowlist.CreateAllowListFunctions(ContractAddress)
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
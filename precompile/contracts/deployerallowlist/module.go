@@ -6,6 +6,7 @@ package deployerallowlist
 import (
 	"fmt"
 
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -18,13 +19,18 @@ var _ contract.Configurator = &configurator{}
 // must be unique across all precompiles.
 const ConfigKey = "contractDeployerAllowListConfig"
 
+// Version is the interface version of the precompile returned by precompileVersion.
+const Version = 2
+
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000000")
 
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
+	Version:      Version,
 	Contract:     ContractDeployerAllowListPrecompile,
 	Configurator: &configurator{},
+	ABI:          &allowlist.AllowListABI,
 }
 
 type configurator struct{}
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -38,6 +39,11 @@ const (
 	SetFeeConfigGasCost     uint64 = contract.WriteGasCostPerSlot * (numFeeConfigField + 1) // plus one for setting last changed at
 	GetFeeConfigGasCost     uint64 = contract.ReadGasCostPerSlot * numFeeConfigField
 	GetLastChangedAtGasCost uint64 = contract.ReadGasCostPerSlot
+	SetFeeDiscountGasCost   uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	GetFeeDiscountGasCost   uint64 = contract.ReadGasCostPerSlot
+
+	// MaxFeeDiscount is the discount percentage that fully exempts an account from the base fee.
+	MaxFeeDiscount = 100
 )
 
 var (
@@ -46,9 +52,12 @@ var (
 	FeeManagerPrecompile contract.StatefulPrecompiledContract = createFeeManagerPrecompile()
 
 	feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
+	feeDiscountPrefix         = []byte("feeDiscount")
 
-	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
-	ErrInvalidLen      = errors.New("invalid input length for fee config Input")
+	ErrCannotChangeFee         = errors.New("non-enabled cannot change fee config")
+	ErrInvalidLen              = errors.New("invalid input length for fee config Input")
+	ErrCannotChangeFeeDiscount = errors.New("non-enabled cannot change fee discount")
+	ErrInvalidFeeDiscount      = errors.New("invalid fee discount")
 
 	// IFeeManagerRawABI contains the raw ABI of FeeManager contract.
 	//go:embed contract.abi
@@ -82,9 +91,44 @@ func SetFeeManagerStatus(stateDB contract.StateDB, address common.Address, role
 
 // GetStoredFeeConfig returns fee config from contract storage in given state
 func GetStoredFeeConfig(stateDB contract.StateDB) commontype.FeeConfig {
+	keys := FeeConfigStorageKeys()
+	values := make([]common.Hash, len(keys))
+	for i, key := range keys {
+		values[i] = stateDB.GetState(ContractAddress, key)
+	}
+	feeConfig, err := UnpackFeeConfigStorage(values)
+	if err != nil {
+		// This should never happen since a value is read for each key
+		panic(err)
+	}
+	return feeConfig
+}
+
+// FeeConfigStorageKeys returns the storage keys of the fields of the stored fee
+// config, in the order expected by UnpackFeeConfigStorage.
+func FeeConfigStorageKeys() []common.Hash {
+	keys := make([]common.Hash, 0, numFeeConfigField)
+	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
+		keys = append(keys, common.Hash{byte(i)})
+	}
+	return keys
+}
+
+// FeeConfigLastChangedAtStorageKey returns the storage key of the block number
+// at which the fee config was last changed.
+func FeeConfigLastChangedAtStorageKey() common.Hash {
+	return feeConfigLastChangedAtKey
+}
+
+// UnpackFeeConfigStorage returns the fee config stored in [values], the values
+// of the keys returned by FeeConfigStorageKeys.
+func UnpackFeeConfigStorage(values []common.Hash) (commontype.FeeConfig, error) {
+	if len(values) != numFeeConfigField {
+		return commontype.FeeConfig{}, fmt.Errorf("expected %d fee config values, got %d", numFeeConfigField, len(values))
+	}
 	feeConfig := commontype.FeeConfig{}
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
-		val := stateDB.GetState(ContractAddress, common.Hash{byte(i)})
+		val := values[i-minFeeConfigFieldKey]
 		switch i {
 		case gasLimitKey:
 			feeConfig.GasLimit = new(big.Int).Set(val.Big())
@@ -107,7 +151,7 @@ func GetStoredFeeConfig(stateDB contract.StateDB) commontype.FeeConfig {
 			panic(fmt.Sprintf("unknown fee config key: %d", i))
 		}
 	}
-	return feeConfig
+	return feeConfig, nil
 }
 
 func GetFeeConfigLastChangedAt(stateDB contract.StateDB) *big.Int {
@@ -371,6 +415,118 @@ func getFeeConfigLastChangedAt(accessibleState contract.AccessibleState, caller
 	return packedOutput, remainingGas, err
 }
 
+// FeeDiscountStorageKey returns the storage key of the fee discount of [address].
+// The key is hashed so it cannot collide with the allow list role slots.
+func FeeDiscountStorageKey(address common.Address) common.Hash {
+	return crypto.Keccak256Hash(feeDiscountPrefix, address.Bytes())
+}
+
+// GetFeeDiscount returns the base fee discount percentage granted to [address],
+// both to the transactions it sends and to the transactions calling it directly.
+func GetFeeDiscount(stateDB contract.StateDB, address common.Address) uint64 {
+	return stateDB.GetState(ContractAddress, FeeDiscountStorageKey(address)).Big().Uint64()
+}
+
+// SetFeeDiscount sets the base fee discount percentage granted to [address].
+// assumes [discount] is at most [MaxFeeDiscount].
+func SetFeeDiscount(stateDB contract.StateDB, address common.Address, discount uint64) {
+	stateDB.SetState(ContractAddress, FeeDiscountStorageKey(address), common.BigToHash(new(big.Int).SetUint64(discount)))
+}
+
+// PackSetFeeDiscount packs [account] and [discount] into the appropriate arguments for setFeeDiscount.
+func PackSetFeeDiscount(account common.Address, discount uint64) ([]byte, error) {
+	return FeeManagerABI.Pack("setFeeDiscount", account, new(big.Int).SetUint64(discount))
+}
+
+// UnpackSetFeeDiscountInput attempts to unpack [input] into the account and discount arguments
+// of setFeeDiscount. The discount must be at most [MaxFeeDiscount].
+func UnpackSetFeeDiscountInput(input []byte) (common.Address, uint64, error) {
+	inputStruct := struct {
+		Account  common.Address
+		Discount *big.Int
+	}{}
+	if err := FeeManagerABI.UnpackInputIntoInterface(&inputStruct, "setFeeDiscount", input, false); err != nil {
+		return common.Address{}, 0, err
+	}
+	if !inputStruct.Discount.IsUint64() || inputStruct.Discount.Uint64() > MaxFeeDiscount {
+		return common.Address{}, 0, fmt.Errorf("%w: %s", ErrInvalidFeeDiscount, inputStruct.Discount)
+	}
+	return inputStruct.Account, inputStruct.Discount.Uint64(), nil
+}
+
+// setFeeDiscount checks if the caller has permissions to set fee discounts and sets
+// the base fee discount percentage of the account in [input].
+func setFeeDiscount(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeDiscountGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	account, discount, err := UnpackSetFeeDiscountInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := GetFeeManagerStatus(stateDB, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFeeDiscount, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, FeeDiscountChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackFeeDiscountChangedEvent(caller, account, GetFeeDiscount(stateDB, account), discount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	SetFeeDiscount(stateDB, account, discount)
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetFeeDiscount packs [account] into the appropriate arguments for getFeeDiscount.
+func PackGetFeeDiscount(account common.Address) ([]byte, error) {
+	return FeeManagerABI.Pack("getFeeDiscount", account)
+}
+
+// PackGetFeeDiscountOutput attempts to pack given [discount] of type uint64
+// to conform the ABI outputs.
+func PackGetFeeDiscountOutput(discount uint64) ([]byte, error) {
+	return FeeManagerABI.PackOutput("getFeeDiscount", new(big.Int).SetUint64(discount))
+}
+
+// getFeeDiscount returns the base fee discount percentage of the account in [input].
+func getFeeDiscount(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeDiscountGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	res, err := FeeManagerABI.UnpackInput("getFeeDiscount", input, false)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	account := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+
+	packedOutput, err := PackGetFeeDiscountOutput(GetFeeDiscount(accessibleState.GetStateDB(), account))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
 // createFeeManagerPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for ContractAddress.
 func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
@@ -388,8 +544,23 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 		if !ok {
 			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
 		}
-		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
+	}
+
+	// Fee discounts are only available after Stromboli.
+	stromboliFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getFeeDiscount": getFeeDiscount,
+		"setFeeDiscount": setFeeDiscount,
+	}
+	for name, function := range stromboliFunctionMap {
+		method, ok := FeeManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
 	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
@@ -210,6 +210,7 @@ var (
 			}(),
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().Number().Return(testBlockNumber)
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
 			},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
 				feeConfig := GetStoredFeeConfig(state)
@@ -314,6 +315,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			SuppliedGas: SetFeeConfigGasCost,
@@ -337,6 +339,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			SuppliedGas: SetFeeConfigGasCost + FeeConfigChangedEventGasCost,
@@ -364,6 +367,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			SuppliedGas: SetFeeConfigGasCost,
@@ -381,6 +385,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			SuppliedGas: SetFeeConfigGasCost + FeeConfigChangedEventGasCost,
@@ -406,6 +411,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -422,9 +428,116 @@ var (
 				require.Len(t, logsData, 0)
 			},
 		},
+		"set fee discount before Stromboli": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeDiscount(testDiscountAddr, 50)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"set fee discount from no role fails": {
+			Caller:        allowlist.TestNoRoleAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeDiscount(testDiscountAddr, 50)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetFeeDiscountGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotChangeFeeDiscount.Error(),
+		},
+		"set fee discount from enabled address": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeDiscount(testDiscountAddr, 50)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetFeeDiscountGasCost + FeeDiscountChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.EqualValues(t, 50, GetFeeDiscount(state, testDiscountAddr))
+
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Len(t, logsData, 1)
+				topics, data, err := PackFeeDiscountChangedEvent(allowlist.TestEnabledAddr, testDiscountAddr, 0, 50)
+				require.NoError(t, err)
+				require.Equal(t, topics, logsTopics[0])
+				require.Equal(t, data, logsData[0])
+			},
+		},
+		"set fee discount above max fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeDiscount(testDiscountAddr, MaxFeeDiscount+1)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetFeeDiscountGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidFeeDiscount.Error(),
+		},
+		"readOnly set fee discount fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeDiscount(testDiscountAddr, 50)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetFeeDiscountGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"get fee discount": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetFeeDiscount(state, testDiscountAddr, 25)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetFeeDiscount(testDiscountAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: GetFeeDiscountGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetFeeDiscountOutput(25)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
 	}
 )
 
+var testDiscountAddr = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
 func TestFeeManager(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
@@ -78,3 +78,14 @@ func convertToCommonConfig(config changeFeeConfigEventData) commontype.FeeConfig
 		BlockGasCostStep:         config.BlockGasCostStep,
 	}
 }
+
+// FeeDiscountChangedEventGasCost is the gas cost of a FeeDiscountChanged event.
+// It is the base gas cost + the gas cost of the topics (signature, sender, account)
+// and the gas cost of the non-indexed data (oldDiscount, newDiscount) + the gas cost of reading the old discount.
+const FeeDiscountChangedEventGasCost = contract.ReadGasCostPerSlot + contract.LogGas + contract.LogTopicGas*3 + 2*common.HashLength*contract.LogDataGas
+
+// PackFeeDiscountChangedEvent packs the event into the appropriate arguments for FeeDiscountChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackFeeDiscountChangedEvent(sender common.Address, account common.Address, oldDiscount uint64, newDiscount uint64) ([]common.Hash, []byte, error) {
+	return FeeManagerABI.PackEvent("FeeDiscountChanged", sender, account, new(big.Int).SetUint64(oldDiscount), new(big.Int).SetUint64(newDiscount))
+}
@@ -0,0 +1,101 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"errors"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+var errGovernanceCannotBeActivated = errors.New("governance cannot be activated before Stromboli")
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for Governance.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	// Threshold is the number of approvals of members of the allow list required to
+	// execute a proposal. If 0, a single approval is required.
+	Threshold uint64 `json:"threshold,omitempty"`
+	// VotingPeriod is the number of seconds a proposal can be approved and executed
+	// after its creation. If 0, [DefaultVotingPeriod] is used.
+	VotingPeriod uint64 `json:"votingPeriod,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// Governance with the given [admins], [enableds] and [managers] as members of the allowlist,
+// [threshold] approvals required to execute a proposal and proposals open for [votingPeriod] seconds.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, threshold uint64, votingPeriod uint64) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:      precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		Threshold:    threshold,
+		VotingPeriod: votingPeriod,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables Governance.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the Governance precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// GetThreshold returns the number of approvals required to execute a proposal.
+func (c *Config) GetThreshold() uint64 {
+	if c.Threshold == 0 {
+		return 1
+	}
+	return c.Threshold
+}
+
+// GetVotingPeriod returns the voting period of proposals in seconds.
+func (c *Config) GetVotingPeriod() uint64 {
+	if c.VotingPeriod == 0 {
+		return DefaultVotingPeriod
+	}
+	return c.VotingPeriod
+}
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
+		return err
+	}
+	if c.Timestamp() != nil && !chainConfig.IsStromboli(*c.Timestamp()) {
+		return errGovernanceCannotBeActivated
+	}
+	return nil
+}
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) &&
+		c.AllowListConfig.Equal(&other.AllowListConfig) &&
+		c.Threshold == other.Threshold &&
+		c.VotingPeriod == other.VotingPeriod
+}
@@ -0,0 +1,788 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// DefaultVotingPeriod is the number of seconds a proposal can be approved and executed
+	// after its creation if [Config.VotingPeriod] is not set.
+	DefaultVotingPeriod uint64 = 7 * 24 * 60 * 60
+
+	// MaxProposalDataSize is the maximum size of the call data or the upgrade of a proposal.
+	MaxProposalDataSize = 4096
+
+	// ProposeGasCost is the base gas cost of propose: read allow list + read voting period and count,
+	// write count, proposer, target, deadline, approvals, data length and approval of the proposer.
+	// ProposeGasCostPerWord is charged in addition for each 32 byte word of the data.
+	ProposeGasCost        uint64 = allowlist.ReadAllowListGasCost + 2*contract.ReadGasCostPerSlot + 7*contract.WriteGasCostPerSlot + ProposalCreatedEventGasCost + ProposalApprovedEventGasCost
+	ProposeGasCostPerWord uint64 = contract.WriteGasCostPerSlot + ProposalCreatedEventGasCostPerWord
+	// ProposeUpgradeGasCost is the base gas cost of proposeUpgrade, which also reads the last
+	// upgrade scheduled for the upgraded precompile. ProposeGasCostPerWord is charged in addition
+	// for each 32 byte word of the upgrade.
+	ProposeUpgradeGasCost uint64 = ProposeGasCost + contract.ReadGasCostPerSlot
+	// ApproveGasCost reads the allow list, the proposal and the approval of the caller,
+	// and writes the approval and the approvals of the proposal.
+	ApproveGasCost uint64 = allowlist.ReadAllowListGasCost + 6*contract.ReadGasCostPerSlot + 2*contract.WriteGasCostPerSlot + ProposalApprovedEventGasCost
+	// ExecuteGasCost is the base gas cost of execute: read allow list, the proposal, its data length
+	// and the threshold and write the executed flag. ExecuteGasCostPerWord is charged in addition for
+	// each 32 byte word of the data. The gas used by the call of the proposal is charged as well.
+	ExecuteGasCost        uint64 = allowlist.ReadAllowListGasCost + 7*contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + ProposalExecutedEventGasCost
+	ExecuteGasCostPerWord uint64 = contract.ReadGasCostPerSlot
+	// ScheduleUpgradeGasCost is charged in addition to ExecuteGasCost to schedule an upgrade: read the
+	// last upgrade scheduled for the upgraded precompile and the scheduled upgrades count, and write them
+	// along with the new scheduled upgrade.
+	ScheduleUpgradeGasCost uint64 = 2*contract.ReadGasCostPerSlot + 3*contract.WriteGasCostPerSlot
+
+	GetProposalGasCost        uint64 = 6 * contract.ReadGasCostPerSlot // read the proposal and its data length
+	GetProposalGasCostPerWord uint64 = contract.ReadGasCostPerSlot
+	HasApprovedGasCost        uint64 = contract.ReadGasCostPerSlot
+	ProposalCountGasCost      uint64 = contract.ReadGasCostPerSlot
+	ThresholdGasCost          uint64 = contract.ReadGasCostPerSlot
+	VotingPeriodGasCost       uint64 = contract.ReadGasCostPerSlot
+	// ScheduledUpgradesGasCost is the base gas cost of scheduledUpgrades and
+	// ScheduledUpgradesGasCostPerUpgrade is charged in addition for each scheduled upgrade.
+	ScheduledUpgradesGasCost           uint64 = contract.ReadGasCostPerSlot
+	ScheduledUpgradesGasCostPerUpgrade uint64 = contract.ReadGasCostPerSlot
+
+	// MaxScheduledUpgrades is the maximum number of scheduled upgrades. Since the activated upgrades
+	// are pruned once superseded, at most one activated upgrade per precompile counts towards it.
+	MaxScheduledUpgrades uint64 = 64
+)
+
+// Fields of a proposal stored under [ProposalStorageKey].
+const (
+	proposerField byte = iota
+	targetField
+	deadlineField
+	approvalsField
+	executedField
+	dataLengthField
+)
+
+var (
+	ErrCannotPropose        = errors.New("non-enabled cannot call propose")
+	ErrCannotApprove        = errors.New("non-enabled cannot call approve")
+	ErrCannotExecute        = errors.New("non-enabled cannot call execute")
+	ErrUnknownProposal      = errors.New("unknown proposal")
+	ErrProposalExpired      = errors.New("proposal expired")
+	ErrProposalExecuted     = errors.New("proposal already executed")
+	ErrAlreadyApproved      = errors.New("proposal already approved")
+	ErrNotEnoughApprovals   = errors.New("not enough approvals")
+	ErrInvalidProposal      = errors.New("invalid proposal")
+	ErrInvalidUpgrade       = errors.New("invalid upgrade")
+	ErrProposalDataTooLarge = errors.New("proposal data too large")
+
+	ErrTooManyScheduledUpgrades = errors.New("too many scheduled upgrades")
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// GovernanceRawABI contains the raw ABI of Governance contract.
+	//go:embed contract.abi
+	GovernanceRawABI string
+
+	GovernanceABI = contract.ParseABI(GovernanceRawABI)
+
+	GovernancePrecompile = createGovernancePrecompile()
+
+	thresholdStorageKey         = common.Hash{'t', 's', 'k'}
+	votingPeriodStorageKey      = common.Hash{'v', 'p', 's', 'k'}
+	proposalCountStorageKey     = common.Hash{'p', 'c', 's', 'k'}
+	scheduledCountStorageKey    = common.Hash{'s', 'c', 's', 'k'}
+	proposalPrefix              = []byte("proposal")
+	proposalDataPrefix          = []byte("proposalData")
+	approvalPrefix              = []byte("approval")
+	scheduledUpgradePrefix      = []byte("scheduledUpgrade")
+	lastScheduledUpgradesPrefix = []byte("lastScheduledUpgrade")
+)
+
+// Proposal is a call of [Target] with [Data] by the Governance precompile, or the
+// scheduling of the precompile upgrade [Data] if [Target] is [ContractAddress].
+type Proposal struct {
+	Proposer  common.Address
+	Target    common.Address
+	Data      []byte
+	Deadline  uint64
+	Approvals uint64
+	Executed  bool
+}
+
+// GetGovernanceAllowListStatus returns the role of [address] for the Governance list.
+func GetGovernanceAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetGovernanceAllowListStatus sets the permissions of [address] to [role] for the
+// Governance list. Assumes [role] has already been verified as valid.
+func SetGovernanceAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// GetThreshold returns the number of approvals required to execute a proposal.
+func GetThreshold(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, thresholdStorageKey).Big().Uint64()
+}
+
+// StoreThreshold stores [threshold] as the number of approvals required to execute a proposal.
+func StoreThreshold(stateDB contract.StateDB, threshold uint64) {
+	stateDB.SetState(ContractAddress, thresholdStorageKey, common.BigToHash(new(big.Int).SetUint64(threshold)))
+}
+
+// GetVotingPeriod returns the number of seconds a proposal is open after its creation.
+func GetVotingPeriod(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, votingPeriodStorageKey).Big().Uint64()
+}
+
+// StoreVotingPeriod stores [period] as the number of seconds a proposal is open after its creation.
+func StoreVotingPeriod(stateDB contract.StateDB, period uint64) {
+	stateDB.SetState(ContractAddress, votingPeriodStorageKey, common.BigToHash(new(big.Int).SetUint64(period)))
+}
+
+// GetProposalCount returns the number of proposals created. Proposals are numbered from 1.
+func GetProposalCount(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, proposalCountStorageKey).Big().Uint64()
+}
+
+// ProposalStorageKey returns the storage key of [field] of the proposal [proposalID].
+func ProposalStorageKey(proposalID uint64, field byte) common.Hash {
+	return crypto.Keccak256Hash(proposalPrefix, common.BigToHash(new(big.Int).SetUint64(proposalID)).Bytes(), []byte{field})
+}
+
+// ProposalDataStorageKey returns the storage key of the 32 byte word [index] of the data of the proposal [proposalID].
+func ProposalDataStorageKey(proposalID uint64, index uint64) common.Hash {
+	return crypto.Keccak256Hash(proposalDataPrefix, common.BigToHash(new(big.Int).SetUint64(proposalID)).Bytes(), common.BigToHash(new(big.Int).SetUint64(index)).Bytes())
+}
+
+// ApprovalStorageKey returns the storage key of the approval of the proposal [proposalID] by [voter].
+func ApprovalStorageKey(proposalID uint64, voter common.Address) common.Hash {
+	return crypto.Keccak256Hash(approvalPrefix, common.BigToHash(new(big.Int).SetUint64(proposalID)).Bytes(), voter.Bytes())
+}
+
+// HasApproved returns true if [voter] approved the proposal [proposalID].
+func HasApproved(stateDB contract.StateDB, proposalID uint64, voter common.Address) bool {
+	return stateDB.GetState(ContractAddress, ApprovalStorageKey(proposalID, voter)) != common.Hash{}
+}
+
+func getProposalField(stateDB contract.StateDB, proposalID uint64, field byte) common.Hash {
+	return stateDB.GetState(ContractAddress, ProposalStorageKey(proposalID, field))
+}
+
+func setProposalField(stateDB contract.StateDB, proposalID uint64, field byte, value common.Hash) {
+	stateDB.SetState(ContractAddress, ProposalStorageKey(proposalID, field), value)
+}
+
+// GetProposal returns the proposal [proposalID] and true, or false if it does not exist.
+func GetProposal(stateDB contract.StateDB, proposalID uint64) (*Proposal, bool) {
+	proposal, ok := getProposalHeader(stateDB, proposalID)
+	if !ok {
+		return nil, false
+	}
+	proposal.Data = getProposalData(stateDB, proposalID)
+	return proposal, true
+}
+
+// getProposalHeader returns the proposal [proposalID] without its data.
+func getProposalHeader(stateDB contract.StateDB, proposalID uint64) (*Proposal, bool) {
+	proposer := getProposalField(stateDB, proposalID, proposerField)
+	if proposer == (common.Hash{}) {
+		return nil, false
+	}
+	return &Proposal{
+		Proposer:  common.BytesToAddress(proposer.Bytes()),
+		Target:    common.BytesToAddress(getProposalField(stateDB, proposalID, targetField).Bytes()),
+		Deadline:  getProposalField(stateDB, proposalID, deadlineField).Big().Uint64(),
+		Approvals: getProposalField(stateDB, proposalID, approvalsField).Big().Uint64(),
+		Executed:  getProposalField(stateDB, proposalID, executedField) != common.Hash{},
+	}, true
+}
+
+func getProposalDataLength(stateDB contract.StateDB, proposalID uint64) uint64 {
+	return getProposalField(stateDB, proposalID, dataLengthField).Big().Uint64()
+}
+
+func getProposalData(stateDB contract.StateDB, proposalID uint64) []byte {
+	length := getProposalDataLength(stateDB, proposalID)
+	data := make([]byte, 0, words(length)*common.HashLength)
+	for i := uint64(0); i < words(length); i++ {
+		data = append(data, stateDB.GetState(ContractAddress, ProposalDataStorageKey(proposalID, i)).Bytes()...)
+	}
+	return data[:length]
+}
+
+// storeProposal stores [proposal] as a new proposal and returns its ID.
+func storeProposal(stateDB contract.StateDB, proposal *Proposal) uint64 {
+	proposalID := GetProposalCount(stateDB) + 1
+	stateDB.SetState(ContractAddress, proposalCountStorageKey, common.BigToHash(new(big.Int).SetUint64(proposalID)))
+
+	setProposalField(stateDB, proposalID, proposerField, common.BytesToHash(proposal.Proposer.Bytes()))
+	setProposalField(stateDB, proposalID, targetField, common.BytesToHash(proposal.Target.Bytes()))
+	setProposalField(stateDB, proposalID, deadlineField, common.BigToHash(new(big.Int).SetUint64(proposal.Deadline)))
+	setProposalField(stateDB, proposalID, approvalsField, common.BigToHash(new(big.Int).SetUint64(proposal.Approvals)))
+	setProposalField(stateDB, proposalID, dataLengthField, common.BigToHash(new(big.Int).SetUint64(uint64(len(proposal.Data)))))
+	for i := uint64(0); i < words(uint64(len(proposal.Data))); i++ {
+		word := common.Hash{}
+		copy(word[:], proposal.Data[i*common.HashLength:])
+		stateDB.SetState(ContractAddress, ProposalDataStorageKey(proposalID, i), word)
+	}
+	return proposalID
+}
+
+// words returns the number of 32 byte words needed to store [length] bytes.
+func words(length uint64) uint64 {
+	return (length + common.HashLength - 1) / common.HashLength
+}
+
+// unpackProposalID attempts to unpack [input] of [method] into a proposal ID.
+// IDs that do not fit in a uint64 cannot refer to a proposal and are returned as 0.
+func unpackProposalID(method string, input []byte) (uint64, error) {
+	res, err := GovernanceABI.UnpackInput(method, input, false)
+	if err != nil {
+		return 0, err
+	}
+	proposalID := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+	if !proposalID.IsUint64() {
+		return 0, nil
+	}
+	return proposalID.Uint64(), nil
+}
+
+// PackPropose packs [target] and [data] into the appropriate arguments for propose.
+func PackPropose(target common.Address, data []byte) ([]byte, error) {
+	return GovernanceABI.Pack("propose", target, data)
+}
+
+// UnpackProposeInput attempts to unpack [input] into the arguments of propose.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackProposeInput(input []byte) (common.Address, []byte, error) {
+	inputStruct := struct {
+		Target common.Address
+		Data   []byte
+	}{}
+	if err := GovernanceABI.UnpackInputIntoInterface(&inputStruct, "propose", input, false); err != nil {
+		return common.Address{}, nil, err
+	}
+	return inputStruct.Target, inputStruct.Data, nil
+}
+
+// PackProposeOutput attempts to pack given [proposalID] of type *big.Int
+// to conform the ABI outputs.
+func PackProposeOutput(proposalID *big.Int) ([]byte, error) {
+	return GovernanceABI.PackOutput("propose", proposalID)
+}
+
+// createProposal stores a new proposal of [caller] calling [target] with [data], approved by
+// [caller], and returns its ID packed as the output of [method].
+func createProposal(accessibleState contract.AccessibleState, method string, caller common.Address, target common.Address, data []byte, remainingGas uint64) ([]byte, uint64, error) {
+	stateDB := accessibleState.GetStateDB()
+	proposal := &Proposal{
+		Proposer:  caller,
+		Target:    target,
+		Data:      data,
+		Deadline:  accessibleState.GetBlockContext().Timestamp() + GetVotingPeriod(stateDB),
+		Approvals: 1,
+	}
+	proposalID := storeProposal(stateDB, proposal)
+	stateDB.SetState(ContractAddress, ApprovalStorageKey(proposalID, caller), common.BytesToHash([]byte{1}))
+
+	blockNumber := accessibleState.GetBlockContext().Number().Uint64()
+	topics, eventData, err := PackProposalCreatedEvent(proposalID, caller, target, data)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, eventData, blockNumber)
+	topics, eventData, err = PackProposalApprovedEvent(proposalID, caller)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, eventData, blockNumber)
+
+	packedOutput, err := GovernanceABI.PackOutput(method, new(big.Int).SetUint64(proposalID))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// propose creates a proposal to call the stateful precompile [target] with [data] from [ContractAddress],
+// approved by the caller. Proposals can be created by enabled addresses of the allow list.
+func propose(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ProposeGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, data, err := UnpackProposeInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotPropose, caller)
+	}
+	if len(data) > MaxProposalDataSize {
+		return nil, remainingGas, fmt.Errorf("%w: %d bytes exceeds %d", ErrProposalDataTooLarge, len(data), MaxProposalDataSize)
+	}
+	// Upgrades are verified when they are proposed, so they cannot be proposed as calls.
+	if target == ContractAddress {
+		return nil, remainingGas, fmt.Errorf("%w: use proposeUpgrade to schedule upgrades", ErrInvalidProposal)
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, ProposeGasCostPerWord*words(uint64(len(data)))); err != nil {
+		return nil, 0, err
+	}
+	return createProposal(accessibleState, "propose", caller, target, data, remainingGas)
+}
+
+// PackProposeUpgrade packs [upgrade] into the appropriate arguments for proposeUpgrade.
+func PackProposeUpgrade(upgrade []byte) ([]byte, error) {
+	return GovernanceABI.Pack("proposeUpgrade", upgrade)
+}
+
+// UnpackProposeUpgradeInput attempts to unpack [input] into the []byte type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackProposeUpgradeInput(input []byte) ([]byte, error) {
+	res, err := GovernanceABI.UnpackInput("proposeUpgrade", input, false)
+	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new([]byte)).(*[]byte)
+	return unpacked, nil
+}
+
+// proposeUpgrade creates a proposal to schedule the precompile upgrade [upgrade], approved by the
+// caller. [upgrade] is a JSON encoded entry of the precompileUpgrades of upgrade.json, such as
+// {"feeManagerConfig": {"blockTimestamp": 1700000000, "adminAddresses": [...]}}, and is verified
+// against the upgrades in effect at its timestamp. Proposals can be created by enabled addresses
+// of the allow list.
+func proposeUpgrade(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ProposeUpgradeGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	upgrade, err := UnpackProposeUpgradeInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotPropose, caller)
+	}
+	if len(upgrade) > MaxProposalDataSize {
+		return nil, remainingGas, fmt.Errorf("%w: %d bytes exceeds %d", ErrProposalDataTooLarge, len(upgrade), MaxProposalDataSize)
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, ProposeGasCostPerWord*words(uint64(len(upgrade)))); err != nil {
+		return nil, 0, err
+	}
+	if _, err := verifyUpgrade(accessibleState, upgrade); err != nil {
+		return nil, remainingGas, err
+	}
+	return createProposal(accessibleState, "proposeUpgrade", caller, ContractAddress, upgrade, remainingGas)
+}
+
+// openProposal returns the proposal [proposalID] if it can still be approved and executed.
+func openProposal(accessibleState contract.AccessibleState, proposalID uint64) (*Proposal, error) {
+	proposal, ok := getProposalHeader(accessibleState.GetStateDB(), proposalID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownProposal, proposalID)
+	}
+	if proposal.Executed {
+		return nil, fmt.Errorf("%w: %d", ErrProposalExecuted, proposalID)
+	}
+	if accessibleState.GetBlockContext().Timestamp() > proposal.Deadline {
+		return nil, fmt.Errorf("%w: %d", ErrProposalExpired, proposalID)
+	}
+	return proposal, nil
+}
+
+// PackApprove packs [proposalID] of type *big.Int into the appropriate arguments for approve.
+func PackApprove(proposalID *big.Int) ([]byte, error) {
+	return GovernanceABI.Pack("approve", proposalID)
+}
+
+// approve records the approval of an open proposal by the caller.
+// Proposals can be approved by enabled addresses of the allow list.
+func approve(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ApproveGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	proposalID, err := unpackProposalID("approve", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotApprove, caller)
+	}
+	proposal, err := openProposal(accessibleState, proposalID)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if HasApproved(stateDB, proposalID, caller) {
+		return nil, remainingGas, fmt.Errorf("%w: %d by %s", ErrAlreadyApproved, proposalID, caller)
+	}
+
+	topics, data, err := PackProposalApprovedEvent(proposalID, caller)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+
+	stateDB.SetState(ContractAddress, ApprovalStorageKey(proposalID, caller), common.BytesToHash([]byte{1}))
+	setProposalField(stateDB, proposalID, approvalsField, common.BigToHash(new(big.Int).SetUint64(proposal.Approvals+1)))
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackExecute packs [proposalID] of type *big.Int into the appropriate arguments for execute.
+func PackExecute(proposalID *big.Int) ([]byte, error) {
+	return GovernanceABI.Pack("execute", proposalID)
+}
+
+// PackExecuteOutput attempts to pack given [result] of type []byte
+// to conform the ABI outputs.
+func PackExecuteOutput(result []byte) ([]byte, error) {
+	return GovernanceABI.PackOutput("execute", result)
+}
+
+// execute executes an open proposal approved by at least the threshold of approvals and returns
+// the result of its call. Upgrade proposals are verified again and scheduled instead.
+// Proposals can be executed by enabled addresses of the allow list.
+func execute(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ExecuteGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	proposalID, err := unpackProposalID("execute", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotExecute, caller)
+	}
+	proposal, err := openProposal(accessibleState, proposalID)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if threshold := GetThreshold(stateDB); proposal.Approvals < threshold {
+		return nil, remainingGas, fmt.Errorf("%w: %d has %d of %d", ErrNotEnoughApprovals, proposalID, proposal.Approvals, threshold)
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, ExecuteGasCostPerWord*words(getProposalDataLength(stateDB, proposalID))); err != nil {
+		return nil, 0, err
+	}
+	data := getProposalData(stateDB, proposalID)
+
+	// Mark the proposal as executed before running it, so it cannot be executed twice.
+	setProposalField(stateDB, proposalID, executedField, common.BytesToHash([]byte{1}))
+	topics, eventData, err := PackProposalExecutedEvent(proposalID, caller)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, eventData, accessibleState.GetBlockContext().Number().Uint64())
+
+	var result []byte
+	if proposal.Target == ContractAddress {
+		if remainingGas, err = contract.DeductGas(remainingGas, ScheduleUpgradeGasCost); err != nil {
+			return nil, 0, err
+		}
+		if err := scheduleUpgrade(accessibleState, proposalID, data); err != nil {
+			return nil, remainingGas, err
+		}
+		result = []byte{}
+	} else {
+		result, remainingGas, err = accessibleState.CallPrecompile(ContractAddress, proposal.Target, data, remainingGas, false)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+	}
+
+	packedOutput, err := PackExecuteOutput(result)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackGetProposal packs [proposalID] of type *big.Int into the appropriate arguments for getProposal.
+func PackGetProposal(proposalID *big.Int) ([]byte, error) {
+	return GovernanceABI.Pack("getProposal", proposalID)
+}
+
+// PackGetProposalOutput attempts to pack given [proposal] to conform the ABI outputs.
+func PackGetProposalOutput(proposal *Proposal) ([]byte, error) {
+	return GovernanceABI.PackOutput("getProposal",
+		proposal.Proposer,
+		proposal.Target,
+		proposal.Data,
+		new(big.Int).SetUint64(proposal.Deadline),
+		new(big.Int).SetUint64(proposal.Approvals),
+		proposal.Executed,
+	)
+}
+
+// UnpackGetProposalOutput attempts to unpack [output] as the proposal returned by getProposal.
+func UnpackGetProposalOutput(output []byte) (*Proposal, error) {
+	outputStruct := struct {
+		Proposer  common.Address
+		Target    common.Address
+		Data      []byte
+		Deadline  *big.Int
+		Approvals *big.Int
+		Executed  bool
+	}{}
+	if err := GovernanceABI.UnpackIntoInterface(&outputStruct, "getProposal", output); err != nil {
+		return nil, err
+	}
+	return &Proposal{
+		Proposer:  outputStruct.Proposer,
+		Target:    outputStruct.Target,
+		Data:      outputStruct.Data,
+		Deadline:  outputStruct.Deadline.Uint64(),
+		Approvals: outputStruct.Approvals.Uint64(),
+		Executed:  outputStruct.Executed,
+	}, nil
+}
+
+func getProposal(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetProposalGasCost); err != nil {
+		return nil, 0, err
+	}
+	proposalID, err := unpackProposalID("getProposal", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	if _, ok := getProposalHeader(stateDB, proposalID); !ok {
+		return nil, remainingGas, fmt.Errorf("%w: %d", ErrUnknownProposal, proposalID)
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, GetProposalGasCostPerWord*words(getProposalDataLength(stateDB, proposalID))); err != nil {
+		return nil, 0, err
+	}
+	proposal, _ := GetProposal(stateDB, proposalID)
+	packedOutput, err := PackGetProposalOutput(proposal)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackHasApproved packs [proposalID] and [voter] into the appropriate arguments for hasApproved.
+func PackHasApproved(proposalID *big.Int, voter common.Address) ([]byte, error) {
+	return GovernanceABI.Pack("hasApproved", proposalID, voter)
+}
+
+// UnpackHasApprovedInput attempts to unpack [input] into the arguments of hasApproved.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackHasApprovedInput(input []byte) (*big.Int, common.Address, error) {
+	inputStruct := struct {
+		ProposalId *big.Int
+		Voter      common.Address
+	}{}
+	if err := GovernanceABI.UnpackInputIntoInterface(&inputStruct, "hasApproved", input, false); err != nil {
+		return nil, common.Address{}, err
+	}
+	return inputStruct.ProposalId, inputStruct.Voter, nil
+}
+
+// PackHasApprovedOutput attempts to pack given [approved] of type bool
+// to conform the ABI outputs.
+func PackHasApprovedOutput(approved bool) ([]byte, error) {
+	return GovernanceABI.PackOutput("hasApproved", approved)
+}
+
+func hasApproved(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, HasApprovedGasCost); err != nil {
+		return nil, 0, err
+	}
+	proposalID, voter, err := UnpackHasApprovedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	approved := proposalID.IsUint64() && HasApproved(accessibleState.GetStateDB(), proposalID.Uint64(), voter)
+	packedOutput, err := PackHasApprovedOutput(approved)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackProposalCount packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackProposalCount() ([]byte, error) {
+	return GovernanceABI.Pack("proposalCount")
+}
+
+// PackProposalCountOutput attempts to pack given [count] of type *big.Int
+// to conform the ABI outputs.
+func PackProposalCountOutput(count *big.Int) ([]byte, error) {
+	return GovernanceABI.PackOutput("proposalCount", count)
+}
+
+func proposalCount(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ProposalCountGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	packedOutput, err := PackProposalCountOutput(new(big.Int).SetUint64(GetProposalCount(accessibleState.GetStateDB())))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackThreshold packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackThreshold() ([]byte, error) {
+	return GovernanceABI.Pack("threshold")
+}
+
+// PackThresholdOutput attempts to pack given [threshold] of type *big.Int
+// to conform the ABI outputs.
+func PackThresholdOutput(threshold *big.Int) ([]byte, error) {
+	return GovernanceABI.PackOutput("threshold", threshold)
+}
+
+func threshold(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ThresholdGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	packedOutput, err := PackThresholdOutput(new(big.Int).SetUint64(GetThreshold(accessibleState.GetStateDB())))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackVotingPeriod packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackVotingPeriod() ([]byte, error) {
+	return GovernanceABI.Pack("votingPeriod")
+}
+
+// PackVotingPeriodOutput attempts to pack given [period] of type *big.Int
+// to conform the ABI outputs.
+func PackVotingPeriodOutput(period *big.Int) ([]byte, error) {
+	return GovernanceABI.PackOutput("votingPeriod", period)
+}
+
+func votingPeriod(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, VotingPeriodGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	packedOutput, err := PackVotingPeriodOutput(new(big.Int).SetUint64(GetVotingPeriod(accessibleState.GetStateDB())))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackScheduledUpgrades packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackScheduledUpgrades() ([]byte, error) {
+	return GovernanceABI.Pack("scheduledUpgrades")
+}
+
+// PackScheduledUpgradesOutput attempts to pack given [proposalIDs] of type []*big.Int
+// to conform the ABI outputs.
+func PackScheduledUpgradesOutput(proposalIDs []*big.Int) ([]byte, error) {
+	return GovernanceABI.PackOutput("scheduledUpgrades", proposalIDs)
+}
+
+// scheduledUpgrades returns the IDs of the executed upgrade proposals, in the order they were executed.
+// The activated upgrades which no longer affect the enabled precompiles are pruned by PruneScheduledUpgrades.
+func scheduledUpgrades(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ScheduledUpgradesGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	stateDB := accessibleState.GetStateDB()
+	count := getScheduledUpgradesCount(stateDB)
+	if remainingGas, err = contract.DeductGas(remainingGas, ScheduledUpgradesGasCostPerUpgrade*count); err != nil {
+		return nil, 0, err
+	}
+	proposalIDs := make([]*big.Int, 0, count)
+	for _, proposalID := range getScheduledUpgradeIDs(stateDB) {
+		proposalIDs = append(proposalIDs, new(big.Int).SetUint64(proposalID))
+	}
+	packedOutput, err := PackScheduledUpgradesOutput(proposalIDs)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createGovernancePrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the getters/setters is controlled by an allow list for [ContractAddress].
+func createGovernancePrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+
+	stromboliFunctionMap := map[string]struct {
+		function contract.RunStatefulPrecompileFunc
+		opts     []contract.FunctionOption
+	}{
+		"propose":           {function: propose},
+		"proposeUpgrade":    {function: proposeUpgrade},
+		"approve":           {function: approve, opts: []contract.FunctionOption{contract.WithGasCost(ApproveGasCost)}},
+		"execute":           {function: execute},
+		"getProposal":       {function: getProposal},
+		"hasApproved":       {function: hasApproved, opts: []contract.FunctionOption{contract.WithGasCost(HasApprovedGasCost)}},
+		"proposalCount":     {function: proposalCount, opts: []contract.FunctionOption{contract.WithGasCost(ProposalCountGasCost)}},
+		"threshold":         {function: threshold, opts: []contract.FunctionOption{contract.WithGasCost(ThresholdGasCost)}},
+		"votingPeriod":      {function: votingPeriod, opts: []contract.FunctionOption{contract.WithGasCost(VotingPeriodGasCost)}},
+		"scheduledUpgrades": {function: scheduledUpgrades},
+	}
+	for name, entry := range stromboliFunctionMap {
+		method, ok := GovernanceABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		opts := append([]contract.FunctionOption{contract.WithStateMutability(contract.StateMutability(method.StateMutability))}, entry.opts...)
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, entry.function, contract.IsStromboliActivated, opts...))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,543 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const blockTimestamp uint64 = 100
+
+var (
+	readAllowListData = mustPack(allowlist.PackReadAllowList(allowlist.TestEnabledAddr))
+	// enableTxAllowList enables the tx allow list after [blockTimestamp].
+	enableTxAllowList = mustMarshal(&params.PrecompileUpgrade{
+		Config: txallowlist.NewConfig(utils.NewUint64(blockTimestamp+100), []common.Address{ContractAddress}, nil, nil),
+	})
+	enablePastTxAllowList = mustMarshal(&params.PrecompileUpgrade{
+		Config: txallowlist.NewConfig(utils.NewUint64(blockTimestamp), []common.Address{ContractAddress}, nil, nil),
+	})
+	disableTxAllowList = mustMarshal(&params.PrecompileUpgrade{
+		Config: txallowlist.NewDisableConfig(utils.NewUint64(blockTimestamp + 100)),
+	})
+	disableGovernance = mustMarshal(&params.PrecompileUpgrade{
+		Config: NewDisableConfig(utils.NewUint64(blockTimestamp + 100)),
+	})
+
+	tests = map[string]testutils.PrecompileTest{
+		"propose before Stromboli": {
+			Caller:      allowlist.TestEnabledAddr,
+			BeforeHook:  allowlist.SetDefaultRoles(Module.Address),
+			InputFn:     proposeInput(txallowlist.ContractAddress, readAllowListData),
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"propose from no role fails": {
+			Caller:            allowlist.TestNoRoleAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeInput(txallowlist.ContractAddress, readAllowListData),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeGasCost,
+			ExpectedErr:       ErrCannotPropose.Error(),
+		},
+		"propose from enabled address": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, stateDB)
+				StoreVotingPeriod(stateDB, 3600)
+			},
+			InputFn:           proposeInput(txallowlist.ContractAddress, readAllowListData),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeGasCost + 2*ProposeGasCostPerWord,
+			ExpectedRes:       mustPack(PackProposeOutput(common.Big1)),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, uint64(1), GetProposalCount(stateDB))
+				proposal, ok := GetProposal(stateDB, 1)
+				require.True(t, ok)
+				require.Equal(t, &Proposal{
+					Proposer:  allowlist.TestEnabledAddr,
+					Target:    txallowlist.ContractAddress,
+					Data:      readAllowListData,
+					Deadline:  blockTimestamp + 3600,
+					Approvals: 1,
+				}, proposal)
+				require.True(t, HasApproved(stateDB, 1, allowlist.TestEnabledAddr))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 2)
+				require.Equal(t, GovernanceABI.Events["ProposalCreated"].ID, logsTopics[0][0])
+				require.Equal(t, common.BytesToHash(txallowlist.ContractAddress[:]), logsTopics[0][3])
+				data, err := UnpackProposalCreatedEventData(logsData[0])
+				require.NoError(t, err)
+				require.Equal(t, readAllowListData, data)
+				require.Equal(t, GovernanceABI.Events["ProposalApproved"].ID, logsTopics[1][0])
+			},
+		},
+		"propose upgrade as call fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeInput(ContractAddress, enableTxAllowList),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeGasCost,
+			ExpectedErr:       ErrInvalidProposal.Error(),
+		},
+		"propose too large data fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeInput(txallowlist.ContractAddress, make([]byte, MaxProposalDataSize+1)),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeGasCost,
+			ExpectedErr:       ErrProposalDataTooLarge.Error(),
+		},
+		"approve from enabled address": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp),
+			InputFn:           proposalInput(PackApprove, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ApproveGasCost,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				proposal, ok := GetProposal(stateDB, 1)
+				require.True(t, ok)
+				require.Equal(t, uint64(2), proposal.Approvals)
+				require.True(t, HasApproved(stateDB, 1, allowlist.TestEnabledAddr))
+			},
+		},
+		"approve twice fails": {
+			Caller:            allowlist.TestAdminAddr,
+			BeforeHook:        storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp),
+			InputFn:           proposalInput(PackApprove, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ApproveGasCost,
+			ExpectedErr:       ErrAlreadyApproved.Error(),
+		},
+		"approve expired proposal fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp-1),
+			InputFn:           proposalInput(PackApprove, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ApproveGasCost,
+			ExpectedErr:       ErrProposalExpired.Error(),
+		},
+		"approve unknown proposal fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposalInput(PackApprove, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ApproveGasCost,
+			ExpectedErr:       ErrUnknownProposal.Error(),
+		},
+		"execute without enough approvals fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp)(t, stateDB)
+				StoreThreshold(stateDB, 2)
+			},
+			InputFn:           proposalInput(PackExecute, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ExecuteGasCost,
+			ExpectedErr:       ErrNotEnoughApprovals.Error(),
+		},
+		"execute call": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp)(t, stateDB)
+				StoreThreshold(stateDB, 1)
+				txallowlist.SetTxAllowListStatus(stateDB, allowlist.TestEnabledAddr, allowlist.EnabledRole)
+			},
+			InputFn:           proposalInput(PackExecute, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ExecuteGasCost + 2*ExecuteGasCostPerWord + allowlist.ReadAllowListGasCost,
+			ExpectedRes:       mustPack(PackExecuteOutput(mustPack(allowlist.PackReadAllowListOutput(allowlist.EnabledRole.Big())))),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				proposal, ok := GetProposal(stateDB, 1)
+				require.True(t, ok)
+				require.True(t, proposal.Executed)
+
+				logsTopics, _ := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, GovernanceABI.Events["ProposalExecuted"].ID, logsTopics[0][0])
+			},
+		},
+		"execute twice fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp)(t, stateDB)
+				setProposalField(stateDB, 1, executedField, common.BytesToHash([]byte{1}))
+			},
+			InputFn:           proposalInput(PackExecute, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ExecuteGasCost,
+			ExpectedErr:       ErrProposalExecuted.Error(),
+		},
+		"propose upgrade": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeUpgradeInput(enableTxAllowList),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeUpgradeGasCost + words(uint64(len(enableTxAllowList)))*ProposeGasCostPerWord,
+			ExpectedRes:       mustPack(PackProposeOutput(common.Big1)),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				proposal, ok := GetProposal(stateDB, 1)
+				require.True(t, ok)
+				require.Equal(t, ContractAddress, proposal.Target)
+				require.Equal(t, enableTxAllowList, proposal.Data)
+			},
+		},
+		"propose past upgrade fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeUpgradeInput(enablePastTxAllowList),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeUpgradeGasCost + words(uint64(len(enablePastTxAllowList)))*ProposeGasCostPerWord,
+			ExpectedErr:       "is not after the current block timestamp",
+		},
+		"propose disabling disabled precompile fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeUpgradeInput(disableTxAllowList),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeUpgradeGasCost + words(uint64(len(disableTxAllowList)))*ProposeGasCostPerWord,
+			ExpectedErr:       "is not enabled at",
+		},
+		"propose enabling scheduled precompile fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, stateDB)
+				setLastScheduledUpgrade(stateDB, txallowlist.ContractAddress, blockTimestamp+50, false)
+			},
+			InputFn:           proposeUpgradeInput(enableTxAllowList),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeUpgradeGasCost + words(uint64(len(enableTxAllowList)))*ProposeGasCostPerWord,
+			ExpectedErr:       "is already enabled at",
+		},
+		"propose governance upgrade fails": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			InputFn:           proposeUpgradeInput(disableGovernance),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ProposeUpgradeGasCost + words(uint64(len(disableGovernance)))*ProposeGasCostPerWord,
+			ExpectedErr:       "cannot upgrade the governance precompile",
+		},
+		"execute upgrade": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeTestProposal(ContractAddress, enableTxAllowList, blockTimestamp)(t, stateDB)
+				StoreThreshold(stateDB, 1)
+			},
+			InputFn:           proposalInput(PackExecute, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ExecuteGasCost + words(uint64(len(enableTxAllowList)))*ExecuteGasCostPerWord + ScheduleUpgradeGasCost,
+			ExpectedRes:       mustPack(PackExecuteOutput([]byte{})),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				upgrades, err := GetScheduledUpgrades(stateDB)
+				require.NoError(t, err)
+				require.Len(t, upgrades, 1)
+				require.Equal(t, uint64(1), upgrades[0].ProposalID)
+				require.Equal(t, txallowlist.ConfigKey, upgrades[0].Key())
+				require.Equal(t, blockTimestamp+100, *upgrades[0].Timestamp())
+
+				timestamp, disabled, ok := getLastScheduledUpgrade(stateDB, txallowlist.ContractAddress)
+				require.True(t, ok)
+				require.False(t, disabled)
+				require.Equal(t, blockTimestamp+100, timestamp)
+			},
+		},
+		"execute upgrade with too many scheduled upgrades fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeTestProposal(ContractAddress, enableTxAllowList, blockTimestamp)(t, stateDB)
+				StoreThreshold(stateDB, 1)
+				stateDB.SetState(ContractAddress, scheduledCountStorageKey, common.BigToHash(new(big.Int).SetUint64(MaxScheduledUpgrades)))
+			},
+			InputFn:           proposalInput(PackExecute, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ExecuteGasCost + words(uint64(len(enableTxAllowList)))*ExecuteGasCostPerWord + ScheduleUpgradeGasCost,
+			ExpectedErr:       ErrTooManyScheduledUpgrades.Error(),
+		},
+		"scheduled upgrades": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeTestProposal(ContractAddress, enableTxAllowList, blockTimestamp)(t, stateDB)
+				StoreThreshold(stateDB, 1)
+				stateDB.SetState(ContractAddress, ScheduledUpgradeStorageKey(0), common.BigToHash(common.Big1))
+				stateDB.SetState(ContractAddress, scheduledCountStorageKey, common.BigToHash(common.Big1))
+			},
+			InputFn:           packNoArgs(PackScheduledUpgrades),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ScheduledUpgradesGasCost + ScheduledUpgradesGasCostPerUpgrade,
+			ExpectedRes:       mustPack(PackScheduledUpgradesOutput([]*big.Int{common.Big1})),
+		},
+		"get proposal": {
+			Caller:            allowlist.TestNoRoleAddr,
+			BeforeHook:        storeTestProposal(txallowlist.ContractAddress, readAllowListData, blockTimestamp),
+			InputFn:           proposalInput(PackGetProposal, 1),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       GetProposalGasCost + 2*GetProposalGasCostPerWord,
+			ExpectedRes: mustPack(PackGetProposalOutput(&Proposal{
+				Proposer:  allowlist.TestAdminAddr,
+				Target:    txallowlist.ContractAddress,
+				Data:      readAllowListData,
+				Deadline:  blockTimestamp,
+				Approvals: 1,
+			})),
+		},
+		"threshold": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				StoreThreshold(stateDB, 3)
+			},
+			InputFn:           packNoArgs(PackThreshold),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       ThresholdGasCost,
+			ExpectedRes:       mustPack(PackThresholdOutput(big.NewInt(3))),
+		},
+	}
+)
+
+func stromboliChainConfig(*gomock.Controller) precompileconfig.ChainConfig {
+	config := *params.TestChainConfig
+	config.OptionalNetworkUpgrades = params.OptionalNetworkUpgrades{StromboliTimestamp: utils.NewUint64(0)}
+	return &config
+}
+
+// governanceChainConfig returns a chain config enabling the governance precompile at genesis.
+func governanceChainConfig() *params.ChainConfig {
+	config := stromboliChainConfig(nil).(*params.ChainConfig)
+	config.GenesisPrecompiles = params.Precompiles{ConfigKey: NewConfig(utils.NewUint64(0), []common.Address{allowlist.TestAdminAddr}, nil, nil, 1, 100)}
+	return config
+}
+
+func setupBlockContext(blockContext *contract.MockBlockContext) {
+	blockContext.EXPECT().Number().Return(big.NewInt(1)).AnyTimes()
+	blockContext.EXPECT().Timestamp().Return(blockTimestamp).AnyTimes()
+}
+
+// storeTestProposal sets the default roles and stores a proposal of the admin calling
+// [target] with [data], open until [deadline].
+func storeTestProposal(target common.Address, data []byte, deadline uint64) func(t testing.TB, stateDB contract.StateDB) {
+	return func(t testing.TB, stateDB contract.StateDB) {
+		allowlist.SetDefaultRoles(Module.Address)(t, stateDB)
+		proposalID := storeProposal(stateDB, &Proposal{
+			Proposer:  allowlist.TestAdminAddr,
+			Target:    target,
+			Data:      data,
+			Deadline:  deadline,
+			Approvals: 1,
+		})
+		stateDB.SetState(ContractAddress, ApprovalStorageKey(proposalID, allowlist.TestAdminAddr), common.BytesToHash([]byte{1}))
+	}
+}
+
+func proposeInput(target common.Address, data []byte) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := PackPropose(target, data)
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func proposeUpgradeInput(upgrade []byte) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := PackProposeUpgrade(upgrade)
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func proposalInput(pack func(*big.Int) ([]byte, error), proposalID int64) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := pack(big.NewInt(proposalID))
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func packNoArgs(pack func() ([]byte, error)) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := pack()
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func mustPack(packed []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return packed
+}
+
+func mustMarshal(upgrade *params.PrecompileUpgrade) []byte {
+	bytes, err := json.Marshal(upgrade)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func TestPackUnpackGetProposalOutput(t *testing.T) {
+	proposal := &Proposal{
+		Proposer:  allowlist.TestAdminAddr,
+		Target:    txallowlist.ContractAddress,
+		Data:      readAllowListData,
+		Deadline:  blockTimestamp,
+		Approvals: 2,
+		Executed:  true,
+	}
+	output, err := PackGetProposalOutput(proposal)
+	require.NoError(t, err)
+	unpacked, err := UnpackGetProposalOutput(output)
+	require.NoError(t, err)
+	require.Equal(t, proposal, unpacked)
+}
+
+// scheduleTestUpgrades schedules [upgrades] in [stateDB] in the given order.
+func scheduleTestUpgrades(stateDB contract.StateDB, upgrades ...*params.PrecompileUpgrade) {
+	for i, upgrade := range upgrades {
+		proposalID := storeProposal(stateDB, &Proposal{Proposer: allowlist.TestAdminAddr, Target: ContractAddress, Data: mustMarshal(upgrade)})
+		stateDB.SetState(ContractAddress, ScheduledUpgradeStorageKey(uint64(i)), common.BigToHash(new(big.Int).SetUint64(proposalID)))
+	}
+	stateDB.SetState(ContractAddress, scheduledCountStorageKey, common.BigToHash(big.NewInt(int64(len(upgrades)))))
+}
+
+func TestScheduledUpgrades(t *testing.T) {
+	stateDB := state.NewTestStateDB(t)
+	chainConfig := governanceChainConfig()
+
+	enable := &params.PrecompileUpgrade{Config: txallowlist.NewConfig(utils.NewUint64(200), nil, nil, nil)}
+	disable := &params.PrecompileUpgrade{Config: txallowlist.NewDisableConfig(utils.NewUint64(300))}
+	scheduleTestUpgrades(stateDB, disable, enable)
+
+	// Upgrades are sorted by timestamp.
+	upgrades, err := GetScheduledUpgrades(stateDB)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 2)
+	require.Equal(t, uint64(2), upgrades[0].ProposalID)
+	require.Equal(t, uint64(1), upgrades[1].ProposalID)
+
+	config, err := ChainConfigAt(chainConfig, stateDB, 250)
+	require.NoError(t, err)
+	require.True(t, config.HasScheduledPrecompileUpgrades())
+	require.Same(t, chainConfig, config.WithoutScheduledPrecompileUpgrades())
+	activating := config.GetActivatingPrecompileConfigs(txallowlist.ContractAddress, utils.NewUint64(100), 250, config.PrecompileUpgrades)
+	require.Len(t, activating, 1)
+	require.True(t, activating[0].Equal(enable.Config))
+	require.Empty(t, config.GetActivatingPrecompileConfigs(txallowlist.ContractAddress, utils.NewUint64(200), 250, config.PrecompileUpgrades))
+
+	for timestamp, enabled := range map[uint64]bool{100: false, 200: true, 250: true, 300: false} {
+		config, err := ChainConfigAt(chainConfig, stateDB, timestamp)
+		require.NoError(t, err)
+		require.Equal(t, enabled, config.IsPrecompileEnabled(txallowlist.ContractAddress, timestamp), "timestamp %d", timestamp)
+		rules := config.Rules(common.Big1, timestamp)
+		require.Equal(t, enabled, rules.IsPrecompileEnabled(txallowlist.ContractAddress), "timestamp %d", timestamp)
+	}
+
+	// Upgrades of the chain config activated at or after a scheduled upgrade take precedence.
+	overriding := *chainConfig
+	overridingConfig := txallowlist.NewConfig(utils.NewUint64(200), []common.Address{allowlist.TestAdminAddr}, nil, nil)
+	overriding.PrecompileUpgrades = []params.PrecompileUpgrade{{Config: overridingConfig}}
+	config, err = ChainConfigAt(&overriding, stateDB, 250)
+	require.NoError(t, err)
+	require.Equal(t, []params.PrecompileUpgrade{{Config: overridingConfig}, *disable}, config.PrecompileUpgrades)
+	require.Equal(t, overridingConfig, config.Rules(common.Big1, 250).ActivePrecompiles[txallowlist.ContractAddress])
+}
+
+func TestScheduledUpgradesUndecodable(t *testing.T) {
+	stateDB := state.NewTestStateDB(t)
+	chainConfig := governanceChainConfig()
+
+	scheduleTestUpgrades(stateDB, &params.PrecompileUpgrade{Config: txallowlist.NewConfig(utils.NewUint64(200), nil, nil, nil)})
+	storeProposal(stateDB, &Proposal{Proposer: allowlist.TestAdminAddr, Target: ContractAddress, Data: []byte(`{"unknownConfig":{"blockTimestamp":300}}`)})
+	stateDB.SetState(ContractAddress, ScheduledUpgradeStorageKey(1), common.BigToHash(big.NewInt(2)))
+	stateDB.SetState(ContractAddress, scheduledCountStorageKey, common.BigToHash(big.NewInt(2)))
+
+	_, err := GetScheduledUpgrades(stateDB)
+	require.ErrorContains(t, err, "unknown precompile config")
+	_, err = ChainConfigAt(chainConfig, stateDB, 250)
+	require.ErrorContains(t, err, "unknown precompile config")
+	require.ErrorContains(t, PruneScheduledUpgrades(chainConfig, stateDB, 250), "unknown precompile config")
+
+	// The scheduled upgrades are not read while the governance precompile is disabled.
+	disabled := stromboliChainConfig(nil).(*params.ChainConfig)
+	config, err := ChainConfigAt(disabled, stateDB, 250)
+	require.NoError(t, err)
+	require.Same(t, disabled, config)
+}
+
+func TestPruneScheduledUpgrades(t *testing.T) {
+	stateDB := state.NewTestStateDB(t)
+	chainConfig := governanceChainConfig()
+
+	var (
+		enable    = &params.PrecompileUpgrade{Config: txallowlist.NewConfig(utils.NewUint64(200), nil, nil, nil)}
+		disable   = &params.PrecompileUpgrade{Config: txallowlist.NewDisableConfig(utils.NewUint64(300))}
+		reenable  = &params.PrecompileUpgrade{Config: txallowlist.NewConfig(utils.NewUint64(400), nil, nil, nil)}
+		scheduled = func() []uint64 { return getScheduledUpgradeIDs(stateDB) }
+	)
+	scheduleTestUpgrades(stateDB, enable, disable, reenable)
+
+	// The last activated upgrade of a precompile is retained.
+	require.NoError(t, PruneScheduledUpgrades(chainConfig, stateDB, 250))
+	require.Equal(t, []uint64{1, 2, 3}, scheduled())
+
+	// The upgrades followed by a later activated upgrade of the same precompile are pruned.
+	require.NoError(t, PruneScheduledUpgrades(chainConfig, stateDB, 300))
+	require.Equal(t, []uint64{2, 3}, scheduled())
+	require.Equal(t, common.Hash{}, stateDB.GetState(ContractAddress, ScheduledUpgradeStorageKey(2)))
+	for timestamp, enabled := range map[uint64]bool{300: false, 350: false, 400: true} {
+		config, err := ChainConfigAt(chainConfig, stateDB, timestamp)
+		require.NoError(t, err)
+		require.Equal(t, enabled, config.IsPrecompileEnabled(txallowlist.ContractAddress, timestamp), "timestamp %d", timestamp)
+	}
+
+	// The upgrades overridden by the upgrades of the chain config are pruned.
+	overriding := *chainConfig
+	overriding.PrecompileUpgrades = []params.PrecompileUpgrade{{Config: txallowlist.NewConfig(utils.NewUint64(500), nil, nil, nil)}}
+	require.NoError(t, PruneScheduledUpgrades(&overriding, stateDB, 500))
+	require.Empty(t, scheduled())
+}
+
+func TestGovernanceRun(t *testing.T) {
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func BenchmarkGovernance(b *testing.B) {
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,53 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ProposalCreatedEventGasCost is the base gas cost of the ProposalCreated event.
+	// It is the base gas cost + the gas cost of the topics (signature, proposalId, proposer, target)
+	// and the gas cost of the offset and length of the non-indexed data.
+	// ProposalCreatedEventGasCostPerWord is charged in addition for each 32 byte word of the data.
+	ProposalCreatedEventGasCost        = contract.LogGas + contract.LogTopicGas*4 + contract.LogDataGas*2*common.HashLength
+	ProposalCreatedEventGasCostPerWord = contract.LogDataGas * common.HashLength
+	// ProposalApprovedEventGasCost is the gas cost of the ProposalApproved event.
+	// It is the base gas cost + the gas cost of the topics (signature, proposalId, voter).
+	ProposalApprovedEventGasCost = contract.LogGas + contract.LogTopicGas*3
+	// ProposalExecutedEventGasCost is the gas cost of the ProposalExecuted event.
+	// It is the base gas cost + the gas cost of the topics (signature, proposalId, executor).
+	ProposalExecutedEventGasCost = contract.LogGas + contract.LogTopicGas*3
+)
+
+// PackProposalCreatedEvent packs the event into the appropriate arguments for ProposalCreated.
+// It returns topic hashes and the encoded non-indexed data.
+func PackProposalCreatedEvent(proposalID uint64, proposer common.Address, target common.Address, data []byte) ([]common.Hash, []byte, error) {
+	return GovernanceABI.PackEvent("ProposalCreated", new(big.Int).SetUint64(proposalID), proposer, target, data)
+}
+
+// UnpackProposalCreatedEventData attempts to unpack non-indexed [dataBytes].
+func UnpackProposalCreatedEventData(dataBytes []byte) ([]byte, error) {
+	var eventData = struct {
+		Data []byte
+	}{}
+	err := GovernanceABI.UnpackIntoInterface(&eventData, "ProposalCreated", dataBytes)
+	return eventData.Data, err
+}
+
+// PackProposalApprovedEvent packs the event into the appropriate arguments for ProposalApproved.
+// It returns topic hashes and the encoded non-indexed data.
+func PackProposalApprovedEvent(proposalID uint64, voter common.Address) ([]common.Hash, []byte, error) {
+	return GovernanceABI.PackEvent("ProposalApproved", new(big.Int).SetUint64(proposalID), voter)
+}
+
+// PackProposalExecutedEvent packs the event into the appropriate arguments for ProposalExecuted.
+// It returns topic hashes and the encoded non-indexed data.
+func PackProposalExecutedEvent(proposalID uint64, executor common.Address) ([]common.Hash, []byte, error) {
+	return GovernanceABI.PackEvent("ProposalExecuted", new(big.Int).SetUint64(proposalID), executor)
+}
@@ -0,0 +1,256 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ScheduledUpgrade is a precompile upgrade scheduled by the execution of the upgrade proposal [ProposalID].
+type ScheduledUpgrade struct {
+	ProposalID uint64
+	params.PrecompileUpgrade
+}
+
+// ScheduledUpgradeStorageKey returns the storage key of the ID of the upgrade proposal
+// executed at position [index] of the scheduled upgrades.
+func ScheduledUpgradeStorageKey(index uint64) common.Hash {
+	return crypto.Keccak256Hash(scheduledUpgradePrefix, common.BigToHash(new(big.Int).SetUint64(index)).Bytes())
+}
+
+// LastScheduledUpgradeStorageKey returns the storage key of the timestamp and the disabled
+// flag of the last upgrade scheduled for the precompile at [address].
+func LastScheduledUpgradeStorageKey(address common.Address) common.Hash {
+	return crypto.Keccak256Hash(lastScheduledUpgradesPrefix, address.Bytes())
+}
+
+func getScheduledUpgradesCount(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, scheduledCountStorageKey).Big().Uint64()
+}
+
+func getScheduledUpgradeIDs(stateDB contract.StateDB) []uint64 {
+	count := getScheduledUpgradesCount(stateDB)
+	proposalIDs := make([]uint64, 0, count)
+	for i := uint64(0); i < count; i++ {
+		proposalIDs = append(proposalIDs, stateDB.GetState(ContractAddress, ScheduledUpgradeStorageKey(i)).Big().Uint64())
+	}
+	return proposalIDs
+}
+
+// getLastScheduledUpgrade returns the timestamp of the last upgrade scheduled for the precompile
+// at [address] and whether it disables the precompile, or false if none was scheduled.
+// The first byte of the slot is the disabled flag and its last 8 bytes the timestamp, which
+// is never 0 since upgrades are scheduled after the current block.
+func getLastScheduledUpgrade(stateDB contract.StateDB, address common.Address) (uint64, bool, bool) {
+	value := stateDB.GetState(ContractAddress, LastScheduledUpgradeStorageKey(address))
+	if value == (common.Hash{}) {
+		return 0, false, false
+	}
+	return new(big.Int).SetBytes(value[common.HashLength-8:]).Uint64(), value[0] != 0, true
+}
+
+func setLastScheduledUpgrade(stateDB contract.StateDB, address common.Address, timestamp uint64, disabled bool) {
+	value := common.BigToHash(new(big.Int).SetUint64(timestamp))
+	if disabled {
+		value[0] = 1
+	}
+	stateDB.SetState(ContractAddress, LastScheduledUpgradeStorageKey(address), value)
+}
+
+// overridesChainConfig returns true if an upgrade of the precompile at [address] scheduled at
+// [timestamp] takes precedence over the upgrades of [c] activated at [at]. Upgrades of the chain
+// config activated at or after [timestamp] take precedence over the scheduled upgrade.
+// [c] must not include scheduled upgrades.
+func overridesChainConfig(c *params.ChainConfig, address common.Address, timestamp uint64, at uint64) bool {
+	configs := c.GetActivatingPrecompileConfigs(address, nil, at, c.PrecompileUpgrades)
+	if len(configs) == 0 {
+		return true
+	}
+	last := configs[len(configs)-1].Timestamp()
+	return last == nil || timestamp > *last
+}
+
+// verifyUpgrade returns the precompile upgrade encoded in [upgrade] if it can be scheduled
+// in [accessibleState]: it must activate after the current block and after the last upgrade
+// scheduled for the same precompile, its config must be valid, and it must enable a precompile
+// disabled at its timestamp or disable an enabled one, as upgrades in upgrade.json do.
+func verifyUpgrade(accessibleState contract.AccessibleState, upgrade []byte) (*params.PrecompileUpgrade, error) {
+	chainConfig, ok := accessibleState.GetChainConfig().(*params.ChainConfig)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected chain config %T", ErrInvalidUpgrade, accessibleState.GetChainConfig())
+	}
+	// The upgrades already scheduled are accounted for by the last upgrade scheduled
+	// for the precompile below.
+	chainConfig = chainConfig.WithoutScheduledPrecompileUpgrades()
+	var precompileUpgrade params.PrecompileUpgrade
+	if err := json.Unmarshal(upgrade, &precompileUpgrade); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUpgrade, err)
+	}
+	config := precompileUpgrade.Config
+	if config.Key() == ConfigKey {
+		return nil, fmt.Errorf("%w: cannot upgrade the governance precompile", ErrInvalidUpgrade)
+	}
+	timestamp := config.Timestamp()
+	if timestamp == nil {
+		return nil, fmt.Errorf("%w: missing block timestamp", ErrInvalidUpgrade)
+	}
+	if currentTimestamp := accessibleState.GetBlockContext().Timestamp(); *timestamp <= currentTimestamp {
+		return nil, fmt.Errorf("%w: timestamp %d is not after the current block timestamp %d", ErrInvalidUpgrade, *timestamp, currentTimestamp)
+	}
+	module, ok := modules.GetPrecompileModule(config.Key())
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown precompile config %s", ErrInvalidUpgrade, config.Key())
+	}
+
+	enabled := chainConfig.IsPrecompileEnabled(module.Address, *timestamp)
+	if lastTimestamp, disabled, ok := getLastScheduledUpgrade(accessibleState.GetStateDB(), module.Address); ok {
+		if *timestamp <= lastTimestamp {
+			return nil, fmt.Errorf("%w: timestamp %d is not after the last upgrade of %s scheduled at %d", ErrInvalidUpgrade, *timestamp, config.Key(), lastTimestamp)
+		}
+		if overridesChainConfig(chainConfig, module.Address, lastTimestamp, *timestamp) {
+			enabled = !disabled
+		}
+	}
+	if config.IsDisabled() {
+		if !enabled {
+			return nil, fmt.Errorf("%w: %s is not enabled at %d", ErrInvalidUpgrade, config.Key(), *timestamp)
+		}
+		return &precompileUpgrade, nil
+	}
+	if enabled {
+		return nil, fmt.Errorf("%w: %s is already enabled at %d", ErrInvalidUpgrade, config.Key(), *timestamp)
+	}
+	if err := config.Verify(chainConfig); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUpgrade, err)
+	}
+	return &precompileUpgrade, nil
+}
+
+// scheduleUpgrade verifies the upgrade of the proposal [proposalID] and schedules it.
+func scheduleUpgrade(accessibleState contract.AccessibleState, proposalID uint64, upgrade []byte) error {
+	precompileUpgrade, err := verifyUpgrade(accessibleState, upgrade)
+	if err != nil {
+		return err
+	}
+	stateDB := accessibleState.GetStateDB()
+	count := getScheduledUpgradesCount(stateDB)
+	if count >= MaxScheduledUpgrades {
+		return fmt.Errorf("%w: %d upgrades are scheduled", ErrTooManyScheduledUpgrades, count)
+	}
+	module, _ := modules.GetPrecompileModule(precompileUpgrade.Key())
+	setLastScheduledUpgrade(stateDB, module.Address, *precompileUpgrade.Timestamp(), precompileUpgrade.IsDisabled())
+
+	stateDB.SetState(ContractAddress, ScheduledUpgradeStorageKey(count), common.BigToHash(new(big.Int).SetUint64(proposalID)))
+	stateDB.SetState(ContractAddress, scheduledCountStorageKey, common.BigToHash(new(big.Int).SetUint64(count+1)))
+	return nil
+}
+
+// getScheduledUpgrades returns the upgrades scheduled in [stateDB] in the order they were
+// scheduled, or an error if one of them cannot be decoded by this node, e.g. because it
+// upgrades a precompile this node does not register.
+func getScheduledUpgrades(stateDB contract.StateDB) ([]ScheduledUpgrade, error) {
+	proposalIDs := getScheduledUpgradeIDs(stateDB)
+	upgrades := make([]ScheduledUpgrade, 0, len(proposalIDs))
+	for _, proposalID := range proposalIDs {
+		upgrade := ScheduledUpgrade{ProposalID: proposalID}
+		if err := json.Unmarshal(getProposalData(stateDB, proposalID), &upgrade.PrecompileUpgrade); err != nil {
+			return nil, fmt.Errorf("cannot decode upgrade of proposal %d: %w", proposalID, err)
+		}
+		if upgrade.Timestamp() == nil {
+			return nil, fmt.Errorf("upgrade of proposal %d has no block timestamp", proposalID)
+		}
+		upgrades = append(upgrades, upgrade)
+	}
+	return upgrades, nil
+}
+
+// GetScheduledUpgrades returns the upgrades scheduled in [stateDB] sorted by timestamp, or an
+// error if one of them cannot be decoded by this node.
+func GetScheduledUpgrades(stateDB contract.StateDB) ([]ScheduledUpgrade, error) {
+	upgrades, err := getScheduledUpgrades(stateDB)
+	if err != nil {
+		return nil, err
+	}
+	// Upgrades of a precompile are scheduled in increasing timestamp order,
+	// so the stable sort keeps them in order.
+	sort.SliceStable(upgrades, func(i, j int) bool {
+		return *upgrades[i].Timestamp() < *upgrades[j].Timestamp()
+	})
+	return upgrades, nil
+}
+
+// ChainConfigAt returns [c] with the upgrades scheduled in [stateDB] merged into its precompile
+// upgrades, so that the precompiles enabled at [timestamp] by the returned config account for
+// them. Upgrades overridden by the upgrades of [c] activated at [timestamp] are left out.
+// [c] is returned as is if the governance precompile is not enabled at [timestamp].
+// Returns an error if a scheduled upgrade cannot be decoded, since the node cannot
+// tell which precompiles are enabled then.
+func ChainConfigAt(c *params.ChainConfig, stateDB contract.StateDB, timestamp uint64) (*params.ChainConfig, error) {
+	c = c.WithoutScheduledPrecompileUpgrades()
+	if !c.IsPrecompileEnabled(ContractAddress, timestamp) {
+		return c, nil
+	}
+	scheduled, err := GetScheduledUpgrades(stateDB)
+	if err != nil {
+		return nil, err
+	}
+	upgrades := make([]params.PrecompileUpgrade, 0, len(scheduled))
+	for _, upgrade := range scheduled {
+		module, _ := modules.GetPrecompileModule(upgrade.Key())
+		if overridesChainConfig(c, module.Address, *upgrade.Timestamp(), timestamp) {
+			upgrades = append(upgrades, upgrade.PrecompileUpgrade)
+		}
+	}
+	return c.WithScheduledPrecompileUpgrades(upgrades), nil
+}
+
+// PruneScheduledUpgrades removes from the upgrades scheduled in [stateDB] the upgrades activated
+// at [timestamp] which no longer affect the precompiles enabled by [c]: the upgrades followed by
+// a later activated upgrade of the same precompile, and the upgrades overridden by the upgrades
+// of [c]. Along with [MaxScheduledUpgrades], this bounds the number of scheduled upgrades.
+func PruneScheduledUpgrades(c *params.ChainConfig, stateDB contract.StateDB, timestamp uint64) error {
+	c = c.WithoutScheduledPrecompileUpgrades()
+	upgrades, err := getScheduledUpgrades(stateDB)
+	if err != nil {
+		return err
+	}
+	// Timestamp of the last activated upgrade of each precompile.
+	lastActivated := make(map[common.Address]uint64)
+	for _, upgrade := range upgrades {
+		module, _ := modules.GetPrecompileModule(upgrade.Key())
+		if upgradeTimestamp := *upgrade.Timestamp(); upgradeTimestamp <= timestamp && upgradeTimestamp > lastActivated[module.Address] {
+			lastActivated[module.Address] = upgradeTimestamp
+		}
+	}
+	retained := make([]uint64, 0, len(upgrades))
+	for _, upgrade := range upgrades {
+		module, _ := modules.GetPrecompileModule(upgrade.Key())
+		upgradeTimestamp := *upgrade.Timestamp()
+		if upgradeTimestamp <= timestamp && (upgradeTimestamp < lastActivated[module.Address] || !overridesChainConfig(c, module.Address, upgradeTimestamp, timestamp)) {
+			continue
+		}
+		retained = append(retained, upgrade.ProposalID)
+	}
+	if len(retained) == len(upgrades) {
+		return nil
+	}
+	for i, proposalID := range retained {
+		stateDB.SetState(ContractAddress, ScheduledUpgradeStorageKey(uint64(i)), common.BigToHash(new(big.Int).SetUint64(proposalID)))
+	}
+	for i := len(retained); i < len(upgrades); i++ {
+		stateDB.SetState(ContractAddress, ScheduledUpgradeStorageKey(uint64(i)), common.Hash{})
+	}
+	stateDB.SetState(ContractAddress, scheduledCountStorageKey, common.BigToHash(new(big.Int).SetUint64(uint64(len(retained)))))
+	return nil
+}
@@ -0,0 +1,158 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisend
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// MaxRecipients is the maximum number of recipients of a single multisend call.
+	MaxRecipients = 1_000
+
+	MultisendBaseGasCost uint64 = contract.ReadGasCostPerSlot // read + debit the balance of the caller
+	// MultisendGasCostPerRecipient is charged for crediting each recipient. It is cheaper than
+	// the CallValueTransferGas (9_000) + ColdAccountAccessCost (2_600) of a CALL with value.
+	MultisendGasCostPerRecipient uint64 = 5_000
+	// MultisendNewAccountGasCost is charged in addition to [MultisendGasCostPerRecipient] for
+	// each recipient that does not exist yet, matching the CallNewAccountGas of a CALL.
+	MultisendNewAccountGasCost uint64 = 25_000
+)
+
+var (
+	ErrInvalidRecipients = errors.New("invalid multisend recipients")
+	ErrMismatchedLengths = errors.New("recipients and amounts have different lengths")
+	ErrNonZeroValue      = errors.New("multisend does not accept value")
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// MultisendRawABI contains the raw ABI of Multisend contract.
+	//go:embed contract.abi
+	MultisendRawABI string
+
+	MultisendABI = contract.ParseABI(MultisendRawABI)
+
+	MultisendPrecompile = createMultisendPrecompile()
+)
+
+// PackMultisend packs [recipients] and [amounts] into the appropriate arguments for multisend.
+func PackMultisend(recipients []common.Address, amounts []*big.Int) ([]byte, error) {
+	return MultisendABI.Pack("multisend", recipients, amounts)
+}
+
+// UnpackMultisendInput attempts to unpack [input] into the arguments of multisend.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackMultisendInput(input []byte) ([]common.Address, []*big.Int, error) {
+	inputStruct := struct {
+		Recipients []common.Address
+		Amounts    []*big.Int
+	}{}
+	if err := MultisendABI.UnpackInputIntoInterface(&inputStruct, "multisend", input, false); err != nil {
+		return nil, nil, err
+	}
+	return inputStruct.Recipients, inputStruct.Amounts, nil
+}
+
+// multisend transfers amounts[i] of the native token from the caller to recipients[i] for each i.
+// The transfers are funded from the balance of the caller. Calls sending value along are
+// rejected, as it would otherwise be locked at the precompile address.
+// Either all transfers succeed or none of them is applied.
+func multisend(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MultisendBaseGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	if value := accessibleState.GetCallValue(); value.Sign() != 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrNonZeroValue, value)
+	}
+	recipients, amounts, err := UnpackMultisendInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if len(recipients) != len(amounts) {
+		return nil, remainingGas, fmt.Errorf("%w: %d recipients, %d amounts", ErrMismatchedLengths, len(recipients), len(amounts))
+	}
+	if len(recipients) == 0 || len(recipients) > MaxRecipients {
+		return nil, remainingGas, fmt.Errorf("%w: %d recipients must be in [1, %d]", ErrInvalidRecipients, len(recipients), MaxRecipients)
+	}
+	// Charge for every recipient before touching the state.
+	if remainingGas, err = contract.DeductGas(remainingGas, MultisendGasCostPerRecipient*uint64(len(recipients))); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	totalAmount := new(big.Int)
+	for i, recipient := range recipients {
+		if !stateDB.Exist(recipient) {
+			if remainingGas, err = contract.DeductGas(remainingGas, MultisendNewAccountGasCost); err != nil {
+				return nil, 0, err
+			}
+		}
+		totalAmount.Add(totalAmount, amounts[i])
+	}
+	if totalAmount.BitLen() > 256 {
+		return nil, remainingGas, fmt.Errorf("%w: total amount exceeds 256 bits", vmerrs.ErrInsufficientBalance)
+	}
+	if stateDB.GetBalance(caller).Cmp(totalAmount) < 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %s cannot send %s", vmerrs.ErrInsufficientBalance, caller, totalAmount)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, MultisendEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackMultisendEvent(caller, big.NewInt(int64(len(recipients))), totalAmount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	stateDB.SubBalance(caller, totalAmount)
+	for i, recipient := range recipients {
+		if !stateDB.Exist(recipient) {
+			stateDB.CreateAccount(recipient)
+		}
+		stateDB.AddBalance(recipient, amounts[i])
+	}
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// createMultisendPrecompile returns a StatefulPrecompiledContract with multisend
+// enabled from Stromboli onward.
+func createMultisendPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	stromboliFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"multisend": multisend,
+	}
+	for name, function := range stromboliFunctionMap {
+		method, ok := MultisendABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
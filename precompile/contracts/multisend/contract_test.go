@@ -0,0 +1,228 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	senderAddr     = common.HexToAddress("0x0123")
+	recipientAddr1 = common.HexToAddress("0x0456")
+	recipientAddr2 = common.HexToAddress("0x0789")
+)
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
+func multisendInput(recipients []common.Address, amounts []*big.Int) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := PackMultisend(recipients, amounts)
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func TestPackUnpackMultisend(t *testing.T) {
+	recipients := []common.Address{recipientAddr1, recipientAddr2}
+	amounts := []*big.Int{big.NewInt(1), abi.MaxUint256}
+	input, err := PackMultisend(recipients, amounts)
+	require.NoError(t, err)
+	unpackedRecipients, unpackedAmounts, err := UnpackMultisendInput(input[4:])
+	require.NoError(t, err)
+	require.Equal(t, recipients, unpackedRecipients)
+	require.Equal(t, amounts, unpackedAmounts)
+
+	_, _, err = UnpackMultisendInput(input[4:36])
+	require.Error(t, err)
+}
+
+func TestMultisend(t *testing.T) {
+	twoRecipients := []common.Address{recipientAddr1, recipientAddr2}
+	tooManyAmounts := make([]*big.Int, MaxRecipients+1)
+	for i := range tooManyAmounts {
+		tooManyAmounts[i] = common.Big1
+	}
+	tests := map[string]testutils.PrecompileTest{
+		"multisend before Stromboli": {
+			Caller:      senderAddr,
+			InputFn:     multisendInput(twoRecipients, []*big.Int{big.NewInt(1), big.NewInt(2)}),
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"multisend to existing accounts": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, big.NewInt(10))
+				stateDB.AddBalance(recipientAddr1, big.NewInt(1))
+				stateDB.AddBalance(recipientAddr2, big.NewInt(1))
+			},
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(3), big.NewInt(4)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*MultisendGasCostPerRecipient + MultisendEventGasCost,
+			ExpectedRes:   []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(3), stateDB.GetBalance(senderAddr))
+				require.Equal(t, big.NewInt(4), stateDB.GetBalance(recipientAddr1))
+				require.Equal(t, big.NewInt(5), stateDB.GetBalance(recipientAddr2))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, MultisendABI.Events["Multisend"].ID, logsTopics[0][0])
+				require.Equal(t, common.BytesToHash(senderAddr[:]), logsTopics[0][1])
+				numRecipients, totalAmount, err := UnpackMultisendEventData(logsData[0])
+				require.NoError(t, err)
+				require.Equal(t, big.NewInt(2), numRecipients)
+				require.Equal(t, big.NewInt(7), totalAmount)
+			},
+		},
+		"multisend with value": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, big.NewInt(10))
+			},
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(3), big.NewInt(4)}),
+			ChainConfigFn: stromboliChainConfig,
+			Value:         big.NewInt(1),
+			SuppliedGas:   MultisendBaseGasCost,
+			ExpectedErr:   ErrNonZeroValue.Error(),
+		},
+		"multisend to new accounts": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, big.NewInt(10))
+			},
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(3), big.NewInt(4)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*(MultisendGasCostPerRecipient+MultisendNewAccountGasCost) + MultisendEventGasCost,
+			ExpectedRes:   []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(3), stateDB.GetBalance(senderAddr))
+				require.Equal(t, big.NewInt(3), stateDB.GetBalance(recipientAddr1))
+				require.Equal(t, big.NewInt(4), stateDB.GetBalance(recipientAddr2))
+			},
+		},
+		"multisend to the same recipient twice": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, big.NewInt(10))
+				stateDB.AddBalance(recipientAddr1, big.NewInt(1))
+			},
+			InputFn:       multisendInput([]common.Address{recipientAddr1, recipientAddr1}, []*big.Int{big.NewInt(3), big.NewInt(4)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*MultisendGasCostPerRecipient + MultisendEventGasCost,
+			ExpectedRes:   []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(3), stateDB.GetBalance(senderAddr))
+				require.Equal(t, big.NewInt(8), stateDB.GetBalance(recipientAddr1))
+			},
+		},
+		"insufficient balance": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, big.NewInt(6))
+				stateDB.AddBalance(recipientAddr1, big.NewInt(1))
+				stateDB.AddBalance(recipientAddr2, big.NewInt(1))
+			},
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(3), big.NewInt(4)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*MultisendGasCostPerRecipient,
+			ExpectedErr:   vmerrs.ErrInsufficientBalance.Error(),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(6), stateDB.GetBalance(senderAddr))
+				require.Equal(t, big.NewInt(1), stateDB.GetBalance(recipientAddr1))
+			},
+		},
+		"total amount overflows": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, abi.MaxUint256)
+				stateDB.AddBalance(recipientAddr1, big.NewInt(1))
+				stateDB.AddBalance(recipientAddr2, big.NewInt(1))
+			},
+			InputFn:       multisendInput(twoRecipients, []*big.Int{abi.MaxUint256, big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*MultisendGasCostPerRecipient,
+			ExpectedErr:   vmerrs.ErrInsufficientBalance.Error(),
+		},
+		"mismatched lengths": {
+			Caller:        senderAddr,
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost,
+			ExpectedErr:   ErrMismatchedLengths.Error(),
+		},
+		"no recipients": {
+			Caller:        senderAddr,
+			InputFn:       multisendInput([]common.Address{}, []*big.Int{}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost,
+			ExpectedErr:   ErrInvalidRecipients.Error(),
+		},
+		"too many recipients": {
+			Caller:        senderAddr,
+			InputFn:       multisendInput(make([]common.Address, MaxRecipients+1), tooManyAmounts),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost,
+			ExpectedErr:   ErrInvalidRecipients.Error(),
+		},
+		"readOnly": {
+			Caller:        senderAddr,
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(1), big.NewInt(2)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost,
+			ReadOnly:      true,
+			ExpectedErr:   vmerrs.ErrWriteProtection.Error(),
+		},
+		"insufficient gas for recipients": {
+			Caller:        senderAddr,
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(1), big.NewInt(2)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*MultisendGasCostPerRecipient - 1,
+			ExpectedErr:   vmerrs.ErrOutOfGas.Error(),
+		},
+		"insufficient gas for new accounts": {
+			Caller: senderAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				stateDB.AddBalance(senderAddr, big.NewInt(10))
+			},
+			InputFn:       multisendInput(twoRecipients, []*big.Int{big.NewInt(1), big.NewInt(2)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   MultisendBaseGasCost + 2*MultisendGasCostPerRecipient + MultisendNewAccountGasCost,
+			ExpectedErr:   vmerrs.ErrOutOfGas.Error(),
+		},
+		"precompileVersion": {
+			Caller:        senderAddr,
+			Input:         contract.PrecompileVersionSelector,
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   contract.PrecompileVersionGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   contract.PackPrecompileVersionOutput(Version),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Run(t, Module, state.NewTestStateDB(t))
+		})
+	}
+}
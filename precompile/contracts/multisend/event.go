@@ -0,0 +1,32 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisend
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MultisendEventGasCost is the gas cost of the Multisend event.
+// It is the base gas cost + the gas cost of the topics (signature, sender)
+// and the gas cost of the non-indexed data (32 bytes each for numRecipients and totalAmount).
+const MultisendEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*2*common.HashLength
+
+// PackMultisendEvent packs the event into the appropriate arguments for Multisend.
+// It returns topic hashes and the encoded non-indexed data.
+func PackMultisendEvent(sender common.Address, numRecipients *big.Int, totalAmount *big.Int) ([]common.Hash, []byte, error) {
+	return MultisendABI.PackEvent("Multisend", sender, numRecipients, totalAmount)
+}
+
+// UnpackMultisendEventData attempts to unpack non-indexed [dataBytes].
+func UnpackMultisendEventData(dataBytes []byte) (*big.Int, *big.Int, error) {
+	var eventData = struct {
+		NumRecipients *big.Int
+		TotalAmount   *big.Int
+	}{}
+	err := MultisendABI.UnpackIntoInterface(&eventData, "Multisend", dataBytes)
+	return eventData.NumRecipients, eventData.TotalAmount, err
+}
@@ -4,6 +4,7 @@
 package nativeminter
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -16,12 +17,22 @@ import (
 
 var _ precompileconfig.Config = &Config{}
 
+var ErrCannotLimitMintingBeforeStromboli = errors.New("cannot limit minting before Stromboli")
+
 // Config implements the precompileconfig.Config interface while adding in the
 // ContractNativeMinter specific precompile config.
 type Config struct {
 	allowlist.AllowListConfig
 	precompileconfig.Upgrade
 	InitialMint map[common.Address]*math.HexOrDecimal256 `json:"initialMint,omitempty"` // addresses to receive the initial mint mapped to the amount to mint
+	// SupplyCap bounds the total amount that can be minted through mintNativeCoin.
+	// MinterBudgets bounds the amount each minter can mint per MintPeriod seconds.
+	// A zero budget prevents a minter from minting, and minters without a budget are
+	// only bounded by the supply cap.
+	// Admins can update the cap, the period and the budgets after activation through the precompile.
+	SupplyCap     *math.HexOrDecimal256                    `json:"supplyCap,omitempty"`
+	MintPeriod    uint64                                   `json:"mintPeriod,omitempty"`
+	MinterBudgets map[common.Address]*math.HexOrDecimal256 `json:"minterBudgets,omitempty"`
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -66,12 +77,21 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 		return false
 	}
 
-	if len(c.InitialMint) != len(other.InitialMint) {
+	if c.MintPeriod != other.MintPeriod || !utils.BigNumEqual((*big.Int)(c.SupplyCap), (*big.Int)(other.SupplyCap)) {
+		return false
+	}
+
+	return amountsEqual(c.InitialMint, other.InitialMint) && amountsEqual(c.MinterBudgets, other.MinterBudgets)
+}
+
+// amountsEqual returns true if [a] and [b] map the same addresses to the same amounts.
+func amountsEqual(a, b map[common.Address]*math.HexOrDecimal256) bool {
+	if len(a) != len(b) {
 		return false
 	}
 
-	for address, amount := range c.InitialMint {
-		val, ok := other.InitialMint[address]
+	for address, amount := range a {
+		val, ok := b[address]
 		if !ok {
 			return false
 		}
@@ -85,6 +105,11 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 	return true
 }
 
+// limitsMinting returns true if [c] configures a supply cap or minter budgets.
+func (c *Config) limitsMinting() bool {
+	return c.SupplyCap != nil || c.MintPeriod != 0 || len(c.MinterBudgets) != 0
+}
+
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	// ensure that all of the initial mint values in the map are non-nil positive values
 	for addr, amount := range c.InitialMint {
@@ -96,5 +121,19 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 			return fmt.Errorf("initial mint cannot contain invalid amount %v for address %s", bigIntAmount, addr)
 		}
 	}
+	if c.limitsMinting() {
+		// If the config attempts to limit minting before Stromboli, fail verification
+		if timestamp := c.Timestamp(); timestamp != nil && !chainConfig.IsStromboli(*timestamp) {
+			return ErrCannotLimitMintingBeforeStromboli
+		}
+		if c.SupplyCap != nil && (*big.Int)(c.SupplyCap).Sign() < 1 {
+			return fmt.Errorf("supply cap must be positive, got %v", (*big.Int)(c.SupplyCap))
+		}
+		for addr, budget := range c.MinterBudgets {
+			if budget == nil || (*big.Int)(budget).Sign() < 0 {
+				return fmt.Errorf("minter budgets cannot contain invalid budget %v for address %s", (*big.Int)(budget), addr)
+			}
+		}
+	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
@@ -25,6 +25,7 @@ func TestVerify(t *testing.T) {
 			ChainConfig: func() precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			}(),
 			ExpectedError: "",
@@ -57,6 +58,39 @@ func TestVerify(t *testing.T) {
 				}),
 			ExpectedError: "initial mint cannot contain invalid amount",
 		},
+		"mint limits before Stromboli": {
+			Config: newLimitedConfig(math.NewHexOrDecimal256(100), 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(10),
+			}),
+			ChainConfig:   stromboliVerifyChainConfig(t, false),
+			ExpectedError: ErrCannotLimitMintingBeforeStromboli.Error(),
+		},
+		"valid mint limits after Stromboli": {
+			Config: newLimitedConfig(math.NewHexOrDecimal256(100), 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(10),
+			}),
+			ChainConfig:   stromboliVerifyChainConfig(t, true),
+			ExpectedError: "",
+		},
+		"zero supply cap": {
+			Config:        newLimitedConfig(math.NewHexOrDecimal256(0), 0, nil),
+			ChainConfig:   stromboliVerifyChainConfig(t, true),
+			ExpectedError: "supply cap must be positive",
+		},
+		"zero minter budget": {
+			Config: newLimitedConfig(nil, 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(0),
+			}),
+			ChainConfig:   stromboliVerifyChainConfig(t, true),
+			ExpectedError: "",
+		},
+		"nil minter budget": {
+			Config: newLimitedConfig(nil, 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: nil,
+			}),
+			ChainConfig:   stromboliVerifyChainConfig(t, true),
+			ExpectedError: "minter budgets cannot contain invalid budget",
+		},
 	}
 	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -114,6 +148,49 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: true,
 		},
+		"different supply cap": {
+			Config:   newLimitedConfig(math.NewHexOrDecimal256(100), 0, nil),
+			Other:    newLimitedConfig(math.NewHexOrDecimal256(200), 0, nil),
+			Expected: false,
+		},
+		"different mint period": {
+			Config:   newLimitedConfig(nil, 60, nil),
+			Other:    newLimitedConfig(nil, 120, nil),
+			Expected: false,
+		},
+		"different minter budgets": {
+			Config: newLimitedConfig(nil, 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(10),
+			}),
+			Other: newLimitedConfig(nil, 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(20),
+			}),
+			Expected: false,
+		},
+		"same mint limits": {
+			Config: newLimitedConfig(math.NewHexOrDecimal256(100), 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(10),
+			}),
+			Other: newLimitedConfig(math.NewHexOrDecimal256(100), 60, map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(10),
+			}),
+			Expected: true,
+		},
 	}
 	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func newLimitedConfig(supplyCap *math.HexOrDecimal256, mintPeriod uint64, minterBudgets map[common.Address]*math.HexOrDecimal256) *Config {
+	config := NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, nil)
+	config.SupplyCap = supplyCap
+	config.MintPeriod = mintPeriod
+	config.MinterBudgets = minterBudgets
+	return config
+}
+
+func stromboliVerifyChainConfig(t *testing.T, isStromboli bool) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(isStromboli).AnyTimes()
+	return config
+}
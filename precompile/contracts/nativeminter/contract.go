@@ -9,16 +9,27 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
 	mintInputLen = common.HashLength + common.HashLength
 
 	MintGasCost = 30_000
+	// MintLimitsGasCost is charged by mintNativeCoin in addition to [MintGasCost]
+	// after Stromboli to enforce the supply cap and the budget of the minter.
+	MintLimitsGasCost uint64 = 3*contract.WriteGasCostPerSlot + 7*contract.ReadGasCostPerSlot // write total minted + minter period, read limits
+
+	SetSupplyCapGasCost    uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost   // write 1 slot + read allow list
+	SetMinterBudgetGasCost uint64 = 2*contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write budget + limited flag + read allow list
+	SetMintPeriodGasCost   uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost   // write 1 slot + read allow list
+	MintLimitsViewGasCost  uint64 = 3 * contract.ReadGasCostPerSlot                                 // read cap + total minted + period
+	MinterBudgetGasCost    uint64 = 5 * contract.ReadGasCostPerSlot                                 // read budget + limited flag + minter period + period
 )
 
 type MintNativeCoinInput struct {
@@ -33,11 +44,32 @@ var (
 	ErrCannotMint = errors.New("non-enabled cannot mint")
 	ErrInvalidLen = errors.New("invalid input length for minting")
 
+	ErrCannotSetSupplyCap    = errors.New("non-admin cannot call setSupplyCap")
+	ErrCannotSetMinterBudget = errors.New("non-admin cannot call setMinterBudget")
+	ErrCannotSetMintPeriod   = errors.New("non-admin cannot call setMintPeriod")
+	ErrInvalidMintPeriod     = errors.New("mint period does not fit in 64 bits")
+	ErrSupplyCapExceeded     = errors.New("mint exceeds supply cap")
+	ErrMinterBudgetExceeded  = errors.New("mint exceeds minter budget")
+
 	// NativeMinterRawABI contains the raw ABI of NativeMinter contract.
 	//go:embed contract.abi
 	NativeMinterRawABI string
 
 	NativeMinterABI = contract.ParseABI(NativeMinterRawABI)
+
+	// UnlimitedMinterBudget is the budget of minters that are only bounded by the supply cap,
+	// which is the budget of every minter until an admin sets one. Setting the budget of a
+	// minter to UnlimitedMinterBudget removes its budget, while a zero budget prevents it
+	// from minting.
+	UnlimitedMinterBudget = new(big.Int).Set(abi.MaxUint256)
+
+	supplyCapStorageKey   = common.Hash{'s', 'c', 's', 'k'}
+	totalMintedStorageKey = common.Hash{'t', 'm', 's', 'k'}
+	mintPeriodStorageKey  = common.Hash{'m', 'p', 's', 'k'}
+	minterBudgetPrefix    = []byte("minterBudget")
+	minterLimitedPrefix   = []byte("minterLimited")
+	minterPeriodPrefix    = []byte("minterPeriodStart")
+	minterMintedPrefix    = []byte("minterPeriodMinted")
 )
 
 // GetContractNativeMinterStatus returns the role of [address] for the minter list.
@@ -73,6 +105,128 @@ func UnpackMintNativeCoinInput(input []byte, useStrictMode bool) (common.Address
 	return inputStruct.Addr, inputStruct.Amount, err
 }
 
+// minterKey returns the storage key of [minter] under [prefix].
+// The key is hashed so it cannot collide with the allow list role slots.
+func minterKey(prefix []byte, minter common.Address) common.Hash {
+	return crypto.Keccak256Hash(prefix, minter.Bytes())
+}
+
+func uint64ToHash(value uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(value))
+}
+
+// SupplyCapStorageKey returns the storage key of the supply cap.
+func SupplyCapStorageKey() common.Hash {
+	return supplyCapStorageKey
+}
+
+// TotalMintedStorageKey returns the storage key of the total amount minted.
+func TotalMintedStorageKey() common.Hash {
+	return totalMintedStorageKey
+}
+
+// GetSupplyCap returns the maximum total amount that can be minted through mintNativeCoin.
+// A zero cap means minting is not capped.
+func GetSupplyCap(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, supplyCapStorageKey).Big()
+}
+
+// StoreSupplyCap sets the supply cap to [supplyCap].
+// assumes [supplyCap] fits in 256 bits.
+func StoreSupplyCap(stateDB contract.StateDB, supplyCap *big.Int) {
+	stateDB.SetState(ContractAddress, supplyCapStorageKey, common.BigToHash(supplyCap))
+}
+
+// GetTotalMinted returns the total amount minted through mintNativeCoin since Stromboli.
+// The initial mint of the config is not included.
+func GetTotalMinted(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, totalMintedStorageKey).Big()
+}
+
+// GetMintPeriod returns the length in seconds of the period after which minter budgets reset.
+// A zero period means minter budgets never reset.
+func GetMintPeriod(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, mintPeriodStorageKey).Big().Uint64()
+}
+
+// StoreMintPeriod sets the length in seconds of the minter budget period to [period].
+func StoreMintPeriod(stateDB contract.StateDB, period uint64) {
+	stateDB.SetState(ContractAddress, mintPeriodStorageKey, uint64ToHash(period))
+}
+
+// GetMinterBudget returns the amount [minter] can mint per mint period, or
+// [UnlimitedMinterBudget] if [minter] is only bounded by the supply cap.
+func GetMinterBudget(stateDB contract.StateDB, minter common.Address) *big.Int {
+	if stateDB.GetState(ContractAddress, minterKey(minterLimitedPrefix, minter)) == (common.Hash{}) {
+		return new(big.Int).Set(UnlimitedMinterBudget)
+	}
+	return stateDB.GetState(ContractAddress, minterKey(minterBudgetPrefix, minter)).Big()
+}
+
+// StoreMinterBudget sets the budget of [minter] to [budget]. A budget of
+// [UnlimitedMinterBudget] removes the budget of [minter].
+// assumes [budget] fits in 256 bits.
+func StoreMinterBudget(stateDB contract.StateDB, minter common.Address, budget *big.Int) {
+	limited := common.Hash{}
+	if budget.Cmp(UnlimitedMinterBudget) != 0 {
+		limited = common.BigToHash(common.Big1)
+	} else {
+		budget = common.Big0
+	}
+	stateDB.SetState(ContractAddress, minterKey(minterLimitedPrefix, minter), limited)
+	stateDB.SetState(ContractAddress, minterKey(minterBudgetPrefix, minter), common.BigToHash(budget))
+}
+
+// getMinterPeriod returns the start of the current mint period of [minter] and the amount
+// minted by [minter] since then, as of [timestamp].
+func getMinterPeriod(stateDB contract.StateDB, minter common.Address, timestamp uint64) (uint64, *big.Int) {
+	start := stateDB.GetState(ContractAddress, minterKey(minterPeriodPrefix, minter)).Big().Uint64()
+	period := GetMintPeriod(stateDB)
+	// The period of the minter starts with its first mint after the previous one elapsed.
+	if period != 0 && timestamp >= start && timestamp-start >= period {
+		return timestamp, new(big.Int)
+	}
+	return start, stateDB.GetState(ContractAddress, minterKey(minterMintedPrefix, minter)).Big()
+}
+
+// GetRemainingMinterBudget returns the amount [minter] can still mint in its current mint
+// period as of [timestamp]. Returns [UnlimitedMinterBudget] if [minter] has no budget.
+func GetRemainingMinterBudget(stateDB contract.StateDB, minter common.Address, timestamp uint64) *big.Int {
+	budget := GetMinterBudget(stateDB, minter)
+	if budget.Cmp(UnlimitedMinterBudget) == 0 {
+		return budget
+	}
+	_, minted := getMinterPeriod(stateDB, minter, timestamp)
+	if minted.Cmp(budget) >= 0 {
+		return new(big.Int)
+	}
+	return budget.Sub(budget, minted)
+}
+
+// SpendMintLimits records [amount] being minted by [minter] at [timestamp] against the supply
+// cap and the budget of [minter]. Returns an error without modifying state if either limit
+// would be exceeded.
+func SpendMintLimits(stateDB contract.StateDB, minter common.Address, amount *big.Int, timestamp uint64) error {
+	totalMinted := new(big.Int).Add(GetTotalMinted(stateDB), amount)
+	supplyCap := GetSupplyCap(stateDB)
+	if totalMinted.BitLen() > 256 || (supplyCap.Sign() > 0 && totalMinted.Cmp(supplyCap) > 0) {
+		return fmt.Errorf("%w: minting %s with %s already minted of cap %s", ErrSupplyCapExceeded, amount, GetTotalMinted(stateDB), supplyCap)
+	}
+
+	if budget := GetMinterBudget(stateDB, minter); budget.Cmp(UnlimitedMinterBudget) != 0 {
+		start, minted := getMinterPeriod(stateDB, minter, timestamp)
+		minted.Add(minted, amount)
+		if minted.Cmp(budget) > 0 {
+			return fmt.Errorf("%w: minting %s exceeds remaining budget %s of %s", ErrMinterBudgetExceeded, amount, GetRemainingMinterBudget(stateDB, minter, timestamp), minter)
+		}
+		stateDB.SetState(ContractAddress, minterKey(minterPeriodPrefix, minter), uint64ToHash(start))
+		stateDB.SetState(ContractAddress, minterKey(minterMintedPrefix, minter), common.BigToHash(minted))
+	}
+
+	stateDB.SetState(ContractAddress, totalMintedStorageKey, common.BigToHash(totalMinted))
+	return nil
+}
+
 // mintNativeCoin checks if the caller is permissioned for minting operation.
 // The execution function parses the [input] into native coin amount and receiver address.
 func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
@@ -97,6 +251,15 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMint, caller)
 	}
 
+	if contract.IsStromboliActivated(accessibleState) {
+		if remainingGas, err = contract.DeductGas(remainingGas, MintLimitsGasCost); err != nil {
+			return nil, 0, err
+		}
+		if err := SpendMintLimits(stateDB, caller, amount, accessibleState.GetBlockContext().Timestamp()); err != nil {
+			return nil, remainingGas, err
+		}
+	}
+
 	if contract.IsDurangoActivated(accessibleState) {
 		if remainingGas, err = contract.DeductGas(remainingGas, NativeCoinMintedEventGasCost); err != nil {
 			return nil, 0, err
@@ -122,6 +285,253 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 	return []byte{}, remainingGas, nil
 }
 
+// PackSetSupplyCap packs [supplyCap] into the appropriate arguments for setSupplyCap.
+func PackSetSupplyCap(supplyCap *big.Int) ([]byte, error) {
+	return NativeMinterABI.Pack("setSupplyCap", supplyCap)
+}
+
+// UnpackSetSupplyCapInput attempts to unpack [input] into the supply cap argument of setSupplyCap.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetSupplyCapInput(input []byte) (*big.Int, error) {
+	inputStruct := struct {
+		Cap *big.Int
+	}{}
+	if err := NativeMinterABI.UnpackInputIntoInterface(&inputStruct, "setSupplyCap", input, false); err != nil {
+		return nil, err
+	}
+	return inputStruct.Cap, nil
+}
+
+// setSupplyCap checks if the caller is an admin of the minter list and sets the supply cap in [input].
+func setSupplyCap(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetSupplyCapGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	supplyCap, err := UnpackSetSupplyCapInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins may change the mint limits, so that a compromised minter cannot lift them.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetSupplyCap, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, SupplyCapChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackSupplyCapChangedEvent(caller, GetSupplyCap(stateDB), supplyCap)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	StoreSupplyCap(stateDB, supplyCap)
+	return []byte{}, remainingGas, nil
+}
+
+// PackSetMinterBudget packs [minter] and [budget] into the appropriate arguments for setMinterBudget.
+func PackSetMinterBudget(minter common.Address, budget *big.Int) ([]byte, error) {
+	return NativeMinterABI.Pack("setMinterBudget", minter, budget)
+}
+
+// UnpackSetMinterBudgetInput attempts to unpack [input] into the minter and budget arguments
+// of setMinterBudget.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetMinterBudgetInput(input []byte) (common.Address, *big.Int, error) {
+	inputStruct := struct {
+		Minter common.Address
+		Budget *big.Int
+	}{}
+	if err := NativeMinterABI.UnpackInputIntoInterface(&inputStruct, "setMinterBudget", input, false); err != nil {
+		return common.Address{}, nil, err
+	}
+	return inputStruct.Minter, inputStruct.Budget, nil
+}
+
+// setMinterBudget checks if the caller is an admin of the minter list and sets the budget
+// of the minter in [input].
+func setMinterBudget(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetMinterBudgetGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	minter, budget, err := UnpackSetMinterBudgetInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins may change the mint limits, so that a compromised minter cannot lift them.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetMinterBudget, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, MinterBudgetChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackMinterBudgetChangedEvent(caller, minter, GetMinterBudget(stateDB, minter), budget)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	StoreMinterBudget(stateDB, minter, budget)
+	return []byte{}, remainingGas, nil
+}
+
+// PackSetMintPeriod packs [period] into the appropriate arguments for setMintPeriod.
+func PackSetMintPeriod(period uint64) ([]byte, error) {
+	return NativeMinterABI.Pack("setMintPeriod", new(big.Int).SetUint64(period))
+}
+
+// UnpackSetMintPeriodInput attempts to unpack [input] into the period argument of setMintPeriod.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetMintPeriodInput(input []byte) (uint64, error) {
+	inputStruct := struct {
+		Period *big.Int
+	}{}
+	if err := NativeMinterABI.UnpackInputIntoInterface(&inputStruct, "setMintPeriod", input, false); err != nil {
+		return 0, err
+	}
+	if !inputStruct.Period.IsUint64() {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidMintPeriod, inputStruct.Period)
+	}
+	return inputStruct.Period.Uint64(), nil
+}
+
+// setMintPeriod checks if the caller is an admin of the minter list and sets the mint period
+// in [input]. The current period of each minter keeps its start, so a shorter period takes
+// effect from the next mint of each minter.
+func setMintPeriod(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetMintPeriodGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	period, err := UnpackSetMintPeriodInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins may change the mint limits, so that a compromised minter cannot lift them.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetMintPeriod, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, MintPeriodChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackMintPeriodChangedEvent(caller, GetMintPeriod(stateDB), period)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	StoreMintPeriod(stateDB, period)
+	return []byte{}, remainingGas, nil
+}
+
+// PackMintLimits packs the input of mintLimits.
+func PackMintLimits() ([]byte, error) {
+	return NativeMinterABI.Pack("mintLimits")
+}
+
+// PackMintLimitsOutput attempts to pack [supplyCap], [totalMinted] and [mintPeriod]
+// to conform the ABI outputs of mintLimits.
+func PackMintLimitsOutput(supplyCap *big.Int, totalMinted *big.Int, mintPeriod uint64) ([]byte, error) {
+	return NativeMinterABI.PackOutput("mintLimits", supplyCap, totalMinted, new(big.Int).SetUint64(mintPeriod))
+}
+
+// mintLimits returns the supply cap, the total amount minted and the mint period.
+func mintLimits(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MintLimitsViewGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	packedOutput, err := PackMintLimitsOutput(GetSupplyCap(stateDB), GetTotalMinted(stateDB), GetMintPeriod(stateDB))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackMinterBudget packs [minter] into the appropriate arguments for minterBudget.
+func PackMinterBudget(minter common.Address) ([]byte, error) {
+	return NativeMinterABI.Pack("minterBudget", minter)
+}
+
+// UnpackMinterBudgetInput attempts to unpack [input] into the minter argument of minterBudget.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackMinterBudgetInput(input []byte) (common.Address, error) {
+	res, err := NativeMinterABI.UnpackInput("minterBudget", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return unpacked, nil
+}
+
+// PackMinterBudgetOutput attempts to pack [budget] and [remaining] to conform the ABI
+// outputs of minterBudget.
+func PackMinterBudgetOutput(budget *big.Int, remaining *big.Int) ([]byte, error) {
+	return NativeMinterABI.PackOutput("minterBudget", budget, remaining)
+}
+
+// minterBudget returns the budget of the minter in [input] and the amount it can still mint
+// in its current mint period.
+func minterBudget(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MinterBudgetGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	minter, err := UnpackMinterBudgetInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	packedOutput, err := PackMinterBudgetOutput(GetMinterBudget(stateDB, minter), GetRemainingMinterBudget(stateDB, minter, timestamp))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
 // createNativeMinterPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for ContractAddress.
 func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
@@ -137,8 +547,26 @@ func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
 		if !ok {
 			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
 		}
-		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
 	}
+
+	// Mint limits are only available after Stromboli.
+	stromboliFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"minterBudget":    minterBudget,
+		"mintLimits":      mintLimits,
+		"setMinterBudget": setMinterBudget,
+		"setMintPeriod":   setMintPeriod,
+		"setSupplyCap":    setSupplyCap,
+	}
+	for name, function := range stromboliFunctionMap {
+		method, ok := NativeMinterABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
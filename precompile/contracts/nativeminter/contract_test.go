@@ -180,6 +180,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -203,6 +204,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -224,6 +226,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -245,9 +248,383 @@ var (
 				assertNativeCoinMintedEvent(t, logsTopics, logsData, allowlist.TestEnabledAddr, allowlist.TestEnabledAddr, common.Big1)
 			},
 		},
+		"setSupplyCap before Stromboli should fail": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSupplyCap(big.NewInt(100))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"setSupplyCap from Enabled should fail": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSupplyCap(big.NewInt(100))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetSupplyCapGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetSupplyCap.Error(),
+		},
+		"setSupplyCap from Admin should succeed": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSupplyCap(big.NewInt(100))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetSupplyCapGasCost + SupplyCapChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, big.NewInt(100).Cmp(GetSupplyCap(stateDB)))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, []common.Hash{
+					NativeMinterABI.Events["SupplyCapChanged"].ID,
+					allowlist.TestAdminAddr.Hash(),
+				}, logsTopics[0])
+				_, expectedData, err := PackSupplyCapChangedEvent(allowlist.TestAdminAddr, common.Big0, big.NewInt(100))
+				require.NoError(t, err)
+				require.Equal(t, expectedData, logsData[0])
+			},
+		},
+		"readOnly setSupplyCap should fail": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSupplyCap(big.NewInt(100))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetSupplyCapGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"setMinterBudget from Manager should fail": {
+			Caller:        allowlist.TestManagerAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMinterBudget(allowlist.TestEnabledAddr, big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMinterBudgetGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetMinterBudget.Error(),
+		},
+		"setMinterBudget from Admin should succeed": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    setMintLimits(nil, 0, big.NewInt(5)),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMinterBudget(allowlist.TestEnabledAddr, big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMinterBudgetGasCost + MinterBudgetChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, big.NewInt(10).Cmp(GetMinterBudget(stateDB, allowlist.TestEnabledAddr)))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, []common.Hash{
+					NativeMinterABI.Events["MinterBudgetChanged"].ID,
+					allowlist.TestAdminAddr.Hash(),
+					allowlist.TestEnabledAddr.Hash(),
+				}, logsTopics[0])
+				_, expectedData, err := PackMinterBudgetChangedEvent(allowlist.TestAdminAddr, allowlist.TestEnabledAddr, big.NewInt(5), big.NewInt(10))
+				require.NoError(t, err)
+				require.Equal(t, expectedData, logsData[0])
+			},
+		},
+		"setMintPeriod from Enabled should fail": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMintPeriod(60)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMintPeriodGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetMintPeriod.Error(),
+		},
+		"setMintPeriod exceeding 64 bits should fail": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := NativeMinterABI.Pack("setMintPeriod", new(big.Int).Lsh(common.Big1, 64))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMintPeriodGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidMintPeriod.Error(),
+		},
+		"setMintPeriod from Admin should succeed": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    setMintLimits(nil, 60, big.NewInt(10)),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMintPeriod(3600)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMintPeriodGasCost + MintPeriodChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, uint64(3600), GetMintPeriod(stateDB))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, []common.Hash{
+					NativeMinterABI.Events["MintPeriodChanged"].ID,
+					allowlist.TestAdminAddr.Hash(),
+				}, logsTopics[0])
+				_, expectedData, err := PackMintPeriodChangedEvent(allowlist.TestAdminAddr, 60, 3600)
+				require.NoError(t, err)
+				require.Equal(t, expectedData, logsData[0])
+			},
+		},
+		"readOnly setMintPeriod should fail": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMintPeriod(60)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMintPeriodGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"setMinterBudget to unlimited should remove the budget": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    setMintLimits(nil, 0, big.NewInt(5)),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetMinterBudget(allowlist.TestEnabledAddr, UnlimitedMinterBudget)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetMinterBudgetGasCost + MinterBudgetChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, UnlimitedMinterBudget.Cmp(GetMinterBudget(stateDB, allowlist.TestEnabledAddr)))
+				require.NoError(t, SpendMintLimits(stateDB, allowlist.TestEnabledAddr, big.NewInt(100), testMintTimestamp))
+			},
+		},
+		"mint with zero minter budget should fail": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        setMintLimits(nil, 60, common.Big0),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMintNativeCoin(allowlist.TestEnabledAddr, common.Big1)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MintGasCost + MintLimitsGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrMinterBudgetExceeded.Error(),
+		},
+		"mint within limits after Stromboli should succeed": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        setMintLimits(big.NewInt(100), 60, big.NewInt(10)),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMintNativeCoin(allowlist.TestEnabledAddr, big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MintGasCost + MintLimitsGasCost + NativeCoinMintedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, big.NewInt(10).Cmp(stateDB.GetBalance(allowlist.TestEnabledAddr)), "expected minted funds")
+				require.Zero(t, big.NewInt(10).Cmp(GetTotalMinted(stateDB)))
+				require.Zero(t, GetRemainingMinterBudget(stateDB, allowlist.TestEnabledAddr, testMintTimestamp).Sign())
+			},
+		},
+		"mint without minter budget is bounded by supply cap": {
+			Caller:            allowlist.TestManagerAddr,
+			BeforeHook:        setMintLimits(big.NewInt(100), 60, big.NewInt(10)),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMintNativeCoin(allowlist.TestManagerAddr, big.NewInt(101))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MintGasCost + MintLimitsGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrSupplyCapExceeded.Error(),
+		},
+		"mint exceeding minter budget should fail": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        setMintLimits(big.NewInt(100), 60, big.NewInt(10)),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMintNativeCoin(allowlist.TestEnabledAddr, big.NewInt(11))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MintGasCost + MintLimitsGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrMinterBudgetExceeded.Error(),
+		},
+		"minter budget resets after mint period": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setMintLimits(big.NewInt(100), 60, big.NewInt(10))(t, state)
+				// Exhaust the budget one full period before the mint.
+				require.NoError(t, SpendMintLimits(state, allowlist.TestEnabledAddr, big.NewInt(10), testMintTimestamp-60))
+				require.Zero(t, GetRemainingMinterBudget(state, allowlist.TestEnabledAddr, testMintTimestamp-1).Sign())
+			},
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMintNativeCoin(allowlist.TestEnabledAddr, big.NewInt(10))
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MintGasCost + MintLimitsGasCost + NativeCoinMintedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, big.NewInt(20).Cmp(GetTotalMinted(stateDB)))
+			},
+		},
+		"read mint limits": {
+			Caller:        allowlist.TestNoRoleAddr,
+			BeforeHook:    setMintLimits(big.NewInt(100), 60, big.NewInt(10)),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMintLimits()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MintLimitsViewGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackMintLimitsOutput(big.NewInt(100), common.Big0, 60)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"read minter budget": {
+			Caller:            allowlist.TestNoRoleAddr,
+			BeforeHook:        setMintLimits(big.NewInt(100), 60, big.NewInt(10)),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMinterBudget(allowlist.TestEnabledAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MinterBudgetGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackMinterBudgetOutput(big.NewInt(10), big.NewInt(10))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"read minter budget without budget": {
+			Caller:            allowlist.TestNoRoleAddr,
+			BeforeHook:        setMintLimits(big.NewInt(100), 60, big.NewInt(10)),
+			ChainConfigFn:     stromboliChainConfig,
+			SetupBlockContext: setTestTimestamp(testMintTimestamp),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackMinterBudget(allowlist.TestManagerAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: MinterBudgetGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackMinterBudgetOutput(UnlimitedMinterBudget, UnlimitedMinterBudget)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"mint limits configured from config": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			Config: &Config{
+				SupplyCap:  math.NewHexOrDecimal256(100),
+				MintPeriod: 60,
+				MinterBudgets: map[common.Address]*math.HexOrDecimal256{
+					allowlist.TestEnabledAddr: math.NewHexOrDecimal256(10),
+				},
+			},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, big.NewInt(100).Cmp(GetSupplyCap(stateDB)))
+				require.Equal(t, uint64(60), GetMintPeriod(stateDB))
+				require.Zero(t, big.NewInt(10).Cmp(GetMinterBudget(stateDB, allowlist.TestEnabledAddr)))
+			},
+		},
 	}
 )
 
+const testMintTimestamp uint64 = 1_000
+
+// setMintLimits returns a BeforeHook that sets the default roles, [supplyCap], [mintPeriod]
+// and the [budget] of the enabled test address.
+func setMintLimits(supplyCap *big.Int, mintPeriod uint64, budget *big.Int) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		allowlist.SetDefaultRoles(Module.Address)(t, state)
+		if supplyCap != nil {
+			StoreSupplyCap(state, supplyCap)
+		}
+		StoreMintPeriod(state, mintPeriod)
+		StoreMinterBudget(state, allowlist.TestEnabledAddr, budget)
+	}
+}
+
+func setTestTimestamp(timestamp uint64) func(*contract.MockBlockContext) {
+	return func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+		mbc.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+	}
+}
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
 func TestContractNativeMinterRun(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
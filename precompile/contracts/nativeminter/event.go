@@ -16,6 +16,21 @@ const (
 	// It is the base gas cost + the gas cost of the topics (signature, sender, recipient)
 	// and the gas cost of the non-indexed data (32 bytes for amount).
 	NativeCoinMintedEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*common.HashLength
+
+	// SupplyCapChangedEventGasCost is the gas cost of the SupplyCapChanged event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender)
+	// and the gas cost of the non-indexed data (32 bytes each for oldCap and newCap).
+	SupplyCapChangedEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*2*common.HashLength
+
+	// MinterBudgetChangedEventGasCost is the gas cost of the MinterBudgetChanged event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender, minter)
+	// and the gas cost of the non-indexed data (32 bytes each for oldBudget and newBudget).
+	MinterBudgetChangedEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*2*common.HashLength
+
+	// MintPeriodChangedEventGasCost is the gas cost of the MintPeriodChanged event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender)
+	// and the gas cost of the non-indexed data (32 bytes each for oldPeriod and newPeriod).
+	MintPeriodChangedEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*2*common.HashLength
 )
 
 // PackNativeCoinMintedEvent packs the event into the appropriate arguments for NativeCoinMinted.
@@ -32,3 +47,21 @@ func UnpackNativeCoinMintedEventData(dataBytes []byte) (*big.Int, error) {
 	err := NativeMinterABI.UnpackIntoInterface(&eventData, "NativeCoinMinted", dataBytes)
 	return eventData.Amount, err
 }
+
+// PackSupplyCapChangedEvent packs the event into the appropriate arguments for SupplyCapChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackSupplyCapChangedEvent(sender common.Address, oldCap *big.Int, newCap *big.Int) ([]common.Hash, []byte, error) {
+	return NativeMinterABI.PackEvent("SupplyCapChanged", sender, oldCap, newCap)
+}
+
+// PackMinterBudgetChangedEvent packs the event into the appropriate arguments for MinterBudgetChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackMinterBudgetChangedEvent(sender common.Address, minter common.Address, oldBudget *big.Int, newBudget *big.Int) ([]common.Hash, []byte, error) {
+	return NativeMinterABI.PackEvent("MinterBudgetChanged", sender, minter, oldBudget, newBudget)
+}
+
+// PackMintPeriodChangedEvent packs the event into the appropriate arguments for MintPeriodChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackMintPeriodChangedEvent(sender common.Address, oldPeriod uint64, newPeriod uint64) ([]common.Hash, []byte, error) {
+	return NativeMinterABI.PackEvent("MintPeriodChanged", sender, new(big.Int).SetUint64(oldPeriod), new(big.Int).SetUint64(newPeriod))
+}
@@ -19,14 +19,19 @@ var _ contract.Configurator = &configurator{}
 // must be unique across all precompiles.
 const ConfigKey = "contractNativeMinterConfig"
 
+// Version is the interface version of the precompile returned by precompileVersion.
+const Version = 2
+
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000001")
 
 // Module is the precompile module. It is used to register the precompile contract.
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
+	Version:      Version,
 	Contract:     ContractNativeMinterPrecompile,
 	Configurator: &configurator{},
+	ABI:          &NativeMinterABI,
 }
 
 type configurator struct{}
@@ -56,6 +61,13 @@ func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg pre
 			state.AddBalance(to, bigIntAmount)
 		}
 	}
+	if config.SupplyCap != nil {
+		StoreSupplyCap(state, (*big.Int)(config.SupplyCap))
+	}
+	StoreMintPeriod(state, config.MintPeriod)
+	for minter, budget := range config.MinterBudgets {
+		StoreMinterBudget(state, minter, (*big.Int)(budget))
+	}
 
 	return config.AllowListConfig.Configure(chainConfig, ContractAddress, state, blockContext)
 }
@@ -0,0 +1,147 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompileregistry
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	GetPrecompileVersionGasCost uint64 = contract.ReadGasCostPerSlot
+	// GetEnabledPrecompilesGasCostPerModule is charged for each registered precompile module
+	// checked by getEnabledPrecompiles.
+	GetEnabledPrecompilesGasCostPerModule uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	// PrecompileRegistryRawABI contains the raw ABI of PrecompileRegistry contract.
+	//go:embed contract.abi
+	PrecompileRegistryRawABI string
+
+	PrecompileRegistryABI        = contract.ParseABI(PrecompileRegistryRawABI)
+	PrecompileRegistryPrecompile = createPrecompileRegistryPrecompile()
+)
+
+// IsPrecompileEnabled returns true if the precompile at [address] is currently enabled.
+// Activating a precompile sets the nonce of its address to 1 and disabling it
+// self destructs the account, so a non-zero nonce marks an enabled precompile.
+func IsPrecompileEnabled(stateDB contract.StateDB, address common.Address) bool {
+	return stateDB.GetNonce(address) != 0
+}
+
+// PackGetPrecompileVersion packs [precompileAddress] into the appropriate arguments for getPrecompileVersion.
+func PackGetPrecompileVersion(precompileAddress common.Address) ([]byte, error) {
+	return PrecompileRegistryABI.Pack("getPrecompileVersion", precompileAddress)
+}
+
+// PackGetPrecompileVersionOutput attempts to pack given [enabled] and [version]
+// to conform the ABI outputs.
+func PackGetPrecompileVersionOutput(enabled bool, version uint64) ([]byte, error) {
+	return PrecompileRegistryABI.PackOutput("getPrecompileVersion", enabled, new(big.Int).SetUint64(version))
+}
+
+// getPrecompileVersion returns whether the precompile at the address in [input] is enabled
+// and its interface version. Addresses that are not registered precompiles are reported as disabled.
+func getPrecompileVersion(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetPrecompileVersionGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	res, err := PrecompileRegistryABI.UnpackInput("getPrecompileVersion", input, false)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	precompileAddress := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+
+	var (
+		enabled bool
+		version uint64
+	)
+	if module, ok := modules.GetPrecompileModuleByAddress(precompileAddress); ok && IsPrecompileEnabled(accessibleState.GetStateDB(), precompileAddress) {
+		enabled = true
+		version = module.Version
+	}
+	packedOutput, err := PackGetPrecompileVersionOutput(enabled, version)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackGetEnabledPrecompiles packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetEnabledPrecompiles() ([]byte, error) {
+	return PrecompileRegistryABI.Pack("getEnabledPrecompiles")
+}
+
+// PackGetEnabledPrecompilesOutput attempts to pack given [addresses] and [versions]
+// to conform the ABI outputs.
+func PackGetEnabledPrecompilesOutput(addresses []common.Address, versions []uint64) ([]byte, error) {
+	bigVersions := make([]*big.Int, len(versions))
+	for i, version := range versions {
+		bigVersions[i] = new(big.Int).SetUint64(version)
+	}
+	return PrecompileRegistryABI.PackOutput("getEnabledPrecompiles", addresses, bigVersions)
+}
+
+// getEnabledPrecompiles returns the addresses and interface versions of all currently
+// enabled precompiles, sorted by address.
+func getEnabledPrecompiles(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	registeredModules := modules.RegisteredModules()
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetEnabledPrecompilesGasCostPerModule*uint64(len(registeredModules))); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	stateDB := accessibleState.GetStateDB()
+	var (
+		addresses []common.Address
+		versions  []uint64
+	)
+	for _, module := range registeredModules {
+		if IsPrecompileEnabled(stateDB, module.Address) {
+			addresses = append(addresses, module.Address)
+			versions = append(versions, module.Version)
+		}
+	}
+	packedOutput, err := PackGetEnabledPrecompilesOutput(addresses, versions)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createPrecompileRegistryPrecompile returns a StatefulPrecompiledContract with getters for the installed precompiles.
+func createPrecompileRegistryPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getEnabledPrecompiles": getEnabledPrecompiles,
+		"getPrecompileVersion":  getPrecompileVersion,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := PrecompileRegistryABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
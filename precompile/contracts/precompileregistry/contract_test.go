@@ -0,0 +1,144 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompileregistry_test
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/precompileregistry"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
+// enablePrecompiles marks [addresses] as enabled the same way precompile activation does.
+func enablePrecompiles(addresses ...common.Address) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		for _, address := range addresses {
+			state.SetNonce(address, 1)
+		}
+	}
+}
+
+func TestPrecompileRegistryRun(t *testing.T) {
+	registeredModules := len(modules.RegisteredModules())
+	tests := map[string]testutils.PrecompileTest{
+		"precompileVersion": {
+			Caller:        common.Address{1},
+			ChainConfigFn: stromboliChainConfig,
+			Input:         contract.PrecompileVersionSelector,
+			SuppliedGas:   contract.PrecompileVersionGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   contract.PackPrecompileVersionOutput(precompileregistry.Version),
+		},
+		"precompileVersion before Stromboli": {
+			Caller:      common.Address{1},
+			Input:       contract.PrecompileVersionSelector,
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"getPrecompileVersion of enabled precompile": {
+			Caller:     common.Address{1},
+			BeforeHook: enablePrecompiles(feemanager.ContractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := precompileregistry.PackGetPrecompileVersion(feemanager.ContractAddress)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: precompileregistry.GetPrecompileVersionGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := precompileregistry.PackGetPrecompileVersionOutput(true, feemanager.Version)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"getPrecompileVersion of disabled precompile": {
+			Caller: common.Address{1},
+			InputFn: func(t testing.TB) []byte {
+				input, err := precompileregistry.PackGetPrecompileVersion(feemanager.ContractAddress)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: precompileregistry.GetPrecompileVersionGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := precompileregistry.PackGetPrecompileVersionOutput(false, 0)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"getPrecompileVersion of unknown address": {
+			Caller:     common.Address{1},
+			BeforeHook: enablePrecompiles(common.Address{2}),
+			InputFn: func(t testing.TB) []byte {
+				input, err := precompileregistry.PackGetPrecompileVersion(common.Address{2})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: precompileregistry.GetPrecompileVersionGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := precompileregistry.PackGetPrecompileVersionOutput(false, 0)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"getPrecompileVersion insufficient gas": {
+			Caller: common.Address{1},
+			InputFn: func(t testing.TB) []byte {
+				input, err := precompileregistry.PackGetPrecompileVersion(feemanager.ContractAddress)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: precompileregistry.GetPrecompileVersionGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"getEnabledPrecompiles": {
+			Caller:     common.Address{1},
+			BeforeHook: enablePrecompiles(feemanager.ContractAddress, txallowlist.ContractAddress, precompileregistry.ContractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := precompileregistry.PackGetEnabledPrecompiles()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: precompileregistry.GetEnabledPrecompilesGasCostPerModule * uint64(registeredModules),
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := precompileregistry.PackGetEnabledPrecompilesOutput(
+					[]common.Address{txallowlist.ContractAddress, feemanager.ContractAddress, precompileregistry.ContractAddress},
+					[]uint64{txallowlist.Version, feemanager.Version, precompileregistry.Version},
+				)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+	}
+	testutils.RunPrecompileTests(t, precompileregistry.Module, state.NewTestStateDB, tests)
+}
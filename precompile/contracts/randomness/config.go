@@ -0,0 +1,135 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package randomness
+
+import (
+	"errors"
+	"fmt"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/predicate"
+)
+
+var (
+	_ precompileconfig.Config     = &Config{}
+	_ precompileconfig.Predicater = &Config{}
+)
+
+var (
+	errRandomnessCannotBeActivated = errors.New("randomness cannot be activated before Stromboli")
+	errInvalidPredicateBytes       = errors.New("cannot unpack predicate bytes")
+	errCannotParseWarpMsg          = errors.New("cannot parse warp message")
+	errInvalidSourceChain          = errors.New("warp message is not from this chain")
+	errInvalidBlockHashPayload     = errors.New("cannot unpack block hash payload")
+	errFailedVerification          = errors.New("cannot verify randomness signature")
+)
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for randomness.
+// The random value of a round is derived from the aggregate BLS signature of the
+// chain's validators over the hash of the block of that round, so the predicates
+// of this precompile are verified with the same quorum rules as Warp: any set of
+// validators whose stake reaches [QuorumNumerator] of the total stake can sign.
+// Randomness therefore stays available as long as validators holding the quorum
+// of the stake are online to sign a round within [MaxRoundAge] blocks, and a
+// round that no quorum signed in time can never be revealed.
+type Config struct {
+	precompileconfig.Upgrade
+	QuorumNumerator uint64 `json:"quorumNumerator"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// randomness with the given quorum numerator.
+func NewConfig(blockTimestamp *uint64, quorumNumerator uint64) *Config {
+	return &Config{
+		Upgrade:         precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		QuorumNumerator: quorumNumerator,
+	}
+}
+
+// NewDefaultConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// randomness with the default quorum numerator (0 denotes using the default).
+func NewDefaultConfig(blockTimestamp *uint64) *Config {
+	return NewConfig(blockTimestamp, 0)
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables randomness.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the randomness precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if c.Timestamp() != nil && !chainConfig.IsStromboli(*c.Timestamp()) {
+		return errRandomnessCannotBeActivated
+	}
+
+	if c.QuorumNumerator > warp.WarpQuorumDenominator {
+		return fmt.Errorf("cannot specify quorum numerator (%d) > quorum denominator (%d)", c.QuorumNumerator, warp.WarpQuorumDenominator)
+	}
+	// If a non-default quorum numerator is specified and it is less than the minimum, return an error
+	if c.QuorumNumerator != 0 && c.QuorumNumerator < warp.WarpQuorumNumeratorMinimum {
+		return fmt.Errorf("cannot specify quorum numerator (%d) < min quorum numerator (%d)", c.QuorumNumerator, warp.WarpQuorumNumeratorMinimum)
+	}
+	return nil
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	equals := c.Upgrade.Equal(&other.Upgrade)
+	return equals && c.QuorumNumerator == other.QuorumNumerator
+}
+
+// warpConfig returns the Warp config used to charge for and verify the signatures
+// of the predicates of this precompile.
+func (c *Config) warpConfig() *warp.Config {
+	return warp.NewConfig(c.Timestamp(), c.QuorumNumerator)
+}
+
+// PredicateGas returns the amount of gas necessary to verify the predicate, which is
+// the same as verifying a Warp message of the same size and number of signers.
+func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
+	return c.warpConfig().PredicateGas(predicateBytes)
+}
+
+// VerifyPredicate returns whether the predicate described by [predicateBytes] is a Warp
+// message from this chain with a block hash payload, signed by a quorum of its validators.
+func (c *Config) VerifyPredicate(predicateContext *precompileconfig.PredicateContext, predicateBytes []byte) error {
+	unpackedPredicateBytes, err := predicate.UnpackPredicate(predicateBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidPredicateBytes, err)
+	}
+	warpMsg, err := avalancheWarp.ParseMessage(unpackedPredicateBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errCannotParseWarpMsg, err)
+	}
+	if warpMsg.SourceChainID != predicateContext.SnowCtx.ChainID {
+		return fmt.Errorf("%w: %s", errInvalidSourceChain, warpMsg.SourceChainID)
+	}
+	if _, err := payload.ParseHash(warpMsg.Payload); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidBlockHashPayload, err)
+	}
+	if err := c.warpConfig().VerifyPredicate(predicateContext, predicateBytes); err != nil {
+		return fmt.Errorf("%w: %w", errFailedVerification, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package randomness
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func stromboliVerifyChainConfig(t *testing.T, isStromboli bool) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	config.EXPECT().IsStromboli(gomock.Any()).Return(isStromboli).AnyTimes()
+	return config
+}
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"quorum numerator less than minimum": {
+			Config:        NewConfig(utils.NewUint64(3), warp.WarpQuorumNumeratorMinimum-1),
+			ChainConfig:   stromboliVerifyChainConfig(t, true),
+			ExpectedError: fmt.Sprintf("cannot specify quorum numerator (%d) < min quorum numerator (%d)", warp.WarpQuorumNumeratorMinimum-1, warp.WarpQuorumNumeratorMinimum),
+		},
+		"quorum numerator greater than quorum denominator": {
+			Config:        NewConfig(utils.NewUint64(3), warp.WarpQuorumDenominator+1),
+			ChainConfig:   stromboliVerifyChainConfig(t, true),
+			ExpectedError: fmt.Sprintf("cannot specify quorum numerator (%d) > quorum denominator (%d)", warp.WarpQuorumDenominator+1, warp.WarpQuorumDenominator),
+		},
+		"default quorum numerator": {
+			Config:      NewDefaultConfig(utils.NewUint64(3)),
+			ChainConfig: stromboliVerifyChainConfig(t, true),
+		},
+		"valid quorum numerator": {
+			Config:      NewConfig(utils.NewUint64(3), warp.WarpQuorumNumeratorMinimum+1),
+			ChainConfig: stromboliVerifyChainConfig(t, true),
+		},
+		"invalid cannot be activated before Stromboli activation": {
+			Config:        NewDefaultConfig(utils.NewUint64(3)),
+			ChainConfig:   stromboliVerifyChainConfig(t, false),
+			ExpectedError: errRandomnessCannotBeActivated.Error(),
+		},
+		"disable before Stromboli activation": {
+			Config:        NewDisableConfig(utils.NewUint64(3)),
+			ChainConfig:   stromboliVerifyChainConfig(t, false),
+			ExpectedError: errRandomnessCannotBeActivated.Error(),
+		},
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewDefaultConfig(utils.NewUint64(3)),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewDefaultConfig(utils.NewUint64(3)),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewDefaultConfig(utils.NewUint64(3)),
+			Other:    NewDefaultConfig(utils.NewUint64(4)),
+			Expected: false,
+		},
+		"different quorum numerator": {
+			Config:   NewConfig(utils.NewUint64(3), warp.WarpQuorumNumeratorMinimum+1),
+			Other:    NewConfig(utils.NewUint64(3), warp.WarpQuorumNumeratorMinimum+2),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), warp.WarpQuorumNumeratorMinimum+1),
+			Other:    NewConfig(utils.NewUint64(3), warp.WarpQuorumNumeratorMinimum+1),
+			Expected: true,
+		},
+	}
+	testutils.RunEqualTests(t, tests)
+}
@@ -0,0 +1,257 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package randomness
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// MaxRoundAge is the maximum number of blocks a round can be behind the current block
+	// to be revealed. It matches the range of block hashes available to the BLOCKHASH opcode.
+	MaxRoundAge uint64 = 256
+
+	GetRandomGasCost uint64 = contract.ReadGasCostPerSlot // read the stored random value
+	// RevealRandomGasCost is charged by getRandom in addition to [GetRandomGasCost]
+	// when it reveals the random value of a round and stores it.
+	RevealRandomGasCost uint64 = contract.WriteGasCostPerSlot
+)
+
+var (
+	ErrInvalidRound          = errors.New("invalid round")
+	ErrRandomnessUnavailable = errors.New("randomness is not available for round")
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// RandomnessRawABI contains the raw ABI of Randomness contract.
+	//go:embed contract.abi
+	RandomnessRawABI string
+
+	RandomnessABI = contract.ParseABI(RandomnessRawABI)
+
+	RandomnessPrecompile = createRandomnessPrecompile()
+
+	randomValuePrefix = []byte("randomness")
+)
+
+// RandomValueStorageKey returns the storage key of the random value of [round].
+func RandomValueStorageKey(round uint64) common.Hash {
+	return crypto.Keccak256Hash(randomValuePrefix, common.BigToHash(new(big.Int).SetUint64(round)).Bytes())
+}
+
+// GetRandomValue returns the random value revealed for [round], or the empty hash
+// if it has not been revealed yet.
+func GetRandomValue(stateDB contract.StateDB, round uint64) common.Hash {
+	return stateDB.GetState(ContractAddress, RandomValueStorageKey(round))
+}
+
+// StoreRandomValue sets the random value of [round] to [value].
+func StoreRandomValue(stateDB contract.StateDB, round uint64, value common.Hash) {
+	stateDB.SetState(ContractAddress, RandomValueStorageKey(round), value)
+}
+
+// DeriveRandomValue returns the random value for the block hash signed by [signature].
+// BLS signatures are unique per signer set, so the value cannot be chosen by the
+// transaction sender once the signature is produced.
+func DeriveRandomValue(signature *avalancheWarp.BitSetSignature) common.Hash {
+	return crypto.Keccak256Hash(signature.Signature[:])
+}
+
+// PackGetRandom packs [round] into the appropriate arguments for getRandom.
+func PackGetRandom(round *big.Int) ([]byte, error) {
+	return RandomnessABI.Pack("getRandom", round)
+}
+
+// UnpackGetRandomInput attempts to unpack [input] into the round argument of getRandom.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetRandomInput(input []byte) (*big.Int, error) {
+	res, err := RandomnessABI.UnpackInput("getRandom", input, false)
+	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+	return unpacked, nil
+}
+
+// PackGetRandomOutput attempts to pack given [randomValue] of type *big.Int
+// to conform the ABI outputs.
+func PackGetRandomOutput(randomValue *big.Int) ([]byte, error) {
+	return RandomnessABI.PackOutput("getRandom", randomValue)
+}
+
+// UnpackGetRandomOutput attempts to unpack [output] as the random value returned by getRandom.
+func UnpackGetRandomOutput(output []byte) (*big.Int, error) {
+	res, err := RandomnessABI.Unpack("getRandom", output)
+	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+	return unpacked, nil
+}
+
+// getRandom returns the random value of the requested round.
+// If the value has not been revealed yet, it is derived from the first verified predicate
+// of the transaction carrying the aggregate signature of the validators over the hash of
+// the block at the requested round, and stored so every later call returns the same value.
+// Note: the first revealed signature of a round is final. Since any quorum of validators
+// produces a valid signature, the sender of the first reveal can pick among the signer
+// subsets available to it, so the value should not secure stakes worth more than
+// colluding with a quorum of validators. In exchange, a round can be revealed as long
+// as a quorum of the stake signs it within [MaxRoundAge] blocks, whichever validators
+// are offline.
+func getRandom(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetRandomGasCost); err != nil {
+		return nil, 0, err
+	}
+	roundInput, err := UnpackGetRandomInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !roundInput.IsUint64() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrInvalidRound, roundInput)
+	}
+	round := roundInput.Uint64()
+
+	stateDB := accessibleState.GetStateDB()
+	if value := GetRandomValue(stateDB, round); value != (common.Hash{}) {
+		packedOutput, err := PackGetRandomOutput(value.Big())
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+
+	blockContext := accessibleState.GetBlockContext()
+	currentNumber := blockContext.Number().Uint64()
+	if round >= currentNumber || currentNumber-round > MaxRoundAge {
+		return nil, remainingGas, fmt.Errorf("%w: %d at block %d", ErrInvalidRound, round, currentNumber)
+	}
+	blockHash := blockContext.GetBlockHash(round)
+	if blockHash == (common.Hash{}) {
+		return nil, remainingGas, fmt.Errorf("%w: %d", ErrRandomnessUnavailable, round)
+	}
+
+	signature, remainingGas, err := findBlockHashSignature(accessibleState, blockHash, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if signature == nil {
+		return nil, remainingGas, fmt.Errorf("%w: %d", ErrRandomnessUnavailable, round)
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, RevealRandomGasCost+RandomnessRevealedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	value := DeriveRandomValue(signature)
+	topics, data, err := PackRandomnessRevealedEvent(roundInput, value.Big())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		currentNumber,
+	)
+	StoreRandomValue(stateDB, round, value)
+
+	packedOutput, err := PackGetRandomOutput(value.Big())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// findBlockHashSignature returns the aggregate signature of the first verified predicate
+// of the current transaction that signs [blockHash], or nil if there is none.
+// Reading each predicate is charged the same per byte cost as reading a Warp message.
+func findBlockHashSignature(accessibleState contract.AccessibleState, blockHash common.Hash, suppliedGas uint64) (*avalancheWarp.BitSetSignature, uint64, error) {
+	remainingGas := suppliedGas
+	state := accessibleState.GetStateDB()
+	predicateResults := set.BitsFromBytes(accessibleState.GetBlockContext().GetPredicateResults(state.GetTxHash(), ContractAddress))
+	for index := 0; ; index++ {
+		predicateBytes, exists := state.GetPredicateStorageSlots(ContractAddress, index)
+		if !exists {
+			return nil, remainingGas, nil
+		}
+		if predicateResults.Contains(index) {
+			continue
+		}
+		msgBytesGas, overflow := math.SafeMul(warp.GasCostPerWarpMessageBytes, uint64(len(predicateBytes)))
+		if overflow {
+			return nil, 0, vmerrs.ErrOutOfGas
+		}
+		var err error
+		if remainingGas, err = contract.DeductGas(remainingGas, msgBytesGas); err != nil {
+			return nil, 0, err
+		}
+		// Note: since the predicate is verified in advance of execution, the precompile should not
+		// hit an error during execution.
+		unpackedPredicateBytes, err := predicate.UnpackPredicate(predicateBytes)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidPredicateBytes, err)
+		}
+		warpMsg, err := avalancheWarp.ParseMessage(unpackedPredicateBytes)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w: %s", errCannotParseWarpMsg, err)
+		}
+		hashPayload, err := payload.ParseHash(warpMsg.Payload)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidBlockHashPayload, err)
+		}
+		if common.Hash(hashPayload.Hash) != blockHash {
+			continue
+		}
+		signature, ok := warpMsg.Signature.(*avalancheWarp.BitSetSignature)
+		if !ok {
+			return nil, remainingGas, fmt.Errorf("%w: unexpected signature type %T", errFailedVerification, warpMsg.Signature)
+		}
+		return signature, remainingGas, nil
+	}
+}
+
+// createRandomnessPrecompile returns a StatefulPrecompiledContract with getRandom
+// enabled from Stromboli onward.
+func createRandomnessPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	stromboliFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getRandom": getRandom,
+	}
+	for name, function := range stromboliFunctionMap {
+		method, ok := RandomnessABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		// getRandom does not declare its state mutability: it reveals randomness when
+		// called in a transaction, and serves revealed randomness in read-only calls.
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,237 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package randomness
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	callerAddr  = common.HexToAddress("0x0123")
+	noFailures  = set.NewBits().Bytes()
+	testRound   = uint64(9)
+	testBlock   = uint64(10)
+	roundHash   = common.Hash{'r', 'o', 'u', 'n', 'd'}
+	otherHash   = common.Hash{'o', 't', 'h', 'e', 'r'}
+	signature   = &avalancheWarp.BitSetSignature{Signers: set.NewBits(0).Bytes(), Signature: [96]byte{1, 2, 3}}
+	randomValue = DeriveRandomValue(signature)
+)
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
+// blockHashPredicate returns the packed predicate of a warp message from this chain
+// signing [blockHash].
+func blockHashPredicate(t testing.TB, blockHash common.Hash) []byte {
+	hashPayload, err := payload.NewHash(ids.ID(blockHash))
+	require.NoError(t, err)
+	snowCtx := utils.TestSnowContext()
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, hashPayload.Bytes())
+	require.NoError(t, err)
+	warpMsg, err := avalancheWarp.NewMessage(unsignedMsg, signature)
+	require.NoError(t, err)
+	return predicate.PackPredicate(warpMsg.Bytes())
+}
+
+// setupBlock expects the block context to be at [testBlock] with [roundHash] as the
+// hash of [testRound] and the given predicate results.
+func setupBlock(predicateResults []byte) func(*contract.MockBlockContext) {
+	return func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().Number().Return(new(big.Int).SetUint64(testBlock)).AnyTimes()
+		mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+		mbc.EXPECT().GetBlockHash(testRound).Return(roundHash).AnyTimes()
+		mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(predicateResults).AnyTimes()
+	}
+}
+
+func TestGetRandom(t *testing.T) {
+	predicateBytes := blockHashPredicate(t, roundHash)
+	otherPredicateBytes := blockHashPredicate(t, otherHash)
+	predicateGas := warp.GasCostPerWarpMessageBytes * uint64(len(predicateBytes))
+	getRandomInput := func(round uint64) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			input, err := PackGetRandom(new(big.Int).SetUint64(round))
+			require.NoError(t, err)
+			return input
+		}
+	}
+	randomOutput, err := PackGetRandomOutput(randomValue.Big())
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"getRandom before Stromboli": {
+			Caller:      callerAddr,
+			InputFn:     getRandomInput(testRound),
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"reveals random value": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicateBytes})
+			},
+			SetupBlockContext: setupBlock(noFailures),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost + predicateGas + RevealRandomGasCost + RandomnessRevealedEventGasCost,
+			ExpectedRes:       randomOutput,
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, randomValue, GetRandomValue(stateDB, testRound))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, RandomnessABI.Events["RandomnessRevealed"].ID, logsTopics[0][0])
+				require.Equal(t, common.BigToHash(new(big.Int).SetUint64(testRound)), logsTopics[0][1])
+				value, err := UnpackRandomnessRevealedEventData(logsData[0])
+				require.NoError(t, err)
+				require.Equal(t, randomValue.Big(), value)
+			},
+		},
+		"reveals from first matching predicate": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{otherPredicateBytes, predicateBytes})
+			},
+			SetupBlockContext: setupBlock(noFailures),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost + 2*predicateGas + RevealRandomGasCost + RandomnessRevealedEventGasCost,
+			ExpectedRes:       randomOutput,
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, randomValue, GetRandomValue(stateDB, testRound))
+			},
+		},
+		"returns revealed random value": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				StoreRandomValue(state, testRound, randomValue)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   GetRandomGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   randomOutput,
+		},
+		"skips failed predicate": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicateBytes})
+			},
+			SetupBlockContext: setupBlock(set.NewBits(0).Bytes()),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost,
+			ExpectedErr:       ErrRandomnessUnavailable.Error(),
+		},
+		"no predicate signing the round": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{otherPredicateBytes})
+			},
+			SetupBlockContext: setupBlock(noFailures),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost + predicateGas,
+			ExpectedErr:       ErrRandomnessUnavailable.Error(),
+		},
+		"readOnly cannot reveal": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicateBytes})
+			},
+			SetupBlockContext: setupBlock(noFailures),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost + predicateGas,
+			ReadOnly:          true,
+			ExpectedErr:       vmerrs.ErrWriteProtection.Error(),
+		},
+		"insufficient gas to reveal": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicateBytes})
+			},
+			SetupBlockContext: setupBlock(noFailures),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost + predicateGas + RevealRandomGasCost + RandomnessRevealedEventGasCost - 1,
+			ExpectedErr:       vmerrs.ErrOutOfGas.Error(),
+		},
+		"current round": {
+			Caller:            callerAddr,
+			InputFn:           getRandomInput(testBlock),
+			SetupBlockContext: setupBlock(noFailures),
+			ChainConfigFn:     stromboliChainConfig,
+			SuppliedGas:       GetRandomGasCost,
+			ExpectedErr:       ErrInvalidRound.Error(),
+		},
+		"round older than max age": {
+			Caller:  callerAddr,
+			InputFn: getRandomInput(testRound),
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(new(big.Int).SetUint64(testRound + MaxRoundAge + 1)).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			},
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   GetRandomGasCost,
+			ExpectedErr:   ErrInvalidRound.Error(),
+		},
+		"round does not fit in uint64": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetRandom(new(big.Int).Lsh(common.Big1, 64))
+				require.NoError(t, err)
+				return input
+			},
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   GetRandomGasCost,
+			ExpectedErr:   ErrInvalidRound.Error(),
+		},
+		"insufficient gas": {
+			Caller:        callerAddr,
+			InputFn:       getRandomInput(testRound),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   GetRandomGasCost - 1,
+			ExpectedErr:   vmerrs.ErrOutOfGas.Error(),
+		},
+		"precompileVersion": {
+			Caller:        callerAddr,
+			Input:         contract.PrecompileVersionSelector,
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   contract.PrecompileVersionGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   contract.PackPrecompileVersionOutput(Version),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Run(t, Module, state.NewTestStateDB(t))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package randomness
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RandomnessRevealedEventGasCost is the gas cost of the RandomnessRevealed event.
+// It is the base gas cost + the gas cost of the topics (signature, round)
+// and the gas cost of the non-indexed data (32 bytes for randomValue).
+const RandomnessRevealedEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*common.HashLength
+
+// PackRandomnessRevealedEvent packs the event into the appropriate arguments for RandomnessRevealed.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRandomnessRevealedEvent(round *big.Int, randomValue *big.Int) ([]common.Hash, []byte, error) {
+	return RandomnessABI.PackEvent("RandomnessRevealed", round, randomValue)
+}
+
+// UnpackRandomnessRevealedEventData attempts to unpack non-indexed [dataBytes].
+func UnpackRandomnessRevealedEventData(dataBytes []byte) (*big.Int, error) {
+	var eventData = struct {
+		RandomValue *big.Int
+	}{}
+	err := RandomnessABI.UnpackIntoInterface(&eventData, "RandomnessRevealed", dataBytes)
+	return eventData.RandomValue, err
+}
@@ -0,0 +1,243 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package randomness
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const numTestVdrs = 5
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	pk     *bls.PublicKey
+}
+
+// newTestValidators returns [numTestVdrs] validators sorted in the canonical order
+// used by Warp to index signers.
+func newTestValidators(t testing.TB) []*testValidator {
+	vdrs := make([]*testValidator, 0, numTestVdrs)
+	for i := 0; i < numTestVdrs; i++ {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+		vdrs = append(vdrs, &testValidator{
+			nodeID: ids.GenerateTestNodeID(),
+			sk:     sk,
+			pk:     bls.PublicFromSecretKey(sk),
+		})
+	}
+	sort.Slice(vdrs, func(i, j int) bool {
+		return bytes.Compare(bls.SerializePublicKey(vdrs[i].pk), bls.SerializePublicKey(vdrs[j].pk)) < 0
+	})
+	return vdrs
+}
+
+// createSnowCtx returns a snow context of the chain validated by [vdrs] with equal weights.
+func createSnowCtx(vdrs []*testValidator) *snow.Context {
+	weights := make([]uint64, len(vdrs))
+	for i := range weights {
+		weights[i] = 20
+	}
+	return createWeightedSnowCtx(vdrs, weights)
+}
+
+// createWeightedSnowCtx returns a snow context of the chain validated by [vdrs],
+// each with the weight at the same index of [weights].
+func createWeightedSnowCtx(vdrs []*testValidator, weights []uint64) *snow.Context {
+	getValidatorsOutput := make(map[ids.NodeID]*validators.GetValidatorOutput)
+	for i, vdr := range vdrs {
+		getValidatorsOutput[vdr.nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.nodeID,
+			PublicKey: vdr.pk,
+			Weight:    weights[i],
+		}
+	}
+
+	snowCtx := utils.TestSnowContext()
+	subnetID := ids.GenerateTestID()
+	snowCtx.SubnetID = subnetID
+	snowCtx.ValidatorState = &validators.TestState{
+		GetSubnetIDF: func(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return getValidatorsOutput, nil
+		},
+	}
+	return snowCtx
+}
+
+// createPredicate returns the predicate of a warp message from [sourceChainID] with [msgPayload]
+// signed by the first [numSigners] of [vdrs].
+func createPredicate(t testing.TB, vdrs []*testValidator, numSigners int, networkID uint32, sourceChainID ids.ID, msgPayload []byte) []byte {
+	indices := make([]int, numSigners)
+	for i := range indices {
+		indices[i] = i
+	}
+	return createPredicateWithSigners(t, vdrs, indices, networkID, sourceChainID, msgPayload)
+}
+
+// createPredicateWithSigners returns the predicate of a warp message from [sourceChainID] with
+// [msgPayload] signed by the validators of [vdrs] at [indices].
+func createPredicateWithSigners(t testing.TB, vdrs []*testValidator, indices []int, networkID uint32, sourceChainID ids.ID, msgPayload []byte) []byte {
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, msgPayload)
+	require.NoError(t, err)
+
+	signers := set.NewBits()
+	signatures := make([]*bls.Signature, 0, len(indices))
+	for _, i := range indices {
+		signers.Add(i)
+		signatures = append(signatures, bls.Sign(vdrs[i].sk, unsignedMsg.Bytes()))
+	}
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	require.NoError(t, err)
+	signature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+
+	warpMsg, err := avalancheWarp.NewMessage(unsignedMsg, signature)
+	require.NoError(t, err)
+	return predicate.PackPredicate(warpMsg.Bytes())
+}
+
+func TestRandomnessPredicate(t *testing.T) {
+	vdrs := newTestValidators(t)
+	snowCtx := createSnowCtx(vdrs)
+	predicateContext := &precompileconfig.PredicateContext{
+		SnowCtx: snowCtx,
+		ProposerVMBlockCtx: &block.Context{
+			PChainHeight: 1,
+		},
+	}
+
+	hashPayload, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(t, err)
+	addressedCall, err := payload.NewAddressedCall([]byte{1}, []byte{2})
+	require.NoError(t, err)
+
+	predicateGas := func(predicateBytes []byte, numSigners uint64) uint64 {
+		return warp.GasCostPerSignatureVerification + uint64(len(predicateBytes))*warp.GasCostPerWarpMessageBytes + numSigners*warp.GasCostPerWarpSigner
+	}
+
+	allSignedPredicate := createPredicate(t, vdrs, numTestVdrs, snowCtx.NetworkID, snowCtx.ChainID, hashPayload.Bytes())
+	quorumPredicate := createPredicate(t, vdrs, 4, snowCtx.NetworkID, snowCtx.ChainID, hashPayload.Bytes())
+	otherQuorumPredicate := createPredicateWithSigners(t, vdrs, []int{1, 2, 3, 4}, snowCtx.NetworkID, snowCtx.ChainID, hashPayload.Bytes())
+	insufficientPredicate := createPredicate(t, vdrs, 3, snowCtx.NetworkID, snowCtx.ChainID, hashPayload.Bytes())
+	otherChainPredicate := createPredicate(t, vdrs, numTestVdrs, snowCtx.NetworkID, ids.GenerateTestID(), hashPayload.Bytes())
+	addressedCallPredicate := createPredicate(t, vdrs, numTestVdrs, snowCtx.NetworkID, snowCtx.ChainID, addressedCall.Bytes())
+
+	tests := map[string]testutils.PredicateTest{
+		"quorum signed": {
+			Config:           NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   quorumPredicate,
+			Gas:              predicateGas(quorumPredicate, 4),
+		},
+		"all validators signed": {
+			Config:           NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   allSignedPredicate,
+			Gas:              predicateGas(allSignedPredicate, numTestVdrs),
+		},
+		"other quorum of validators signed": {
+			Config:           NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   otherQuorumPredicate,
+			Gas:              predicateGas(otherQuorumPredicate, 4),
+		},
+		"insufficient signers": {
+			Config:           NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   insufficientPredicate,
+			Gas:              predicateGas(insufficientPredicate, 3),
+			ExpectedErr:      errFailedVerification,
+		},
+		"insufficient signers for lower quorum": {
+			Config:           NewConfig(utils.NewUint64(0), 60),
+			PredicateContext: predicateContext,
+			PredicateBytes:   insufficientPredicate,
+			Gas:              predicateGas(insufficientPredicate, 3),
+		},
+		"message from another chain": {
+			Config:           NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   otherChainPredicate,
+			Gas:              predicateGas(otherChainPredicate, numTestVdrs),
+			ExpectedErr:      errInvalidSourceChain,
+		},
+		"addressed call payload": {
+			Config:           NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   addressedCallPredicate,
+			Gas:              predicateGas(addressedCallPredicate, numTestVdrs),
+			ExpectedErr:      errInvalidBlockHashPayload,
+		},
+	}
+	testutils.RunPredicateTests(t, tests)
+}
+
+// TestRandomnessPredicateLiveness checks that randomness can be revealed whichever
+// validators are offline, as long as the signers hold the quorum of the stake.
+func TestRandomnessPredicateLiveness(t *testing.T) {
+	vdrs := newTestValidators(t)
+	snowCtx := createWeightedSnowCtx(vdrs, []uint64{10, 10, 50, 10, 20})
+	predicateContext := &precompileconfig.PredicateContext{
+		SnowCtx: snowCtx,
+		ProposerVMBlockCtx: &block.Context{
+			PChainHeight: 1,
+		},
+	}
+	hashPayload, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		quorumNumerator uint64
+		signers         []int
+		expectedErr     error
+	}{
+		"first validators offline": {
+			signers: []int{2, 4},
+		},
+		"heaviest validator offline": {
+			signers:     []int{0, 1, 3, 4},
+			expectedErr: errFailedVerification,
+		},
+		"heaviest validator and two others online": {
+			signers: []int{1, 2, 3},
+		},
+		"signers below the quorum": {
+			signers:     []int{2, 3},
+			expectedErr: errFailedVerification,
+		},
+		"signers reaching a lower quorum": {
+			quorumNumerator: 60,
+			signers:         []int{2, 3},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			predicateBytes := createPredicateWithSigners(t, vdrs, test.signers, snowCtx.NetworkID, snowCtx.ChainID, hashPayload.Bytes())
+			err := NewConfig(utils.NewUint64(0), test.quorumNumerator).VerifyPredicate(predicateContext, predicateBytes)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/constants"
@@ -18,6 +19,7 @@ import (
 	"github.com/ava-labs/subnet-evm/vmerrs"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -26,6 +28,17 @@ const (
 	CurrentRewardAddressGasCost    uint64 = allowlist.ReadAllowListGasCost
 	DisableRewardsGasCost          uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
 	SetRewardAddressGasCost        uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	// SetRewardSplitsGasCost is the base gas cost of setRewardSplits, and
+	// SetRewardSplitsGasCostPerRecipient is charged in addition for each recipient.
+	SetRewardSplitsGasCost             uint64 = 2*contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write reward address + count + read allow list
+	SetRewardSplitsGasCostPerRecipient uint64 = 2 * contract.WriteGasCostPerSlot                                // write recipient + weight
+	// CurrentRewardSplitsGasCost is the base gas cost of currentRewardSplits, and
+	// CurrentRewardSplitsGasCostPerRecipient is charged in addition for each recipient.
+	CurrentRewardSplitsGasCost             uint64 = contract.ReadGasCostPerSlot     // read count
+	CurrentRewardSplitsGasCostPerRecipient uint64 = 2 * contract.ReadGasCostPerSlot // read recipient + weight
+
+	// MaxRewardSplits is the maximum number of recipients of the reward splits.
+	MaxRewardSplits = 16
 )
 
 // Singleton StatefulPrecompiledContract and signatures.
@@ -35,9 +48,11 @@ var (
 	ErrCannotCurrentRewardAddress    = errors.New("non-enabled cannot call currentRewardAddress")
 	ErrCannotDisableRewards          = errors.New("non-enabled cannot call disableRewards")
 	ErrCannotSetRewardAddress        = errors.New("non-enabled cannot call setRewardAddress")
+	ErrCannotSetRewardSplits         = errors.New("non-enabled cannot call setRewardSplits")
 
 	ErrCannotEnableBothRewards = errors.New("cannot enable both fee recipients and reward address at the same time")
 	ErrEmptyRewardAddress      = errors.New("reward address cannot be empty")
+	ErrInvalidRewardSplits     = errors.New("invalid reward splits")
 
 	// RewardManagerRawABI contains the raw ABI of RewardManager contract.
 	//go:embed contract.abi
@@ -48,6 +63,10 @@ var (
 
 	rewardAddressStorageKey        = common.Hash{'r', 'a', 's', 'k'}
 	allowFeeRecipientsAddressValue = common.Hash{'a', 'f', 'r', 'a', 'v'}
+
+	rewardSplitsCountStorageKey = common.Hash{'r', 's', 'c', 's', 'k'}
+	rewardSplitRecipientPrefix  = []byte("rewardSplitRecipient")
+	rewardSplitWeightPrefix     = []byte("rewardSplitWeight")
 )
 
 // GetRewardManagerAllowListStatus returns the role of [address] for the RewardManager list.
@@ -70,11 +89,13 @@ func PackAllowFeeRecipients() ([]byte, error) {
 // EnableAllowFeeRecipients enables fee recipients.
 func EnableAllowFeeRecipients(stateDB contract.StateDB) {
 	stateDB.SetState(ContractAddress, rewardAddressStorageKey, allowFeeRecipientsAddressValue)
+	clearRewardSplits(stateDB)
 }
 
 // DisableRewardAddress disables rewards and burns them by sending to Blackhole Address.
 func DisableFeeRewards(stateDB contract.StateDB) {
 	stateDB.SetState(ContractAddress, rewardAddressStorageKey, constants.BlackholeAddr.Hash())
+	clearRewardSplits(stateDB)
 }
 
 func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
@@ -163,13 +184,28 @@ func PackCurrentRewardAddressOutput(rewardAddress common.Address) ([]byte, error
 // GetStoredRewardAddress returns the current value of the address stored under rewardAddressStorageKey.
 // Returns an empty address and true if allow fee recipients is enabled, otherwise returns current reward address and false.
 func GetStoredRewardAddress(stateDB contract.StateDB) (common.Address, bool) {
-	val := stateDB.GetState(ContractAddress, rewardAddressStorageKey)
+	return UnpackRewardAddressStorage(stateDB.GetState(ContractAddress, rewardAddressStorageKey))
+}
+
+// RewardAddressStorageKey returns the storage key of the reward address.
+func RewardAddressStorageKey() common.Hash {
+	return rewardAddressStorageKey
+}
+
+// UnpackRewardAddressStorage returns the reward address stored in [val], the
+// value of the key returned by RewardAddressStorageKey, and whether fee
+// recipients are allowed instead.
+func UnpackRewardAddressStorage(val common.Hash) (common.Address, bool) {
 	return common.BytesToAddress(val.Bytes()), val == allowFeeRecipientsAddressValue
 }
 
 // StoredRewardAddress stores the given [val] under rewardAddressStorageKey.
+// The reward splits are cleared unless [val] is the reward manager contract address.
 func StoreRewardAddress(stateDB contract.StateDB, val common.Address) {
 	stateDB.SetState(ContractAddress, rewardAddressStorageKey, val.Hash())
+	if val != ContractAddress {
+		clearRewardSplits(stateDB)
+	}
 }
 
 // PackSetRewardAddress packs [addr] of type common.Address into the appropriate arguments for setRewardAddress.
@@ -308,6 +344,168 @@ func disableRewards(accessibleState contract.AccessibleState, caller common.Addr
 	return []byte{}, remainingGas, nil
 }
 
+// rewardSplitKey returns the storage key of the field identified by [prefix] of the reward split at [index].
+func rewardSplitKey(prefix []byte, index int) common.Hash {
+	return crypto.Keccak256Hash(prefix, common.BigToHash(big.NewInt(int64(index))).Bytes())
+}
+
+// GetRewardSplits returns the recipients and weights of the reward splits stored in [stateDB].
+// The reward splits are cleared whenever the reward address changes to another address than the
+// reward manager contract address, so the returned splits are always in effect.
+func GetRewardSplits(stateDB contract.StateDB) ([]common.Address, []*big.Int) {
+	count := int(stateDB.GetState(ContractAddress, rewardSplitsCountStorageKey).Big().Uint64())
+	recipients := make([]common.Address, count)
+	weights := make([]*big.Int, count)
+	for i := 0; i < count; i++ {
+		recipients[i] = common.BytesToAddress(stateDB.GetState(ContractAddress, rewardSplitKey(rewardSplitRecipientPrefix, i)).Bytes())
+		weights[i] = stateDB.GetState(ContractAddress, rewardSplitKey(rewardSplitWeightPrefix, i)).Big()
+	}
+	return recipients, weights
+}
+
+// clearRewardSplits removes the reward splits stored in [stateDB], which are no longer in effect
+// once the reward address changes. Only the number of splits is reset, since the recipients and
+// weights are never read past it. Nothing is written if no splits are stored.
+func clearRewardSplits(stateDB contract.StateDB) {
+	if stateDB.GetState(ContractAddress, rewardSplitsCountStorageKey) != (common.Hash{}) {
+		stateDB.SetState(ContractAddress, rewardSplitsCountStorageKey, common.Hash{})
+	}
+}
+
+// StoreRewardSplits stores the reward splits to [recipients] with [weights] and sets the reward address
+// to the reward manager contract address, so the fees collected by the contract are distributed to
+// the recipients when the block is finalized.
+// assumes [recipients] and [weights] have already been verified with VerifyRewardSplits.
+func StoreRewardSplits(stateDB contract.StateDB, recipients []common.Address, weights []*big.Int) {
+	for i, recipient := range recipients {
+		stateDB.SetState(ContractAddress, rewardSplitKey(rewardSplitRecipientPrefix, i), recipient.Hash())
+		stateDB.SetState(ContractAddress, rewardSplitKey(rewardSplitWeightPrefix, i), common.BigToHash(weights[i]))
+	}
+	stateDB.SetState(ContractAddress, rewardSplitsCountStorageKey, common.BigToHash(big.NewInt(int64(len(recipients)))))
+	StoreRewardAddress(stateDB, ContractAddress)
+}
+
+// VerifyRewardSplits returns an error if [recipients] and [weights] are not valid reward splits.
+// There must be between 1 and [MaxRewardSplits] unique, non-empty recipients, each with a positive weight.
+func VerifyRewardSplits(recipients []common.Address, weights []*big.Int) error {
+	if len(recipients) == 0 || len(recipients) > MaxRewardSplits {
+		return fmt.Errorf("%w: number of recipients (%d) must be between 1 and %d", ErrInvalidRewardSplits, len(recipients), MaxRewardSplits)
+	}
+	if len(recipients) != len(weights) {
+		return fmt.Errorf("%w: number of recipients (%d) does not match number of weights (%d)", ErrInvalidRewardSplits, len(recipients), len(weights))
+	}
+	seen := make(map[common.Address]struct{}, len(recipients))
+	for i, recipient := range recipients {
+		if recipient == (common.Address{}) || recipient == ContractAddress {
+			return fmt.Errorf("%w: invalid recipient %s", ErrInvalidRewardSplits, recipient)
+		}
+		if _, ok := seen[recipient]; ok {
+			return fmt.Errorf("%w: duplicate recipient %s", ErrInvalidRewardSplits, recipient)
+		}
+		seen[recipient] = struct{}{}
+		if weights[i].Sign() <= 0 {
+			return fmt.Errorf("%w: weight of recipient %s must be positive", ErrInvalidRewardSplits, recipient)
+		}
+	}
+	return nil
+}
+
+// PackSetRewardSplits packs [recipients] and [weights] into the appropriate arguments for setRewardSplits.
+func PackSetRewardSplits(recipients []common.Address, weights []*big.Int) ([]byte, error) {
+	return RewardManagerABI.Pack("setRewardSplits", recipients, weights)
+}
+
+// UnpackSetRewardSplitsInput attempts to unpack [input] into the arguments of setRewardSplits.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetRewardSplitsInput(input []byte) ([]common.Address, []*big.Int, error) {
+	inputStruct := struct {
+		Recipients []common.Address
+		Weights    []*big.Int
+	}{}
+	if err := RewardManagerABI.UnpackInputIntoInterface(&inputStruct, "setRewardSplits", input, false); err != nil {
+		return nil, nil, err
+	}
+	return inputStruct.Recipients, inputStruct.Weights, nil
+}
+
+// setRewardSplits checks if the caller has permissions to set the reward splits and stores them,
+// directing the block rewards to the reward manager so they can be split among the recipients.
+func setRewardSplits(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetRewardSplitsGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	recipients, weights, err := UnpackSetRewardSplitsInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if err := VerifyRewardSplits(recipients, weights); err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, SetRewardSplitsGasCostPerRecipient*uint64(len(recipients))); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRewardSplits, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, RewardSplitsChangedEventGasCost(len(recipients))); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackRewardSplitsChangedEvent(caller, recipients, weights)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	StoreRewardSplits(stateDB, recipients, weights)
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackCurrentRewardSplits packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackCurrentRewardSplits() ([]byte, error) {
+	return RewardManagerABI.Pack("currentRewardSplits")
+}
+
+// PackCurrentRewardSplitsOutput attempts to pack given [recipients] and [weights]
+// to conform the ABI outputs.
+func PackCurrentRewardSplitsOutput(recipients []common.Address, weights []*big.Int) ([]byte, error) {
+	return RewardManagerABI.PackOutput("currentRewardSplits", recipients, weights)
+}
+
+// currentRewardSplits returns the stored reward splits.
+func currentRewardSplits(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CurrentRewardSplitsGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	recipients, weights := GetRewardSplits(accessibleState.GetStateDB())
+	if remainingGas, err = contract.DeductGas(remainingGas, CurrentRewardSplitsGasCostPerRecipient*uint64(len(recipients))); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackCurrentRewardSplitsOutput(recipients, weights)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return the packed output and the remaining gas
+	return packedOutput, remainingGas, nil
+}
+
 // createRewardManagerPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for [precompileAddr].
 func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
@@ -326,8 +524,21 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 		if !ok {
 			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
 		}
-		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
+	}
+
+	stromboliFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"currentRewardSplits": currentRewardSplits,
+		"setRewardSplits":     setRewardSplits,
+	}
+	for name, function := range stromboliFunctionMap {
+		method, ok := RewardManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
 	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
 
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
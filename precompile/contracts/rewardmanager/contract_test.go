@@ -4,6 +4,7 @@
 package rewardmanager
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -96,6 +97,7 @@ var (
 				mockChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(commontype.ValidTestFeeConfig)
 				mockChainConfig.EXPECT().AllowedFeeRecipients().AnyTimes().Return(false)
 				mockChainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(false)
+				mockChainConfig.EXPECT().IsStromboli(gomock.Any()).AnyTimes().Return(false)
 				return mockChainConfig
 			},
 			SuppliedGas: AllowFeeRecipientsGasCost,
@@ -184,6 +186,7 @@ var (
 				mockChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(commontype.ValidTestFeeConfig)
 				mockChainConfig.EXPECT().AllowedFeeRecipients().AnyTimes().Return(false)
 				mockChainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(false)
+				mockChainConfig.EXPECT().IsStromboli(gomock.Any()).AnyTimes().Return(false)
 				return mockChainConfig
 			},
 			SuppliedGas: SetRewardAddressGasCost,
@@ -252,6 +255,7 @@ var (
 				mockChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(commontype.ValidTestFeeConfig)
 				mockChainConfig.EXPECT().AllowedFeeRecipients().AnyTimes().Return(false)
 				mockChainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(false)
+				mockChainConfig.EXPECT().IsStromboli(gomock.Any()).AnyTimes().Return(false)
 				return mockChainConfig
 			},
 			SuppliedGas: SetRewardAddressGasCost,
@@ -431,9 +435,263 @@ var (
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"set reward splits before Stromboli": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients, testSplitWeights)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"set reward splits from no role fails": {
+			Caller:        allowlist.TestNoRoleAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients, testSplitWeights)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitsGasCost + SetRewardSplitsGasCostPerRecipient*uint64(len(testSplitRecipients)),
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetRewardSplits.Error(),
+		},
+		"set reward splits from enabled succeeds": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients, testSplitWeights)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitsGasCost + SetRewardSplitsGasCostPerRecipient*uint64(len(testSplitRecipients)) + RewardSplitsChangedEventGasCost(len(testSplitRecipients)),
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				recipients, weights := GetRewardSplits(state)
+				require.Equal(t, testSplitRecipients, recipients)
+				require.Equal(t, testSplitWeights, weights)
+				address, isFeeRecipients := GetStoredRewardAddress(state)
+				require.Equal(t, ContractAddress, address)
+				require.False(t, isFeeRecipients)
+
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Len(t, logsData, 1)
+				topics, data, err := PackRewardSplitsChangedEvent(allowlist.TestEnabledAddr, testSplitRecipients, testSplitWeights)
+				require.NoError(t, err)
+				require.Equal(t, topics, logsTopics[0])
+				require.Equal(t, data, logsData[0])
+			},
+		},
+		"set reward splits with mismatched lengths fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients, testSplitWeights[:1])
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitsGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidRewardSplits.Error(),
+		},
+		"set reward splits with zero weight fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients, []*big.Int{big.NewInt(1), big.NewInt(0), big.NewInt(1)})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitsGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrInvalidRewardSplits.Error(),
+		},
+		"readOnly set reward splits fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients, testSplitWeights)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitsGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set reward address clears reward splits": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				StoreRewardSplits(state, testSplitRecipients, testSplitWeights)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardAddress(rewardAddress)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardAddressGasCost + RewardAddressChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				recipients, weights := GetRewardSplits(state)
+				require.Empty(t, recipients)
+				require.Empty(t, weights)
+			},
+		},
+		"allow fee recipients clears reward splits": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				StoreRewardSplits(state, testSplitRecipients, testSplitWeights)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackAllowFeeRecipients()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: AllowFeeRecipientsGasCost + FeeRecipientsAllowedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				recipients, _ := GetRewardSplits(state)
+				require.Empty(t, recipients)
+			},
+		},
+		"disable rewards clears reward splits": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				StoreRewardSplits(state, testSplitRecipients, testSplitWeights)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackDisableRewards()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: DisableRewardsGasCost + RewardsDisabledEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				recipients, _ := GetRewardSplits(state)
+				require.Empty(t, recipients)
+			},
+		},
+		"set reward splits again keeps reward splits": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				StoreRewardSplits(state, testSplitRecipients, testSplitWeights)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplits(testSplitRecipients[:1], testSplitWeights[:1])
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardSplitsGasCost + SetRewardSplitsGasCostPerRecipient + RewardSplitsChangedEventGasCost(1),
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				recipients, weights := GetRewardSplits(state)
+				require.Equal(t, testSplitRecipients[:1], recipients)
+				require.Equal(t, testSplitWeights[:1], weights)
+			},
+		},
+		"get current reward splits": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				StoreRewardSplits(state, testSplitRecipients, testSplitWeights)
+			},
+			ChainConfigFn: stromboliChainConfig,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentRewardSplits()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: CurrentRewardSplitsGasCost + CurrentRewardSplitsGasCostPerRecipient*uint64(len(testSplitRecipients)),
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackCurrentRewardSplitsOutput(testSplitRecipients, testSplitWeights)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
 	}
 )
 
+var (
+	testSplitRecipients = []common.Address{common.HexToAddress("0x0124"), common.HexToAddress("0x0125"), constants.BlackholeAddr}
+	testSplitWeights    = []*big.Int{big.NewInt(50), big.NewInt(30), big.NewInt(20)}
+)
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
+func TestVerifyRewardSplits(t *testing.T) {
+	tooMany := make([]common.Address, MaxRewardSplits+1)
+	tooManyWeights := make([]*big.Int, MaxRewardSplits+1)
+	for i := range tooMany {
+		tooMany[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		tooManyWeights[i] = big.NewInt(1)
+	}
+	for name, test := range map[string]struct {
+		recipients  []common.Address
+		weights     []*big.Int
+		expectedErr string
+	}{
+		"valid":                 {recipients: testSplitRecipients, weights: testSplitWeights},
+		"empty":                 {expectedErr: "number of recipients (0) must be between 1 and 16"},
+		"too many":              {recipients: tooMany, weights: tooManyWeights, expectedErr: "number of recipients (17) must be between 1 and 16"},
+		"mismatched lengths":    {recipients: testSplitRecipients, weights: testSplitWeights[:2], expectedErr: "does not match number of weights"},
+		"empty recipient":       {recipients: []common.Address{{}}, weights: []*big.Int{big.NewInt(1)}, expectedErr: "invalid recipient"},
+		"contract as recipient": {recipients: []common.Address{ContractAddress}, weights: []*big.Int{big.NewInt(1)}, expectedErr: "invalid recipient"},
+		"duplicate recipient":   {recipients: []common.Address{rewardAddress, rewardAddress}, weights: []*big.Int{big.NewInt(1), big.NewInt(1)}, expectedErr: "duplicate recipient"},
+		"zero weight":           {recipients: []common.Address{rewardAddress}, weights: []*big.Int{big.NewInt(0)}, expectedErr: "must be positive"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyRewardSplits(test.recipients, test.weights)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidRewardSplits)
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestRewardManagerRun(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
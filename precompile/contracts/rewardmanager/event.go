@@ -5,6 +5,8 @@
 package rewardmanager
 
 import (
+	"math/big"
+
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -22,6 +24,13 @@ const (
 	RewardsDisabledEventGasCost = contract.LogGas + contract.LogTopicGas*2
 )
 
+// RewardSplitsChangedEventGasCost returns the gas cost of the RewardSplitsChanged event with [numRecipients] recipients.
+// It is calculated as the gas cost of the log operation + the gas cost of 2 topic hashes (signature + sender)
+// + the gas cost of the non-indexed data (2 offsets, 2 lengths and a word per recipient and per weight).
+func RewardSplitsChangedEventGasCost(numRecipients int) uint64 {
+	return contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*common.HashLength*uint64(4+2*numRecipients)
+}
+
 // PackFeeRecipientsAllowedEvent packs the event into the appropriate arguments for FeeRecipientsAllowed.
 // It returns topic hashes and the encoded non-indexed data.
 func PackFeeRecipientsAllowedEvent(sender common.Address) ([]common.Hash, []byte, error) {
@@ -39,3 +48,9 @@ func PackRewardAddressChangedEvent(sender common.Address, oldRewardAddress commo
 func PackRewardsDisabledEvent(sender common.Address) ([]common.Hash, []byte, error) {
 	return RewardManagerABI.PackEvent("RewardsDisabled", sender)
 }
+
+// PackRewardSplitsChangedEvent packs the event into the appropriate arguments for RewardSplitsChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRewardSplitsChangedEvent(sender common.Address, recipients []common.Address, weights []*big.Int) ([]common.Hash, []byte, error) {
+	return RewardManagerABI.PackEvent("RewardSplitsChanged", sender, recipients, weights)
+}
@@ -19,14 +19,19 @@ var _ contract.Configurator = &configurator{}
 // must be unique across all precompiles.
 const ConfigKey = "rewardManagerConfig"
 
+// Version is the interface version of the precompile returned by precompileVersion.
+const Version = 2
+
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000004")
 
 // Module is the precompile module. It is used to register the precompile contract.
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
+	Version:      Version,
 	Contract:     RewardManagerPrecompile,
 	Configurator: &configurator{},
+	ABI:          &RewardManagerABI,
 }
 
 type configurator struct{}
@@ -4,6 +4,9 @@
 package txallowlist
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ethereum/go-ethereum/common"
@@ -11,11 +14,22 @@ import (
 
 var _ precompileconfig.Config = &Config{}
 
+var ErrCannotRestrictTargetsBeforeStromboli = errors.New("cannot restrict transaction targets before Stromboli")
+
 // Config implements the StatefulPrecompileConfig interface while adding in the
 // TxAllowList specific precompile config.
 type Config struct {
 	allowlist.AllowListConfig
 	precompileconfig.Upgrade
+	// RestrictTargets restricts the contracts that non-admin accounts can send
+	// transactions to, to the [AllowedTargets]. Admins can update both after
+	// activation through the precompile.
+	// The restriction only applies to the recipient of a transaction if it has
+	// code when the transaction is checked: transfers to accounts without code
+	// are not restricted, contracts called by an allowed target are not checked,
+	// and contract creation is only restricted by the contract deployer allow list.
+	RestrictTargets bool             `json:"restrictTargets,omitempty"`
+	AllowedTargets  []common.Address `json:"allowedTargets,omitempty"`
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -51,9 +65,30 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 	if !ok {
 		return false
 	}
+	if c.RestrictTargets != other.RestrictTargets || len(c.AllowedTargets) != len(other.AllowedTargets) {
+		return false
+	}
+	for i, target := range c.AllowedTargets {
+		if target != other.AllowedTargets[i] {
+			return false
+		}
+	}
 	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
 }
 
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if c.RestrictTargets || len(c.AllowedTargets) != 0 {
+		// If the config attempts to restrict targets before Stromboli, fail verification
+		if timestamp := c.Timestamp(); timestamp != nil && !chainConfig.IsStromboli(*timestamp) {
+			return ErrCannotRestrictTargetsBeforeStromboli
+		}
+		seen := make(map[common.Address]struct{}, len(c.AllowedTargets))
+		for _, target := range c.AllowedTargets {
+			if _, ok := seen[target]; ok {
+				return fmt.Errorf("duplicate address in allowed targets: %s", target)
+			}
+			seen[target] = struct{}{}
+		}
+	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
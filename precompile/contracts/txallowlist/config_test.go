@@ -15,7 +15,51 @@ import (
 )
 
 func TestVerify(t *testing.T) {
-	allowlist.VerifyPrecompileWithAllowListTests(t, Module, nil)
+	target := common.HexToAddress("0x0000000000000000000000000000000000000aaa")
+	tests := map[string]testutils.ConfigVerifyTest{
+		"restrict targets before Stromboli": {
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				RestrictTargets: true,
+			},
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
+				return config
+			}(),
+			ExpectedError: ErrCannotRestrictTargetsBeforeStromboli.Error(),
+		},
+		"duplicate allowed targets": {
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				RestrictTargets: true,
+				AllowedTargets:  []common.Address{target, target},
+			},
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+				return config
+			}(),
+			ExpectedError: "duplicate address in allowed targets",
+		},
+		"restrict targets after Stromboli": {
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				RestrictTargets: true,
+				AllowedTargets:  []common.Address{target},
+			},
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+				return config
+			}(),
+			ExpectedError: "",
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
 
 func TestEqual(t *testing.T) {
@@ -38,6 +82,16 @@ func TestEqual(t *testing.T) {
 			Other:    NewConfig(utils.NewUint64(4), admins, enableds, managers),
 			Expected: false,
 		},
+		"different restrict targets": {
+			Config:   &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, RestrictTargets: true},
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}},
+			Expected: false,
+		},
+		"different allowed targets": {
+			Config:   &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, AllowedTargets: admins},
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, AllowedTargets: enableds},
+			Expected: false,
+		},
 		"same config": {
 			Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers),
 			Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers),
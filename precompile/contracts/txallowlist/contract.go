@@ -4,13 +4,43 @@
 package txallowlist
 
 import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	SetTargetRestrictionGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	SetTargetAllowedGasCost     uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	IsTargetRestrictedGasCost   uint64 = contract.ReadGasCostPerSlot
+	IsTargetAllowedGasCost      uint64 = contract.ReadGasCostPerSlot
 )
 
-// Singleton StatefulPrecompiledContract for W/R access to the tx allow list.
-var TxAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
+var (
+	ErrCannotSetTargetRestriction = errors.New("non-admin cannot call setTargetRestriction")
+	ErrCannotSetTargetAllowed     = errors.New("non-admin cannot call setTargetAllowed")
+
+	// TxAllowListRawABI contains the raw ABI of TxAllowList contract.
+	//go:embed contract.abi
+	TxAllowListRawABI string
+
+	TxAllowListABI = contract.ParseABI(TxAllowListRawABI)
+
+	// Singleton StatefulPrecompiledContract for W/R access to the tx allow list.
+	TxAllowListPrecompile contract.StatefulPrecompiledContract = createTxAllowListPrecompile()
+
+	targetRestrictionStorageKey = common.Hash{'t', 'r', 's', 'k'}
+	targetRestrictionEnabled    = common.Hash{'t', 'r', 'e'}
+	allowedTargetValue          = common.Hash{'a', 't', 'v'}
+	allowedTargetPrefix         = []byte("txAllowListTarget")
+)
 
 // GetTxAllowListStatus returns the role of [address] for the tx allow list.
 func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
@@ -23,3 +53,253 @@ func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address) allo
 func SetTxAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
 	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
 }
+
+// allowedTargetKey returns the storage key of the allowed flag of [target].
+// The key is hashed so it cannot collide with the allow list role slots.
+func allowedTargetKey(target common.Address) common.Hash {
+	return crypto.Keccak256Hash(allowedTargetPrefix, target.Bytes())
+}
+
+// IsTargetRestricted returns true if transactions of non-admin accounts are
+// restricted to the allowed target contracts.
+func IsTargetRestricted(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, targetRestrictionStorageKey) == targetRestrictionEnabled
+}
+
+// SetTargetRestriction enables or disables the target restriction.
+func SetTargetRestriction(stateDB contract.StateDB, enabled bool) {
+	value := common.Hash{}
+	if enabled {
+		value = targetRestrictionEnabled
+	}
+	stateDB.SetState(ContractAddress, targetRestrictionStorageKey, value)
+}
+
+// IsTargetAllowed returns true if [target] is in the allowed targets.
+func IsTargetAllowed(stateDB contract.StateDB, target common.Address) bool {
+	return stateDB.GetState(ContractAddress, allowedTargetKey(target)) == allowedTargetValue
+}
+
+// SetTargetAllowed adds [target] to or removes it from the allowed targets.
+func SetTargetAllowed(stateDB contract.StateDB, target common.Address, allowed bool) {
+	value := common.Hash{}
+	if allowed {
+		value = allowedTargetValue
+	}
+	stateDB.SetState(ContractAddress, allowedTargetKey(target), value)
+}
+
+// CanCallTarget returns true if an account with [senderRole] may send a
+// transaction to the contract at [target].
+// Admins may call any contract. Other accounts may only call allowed targets
+// if the target restriction is enabled.
+// Callers only check the recipients of transactions that have code, so the
+// restriction does not cover transfers to accounts without code or contract
+// creation, which is restricted by the contract deployer allow list instead.
+func CanCallTarget(stateDB contract.StateDB, senderRole allowlist.Role, target common.Address) bool {
+	if senderRole.IsAdmin() || !IsTargetRestricted(stateDB) {
+		return true
+	}
+	return IsTargetAllowed(stateDB, target)
+}
+
+// PackSetTargetRestriction packs [enabled] of type bool into the appropriate arguments for setTargetRestriction.
+func PackSetTargetRestriction(enabled bool) ([]byte, error) {
+	return TxAllowListABI.Pack("setTargetRestriction", enabled)
+}
+
+// UnpackSetTargetRestrictionInput attempts to unpack [input] into the bool type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetTargetRestrictionInput(input []byte) (bool, error) {
+	res, err := TxAllowListABI.UnpackInput("setTargetRestriction", input, false)
+	if err != nil {
+		return false, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(bool)).(*bool)
+	return unpacked, nil
+}
+
+func setTargetRestriction(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetTargetRestrictionGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	enabled, err := UnpackSetTargetRestrictionInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins may change which contracts non-admin accounts can call.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetTargetRestriction, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, TargetRestrictionSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackTargetRestrictionSetEvent(caller, enabled)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	SetTargetRestriction(stateDB, enabled)
+	return []byte{}, remainingGas, nil
+}
+
+// PackSetTargetAllowed packs [target] and [allowed] into the appropriate arguments for setTargetAllowed.
+func PackSetTargetAllowed(target common.Address, allowed bool) ([]byte, error) {
+	return TxAllowListABI.Pack("setTargetAllowed", target, allowed)
+}
+
+// UnpackSetTargetAllowedInput attempts to unpack [input] into the target and allowed arguments
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetTargetAllowedInput(input []byte) (common.Address, bool, error) {
+	inputStruct := struct {
+		Target  common.Address
+		Allowed bool
+	}{}
+	if err := TxAllowListABI.UnpackInputIntoInterface(&inputStruct, "setTargetAllowed", input, false); err != nil {
+		return common.Address{}, false, err
+	}
+	return inputStruct.Target, inputStruct.Allowed, nil
+}
+
+func setTargetAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetTargetAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, allowed, err := UnpackSetTargetAllowedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Only admins may change which contracts non-admin accounts can call.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetTargetAllowed, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, TargetAllowedSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackTargetAllowedSetEvent(target, caller, allowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(
+		ContractAddress,
+		topics,
+		data,
+		accessibleState.GetBlockContext().Number().Uint64(),
+	)
+
+	SetTargetAllowed(stateDB, target, allowed)
+	return []byte{}, remainingGas, nil
+}
+
+// PackIsTargetRestricted packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackIsTargetRestricted() ([]byte, error) {
+	return TxAllowListABI.Pack("isTargetRestricted")
+}
+
+// PackIsTargetRestrictedOutput attempts to pack given enabled of type bool
+// to conform the ABI outputs.
+func PackIsTargetRestrictedOutput(enabled bool) ([]byte, error) {
+	return TxAllowListABI.PackOutput("isTargetRestricted", enabled)
+}
+
+func isTargetRestricted(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsTargetRestrictedGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	packedOutput, err := PackIsTargetRestrictedOutput(IsTargetRestricted(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackIsTargetAllowed packs [target] of type common.Address into the appropriate arguments for isTargetAllowed.
+func PackIsTargetAllowed(target common.Address) ([]byte, error) {
+	return TxAllowListABI.Pack("isTargetAllowed", target)
+}
+
+// UnpackIsTargetAllowedInput attempts to unpack [input] into the common.Address type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackIsTargetAllowedInput(input []byte) (common.Address, error) {
+	res, err := TxAllowListABI.UnpackInput("isTargetAllowed", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return unpacked, nil
+}
+
+// PackIsTargetAllowedOutput attempts to pack given allowed of type bool
+// to conform the ABI outputs.
+func PackIsTargetAllowedOutput(allowed bool) ([]byte, error) {
+	return TxAllowListABI.PackOutput("isTargetAllowed", allowed)
+}
+
+func isTargetAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsTargetAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	target, err := UnpackIsTargetAllowedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	packedOutput, err := PackIsTargetAllowedOutput(IsTargetAllowed(accessibleState.GetStateDB(), target))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createTxAllowListPrecompile returns a StatefulPrecompiledContract with the allow list
+// functions and, after Stromboli, the functions to restrict the targets of transactions.
+func createTxAllowListPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setTargetRestriction": setTargetRestriction,
+		"setTargetAllowed":     setTargetAllowed,
+		"isTargetRestricted":   isTargetRestricted,
+		"isTargetAllowed":      isTargetAllowed,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := TxAllowListABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, contract.IsStromboliActivated, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
+	}
+
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
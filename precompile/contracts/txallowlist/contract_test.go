@@ -8,12 +8,184 @@ import (
 
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testTargetAddr = common.HexToAddress("0x0000000000000000000000000000000000000aaa")
+
+	stromboliChainConfigFn = func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+		config := precompileconfig.NewMockChainConfig(ctrl)
+		config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+		config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+		return config
+	}
+
+	tests = map[string]testutils.PrecompileTest{
+		"set target restriction pre-Stromboli": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetRestriction(true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"admin set target restriction": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetRestriction(true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetTargetRestrictionGasCost + TargetRestrictionSetEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsTargetRestricted(state))
+				require.True(t, CanCallTarget(state, allowlist.AdminRole, testTargetAddr))
+				require.False(t, CanCallTarget(state, allowlist.EnabledRole, testTargetAddr))
+
+				topics, data := state.GetLogData()
+				require.Len(t, topics, 1)
+				expectedTopics, expectedData, err := PackTargetRestrictionSetEvent(allowlist.TestAdminAddr, true)
+				require.NoError(t, err)
+				require.Equal(t, expectedTopics, topics[0])
+				require.Equal(t, expectedData, data[0])
+			},
+		},
+		"manager set target restriction": {
+			Caller:        allowlist.TestManagerAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetRestriction(true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetTargetRestrictionGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetTargetRestriction.Error(),
+		},
+		"admin set target restriction readOnly": {
+			Caller:        allowlist.TestAdminAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetRestriction(true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetTargetRestrictionGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"admin set target allowed": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetTargetRestriction(state, true)
+			},
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetAllowed(testTargetAddr, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetTargetAllowedGasCost + TargetAllowedSetEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsTargetAllowed(state, testTargetAddr))
+				require.True(t, CanCallTarget(state, allowlist.EnabledRole, testTargetAddr))
+			},
+		},
+		"enabled set target allowed": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetAllowed(testTargetAddr, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetTargetAllowedGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetTargetAllowed.Error(),
+		},
+		"is target allowed": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetTargetAllowed(state, testTargetAddr, true)
+			},
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsTargetAllowed(testTargetAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsTargetAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsTargetAllowedOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"is target restricted": {
+			Caller:        allowlist.TestNoRoleAddr,
+			ChainConfigFn: stromboliChainConfigFn,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsTargetRestricted()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsTargetRestrictedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsTargetRestrictedOutput(false)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"initial config restricts targets": {
+			ChainConfigFn: stromboliChainConfigFn,
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				RestrictTargets: true,
+				AllowedTargets:  []common.Address{testTargetAddr},
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsTargetRestricted(state))
+				require.True(t, IsTargetAllowed(state, testTargetAddr))
+				require.False(t, IsTargetAllowed(state, allowlist.TestAdminAddr))
+			},
+		},
+	}
 )
 
 func TestTxAllowListRun(t *testing.T) {
-	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, nil)
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
 
 func BenchmarkTxAllowList(b *testing.B) {
-	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, nil)
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
 }
@@ -0,0 +1,32 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txallowlist
+
+import (
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// TargetAllowedSetEventGasCost is the gas cost of the TargetAllowedSet event.
+	// It is the base gas cost + the gas cost of the topics (signature, target, sender)
+	// and the gas cost of the non-indexed data (allowed).
+	TargetAllowedSetEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*common.HashLength
+	// TargetRestrictionSetEventGasCost is the gas cost of the TargetRestrictionSet event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender)
+	// and the gas cost of the non-indexed data (enabled).
+	TargetRestrictionSetEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*common.HashLength
+)
+
+// PackTargetAllowedSetEvent packs the event into the appropriate arguments for TargetAllowedSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackTargetAllowedSetEvent(target common.Address, sender common.Address, allowed bool) ([]common.Hash, []byte, error) {
+	return TxAllowListABI.PackEvent("TargetAllowedSet", target, sender, allowed)
+}
+
+// PackTargetRestrictionSetEvent packs the event into the appropriate arguments for TargetRestrictionSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackTargetRestrictionSetEvent(sender common.Address, enabled bool) ([]common.Hash, []byte, error) {
+	return TxAllowListABI.PackEvent("TargetRestrictionSet", sender, enabled)
+}
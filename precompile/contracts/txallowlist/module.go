@@ -18,13 +18,18 @@ var _ contract.Configurator = &configurator{}
 // must be unique across all precompiles.
 const ConfigKey = "txAllowListConfig"
 
+// Version is the interface version of the precompile returned by precompileVersion.
+const Version = 2
+
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000002")
 
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
+	Version:      Version,
 	Contract:     TxAllowListPrecompile,
 	Configurator: &configurator{},
+	ABI:          &TxAllowListABI,
 }
 
 type configurator struct{}
@@ -48,5 +53,13 @@ func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg pre
 	if !ok {
 		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
 	}
+	// Verify() should have been called before Configure()
+	// so we know target restriction is activated
+	if config.RestrictTargets {
+		SetTargetRestriction(state, true)
+	}
+	for _, target := range config.AllowedTargets {
+		SetTargetAllowed(state, target, true)
+	}
 	return config.AllowListConfig.Configure(chainConfig, ContractAddress, state, blockContext)
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -32,6 +33,7 @@ var (
 
 var (
 	errOverflowSignersGasCost  = errors.New("overflow calculating warp signers gas cost")
+	errUnknownSignatureType    = errors.New("unknown warp signature type")
 	errInvalidPredicateBytes   = errors.New("cannot unpack predicate bytes")
 	errInvalidWarpMsg          = errors.New("cannot unpack warp message")
 	errCannotParseWarpMsg      = errors.New("cannot parse warp message")
@@ -48,6 +50,16 @@ var (
 type Config struct {
 	precompileconfig.Upgrade
 	QuorumNumerator uint64 `json:"quorumNumerator"`
+	// PerSignerGas replaces the flat signature verification cost of a warp predicate
+	// with a cost based on the BLS pairings, the number of signers and the size of the
+	// validator set indexed by the signature. It is enabled by reconfiguring Warp with
+	// a precompile upgrade.
+	PerSignerGas bool `json:"perSignerGas,omitempty"`
+	// ReplayProtection records the IDs of the messages returned by getVerifiedWarpMessage
+	// in the state of the precompile and rejects the messages already returned, so that
+	// each message can be consumed once. Messages must then be consumed in calls that
+	// can modify the state, rather than in static calls.
+	ReplayProtection bool `json:"replayProtection,omitempty"`
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -108,7 +120,7 @@ func (c *Config) Equal(s precompileconfig.Config) bool {
 		return false
 	}
 	equals := c.Upgrade.Equal(&other.Upgrade)
-	return equals && c.QuorumNumerator == other.QuorumNumerator
+	return equals && c.QuorumNumerator == other.QuorumNumerator && c.PerSignerGas == other.PerSignerGas && c.ReplayProtection == other.ReplayProtection
 }
 
 func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int, topics []common.Hash, logData []byte) error {
@@ -136,11 +148,14 @@ func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash com
 // 1. Base cost of the message
 // 2. Size of the message
 // 3. Number of signers
-// 4. TODO: Lookup of the validator set
+// 4. Lookup of the validator set (only if [PerSignerGas] is enabled)
 //
 // If the payload of the warp message fails parsing, return a non-nil error invalidating the transaction.
 func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
 	totalGas := GasCostPerSignatureVerification
+	if c.PerSignerGas {
+		totalGas = BLSPairingsPerVerification * GasCostPerBLSPairing
+	}
 	bytesGasCost, overflow := math.SafeMul(GasCostPerWarpMessageBytes, uint64(len(predicateBytes)))
 	if overflow {
 		return 0, fmt.Errorf("overflow calculating gas cost for warp message bytes of size %d", len(predicateBytes))
@@ -167,9 +182,9 @@ func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("%w: %s", errCannotGetNumSigners, err)
 	}
-	signerGas, overflow := math.SafeMul(uint64(numSigners), GasCostPerWarpSigner)
-	if overflow {
-		return 0, errOverflowSignersGasCost
+	signerGas, err := c.signersGas(warpMessage.Signature, numSigners)
+	if err != nil {
+		return 0, err
 	}
 	totalGas, overflow = math.SafeAdd(totalGas, signerGas)
 	if overflow {
@@ -179,16 +194,51 @@ func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
 	return totalGas, nil
 }
 
+// signersGas returns the gas charged for the [numSigners] signers of [signature].
+// If [PerSignerGas] is enabled, this includes aggregating the public keys of the
+// signers and indexing the validator set up to the highest signer.
+func (c *Config) signersGas(signature warp.Signature, numSigners int) (uint64, error) {
+	if !c.PerSignerGas {
+		signerGas, overflow := math.SafeMul(uint64(numSigners), GasCostPerWarpSigner)
+		if overflow {
+			return 0, errOverflowSignersGasCost
+		}
+		return signerGas, nil
+	}
+
+	bitSetSignature, ok := signature.(*warp.BitSetSignature)
+	if !ok {
+		return 0, fmt.Errorf("%w: %T", errUnknownSignatureType, signature)
+	}
+	aggregationGas, overflow := math.SafeMul(uint64(numSigners), GasCostPerWarpSignerAggregation)
+	if overflow {
+		return 0, errOverflowSignersGasCost
+	}
+	numIndices := set.BitsFromBytes(bitSetSignature.Signers).BitLen()
+	indexGas, overflow := math.SafeMul(uint64(numIndices), GasCostPerWarpValidatorIndex)
+	if overflow {
+		return 0, errOverflowSignersGasCost
+	}
+	signerGas, overflow := math.SafeAdd(aggregationGas, indexGas)
+	if overflow {
+		return 0, errOverflowSignersGasCost
+	}
+	return signerGas, nil
+}
+
 // VerifyPredicate returns whether the predicate described by [predicateBytes] passes verification.
 func (c *Config) VerifyPredicate(predicateContext *precompileconfig.PredicateContext, predicateBytes []byte) error {
+	predicateVerifications.Inc(1)
 	unpackedPredicateBytes, err := predicate.UnpackPredicate(predicateBytes)
 	if err != nil {
+		recordPredicateFailure(failureInvalidPredicate)
 		return fmt.Errorf("%w: %w", errInvalidPredicateBytes, err)
 	}
 
 	// Note: PredicateGas should be called before VerifyPredicate, so we should never reach an error case here.
 	warpMsg, err := warp.ParseMessage(unpackedPredicateBytes)
 	if err != nil {
+		recordPredicateFailure(failureInvalidMessage)
 		return fmt.Errorf("%w: %w", errCannotParseWarpMsg, err)
 	}
 
@@ -210,6 +260,7 @@ func (c *Config) VerifyPredicate(predicateContext *precompileconfig.PredicateCon
 
 	if err != nil {
 		log.Debug("failed to verify warp signature", "msgID", warpMsg.ID(), "err", err)
+		recordPredicateFailure(signatureFailureReason(err))
 		return fmt.Errorf("%w: %w", errFailedVerification, err)
 	}
 
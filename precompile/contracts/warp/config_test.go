@@ -37,6 +37,7 @@ func TestVerify(t *testing.T) {
 			ChainConfig: func() precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
 				config.EXPECT().IsDurango(gomock.Any()).Return(false)
+				config.EXPECT().IsStromboli(gomock.Any()).Return(false).AnyTimes()
 				return config
 			}(),
 			ExpectedError: errWarpCannotBeActivated.Error(),
@@ -71,6 +72,18 @@ func TestEqualWarpConfig(t *testing.T) {
 			Expected: false,
 		},
 
+		"different per signer gas": {
+			Config:   NewDefaultConfig(utils.NewUint64(3)),
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, PerSignerGas: true},
+			Expected: false,
+		},
+
+		"different replay protection": {
+			Config:   NewDefaultConfig(utils.NewUint64(3)),
+			Other:    &Config{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)}, ReplayProtection: true},
+			Expected: false,
+		},
+
 		"same default config": {
 			Config:   NewDefaultConfig(utils.NewUint64(3)),
 			Other:    NewDefaultConfig(utils.NewUint64(3)),
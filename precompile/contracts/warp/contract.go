@@ -34,6 +34,15 @@ const (
 	GasCostPerWarpSigner            uint64 = 500
 	GasCostPerWarpMessageBytes      uint64 = 100
 	GasCostPerSignatureVerification uint64 = 200_000
+
+	// Per signer predicate gas, used when [Config.PerSignerGas] is enabled.
+	// Verifying an aggregate BLS signature takes a fixed number of pairings, while the
+	// aggregation of the public keys scales with the number of signers and the lookup
+	// of the signers scales with the size of the validator set indexed by the bitset.
+	BLSPairingsPerVerification      uint64 = 2
+	GasCostPerBLSPairing            uint64 = 45_000
+	GasCostPerWarpSignerAggregation uint64 = 1_000
+	GasCostPerWarpValidatorIndex    uint64 = 50
 )
 
 var (
@@ -144,7 +153,7 @@ func UnpackGetVerifiedWarpBlockHashOutput(output []byte) (GetVerifiedWarpBlockHa
 }
 
 func getVerifiedWarpBlockHash(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	return handleWarpMessage(accessibleState, input, suppliedGas, blockHashHandler{})
+	return handleWarpMessage(accessibleState, input, suppliedGas, readOnly, false, blockHashHandler{})
 }
 
 // UnpackGetVerifiedWarpMessageInput attempts to unpack [input] into the uint32 type argument
@@ -187,8 +196,9 @@ func UnpackGetVerifiedWarpMessageOutput(output []byte) (GetVerifiedWarpMessageOu
 
 // getVerifiedWarpMessage retrieves the pre-verified warp message from the predicate storage slots and returns
 // the expected ABI encoding of the message to the caller.
+// With replay protection enabled, the message is consumed: it is reported as invalid to later calls.
 func getVerifiedWarpMessage(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	return handleWarpMessage(accessibleState, input, suppliedGas, addressedPayloadHandler{})
+	return handleWarpMessage(accessibleState, input, suppliedGas, readOnly, true, addressedPayloadHandler{})
 }
 
 // UnpackSendWarpMessageInput attempts to unpack [input] as []byte
@@ -272,20 +282,18 @@ func sendWarpMessage(accessibleState contract.AccessibleState, caller common.Add
 	}
 
 	// Add a log to be handled if this action is finalized.
-	topics, data, err := PackSendWarpMessageEvent(
+	// The gas of the log is included in [SendWarpMessageGasCost] and [SendWarpMessageGasCostPerByte].
+	if err := contract.EmitEvent(
+		accessibleState,
+		ContractAddress,
+		WarpABI,
+		"SendWarpMessage",
 		sourceAddress,
 		common.Hash(unsignedWarpMessage.ID()),
 		unsignedWarpMessage.Bytes(),
-	)
-	if err != nil {
+	); err != nil {
 		return nil, remainingGas, err
 	}
-	accessibleState.GetStateDB().AddLog(
-		ContractAddress,
-		topics,
-		data,
-		accessibleState.GetBlockContext().Number().Uint64(),
-	)
 
 	packed, err := PackSendWarpMessageOutput(common.Hash(unsignedWarpMessage.ID()))
 	if err != nil {
@@ -298,7 +306,7 @@ func sendWarpMessage(accessibleState contract.AccessibleState, caller common.Add
 
 // PackSendWarpMessageEvent packs the given arguments into SendWarpMessage events including topics and data.
 func PackSendWarpMessageEvent(sourceAddress common.Address, unsignedMessageID common.Hash, unsignedMessageBytes []byte) ([]common.Hash, []byte, error) {
-	return WarpABI.PackEvent("SendWarpMessage", sourceAddress, unsignedMessageID, unsignedMessageBytes)
+	return contract.PackEvent(WarpABI, "SendWarpMessage", sourceAddress, unsignedMessageID, unsignedMessageBytes)
 }
 
 // UnpackSendWarpEventDataToMessage attempts to unpack event [data] as warp.UnsignedMessage.
@@ -327,8 +335,9 @@ func createWarpPrecompile() contract.StatefulPrecompiledContract {
 		if !ok {
 			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
 		}
-		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function, contract.WithStateMutability(contract.StateMutability(method.StateMutability))))
 	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
@@ -457,6 +457,98 @@ func TestGetVerifiedWarpMessage(t *testing.T) {
 	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
 }
 
+func TestGetVerifiedWarpMessageReplayProtection(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	packagedPayloadBytes := []byte("mcsorley")
+	addressedPayload, err := payload.NewAddressedCall(
+		sourceAddress.Bytes(),
+		packagedPayloadBytes,
+	)
+	require.NoError(t, err)
+	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(54321, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(t, err)
+	warpMessagePredicateBytes := predicate.PackPredicate(warpMessage.Bytes())
+	getVerifiedWarpMsg, err := PackGetVerifiedWarpMessage(0)
+	require.NoError(t, err)
+	validOutput, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+		Message: WarpMessage{
+			SourceChainID:       common.Hash(sourceChainID),
+			OriginSenderAddress: sourceAddress,
+			Payload:             packagedPayloadBytes,
+		},
+		Valid: true,
+	})
+	require.NoError(t, err)
+	invalidOutput, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+	require.NoError(t, err)
+
+	replayProtectionConfig := NewDefaultConfig(utils.NewUint64(0))
+	replayProtectionConfig.ReplayProtection = true
+	setPredicate := func(t testing.TB, state contract.StateDB) {
+		state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+	}
+	setupBlockContext := func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits().Bytes())
+	}
+	messageGas := GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes))
+
+	tests := map[string]testutils.PrecompileTest{
+		"consume message": {
+			Caller:            callerAddr,
+			Input:             getVerifiedWarpMsg,
+			Config:            replayProtectionConfig,
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       messageGas + ConsumedMessageCheckGasCost + ConsumeMessageGasCost,
+			ExpectedRes:       validOutput,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsMessageConsumed(state, warpMessage.ID()))
+			},
+		},
+		"reject consumed message": {
+			Caller: callerAddr,
+			Input:  getVerifiedWarpMsg,
+			Config: replayProtectionConfig,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setPredicate(t, state)
+				setMessageConsumed(state, warpMessage.ID())
+			},
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       messageGas + ConsumedMessageCheckGasCost,
+			ExpectedRes:       invalidOutput,
+		},
+		"consume message in read only call": {
+			Caller:            callerAddr,
+			Input:             getVerifiedWarpMsg,
+			Config:            replayProtectionConfig,
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       messageGas + ConsumedMessageCheckGasCost + ConsumeMessageGasCost,
+			ReadOnly:          true,
+			ExpectedErr:       vmerrs.ErrWriteProtection.Error(),
+		},
+		"replay protection disabled": {
+			Caller:            callerAddr,
+			Input:             getVerifiedWarpMsg,
+			Config:            NewDefaultConfig(utils.NewUint64(0)),
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext,
+			SuppliedGas:       messageGas,
+			ReadOnly:          true,
+			ExpectedRes:       validOutput,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.False(t, IsMessageConsumed(state, warpMessage.ID()))
+			},
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
 func TestGetVerifiedWarpBlockHash(t *testing.T) {
 	networkID := uint32(54321)
 	callerAddr := common.HexToAddress("0x0123")
@@ -45,7 +45,10 @@ type messageHandler interface {
 	handleMessage(msg *warp.Message) ([]byte, error)
 }
 
-func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, suppliedGas uint64, handler messageHandler) ([]byte, uint64, error) {
+// handleWarpMessage returns the output of [handler] for the verified warp message at the
+// index in [input]. If [consume] is set and replay protection is enabled, the message is
+// recorded as consumed, and reported as invalid if it already was.
+func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, suppliedGas uint64, readOnly bool, consume bool, handler messageHandler) ([]byte, uint64, error) {
 	remainingGas, err := contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost)
 	if err != nil {
 		return nil, remainingGas, err
@@ -86,6 +89,22 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, s
 	if err != nil {
 		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidWarpMsg, err)
 	}
+	if consume && IsReplayProtectionEnabled(state) {
+		if remainingGas, err = contract.DeductGas(remainingGas, ConsumedMessageCheckGasCost); err != nil {
+			return nil, 0, err
+		}
+		messageID := warpMessage.ID()
+		if IsMessageConsumed(state, messageID) {
+			return handler.packFailed(), remainingGas, nil
+		}
+		if remainingGas, err = contract.DeductGas(remainingGas, ConsumeMessageGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+		setMessageConsumed(state, messageID)
+	}
 	res, err := handler.handleMessage(warpMessage)
 	if err != nil {
 		return nil, remainingGas, err
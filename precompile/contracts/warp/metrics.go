@@ -0,0 +1,47 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/metrics"
+)
+
+// Reasons of the predicate verification failures, reported by
+// warp_predicate_failure_<reason>.
+const (
+	failureInvalidPredicate   = "invalid_predicate"
+	failureInvalidMessage     = "invalid_message"
+	failureWrongNetwork       = "wrong_network"
+	failureInvalidBitSet      = "invalid_bitset"
+	failureInsufficientWeight = "insufficient_weight"
+	failureInvalidSignature   = "invalid_signature"
+	failureOther              = "other"
+)
+
+var predicateVerifications = metrics.GetOrRegisterCounter("warp_predicate_verification_count", nil)
+
+// recordPredicateFailure counts a failed predicate verification by [reason].
+func recordPredicateFailure(reason string) {
+	metrics.GetOrRegisterCounter("warp_predicate_failure_"+reason, nil).Inc(1)
+}
+
+// signatureFailureReason returns the reason of the signature verification
+// failure [err].
+func signatureFailureReason(err error) string {
+	switch {
+	case errors.Is(err, warp.ErrWrongNetworkID):
+		return failureWrongNetwork
+	case errors.Is(err, warp.ErrInvalidBitSet):
+		return failureInvalidBitSet
+	case errors.Is(err, warp.ErrInsufficientWeight):
+		return failureInsufficientWeight
+	case errors.Is(err, warp.ErrParseSignature), errors.Is(err, warp.ErrInvalidSignature):
+		return failureInvalidSignature
+	default:
+		return failureOther
+	}
+}
@@ -0,0 +1,50 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureFailureReason(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"wrong network": {
+			err:  warp.ErrWrongNetworkID,
+			want: failureWrongNetwork,
+		},
+		"invalid bitset": {
+			err:  fmt.Errorf("%w: %w", errFailedVerification, warp.ErrInvalidBitSet),
+			want: failureInvalidBitSet,
+		},
+		"insufficient weight": {
+			err:  fmt.Errorf("%w: 1 < 2", warp.ErrInsufficientWeight),
+			want: failureInsufficientWeight,
+		},
+		"unparsable signature": {
+			err:  warp.ErrParseSignature,
+			want: failureInvalidSignature,
+		},
+		"invalid signature": {
+			err:  warp.ErrInvalidSignature,
+			want: failureInvalidSignature,
+		},
+		"other": {
+			err:  errors.New("unexpected"),
+			want: failureOther,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.want, signatureFailureReason(test.err))
+		})
+	}
+}
@@ -19,6 +19,9 @@ var _ contract.Configurator = &configurator{}
 // must be unique across all precompiles.
 const ConfigKey = "warpConfig"
 
+// Version is the interface version of the precompile returned by precompileVersion.
+const Version = 1
+
 // ContractAddress is the address of the warp precompile contract
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000005")
 
@@ -26,8 +29,10 @@ var ContractAddress = common.HexToAddress("0x02000000000000000000000000000000000
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
+	Version:      Version,
 	Contract:     WarpPrecompile,
 	Configurator: &configurator{},
+	ABI:          &WarpABI,
 }
 
 type configurator struct{}
@@ -46,10 +51,12 @@ func (*configurator) MakeConfig() precompileconfig.Config {
 	return new(Config)
 }
 
-// Configure is a no-op for warp since it does not need to store any information in the state
+// Configure stores whether replay protection is enabled in the state.
 func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg precompileconfig.Config, state contract.StateDB, _ contract.ConfigurationBlockContext) error {
-	if _, ok := cfg.(*Config); !ok {
+	config, ok := cfg.(*Config)
+	if !ok {
 		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
 	}
+	setReplayProtection(state, config.ReplayProtection)
 	return nil
 }
@@ -592,6 +592,62 @@ func TestWarpSignatureWeightsNonDefaultQuorumNumerator(t *testing.T) {
 	testutils.RunPredicateTests(t, tests)
 }
 
+func TestWarpPerSignerGas(t *testing.T) {
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       100,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	predicateContext := &precompileconfig.PredicateContext{
+		SnowCtx: snowCtx,
+		ProposerVMBlockCtx: &block.Context{
+			PChainHeight: 1,
+		},
+	}
+	config := NewDefaultConfig(utils.NewUint64(0))
+	config.PerSignerGas = true
+	baseGas := BLSPairingsPerVerification * GasCostPerBLSPairing
+
+	tests := make(map[string]testutils.PredicateTest)
+	for _, numSigners := range []int{1, int(WarpDefaultQuorumNumerator), int(WarpQuorumDenominator)} {
+		predicateBytes := createPredicate(numSigners)
+		var expectedErr error
+		if numSigners < int(WarpDefaultQuorumNumerator) {
+			expectedErr = errFailedVerification
+		}
+		tests[fmt.Sprintf("per signer gas %d signature(s)", numSigners)] = testutils.PredicateTest{
+			Config:           config,
+			PredicateContext: predicateContext,
+			PredicateBytes:   predicateBytes,
+			Gas:              baseGas + uint64(len(predicateBytes))*GasCostPerWarpMessageBytes + uint64(numSigners)*(GasCostPerWarpSignerAggregation+GasCostPerWarpValidatorIndex),
+			ExpectedErr:      expectedErr,
+		}
+	}
+
+	// A single signer with a high index is charged for the lookup of every lower index.
+	const signerIndex = 99
+	bitSet := set.NewBits(signerIndex)
+	warpSignature := &avalancheWarp.BitSetSignature{
+		Signers: bitSet.Bytes(),
+	}
+	copy(warpSignature.Signature[:], bls.SignatureToBytes(blsSignatures[signerIndex]))
+	warpMsg, err := avalancheWarp.NewMessage(unsignedMsg, warpSignature)
+	require.NoError(t, err)
+	predicateBytes := predicate.PackPredicate(warpMsg.Bytes())
+	tests["per signer gas sparse signers"] = testutils.PredicateTest{
+		Config:           config,
+		PredicateContext: predicateContext,
+		PredicateBytes:   predicateBytes,
+		Gas:              baseGas + uint64(len(predicateBytes))*GasCostPerWarpMessageBytes + GasCostPerWarpSignerAggregation + (signerIndex+1)*GasCostPerWarpValidatorIndex,
+		ExpectedErr:      errFailedVerification,
+	}
+
+	testutils.RunPredicateTests(t, tests)
+}
+
 func initWarpPredicateTests() {
 	for _, totalNodes := range []int{10, 100, 1_000, 10_000} {
 		testName := fmt.Sprintf("%d signers/%d validators", totalNodes, totalNodes)
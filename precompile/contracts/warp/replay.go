@@ -0,0 +1,55 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// ConsumedMessageCheckGasCost is charged when getVerifiedWarpMessage reads whether
+	// replay protection is enabled and whether the message was consumed.
+	ConsumedMessageCheckGasCost = contract.ReadGasCostPerSlot * 2
+	// ConsumeMessageGasCost is charged when getVerifiedWarpMessage records a message as
+	// consumed, with replay protection enabled.
+	ConsumeMessageGasCost = contract.WriteGasCostPerSlot
+)
+
+var (
+	replayProtectionStorageKey = common.Hash{'r', 'p', 's', 'k'}
+	consumedMessagePrefix      = []byte("consumedMessage")
+)
+
+// ConsumedMessageStorageKey returns the storage key recording that the message [messageID]
+// was consumed.
+func ConsumedMessageStorageKey(messageID ids.ID) common.Hash {
+	return crypto.Keccak256Hash(consumedMessagePrefix, messageID[:])
+}
+
+func setReplayProtection(stateDB contract.StateDB, enabled bool) {
+	var value common.Hash
+	if enabled {
+		value[common.HashLength-1] = 1
+	}
+	stateDB.SetState(ContractAddress, replayProtectionStorageKey, value)
+}
+
+// IsReplayProtectionEnabled returns true if the current config of warp in [stateDB]
+// enables replay protection.
+func IsReplayProtectionEnabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, replayProtectionStorageKey) != (common.Hash{})
+}
+
+// IsMessageConsumed returns true if the message [messageID] was consumed with
+// replay protection enabled.
+func IsMessageConsumed(stateDB contract.StateDB, messageID ids.ID) bool {
+	return stateDB.GetState(ContractAddress, ConsumedMessageStorageKey(messageID)) != (common.Hash{})
+}
+
+func setMessageConsumed(stateDB contract.StateDB, messageID ids.ID) {
+	stateDB.SetState(ContractAddress, ConsumedMessageStorageKey(messageID), common.Hash{31: 1})
+}
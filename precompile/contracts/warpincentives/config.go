@@ -0,0 +1,88 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warpincentives
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+var (
+	errWarpIncentivesCannotBeActivated = errors.New("warp incentives cannot be activated before Stromboli")
+	errInvalidFeeShare                 = errors.New("invalid fee share")
+)
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for WarpIncentives.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	// FeeShareBasisPoints is the portion of the fees credited to the coinbase in each block,
+	// in basis points, that accrues to the reward pool instead. Burned fees do not accrue.
+	// If 0, the reward pool is only funded by transfers to the precompile address.
+	FeeShareBasisPoints uint64 `json:"feeShareBasisPoints,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// WarpIncentives with the given [admins], [enableds] and [managers] as members of the allowlist
+// and [feeShareBasisPoints] of the block fees accruing to the reward pool.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, feeShareBasisPoints uint64) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		FeeShareBasisPoints: feeShareBasisPoints,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables WarpIncentives.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the WarpIncentives precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
+		return err
+	}
+	if c.Timestamp() != nil && !chainConfig.IsStromboli(*c.Timestamp()) {
+		return errWarpIncentivesCannotBeActivated
+	}
+	if c.FeeShareBasisPoints > MaxFeeShareBasisPoints {
+		return fmt.Errorf("%w: %d basis points exceeds the maximum of %d", errInvalidFeeShare, c.FeeShareBasisPoints, MaxFeeShareBasisPoints)
+	}
+	return nil
+}
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) &&
+		c.AllowListConfig.Equal(&other.AllowListConfig) &&
+		c.FeeShareBasisPoints == other.FeeShareBasisPoints
+}
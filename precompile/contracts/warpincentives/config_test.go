@@ -0,0 +1,77 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warpincentives
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	stromboliChainConfig := func() precompileconfig.ChainConfig {
+		config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+		config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+		config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+		return config
+	}
+	tests := map[string]testutils.ConfigVerifyTest{
+		"valid config after Stromboli": {
+			Config:      NewConfig(utils.NewUint64(3), admins, nil, nil, MaxFeeShareBasisPoints),
+			ChainConfig: stromboliChainConfig(),
+		},
+		"invalid cannot activated before Stromboli activation": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			ExpectedError: errWarpIncentivesCannotBeActivated.Error(),
+		},
+		"fee share exceeds maximum": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, MaxFeeShareBasisPoints+1),
+			ChainConfig:   stromboliChainConfig(),
+			ExpectedError: errInvalidFeeShare.Error(),
+		},
+		"disable config": {
+			Config:      NewDisableConfig(utils.NewUint64(3)),
+			ChainConfig: stromboliChainConfig(),
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			Other:    NewConfig(utils.NewUint64(4), admins, nil, nil, 100),
+			Expected: false,
+		},
+		"different fee share": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, 200),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, 100),
+			Expected: true,
+		},
+	}
+	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
+}
@@ -0,0 +1,406 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warpincentives
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// BasisPointsDenominator is the denominator of [Config.FeeShareBasisPoints].
+	BasisPointsDenominator = 10_000
+	// MaxFeeShareBasisPoints is the maximum portion of the block fees that can accrue to the reward pool.
+	MaxFeeShareBasisPoints = 1_000
+
+	// MaxReportedValidators is the maximum number of validators credited by a single report.
+	MaxReportedValidators = 256
+
+	// ReportServedSignaturesGasCost is the base gas cost of reportServedSignatures, and
+	// ReportServedSignaturesGasCostPerValidator is charged in addition for each reported validator.
+	ReportServedSignaturesGasCost             uint64 = allowlist.ReadAllowListGasCost + contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot // read allow list + read and write total
+	ReportServedSignaturesGasCostPerValidator uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + ServedSignaturesReportedEventGasCost
+	// ClaimRewardsGasCost reads the served signatures, their total and the pool balance and writes
+	// the served signatures, their total and the balances of the pool and the caller.
+	ClaimRewardsGasCost          uint64 = 3*contract.ReadGasCostPerSlot + 4*contract.WriteGasCostPerSlot + RewardsClaimedEventGasCost
+	ServedSignaturesGasCost      uint64 = contract.ReadGasCostPerSlot
+	TotalServedSignaturesGasCost uint64 = contract.ReadGasCostPerSlot
+	PendingRewardsGasCost        uint64 = 3 * contract.ReadGasCostPerSlot // read served signatures, total and pool balance
+	FeeShareGasCost              uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	ErrCannotReportServedSignatures = errors.New("non-enabled cannot call reportServedSignatures")
+	ErrInvalidReport                = errors.New("invalid served signatures report")
+	ErrNoRewards                    = errors.New("no rewards to claim")
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// WarpIncentivesRawABI contains the raw ABI of WarpIncentives contract.
+	//go:embed contract.abi
+	WarpIncentivesRawABI string
+
+	WarpIncentivesABI = contract.ParseABI(WarpIncentivesRawABI)
+
+	WarpIncentivesPrecompile = createWarpIncentivesPrecompile()
+
+	feeShareStorageKey        = common.Hash{'f', 's', 's', 'k'}
+	totalServedStorageKey     = common.Hash{'t', 's', 's', 'k'}
+	servedSignaturesPrefix    = []byte("servedSignatures")
+	basisPointsDenominatorBig = big.NewInt(BasisPointsDenominator)
+)
+
+// GetWarpIncentivesAllowListStatus returns the role of [address] for the WarpIncentives list.
+func GetWarpIncentivesAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetWarpIncentivesAllowListStatus sets the permissions of [address] to [role] for the
+// WarpIncentives list. Assumes [role] has already been verified as valid.
+func SetWarpIncentivesAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// GetFeeShare returns the portion of the block fees, in basis points, that accrues to the reward pool.
+func GetFeeShare(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, feeShareStorageKey).Big().Uint64()
+}
+
+// StoreFeeShare stores [basisPoints] as the portion of the block fees that accrues to the reward pool.
+// Assumes [basisPoints] has already been verified to not exceed [MaxFeeShareBasisPoints].
+func StoreFeeShare(stateDB contract.StateDB, basisPoints uint64) {
+	stateDB.SetState(ContractAddress, feeShareStorageKey, common.BigToHash(new(big.Int).SetUint64(basisPoints)))
+}
+
+// FeeShareOf returns the portion of [fees] that accrues to the reward pool in [stateDB].
+func FeeShareOf(stateDB contract.StateDB, fees *big.Int) *big.Int {
+	share := new(big.Int).SetUint64(GetFeeShare(stateDB))
+	share.Mul(share, fees)
+	return share.Div(share, basisPointsDenominatorBig)
+}
+
+// ServedSignaturesStorageKey returns the storage key of the number of signatures
+// served by [validator].
+func ServedSignaturesStorageKey(validator common.Address) common.Hash {
+	return crypto.Keccak256Hash(servedSignaturesPrefix, validator.Bytes())
+}
+
+// GetServedSignatures returns the number of served signatures credited to [validator] and not claimed yet.
+func GetServedSignatures(stateDB contract.StateDB, validator common.Address) *big.Int {
+	return stateDB.GetState(ContractAddress, ServedSignaturesStorageKey(validator)).Big()
+}
+
+// GetTotalServedSignatures returns the number of served signatures credited to all validators and not claimed yet.
+func GetTotalServedSignatures(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, totalServedStorageKey).Big()
+}
+
+// GetPendingRewards returns the rewards [validator] would receive by claiming now, which is
+// the share of the reward pool proportional to its served signatures.
+func GetPendingRewards(stateDB contract.StateDB, validator common.Address) *big.Int {
+	total := GetTotalServedSignatures(stateDB)
+	if total.Sign() == 0 {
+		return new(big.Int)
+	}
+	rewards := new(big.Int).Mul(stateDB.GetBalance(ContractAddress), GetServedSignatures(stateDB, validator))
+	return rewards.Div(rewards, total)
+}
+
+// PackReportServedSignatures packs [validators] and [counts] into the appropriate arguments for reportServedSignatures.
+func PackReportServedSignatures(validators []common.Address, counts []*big.Int) ([]byte, error) {
+	return WarpIncentivesABI.Pack("reportServedSignatures", validators, counts)
+}
+
+// UnpackReportServedSignaturesInput attempts to unpack [input] into the arguments of reportServedSignatures.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackReportServedSignaturesInput(input []byte) ([]common.Address, []*big.Int, error) {
+	inputStruct := struct {
+		Validators []common.Address
+		Counts     []*big.Int
+	}{}
+	if err := WarpIncentivesABI.UnpackInputIntoInterface(&inputStruct, "reportServedSignatures", input, false); err != nil {
+		return nil, nil, err
+	}
+	return inputStruct.Validators, inputStruct.Counts, nil
+}
+
+// reportServedSignatures credits counts[i] served signatures to validators[i] for each i.
+// Served signatures are tracked off-chain by the nodes aggregating warp signatures, so
+// reports are restricted to enabled addresses of the allow list.
+func reportServedSignatures(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ReportServedSignaturesGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	validators, counts, err := UnpackReportServedSignaturesInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotReportServedSignatures, caller)
+	}
+	if len(validators) != len(counts) {
+		return nil, remainingGas, fmt.Errorf("%w: %d validators, %d counts", ErrInvalidReport, len(validators), len(counts))
+	}
+	if len(validators) == 0 || len(validators) > MaxReportedValidators {
+		return nil, remainingGas, fmt.Errorf("%w: number of validators (%d) must be between 1 and %d", ErrInvalidReport, len(validators), MaxReportedValidators)
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, ReportServedSignaturesGasCostPerValidator*uint64(len(validators))); err != nil {
+		return nil, 0, err
+	}
+
+	// Verify the whole report before touching the state.
+	total := GetTotalServedSignatures(stateDB)
+	for i, count := range counts {
+		if count.Sign() <= 0 {
+			return nil, remainingGas, fmt.Errorf("%w: count of %s must be positive", ErrInvalidReport, validators[i])
+		}
+		total.Add(total, count)
+	}
+	// The served signatures of each validator are bounded by the total.
+	if total.BitLen() > 256 {
+		return nil, remainingGas, fmt.Errorf("%w: total served signatures exceed 256 bits", ErrInvalidReport)
+	}
+
+	blockNumber := accessibleState.GetBlockContext().Number().Uint64()
+	for i, validator := range validators {
+		topics, data, err := PackServedSignaturesReportedEvent(caller, validator, counts[i])
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		stateDB.AddLog(ContractAddress, topics, data, blockNumber)
+
+		served := GetServedSignatures(stateDB, validator)
+		stateDB.SetState(ContractAddress, ServedSignaturesStorageKey(validator), common.BigToHash(served.Add(served, counts[i])))
+	}
+	stateDB.SetState(ContractAddress, totalServedStorageKey, common.BigToHash(total))
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackClaimRewards packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackClaimRewards() ([]byte, error) {
+	return WarpIncentivesABI.Pack("claimRewards")
+}
+
+// PackClaimRewardsOutput attempts to pack given [amount] of type *big.Int
+// to conform the ABI outputs.
+func PackClaimRewardsOutput(amount *big.Int) ([]byte, error) {
+	return WarpIncentivesABI.PackOutput("claimRewards", amount)
+}
+
+// claimRewards transfers the pending rewards of the caller from the reward pool to the
+// caller and resets its served signatures.
+func claimRewards(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ClaimRewardsGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	// no input provided for this function
+
+	stateDB := accessibleState.GetStateDB()
+	amount := GetPendingRewards(stateDB, caller)
+	// Keep the served signatures of the caller until the pool can pay for them.
+	if amount.Sign() == 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrNoRewards, caller)
+	}
+
+	topics, data, err := PackRewardsClaimedEvent(caller, amount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+
+	total := GetTotalServedSignatures(stateDB)
+	total.Sub(total, GetServedSignatures(stateDB, caller))
+	stateDB.SetState(ContractAddress, totalServedStorageKey, common.BigToHash(total))
+	stateDB.SetState(ContractAddress, ServedSignaturesStorageKey(caller), common.Hash{})
+
+	stateDB.SubBalance(ContractAddress, amount)
+	stateDB.AddBalance(caller, amount)
+
+	packedOutput, err := PackClaimRewardsOutput(amount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackServedSignatures packs [validator] of type common.Address into the appropriate arguments for servedSignatures.
+func PackServedSignatures(validator common.Address) ([]byte, error) {
+	return WarpIncentivesABI.Pack("servedSignatures", validator)
+}
+
+// UnpackServedSignaturesInput attempts to unpack [input] into the common.Address type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackServedSignaturesInput(input []byte) (common.Address, error) {
+	res, err := WarpIncentivesABI.UnpackInput("servedSignatures", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return unpacked, nil
+}
+
+// PackServedSignaturesOutput attempts to pack given [count] of type *big.Int
+// to conform the ABI outputs.
+func PackServedSignaturesOutput(count *big.Int) ([]byte, error) {
+	return WarpIncentivesABI.PackOutput("servedSignatures", count)
+}
+
+func servedSignatures(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ServedSignaturesGasCost); err != nil {
+		return nil, 0, err
+	}
+	validator, err := UnpackServedSignaturesInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	packedOutput, err := PackServedSignaturesOutput(GetServedSignatures(accessibleState.GetStateDB(), validator))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackTotalServedSignatures packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackTotalServedSignatures() ([]byte, error) {
+	return WarpIncentivesABI.Pack("totalServedSignatures")
+}
+
+// PackTotalServedSignaturesOutput attempts to pack given [count] of type *big.Int
+// to conform the ABI outputs.
+func PackTotalServedSignaturesOutput(count *big.Int) ([]byte, error) {
+	return WarpIncentivesABI.PackOutput("totalServedSignatures", count)
+}
+
+func totalServedSignatures(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TotalServedSignaturesGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	packedOutput, err := PackTotalServedSignaturesOutput(GetTotalServedSignatures(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackPendingRewards packs [validator] of type common.Address into the appropriate arguments for pendingRewards.
+func PackPendingRewards(validator common.Address) ([]byte, error) {
+	return WarpIncentivesABI.Pack("pendingRewards", validator)
+}
+
+// UnpackPendingRewardsInput attempts to unpack [input] into the common.Address type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackPendingRewardsInput(input []byte) (common.Address, error) {
+	res, err := WarpIncentivesABI.UnpackInput("pendingRewards", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return unpacked, nil
+}
+
+// PackPendingRewardsOutput attempts to pack given [amount] of type *big.Int
+// to conform the ABI outputs.
+func PackPendingRewardsOutput(amount *big.Int) ([]byte, error) {
+	return WarpIncentivesABI.PackOutput("pendingRewards", amount)
+}
+
+func pendingRewards(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, PendingRewardsGasCost); err != nil {
+		return nil, 0, err
+	}
+	validator, err := UnpackPendingRewardsInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	packedOutput, err := PackPendingRewardsOutput(GetPendingRewards(accessibleState.GetStateDB(), validator))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackFeeShare packs the function selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackFeeShare() ([]byte, error) {
+	return WarpIncentivesABI.Pack("feeShare")
+}
+
+// PackFeeShareOutput attempts to pack given [basisPoints] of type *big.Int
+// to conform the ABI outputs.
+func PackFeeShareOutput(basisPoints *big.Int) ([]byte, error) {
+	return WarpIncentivesABI.PackOutput("feeShare", basisPoints)
+}
+
+func feeShare(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, FeeShareGasCost); err != nil {
+		return nil, 0, err
+	}
+	// no input provided for this function
+
+	packedOutput, err := PackFeeShareOutput(new(big.Int).SetUint64(GetFeeShare(accessibleState.GetStateDB())))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createWarpIncentivesPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the getters/setters is controlled by an allow list for [ContractAddress].
+func createWarpIncentivesPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+
+	stromboliFunctionMap := map[string]struct {
+		function contract.RunStatefulPrecompileFunc
+		opts     []contract.FunctionOption
+	}{
+		"reportServedSignatures": {function: reportServedSignatures},
+		"claimRewards":           {function: claimRewards, opts: []contract.FunctionOption{contract.WithGasCost(ClaimRewardsGasCost)}},
+		"servedSignatures":       {function: servedSignatures, opts: []contract.FunctionOption{contract.WithGasCost(ServedSignaturesGasCost)}},
+		"totalServedSignatures":  {function: totalServedSignatures, opts: []contract.FunctionOption{contract.WithGasCost(TotalServedSignaturesGasCost)}},
+		"pendingRewards":         {function: pendingRewards, opts: []contract.FunctionOption{contract.WithGasCost(PendingRewardsGasCost)}},
+		"feeShare":               {function: feeShare, opts: []contract.FunctionOption{contract.WithGasCost(FeeShareGasCost)}},
+	}
+	for name, entry := range stromboliFunctionMap {
+		method, ok := WarpIncentivesABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		opts := append([]contract.FunctionOption{contract.WithStateMutability(contract.StateMutability(method.StateMutability))}, entry.opts...)
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, entry.function, contract.IsStromboliActivated, opts...))
+	}
+	functions = append(functions, contract.NewPrecompileVersionFunction(Version))
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
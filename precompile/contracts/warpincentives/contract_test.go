@@ -0,0 +1,311 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warpincentives
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	validator1 = common.HexToAddress("0x0456")
+	validator2 = common.HexToAddress("0x0789")
+
+	tests = map[string]testutils.PrecompileTest{
+		"report before Stromboli": {
+			Caller:      allowlist.TestEnabledAddr,
+			BeforeHook:  allowlist.SetDefaultRoles(Module.Address),
+			InputFn:     reportInput([]common.Address{validator1}, []*big.Int{big.NewInt(1)}),
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"report from no role fails": {
+			Caller:        allowlist.TestNoRoleAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{validator1}, []*big.Int{big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost,
+			ExpectedErr:   ErrCannotReportServedSignatures.Error(),
+		},
+		"report from enabled address": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{validator1, validator2}, []*big.Int{big.NewInt(3), big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost + 2*ReportServedSignaturesGasCostPerValidator,
+			ExpectedRes:   []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(3), GetServedSignatures(stateDB, validator1))
+				require.Equal(t, big.NewInt(1), GetServedSignatures(stateDB, validator2))
+				require.Equal(t, big.NewInt(4), GetTotalServedSignatures(stateDB))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 2)
+				for i, validator := range []common.Address{validator1, validator2} {
+					require.Equal(t, WarpIncentivesABI.Events["ServedSignaturesReported"].ID, logsTopics[i][0])
+					require.Equal(t, common.BytesToHash(allowlist.TestEnabledAddr[:]), logsTopics[i][1])
+					require.Equal(t, common.BytesToHash(validator[:]), logsTopics[i][2])
+				}
+				count, err := UnpackServedSignaturesReportedEventData(logsData[0])
+				require.NoError(t, err)
+				require.Equal(t, big.NewInt(3), count)
+			},
+		},
+		"report adds to unclaimed signatures": {
+			Caller: allowlist.TestManagerAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, stateDB)
+				storeServedSignatures(stateDB, validator1, 2)
+			},
+			InputFn:       reportInput([]common.Address{validator1, validator1}, []*big.Int{big.NewInt(3), big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost + 2*ReportServedSignaturesGasCostPerValidator,
+			ExpectedRes:   []byte{},
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(6), GetServedSignatures(stateDB, validator1))
+				require.Equal(t, big.NewInt(6), GetTotalServedSignatures(stateDB))
+			},
+		},
+		"report with mismatched lengths fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{validator1, validator2}, []*big.Int{big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost,
+			ExpectedErr:   ErrInvalidReport.Error(),
+		},
+		"report without validators fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{}, []*big.Int{}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost,
+			ExpectedErr:   ErrInvalidReport.Error(),
+		},
+		"report with zero count fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{validator1, validator2}, []*big.Int{big.NewInt(1), big.NewInt(0)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost + 2*ReportServedSignaturesGasCostPerValidator,
+			ExpectedErr:   ErrInvalidReport.Error(),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Zero(t, GetTotalServedSignatures(stateDB).Sign())
+			},
+		},
+		"report overflowing total fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, stateDB)
+				storeServedSignatures(stateDB, validator1, 1)
+			},
+			InputFn:       reportInput([]common.Address{validator2}, []*big.Int{abi.MaxUint256}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost + ReportServedSignaturesGasCostPerValidator,
+			ExpectedErr:   ErrInvalidReport.Error(),
+		},
+		"report with insufficient gas for validators fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{validator1, validator2}, []*big.Int{big.NewInt(1), big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost + ReportServedSignaturesGasCostPerValidator,
+			ExpectedErr:   vmerrs.ErrOutOfGas.Error(),
+		},
+		"report readOnly fails": {
+			Caller:        allowlist.TestEnabledAddr,
+			BeforeHook:    allowlist.SetDefaultRoles(Module.Address),
+			InputFn:       reportInput([]common.Address{validator1}, []*big.Int{big.NewInt(1)}),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ReportServedSignaturesGasCost + ReportServedSignaturesGasCostPerValidator,
+			ReadOnly:      true,
+			ExpectedErr:   vmerrs.ErrWriteProtection.Error(),
+		},
+		"claim rewards": {
+			Caller: validator1,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeServedSignatures(stateDB, validator1, 3)
+				storeServedSignatures(stateDB, validator2, 1)
+				stateDB.AddBalance(ContractAddress, big.NewInt(101))
+			},
+			InputFn:       packNoArgs(PackClaimRewards),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ClaimRewardsGasCost,
+			ExpectedRes:   packOutput(PackClaimRewardsOutput, big.NewInt(75)),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(75), stateDB.GetBalance(validator1))
+				require.Equal(t, big.NewInt(26), stateDB.GetBalance(ContractAddress))
+				require.Zero(t, GetServedSignatures(stateDB, validator1).Sign())
+				require.Equal(t, big.NewInt(1), GetTotalServedSignatures(stateDB))
+				// The remaining validator is entitled to the rest of the pool.
+				require.Equal(t, big.NewInt(26), GetPendingRewards(stateDB, validator2))
+
+				logsTopics, logsData := stateDB.GetLogData()
+				require.Len(t, logsTopics, 1)
+				require.Equal(t, WarpIncentivesABI.Events["RewardsClaimed"].ID, logsTopics[0][0])
+				require.Equal(t, common.BytesToHash(validator1[:]), logsTopics[0][1])
+				amount, err := UnpackRewardsClaimedEventData(logsData[0])
+				require.NoError(t, err)
+				require.Equal(t, big.NewInt(75), amount)
+			},
+		},
+		"claim without served signatures fails": {
+			Caller: validator2,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeServedSignatures(stateDB, validator1, 3)
+				stateDB.AddBalance(ContractAddress, big.NewInt(100))
+			},
+			InputFn:       packNoArgs(PackClaimRewards),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ClaimRewardsGasCost,
+			ExpectedErr:   ErrNoRewards.Error(),
+		},
+		"claim from empty pool keeps served signatures": {
+			Caller: validator1,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeServedSignatures(stateDB, validator1, 3)
+			},
+			InputFn:       packNoArgs(PackClaimRewards),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ClaimRewardsGasCost,
+			ExpectedErr:   ErrNoRewards.Error(),
+			AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+				require.Equal(t, big.NewInt(3), GetServedSignatures(stateDB, validator1))
+			},
+		},
+		"claim readOnly fails": {
+			Caller:        validator1,
+			InputFn:       packNoArgs(PackClaimRewards),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ClaimRewardsGasCost,
+			ReadOnly:      true,
+			ExpectedErr:   vmerrs.ErrWriteProtection.Error(),
+		},
+		"read served signatures": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeServedSignatures(stateDB, validator1, 3)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackServedSignatures(validator1)
+				require.NoError(t, err)
+				return input
+			},
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   ServedSignaturesGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   packOutput(PackServedSignaturesOutput, big.NewInt(3)),
+		},
+		"read total served signatures": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeServedSignatures(stateDB, validator1, 3)
+				storeServedSignatures(stateDB, validator2, 2)
+			},
+			InputFn:       packNoArgs(PackTotalServedSignatures),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   TotalServedSignaturesGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   packOutput(PackTotalServedSignaturesOutput, big.NewInt(5)),
+		},
+		"read pending rewards": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, stateDB contract.StateDB) {
+				storeServedSignatures(stateDB, validator1, 3)
+				storeServedSignatures(stateDB, validator2, 2)
+				stateDB.AddBalance(ContractAddress, big.NewInt(100))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackPendingRewards(validator2)
+				require.NoError(t, err)
+				return input
+			},
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   PendingRewardsGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   packOutput(PackPendingRewardsOutput, big.NewInt(40)),
+		},
+		"read fee share": {
+			Caller:        allowlist.TestNoRoleAddr,
+			Config:        NewConfig(nil, nil, nil, nil, 250),
+			InputFn:       packNoArgs(PackFeeShare),
+			ChainConfigFn: stromboliChainConfig,
+			SuppliedGas:   FeeShareGasCost,
+			ReadOnly:      true,
+			ExpectedRes:   packOutput(PackFeeShareOutput, big.NewInt(250)),
+		},
+	}
+)
+
+func stromboliChainConfig(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(ctrl)
+	config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
+	config.EXPECT().AllowedFeeRecipients().Return(false).AnyTimes()
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsStromboli(gomock.Any()).Return(true).AnyTimes()
+	return config
+}
+
+// storeServedSignatures credits [count] served signatures to [validator].
+func storeServedSignatures(stateDB contract.StateDB, validator common.Address, count int64) {
+	served := GetServedSignatures(stateDB, validator)
+	stateDB.SetState(ContractAddress, ServedSignaturesStorageKey(validator), common.BigToHash(served.Add(served, big.NewInt(count))))
+	total := GetTotalServedSignatures(stateDB)
+	stateDB.SetState(ContractAddress, totalServedStorageKey, common.BigToHash(total.Add(total, big.NewInt(count))))
+}
+
+func reportInput(validators []common.Address, counts []*big.Int) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := PackReportServedSignatures(validators, counts)
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func packNoArgs(pack func() ([]byte, error)) func(t testing.TB) []byte {
+	return func(t testing.TB) []byte {
+		input, err := pack()
+		require.NoError(t, err)
+		return input
+	}
+}
+
+func packOutput(pack func(*big.Int) ([]byte, error), value *big.Int) []byte {
+	output, err := pack(value)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+func TestPackUnpackReportServedSignatures(t *testing.T) {
+	validators := []common.Address{validator1, validator2}
+	counts := []*big.Int{big.NewInt(1), abi.MaxUint256}
+	input, err := PackReportServedSignatures(validators, counts)
+	require.NoError(t, err)
+	unpackedValidators, unpackedCounts, err := UnpackReportServedSignaturesInput(input[4:])
+	require.NoError(t, err)
+	require.Equal(t, validators, unpackedValidators)
+	require.Equal(t, counts, unpackedCounts)
+}
+
+func TestWarpIncentivesRun(t *testing.T) {
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func BenchmarkWarpIncentives(b *testing.B) {
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
+}
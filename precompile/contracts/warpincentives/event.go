@@ -0,0 +1,52 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warpincentives
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ServedSignaturesReportedEventGasCost is the gas cost of the ServedSignaturesReported event.
+	// It is the base gas cost + the gas cost of the topics (signature, reporter, validator)
+	// and the gas cost of the non-indexed data (32 bytes for count).
+	ServedSignaturesReportedEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*common.HashLength
+	// RewardsClaimedEventGasCost is the gas cost of the RewardsClaimed event.
+	// It is the base gas cost + the gas cost of the topics (signature, validator)
+	// and the gas cost of the non-indexed data (32 bytes for amount).
+	RewardsClaimedEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*common.HashLength
+)
+
+// PackServedSignaturesReportedEvent packs the event into the appropriate arguments for ServedSignaturesReported.
+// It returns topic hashes and the encoded non-indexed data.
+func PackServedSignaturesReportedEvent(reporter common.Address, validator common.Address, count *big.Int) ([]common.Hash, []byte, error) {
+	return WarpIncentivesABI.PackEvent("ServedSignaturesReported", reporter, validator, count)
+}
+
+// UnpackServedSignaturesReportedEventData attempts to unpack non-indexed [dataBytes].
+func UnpackServedSignaturesReportedEventData(dataBytes []byte) (*big.Int, error) {
+	var eventData = struct {
+		Count *big.Int
+	}{}
+	err := WarpIncentivesABI.UnpackIntoInterface(&eventData, "ServedSignaturesReported", dataBytes)
+	return eventData.Count, err
+}
+
+// PackRewardsClaimedEvent packs the event into the appropriate arguments for RewardsClaimed.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRewardsClaimedEvent(validator common.Address, amount *big.Int) ([]common.Hash, []byte, error) {
+	return WarpIncentivesABI.PackEvent("RewardsClaimed", validator, amount)
+}
+
+// UnpackRewardsClaimedEventData attempts to unpack non-indexed [dataBytes].
+func UnpackRewardsClaimedEventData(dataBytes []byte) (*big.Int, error) {
+	var eventData = struct {
+		Amount *big.Int
+	}{}
+	err := WarpIncentivesABI.UnpackIntoInterface(&eventData, "RewardsClaimed", dataBytes)
+	return eventData.Amount, err
+}
@@ -6,6 +6,7 @@ package modules
 import (
 	"bytes"
 
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -15,11 +16,24 @@ type Module struct {
 	ConfigKey string
 	// Address returns the address where the stateful precompile is accessible.
 	Address common.Address
+	// Version is the interface version of the stateful precompile returned by its precompileVersion function.
+	// It must be incremented whenever functions are added to the precompile's interface.
+	Version uint64
 	// Contract returns a thread-safe singleton that can be used as the StatefulPrecompiledContract when
 	// this config is enabled.
 	Contract contract.StatefulPrecompiledContract
 	// Configurator is used to configure the stateful precompile when the config is enabled.
 	contract.Configurator
+	// ABI is the interface of the stateful precompile, if any. It is used to decode the calls
+	// to the precompile and the logs it emits, e.g. by tracers.
+	ABI *abi.ABI
+	// Reservation is the name of the address range reservation the address belongs to, if any.
+	// See [ReserveAddressRange].
+	Reservation string
+	// ConfigurableAddress allows chains to move the precompile to another address with
+	// [SetModuleAddress]. Such a precompile must not rely on a compile-time address: it must
+	// use the address it is run at and the address returned by [ModuleAddress].
+	ConfigurableAddress bool
 }
 
 type moduleArray []Module
@@ -0,0 +1,23 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package modules
+
+import "github.com/ethereum/go-ethereum/common"
+
+// commonPredeploys are the addresses of contracts commonly deployed at the same
+// address on every EVM chain, that precompiles must not shadow.
+var commonPredeploys = map[common.Address]string{
+	common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C"): "deterministic deployment proxy",
+	common.HexToAddress("0x914d7Fec6aaC8cd542e72Bca78B30650d45643d7"): "safe singleton factory",
+	common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"): "multicall3",
+	common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3"): "permit2",
+	common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"): "ERC-4337 entry point v0.6",
+	common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032"): "ERC-4337 entry point v0.7",
+}
+
+// CommonPredeploy returns the name of the commonly deployed contract at [addr], if any.
+func CommonPredeploy(addr common.Address) (string, bool) {
+	name, ok := commonPredeploys[addr]
+	return name, ok
+}
@@ -4,7 +4,11 @@
 package modules
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 	"sort"
 
 	"github.com/ava-labs/subnet-evm/constants"
@@ -17,6 +21,10 @@ var (
 	// for deterministic iteration
 	registeredModules = make([]Module, 0)
 
+	// reservations is a list of the address ranges reserved by
+	// sets of modules, in reservation order.
+	reservations = make([]Reservation, 0)
+
 	reservedRanges = []utils.AddressRange{
 		{
 			Start: common.HexToAddress("0x0100000000000000000000000000000000000000"),
@@ -31,8 +39,25 @@ var (
 			End:   common.HexToAddress("0x03000000000000000000000000000000000000ff"),
 		},
 	}
+
+	ErrAddressCollision     = errors.New("precompile address collision")
+	ErrAddressReserved      = errors.New("precompile address reserved")
+	ErrAddressNotAllowed    = errors.New("precompile address not allowed")
+	ErrUnknownModule        = errors.New("unknown precompile module")
+	ErrAddressNotSettable   = errors.New("precompile address cannot be configured")
+	ErrNoAddressAvailable   = errors.New("no precompile address available")
+	errInvalidReservation   = errors.New("invalid address range reservation")
+	errDuplicateReservation = errors.New("address range reservation name already used")
 )
 
+// Reservation is a range of precompile addresses reserved for the modules
+// registered with the same reservation name.
+type Reservation struct {
+	// Name identifies the reservation, typically the set of modules it is made for.
+	Name string
+	utils.AddressRange
+}
+
 // ReservedAddress returns true if [addr] is in a reserved range for custom precompiles
 func ReservedAddress(addr common.Address) bool {
 	for _, reservedRange := range reservedRanges {
@@ -44,31 +69,171 @@ func ReservedAddress(addr common.Address) bool {
 	return false
 }
 
+// ReservedRanges returns the address ranges reserved for precompiles.
+func ReservedRanges() []utils.AddressRange {
+	return slices.Clone(reservedRanges)
+}
+
+// ReserveAddressRange reserves [addressRange] for the modules registered with the
+// reservation [name]. The range must be within one of the precompile reserved ranges,
+// must not overlap another reservation, and must not contain a module registered
+// with another reservation. This should be called from an init function, before the
+// modules using the reservation are registered.
+func ReserveAddressRange(name string, addressRange utils.AddressRange) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", errInvalidReservation)
+	}
+	if bytes.Compare(addressRange.Start.Bytes(), addressRange.End.Bytes()) > 0 {
+		return fmt.Errorf("%w: start %s after end %s", errInvalidReservation, addressRange.Start, addressRange.End)
+	}
+	if !withinReservedRange(addressRange) {
+		return fmt.Errorf("%w: range %s-%s not in a reserved range", errInvalidReservation, addressRange.Start, addressRange.End)
+	}
+	for _, reservation := range reservations {
+		if reservation.Name == name {
+			return fmt.Errorf("%w: %s", errDuplicateReservation, name)
+		}
+		if overlaps(reservation.AddressRange, addressRange) {
+			return fmt.Errorf("%w: range %s-%s overlaps the range %s-%s reserved by %s", ErrAddressCollision, addressRange.Start, addressRange.End, reservation.Start, reservation.End, reservation.Name)
+		}
+	}
+	for _, module := range registeredModules {
+		if addressRange.Contains(module.Address) && module.Reservation != name {
+			return fmt.Errorf("%w: range %s-%s contains the address %s of %s", ErrAddressCollision, addressRange.Start, addressRange.End, module.Address, module.ConfigKey)
+		}
+	}
+	reservations = append(reservations, Reservation{Name: name, AddressRange: addressRange})
+	return nil
+}
+
+// Reservations returns the address range reservations in reservation order.
+func Reservations() []Reservation {
+	return reservations
+}
+
+// AllocateAddress returns the lowest address a new module registered with the
+// reservation [reservation] can use: within the range of [reservation], or outside
+// of any reservation if [reservation] is empty, and not used by a registered module.
+// This helps custom precompiles to pick an address that does not collide with the
+// modules shipped with Subnet-EVM.
+func AllocateAddress(reservation string) (common.Address, error) {
+	ranges := reservedRanges
+	if reservation != "" {
+		ranges = nil
+		for _, r := range reservations {
+			if r.Name == reservation {
+				ranges = []utils.AddressRange{r.AddressRange}
+				break
+			}
+		}
+		if ranges == nil {
+			return common.Address{}, fmt.Errorf("%w: no address range reserved by %s", ErrAddressNotAllowed, reservation)
+		}
+	}
+	stm := Module{Reservation: reservation}
+	for _, r := range ranges {
+		start, end := new(big.Int).SetBytes(r.Start.Bytes()), new(big.Int).SetBytes(r.End.Bytes())
+		for i := start; i.Cmp(end) <= 0; i.Add(i, common.Big1) {
+			address := common.BigToAddress(i)
+			if verifyAddress(stm, address) == nil {
+				return address, nil
+			}
+		}
+	}
+	return common.Address{}, ErrNoAddressAvailable
+}
+
 // RegisterModule registers a stateful precompile module
 func RegisterModule(stm Module) error {
-	address := stm.Address
 	key := stm.ConfigKey
+	for _, registeredModule := range registeredModules {
+		if registeredModule.ConfigKey == key {
+			return fmt.Errorf("name %s already used by a stateful precompile", key)
+		}
+	}
+	if err := verifyAddress(stm, stm.Address); err != nil {
+		return err
+	}
+	// sort by address to ensure deterministic iteration
+	registeredModules = insertSortedByAddress(registeredModules, stm)
+	return nil
+}
+
+// SetModuleAddress moves the module registered with [key] to [address], so that a
+// chain can configure the address of a custom precompile instead of using its
+// compile-time address. The module must allow it with [Module.ConfigurableAddress]
+// and [address] is validated as when registering the module.
+// This must be called before the chain config using the module is used.
+func SetModuleAddress(key string, address common.Address) error {
+	for i, module := range registeredModules {
+		if module.ConfigKey != key {
+			continue
+		}
+		if module.Address == address {
+			return nil
+		}
+		if !module.ConfigurableAddress {
+			return fmt.Errorf("%w: %s", ErrAddressNotSettable, key)
+		}
+		if err := verifyAddress(module, address); err != nil {
+			return err
+		}
+		registeredModules[i].Address = address
+		sort.Sort(moduleArray(registeredModules))
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownModule, key)
+}
 
+// verifyAddress returns an error if [stm] cannot be registered at [address].
+func verifyAddress(stm Module, address common.Address) error {
 	if address == constants.BlackholeAddr {
-		return fmt.Errorf("address %s overlaps with blackhole address", address)
+		return fmt.Errorf("%w: address %s overlaps with blackhole address", ErrAddressNotAllowed, address)
+	}
+	if name, ok := CommonPredeploy(address); ok {
+		return fmt.Errorf("%w: address %s collides with the %s predeploy", ErrAddressNotAllowed, address, name)
 	}
 	if !ReservedAddress(address) {
-		return fmt.Errorf("address %s not in a reserved range", address)
+		return fmt.Errorf("%w: address %s not in a reserved range", ErrAddressNotAllowed, address)
 	}
-
 	for _, registeredModule := range registeredModules {
-		if registeredModule.ConfigKey == key {
-			return fmt.Errorf("name %s already used by a stateful precompile", key)
+		if registeredModule.ConfigKey != stm.ConfigKey && registeredModule.Address == address {
+			return fmt.Errorf("%w: address %s already used by %s", ErrAddressCollision, address, registeredModule.ConfigKey)
 		}
-		if registeredModule.Address == address {
-			return fmt.Errorf("address %s already used by a stateful precompile", address)
+	}
+	reserved := stm.Reservation == ""
+	for _, reservation := range reservations {
+		if reservation.Contains(address) && reservation.Name != stm.Reservation {
+			return fmt.Errorf("%w: address %s reserved by %s", ErrAddressReserved, address, reservation.Name)
+		}
+		if reservation.Name == stm.Reservation {
+			if !reservation.Contains(address) {
+				return fmt.Errorf("%w: address %s outside of the range reserved by %s", ErrAddressNotAllowed, address, reservation.Name)
+			}
+			reserved = true
 		}
 	}
-	// sort by address to ensure deterministic iteration
-	registeredModules = insertSortedByAddress(registeredModules, stm)
+	if !reserved {
+		return fmt.Errorf("%w: no address range reserved by %s", ErrAddressNotAllowed, stm.Reservation)
+	}
 	return nil
 }
 
+// withinReservedRange returns true if [addressRange] is within one of the reserved ranges.
+func withinReservedRange(addressRange utils.AddressRange) bool {
+	for _, reservedRange := range reservedRanges {
+		if reservedRange.Contains(addressRange.Start) && reservedRange.Contains(addressRange.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps returns true if [a] and [b] share at least one address.
+func overlaps(a, b utils.AddressRange) bool {
+	return bytes.Compare(a.Start.Bytes(), b.End.Bytes()) <= 0 && bytes.Compare(b.Start.Bytes(), a.End.Bytes()) <= 0
+}
+
 func GetPrecompileModuleByAddress(address common.Address) (Module, bool) {
 	for _, stm := range registeredModules {
 		if stm.Address == address {
@@ -78,6 +243,13 @@ func GetPrecompileModuleByAddress(address common.Address) (Module, bool) {
 	return Module{}, false
 }
 
+// ModuleAddress returns the address of the module registered with [key], taking into
+// account the address configured by the chain, or the zero address if there is none.
+func ModuleAddress(key string) common.Address {
+	module, _ := GetPrecompileModule(key)
+	return module.Address
+}
+
 func GetPrecompileModule(key string) (Module, bool) {
 	for _, stm := range registeredModules {
 		if stm.ConfigKey == key {
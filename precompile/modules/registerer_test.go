@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -57,3 +58,122 @@ func TestRegisterModuleInvalidAddresses(t *testing.T) {
 	err = RegisterModule(m)
 	require.ErrorContains(t, err, "not in a reserved range")
 }
+
+// withRegistry runs [f] with empty registered modules and reservations, restoring them afterwards.
+func withRegistry(t *testing.T, f func()) {
+	t.Helper()
+	modules, reserved := registeredModules, reservations
+	registeredModules, reservations = make([]Module, 0), make([]Reservation, 0)
+	defer func() {
+		registeredModules, reservations = modules, reserved
+	}()
+	f()
+}
+
+func TestReserveAddressRange(t *testing.T) {
+	withRegistry(t, func() {
+		require := require.New(t)
+		rangeA := utils.AddressRange{
+			Start: common.HexToAddress("0x0300000000000000000000000000000000000010"),
+			End:   common.HexToAddress("0x030000000000000000000000000000000000001f"),
+		}
+		require.NoError(ReserveAddressRange("a", rangeA))
+		require.Equal([]Reservation{{Name: "a", AddressRange: rangeA}}, Reservations())
+
+		require.ErrorIs(ReserveAddressRange("", rangeA), errInvalidReservation)
+		require.ErrorIs(ReserveAddressRange("a", utils.AddressRange{Start: rangeA.End, End: rangeA.End}), errDuplicateReservation)
+		require.ErrorIs(ReserveAddressRange("b", utils.AddressRange{Start: rangeA.End, End: rangeA.Start}), errInvalidReservation)
+		require.ErrorIs(ReserveAddressRange("b", utils.AddressRange{
+			Start: common.HexToAddress("0x02000000000000000000000000000000000000f0"),
+			End:   common.HexToAddress("0x0300000000000000000000000000000000000000"),
+		}), errInvalidReservation)
+		require.ErrorIs(ReserveAddressRange("b", utils.AddressRange{
+			Start: common.HexToAddress("0x0300000000000000000000000000000000000000"),
+			End:   rangeA.Start,
+		}), ErrAddressCollision)
+
+		// Modules must be registered in the range reserved for them
+		require.NoError(RegisterModule(Module{ConfigKey: "a1", Address: rangeA.Start, Reservation: "a"}))
+		require.ErrorIs(RegisterModule(Module{ConfigKey: "b1", Address: rangeA.End}), ErrAddressReserved)
+		require.ErrorIs(RegisterModule(Module{ConfigKey: "a2", Address: common.HexToAddress("0x0300000000000000000000000000000000000020"), Reservation: "a"}), ErrAddressNotAllowed)
+		require.ErrorIs(RegisterModule(Module{ConfigKey: "c1", Address: common.HexToAddress("0x0300000000000000000000000000000000000020"), Reservation: "c"}), ErrAddressNotAllowed)
+		require.ErrorIs(RegisterModule(Module{ConfigKey: "a2", Address: rangeA.Start, Reservation: "a"}), ErrAddressCollision)
+
+		// A range cannot be reserved over modules registered without the reservation
+		b1 := common.HexToAddress("0x0300000000000000000000000000000000000030")
+		require.NoError(RegisterModule(Module{ConfigKey: "b1", Address: b1}))
+		require.ErrorIs(ReserveAddressRange("b", utils.AddressRange{Start: b1, End: b1}), ErrAddressCollision)
+	})
+}
+
+func TestSetModuleAddress(t *testing.T) {
+	withRegistry(t, func() {
+		require := require.New(t)
+		fixed := common.HexToAddress("0x0300000000000000000000000000000000000001")
+		configurable := common.HexToAddress("0x0300000000000000000000000000000000000002")
+		moved := common.HexToAddress("0x0300000000000000000000000000000000000000")
+		require.NoError(RegisterModule(Module{ConfigKey: "fixed", Address: fixed}))
+		require.NoError(RegisterModule(Module{ConfigKey: "configurable", Address: configurable, ConfigurableAddress: true}))
+
+		require.ErrorIs(SetModuleAddress("unknown", moved), ErrUnknownModule)
+		require.ErrorIs(SetModuleAddress("fixed", moved), ErrAddressNotSettable)
+		require.NoError(SetModuleAddress("fixed", fixed))
+		require.ErrorIs(SetModuleAddress("configurable", fixed), ErrAddressCollision)
+		require.ErrorIs(SetModuleAddress("configurable", common.BigToAddress(big.NewInt(1))), ErrAddressNotAllowed)
+
+		require.NoError(SetModuleAddress("configurable", moved))
+		require.Equal(moved, ModuleAddress("configurable"))
+		module, ok := GetPrecompileModuleByAddress(moved)
+		require.True(ok)
+		require.Equal("configurable", module.ConfigKey)
+		_, ok = GetPrecompileModuleByAddress(configurable)
+		require.False(ok)
+		// Modules are kept sorted by address
+		require.Equal(moved, RegisteredModules()[0].Address)
+	})
+}
+
+func TestAllocateAddress(t *testing.T) {
+	withRegistry(t, func() {
+		require := require.New(t)
+		first := common.HexToAddress("0x0100000000000000000000000000000000000001")
+		// The blackhole address is never allocated
+		address, err := AllocateAddress("")
+		require.NoError(err)
+		require.Equal(first, address)
+
+		require.NoError(RegisterModule(Module{ConfigKey: "a", Address: first}))
+		address, err = AllocateAddress("")
+		require.NoError(err)
+		require.Equal(common.HexToAddress("0x0100000000000000000000000000000000000002"), address)
+
+		// Reserved ranges are only allocated to their reservation
+		reserved := utils.AddressRange{
+			Start: common.HexToAddress("0x0100000000000000000000000000000000000002"),
+			End:   common.HexToAddress("0x0100000000000000000000000000000000000003"),
+		}
+		require.NoError(ReserveAddressRange("r", reserved))
+		address, err = AllocateAddress("")
+		require.NoError(err)
+		require.Equal(common.HexToAddress("0x0100000000000000000000000000000000000004"), address)
+		address, err = AllocateAddress("r")
+		require.NoError(err)
+		require.Equal(reserved.Start, address)
+
+		require.NoError(RegisterModule(Module{ConfigKey: "r1", Address: reserved.Start, Reservation: "r"}))
+		require.NoError(RegisterModule(Module{ConfigKey: "r2", Address: reserved.End, Reservation: "r"}))
+		_, err = AllocateAddress("r")
+		require.ErrorIs(err, ErrNoAddressAvailable)
+		_, err = AllocateAddress("unknown")
+		require.ErrorIs(err, ErrAddressNotAllowed)
+	})
+}
+
+func TestCommonPredeploys(t *testing.T) {
+	name, ok := CommonPredeploy(common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"))
+	require.True(t, ok)
+	require.Equal(t, "multicall3", name)
+
+	err := RegisterModule(Module{ConfigKey: "multicall", Address: common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")})
+	require.ErrorIs(t, err, ErrAddressNotAllowed)
+}
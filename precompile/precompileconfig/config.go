@@ -90,4 +90,6 @@ type ChainConfig interface {
 	AllowedFeeRecipients() bool
 	// IsDurango returns true if the time is after Durango.
 	IsDurango(time uint64) bool
+	// IsStromboli returns true if the time is after Stromboli.
+	IsStromboli(time uint64) bool
 }
@@ -227,6 +227,20 @@ func (mr *MockChainConfigMockRecorder) IsDurango(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDurango", reflect.TypeOf((*MockChainConfig)(nil).IsDurango), arg0)
 }
 
+// IsStromboli mocks base method.
+func (m *MockChainConfig) IsStromboli(arg0 uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsStromboli", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsStromboli indicates an expected call of IsStromboli.
+func (mr *MockChainConfigMockRecorder) IsStromboli(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStromboli", reflect.TypeOf((*MockChainConfig)(nil).IsStromboli), arg0)
+}
+
 // MockAccepter is a mock of Accepter interface.
 type MockAccepter struct {
 	ctrl     *gomock.Controller
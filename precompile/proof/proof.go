@@ -0,0 +1,146 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package proof verifies the Merkle proofs returned by eth_getProof for the
+// storage of stateful precompiles, so that off-chain systems can check allow
+// list roles and precompile configs against a trusted state root.
+//
+// The storage keys of the precompiles are derived by their packages, e.g.
+// allowlist.RoleStorageKey and feemanager.FeeConfigStorageKeys.
+package proof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var errStorageProofs = errors.New("number of storage proofs does not match number of storage keys")
+
+// VerifyAccount verifies [proof], the hex encoded trie nodes of an account
+// proof, against [root] and returns the account of [address]. A nil account is
+// returned if the proof shows that the account does not exist.
+func VerifyAccount(root common.Hash, address common.Address, proof []string) (*types.StateAccount, error) {
+	value, err := verify(root, crypto.Keccak256(address.Bytes()), proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account proof of %s: %w", address.Hex(), err)
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	account := new(types.StateAccount)
+	if err := rlp.DecodeBytes(value, account); err != nil {
+		return nil, fmt.Errorf("invalid account %s: %w", address.Hex(), err)
+	}
+	return account, nil
+}
+
+// VerifyStorage verifies [proof], the hex encoded trie nodes of a storage
+// proof, against [storageRoot] and returns the value stored under [key].
+func VerifyStorage(storageRoot common.Hash, key common.Hash, proof []string) (common.Hash, error) {
+	value, err := verify(storageRoot, crypto.Keccak256(key.Bytes()), proof)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid storage proof of %s: %w", key.Hex(), err)
+	}
+	if len(value) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid storage value of %s: %w", key.Hex(), err)
+	}
+	return common.BytesToHash(content), nil
+}
+
+// VerifyPrecompileStorage verifies the account proof of the precompile at
+// [precompileAddr] against [root], and the storage proofs of [keys] against its
+// storage root. It returns the values stored under [keys].
+func VerifyPrecompileStorage(root common.Hash, precompileAddr common.Address, accountProof []string, keys []common.Hash, storageProofs [][]string) ([]common.Hash, error) {
+	if len(keys) != len(storageProofs) {
+		return nil, errStorageProofs
+	}
+	account, err := VerifyAccount(root, precompileAddr, accountProof)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]common.Hash, len(keys))
+	if account == nil {
+		// The precompile was never activated, so its storage is empty.
+		return values, nil
+	}
+	for i, key := range keys {
+		if values[i], err = VerifyStorage(account.Root, key, storageProofs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// VerifyAllowListRole verifies the role of [address] in the allow list of the
+// precompile at [precompileAddr] against [root]. [storageProof] is the proof of
+// the key returned by allowlist.RoleStorageKey.
+func VerifyAllowListRole(root common.Hash, precompileAddr common.Address, address common.Address, accountProof []string, storageProof []string) (allowlist.Role, error) {
+	values, err := VerifyPrecompileStorage(root, precompileAddr, accountProof, []common.Hash{allowlist.RoleStorageKey(address)}, [][]string{storageProof})
+	if err != nil {
+		return allowlist.NoRole, err
+	}
+	return allowlist.Role(values[0]), nil
+}
+
+// VerifyFeeConfig verifies the fee config stored by the fee manager precompile
+// against [root], and returns it along with the block number at which it was
+// last changed. [storageProofs] are the proofs of the keys returned by
+// feemanager.FeeConfigStorageKeys, followed by the proof of the key returned by
+// feemanager.FeeConfigLastChangedAtStorageKey.
+func VerifyFeeConfig(root common.Hash, accountProof []string, storageProofs [][]string) (commontype.FeeConfig, *big.Int, error) {
+	keys := append(feemanager.FeeConfigStorageKeys(), feemanager.FeeConfigLastChangedAtStorageKey())
+	values, err := VerifyPrecompileStorage(root, feemanager.ContractAddress, accountProof, keys, storageProofs)
+	if err != nil {
+		return commontype.FeeConfig{}, nil, err
+	}
+	feeConfig, err := feemanager.UnpackFeeConfigStorage(values[:len(values)-1])
+	if err != nil {
+		return commontype.FeeConfig{}, nil, err
+	}
+	return feeConfig, values[len(values)-1].Big(), nil
+}
+
+// VerifyRewardAddress verifies the reward address stored by the reward manager
+// precompile against [root], and returns it along with whether fee recipients
+// are allowed instead. [storageProof] is the proof of the key returned by
+// rewardmanager.RewardAddressStorageKey.
+func VerifyRewardAddress(root common.Hash, accountProof []string, storageProof []string) (common.Address, bool, error) {
+	values, err := VerifyPrecompileStorage(root, rewardmanager.ContractAddress, accountProof, []common.Hash{rewardmanager.RewardAddressStorageKey()}, [][]string{storageProof})
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	address, allowFeeRecipients := rewardmanager.UnpackRewardAddressStorage(values[0])
+	return address, allowFeeRecipients, nil
+}
+
+// verify verifies [proof] of [key] against [root] and returns the proven value.
+func verify(root common.Hash, key []byte, proof []string) ([]byte, error) {
+	db := memorydb.New()
+	for _, encoded := range proof {
+		node, err := hexutil.Decode(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	return trie.VerifyProof(root, key, db)
+}
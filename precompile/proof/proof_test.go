@@ -0,0 +1,96 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type blockContext struct {
+	number *big.Int
+}
+
+func (b blockContext) Number() *big.Int  { return b.number }
+func (b blockContext) Timestamp() uint64 { return 0 }
+
+func toHex(proof [][]byte) []string {
+	encoded := make([]string, len(proof))
+	for i, node := range proof {
+		encoded[i] = hexutil.Encode(node)
+	}
+	return encoded
+}
+
+func TestVerifyPrecompileStorage(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		enabled   = common.Address{1}
+		unlisted  = common.Address{2}
+		feeConfig = commontype.ValidTestFeeConfig
+	)
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	for _, addr := range []common.Address{txallowlist.ContractAddress, feemanager.ContractAddress} {
+		statedb.SetNonce(addr, 1)
+		statedb.SetCode(addr, []byte{0x1})
+	}
+	txallowlist.SetTxAllowListStatus(statedb, enabled, allowlist.EnabledRole)
+	require.NoError(feemanager.StoreFeeConfig(statedb, feeConfig, blockContext{number: big.NewInt(7)}))
+	root, err := statedb.Commit(0, false, false)
+	require.NoError(err)
+
+	statedb, err = state.New(root, statedb.Database(), nil)
+	require.NoError(err)
+	proveStorage := func(addr common.Address, keys []common.Hash) ([]string, [][]string) {
+		accountProof, err := statedb.GetProof(addr)
+		require.NoError(err)
+		storageProofs := make([][]string, len(keys))
+		for i, key := range keys {
+			storageProof, err := statedb.GetStorageProof(addr, key)
+			require.NoError(err)
+			storageProofs[i] = toHex(storageProof)
+		}
+		return toHex(accountProof), storageProofs
+	}
+
+	// Allow list membership and non-membership
+	for addr, want := range map[common.Address]allowlist.Role{enabled: allowlist.EnabledRole, unlisted: allowlist.NoRole} {
+		accountProof, storageProofs := proveStorage(txallowlist.ContractAddress, []common.Hash{allowlist.RoleStorageKey(addr)})
+		role, err := VerifyAllowListRole(root, txallowlist.ContractAddress, addr, accountProof, storageProofs[0])
+		require.NoError(err)
+		require.Equal(want, role)
+	}
+
+	// Fee config
+	accountProof, storageProofs := proveStorage(feemanager.ContractAddress, append(feemanager.FeeConfigStorageKeys(), feemanager.FeeConfigLastChangedAtStorageKey()))
+	gotFeeConfig, lastChangedAt, err := VerifyFeeConfig(root, accountProof, storageProofs)
+	require.NoError(err)
+	require.True(feeConfig.Equal(&gotFeeConfig))
+	require.Equal(big.NewInt(7), lastChangedAt)
+
+	// Proofs do not verify against another root
+	_, _, err = VerifyFeeConfig(common.Hash{1}, accountProof, storageProofs)
+	require.Error(err)
+
+	// Precompiles that were never activated have empty storage, for which
+	// eth_getProof returns empty storage proofs
+	accountProof, _ = proveStorage(deployerallowlist.ContractAddress, nil)
+	role, err := VerifyAllowListRole(root, deployerallowlist.ContractAddress, enabled, accountProof, nil)
+	require.NoError(err)
+	require.Equal(allowlist.NoRole, role)
+}
@@ -18,6 +18,18 @@ import (
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/precompileregistry"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/randomness"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/multisend"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/warpincentives"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/blockhashhistory"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/governance"
 	// ADD YOUR PRECOMPILE HERE
 	// _ "github.com/ava-labs/subnet-evm/precompile/contracts/yourprecompile"
 )
@@ -34,11 +46,21 @@ import (
 // For forks of subnet-evm, users should start at 0x0300000000000000000000000000000000000000 to ensure
 // that their own modifications do not conflict with stateful precompiles that may be added to subnet-evm
 // in the future.
+// Sets of precompiles imported together can reserve a sub-range of these addresses with
+// modules.ReserveAddressRange so that collisions with other imported precompiles are detected
+// at init. Precompiles registered with ConfigurableAddress can also be moved to another address
+// by a chain with the "precompileAddresses" field of its chain config.
 // ContractDeployerAllowListAddress = common.HexToAddress("0x0200000000000000000000000000000000000000")
 // ContractNativeMinterAddress      = common.HexToAddress("0x0200000000000000000000000000000000000001")
 // TxAllowListAddress               = common.HexToAddress("0x0200000000000000000000000000000000000002")
 // FeeManagerAddress                = common.HexToAddress("0x0200000000000000000000000000000000000003")
 // RewardManagerAddress             = common.HexToAddress("0x0200000000000000000000000000000000000004")
 // WarpAddress                      = common.HexToAddress("0x0200000000000000000000000000000000000005")
+// PrecompileRegistryAddress        = common.HexToAddress("0x0200000000000000000000000000000000000006")
+// RandomnessAddress                = common.HexToAddress("0x0200000000000000000000000000000000000007")
+// MultisendAddress                 = common.HexToAddress("0x0200000000000000000000000000000000000008")
+// WarpIncentivesAddress            = common.HexToAddress("0x0200000000000000000000000000000000000009")
+// BlockHashHistoryAddress          = common.HexToAddress("0x020000000000000000000000000000000000000a")
+// GovernanceAddress                = common.HexToAddress("0x020000000000000000000000000000000000000b")
 // ADD YOUR PRECOMPILE HERE
 // {YourPrecompile}Address          = common.HexToAddress("0x03000000000000000000000000000000000000??")
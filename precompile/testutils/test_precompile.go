@@ -4,6 +4,7 @@
 package testutils
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -29,6 +31,8 @@ type PrecompileTest struct {
 	InputFn func(t testing.TB) []byte
 	// SuppliedGas is the amount of gas supplied to the precompile
 	SuppliedGas uint64
+	// Value is the value transferred to the precompile by the call, or zero if nil.
+	Value *big.Int
 	// ReadOnly is whether the precompile should be called in read only
 	// mode. If true, the precompile should not modify the state.
 	ReadOnly bool
@@ -96,6 +100,7 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state cont
 			mockChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(commontype.ValidTestFeeConfig)
 			mockChainConfig.EXPECT().AllowedFeeRecipients().AnyTimes().Return(false)
 			mockChainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(true)
+			mockChainConfig.EXPECT().IsStromboli(gomock.Any()).AnyTimes().Return(false)
 			return mockChainConfig
 		}
 	}
@@ -115,6 +120,22 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state cont
 	accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
 	accessibleState.EXPECT().GetSnowContext().Return(snowContext).AnyTimes()
 	accessibleState.EXPECT().GetChainConfig().Return(chainConfig).AnyTimes()
+	value := test.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	accessibleState.EXPECT().GetCallValue().Return(value).AnyTimes()
+	// Calls to other precompiles are dispatched to the registered modules, without
+	// the reentrancy guard and snapshots of the EVM.
+	accessibleState.EXPECT().CallPrecompile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+			module, ok := modules.GetPrecompileModuleByAddress(addr)
+			if !ok {
+				return nil, suppliedGas, fmt.Errorf("%w: %s", vmerrs.ErrNotStatefulPrecompile, addr)
+			}
+			return module.Contract.Run(accessibleState, caller, addr, input, suppliedGas, readOnly)
+		},
+	).AnyTimes()
 
 	if test.Config != nil {
 		err := module.Configure(chainConfig, test.Config, state, blockContext)
@@ -53,6 +53,7 @@ func UnpackPredicate(paddedPredicate []byte) ([]byte, error) {
 
 // GetPredicateResultBytes returns the predicate result bytes from the extra data and
 // true iff the predicate results bytes have non-zero length.
+// The operator data between the fee window and the predicate results, if any, is skipped.
 func GetPredicateResultBytes(extraData []byte) ([]byte, bool) {
 	// Prior to Durango, the VM enforces the extra data is smaller than or equal to this size.
 	// After Durango, the VM pre-verifies the extra data past the dynamic fee rollup window is
@@ -60,5 +61,10 @@ func GetPredicateResultBytes(extraData []byte) ([]byte, bool) {
 	if len(extraData) <= params.DynamicFeeExtraDataSize {
 		return nil, false
 	}
-	return extraData[params.DynamicFeeExtraDataSize:], true
+	resultBytes := extraData[params.DynamicFeeExtraDataSize:]
+	// Malformed operator data is left in place and rejected when verifying the header.
+	if _, rest, err := params.ParseOperatorData(resultBytes); err == nil {
+		resultBytes = rest
+	}
+	return resultBytes, len(resultBytes) > 0
 }
@@ -64,3 +64,19 @@ func TestPredicateResultsBytes(t *testing.T) {
 	require.True(ok)
 	require.Equal(resultBytes, postDurangoData[params.DynamicFeeExtraDataSize:])
 }
+
+func TestPredicateResultsBytesWithOperatorData(t *testing.T) {
+	require := require.New(t)
+	feeWindow := utils.RandomBytes(params.DynamicFeeExtraDataSize)
+	operatorData := params.PackOperatorData([]byte("rollup commitment"))
+	results, err := NewResults().Bytes()
+	require.NoError(err)
+
+	extraData := append(append(append([]byte{}, feeWindow...), operatorData...), results...)
+	resultBytes, ok := GetPredicateResultBytes(extraData)
+	require.True(ok)
+	require.Equal(results, resultBytes)
+
+	_, ok = GetPredicateResultBytes(append(append([]byte{}, feeWindow...), operatorData...))
+	require.False(ok)
+}
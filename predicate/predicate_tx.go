@@ -4,15 +4,26 @@
 package predicate
 
 import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+var (
+	ErrNoPredicater         = errors.New("no predicater at address")
+	ErrPredicateGasOverflow = errors.New("predicate gas overflow")
 )
 
 // NewPredicateTx returns a transaction with the predicateAddress/predicateBytes tuple
 // packed and added to the access list of the transaction.
+// Use TxBuilder to build transactions carrying several predicates.
 func NewPredicateTx(
 	chainID *big.Int,
 	nonce uint64,
@@ -26,19 +37,167 @@ func NewPredicateTx(
 	predicateAddress common.Address,
 	predicateBytes []byte,
 ) *types.Transaction {
-	accessList = append(accessList, types.AccessTuple{
+	// Build cannot fail since the predicate gas is not estimated
+	tx, _ := NewTxBuilder(chainID).
+		Nonce(nonce).
+		To(to).
+		Gas(gas).
+		GasFeeCap(gasFeeCap).
+		GasTipCap(gasTipCap).
+		Value(value).
+		Data(data).
+		AccessList(accessList).
+		AddPredicate(predicateAddress, predicateBytes).
+		Build()
+	return tx
+}
+
+// TxBuilder builds dynamic fee transactions carrying predicates, e.g. the
+// delivery of several warp messages in a single transaction.
+// The predicates are appended to the access list of the transaction in the
+// order they are added, after the tuples of the access list set by AccessList.
+type TxBuilder struct {
+	tx         types.DynamicFeeTx
+	predicates types.AccessList
+	rules      *params.Rules
+}
+
+// NewTxBuilder returns a builder of transactions for the chain [chainID].
+func NewTxBuilder(chainID *big.Int) *TxBuilder {
+	return &TxBuilder{
+		tx: types.DynamicFeeTx{ChainID: chainID},
+	}
+}
+
+// Nonce sets the nonce of the transaction.
+func (b *TxBuilder) Nonce(nonce uint64) *TxBuilder {
+	b.tx.Nonce = nonce
+	return b
+}
+
+// To sets the recipient of the transaction, nil for a contract creation.
+func (b *TxBuilder) To(to *common.Address) *TxBuilder {
+	b.tx.To = to
+	return b
+}
+
+// Gas sets the gas limit of the transaction. If EstimatePredicateGas is set,
+// the gas of the predicates is added to [gas] by Build.
+func (b *TxBuilder) Gas(gas uint64) *TxBuilder {
+	b.tx.Gas = gas
+	return b
+}
+
+// GasFeeCap sets the maximum fee per gas of the transaction.
+func (b *TxBuilder) GasFeeCap(gasFeeCap *big.Int) *TxBuilder {
+	b.tx.GasFeeCap = gasFeeCap
+	return b
+}
+
+// GasTipCap sets the maximum priority fee per gas of the transaction.
+func (b *TxBuilder) GasTipCap(gasTipCap *big.Int) *TxBuilder {
+	b.tx.GasTipCap = gasTipCap
+	return b
+}
+
+// Value sets the amount transferred by the transaction.
+func (b *TxBuilder) Value(value *big.Int) *TxBuilder {
+	b.tx.Value = value
+	return b
+}
+
+// Data sets the input data of the transaction.
+func (b *TxBuilder) Data(data []byte) *TxBuilder {
+	b.tx.Data = data
+	return b
+}
+
+// AccessList sets the access list the predicates are merged into.
+// Tuples of [accessList] at the address of a predicater are verified as
+// predicates like the ones added by AddPredicate.
+func (b *TxBuilder) AccessList(accessList types.AccessList) *TxBuilder {
+	b.tx.AccessList = accessList
+	return b
+}
+
+// AddPredicate adds [predicateBytes] to be verified by the predicater at
+// [predicateAddress]. The same address can be given several times, in which
+// case each predicate is verified separately.
+func (b *TxBuilder) AddPredicate(predicateAddress common.Address, predicateBytes []byte) *TxBuilder {
+	b.predicates = append(b.predicates, types.AccessTuple{
 		Address:     predicateAddress,
-		StorageKeys: utils.BytesToHashSlice(PackPredicate(predicateBytes)),
-	})
-	return types.NewTx(&types.DynamicFeeTx{
-		ChainID:    chainID,
-		Nonce:      nonce,
-		To:         to,
-		Gas:        gas,
-		GasFeeCap:  gasFeeCap,
-		GasTipCap:  gasTipCap,
-		Value:      value,
-		Data:       data,
-		AccessList: accessList,
+		StorageKeys: utils.BytesToHashSlice(PackPredicate(common.CopyBytes(predicateBytes))),
 	})
+	return b
+}
+
+// EstimatePredicateGas makes Build add the gas charged for the predicates of
+// the transaction under [rules] to the gas limit set by Gas.
+func (b *TxBuilder) EstimatePredicateGas(rules params.Rules) *TxBuilder {
+	b.rules = &rules
+	return b
+}
+
+// accessList returns the access list of the transaction, with the predicates
+// appended to the access list set by AccessList.
+func (b *TxBuilder) accessList() types.AccessList {
+	accessList := make(types.AccessList, 0, len(b.tx.AccessList)+len(b.predicates))
+	accessList = append(accessList, b.tx.AccessList...)
+	return append(accessList, b.predicates...)
+}
+
+// PredicateGas returns the gas charged under [rules] for the predicates of
+// the transaction, including the tuples of the access list at the address
+// of a predicater. It fails if a predicate added by AddPredicate is not at
+// the address of a predicater, since it would not be verified.
+func (b *TxBuilder) PredicateGas(rules params.Rules) (uint64, error) {
+	for _, predicate := range b.predicates {
+		if !rules.PredicaterExists(predicate.Address) {
+			return 0, fmt.Errorf("%w: %s", ErrNoPredicater, predicate.Address)
+		}
+	}
+	var gas uint64
+	for _, accessTuple := range b.accessList() {
+		predicater, ok := rules.Predicaters[accessTuple.Address]
+		if !ok {
+			continue
+		}
+		predicateGas, err := predicater.PredicateGas(utils.HashSliceToBytes(accessTuple.StorageKeys))
+		if err != nil {
+			return 0, fmt.Errorf("invalid predicate at %s: %w", accessTuple.Address, err)
+		}
+		totalGas, overflow := math.SafeAdd(gas, predicateGas)
+		if overflow {
+			return 0, ErrPredicateGasOverflow
+		}
+		gas = totalGas
+	}
+	return gas, nil
+}
+
+// Build returns the unsigned transaction.
+func (b *TxBuilder) Build() (*types.Transaction, error) {
+	tx := b.tx
+	tx.AccessList = b.accessList()
+	if b.rules != nil {
+		predicateGas, err := b.PredicateGas(*b.rules)
+		if err != nil {
+			return nil, err
+		}
+		gas, overflow := math.SafeAdd(tx.Gas, predicateGas)
+		if overflow {
+			return nil, ErrPredicateGasOverflow
+		}
+		tx.Gas = gas
+	}
+	return types.NewTx(&tx), nil
+}
+
+// Sign builds the transaction and signs it with [key] according to [signer].
+func (b *TxBuilder) Sign(signer types.Signer, key *ecdsa.PrivateKey) (*types.Transaction, error) {
+	tx, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return types.SignTx(tx, signer, key)
 }
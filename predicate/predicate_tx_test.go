@@ -0,0 +1,100 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package predicate
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTxBuilder(t *testing.T) {
+	var (
+		require          = require.New(t)
+		chainID          = big.NewInt(1)
+		predicateAddress = common.Address{1}
+		otherAddress     = common.Address{2}
+		to               = common.Address{3}
+		accessList       = types.AccessList{{Address: otherAddress, StorageKeys: []common.Hash{{4}}}}
+		predicates       = [][]byte{{1, 2, 3}, {4, 5, 6}}
+	)
+
+	predicater := precompileconfig.NewMockPredicater(gomock.NewController(t))
+	for _, predicate := range predicates {
+		predicater.EXPECT().PredicateGas(PackPredicate(common.CopyBytes(predicate))).Return(uint64(1_000), nil).AnyTimes()
+	}
+	rules := params.Rules{
+		Predicaters: map[common.Address]precompileconfig.Predicater{predicateAddress: predicater},
+	}
+
+	builder := NewTxBuilder(chainID).
+		Nonce(1).
+		To(&to).
+		Gas(21_000).
+		GasFeeCap(big.NewInt(2)).
+		GasTipCap(big.NewInt(1)).
+		AccessList(accessList).
+		AddPredicate(predicateAddress, predicates[0]).
+		AddPredicate(predicateAddress, predicates[1])
+
+	predicateGas, err := builder.PredicateGas(rules)
+	require.NoError(err)
+	require.Equal(uint64(2_000), predicateGas)
+
+	tx, err := builder.Build()
+	require.NoError(err)
+	require.Equal(uint64(21_000), tx.Gas())
+	require.Len(tx.AccessList(), 3)
+	require.Equal(accessList[0], tx.AccessList()[0])
+	for i, predicate := range predicates {
+		tuple := tx.AccessList()[i+1]
+		require.Equal(predicateAddress, tuple.Address)
+		unpacked, err := UnpackPredicate(utils.HashSliceToBytes(tuple.StorageKeys))
+		require.NoError(err)
+		require.Equal(predicate, unpacked)
+	}
+	// Building does not modify the access list of the caller
+	require.Len(accessList, 1)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	signer := types.LatestSignerForChainID(chainID)
+	signedTx, err := builder.EstimatePredicateGas(rules).Sign(signer, key)
+	require.NoError(err)
+	require.Equal(uint64(23_000), signedTx.Gas())
+	sender, err := types.Sender(signer, signedTx)
+	require.NoError(err)
+	require.Equal(crypto.PubkeyToAddress(key.PublicKey), sender)
+}
+
+func TestTxBuilderPredicateGasErrors(t *testing.T) {
+	predicateAddress := common.Address{1}
+	errInvalid := errors.New("invalid predicate")
+	predicater := precompileconfig.NewMockPredicater(gomock.NewController(t))
+	predicater.EXPECT().PredicateGas(gomock.Any()).Return(uint64(0), errInvalid).AnyTimes()
+	rules := params.Rules{
+		Predicaters: map[common.Address]precompileconfig.Predicater{predicateAddress: predicater},
+	}
+
+	_, err := NewTxBuilder(big.NewInt(1)).
+		AddPredicate(common.Address{2}, []byte{1}).
+		EstimatePredicateGas(rules).
+		Build()
+	require.ErrorIs(t, err, ErrNoPredicater)
+
+	_, err = NewTxBuilder(big.NewInt(1)).
+		AddPredicate(predicateAddress, []byte{1}).
+		EstimatePredicateGas(rules).
+		Build()
+	require.ErrorIs(t, err, errInvalid)
+}
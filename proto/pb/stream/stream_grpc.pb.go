@@ -0,0 +1,144 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: stream/stream.proto
+
+package stream
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Stream_AcceptedBlocks_FullMethodName = "/stream.Stream/AcceptedBlocks"
+)
+
+// StreamClient is the client API for Stream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StreamClient interface {
+	// AcceptedBlocks streams the accepted blocks from the requested height,
+	// followed by the blocks accepted afterwards as they are accepted. Blocks are
+	// delivered at least once: after a disconnection, clients resume from the
+	// cursor of the last block they processed.
+	AcceptedBlocks(ctx context.Context, in *AcceptedBlocksRequest, opts ...grpc.CallOption) (Stream_AcceptedBlocksClient, error)
+}
+
+type streamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreamClient(cc grpc.ClientConnInterface) StreamClient {
+	return &streamClient{cc}
+}
+
+func (c *streamClient) AcceptedBlocks(ctx context.Context, in *AcceptedBlocksRequest, opts ...grpc.CallOption) (Stream_AcceptedBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Stream_ServiceDesc.Streams[0], Stream_AcceptedBlocks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamAcceptedBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Stream_AcceptedBlocksClient interface {
+	Recv() (*AcceptedBlock, error)
+	grpc.ClientStream
+}
+
+type streamAcceptedBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamAcceptedBlocksClient) Recv() (*AcceptedBlock, error) {
+	m := new(AcceptedBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StreamServer is the server API for Stream service.
+// All implementations must embed UnimplementedStreamServer
+// for forward compatibility
+type StreamServer interface {
+	// AcceptedBlocks streams the accepted blocks from the requested height,
+	// followed by the blocks accepted afterwards as they are accepted. Blocks are
+	// delivered at least once: after a disconnection, clients resume from the
+	// cursor of the last block they processed.
+	AcceptedBlocks(*AcceptedBlocksRequest, Stream_AcceptedBlocksServer) error
+	mustEmbedUnimplementedStreamServer()
+}
+
+// UnimplementedStreamServer must be embedded to have forward compatible implementations.
+type UnimplementedStreamServer struct {
+}
+
+func (UnimplementedStreamServer) AcceptedBlocks(*AcceptedBlocksRequest, Stream_AcceptedBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method AcceptedBlocks not implemented")
+}
+func (UnimplementedStreamServer) mustEmbedUnimplementedStreamServer() {}
+
+// UnsafeStreamServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StreamServer will
+// result in compilation errors.
+type UnsafeStreamServer interface {
+	mustEmbedUnimplementedStreamServer()
+}
+
+func RegisterStreamServer(s grpc.ServiceRegistrar, srv StreamServer) {
+	s.RegisterService(&Stream_ServiceDesc, srv)
+}
+
+func _Stream_AcceptedBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AcceptedBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreamServer).AcceptedBlocks(m, &streamAcceptedBlocksServer{stream})
+}
+
+type Stream_AcceptedBlocksServer interface {
+	Send(*AcceptedBlock) error
+	grpc.ServerStream
+}
+
+type streamAcceptedBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamAcceptedBlocksServer) Send(m *AcceptedBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Stream_ServiceDesc is the grpc.ServiceDesc for Stream service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Stream_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stream.Stream",
+	HandlerType: (*StreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AcceptedBlocks",
+			Handler:       _Stream_AcceptedBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "stream/stream.proto",
+}
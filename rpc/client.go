@@ -97,6 +97,7 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	callAuthorizer       CallAuthorizer
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -129,6 +130,7 @@ func (c *Client) newClientConn(conn ServerCodec, apiMaxDuration, refillRate, max
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize)
+	handler.callAuthorizer = c.callAuthorizer
 
 	// When [apiMaxDuration] or [refillRate]/[maxStored] is 0 (as is the case for
 	// all client invocations of this function), it is ignored.
@@ -261,6 +263,7 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig,
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		callAuthorizer:       cfg.callAuthorizer,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
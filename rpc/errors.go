@@ -65,12 +65,16 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+
+	_ Error     = new(LimitExceededError)
+	_ DataError = new(LimitExceededError)
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -164,3 +168,23 @@ type internalServerError struct {
 func (e *internalServerError) ErrorCode() int { return e.code }
 
 func (e *internalServerError) Error() string { return e.message }
+
+// LimitExceededError is returned when a request exceeds a limit configured by
+// the node operator. The name of the limit and its value are returned as the
+// error data, so that clients can adjust their requests.
+type LimitExceededError struct {
+	Message string
+	Limit   string      // name of the limit, e.g. maxLogsPerRequest
+	Max     interface{} // value of the limit
+}
+
+func (e *LimitExceededError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *LimitExceededError) Error() string { return e.Message }
+
+func (e *LimitExceededError) ErrorData() interface{} {
+	return map[string]interface{}{
+		"limit": e.Limit,
+		"max":   e.Max,
+	}
+}
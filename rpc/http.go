@@ -38,6 +38,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -337,6 +338,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	connInfo.HTTP.APIKey = APIKeyFromHeader(r.Header)
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
@@ -407,3 +409,15 @@ func ContextRequestTimeout(ctx context.Context) (time.Duration, bool) {
 
 	return timeout, hasTimeout
 }
+
+// APIKeyFromHeader returns the API key sent in the X-API-Key header, or as a
+// bearer token in the Authorization header.
+func APIKeyFromHeader(header http.Header) string {
+	if key := header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	return ""
+}
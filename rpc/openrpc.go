@@ -0,0 +1,223 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// openRPCVersion is the version of the OpenRPC specification the document
+	// returned by rpc.discover conforms to.
+	openRPCVersion = "1.2.6"
+
+	// openRPCDiscoverMethod is the method name reserved by the OpenRPC
+	// specification for service discovery. It is an alias of rpc_discover.
+	openRPCDiscoverMethod = "rpc.discover"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// OpenRPCDocument is an OpenRPC document describing the methods exposed by a
+// server.
+type OpenRPCDocument struct {
+	OpenRPC string          `json:"openrpc"`
+	Info    OpenRPCInfo     `json:"info"`
+	Methods []OpenRPCMethod `json:"methods"`
+}
+
+// OpenRPCInfo is the metadata of an OpenRPC document.
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenRPCMethod describes a single method of an OpenRPC document.
+type OpenRPCMethod struct {
+	Name   string                     `json:"name"`
+	Params []OpenRPCContentDescriptor `json:"params"`
+	Result OpenRPCContentDescriptor   `json:"result"`
+}
+
+// OpenRPCContentDescriptor describes a parameter or the result of a method.
+type OpenRPCContentDescriptor struct {
+	Name     string                 `json:"name"`
+	Required bool                   `json:"required,omitempty"`
+	Schema   map[string]interface{} `json:"schema"`
+}
+
+// Discover returns an OpenRPC document describing the methods and subscriptions
+// of all the registered services. It is also served as rpc.discover.
+func (s *RPCService) Discover() *OpenRPCDocument {
+	s.server.services.mu.Lock()
+	defer s.server.services.mu.Unlock()
+
+	doc := &OpenRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info:    OpenRPCInfo{Title: "JSON-RPC API", Version: "1.0"},
+		Methods: make([]OpenRPCMethod, 0),
+	}
+	for name, svc := range s.server.services.services {
+		for method, cb := range svc.callbacks {
+			doc.Methods = append(doc.Methods, newOpenRPCMethod(name+serviceMethodSeparator+method, cb))
+		}
+		if len(svc.subscriptions) > 0 {
+			doc.Methods = append(doc.Methods, newOpenRPCSubscribeMethods(name, svc.subscriptions)...)
+		}
+	}
+	sort.Slice(doc.Methods, func(i, j int) bool {
+		return doc.Methods[i].Name < doc.Methods[j].Name
+	})
+	return doc
+}
+
+// newOpenRPCMethod describes the method [name] handled by [cb].
+func newOpenRPCMethod(name string, cb *callback) OpenRPCMethod {
+	method := OpenRPCMethod{
+		Name:   name,
+		Params: newOpenRPCParams(cb.argTypes),
+		Result: OpenRPCContentDescriptor{Name: "result", Schema: map[string]interface{}{"type": "null"}},
+	}
+	fntype := cb.fn.Type()
+	for i := 0; i < fntype.NumOut(); i++ {
+		if i != cb.errPos {
+			method.Result.Schema = newJSONSchema(fntype.Out(i), make(map[reflect.Type]bool))
+		}
+	}
+	return method
+}
+
+// newOpenRPCSubscribeMethods describes the subscribe and unsubscribe methods of
+// the service [name]. The subscribe method takes the subscription name followed
+// by the parameters of the subscription, which depend on the subscription.
+func newOpenRPCSubscribeMethods(name string, subscriptions map[string]*callback) []OpenRPCMethod {
+	names := make([]string, 0, len(subscriptions))
+	for subscription := range subscriptions {
+		names = append(names, subscription)
+	}
+	sort.Strings(names)
+
+	idSchema := map[string]interface{}{"type": "string", "title": "ID"}
+	return []OpenRPCMethod{
+		{
+			Name: name + subscribeMethodSuffix,
+			Params: []OpenRPCContentDescriptor{
+				{Name: "subscription", Required: true, Schema: map[string]interface{}{"type": "string", "enum": names}},
+			},
+			Result: OpenRPCContentDescriptor{Name: "id", Schema: idSchema},
+		},
+		{
+			Name:   name + unsubscribeMethodSuffix,
+			Params: []OpenRPCContentDescriptor{{Name: "id", Required: true, Schema: idSchema}},
+			Result: OpenRPCContentDescriptor{Name: "result", Schema: map[string]interface{}{"type": "boolean"}},
+		},
+	}
+}
+
+// newOpenRPCParams describes the parameters of a method with [argTypes]. Like
+// parsePositionalArguments, trailing pointer parameters are optional.
+func newOpenRPCParams(argTypes []reflect.Type) []OpenRPCContentDescriptor {
+	params := make([]OpenRPCContentDescriptor, len(argTypes))
+	optional := true
+	for i := len(argTypes) - 1; i >= 0; i-- {
+		if argTypes[i].Kind() != reflect.Ptr {
+			optional = false
+		}
+		params[i] = OpenRPCContentDescriptor{
+			Name:     fmt.Sprintf("param%d", i+1),
+			Required: !optional,
+			Schema:   newJSONSchema(argTypes[i], make(map[reflect.Type]bool)),
+		}
+	}
+	return params
+}
+
+// newJSONSchema returns the JSON schema of values of type [typ] as encoded by
+// encoding/json. Types implementing a custom encoding are only described by
+// their name, since their encoding cannot be inferred. [seen] holds the struct
+// types being described, to stop on recursive types.
+func newJSONSchema(typ reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	ptr := reflect.PtrTo(typ)
+	switch {
+	case typ.Implements(textMarshalerType) || ptr.Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string", "title": typ.Name()}
+	case typ.Implements(jsonMarshalerType) || ptr.Implements(jsonMarshalerType):
+		return map[string]interface{}{"title": typ.Name()}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": newJSONSchema(typ.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": newJSONSchema(typ.Elem(), seen)}
+	case reflect.Struct:
+		if seen[typ] {
+			return map[string]interface{}{"type": "object", "title": typ.Name()}
+		}
+		seen[typ] = true
+		defer delete(seen, typ)
+
+		properties := make(map[string]interface{})
+		addJSONSchemaProperties(typ, properties, seen)
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if typ.Name() != "" {
+			schema["title"] = typ.Name()
+		}
+		return schema
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// addJSONSchemaProperties adds the schemas of the fields of the struct [typ] to
+// [properties], flattening embedded structs as encoding/json does.
+func addJSONSchemaProperties(typ reflect.Type, properties map[string]interface{}, seen map[reflect.Type]bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addJSONSchemaProperties(embedded, properties, seen)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = newJSONSchema(field.Type, seen)
+	}
+}
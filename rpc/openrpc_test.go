@@ -0,0 +1,43 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerDiscover(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	var doc OpenRPCDocument
+	require.NoError(t, client.Call(&doc, openRPCDiscoverMethod))
+	require.Equal(t, openRPCVersion, doc.OpenRPC)
+
+	methods := make(map[string]OpenRPCMethod)
+	for _, method := range doc.Methods {
+		methods[method.Name] = method
+	}
+	require.Contains(t, methods, "rpc_discover")
+	require.Contains(t, methods, "rpc_modules")
+	require.Contains(t, methods, "nftest_subscribe")
+	require.Contains(t, methods, "nftest_unsubscribe")
+
+	// test_echo(str string, i int, args *echoArgs) echoResult
+	echo, ok := methods["test_echo"]
+	require.True(t, ok)
+	require.Len(t, echo.Params, 3)
+	require.True(t, echo.Params[0].Required)
+	require.Equal(t, "string", echo.Params[0].Schema["type"])
+	require.True(t, echo.Params[1].Required)
+	require.Equal(t, "integer", echo.Params[1].Schema["type"])
+	require.False(t, echo.Params[2].Required)
+	require.Equal(t, "object", echo.Params[2].Schema["type"])
+	require.Equal(t, "echoResult", echo.Result.Schema["title"])
+	require.Contains(t, echo.Result.Schema["properties"], "Args")
+}
@@ -62,8 +62,14 @@ type Server struct {
 	run                atomic.Bool
 	batchItemLimit     int
 	batchResponseLimit int
+	callAuthorizer     CallAuthorizer
 }
 
+// CallAuthorizer is called before executing each call of [method], including
+// subscriptions. A non-nil error is returned to the client instead of calling
+// the method. [ctx] carries the PeerInfo of the client.
+type CallAuthorizer func(ctx context.Context, method string) error
+
 // NewServer creates a new server instance with no registered handlers.
 //
 // If [maximumDuration] > 0, the deadline of incoming requests is
@@ -94,6 +100,14 @@ func (s *Server) SetBatchLimits(itemLimit, maxResponseSize int) {
 	s.batchResponseLimit = maxResponseSize
 }
 
+// SetCallAuthorizer sets the function authorizing the calls of the clients.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetCallAuthorizer(authorizer CallAuthorizer) {
+	s.callAuthorizer = authorizer
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -119,6 +133,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption, apiMaxDurati
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		callAuthorizer:     s.callAuthorizer,
 	}
 	c := initClient(codec, &s.services, cfg, apiMaxDuration, refillRate, maxStored)
 	<-codec.closed()
@@ -155,6 +170,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.deadlineContext = s.maximumDuration
 	h.allowSubscribe = false
+	h.callAuthorizer = s.callAuthorizer
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -226,6 +242,9 @@ type PeerInfo struct {
 		UserAgent string
 		Origin    string
 		Host      string
+		// APIKey is the key sent in the X-API-Key header, or as a bearer
+		// token in the Authorization header.
+		APIKey string
 	}
 }
 
@@ -28,6 +28,7 @@ package rpc
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"net"
 	"os"
@@ -199,3 +200,26 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestServerCallAuthorizer(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	errDenied := &invalidRequestError{"denied"}
+	server.SetCallAuthorizer(func(ctx context.Context, method string) error {
+		if method == "test_echo" {
+			return errDenied
+		}
+		return nil
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("authorized call failed:", err)
+	}
+	err := client.Call(new(echoResult), "test_echo", "x", 1)
+	re, ok := err.(Error)
+	if !ok || re.ErrorCode() != errDenied.ErrorCode() || re.Error() != errDenied.Error() {
+		t.Fatalf("wrong error for denied call: %v", err)
+	}
+}
@@ -127,7 +127,7 @@ func (bn BlockNumber) Int64() int64 {
 }
 
 // MarshalText implements encoding.TextMarshaler. It marshals:
-// - "accepted", "latest", "earliest" or "pending" as strings
+// - "finalized", "safe", "latest", "earliest" or "pending" as strings
 // - other numbers as hex
 func (bn BlockNumber) MarshalText() ([]byte, error) {
 	return []byte(bn.String()), nil
@@ -142,7 +142,7 @@ func (bn BlockNumber) String() string {
 	case PendingBlockNumber:
 		return "pending"
 	case FinalizedBlockNumber:
-		return "accepted"
+		return "finalized"
 	case SafeBlockNumber:
 		return "safe"
 	default:
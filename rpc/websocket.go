@@ -319,6 +319,7 @@ func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header) Serve
 	wc.info.HTTP.Host = host
 	wc.info.HTTP.Origin = req.Get("Origin")
 	wc.info.HTTP.UserAgent = req.Get("User-Agent")
+	wc.info.HTTP.APIKey = APIKeyFromHeader(req)
 	// Start pinger.
 	wc.wg.Add(1)
 	go wc.pingLoop()
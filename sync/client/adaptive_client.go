@@ -0,0 +1,119 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesyncclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/metrics"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+)
+
+// DefaultTargetLeafsLatency is the latency of leaf requests above which
+// [AdaptiveLeafClient] reduces the number of concurrent requests.
+const DefaultTargetLeafsLatency = 2 * time.Second
+
+var leafsParallelismGauge = metrics.NewRegisteredGauge("sync_leafs_parallelism", nil)
+
+var _ LeafClient = (*AdaptiveLeafClient)(nil)
+
+// AdaptiveLeafClient limits the number of concurrent requests made through a
+// LeafClient, adapting the limit to the performance of peers: the limit is
+// increased by one after a full window of requests completing within the
+// target latency, and halved when a request fails or is slower than the target.
+type AdaptiveLeafClient struct {
+	client   LeafClient
+	minLimit int
+	maxLimit int
+	target   time.Duration
+
+	lock      sync.Mutex
+	limit     int
+	inFlight  int
+	successes int           // Fast requests since the limit last changed
+	released  chan struct{} // Closed when a request completes
+}
+
+// NewAdaptiveLeafClient returns a LeafClient making at most [maxLimit]
+// concurrent requests through [client], starting from and never going below
+// [minLimit].
+func NewAdaptiveLeafClient(client LeafClient, minLimit, maxLimit int, target time.Duration) *AdaptiveLeafClient {
+	if minLimit < 1 {
+		minLimit = 1
+	}
+	if maxLimit < minLimit {
+		maxLimit = minLimit
+	}
+	leafsParallelismGauge.Update(int64(minLimit))
+	return &AdaptiveLeafClient{
+		client:   client,
+		minLimit: minLimit,
+		maxLimit: maxLimit,
+		target:   target,
+		limit:    minLimit,
+		released: make(chan struct{}),
+	}
+}
+
+// GetLeafs waits until the number of concurrent requests is below the limit,
+// then sends [request] and adapts the limit to its outcome.
+func (c *AdaptiveLeafClient) GetLeafs(ctx context.Context, request message.LeafsRequest) (message.LeafsResponse, error) {
+	if err := c.acquire(ctx); err != nil {
+		return message.LeafsResponse{}, err
+	}
+	start := time.Now()
+	response, err := c.client.GetLeafs(ctx, request)
+	c.release(err == nil && time.Since(start) <= c.target)
+	return response, err
+}
+
+// Limit returns the current number of concurrent requests allowed.
+func (c *AdaptiveLeafClient) Limit() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.limit
+}
+
+func (c *AdaptiveLeafClient) acquire(ctx context.Context) error {
+	for {
+		c.lock.Lock()
+		if c.inFlight < c.limit {
+			c.inFlight++
+			c.lock.Unlock()
+			return nil
+		}
+		released := c.released
+		c.lock.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *AdaptiveLeafClient) release(fast bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.inFlight--
+	if fast {
+		c.successes++
+		if c.successes >= c.limit && c.limit < c.maxLimit {
+			c.limit++
+			c.successes = 0
+		}
+	} else {
+		c.limit = max(c.limit/2, c.minLimit)
+		c.successes = 0
+	}
+	leafsParallelismGauge.Update(int64(c.limit))
+
+	close(c.released)
+	c.released = make(chan struct{})
+}
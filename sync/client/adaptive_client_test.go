@@ -0,0 +1,103 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesyncclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/stretchr/testify/require"
+)
+
+type testLeafClient struct {
+	delay    time.Duration
+	err      error
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func (c *testLeafClient) GetLeafs(ctx context.Context, _ message.LeafsRequest) (message.LeafsResponse, error) {
+	inFlight := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		seen := c.maxSeen.Load()
+		if inFlight <= seen || c.maxSeen.CompareAndSwap(seen, inFlight) {
+			break
+		}
+	}
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return message.LeafsResponse{}, ctx.Err()
+	}
+	return message.LeafsResponse{}, c.err
+}
+
+func getLeafsConcurrently(t *testing.T, client LeafClient, numThreads, numRequests int) {
+	var wg sync.WaitGroup
+	for i := 0; i < numThreads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numRequests; j++ {
+				_, _ = client.GetLeafs(context.Background(), message.LeafsRequest{})
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAdaptiveLeafClientIncreasesLimit(t *testing.T) {
+	require := require.New(t)
+
+	inner := &testLeafClient{delay: time.Millisecond}
+	client := NewAdaptiveLeafClient(inner, 1, 8, time.Second)
+	require.Equal(1, client.Limit())
+
+	getLeafsConcurrently(t, client, 16, 20)
+	require.Equal(8, client.Limit())
+	require.LessOrEqual(inner.maxSeen.Load(), int32(8))
+	require.Greater(inner.maxSeen.Load(), int32(1))
+}
+
+func TestAdaptiveLeafClientDecreasesLimit(t *testing.T) {
+	require := require.New(t)
+
+	inner := &testLeafClient{delay: time.Millisecond}
+	client := NewAdaptiveLeafClient(inner, 2, 8, time.Second)
+	getLeafsConcurrently(t, client, 8, 20)
+	require.Equal(8, client.Limit())
+
+	// Failing requests halve the limit down to the minimum.
+	inner.err = errors.New("request failed")
+	getLeafsConcurrently(t, client, 1, 3)
+	require.Equal(2, client.Limit())
+
+	// Slow requests also decrease the limit.
+	inner = &testLeafClient{delay: time.Millisecond}
+	client = NewAdaptiveLeafClient(inner, 1, 8, 0)
+	getLeafsConcurrently(t, client, 8, 5)
+	require.Equal(1, client.Limit())
+	require.Equal(int32(1), inner.maxSeen.Load())
+}
+
+func TestAdaptiveLeafClientContextCancelled(t *testing.T) {
+	inner := &testLeafClient{delay: time.Second}
+	client := NewAdaptiveLeafClient(inner, 1, 1, time.Second)
+
+	go func() {
+		_, _ = client.GetLeafs(context.Background(), message.LeafsRequest{})
+	}()
+	require.Eventually(t, func() bool { return inner.inFlight.Load() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.GetLeafs(ctx, message.LeafsRequest{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
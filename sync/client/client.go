@@ -66,6 +66,8 @@ type Client interface {
 	GetBlocks(ctx context.Context, blockHash common.Hash, height uint64, parents uint16) ([]*types.Block, error)
 
 	// GetCode synchronously retrieves code associated with the given hashes
+	// Note: the code of a non-empty prefix of [hashes] may be returned if the
+	// code of all of them does not fit in a response.
 	GetCode(ctx context.Context, hashes []common.Hash) ([][]byte, error)
 }
 
@@ -265,7 +267,7 @@ func parseCode(codec codec.Manager, req message.Request, data []byte) (interface
 	}
 
 	codeRequest := req.(message.CodeRequest)
-	if len(response.Data) != len(codeRequest.Hashes) {
+	if len(response.Data) == 0 || len(response.Data) > len(codeRequest.Hashes) {
 		return nil, 0, fmt.Errorf("%w (got %d) (requested %d)", errInvalidCodeResponseLen, len(response.Data), len(codeRequest.Hashes))
 	}
 
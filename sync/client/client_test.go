@@ -56,6 +56,16 @@ func TestGetCode(t *testing.T) {
 			},
 			expectedErr: errHashMismatch,
 		},
+		"prefix of code elements returned": {
+			setupRequest: func() ([]common.Hash, message.CodeResponse, [][]byte) {
+				code := []byte("this is the code")
+				codeSlices := [][]byte{code}
+				return []common.Hash{crypto.Keccak256Hash(code), {2}}, message.CodeResponse{
+					Data: codeSlices,
+				}, codeSlices
+			},
+			expectedErr: nil,
+		},
 		"too many code elements returned": {
 			setupRequest: func() (requestHashes []common.Hash, mockResponse message.CodeResponse, expectedCode [][]byte) {
 				return []common.Hash{{1}}, message.CodeResponse{
@@ -49,7 +49,7 @@ func (n *CodeRequestHandler) OnCodeRequest(_ context.Context, nodeID ids.NodeID,
 		n.stats.UpdateCodeReadTime(time.Since(startTime))
 	}()
 
-	if len(codeRequest.Hashes) > message.MaxCodeHashesPerRequest {
+	if len(codeRequest.Hashes) > message.MaxCodeHashesPerBatchRequest {
 		n.stats.IncTooManyHashesRequested()
 		log.Debug("too many hashes requested, dropping request", "nodeID", nodeID, "requestID", requestID, "numHashes", len(codeRequest.Hashes))
 		return nil, nil
@@ -60,16 +60,23 @@ func (n *CodeRequestHandler) OnCodeRequest(_ context.Context, nodeID ids.NodeID,
 		return nil, nil
 	}
 
-	codeBytes := make([][]byte, len(codeRequest.Hashes))
+	codeBytes := make([][]byte, 0, len(codeRequest.Hashes))
 	totalBytes := 0
-	for i, hash := range codeRequest.Hashes {
-		codeBytes[i] = rawdb.ReadCode(n.codeReader, hash)
-		if len(codeBytes[i]) == 0 {
+	for _, hash := range codeRequest.Hashes {
+		// Only serve the code of a prefix of the hashes once the response
+		// reaches [MaxCodeResponseBytes], so it fits in a message.
+		if totalBytes >= message.MaxCodeResponseBytes {
+			log.Debug("code response size limit reached, serving prefix of request", "nodeID", nodeID, "requestID", requestID, "served", len(codeBytes), "requested", len(codeRequest.Hashes))
+			break
+		}
+		code := rawdb.ReadCode(n.codeReader, hash)
+		if len(code) == 0 {
 			n.stats.IncMissingCodeHash()
 			log.Debug("requested code not found, dropping request", "nodeID", nodeID, "requestID", requestID, "hash", hash)
 			return nil, nil
 		}
-		totalBytes += len(codeBytes[i])
+		codeBytes = append(codeBytes, code)
+		totalBytes += len(code)
 	}
 
 	codeResponse := message.CodeResponse{Data: codeBytes}
@@ -6,6 +6,7 @@ package handlers
 import (
 	"context"
 	"crypto/rand"
+	"fmt"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/params"
@@ -34,6 +35,25 @@ func TestCodeRequestHandler(t *testing.T) {
 	maxSizeCodeHash := crypto.Keccak256Hash(maxSizeCodeBytes)
 	rawdb.WriteCode(database, maxSizeCodeHash, maxSizeCodeBytes)
 
+	batchCodeHashes := make([]common.Hash, message.MaxCodeHashesPerBatchRequest)
+	batchCodeBytes := make([][]byte, message.MaxCodeHashesPerBatchRequest)
+	for i := range batchCodeHashes {
+		batchCodeBytes[i] = []byte(fmt.Sprintf("code %d", i))
+		batchCodeHashes[i] = crypto.Keccak256Hash(batchCodeBytes[i])
+		rawdb.WriteCode(database, batchCodeHashes[i], batchCodeBytes[i])
+	}
+
+	numMaxSizeCode := message.MaxCodeResponseBytes/params.MaxCodeSize + 3
+	maxSizeCodeHashes := make([]common.Hash, numMaxSizeCode)
+	maxSizeCodeSlices := make([][]byte, numMaxSizeCode)
+	for i := range maxSizeCodeHashes {
+		maxSizeCodeSlices[i] = make([]byte, params.MaxCodeSize)
+		_, err := rand.Read(maxSizeCodeSlices[i])
+		assert.NoError(t, err)
+		maxSizeCodeHashes[i] = crypto.Keccak256Hash(maxSizeCodeSlices[i])
+		rawdb.WriteCode(database, maxSizeCodeHashes[i], maxSizeCodeSlices[i])
+	}
+
 	mockHandlerStats := &stats.MockHandlerStats{}
 	codeRequestHandler := NewCodeRequestHandler(database, message.Codec, mockHandlerStats)
 
@@ -62,10 +82,43 @@ func TestCodeRequestHandler(t *testing.T) {
 				assert.EqualValues(t, 1, mockHandlerStats.DuplicateHashesRequested)
 			},
 		},
+		"batch of hashes": {
+			setup: func() (request message.CodeRequest, expectedCodeResponse [][]byte) {
+				hashes := make([]common.Hash, message.MaxCodeHashesPerBatchRequest)
+				code := make([][]byte, message.MaxCodeHashesPerBatchRequest)
+				for i := range hashes {
+					hashes[i], code[i] = batchCodeHashes[i], batchCodeBytes[i]
+				}
+				return message.CodeRequest{Hashes: hashes}, code
+			},
+			verifyStats: func(t *testing.T, stats *stats.MockHandlerStats) {
+				assert.EqualValues(t, 1, mockHandlerStats.CodeRequestCount)
+			},
+		},
+		"response size limit reached": {
+			setup: func() (request message.CodeRequest, expectedCodeResponse [][]byte) {
+				// Only the code of the hashes until the response reaches
+				// [message.MaxCodeResponseBytes] is served.
+				numServed := (message.MaxCodeResponseBytes + params.MaxCodeSize - 1) / params.MaxCodeSize
+				hashes := make([]common.Hash, 0, numServed+2)
+				code := make([][]byte, 0, numServed)
+				for i := 0; i < numServed+2; i++ {
+					hashes = append(hashes, maxSizeCodeHashes[i])
+					if i < numServed {
+						code = append(code, maxSizeCodeSlices[i])
+					}
+				}
+				return message.CodeRequest{Hashes: hashes}, code
+			},
+			verifyStats: func(t *testing.T, stats *stats.MockHandlerStats) {
+				assert.EqualValues(t, 1, mockHandlerStats.CodeRequestCount)
+				assert.Less(t, mockHandlerStats.CodeBytesReturnedSum, uint32(message.MaxCodeResponseBytes+params.MaxCodeSize))
+			},
+		},
 		"too many hashes": {
 			setup: func() (request message.CodeRequest, expectedCodeResponse [][]byte) {
 				return message.CodeRequest{
-					Hashes: []common.Hash{{1}, {2}, {3}, {4}, {5}, {6}},
+					Hashes: make([]common.Hash, message.MaxCodeHashesPerBatchRequest+1),
 				}, nil
 			},
 			verifyStats: func(t *testing.T, stats *stats.MockHandlerStats) {
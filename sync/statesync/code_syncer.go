@@ -31,6 +31,9 @@ type CodeSyncerConfig struct {
 	MaxOutstandingCodeHashes int
 	// Number of worker threads to fetch code from the network
 	NumCodeFetchingWorkers int
+	// Maximum number of code hashes to batch into a single request. Defaults
+	// to [message.MaxCodeHashesPerRequest], which every server supports.
+	CodeBatchSize int
 
 	// Client for fetching code from the network
 	Client statesyncclient.Client
@@ -60,6 +63,9 @@ type codeSyncer struct {
 
 // newCodeSyncer returns a a code syncer that will sync code bytes from the network in a separate thread.
 func newCodeSyncer(config CodeSyncerConfig) *codeSyncer {
+	if config.CodeBatchSize <= 0 {
+		config.CodeBatchSize = message.MaxCodeHashesPerRequest
+	}
 	return &codeSyncer{
 		CodeSyncerConfig:      config,
 		codeHashes:            make(chan common.Hash, config.MaxOutstandingCodeHashes),
@@ -140,7 +146,7 @@ func (c *codeSyncer) addCodeToFetchFromDBToQueue() error {
 // work fulfills any incoming requests from the producer channel by fetching code bytes from the network
 // and fulfilling them by updating the database.
 func (c *codeSyncer) work(ctx context.Context) error {
-	codeHashes := make([]common.Hash, 0, message.MaxCodeHashesPerRequest)
+	codeHashes := make([]common.Hash, 0, c.CodeBatchSize)
 
 	for {
 		select {
@@ -157,9 +163,9 @@ func (c *codeSyncer) work(ctx context.Context) error {
 			}
 
 			codeHashes = append(codeHashes, codeHash)
-			// Try to wait for at least [MaxCodeHashesPerRequest] code hashes to batch into a single request
+			// Try to wait for at least [CodeBatchSize] code hashes to batch into a single request
 			// if there's more work remaining.
-			if len(codeHashes) < message.MaxCodeHashesPerRequest {
+			if len(codeHashes) < c.CodeBatchSize {
 				continue
 			}
 			if err := c.fulfillCodeRequest(ctx, codeHashes); err != nil {
@@ -172,15 +178,30 @@ func (c *codeSyncer) work(ctx context.Context) error {
 	}
 }
 
-// fulfillCodeRequest sends a request for [codeHashes], writes the result to the database, and
-// marks the work as complete.
+// fulfillCodeRequest sends requests for [codeHashes] until the code of all of
+// them is fetched, writing each result to the database and marking its work as
+// complete.
 // codeHashes should not be empty or contain duplicate hashes.
 // Returns an error if one is encountered, signaling the worker thread to terminate.
 func (c *codeSyncer) fulfillCodeRequest(ctx context.Context, codeHashes []common.Hash) error {
-	codeByteSlices, err := c.Client.GetCode(ctx, codeHashes)
-	if err != nil {
-		return err
+	for len(codeHashes) > 0 {
+		codeByteSlices, err := c.Client.GetCode(ctx, codeHashes)
+		if err != nil {
+			return err
+		}
+		// Servers may only respond with the code of a prefix of the hashes,
+		// so the remaining hashes are requested again.
+		if err := c.writeCode(codeHashes[:len(codeByteSlices)], codeByteSlices); err != nil {
+			return err
+		}
+		codeHashes = codeHashes[len(codeByteSlices):]
 	}
+	return nil
+}
+
+// writeCode writes [codeByteSlices] fetched for [codeHashes] to the database,
+// and marks the work as complete.
+func (c *codeSyncer) writeCode(codeHashes []common.Hash, codeByteSlices [][]byte) error {
 
 	// Hold the lock while modifying outstandingCodeHashes.
 	c.lock.Lock()
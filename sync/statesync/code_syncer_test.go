@@ -6,6 +6,7 @@ package statesync
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/utils"
@@ -115,6 +116,46 @@ func TestCodeSyncerManyCodeHashes(t *testing.T) {
 	})
 }
 
+func TestCodeSyncerBatchesPartialResponses(t *testing.T) {
+	numCodeSlices := 500
+	codeHashes := make([]common.Hash, 0, numCodeSlices)
+	codeByteSlices := make([][]byte, 0, numCodeSlices)
+	for i := 0; i < numCodeSlices; i++ {
+		codeBytes := utils.RandomBytes(100)
+		codeHash := crypto.Keccak256Hash(codeBytes)
+		codeHashes = append(codeHashes, codeHash)
+		codeByteSlices = append(codeByteSlices, codeBytes)
+	}
+
+	var (
+		lock       sync.Mutex
+		maxHashes  int
+		numPartial int
+	)
+	testCodeSyncer(t, codeSyncerTest{
+		setupCodeSyncer: func(c *codeSyncer) {
+			c.CodeBatchSize = message.MaxCodeHashesPerBatchRequest
+		},
+		codeRequestHashes: [][]common.Hash{codeHashes},
+		codeByteSlices:    codeByteSlices,
+		// Only respond with the code of the first half of the hashes, as a
+		// server reaching its response size limit would.
+		getCodeIntercept: func(hashes []common.Hash, codeBytes [][]byte) ([][]byte, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			maxHashes = max(maxHashes, len(hashes))
+			if len(codeBytes) == 1 {
+				return codeBytes, nil
+			}
+			numPartial++
+			return codeBytes[:len(codeBytes)/2], nil
+		},
+	})
+	assert.Equal(t, message.MaxCodeHashesPerBatchRequest, maxHashes)
+	assert.Positive(t, numPartial)
+}
+
 func TestCodeSyncerRequestErrors(t *testing.T) {
 	codeBytes := utils.RandomBytes(100)
 	codeHash := crypto.Keccak256Hash(codeBytes)
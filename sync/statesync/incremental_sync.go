@@ -0,0 +1,144 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// baseState is the complete local state an incremental sync starts from.
+//
+// An incremental sync keeps the storage snapshot of the base state, so the
+// storage tries of accounts whose storage root did not change are neither
+// fetched from peers nor copied from disk. The storage snapshot of the other
+// accounts is removed before syncing their storage tries, and the storage
+// snapshot of accounts missing from the synced state is removed once the main
+// trie is synced.
+type baseState struct {
+	root common.Hash
+
+	lock sync.Mutex // trie.Trie is not safe for concurrent use
+	trie *trie.Trie
+}
+
+func newBaseState(root common.Hash, trieDB *trie.Database) (*baseState, error) {
+	tr, err := trie.New(trie.StateTrieID(root), trieDB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open base state trie %s: %w", root, err)
+	}
+	return &baseState{root: root, trie: tr}, nil
+}
+
+// storageRoot returns the storage root of [accountHash] in the base state.
+func (b *baseState) storageRoot(accountHash common.Hash) (common.Hash, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	accBytes, err := b.trie.Get(accountHash[:])
+	if err != nil || len(accBytes) == 0 {
+		return types.EmptyRootHash, err
+	}
+	var acc types.StateAccount
+	if err := rlp.DecodeBytes(accBytes, &acc); err != nil {
+		return common.Hash{}, err
+	}
+	return acc.Root, nil
+}
+
+// storageUnchanged returns true if the storage of [accountHash] has [root] in
+// the base state, in which case its storage snapshot is already complete.
+// Otherwise the storage snapshot of [accountHash] is deleted in [db].
+func (b *baseState) storageUnchanged(db ethdb.KeyValueWriter, diskdb ethdb.Iteratee, accountHash common.Hash, root common.Hash) (bool, error) {
+	baseRoot, err := b.storageRoot(accountHash)
+	if err != nil {
+		// The base state is incomplete (ie. pruned), so the storage of this
+		// account is synced instead.
+		log.Debug("could not read account of base state, syncing its storage", "account", accountHash, "err", err)
+	} else if baseRoot == root {
+		return true, nil
+	}
+	return false, deleteStorageSnapshot(db, diskdb, accountHash)
+}
+
+// deleteStorageSnapshot deletes the storage snapshot of [accountHash] in [db].
+func deleteStorageSnapshot(db ethdb.KeyValueWriter, diskdb ethdb.Iteratee, accountHash common.Hash) error {
+	it := rawdb.IterateStorageSnapshots(diskdb, accountHash)
+	defer it.Release()
+
+	for it.Next() {
+		if err := db.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// removeStaleStorageSnapshots deletes the storage snapshot of the accounts
+// missing from the account snapshot, which must be complete.
+func (t *stateSync) removeStaleStorageSnapshots(ctx context.Context) error {
+	var (
+		batch   = t.db.NewBatch()
+		start   []byte
+		removed int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Find the next account with a storage snapshot, skipping the slots of
+		// the previous one.
+		it := t.db.NewIterator(rawdb.SnapshotStoragePrefix, start)
+		found := it.Next()
+		var key []byte
+		if found {
+			key = common.CopyBytes(it.Key())
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		if len(key) != len(rawdb.SnapshotStoragePrefix)+2*common.HashLength {
+			start = append(key[len(rawdb.SnapshotStoragePrefix):], 0)
+			continue
+		}
+		accountHash := common.BytesToHash(key[len(rawdb.SnapshotStoragePrefix) : len(rawdb.SnapshotStoragePrefix)+common.HashLength])
+		if len(rawdb.ReadAccountSnapshot(t.db, accountHash)) == 0 {
+			if err := deleteStorageSnapshot(batch, t.db, accountHash); err != nil {
+				return err
+			}
+			removed++
+			if batch.ValueSize() > t.batchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		start = common.CopyBytes(accountHash[:])
+		utils.IncrOne(start)
+		if common.BytesToHash(start) == (common.Hash{}) {
+			break // [accountHash] was the last possible account
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("state sync: removed storage snapshots of deleted accounts", "accounts", removed, "base", t.base.root)
+	return nil
+}
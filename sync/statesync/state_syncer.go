@@ -20,7 +20,8 @@ const (
 	segmentThreshold       = 500_000 // if we estimate trie to have greater than this number of leafs, split it
 	numStorageTrieSegments = 4
 	numMainTrieSegments    = 8
-	defaultNumThreads      = 8
+
+	DefaultParallelism = 8
 )
 
 type StateSyncerConfig struct {
@@ -31,6 +32,19 @@ type StateSyncerConfig struct {
 	MaxOutstandingCodeHashes int    // Maximum number of code hashes in the code syncer queue
 	NumCodeFetchingWorkers   int    // Number of code syncing threads
 	RequestSize              uint16 // Number of leafs to request from a peer at a time
+	CodeBatchSize            int    // Number of code hashes to request from a peer at a time
+
+	// Parallelism is the number of leaf syncing threads, defaulting to
+	// [DefaultParallelism]. If AdaptiveParallelism is set, it is the maximum
+	// number of concurrent leaf requests, which is adapted to the performance
+	// of peers.
+	Parallelism         int
+	AdaptiveParallelism bool
+
+	// BaseRoot is the root of a complete local state whose storage snapshot
+	// is kept, to sync incrementally from it. If empty, the whole state is
+	// synced.
+	BaseRoot common.Hash
 }
 
 // stateSync keeps the state of the entire state sync operation.
@@ -41,11 +55,13 @@ type stateSync struct {
 	snapshot  snapshot.Snapshot // used to access the database we are syncing as a snapshot.
 	batchSize int               // write batches when they reach this size
 	client    syncclient.Client // used to contact peers over the network
+	base      *baseState        // local state the sync is incremental from, if any
 
-	segments   chan syncclient.LeafSyncTask   // channel of tasks to sync
-	syncer     *syncclient.CallbackLeafSyncer // performs the sync, looping over each task's range and invoking specified callbacks
-	codeSyncer *codeSyncer                    // manages the asynchronous download and batching of code hashes
-	trieQueue  *trieQueue                     // manages a persistent list of storage tries we need to sync and any segments that are created for them
+	segments    chan syncclient.LeafSyncTask   // channel of tasks to sync
+	parallelism int                            // number of leaf syncing threads
+	syncer      *syncclient.CallbackLeafSyncer // performs the sync, looping over each task's range and invoking specified callbacks
+	codeSyncer  *codeSyncer                    // manages the asynchronous download and batching of code hashes
+	trieQueue   *trieQueue                     // manages a persistent list of storage tries we need to sync and any segments that are created for them
 
 	// track the main account trie specifically to commit its root at the end of the operation
 	mainTrie *trieToSync
@@ -62,7 +78,12 @@ type stateSync struct {
 }
 
 func NewStateSyncer(config *StateSyncerConfig) (*stateSync, error) {
+	parallelism := config.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
 	ss := &stateSync{
+		parallelism:     parallelism,
 		batchSize:       config.BatchSize,
 		db:              config.DB,
 		client:          config.Client,
@@ -73,24 +94,37 @@ func NewStateSyncer(config *StateSyncerConfig) (*stateSync, error) {
 		triesInProgress: make(map[common.Hash]*trieToSync),
 
 		// [triesInProgressSem] is used to keep the number of tries syncing
-		// less than or equal to [defaultNumThreads].
-		triesInProgressSem: make(chan struct{}, defaultNumThreads),
+		// less than or equal to [parallelism].
+		triesInProgressSem: make(chan struct{}, parallelism),
 
 		// Each [trieToSync] will have a maximum of [numSegments] segments.
-		// We set the capacity of [segments] such that [defaultNumThreads]
+		// We set the capacity of [segments] such that [parallelism]
 		// storage tries can sync concurrently.
-		segments:     make(chan syncclient.LeafSyncTask, defaultNumThreads*numStorageTrieSegments),
+		segments:     make(chan syncclient.LeafSyncTask, parallelism*numStorageTrieSegments),
 		mainTrieDone: make(chan struct{}),
 		done:         make(chan error, 1),
 	}
-	ss.syncer = syncclient.NewCallbackLeafSyncer(config.Client, ss.segments, config.RequestSize)
+	var leafClient syncclient.LeafClient = config.Client
+	if config.AdaptiveParallelism {
+		leafClient = syncclient.NewAdaptiveLeafClient(config.Client, 1, parallelism, syncclient.DefaultTargetLeafsLatency)
+	}
+	ss.syncer = syncclient.NewCallbackLeafSyncer(leafClient, ss.segments, config.RequestSize)
 	ss.codeSyncer = newCodeSyncer(CodeSyncerConfig{
 		DB:                       config.DB,
 		Client:                   config.Client,
 		MaxOutstandingCodeHashes: config.MaxOutstandingCodeHashes,
 		NumCodeFetchingWorkers:   config.NumCodeFetchingWorkers,
+		CodeBatchSize:            config.CodeBatchSize,
 	})
 
+	if config.BaseRoot != (common.Hash{}) {
+		var err error
+		ss.base, err = newBaseState(config.BaseRoot, ss.trieDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ss.trieQueue = NewTrieQueue(config.DB)
 	if err := ss.trieQueue.clearIfRootDoesNotMatch(ss.root); err != nil {
 		return nil, err
@@ -156,6 +190,14 @@ func (t *stateSync) storageTrieProducer(ctx context.Context) error {
 		return ctx.Err()
 	}
 
+	// Now that the account snapshot is complete, remove the storage kept from
+	// the base state of the accounts that no longer exist.
+	if t.base != nil {
+		if err := t.removeStaleStorageSnapshots(ctx); err != nil {
+			return err
+		}
+	}
+
 	for {
 		// check ctx here to exit the loop early
 		if err := ctx.Err(); err != nil {
@@ -200,7 +242,7 @@ func (t *stateSync) Start(ctx context.Context) error {
 	// Start the code syncer and leaf syncer.
 	eg, egCtx := errgroup.WithContext(ctx)
 	t.codeSyncer.start(egCtx) // start the code syncer first since the leaf syncer may add code tasks
-	t.syncer.Start(egCtx, defaultNumThreads, t.onSyncFailure)
+	t.syncer.Start(egCtx, t.parallelism, t.onSyncFailure)
 	eg.Go(func() error {
 		if err := <-t.syncer.Done(); err != nil {
 			return err
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"math"
 	"math/rand"
 	"runtime/pprof"
 	"sync/atomic"
@@ -22,6 +23,7 @@ import (
 	handlerstats "github.com/ava-labs/subnet-evm/sync/handlers/stats"
 	"github.com/ava-labs/subnet-evm/sync/syncutils"
 	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ava-labs/subnet-evm/trie/trienode"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -39,6 +41,11 @@ type syncTest struct {
 	expectedError     error
 	GetLeafsIntercept func(message.LeafsRequest, message.LeafsResponse) (message.LeafsResponse, error)
 	GetCodeIntercept  func([]common.Hash, [][]byte) ([][]byte, error)
+	baseRoot          common.Hash
+
+	parallelism         int
+	adaptiveParallelism bool
+	codeBatchSize       int
 }
 
 func testSync(t *testing.T, test syncTest) {
@@ -63,6 +70,10 @@ func testSync(t *testing.T, test syncTest) {
 		NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
 		MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
 		RequestSize:              1024,
+		BaseRoot:                 test.baseRoot,
+		Parallelism:              test.parallelism,
+		AdaptiveParallelism:      test.adaptiveParallelism,
+		CodeBatchSize:            test.codeBatchSize,
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -154,6 +165,17 @@ func TestSimpleSyncCases(t *testing.T) {
 				return rawdb.NewMemoryDatabase(), serverDB, serverTrieDB, root
 			},
 		},
+		"accounts with code and storage in adaptive parallel batches": {
+			prepareForTest: func(t *testing.T) (ethdb.Database, ethdb.Database, *trie.Database, common.Hash) {
+				serverDB := rawdb.NewMemoryDatabase()
+				serverTrieDB := trie.NewDatabase(serverDB)
+				root := fillAccountsWithStorage(t, serverDB, serverTrieDB, common.Hash{}, numAccounts)
+				return rawdb.NewMemoryDatabase(), serverDB, serverTrieDB, root
+			},
+			parallelism:         32,
+			adaptiveParallelism: true,
+			codeBatchSize:       message.MaxCodeHashesPerBatchRequest,
+		},
 		"accounts with storage": {
 			prepareForTest: func(t *testing.T) (ethdb.Database, ethdb.Database, *trie.Database, common.Hash) {
 				serverDB := rawdb.NewMemoryDatabase()
@@ -531,3 +553,148 @@ func testSyncerSyncsToNewRoot(t *testing.T, deleteBetweenSyncs func(*testing.T,
 		deleteBetweenSyncs(t, root1, clientDB)
 	})
 }
+
+// modifyAccounts deletes, changes the storage of, or removes the storage of
+// some of the accounts with storage at [root] in [trieDB], and returns the new
+// root along with the hashes of the deleted accounts.
+func modifyAccounts(t *testing.T, trieDB *trie.Database, root common.Hash) (common.Hash, []common.Hash) {
+	tr, err := trie.New(trie.TrieID(root), trieDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeIt, err := tr.NodeIterator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		it       = trie.NewIterator(nodeIt)
+		keys     [][]byte
+		accounts []types.StateAccount
+	)
+	for it.Next() {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			t.Fatal(err)
+		}
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		keys = append(keys, common.CopyBytes(it.Key))
+		accounts = append(accounts, acc)
+	}
+	if it.Err != nil {
+		t.Fatal(it.Err)
+	}
+
+	var deleted []common.Hash
+	for i, acc := range accounts {
+		switch i % 8 {
+		case 0:
+			tr.MustDelete(keys[i])
+			deleted = append(deleted, common.BytesToHash(keys[i]))
+			continue
+		case 1:
+			acc.Root, _, _ = syncutils.GenerateTrie(t, trieDB, 16, common.HashLength)
+		case 2:
+			acc.Root = types.EmptyRootHash
+		default:
+			continue
+		}
+		accBytes, err := rlp.EncodeToBytes(&acc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr.MustUpdate(keys[i], accBytes)
+	}
+	newRoot, nodes, err := tr.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := trieDB.Update(newRoot, root, 0, trienode.NewWithNodeSet(nodes), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := trieDB.Commit(newRoot, false); err != nil {
+		t.Fatal(err)
+	}
+	return newRoot, deleted
+}
+
+func TestIncrementalResync(t *testing.T) {
+	rand.Seed(1)
+	clientDB := rawdb.NewMemoryDatabase()
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := trie.NewDatabase(serverDB)
+
+	root1, _ := FillAccountsWithOverlappingStorage(t, serverTrieDB, common.Hash{}, 1000, 3)
+	root2, deleted := modifyAccounts(t, serverTrieDB, root1)
+	root2, _ = FillAccountsWithOverlappingStorage(t, serverTrieDB, root2, 200, 3)
+
+	// Collect the storage roots of the base state, which must not be fetched.
+	baseStorageRoots := make(map[common.Hash]struct{})
+	syncutils.AssertTrieConsistency(t, root1, serverTrieDB, serverTrieDB, func(_, val []byte) error {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(val, &acc); err != nil {
+			return err
+		}
+		baseStorageRoots[acc.Root] = struct{}{}
+		return nil
+	})
+
+	var (
+		step            int
+		storageRequests uint32
+	)
+	intercept := &interruptLeafsIntercept{
+		root:           root2,
+		interruptAfter: 1,
+	}
+	getLeafsIntercept := func(request message.LeafsRequest, response message.LeafsResponse) (message.LeafsResponse, error) {
+		if request.Root != root2 {
+			if _, ok := baseStorageRoots[request.Root]; ok {
+				t.Errorf("requested storage trie %s of the base state", request.Root)
+			}
+			atomic.AddUint32(&storageRequests, 1)
+		}
+		return intercept.getLeafsIntercept(request, response)
+	}
+
+	testSyncResumes(t, []syncTest{
+		{
+			prepareForTest: func(t *testing.T) (ethdb.Database, ethdb.Database, *trie.Database, common.Hash) {
+				return clientDB, serverDB, serverTrieDB, root1
+			},
+		},
+		{
+			prepareForTest: func(t *testing.T) (ethdb.Database, ethdb.Database, *trie.Database, common.Hash) {
+				return clientDB, serverDB, serverTrieDB, root2
+			},
+			baseRoot:          root1,
+			expectedError:     errInterrupted,
+			GetLeafsIntercept: getLeafsIntercept,
+		},
+		{
+			prepareForTest: func(t *testing.T) (ethdb.Database, ethdb.Database, *trie.Database, common.Hash) {
+				return clientDB, serverDB, serverTrieDB, root2
+			},
+			baseRoot:          root1,
+			GetLeafsIntercept: getLeafsIntercept,
+		},
+	}, func() {
+		step++
+		switch step {
+		case 1:
+			// Keep the storage snapshot of the base state.
+			<-snapshot.WipeAccountSnapshot(clientDB)
+		case 2:
+			// Resume the interrupted sync without wiping anything.
+			intercept.interruptAfter = math.MaxUint32
+		}
+	})
+
+	assert.NotZero(t, atomic.LoadUint32(&storageRequests))
+	for _, account := range deleted {
+		it := rawdb.IterateStorageSnapshots(clientDB, account)
+		assert.False(t, it.Next(), "storage snapshot of deleted account %s was not removed", account)
+		it.Release()
+	}
+}
@@ -72,8 +72,17 @@ func (m *mainTrieTask) OnLeafs(db ethdb.KeyValueWriter, keys, vals [][]byte) err
 		// persist the account data
 		writeAccountSnapshot(db, accountHash, acc)
 
-		// check if this account has storage root that we need to fetch
-		if acc.Root != (common.Hash{}) && acc.Root != types.EmptyRootHash {
+		// check if this account has storage root that we need to fetch, unless
+		// the storage of the base state is still valid for it.
+		unchanged := false
+		if m.sync.base != nil {
+			var err error
+			unchanged, err = m.sync.base.storageUnchanged(db, m.sync.db, accountHash, acc.Root)
+			if err != nil {
+				return err
+			}
+		}
+		if !unchanged && acc.Root != (common.Hash{}) && acc.Root != types.EmptyRootHash {
 			m.sync.trieQueue.RegisterStorageTrie(acc.Root, accountHash)
 		}
 
@@ -20,10 +20,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ava-labs/avalanchego/config"
-	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/tests/fixture/e2e"
 	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
-	"github.com/ava-labs/avalanchego/utils/set"
 
 	"github.com/ava-labs/subnet-evm/tests"
 	"github.com/ava-labs/subnet-evm/tests/utils"
@@ -54,7 +52,7 @@ func TestE2E(t *testing.T) {
 var _ = ginkgo.Describe("[Load Simulator]", ginkgo.Ordered, func() {
 	require := require.New(ginkgo.GinkgoT())
 
-	var env *e2e.TestEnvironment
+	var network *utils.Network
 
 	ginkgo.BeforeAll(func() {
 		genesisPath := filepath.Join(repoRootPath, "tests/load/genesis/genesis.json")
@@ -63,37 +61,33 @@ var _ = ginkgo.Describe("[Load Simulator]", ginkgo.Ordered, func() {
 		// the default level instead of raising it to debug (as the warp testing does).
 		chainConfig := tmpnet.FlagsMap{}
 
-		nodes := utils.NewTmpnetNodes(nodeCount)
-
-		env = e2e.NewTestEnvironment(
-			flagVars,
-			utils.NewTmpnetNetwork(
-				nodes,
-				tmpnet.FlagsMap{
-					// The default tmpnet log level (debug) induces too much overhead for load testing.
-					config.LogLevelKey: "info",
-				},
-				utils.NewTmpnetSubnet(subnetAName, genesisPath, chainConfig, nodes...),
-			),
-		)
+		backend := utils.NewNetworkBackend(&utils.TmpnetBackend{
+			FlagVars: flagVars,
+			NewNetwork: func() *tmpnet.Network {
+				nodes := utils.NewTmpnetNodes(nodeCount)
+				return utils.NewTmpnetNetwork(
+					nodes,
+					tmpnet.FlagsMap{
+						// The default tmpnet log level (debug) induces too much overhead for load testing.
+						config.LogLevelKey: "info",
+					},
+					utils.NewTmpnetSubnet(subnetAName, genesisPath, chainConfig, nodes...),
+				)
+			},
+		})
+		var err error
+		network, err = backend.Start(e2e.DefaultContext())
+		require.NoError(err)
+		ginkgo.DeferCleanup(backend.Stop)
 	})
 
 	ginkgo.It("basic subnet load test", ginkgo.Label("load"), func() {
-		network := env.GetNetwork()
-
 		subnet := network.GetSubnet(subnetAName)
 		require.NotNil(subnet)
-		blockchainID := subnet.Chains[0].ChainID
-
-		nodeURIs := tmpnet.GetNodeURIs(network.Nodes)
-		validatorIDs := set.NewSet[ids.NodeID](len(subnet.ValidatorIDs))
-		validatorIDs.Add(subnet.ValidatorIDs...)
-		rpcEndpoints := make([]string, 0, len(nodeURIs))
-		for _, nodeURI := range nodeURIs {
-			if !validatorIDs.Contains(nodeURI.NodeID) {
-				continue
-			}
-			rpcEndpoints = append(rpcEndpoints, fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURI.URI, blockchainID))
+
+		rpcEndpoints := make([]string, 0, len(subnet.ValidatorURIs))
+		for _, uri := range subnet.ValidatorURIs {
+			rpcEndpoints = append(rpcEndpoints, fmt.Sprintf("%s/ext/bc/%s/rpc", uri, subnet.BlockchainID))
 		}
 		commaSeparatedRPCEndpoints := strings.Join(rpcEndpoints, ",")
 		err := os.Setenv("RPC_ENDPOINTS", commaSeparatedRPCEndpoints)
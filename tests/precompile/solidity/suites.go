@@ -30,7 +30,7 @@ func RegisterAsyncTests() {
 
 	var _ = ginkgo.Describe("[Asynchronized Precompile Tests]", func() {
 		// Register the ping test first
-		utils.RegisterPingTest()
+		subnetsSuite.RegisterPingTest()
 
 		// Each ginkgo It node specifies the name of the genesis file (in ./tests/precompile/genesis/)
 		// to use to launch the subnet and the name of the TS test file to run on the subnet (in ./contracts/tests/)
@@ -38,40 +38,35 @@ func RegisterAsyncTests() {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 			defer cancel()
 
-			blockchainID := subnetsSuite.GetBlockchainID("contract_native_minter")
-			runDefaultHardhatTests(ctx, blockchainID, "contract_native_minter")
+			runDefaultHardhatTests(ctx, subnetsSuite.GetChainURI("contract_native_minter"), "contract_native_minter")
 		})
 
 		ginkgo.It("tx allow list", ginkgo.Label("Precompile"), ginkgo.Label("TxAllowList"), func() {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 			defer cancel()
 
-			blockchainID := subnetsSuite.GetBlockchainID("tx_allow_list")
-			runDefaultHardhatTests(ctx, blockchainID, "tx_allow_list")
+			runDefaultHardhatTests(ctx, subnetsSuite.GetChainURI("tx_allow_list"), "tx_allow_list")
 		})
 
 		ginkgo.It("contract deployer allow list", ginkgo.Label("Precompile"), ginkgo.Label("ContractDeployerAllowList"), func() {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 			defer cancel()
 
-			blockchainID := subnetsSuite.GetBlockchainID("contract_deployer_allow_list")
-			runDefaultHardhatTests(ctx, blockchainID, "contract_deployer_allow_list")
+			runDefaultHardhatTests(ctx, subnetsSuite.GetChainURI("contract_deployer_allow_list"), "contract_deployer_allow_list")
 		})
 
 		ginkgo.It("fee manager", ginkgo.Label("Precompile"), ginkgo.Label("FeeManager"), func() {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 			defer cancel()
 
-			blockchainID := subnetsSuite.GetBlockchainID("fee_manager")
-			runDefaultHardhatTests(ctx, blockchainID, "fee_manager")
+			runDefaultHardhatTests(ctx, subnetsSuite.GetChainURI("fee_manager"), "fee_manager")
 		})
 
 		ginkgo.It("reward manager", ginkgo.Label("Precompile"), ginkgo.Label("RewardManager"), func() {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 			defer cancel()
 
-			blockchainID := subnetsSuite.GetBlockchainID("reward_manager")
-			runDefaultHardhatTests(ctx, blockchainID, "reward_manager")
+			runDefaultHardhatTests(ctx, subnetsSuite.GetChainURI("reward_manager"), "reward_manager")
 		})
 
 		// ADD YOUR PRECOMPILE HERE
@@ -95,9 +90,9 @@ func RegisterAsyncTests() {
 // 1. Hardhat contract environment is located at ./contracts
 // 2. Hardhat test file is located at ./contracts/test/<test>.ts
 // 3. npx is available in the ./contracts directory
-func runDefaultHardhatTests(ctx context.Context, blockchainID, testName string) {
+func runDefaultHardhatTests(ctx context.Context, chainURI, testName string) {
 	cmdPath := "./contracts"
 	// test path is relative to the cmd path
 	testPath := fmt.Sprintf("./test/%s.ts", testName)
-	utils.RunHardhatTests(ctx, blockchainID, cmdPath, testPath)
+	utils.RunHardhatTestsCustomURI(ctx, chainURI, cmdPath, testPath)
 }
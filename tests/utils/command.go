@@ -50,15 +50,6 @@ func RunCommand(bin string, args ...string) (*cmd.Cmd, error) {
 	return curCmd, nil
 }
 
-func RegisterPingTest() {
-	ginkgo.It("ping the network", ginkgo.Label("ping"), func() {
-		client := health.NewClient(DefaultLocalNodeURI)
-		healthy, err := client.Readiness(context.Background(), nil)
-		gomega.Expect(err).Should(gomega.BeNil())
-		gomega.Expect(healthy.Healthy).Should(gomega.BeTrue())
-	})
-}
-
 // RegisterNodeRun registers a before suite that starts an AvalancheGo process to use for the e2e tests
 // and an after suite that stops the AvalancheGo process
 func RegisterNodeRun() {
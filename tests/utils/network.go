@@ -0,0 +1,243 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/tests/fixture/e2e"
+	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// NetworkConfigEnvVar is the environment variable set to the path of a
+	// [Network] file describing an existing network, e.g. a devnet, to run
+	// the e2e suites against instead of the network they start by default.
+	NetworkConfigEnvVar = "E2E_NETWORK_CONFIG"
+
+	// CChainName is the name of the C-Chain in the subnets of a [Network].
+	CChainName = "C-Chain"
+)
+
+var (
+	errNoNodeURIs     = errors.New("network must have at least one node URI")
+	errNoSubnetName   = errors.New("subnet must have a name")
+	errNoBlockchainID = errors.New("subnet must have a blockchain ID")
+)
+
+// Subnet provides the basic details of a subnet of a [Network].
+type Subnet struct {
+	Name string
+	// SubnetID is the txID of the transaction that created the subnet
+	SubnetID ids.ID
+	// For simplicity assume a single blockchain per subnet
+	BlockchainID ids.ID
+	// Key funded in the genesis of the blockchain, if known
+	PreFundedKey *ecdsa.PrivateKey
+	// ValidatorURIs are the base URIs for each participant of the Subnet
+	ValidatorURIs []string
+}
+
+type subnetJSON struct {
+	Name          string        `json:"name"`
+	SubnetID      ids.ID        `json:"subnetID"`
+	BlockchainID  ids.ID        `json:"blockchainID"`
+	PreFundedKey  hexutil.Bytes `json:"preFundedKey,omitempty"`
+	ValidatorURIs []string      `json:"validatorURIs,omitempty"`
+}
+
+func (s *Subnet) MarshalJSON() ([]byte, error) {
+	enc := subnetJSON{
+		Name:          s.Name,
+		SubnetID:      s.SubnetID,
+		BlockchainID:  s.BlockchainID,
+		ValidatorURIs: s.ValidatorURIs,
+	}
+	if s.PreFundedKey != nil {
+		enc.PreFundedKey = crypto.FromECDSA(s.PreFundedKey)
+	}
+	return json.Marshal(enc)
+}
+
+func (s *Subnet) UnmarshalJSON(data []byte) error {
+	var dec subnetJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	*s = Subnet{
+		Name:          dec.Name,
+		SubnetID:      dec.SubnetID,
+		BlockchainID:  dec.BlockchainID,
+		ValidatorURIs: dec.ValidatorURIs,
+	}
+	if len(dec.PreFundedKey) != 0 {
+		key, err := crypto.ToECDSA(dec.PreFundedKey)
+		if err != nil {
+			return fmt.Errorf("invalid pre-funded key of subnet %q: %w", dec.Name, err)
+		}
+		s.PreFundedKey = key
+	}
+	return nil
+}
+
+// ChainURI returns the RPC URI of the blockchain of the subnet on its first
+// validator.
+func (s *Subnet) ChainURI() string {
+	return fmt.Sprintf("%s/ext/bc/%s/rpc", s.ValidatorURIs[0], s.BlockchainID)
+}
+
+// Network is a network of nodes running the subnets of an e2e suite, as
+// started or connected to by a [NetworkBackend]. It is shared with the ginkgo
+// processes of a suite as JSON, which is also the format of the file at
+// [NetworkConfigEnvVar].
+type Network struct {
+	NodeURIs []string  `json:"nodeURIs"`
+	Subnets  []*Subnet `json:"subnets"`
+}
+
+// GetSubnet returns the subnet named [name], or nil if there is none.
+func (n *Network) GetSubnet(name string) *Subnet {
+	for _, subnet := range n.Subnets {
+		if subnet.Name == name {
+			return subnet
+		}
+	}
+	return nil
+}
+
+// Verify returns an error if the network is incomplete, and defaults the
+// validators of the subnets without any to all nodes of the network.
+func (n *Network) Verify() error {
+	if len(n.NodeURIs) == 0 {
+		return errNoNodeURIs
+	}
+	for _, subnet := range n.Subnets {
+		if subnet.Name == "" {
+			return errNoSubnetName
+		}
+		if subnet.BlockchainID == ids.Empty {
+			return fmt.Errorf("%w: %s", errNoBlockchainID, subnet.Name)
+		}
+		if len(subnet.ValidatorURIs) == 0 {
+			subnet.ValidatorURIs = n.NodeURIs
+		}
+	}
+	return nil
+}
+
+// NetworkBackend starts, or connects to, the network of an e2e suite.
+type NetworkBackend interface {
+	// Start returns the network, with the subnets of the suite.
+	Start(ctx context.Context) (*Network, error)
+	// Stop stops the network if it was started by [Start].
+	Stop() error
+}
+
+// NewNetworkBackend returns the backend of the network of an e2e suite, which
+// is the existing network described by the file at [NetworkConfigEnvVar] if
+// it is set, or [defaultBackend] otherwise.
+func NewNetworkBackend(defaultBackend NetworkBackend) NetworkBackend {
+	if path := os.Getenv(NetworkConfigEnvVar); path != "" {
+		return &RemoteBackend{ConfigPath: path}
+	}
+	return defaultBackend
+}
+
+var _ NetworkBackend = (*RemoteBackend)(nil)
+
+// RemoteBackend connects to an existing network described by the [Network]
+// file at [ConfigPath]. The subnets of the suite must already exist on it,
+// with the names the suite expects.
+type RemoteBackend struct {
+	ConfigPath string
+}
+
+func (b *RemoteBackend) Start(context.Context) (*Network, error) {
+	data, err := os.ReadFile(b.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	network := &Network{}
+	if err := json.Unmarshal(data, network); err != nil {
+		return nil, fmt.Errorf("failed to parse network config %s: %w", b.ConfigPath, err)
+	}
+	return network, network.Verify()
+}
+
+// Stop does nothing, as the network outlives the suite.
+func (b *RemoteBackend) Stop() error { return nil }
+
+var _ NetworkBackend = (*TmpnetBackend)(nil)
+
+// TmpnetBackend starts the temporary network returned by [NewNetwork] with
+// the tmpnet fixture, or reuses it if the suite runs with
+// --use-existing-network.
+type TmpnetBackend struct {
+	FlagVars   *e2e.FlagVars
+	NewNetwork func() *tmpnet.Network
+	// Setup is called with the started network if set, e.g. to add subnets
+	// tmpnet can't create by itself.
+	Setup func(ctx context.Context, network *tmpnet.Network) error
+}
+
+func (b *TmpnetBackend) Start(ctx context.Context) (*Network, error) {
+	env := e2e.NewTestEnvironment(b.FlagVars, b.NewNetwork())
+	network := env.GetNetwork()
+	if b.Setup != nil {
+		if err := b.Setup(ctx, network); err != nil {
+			return nil, err
+		}
+	}
+	return NetworkFromTmpnet(ctx, network)
+}
+
+// Stop does nothing, as the tmpnet fixture stops the network it started
+// after the suite.
+func (b *TmpnetBackend) Stop() error { return nil }
+
+// NetworkFromTmpnet returns the details of [network], including its C-Chain
+// funded with [tmpnet.HardhatKey].
+func NetworkFromTmpnet(ctx context.Context, network *tmpnet.Network) (*Network, error) {
+	nodeURIs := make([]string, len(network.Nodes))
+	for i, node := range network.Nodes {
+		nodeURIs[i] = node.URI
+	}
+	cChainID, err := info.NewClient(nodeURIs[0]).GetBlockchainID(ctx, "C")
+	if err != nil {
+		return nil, err
+	}
+	subnets := []*Subnet{{
+		Name:          CChainName,
+		SubnetID:      constants.PrimaryNetworkID,
+		BlockchainID:  cChainID,
+		PreFundedKey:  tmpnet.HardhatKey.ToECDSA(),
+		ValidatorURIs: nodeURIs,
+	}}
+	for _, subnet := range network.Subnets {
+		validatorURIs := make([]string, len(subnet.ValidatorIDs))
+		for i, nodeID := range subnet.ValidatorIDs {
+			if validatorURIs[i], err = network.GetURIForNodeID(nodeID); err != nil {
+				return nil, err
+			}
+		}
+		subnets = append(subnets, &Subnet{
+			Name:          subnet.Name,
+			SubnetID:      subnet.SubnetID,
+			BlockchainID:  subnet.Chains[0].ChainID,
+			PreFundedKey:  subnet.Chains[0].PreFundedKey.ToECDSA(),
+			ValidatorURIs: validatorURIs,
+		})
+	}
+	return &Network{NodeURIs: nodeURIs, Subnets: subnets}, nil
+}
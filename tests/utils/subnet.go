@@ -28,86 +28,158 @@ import (
 )
 
 type SubnetSuite struct {
-	blockchainIDs map[string]string
-	lock          sync.RWMutex
+	network *Network
+	lock    sync.RWMutex
 }
 
+// GetBlockchainID returns the blockchain ID of the subnet of [alias].
 func (s *SubnetSuite) GetBlockchainID(alias string) string {
+	return s.getSubnet(alias).BlockchainID.String()
+}
+
+// GetChainURI returns the RPC URI of the blockchain of the subnet of [alias].
+func (s *SubnetSuite) GetChainURI(alias string) string {
+	return s.getSubnet(alias).ChainURI()
+}
+
+func (s *SubnetSuite) getSubnet(alias string) *Subnet {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	return s.blockchainIDs[alias]
+	subnet := s.network.GetSubnet(alias)
+	gomega.Expect(subnet).ShouldNot(gomega.BeNil(), "missing subnet %q", alias)
+	return subnet
 }
 
-func (s *SubnetSuite) SetBlockchainIDs(blockchainIDs map[string]string) {
+func (s *SubnetSuite) SetNetwork(network *Network) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.blockchainIDs = blockchainIDs
+	s.network = network
+}
+
+// RegisterPingTest registers a test checking the readiness of the first node
+// of the network of the suite.
+func (s *SubnetSuite) RegisterPingTest() {
+	ginkgo.It("ping the network", ginkgo.Label("ping"), func() {
+		s.lock.RLock()
+		uri := s.network.NodeURIs[0]
+		s.lock.RUnlock()
+
+		client := health.NewClient(uri)
+		healthy, err := client.Readiness(context.Background(), nil)
+		gomega.Expect(err).Should(gomega.BeNil())
+		gomega.Expect(healthy.Healthy).Should(gomega.BeTrue())
+	})
 }
 
-// CreateSubnetsSuite creates subnets for given [genesisFiles], and registers a before suite that starts an AvalancheGo process to use for the e2e tests.
+// CreateSubnetsSuite registers a before suite starting the network of the e2e tests with a subnet
+// for each of the given [genesisFiles], and an after suite stopping it.
 // genesisFiles is a map of test aliases to genesis file paths.
+//
+// By default, an AvalancheGo process is started to create the subnets on. If [NetworkConfigEnvVar]
+// is set, the tests run against the existing network it describes instead, which must have the
+// subnets named after the aliases.
 func CreateSubnetsSuite(genesisFiles map[string]string) *SubnetSuite {
-	// Keep track of the AvalancheGo external bash script, it is null for most
-	// processes except the first process that starts AvalancheGo
-	var startCmd *cmd.Cmd
+	// Keep track of the backend of the network, it is only started by the
+	// first process
+	var backend NetworkBackend
 
-	// This is used to pass the blockchain IDs from the SynchronizedBeforeSuite() to the tests
+	// This is used to pass the network from the SynchronizedBeforeSuite() to the tests
 	var globalSuite SubnetSuite
 
 	// Our test suite runs in separate processes, ginkgo has
 	// SynchronizedBeforeSuite() which runs once, and its return value is passed
 	// over to each worker.
 	//
-	// Here an AvalancheGo node instance is started, and subnets are created for
-	// each test case. Each test case has its own subnet, therefore all tests
-	// can run in parallel without any issue.
+	// Here the network is started, and subnets are created for each test case.
+	// Each test case has its own subnet, therefore all tests can run in parallel
+	// without any issue.
 	//
 	var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 		ctx, cancel := context.WithTimeout(context.Background(), BootAvalancheNodeTimeout)
 		defer cancel()
 
-		wd, err := os.Getwd()
-		gomega.Expect(err).Should(gomega.BeNil())
-		log.Info("Starting AvalancheGo node", "wd", wd)
-		cmd, err := RunCommand("./scripts/run.sh")
-		startCmd = cmd
-		gomega.Expect(err).Should(gomega.BeNil())
-
-		// Assumes that startCmd will launch a node with HTTP Port at [utils.DefaultLocalNodeURI]
-		healthClient := health.NewClient(DefaultLocalNodeURI)
-		healthy, err := health.AwaitReady(ctx, healthClient, HealthCheckTimeout, nil)
+		backend = NewNetworkBackend(&LocalNodeBackend{GenesisFiles: genesisFiles})
+		network, err := backend.Start(ctx)
 		gomega.Expect(err).Should(gomega.BeNil())
-		gomega.Expect(healthy).Should(gomega.BeTrue())
-		log.Info("AvalancheGo node is healthy")
-
-		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		blockchainIDs := make(map[string]string)
-		for alias, file := range genesisFiles {
-			blockchainIDs[alias] = CreateNewSubnet(ctx, file)
-		}
 
-		blockchainIDsBytes, err := json.Marshal(blockchainIDs)
+		networkBytes, err := json.Marshal(network)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		return blockchainIDsBytes
+		return networkBytes
 	}, func(ctx ginkgo.SpecContext, data []byte) {
-		blockchainIDs := make(map[string]string)
-		err := json.Unmarshal(data, &blockchainIDs)
+		network := &Network{}
+		err := json.Unmarshal(data, network)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
-		globalSuite.SetBlockchainIDs(blockchainIDs)
+		globalSuite.SetNetwork(network)
 	})
 
 	// SynchronizedAfterSuite() takes two functions, the first runs after each test suite is done and the second
 	// function is executed once when all the tests are done. This function is used
-	// to gracefully shutdown the AvalancheGo node.
+	// to gracefully shutdown the network.
 	var _ = ginkgo.SynchronizedAfterSuite(func() {}, func() {
-		gomega.Expect(startCmd).ShouldNot(gomega.BeNil())
-		gomega.Expect(startCmd.Stop()).Should(gomega.BeNil())
+		gomega.Expect(backend).ShouldNot(gomega.BeNil())
+		gomega.Expect(backend.Stop()).Should(gomega.BeNil())
 	})
 
 	return &globalSuite
 }
 
+var _ NetworkBackend = (*LocalNodeBackend)(nil)
+
+// LocalNodeBackend starts a single AvalancheGo node with ./scripts/run.sh, listening at
+// [DefaultLocalNodeURI], and creates a subnet named after each alias of [GenesisFiles].
+type LocalNodeBackend struct {
+	// GenesisFiles is a map of subnet names to genesis file paths.
+	GenesisFiles map[string]string
+
+	startCmd *cmd.Cmd
+}
+
+func (b *LocalNodeBackend) Start(ctx context.Context) (*Network, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Starting AvalancheGo node", "wd", wd)
+	b.startCmd, err = RunCommand("./scripts/run.sh")
+	if err != nil {
+		return nil, err
+	}
+
+	// Assumes that startCmd will launch a node with HTTP Port at [utils.DefaultLocalNodeURI]
+	healthClient := health.NewClient(DefaultLocalNodeURI)
+	healthy, err := health.AwaitReady(ctx, healthClient, HealthCheckTimeout, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !healthy {
+		return nil, fmt.Errorf("node at %s is not healthy", DefaultLocalNodeURI)
+	}
+	log.Info("AvalancheGo node is healthy")
+
+	network := &Network{NodeURIs: []string{DefaultLocalNodeURI}}
+	for alias, file := range b.GenesisFiles {
+		blockchainID, err := ids.FromString(CreateNewSubnet(ctx, file))
+		if err != nil {
+			return nil, err
+		}
+		network.Subnets = append(network.Subnets, &Subnet{
+			Name:          alias,
+			BlockchainID:  blockchainID,
+			ValidatorURIs: network.NodeURIs,
+		})
+	}
+	return network, nil
+}
+
+// Stop stops the AvalancheGo process started by [Start].
+func (b *LocalNodeBackend) Stop() error {
+	if b.startCmd == nil {
+		return nil
+	}
+	return b.startCmd.Stop()
+}
+
 // CreateNewSubnet creates a new subnet and Subnet-EVM blockchain with the given genesis file.
 // returns the ID of the new created blockchain.
 func CreateNewSubnet(ctx context.Context, genesisFilePath string) string {
@@ -4,12 +4,24 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	walletcommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 
 	"github.com/ava-labs/subnet-evm/plugin/evm"
 )
@@ -74,3 +86,190 @@ func NewTmpnetSubnet(name string, genesisPath string, chainConfig tmpnet.FlagsMa
 		ValidatorIDs: validatorIDs,
 	}
 }
+
+// CreateWeightedSubnet creates [subnet] on the running [network] and adds each node in
+// [subnet.ValidatorIDs] as a validator with the weight given in [weights], instead of the
+// equal weight tmpnet assigns to every validator. Validators missing from [weights] are
+// added with a weight of [units.Schmeckle]. If a subnet with the same name was already
+// created on [network], it is reused as is.
+//
+// This mirrors [tmpnet.Network.CreateSubnets] and must be called after the network has
+// been started, e.g. right after the test environment is created.
+func CreateWeightedSubnet(ctx context.Context, w io.Writer, network *tmpnet.Network, subnet *tmpnet.Subnet, weights map[ids.NodeID]uint64) error {
+	if existing := network.GetSubnet(subnet.Name); existing != nil && existing.SubnetID != ids.Empty {
+		return nil
+	}
+	if len(subnet.ValidatorIDs) == 0 {
+		return fmt.Errorf("subnet %q must be validated by at least one node", subnet.Name)
+	}
+	if subnet.OwningKey == nil {
+		if len(network.PreFundedKeys) == 0 {
+			return fmt.Errorf("no pre-funded keys available to create subnet %q", subnet.Name)
+		}
+		subnet.OwningKey = network.PreFundedKeys[len(network.PreFundedKeys)-1]
+		network.PreFundedKeys = network.PreFundedKeys[:len(network.PreFundedKeys)-1]
+	}
+
+	uri := network.Nodes[0].URI
+	if err := subnet.Create(ctx, uri); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, " created weighted subnet %q as %q\n", subnet.Name, subnet.SubnetID); err != nil {
+		return err
+	}
+
+	// tmpnet keeps the subnet and chain configurations in these directories of the network.
+	subnetDir := filepath.Join(network.Dir, "subnets")
+	chainConfigDir := filepath.Join(network.Dir, "chains")
+	if err := subnet.Write(subnetDir, chainConfigDir); err != nil {
+		return err
+	}
+	network.Subnets = append(network.Subnets, subnet)
+	if err := network.Write(); err != nil {
+		return err
+	}
+
+	// Restart the nodes to track the new subnet before they start validating it.
+	for _, node := range network.Nodes {
+		if err := network.EnsureNodeConfig(node); err != nil {
+			return err
+		}
+	}
+	if err := network.Restart(ctx, w); err != nil {
+		return err
+	}
+
+	if err := addWeightedValidators(ctx, w, uri, subnet, weights); err != nil {
+		return err
+	}
+	if err := waitForSubnetValidators(ctx, uri, subnet); err != nil {
+		return err
+	}
+
+	if err := subnet.CreateChains(ctx, w, uri); err != nil {
+		return err
+	}
+	if err := subnet.Write(subnetDir, chainConfigDir); err != nil {
+		return err
+	}
+	if subnet.HasChainConfig() {
+		// Restart the nodes to apply the chain configuration written above.
+		if err := network.Restart(ctx, w); err != nil {
+			return err
+		}
+	}
+	return waitForChainsBootstrapped(ctx, network, subnet)
+}
+
+// addWeightedValidators issues a subnet validator tx for each of the validators of [subnet]
+// with its weight in [weights], ending with its primary network validation.
+func addWeightedValidators(ctx context.Context, w io.Writer, uri string, subnet *tmpnet.Subnet, weights map[ids.NodeID]uint64) error {
+	wallet, err := subnet.GetWallet(ctx, uri)
+	if err != nil {
+		return err
+	}
+	pWallet := wallet.P()
+
+	pChainClient := platformvm.NewClient(uri)
+	primaryValidators, err := pChainClient.GetCurrentValidators(ctx, constants.PrimaryNetworkID, nil)
+	if err != nil {
+		return err
+	}
+	endTimes := make(map[ids.NodeID]uint64, len(primaryValidators))
+	for _, validator := range primaryValidators {
+		endTimes[validator.NodeID] = validator.EndTime
+	}
+
+	startTime := time.Now().Add(tmpnet.DefaultValidatorStartTimeDiff)
+	for _, nodeID := range subnet.ValidatorIDs {
+		endTime, ok := endTimes[nodeID]
+		if !ok {
+			return fmt.Errorf("failed to find end time for %s", nodeID)
+		}
+		weight, ok := weights[nodeID]
+		if !ok {
+			weight = units.Schmeckle
+		}
+
+		_, err := pWallet.IssueAddSubnetValidatorTx(
+			&txs.SubnetValidator{
+				Validator: txs.Validator{
+					NodeID: nodeID,
+					Start:  uint64(startTime.Unix()),
+					End:    endTime,
+					Wght:   weight,
+				},
+				Subnet: subnet.SubnetID,
+			},
+			walletcommon.WithContext(ctx),
+		)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, " added %s as validator with weight %d for subnet %q\n", nodeID, weight, subnet.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForSubnetValidators waits until all validators of [subnet] are active on the P-Chain.
+func waitForSubnetValidators(ctx context.Context, uri string, subnet *tmpnet.Subnet) error {
+	pChainClient := platformvm.NewClient(uri)
+	ticker := time.NewTicker(tmpnet.DefaultPollingInterval)
+	defer ticker.Stop()
+
+	for {
+		validators, err := pChainClient.GetCurrentValidators(ctx, subnet.SubnetID, nil)
+		if err != nil {
+			return err
+		}
+		active := set.NewSet[ids.NodeID](len(validators))
+		for _, validator := range validators {
+			active.Add(validator.NodeID)
+		}
+		allActive := true
+		for _, nodeID := range subnet.ValidatorIDs {
+			if !active.Contains(nodeID) {
+				allActive = false
+			}
+		}
+		if allActive {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to see the expected active validators of subnet %q before timeout", subnet.Name)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForChainsBootstrapped waits until every validator of [subnet] has bootstrapped its chains.
+func waitForChainsBootstrapped(ctx context.Context, network *tmpnet.Network, subnet *tmpnet.Subnet) error {
+	ticker := time.NewTicker(tmpnet.DefaultPollingInterval)
+	defer ticker.Stop()
+
+	for _, nodeID := range subnet.ValidatorIDs {
+		uri, err := network.GetURIForNodeID(nodeID)
+		if err != nil {
+			return err
+		}
+		infoClient := info.NewClient(uri)
+		for _, chain := range subnet.Chains {
+			for {
+				// Errors are ignored since a chain that is not yet known results in a recoverable error.
+				if isBootstrapped, err := infoClient.IsBootstrapped(ctx, chain.ChainID.String()); err == nil && isBootstrapped {
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("failed to see chain %s bootstrap on %s before timeout", chain.ChainID, nodeID)
+				case <-ticker.C:
+				}
+			}
+		}
+	}
+	return nil
+}
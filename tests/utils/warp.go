@@ -0,0 +1,52 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errMissingPredicateResults = errors.New("block does not contain predicate results")
+
+// SendTxAndWaitForReceipt issues [tx] to [client] and waits for its receipt until
+// the transaction is accepted or [ctx] is done.
+func SendTxAndWaitForReceipt(ctx context.Context, client ethclient.Client, tx *types.Transaction) (*types.Receipt, error) {
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send tx %s: %w", tx.Hash(), err)
+	}
+	return client.WaitForTxAcceptance(ctx, tx.Hash())
+}
+
+// GetPredicateResults returns the predicate results stored in the header of the block
+// that accepted the transaction in [receipt].
+func GetPredicateResults(ctx context.Context, client ethclient.Client, receipt *types.Receipt) (*predicate.Results, error) {
+	header, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch header %d: %w", receipt.BlockNumber, err)
+	}
+	resultBytes, ok := predicate.GetPredicateResultBytes(header.Extra)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errMissingPredicateResults, receipt.BlockNumber)
+	}
+	return predicate.ParseResults(resultBytes)
+}
+
+// IsPredicateVerified returns true if the predicate at [index] of the transaction in [receipt]
+// for the precompile at [address] passed verification when the transaction was accepted.
+func IsPredicateVerified(ctx context.Context, client ethclient.Client, receipt *types.Receipt, address common.Address, index int) (bool, error) {
+	results, err := GetPredicateResults(ctx, client, receipt)
+	if err != nil {
+		return false, err
+	}
+	// Failed predicates are marked by setting their bit in the results bitset.
+	return !set.BitsFromBytes(results.GetResults(receipt.TxHash, address)).Contains(index), nil
+}
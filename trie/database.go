@@ -206,6 +206,16 @@ func (db *Database) Close() error {
 	return db.backend.Close()
 }
 
+// Preimage retrieves a cached trie node pre-image from the preimage store,
+// falling back to the persistent database. It returns nil if preimage
+// recording is disabled or the preimage is unknown.
+func (db *Database) Preimage(hash common.Hash) []byte {
+	if db.preimages == nil {
+		return nil
+	}
+	return db.preimages.preimage(hash)
+}
+
 // WritePreimages flushes all accumulated preimages to disk forcibly.
 func (db *Database) WritePreimages() {
 	if db.preimages != nil {
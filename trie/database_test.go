@@ -27,9 +27,14 @@
 package trie
 
 import (
+	"bytes"
+	"testing"
+
 	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/trie/triedb/hashdb"
 	"github.com/ava-labs/subnet-evm/trie/triedb/pathdb"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 )
 
@@ -43,3 +48,35 @@ func newTestDatabase(diskdb ethdb.Database, scheme string) *Database {
 	}
 	return db
 }
+
+func TestDatabasePreimage(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := NewDatabaseWithConfig(diskdb, &Config{Preimages: true})
+	tr, err := NewStateTrie(TrieID(types.EmptyRootHash), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := []byte("storage-slot")
+	tr.MustUpdate(key, []byte("value"))
+	if _, _, err := tr.Commit(false); err != nil {
+		t.Fatal(err)
+	}
+	hash := crypto.Keccak256Hash(key)
+
+	// The preimage is served from the cache before it is flushed to disk.
+	if preimage := db.Preimage(hash); !bytes.Equal(preimage, key) {
+		t.Fatalf("unexpected cached preimage: have %x, want %x", preimage, key)
+	}
+	if preimage := rawdb.ReadPreimage(diskdb, hash); preimage != nil {
+		t.Fatalf("preimage flushed to disk early: %x", preimage)
+	}
+	db.WritePreimages()
+	if preimage := db.Preimage(hash); !bytes.Equal(preimage, key) {
+		t.Fatalf("unexpected persisted preimage: have %x, want %x", preimage, key)
+	}
+
+	// No preimages are returned if recording is disabled.
+	if preimage := NewDatabase(diskdb).Preimage(hash); preimage != nil {
+		t.Fatalf("unexpected preimage with recording disabled: %x", preimage)
+	}
+}
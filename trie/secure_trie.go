@@ -249,6 +249,12 @@ func (t *StateTrie) Commit(collectLeaf bool) (common.Hash, *trienode.NodeSet, er
 	return t.trie.Commit(collectLeaf)
 }
 
+// Witness returns the encoded trie nodes resolved from the database since the
+// trie was opened or last committed.
+func (t *StateTrie) Witness() map[string]struct{} {
+	return t.trie.Witness()
+}
+
 // Hash returns the root hash of StateTrie. It does not write to the
 // database and can be used even if the trie doesn't have one.
 func (t *StateTrie) Hash() common.Hash {
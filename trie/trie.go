@@ -611,6 +611,17 @@ func (t *Trie) Hash() common.Hash {
 	return common.BytesToHash(hash.(hashNode))
 }
 
+// Witness returns the encoded trie nodes resolved from the database since the
+// trie was opened or last committed. Together, they prove every value read
+// from or written to the trie against its original root.
+func (t *Trie) Witness() map[string]struct{} {
+	witness := make(map[string]struct{}, len(t.tracer.accessList))
+	for _, blob := range t.tracer.accessList {
+		witness[string(blob)] = struct{}{}
+	}
+	return witness
+}
+
 // Commit collects all dirty nodes in the trie and replaces them with the
 // corresponding node hash. All collected nodes (including dirty leaves if
 // collectLeaf is true) will be encapsulated into a nodeset for return.
@@ -49,4 +49,7 @@ var (
 	ErrAddrProhibited              = errors.New("prohibited address cannot be sender or created contract address")
 	ErrInvalidCoinbase             = errors.New("invalid coinbase")
 	ErrSenderAddressNotAllowListed = errors.New("cannot issue transaction from non-allow listed address")
+	ErrTargetAddressNotAllowListed = errors.New("cannot issue transaction to non-allow listed contract")
+	ErrNotStatefulPrecompile       = errors.New("not an enabled stateful precompile")
+	ErrPrecompileReentrancy        = errors.New("precompile reentrancy")
 )
@@ -5,7 +5,11 @@ package aggregator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -15,6 +19,10 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 )
 
+// ErrInsufficientResponsiveWeight is returned without requesting any signature when the
+// validators that are not known to be unresponsive cannot reach the requested quorum.
+var ErrInsufficientResponsiveWeight = errors.New("insufficient weight of responsive validators")
+
 type AggregateSignatureResult struct {
 	// Weight of validators included in the aggregate signature.
 	SignatureWeight uint64
@@ -24,6 +32,14 @@ type AggregateSignatureResult struct {
 	Message *avalancheWarp.Message
 }
 
+// SamplingConfig bounds the number of validators queried by [Aggregator.AggregateSampledSignatures].
+type SamplingConfig struct {
+	// SampleSize is the minimum number of validators queried by the first attempt.
+	SampleSize int
+	// MaxAttempts is the maximum number of samples queried before giving up.
+	MaxAttempts int
+}
+
 type signatureFetchResult struct {
 	sig    *bls.Signature
 	index  int
@@ -36,30 +52,176 @@ type Aggregator struct {
 	validators  []*avalancheWarp.Validator
 	totalWeight uint64
 	client      SignatureGetter
+	served      *ServedSignatures
+	// responsiveness is used to skip validators that recently failed to serve
+	// signatures and to query recently responsive validators first.
+	responsiveness *Responsiveness
 }
 
 // New returns a signature aggregator that will attempt to aggregate signatures from [validators].
-func New(client SignatureGetter, validators []*avalancheWarp.Validator, totalWeight uint64) *Aggregator {
+// Valid signatures are counted in [served] if it is non-nil.
+// If [responsiveness] is non-nil, the outcome of each request is recorded in it and validators
+// that recently failed to serve a signature are not queried.
+func New(client SignatureGetter, validators []*avalancheWarp.Validator, totalWeight uint64, served *ServedSignatures, responsiveness *Responsiveness) *Aggregator {
 	return &Aggregator{
-		client:      client,
-		validators:  validators,
-		totalWeight: totalWeight,
+		client:         client,
+		validators:     validators,
+		totalWeight:    totalWeight,
+		served:         served,
+		responsiveness: responsiveness,
 	}
 }
 
 // Returns an aggregate signature over [unsignedMessage].
 // The returned signature's weight exceeds the threshold given by [quorumNum].
 func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64) (*AggregateSignatureResult, error) {
+	indices := make([]int, len(a.validators))
+	for i := range indices {
+		indices[i] = i
+	}
+	indices, err := a.responsiveIndices(indices, quorumNum)
+	if err != nil {
+		return nil, err
+	}
+	agg := newAggregation(len(a.validators))
+	if !a.fetchSignatures(ctx, unsignedMessage, quorumNum, indices, agg) {
+		return nil, avalancheWarp.ErrInsufficientWeight
+	}
+	return a.newResult(unsignedMessage, agg)
+}
+
+// AggregateSampledSignatures returns an aggregate signature over [unsignedMessage] whose weight
+// exceeds the threshold given by [quorumNum], requesting signatures from a sample of the validators
+// instead of all of them.
+// Validators are sampled by stake, so the first attempt queries at least [config.SampleSize]
+// validators and enough of them to reach the quorum if they all reply. Each retry queries a
+// sample twice as large from the validators that were not queried yet, keeping the signatures
+// already collected, until the quorum is reached or [config.MaxAttempts] is exhausted.
+func (a *Aggregator) AggregateSampledSignatures(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64, config SamplingConfig) (*AggregateSignatureResult, error) {
+	order, err := a.responsiveIndices(a.sampleByWeight(), quorumNum)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		sampleSize = max(config.SampleSize, 1)
+		queried    = 0
+		agg        = newAggregation(len(a.validators))
+	)
+	for attempt := 0; attempt < config.MaxAttempts && queried < len(order); attempt++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		end := min(queried+sampleSize, len(order))
+		// Weight reachable by this attempt if every newly sampled validator replies.
+		sampleWeight := agg.weight
+		for _, i := range order[queried:end] {
+			sampleWeight += a.validators[i].Weight
+		}
+		// Extend the sample until it can reach the quorum.
+		for ; end < len(order) && avalancheWarp.VerifyWeight(sampleWeight, a.totalWeight, quorumNum, warp.WarpQuorumDenominator) != nil; end++ {
+			sampleWeight += a.validators[order[end]].Weight
+		}
+
+		log.Debug("Fetching sampled warp signatures",
+			"attempt", attempt,
+			"sampleSize", end-queried,
+			"numValidators", len(a.validators),
+			"msgID", unsignedMessage.ID(),
+		)
+		if a.fetchSignatures(ctx, unsignedMessage, quorumNum, order[queried:end], agg) {
+			return a.newResult(unsignedMessage, agg)
+		}
+		queried = end
+		sampleSize *= 2
+	}
+	return nil, avalancheWarp.ErrInsufficientWeight
+}
+
+// sampleByWeight returns the indices of all validators in a random order where validators
+// with more weight are more likely to come first.
+func (a *Aggregator) sampleByWeight() []int {
+	keys := make([]float64, len(a.validators))
+	order := make([]int, len(a.validators))
+	for i, validator := range a.validators {
+		order[i] = i
+		// Exponentially distributed key with rate [validator.Weight], so sorting
+		// by key samples validators by weight without replacement.
+		keys[i] = -math.Log(1-rand.Float64()) / float64(validator.Weight) // #nosec G404
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]] < keys[order[j]]
+	})
+	return order
+}
+
+// responsiveIndices returns the validators at [indices] that are not known to be unresponsive,
+// moving the validators that recently served a signature first and keeping the order of
+// [indices] otherwise.
+// Returns [ErrInsufficientResponsiveWeight] if they cannot reach the threshold given by [quorumNum].
+func (a *Aggregator) responsiveIndices(indices []int, quorumNum uint64) ([]int, error) {
+	if a.responsiveness == nil {
+		return indices, nil
+	}
+	var (
+		responsive = make([]int, 0, len(indices))
+		unknown    = make([]int, 0, len(indices))
+		weight     uint64
+	)
+	for _, i := range indices {
+		validator := a.validators[i]
+		switch a.responsiveness.status(validator.NodeIDs[0]) {
+		case statusResponsive:
+			responsive = append(responsive, i)
+		case statusUnknown:
+			unknown = append(unknown, i)
+		default:
+			continue
+		}
+		weight += validator.Weight
+	}
+	if err := avalancheWarp.VerifyWeight(weight, a.totalWeight, quorumNum, warp.WarpQuorumDenominator); err != nil {
+		return nil, fmt.Errorf("%w: responsive weight %d of total weight %d", ErrInsufficientResponsiveWeight, weight, a.totalWeight)
+	}
+	if len(responsive)+len(unknown) < len(indices) {
+		log.Debug("Skipping unresponsive validators",
+			"numSkipped", len(indices)-len(responsive)-len(unknown),
+			"responsiveWeight", weight,
+			"totalWeight", a.totalWeight,
+		)
+	}
+	return append(responsive, unknown...), nil
+}
+
+// aggregation holds the signatures collected by an aggregator for a single message.
+type aggregation struct {
+	signatures []*bls.Signature
+	signers    set.Bits
+	weight     uint64
+}
+
+func newAggregation(numValidators int) *aggregation {
+	return &aggregation{
+		signatures: make([]*bls.Signature, 0, numValidators),
+		signers:    set.NewBits(),
+	}
+}
+
+// fetchSignatures requests signatures over [unsignedMessage] from the validators at [indices]
+// concurrently and adds the valid ones to [agg].
+// Returns true once the weight of [agg] meets the threshold given by [quorumNum].
+func (a *Aggregator) fetchSignatures(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64, indices []int, agg *aggregation) bool {
 	// Create a child context to cancel signature fetching if we reach signature threshold.
 	signatureFetchCtx, signatureFetchCancel := context.WithCancel(ctx)
 	defer signatureFetchCancel()
 
 	// Fetch signatures from validators concurrently.
-	signatureFetchResultChan := make(chan *signatureFetchResult)
-	for i, validator := range a.validators {
+	// The channel is buffered so goroutines still in flight when the threshold is reached can exit.
+	signatureFetchResultChan := make(chan *signatureFetchResult, len(indices))
+	for _, i := range indices {
 		var (
 			i         = i
-			validator = validator
+			validator = a.validators[i]
 			// TODO: update from a single nodeID to the original slice and use extra nodeIDs as backup.
 			nodeID = validator.NodeIDs[0]
 		)
@@ -78,6 +240,10 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 					"err", err,
 					"msgID", unsignedMessage.ID(),
 				)
+				// Requests cancelled because the threshold was reached are not failures.
+				if a.responsiveness != nil && (signatureFetchCtx.Err() == nil || ctx.Err() != nil) {
+					a.responsiveness.Failure(nodeID)
+				}
 				signatureFetchResultChan <- nil
 				return
 			}
@@ -94,9 +260,18 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 					"index", i,
 					"msgID", unsignedMessage.ID(),
 				)
+				if a.responsiveness != nil {
+					a.responsiveness.Failure(nodeID)
+				}
 				signatureFetchResultChan <- nil
 				return
 			}
+			if a.served != nil {
+				a.served.Add(nodeID)
+			}
+			if a.responsiveness != nil {
+				a.responsiveness.Success(nodeID)
+			}
 
 			signatureFetchResultChan <- &signatureFetchResult{
 				sig:    signature,
@@ -106,55 +281,44 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 		}()
 	}
 
-	var (
-		signatures                = make([]*bls.Signature, 0, len(a.validators))
-		signersBitset             = set.NewBits()
-		signaturesWeight          = uint64(0)
-		signaturesPassedThreshold = false
-	)
-
-	for i := 0; i < len(a.validators); i++ {
+	for range indices {
 		signatureFetchResult := <-signatureFetchResultChan
 		if signatureFetchResult == nil {
 			continue
 		}
 
-		signatures = append(signatures, signatureFetchResult.sig)
-		signersBitset.Add(signatureFetchResult.index)
-		signaturesWeight += signatureFetchResult.weight
+		agg.signatures = append(agg.signatures, signatureFetchResult.sig)
+		agg.signers.Add(signatureFetchResult.index)
+		agg.weight += signatureFetchResult.weight
 		log.Debug("Updated weight",
-			"totalWeight", signaturesWeight,
+			"totalWeight", agg.weight,
 			"addedWeight", signatureFetchResult.weight,
 			"msgID", unsignedMessage.ID(),
 		)
 
 		// If the signature weight meets the requested threshold, cancel signature fetching
-		if err := avalancheWarp.VerifyWeight(signaturesWeight, a.totalWeight, quorumNum, warp.WarpQuorumDenominator); err == nil {
+		if err := avalancheWarp.VerifyWeight(agg.weight, a.totalWeight, quorumNum, warp.WarpQuorumDenominator); err == nil {
 			log.Debug("Verify weight passed, exiting aggregation early",
 				"quorumNum", quorumNum,
 				"totalWeight", a.totalWeight,
-				"signatureWeight", signaturesWeight,
+				"signatureWeight", agg.weight,
 				"msgID", unsignedMessage.ID(),
 			)
-			signatureFetchCancel()
-			signaturesPassedThreshold = true
-			break
+			return true
 		}
 	}
+	return false
+}
 
-	// If I failed to fetch sufficient signature stake, return an error
-	if !signaturesPassedThreshold {
-		return nil, avalancheWarp.ErrInsufficientWeight
-	}
-
-	// Otherwise, return the aggregate signature
-	aggregateSignature, err := bls.AggregateSignatures(signatures)
+// newResult returns the message signed by the aggregate signature of [agg].
+func (a *Aggregator) newResult(unsignedMessage *avalancheWarp.UnsignedMessage, agg *aggregation) (*AggregateSignatureResult, error) {
+	aggregateSignature, err := bls.AggregateSignatures(agg.signatures)
 	if err != nil {
 		return nil, fmt.Errorf("failed to aggregate BLS signatures: %w", err)
 	}
 
 	warpSignature := &avalancheWarp.BitSetSignature{
-		Signers: signersBitset.Bytes(),
+		Signers: agg.signers.Bytes(),
 	}
 	copy(warpSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
 
@@ -165,7 +329,7 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 
 	return &AggregateSignatureResult{
 		Message:         msg,
-		SignatureWeight: signaturesWeight,
+		SignatureWeight: agg.weight,
 		TotalWeight:     a.totalWeight,
 	}, nil
 }
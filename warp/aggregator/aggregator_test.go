@@ -6,7 +6,9 @@ package aggregator
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -14,6 +16,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 )
 
@@ -91,7 +94,7 @@ func TestAggregateSignatures(t *testing.T) {
 			aggregatorFunc: func(ctrl *gomock.Controller, _ context.CancelFunc) *Aggregator {
 				client := NewMockSignatureGetter(ctrl)
 				client.EXPECT().GetSignature(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errTest).Times(len(vdrs))
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   1,
@@ -107,7 +110,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nil, errTest).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nil, errTest).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   35, // Require >1/3 of weight
@@ -123,7 +126,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nil, errTest).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   69, // Require >2/3 of weight
@@ -139,7 +142,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nil, errTest).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       65, // Require <2/3 of weight
@@ -156,7 +159,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       100, // Require all weight
@@ -173,7 +176,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       64,
@@ -190,7 +193,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nonVdrSig, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   1,
@@ -206,7 +209,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   40,
@@ -222,7 +225,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nil, errTest).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       30,
@@ -264,7 +267,7 @@ func TestAggregateSignatures(t *testing.T) {
 						return nil, err
 					},
 				).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       60, // Require 2/3 validators
@@ -304,7 +307,7 @@ func TestAggregateSignatures(t *testing.T) {
 						return nil, err
 					},
 				).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       33, // 1/3 Should have gotten one signature before cancellation
@@ -330,7 +333,7 @@ func TestAggregateSignatures(t *testing.T) {
 						return nil, err
 					},
 				).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil, nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       60, // Require 2/3 validators
@@ -382,3 +385,252 @@ func TestAggregateSignatures(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateSampledSignatures(t *testing.T) {
+	errTest := errors.New("test error")
+	unsignedMsg := &avalancheWarp.UnsignedMessage{
+		NetworkID:     1338,
+		SourceChainID: ids.ID{'y', 'e', 'e', 't'},
+		Payload:       []byte("hello world"),
+	}
+	require.NoError(t, unsignedMsg.Initialize())
+
+	// newValidators returns validators with [weights] and their signatures of [unsignedMsg].
+	newValidators := func(t testing.TB, weights []uint64) ([]*avalancheWarp.Validator, map[ids.NodeID]*bls.Signature, uint64) {
+		vdrs := make([]*avalancheWarp.Validator, 0, len(weights))
+		sigs := make(map[ids.NodeID]*bls.Signature, len(weights))
+		totalWeight := uint64(0)
+		for _, weight := range weights {
+			sk, vdr := newValidator(t, weight)
+			vdrs = append(vdrs, vdr)
+			sigs[vdr.NodeIDs[0]] = bls.Sign(sk, unsignedMsg.Bytes())
+			totalWeight += weight
+		}
+		return vdrs, sigs, totalWeight
+	}
+	equalWeights := func(n int, weight uint64) []uint64 {
+		weights := make([]uint64, n)
+		for i := range weights {
+			weights[i] = weight
+		}
+		return weights
+	}
+
+	type test struct {
+		name            string
+		weights         []uint64
+		failing         func(index int) bool
+		canceled        bool
+		quorumNum       uint64
+		config          SamplingConfig
+		expectedErr     error
+		expectedCalls   int
+		maxCalls        int
+		expectedSigners int
+	}
+
+	tests := []test{
+		{
+			name:            "first sample is extended to reach quorum",
+			weights:         equalWeights(100, 10),
+			quorumNum:       67,
+			config:          SamplingConfig{SampleSize: 10, MaxAttempts: 1},
+			expectedCalls:   67,
+			expectedSigners: 67,
+		},
+		{
+			name:      "sample favors validators with more stake",
+			weights:   append(equalWeights(10, 900), equalWeights(990, 1)...),
+			quorumNum: 67,
+			config:    SamplingConfig{SampleSize: 10, MaxAttempts: 1},
+			maxCalls:  100,
+		},
+		{
+			name:            "retries expand the sample",
+			weights:         equalWeights(10, 10),
+			failing:         func(index int) bool { return index < 5 },
+			quorumNum:       50,
+			config:          SamplingConfig{SampleSize: 1, MaxAttempts: 4},
+			maxCalls:        10,
+			expectedSigners: 5,
+		},
+		{
+			name:          "insufficient weight after max attempts",
+			weights:       equalWeights(20, 10),
+			failing:       func(int) bool { return true },
+			quorumNum:     25,
+			config:        SamplingConfig{SampleSize: 2, MaxAttempts: 2},
+			expectedErr:   avalancheWarp.ErrInsufficientWeight,
+			expectedCalls: 10, // each sample is extended to the 5 validators needed to reach quorum
+		},
+		{
+			name:        "canceled context",
+			weights:     equalWeights(10, 10),
+			canceled:    true,
+			quorumNum:   50,
+			config:      SamplingConfig{SampleSize: 2, MaxAttempts: 2},
+			expectedErr: avalancheWarp.ErrInsufficientWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			vdrs, sigs, totalWeight := newValidators(t, tt.weights)
+			failingNodes := make(map[ids.NodeID]bool)
+			for i, vdr := range vdrs {
+				failingNodes[vdr.NodeIDs[0]] = tt.failing != nil && tt.failing(i)
+			}
+
+			var (
+				lock  sync.Mutex
+				calls = make(map[ids.NodeID]int)
+			)
+			client := NewMockSignatureGetter(ctrl)
+			client.EXPECT().GetSignature(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, nodeID ids.NodeID, _ *avalancheWarp.UnsignedMessage) (*bls.Signature, error) {
+					lock.Lock()
+					calls[nodeID]++
+					lock.Unlock()
+					if failingNodes[nodeID] {
+						return nil, errTest
+					}
+					return sigs[nodeID], nil
+				},
+			).AnyTimes()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
+			a := New(client, vdrs, totalWeight, nil, nil)
+			res, err := a.AggregateSampledSignatures(ctx, unsignedMsg, tt.quorumNum, tt.config)
+			require.ErrorIs(err, tt.expectedErr)
+
+			lock.Lock()
+			defer lock.Unlock()
+			// Each validator is queried at most once across attempts.
+			for nodeID, numCalls := range calls {
+				require.Equal(1, numCalls, nodeID)
+			}
+			if tt.expectedCalls != 0 || tt.canceled {
+				require.Len(calls, tt.expectedCalls)
+			}
+			if tt.maxCalls != 0 {
+				require.LessOrEqual(len(calls), tt.maxCalls)
+			}
+			if err != nil {
+				return
+			}
+
+			require.NoError(avalancheWarp.VerifyWeight(res.SignatureWeight, totalWeight, tt.quorumNum, 100))
+			require.Equal(totalWeight, res.TotalWeight)
+			// The aggregate signature verifies against the public keys of the signers.
+			signature, ok := res.Message.Signature.(*avalancheWarp.BitSetSignature)
+			require.True(ok)
+			signers := set.BitsFromBytes(signature.Signers)
+			signerKeys := make([]*bls.PublicKey, 0, signers.Len())
+			signersWeight := uint64(0)
+			for i, vdr := range vdrs {
+				if signers.Contains(i) {
+					signerKeys = append(signerKeys, vdr.PublicKey)
+					signersWeight += vdr.Weight
+				}
+			}
+			require.Equal(signersWeight, res.SignatureWeight)
+			aggregateKey, err := bls.AggregatePublicKeys(signerKeys)
+			require.NoError(err)
+			aggregateSignature, err := bls.SignatureFromBytes(signature.Signature[:])
+			require.NoError(err)
+			require.True(bls.Verify(aggregateKey, aggregateSignature, unsignedMsg.Bytes()))
+			if tt.expectedSigners != 0 {
+				numSigners, err := res.Message.Signature.NumSigners()
+				require.NoError(err)
+				require.Equal(tt.expectedSigners, numSigners)
+			}
+		})
+	}
+}
+
+func TestServedSignatures(t *testing.T) {
+	require := require.New(t)
+	errTest := errors.New("test error")
+	unsignedMsg := &avalancheWarp.UnsignedMessage{
+		NetworkID:     1338,
+		SourceChainID: ids.ID{'y', 'e', 'e', 't'},
+		Payload:       []byte("hello world"),
+	}
+	require.NoError(unsignedMsg.Initialize())
+
+	validSk, valid := newValidator(t, 1)
+	_, invalid := newValidator(t, 1)
+	_, failing := newValidator(t, 1)
+	invalidSk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	ctrl := gomock.NewController(t)
+	client := NewMockSignatureGetter(ctrl)
+	client.EXPECT().GetSignature(gomock.Any(), valid.NodeIDs[0], gomock.Any()).Return(bls.Sign(validSk, unsignedMsg.Bytes()), nil).Times(2)
+	client.EXPECT().GetSignature(gomock.Any(), invalid.NodeIDs[0], gomock.Any()).Return(bls.Sign(invalidSk, unsignedMsg.Bytes()), nil).Times(2)
+	client.EXPECT().GetSignature(gomock.Any(), failing.NodeIDs[0], gomock.Any()).Return(nil, errTest).Times(2)
+
+	served := NewServedSignatures()
+	a := New(client, []*avalancheWarp.Validator{valid, invalid, failing}, 3, served, nil)
+	// Require every validator to sign so all of them are queried.
+	for i := 0; i < 2; i++ {
+		_, err := a.AggregateSignatures(context.Background(), unsignedMsg, 100)
+		require.ErrorIs(err, avalancheWarp.ErrInsufficientWeight)
+	}
+
+	// Only valid signatures are counted.
+	require.Equal(map[ids.NodeID]uint64{valid.NodeIDs[0]: 2}, served.Counts())
+}
+
+func TestResponsiveness(t *testing.T) {
+	require := require.New(t)
+	errTest := errors.New("test error")
+	unsignedMsg := &avalancheWarp.UnsignedMessage{
+		NetworkID:     1338,
+		SourceChainID: ids.ID{'y', 'e', 'e', 't'},
+		Payload:       []byte("hello world"),
+	}
+	require.NoError(unsignedMsg.Initialize())
+
+	sk1, vdr1 := newValidator(t, 2)
+	sk2, vdr2 := newValidator(t, 2)
+	_, failing := newValidator(t, 1)
+
+	ctrl := gomock.NewController(t)
+	client := NewMockSignatureGetter(ctrl)
+	client.EXPECT().GetSignature(gomock.Any(), vdr1.NodeIDs[0], gomock.Any()).Return(bls.Sign(sk1, unsignedMsg.Bytes()), nil).Times(3)
+	client.EXPECT().GetSignature(gomock.Any(), vdr2.NodeIDs[0], gomock.Any()).Return(bls.Sign(sk2, unsignedMsg.Bytes()), nil).Times(3)
+	client.EXPECT().GetSignature(gomock.Any(), failing.NodeIDs[0], gomock.Any()).Return(nil, errTest).Times(2)
+
+	window := time.Minute
+	responsiveness := NewResponsiveness(window)
+	now := time.Now()
+	responsiveness.clock.Set(now)
+	a := New(client, []*avalancheWarp.Validator{vdr1, vdr2, failing}, 5, nil, responsiveness)
+
+	// Requiring every validator to sign queries all of them and marks [failing] unresponsive.
+	_, err := a.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.ErrorIs(err, avalancheWarp.ErrInsufficientWeight)
+
+	// The quorum can no longer be reached without [failing], so the aggregation fails fast.
+	_, err = a.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.ErrorIs(err, ErrInsufficientResponsiveWeight)
+
+	// A quorum reachable by the responsive validators is aggregated without querying [failing].
+	res, err := a.AggregateSignatures(context.Background(), unsignedMsg, 60)
+	require.NoError(err)
+	require.Equal(uint64(4), res.SignatureWeight)
+
+	// Once the window elapses, [failing] is queried again.
+	responsiveness.clock.Set(now.Add(2 * window))
+	_, err = a.AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.ErrorIs(err, avalancheWarp.ErrInsufficientWeight)
+}
@@ -0,0 +1,82 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// responsivenessStatus is the responsiveness of a validator to the signature requests of this node.
+type responsivenessStatus int
+
+const (
+	// statusUnknown is used for validators that were not requested a signature within the window.
+	statusUnknown responsivenessStatus = iota
+	// statusResponsive is used for validators whose latest request within the window succeeded.
+	statusResponsive
+	// statusUnresponsive is used for validators whose latest request within the window failed.
+	statusUnresponsive
+)
+
+type responseRecord struct {
+	time time.Time
+	ok   bool
+}
+
+// Responsiveness tracks the outcome of the latest signature request sent to each validator.
+// Outcomes older than the window are forgotten, so validators that were unresponsive are
+// requested signatures again once the window elapses.
+type Responsiveness struct {
+	lock    sync.Mutex
+	clock   mockable.Clock
+	window  time.Duration
+	records map[ids.NodeID]responseRecord
+}
+
+func NewResponsiveness(window time.Duration) *Responsiveness {
+	return &Responsiveness{
+		window:  window,
+		records: make(map[ids.NodeID]responseRecord),
+	}
+}
+
+// Success records that [nodeID] served a valid signature.
+func (r *Responsiveness) Success(nodeID ids.NodeID) {
+	r.record(nodeID, true)
+}
+
+// Failure records that [nodeID] failed to serve a valid signature.
+func (r *Responsiveness) Failure(nodeID ids.NodeID) {
+	r.record(nodeID, false)
+}
+
+func (r *Responsiveness) record(nodeID ids.NodeID, ok bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.records[nodeID] = responseRecord{time: r.clock.Time(), ok: ok}
+}
+
+// status returns the responsiveness of [nodeID] within the window.
+func (r *Responsiveness) status(nodeID ids.NodeID) responsivenessStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	record, ok := r.records[nodeID]
+	if !ok {
+		return statusUnknown
+	}
+	if r.clock.Time().Sub(record.time) > r.window {
+		delete(r.records, nodeID)
+		return statusUnknown
+	}
+	if record.ok {
+		return statusResponsive
+	}
+	return statusUnresponsive
+}
@@ -0,0 +1,41 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// ServedSignatures counts the valid signatures each validator has served to this node
+// since it started. The counts are not part of consensus and can be reported on-chain
+// through the warp incentives precompile to reward the validators serving signatures.
+type ServedSignatures struct {
+	lock   sync.Mutex
+	counts map[ids.NodeID]uint64
+}
+
+func NewServedSignatures() *ServedSignatures {
+	return &ServedSignatures{
+		counts: make(map[ids.NodeID]uint64),
+	}
+}
+
+// Add records a valid signature served by [nodeID].
+func (s *ServedSignatures) Add(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.counts[nodeID]++
+}
+
+// Counts returns a copy of the number of valid signatures served by each validator.
+func (s *ServedSignatures) Counts() map[ids.NodeID]uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return maps.Clone(s.counts)
+}
@@ -23,6 +23,7 @@ import (
 var (
 	_                         Backend = &backend{}
 	errParsingOffChainMessage         = errors.New("failed to parse off-chain message")
+	errUntrackedMessage               = errors.New("message latency is not tracked")
 )
 
 const batchSize = ethdb.IdealBatchSize
@@ -46,6 +47,14 @@ type Backend interface {
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*avalancheWarp.UnsignedMessage, error)
 
+	// MessageDelivered records that a block delivering [messageID] with a verified predicate
+	// was accepted, to measure the latency of the messages added to the backend.
+	MessageDelivered(messageID ids.ID)
+
+	// GetMessageLatency returns the delivery latency of [messageID], if it is one of the
+	// most recent messages added to the backend.
+	GetMessageLatency(messageID ids.ID) (MessageLatency, error)
+
 	// Clear clears the entire db
 	Clear() error
 }
@@ -61,6 +70,8 @@ type backend struct {
 	blockSignatureCache       *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	messageCache              *cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]
 	offchainAddressedCallMsgs map[ids.ID]*avalancheWarp.UnsignedMessage
+	latency                   *latencyTracker
+	metrics                   *backendMetrics
 }
 
 // NewBackend creates a new Backend, and initializes the signature cache and message tracking database.
@@ -83,6 +94,8 @@ func NewBackend(
 		blockSignatureCache:       &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
 		messageCache:              &cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]{Size: cacheSize},
 		offchainAddressedCallMsgs: make(map[ids.ID]*avalancheWarp.UnsignedMessage),
+		latency:                   newLatencyTracker(cacheSize),
+		metrics:                   newBackendMetrics(),
 	}
 	return b, b.initOffChainMessages(offchainMessages)
 }
@@ -108,6 +121,7 @@ func (b *backend) initOffChainMessages(offchainMessages [][]byte) error {
 		}
 		b.offchainAddressedCallMsgs[unsignedMsg.ID()] = unsignedMsg
 	}
+	b.metrics.offChainMessages.Update(int64(len(b.offchainAddressedCallMsgs)))
 
 	return nil
 }
@@ -116,6 +130,7 @@ func (b *backend) Clear() error {
 	b.messageSignatureCache.Flush()
 	b.blockSignatureCache.Flush()
 	b.messageCache.Flush()
+	b.latency.clear()
 	return database.Clear(b.db, batchSize)
 }
 
@@ -137,6 +152,7 @@ func (b *backend) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) err
 
 	copy(signature[:], sig)
 	b.messageSignatureCache.Put(messageID, signature)
+	b.latency.messageSent(messageID)
 	log.Debug("Adding warp message to backend", "messageID", messageID)
 	return nil
 }
@@ -144,8 +160,10 @@ func (b *backend) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) err
 func (b *backend) GetMessageSignature(messageID ids.ID) ([bls.SignatureLen]byte, error) {
 	log.Debug("Getting warp message from backend", "messageID", messageID)
 	if sig, ok := b.messageSignatureCache.Get(messageID); ok {
+		b.metrics.signatureCacheLookup(b.metrics.messageSignatureCacheHit)
 		return sig, nil
 	}
+	b.metrics.signatureCacheLookup(b.metrics.messageSignatureCacheMiss)
 
 	unsignedMessage, err := b.GetMessage(messageID)
 	if err != nil {
@@ -166,8 +184,10 @@ func (b *backend) GetMessageSignature(messageID ids.ID) ([bls.SignatureLen]byte,
 func (b *backend) GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, error) {
 	log.Debug("Getting block from backend", "blockID", blockID)
 	if sig, ok := b.blockSignatureCache.Get(blockID); ok {
+		b.metrics.signatureCacheLookup(b.metrics.blockSignatureCacheHit)
 		return sig, nil
 	}
+	b.metrics.signatureCacheLookup(b.metrics.blockSignatureCacheMiss)
 
 	block, err := b.blockClient.GetBlock(context.TODO(), blockID)
 	if err != nil {
@@ -217,3 +237,15 @@ func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage,
 
 	return unsignedMessage, nil
 }
+
+func (b *backend) MessageDelivered(messageID ids.ID) {
+	b.latency.messageDelivered(messageID)
+}
+
+func (b *backend) GetMessageLatency(messageID ids.ID) (MessageLatency, error) {
+	latency, ok := b.latency.getMessageLatency(messageID)
+	if !ok {
+		return MessageLatency{}, fmt.Errorf("%w: %s", errUntrackedMessage, messageID)
+	}
+	return latency, nil
+}
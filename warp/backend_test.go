@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -123,6 +124,34 @@ func TestAddAndGetUnknownMessage(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSignatureCacheMetrics(t *testing.T) {
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500, nil)
+	require.NoError(t, err)
+	backend := backendIntf.(*backend)
+	// The metrics are global, so clear the counts of the other tests.
+	backend.metrics.messageSignatureCacheHit.Clear()
+	backend.metrics.messageSignatureCacheMiss.Clear()
+	backend.metrics.blockSignatureCacheHit.Clear()
+	backend.metrics.blockSignatureCacheMiss.Clear()
+
+	require.NoError(t, backend.AddMessage(testUnsignedMessage))
+	_, err = backend.GetMessageSignature(testUnsignedMessage.ID())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, backend.metrics.messageSignatureCacheHit.Count())
+	require.Equal(t, 1.0, backend.metrics.signatureCacheHitRatio.Value())
+
+	// Signatures of unknown messages are cache misses
+	_, err = backend.GetMessageSignature(ids.GenerateTestID())
+	require.Error(t, err)
+	require.EqualValues(t, 1, backend.metrics.messageSignatureCacheMiss.Count())
+	require.Equal(t, 0.5, backend.metrics.signatureCacheHitRatio.Value())
+}
+
 func TestGetBlockSignature(t *testing.T) {
 	require := require.New(t)
 
@@ -164,6 +193,47 @@ func TestGetBlockSignature(t *testing.T) {
 	require.Error(err)
 }
 
+func TestMessageLatency(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500, nil)
+	require.NoError(err)
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+	messageID := testUnsignedMessage.ID()
+
+	// Deliveries of messages not sent by this chain are ignored
+	backend.MessageDelivered(messageID)
+	_, err = backend.GetMessageLatency(messageID)
+	require.ErrorIs(err, errUntrackedMessage)
+
+	sentTime := time.Unix(1000, 0)
+	backend.latency.clock.Set(sentTime)
+	require.NoError(backend.AddMessage(testUnsignedMessage))
+	latency, err := backend.GetMessageLatency(messageID)
+	require.NoError(err)
+	require.Equal(MessageLatency{SentTime: sentTime}, latency)
+
+	// Only the first delivery is measured
+	backend.latency.clock.Set(sentTime.Add(3 * time.Second))
+	backend.MessageDelivered(messageID)
+	backend.latency.clock.Set(sentTime.Add(5 * time.Second))
+	backend.MessageDelivered(messageID)
+	require.NoError(backend.AddMessage(testUnsignedMessage))
+	latency, err = backend.GetMessageLatency(messageID)
+	require.NoError(err)
+	require.Equal(sentTime.Add(3*time.Second), *latency.DeliveredTime)
+	require.Equal(3*time.Second, *latency.Latency)
+
+	require.NoError(backend.Clear())
+	_, err = backend.GetMessageLatency(messageID)
+	require.ErrorIs(err, errUntrackedMessage)
+}
+
 func TestZeroSizedCache(t *testing.T) {
 	db := memdb.New()
 
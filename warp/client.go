@@ -20,6 +20,8 @@ type Client interface {
 	GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) ([]byte, error)
 	GetBlockSignature(ctx context.Context, blockID ids.ID) ([]byte, error)
 	GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, quorumNum uint64, subnetIDStr string) ([]byte, error)
+	GetServedSignatures(ctx context.Context) (map[ids.NodeID]uint64, error)
+	GetMessageLatency(ctx context.Context, messageID ids.ID) (MessageLatency, error)
 }
 
 // client implementation for interacting with EVM [chain]
@@ -77,3 +79,19 @@ func (c *client) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID,
 	}
 	return res, nil
 }
+
+func (c *client) GetServedSignatures(ctx context.Context) (map[ids.NodeID]uint64, error) {
+	var res map[ids.NodeID]uint64
+	if err := c.client.CallContext(ctx, &res, "warp_getServedSignatures"); err != nil {
+		return nil, fmt.Errorf("call to warp_getServedSignatures failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetMessageLatency(ctx context.Context, messageID ids.ID) (MessageLatency, error) {
+	var res MessageLatency
+	if err := c.client.CallContext(ctx, &res, "warp_getMessageLatency", messageID); err != nil {
+		return MessageLatency{}, fmt.Errorf("call to warp_getMessageLatency failed. err: %w", err)
+	}
+	return res, nil
+}
@@ -9,23 +9,28 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/warp"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // SignatureRequestHandler serves warp signature requests. It is a peer.RequestHandler for message.MessageSignatureRequest.
 type SignatureRequestHandler struct {
 	backend warp.Backend
 	codec   codec.Manager
+	tracer  trace.Tracer
 	stats   *handlerStats
 }
 
-func NewSignatureRequestHandler(backend warp.Backend, codec codec.Manager) *SignatureRequestHandler {
+func NewSignatureRequestHandler(backend warp.Backend, codec codec.Manager, tracer trace.Tracer) *SignatureRequestHandler {
 	return &SignatureRequestHandler{
 		backend: backend,
 		codec:   codec,
+		tracer:  tracer,
 		stats:   newStats(),
 	}
 }
@@ -36,6 +41,12 @@ func NewSignatureRequestHandler(backend warp.Backend, codec codec.Manager) *Sign
 // Returns empty response if signature is not found
 // Assumes ctx is active
 func (s *SignatureRequestHandler) OnMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest message.MessageSignatureRequest) ([]byte, error) {
+	_, span := s.tracer.Start(ctx, "warp.OnMessageSignatureRequest", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Stringer("messageID", signatureRequest.MessageID),
+	))
+	defer span.End()
+
 	startTime := time.Now()
 	s.stats.IncMessageSignatureRequest()
 
@@ -48,9 +59,12 @@ func (s *SignatureRequestHandler) OnMessageSignatureRequest(ctx context.Context,
 	if err != nil {
 		log.Debug("Unknown warp signature requested", "messageID", signatureRequest.MessageID)
 		s.stats.IncMessageSignatureMiss()
+		span.SetAttributes(attribute.Bool("found", false))
 		signature = [bls.SignatureLen]byte{}
 	} else {
 		s.stats.IncMessageSignatureHit()
+		s.stats.IncSignatureServed(nodeID)
+		span.SetAttributes(attribute.Bool("found", true))
 	}
 
 	response := message.SignatureResponse{Signature: signature}
@@ -64,6 +78,12 @@ func (s *SignatureRequestHandler) OnMessageSignatureRequest(ctx context.Context,
 }
 
 func (s *SignatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, request message.BlockSignatureRequest) ([]byte, error) {
+	_, span := s.tracer.Start(ctx, "warp.OnBlockSignatureRequest", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Stringer("blockID", request.BlockID),
+	))
+	defer span.End()
+
 	startTime := time.Now()
 	s.stats.IncBlockSignatureRequest()
 
@@ -76,9 +96,12 @@ func (s *SignatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, n
 	if err != nil {
 		log.Debug("Unknown warp signature requested", "blockID", request.BlockID)
 		s.stats.IncBlockSignatureMiss()
+		span.SetAttributes(attribute.Bool("found", false))
 		signature = [bls.SignatureLen]byte{}
 	} else {
 		s.stats.IncBlockSignatureHit()
+		s.stats.IncSignatureServed(nodeID)
+		span.SetAttributes(attribute.Bool("found", true))
 	}
 
 	response := message.SignatureResponse{Signature: signature}
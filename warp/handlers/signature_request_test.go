@@ -14,12 +14,14 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/warp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,14 +106,16 @@ func TestMessageSignatureHandler(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			handler := NewSignatureRequestHandler(backend, message.Codec)
+			handler := NewSignatureRequestHandler(backend, message.Codec, trace.Noop)
 			handler.stats.Clear()
 
 			request, expectedResponse := test.setup()
-			responseBytes, err := handler.OnMessageSignatureRequest(context.Background(), ids.GenerateTestNodeID(), 1, request)
+			nodeID := ids.GenerateTestNodeID()
+			responseBytes, err := handler.OnMessageSignatureRequest(context.Background(), nodeID, 1, request)
 			require.NoError(t, err)
 
 			test.verifyStats(t, handler.stats)
+			require.EqualValues(t, handler.stats.messageSignatureHit.Count(), testutil.ToFloat64(SignaturesServed.WithLabelValues(nodeID.String())))
 
 			// If the expected response is empty, assert that the handler returns an empty response and return early.
 			if len(expectedResponse) == 0 {
@@ -204,14 +208,16 @@ func TestBlockSignatureHandler(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			handler := NewSignatureRequestHandler(backend, message.Codec)
+			handler := NewSignatureRequestHandler(backend, message.Codec, trace.Noop)
 			handler.stats.Clear()
 
 			request, expectedResponse := test.setup()
-			responseBytes, err := handler.OnBlockSignatureRequest(context.Background(), ids.GenerateTestNodeID(), 1, request)
+			nodeID := ids.GenerateTestNodeID()
+			responseBytes, err := handler.OnBlockSignatureRequest(context.Background(), nodeID, 1, request)
 			require.NoError(t, err)
 
 			test.verifyStats(t, handler.stats)
+			require.EqualValues(t, handler.stats.blockSignatureHit.Count(), testutil.ToFloat64(SignaturesServed.WithLabelValues(nodeID.String())))
 
 			// If the expected response is empty, assert that the handler returns an empty response and return early.
 			if len(expectedResponse) == 0 {
@@ -6,9 +6,18 @@ package handlers
 import (
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/subnet-evm/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// SignaturesServed counts the signatures served to each peer. Like the other
+// metrics of the handlers it is global, and it is registered by the VM.
+var SignaturesServed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "signatures_served",
+	Help: "Number of warp signatures served to each peer",
+}, []string{"nodeID"})
+
 type handlerStats struct {
 	// MessageSignatureRequestHandler metrics
 	messageSignatureRequest         metrics.Counter
@@ -41,6 +50,9 @@ func (h *handlerStats) IncMessageSignatureMiss()    { h.messageSignatureMiss.Inc
 func (h *handlerStats) UpdateMessageSignatureRequestTime(duration time.Duration) {
 	h.messageSignatureRequestDuration.Inc(int64(duration))
 }
+func (h *handlerStats) IncSignatureServed(nodeID ids.NodeID) {
+	SignaturesServed.WithLabelValues(nodeID.String()).Inc()
+}
 func (h *handlerStats) IncBlockSignatureRequest() { h.blockSignatureRequest.Inc(1) }
 func (h *handlerStats) IncBlockSignatureHit()     { h.blockSignatureHit.Inc(1) }
 func (h *handlerStats) IncBlockSignatureMiss()    { h.blockSignatureMiss.Inc(1) }
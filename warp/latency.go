@@ -0,0 +1,95 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/metrics"
+)
+
+// MessageLatency is the end-to-end latency of a warp message sent by this chain, from the
+// acceptance of the block emitting it to the acceptance of the first block delivering it
+// with a verified predicate on this chain.
+type MessageLatency struct {
+	SentTime time.Time `json:"sentTime"`
+	// DeliveredTime and Latency are nil until the message is delivered.
+	DeliveredTime *time.Time     `json:"deliveredTime,omitempty"`
+	Latency       *time.Duration `json:"latency,omitempty"`
+}
+
+// latencyTracker tracks the delivery of the most recent warp messages sent by this chain.
+// Since the times are observed by this node, the latency includes the time taken by the
+// node to accept the blocks, but only deliveries to this chain can be observed.
+type latencyTracker struct {
+	lock     sync.Mutex
+	clock    mockable.Clock
+	messages *cache.LRU[ids.ID, *MessageLatency]
+
+	deliveryLatency metrics.Timer
+	sent            metrics.Counter
+	delivered       metrics.Counter
+}
+
+func newLatencyTracker(size int) *latencyTracker {
+	return &latencyTracker{
+		messages:        &cache.LRU[ids.ID, *MessageLatency]{Size: size},
+		deliveryLatency: metrics.GetOrRegisterTimer("warp_message_delivery_latency", nil),
+		sent:            metrics.GetOrRegisterCounter("warp_message_sent_count", nil),
+		delivered:       metrics.GetOrRegisterCounter("warp_message_delivered_count", nil),
+	}
+}
+
+// messageSent starts tracking the delivery of [messageID], unless it is already tracked.
+func (t *latencyTracker) messageSent(messageID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.messages.Get(messageID); ok {
+		return
+	}
+	t.messages.Put(messageID, &MessageLatency{SentTime: t.clock.Time()})
+	t.sent.Inc(1)
+}
+
+// messageDelivered records the delivery of [messageID] if it is tracked and was not
+// delivered yet, so only the first delivery of each message is measured.
+func (t *latencyTracker) messageDelivered(messageID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	message, ok := t.messages.Get(messageID)
+	if !ok || message.DeliveredTime != nil {
+		return
+	}
+	deliveredTime := t.clock.Time()
+	latency := deliveredTime.Sub(message.SentTime)
+	message.DeliveredTime = &deliveredTime
+	message.Latency = &latency
+	t.deliveryLatency.Update(latency)
+	t.delivered.Inc(1)
+}
+
+// getMessageLatency returns a copy of the latency tracked for [messageID].
+func (t *latencyTracker) getMessageLatency(messageID ids.ID) (MessageLatency, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	message, ok := t.messages.Get(messageID)
+	if !ok {
+		return MessageLatency{}, false
+	}
+	return *message, true
+}
+
+func (t *latencyTracker) clear() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.messages.Flush()
+}
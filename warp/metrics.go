@@ -0,0 +1,54 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/subnet-evm/metrics"
+)
+
+// backendMetrics reports the health of the signatures served by the backend.
+type backendMetrics struct {
+	messageSignatureCacheHit  metrics.Counter
+	messageSignatureCacheMiss metrics.Counter
+	blockSignatureCacheHit    metrics.Counter
+	blockSignatureCacheMiss   metrics.Counter
+	// signatureCacheHitRatio is the ratio of the message and block signatures
+	// served from the caches.
+	signatureCacheHitRatio metrics.GaugeFloat64
+	// offChainMessages is the number of off-chain messages the backend signs.
+	offChainMessages metrics.Gauge
+}
+
+func newBackendMetrics() *backendMetrics {
+	return &backendMetrics{
+		messageSignatureCacheHit:  metrics.GetOrRegisterCounter("warp_message_signature_cache_hit", nil),
+		messageSignatureCacheMiss: metrics.GetOrRegisterCounter("warp_message_signature_cache_miss", nil),
+		blockSignatureCacheHit:    metrics.GetOrRegisterCounter("warp_block_signature_cache_hit", nil),
+		blockSignatureCacheMiss:   metrics.GetOrRegisterCounter("warp_block_signature_cache_miss", nil),
+		signatureCacheHitRatio:    metrics.GetOrRegisterGaugeFloat64("warp_signature_cache_hit_ratio", nil),
+		offChainMessages:          metrics.GetOrRegisterGauge("warp_offchain_messages", nil),
+	}
+}
+
+func (m *backendMetrics) signatureCacheLookup(hit metrics.Counter) {
+	hit.Inc(1)
+	hits := m.messageSignatureCacheHit.Count() + m.blockSignatureCacheHit.Count()
+	lookups := hits + m.messageSignatureCacheMiss.Count() + m.blockSignatureCacheMiss.Count()
+	if lookups > 0 {
+		m.signatureCacheHitRatio.Update(float64(hits) / float64(lookups))
+	}
+}
+
+// aggregationMetrics reports the signature aggregations of the API.
+type aggregationMetrics struct {
+	duration metrics.Timer
+	failures metrics.Counter
+}
+
+func newAggregationMetrics() *aggregationMetrics {
+	return &aggregationMetrics{
+		duration: metrics.GetOrRegisterTimer("warp_signature_aggregation_duration", nil),
+		failures: metrics.GetOrRegisterCounter("warp_signature_aggregation_failure", nil),
+	}
+}
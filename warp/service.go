@@ -7,8 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/peer"
@@ -16,8 +19,19 @@ import (
 	"github.com/ava-labs/subnet-evm/warp/validators"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
+// primaryNetworkSampleAttempts is the maximum number of samples of the Primary Network
+// validators queried when aggregating signatures of a message from the Primary Network.
+const primaryNetworkSampleAttempts = 4
+
+// validatorResponsivenessWindow is how long a validator that failed to serve a signature
+// is skipped when aggregating signatures.
+const validatorResponsivenessWindow = 5 * time.Minute
+
 var errNoValidators = errors.New("cannot aggregate signatures from subnet with no validators")
 
 // API introduces snowman specific functionality to the evm
@@ -27,16 +41,31 @@ type API struct {
 	backend                       Backend
 	state                         *validators.State
 	client                        peer.NetworkClient
+	// primaryNetworkSampleSize is the number of Primary Network validators sampled by stake
+	// in the first attempt to aggregate signatures from the Primary Network.
+	// If 0, signatures are requested from every validator.
+	primaryNetworkSampleSize int
+	// served counts the valid signatures each validator served while aggregating.
+	served *aggregator.ServedSignatures
+	// responsiveness tracks which validators recently failed to serve signatures.
+	responsiveness *aggregator.Responsiveness
+	tracer         trace.Tracer
+	metrics        *aggregationMetrics
 }
 
-func NewAPI(networkID uint32, sourceSubnetID ids.ID, sourceChainID ids.ID, state *validators.State, backend Backend, client peer.NetworkClient) *API {
+func NewAPI(networkID uint32, sourceSubnetID ids.ID, sourceChainID ids.ID, state *validators.State, backend Backend, client peer.NetworkClient, primaryNetworkSampleSize int, tracer trace.Tracer) *API {
 	return &API{
-		networkID:      networkID,
-		sourceSubnetID: sourceSubnetID,
-		sourceChainID:  sourceChainID,
-		backend:        backend,
-		state:          state,
-		client:         client,
+		networkID:                networkID,
+		sourceSubnetID:           sourceSubnetID,
+		sourceChainID:            sourceChainID,
+		backend:                  backend,
+		state:                    state,
+		client:                   client,
+		primaryNetworkSampleSize: primaryNetworkSampleSize,
+		served:                   aggregator.NewServedSignatures(),
+		responsiveness:           aggregator.NewResponsiveness(validatorResponsivenessWindow),
+		tracer:                   tracer,
+		metrics:                  newAggregationMetrics(),
 	}
 }
 
@@ -58,6 +87,13 @@ func (a *API) GetMessageSignature(ctx context.Context, messageID ids.ID) (hexuti
 	return signature[:], nil
 }
 
+// GetMessageLatency returns the time between the acceptance of the block emitting the
+// message with [messageID] and the acceptance of the first block delivering it to this chain.
+// Only the most recent messages sent by this chain since the node started are tracked.
+func (a *API) GetMessageLatency(ctx context.Context, messageID ids.ID) (MessageLatency, error) {
+	return a.backend.GetMessageLatency(messageID)
+}
+
 // GetBlockSignature returns the BLS signature associated with a blockID.
 func (a *API) GetBlockSignature(ctx context.Context, blockID ids.ID) (hexutil.Bytes, error) {
 	signature, err := a.backend.GetBlockSignature(blockID)
@@ -67,6 +103,13 @@ func (a *API) GetBlockSignature(ctx context.Context, blockID ids.ID) (hexutil.By
 	return signature[:], nil
 }
 
+// GetServedSignatures returns the number of valid signatures each validator has served
+// to this node while aggregating signatures since it started.
+// Served signatures can be reported to the warp incentives precompile to reward validators.
+func (a *API) GetServedSignatures(ctx context.Context) (map[ids.NodeID]uint64, error) {
+	return a.served.Counts(), nil
+}
+
 // GetMessageAggregateSignature fetches the aggregate signature for the requested [messageID]
 func (a *API) GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) (signedMessageBytes hexutil.Bytes, err error) {
 	unsignedMessage, err := a.backend.GetMessage(messageID)
@@ -90,7 +133,22 @@ func (a *API) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, qu
 	return a.aggregateSignatures(ctx, unsignedMessage, quorumNum, subnetIDStr)
 }
 
-func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64, subnetIDStr string) (hexutil.Bytes, error) {
+func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64, subnetIDStr string) (_ hexutil.Bytes, err error) {
+	ctx, span := a.tracer.Start(ctx, "warp.aggregateSignatures", oteltrace.WithAttributes(
+		attribute.Stringer("messageID", unsignedMessage.ID()),
+		attribute.Int64("quorumNum", int64(quorumNum)),
+	))
+	startTime := time.Now()
+	defer func() {
+		a.metrics.duration.UpdateSince(startTime)
+		if err != nil {
+			a.metrics.failures.Inc(1)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	subnetID := a.sourceSubnetID
 	if len(subnetIDStr) > 0 {
 		sid, err := ids.FromString(subnetIDStr)
@@ -119,11 +177,31 @@ func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.Uns
 		"totalWeight", totalWeight,
 	)
 
-	agg := aggregator.New(aggregator.NewSignatureGetter(a.client), validators, totalWeight)
-	signatureResult, err := agg.AggregateSignatures(ctx, unsignedMessage, quorumNum)
+	span.SetAttributes(
+		attribute.Stringer("subnetID", subnetID),
+		attribute.Int64("pChainHeight", int64(pChainHeight)),
+		attribute.Int("numValidators", len(validators)),
+	)
+
+	agg := aggregator.New(aggregator.NewSignatureGetter(a.client), validators, totalWeight, a.served, a.responsiveness)
+	var signatureResult *aggregator.AggregateSignatureResult
+	if subnetID == constants.PrimaryNetworkID && a.primaryNetworkSampleSize > 0 {
+		// The Primary Network has too many validators to query all of them, so only
+		// request signatures from a sample weighted by stake.
+		signatureResult, err = agg.AggregateSampledSignatures(ctx, unsignedMessage, quorumNum, aggregator.SamplingConfig{
+			SampleSize:  a.primaryNetworkSampleSize,
+			MaxAttempts: primaryNetworkSampleAttempts,
+		})
+	} else {
+		signatureResult, err = agg.AggregateSignatures(ctx, unsignedMessage, quorumNum)
+	}
 	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(
+		attribute.Int64("signatureWeight", int64(signatureResult.SignatureWeight)),
+		attribute.Int64("totalWeight", int64(signatureResult.TotalWeight)),
+	)
 	// TODO: return the signature and total weight as well to the caller for more complete details
 	// Need to decide on the best UI for this and write up documentation with the potential
 	// gotchas that could impact signed messages becoming invalid.